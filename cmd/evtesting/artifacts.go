@@ -0,0 +1,121 @@
+package evtesting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// defaultArtifactsDir is the directory artifacts are written into when neither ArtifactsDir nor report file is set
+var defaultArtifactsDir = filepath.Join(os.TempDir(), "evtesting-artifacts")
+
+// Artifact is a struct to describe a file attached by a test e.g. raw cli output or fixture state
+type Artifact struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Size int    `json:"size"`
+}
+
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// artifactPathPart is a function to make test or artifact name safe as a file name
+func artifactPathPart(name string) string {
+	part := strings.Trim(unsafePathChars.ReplaceAllString(name, "_"), "_.")
+	if len(part) == 0 {
+		return "_"
+	}
+	return part
+}
+
+// artifactDir is a function to get directory of artifacts of a test, subtests get directories nested in their parents
+func artifactDir(baseDir, testName string) string {
+	parts := []string{baseDir}
+	for _, part := range strings.Split(testName, "/") {
+		parts = append(parts, artifactPathPart(part))
+	}
+	return filepath.Join(parts...)
+}
+
+// AttachFile is a function to write artifact of the test into its artifacts directory and reference it in the report
+// Content is redacted like logs, and failing to write it is logged as a warning instead of failing the test.
+func (t *T) AttachFile(name string, data []byte) {
+	if t.useLogPkg {
+		return
+	}
+	baseDir := ReportOpts.ArtifactsDir
+	if len(baseDir) == 0 {
+		baseDir = defaultArtifactsDir
+	}
+	dir := artifactDir(baseDir, t.origin.Name())
+	filePath := filepath.Join(dir, artifactPathPart(name))
+	content := []byte(Redact(string(data)))
+	err := os.MkdirAll(dir, 0755)
+	if err == nil {
+		err = ioutil.WriteFile(filePath, content, 0644)
+	}
+	if err != nil {
+		t.WithFields(Fields{"artifact": name, "error": err}).Warn("error writing artifact")
+		return
+	}
+	GlobalReporter.attach(t.origin.Name(), Artifact{Name: name, Path: filePath, Size: len(content)})
+	t.WithFields(Fields{"artifact": name, "path": filePath}).Debug("attached artifact")
+}
+
+// AttachJSON is a function to attach indented json of obj as artifact of the test
+func (t *T) AttachJSON(name string, obj interface{}) {
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		t.WithFields(Fields{"artifact": name, "error": err}).Warn("error encoding artifact")
+		return
+	}
+	if filepath.Ext(name) == "" {
+		name += ".json"
+	}
+	t.AttachFile(name, data)
+}
+
+// attach is a function to add artifact of a test, artifact written again with same path replaces the old one
+func (r *Reporter) attach(name string, artifact Artifact) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	result, ok := r.results[name]
+	if !ok {
+		return
+	}
+	for idx := range result.Artifacts {
+		if result.Artifacts[idx].Path == artifact.Path {
+			result.Artifacts[idx] = artifact
+			return
+		}
+	}
+	result.Artifacts = append(result.Artifacts, artifact)
+}
+
+// artifactLink is a function to get link of artifact relative to directory of report file
+func artifactLink(reportDir string, artifact Artifact) string {
+	if len(reportDir) == 0 {
+		return filepath.ToSlash(artifact.Path)
+	}
+	absReportDir, err := filepath.Abs(reportDir)
+	if err != nil {
+		return filepath.ToSlash(artifact.Path)
+	}
+	absPath, err := filepath.Abs(artifact.Path)
+	if err != nil {
+		return filepath.ToSlash(artifact.Path)
+	}
+	rel, err := filepath.Rel(absReportDir, absPath)
+	if err != nil {
+		return filepath.ToSlash(absPath)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// reportArtifactsDir is a function to get artifacts directory next to report file e.g. report_artifacts for report.html
+func reportArtifactsDir(reportPath string) string {
+	return fmt.Sprintf("%s_artifacts", strings.TrimSuffix(reportPath, filepath.Ext(reportPath)))
+}
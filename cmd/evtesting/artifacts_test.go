@@ -0,0 +1,42 @@
+package evtesting
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAttachFile(originT *testing.T) {
+	t := NewT(originT)
+
+	dir, err := ioutil.TempDir("", "artifacts")
+	t.MustNil(err, "error creating artifacts directory")
+	defer os.RemoveAll(dir)
+	artifactsDir := ReportOpts.ArtifactsDir
+	ReportOpts.ArtifactsDir = dir
+	defer func() { ReportOpts.ArtifactsDir = artifactsDir }()
+
+	t.Run("step 1/cli", func(t *T) {
+		t.AttachFile("output.txt", []byte("raw output"))
+		t.AttachJSON("state", map[string]int{"pylon": 100})
+	})
+
+	stepDir := filepath.Join(dir, "TestAttachFile", "step_1", "cli")
+	output, err := ioutil.ReadFile(filepath.Join(stepDir, "output.txt"))
+	t.MustNil(err, "error reading attached file")
+	t.MustTrue(string(output) == "raw output", "attached file should keep its content")
+	state, err := ioutil.ReadFile(filepath.Join(stepDir, "state.json"))
+	t.MustNil(err, "error reading attached json")
+	t.MustContain(string(state), `"pylon": 100`)
+
+	var artifacts []Artifact
+	for _, result := range GlobalReporter.Summary().Results {
+		if result.Name == "TestAttachFile/step_1/cli" {
+			artifacts = result.Artifacts
+		}
+	}
+	t.MustTrue(len(artifacts) == 2 && artifacts[1].Name == "state.json" && artifacts[0].Size == len("raw output"), "attached files should be referenced in report")
+	t.MustTrue(artifactLink(dir, artifacts[0]) == "TestAttachFile/step_1/cli/output.txt", "artifact should be linked relative to report directory")
+	t.MustTrue(reportArtifactsDir(filepath.Join("out", "report.html")) == filepath.Join("out", "report_artifacts"), "artifacts directory should be next to report file")
+}
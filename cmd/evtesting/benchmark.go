@@ -0,0 +1,100 @@
+package evtesting
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// B is a modified testing.B, it has fields, logging and event dispatch of T
+// Benchmark function is run several times with growing N, so B is created by each run.
+type B struct {
+	T
+	origin *testing.B
+}
+
+// NewB is function returns modified B from original testing.B
+func NewB(origin *testing.B) B {
+	GlobalReporter.track(origin)
+	watchResult(origin)
+	return B{
+		T: T{
+			origin:     origin,
+			useLogPkg:  false,
+			fields:     log.Fields{},
+			logLevel:   DefaultLogLevel(),
+			sortType:   SortValueLength,
+			sortFields: []string{},
+		},
+		origin: origin,
+	}
+}
+
+// NewLogLevelB is a NewB variant that has custom logLevel
+func NewLogLevelB(origin *testing.B, logLevel log.Level) B {
+	newB := NewB(origin)
+	newB.logLevel = logLevel
+	return newB
+}
+
+// WithFields is modified WithFields which keeps B, fields are merged into fields of b which is kept unchanged
+func (b *B) WithFields(fields Fields) *B {
+	return &B{T: *b.T.WithFields(fields), origin: b.origin}
+}
+
+// WithField is to add a single field as WithFields does
+func (b *B) WithField(key string, value interface{}) *B {
+	return b.WithFields(Fields{key: value})
+}
+
+// N is a function to get the number of iterations benchmark should run
+func (b *B) N() int {
+	return b.origin.N
+}
+
+// Run is modified Run for sub-benchmarks, fields of b are kept in sub-benchmarks
+func (b *B) Run(name string, f func(b *B)) bool {
+	return b.origin.Run(name, func(subb *testing.B) {
+		newB := NewB(subb)
+		newB.fields = b.fields
+		newB.logLevel = b.logLevel
+		newB.sortType = b.sortType
+		newB.sortFields = b.sortFields
+		f(&newB)
+	})
+}
+
+// RunParallel is modified RunParallel
+func (b *B) RunParallel(body func(pb *testing.PB)) {
+	b.origin.RunParallel(body)
+}
+
+// ResetTimer is modified ResetTimer
+func (b *B) ResetTimer() {
+	b.origin.ResetTimer()
+}
+
+// StartTimer is modified StartTimer
+func (b *B) StartTimer() {
+	b.origin.StartTimer()
+}
+
+// StopTimer is modified StopTimer
+func (b *B) StopTimer() {
+	b.origin.StopTimer()
+}
+
+// ReportAllocs is modified ReportAllocs
+func (b *B) ReportAllocs() {
+	b.origin.ReportAllocs()
+}
+
+// ReportMetric is modified ReportMetric
+func (b *B) ReportMetric(n float64, unit string) {
+	b.origin.ReportMetric(n, unit)
+}
+
+// SetBytes is modified SetBytes
+func (b *B) SetBytes(n int64) {
+	b.origin.SetBytes(n)
+}
@@ -0,0 +1,41 @@
+package evtesting
+
+import (
+	"testing"
+)
+
+func BenchmarkWithFields(originB *testing.B) {
+	b := NewB(originB)
+	b.ReportAllocs()
+	nB := b.WithFields(Fields{"bench": "with_fields"})
+	b.ResetTimer()
+	for i := 0; i < b.N(); i++ {
+		nB.WithField("iteration", i)
+	}
+}
+
+func TestBenchmark(originT *testing.T) {
+	t := NewT(originT)
+	runs := 0
+	passed := 0
+	// benchmarks run by testing.Benchmark have no name
+	AddEventListener(TestPassed, "TestBenchmark", func(event Event) {
+		passed++
+	})
+	defer RemoveEventListener(TestPassed, "TestBenchmark")
+
+	result := testing.Benchmark(func(originB *testing.B) {
+		b := NewB(originB)
+		nB := b.WithField("role", "bench")
+		nB.Run("bench", func(sub *B) {
+			runs++
+			t.MustTrue(sub.fields["role"] == "bench", "sub-benchmark should have fields of parent")
+			for i := 0; i < sub.N(); i++ {
+				sub.MustTrue(true, "assertion should pass in benchmark")
+			}
+		})
+	})
+	t.MustTrue(result.N > 0, "benchmark should run")
+	t.MustTrue(runs > 0, "sub-benchmark should run")
+	t.MustTrue(passed > 0, "sub-benchmark should dispatch TestPassed")
+}
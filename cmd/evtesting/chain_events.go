@@ -0,0 +1,184 @@
+package evtesting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// EventAttribute is a struct to describe a key value attribute of chain event
+type EventAttribute struct {
+	Key   string
+	Value string
+}
+
+// ChainEvent is a struct to describe an event emitted by chain e.g. recipe_executed or item_created
+type ChainEvent struct {
+	Type       string
+	Attributes []EventAttribute
+}
+
+// chainEventState is a struct to manage expected and observed chain events of a test
+type chainEventState struct {
+	mux        sync.Mutex
+	expected   []ChainEvent
+	observed   []ChainEvent
+	observedTx map[string]bool // transactions whose events are observed, by txhash
+}
+
+var chainEventStates sync.Map // *chainEventState per testing.T
+
+func (e ChainEvent) String() string {
+	attrs := []string{}
+	for _, attr := range e.Attributes {
+		attrs = append(attrs, attr.Key+"="+attr.Value)
+	}
+	sort.Strings(attrs)
+	return fmt.Sprintf("%s{%s}", e.Type, strings.Join(attrs, ","))
+}
+
+// Matches is a function to check if observed event has the type and all attributes of expected event
+func (e ChainEvent) Matches(observed ChainEvent) bool {
+	if e.Type != observed.Type {
+		return false
+	}
+	for _, attr := range e.Attributes {
+		found := false
+		for _, observedAttr := range observed.Attributes {
+			if observedAttr == attr {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// chainEvents is a function to get chain event state of the test, events are checked when the test finishes
+func (t *T) chainEvents() *chainEventState {
+	state, loaded := chainEventStates.LoadOrStore(t.origin, &chainEventState{})
+	if !loaded {
+		origin := t.origin
+		t.origin.Cleanup(func() {
+			chainEventStates.Delete(origin)
+			t.checkChainEvents(state.(*chainEventState))
+		})
+	}
+	return state.(*chainEventState)
+}
+
+// ExpectEvent is a function to declare a chain event the test should observe before it finishes
+// Observed event matches when it has the type and all of attrs, other attributes of observed event are ignored.
+// Each observed event matches one expectation, so expecting the same event twice requires two observed events.
+func (t *T) ExpectEvent(eventType string, attrs map[string]string) {
+	if t.useLogPkg {
+		t.Warn("chain event expectation is not checked without testing.T", eventType)
+		return
+	}
+	event := ChainEvent{Type: eventType}
+	for key, value := range attrs {
+		event.Attributes = append(event.Attributes, EventAttribute{Key: key, Value: value})
+	}
+	state := t.chainEvents()
+	state.mux.Lock()
+	defer state.mux.Unlock()
+	state.expected = append(state.expected, event)
+}
+
+// ObserveEvent is a function to record chain event emitted while the test runs
+// Event sources such as tx results or event subscribers call it for each emitted event.
+func (t *T) ObserveEvent(event ChainEvent) {
+	if t == nil || t.useLogPkg {
+		return
+	}
+	state := t.chainEvents()
+	state.mux.Lock()
+	defer state.mux.Unlock()
+	state.observed = append(state.observed, event)
+}
+
+// ObserveTxEvents is a function to record chain events emitted by a transaction once per txhash
+// Events of the same transaction can come from its tx result and from an event subscriber, the first source is kept.
+func (t *T) ObserveTxEvents(txhash string, events []ChainEvent) {
+	if t == nil || t.useLogPkg {
+		return
+	}
+	state := t.chainEvents()
+	state.mux.Lock()
+	defer state.mux.Unlock()
+	if state.observedTx == nil {
+		state.observedTx = map[string]bool{}
+	}
+	txhash = strings.ToUpper(txhash)
+	if state.observedTx[txhash] {
+		return
+	}
+	state.observedTx[txhash] = true
+	state.observed = append(state.observed, events...)
+}
+
+// ObservedEvents is a function to get chain events observed by the test in order
+func (t *T) ObservedEvents() []ChainEvent {
+	if t.useLogPkg {
+		return nil
+	}
+	state := t.chainEvents()
+	state.mux.Lock()
+	defer state.mux.Unlock()
+	return append([]ChainEvent{}, state.observed...)
+}
+
+// diffChainEvents is a function to match expected events with observed events
+// The diff has "-" lines for missing expected events, "+" lines for unmatched observed events and "=" lines for matches.
+func diffChainEvents(expected, observed []ChainEvent) (missing []ChainEvent, diff string) {
+	matched := make([]bool, len(observed))
+	lines := []string{}
+	for _, exp := range expected {
+		found := false
+		for idx, obs := range observed {
+			if !matched[idx] && exp.Matches(obs) {
+				matched[idx] = true
+				found = true
+				lines = append(lines, "= "+obs.String())
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, exp)
+			lines = append(lines, "- "+exp.String())
+		}
+	}
+	for idx, obs := range observed {
+		if !matched[idx] {
+			lines = append(lines, "+ "+obs.String())
+		}
+	}
+	return missing, strings.Join(lines, "\n")
+}
+
+// checkChainEvents is a function to fail the test when expected chain events are not observed
+func (t *T) checkChainEvents(state *chainEventState) {
+	state.mux.Lock()
+	missing, diff := diffChainEvents(state.expected, state.observed)
+	expectedCount, observedCount := len(state.expected), len(state.observed)
+	state.mux.Unlock()
+	if len(missing) == 0 {
+		return
+	}
+	requiredLevel := log.ErrorLevel
+	cause := fmt.Sprintf("%d of %d expected chain events are not observed", len(missing), expectedCount)
+	nT := t.WithFields(Fields{
+		"expected_count": expectedCount,
+		"observed_count": observedCount,
+		"error_from":     "ExpectEvent validation failure",
+	})
+	GlobalReporter.recordFailure(t.origin.Name(), cause, Fields(nT.fields))
+	text := fmt.Sprintf("%s msg=%s\n%s", nT.FormatFields(requiredLevel), cause, diff)
+	t.origin.Error(colorize(requiredLevel, text))
+}
@@ -0,0 +1,41 @@
+package evtesting
+
+import (
+	"testing"
+)
+
+func TestExpectEvent(originT *testing.T) {
+	t := NewT(originT)
+
+	executed := ChainEvent{Type: "recipe_executed", Attributes: []EventAttribute{{"recipe_id", "recipe1"}, {"sender", "alice"}}}
+	created := ChainEvent{Type: "item_created", Attributes: []EventAttribute{{"item_id", "item1"}}}
+	t.Run("observed", func(t *T) {
+		t.ExpectEvent("recipe_executed", map[string]string{"recipe_id": "recipe1"})
+		t.ObserveEvent(created)
+		t.ObserveEvent(executed)
+		t.MustTrue(len(t.ObservedEvents()) == 2, "observed events should be recorded")
+	})
+	t.Run("observed once per tx", func(t *T) {
+		t.ExpectEvent("recipe_executed", map[string]string{"recipe_id": "recipe1"})
+		t.ExpectEvent("recipe_executed", map[string]string{"recipe_id": "recipe1"})
+		t.ObserveTxEvents("abcd", []ChainEvent{executed})
+		// the same transaction seen by another source doesn't satisfy the second expectation
+		t.ObserveTxEvents("ABCD", []ChainEvent{executed, created})
+		t.ObserveTxEvents("EF01", []ChainEvent{executed})
+		t.MustTrue(len(t.ObservedEvents()) == 2, "events of a transaction should be observed once")
+	})
+
+	expected := []ChainEvent{
+		{Type: "recipe_executed", Attributes: []EventAttribute{{"recipe_id", "recipe1"}}},
+		{Type: "item_created", Attributes: []EventAttribute{{"item_id", "item2"}}},
+		{Type: "recipe_executed"},
+	}
+	missing, diff := diffChainEvents(expected, []ChainEvent{executed, created})
+	t.WithFields(Fields{
+		"diff": diff,
+	}).MustTrue(len(missing) == 2 && missing[0].Type == "item_created" && missing[1].Type == "recipe_executed",
+		"expectations without unmatched observed events should be missing")
+	t.MustContain(diff, "= recipe_executed{recipe_id=recipe1,sender=alice}", "diff should show matched event")
+	t.MustContain(diff, "- item_created{item_id=item2}", "diff should show missing event")
+	t.MustContain(diff, "+ item_created{item_id=item1}", "diff should show unmatched observed event")
+}
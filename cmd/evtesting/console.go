@@ -0,0 +1,201 @@
+package evtesting
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Verbosity is the amount of logs printed by tests, e.g. quiet prints only warnings and failures
+type Verbosity string
+
+// describes the verbosity levels of -verbosity flag
+const (
+	VerbosityQuiet  Verbosity = "quiet"  // only warnings, errors and one-line summaries
+	VerbosityNormal Verbosity = "normal" // info logs on top of quiet
+	VerbosityDebug  Verbosity = "debug"  // debug logs and caller lines on top of normal
+	VerbosityTrace  Verbosity = "trace"  // everything
+)
+
+// Verbosities is the list of verbosity levels from least to most verbose
+var Verbosities = []Verbosity{VerbosityQuiet, VerbosityNormal, VerbosityDebug, VerbosityTrace}
+
+// ParseVerbosity is a function to get verbosity from its name, empty name is debug which is the default
+func ParseVerbosity(name string) (Verbosity, error) {
+	if len(name) == 0 {
+		return VerbosityDebug, nil
+	}
+	for _, v := range Verbosities {
+		if strings.EqualFold(name, string(v)) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("unknown verbosity %s, should be one of %v", name, Verbosities)
+}
+
+// LogLevel is a function to get log level of T created while verbosity is applied
+func (v Verbosity) LogLevel() log.Level {
+	switch v {
+	case VerbosityQuiet:
+		return log.WarnLevel
+	case VerbosityNormal:
+		return log.InfoLevel
+	case VerbosityTrace:
+		return log.TraceLevel
+	}
+	return log.DebugLevel
+}
+
+var (
+	consoleMux      sync.RWMutex
+	defaultLogLevel = log.DebugLevel
+	colorEnabled    = len(os.Getenv("NO_COLOR")) == 0
+	console         *ConsoleSink
+)
+
+// SetVerbosity is a function to set log level of T, B and F created afterwards
+func SetVerbosity(v Verbosity) {
+	consoleMux.Lock()
+	defer consoleMux.Unlock()
+	defaultLogLevel = v.LogLevel()
+}
+
+// DefaultLogLevel is a function to get log level of T, B and F decided by verbosity
+func DefaultLogLevel() log.Level {
+	consoleMux.RLock()
+	defer consoleMux.RUnlock()
+	return defaultLogLevel
+}
+
+// SetColor is a function to enable or disable ANSI colors of logs, they are disabled when NO_COLOR env is set
+func SetColor(enabled bool) {
+	consoleMux.Lock()
+	defer consoleMux.Unlock()
+	colorEnabled = enabled
+}
+
+// colorize is a function to wrap text with ANSI color of log level when colors are enabled
+func colorize(level log.Level, text string) string {
+	return colorizeCode(FieldColorByLogLevel(level), text)
+}
+
+func colorizeCode(color int, text string) string {
+	consoleMux.RLock()
+	enabled := colorEnabled
+	consoleMux.RUnlock()
+	if !enabled {
+		return text + " "
+	}
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m ", color, text)
+}
+
+// summary states which don't come from test events, e.g. step states of fixture runner
+const (
+	SummarySkipped = "SKIP"
+)
+
+// summaryColor is a function to get color of summary state, passed ones are green
+func summaryColor(state string) int {
+	const green = 32
+	switch state {
+	case string(TestPassed):
+		return green
+	case string(TestFailed), string(FatalError):
+		return FieldColorByLogLevel(log.ErrorLevel)
+	case SummarySkipped:
+		return FieldColorByLogLevel(log.WarnLevel)
+	}
+	return FieldColorByLogLevel(log.InfoLevel)
+}
+
+// StepSummary is a struct to describe result of a single step in one line
+type StepSummary struct {
+	Scenario string
+	Step     string
+	Action   string
+	State    string // PASS, FAIL or SKIP
+	Duration time.Duration
+	Message  string // failure cause or skip reason
+}
+
+// String is a function to render step summary in one line e.g. PASS scenario.json/STEP1 create_cookbook (120ms)
+func (s StepSummary) String() string {
+	text := fmt.Sprintf("%s %s/%s %s (%s)", s.State, s.Scenario, s.Step, s.Action, s.Duration.Round(time.Millisecond))
+	if len(s.Message) > 0 {
+		text += ": " + Redact(s.Message)
+	}
+	return text
+}
+
+// ConsoleSink is a struct to print compact one-line summaries of tests and steps to a console
+type ConsoleSink struct {
+	mux sync.Mutex
+	w   io.Writer
+}
+
+// NewConsoleSink is a function to create console sink writing to w
+func NewConsoleSink(w io.Writer) *ConsoleSink {
+	return &ConsoleSink{w: w}
+}
+
+// consoleListenerID is the id of listeners printing test summaries to console sink
+const consoleListenerID = "console_sink"
+
+// SetConsoleSink is a function to set sink printing step summaries, nil sink stops printing them
+func SetConsoleSink(sink *ConsoleSink) {
+	consoleMux.Lock()
+	defer consoleMux.Unlock()
+	console = sink
+}
+
+// WatchTests is a function to print summary of each finished test to sink, skipped tests are not printed
+func (c *ConsoleSink) WatchTests() {
+	for _, event := range []EventType{TestPassed, TestFailed} {
+		AddEventListener(event, consoleListenerID, c.WriteEvent)
+	}
+}
+
+// UnwatchTests is a function to stop printing summaries of finished tests to any sink
+func UnwatchTests() {
+	for _, event := range []EventType{TestPassed, TestFailed} {
+		RemoveEventListener(event, consoleListenerID)
+	}
+}
+
+// WriteStepSummary is a function to print step summary to console sink if it's set
+func WriteStepSummary(summary StepSummary) {
+	consoleMux.RLock()
+	sink := console
+	consoleMux.RUnlock()
+	if sink != nil {
+		sink.WriteStep(summary)
+	}
+}
+
+// WriteEvent is a function to print TestPassed or TestFailed event in one line e.g. FAIL TestCookbook (2s): cause
+func (c *ConsoleSink) WriteEvent(event Event) {
+	text := fmt.Sprintf("%s %s (%s)", event.Type, event.TestName, event.Duration.Round(time.Millisecond))
+	if len(event.Message) > 0 {
+		text += ": " + Redact(event.Message)
+	}
+	c.writeLine(string(event.Type), text)
+}
+
+// WriteStep is a function to print step summary in one line
+func (c *ConsoleSink) WriteStep(summary StepSummary) {
+	c.writeLine(summary.State, summary.String())
+}
+
+func (c *ConsoleSink) writeLine(state, text string) {
+	// keep summary in one line even when failure cause has multiple lines
+	text = strings.Join(strings.Fields(text), " ")
+	line := strings.TrimRight(colorizeCode(summaryColor(state), text), " ")
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	fmt.Fprintln(c.w, line)
+}
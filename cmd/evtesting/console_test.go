@@ -0,0 +1,77 @@
+package evtesting
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestVerbosity(originT *testing.T) {
+	t := NewT(originT)
+
+	for _, tc := range []struct {
+		name  string
+		level log.Level
+	}{
+		{"", log.DebugLevel},
+		{"quiet", log.WarnLevel},
+		{"Normal", log.InfoLevel},
+		{"debug", log.DebugLevel},
+		{"trace", log.TraceLevel},
+	} {
+		v, err := ParseVerbosity(tc.name)
+		t.WithFields(Fields{
+			"verbosity": tc.name,
+		}).MustTrue(err == nil && v.LogLevel() == tc.level, "verbosity should map to log level")
+	}
+	_, err := ParseVerbosity("loud")
+	t.MustTrue(err != nil, "unknown verbosity should fail")
+
+	SetVerbosity(VerbosityQuiet)
+	defer SetVerbosity(VerbosityDebug)
+	quietT := NewT(originT)
+	t.MustTrue(quietT.logLevel == log.WarnLevel, "T should be created with log level of verbosity")
+}
+
+func TestConsoleSink(originT *testing.T) {
+	t := NewT(originT)
+
+	SetColor(false)
+	defer SetColor(true)
+	var buf bytes.Buffer
+	sink := NewConsoleSink(&buf)
+	t.MustTrue(colorize(log.InfoLevel, "text") == "text ", "colorize should keep text when colors are disabled")
+
+	WriteStepSummary(StepSummary{Scenario: "a.json", Step: "STEP1"})
+	t.MustTrue(buf.Len() == 0, "step summary should not be printed without console sink")
+
+	SetConsoleSink(sink)
+	defer SetConsoleSink(nil)
+	WriteStepSummary(StepSummary{
+		Scenario: "a.json",
+		Step:     "STEP1",
+		Action:   "create_cookbook",
+		State:    string(TestFailed),
+		Duration: 1234 * time.Microsecond,
+		Message:  "error\nsending tx",
+	})
+	t.WithFields(Fields{
+		"output": buf.String(),
+	}).MustTrue(buf.String() == "FAIL a.json/STEP1 create_cookbook (1ms): error sending tx\n", "step summary should be printed in one line")
+
+	buf.Reset()
+	sink.WatchTests()
+	defer UnwatchTests()
+	t.Run("passing", func(t *T) {})
+	t.WithFields(Fields{
+		"output": buf.String(),
+	}).MustTrue(strings.HasPrefix(buf.String(), "PASS TestConsoleSink/passing ("), "finished test should be printed in one line")
+
+	UnwatchTests()
+	buf.Reset()
+	t.Run("unwatched", func(t *T) {})
+	t.MustTrue(buf.Len() == 0, "finished test should not be printed after unwatching")
+}
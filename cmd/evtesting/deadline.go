@@ -0,0 +1,106 @@
+package evtesting
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var deadlines sync.Map // *deadlineWatch per testing.T
+
+// deadlineWatch is a struct to manage deadline timer of a test so that it doesn't report after the test finishes
+type deadlineWatch struct {
+	mux   sync.Mutex
+	timer *time.Timer
+	done  chan struct{} // closed when the test finishes or the deadline is replaced
+}
+
+// exceeded is a function to call onExceeded with goroutine dump unless the watch is finished
+// It holds the lock while reporting, so finish waits for the report to complete.
+func (w *deadlineWatch) exceeded(onExceeded func(dump string)) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	select {
+	case <-w.done:
+		return
+	default:
+	}
+	onExceeded(goroutineDump())
+}
+
+// finish is a function to stop the timer and wait for the report of a timer which already fired
+func (w *deadlineWatch) finish() {
+	w.timer.Stop()
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	select {
+	case <-w.done:
+	default:
+		close(w.done)
+	}
+}
+
+// SetDeadline is a function to fail the test with a stack dump of all goroutines when it runs longer than d from now
+// Setting deadline again replaces previous one. The test goroutine is not stopped, so the dump shows where it hangs
+// e.g. in a WaitForBlockInterval-style loop, and go test -timeout still ends a test which never returns.
+func (t *T) SetDeadline(d time.Duration) {
+	t.watchDeadline(d, func(dump string) {
+		t.failDeadline(d, dump)
+	})
+}
+
+// watchDeadline is a function to call onExceeded with goroutine dump when d elapses before the test finishes
+// Cleanup of the test waits for onExceeded which already started, so the test isn't failed after it returns.
+func (t *T) watchDeadline(d time.Duration, onExceeded func(dump string)) {
+	origin := t.origin
+	watch := &deadlineWatch{done: make(chan struct{})}
+	watch.timer = time.AfterFunc(d, func() {
+		watch.exceeded(onExceeded)
+	})
+	previous, loaded := deadlines.Load(origin)
+	deadlines.Store(origin, watch)
+	if loaded {
+		previous.(*deadlineWatch).finish()
+		return
+	}
+	origin.Cleanup(func() {
+		if watch, ok := deadlines.Load(origin); ok {
+			watch.(*deadlineWatch).finish()
+			deadlines.Delete(origin)
+		}
+	})
+}
+
+// failDeadline is a function to mark the test failed by exceeded deadline
+// It is called from timer goroutine, so it can't stop the test like Fatal does.
+func (t *T) failDeadline(d time.Duration, dump string) {
+	requiredLevel := log.FatalLevel
+	cause := fmt.Sprintf("test exceeded deadline of %s", d)
+	nT := t.WithFields(Fields{
+		"deadline":   d.String(),
+		"error_from": "deadline exceeded",
+	})
+	nT.DispatchEvent(FatalError, cause)
+	GlobalReporter.recordFailure(t.origin.Name(), cause, Fields(nT.fields))
+	text := fmt.Sprintf("%s msg=%s\n%s", nT.FormatFields(requiredLevel), cause, dump)
+	// test log is not printed until the test returns, which may never happen for a hanging test
+	fmt.Fprintf(os.Stderr, "%s: %s\n", t.origin.Name(), text)
+	t.origin.Error(colorize(requiredLevel, text))
+}
+
+// goroutineDump is a function to get stack traces of all goroutines
+func goroutineDump() string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return strings.TrimSpace(string(buf[:n]))
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
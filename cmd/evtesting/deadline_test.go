@@ -0,0 +1,57 @@
+package evtesting
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDeadline(originT *testing.T) {
+	t := NewT(originT)
+
+	dumps := make(chan string, 1)
+	t.Run("exceeded", func(t *T) {
+		t.watchDeadline(10*time.Millisecond, func(dump string) { dumps <- dump })
+		select {
+		case dump := <-dumps:
+			t.MustContain(dump, "goroutine", "dump should have stacks of goroutines")
+			t.MustContain(dump, "TestDeadline", "dump should have stack of hanging test")
+		case <-time.After(5 * time.Second):
+			t.Fatal("exceeded deadline should be reported")
+		}
+	})
+
+	t.Run("replaced", func(t *T) {
+		t.watchDeadline(10*time.Millisecond, func(dump string) { dumps <- "first" })
+		t.watchDeadline(time.Hour, func(dump string) { dumps <- "second" })
+	})
+	time.Sleep(50 * time.Millisecond)
+	t.MustTrue(len(dumps) == 0, "replaced deadline and deadline of finished test should not be reported")
+
+	var reported int32
+	t.Run("exceeded while finishing", func(t *T) {
+		started := make(chan struct{})
+		t.watchDeadline(time.Millisecond, func(dump string) {
+			close(started)
+			time.Sleep(20 * time.Millisecond)
+			atomic.StoreInt32(&reported, 1)
+		})
+		<-started
+	})
+	t.MustTrue(atomic.LoadInt32(&reported) == 1, "cleanup should wait for deadline report which started before the test finished")
+
+	var events []Event
+	AddEventListener(TestPassed, "timing", func(event Event) { events = append(events, event) })
+	defer RemoveEventListener(TestPassed, "timing")
+	t.Run("timed", func(t *T) {
+		t.SetDeadline(time.Minute)
+		time.Sleep(20 * time.Millisecond)
+	})
+	t.WithFields(Fields{
+		"events": events,
+	}).MustTrue(len(events) == 1 && events[0].Fields["duration_ms"].(int64) >= 20, "finished test should have duration_ms field")
+	_, ok := deadlines.Load(originT)
+	t.MustTrue(!ok, "deadline should not be set on parent test")
+	t.MustTrue(!strings.Contains(GlobalReporter.failureCause("TestDeadline/timed"), "deadline"), "test finishing in time should not fail")
+}
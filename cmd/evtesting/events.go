@@ -0,0 +1,119 @@
+package evtesting
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// EventType describes the type of an event dispatched by T
+type EventType string
+
+// describes the types of events dispatched by T
+const (
+	TestFailed EventType = "FAIL"  // test finished with failure
+	TestPassed EventType = "PASS"  // test finished without failure
+	FatalError EventType = "FATAL" // fatal error is raised in a test
+)
+
+// Event is a struct to manage payload of an event
+type Event struct {
+	Type     EventType
+	TestName string
+	Message  string // fatal error message for FatalError and first failure cause for TestFailed
+	Fields   Fields
+	Duration time.Duration // only for TestFailed and TestPassed
+}
+
+// EventListener is a function to handle an event
+type EventListener func(event Event)
+
+type eventListenerEntry struct {
+	id       string
+	listener EventListener
+}
+
+var (
+	listenersMux sync.RWMutex
+	listeners    = make(map[EventType][]eventListenerEntry)
+	watchedTests sync.Map
+)
+
+// AddEventListener is a function to register listener for event with id
+// Listener registered with same id for the event is replaced
+func AddEventListener(event EventType, id string, listener EventListener) {
+	listenersMux.Lock()
+	defer listenersMux.Unlock()
+	for idx, entry := range listeners[event] {
+		if entry.id == id {
+			listeners[event][idx].listener = listener
+			return
+		}
+	}
+	listeners[event] = append(listeners[event], eventListenerEntry{id: id, listener: listener})
+}
+
+// RemoveEventListener is a function to unregister listener with id for event
+// It returns false when no listener is registered with the id
+func RemoveEventListener(event EventType, id string) bool {
+	listenersMux.Lock()
+	defer listenersMux.Unlock()
+	for idx, entry := range listeners[event] {
+		if entry.id == id {
+			listeners[event] = append(listeners[event][:idx:idx], listeners[event][idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// DispatchEvent is a function to call listeners of the event in registration order
+func DispatchEvent(event Event) {
+	listenersMux.RLock()
+	entries := append([]eventListenerEntry{}, listeners[event.Type]...)
+	listenersMux.RUnlock()
+	for _, entry := range entries {
+		entry.listener(event)
+	}
+}
+
+// DispatchEvent process events that are related to the event e.g. failure in one test case make others to fail without continuing
+func (t *T) DispatchEvent(event EventType, args ...interface{}) {
+	DispatchEvent(Event{
+		Type:     event,
+		TestName: t.origin.Name(),
+		Message:  strings.TrimSpace(logMessage(args...)),
+		Fields:   Fields(t.fields),
+	})
+}
+
+// watchResult is a function to log duration and dispatch TestPassed or TestFailed when a test finishes
+// Skipped tests do not dispatch an event
+func watchResult(origin testing.TB) {
+	if _, watched := watchedTests.LoadOrStore(origin, true); watched {
+		return
+	}
+	startedAt := time.Now()
+	origin.Cleanup(func() {
+		watchedTests.Delete(origin)
+		if origin.Skipped() {
+			return
+		}
+		duration := time.Since(startedAt)
+		event := Event{
+			Type:     TestPassed,
+			TestName: origin.Name(),
+			Fields:   Fields{"duration_ms": duration.Milliseconds()},
+			Duration: duration,
+		}
+		if origin.Failed() {
+			event.Type = TestFailed
+			event.Message = GlobalReporter.failureCause(origin.Name())
+		}
+		origin.Logf("level=%s duration_ms=%d msg=test %s", log.InfoLevel, duration.Milliseconds(), strings.ToLower(string(event.Type)))
+		DispatchEvent(event)
+	})
+}
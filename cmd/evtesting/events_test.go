@@ -0,0 +1,45 @@
+package evtesting
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestEventListeners(originT *testing.T) {
+	t := NewT(originT)
+
+	var mux sync.Mutex
+	received := map[string][]Event{}
+	listenerFor := func(id string) EventListener {
+		return func(event Event) {
+			mux.Lock()
+			defer mux.Unlock()
+			received[id] = append(received[id], event)
+		}
+	}
+	AddEventListener(TestPassed, "first", listenerFor("first"))
+	AddEventListener(TestPassed, "second", listenerFor("ignored"))
+	AddEventListener(TestPassed, "second", listenerFor("second"))
+	defer RemoveEventListener(TestPassed, "second")
+
+	t.Run("passing", func(t *T) {})
+	t.MustTrue(len(received["first"]) == 1 && len(received["second"]) == 1, "all listeners should receive event")
+	t.MustTrue(len(received["ignored"]) == 0, "replaced listener should not receive event")
+	t.MustTrue(received["first"][0].TestName == "TestEventListeners/passing", "event should have test name")
+
+	t.MustTrue(RemoveEventListener(TestPassed, "first"), "registered listener should be removed")
+	t.MustTrue(!RemoveEventListener(TestPassed, "first"), "removed listener should not be removed again")
+	t.Run("skipped", func(t *T) {
+		t.Skip("skip for event listener test")
+	})
+	t.Run("passing again", func(t *T) {})
+	t.MustTrue(len(received["first"]) == 1, "removed listener should not receive event")
+	t.MustTrue(len(received["second"]) == 2, "skipped test should not dispatch event")
+
+	AddEventListener(FatalError, "fatal", listenerFor("fatal"))
+	defer RemoveEventListener(FatalError, "fatal")
+	t.WithFields(Fields{"key": "value"}).DispatchEvent(FatalError, "fatal message")
+	t.MustTrue(len(received["fatal"]) == 1, "dispatched event should be received")
+	t.MustTrue(received["fatal"][0].Message == "fatal message", "event should have message")
+	t.MustTrue(received["fatal"][0].Fields["key"] == "value", "event should have fields")
+}
@@ -20,8 +20,9 @@ const (
 )
 
 // T is a modified testing.T
+// origin is testing.TB so that B and F share the logging surface of T.
 type T struct {
-	origin     *testing.T
+	origin     testing.TB
 	useLogPkg  bool
 	fields     log.Fields
 	logLevel   log.Level
@@ -32,7 +33,34 @@ type T struct {
 // Fields is a type to manage json based output
 type Fields log.Fields
 
-var listeners = make(map[string]func())
+// errorExplainer is a function to explain error of MustNil failure, returns empty string for unknown errors
+var errorExplainer func(error) string
+
+// SetErrorExplainer is a function to set explainer which adds error_explanation field to MustNil failures
+func SetErrorExplainer(explainer func(error) string) {
+	errorExplainer = explainer
+}
+
+// redactor is a function to remove sensitive data from log text, nothing is redacted when it's nil
+var redactor func(string) string
+
+// SetRedactor is a function to set redactor applied to messages and fields of logs, events and reports
+func SetRedactor(fn func(string) string) {
+	redactor = fn
+}
+
+// Redact is a function to remove sensitive data from text by redactor
+func Redact(text string) string {
+	if redactor == nil {
+		return text
+	}
+	return redactor(text)
+}
+
+// logMessage is a function to render log message of args with sensitive data redacted
+func logMessage(args ...interface{}) string {
+	return Redact(fmt.Sprintln(args...))
+}
 
 // NewT is function returns modified T from original testing.T
 func NewT(origin *testing.T) T {
@@ -40,7 +68,7 @@ func NewT(origin *testing.T) T {
 		origin:     origin,
 		useLogPkg:  false,
 		fields:     log.Fields{},
-		logLevel:   log.DebugLevel,
+		logLevel:   DefaultLogLevel(),
 		sortType:   SortValueLength,
 		sortFields: []string{},
 	}
@@ -51,6 +79,9 @@ func NewT(origin *testing.T) T {
 		newT.logLevel = log.TraceLevel
 		newT.sortType = SortValueLength
 		newT.sortFields = []string{}
+	} else {
+		GlobalReporter.track(origin)
+		watchResult(origin)
 	}
 	return newT
 }
@@ -62,10 +93,23 @@ func NewLogLevelT(origin *testing.T, logLevel log.Level) T {
 	return newT
 }
 
-// WithFields is to manage data in json format
-func (t *T) WithFields(fields Fields) *T {
+// mergeFields is a function to create a new field set having base fields overridden by fields
+// Field sets are never modified after creation, so T copies sharing them are safe to use from parallel tests.
+func mergeFields(base log.Fields, fields Fields) log.Fields {
+	merged := make(log.Fields, len(base)+len(fields))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return merged
+}
+
+// withOwnFields is a function to copy T with fields replaced
+func (t *T) withOwnFields(fields log.Fields) *T {
 	return &T{
-		fields:     log.Fields(fields),
+		fields:     fields,
 		origin:     t.origin,
 		useLogPkg:  t.useLogPkg,
 		logLevel:   t.logLevel,
@@ -74,9 +118,25 @@ func (t *T) WithFields(fields Fields) *T {
 	}
 }
 
+// WithFields is to manage data in json format, fields are merged into fields of t which is kept unchanged
+func (t *T) WithFields(fields Fields) *T {
+	return t.withOwnFields(mergeFields(t.fields, fields))
+}
+
+// WithField is to add a single field as WithFields does
+func (t *T) WithField(key string, value interface{}) *T {
+	return t.WithFields(Fields{key: value})
+}
+
 // Run is modified Run
+// Subtests are only run by T of testing.T, use Run of B for sub-benchmarks.
 func (t *T) Run(name string, f func(t *T)) bool {
-	return t.origin.Run(name, func(subt *testing.T) {
+	origin, ok := t.origin.(*testing.T)
+	if !ok {
+		t.Fatal("Run is not supported by", fmt.Sprintf("%T", t.origin))
+		return false
+	}
+	return origin.Run(name, func(subt *testing.T) {
 		newT := T{
 			origin:     subt,
 			fields:     t.fields,
@@ -85,16 +145,15 @@ func (t *T) Run(name string, f func(t *T)) bool {
 			sortType:   t.sortType,
 			sortFields: t.sortFields,
 		}
+		GlobalReporter.track(subt)
+		watchResult(subt)
 		f(&newT)
 	})
 }
 
-// AddFields is to add additional data to existing fields
+// AddFields is to add additional data to existing fields, it's same as WithFields and t is kept unchanged
 func (t *T) AddFields(fields log.Fields) *T {
-	for k, v := range fields {
-		t.fields[k] = v
-	}
-	return t
+	return t.WithFields(Fields(fields))
 }
 
 // SetFieldsOrder is to set fields order for better debugging
@@ -104,13 +163,6 @@ func (t *T) SetFieldsOrder(sortType int, sortFields []string) *T {
 	return t
 }
 
-// DispatchEvent process events that are related to the event e.g. failure in one test case make others to fail without continuing
-func (t *T) DispatchEvent(event string) {
-	if listener, ok := listeners[event]; ok {
-		listener()
-	}
-}
-
 func getFrame(skipFrames int) runtime.Frame {
 	// We need the frame at index skipFrames+2, since we never want runtime.Callers and getFrame
 	targetFrameIndex := skipFrames + 2
@@ -136,6 +188,9 @@ func getFrame(skipFrames int) runtime.Frame {
 
 func (t *T) printCallerLine() {
 	requiredLevel := log.DebugLevel
+	if t.logLevel < requiredLevel {
+		return
+	}
 	frame := getFrame(2)
 	if t.useLogPkg {
 		text := fmt.Sprintf("%s:%d %s", frame.File, frame.Line, frame.Function)
@@ -144,11 +199,11 @@ func (t *T) printCallerLine() {
 			"func":      frame.Function,
 		}).Trace(text)
 	} else {
-		nT := t.WithFields(Fields{
+		nT := t.withOwnFields(log.Fields{
 			"file_line": fmt.Sprintf("%s:%d", frame.File, frame.Line),
 			"func":      frame.Function,
 		})
-		logOutput := fmt.Sprintf("\x1b[%dm%s\x1b[0m ", FieldColorByLogLevel(requiredLevel), nT.FormatFields(requiredLevel))
+		logOutput := colorize(requiredLevel, nT.FormatFields(requiredLevel))
 		t.origin.Log(logOutput)
 	}
 }
@@ -167,19 +222,21 @@ func (t *T) printEntireStack() {
 func FieldColorByLogLevel(logLevel log.Level) int {
 	// https://misc.flogisoft.com/bash/tip_colors_and_formatting
 	const (
-		red    = 31
-		orange = 202
-		yellow = 33
-		blue   = 36
-		gray   = 37
+		red      = 31
+		yellow   = 33
+		blue     = 36
+		gray     = 37
+		darkGray = 90
 	)
 	var levelColor int
 	switch logLevel {
-	case log.DebugLevel, log.TraceLevel:
+	case log.TraceLevel:
+		levelColor = darkGray
+	case log.DebugLevel:
 		levelColor = gray
-	case log.WarnLevel, log.ErrorLevel:
+	case log.WarnLevel:
 		levelColor = yellow
-	case log.FatalLevel, log.PanicLevel:
+	case log.ErrorLevel, log.FatalLevel, log.PanicLevel:
 		levelColor = red
 	default:
 		levelColor = blue
@@ -200,18 +257,18 @@ func (t *T) FormatFields(logLevel log.Level) string {
 		keys = append(keys, k)
 	}
 
+	// keys are sorted alphabetically first so that keys of the same order are rendered deterministically
 	fixedKeys := []string{}
 	fixedKeys = append(fixedKeys, keys...)
+	sort.Strings(fixedKeys)
 	switch t.sortType {
-	case NoSort:
-	case SortKeyAlphaBet:
-		sort.Strings(fixedKeys)
+	case NoSort, SortKeyAlphaBet:
 	case SortCustomKey:
 		customIndexMap := map[string]int{}
 		for i, k := range t.sortFields {
 			customIndexMap[k] = len(t.sortFields) - i + 1
 		}
-		sort.Slice(fixedKeys, func(i, j int) bool {
+		sort.SliceStable(fixedKeys, func(i, j int) bool {
 			cik := customIndexMap[fixedKeys[i]]
 			cjk := customIndexMap[fixedKeys[j]]
 			if cik != cjk {
@@ -222,7 +279,7 @@ func (t *T) FormatFields(logLevel log.Level) string {
 			return len(sik) < len(sjk)
 		})
 	case SortValueLength:
-		sort.Slice(fixedKeys, func(i, j int) bool {
+		sort.SliceStable(fixedKeys, func(i, j int) bool {
 			sik := fmt.Sprintf("%+v", data[fixedKeys[i]])
 			sjk := fmt.Sprintf("%+v", data[fixedKeys[j]])
 			return len(sik) < len(sjk)
@@ -230,7 +287,7 @@ func (t *T) FormatFields(logLevel log.Level) string {
 	}
 
 	for _, key := range fixedKeys {
-		formated += fmt.Sprintf(" %s=%+v", key, data[key])
+		formated += fmt.Sprintf(" %s=%s", key, Redact(fmt.Sprintf("%+v", data[key])))
 	}
 
 	return formated
@@ -243,8 +300,8 @@ func (t *T) Error(args ...interface{}) {
 	if t.useLogPkg {
 		log.WithFields(t.fields).Error(args...)
 	} else {
-		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), fmt.Sprintln(args...))
-		logOutput := fmt.Sprintf("\x1b[%dm%s\x1b[0m ", FieldColorByLogLevel(requiredLevel), text)
+		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), logMessage(args...))
+		logOutput := colorize(requiredLevel, text)
 		t.origin.Log(logOutput)
 	}
 }
@@ -252,13 +309,17 @@ func (t *T) Error(args ...interface{}) {
 // Panic is a modified Panic
 func (t *T) Panic(args ...interface{}) {
 	requiredLevel := log.PanicLevel
-	t.DispatchEvent("FAIL")
+	t.DispatchEvent(FatalError, args...)
+	if !t.useLogPkg {
+		GlobalReporter.recordFailure(t.origin.Name(), strings.TrimSpace(logMessage(args...)), Fields(t.fields))
+		t.captureFailureSnapshot(strings.TrimSpace(logMessage(args...)))
+	}
 	t.printCallerLine()
 	if t.useLogPkg {
 		log.WithFields(t.fields).Panic(args...)
 	} else {
-		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), fmt.Sprintln(args...))
-		logOutput := fmt.Sprintf("\x1b[%dm%s\x1b[0m ", FieldColorByLogLevel(requiredLevel), text)
+		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), logMessage(args...))
+		logOutput := colorize(requiredLevel, text)
 		t.origin.Fatal(logOutput)
 	}
 }
@@ -266,13 +327,17 @@ func (t *T) Panic(args ...interface{}) {
 // Fatal is a modified Fatal
 func (t *T) Fatal(args ...interface{}) {
 	requiredLevel := log.FatalLevel
-	t.DispatchEvent("FAIL")
+	t.DispatchEvent(FatalError, args...)
+	if !t.useLogPkg {
+		GlobalReporter.recordFailure(t.origin.Name(), strings.TrimSpace(logMessage(args...)), Fields(t.fields))
+		t.captureFailureSnapshot(strings.TrimSpace(logMessage(args...)))
+	}
 	t.printCallerLine()
 	if t.useLogPkg {
 		log.WithFields(t.fields).Fatal(args...)
 	} else {
-		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), fmt.Sprintln(args...))
-		logOutput := fmt.Sprintf("\x1b[%dm%s\x1b[0m ", FieldColorByLogLevel(requiredLevel), text)
+		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), logMessage(args...))
+		logOutput := colorize(requiredLevel, text)
 		t.origin.Fatal(logOutput)
 	}
 }
@@ -280,13 +345,17 @@ func (t *T) Fatal(args ...interface{}) {
 // Fatalf is a modified Fatalf
 func (t *T) Fatalf(format string, args ...interface{}) {
 	requiredLevel := log.FatalLevel
-	t.DispatchEvent("FAIL")
+	t.DispatchEvent(FatalError, fmt.Sprintf(format, args...))
+	if !t.useLogPkg {
+		GlobalReporter.recordFailure(t.origin.Name(), Redact(fmt.Sprintf(format, args...)), Fields(t.fields))
+		t.captureFailureSnapshot(Redact(fmt.Sprintf(format, args...)))
+	}
 	t.printCallerLine()
 	if t.useLogPkg {
 		log.WithFields(t.fields).Fatalf(format, args...)
 	} else {
-		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), fmt.Sprintf(format, args...))
-		logOutput := fmt.Sprintf("\x1b[%dm%s\x1b[0m ", FieldColorByLogLevel(requiredLevel), text)
+		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), Redact(fmt.Sprintf(format, args...)))
+		logOutput := colorize(requiredLevel, text)
 		t.origin.Fatal(logOutput)
 	}
 }
@@ -294,7 +363,6 @@ func (t *T) Fatalf(format string, args ...interface{}) {
 // MustTrue validate if value is true
 func (t *T) MustTrue(value bool, args ...interface{}) {
 	if !value {
-		t.DispatchEvent("FAIL")
 		t.printEntireStack()
 		t.WithFields(Fields(t.fields)).
 			AddFields(log.Fields{
@@ -306,13 +374,18 @@ func (t *T) MustTrue(value bool, args ...interface{}) {
 // MustNil validate if error is nil
 func (t *T) MustNil(err error, args ...interface{}) {
 	if err != nil {
-		t.DispatchEvent("FAIL")
 		t.printEntireStack()
+		fields := log.Fields{
+			"error":      err,
+			"error_from": "MustNil validation failure",
+		}
+		if errorExplainer != nil {
+			if explanation := errorExplainer(err); len(explanation) > 0 {
+				fields["error_explanation"] = explanation
+			}
+		}
 		t.WithFields(Fields(t.fields)).
-			AddFields(log.Fields{
-				"error":      err,
-				"error_from": "MustNil validation failure",
-			}).Fatal(args...)
+			AddFields(fields).Fatal(args...)
 	}
 }
 
@@ -320,7 +393,6 @@ func (t *T) MustNil(err error, args ...interface{}) {
 func (t *T) MustContain(srcstring, substring string, args ...interface{}) {
 	value := strings.Contains(srcstring, substring)
 	if !value {
-		t.DispatchEvent("FAIL")
 		t.printEntireStack()
 		t.WithFields(Fields(t.fields)).
 			AddFields(log.Fields{
@@ -331,9 +403,48 @@ func (t *T) MustContain(srcstring, substring string, args ...interface{}) {
 	}
 }
 
-// Parallel is modified Parallel
+// Parallel is modified Parallel, it's no-op for benchmarks
 func (t *T) Parallel() {
-	t.origin.Parallel()
+	if origin, ok := t.origin.(*testing.T); ok {
+		origin.Parallel()
+	}
+}
+
+// Skip is modified Skip
+// In log-only mode there's no test to stop, so the reason is only logged and Skip returns.
+func (t *T) Skip(args ...interface{}) {
+	requiredLevel := log.InfoLevel
+	text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), logMessage(args...))
+	if t.useLogPkg {
+		log.WithFields(t.fields).WithField("skipped", true).Infoln(args...)
+		return
+	}
+	t.origin.Skip(text)
+}
+
+// Skipf is modified Skipf
+func (t *T) Skipf(format string, args ...interface{}) {
+	t.Skip(fmt.Sprintf(format, args...))
+}
+
+// Skipped is modified Skipped
+func (t *T) Skipped() bool {
+	return t.origin.Skipped()
+}
+
+// Failed is modified Failed
+func (t *T) Failed() bool {
+	return t.origin.Failed()
+}
+
+// Name is modified Name
+func (t *T) Name() string {
+	return t.origin.Name()
+}
+
+// Cleanup is modified Cleanup
+func (t *T) Cleanup(f func()) {
+	t.origin.Cleanup(f)
 }
 
 // Log is modified Log
@@ -342,8 +453,8 @@ func (t *T) Log(args ...interface{}) {
 	if t.useLogPkg {
 		log.WithFields(t.fields).Infoln(args...)
 	} else {
-		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), fmt.Sprintln(args...))
-		logOutput := fmt.Sprintf("\x1b[%dm%s\x1b[0m ", FieldColorByLogLevel(requiredLevel), text)
+		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), logMessage(args...))
+		logOutput := colorize(requiredLevel, text)
 		t.origin.Log(logOutput)
 	}
 }
@@ -357,8 +468,8 @@ func (t *T) Info(args ...interface{}) {
 	if t.useLogPkg {
 		log.WithFields(t.fields).Infoln(args...)
 	} else {
-		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), fmt.Sprintln(args...))
-		logOutput := fmt.Sprintf("\x1b[%dm%s\x1b[0m ", FieldColorByLogLevel(requiredLevel), text)
+		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), logMessage(args...))
+		logOutput := colorize(requiredLevel, text)
 		t.origin.Log(logOutput)
 	}
 }
@@ -373,8 +484,8 @@ func (t *T) Warn(args ...interface{}) {
 	if t.useLogPkg {
 		log.WithFields(t.fields).Warnln(args...)
 	} else {
-		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), fmt.Sprintln(args...))
-		logOutput := fmt.Sprintf("\x1b[%dm%s\x1b[0m ", FieldColorByLogLevel(requiredLevel), text)
+		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), logMessage(args...))
+		logOutput := colorize(requiredLevel, text)
 		t.origin.Log(logOutput)
 	}
 }
@@ -389,8 +500,8 @@ func (t *T) Trace(args ...interface{}) {
 	if t.useLogPkg {
 		log.WithFields(t.fields).Traceln(args...)
 	} else {
-		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), fmt.Sprintln(args...))
-		logOutput := fmt.Sprintf("\x1b[%dm%s\x1b[0m ", FieldColorByLogLevel(requiredLevel), text)
+		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), logMessage(args...))
+		logOutput := colorize(requiredLevel, text)
 		t.origin.Log(logOutput)
 	}
 }
@@ -405,8 +516,8 @@ func (t *T) Debug(args ...interface{}) {
 	if t.useLogPkg {
 		log.WithFields(t.fields).Debugln(args...)
 	} else {
-		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), fmt.Sprintln(args...))
-		logOutput := fmt.Sprintf("\x1b[%dm%s\x1b[0m ", FieldColorByLogLevel(requiredLevel), text)
+		text := fmt.Sprintf("%s msg=%s", t.FormatFields(requiredLevel), logMessage(args...))
+		logOutput := colorize(requiredLevel, text)
 		t.origin.Log(logOutput)
 	}
 }
@@ -0,0 +1,35 @@
+package evtesting
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestWithFields(originT *testing.T) {
+	t := NewT(originT)
+
+	base := t.WithFields(Fields{"a": 1})
+	chained := base.WithFields(Fields{"b": 2}).WithField("c", 3)
+	t.MustTrue(len(chained.fields) == 3, "chained fields should be merged")
+	t.MustTrue(len(base.fields) == 1, "chaining should not change fields of base")
+	added := base.AddFields(log.Fields{"a": 10})
+	t.MustTrue(added.fields["a"] == 10 && base.fields["a"] == 1, "added fields should override without changing base")
+
+	ordered := t.WithFields(Fields{"bb": "x", "aa": "y", "c": "zz"})
+	ordered.SetFieldsOrder(SortValueLength, nil)
+	t.MustTrue(ordered.FormatFields(log.InfoLevel) == "level=info aa=y bb=x c=zz", "fields of the same value length should be sorted by key")
+
+	for i := 0; i < 4; i++ {
+		i := i
+		base.Run(fmt.Sprintf("parallel_%d", i), func(t *T) {
+			t.Parallel()
+			for j := 0; j < 100; j++ {
+				t = t.WithField(fmt.Sprintf("key_%d", j%5), j)
+			}
+			t.MustTrue(strings.Contains(t.FormatFields(log.InfoLevel), "a=1"), "fields of parent should be kept")
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package evtesting
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultFailureSnapshotTxs is the number of latest transactions of each account in failure snapshot when ReportOpts doesn't set it
+const defaultFailureSnapshotTxs = 5
+
+// failureSnapshotTimeout is the time budget of all capturers of a failure snapshot, so that an unreachable node doesn't hang the failure
+var failureSnapshotTimeout = 20 * time.Second
+
+// FailureSnapshotRequest is a struct to describe what chain context snapshot of a failed test is captured for
+type FailureSnapshotRequest struct {
+	TestName  string
+	Accounts  []string  // addresses involved in the test, sorted
+	TxLimit   int       // number of latest transactions of each account
+	StartedAt time.Time // zero when the test is not tracked by GlobalReporter
+	TxHashes  []string  // transactions sent by the test in send order
+}
+
+// FailureSnapshotCapturer is a function to capture a section of chain context snapshot e.g. balances of accounts
+type FailureSnapshotCapturer func(ctx context.Context, req FailureSnapshotRequest) (interface{}, error)
+
+// FailureSnapshot is a struct to describe chain context at the time a test failed, sections are keyed by capturer name
+type FailureSnapshot struct {
+	TestName string                 `json:"test"`
+	Cause    string                 `json:"cause"`
+	Accounts []string               `json:"accounts"`
+	Sections map[string]interface{} `json:"sections"`
+	Errors   map[string]string      `json:"errors,omitempty"`
+}
+
+type failureSnapshotCapturerEntry struct {
+	name     string
+	capturer FailureSnapshotCapturer
+}
+
+var (
+	snapshotCapturersMux sync.RWMutex
+	snapshotCapturers    []failureSnapshotCapturerEntry
+	involvedAccounts     sync.Map // *involvedAccountSet per testing.T
+	failureSnapshots     sync.Map // testing.T whose failure snapshot is taken
+)
+
+type involvedAccountSet struct {
+	mux      sync.Mutex
+	accounts map[string]bool
+}
+
+// AddFailureSnapshotCapturer is a function to register capturer of a failure snapshot section with name
+// Capturer registered with same name is replaced, sections are captured in registration order.
+func AddFailureSnapshotCapturer(name string, capturer FailureSnapshotCapturer) {
+	snapshotCapturersMux.Lock()
+	defer snapshotCapturersMux.Unlock()
+	for idx, entry := range snapshotCapturers {
+		if entry.name == name {
+			snapshotCapturers[idx].capturer = capturer
+			return
+		}
+	}
+	snapshotCapturers = append(snapshotCapturers, failureSnapshotCapturerEntry{name: name, capturer: capturer})
+}
+
+// RemoveFailureSnapshotCapturer is a function to unregister capturer with name
+// It returns false when no capturer is registered with the name
+func RemoveFailureSnapshotCapturer(name string) bool {
+	snapshotCapturersMux.Lock()
+	defer snapshotCapturersMux.Unlock()
+	for idx, entry := range snapshotCapturers {
+		if entry.name == name {
+			snapshotCapturers = append(snapshotCapturers[:idx:idx], snapshotCapturers[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// InvolveAccounts is a function to add addresses the test touches to the accounts of its failure snapshot
+func (t *T) InvolveAccounts(addrs ...string) {
+	if t == nil || t.useLogPkg {
+		return
+	}
+	set, loaded := involvedAccounts.LoadOrStore(t.origin, &involvedAccountSet{accounts: make(map[string]bool)})
+	if !loaded {
+		origin := t.origin
+		t.origin.Cleanup(func() {
+			involvedAccounts.Delete(origin)
+		})
+	}
+	accountSet := set.(*involvedAccountSet)
+	accountSet.mux.Lock()
+	defer accountSet.mux.Unlock()
+	for _, addr := range addrs {
+		if len(addr) > 0 {
+			accountSet.accounts[addr] = true
+		}
+	}
+}
+
+// InvolvedAccounts is a function to get addresses the test touched in sorted order
+func (t *T) InvolvedAccounts() []string {
+	accounts := []string{}
+	if t.useLogPkg {
+		return accounts
+	}
+	set, ok := involvedAccounts.Load(t.origin)
+	if !ok {
+		return accounts
+	}
+	accountSet := set.(*involvedAccountSet)
+	accountSet.mux.Lock()
+	defer accountSet.mux.Unlock()
+	for addr := range accountSet.accounts {
+		accounts = append(accounts, addr)
+	}
+	sort.Strings(accounts)
+	return accounts
+}
+
+// CaptureFailureSnapshot is a function to run registered capturers and get chain context snapshot of the test
+// Errors of capturers are kept in the snapshot instead of failing, as the snapshot is taken while the test is failing.
+func (t *T) CaptureFailureSnapshot(ctx context.Context, cause string) FailureSnapshot {
+	snapshotCapturersMux.RLock()
+	entries := append([]failureSnapshotCapturerEntry{}, snapshotCapturers...)
+	snapshotCapturersMux.RUnlock()
+	req := FailureSnapshotRequest{
+		TestName: t.origin.Name(),
+		Accounts: t.InvolvedAccounts(),
+		TxLimit:  ReportOpts.FailureSnapshotTxs,
+	}
+	if req.TxLimit <= 0 {
+		req.TxLimit = defaultFailureSnapshotTxs
+	}
+	req.StartedAt, req.TxHashes = GlobalReporter.timeline(req.TestName)
+	snapshot := FailureSnapshot{
+		TestName: req.TestName,
+		Cause:    cause,
+		Accounts: req.Accounts,
+		Sections: make(map[string]interface{}),
+	}
+	for _, entry := range entries {
+		section, err := entry.capturer(ctx, req)
+		if err != nil {
+			if snapshot.Errors == nil {
+				snapshot.Errors = make(map[string]string)
+			}
+			snapshot.Errors[entry.name] = err.Error()
+			continue
+		}
+		snapshot.Sections[entry.name] = section
+	}
+	return snapshot
+}
+
+// captureFailureSnapshot is a function to attach chain context snapshot to the failure output once per test
+// It's no-op when no capturer is registered or ReportOpts.DisableFailureSnapshot is set.
+func (t *T) captureFailureSnapshot(cause string) {
+	if t.useLogPkg || ReportOpts.DisableFailureSnapshot {
+		return
+	}
+	snapshotCapturersMux.RLock()
+	noCapturer := len(snapshotCapturers) == 0
+	snapshotCapturersMux.RUnlock()
+	if noCapturer {
+		return
+	}
+	if _, taken := failureSnapshots.LoadOrStore(t.origin, true); taken {
+		return
+	}
+	origin := t.origin
+	t.origin.Cleanup(func() {
+		failureSnapshots.Delete(origin)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), failureSnapshotTimeout)
+	defer cancel()
+	snapshot := t.CaptureFailureSnapshot(ctx, cause)
+	t.AttachJSON("failure_snapshot.json", snapshot)
+	fields := Fields{"accounts": snapshot.Accounts}
+	if sections, err := json.Marshal(snapshot.Sections); err == nil {
+		fields["sections"] = string(sections)
+	}
+	if len(snapshot.Errors) > 0 {
+		fields["capture_errors"] = snapshot.Errors
+	}
+	t.withOwnFields(mergeFields(nil, fields)).Error("chain context snapshot at failure")
+}
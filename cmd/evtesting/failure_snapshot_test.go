@@ -0,0 +1,58 @@
+package evtesting
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFailureSnapshot(originT *testing.T) {
+	t := NewT(originT)
+
+	dir, err := ioutil.TempDir("", "artifacts")
+	t.MustNil(err, "error creating artifacts directory")
+	defer os.RemoveAll(dir)
+	artifactsDir := ReportOpts.ArtifactsDir
+	ReportOpts.ArtifactsDir = dir
+	defer func() { ReportOpts.ArtifactsDir = artifactsDir }()
+
+	var requested FailureSnapshotRequest
+	AddFailureSnapshotCapturer("height", func(ctx context.Context, req FailureSnapshotRequest) (interface{}, error) {
+		requested = req
+		return 42, nil
+	})
+	defer RemoveFailureSnapshotCapturer("height")
+	AddFailureSnapshotCapturer("balances", func(ctx context.Context, req FailureSnapshotRequest) (interface{}, error) {
+		return nil, errors.New("node is unreachable")
+	})
+	t.MustTrue(RemoveFailureSnapshotCapturer("balances"), "registered capturer should be removed")
+	t.MustTrue(!RemoveFailureSnapshotCapturer("balances"), "removed capturer should not be found")
+	AddFailureSnapshotCapturer("balances", func(ctx context.Context, req FailureSnapshotRequest) (interface{}, error) {
+		return nil, errors.New("node is unreachable")
+	})
+	defer RemoveFailureSnapshotCapturer("balances")
+
+	t.Run("failing", func(t *T) {
+		t.InvolveAccounts("player2", "player1", "", "player2")
+		t.RecordTx(TxRecord{TxHash: "ABCD", Msgs: []string{"execute_recipe"}})
+		t.MustTrue(len(t.InvolvedAccounts()) == 2 && t.InvolvedAccounts()[0] == "player1", "involved accounts should be unique and sorted")
+
+		snapshot := t.CaptureFailureSnapshot(context.Background(), "balance is incorrect")
+		t.MustTrue(requested.TxLimit == defaultFailureSnapshotTxs && len(requested.Accounts) == 2, "capturers should get involved accounts and tx limit")
+		t.MustTrue(!requested.StartedAt.IsZero(), "capturers should get start time of the test")
+		t.MustTrue(len(requested.TxHashes) == 1 && requested.TxHashes[0] == "ABCD", "capturers should get transactions of the test")
+		t.MustTrue(snapshot.Sections["height"] == 42, "captured section should be kept by capturer name")
+		t.MustTrue(snapshot.Errors["balances"] == "node is unreachable", "capturer error should be kept instead of failing")
+
+		t.captureFailureSnapshot("balance is incorrect")
+		t.captureFailureSnapshot("second failure")
+	})
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "TestFailureSnapshot", "failing", "failure_snapshot.json"))
+	t.MustNil(err, "error reading failure snapshot")
+	t.MustContain(string(data), `"cause": "balance is incorrect"`, "snapshot of the first failure should be attached")
+	t.MustContain(string(data), `"height": 42`)
+}
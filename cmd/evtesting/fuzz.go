@@ -0,0 +1,75 @@
+//go:build go1.18
+// +build go1.18
+
+package evtesting
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// F is a modified testing.F for native fuzz targets, it has fields, logging and event dispatch of T
+type F struct {
+	T
+	origin *testing.F
+}
+
+// NewF is function returns modified F from original testing.F
+func NewF(origin *testing.F) F {
+	GlobalReporter.track(origin)
+	watchResult(origin)
+	return F{
+		T: T{
+			origin:     origin,
+			useLogPkg:  false,
+			fields:     log.Fields{},
+			logLevel:   DefaultLogLevel(),
+			sortType:   SortValueLength,
+			sortFields: []string{},
+		},
+		origin: origin,
+	}
+}
+
+// WithFields is modified WithFields which keeps F, fields are merged into fields of f which is kept unchanged
+func (f *F) WithFields(fields Fields) *F {
+	return &F{T: *f.T.WithFields(fields), origin: f.origin}
+}
+
+// WithField is to add a single field as WithFields does
+func (f *F) WithField(key string, value interface{}) *F {
+	return f.WithFields(Fields{key: value})
+}
+
+// Add is modified Add to add seed corpus entry
+func (f *F) Add(args ...interface{}) {
+	f.origin.Add(args...)
+}
+
+// Fuzz is modified Fuzz, ff is a function like func(t *T, data []byte, name string) with fuzzed args of testing.F
+// T of each input has fields of f.
+func (f *F) Fuzz(ff interface{}) {
+	fn := reflect.ValueOf(ff)
+	fnType := fn.Type()
+	if fnType.Kind() != reflect.Func || fnType.NumIn() < 1 || fnType.In(0) != reflect.TypeOf(&T{}) || fnType.NumOut() != 0 {
+		f.Fatal("fuzz target should be func(*evtesting.T, ...) without results, got", fmt.Sprintf("%T", ff))
+		return
+	}
+	in := []reflect.Type{reflect.TypeOf(&testing.T{})}
+	for idx := 1; idx < fnType.NumIn(); idx++ {
+		in = append(in, fnType.In(idx))
+	}
+	target := reflect.MakeFunc(reflect.FuncOf(in, nil, false), func(args []reflect.Value) []reflect.Value {
+		t := NewLogLevelT(args[0].Interface().(*testing.T), f.logLevel)
+		t.fields = f.fields
+		t.sortType = f.sortType
+		t.sortFields = f.sortFields
+		args[0] = reflect.ValueOf(&t)
+		fn.Call(args)
+		return nil
+	})
+	f.origin.Fuzz(target.Interface())
+}
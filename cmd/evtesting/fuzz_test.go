@@ -0,0 +1,22 @@
+//go:build go1.18
+// +build go1.18
+
+package evtesting
+
+import (
+	"strings"
+	"testing"
+)
+
+func FuzzWithFields(originF *testing.F) {
+	f := NewF(originF)
+	f.Add("seed", 1)
+	f.Add("", 0)
+	nF := f.WithField("target", "with_fields")
+	nF.Fuzz(func(t *T, key string, value int) {
+		t.MustTrue(t.fields["target"] == "with_fields", "T of input should have fields of F")
+		nT := t.WithFields(Fields{key: value})
+		t.MustTrue(len(nT.fields) == len(t.fields)+1, "fields should be merged")
+		t.MustTrue(strings.HasPrefix(t.Name(), "FuzzWithFields/"), "input should run as subtest of fuzz target")
+	})
+}
@@ -0,0 +1,108 @@
+package evtesting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// gasBudgetState is a struct to manage gas budgets per msg type declared by a test
+type gasBudgetState struct {
+	mux     sync.Mutex
+	budgets map[string]int64
+}
+
+var gasBudgetStates sync.Map // *gasBudgetState per testing.T
+
+// GasOverrun is a struct to describe a transaction which used more gas than budget of its msgs
+type GasOverrun struct {
+	TxHash  string
+	Msgs    []string
+	GasUsed int64
+	Budget  int64
+}
+
+func (o GasOverrun) String() string {
+	return fmt.Sprintf("tx %s of %s used %d gas, budget is %d", o.TxHash, strings.Join(o.Msgs, ","), o.GasUsed, o.Budget)
+}
+
+// gasBudgets is a function to get gas budget state of the test, budgets are checked when the test finishes
+func (t *T) gasBudgets() *gasBudgetState {
+	state, loaded := gasBudgetStates.LoadOrStore(t.origin, &gasBudgetState{budgets: make(map[string]int64)})
+	if !loaded {
+		origin := t.origin
+		t.origin.Cleanup(func() {
+			gasBudgetStates.Delete(origin)
+			t.checkGasBudgets(state.(*gasBudgetState))
+		})
+	}
+	return state.(*gasBudgetState)
+}
+
+// ExpectGasAtMost is a function to declare gas budget of msg type which transactions of the test and its subtests should keep
+// Budget of a transaction is the sum of budgets of its msgs, transactions having a msg type without budget are not checked.
+func (t *T) ExpectGasAtMost(msgType string, limit int64) {
+	if t.useLogPkg {
+		t.Warn("gas budget is not checked without testing.T", msgType)
+		return
+	}
+	state := t.gasBudgets()
+	state.mux.Lock()
+	defer state.mux.Unlock()
+	state.budgets[msgType] = limit
+}
+
+// CheckGasBudgets is a function to get transactions which used more gas than budgets of their msgs
+// Transactions not included in a block yet have no gas used and are not checked.
+func CheckGasBudgets(budgets map[string]int64, txs []TxRecord) []GasOverrun {
+	overruns := []GasOverrun{}
+	for _, tx := range txs {
+		if tx.Height == 0 || len(tx.Msgs) == 0 {
+			continue
+		}
+		budget, covered := int64(0), true
+		for _, msg := range tx.Msgs {
+			limit, ok := budgets[msg]
+			if !ok {
+				covered = false
+				break
+			}
+			budget += limit
+		}
+		if covered && tx.GasUsed > budget {
+			overruns = append(overruns, GasOverrun{TxHash: tx.TxHash, Msgs: tx.Msgs, GasUsed: tx.GasUsed, Budget: budget})
+		}
+	}
+	return overruns
+}
+
+// checkGasBudgets is a function to fail the test when its transactions used more gas than budgets
+func (t *T) checkGasBudgets(state *gasBudgetState) {
+	state.mux.Lock()
+	budgets := make(map[string]int64, len(state.budgets))
+	for msgType, limit := range state.budgets {
+		budgets[msgType] = limit
+	}
+	state.mux.Unlock()
+	overruns := CheckGasBudgets(budgets, GlobalReporter.txsOf(t.origin.Name()))
+	if len(overruns) == 0 {
+		return
+	}
+	lines := []string{}
+	for _, overrun := range overruns {
+		lines = append(lines, overrun.String())
+	}
+	sort.Strings(lines)
+	requiredLevel := log.ErrorLevel
+	cause := fmt.Sprintf("%d transactions used more gas than budget", len(overruns))
+	nT := t.WithFields(Fields{
+		"overrun_count": len(overruns),
+		"error_from":    "ExpectGasAtMost validation failure",
+	})
+	GlobalReporter.recordFailure(t.origin.Name(), cause, Fields(nT.fields))
+	text := fmt.Sprintf("%s msg=%s\n%s", nT.FormatFields(requiredLevel), cause, strings.Join(lines, "\n"))
+	t.origin.Error(colorize(requiredLevel, text))
+}
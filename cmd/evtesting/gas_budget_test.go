@@ -0,0 +1,34 @@
+package evtesting
+
+import (
+	"testing"
+)
+
+func TestExpectGasAtMost(originT *testing.T) {
+	t := NewT(originT)
+
+	t.Run("within budget", func(t *T) {
+		t.ExpectGasAtMost("create_cookbook", 60000)
+		t.Run("step", func(t *T) {
+			t.RecordTx(TxRecord{TxHash: "tx1", Msgs: []string{"create_cookbook"}})
+			t.RecordTx(TxRecord{TxHash: "tx1", Height: 10, GasUsed: 55000})
+		})
+	})
+	txs := GlobalReporter.txsOf("TestExpectGasAtMost/within_budget")
+	t.MustTrue(len(txs) == 1 && txs[0].GasUsed == 55000, "gas used of subtest transactions should be recorded")
+
+	budgets := map[string]int64{"create_cookbook": 60000, "create_recipe": 80000}
+	overruns := CheckGasBudgets(budgets, []TxRecord{
+		{TxHash: "within", Msgs: []string{"create_cookbook"}, Height: 1, GasUsed: 60000},
+		{TxHash: "over", Msgs: []string{"create_recipe"}, Height: 1, GasUsed: 80001},
+		{TxHash: "multi", Msgs: []string{"create_cookbook", "create_recipe"}, Height: 1, GasUsed: 140000},
+		{TxHash: "multi_over", Msgs: []string{"create_recipe", "create_recipe"}, Height: 1, GasUsed: 160001},
+		{TxHash: "no_budget", Msgs: []string{"create_cookbook", "send_items"}, Height: 1, GasUsed: 900000},
+		{TxHash: "pending", Msgs: []string{"create_recipe"}, GasUsed: 900000},
+	})
+	t.WithFields(Fields{
+		"overruns": overruns,
+	}).MustTrue(len(overruns) == 2 && overruns[0].TxHash == "over" && overruns[1].TxHash == "multi_over",
+		"transactions using more gas than sum of msg budgets should overrun")
+	t.MustTrue(overruns[1].Budget == 160000, "budget of transaction should be sum of its msg budgets")
+}
@@ -0,0 +1,370 @@
+package evtesting
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StatusNotRun is the status of a test in matrix report which did not run against a target
+const StatusNotRun = "not_run"
+
+// matrixTargetNameRegexp matches target names which are used as file names of target results
+var matrixTargetNameRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// MatrixTarget is a struct to describe a chain the same suite runs against in matrix mode e.g. devnet of a node version
+type MatrixTarget struct {
+	Name string `json:"name"`
+	// Args are test flags selecting the chain e.g. ["-chain-profile=devnet", "-node=tcp://v2.devnet.example.com:26657"]
+	Args []string `json:"args"`
+}
+
+// LoadMatrixTargets is a function to read json array of matrix targets from file
+func LoadMatrixTargets(file string) ([]MatrixTarget, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var targets []MatrixTarget
+	if err := json.Unmarshal(bz, &targets); err != nil {
+		return nil, fmt.Errorf("error parsing matrix targets %s: %w", file, err)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("matrix targets %s should have a target", file)
+	}
+	names := map[string]bool{}
+	for _, target := range targets {
+		if !matrixTargetNameRegexp.MatchString(target.Name) {
+			return nil, fmt.Errorf("matrix target name %q should be letters, digits, dots, dashes or underscores", target.Name)
+		}
+		if names[target.Name] {
+			return nil, fmt.Errorf("matrix target %s is duplicated", target.Name)
+		}
+		names[target.Name] = true
+	}
+	return targets, nil
+}
+
+// StripFlagArgs is a function to remove flags of names from command line args e.g. to run the test binary again
+// without matrix flags, values of non-boolean flags registered on flag.CommandLine are removed with them.
+func StripFlagArgs(args []string, names ...string) []string {
+	strip := map[string]bool{}
+	for _, name := range names {
+		strip[name] = true
+	}
+	stripped := []string{}
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		if arg == "--" || !strings.HasPrefix(arg, "-") {
+			stripped = append(stripped, arg)
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		hasValue := strings.Contains(name, "=")
+		if hasValue {
+			name = name[:strings.Index(name, "=")]
+		}
+		if !strip[name] {
+			stripped = append(stripped, arg)
+			continue
+		}
+		if !hasValue && !isBoolFlag(name) && idx+1 < len(args) {
+			idx++
+		}
+	}
+	return stripped
+}
+
+// isBoolFlag is a function to check if flag of name is registered as boolean flag which takes no value argument
+func isBoolFlag(name string) bool {
+	f := flag.Lookup(name)
+	if f == nil {
+		return false
+	}
+	boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && boolFlag.IsBoolFlag()
+}
+
+// MatrixOptions is a struct to configure matrix runs
+type MatrixOptions struct {
+	Targets []MatrixTarget
+	// Parallel runs targets at once, targets sharing a keyring directory should run sequentially
+	Parallel bool
+	// Dir is the directory result json file and log of each target are written into
+	Dir string
+	// Output gets a line when a target starts and finishes, nothing is written when it's nil
+	Output io.Writer
+}
+
+// MatrixRun is a struct to describe the run of the suite against a target
+type MatrixRun struct {
+	Target   string        `json:"target"`
+	ExitCode int           `json:"exit_code"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+	// NodeVersion is the node version of chain fingerprints recorded by tests of the target
+	NodeVersion string        `json:"node_version,omitempty"`
+	ResultFile  string        `json:"result_file"`
+	LogFile     string        `json:"log_file"`
+	Summary     ReportSummary `json:"summary"`
+}
+
+// MatrixTest is a struct to describe results of a test by target
+type MatrixTest struct {
+	Name    string                `json:"name"`
+	Results map[string]TestResult `json:"results"`
+}
+
+// Status is a function to get status of the test against target, StatusNotRun when it did not run
+func (mt MatrixTest) Status(target string) string {
+	result, ok := mt.Results[target]
+	if !ok {
+		return StatusNotRun
+	}
+	return result.Status
+}
+
+// MatrixReport is a struct to describe combined results of matrix runs
+type MatrixReport struct {
+	Targets []string     `json:"targets"`
+	Runs    []MatrixRun  `json:"runs"`
+	Tests   []MatrixTest `json:"tests"`
+}
+
+// ReadResultFile is a function to read finished test results from json lines file of JSONFileResultSink
+// Results of a run which crashed before suite end are read as well.
+func ReadResultFile(filePath string) ([]TestResult, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	results := []TestResult{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var event resultEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return results, fmt.Errorf("error decoding result line of %s: %w", filePath, err)
+		}
+		if event.Event == resultEventTestEnd && event.Result != nil {
+			results = append(results, *event.Result)
+		}
+	}
+	return results, scanner.Err()
+}
+
+// NewMatrixReport is a function to combine runs with test results of each target by test name
+func NewMatrixReport(runs []MatrixRun, results map[string][]TestResult) MatrixReport {
+	report := MatrixReport{Targets: []string{}, Runs: runs, Tests: []MatrixTest{}}
+	byName := map[string]*MatrixTest{}
+	order := []string{}
+	for _, run := range runs {
+		report.Targets = append(report.Targets, run.Target)
+		for _, result := range results[run.Target] {
+			test, ok := byName[result.Name]
+			if !ok {
+				test = &MatrixTest{Name: result.Name, Results: map[string]TestResult{}}
+				byName[result.Name] = test
+				order = append(order, result.Name)
+			}
+			test.Results[run.Target] = result
+		}
+	}
+	sort.Strings(order)
+	for _, name := range order {
+		report.Tests = append(report.Tests, *byName[name])
+	}
+	return report
+}
+
+// Differences is a function to get tests whose statuses are different between targets
+func (r MatrixReport) Differences() []MatrixTest {
+	diffs := []MatrixTest{}
+	for _, test := range r.Tests {
+		for _, target := range r.Targets[1:] {
+			if test.Status(target) != test.Status(r.Targets[0]) {
+				diffs = append(diffs, test)
+				break
+			}
+		}
+	}
+	return diffs
+}
+
+// Failed is a function to check if a target failed to run or had failed tests
+func (r MatrixReport) Failed() bool {
+	for _, run := range r.Runs {
+		if run.ExitCode != 0 || len(run.Error) > 0 || run.Summary.Failed > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteMarkdown is a function to write summary of each target and tests behaving differently between targets
+func (r MatrixReport) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("# Matrix report\n\n| target | node version | exit code | passed | failed | skipped | duration | log |\n|---|---|---|---|---|---|---|---|\n")
+	for _, run := range r.Runs {
+		exit := fmt.Sprintf("%d", run.ExitCode)
+		if len(run.Error) > 0 {
+			exit += " " + markdownCell(run.Error)
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %d | %d | %d | %s | %s |\n", run.Target, markdownCell(run.NodeVersion), exit,
+			run.Summary.Passed, run.Summary.Failed, run.Summary.Skipped, run.Duration.Round(time.Second), markdownCell(run.LogFile))
+	}
+	diffs := r.Differences()
+	fmt.Fprintf(&sb, "\n## Differences\n\n%d of %d tests behave differently between targets\n", len(diffs), len(r.Tests))
+	if len(diffs) > 0 {
+		sb.WriteString("\n| test | " + strings.Join(r.Targets, " | ") + " |\n|---|" + strings.Repeat("---|", len(r.Targets)) + "\n")
+		for _, test := range diffs {
+			cells := []string{}
+			for _, target := range r.Targets {
+				cell := test.Status(target)
+				if cause := test.Results[target].FailureCause; len(cause) > 0 {
+					cell += ": " + cause
+				}
+				cells = append(cells, markdownCell(cell))
+			}
+			fmt.Fprintf(&sb, "| %s | %s |\n", markdownCell(test.Name), strings.Join(cells, " | "))
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteFile is a function to write matrix report, markdown is chosen by .md extension and json otherwise
+func (r MatrixReport) WriteFile(filePath string) error {
+	if reportFormat(filePath) != reportFormatMarkdown {
+		bz, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filePath, bz, 0644)
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	err = r.WriteMarkdown(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// runMatrixTarget is a function to run command with args of target writing its results to result json file
+func runMatrixTarget(ctx context.Context, command string, args []string, target MatrixTarget, dir string) (MatrixRun, []TestResult) {
+	run := MatrixRun{
+		Target:     target.Name,
+		ResultFile: filepath.Join(dir, target.Name+".jsonl"),
+		LogFile:    filepath.Join(dir, target.Name+".log"),
+	}
+	started := time.Now()
+	defer func() {
+		run.Duration = time.Since(started)
+	}()
+	logFile, err := os.Create(run.LogFile)
+	if err != nil {
+		run.ExitCode = -1
+		run.Error = err.Error()
+		return run, nil
+	}
+	defer logFile.Close()
+	cmdArgs := append(append(append([]string{}, args...), target.Args...), "-result-json-file="+run.ResultFile)
+	cmd := exec.CommandContext(ctx, command, cmdArgs...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			run.ExitCode = exitErr.ExitCode()
+		} else {
+			run.ExitCode = -1
+			run.Error = err.Error()
+		}
+	}
+	results, err := ReadResultFile(run.ResultFile)
+	if err != nil && len(run.Error) == 0 {
+		run.Error = "error reading results: " + err.Error()
+	}
+	for _, result := range results {
+		switch result.Status {
+		case StatusPass:
+			run.Summary.Passed++
+		case StatusFail:
+			run.Summary.Failed++
+		case StatusSkip:
+			run.Summary.Skipped++
+		}
+		if len(run.NodeVersion) == 0 && len(result.Fingerprints) > 0 {
+			run.NodeVersion = result.Fingerprints[0].NodeVersion
+		}
+	}
+	return run, results
+}
+
+// RunMatrix is a function to run command e.g. test binary with args against each target of options and combine results
+// Results of each target are collected by -result-json-file flag appended to args, so the command should register it.
+func RunMatrix(ctx context.Context, command string, args []string, opts MatrixOptions) (MatrixReport, error) {
+	if len(opts.Targets) == 0 {
+		return MatrixReport{}, errors.New("matrix should have a target")
+	}
+	if len(opts.Dir) == 0 {
+		opts.Dir = "matrix"
+	}
+	if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+		return MatrixReport{}, err
+	}
+	var mux sync.Mutex
+	progress := func(format string, args ...interface{}) {
+		if opts.Output == nil {
+			return
+		}
+		mux.Lock()
+		defer mux.Unlock()
+		fmt.Fprintf(opts.Output, format+"\n", args...)
+	}
+	runs := make([]MatrixRun, len(opts.Targets))
+	results := map[string][]TestResult{}
+	runTarget := func(idx int) {
+		target := opts.Targets[idx]
+		progress("matrix target %s started", target.Name)
+		run, targetResults := runMatrixTarget(ctx, command, args, target, opts.Dir)
+		progress("matrix target %s finished with exit code %d, passed=%d failed=%d skipped=%d", target.Name, run.ExitCode, run.Summary.Passed, run.Summary.Failed, run.Summary.Skipped)
+		mux.Lock()
+		defer mux.Unlock()
+		runs[idx] = run
+		results[target.Name] = targetResults
+	}
+	if opts.Parallel {
+		var wg sync.WaitGroup
+		for idx := range opts.Targets {
+			wg.Add(1)
+			go func(idx int) {
+				defer wg.Done()
+				runTarget(idx)
+			}(idx)
+		}
+		wg.Wait()
+	} else {
+		for idx := range opts.Targets {
+			runTarget(idx)
+		}
+	}
+	return NewMatrixReport(runs, results), ctx.Err()
+}
@@ -0,0 +1,83 @@
+package evtesting
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStripFlagArgs(originT *testing.T) {
+	t := NewT(originT)
+
+	args := []string{"-test.v=true", "-matrix", "targets.json", "-matrix-parallel=true", "-seed", "5", "--report-file=report.md", "-verbosity=quiet"}
+	stripped := StripFlagArgs(args, "matrix", "matrix-parallel", "report-file")
+	t.WithFields(Fields{
+		"stripped": stripped,
+	}).MustTrue(strings.Join(stripped, " ") == "-test.v=true -seed 5 -verbosity=quiet", "matrix flags and their values should be removed")
+}
+
+func TestMatrixReport(originT *testing.T) {
+	t := NewT(originT)
+
+	runs := []MatrixRun{{Target: "v1-devnet"}, {Target: "v2-devnet", ExitCode: 1, NodeVersion: "v2.0.0"}}
+	report := NewMatrixReport(runs, map[string][]TestResult{
+		"v1-devnet": {
+			{Name: "TestFixturesViaCLI/trade.json", Status: StatusPass},
+			{Name: "TestFixturesViaCLI/recipe.json", Status: StatusPass},
+			{Name: "TestFixturesViaCLI/legacy.json", Status: StatusPass},
+		},
+		"v2-devnet": {
+			{Name: "TestFixturesViaCLI/trade.json", Status: StatusPass},
+			{Name: "TestFixturesViaCLI/recipe.json", Status: StatusFail, FailureCause: "item output is different"},
+		},
+	})
+	diffs := report.Differences()
+	t.WithFields(Fields{
+		"diffs": diffs,
+	}).MustTrue(len(report.Tests) == 3 && len(diffs) == 2, "tests with different statuses should be differences")
+	t.MustTrue(diffs[0].Name == "TestFixturesViaCLI/legacy.json" && diffs[0].Status("v2-devnet") == StatusNotRun, "missing test should not be run")
+	t.MustTrue(report.Failed(), "failed target should fail the matrix")
+
+	var sb strings.Builder
+	t.MustNil(report.WriteMarkdown(&sb), "error writing matrix report")
+	t.MustContain(sb.String(), "| v2-devnet | v2.0.0 | 1 | 0 | 0 | 0 |")
+	t.MustContain(sb.String(), "2 of 3 tests behave differently between targets")
+	t.MustContain(sb.String(), "| TestFixturesViaCLI/recipe.json | pass | fail: item output is different |")
+}
+
+func TestRunMatrix(originT *testing.T) {
+	t := NewT(originT)
+
+	// fake suite writes a result line of status of its target arg and fails when it's not pass
+	script := `for a; do case $a in -result-json-file=*) f=${a#-result-json-file=};; esac; done
+echo '{"event":"test_end","result":{"name":"TestSuite","status":"'$1'"}}' > $f
+[ "$1" = pass ]`
+	dir := originT.TempDir()
+	targets := filepath.Join(dir, "targets.json")
+	err := ioutil.WriteFile(targets, []byte(`[{"name": "local", "args": ["pass"]}, {"name": "devnet", "args": ["fail"]}]`), 0644)
+	t.MustNil(err, "error writing matrix targets")
+	loaded, err := LoadMatrixTargets(targets)
+	t.MustNil(err, "error loading matrix targets")
+
+	var output strings.Builder
+	report, err := RunMatrix(context.Background(), "sh", []string{"-c", script, "sh"}, MatrixOptions{
+		Targets:  loaded,
+		Parallel: true,
+		Dir:      filepath.Join(dir, "matrix"),
+		Output:   &output,
+	})
+	t.MustNil(err, "error running matrix")
+	t.WithFields(Fields{
+		"report": report,
+		"output": output.String(),
+	}).MustTrue(report.Runs[0].ExitCode == 0 && report.Runs[1].ExitCode == 1 && report.Runs[1].Summary.Failed == 1, "runs should keep target order with exit codes and results")
+	t.MustTrue(len(report.Differences()) == 1, "status of the suite should differ between targets")
+	t.MustContain(output.String(), "matrix target devnet finished with exit code 1")
+
+	err = ioutil.WriteFile(targets, []byte(`[{"name": "local"}, {"name": "local"}]`), 0644)
+	t.MustNil(err, "error writing matrix targets")
+	_, err = LoadMatrixTargets(targets)
+	t.MustTrue(err != nil, "duplicated target should be refused")
+}
@@ -0,0 +1,310 @@
+package evtesting
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// report file formats chosen by file extension
+const (
+	reportFormatJSON     = "json"
+	reportFormatMarkdown = "markdown"
+	reportFormatHTML     = "html"
+)
+
+// ReportOptions is a struct to configure rich test reports
+type ReportOptions struct {
+	Title string
+	// ExplorerTxURL is url of explorer transaction page, "%s" is replaced by txhash or txhash is appended when it's missing
+	ExplorerTxURL string
+	// CaptureState enables capturing balances and inventories checked by tests
+	CaptureState bool
+	// ArtifactsDir is the directory files attached by tests are written into, a directory per test
+	// RunWithReport sets it next to the report file when it's empty
+	ArtifactsDir string
+	// DisableFailureSnapshot disables capturing chain context snapshot on fatal failures
+	DisableFailureSnapshot bool
+	// FailureSnapshotTxs is the number of latest transactions of each involved account in failure snapshot, 5 when it's 0
+	FailureSnapshotTxs int
+	// reportDir is directory of report file which artifact links are relative to
+	reportDir string
+}
+
+// ReportOpts is a variable to have options of rich test reports
+var ReportOpts = ReportOptions{
+	Title: "Test report",
+}
+
+// RecordTx is a function to add transaction sent by the test to its report
+func (t *T) RecordTx(record TxRecord) {
+	if t.useLogPkg {
+		return
+	}
+	GlobalReporter.recordTx(t.origin.Name(), record)
+}
+
+// CaptureState is a function to add account state checked by the test to its report
+func (t *T) CaptureState(capture StateCapture) {
+	if t.useLogPkg || !ReportOpts.CaptureState {
+		return
+	}
+	GlobalReporter.captureState(t.origin.Name(), capture)
+}
+
+// RecordFingerprint is a function to add chain fingerprint to report of the test
+func (t *T) RecordFingerprint(fingerprint ChainFingerprint) {
+	if t.useLogPkg {
+		return
+	}
+	GlobalReporter.recordFingerprint(t.origin.Name(), fingerprint)
+}
+
+// reportFormat is a function to get report format of file path
+func reportFormat(filePath string) string {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".html", ".htm":
+		return reportFormatHTML
+	case ".md", ".markdown":
+		return reportFormatMarkdown
+	}
+	return reportFormatJSON
+}
+
+// WriteReportFile is a function to write report file, html and markdown are chosen by .html and .md extensions and json otherwise
+func (r *Reporter) WriteReportFile(filePath string) error {
+	format := reportFormat(filePath)
+	if format == reportFormatJSON {
+		return r.WriteJSONFile(filePath)
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	opts := ReportOpts
+	opts.reportDir = filepath.Dir(filePath)
+	if format == reportFormatHTML {
+		err = r.WriteHTML(file, opts)
+	} else {
+		err = r.WriteMarkdown(file, opts)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// reportTx is a transaction row of rich report
+type reportTx struct {
+	TxRecord
+	Short string
+	Link  string
+}
+
+// reportStep is a test which has no subtests e.g. a fixture step
+type reportStep struct {
+	Name         string
+	Status       string
+	Duration     time.Duration
+	FailureCause string
+	Txs          []reportTx
+	States       []StateCapture
+	Artifacts    []reportArtifact
+}
+
+// reportArtifact is an artifact of rich report linked relative to report file
+type reportArtifact struct {
+	Artifact
+	Link string
+}
+
+// reportGroup is a parent test having steps e.g. a fixture scenario
+type reportGroup struct {
+	Name         string
+	Status       string
+	Fingerprints []ChainFingerprint
+	Steps        []reportStep
+}
+
+// reportView is a struct to render rich report
+type reportView struct {
+	Title       string
+	GeneratedAt time.Time
+	Summary     ReportSummary
+	Groups      []reportGroup
+}
+
+// explorerTxLink is a function to get explorer link of transaction, empty without explorer url
+func explorerTxLink(explorerTxURL, txhash string) string {
+	if len(explorerTxURL) == 0 {
+		return ""
+	}
+	if strings.Contains(explorerTxURL, "%s") {
+		return strings.Replace(explorerTxURL, "%s", txhash, 1)
+	}
+	return strings.TrimSuffix(explorerTxURL, "/") + "/" + txhash
+}
+
+func shortTxHash(txhash string) string {
+	if len(txhash) > 12 {
+		return txhash[:12]
+	}
+	return txhash
+}
+
+// newReportView is a function to group results of tests without subtests by their parent tests
+func (r *Reporter) newReportView(opts ReportOptions) reportView {
+	summary := r.Summary()
+	view := reportView{
+		Title:       opts.Title,
+		GeneratedAt: time.Now(),
+		Summary:     summary,
+	}
+	statuses := map[string]string{}
+	fingerprints := map[string][]ChainFingerprint{}
+	hasSubtests := map[string]bool{}
+	for _, result := range summary.Results {
+		statuses[result.Name] = result.Status
+		fingerprints[result.Name] = result.Fingerprints
+		if idx := strings.LastIndex(result.Name, "/"); idx >= 0 {
+			hasSubtests[result.Name[:idx]] = true
+		}
+	}
+	groupIdx := map[string]int{}
+	for _, result := range summary.Results {
+		if hasSubtests[result.Name] {
+			continue
+		}
+		parent, name := "", result.Name
+		if idx := strings.LastIndex(result.Name, "/"); idx >= 0 {
+			parent, name = result.Name[:idx], result.Name[idx+1:]
+		}
+		if _, ok := groupIdx[parent]; !ok {
+			groupIdx[parent] = len(view.Groups)
+			view.Groups = append(view.Groups, reportGroup{Name: parent, Status: statuses[parent], Fingerprints: fingerprints[parent]})
+		}
+		step := reportStep{
+			Name:         name,
+			Status:       result.Status,
+			Duration:     result.Duration.Round(time.Millisecond),
+			FailureCause: result.FailureCause,
+			States:       result.States,
+		}
+		for _, tx := range result.Txs {
+			step.Txs = append(step.Txs, reportTx{
+				TxRecord: tx,
+				Short:    shortTxHash(tx.TxHash),
+				Link:     explorerTxLink(opts.ExplorerTxURL, tx.TxHash),
+			})
+		}
+		for _, artifact := range result.Artifacts {
+			step.Artifacts = append(step.Artifacts, reportArtifact{
+				Artifact: artifact,
+				Link:     artifactLink(opts.reportDir, artifact),
+			})
+		}
+		group := &view.Groups[groupIdx[parent]]
+		group.Steps = append(group.Steps, step)
+	}
+	return view
+}
+
+// markdownCell is a function to make text safe in a markdown table cell
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// WriteMarkdown is a function to write report with steps, transactions and captured state of tests as markdown
+func (r *Reporter) WriteMarkdown(w io.Writer, opts ReportOptions) error {
+	view := r.newReportView(opts)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", view.Title)
+	fmt.Fprintf(&sb, "generated at %s, passed %d, failed %d, skipped %d\n", view.GeneratedAt.Format(time.RFC3339), view.Summary.Passed, view.Summary.Failed, view.Summary.Skipped)
+	if view.Summary.FirstFailure != nil {
+		fmt.Fprintf(&sb, "\nfirst failure: `%s` %s\n", view.Summary.FirstFailure.Name, markdownCell(view.Summary.FirstFailure.FailureCause))
+	}
+	for _, group := range view.Groups {
+		name := group.Name
+		if len(name) == 0 {
+			name = "top level tests"
+		}
+		fmt.Fprintf(&sb, "\n## %s", name)
+		if len(group.Status) > 0 {
+			fmt.Fprintf(&sb, " (%s)", group.Status)
+		}
+		sb.WriteString("\n")
+		for _, fp := range group.Fingerprints {
+			fmt.Fprintf(&sb, "\n- chain at %s: height %d, chain id `%s`, node version `%s`, app hash `%s`", fp.Stage, fp.Height, fp.ChainID, fp.NodeVersion, fp.AppHash)
+		}
+		if len(group.Fingerprints) > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n| step | status | duration | transactions | failure |\n|---|---|---|---|---|\n")
+		for _, step := range group.Steps {
+			txs := []string{}
+			for _, tx := range step.Txs {
+				txText := "`" + tx.Short + "`"
+				if len(tx.Link) > 0 {
+					txText = fmt.Sprintf("[%s](%s)", tx.Short, tx.Link)
+				}
+				if tx.Height > 0 {
+					txText += fmt.Sprintf(" @%d", tx.Height)
+				}
+				if tx.Code != 0 {
+					txText += fmt.Sprintf(" code %d", tx.Code)
+				}
+				if tx.GasUsed > 0 {
+					txText += fmt.Sprintf(" gas %d", tx.GasUsed)
+				}
+				txs = append(txs, txText)
+			}
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n", markdownCell(step.Name), step.Status, step.Duration, strings.Join(txs, "<br>"), markdownCell(step.FailureCause))
+		}
+		for _, step := range group.Steps {
+			for _, state := range step.States {
+				fmt.Fprintf(&sb, "\n- %s: `%s` (%s)", markdownCell(step.Name), state.Owner, state.Address)
+				if state.Height > 0 {
+					fmt.Fprintf(&sb, " at height %d", state.Height)
+				}
+				fmt.Fprintf(&sb, "\n  - balances: %s\n  - items: %s\n", markdownCell(state.Balances), markdownCell(strings.Join(state.Items, ", ")))
+			}
+			for _, artifact := range step.Artifacts {
+				fmt.Fprintf(&sb, "\n- %s: artifact [%s](%s) (%d bytes)\n", markdownCell(step.Name), markdownCell(artifact.Name), artifact.Link, artifact.Size)
+			}
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Title}}</title>
+<style>table{border-collapse:collapse}td,th{border:1px solid #ccc;padding:2px 6px;vertical-align:top}.fail{color:#c00}.skip{color:#888}</style></head>
+<body>
+<h1>{{.Title}}</h1>
+<p>generated at {{.GeneratedAt.Format "2006-01-02 15:04:05"}}, passed {{.Summary.Passed}}, failed {{.Summary.Failed}}, skipped {{.Summary.Skipped}}</p>
+{{with .Summary.FirstFailure}}<p class="fail">first failure: {{.Name}} {{.FailureCause}}</p>{{end}}
+{{range .Groups}}<h2>{{if .Name}}{{.Name}}{{else}}top level tests{{end}}{{if .Status}} <span class="{{.Status}}">({{.Status}})</span>{{end}}</h2>
+{{range .Fingerprints}}<p>chain at {{.Stage}}: height {{.Height}}, chain id <code>{{.ChainID}}</code>, node version <code>{{.NodeVersion}}</code>, app hash <code>{{.AppHash}}</code></p>
+{{end}}<table>
+<tr><th>step</th><th>status</th><th>duration</th><th>transactions</th><th>captured state</th><th>artifacts</th><th>failure</th></tr>
+{{range .Steps}}<tr class="{{.Status}}"><td>{{.Name}}</td><td>{{.Status}}</td><td>{{.Duration}}</td>
+<td>{{range .Txs}}<div>{{if .Link}}<a href="{{.Link}}">{{.Short}}</a>{{else}}<code title="{{.TxHash}}">{{.Short}}</code>{{end}}{{if .Height}} @{{.Height}}{{end}}{{if .Code}} code {{.Code}}{{end}}{{if .GasUsed}} gas {{.GasUsed}}{{end}}{{range .Msgs}} {{.}}{{end}}</div>{{end}}</td>
+<td>{{range .States}}<div><b>{{.Owner}}</b> {{.Address}}{{if .Height}} at height {{.Height}}{{end}}<br>balances: {{.Balances}}<br>items: {{range $i, $item := .Items}}{{if $i}}, {{end}}{{$item}}{{end}}</div>{{end}}</td>
+<td>{{range .Artifacts}}<div><a href="{{.Link}}">{{.Name}}</a> ({{.Size}} bytes)</div>{{end}}</td>
+<td>{{.FailureCause}}</td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`))
+
+// WriteHTML is a function to write report with steps, transactions and captured state of tests as html page
+func (r *Reporter) WriteHTML(w io.Writer, opts ReportOptions) error {
+	return reportHTMLTemplate.Execute(w, r.newReportView(opts))
+}
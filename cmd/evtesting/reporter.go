@@ -0,0 +1,371 @@
+package evtesting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// describes the result status of a test
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+// maxSlowestTests is the number of slowest tests shown in summary
+const maxSlowestTests = 10
+
+// TestResult is a struct to manage result of a test
+type TestResult struct {
+	Name         string        `json:"name"`
+	Status       string        `json:"status"`
+	StartedAt    time.Time     `json:"started_at"`
+	Duration     time.Duration `json:"duration"`
+	FailureCause string        `json:"failure_cause,omitempty"`
+	Fields       Fields        `json:"fields,omitempty"`
+	// Txs are transactions sent by the test with block heights they are included in
+	Txs []TxRecord `json:"txs,omitempty"`
+	// States are balances and inventories captured while the test checks them
+	States []StateCapture `json:"states,omitempty"`
+	// Artifacts are files attached by the test e.g. raw cli output to debug failures without rerunning
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+	// Fingerprints are chain states recorded e.g. at start and end of a fixture scenario to reproduce the run
+	Fingerprints []ChainFingerprint `json:"fingerprints,omitempty"`
+}
+
+// TxRecord is a struct to describe a transaction sent by a test, height is 0 until it's included in a block
+type TxRecord struct {
+	TxHash string   `json:"txhash"`
+	Msgs   []string `json:"msgs,omitempty"`
+	Height int64    `json:"height,omitempty"`
+	Code   uint32   `json:"code,omitempty"`
+	// GasUsed is gas used by the transaction, it's known after it's included in a block
+	GasUsed int64 `json:"gas_used,omitempty"`
+}
+
+// StateCapture is a struct to describe balances and items of an account at the time a test checked them
+type StateCapture struct {
+	Owner    string   `json:"owner"`
+	Address  string   `json:"address"`
+	Height   int64    `json:"height,omitempty"`
+	Balances string   `json:"balances"`
+	Items    []string `json:"items,omitempty"`
+}
+
+// ChainFingerprint is a struct to describe chain state at a stage of a test e.g. start or end of a scenario
+type ChainFingerprint struct {
+	Stage       string    `json:"stage"`
+	Height      int64     `json:"height"`
+	ChainID     string    `json:"chain_id"`
+	NodeVersion string    `json:"node_version,omitempty"`
+	AppHash     string    `json:"app_hash"`
+	Time        time.Time `json:"time"`
+}
+
+// ReportSummary is a struct to manage summary of all test results
+type ReportSummary struct {
+	Passed       int          `json:"passed"`
+	Failed       int          `json:"failed"`
+	Skipped      int          `json:"skipped"`
+	FirstFailure *TestResult  `json:"first_failure,omitempty"`
+	Slowest      []TestResult `json:"slowest"`
+	Results      []TestResult `json:"results"`
+}
+
+// Reporter is a struct to collect test results
+type Reporter struct {
+	mux     sync.Mutex
+	results map[string]*TestResult
+	order   []string
+	failSeq []string
+	sinks   []ResultSink
+}
+
+// GlobalReporter is a reporter which collects results of all tests run with T
+var GlobalReporter = NewReporter()
+
+// NewReporter is a function to create an empty reporter
+func NewReporter() *Reporter {
+	return &Reporter{
+		results: make(map[string]*TestResult),
+	}
+}
+
+// track is a function to start tracking a test and record its result when it finishes
+func (r *Reporter) track(origin testing.TB) {
+	name := origin.Name()
+	r.mux.Lock()
+	if _, ok := r.results[name]; ok {
+		r.mux.Unlock()
+		return
+	}
+	result := &TestResult{
+		Name:      name,
+		StartedAt: time.Now(),
+	}
+	r.results[name] = result
+	r.order = append(r.order, name)
+	started := *result
+	r.mux.Unlock()
+	r.notifySinks(func(sink ResultSink) error {
+		return sink.OnTestStart(started)
+	})
+
+	origin.Cleanup(func() {
+		status := StatusPass
+		if origin.Failed() {
+			status = StatusFail
+		} else if origin.Skipped() {
+			status = StatusSkip
+		}
+		r.finish(name, status)
+	})
+}
+
+func (r *Reporter) finish(name, status string) {
+	r.mux.Lock()
+	result, ok := r.results[name]
+	if !ok {
+		r.mux.Unlock()
+		return
+	}
+	result.Status = status
+	result.Duration = time.Since(result.StartedAt)
+	finished := *result
+	r.mux.Unlock()
+	r.notifySinks(func(sink ResultSink) error {
+		return sink.OnTestEnd(finished)
+	})
+}
+
+// AddResult is a function to add finished result e.g. read by ReadResultFile, so that reports are rendered from it later
+// Result of the same test name replaces the previous one, failed result is the first failure when none is added before.
+func (r *Reporter) AddResult(result TestResult) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if _, ok := r.results[result.Name]; !ok {
+		r.order = append(r.order, result.Name)
+	}
+	added := result
+	r.results[result.Name] = &added
+	if result.Status == StatusFail {
+		r.failSeq = append(r.failSeq, result.Name)
+	}
+}
+
+// recordFailure is a function to keep first failure cause of a test
+func (r *Reporter) recordFailure(name, cause string, fields Fields) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	result, ok := r.results[name]
+	if !ok || len(result.FailureCause) > 0 {
+		return
+	}
+	result.FailureCause = Redact(cause)
+	result.Fields = Fields{}
+	for k, v := range fields {
+		result.Fields[k] = Redact(fmt.Sprintf("%+v", v))
+	}
+	r.failSeq = append(r.failSeq, name)
+}
+
+// recordTx is a function to add transaction of a test, record of the same txhash is updated with known fields
+func (r *Reporter) recordTx(name string, record TxRecord) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	result, ok := r.results[name]
+	if !ok || len(record.TxHash) == 0 {
+		return
+	}
+	for idx := range result.Txs {
+		tx := &result.Txs[idx]
+		if tx.TxHash != record.TxHash {
+			continue
+		}
+		if len(record.Msgs) > 0 {
+			tx.Msgs = record.Msgs
+		}
+		if record.Height > 0 {
+			tx.Height = record.Height
+			tx.Code = record.Code
+			tx.GasUsed = record.GasUsed
+		}
+		return
+	}
+	result.Txs = append(result.Txs, record)
+}
+
+// captureState is a function to add account state captured by a test
+func (r *Reporter) captureState(name string, capture StateCapture) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if result, ok := r.results[name]; ok {
+		result.States = append(result.States, capture)
+	}
+}
+
+// recordFingerprint is a function to add chain fingerprint recorded by a test
+func (r *Reporter) recordFingerprint(name string, fingerprint ChainFingerprint) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if result, ok := r.results[name]; ok {
+		result.Fingerprints = append(result.Fingerprints, fingerprint)
+	}
+}
+
+// timeline is a function to get start time and txhashes of a test, zero time when it's not tracked
+func (r *Reporter) timeline(name string) (time.Time, []string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	txhashes := []string{}
+	result, ok := r.results[name]
+	if !ok {
+		return time.Time{}, txhashes
+	}
+	for _, tx := range result.Txs {
+		txhashes = append(txhashes, tx.TxHash)
+	}
+	return result.StartedAt, txhashes
+}
+
+// txsOf is a function to get transactions of a test and its subtests
+func (r *Reporter) txsOf(name string) []TxRecord {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	txs := []TxRecord{}
+	for _, testName := range r.order {
+		if testName == name || strings.HasPrefix(testName, name+"/") {
+			txs = append(txs, r.results[testName].Txs...)
+		}
+	}
+	return txs
+}
+
+// failureCause is a function to get first failure cause of a test
+func (r *Reporter) failureCause(name string) string {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if result, ok := r.results[name]; ok {
+		return result.FailureCause
+	}
+	return ""
+}
+
+// FailureCause is a function to get first failure cause of t, empty if it has not failed
+func (t *T) FailureCause() string {
+	return GlobalReporter.failureCause(t.origin.Name())
+}
+
+// Summary is a function to summarize collected test results
+func (r *Reporter) Summary() ReportSummary {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	summary := ReportSummary{
+		Slowest: []TestResult{},
+		Results: []TestResult{},
+	}
+	for _, name := range r.order {
+		result := *r.results[name]
+		result.Txs = append([]TxRecord{}, result.Txs...)
+		result.States = append([]StateCapture{}, result.States...)
+		result.Artifacts = append([]Artifact{}, result.Artifacts...)
+		result.Fingerprints = append([]ChainFingerprint{}, result.Fingerprints...)
+		switch result.Status {
+		case StatusPass:
+			summary.Passed++
+		case StatusFail:
+			summary.Failed++
+		case StatusSkip:
+			summary.Skipped++
+		}
+		summary.Results = append(summary.Results, result)
+	}
+	for _, name := range r.failSeq {
+		if r.results[name].Status == StatusFail {
+			firstFailure := *r.results[name]
+			summary.FirstFailure = &firstFailure
+			break
+		}
+	}
+	summary.Slowest = append(summary.Slowest, summary.Results...)
+	sort.SliceStable(summary.Slowest, func(i, j int) bool {
+		return summary.Slowest[i].Duration > summary.Slowest[j].Duration
+	})
+	if len(summary.Slowest) > maxSlowestTests {
+		summary.Slowest = summary.Slowest[:maxSlowestTests]
+	}
+	return summary
+}
+
+// WriteText is a function to write human readable summary
+func (r *Reporter) WriteText(w io.Writer) error {
+	return writeSummaryText(w, r.Summary())
+}
+
+// writeSummaryText is a function to write counts, first failure and slowest tests of summary
+func writeSummaryText(w io.Writer, summary ReportSummary) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "test summary: passed=%d failed=%d skipped=%d\n", summary.Passed, summary.Failed, summary.Skipped)
+	if summary.FirstFailure != nil {
+		fmt.Fprintf(&sb, "first failure: %s cause=%s\n", summary.FirstFailure.Name, summary.FirstFailure.FailureCause)
+	}
+	sb.WriteString("slowest tests:\n")
+	for _, result := range summary.Slowest {
+		fmt.Fprintf(&sb, "  %s %s %s\n", result.Duration.Round(time.Millisecond), result.Status, result.Name)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteJSONFile is a function to write summary as json file
+func (r *Reporter) WriteJSONFile(filePath string) error {
+	output, err := json.MarshalIndent(r.Summary(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, output, 0644)
+}
+
+// RunWithReport is a function to run tests and emit summary of collected results on completion
+// report file is written only when reportPath is set, its format is chosen by extension as WriteReportFile does
+func RunWithReport(m *testing.M, reportPath string) int {
+	StartReport(reportPath)
+	code := m.Run()
+	FinishReport(reportPath)
+	return code
+}
+
+// StartReport is a function to set report options for report file of reportPath before tests run
+// It's called by RunWithReport, runners which don't have testing.M e.g. cmd/fixturetest call it with FinishReport.
+func StartReport(reportPath string) {
+	if reportFormat(reportPath) != reportFormatJSON {
+		// capturing balances and inventories costs queries, so it's done only for reports showing them
+		ReportOpts.CaptureState = true
+	}
+	if len(ReportOpts.ArtifactsDir) == 0 && len(reportPath) > 0 {
+		ReportOpts.ArtifactsDir = reportArtifactsDir(reportPath)
+	}
+}
+
+// FinishReport is a function to emit summary of collected results to stdout, result sinks and report file of reportPath
+func FinishReport(reportPath string) {
+	if err := GlobalReporter.WriteText(os.Stdout); err != nil {
+		fmt.Println("error writing test summary", err)
+	}
+	if err := GlobalReporter.EndSuite(); err != nil {
+		fmt.Println("error sending results to result sinks", err)
+	}
+	if len(reportPath) > 0 {
+		if err := GlobalReporter.WriteReportFile(reportPath); err != nil {
+			fmt.Println("error writing test report file", err)
+		}
+	}
+}
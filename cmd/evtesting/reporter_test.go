@@ -0,0 +1,97 @@
+package evtesting
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReporter(originT *testing.T) {
+	t := NewT(originT)
+
+	t.Run("passing", func(t *T) {})
+	t.Run("skipped", func(t *T) {
+		t.Skip("skip for reporter test")
+	})
+
+	summary := GlobalReporter.Summary()
+	results := map[string]TestResult{}
+	for _, result := range summary.Results {
+		results[result.Name] = result
+	}
+	t.MustTrue(results["TestReporter/passing"].Status == StatusPass, "passing test should be reported as pass")
+	t.MustTrue(results["TestReporter/skipped"].Status == StatusSkip, "skipped test should be reported as skip")
+	t.MustTrue(results["TestReporter"].Status == "", "running test should not have status yet")
+
+	reporter := NewReporter()
+	reporter.track(originT)
+	reporter.recordFailure(originT.Name(), "first cause", Fields{"key": 1})
+	reporter.recordFailure(originT.Name(), "second cause", Fields{})
+	reporter.finish(originT.Name(), StatusFail)
+	summary = reporter.Summary()
+	t.MustTrue(summary.Failed == 1, "failed test should be counted")
+	t.MustTrue(summary.FirstFailure != nil && summary.FirstFailure.FailureCause == "first cause", "first failure cause should be kept")
+
+	var sb strings.Builder
+	err := reporter.WriteText(&sb)
+	t.MustNil(err, "error writing text summary")
+	t.MustContain(sb.String(), "first failure: TestReporter cause=first cause")
+}
+
+func TestReporterAddResult(originT *testing.T) {
+	t := NewT(originT)
+
+	reporter := NewReporter()
+	reporter.AddResult(TestResult{Name: "suite/passing", Status: StatusPass})
+	reporter.AddResult(TestResult{Name: "suite/failing", Status: StatusFail, FailureCause: "balance mismatch"})
+	reporter.AddResult(TestResult{Name: "suite/passing", Status: StatusSkip})
+	summary := reporter.Summary()
+	t.MustTrue(len(summary.Results) == 2, "result of the same test should replace previous one")
+	t.MustTrue(summary.Skipped == 1 && summary.Failed == 1 && summary.Passed == 0, "added results should be counted by status")
+	t.MustTrue(summary.FirstFailure != nil && summary.FirstFailure.FailureCause == "balance mismatch", "failed result should be the first failure")
+}
+
+func TestReportRender(originT *testing.T) {
+	t := NewT(originT)
+
+	reporter := NewReporter()
+	reporter.track(originT)
+	originT.Run("trade.json", func(scenarioT *testing.T) {
+		reporter.track(scenarioT)
+		reporter.recordFingerprint(scenarioT.Name(), ChainFingerprint{Stage: "start", Height: 40, ChainID: "pylons-testnet", NodeVersion: "v0.1.0", AppHash: "A1B2"})
+		scenarioT.Run("0_CREATE_TRADE", func(stepT *testing.T) {
+			reporter.track(stepT)
+			reporter.recordTx(stepT.Name(), TxRecord{TxHash: "ABCDEF0123456789", Msgs: []string{"create_trade"}})
+			reporter.recordTx(stepT.Name(), TxRecord{TxHash: "ABCDEF0123456789", Height: 42})
+			reporter.captureState(stepT.Name(), StateCapture{Owner: "account1", Address: "pylo1xyz", Balances: "100pylon", Items: []string{"Knife"}})
+			reporter.attach(stepT.Name(), Artifact{Name: "output.txt", Path: "reports/nightly_artifacts/0_CREATE_TRADE/output.txt", Size: 5})
+		})
+	})
+	summary := reporter.Summary()
+	var step TestResult
+	for _, result := range summary.Results {
+		if strings.HasSuffix(result.Name, "0_CREATE_TRADE") {
+			step = result
+		}
+	}
+	t.MustTrue(len(step.Txs) == 1 && step.Txs[0].Height == 42 && len(step.Txs[0].Msgs) == 1, "tx record should be updated with its height")
+
+	opts := ReportOptions{Title: "nightly", ExplorerTxURL: "https://explorer.example.com/txs/%s", reportDir: "reports"}
+	var md strings.Builder
+	err := reporter.WriteMarkdown(&md, opts)
+	t.MustNil(err, "error writing markdown report")
+	t.MustContain(md.String(), "## TestReportRender/trade.json (pass)")
+	t.MustContain(md.String(), "[ABCDEF012345](https://explorer.example.com/txs/ABCDEF0123456789) @42")
+	t.MustContain(md.String(), "balances: 100pylon")
+	t.MustContain(md.String(), "- chain at start: height 40, chain id `pylons-testnet`, node version `v0.1.0`, app hash `A1B2`")
+	t.MustContain(md.String(), "artifact [output.txt](nightly_artifacts/0_CREATE_TRADE/output.txt) (5 bytes)")
+
+	var html strings.Builder
+	err = reporter.WriteHTML(&html, opts)
+	t.MustNil(err, "error writing html report")
+	t.MustContain(html.String(), `<a href="https://explorer.example.com/txs/ABCDEF0123456789">ABCDEF012345</a> @42`)
+	t.MustContain(html.String(), "items: Knife")
+	t.MustContain(html.String(), "chain at start: height 40, chain id <code>pylons-testnet</code>")
+	t.MustContain(html.String(), `<a href="nightly_artifacts/0_CREATE_TRADE/output.txt">output.txt</a>`)
+
+	t.MustTrue(reportFormat("report.HTML") == reportFormatHTML && reportFormat("report.md") == reportFormatMarkdown && reportFormat("report.json") == reportFormatJSON, "report format should be chosen by extension")
+}
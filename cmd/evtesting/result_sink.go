@@ -0,0 +1,250 @@
+package evtesting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResultSink is an interface of plugins which receive test results e.g. to push them to a chat or a test management system
+// Tests run in parallel, so sinks should be safe for concurrent use.
+type ResultSink interface {
+	// OnTestStart is called when a test created with T starts, result has name and start time only
+	OnTestStart(result TestResult) error
+	// OnTestEnd is called when a test finishes with its status, duration and failure cause
+	OnTestEnd(result TestResult) error
+	// OnSuiteEnd is called once with summary of all results after tests finish
+	OnSuiteEnd(summary ReportSummary) error
+}
+
+// AddSink is a function to add sink which receives results collected by reporter from now on
+func (r *Reporter) AddSink(sink ResultSink) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.sinks = append(r.sinks, sink)
+}
+
+// AddResultSink is a function to add sink which receives results of all tests run with T
+func AddResultSink(sink ResultSink) {
+	GlobalReporter.AddSink(sink)
+}
+
+// ResetResultSinks is a function to remove sinks added by AddResultSink
+func ResetResultSinks() {
+	GlobalReporter.mux.Lock()
+	defer GlobalReporter.mux.Unlock()
+	GlobalReporter.sinks = nil
+}
+
+// notifySinks is a function to call sinks in order, errors are printed so that a broken sink does not fail tests
+func (r *Reporter) notifySinks(notify func(ResultSink) error) {
+	r.mux.Lock()
+	sinks := append([]ResultSink{}, r.sinks...)
+	r.mux.Unlock()
+	for _, sink := range sinks {
+		if err := notify(sink); err != nil {
+			fmt.Println("error sending result to result sink", err)
+		}
+	}
+}
+
+// EndSuite is a function to send summary to sinks, errors of all sinks are joined
+func (r *Reporter) EndSuite() error {
+	r.mux.Lock()
+	sinks := append([]ResultSink{}, r.sinks...)
+	r.mux.Unlock()
+	if len(sinks) == 0 {
+		return nil
+	}
+	summary := r.Summary()
+	errs := []string{}
+	for _, sink := range sinks {
+		if err := sink.OnSuiteEnd(summary); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// resultLine is a function to get one-line text of finished test result
+func resultLine(result TestResult) string {
+	line := fmt.Sprintf("%s %s (%s)", strings.ToUpper(result.Status), result.Name, result.Duration.Round(time.Millisecond))
+	if len(result.FailureCause) > 0 {
+		line += ": " + result.FailureCause
+	}
+	return line
+}
+
+// StdoutResultSink is a result sink writing a line per finished test and summary when tests finish
+type StdoutResultSink struct {
+	mux sync.Mutex
+	w   io.Writer
+}
+
+// NewStdoutResultSink is a function to create stdout result sink writing to w, os.Stdout when it's nil
+func NewStdoutResultSink(w io.Writer) *StdoutResultSink {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &StdoutResultSink{w: w}
+}
+
+// OnTestStart is a function to do nothing as only finished tests are written
+func (s *StdoutResultSink) OnTestStart(result TestResult) error {
+	return nil
+}
+
+// OnTestEnd is a function to write a line of test result
+func (s *StdoutResultSink) OnTestEnd(result TestResult) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	_, err := fmt.Fprintln(s.w, resultLine(result))
+	return err
+}
+
+// OnSuiteEnd is a function to write counts, first failure and slowest tests
+func (s *StdoutResultSink) OnSuiteEnd(summary ReportSummary) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return writeSummaryText(s.w, summary)
+}
+
+// resultEvent is a struct to describe a line of json result file and payload of webhook
+type resultEvent struct {
+	Event   string         `json:"event"`
+	Time    time.Time      `json:"time"`
+	Text    string         `json:"text,omitempty"`
+	Result  *TestResult    `json:"result,omitempty"`
+	Summary *ReportSummary `json:"summary,omitempty"`
+}
+
+// describes the events of json result file and webhook payloads
+const (
+	resultEventTestStart = "test_start"
+	resultEventTestEnd   = "test_end"
+	resultEventSuiteEnd  = "suite_end"
+)
+
+// JSONFileResultSink is a result sink writing a json line per event so that results of a crashed run are kept
+type JSONFileResultSink struct {
+	mux  sync.Mutex
+	file *os.File
+}
+
+// NewJSONFileResultSink is a function to create json result sink writing to file, existing file is truncated
+func NewJSONFileResultSink(filePath string) (*JSONFileResultSink, error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error creating result file: %w", err)
+	}
+	return &JSONFileResultSink{file: file}, nil
+}
+
+// write is a function to append event as a json line
+func (s *JSONFileResultSink) write(event resultEvent) error {
+	bz, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.file == nil {
+		return fmt.Errorf("result file is closed, %s event is dropped", event.Event)
+	}
+	_, err = s.file.Write(append(bz, '\n'))
+	return err
+}
+
+// OnTestStart is a function to write test_start event
+func (s *JSONFileResultSink) OnTestStart(result TestResult) error {
+	return s.write(resultEvent{Event: resultEventTestStart, Time: time.Now(), Result: &result})
+}
+
+// OnTestEnd is a function to write test_end event with result
+func (s *JSONFileResultSink) OnTestEnd(result TestResult) error {
+	return s.write(resultEvent{Event: resultEventTestEnd, Time: time.Now(), Result: &result})
+}
+
+// OnSuiteEnd is a function to write suite_end event with summary and close the file
+func (s *JSONFileResultSink) OnSuiteEnd(summary ReportSummary) error {
+	if err := s.write(resultEvent{Event: resultEventSuiteEnd, Time: time.Now(), Summary: &summary}); err != nil {
+		return err
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// WebhookResultSink is a result sink posting json events to an http webhook e.g. of Slack or TestRail integrations
+// Payloads have "text" field with a readable line, which chat incoming webhooks show as message.
+type WebhookResultSink struct {
+	URL string
+	// OnlyFailures posts failed tests only, summary is posted anyway
+	OnlyFailures bool
+	Client       *http.Client
+}
+
+// NewWebhookResultSink is a function to create webhook result sink posting to url with 10s timeout
+func NewWebhookResultSink(url string, onlyFailures bool) *WebhookResultSink {
+	return &WebhookResultSink{
+		URL:          url,
+		OnlyFailures: onlyFailures,
+		Client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// post is a function to send event to webhook
+func (s *WebhookResultSink) post(event resultEvent) error {
+	bz, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Post(s.URL, "application/json", bytes.NewReader(bz))
+	if err != nil {
+		return fmt.Errorf("error posting %s event to webhook: %w", event.Event, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook responded %s to %s event", res.Status, event.Event)
+	}
+	return nil
+}
+
+// OnTestStart is a function to do nothing not to flood webhook
+func (s *WebhookResultSink) OnTestStart(result TestResult) error {
+	return nil
+}
+
+// OnTestEnd is a function to post test_end event, passed and skipped tests are not posted with OnlyFailures
+func (s *WebhookResultSink) OnTestEnd(result TestResult) error {
+	if s.OnlyFailures && result.Status != StatusFail {
+		return nil
+	}
+	return s.post(resultEvent{Event: resultEventTestEnd, Time: time.Now(), Text: resultLine(result), Result: &result})
+}
+
+// OnSuiteEnd is a function to post suite_end event with counts, first failure and slowest tests
+// Results of all tests are left out of the payload as they're posted by test_end events.
+func (s *WebhookResultSink) OnSuiteEnd(summary ReportSummary) error {
+	summary.Results = []TestResult{}
+	text := fmt.Sprintf("test summary: passed=%d failed=%d skipped=%d", summary.Passed, summary.Failed, summary.Skipped)
+	if summary.FirstFailure != nil {
+		text += "\nfirst failure: " + resultLine(*summary.FirstFailure)
+	}
+	return s.post(resultEvent{Event: resultEventSuiteEnd, Time: time.Now(), Text: text, Summary: &summary})
+}
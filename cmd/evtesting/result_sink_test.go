@@ -0,0 +1,116 @@
+package evtesting
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingSink is a result sink keeping names of events it received
+type recordingSink struct {
+	mux    sync.Mutex
+	events []string
+}
+
+func (s *recordingSink) record(event string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *recordingSink) OnTestStart(result TestResult) error {
+	return s.record("start " + result.Name)
+}
+
+func (s *recordingSink) OnTestEnd(result TestResult) error {
+	return s.record("end " + result.Name + " " + result.Status)
+}
+
+func (s *recordingSink) OnSuiteEnd(summary ReportSummary) error {
+	return s.record("suite")
+}
+
+// failedTB is a test reported as failed without failing the go test
+type failedTB struct {
+	testing.TB
+}
+
+func (f failedTB) Failed() bool {
+	return true
+}
+
+func TestResultSinks(originT *testing.T) {
+	t := NewT(originT)
+
+	var posted []resultEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var event resultEvent
+		if err := json.NewDecoder(req.Body).Decode(&event); err == nil {
+			posted = append(posted, event)
+		}
+	}))
+	defer server.Close()
+
+	resultFile := filepath.Join(originT.TempDir(), "results.jsonl")
+	jsonSink, err := NewJSONFileResultSink(resultFile)
+	t.MustNil(err, "error creating json result sink")
+	var stdout strings.Builder
+	recorder := &recordingSink{}
+
+	reporter := NewReporter()
+	reporter.AddSink(recorder)
+	reporter.AddSink(NewStdoutResultSink(&stdout))
+	reporter.AddSink(jsonSink)
+	reporter.AddSink(NewWebhookResultSink(server.URL, true))
+	originT.Run("passing", func(sub *testing.T) {
+		reporter.track(sub)
+	})
+	originT.Run("failing", func(sub *testing.T) {
+		reporter.track(failedTB{sub})
+		reporter.recordFailure(sub.Name(), "balance is incorrect", Fields{})
+	})
+	err = reporter.EndSuite()
+	t.MustNil(err, "error ending suite")
+
+	t.WithFields(Fields{
+		"events": recorder.events,
+	}).MustTrue(strings.Join(recorder.events, ",") == "start TestResultSinks/passing,end TestResultSinks/passing pass,start TestResultSinks/failing,end TestResultSinks/failing fail,suite",
+		"sink should receive start and end of tests and suite end in order")
+	t.MustContain(stdout.String(), "FAIL TestResultSinks/failing")
+	t.MustContain(stdout.String(), "test summary: passed=")
+
+	file, err := os.Open(resultFile)
+	t.MustNil(err, "error opening result file")
+	defer file.Close()
+	lines := []resultEvent{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event resultEvent
+		t.MustNil(json.Unmarshal(scanner.Bytes(), &event), "error decoding result line")
+		lines = append(lines, event)
+	}
+	t.MustTrue(len(lines) == 5 && lines[0].Event == resultEventTestStart && lines[4].Event == resultEventSuiteEnd, "json sink should write a line per event")
+	t.MustTrue(jsonSink.OnTestEnd(TestResult{}) != nil, "events after suite end should be refused")
+
+	t.WithFields(Fields{
+		"posted": posted,
+	}).MustTrue(len(posted) == 2 && posted[0].Result.FailureCause == "balance is incorrect" && posted[1].Event == resultEventSuiteEnd, "webhook should get failed tests and summary")
+	t.MustContain(posted[1].Text, "first failure: FAIL TestResultSinks/failing")
+	t.MustTrue(len(posted[1].Summary.Results) == 0, "webhook summary should leave out results")
+
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = ioutil.ReadAll(req.Body)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failingServer.Close()
+	err = NewWebhookResultSink(failingServer.URL, false).OnSuiteEnd(ReportSummary{})
+	t.MustTrue(err != nil, "webhook error status should be returned")
+}
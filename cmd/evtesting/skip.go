@@ -0,0 +1,82 @@
+package evtesting
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// nodeVersionProvider is a function to get version of the node tests run against
+var nodeVersionProvider func() (string, error)
+
+// SetNodeVersionProvider is a function to set provider of node version checked by SkipIfNodeVersionBelow
+func SetNodeVersionProvider(provider func() (string, error)) {
+	nodeVersionProvider = provider
+}
+
+// SkipIfNodeVersionBelow is a function to skip the test when node version is lower than minVersion e.g. "v0.4.0"
+// The test is skipped as well when node version can't be known e.g. node is unreachable.
+func (t *T) SkipIfNodeVersionBelow(minVersion string) {
+	if nodeVersionProvider == nil {
+		t.Skipf("node version is unknown, requires %s: no node version provider", minVersion)
+		return
+	}
+	version, err := nodeVersionProvider()
+	if err != nil {
+		t.Skipf("node version is unknown, requires %s: %s", minVersion, err.Error())
+		return
+	}
+	if CompareVersions(version, minVersion) < 0 {
+		t.Skipf("node version %s is below %s", version, minVersion)
+	}
+}
+
+// SkipUnlessEnv is a function to skip the test unless environment variable key is set to a true value
+// Non boolean values e.g. an endpoint url are treated as true.
+func (t *T) SkipUnlessEnv(key string) {
+	value := strings.TrimSpace(os.Getenv(key))
+	if len(value) == 0 {
+		t.Skipf("%s environment variable is not set", key)
+		return
+	}
+	if enabled, err := strconv.ParseBool(value); err == nil && !enabled {
+		t.Skipf("%s environment variable is %s", key, value)
+	}
+}
+
+// CompareVersions is a function to compare dotted versions like v1.2.3, returns -1, 0 or 1
+// Pre-release and build suffixes are ignored and missing parts are treated as 0.
+func CompareVersions(a, b string) int {
+	aParts, bParts := versionParts(a), versionParts(b)
+	for len(aParts) < len(bParts) {
+		aParts = append(aParts, 0)
+	}
+	for len(bParts) < len(aParts) {
+		bParts = append(bParts, 0)
+	}
+	for i := range aParts {
+		if aParts[i] != bParts[i] {
+			if aParts[i] < bParts[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []int {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if idx := strings.IndexAny(version, "-+"); idx >= 0 {
+		version = version[:idx]
+	}
+	parts := []int{}
+	for _, part := range strings.Split(version, ".") {
+		num, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		parts = append(parts, num)
+	}
+	return parts
+}
@@ -0,0 +1,53 @@
+package evtesting
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestSkipHelpers(originT *testing.T) {
+	t := NewT(originT)
+
+	t.MustTrue(CompareVersions("v1.2.3", "1.2.3") == 0, "v prefix should be ignored")
+	t.MustTrue(CompareVersions("v0.10.0", "v0.9.1") == 1, "version parts should be compared as numbers")
+	t.MustTrue(CompareVersions("0.4.0-rc1", "v0.4") == 0, "pre-release suffix and missing parts should be ignored")
+	t.MustTrue(CompareVersions("0.3.9", "0.4.0") == -1, "lower version should be less")
+
+	defer SetNodeVersionProvider(nodeVersionProvider)
+	SetNodeVersionProvider(func() (string, error) { return "v0.4.2", nil })
+	t.Run("new enough node", func(t *T) {
+		t.SkipIfNodeVersionBelow("v0.4.0")
+	})
+	t.Run("old node", func(t *T) {
+		t.SkipIfNodeVersionBelow("v0.5.0")
+		t.Fatal("test on old node should be skipped")
+	})
+	SetNodeVersionProvider(func() (string, error) { return "", errors.New("connection refused") })
+	t.Run("unreachable node", func(t *T) {
+		t.SkipIfNodeVersionBelow("v0.4.0")
+		t.Fatal("test on unreachable node should be skipped")
+	})
+
+	os.Setenv("EVTESTING_SKIP_FLAG", "false")
+	defer os.Unsetenv("EVTESTING_SKIP_FLAG")
+	t.Run("flag off", func(t *T) {
+		t.SkipUnlessEnv("EVTESTING_SKIP_FLAG")
+		t.Fatal("test with flag off should be skipped")
+	})
+	t.Run("flag unset", func(t *T) {
+		t.SkipUnlessEnv("EVTESTING_SKIP_FLAG_UNSET")
+		t.Fatal("test without flag should be skipped")
+	})
+
+	summary := GlobalReporter.Summary()
+	results := map[string]string{}
+	for _, result := range summary.Results {
+		results[result.Name] = result.Status
+	}
+	t.MustTrue(results["TestSkipHelpers/new_enough_node"] == StatusPass, "test on new enough node should pass")
+	t.MustTrue(results["TestSkipHelpers/old_node"] == StatusSkip, "test on old node should be reported as skip")
+
+	logT := NewT(nil)
+	logT.Skipf("log-only mode %s", "returns")
+}
@@ -0,0 +1,300 @@
+package evtesting
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// runHistoryPrefix and runHistoryExt make names of result files of runs kept in run history directory
+const (
+	runHistoryPrefix = "run-"
+	runHistoryExt    = ".jsonl"
+	// runHistoryTimeFormat sorts by start time of runs as text
+	runHistoryTimeFormat = "20060102T150405.000000000Z"
+)
+
+// maxFailureCategoryLength is the maximum length of failure category, longer causes are truncated
+const maxFailureCategoryLength = 80
+
+// failureCategoryMasks replace parts of failure causes which differ between runs e.g. addresses, hashes and numbers
+var failureCategoryMasks = []struct {
+	pattern *regexp.Regexp
+	replace string
+}{
+	{regexp.MustCompile(`\b[a-z]+1[02-9ac-hj-np-z]{38,}\b`), "<address>"},
+	{regexp.MustCompile(`\b[0-9A-Fa-f]{16,}\b`), "<hash>"},
+	{regexp.MustCompile(`\d+`), "N"},
+}
+
+// RunHistoryFile is a function to get result file of a run started at started in run history directory dir
+// Names of run history files sort by start time of runs.
+func RunHistoryFile(dir string, started time.Time) string {
+	return filepath.Join(dir, runHistoryPrefix+started.UTC().Format(runHistoryTimeFormat)+runHistoryExt)
+}
+
+// TrendRun is a struct to describe duration, gas usage and failures of a run of run history
+type TrendRun struct {
+	Name      string        `json:"name"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	Passed    int           `json:"passed"`
+	Failed    int           `json:"failed"`
+	Skipped   int           `json:"skipped"`
+	Txs       int           `json:"txs"`
+	GasUsed   int64         `json:"gas_used"`
+	// FailureCategories are numbers of failed tests by failure category, see FailureCategory
+	FailureCategories map[string]int `json:"failure_categories"`
+}
+
+// GasPerTx is a function to get average gas used by transactions of the run, 0 without transactions
+func (run TrendRun) GasPerTx() int64 {
+	if run.Txs == 0 {
+		return 0
+	}
+	return run.GasUsed / int64(run.Txs)
+}
+
+// TrendReport is a struct to describe trends of the last runs of run history, runs are ordered from the oldest
+type TrendReport struct {
+	Runs []TrendRun `json:"runs"`
+	// Categories are failure categories of the runs, the most frequent first
+	Categories []string `json:"categories"`
+}
+
+// FailureCategory is a function to get category of failure cause, addresses, hashes and numbers are masked
+// so the same failure of different runs gets the same category.
+func FailureCategory(cause string) string {
+	category := strings.TrimSpace(strings.SplitN(cause, "\n", 2)[0])
+	if len(category) == 0 {
+		return "unknown"
+	}
+	for _, mask := range failureCategoryMasks {
+		category = mask.pattern.ReplaceAllString(category, mask.replace)
+	}
+	if len(category) > maxFailureCategoryLength {
+		category = category[:maxFailureCategoryLength] + "..."
+	}
+	return category
+}
+
+// NewTrendRun is a function to summarize results of a run, transactions recorded by several tests are counted once
+func NewTrendRun(name string, results []TestResult) TrendRun {
+	run := TrendRun{Name: name, FailureCategories: map[string]int{}}
+	var ended time.Time
+	txs := map[string]bool{}
+	for _, result := range results {
+		switch result.Status {
+		case StatusPass:
+			run.Passed++
+		case StatusFail:
+			run.Failed++
+			run.FailureCategories[FailureCategory(result.FailureCause)]++
+		case StatusSkip:
+			run.Skipped++
+		}
+		if run.StartedAt.IsZero() || (!result.StartedAt.IsZero() && result.StartedAt.Before(run.StartedAt)) {
+			run.StartedAt = result.StartedAt
+		}
+		if end := result.StartedAt.Add(result.Duration); end.After(ended) {
+			ended = end
+		}
+		for _, tx := range result.Txs {
+			if txs[tx.TxHash] {
+				continue
+			}
+			txs[tx.TxHash] = true
+			run.Txs++
+			run.GasUsed += tx.GasUsed
+		}
+	}
+	if !run.StartedAt.IsZero() {
+		run.Duration = ended.Sub(run.StartedAt)
+	}
+	return run
+}
+
+// NewTrendReport is a function to create trend report of runs ordered from the oldest
+func NewTrendReport(runs []TrendRun) TrendReport {
+	counts := map[string]int{}
+	for _, run := range runs {
+		for category, count := range run.FailureCategories {
+			counts[category] += count
+		}
+	}
+	categories := []string{}
+	for category := range counts {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if counts[categories[i]] != counts[categories[j]] {
+			return counts[categories[i]] > counts[categories[j]]
+		}
+		return categories[i] < categories[j]
+	})
+	return TrendReport{Runs: runs, Categories: categories}
+}
+
+// ReadRunHistory is a function to create trend report of the last runs of run history directory, all runs when last is 0
+func ReadRunHistory(dir string, last int) (TrendReport, error) {
+	files, err := filepath.Glob(filepath.Join(dir, runHistoryPrefix+"*"+runHistoryExt))
+	if err != nil {
+		return TrendReport{}, err
+	}
+	sort.Strings(files)
+	if last > 0 && len(files) > last {
+		files = files[len(files)-last:]
+	}
+	runs := []TrendRun{}
+	for _, file := range files {
+		results, err := ReadResultFile(file)
+		if err != nil {
+			return TrendReport{}, err
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), runHistoryPrefix), runHistoryExt)
+		if started, err := time.Parse(runHistoryTimeFormat, name); err == nil {
+			name = started.Format("2006-01-02 15:04:05")
+		}
+		runs = append(runs, NewTrendRun(name, results))
+	}
+	return NewTrendReport(runs), nil
+}
+
+// trendChange is a function to describe change of value from the previous run e.g. "+12%"
+func trendChange(prev, value float64) string {
+	if prev == 0 {
+		if value == 0 {
+			return "0%"
+		}
+		return "new"
+	}
+	return fmt.Sprintf("%+.0f%%", (value-prev)/prev*100)
+}
+
+// trendRow is a run row of rendered trend report
+type trendRow struct {
+	TrendRun
+	DurationText   string
+	DurationChange string
+	GasChange      string
+	Categories     []int
+}
+
+// trendView is a struct to render trend report
+type trendView struct {
+	GeneratedAt time.Time
+	Categories  []string
+	Rows        []trendRow
+}
+
+// view is a function to get runs of report with changes from their previous runs
+func (r TrendReport) view() trendView {
+	view := trendView{GeneratedAt: time.Now(), Categories: r.Categories, Rows: []trendRow{}}
+	for idx, run := range r.Runs {
+		row := trendRow{TrendRun: run, DurationText: run.Duration.Round(time.Second).String(), Categories: []int{}}
+		if idx > 0 {
+			prev := r.Runs[idx-1]
+			row.DurationChange = trendChange(float64(prev.Duration), float64(run.Duration))
+			row.GasChange = trendChange(float64(prev.GasPerTx()), float64(run.GasPerTx()))
+		}
+		for _, category := range r.Categories {
+			row.Categories = append(row.Categories, run.FailureCategories[category])
+		}
+		view.Rows = append(view.Rows, row)
+	}
+	return view
+}
+
+// WriteMarkdown is a function to write duration, gas usage and failure category trends of runs
+func (r TrendReport) WriteMarkdown(w io.Writer) error {
+	view := r.view()
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Trend report\n\n%d runs\n\n", len(view.Rows))
+	sb.WriteString("| run | duration | change | passed | failed | skipped | txs | gas used | gas per tx | change |\n|---|---|---|---|---|---|---|---|---|---|\n")
+	for _, row := range view.Rows {
+		fmt.Fprintf(&sb, "| %s | %s | %s | %d | %d | %d | %d | %d | %d | %s |\n", row.Name, row.DurationText, row.DurationChange,
+			row.Passed, row.Failed, row.Skipped, row.Txs, row.GasUsed, row.GasPerTx(), row.GasChange)
+	}
+	sb.WriteString("\n## Failure categories\n\n")
+	if len(view.Categories) == 0 {
+		sb.WriteString("no failures\n")
+	} else {
+		sb.WriteString("| category | " + strings.Join(runNames(view.Rows), " | ") + " |\n|---|" + strings.Repeat("---|", len(view.Rows)) + "\n")
+		for idx, category := range view.Categories {
+			cells := []string{}
+			for _, row := range view.Rows {
+				cells = append(cells, fmt.Sprintf("%d", row.Categories[idx]))
+			}
+			fmt.Fprintf(&sb, "| %s | %s |\n", markdownCell(category), strings.Join(cells, " | "))
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// runNames is a function to get names of runs of rows
+func runNames(rows []trendRow) []string {
+	names := []string{}
+	for _, row := range rows {
+		names = append(names, row.Name)
+	}
+	return names
+}
+
+var trendHTMLTemplate = template.Must(template.New("trends").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Trend report</title>
+<style>table{border-collapse:collapse}td,th{border:1px solid #ccc;padding:2px 6px;vertical-align:top}.fail{color:#c00}</style></head>
+<body>
+<h1>Trend report</h1>
+<p>generated at {{.GeneratedAt.Format "2006-01-02 15:04:05"}}, {{len .Rows}} runs</p>
+<table>
+<tr><th>run</th><th>duration</th><th>change</th><th>passed</th><th>failed</th><th>skipped</th><th>txs</th><th>gas used</th><th>gas per tx</th><th>change</th></tr>
+{{range .Rows}}<tr><td>{{.Name}}</td><td>{{.DurationText}}</td><td>{{.DurationChange}}</td><td>{{.Passed}}</td><td{{if .Failed}} class="fail"{{end}}>{{.Failed}}</td><td>{{.Skipped}}</td><td>{{.Txs}}</td><td>{{.GasUsed}}</td><td>{{.GasPerTx}}</td><td>{{.GasChange}}</td></tr>
+{{end}}</table>
+<h2>Failure categories</h2>
+{{if .Categories}}<table>
+<tr><th>category</th>{{range .Rows}}<th>{{.Name}}</th>{{end}}</tr>
+{{range $idx, $category := .Categories}}<tr><td>{{$category}}</td>{{range $.Rows}}<td>{{index .Categories $idx}}</td>{{end}}</tr>
+{{end}}</table>{{else}}<p>no failures</p>{{end}}
+</body>
+</html>
+`))
+
+// WriteHTML is a function to write duration, gas usage and failure category trends of runs as html page
+func (r TrendReport) WriteHTML(w io.Writer) error {
+	return trendHTMLTemplate.Execute(w, r.view())
+}
+
+// WriteFile is a function to write trend report, html and markdown are chosen by .html and .md extensions and json otherwise
+func (r TrendReport) WriteFile(filePath string) error {
+	format := reportFormat(filePath)
+	if format == reportFormatJSON {
+		bz, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filePath, bz, 0644)
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	if format == reportFormatHTML {
+		err = r.WriteHTML(file)
+	} else {
+		err = r.WriteMarkdown(file)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
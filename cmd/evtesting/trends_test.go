@@ -0,0 +1,73 @@
+package evtesting
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFailureCategory(originT *testing.T) {
+	t := NewT(originT)
+
+	first := FailureCategory("account sequence mismatch, expected 12, got 11 for pylo1y8vysg9hmvavkdxpvccv2ve3nssv5avm0kt337")
+	second := FailureCategory("account sequence mismatch, expected 3, got 2 for pylo1qqqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v7")
+	t.WithFields(Fields{
+		"first":  first,
+		"second": second,
+	}).MustTrue(first == second && !strings.Contains(first, "pylo1"), "causes differing by numbers and addresses should get the same category")
+	t.MustTrue(FailureCategory("") == "unknown", "failure without cause should be unknown")
+}
+
+func TestRunHistoryTrends(originT *testing.T) {
+	t := NewT(originT)
+
+	dir := originT.TempDir()
+	started := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	runs := [][]TestResult{
+		{
+			{Name: "TestFixturesViaCLI/trade.json", Status: StatusPass, StartedAt: started, Duration: time.Minute,
+				Txs: []TxRecord{{TxHash: "A1", GasUsed: 100000}, {TxHash: "A2", GasUsed: 300000}}},
+		},
+		{
+			{Name: "TestFixturesViaCLI/trade.json", Status: StatusPass, StartedAt: started.Add(24 * time.Hour), Duration: time.Minute,
+				Txs: []TxRecord{{TxHash: "B1", GasUsed: 200000}}},
+			{Name: "TestFixturesViaCLI/recipe.json", Status: StatusFail, StartedAt: started.Add(24 * time.Hour), Duration: 2 * time.Minute,
+				FailureCause: "insufficient fee: got 10upylon", Txs: []TxRecord{{TxHash: "B1", GasUsed: 200000}}},
+		},
+		{
+			{Name: "TestFixturesViaCLI/recipe.json", Status: StatusFail, StartedAt: started.Add(48 * time.Hour), Duration: time.Minute,
+				FailureCause: "insufficient fee: got 20upylon"},
+		},
+	}
+	for idx, results := range runs {
+		sink, err := NewJSONFileResultSink(RunHistoryFile(dir, started.Add(time.Duration(idx)*24*time.Hour)))
+		t.MustNil(err, "error creating run history file")
+		for _, result := range results {
+			t.MustNil(sink.OnTestEnd(result), "error writing result")
+		}
+		t.MustNil(sink.OnSuiteEnd(ReportSummary{}), "error finishing run history file")
+	}
+
+	report, err := ReadRunHistory(dir, 2)
+	t.MustNil(err, "error reading run history")
+	t.WithFields(Fields{
+		"report": report,
+	}).MustTrue(len(report.Runs) == 2 && report.Runs[0].StartedAt.Equal(started.Add(24*time.Hour)), "only the last runs should be read from the oldest")
+	t.MustTrue(report.Runs[0].Duration == 2*time.Minute && report.Runs[0].Txs == 1 && report.Runs[0].GasUsed == 200000,
+		"duration should span results and transactions recorded by several tests should be counted once")
+	t.MustTrue(len(report.Categories) == 1 && report.Runs[1].FailureCategories[report.Categories[0]] == 1, "failures of the same category should be grouped across runs")
+
+	all, err := ReadRunHistory(dir, 0)
+	t.MustNil(err, "error reading run history")
+	t.MustTrue(len(all.Runs) == 3 && all.Runs[0].GasPerTx() == 200000, "all runs should be read without limit")
+
+	var sb strings.Builder
+	t.MustNil(all.WriteMarkdown(&sb), "error writing trend report")
+	t.MustContain(sb.String(), "| 2 | 400000 | 200000 |")
+	t.MustContain(sb.String(), "| 2026-10-02 09:00:00 | 2m0s | +100% | 1 | 1 | 0 | 1 | 200000 | 200000 | +0% |")
+	t.MustContain(sb.String(), "| insufficient fee: got Nupylon | 0 | 1 | 1 |")
+
+	htmlFile := filepath.Join(dir, "trends.html")
+	t.MustNil(all.WriteFile(htmlFile), "error writing html trend report")
+}
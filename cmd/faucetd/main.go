@@ -0,0 +1,98 @@
+// faucetd serves an http endpoint funding addresses on local and devnet chains with coins of a funded key
+// Coins are sent by the same client and flags (-node, -chain-profile, -keyring-backend, ...) as tests use.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	listenAddr      = ":8090"
+	faucetKey       = ""
+	amount          = "10000pylon"
+	maxAmount       = ""
+	interval        = time.Hour
+	ipInterval      = time.Minute
+	trustProxy      = false
+	maxPerHour      = 100
+	allowlist       = ""
+	addressBookFile = ""
+)
+
+func init() {
+	flag.StringVar(&listenAddr, "addr", ":8090", "address to serve faucet on")
+	flag.StringVar(&faucetKey, "key", "", "key name of the funded account coins are sent from")
+	flag.StringVar(&amount, "amount", "10000pylon", "coins sent for requests without amount")
+	flag.StringVar(&maxAmount, "max-amount", "", "maximum coins of a request, default amount")
+	flag.DurationVar(&interval, "interval", time.Hour, "time an address should wait between requests, 0 to disable rate limit")
+	flag.DurationVar(&ipInterval, "ip-interval", time.Minute, "time a client ip should wait between requests for any address, 0 to disable rate limit")
+	flag.BoolVar(&trustProxy, "trust-proxy", false, "take client ip from X-Forwarded-For, set it only when faucet is behind a proxy setting it")
+	flag.IntVar(&maxPerHour, "max-per-hour", 100, "number of requests of all addresses funded in an hour, 0 to disable rate limit")
+	flag.StringVar(&allowlist, "allowlist", "", "comma separated addresses or aliases of address-book which can be funded, faucet is open to any address when it's empty")
+	flag.StringVar(&addressBookFile, "address-book", "", "json file of account aliases to addresses e.g. {\"frontend\": \"cosmos1...\"}")
+}
+
+// fail is a function to print error and exit
+func fail(args ...interface{}) {
+	fmt.Println(args...)
+	os.Exit(1)
+}
+
+func main() {
+	flag.Parse()
+	if err := inttest.ApplyChainProfile(); err != nil {
+		fail("error applying chain profile", err)
+	}
+	if inttest.CLIOpts.Profile == inttest.ChainProfileMainnet {
+		fail("faucet should not run against", inttest.ChainProfileMainnet)
+	}
+	if len(addressBookFile) > 0 {
+		if err := inttest.LoadAddressBook(addressBookFile); err != nil {
+			fail("error reading address book", err)
+		}
+	}
+	opts := inttest.FaucetOptions{
+		Key:        faucetKey,
+		Interval:   interval,
+		IPInterval: ipInterval,
+		TrustProxy: trustProxy,
+		MaxPerHour: maxPerHour,
+		Allowlist:  []string{},
+	}
+	var err error
+	if opts.Amount, err = sdk.ParseCoinsNormalized(amount); err != nil {
+		fail("error parsing amount", err)
+	}
+	if opts.MaxAmount, err = sdk.ParseCoinsNormalized(maxAmount); err != nil {
+		fail("error parsing max amount", err)
+	}
+	if len(allowlist) > 0 {
+		opts.Allowlist = strings.Split(allowlist, ",")
+	} else {
+		fmt.Println("faucet allowlist is empty, any address can be funded within rate limits")
+	}
+	faucet, err := inttest.NewFaucet(nil, opts)
+	if err != nil {
+		fail("error creating faucet", err)
+	}
+
+	t := testing.NewT(nil)
+	mux := http.NewServeMux()
+	mux.Handle("/fund", faucet.Handler(&t))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	fmt.Println("faucet of key", faucetKey, "serving on", listenAddr, "chain", inttest.GetChainID())
+	if err := http.ListenAndServe(listenAddr, mux); err != nil {
+		fail("faucet stopped", err)
+	}
+}
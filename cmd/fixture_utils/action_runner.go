@@ -45,11 +45,23 @@ func RegisterDefaultActionRunners() {
 	RegisterActionRunner("update_recipe", RunUpdateRecipe)
 	RegisterActionRunner("enable_recipe", RunEnableRecipe)
 	RegisterActionRunner("disable_recipe", RunDisableRecipe)
+	RegisterActionRunner("check_permissions", RunCheckPermissions) // update, disable and enable recipe by each role
 	RegisterActionRunner("execute_recipe", RunExecuteRecipe)
 	RegisterActionRunner("check_execution", RunCheckExecution)
+	RegisterActionRunner("pay_to_complete", RunPayToComplete)               // check_execution paying to complete pending execution
+	RegisterActionRunner("refund_execution", RunRefundExecution)            // check_execution verifying locked coins and items are refunded
+	RegisterActionRunner("execute_delayed_recipe", RunExecuteDelayedRecipe) // create_recipe + execute_recipe + check_execution
 	RegisterActionRunner("create_trade", RunCreateTrade)
+	for action, shape := range createTradeShapeActions {
+		RegisterActionRunner(action, RunCreateTradeOfShape(shape)) // create_trade checking sides of trade
+	}
 	RegisterActionRunner("fulfill_trade", RunFulfillTrade)
+	RegisterActionRunner("auto_fulfill_trade", RunAutoFulfillTrade) // fulfill_trade with items picked from sender's inventory
 	RegisterActionRunner("disable_trade", RunDisableTrade)
 	RegisterActionRunner("enable_trade", RunEnableTrade)
 	RegisterActionRunner("multi_msg_tx", RunMultiMsgTx)
+	RegisterActionRunner("send_msg", RunSendMsg) // msg of any type written as raw json
+	RegisterActionRunner("authz_grant", RunAuthzGrant)
+	RegisterActionRunner("authz_exec", RunAuthzExec) // msgs of msgRefs sent by grantee on behalf of their senders
+	RegisterActionRunner("authz_revoke", RunAuthzRevoke)
 }
@@ -0,0 +1,143 @@
+package fixturetest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// authzActionMsgs is msgs of actions whose name can be used as msg type of authz grant e.g. execute_recipe
+var authzActionMsgs = map[string]sdk.Msg{
+	"get_pylons":            &types.MsgGetPylons{},
+	"google_iap_get_pylons": &types.MsgGoogleIAPGetPylons{},
+	"send_coins":            &types.MsgSendCoins{},
+	"send_items":            &types.MsgSendItems{},
+	"fiat_item":             &types.MsgFiatItem{},
+	"update_item_string":    &types.MsgUpdateItemString{},
+	"create_cookbook":       &types.MsgCreateCookbook{},
+	"update_cookbook":       &types.MsgUpdateCookbook{},
+	"create_recipe":         &types.MsgCreateRecipe{},
+	"update_recipe":         &types.MsgUpdateRecipe{},
+	"enable_recipe":         &types.MsgEnableRecipe{},
+	"disable_recipe":        &types.MsgDisableRecipe{},
+	"execute_recipe":        &types.MsgExecuteRecipe{},
+	"check_execution":       &types.MsgCheckExecution{},
+	"create_trade":          &types.MsgCreateTrade{},
+	"fulfill_trade":         &types.MsgFulfillTrade{},
+	"disable_trade":         &types.MsgDisableTrade{},
+	"enable_trade":          &types.MsgEnableTrade{},
+}
+
+// AuthzMsgTypeURL is a function to get type url of authz msg type which is a type url or an action name e.g. execute_recipe
+func AuthzMsgTypeURL(msgType string, t *testing.T) string {
+	if strings.HasPrefix(msgType, "/") {
+		return msgType
+	}
+	msg, ok := authzActionMsgs[msgType]
+	t.WithFields(testing.Fields{
+		"msg_type": msgType,
+	}).MustTrue(ok, "msg type should be a type url or an action sending one msg")
+	return inttest.MsgTypeURL(msg)
+}
+
+// AuthzGrantFromRef is a function to read authz grant from reference, granter and grantee can be account names
+func AuthzGrantFromRef(ref string, t *testing.T) inttest.AuthzGrant {
+	byteValue := ReadFile(ref, t)
+	var grantType struct {
+		Granter    string
+		Grantee    string
+		MsgType    string
+		Expiration string
+	}
+	err := json.Unmarshal(byteValue, &grantType)
+	t.WithFields(testing.Fields{
+		"bytes": string(byteValue),
+	}).MustNil(err, "error reading using json Unmarshaler")
+
+	grant := inttest.AuthzGrant{
+		Granter:    inttest.SignerAddress(GetAccountAddressFromTempName(grantType.Granter, t)),
+		Grantee:    GetAccountAddressFromTempName(grantType.Grantee, t),
+		MsgTypeURL: AuthzMsgTypeURL(grantType.MsgType, t),
+	}
+	if len(grantType.Expiration) > 0 {
+		grant.Expiration, err = time.Parse(time.RFC3339, grantType.Expiration)
+		t.WithFields(testing.Fields{
+			"expiration": grantType.Expiration,
+		}).MustNil(err, "error parsing expiration, it should be RFC3339 time")
+	}
+	return grant
+}
+
+// checkAuthzTx is a function to check result of authz transaction step like other transaction steps
+func checkAuthzTx(txhash string, err error, step FixtureStep, t *testing.T) {
+	if err != nil {
+		TxBroadcastErrorCheck(err, txhash, step, t)
+		return
+	}
+
+	WaitForNextBlockWithErrorCheck(t)
+	if TxFailureCheck(txhash, step, t) {
+		return
+	}
+	GetTxHandleResult(txhash, t)
+}
+
+// RunAuthzGrant is a function to grant grantee to send msgs of a type on behalf of granter
+func RunAuthzGrant(step FixtureStep, t *testing.T) {
+	if FixtureTestOpts.VerifyOnly {
+		return
+	}
+	if step.ParamsRef != "" {
+		grant := AuthzGrantFromRef(step.ParamsRef, t)
+		txhash, err := inttest.NewClient().GrantAuthz(context.Background(), t, grant)
+		checkAuthzTx(txhash, err, step, t)
+	}
+}
+
+// RunAuthzRevoke is a function to revoke grant of grantee to send msgs of a type on behalf of granter
+func RunAuthzRevoke(step FixtureStep, t *testing.T) {
+	if FixtureTestOpts.VerifyOnly {
+		return
+	}
+	if step.ParamsRef != "" {
+		grant := AuthzGrantFromRef(step.ParamsRef, t)
+		txhash, err := inttest.NewClient().RevokeAuthz(context.Background(), t, grant.Granter, grant.Grantee, grant.MsgTypeURL)
+		checkAuthzTx(txhash, err, step, t)
+	}
+}
+
+// RunAuthzExec is a function to send msgs of msgRefs on behalf of their senders signed by grantee of params
+func RunAuthzExec(step FixtureStep, t *testing.T) {
+	if FixtureTestOpts.VerifyOnly {
+		return
+	}
+	if step.ParamsRef != "" && len(step.MsgRefs) != 0 {
+		byteValue := ReadFile(step.ParamsRef, t)
+		var execType struct {
+			Grantee string
+		}
+		err := json.Unmarshal(byteValue, &execType)
+		t.WithFields(testing.Fields{
+			"bytes": string(byteValue),
+		}).MustNil(err, "error reading using json Unmarshaler")
+		grantee := GetAccountAddressFromTempName(execType.Grantee, t)
+
+		var msgs []sdk.Msg
+		for _, ref := range step.MsgRefs {
+			newMsg, _ := ActionMsgFromRef(ref.Action, ref.ParamsRef, t)
+			t.WithFields(testing.Fields{
+				"action": ref.Action,
+			}).MustTrue(newMsg != nil, "action can't be used in authz exec")
+			ValidateStepMsg(step, ref.ParamsRef, newMsg, t)
+			msgs = append(msgs, newMsg)
+		}
+		txhash, err := inttest.NewClient().ExecAuthz(context.Background(), t, inttest.SignerAddress(grantee), msgs...)
+		checkAuthzTx(txhash, err, step, t)
+	}
+}
@@ -0,0 +1,108 @@
+package fixturetest
+
+import (
+	"strings"
+	"sync"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+// chainAccount is a struct to describe account created on chain by steps, funded accounts got pylons by mock_account
+type chainAccount struct {
+	tempName string
+	funded   bool
+}
+
+var chainAccountsMux sync.Mutex
+var chainAccounts []chainAccount
+
+// rememberChainAccount is a function to keep account created by a step so that it's created again after chain reset
+func rememberChainAccount(tempName string, funded bool) {
+	chainAccountsMux.Lock()
+	defer chainAccountsMux.Unlock()
+	for idx, account := range chainAccounts {
+		if account.tempName == tempName {
+			chainAccounts[idx].funded = account.funded || funded
+			return
+		}
+	}
+	chainAccounts = append(chainAccounts, chainAccount{tempName: tempName, funded: funded})
+}
+
+// rebaselineMux is locked while state is re-baselined, so that steps start after accounts and genesis fixtures are restored
+var rebaselineMux sync.Mutex
+var pendingChainResetMux sync.Mutex
+var pendingChainReset *inttest.ChainReset
+var watchChainResetOnce sync.Once
+
+// WatchChainReset is a function to re-baseline fixture state before the next step when client detects chain reset
+func WatchChainReset() {
+	watchChainResetOnce.Do(func() {
+		inttest.OnChainReset(func(reset inttest.ChainReset) {
+			pendingChainResetMux.Lock()
+			defer pendingChainResetMux.Unlock()
+			pendingChainReset = &reset
+		})
+	})
+}
+
+// forgetRegisteredIDs is a function to drop IDs and outputs registered by steps which don't exist on reset chain
+func forgetRegisteredIDs() {
+	execIDRWMutex.Lock()
+	execIDs = make(map[string]string)
+	execIDRWMutex.Unlock()
+	resetScenarioStates()
+	FixtureCleanup.Reset()
+	forgetCompletedSteps()
+}
+
+// RebaselineAfterChainReset is a function to restore fixture state when chain was reset since the last step
+// IDs registered by steps are dropped, accounts created by steps are created on chain again and
+// genesis fixtures of FixtureTestOpts.GenesisFixtures are replayed in order.
+func RebaselineAfterChainReset(t *testing.T) {
+	rebaselineMux.Lock()
+	defer rebaselineMux.Unlock()
+	pendingChainResetMux.Lock()
+	pending := pendingChainReset
+	pendingChainReset = nil
+	pendingChainResetMux.Unlock()
+	if pending == nil {
+		return
+	}
+	reset := *pending
+	t.WithFields(testing.Fields{
+		"chain_reset":      reset.String(),
+		"genesis_fixtures": strings.Join(FixtureTestOpts.GenesisFixtures, ","),
+	}).Warn("re-baselining fixture state after chain reset")
+
+	forgetRegisteredIDs()
+
+	chainAccountsMux.Lock()
+	accounts := append([]chainAccount{}, chainAccounts...)
+	chainAccountsMux.Unlock()
+	for _, account := range accounts {
+		caKey := GetAccountKeyFromTempName(account.tempName, t)
+		createChainAccount(caKey, GetAccountAddressFromTempName(account.tempName, t), t)
+		if account.funded {
+			RunGetPylons(FixtureStep{ID: "REBASELINE_" + strings.ToUpper(account.tempName), Action: "get_pylons", ParamsRef: account.tempName}, t)
+		}
+	}
+
+	for _, file := range FixtureTestOpts.GenesisFixtures {
+		ReplayFixture(file, t)
+	}
+}
+
+// ReplayFixture is a function to run steps of fixture file in order regardless of parallel option
+// It's used to restore state which scenarios rely on e.g. cookbooks of genesis fixtures after chain reset.
+func ReplayFixture(file string, t *testing.T) {
+	fixtureSteps := ReadFixtureScenario(file, t).Steps
+	CheckSteps(fixtureSteps, t)
+	t.Run("replay_"+file, func(t *testing.T) {
+		for _, step := range fixtureSteps {
+			RunActionRunner(step.Action, step, t)
+			PropertyExistCheck(step, t)
+		}
+	})
+}
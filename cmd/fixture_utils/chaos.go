@@ -0,0 +1,35 @@
+package fixturetest
+
+import (
+	"context"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+// chaosRestartTimeout is the time budget for node to answer status queries after restart
+const chaosRestartTimeout = 2 * time.Minute
+
+// RegisterChaosRestarts is a function to restart FixtureTestOpts.ChaosNode after each step of FixtureTestOpts.ChaosRestartSteps
+// Steps running in parallel meanwhile get the node unavailable, so retry and recovery of the harness should keep them passing.
+func RegisterChaosRestarts() {
+	if FixtureTestOpts.ChaosNode == nil {
+		return
+	}
+	for _, stepID := range FixtureTestOpts.ChaosRestartSteps {
+		RegisterAfterStep(StepSelector{StepID: stepID}, func(info StepHookInfo, t *testing.T) {
+			if info.State != StepPassed && info.State != StepFailed {
+				return
+			}
+			ctx, cancel := context.WithTimeout(inttest.TestContext(t), chaosRestartTimeout)
+			defer cancel()
+			startedAt := time.Now()
+			err := FixtureTestOpts.ChaosNode.Restart(ctx)
+			t.WithFields(testing.Fields{
+				"step":     info.Step.ID,
+				"duration": time.Since(startedAt).String(),
+			}).MustNil(err, "error restarting node by chaos")
+		})
+	}
+}
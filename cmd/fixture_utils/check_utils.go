@@ -1,14 +1,13 @@
 package fixturetest
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"os"
-	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	originT "testing"
 
@@ -26,9 +25,12 @@ type FixtureStep struct {
 		PreCondition []string `json:"precondition"`
 		BlockWait    int64    `json:"blockWait"`
 	} `json:"runAfter"`
-	Action    string `json:"action"`
-	ParamsRef string `json:"paramsRef"`
-	MsgRefs   []struct {
+	Action      string   `json:"action"`
+	ParamsRef   string   `json:"paramsRef"`
+	Requires    []string `json:"requires"`
+	Skip        bool     `json:"skip"`
+	Quarantined bool     `json:"quarantined"`
+	MsgRefs     []struct {
 		Action    string `json:"action"`
 		ParamsRef string `json:"paramsRef"`
 	} `json:"msgRefs"`
@@ -39,11 +41,25 @@ type FixtureStep struct {
 			ErrorLog       string `json:"errLog"`
 			BroadcastError string `json:"broadcastError"`
 		} `json:"txResult"`
+		VerifyTransfer bool `json:"verifyTransfer"`
+		// VerifyTransferFee checks transfer fees of sent or traded items are paid to cookbook owners and previous owners get their cuts
+		VerifyTransferFee bool `json:"verifyTransferFee"`
+		// VerifySupply checks total supply of coins minted by recipe execution increases by exactly the output amounts
+		VerifySupply bool `json:"verifySupply"`
+		// VerifyUpdate checks item attribute changes and charged fee of item update
+		VerifyUpdate bool `json:"verifyUpdate"`
+		// NewItems are items minted by recipe execution, see ExecutionItemsCheck
+		NewItems []ExecutionItemSpec `json:"newItems"`
+		// ModifiedItems are input items modified in place by recipe execution with their changed attributes
+		ModifiedItems []ExecutionItemSpec `json:"modifiedItems"`
+		// Golden is the golden file of execution output snapshot relative to base directory, see ExecutionGoldenCheck
+		Golden   string `json:"golden"`
 		Property []struct {
 			Owner          string   `json:"owner"`
 			ShouldNotExist bool     `json:"shouldNotExist"`
 			Cookbooks      []string `json:"cookbooks"`
 			Recipes        []string `json:"recipes"`
+			Trades         []string `json:"trades"` // extra info of trades
 			Items          []struct {
 				StringKeys   []string           `json:"stringKeys"`
 				StringValues map[string]string  `json:"stringValues"`
@@ -59,6 +75,10 @@ type FixtureStep struct {
 			} `json:"coins"`
 		} `json:"property"`
 	} `json:"output"`
+	// Register is result name to path of step result e.g. {"recipeID": "$.RecipeID"}, referred by "@recipeID" in params
+	Register map[string]string `json:"register"`
+	// ExpectError is the error the step should be rejected with, by broadcast or by transaction result
+	ExpectError *ExpectError `json:"expectError"`
 }
 
 // TestOptions is options struct to manage test options
@@ -68,6 +88,52 @@ type TestOptions struct {
 	VerifyOnly        bool
 	AccountNames      []string
 	BaseDirectory     string
+	StatusServerAddr  string
+	NodeCapabilities  []string
+	RunQuarantined    bool
+	FailOnStates      []StepState
+	// StateGuardAccounts are account keys whose state should not be changed by scenarios
+	StateGuardAccounts []string
+	// AdminKey is the key name of privileged account loaded by AdminKeyProvider, referred by "admin" temp name
+	AdminKey string
+	// Cleanup enables suite teardown of recipes, trades and items created by scenarios
+	Cleanup bool
+	// CleanupItemReceiver is the address or account name created items are sent to on teardown, items are kept when it's empty
+	CleanupItemReceiver string
+	// Tags selects scenarios having any of tags, all scenarios run when it's empty
+	Tags []string
+	// DryRun simulates transactions of steps and reports estimated gas without broadcasting them
+	DryRun bool
+	// InMemory applies msgs of steps to an in-process mock chain instead of a live node, see EnableInMemory
+	InMemory bool
+	// ModelCheck reconciles chain state after each block against a local model of transactions sent by steps
+	ModelCheck bool
+	// SkipExisting skips broadcasting create cookbook and recipe steps when identical ones exist on chain
+	SkipExisting bool
+	// MaxScenarioBlocks fails scenarios when chain advances more blocks while each runs, it's not checked when it's 0
+	MaxScenarioBlocks int64
+	// GasBudgets are max gas per msg type which transactions of steps should keep, see ReadGasBudgets
+	GasBudgets map[string]int64
+	// GenesisFixtures are fixture files replayed in order after chain reset is detected, see RebaselineAfterChainReset
+	GenesisFixtures []string
+	// ChaosNode is the node restarted after steps of ChaosRestartSteps, see RegisterChaosRestarts
+	ChaosNode inttest.NodeRestarter
+	// ChaosRestartSteps are IDs of steps the node is restarted after
+	ChaosRestartSteps []string
+	// CheckpointFile is the file passed steps and their outputs are recorded to, see CheckpointStep
+	CheckpointFile string
+	// Resume continues from CheckpointFile, steps which passed before are not run again
+	Resume bool
+	// ScenarioPageSize is the number of fixture files of a page, all files run when it's 0
+	ScenarioPageSize int
+	// ScenarioPage is the 1-based page of fixture files to run
+	ScenarioPage int
+	// AuditExecutions reports executions of test accounts which are not completed when scenarios finish
+	AuditExecutions bool
+	// FailOnExecutionLeaks fails the run on executions reported by AuditExecutions instead of logging warnings
+	FailOnExecutionLeaks bool
+	// ExecutionGraceBlocks is the number of blocks a ready execution can stay unchecked before it's stuck, see AuditExecutions
+	ExecutionGraceBlocks int64
 }
 
 var runtimeKeyGenMux sync.Mutex
@@ -79,6 +145,36 @@ var FixtureTestOpts = TestOptions{
 	VerifyOnly: false,
 }
 
+// CheckCookbookUpdateVersion is a function to check update doesn't lower version of the on-chain cookbook
+// Update keeping the version is allowed for metadata only changes, other updates should carry a higher version.
+func CheckCookbookUpdateVersion(msg types.MsgUpdateCookbook, t *testing.T) {
+	cookbook, err := inttest.GetCookbookByGUID(msg.ID)
+	t.WithFields(testing.Fields{
+		"cookbook_id": msg.ID,
+	}).MustNil(err, "error getting cookbook to check version of update")
+	if cookbook.Version == msg.Version {
+		return
+	}
+	t.WithFields(testing.Fields{
+		"cookbook_id":     msg.ID,
+		"onchain_version": cookbook.Version,
+		"update_version":  msg.Version,
+	}).MustNil(inttest.CheckVersionIncreased(cookbook.Version, msg.Version), "cookbook update should carry a higher version than on-chain one")
+}
+
+// CheckCookbookVersion is a function to verify version of the on-chain cookbook, e.g. after MsgUpdateCookbook
+func CheckCookbookVersion(cookbookID, version string, t *testing.T) {
+	cookbook, err := inttest.GetCookbookByGUID(cookbookID)
+	t.WithFields(testing.Fields{
+		"cookbook_id": cookbookID,
+	}).MustNil(err, "error getting cookbook to check version")
+	t.WithFields(testing.Fields{
+		"cookbook_id":      cookbookID,
+		"onchain_version":  cookbook.Version,
+		"expected_version": version,
+	}).MustTrue(cookbook.Version == version, "cookbook version should be updated")
+}
+
 // CheckItemWithStringKeys checks if string keys are all available
 func CheckItemWithStringKeys(item types.Item, stringKeys []string) bool {
 	for _, sK := range stringKeys {
@@ -207,10 +303,13 @@ func PropertyExistCheck(step FixtureStep, t *testing.T) {
 			pOwnerAddr = ""
 		} else {
 			pOwnerAddr = GetAccountAddressFromTempName(pCheck.Owner, t)
+			if len(pCheck.Items) > 0 || len(pCheck.Coins) > 0 {
+				inttest.CaptureInventory(t, pCheck.Owner, pOwnerAddr, inttest.WithEnv(FixtureEnv()))
+			}
 		}
 		if len(pCheck.Cookbooks) > 0 {
 			for _, cbName := range pCheck.Cookbooks {
-				_, exist, err := inttest.GetCookbookIDFromName(cbName, pOwnerAddr)
+				_, exist, err := inttest.GetCookbookIDFromName(cbName, pOwnerAddr, inttest.WithEnv(FixtureEnv()))
 				t.MustNil(err, "error checking cookbook existance")
 				if !shouldNotExist {
 					if exist {
@@ -235,7 +334,7 @@ func PropertyExistCheck(step FixtureStep, t *testing.T) {
 		}
 		if len(pCheck.Recipes) > 0 {
 			for _, rcpName := range pCheck.Recipes {
-				guid, err := inttest.GetRecipeGUIDFromName(rcpName, pOwnerAddr)
+				guid, err := inttest.GetRecipeGUIDFromName(rcpName, pOwnerAddr, inttest.WithEnv(FixtureEnv()))
 				t.MustNil(err, "error checking if recipe already exist")
 
 				if !shouldNotExist {
@@ -259,6 +358,22 @@ func PropertyExistCheck(step FixtureStep, t *testing.T) {
 				}
 			}
 		}
+		if len(pCheck.Trades) > 0 {
+			trades, err := inttest.ListTradeViaCLI(pOwnerAddr, inttest.WithEnv(FixtureEnv()))
+			t.MustNil(err, "error listing trades")
+			for _, trdInfo := range pCheck.Trades {
+				_, exist := inttest.FindTradeFromArrayByExtraInfo(trades, trdInfo)
+				if !shouldNotExist {
+					t.WithFields(testing.Fields{
+						"trade_info": trdInfo,
+					}).MustTrue(exist, "trade does not exist, but should exist")
+				} else {
+					t.WithFields(testing.Fields{
+						"trade_info": trdInfo,
+					}).MustTrue(!exist, "trade exist, but shouldn't exist")
+				}
+			}
+		}
 		if len(pCheck.Items) > 0 {
 			for _, itemCheck := range pCheck.Items {
 				fitItemExist := false
@@ -266,7 +381,7 @@ func PropertyExistCheck(step FixtureStep, t *testing.T) {
 				// 	// "id": idx,
 				// 	"item_spec": itemCheck,
 				// }).Info("checking item")
-				items, err := inttest.ListItemsViaCLI(pOwnerAddr)
+				items, err := inttest.ListItemsViaCLI(pOwnerAddr, inttest.WithEnv(FixtureEnv()))
 				t.MustNil(err, "error listing items")
 
 				for _, item := range items {
@@ -308,7 +423,11 @@ func PropertyExistCheck(step FixtureStep, t *testing.T) {
 		}
 		if len(pCheck.Coins) > 0 {
 			for _, coinCheck := range pCheck.Coins {
-				accBalance := inttest.GetAccountBalanceFromAddr(pOwnerAddr, t)
+				accBalance := inttest.GetAccountBalanceFromAddr(pOwnerAddr, t, inttest.WithEnv(FixtureEnv()))
+				t.WithFields(testing.Fields{
+					"owner_address": pOwnerAddr,
+					"denom":         coinCheck.Coin,
+				}).MustNil(inttest.CheckBalanceDenom(inttest.ContextWithEnv(context.Background(), FixtureEnv()), coinCheck.Coin, accBalance.Coins), "balance check has unknown denom")
 				// TODO should we have the case of using GTE, LTE, GT or LT ?
 				t.WithFields(testing.Fields{
 					"target_balance": coinCheck.Amount,
@@ -326,27 +445,90 @@ func ProcessSingleFixtureQueueItem(file string, idx int, fixtureSteps []FixtureS
 		if FixtureTestOpts.IsParallel {
 			t.Parallel()
 		}
-		if step.RunAfter.BlockWait > 0 {
-			err := inttest.WaitForBlockInterval(step.RunAfter.BlockWait)
+		state := StepPassed
+		skipReason := ""
+		resumed := false
+		startedAt := time.Now()
+		span := StartStepSpan(file, step, t)
+		defer func() {
+			if state == StepPassed && t.Failed() {
+				state = StepFailed
+			}
+			if state == StepPassed && !resumed && !FixtureTestOpts.DryRun && !FixtureTestOpts.InMemory {
+				if err := CheckpointStep(file, step); err != nil {
+					t.WithFields(testing.Fields{
+						"checkpoint": FixtureTestOpts.CheckpointFile,
+						"error":      err,
+					}).Warn("error recording step into checkpoint")
+				}
+			}
+			FixtureRunStatus.StepFinished(file, step, state)
+			EndStepSpan(span, state)
+			WriteStepSummary(file, step, state, time.Since(startedAt), skipReason, t)
+		}()
+		// deferred after the above so that hooks failing the test are reflected in the step state
+		defer func() {
+			if resumed {
+				return
+			}
+			hookState := state
+			if hookState == StepPassed && t.Failed() {
+				hookState = StepFailed
+			}
+			RunAfterStepHooks(file, step, hookState, time.Since(startedAt), t)
+		}()
+		// deferred last so that panics of the step are failures when the above record the step state
+		defer RecoverStepPanic(file, step, t)
+		if skipState, reason := GetStepSkipState(file, step); skipState != "" {
+			state, skipReason = skipState, reason
+			UpdateWorkQueueStatus(file, idx, fixtureSteps, Done, t)
+			t.WithFields(testing.Fields{
+				"state": skipState,
+			}).Skip(reason)
+		}
+		if FixtureTestOpts.Resume && IsStepCompleted(file, step.ID) {
+			resumed, skipReason = true, "step passed before checkpoint"
+			UpdateWorkQueueStatus(file, idx, fixtureSteps, Done, t)
+			t.Log("resumed step", step.ID, "passed before checkpoint")
+			return
+		}
+		if step.RunAfter.BlockWait > 0 && FixtureTestOpts.InMemory {
+			InMemoryChain.AdvanceBlocks(step.RunAfter.BlockWait)
+		} else if step.RunAfter.BlockWait > 0 && !FixtureTestOpts.DryRun {
+			FixtureRunStatus.StepWaiting(file, step)
+			err := inttest.WaitForBlockIntervalCtx(inttest.TestContext(t), step.RunAfter.BlockWait)
 			t.MustNil(err, "error waiting for block interval")
 		}
-		RunActionRunner(step.Action, step, t)
-		PropertyExistCheck(step, t)
+		if inttest.ShuttingDown() {
+			// shutdown started while the step waited, it's run when the suite is resumed
+			state, skipReason = StepSkipped, "run is shutting down"
+			UpdateWorkQueueStatus(file, idx, fixtureSteps, Done, t)
+			t.Skip(skipReason)
+		}
+		if !FixtureTestOpts.DryRun && !FixtureTestOpts.InMemory {
+			RebaselineAfterChainReset(t)
+		}
+		RunBeforeStepHooks(file, step, t)
+		FixtureRunStatus.StepStarted(file, step)
+		if FixtureTestOpts.DryRun {
+			RunDryRunStep(file, step, t)
+		} else if FixtureTestOpts.InMemory {
+			RunInMemoryStep(file, step, t)
+			PropertyExistCheck(step, t)
+		} else {
+			RunActionRunner(step.Action, step, t)
+			PropertyExistCheck(step, t)
+		}
 		UpdateWorkQueueStatus(file, idx, fixtureSteps, Done, t)
 	})
 }
 
 // RunRegisterWorkQueuesForSingleFixture is function to add queue items before running whole test
 func RunRegisterWorkQueuesForSingleFixture(file string, t *testing.T) {
-	var fixtureSteps []FixtureStep
-	byteValue := ReadFile(file, t)
-
-	err := json.Unmarshal([]byte(byteValue), &fixtureSteps)
-	t.WithFields(testing.Fields{
-		"raw_json": string(byteValue),
-	}).MustNil(err, "error decoding fixture steps")
+	fixtureSteps := ReadFixtureScenario(file, t).Steps
 
 	CheckSteps(fixtureSteps, t)
+	FixtureRunStatus.RegisterFixture(file, len(fixtureSteps))
 
 	for idx, step := range fixtureSteps {
 		workQueues = append(workQueues, QueueItem{
@@ -360,18 +542,15 @@ func RunRegisterWorkQueuesForSingleFixture(file string, t *testing.T) {
 
 // RunSingleFixtureTest add a work queue into fixture test runner and execute work queues
 func RunSingleFixtureTest(file string, t *testing.T) {
-	var fixtureSteps []FixtureStep
-	byteValue := ReadFile(file, t)
-
-	err := json.Unmarshal([]byte(byteValue), &fixtureSteps)
-	t.WithFields(testing.Fields{
-		"raw_json": string(byteValue),
-	}).MustNil(err, "error decoding fixture steps")
+	fixtureSteps := ReadFixtureScenario(file, t).Steps
 
 	t.Run(file, func(t *testing.T) {
 		if FixtureTestOpts.IsParallel {
 			t.Parallel()
 		}
+		SetScenarioOfTest(file, t)
+		StartScenarioSpan(file, t)
+		RecordScenarioFingerprints(file, t)
 
 		for idx := range fixtureSteps {
 			UpdateWorkQueueStatus(file, idx, fixtureSteps, InProgress, t)
@@ -379,32 +558,170 @@ func RunSingleFixtureTest(file string, t *testing.T) {
 	})
 }
 
-// RunTestScenarios execute all scenarios
-func RunTestScenarios(scenarioDir string, scenarioFileNames []string, t *originT.T) {
-	newT := testing.NewT(t)
-
-	// Register default accounts configured into runtime key mapping
-	RegisterDefaultAccountKeys()
-
-	var files []string
-
-	scenarioDirectory := path.Join(FixtureTestOpts.BaseDirectory, scenarioDir)
-	err := filepath.Walk(scenarioDirectory, func(path string, info os.FileInfo, err error) error {
+// ScenarioFiles is a function to get json files of scenario directory under base directory, all files for empty names
+// Files are returned relative to base directory, so they're read by FixturePath like other fixture file references.
+func ScenarioFiles(scenarioDir string, scenarioFileNames []string) ([]string, error) {
+	files := []string{}
+	baseDir := FixturePath("")
+	err := filepath.Walk(FixturePath(scenarioDir), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
 		if filepath.Ext(path) != ".json" {
 			return nil
 		}
 		scenarioName := strings.TrimSuffix(info.Name(), ".json")
-		t.Log(fmt.Sprintf("checking %s from %+v", scenarioName, scenarioFileNames))
 		if len(scenarioFileNames) != 0 && !inttest.Exists(scenarioFileNames, scenarioName) {
 			return nil
 		}
-		t.Log("added", scenarioName)
-		files = append(files, path)
+		file, err := filepath.Rel(baseDir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(file))
 		return nil
 	})
+	return files, err
+}
+
+// RunTestScenarios execute all scenarios
+func RunTestScenarios(scenarioDir string, scenarioFileNames []string, t *originT.T) {
+	newT := testing.NewT(t)
+
+	if len(FixtureTestOpts.StatusServerAddr) > 0 {
+		server := StartStatusServer(FixtureTestOpts.StatusServerAddr)
+		// parallel scenarios finish after this function returns, so close the server on cleanup
+		t.Cleanup(func() {
+			server.Close()
+		})
+	}
+
+	t.Cleanup(func() {
+		CheckStepStatePolicy(t)
+	})
+
+	t.Cleanup(func() {
+		if sig := inttest.GlobalShutdown.Signal(); sig != nil {
+			t.Errorf("run is interrupted by %s, steps not started are skipped, run again with -resume to continue from checkpoint", sig)
+		}
+	})
+
+	t.Cleanup(func() {
+		newT.WithFields(testing.Fields{
+			"command_pool": inttest.GetCommandPoolStats(),
+		}).Info("pylonsd command pool stats")
+	})
+
+	t.Log("test data seed", inttest.GetTestDataSeed())
+
+	// Register default accounts configured into runtime key mapping
+	RegisterDefaultAccountKeys()
+	if FixtureTestOpts.InMemory {
+		FundInMemoryAccounts(&newT)
+	}
+
+	if FixtureTestOpts.Resume && !FixtureTestOpts.DryRun && !FixtureTestOpts.InMemory {
+		if len(FixtureTestOpts.CheckpointFile) == 0 {
+			newT.Fatal("checkpoint file should be set to resume")
+		}
+		loaded, err := LoadCheckpoint(FixtureTestOpts.CheckpointFile, &newT)
+		if err != nil {
+			newT.Fatal(err.Error())
+		}
+		if !loaded {
+			t.Log("checkpoint", FixtureTestOpts.CheckpointFile, "does not exist, running all steps")
+		}
+	}
+
+	// accounts and genesis fixtures are restored before the next step when the chain is reset while scenarios run
+	WatchChainReset()
+
+	RegisterChaosRestarts()
+
+	// fail before scenarios run instead of failing steps on decoding errors of outputs
+	if FixtureTestOpts.InMemory {
+		t.Log("running scenarios against in-memory chain")
+	} else if err := inttest.NodeVersionCheck(context.Background(), &newT); err != nil {
+		newT.Fatal(err.Error())
+	}
+
+	if FixtureTestOpts.DryRun {
+		t.Cleanup(func() {
+			LogDryRunReport(&newT)
+		})
+	}
+
+	if FixtureTestOpts.Cleanup && !FixtureTestOpts.DryRun && !FixtureTestOpts.InMemory {
+		// registered before state guard so that teardown runs after the state diff
+		t.Cleanup(func() {
+			if inttest.ShuttingDown() {
+				// resumed run refers recipes, trades and items created by steps
+				t.Log("teardown is skipped as the run is shutting down")
+				return
+			}
+			itemReceiver := ""
+			if len(FixtureTestOpts.CleanupItemReceiver) > 0 {
+				itemReceiver = GetAccountAddressFromTempName(FixtureTestOpts.CleanupItemReceiver, &newT)
+			}
+			FixtureCleanup.Teardown(context.Background(), itemReceiver, &newT)
+		})
+	}
+
+	if len(FixtureTestOpts.StateGuardAccounts) > 0 {
+		GuardAccountsState(FixtureTestOpts.StateGuardAccounts, t, &newT)
+	}
+
+	if FixtureTestOpts.AuditExecutions && !FixtureTestOpts.DryRun && !FixtureTestOpts.InMemory {
+		AuditExecutionLeaks(t, &newT)
+	}
+
+	if FixtureTestOpts.ModelCheck && !FixtureTestOpts.DryRun && !FixtureTestOpts.InMemory {
+		CheckStateModel(t)
+	}
+
+	// budgets are checked against transactions of all steps when scenarios finish
+	for msgType, limit := range FixtureTestOpts.GasBudgets {
+		newT.ExpectGasAtMost(msgType, limit)
+	}
+
+	files, err := ScenarioFiles(scenarioDir, scenarioFileNames)
 	if err != nil {
 		t.Fatal("error walking through scenario directory", err)
 	}
+	t.Log("added scenarios", files)
+	if len(FixtureTestOpts.Tags) > 0 {
+		var skipped []string
+		files, skipped, err = FilterScenariosByTags(files, FixtureTestOpts.Tags)
+		if err != nil {
+			newT.Fatal(err.Error())
+		}
+		for _, file := range skipped {
+			FixtureRunStatus.SkipFixture(file)
+		}
+		newT.WithFields(testing.Fields{
+			"tags":     FixtureTestOpts.Tags,
+			"selected": files,
+			"skipped":  skipped,
+		}).Info("scenarios selected by tags")
+	}
+	if FixtureTestOpts.ScenarioPageSize > 0 {
+		var skipped []string
+		files, skipped, err = PageScenarios(files, FixtureTestOpts.ScenarioPage, FixtureTestOpts.ScenarioPageSize)
+		if err != nil {
+			newT.Fatal(err.Error())
+		}
+		for _, file := range skipped {
+			FixtureRunStatus.SkipFixture(file)
+		}
+		newT.WithFields(testing.Fields{
+			"page":      FixtureTestOpts.ScenarioPage,
+			"page_size": FixtureTestOpts.ScenarioPageSize,
+			"selected":  files,
+		}).Info("scenarios selected by page")
+	}
+	// check all fixture files up front not to fail deep inside step execution
+	ValidateFixtureFiles(files, &newT)
+
 	for _, file := range files {
 		t.Log("Registering work queues for scenario path=", file)
 		RunRegisterWorkQueuesForSingleFixture(file, &newT)
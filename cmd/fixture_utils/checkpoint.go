@@ -0,0 +1,234 @@
+package fixturetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+// checkpointVersion is the version of checkpoint file format
+const checkpointVersion = 1
+
+// checkpointAccount is a struct to keep account created on chain by steps in a checkpoint
+type checkpointAccount struct {
+	TempName string `json:"tempName"`
+	Funded   bool   `json:"funded"`
+}
+
+// checkpointState is a struct to describe a checkpoint file
+// It has passed steps per fixture file and everything later steps refer from them.
+type checkpointState struct {
+	Version        int                                     `json:"version"`
+	Seed           int64                                   `json:"seed"`
+	CompletedSteps map[string][]string                     `json:"completedSteps"`
+	Results        map[string]map[string]registeredResult  `json:"results"`     // by scenario file and result name
+	StepOutputs    map[string]map[string]map[string]string `json:"stepOutputs"` // by scenario file and step ID
+	ExecIDs        map[string]string                       `json:"execIDs"`
+	AccountKeys    map[string]string                       `json:"accountKeys"`
+	ChainAccounts  []checkpointAccount                     `json:"chainAccounts"`
+	RenderedFiles  map[string]map[string][]byte            `json:"renderedFiles"` // by scenario file and params file
+	Cleanup        cleanupSnapshot                         `json:"cleanup"`
+}
+
+// checkpointMux is locked while completed steps change and checkpoint file is written
+var checkpointMux sync.Mutex
+var completedSteps = make(map[string]map[string]bool)
+
+// IsStepCompleted is a function to check if step of file passed in a run recorded by checkpoint
+func IsStepCompleted(file string, stepID string) bool {
+	checkpointMux.Lock()
+	defer checkpointMux.Unlock()
+	return completedSteps[file][stepID]
+}
+
+// snapshotCheckpoint is a function to copy fixture state into checkpoint, checkpointMux should be locked
+func snapshotCheckpoint() checkpointState {
+	state := checkpointState{
+		Version:        checkpointVersion,
+		Seed:           inttest.GetTestDataSeed(),
+		CompletedSteps: make(map[string][]string),
+		Results:        make(map[string]map[string]registeredResult),
+		StepOutputs:    make(map[string]map[string]map[string]string),
+		ExecIDs:        make(map[string]string),
+		AccountKeys:    make(map[string]string),
+		ChainAccounts:  []checkpointAccount{},
+		RenderedFiles:  make(map[string]map[string][]byte),
+		Cleanup:        FixtureCleanup.snapshot(),
+	}
+	for file, steps := range completedSteps {
+		for stepID := range steps {
+			state.CompletedSteps[file] = append(state.CompletedSteps[file], stepID)
+		}
+	}
+	scenarioStatesMux.Lock()
+	for file, scenario := range scenarioStates {
+		scenario.mux.RLock()
+		state.Results[file] = make(map[string]registeredResult)
+		for name, result := range scenario.results {
+			state.Results[file][name] = result
+		}
+		state.StepOutputs[file] = make(map[string]map[string]string)
+		for stepID, outputs := range scenario.stepOutputs {
+			state.StepOutputs[file][stepID] = make(map[string]string)
+			for key, value := range outputs {
+				state.StepOutputs[file][stepID][key] = value
+			}
+		}
+		state.RenderedFiles[file] = make(map[string][]byte)
+		for name, rendered := range scenario.renderedFiles {
+			state.RenderedFiles[file][name] = rendered
+		}
+		scenario.mux.RUnlock()
+	}
+	scenarioStatesMux.Unlock()
+	execIDRWMutex.Lock()
+	for stepID, execID := range execIDs {
+		state.ExecIDs[stepID] = execID
+	}
+	execIDRWMutex.Unlock()
+	runtimeKeyGenMux.Lock()
+	for tempName, key := range runtimeAccountKeys {
+		state.AccountKeys[tempName] = key
+	}
+	runtimeKeyGenMux.Unlock()
+	chainAccountsMux.Lock()
+	for _, account := range chainAccounts {
+		state.ChainAccounts = append(state.ChainAccounts, checkpointAccount{TempName: account.tempName, Funded: account.funded})
+	}
+	chainAccountsMux.Unlock()
+	return state
+}
+
+// restoreCheckpoint is a function to put fixture state of checkpoint back, checkpointMux should be locked
+func restoreCheckpoint(state checkpointState) {
+	for file, stepIDs := range state.CompletedSteps {
+		if _, ok := completedSteps[file]; !ok {
+			completedSteps[file] = make(map[string]bool)
+		}
+		for _, stepID := range stepIDs {
+			completedSteps[file][stepID] = true
+		}
+	}
+	for file, results := range state.Results {
+		scenario := getScenarioState(file)
+		scenario.mux.Lock()
+		for name, result := range results {
+			scenario.results[name] = result
+		}
+		scenario.mux.Unlock()
+	}
+	for file, stepOutputs := range state.StepOutputs {
+		scenario := getScenarioState(file)
+		for stepID, outputs := range stepOutputs {
+			for key, value := range outputs {
+				scenario.setStepOutput(stepID, key, value)
+			}
+		}
+	}
+	execIDRWMutex.Lock()
+	for stepID, execID := range state.ExecIDs {
+		execIDs[stepID] = execID
+	}
+	execIDRWMutex.Unlock()
+	runtimeKeyGenMux.Lock()
+	for tempName, key := range state.AccountKeys {
+		runtimeAccountKeys[tempName] = key
+	}
+	runtimeKeyGenMux.Unlock()
+	for _, account := range state.ChainAccounts {
+		rememberChainAccount(account.TempName, account.Funded)
+	}
+	for file, renderedFiles := range state.RenderedFiles {
+		scenario := getScenarioState(file)
+		scenario.mux.Lock()
+		for name, rendered := range renderedFiles {
+			scenario.renderedFiles[name] = rendered
+		}
+		scenario.mux.Unlock()
+	}
+	FixtureCleanup.restore(state.Cleanup)
+}
+
+// writeCheckpoint is a function to write checkpoint file atomically so that an interrupted write keeps the previous one
+func writeCheckpoint(file string, state checkpointState) error {
+	bz, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpFile := file + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, bz, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, file)
+}
+
+// CheckpointStep is a function to record step of file as completed into FixtureTestOpts.CheckpointFile
+// with results, outputs, accounts and created entities which later steps refer, nothing is written when it's empty.
+func CheckpointStep(file string, step FixtureStep) error {
+	checkpointMux.Lock()
+	defer checkpointMux.Unlock()
+	if _, ok := completedSteps[file]; !ok {
+		completedSteps[file] = make(map[string]bool)
+	}
+	completedSteps[file][step.ID] = true
+	if len(FixtureTestOpts.CheckpointFile) == 0 {
+		return nil
+	}
+	return writeCheckpoint(FixtureTestOpts.CheckpointFile, snapshotCheckpoint())
+}
+
+// WriteCheckpoint is a function to write completed steps and fixture state into FixtureTestOpts.CheckpointFile
+// It's run by suite shutdown so that the checkpoint has steps concluded after the last recorded one before exiting.
+func WriteCheckpoint() error {
+	if len(FixtureTestOpts.CheckpointFile) == 0 || FixtureTestOpts.DryRun || FixtureTestOpts.InMemory {
+		return nil
+	}
+	checkpointMux.Lock()
+	defer checkpointMux.Unlock()
+	return writeCheckpoint(FixtureTestOpts.CheckpointFile, snapshotCheckpoint())
+}
+
+// LoadCheckpoint is a function to restore fixture state of checkpoint file so that completed steps are not run again
+// It returns false when the file does not exist e.g. the interrupted run crashed before its first step passed.
+func LoadCheckpoint(file string, t *testing.T) (bool, error) {
+	bz, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error reading checkpoint: %w", err)
+	}
+	var state checkpointState
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	// registered results are kept as json.Number as RegisterStepResults does
+	dec.UseNumber()
+	if err := dec.Decode(&state); err != nil {
+		return false, fmt.Errorf("error decoding checkpoint %s: %w", file, err)
+	}
+	if state.Version != checkpointVersion {
+		return false, fmt.Errorf("checkpoint %s has version %d, but %d is supported", file, state.Version, checkpointVersion)
+	}
+	if seed := inttest.GetTestDataSeed(); seed != state.Seed {
+		t.WithFields(testing.Fields{
+			"checkpoint_seed": state.Seed,
+			"seed":            seed,
+		}).Warn("checkpoint was recorded with another seed, run with -seed to get the same test data in steps not rendered yet")
+	}
+	checkpointMux.Lock()
+	defer checkpointMux.Unlock()
+	restoreCheckpoint(state)
+	return true, nil
+}
+
+// forgetCompletedSteps is a function to run all steps again e.g. after chain reset dropped what they created
+func forgetCompletedSteps() {
+	checkpointMux.Lock()
+	defer checkpointMux.Unlock()
+	completedSteps = make(map[string]map[string]bool)
+}
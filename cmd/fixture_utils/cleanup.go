@@ -0,0 +1,232 @@
+package fixturetest
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// createdEntity is a struct to keep id of entity created by fixture step and its owner
+type createdEntity struct {
+	ID    string
+	Owner string
+}
+
+// CleanupRegistry is a struct to collect cookbooks, recipes, trades and items created by fixture steps
+// so that suite teardown can retire them on a shared node
+type CleanupRegistry struct {
+	mux       sync.Mutex
+	cookbooks []createdEntity
+	recipes   []createdEntity
+	trades    []createdEntity
+	items     []createdEntity
+}
+
+// FixtureCleanup is a variable to have entities created by fixture steps of current run
+var FixtureCleanup = &CleanupRegistry{}
+
+// RegisterCookbook is a function to register cookbook created by fixture step
+func (r *CleanupRegistry) RegisterCookbook(cookbookID, sender string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.cookbooks = append(r.cookbooks, createdEntity{ID: cookbookID, Owner: sender})
+}
+
+// RegisterRecipe is a function to register recipe created by fixture step
+func (r *CleanupRegistry) RegisterRecipe(recipeID, sender string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.recipes = append(r.recipes, createdEntity{ID: recipeID, Owner: sender})
+}
+
+// RegisterTrade is a function to register trade created by fixture step
+func (r *CleanupRegistry) RegisterTrade(tradeID, sender string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.trades = append(r.trades, createdEntity{ID: tradeID, Owner: sender})
+}
+
+// RegisterItems is a function to register items created for owner by fixture step
+func (r *CleanupRegistry) RegisterItems(owner string, itemIDs ...string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	for _, itemID := range itemIDs {
+		r.items = append(r.items, createdEntity{ID: itemID, Owner: owner})
+	}
+}
+
+// RegisterExecutionOutput is a function to register items created by straight recipe execution output
+func (r *CleanupRegistry) RegisterExecutionOutput(owner string, output []byte) {
+	var entries []types.ExecuteRecipeSerialize
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.Type == "ITEM" && entry.ItemID != "" {
+			r.RegisterItems(owner, entry.ItemID)
+		}
+	}
+}
+
+// Reset is a function to forget all registered entities
+func (r *CleanupRegistry) Reset() {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.cookbooks = nil
+	r.recipes = nil
+	r.trades = nil
+	r.items = nil
+}
+
+// cleanupSnapshot is a struct to keep registered entities in a checkpoint
+type cleanupSnapshot struct {
+	Cookbooks []createdEntity `json:"cookbooks"`
+	Recipes   []createdEntity `json:"recipes"`
+	Trades    []createdEntity `json:"trades"`
+	Items     []createdEntity `json:"items"`
+}
+
+// snapshot is a function to copy registered entities
+func (r *CleanupRegistry) snapshot() cleanupSnapshot {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return cleanupSnapshot{
+		Cookbooks: append([]createdEntity{}, r.cookbooks...),
+		Recipes:   append([]createdEntity{}, r.recipes...),
+		Trades:    append([]createdEntity{}, r.trades...),
+		Items:     append([]createdEntity{}, r.items...),
+	}
+}
+
+// restore is a function to register entities of a checkpoint again
+func (r *CleanupRegistry) restore(snapshot cleanupSnapshot) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.cookbooks = append(r.cookbooks, snapshot.Cookbooks...)
+	r.recipes = append(r.recipes, snapshot.Recipes...)
+	r.trades = append(r.trades, snapshot.Trades...)
+	r.items = append(r.items, snapshot.Items...)
+}
+
+// take is a function to get registered entities and reset registry
+func (r *CleanupRegistry) take() (cookbooks, recipes, trades, items []createdEntity) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	cookbooks, recipes, trades, items = r.cookbooks, r.recipes, r.trades, r.items
+	r.cookbooks, r.recipes, r.trades, r.items = nil, nil, nil, nil
+	return
+}
+
+// CleanupReport is a struct to summarize what suite teardown retired
+type CleanupReport struct {
+	DisabledRecipes int
+	DisabledTrades  int
+	MovedItems      int
+	DiscardedItems  int
+	Failures        int
+}
+
+// Fields is a function to get log fields of cleanup report
+func (r CleanupReport) Fields() testing.Fields {
+	return testing.Fields{
+		"disabled_recipes": r.DisabledRecipes,
+		"disabled_trades":  r.DisabledTrades,
+		"moved_items":      r.MovedItems,
+		"discarded_items":  r.DiscardedItems,
+		"failures":         r.Failures,
+	}
+}
+
+// Teardown is a function to disable registered recipes and open trades and move registered items to itemReceiver.
+// Items are only dropped from registry when itemReceiver is empty as pylons has no message to burn items.
+// Cookbooks can't be removed, they stay on chain with all their recipes disabled.
+// Failures are logged and don't stop teardown, each retired entity is forgotten so repeated teardown is no-op.
+func (r *CleanupRegistry) Teardown(ctx context.Context, itemReceiver string, t *testing.T) CleanupReport {
+	report := CleanupReport{}
+	cookbooks, recipes, trades, items := r.take()
+	client := inttest.NewClient()
+	send := func(sender string, msg sdk.Msg, fields testing.Fields) bool {
+		_, err := client.SendTxAndWait(ctx, t, inttest.SignerAddress(sender), msg)
+		if err != nil {
+			report.Failures++
+			t.WithFields(fields).WithFields(testing.Fields{
+				"sender": sender,
+				"error":  err.Error(),
+			}).Error("cleanup transaction failed")
+			return false
+		}
+		return true
+	}
+
+	// trades are disabled before items are moved as open trades lock their items
+	ownerTrades := map[string][]types.Trade{}
+	for _, trade := range trades {
+		if _, ok := ownerTrades[trade.Owner]; !ok {
+			// trade which can't be listed is still tried to be disabled
+			ownerTrades[trade.Owner], _ = inttest.ListTradeViaCLI(trade.Owner)
+		}
+		if closedTrade(ownerTrades[trade.Owner], trade.ID) {
+			continue
+		}
+		msg := types.NewMsgDisableTrade(trade.ID, trade.Owner)
+		if send(trade.Owner, &msg, testing.Fields{"trade_id": trade.ID}) {
+			report.DisabledTrades++
+		}
+	}
+
+	for _, recipe := range recipes {
+		rcp, err := inttest.GetRecipeByGUID(recipe.ID)
+		if err == nil && rcp.Disabled {
+			continue
+		}
+		msg := types.NewMsgDisableRecipe(recipe.ID, recipe.Owner)
+		if send(recipe.Owner, &msg, testing.Fields{"recipe_id": recipe.ID}) {
+			report.DisabledRecipes++
+		}
+	}
+
+	itemsByOwner := map[string][]string{}
+	owners := []string{}
+	for _, item := range items {
+		if len(itemReceiver) == 0 {
+			report.DiscardedItems++
+			continue
+		}
+		itm, err := inttest.GetItemByGUID(item.ID)
+		// items already sent, traded or used by scenarios are not owned by creator anymore
+		if err != nil || itm.Sender != item.Owner || itm.Sender == itemReceiver || len(itm.OwnerRecipeID) != 0 || len(itm.OwnerTradeID) != 0 {
+			report.DiscardedItems++
+			continue
+		}
+		if _, ok := itemsByOwner[item.Owner]; !ok {
+			owners = append(owners, item.Owner)
+		}
+		itemsByOwner[item.Owner] = append(itemsByOwner[item.Owner], item.ID)
+	}
+	for _, owner := range owners {
+		msg := types.NewMsgSendItems(itemsByOwner[owner], owner, itemReceiver)
+		if send(owner, &msg, testing.Fields{"item_ids": itemsByOwner[owner]}) {
+			report.MovedItems += len(itemsByOwner[owner])
+		}
+	}
+
+	t.WithFields(report.Fields()).WithFields(testing.Fields{
+		"cookbooks": len(cookbooks),
+	}).Info("fixture test data teardown")
+	return report
+}
+
+// closedTrade is a function to check if trade is already completed or disabled
+func closedTrade(trades []types.Trade, tradeID string) bool {
+	for _, trd := range trades {
+		if trd.ID == tradeID {
+			return trd.Completed || trd.Disabled
+		}
+	}
+	return false
+}
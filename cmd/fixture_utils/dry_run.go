@@ -0,0 +1,168 @@
+package fixturetest
+
+import (
+	"context"
+	"sync"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DryRunEstimate is a struct to describe gas estimated by simulating transaction of a fixture step
+type DryRunEstimate struct {
+	File      string   `json:"file"`
+	StepID    string   `json:"step_id"`
+	Action    string   `json:"action"`
+	Msgs      []string `json:"msgs"`
+	GasWanted uint64   `json:"gas_wanted"`
+	GasUsed   uint64   `json:"gas_used"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// DryRunEstimates is a struct to collect gas estimates of steps simulated in dry run
+type DryRunEstimates struct {
+	mux       sync.Mutex
+	estimates []DryRunEstimate
+}
+
+// DryRunReport is a variable to have gas estimates of steps simulated in dry run
+var DryRunReport = &DryRunEstimates{}
+
+// Add is a function to add gas estimate of a step
+func (r *DryRunEstimates) Add(estimate DryRunEstimate) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.estimates = append(r.estimates, estimate)
+}
+
+// List is a function to get gas estimates of steps in the order they are simulated
+func (r *DryRunEstimates) List() []DryRunEstimate {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return append([]DryRunEstimate{}, r.estimates...)
+}
+
+// LogDryRunReport is a function to log gas estimated for each step simulated in dry run and total gas
+func LogDryRunReport(t *testing.T) {
+	var totalGas uint64
+	failed := 0
+	for _, estimate := range DryRunReport.List() {
+		totalGas += estimate.GasUsed
+		if len(estimate.Error) > 0 {
+			failed++
+		}
+		t.WithFields(testing.Fields{
+			"file":       estimate.File,
+			"step_id":    estimate.StepID,
+			"action":     estimate.Action,
+			"msgs":       estimate.Msgs,
+			"gas_wanted": estimate.GasWanted,
+			"gas_used":   estimate.GasUsed,
+			"error":      estimate.Error,
+		}).Info("dry run gas estimate")
+	}
+	t.WithFields(testing.Fields{
+		"simulated_steps": len(DryRunReport.List()),
+		"failed_steps":    failed,
+		"total_gas_used":  totalGas,
+	}).Info("dry run finished")
+}
+
+// addDryRunLocalKey is a function to add local key of account step creates, account is not created on chain in dry run
+func addDryRunLocalKey(accountRef string, t *testing.T) {
+	key := GetAccountKeyFromTempName(accountRef, t)
+	if _, err := inttest.AddNewLocalKey(key); err != nil {
+		t.WithFields(testing.Fields{
+			"key":   key,
+			"error": err,
+		}).Debug("local key is not added for dry run")
+	}
+}
+
+// DryRunStepMsgs is a function to read msgs of the transaction step sends with the sender of them
+// Composite actions return msgs of their first transaction and steps sending no transaction return no msgs.
+func DryRunStepMsgs(step FixtureStep, t *testing.T) ([]sdk.Msg, string) {
+	if step.ParamsRef == "" && len(step.MsgRefs) == 0 {
+		return nil, ""
+	}
+	switch step.Action {
+	case "create_account":
+		addDryRunLocalKey(step.ParamsRef, t)
+		return nil, ""
+	case "mock_account":
+		addDryRunLocalKey(step.ParamsRef, t)
+		msg, sender := ActionMsgFromRef("get_pylons", step.ParamsRef, t)
+		return []sdk.Msg{msg}, sender
+	case "mock_cookbook":
+		if !FixtureTestOpts.CreateNewCookbook {
+			return nil, ""
+		}
+		addDryRunLocalKey(GetSenderKeyFromRef(step.ParamsRef, t), t)
+		msg, sender := ActionMsgFromRef("create_cookbook", step.ParamsRef, t)
+		return []sdk.Msg{msg}, sender
+	case "execute_delayed_recipe":
+		msg, sender := ActionMsgFromRef("create_recipe", step.ParamsRef, t)
+		return []sdk.Msg{msg}, sender
+	case "pay_to_complete":
+		msg, sender := ActionMsgFromRef("check_execution", step.ParamsRef, t)
+		return []sdk.Msg{msg}, sender
+	case "multi_msg_tx":
+		var msgs []sdk.Msg
+		var sender string
+		for _, ref := range step.MsgRefs {
+			msg, msgSender := ActionMsgFromRef(ref.Action, ref.ParamsRef, t)
+			t.WithFields(testing.Fields{
+				"action": ref.Action,
+			}).MustTrue(msg != nil, "action can't be used in multi msg transaction")
+			msgs, sender = append(msgs, msg), msgSender
+		}
+		return msgs, sender
+	}
+	msg, sender := ActionMsgFromRef(step.Action, step.ParamsRef, t)
+	if msg == nil {
+		return nil, ""
+	}
+	return []sdk.Msg{msg}, sender
+}
+
+// RunDryRunStep is a function to validate msgs of step and simulate its transaction by CheckTx of node without broadcasting it
+// Simulation error is reported instead of failing the step, as state earlier steps would create is not committed in dry run.
+func RunDryRunStep(file string, step FixtureStep, t *testing.T) {
+	msgs, sender := DryRunStepMsgs(step, t)
+	if len(msgs) == 0 {
+		t.WithFields(testing.Fields{
+			"action": step.Action,
+		}).Info("step does not send transaction to simulate")
+		return
+	}
+	estimate := DryRunEstimate{
+		File:   file,
+		StepID: step.ID,
+		Action: step.Action,
+	}
+	for _, msg := range msgs {
+		ValidateStepMsg(step, step.ParamsRef, msg, t)
+		estimate.Msgs = append(estimate.Msgs, msg.Type())
+	}
+	result, err := inttest.SimulateTx(context.Background(), sender, msgs...)
+	estimate.GasWanted, estimate.GasUsed = result.GasWanted, result.GasUsed
+	expectFailure := step.ExpectError != nil || step.Output.TxResult.BroadcastError != "" || step.Output.TxResult.ErrorLog != ""
+	switch {
+	case err != nil && expectFailure:
+		t.WithFields(testing.Fields{
+			"error": err,
+		}).Info("simulation failed as step expects")
+	case err != nil:
+		estimate.Error = err.Error()
+		t.WithFields(testing.Fields{
+			"error": err,
+		}).Warn("simulation failed, step may depend on state earlier steps don't commit in dry run")
+	default:
+		t.WithFields(testing.Fields{
+			"gas_wanted": result.GasWanted,
+			"gas_used":   result.GasUsed,
+		}).Info("simulated step transaction")
+	}
+	DryRunReport.Add(estimate)
+}
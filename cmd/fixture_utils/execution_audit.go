@@ -0,0 +1,64 @@
+package fixturetest
+
+import (
+	"context"
+	"sort"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+// testAccountAddresses is a function to get addresses of accounts of temp names, keys which are not created are left out
+func testAccountAddresses() []string {
+	runtimeKeyGenMux.Lock()
+	keys := []string{}
+	for _, key := range runtimeAccountKeys {
+		keys = append(keys, key)
+	}
+	runtimeKeyGenMux.Unlock()
+	sort.Strings(keys)
+	addrs := []string{}
+	for _, key := range keys {
+		if addr, err := inttest.LookupAccountAddr(key); err == nil && !inttest.Exists(addrs, addr) {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// AuditExecutionLeaks is a function to classify executions scheduled by test accounts while scenarios run
+// and report the ones not completed when all scenarios finish, as scenarios scheduled them but never checked them.
+// Leaks fail the test with FixtureTestOpts.FailOnExecutionLeaks, otherwise they're logged as warnings.
+func AuditExecutionLeaks(t *originT.T, newT *testing.T) {
+	transport, err := inttest.GetTransport()
+	newT.MustNil(err, "error getting transport to audit executions")
+	sinceHeight, err := transport.LatestHeight(context.Background())
+	newT.MustNil(err, "error getting height executions are audited from")
+
+	// parallel scenarios finish after RunTestScenarios returns, so audit executions on cleanup
+	t.Cleanup(func() {
+		audit, err := inttest.AuditExecutions(context.Background(), testAccountAddresses(), sinceHeight+1, FixtureTestOpts.ExecutionGraceBlocks)
+		if err != nil {
+			t.Errorf("error auditing executions: %s", err.Error())
+			return
+		}
+		t.Log(audit.String())
+		for _, leak := range audit.Leaks() {
+			fields := testing.Fields{
+				"exec_id":        leak.ExecID,
+				"recipe_id":      leak.RecipeID,
+				"sender":         leak.Sender,
+				"state":          leak.State,
+				"block_height":   leak.BlockHeight,
+				"ready_height":   leak.ReadyHeight,
+				"blocks_overdue": leak.BlocksOverdue,
+			}
+			if FixtureTestOpts.FailOnExecutionLeaks {
+				t.Errorf("execution %s is %s, it's scheduled by a scenario which does not check it: %+v", leak.ExecID, leak.State, fields)
+			} else {
+				newT.WithFields(fields).Warn("execution is scheduled by a scenario which does not check it")
+			}
+		}
+	})
+}
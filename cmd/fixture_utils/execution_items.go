@@ -0,0 +1,132 @@
+package fixturetest
+
+import (
+	"sort"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ExecutionItemSpec is a struct to describe an item paid out by recipe execution in "newItems" or "modifiedItems" of step output
+type ExecutionItemSpec struct {
+	// ItemID is the ID of modified input item e.g. "{{.step1.item_id}}", any item fitting values is matched when it's empty
+	ItemID       string             `json:"itemID"`
+	StringValues map[string]string  `json:"stringValues"`
+	DblValues    map[string]sdk.Dec `json:"dblValues"`
+	LongValues   map[string]int64   `json:"longValues"`
+	// Changed are keys of all attributes changed on modified item, changes are not checked when it's not set
+	Changed []string `json:"changed"`
+}
+
+// hasExecutionItemSpecs is a function to check if step asserts new or modified items of execution
+func hasExecutionItemSpecs(step FixtureStep) bool {
+	return step.Output.NewItems != nil || step.Output.ModifiedItems != nil
+}
+
+// ExecutionItemInputs is a function to get item inputs before straight execution of step modifies them
+// It returns nil when step does not assert new or modified items.
+func ExecutionItemInputs(step FixtureStep, itemIDs []string, t *testing.T) []types.Item {
+	if !hasExecutionItemSpecs(step) {
+		return nil
+	}
+	inputs := []types.Item{}
+	for _, itemID := range itemIDs {
+		item, err := inttest.GetItemByGUID(itemID)
+		t.WithFields(testing.Fields{
+			"item_id": itemID,
+		}).MustNil(err, "error getting item input before execution")
+		inputs = append(inputs, item)
+	}
+	return inputs
+}
+
+// fitExecutionItemSpec is a function to check if item fits values of spec, and changes of modified item fit changed keys
+func fitExecutionItemSpec(spec ExecutionItemSpec, item types.Item, changedKeys []string) bool {
+	if len(spec.ItemID) > 0 && spec.ItemID != item.ID {
+		return false
+	}
+	if !CheckItemWithStringValues(item, spec.StringValues) ||
+		!CheckItemWithDblValues(item, spec.DblValues) ||
+		!CheckItemWithLongValues(item, spec.LongValues) {
+		return false
+	}
+	if spec.Changed == nil || changedKeys == nil {
+		return true
+	}
+	expected := append([]string{}, spec.Changed...)
+	sort.Strings(expected)
+	return strings.Join(expected, ",") == strings.Join(changedKeys, ",")
+}
+
+// matchExecutionItemSpecs is a function to match each spec to a distinct item, it returns indexes of specs not matched
+func matchExecutionItemSpecs(specs []ExecutionItemSpec, count int, fit func(spec ExecutionItemSpec, idx int) bool) []int {
+	used := make([]bool, count)
+	unmatched := []int{}
+	for specIdx, spec := range specs {
+		matched := false
+		for idx := 0; idx < count; idx++ {
+			if !used[idx] && fit(spec, idx) {
+				used[idx] = true
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, specIdx)
+		}
+	}
+	return unmatched
+}
+
+// ExecutionItemsCheck is a function to check new and modified items of execution output by specs of step output
+// Items are classified by inputs as they were before execution, each spec should match a distinct item and
+// the number of specs should be the number of items, so that "newItems": [] asserts execution mints no item.
+func ExecutionItemsCheck(step FixtureStep, inputs []types.Item, output []byte, t *testing.T) {
+	if !hasExecutionItemSpecs(step) {
+		return
+	}
+	result, err := inttest.DecodeExecutionItems(inputs, output)
+	t.WithFields(testing.Fields{
+		"output": string(output),
+	}).MustNil(err, "error classifying items of execution output")
+
+	if step.Output.NewItems != nil {
+		specs := step.Output.NewItems
+		unmatched := matchExecutionItemSpecs(specs, len(result.NewItems), func(spec ExecutionItemSpec, idx int) bool {
+			return fitExecutionItemSpec(spec, result.NewItems[idx], nil)
+		})
+		t.WithFields(testing.Fields{
+			"new_items":       inttest.JSONFormatter(result.NewItems),
+			"new_items_specs": inttest.JSONFormatter(specs),
+			"unmatched_specs": unmatched,
+		}).MustTrue(len(unmatched) == 0 && len(specs) == len(result.NewItems), "new items of execution do not fit newItems")
+	}
+	if step.Output.ModifiedItems != nil {
+		specs := step.Output.ModifiedItems
+		unmatched := matchExecutionItemSpecs(specs, len(result.ModifiedItems), func(spec ExecutionItemSpec, idx int) bool {
+			modified := result.ModifiedItems[idx]
+			return fitExecutionItemSpec(spec, modified.After, modified.ChangedKeys())
+		})
+		t.WithFields(testing.Fields{
+			"modified_items":       inttest.JSONFormatter(result.ModifiedItems),
+			"modified_items_specs": inttest.JSONFormatter(specs),
+			"unmatched_specs":      unmatched,
+		}).MustTrue(len(unmatched) == 0 && len(specs) == len(result.ModifiedItems), "modified items of execution do not fit modifiedItems")
+	}
+	t.WithFields(testing.Fields{
+		"new_items":      len(result.NewItems),
+		"modified_items": len(result.ModifiedItems),
+	}).Info("checked new and modified items of execution")
+}
+
+// ExecutionGoldenCheck is a function to compare snapshot of execution output against golden file of step output
+// Run with -update-goldens to write the snapshots after intended recipe changes.
+func ExecutionGoldenCheck(step FixtureStep, recipeID string, output []byte, t *testing.T) {
+	if step.Output.Golden == "" {
+		return
+	}
+	inttest.MustMatchExecutionGolden(t, FixturePath(step.Output.Golden), recipeID, output)
+}
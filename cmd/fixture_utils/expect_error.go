@@ -0,0 +1,61 @@
+package fixturetest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+// ExpectError describes the error a step should fail with, all the fields set should match
+type ExpectError struct {
+	Code      uint32 `json:"code"`      // abci code
+	Codespace string `json:"codespace"` // abci codespace, checked with code
+	Contains  string `json:"contains"`  // substring of error message or raw log
+	Class     string `json:"class"`     // failure class e.g. insufficient_funds, unauthorized, recipe_not_found
+}
+
+// Validate is a function to check expected error has at least one condition and a known class
+func (e ExpectError) Validate() error {
+	if e.Code == 0 && len(e.Codespace) == 0 && len(e.Contains) == 0 && len(e.Class) == 0 {
+		return errors.New("expectError should have code, codespace, contains or class")
+	}
+	if len(e.Class) > 0 {
+		if _, ok := inttest.ErrorClassByName(e.Class); !ok {
+			return fmt.Errorf("unknown error class %s", e.Class)
+		}
+	}
+	return nil
+}
+
+// Check is a function to check if error is the expected one, it returns error describing the mismatch
+func (e ExpectError) Check(err error) error {
+	if err == nil {
+		return errors.New("step succeeded but it is expected to fail")
+	}
+	var cmdErr *inttest.CommandError
+	if !errors.As(err, &cmdErr) {
+		// error before broadcast e.g. msg validation, class is detected from message
+		cmdErr = inttest.NewTxError("", 0, err.Error())
+	}
+	if e.Code != 0 && cmdErr.Code != e.Code {
+		return fmt.Errorf("abci code %d is different from expected %d", cmdErr.Code, e.Code)
+	}
+	if len(e.Codespace) > 0 && cmdErr.Codespace != e.Codespace {
+		return fmt.Errorf("abci codespace %s is different from expected %s", cmdErr.Codespace, e.Codespace)
+	}
+	if len(e.Contains) > 0 && !strings.Contains(err.Error(), e.Contains) && !strings.Contains(cmdErr.Output, e.Contains) {
+		return fmt.Errorf("error does not contain %s", e.Contains)
+	}
+	if len(e.Class) > 0 {
+		class, ok := inttest.ErrorClassByName(e.Class)
+		if !ok {
+			return fmt.Errorf("unknown error class %s", e.Class)
+		}
+		if !errors.Is(cmdErr, class) {
+			return fmt.Errorf("error is not %s", e.Class)
+		}
+	}
+	return nil
+}
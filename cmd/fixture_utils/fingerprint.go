@@ -0,0 +1,38 @@
+package fixturetest
+
+import (
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+// RecordScenarioFingerprints is a function to add chain fingerprints at start and end of scenario to the report
+// Scenario fails at its end when chain advanced more than FixtureTestOpts.MaxScenarioBlocks blocks while it ran.
+func RecordScenarioFingerprints(file string, t *testing.T) {
+	start, err := inttest.GetChainFingerprint(inttest.TestContext(t), "start")
+	if err != nil {
+		if FixtureTestOpts.MaxScenarioBlocks > 0 {
+			t.WithFields(testing.Fields{
+				"scenario": file,
+			}).MustNil(err, "error getting chain fingerprint at scenario start")
+		}
+		t.WithFields(testing.Fields{
+			"scenario": file,
+			"error":    err,
+		}).Warn("chain fingerprint of scenario is not recorded")
+		return
+	}
+	t.RecordFingerprint(start)
+	t.Cleanup(func() {
+		end, err := inttest.GetChainFingerprint(inttest.TestContext(t), "end")
+		t.WithFields(testing.Fields{
+			"scenario": file,
+		}).MustNil(err, "error getting chain fingerprint at scenario end")
+		t.RecordFingerprint(end)
+		t.WithFields(testing.Fields{
+			"scenario":     file,
+			"start_height": start.Height,
+			"end_height":   end.Height,
+			"max_blocks":   FixtureTestOpts.MaxScenarioBlocks,
+		}).MustNil(inttest.CheckChainAdvance(start, end, FixtureTestOpts.MaxScenarioBlocks), "chain advanced unexpectedly while scenario ran, it can be changed by others")
+	})
+}
@@ -0,0 +1,136 @@
+package fixturetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Steps of fixture file can include a fixture fragment which is replaced by steps of the fragment when the file is read
+//   {"include": "./includes/cookbook_setup.json", "params": {"prefix": "LOUD", "sender": "eugen"}}
+// Fragments are fixture files which can include other fragments, [[.name]] placeholders are substituted by params
+// so that IDs, preconditions and paramsRef of included steps don't collide when a fragment is included twice.
+
+// maxIncludeDepth is the max depth of nested includes, deeper includes are considered as a cycle
+const maxIncludeDepth = 8
+
+// FixtureInclude is a struct to describe a step entry which is replaced by steps of a fixture fragment
+type FixtureInclude struct {
+	Include string            `json:"include"`
+	Params  map[string]string `json:"params"`
+}
+
+// parseFixtureInclude is a function to decode raw step as include entry, false when it's a normal step
+func parseFixtureInclude(rawStep json.RawMessage) (FixtureInclude, bool, error) {
+	var keys map[string]json.RawMessage
+	if json.Unmarshal(rawStep, &keys) != nil {
+		return FixtureInclude{}, false, nil
+	}
+	if _, ok := keys["include"]; !ok {
+		return FixtureInclude{}, false, nil
+	}
+	for key := range keys {
+		if key != "include" && key != "params" {
+			return FixtureInclude{}, true, fmt.Errorf("unknown include field %s, available fields are include and params", key)
+		}
+	}
+	var include FixtureInclude
+	if err := json.Unmarshal(rawStep, &include); err != nil {
+		return include, true, fmt.Errorf("invalid include: %w", err)
+	}
+	if len(include.Include) == 0 {
+		return include, true, fmt.Errorf("include should be a fixture file reference")
+	}
+	return include, true, nil
+}
+
+// RenderFixtureInclude is a function to read fixture fragment of include and substitute its [[.name]] placeholders by params
+func RenderFixtureInclude(include FixtureInclude) ([]byte, error) {
+	bz, err := readFixtureFile(include.Include)
+	if err != nil {
+		return nil, fmt.Errorf("error reading include %s: %w", include.Include, err)
+	}
+	if !bytes.Contains(bz, []byte("[[")) {
+		return bz, nil
+	}
+	tmpl, err := template.New(include.Include).Delims("[[", "]]").Option("missingkey=error").Parse(string(bz))
+	if err != nil {
+		return nil, fmt.Errorf("bad placeholder in include %s: %w", include.Include, err)
+	}
+	params := include.Params
+	if params == nil {
+		params = map[string]string{}
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, params); err != nil {
+		return nil, fmt.Errorf("error substituting params of include %s: %w", include.Include, err)
+	}
+	return rendered.Bytes(), nil
+}
+
+// includedStep is a struct to keep raw step with the file and offset it's written at
+type includedStep struct {
+	File   string
+	Source []byte // rendered content of File
+	Offset int
+	Raw    json.RawMessage
+}
+
+// expandIncludes is a function to replace include entries of raw steps by steps of fragments recursively
+// stack is the chain of files including the raw steps, it's used to detect include cycles.
+func expandIncludes(file string, bz []byte, offsets []int, rawSteps []json.RawMessage, stack []string) ([]includedStep, error) {
+	stack = append(stack, file)
+	steps := []includedStep{}
+	for idx, rawStep := range rawSteps {
+		include, ok, err := parseFixtureInclude(rawStep)
+		if err != nil {
+			return nil, FixtureValidationError{File: file, Line: lineOfOffset(bz, offsets[idx]), Message: err.Error()}
+		}
+		if !ok {
+			steps = append(steps, includedStep{File: file, Source: bz, Offset: offsets[idx], Raw: rawStep})
+			continue
+		}
+		line := lineOfOffset(bz, offsets[idx]+tokenOffset(rawStep, `"include"`))
+		for _, including := range stack {
+			if FixturePath(including) == FixturePath(include.Include) {
+				return nil, FixtureValidationError{File: file, Line: line, Message: fmt.Sprintf("include cycle %s -> %s", strings.Join(stack, " -> "), include.Include)}
+			}
+		}
+		if len(stack) >= maxIncludeDepth {
+			return nil, FixtureValidationError{File: file, Line: line, Message: fmt.Sprintf("includes are nested deeper than %d", maxIncludeDepth)}
+		}
+		fragment, err := RenderFixtureInclude(include)
+		if err != nil {
+			return nil, FixtureValidationError{File: file, Line: line, Message: err.Error()}
+		}
+		fragmentOffsets, fragmentSteps, err := splitFixtureSteps(fragment)
+		if err != nil {
+			return nil, FixtureValidationError{File: include.Include, Line: lineOfError(fragment, err), Message: fmt.Sprintf("invalid json: %s", err.Error())}
+		}
+		included, err := expandIncludes(include.Include, fragment, fragmentOffsets, fragmentSteps, stack)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, included...)
+	}
+	return steps, nil
+}
+
+// ExpandFixtureIncludes is a function to get raw steps of fixture file content with include entries replaced by included steps
+func ExpandFixtureIncludes(file string, bz []byte) ([]json.RawMessage, error) {
+	offsets, rawSteps, err := splitFixtureSteps(bz)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := expandIncludes(file, bz, offsets, rawSteps, []string{})
+	if err != nil {
+		return nil, err
+	}
+	expanded := make([]json.RawMessage, 0, len(steps))
+	for _, step := range steps {
+		expanded = append(expanded, step.Raw)
+	}
+	return expanded, nil
+}
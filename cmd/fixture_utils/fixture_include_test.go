@@ -0,0 +1,163 @@
+package fixturetest
+
+import (
+	"encoding/json"
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// fixtureIncludeCase is a fixture directory with the expanded step IDs of its scenario.json or the error expanding it
+type fixtureIncludeCase struct {
+	name    string
+	files   map[string]string
+	stepIDs []string
+	err     string // prefix of error, "file:line" included
+}
+
+var fixtureIncludeCases = []fixtureIncludeCase{
+	{
+		name: "nested includes",
+		files: map[string]string{
+			"scenario.json": `[
+    {"include": "./includes/setup.json", "params": {"prefix": "LOUD", "sender": "eugen"}},
+    {"include": "./includes/setup.json", "params": {"prefix": "QUIET", "sender": "michael"}},
+    {"ID": "EXECUTE_RECIPE", "runAfter": {"precondition": ["LOUD_CREATE_RECIPE", "QUIET_CREATE_RECIPE"]}}
+]`,
+			"includes/setup.json": `[
+    {"include": "./includes/cookbook.json", "params": {"prefix": "[[.prefix]]", "sender": "[[.sender]]"}},
+    {"ID": "[[.prefix]]_CREATE_RECIPE", "runAfter": {"precondition": ["[[.prefix]]_CREATE_COOKBOOK"]}, "paramsRef": "./recipes/[[.sender]].json"}
+]`,
+			"includes/cookbook.json": `[
+    {"ID": "[[.prefix]]_CREATE_COOKBOOK", "paramsRef": "./cookbooks/[[.sender]].json"}
+]`,
+		},
+		stepIDs: []string{"LOUD_CREATE_COOKBOOK", "LOUD_CREATE_RECIPE", "QUIET_CREATE_COOKBOOK", "QUIET_CREATE_RECIPE", "EXECUTE_RECIPE"},
+	},
+	{
+		name: "fragment without placeholders",
+		files: map[string]string{
+			"scenario.json": `[
+    {"include": "./includes/account.json"}
+]`,
+			"includes/account.json": `[
+    {"ID": "CREATE_ACCOUNT"}
+]`,
+		},
+		stepIDs: []string{"CREATE_ACCOUNT"},
+	},
+	{
+		name: "include cycle",
+		files: map[string]string{
+			"scenario.json": `[
+    {"ID": "CREATE_ACCOUNT"},
+    {"include": "./includes/a.json"}
+]`,
+			"includes/a.json": `[
+    {"include": "./includes/b.json"}
+]`,
+			"includes/b.json": `[
+    {"include": "./includes/a.json"}
+]`,
+		},
+		err: "./includes/b.json:2: include cycle scenario.json -> ./includes/a.json -> ./includes/b.json -> ./includes/a.json",
+	},
+	{
+		name: "self include",
+		files: map[string]string{
+			"scenario.json": `[
+    {"include": "scenario.json"}
+]`,
+		},
+		err: "scenario.json:2: include cycle scenario.json -> scenario.json",
+	},
+	{
+		name: "missing include",
+		files: map[string]string{
+			"scenario.json": `[
+    {"ID": "CREATE_ACCOUNT"},
+    {
+        "include": "./includes/missing.json"
+    }
+]`,
+		},
+		err: "scenario.json:4: error reading include ./includes/missing.json",
+	},
+	{
+		name: "missing nested include",
+		files: map[string]string{
+			"scenario.json": `[
+    {"include": "./includes/setup.json"}
+]`,
+			"includes/setup.json": `[
+    {"include": "./includes/missing.json"}
+]`,
+		},
+		err: "./includes/setup.json:2: error reading include ./includes/missing.json",
+	},
+	{
+		name: "missing param",
+		files: map[string]string{
+			"scenario.json": `[
+    {"include": "./includes/cookbook.json", "params": {"sender": "eugen"}}
+]`,
+			"includes/cookbook.json": `[
+    {"ID": "[[.prefix]]_CREATE_COOKBOOK", "paramsRef": "./cookbooks/[[.sender]].json"}
+]`,
+		},
+		err: "scenario.json:2: error substituting params of include ./includes/cookbook.json",
+	},
+	{
+		name: "unknown include field",
+		files: map[string]string{
+			"scenario.json": `[
+    {"include": "./includes/cookbook.json", "param": {"sender": "eugen"}}
+]`,
+		},
+		err: "scenario.json:2: unknown include field param",
+	},
+}
+
+func TestExpandFixtureIncludes(originT *originT.T) {
+	t := testing.NewT(originT)
+	defer func(baseDir string) {
+		FixtureTestOpts.BaseDirectory = baseDir
+	}(FixtureTestOpts.BaseDirectory)
+
+	for _, tc := range fixtureIncludeCases {
+		FixtureTestOpts.BaseDirectory = originT.TempDir()
+		writeFixtureFiles(FixtureTestOpts.BaseDirectory, tc.files, &t)
+		rawSteps, err := ExpandFixtureIncludes("scenario.json", []byte(tc.files["scenario.json"]))
+		if len(tc.err) > 0 {
+			t.WithFields(testing.Fields{
+				"case":     tc.name,
+				"error":    err,
+				"expected": tc.err,
+			}).MustTrue(err != nil && strings.HasPrefix(err.Error(), tc.err), "error expanding includes is different")
+			continue
+		}
+		t.WithFields(testing.Fields{
+			"case": tc.name,
+		}).MustNil(err, "error expanding includes")
+		stepIDs := []string{}
+		for _, rawStep := range rawSteps {
+			var step FixtureStep
+			t.MustNil(json.Unmarshal(rawStep, &step), "error decoding expanded step")
+			stepIDs = append(stepIDs, step.ID)
+		}
+		t.WithFields(testing.Fields{
+			"case":     tc.name,
+			"step_ids": stepIDs,
+			"expected": tc.stepIDs,
+		}).MustTrue(strings.Join(stepIDs, ",") == strings.Join(tc.stepIDs, ","), "expanded steps are different")
+		if tc.name == "nested includes" {
+			var step FixtureStep
+			t.MustNil(json.Unmarshal(rawSteps[3], &step), "error decoding expanded step")
+			t.WithFields(testing.Fields{
+				"step": step,
+			}).MustTrue(step.ParamsRef == "./recipes/michael.json" && step.RunAfter.PreCondition[0] == "QUIET_CREATE_COOKBOOK",
+				"placeholders of nested include should be substituted by params of its include")
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package fixturetest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// Steps can register values of their result by "register": {"recipeID": "$.RecipeID"}
+// and params files of later steps of the same scenario refer them by "@recipeID" string values
+
+// resultRefRegexp matches a json string value which refers a registered result
+var resultRefRegexp = regexp.MustCompile(`^@([A-Za-z_][A-Za-z0-9_]*)$`)
+
+// resultPathRegexp matches a path of step result e.g. $.Output.ItemIDs[0]
+var resultPathRegexp = regexp.MustCompile(`^\$((\.[A-Za-z_][A-Za-z0-9_]*)|(\[[0-9]+\]))*$`)
+
+// registeredResult is a struct to keep value registered by a step
+type registeredResult struct {
+	StepID string      `json:"stepID"`
+	Value  interface{} `json:"value"`
+}
+
+// GetRegisteredResult is a function to get value registered by a step of scenario run by test
+func GetRegisteredResult(name string, t *testing.T) (interface{}, bool) {
+	state := testScenarioState(t)
+	state.mux.RLock()
+	defer state.mux.RUnlock()
+	result, ok := state.results[name]
+	return result.Value, ok
+}
+
+// RegisterStepResults is a function to register values of step result declared by "register" field of step
+// result is encoded to json and "Output" field which has json bytes e.g. of execute_recipe is decoded.
+// Names are registered per scenario run, and a name registered by another step of the scenario fails the test.
+func RegisterStepResults(step FixtureStep, result interface{}, t *testing.T) {
+	if len(step.Register) == 0 {
+		return
+	}
+	resultValue := resultToValue(result, t)
+	state := testScenarioState(t)
+	state.mux.Lock()
+	defer state.mux.Unlock()
+	for name, resultPath := range step.Register {
+		value, err := EvalResultPath(resultValue, resultPath)
+		t.WithFields(testing.Fields{
+			"step_id": step.ID,
+			"name":    name,
+			"path":    resultPath,
+			"result":  resultValue,
+		}).MustNil(err, "error registering step result")
+		t.WithFields(testing.Fields{
+			"step_id": step.ID,
+			"name":    name,
+		}).MustNil(state.registerResult(name, step.ID, value), "error registering step result")
+	}
+}
+
+// registerResult is a function to register value of step in scenario, a step can register its name again e.g. on retry
+// but a name registered by another step is an error. Scenario state should be locked by the caller.
+func (s *scenarioState) registerResult(name, stepID string, value interface{}) error {
+	if registered, ok := s.results[name]; ok && registered.StepID != stepID {
+		return fmt.Errorf("result name %s is already registered by step %s of the scenario", name, registered.StepID)
+	}
+	s.results[name] = registeredResult{StepID: stepID, Value: value}
+	return nil
+}
+
+func resultToValue(result interface{}, t *testing.T) interface{} {
+	bz, err := json.Marshal(result)
+	t.MustNil(err, "error encoding step result")
+	var value interface{}
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	err = dec.Decode(&value)
+	t.MustNil(err, "error decoding step result")
+
+	if fields, ok := value.(map[string]interface{}); ok {
+		if output, ok := fields["Output"].(string); ok {
+			if outputBytes, err := base64.StdEncoding.DecodeString(output); err == nil {
+				var outputValue interface{}
+				dec := json.NewDecoder(bytes.NewReader(outputBytes))
+				dec.UseNumber()
+				if dec.Decode(&outputValue) == nil {
+					fields["Output"] = outputValue
+				}
+			}
+		}
+	}
+	return value
+}
+
+// EvalResultPath is a function to get value of decoded json by path e.g. $.Output.ItemIDs[0]
+func EvalResultPath(value interface{}, resultPath string) (interface{}, error) {
+	if !resultPathRegexp.MatchString(resultPath) {
+		return nil, fmt.Errorf("invalid result path %s, it should be like $.Field.List[0]", resultPath)
+	}
+	rest := strings.TrimPrefix(resultPath, "$")
+	for len(rest) > 0 {
+		if strings.HasPrefix(rest, "[") {
+			end := strings.Index(rest, "]")
+			idx, _ := strconv.Atoi(rest[1:end])
+			rest = rest[end+1:]
+			list, ok := value.([]interface{})
+			if !ok || idx >= len(list) {
+				return nil, fmt.Errorf("index %d of %s does not exist", idx, resultPath)
+			}
+			value = list[idx]
+			continue
+		}
+		rest = rest[1:]
+		end := strings.IndexAny(rest, ".[")
+		if end < 0 {
+			end = len(rest)
+		}
+		field := rest[:end]
+		rest = rest[end:]
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("field %s of %s does not exist", field, resultPath)
+		}
+		if value, ok = fields[field]; !ok {
+			return nil, fmt.Errorf("field %s of %s does not exist", field, resultPath)
+		}
+	}
+	return value, nil
+}
+
+// ResolveResultRefs is a function to replace "@name" string values of json with registered results
+func ResolveResultRefs(bz []byte, t *testing.T) []byte {
+	if !bytes.Contains(bz, []byte(`"@`)) {
+		return bz
+	}
+	var value interface{}
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		// not a json, nothing to resolve
+		return bz
+	}
+	state := testScenarioState(t)
+	value, resolved := func() (interface{}, bool) {
+		state.mux.RLock()
+		defer state.mux.RUnlock()
+		return state.resolveResultRefs(value, func(ref string) {
+			t.WithFields(testing.Fields{
+				"reference": ref,
+			}).MustTrue(false, "result is not registered, check register field and precondition of the step which registers it")
+		})
+	}()
+	if !resolved {
+		return bz
+	}
+	newBytes, err := json.Marshal(value)
+	t.WithFields(testing.Fields{
+		"resolved_interface": value,
+	}).MustNil(err, "error encoding raw json")
+	return newBytes
+}
+
+// resolveResultRefs is a function to replace references of results registered in scenario in decoded json value, missing is called
+// with references which are not registered and they're kept as they are. Scenario state should be locked by the caller.
+func (s *scenarioState) resolveResultRefs(value interface{}, missing func(ref string)) (interface{}, bool) {
+	resolved := false
+	switch v := value.(type) {
+	case string:
+		match := resultRefRegexp.FindStringSubmatch(v)
+		if match == nil {
+			return value, false
+		}
+		result, ok := s.results[match[1]]
+		if !ok {
+			missing(v)
+			return value, false
+		}
+		return result.Value, true
+	case map[string]interface{}:
+		for key, elem := range v {
+			newElem, ok := s.resolveResultRefs(elem, missing)
+			v[key] = newElem
+			resolved = resolved || ok
+		}
+	case []interface{}:
+		for idx, elem := range v {
+			newElem, ok := s.resolveResultRefs(elem, missing)
+			v[idx] = newElem
+			resolved = resolved || ok
+		}
+	}
+	return value, resolved
+}
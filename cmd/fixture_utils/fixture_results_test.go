@@ -0,0 +1,85 @@
+package fixturetest
+
+import (
+	"encoding/json"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestEvalResultPath(originT *originT.T) {
+	t := testing.NewT(originT)
+	var result interface{}
+	t.MustNil(json.Unmarshal([]byte(`{"RecipeID": "recipe-1", "Output": [{"ItemID": "item-1"}, {"ItemID": "item-2"}], "Matrix": [[1, 2]]}`), &result), "error decoding result")
+
+	for path, expected := range map[string]interface{}{
+		"$.RecipeID":         "recipe-1",
+		"$.Output[1].ItemID": "item-2",
+		"$.Matrix[0][1]":     float64(2),
+	} {
+		value, err := EvalResultPath(result, path)
+		t.WithFields(testing.Fields{
+			"path":  path,
+			"value": value,
+		}).MustTrue(err == nil && value == expected, "value of path is different")
+	}
+	for _, path := range []string{"RecipeID", "$.Output[a]", "$.Missing", "$.Output[2]", "$.RecipeID.Name", "$.Output.ItemID"} {
+		_, err := EvalResultPath(result, path)
+		t.WithFields(testing.Fields{
+			"path": path,
+		}).MustTrue(err != nil, "invalid or missing path should be an error")
+	}
+}
+
+func TestResolveResultRefsPerScenario(originT *originT.T) {
+	t := testing.NewT(originT)
+	defer resetScenarioStates()
+	params := []byte(`{"RecipeID": "@recipeID", "ItemIDs": ["@itemID", "@itemID"], "Name": "@ not a reference", "Email": "a@b.c"}`)
+	resolved := map[string]map[string]interface{}{}
+	for scenario, recipeID := range map[string]string{"scenarios/a.json": "recipe-a", "scenarios/b.json": "recipe-b"} {
+		scenario, recipeID := scenario, recipeID
+		t.Run(scenario, func(t *testing.T) {
+			SetScenarioOfTest(scenario, t)
+			t.Run("CREATE_RECIPE", func(t *testing.T) {
+				step := FixtureStep{ID: "CREATE_RECIPE", Register: map[string]string{"recipeID": "$.RecipeID"}}
+				RegisterStepResults(step, map[string]string{"RecipeID": recipeID}, t)
+				// registering again by the same step e.g. on retry keeps the name
+				RegisterStepResults(step, map[string]string{"RecipeID": recipeID}, t)
+			})
+			t.Run("EXECUTE_RECIPE", func(t *testing.T) {
+				step := FixtureStep{ID: "EXECUTE_RECIPE", Register: map[string]string{"itemID": "$.Output[0].ItemID"}}
+				output, err := json.Marshal([]map[string]string{{"ItemID": "item-" + recipeID}})
+				t.MustNil(err, "error encoding output")
+				RegisterStepResults(step, struct{ Output []byte }{output}, t)
+			})
+			t.Run("UPDATE_RECIPE", func(t *testing.T) {
+				value, ok := GetRegisteredResult("recipeID", t)
+				t.MustTrue(ok && value == recipeID, "result registered by step of the same scenario should be got")
+				resolved[scenario] = UnmarshalIntoEmptyInterface(ResolveResultRefs(params, t), t)
+			})
+		})
+	}
+
+	for scenario, recipeID := range map[string]string{"scenarios/a.json": "recipe-a", "scenarios/b.json": "recipe-b"} {
+		fields := resolved[scenario]
+		itemIDs := fields["ItemIDs"].([]interface{})
+		t.WithFields(testing.Fields{
+			"scenario": scenario,
+			"resolved": fields,
+		}).MustTrue(fields["RecipeID"] == recipeID && itemIDs[0] == "item-"+recipeID && itemIDs[1] == "item-"+recipeID,
+			"references should be resolved with results of the same scenario")
+		t.MustTrue(fields["Name"] == "@ not a reference" && fields["Email"] == "a@b.c", "strings which are not references should be kept")
+	}
+
+	noRefs := []byte(`{"Name": "sword"}`)
+	t.MustTrue(string(ResolveResultRefs(noRefs, &t)) == string(noRefs), "json without references should be kept")
+	_, ok := GetRegisteredResult("recipeID", &t)
+	t.MustTrue(!ok, "result of other scenarios should not be got out of them")
+
+	state := getScenarioState("scenarios/a.json")
+	state.mux.Lock()
+	defer state.mux.Unlock()
+	err := state.registerResult("recipeID", "CREATE_OTHER_RECIPE", "recipe-c")
+	t.MustTrue(err != nil, "name registered by another step of the scenario should be an error")
+	t.MustTrue(state.results["recipeID"].Value == "recipe-a", "result of first step should be kept")
+}
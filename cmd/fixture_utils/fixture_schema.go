@@ -0,0 +1,585 @@
+package fixturetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FixtureValidationError is a struct to describe a problem of fixture file which is found before running steps
+type FixtureValidationError struct {
+	File    string
+	Line    int
+	StepID  string
+	Message string
+}
+
+// Error is a function to get validation error message with file, line and step context
+func (e FixtureValidationError) Error() string {
+	if len(e.StepID) == 0 {
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s:%d: step %s: %s", e.File, e.Line, e.StepID, e.Message)
+}
+
+// ActionParamsSchema is a struct to describe params required by an action
+type ActionParamsSchema struct {
+	AccountRef bool     // paramsRef is an account temp name instead of params file
+	Required   []string // fields required in params file, alternatives are separated by "|" e.g. "RecipeName|RecipeID"
+	Coins      []string // fields of params file which are coins string e.g. "100pylon"
+}
+
+var actionParamsSchemas = map[string]ActionParamsSchema{
+	"create_account":               {AccountRef: true},
+	"get_pylons":                   {AccountRef: true},
+	"mock_account":                 {AccountRef: true},
+	"google_iap_get_pylons":        {Required: []string{"Requester", "ProductID"}},
+	"send_coins":                   {Required: []string{"Sender", "Receiver", "Amount"}, Coins: []string{"Amount"}},
+	"fiat_item":                    {Required: []string{"Sender"}},
+	"update_item_string":           {Required: []string{"Sender", "ItemName"}},
+	"send_items":                   {Required: []string{"Sender", "Receiver"}},
+	"create_cookbook":              {Required: []string{"Sender", "Name"}},
+	"update_cookbook":              {Required: []string{"Sender", "ID"}},
+	"mock_cookbook":                {Required: []string{"Sender", "Name"}},
+	"create_recipe":                {Required: []string{"Sender", "Name"}},
+	"update_recipe":                {Required: []string{"Sender", "Name"}},
+	"enable_recipe":                {Required: []string{"Sender", "RecipeName|RecipeID"}},
+	"disable_recipe":               {Required: []string{"Sender", "RecipeName|RecipeID"}},
+	"execute_recipe":               {Required: []string{"Sender", "RecipeName|RecipeID"}},
+	"check_permissions":            {Required: []string{"RecipeName|RecipeID"}},
+	"check_execution":              {Required: []string{"Sender", "ExecRef|ExecID"}},
+	"pay_to_complete":              {Required: []string{"Sender", "ExecRef|ExecID"}},
+	"refund_execution":             {Required: []string{"Sender", "ExecRef|ExecID"}, Coins: []string{"RefundCoins"}},
+	"execute_delayed_recipe":       {Required: []string{"Sender", "Name"}},
+	"create_trade":                 {Required: []string{"Sender"}},
+	"create_coins_for_coins_trade": {Required: []string{"Sender"}},
+	"create_coins_for_items_trade": {Required: []string{"Sender"}},
+	"create_items_for_coins_trade": {Required: []string{"Sender"}},
+	"create_items_for_items_trade": {Required: []string{"Sender"}},
+	"create_mixed_trade":           {Required: []string{"Sender"}},
+	"fulfill_trade":                {Required: []string{"Sender", "TradeInfo|TradeID"}},
+	"auto_fulfill_trade":           {Required: []string{"Sender", "TradeInfo|TradeID"}},
+	"disable_trade":                {Required: []string{"Sender", "TradeInfo|TradeID"}},
+	"enable_trade":                 {Required: []string{"Sender", "TradeInfo|TradeID"}},
+	"authz_grant":                  {Required: []string{"Granter", "Grantee", "MsgType"}},
+	"authz_revoke":                 {Required: []string{"Granter", "Grantee", "MsgType"}},
+	"authz_exec":                   {Required: []string{"Grantee"}},
+	"send_msg":                     {Required: []string{"MsgType", "Msg"}},
+}
+
+// RegisterActionParamsSchema registers params schema of custom action
+// Params of actions without schema are only checked to be a json object
+func RegisterActionParamsSchema(action string, schema ActionParamsSchema) {
+	actionParamsSchemas[action] = schema
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// ValidateFixtureFiles is a function to validate all fixture files before running steps
+// It logs every problem found with file, line and step context and fails the test when any exists
+func ValidateFixtureFiles(files []string, t *testing.T) {
+	numErrors := 0
+	for _, file := range files {
+		for _, verr := range ValidateFixtureFile(file) {
+			t.WithFields(testing.Fields{
+				"file":    verr.File,
+				"line":    verr.Line,
+				"step_id": verr.StepID,
+			}).Error(verr.Error())
+			numErrors++
+		}
+	}
+	if numErrors > 0 {
+		t.WithFields(testing.Fields{
+			"num_errors": numErrors,
+		}).Fatal("fixture files are invalid, fix the errors logged above")
+	}
+}
+
+// ValidateFixtureFile is a function to check unknown fields, required params per action and coin denoms of fixture file
+func ValidateFixtureFile(file string) []FixtureValidationError {
+	bz, err := readFixtureFile(file)
+	if err != nil {
+		return []FixtureValidationError{{File: file, Line: 1, Message: err.Error()}}
+	}
+	stepOffsets, rawSteps, err := splitFixtureSteps(bz)
+	if err != nil {
+		return []FixtureValidationError{{File: file, Line: lineOfError(bz, err), Message: fmt.Sprintf("invalid json: %s", err.Error())}}
+	}
+
+	steps, err := expandIncludes(file, bz, stepOffsets, rawSteps, []string{})
+	if err != nil {
+		var verr FixtureValidationError
+		if errors.As(err, &verr) {
+			return []FixtureValidationError{verr}
+		}
+		return []FixtureValidationError{{File: file, Line: 1, Message: err.Error()}}
+	}
+
+	errs := []FixtureValidationError{}
+	registeredNames := make(map[string]string)
+	scenarioNames := scenarioResultNames(steps)
+	knownDenoms := fixtureDenoms(steps)
+	for _, included := range steps {
+		included := included
+		rawStep := included.Raw
+		addError := func(token string, format string, args ...interface{}) {
+			var step struct{ ID string }
+			json.Unmarshal(rawStep, &step) // nolint: errcheck
+			errs = append(errs, FixtureValidationError{
+				File:    included.File,
+				Line:    lineOfOffset(included.Source, included.Offset+tokenOffset(rawStep, token)),
+				StepID:  step.ID,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		for _, field := range unknownFields(rawStep, reflect.TypeOf(FixtureStep{}), "") {
+			addError(fmt.Sprintf(`"%s"`, lastFieldName(field)), "unknown field %s", field)
+		}
+		var step FixtureStep
+		if err := json.Unmarshal(rawStep, &step); err != nil {
+			addError("", "invalid step: %s", err.Error())
+			continue
+		}
+		if len(step.ID) == 0 {
+			addError("", "ID is required")
+		}
+		if len(step.Action) == 0 {
+			addError("", "action is required")
+			continue
+		}
+		if GetActionRunner(step.Action) == nil {
+			addError(`"action"`, "action %s is not registered", step.Action)
+		}
+		if step.Action == "multi_msg_tx" || step.Action == "authz_exec" {
+			if len(step.MsgRefs) == 0 {
+				addError(`"action"`, "msgRefs is required for action %s", step.Action)
+			}
+			for _, msgRef := range step.MsgRefs {
+				for _, msg := range validateActionParams(msgRef.Action, msgRef.ParamsRef) {
+					addError(fmt.Sprintf(`"%s"`, msgRef.ParamsRef), "%s", msg)
+				}
+			}
+		}
+		if step.Action != "multi_msg_tx" {
+			for _, msg := range validateActionParams(step.Action, step.ParamsRef) {
+				addError(`"paramsRef"`, "%s", msg)
+			}
+		}
+		for _, name := range sortedKeys(step.Register) {
+			if !resultRefRegexp.MatchString("@" + name) {
+				addError(`"register"`, "bad register name %s, it should be an identifier", name)
+			}
+			if !resultPathRegexp.MatchString(step.Register[name]) {
+				addError(`"register"`, "bad result path %s of %s, it should be like $.Field.List[0]", step.Register[name], name)
+			}
+			if registeredBy, ok := registeredNames[name]; ok {
+				addError(`"register"`, "%s is already registered by step %s", name, registeredBy)
+			}
+			registeredNames[name] = step.ID
+		}
+		paramsRefs := []string{step.ParamsRef}
+		for _, msgRef := range step.MsgRefs {
+			paramsRefs = append(paramsRefs, msgRef.ParamsRef)
+		}
+		for _, paramsRef := range paramsRefs {
+			for _, name := range paramsResultRefs(paramsRef) {
+				if !scenarioNames[name] {
+					addError(fmt.Sprintf(`"%s"`, paramsRef), "@%s of params %s is not registered by any step of the scenario", name, paramsRef)
+				}
+			}
+		}
+		if step.ExpectError != nil {
+			if err := step.ExpectError.Validate(); err != nil {
+				addError(`"expectError"`, "%s", err.Error())
+			}
+			if len(step.Output.TxResult.Status) > 0 {
+				addError(`"expectError"`, "txResult status %s can't be checked when expectError is set", step.Output.TxResult.Status)
+			}
+		}
+		for _, property := range step.Output.Property {
+			for _, coin := range property.Coins {
+				if err := sdk.ValidateDenom(coin.Coin); err != nil {
+					addError(fmt.Sprintf(`"%s"`, coin.Coin), "bad coin denom of %s property: %s", property.Owner, err.Error())
+					continue
+				}
+				if _, ok := knownDenoms.Lookup(coin.Coin); ok {
+					continue
+				}
+				if suggestion, ok := knownDenoms.Suggest(coin.Coin); ok {
+					addError(fmt.Sprintf(`"%s"`, coin.Coin), "unknown coin denom %s of %s property, did you mean %s?", coin.Coin, property.Owner, suggestion)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// fixtureDenoms is a function to get registry of denoms fixture steps can check balances of
+// It has denoms of the shared registry and denoms mentioned by params of steps e.g. coin outputs of recipes they create,
+// denoms close to them but not known are reported as typos while other unknown denoms are checked when steps run.
+func fixtureDenoms(steps []includedStep) *inttest.DenomRegistry {
+	registry := inttest.NewDenomRegistry()
+	profile, _ := inttest.SelectedChainProfile()
+	registry.RegisterProfile(profile) // nolint: errcheck
+	denoms := inttest.GlobalDenomRegistry.Denoms()
+	for _, included := range steps {
+		var step FixtureStep
+		if err := json.Unmarshal(included.Raw, &step); err != nil {
+			continue
+		}
+		paramsRefs := []string{step.ParamsRef}
+		for _, msgRef := range step.MsgRefs {
+			paramsRefs = append(paramsRefs, msgRef.ParamsRef)
+		}
+		for _, paramsRef := range paramsRefs {
+			denoms = append(denoms, paramsDenoms(paramsRef)...)
+		}
+	}
+	for _, denom := range denoms {
+		registry.Register(inttest.DenomInfo{Denom: denom, Source: inttest.DenomSourceManual}) // nolint: errcheck
+	}
+	return registry
+}
+
+// paramsDenoms is a function to get denoms mentioned by params file, templated params are not read
+func paramsDenoms(paramsRef string) []string {
+	if len(paramsRef) == 0 {
+		return nil
+	}
+	bz, err := readFixtureFile(paramsRef)
+	if err != nil || bytes.Contains(bz, []byte("{{")) {
+		return nil
+	}
+	var params interface{}
+	if err := json.Unmarshal(bz, &params); err != nil {
+		return nil
+	}
+	return collectDenoms(params)
+}
+
+// validateActionParams is a function to check params reference of action by its schema
+func validateActionParams(action, paramsRef string) []string {
+	schema, ok := actionParamsSchemas[action]
+	if len(paramsRef) == 0 {
+		if ok {
+			return []string{fmt.Sprintf("paramsRef is required for action %s", action)}
+		}
+		return nil
+	}
+	if schema.AccountRef {
+		return nil
+	}
+	bz, err := readFixtureFile(paramsRef)
+	if err != nil {
+		return []string{fmt.Sprintf("error reading params %s: %s", paramsRef, err.Error())}
+	}
+	if bytes.Contains(bz, []byte("{{")) {
+		// placeholders are resolved when the step runs, only template syntax can be checked up front
+		if _, err := ParseFixtureTemplate(paramsRef, bz); err != nil {
+			return []string{fmt.Sprintf("bad template in params %s: %s", paramsRef, err.Error())}
+		}
+		return nil
+	}
+	var params map[string]interface{}
+	if err := json.Unmarshal(bz, &params); err != nil {
+		return []string{fmt.Sprintf("params %s is not a json object: %s", paramsRef, err.Error())}
+	}
+
+	msgs := []string{}
+	for _, field := range schema.Required {
+		exist := false
+		for _, alternative := range strings.Split(field, "|") {
+			if _, ok := params[alternative]; ok {
+				exist = true
+			}
+		}
+		if !exist {
+			msgs = append(msgs, fmt.Sprintf("%s is required in params %s for action %s", strings.Replace(field, "|", " or ", -1), paramsRef, action))
+		}
+	}
+	for _, field := range schema.Coins {
+		if coins, ok := params[field].(string); ok {
+			if _, err := sdk.ParseCoinsNormalized(coins); err != nil {
+				msgs = append(msgs, fmt.Sprintf("bad coins %s of %s in params %s: %s", coins, field, paramsRef, err.Error()))
+			}
+		}
+	}
+	for _, denom := range collectDenoms(params) {
+		if err := sdk.ValidateDenom(denom); err != nil {
+			msgs = append(msgs, fmt.Sprintf("bad coin denom in params %s: %s", paramsRef, err.Error()))
+		}
+	}
+	for _, addr := range collectAddresses(params) {
+		if err := inttest.ValidateAccountAddress(addr); err != nil {
+			msgs = append(msgs, fmt.Sprintf("bad address in params %s: %s", paramsRef, err.Error()))
+		}
+	}
+	if action == "send_msg" {
+		// msg json is checked strictly by its schema, account names are resolved and the msg is validated when the step runs
+		msgParams, err := readMsgJSONParams(bz)
+		if err == nil {
+			_, err = inttest.DecodeMsgJSON(msgParams.MsgType, msgParams.Msg)
+		}
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("bad msg in params %s: %s", paramsRef, err.Error()))
+		}
+	}
+	return msgs
+}
+
+// collectDenoms is a function to get values of "Coin" and "denom" fields from decoded json recursively
+func collectDenoms(value interface{}) []string {
+	denoms := []string{}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys := []string{}
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if denom, ok := value[key].(string); ok && (key == "Coin" || strings.EqualFold(key, "denom")) {
+				denoms = append(denoms, denom)
+				continue
+			}
+			denoms = append(denoms, collectDenoms(value[key])...)
+		}
+	case []interface{}:
+		for _, elem := range value {
+			denoms = append(denoms, collectDenoms(elem)...)
+		}
+	}
+	return denoms
+}
+
+// collectAddresses is a function to get string values meant to be bech32 addresses from decoded json recursively
+func collectAddresses(value interface{}) []string {
+	addrs := []string{}
+	switch value := value.(type) {
+	case string:
+		if inttest.LooksLikeAddress(value) {
+			addrs = append(addrs, value)
+		}
+	case map[string]interface{}:
+		keys := []string{}
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			addrs = append(addrs, collectAddresses(value[key])...)
+		}
+	case []interface{}:
+		for _, elem := range value {
+			addrs = append(addrs, collectAddresses(elem)...)
+		}
+	}
+	return addrs
+}
+
+// unknownFields is a function to get paths of json fields which are not decoded into typ
+// Field names are matched case insensitively as encoding/json does
+func unknownFields(raw json.RawMessage, typ reflect.Type, prefix string) []string {
+	unknown := []string{}
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return unknownFields(raw, typ.Elem(), prefix)
+	case reflect.Slice:
+		var elems []json.RawMessage
+		if json.Unmarshal(raw, &elems) != nil {
+			return unknown
+		}
+		for idx, elem := range elems {
+			unknown = append(unknown, unknownFields(elem, typ.Elem(), fmt.Sprintf("%s[%d]", prefix, idx))...)
+		}
+	case reflect.Struct:
+		if reflect.PtrTo(typ).Implements(jsonUnmarshalerType) {
+			return unknown
+		}
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(raw, &obj) != nil {
+			return unknown
+		}
+		keys := []string{}
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fieldPath := key
+			if len(prefix) > 0 {
+				fieldPath = prefix + "." + key
+			}
+			field, ok := jsonField(typ, key)
+			if !ok {
+				unknown = append(unknown, fieldPath)
+				continue
+			}
+			unknown = append(unknown, unknownFields(obj[key], field.Type, fieldPath)...)
+		}
+	}
+	return unknown
+}
+
+// jsonField is a function to find struct field which json key is decoded into
+func jsonField(typ reflect.Type, key string) (reflect.StructField, bool) {
+	for idx := 0; idx < typ.NumField(); idx++ {
+		field := typ.Field(idx)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// splitFixtureSteps is a function to split fixture file into raw steps with their offsets in the file
+func splitFixtureSteps(bz []byte) ([]int, []json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	token, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if delim, ok := token.(json.Delim); ok && delim == '{' {
+		return splitScenarioObject(bz, dec)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, nil, fmt.Errorf("fixture file should be an array of steps or an object having tags and steps")
+	}
+	return splitStepArray(bz, dec)
+}
+
+// splitStepArray is a function to split raw steps of array which decoder is in with their offsets in the file
+func splitStepArray(bz []byte, dec *json.Decoder) ([]int, []json.RawMessage, error) {
+	offsets := []int{}
+	rawSteps := []json.RawMessage{}
+	for dec.More() {
+		offset := int(dec.InputOffset())
+		var rawStep json.RawMessage
+		if err := dec.Decode(&rawStep); err != nil {
+			return nil, nil, err
+		}
+		// input offset is at the end of previous value, skip separators before the step
+		for offset < len(bz) && strings.ContainsRune(" \t\r\n,", rune(bz[offset])) {
+			offset++
+		}
+		offsets = append(offsets, offset)
+		rawSteps = append(rawSteps, rawStep)
+	}
+	return offsets, rawSteps, nil
+}
+
+// tokenOffset is a function to get offset of token in raw step, 0 when it's not found
+func tokenOffset(rawStep json.RawMessage, token string) int {
+	if len(token) == 0 {
+		return 0
+	}
+	if idx := bytes.Index(rawStep, []byte(token)); idx >= 0 {
+		return idx
+	}
+	return 0
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func lastFieldName(fieldPath string) string {
+	fields := strings.Split(fieldPath, ".")
+	return strings.Split(fields[len(fields)-1], "[")[0]
+}
+
+func lineOfOffset(bz []byte, offset int) int {
+	if offset > len(bz) {
+		offset = len(bz)
+	}
+	return bytes.Count(bz[:offset], []byte("\n")) + 1
+}
+
+func lineOfError(bz []byte, err error) int {
+	if syntaxErr, ok := err.(*json.SyntaxError); ok {
+		return lineOfOffset(bz, int(syntaxErr.Offset))
+	}
+	if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+		return lineOfOffset(bz, int(typeErr.Offset))
+	}
+	return 1
+}
+
+// scenarioResultNames is a function to get result names registered by steps of a scenario
+func scenarioResultNames(steps []includedStep) map[string]bool {
+	names := make(map[string]bool)
+	for _, included := range steps {
+		var step struct {
+			Register map[string]string `json:"register"`
+		}
+		json.Unmarshal(included.Raw, &step) // nolint: errcheck
+		for name := range step.Register {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// paramsResultRefs is a function to get names of registered results referred by "@name" values of params file
+// Params which can't be read or decoded refer nothing, their errors are reported by params validation.
+func paramsResultRefs(paramsRef string) []string {
+	if len(paramsRef) == 0 {
+		return nil
+	}
+	bz, err := readFixtureFile(paramsRef)
+	if err != nil {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(bz, &value); err != nil {
+		return nil
+	}
+	names := make(map[string]string)
+	collectResultRefs(value, names)
+	return sortedKeys(names)
+}
+
+// collectResultRefs is a function to add names of "@name" string values of decoded json to names
+func collectResultRefs(value interface{}, names map[string]string) {
+	switch v := value.(type) {
+	case string:
+		if match := resultRefRegexp.FindStringSubmatch(v); match != nil {
+			names[match[1]] = v
+		}
+	case map[string]interface{}:
+		for _, elem := range v {
+			collectResultRefs(elem, names)
+		}
+	case []interface{}:
+		for _, elem := range v {
+			collectResultRefs(elem, names)
+		}
+	}
+}
+
+func readFixtureFile(fileURL string) ([]byte, error) {
+	return ioutil.ReadFile(FixturePath(fileURL))
+}
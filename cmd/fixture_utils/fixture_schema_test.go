@@ -0,0 +1,196 @@
+package fixturetest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// fixtureValidationCase is a fixture directory with the errors ValidateFixtureFile should find in its scenario.json
+type fixtureValidationCase struct {
+	name   string
+	files  map[string]string
+	errors []string // substrings of errors in order, "file:line" prefixes included
+}
+
+var fixtureValidationCases = []fixtureValidationCase{
+	{
+		name: "valid fixture",
+		files: map[string]string{
+			"scenario.json": `[
+    {
+        "ID": "CREATE_ACCOUNT",
+        "action": "create_account",
+        "paramsRef": "account1"
+    },
+    {
+        "ID": "CREATE_COOKBOOK",
+        "runAfter": {"precondition": ["CREATE_ACCOUNT"], "blockWait": 0},
+        "action": "create_cookbook",
+        "paramsRef": "./cookbooks/cookbook.json",
+        "output": {"txResult": {"status": "Success"}}
+    }
+]`,
+			"cookbooks/cookbook.json": `{"Sender": "account1", "Name": "cookbook"}`,
+		},
+		errors: []string{},
+	},
+	{
+		name: "unknown field",
+		files: map[string]string{
+			"scenario.json": `[
+    {
+        "ID": "CREATE_ACCOUNT",
+        "action": "create_account",
+        "paramRef": "account1"
+    }
+]`,
+		},
+		errors: []string{
+			"scenario.json:5: step CREATE_ACCOUNT: unknown field paramRef",
+			"scenario.json:2: step CREATE_ACCOUNT: paramsRef is required for action create_account",
+		},
+	},
+	{
+		name: "unknown nested field",
+		files: map[string]string{
+			"scenario.json": `[
+    {
+        "ID": "CREATE_ACCOUNT",
+        "action": "create_account",
+        "paramsRef": "account1",
+        "output": {"txResult": {"state": "Success"}}
+    }
+]`,
+		},
+		errors: []string{"scenario.json:6: step CREATE_ACCOUNT: unknown field output.txResult.state"},
+	},
+	{
+		name: "action not registered",
+		files: map[string]string{
+			"scenario.json": `[
+    {
+        "ID": "CREATE_COOKBOOK",
+        "action": "create_cookbok",
+        "paramsRef": "./cookbooks/cookbook.json"
+    }
+]`,
+			"cookbooks/cookbook.json": `{"Sender": "account1", "Name": "cookbook"}`,
+		},
+		errors: []string{"scenario.json:4: step CREATE_COOKBOOK: action create_cookbok is not registered"},
+	},
+	{
+		name: "required params missing",
+		files: map[string]string{
+			"scenario.json": `[
+    {
+        "ID": "CREATE_COOKBOOK",
+        "action": "create_cookbook",
+        "paramsRef": "./cookbooks/cookbook.json"
+    }
+]`,
+			"cookbooks/cookbook.json": `{"Sender": "account1"}`,
+		},
+		errors: []string{"scenario.json:5: step CREATE_COOKBOOK: Name is required in params ./cookbooks/cookbook.json for action create_cookbook"},
+	},
+	{
+		name: "params file missing",
+		files: map[string]string{
+			"scenario.json": `[
+    {
+        "ID": "CREATE_COOKBOOK",
+        "action": "create_cookbook",
+        "paramsRef": "./cookbooks/missing.json"
+    }
+]`,
+		},
+		errors: []string{"scenario.json:5: step CREATE_COOKBOOK: error reading params ./cookbooks/missing.json"},
+	},
+	{
+		name: "bad @name reference",
+		files: map[string]string{
+			"scenario.json": `[
+    {
+        "ID": "CREATE_COOKBOOK",
+        "action": "create_cookbook",
+        "paramsRef": "./cookbooks/cookbook.json",
+        "register": {"cookbookID": "$.CookbookID"}
+    },
+    {
+        "ID": "CREATE_RECIPE",
+        "runAfter": {"precondition": ["CREATE_COOKBOOK"], "blockWait": 0},
+        "action": "create_recipe",
+        "paramsRef": "./recipes/recipe.json"
+    }
+]`,
+			"cookbooks/cookbook.json": `{"Sender": "account1", "Name": "cookbook"}`,
+			"recipes/recipe.json":     `{"Sender": "account1", "Name": "recipe", "CookbookID": "@cookbookId"}`,
+		},
+		errors: []string{"scenario.json:12: step CREATE_RECIPE: @cookbookId of params ./recipes/recipe.json is not registered by any step of the scenario"},
+	},
+	{
+		name: "missing include",
+		files: map[string]string{
+			"scenario.json": `[
+    {
+        "ID": "CREATE_ACCOUNT",
+        "action": "create_account",
+        "paramsRef": "account1"
+    },
+    {"include": "./includes/missing.json"}
+]`,
+		},
+		errors: []string{"scenario.json:7: error reading include ./includes/missing.json"},
+	},
+	{
+		name: "invalid json",
+		files: map[string]string{
+			"scenario.json": `[
+    {
+        "ID": "CREATE_ACCOUNT",
+        "action": "create_account",
+        "paramsRef": "account1",
+    }
+]`,
+		},
+		errors: []string{"scenario.json:6: invalid json"},
+	},
+}
+
+// writeFixtureFiles is a function to write fixture files under directory
+func writeFixtureFiles(dir string, files map[string]string, t *testing.T) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		t.MustNil(os.MkdirAll(filepath.Dir(path), 0755), "error creating fixture directory")
+		t.MustNil(ioutil.WriteFile(path, []byte(content), 0644), "error writing fixture file")
+	}
+}
+
+func TestValidateFixtureFile(originT *originT.T) {
+	t := testing.NewT(originT)
+	defer func(baseDir string) {
+		FixtureTestOpts.BaseDirectory = baseDir
+	}(FixtureTestOpts.BaseDirectory)
+	RegisterDefaultActionRunners()
+
+	for _, tc := range fixtureValidationCases {
+		FixtureTestOpts.BaseDirectory = originT.TempDir()
+		writeFixtureFiles(FixtureTestOpts.BaseDirectory, tc.files, &t)
+		verrs := ValidateFixtureFile("scenario.json")
+		t.WithFields(testing.Fields{
+			"case":   tc.name,
+			"errors": verrs,
+		}).MustTrue(len(verrs) == len(tc.errors), "number of validation errors is different")
+		for idx, verr := range verrs {
+			t.WithFields(testing.Fields{
+				"case":     tc.name,
+				"error":    verr.Error(),
+				"expected": tc.errors[idx],
+			}).MustTrue(strings.HasPrefix(verr.Error(), tc.errors[idx]), "validation error is different")
+		}
+	}
+}
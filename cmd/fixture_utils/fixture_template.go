@@ -0,0 +1,178 @@
+package fixturetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"text/template"
+	"text/template/parse"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Params files can have go template placeholders which are resolved when the file is read by a step
+//   {{.account1.address}}, {{.account1.key}}    address and key of account temp name
+//   {{.steps.STEP_ID.recipe_id}}                output of a finished step, see SetStepOutput
+//   {{.roles.player.address}}                   address and key of role account set up on first reference, see Role
+//   {{rand_string 8}}                           random lowercase alphanumeric string reproducible by -seed
+//   {{coins "100pylon"}}                        json array of coins, denoms are validated
+
+// stepsTemplateKey is the top level template key of step outputs
+const stepsTemplateKey = "steps"
+
+var templateDataGenOnce sync.Once
+var templateDataGen *inttest.TestDataGenerator
+
+var templateFuncs = template.FuncMap{
+	"rand_string": func(length int) string {
+		templateDataGenOnce.Do(func() {
+			templateDataGen = inttest.NewTestDataGeneratorWithSeed(inttest.GetTestDataSeed())
+		})
+		return templateDataGen.String(length)
+	},
+	"coins": func(coinsStr string) (string, error) {
+		coins, err := sdk.ParseCoinsNormalized(coinsStr)
+		if err != nil {
+			return "", err
+		}
+		bz, err := json.Marshal(coins)
+		return string(bz), err
+	},
+}
+
+// SetStepOutput is a function to keep output of a step which is referred by {{.steps.STEP_ID.key}} in params files
+// of the same scenario
+func SetStepOutput(stepID, key, value string, t *testing.T) {
+	testScenarioState(t).setStepOutput(stepID, key, value)
+}
+
+// setStepOutput is a function to keep output of a step of scenario
+func (s *scenarioState) setStepOutput(stepID, key, value string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if _, ok := s.stepOutputs[stepID]; !ok {
+		s.stepOutputs[stepID] = make(map[string]string)
+	}
+	s.stepOutputs[stepID][key] = value
+}
+
+// ParseFixtureTemplate is a function to parse go template placeholders of fixture file
+func ParseFixtureTemplate(name string, bz []byte) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(string(bz))
+}
+
+// RenderFixtureTemplate is a function to resolve go template placeholders of fixture file
+// A file is rendered once per scenario run so that random values are the same whenever the scenario reads the file
+func RenderFixtureTemplate(name string, bz []byte, t *testing.T) []byte {
+	if !bytes.Contains(bz, []byte("{{")) {
+		return bz
+	}
+	state := testScenarioState(t)
+	state.mux.Lock()
+	defer state.mux.Unlock()
+	if rendered, ok := state.renderedFiles[name]; ok {
+		return rendered
+	}
+
+	tmpl, err := ParseFixtureTemplate(name, bz)
+	t.WithFields(testing.Fields{
+		"file": name,
+	}).MustNil(err, "error parsing fixture template")
+
+	data := map[string]interface{}{}
+	steps := make(map[string]map[string]string, len(state.stepOutputs))
+	for stepID, outputs := range state.stepOutputs {
+		steps[stepID] = make(map[string]string, len(outputs))
+		for key, value := range outputs {
+			steps[stepID][key] = value
+		}
+	}
+	data[stepsTemplateKey] = steps
+	for _, tempName := range templateAccountNames(tmpl) {
+		data[tempName] = templateAccount(tempName, t)
+	}
+	roles := templateRoles(templateFields(tmpl), t)
+	SetupRoleAccounts(roles, t)
+	roleAccounts := make(map[string]map[string]string)
+	for _, role := range roles {
+		roleAccounts[string(role)] = templateAccount(role.TempName(), t)
+	}
+	data[rolesTemplateKey] = roleAccounts
+
+	var rendered bytes.Buffer
+	err = tmpl.Execute(&rendered, data)
+	t.WithFields(testing.Fields{
+		"file": name,
+	}).MustNil(err, "error resolving fixture template")
+	state.renderedFiles[name] = rendered.Bytes()
+	return rendered.Bytes()
+}
+
+// templateAccount is a function to get key and address of account temp name referred by fixture template
+func templateAccount(tempName string, t *testing.T) map[string]string {
+	return map[string]string{
+		"key":     GetAccountKeyFromTempName(tempName, t),
+		"address": GetAccountAddressFromTempName(tempName, t),
+	}
+}
+
+// templateFields is a function to get identifiers of all fields referred by template e.g. [account1 address] of {{.account1.address}}
+func templateFields(tmpl *template.Template) [][]string {
+	fields := [][]string{}
+	walkTemplateFields(tmpl.Tree.Root, func(ident []string) {
+		fields = append(fields, ident)
+	})
+	return fields
+}
+
+// templateAccountNames is a function to get account temp names referred by fields of template e.g. account1 of {{.account1.address}}
+func templateAccountNames(tmpl *template.Template) []string {
+	names := []string{}
+	for _, ident := range templateFields(tmpl) {
+		if len(ident) > 0 && ident[0] != stepsTemplateKey && ident[0] != rolesTemplateKey && !inttest.Exists(names, ident[0]) {
+			names = append(names, ident[0])
+		}
+	}
+	return names
+}
+
+func walkTemplateFields(node parse.Node, fn func(ident []string)) {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return
+		}
+		for _, child := range node.Nodes {
+			walkTemplateFields(child, fn)
+		}
+	case *parse.ActionNode:
+		walkTemplateFields(node.Pipe, fn)
+	case *parse.PipeNode:
+		if node == nil {
+			return
+		}
+		for _, cmd := range node.Cmds {
+			walkTemplateFields(cmd, fn)
+		}
+	case *parse.CommandNode:
+		for _, arg := range node.Args {
+			walkTemplateFields(arg, fn)
+		}
+	case *parse.FieldNode:
+		fn(node.Ident)
+	case *parse.IfNode:
+		walkTemplateBranch(&node.BranchNode, fn)
+	case *parse.RangeNode:
+		walkTemplateBranch(&node.BranchNode, fn)
+	case *parse.WithNode:
+		walkTemplateBranch(&node.BranchNode, fn)
+	}
+}
+
+func walkTemplateBranch(node *parse.BranchNode, fn func(ident []string)) {
+	walkTemplateFields(node.Pipe, fn)
+	walkTemplateFields(node.List, fn)
+	walkTemplateFields(node.ElseList, fn)
+}
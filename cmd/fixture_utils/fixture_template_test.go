@@ -0,0 +1,43 @@
+package fixturetest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestRenderFixtureTemplatePerScenario(originT *originT.T) {
+	t := testing.NewT(originT)
+	defer resetScenarioStates()
+	params := []byte(`{"RecipeID": "{{.steps.CREATE_RECIPE.recipe_id}}", "Name": "sword_{{rand_string 8}}", "Coins": {{coins "100pylon"}}}`)
+	rendered := map[string]string{}
+	for scenario, recipeID := range map[string]string{"scenarios/a.json": "recipe-a", "scenarios/b.json": "recipe-b"} {
+		scenario, recipeID := scenario, recipeID
+		t.Run(scenario, func(t *testing.T) {
+			SetScenarioOfTest(scenario, t)
+			t.Run("CREATE_RECIPE", func(t *testing.T) {
+				t.MustTrue(ScenarioOfTest(t) == scenario, "step should get scenario of its parent test")
+				SetStepOutput("CREATE_RECIPE", "recipe_id", recipeID, t)
+			})
+			t.Run("EXECUTE_RECIPE", func(t *testing.T) {
+				first := string(RenderFixtureTemplate("./recipes/sword.json", params, t))
+				t.WithFields(testing.Fields{
+					"rendered": first,
+				}).MustTrue(UnmarshalIntoEmptyInterface([]byte(first), t)["RecipeID"] == recipeID, "step output of the same scenario should be rendered")
+				second := string(RenderFixtureTemplate("./recipes/sword.json", params, t))
+				t.MustTrue(first == second, "file should be rendered once per scenario")
+				rendered[scenario] = first
+			})
+		})
+	}
+
+	t.WithFields(testing.Fields{
+		"rendered": rendered,
+	}).MustTrue(rendered["scenarios/a.json"] != rendered["scenarios/b.json"], "scenarios rendering the same file should get their own outputs")
+	coins := UnmarshalIntoEmptyInterface([]byte(rendered["scenarios/a.json"]), &t)["Coins"].([]interface{})
+	t.MustTrue(len(coins) == 1 && coins[0].(map[string]interface{})["denom"] == "pylon", "coins should be rendered as json array")
+
+	_, err := ParseFixtureTemplate("bad.json", []byte(`{"Name": "{{rand_string"}`))
+	t.MustTrue(err != nil, "bad template should not be parsed")
+	t.MustTrue(ScenarioOfTest(&t) == "", "test which doesn't run a scenario should not have scenario")
+}
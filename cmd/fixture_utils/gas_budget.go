@@ -0,0 +1,26 @@
+package fixturetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// ReadGasBudgets is a function to read max gas per msg type from json file e.g. {"create_cookbook": 60000}
+// Msg types are the ones of sdk.Msg.Type(), transactions of steps using more gas than budgets of their msgs fail the run.
+func ReadGasBudgets(file string) (map[string]int64, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading gas budgets: %w", err)
+	}
+	budgets := make(map[string]int64)
+	if err := json.Unmarshal(bz, &budgets); err != nil {
+		return nil, fmt.Errorf("gas budgets should be a json object of msg type and max gas: %w", err)
+	}
+	for msgType, limit := range budgets {
+		if limit <= 0 {
+			return nil, fmt.Errorf("gas budget %d of %s should be positive", limit, msgType)
+		}
+	}
+	return budgets, nil
+}
@@ -0,0 +1,63 @@
+package fixturetest
+
+import (
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// identicalSkipAllowed is a function to check if step can skip broadcasting when identical object exists on chain
+// Steps expecting an error always broadcast, as the error is what they check.
+func identicalSkipAllowed(step FixtureStep) bool {
+	return FixtureTestOpts.SkipExisting &&
+		step.ExpectError == nil &&
+		len(step.Output.TxResult.ErrorLog) == 0 &&
+		len(step.Output.TxResult.BroadcastError) == 0
+}
+
+// SkipIdenticalCookbook is a function to skip create cookbook step when cookbook with the same content hash exists
+// Existing cookbook is registered as result of the step, so that following steps refer it as if it was created.
+// It returns true when broadcasting is skipped.
+func SkipIdenticalCookbook(step FixtureStep, msg types.MsgCreateCookbook, t *testing.T) bool {
+	if !identicalSkipAllowed(step) {
+		return false
+	}
+	cookbook, found, err := inttest.FindIdenticalCookbook(msg)
+	t.WithFields(testing.Fields{
+		"cookbook_name": msg.Name,
+		"sender":        msg.Sender,
+	}).MustNil(err, "error finding identical cookbook")
+	if !found {
+		return false
+	}
+	RegisterStepResults(step, types.MsgCreateCookbookResponse{CookbookID: cookbook.ID, Status: "Success"}, t)
+	SetStepOutput(step.ID, "cookbook_id", cookbook.ID, t)
+	t.WithFields(testing.Fields{
+		"step_id":     step.ID,
+		"cookbook_id": cookbook.ID,
+	}).Info("identical cookbook exists, skipped broadcasting")
+	return true
+}
+
+// SkipIdenticalRecipe is a function to skip create recipe step when enabled recipe with the same content hash exists
+// It returns true when broadcasting is skipped.
+func SkipIdenticalRecipe(step FixtureStep, msg types.MsgCreateRecipe, t *testing.T) bool {
+	if !identicalSkipAllowed(step) {
+		return false
+	}
+	recipe, found, err := inttest.FindIdenticalRecipe(msg)
+	t.WithFields(testing.Fields{
+		"recipe_name": msg.Name,
+		"sender":      msg.Sender,
+	}).MustNil(err, "error finding identical recipe")
+	if !found {
+		return false
+	}
+	RegisterStepResults(step, types.MsgCreateRecipeResponse{RecipeID: recipe.ID, Status: "Success"}, t)
+	SetStepOutput(step.ID, "recipe_id", recipe.ID, t)
+	t.WithFields(testing.Fields{
+		"step_id":   step.ID,
+		"recipe_id": recipe.ID,
+	}).Info("identical recipe exists, skipped broadcasting")
+	return true
+}
@@ -0,0 +1,240 @@
+package fixturetest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/mockchain"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// TransportMemory is the node interface of in-process mock chain fixture steps run against in memory mode
+const TransportMemory = inttest.TransportMemory
+
+// InMemoryChain is the in-process chain of in memory mode, see EnableInMemory
+var InMemoryChain *mockchain.Chain
+
+// InMemoryEnv is the env of memory transport property checks of in memory mode query through, see EnableInMemory
+var InMemoryEnv *inttest.Env
+
+// EnableInMemory is a function to run fixture steps against a new in-process mock chain of seed instead of a live node
+// Msgs of steps are applied to the chain at once and queries of property checks go through memory transport of InMemoryEnv,
+// so recipe logic of fixtures is checked in a second while the same fixture files are used for integration runs.
+// Mock chain reimplements pylons msgs without signatures, fees, gas and tx results, so passing in memory doesn't prove a node behaves the same.
+// CLIOpts are kept, helpers of DefaultEnv keep querying the configured node.
+func EnableInMemory(seed int64) *mockchain.Chain {
+	chain := mockchain.New(seed)
+	InMemoryChain = chain
+	inttest.RegisterTransport(TransportMemory, func() (inttest.Transport, error) {
+		return inttest.NewMemoryTransport(chain), nil
+	})
+	opts := *inttest.DefaultEnv().Options()
+	opts.Transport = TransportMemory
+	InMemoryEnv = inttest.NewEnv(opts, nil)
+	FixtureTestOpts.InMemory = true
+	return chain
+}
+
+// FixtureEnv is a function to get env fixture steps query through, InMemoryEnv in memory mode and DefaultEnv otherwise
+func FixtureEnv() *inttest.Env {
+	if FixtureTestOpts.InMemory && InMemoryEnv != nil {
+		return InMemoryEnv
+	}
+	return inttest.DefaultEnv()
+}
+
+// InMemoryAccountPylons is pylon balance default accounts of AccountNames start with in memory mode
+// like accounts funded by genesis of a local node.
+var InMemoryAccountPylons int64 = 1000000
+
+// inMemoryAddress is a function to get deterministic address of temp name, accounts don't need keys in memory mode
+// Default accounts e.g. account1 get addresses of their account names so that both names refer to the same account.
+func inMemoryAddress(tempName string) string {
+	name := tempName
+	for idx, accountName := range FixtureTestOpts.AccountNames {
+		if tempName == fmt.Sprintf("account%d", idx+1) {
+			name = accountName
+		}
+	}
+	return sdk.AccAddress(tmhash.SumTruncated([]byte(name))).String()
+}
+
+// FundInMemoryAccounts is a function to set InMemoryAccountPylons balance of default accounts on InMemoryChain
+func FundInMemoryAccounts(t *testing.T) {
+	for _, accountName := range FixtureTestOpts.AccountNames {
+		addr := GetAccountAddressFromTempName(accountName, t)
+		InMemoryChain.SetBalance(addr, types.NewPylon(InMemoryAccountPylons))
+	}
+}
+
+// getItemByGUID is a function to get item of id from InMemoryChain in memory mode and from node otherwise
+func getItemByGUID(id string) (types.Item, error) {
+	if !FixtureTestOpts.InMemory {
+		return inttest.GetItemByGUID(id)
+	}
+	res, err := InMemoryChain.GetItem(context.Background(), &types.GetItemRequest{ItemID: id})
+	if err != nil {
+		return types.Item{}, err
+	}
+	return res.Item, nil
+}
+
+// InMemoryStepMsgs is a function to read msgs step applies in memory, steps of other actions return no msgs
+// Accounts exist without being created in memory, so create_account sends nothing and mock_account only gets pylons.
+func InMemoryStepMsgs(step FixtureStep, t *testing.T) []sdk.Msg {
+	if step.ParamsRef == "" && len(step.MsgRefs) == 0 {
+		return nil
+	}
+	switch step.Action {
+	case "create_account":
+		return nil
+	case "mock_account":
+		msg, _ := ActionMsgFromRef("get_pylons", step.ParamsRef, t)
+		return []sdk.Msg{msg}
+	case "mock_cookbook":
+		if !FixtureTestOpts.CreateNewCookbook {
+			return nil
+		}
+		pylonsMsg, _ := ActionMsgFromRef("get_pylons", GetSenderKeyFromRef(step.ParamsRef, t), t)
+		cookbookMsg, _ := ActionMsgFromRef("create_cookbook", step.ParamsRef, t)
+		return []sdk.Msg{pylonsMsg, cookbookMsg}
+	case "pay_to_complete":
+		msg := CheckExecutionMsgFromRef(step.ParamsRef, t)
+		msg.PayToComplete = true
+		return []sdk.Msg{&msg}
+	case "multi_msg_tx":
+		var msgs []sdk.Msg
+		for _, ref := range step.MsgRefs {
+			msg, _ := ActionMsgFromRef(ref.Action, ref.ParamsRef, t)
+			t.WithFields(testing.Fields{
+				"action": ref.Action,
+			}).MustTrue(msg != nil, "action can't be used in multi msg transaction")
+			msgs = append(msgs, msg)
+		}
+		return msgs
+	}
+	msg, _ := ActionMsgFromRef(step.Action, step.ParamsRef, t)
+	if msg == nil {
+		return nil
+	}
+	return []sdk.Msg{msg}
+}
+
+// responseStatus describes status and message all msg responses have
+type responseStatus interface {
+	GetStatus() string
+	GetMessage() string
+}
+
+// setInMemoryStepOutputs is a function to keep IDs of msg response as step outputs like the step ran on a node
+func setInMemoryStepOutputs(step FixtureStep, res proto.Message, t *testing.T) {
+	switch res := res.(type) {
+	case *types.MsgCreateCookbookResponse:
+		SetStepOutput(step.ID, "cookbook_id", res.CookbookID, t)
+	case *types.MsgCreateRecipeResponse:
+		SetStepOutput(step.ID, "recipe_id", res.RecipeID, t)
+	case *types.MsgFiatItemResponse:
+		SetStepOutput(step.ID, "item_id", res.ItemID, t)
+	case *types.MsgCreateTradeResponse:
+		SetStepOutput(step.ID, "trade_id", res.TradeID, t)
+	case *types.MsgExecuteRecipeResponse:
+		if res.Message != "scheduled the recipe" { // delayed execution
+			return
+		}
+		var scheduleRes types.ExecuteRecipeScheduleOutput
+		err := json.Unmarshal(res.Output, &scheduleRes)
+		t.WithFields(testing.Fields{
+			"response_output": string(res.Output),
+		}).MustNil(err, "error decoding raw json")
+		execIDRWMutex.Lock()
+		execIDs[step.ID] = scheduleRes.ExecID
+		execIDRWMutex.Unlock()
+		SetStepOutput(step.ID, "exec_id", scheduleRes.ExecID, t)
+	}
+}
+
+// RunInMemoryStep is a function to apply msgs of step to InMemoryChain and check the result as the step's transaction
+// Msgs of a multi msg step are applied in order and the ones before a failing msg are not reverted.
+func RunInMemoryStep(file string, step FixtureStep, t *testing.T) {
+	if step.Action == "execute_delayed_recipe" {
+		runInMemoryDelayedRecipe(step, t)
+		return
+	}
+	msgs := InMemoryStepMsgs(step, t)
+	if len(msgs) == 0 {
+		t.WithFields(testing.Fields{
+			"action": step.Action,
+		}).Info("step does not send msgs to apply in memory")
+		return
+	}
+	for _, msg := range msgs {
+		ValidateStepMsg(step, step.ParamsRef, msg, t)
+	}
+	// like on a node, the transaction is included in the next block and runners wait for one more block
+	InMemoryChain.AdvanceBlocks(1)
+	defer InMemoryChain.AdvanceBlocks(1)
+	var res proto.Message
+	var err error
+	for _, msg := range msgs {
+		res, err = InMemoryChain.Deliver(context.Background(), msg)
+		if err != nil {
+			break
+		}
+		RegisterStepResults(step, res, t)
+		setInMemoryStepOutputs(step, res, t)
+	}
+
+	txResult := step.Output.TxResult
+	switch {
+	case step.ExpectError != nil:
+		t.WithFields(testing.Fields{
+			"expected_error": *step.ExpectError,
+			"error":          err,
+		}).MustNil(step.ExpectError.Check(err), "msg error is different from expected one")
+	case txResult.BroadcastError != "" || txResult.ErrorLog != "":
+		// msgs are not broadcast in memory, so broadcast and transaction errors are both errors of the msg
+		expected := txResult.BroadcastError
+		if len(expected) == 0 {
+			expected = txResult.ErrorLog
+		}
+		t.MustTrue(err != nil, "step succeeded but it is expected to fail")
+		t.MustContain(err.Error(), expected, "msg error is different from expected one")
+	default:
+		t.WithFields(testing.Fields{
+			"file": file,
+		}).MustNil(err, "error applying msg to in-memory chain")
+		if status, ok := res.(responseStatus); ok {
+			TxResultStatusMessageCheck(status.GetStatus(), status.GetMessage(), "", step, t)
+		}
+	}
+}
+
+// runInMemoryDelayedRecipe is a function to create recipe of step, execute it, advance blocks of its interval and check the execution
+func runInMemoryDelayedRecipe(step FixtureStep, t *testing.T) {
+	ctx := context.Background()
+	rcpMsg := CreateRecipeMsgFromRef(step.ParamsRef, t)
+	ValidateStepMsg(step, step.ParamsRef, &rcpMsg, t)
+	t.MustTrue(rcpMsg.BlockInterval > 0, "recipe should have positive block interval for delayed execution")
+	rcpRes, err := InMemoryChain.CreateRecipe(ctx, &rcpMsg)
+	t.MustNil(err, "error creating recipe on in-memory chain")
+	execRes, err := InMemoryChain.ExecuteRecipe(ctx, &types.MsgExecuteRecipe{RecipeID: rcpRes.RecipeID, Sender: rcpMsg.Sender})
+	t.MustNil(err, "error executing recipe on in-memory chain")
+	var scheduleRes types.ExecuteRecipeScheduleOutput
+	err = json.Unmarshal(execRes.Output, &scheduleRes)
+	t.WithFields(testing.Fields{
+		"response_output": string(execRes.Output),
+	}).MustNil(err, "error decoding raw json")
+	InMemoryChain.AdvanceBlocks(rcpMsg.BlockInterval)
+	chkRes, err := InMemoryChain.CheckExecution(ctx, &types.MsgCheckExecution{ExecID: scheduleRes.ExecID, Sender: rcpMsg.Sender})
+	t.WithFields(testing.Fields{
+		"recipe_id": rcpRes.RecipeID,
+		"exec_id":   scheduleRes.ExecID,
+	}).MustTrue(err == nil && chkRes.Status == mockchain.StatusSuccess, "delayed execution should be completed after block interval")
+	RegisterStepResults(step, inttest.DelayedExecutionResult{RecipeID: rcpRes.RecipeID, ExecID: scheduleRes.ExecID}, t)
+}
@@ -0,0 +1,43 @@
+package fixturetest
+
+import (
+	"context"
+	"sync"
+	originT "testing"
+
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+// CheckStateModel is a function to keep local model of state expected from transactions sent by scenarios
+// and fail the test when chain state diverges from it after a block or after all scenarios finish
+func CheckStateModel(t *originT.T) {
+	model := inttest.NewStateModel()
+	recorder := inttest.CLIOpts.TxRecorder
+	inttest.CLIOpts.TxRecorder = inttest.TxRecorders{recorder, model}
+
+	report := func(report inttest.ModelReport, err error) {
+		if err != nil {
+			t.Logf("error reconciling state model: %s", err.Error())
+			return
+		}
+		for _, divergence := range report.Divergences {
+			t.Errorf("state diverged from model at height %d: %s", report.Height, divergence)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		model.WatchBlocks(ctx, report)
+	}()
+
+	// parallel scenarios finish after RunTestScenarios returns, so the final reconcile runs on cleanup
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+		inttest.CLIOpts.TxRecorder = recorder
+		report(model.Reconcile(context.Background()))
+	})
+}
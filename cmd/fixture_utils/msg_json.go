@@ -0,0 +1,86 @@
+package fixturetest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// msgJSONParams is a struct to describe params of send_msg action, Msg is json of msg type e.g. {"RecipeID": "...", "Sender": "eugen"}
+type msgJSONParams struct {
+	MsgType string
+	Msg     json.RawMessage
+}
+
+// msgJSONAccountFields are fields of msg json which can be account names instead of addresses
+var msgJSONAccountFields = []string{"Sender", "Receiver", "Requester"}
+
+// readMsgJSONParams is a function to read params of send_msg action strictly
+func readMsgJSONParams(bz []byte) (msgJSONParams, error) {
+	var params msgJSONParams
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&params)
+	return params, err
+}
+
+// MsgJSONFromRef is a function to read msg of send_msg params through inttest.DecodeMsgJSON, it returns msg with its first signer
+// Sender, Receiver and Requester of msg json can be account names, msg is validated by ValidateStepMsg so that steps can expect errors.
+func MsgJSONFromRef(ref string, t *testing.T) (sdk.Msg, string) {
+	byteValue := ReadFile(ref, t)
+	params, err := readMsgJSONParams(byteValue)
+	t.WithFields(testing.Fields{
+		"params_ref": ref,
+	}).MustNil(err, "error reading send_msg params")
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(params.Msg, &fields)
+	t.WithFields(testing.Fields{
+		"msg": string(params.Msg),
+	}).MustNil(err, "msg of send_msg params should be a json object")
+	for _, field := range msgJSONAccountFields {
+		var tempName string
+		if json.Unmarshal(fields[field], &tempName) != nil || len(tempName) == 0 {
+			continue
+		}
+		fields[field], err = json.Marshal(GetAccountAddressFromTempName(tempName, t))
+		t.MustNil(err, "error encoding account address")
+	}
+	msgBytes, err := json.Marshal(fields)
+	t.MustNil(err, "error encoding msg json")
+
+	msg, err := inttest.DecodeMsgJSON(params.MsgType, msgBytes)
+	t.WithFields(testing.Fields{
+		"msg_type": params.MsgType,
+		"msg":      string(msgBytes),
+	}).MustNil(err, "error decoding msg json")
+	signers := msg.GetSigners()
+	t.MustTrue(len(signers) > 0, "msg should have a signer")
+	return msg, signers[0].String()
+}
+
+// RunSendMsg is a function to send a msg of any type written as raw json in params e.g. to test msgs which have no dedicated action
+func RunSendMsg(step FixtureStep, t *testing.T) {
+	if FixtureTestOpts.VerifyOnly {
+		return
+	}
+	if step.ParamsRef != "" {
+		msg, sender := MsgJSONFromRef(step.ParamsRef, t)
+		ValidateStepMsg(step, step.ParamsRef, msg, t)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(sender), msg)
+		if err != nil {
+			TxBroadcastErrorCheck(err, txhash, step, t)
+			return
+		}
+
+		WaitForNextBlockWithErrorCheck(t)
+		if TxFailureCheck(txhash, step, t) {
+			return
+		}
+		GetTxHandleResult(txhash, t)
+	}
+}
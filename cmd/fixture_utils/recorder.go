@@ -0,0 +1,269 @@
+package fixturetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// Recorder is a struct to record transactions of a live session into fixture scenario and params files
+// It implements inttest.TxRecorder, set it by inttest.WithTxRecorder or inttest.CLIOpts.TxRecorder
+// Supported actions are create_cookbook, create_recipe and execute_recipe, transactions with several of them are
+// recorded as multi_msg_tx and other messages are not recorded
+type Recorder struct {
+	mux          sync.Mutex
+	name         string
+	accountNames map[string]string // account address to temp name used in params files
+	recipeNames  map[string]string // recipe id to recipe name of recorded recipes
+	steps        []FixtureStep
+	stepIdxByTx  map[string]int
+	params       map[string][]byte // params ref to file content
+	actionCount  map[string]int
+}
+
+// NewRecorder is a function to create recorder, name is used for scenario file name and params directories
+func NewRecorder(name string) *Recorder {
+	return &Recorder{
+		name:         name,
+		accountNames: make(map[string]string),
+		recipeNames:  make(map[string]string),
+		stepIdxByTx:  make(map[string]int),
+		params:       make(map[string][]byte),
+		actionCount:  make(map[string]int),
+	}
+}
+
+// AddAccount is a function to set temp name of account address written to params files instead of the address
+func (r *Recorder) AddAccount(tempName, address string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.accountNames[address] = tempName
+}
+
+// RecordTx is a function to record broadcast transaction as a step
+func (r *Recorder) RecordTx(msgs []sdk.Msg, output string, err error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	step := FixtureStep{}
+	actions := []string{}
+	msgParams := [][]byte{}
+	for _, msg := range msgs {
+		action, params, convErr := r.msgToParams(msg)
+		if convErr != nil {
+			log.WithFields(log.Fields{
+				"msg_type": fmt.Sprintf("%T", msg),
+				"error":    convErr,
+			}).Warn("transaction is not recorded as fixture step")
+			return
+		}
+		actions = append(actions, action)
+		msgParams = append(msgParams, params)
+	}
+	if len(actions) == 0 {
+		return
+	}
+	refs := []string{}
+	for idx, action := range actions {
+		r.actionCount[action]++
+		ref := fmt.Sprintf("./%s/%s/%s_%d.json", paramsDirOfAction(action), r.name, action, r.actionCount[action])
+		r.params[ref] = msgParams[idx]
+		refs = append(refs, ref)
+	}
+	if len(actions) == 1 {
+		step.Action = actions[0]
+		step.ParamsRef = refs[0]
+	} else {
+		step.Action = "multi_msg_tx"
+		for idx := range actions {
+			step.MsgRefs = append(step.MsgRefs, struct {
+				Action    string `json:"action"`
+				ParamsRef string `json:"paramsRef"`
+			}{actions[idx], refs[idx]})
+		}
+	}
+	step.ID = strings.ToUpper(fmt.Sprintf("%s_%s_%d", r.name, step.Action, len(r.steps)+1))
+	step.RunAfter.PreCondition = []string{}
+	if len(r.steps) > 0 {
+		step.RunAfter.PreCondition = []string{r.steps[len(r.steps)-1].ID}
+	}
+	if err != nil {
+		step.Output.TxResult.BroadcastError = err.Error()
+	} else {
+		step.Output.TxResult.Status = "Success"
+		r.stepIdxByTx[output] = len(r.steps)
+	}
+	r.steps = append(r.steps, step)
+}
+
+// RecordTxResult is a function to record result of a recorded transaction
+func (r *Recorder) RecordTxResult(txhash string, txResult inttest.TxResult, err error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	idx, ok := r.stepIdxByTx[txhash]
+	if !ok || err == nil || txResult.Code == 0 {
+		return
+	}
+	r.steps[idx].Output.TxResult.Status = ""
+	r.steps[idx].Output.TxResult.ErrorLog = txResult.RawLog
+}
+
+// RecordHistory is a function to record committed transactions e.g. of inttest.SearchTxsBySender as steps in order
+// Failed transactions are recorded with their error log, transactions which couldn't be decoded are not recorded.
+func (r *Recorder) RecordHistory(txs []inttest.HistoryTx) {
+	for _, tx := range txs {
+		if len(tx.Msgs) == 0 {
+			continue
+		}
+		r.RecordTx(tx.Msgs, tx.TxHash, nil)
+		if tx.Code != 0 {
+			txResult := inttest.TxResult{TxResponse: sdk.TxResponse{TxHash: tx.TxHash, Code: tx.Code, RawLog: tx.Log}}
+			r.RecordTxResult(tx.TxHash, txResult, fmt.Errorf("transaction failed with code %d", tx.Code))
+		}
+	}
+}
+
+// Steps is a function to get recorded steps
+func (r *Recorder) Steps() []FixtureStep {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return append([]FixtureStep{}, r.steps...)
+}
+
+// Save is a function to write scenario file and params files of recorded steps under base directory of fixture test
+func (r *Recorder) Save(baseDir string) (string, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	for ref, params := range r.params {
+		if err := writeRecordedFile(filepath.Join(baseDir, ref), params); err != nil {
+			return "", err
+		}
+	}
+	scenario, err := json.MarshalIndent(r.steps, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	scenarioFile := filepath.Join(baseDir, "scenarios", r.name+".json")
+	return scenarioFile, writeRecordedFile(scenarioFile, scenario)
+}
+
+// msgToParams is a function to get fixture action and params file content of message
+func (r *Recorder) msgToParams(msg sdk.Msg) (string, []byte, error) {
+	switch msg := msg.(type) {
+	case *types.MsgCreateCookbook:
+		cb := types.Cookbook{
+			NodeVersion:  "0.0.1",
+			ID:           msg.CookbookID,
+			Name:         msg.Name,
+			Description:  msg.Description,
+			Version:      msg.Version,
+			Developer:    msg.Developer,
+			Level:        msg.Level,
+			SupportEmail: msg.SupportEmail,
+			CostPerBlock: msg.CostPerBlock,
+			Sender:       r.accountName(msg.Sender),
+		}
+		params, err := inttest.GetJSONMarshaler().MarshalJSON(&cb)
+		if err != nil {
+			return "", nil, err
+		}
+		return "create_cookbook", indentJSON(params), nil
+	case *types.MsgCreateRecipe:
+		if len(msg.RecipeID) > 0 {
+			r.recipeNames[msg.RecipeID] = msg.Name
+		}
+		rcp := types.Recipe{
+			NodeVersion:   "0.0.1",
+			ID:            msg.RecipeID,
+			CookbookID:    msg.CookbookID,
+			Name:          msg.Name,
+			CoinInputs:    msg.CoinInputs,
+			ItemInputs:    msg.ItemInputs,
+			Entries:       msg.Entries,
+			Outputs:       msg.Outputs,
+			Description:   msg.Description,
+			BlockInterval: msg.BlockInterval,
+			Sender:        r.accountName(msg.Sender),
+			ExtraInfo:     msg.ExtraInfo,
+		}
+		params, err := json.MarshalIndent(rcp, "", "    ")
+		return "create_recipe", params, err
+	case *types.MsgExecuteRecipe:
+		rcpName, ok := r.recipeNames[msg.RecipeID]
+		if !ok {
+			rcp, err := inttest.GetRecipeByGUID(msg.RecipeID)
+			if err != nil {
+				return "", nil, fmt.Errorf("error getting recipe name of %s: %w", msg.RecipeID, err)
+			}
+			rcpName = rcp.Name
+		}
+		itemNames := []string{}
+		for _, itemID := range msg.ItemIDs {
+			item, err := inttest.GetItemByGUID(itemID)
+			if err != nil {
+				return "", nil, fmt.Errorf("error getting item name of %s: %w", itemID, err)
+			}
+			itemName, ok := item.FindString("Name")
+			if !ok {
+				return "", nil, fmt.Errorf("item %s does not have Name", itemID)
+			}
+			itemNames = append(itemNames, itemName)
+		}
+		params, err := json.MarshalIndent(struct {
+			NodeVersion string
+			RecipeName  string
+			Sender      string
+			ItemNames   []string
+		}{"0.0.1", rcpName, r.accountName(msg.Sender), itemNames}, "", "    ")
+		return "execute_recipe", params, err
+	default:
+		return "", nil, fmt.Errorf("recording %T is not supported", msg)
+	}
+}
+
+// accountName is a function to get temp name of account address, the address is kept when it's not added
+func (r *Recorder) accountName(address string) string {
+	if tempName, ok := r.accountNames[address]; ok {
+		return tempName
+	}
+	return address
+}
+
+// paramsDirOfAction is a function to get directory of params files for fixture action
+func paramsDirOfAction(action string) string {
+	switch action {
+	case "create_cookbook":
+		return "cookbooks"
+	case "create_recipe":
+		return "recipes"
+	default:
+		return "executions"
+	}
+}
+
+func indentJSON(bz []byte) []byte {
+	var out bytes.Buffer
+	if err := json.Indent(&out, bz, "", "    "); err != nil {
+		return bz
+	}
+	return out.Bytes()
+}
+
+func writeRecordedFile(filename string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, content, 0644)
+}
@@ -0,0 +1,306 @@
+package fixturetest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Role is a type of test account role which params files refer instead of account temp names
+// e.g. {{.roles.player.address}} and {{.roles.cookbook_owner.key}} are address and key of role account.
+type Role string
+
+// roles of test accounts
+const (
+	// RoleCookbookOwner is the role of account creating cookbooks and recipes of scenario
+	RoleCookbookOwner Role = "cookbook_owner"
+	// RolePlayer is the role of account executing recipes and trading items
+	RolePlayer Role = "player"
+	// RoleAdmin is the role of privileged account loaded by -admin-mnemonic-file
+	RoleAdmin Role = "admin"
+	// RoleStranger is the role of funded account which has nothing to do with the scenario
+	RoleStranger Role = "stranger"
+)
+
+// Roles is the list of all roles
+var Roles = []Role{RoleCookbookOwner, RolePlayer, RoleAdmin, RoleStranger}
+
+// rolesTemplateKey is the top level template key of role accounts
+const rolesTemplateKey = "roles"
+
+var roleSetupMux sync.Mutex
+var setupRoles = make(map[Role]bool)
+
+// ParseRole is a function to get role from its name, "cookbook-owner" is accepted as well as "cookbook_owner"
+func ParseRole(name string) (Role, error) {
+	role := Role(strings.ReplaceAll(strings.ToLower(name), "-", "_"))
+	for _, r := range Roles {
+		if r == role {
+			return role, nil
+		}
+	}
+	return "", fmt.Errorf("unknown role %s, it should be one of cookbook_owner, player, admin and stranger", name)
+}
+
+// TempName is a function to get account temp name of role, e.g. "Sender": "role_player" in params files
+func (r Role) TempName() string {
+	if r == RoleAdmin {
+		return AdminTempName
+	}
+	return "role_" + string(r)
+}
+
+// SetupRoleAccounts is a function to create and fund accounts of roles once per run, admin role uses admin key
+func SetupRoleAccounts(roles []Role, t *testing.T) {
+	roleSetupMux.Lock()
+	defer roleSetupMux.Unlock()
+	for _, role := range roles {
+		if setupRoles[role] {
+			continue
+		}
+		if role == RoleAdmin {
+			t.MustTrue(len(FixtureTestOpts.AdminKey) > 0, "admin role requires admin key set by -admin-mnemonic-file")
+		} else if !FixtureTestOpts.VerifyOnly {
+			RunMockAccount(FixtureStep{ID: "SETUP_ROLE_" + strings.ToUpper(string(role)), Action: "mock_account", ParamsRef: role.TempName()}, t)
+		}
+		t.WithFields(testing.Fields{
+			"role":    role,
+			"address": GetAccountAddressFromTempName(role.TempName(), t),
+		}).Info("role account is set up")
+		setupRoles[role] = true
+	}
+}
+
+// templateRoles is a function to get roles referred by fields of template e.g. player of {{.roles.player.address}}
+func templateRoles(tmplFields [][]string, t *testing.T) []Role {
+	roles := []Role{}
+	for _, ident := range tmplFields {
+		if len(ident) < 2 || ident[0] != rolesTemplateKey {
+			continue
+		}
+		role, err := ParseRole(ident[1])
+		t.MustNil(err, "error parsing role of fixture template")
+		if !roleExists(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func roleExists(roles []Role, role Role) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionMatrix is a type to describe roles allowed to send each action, e.g. {"disable_recipe": ["cookbook_owner"]}
+type PermissionMatrix map[string][]Role
+
+// DefaultRecipePermissions is the permission matrix of recipe actions, only cookbook owner can change its recipes
+var DefaultRecipePermissions = PermissionMatrix{
+	"update_recipe":  {RoleCookbookOwner},
+	"disable_recipe": {RoleCookbookOwner},
+	"enable_recipe":  {RoleCookbookOwner},
+}
+
+// recipePermissionActions is the order recipe actions are checked in
+var recipePermissionActions = []string{"update_recipe", "disable_recipe", "enable_recipe"}
+
+// recipeStateBefore is recipe disabled state each action should start from, so that a failure is caused by permission
+// rather than recipe being disabled or enabled already
+var recipeStateBefore = map[string]bool{
+	"disable_recipe": false,
+	"enable_recipe":  true,
+}
+
+// PermissionResult is a struct to describe result of an action sent by a role
+type PermissionResult struct {
+	Action  string `json:"action"`
+	Role    Role   `json:"role"`
+	Allowed bool   `json:"allowed"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Unexpected is a function to check if action succeeded by a role which is not allowed or failed by an allowed role
+func (r PermissionResult) Unexpected() bool {
+	return r.Allowed != r.Success
+}
+
+// recipeActionMsg is a function to get msg of recipe action sent by sender, update doesn't change the recipe
+func recipeActionMsg(action string, recipe types.Recipe, sender string) (sdk.Msg, error) {
+	switch action {
+	case "update_recipe":
+		msg := types.NewMsgUpdateRecipe(recipe.ID, recipe.Name, recipe.CookbookID, recipe.Description,
+			recipe.CoinInputs, recipe.ItemInputs, recipe.Entries, recipe.Outputs, recipe.BlockInterval, sender)
+		return &msg, nil
+	case "disable_recipe":
+		msg := types.NewMsgDisableRecipe(recipe.ID, sender)
+		return &msg, nil
+	case "enable_recipe":
+		msg := types.NewMsgEnableRecipe(recipe.ID, sender)
+		return &msg, nil
+	default:
+		return nil, fmt.Errorf("unknown recipe action %s, it should be one of update_recipe, disable_recipe and enable_recipe", action)
+	}
+}
+
+// CheckRecipePermissions is a function to send each recipe action of matrix by each role and compare results with the matrix
+// Cookbook owner role is the sender of the recipe, other roles are set up by SetupRoleAccounts.
+// Recipe is disabled or enabled by its sender before each action as needed, and it's enabled again after the check.
+func CheckRecipePermissions(recipeID string, matrix PermissionMatrix, roles []Role, t *testing.T) []PermissionResult {
+	for action := range matrix {
+		t.WithFields(testing.Fields{
+			"action": action,
+		}).MustTrue(inttest.Exists(recipePermissionActions, action), "permission matrix should only have update_recipe, disable_recipe and enable_recipe")
+	}
+
+	recipe, err := inttest.GetRecipeByGUID(recipeID)
+	t.WithFields(testing.Fields{
+		"recipe_id": recipeID,
+	}).MustNil(err, "error getting recipe of permission check")
+	t.WithFields(testing.Fields{
+		"recipe_id": recipeID,
+	}).MustTrue(!recipe.Disabled, "recipe should be enabled before permission check")
+
+	roleAddrs := make(map[Role]string)
+	setup := []Role{}
+	for _, role := range roles {
+		if role == RoleCookbookOwner {
+			roleAddrs[role] = recipe.Sender
+			continue
+		}
+		setup = append(setup, role)
+	}
+	SetupRoleAccounts(setup, t)
+	for _, role := range setup {
+		roleAddrs[role] = GetAccountAddressFromTempName(role.TempName(), t)
+	}
+
+	results := []PermissionResult{}
+	disabled := false
+	for _, action := range recipePermissionActions {
+		allowedRoles, ok := matrix[action]
+		if !ok {
+			continue
+		}
+		for _, role := range roles {
+			if disabledBefore, ok := recipeStateBefore[action]; ok && disabled != disabledBefore {
+				disabled = setRecipeDisabled(recipe, disabledBefore, t)
+			}
+			msg, err := recipeActionMsg(action, recipe, roleAddrs[role])
+			t.MustNil(err, "error building msg of permission check")
+			result := PermissionResult{Action: action, Role: role, Allowed: roleExists(allowedRoles, role)}
+			_, err = inttest.NewClient().SendTxAndWait(context.Background(), t, inttest.SignerAddress(roleAddrs[role]), msg)
+			result.Success = err == nil
+			if err != nil {
+				result.Error = err.Error()
+			} else if action != "update_recipe" {
+				disabled = action == "disable_recipe"
+			}
+			t.WithFields(testing.Fields{
+				"action":  action,
+				"role":    role,
+				"allowed": result.Allowed,
+				"error":   result.Error,
+			}).Debug("permission checked")
+			results = append(results, result)
+		}
+	}
+	if disabled {
+		setRecipeDisabled(recipe, false, t)
+	}
+	return results
+}
+
+// setRecipeDisabled is a function to disable or enable recipe by its sender, it returns the disabled state
+func setRecipeDisabled(recipe types.Recipe, disabled bool, t *testing.T) bool {
+	action := "enable_recipe"
+	if disabled {
+		action = "disable_recipe"
+	}
+	msg, err := recipeActionMsg(action, recipe, recipe.Sender)
+	t.MustNil(err, "error building msg of recipe state")
+	_, err = inttest.NewClient().SendTxAndWait(context.Background(), t, inttest.SignerAddress(recipe.Sender), msg)
+	t.WithFields(testing.Fields{
+		"recipe_id": recipe.ID,
+		"action":    action,
+	}).MustNil(err, "error changing recipe state by its sender for permission check")
+	return disabled
+}
+
+// CheckPermissionsMsg is a struct to describe params of check_permissions action
+type CheckPermissionsMsg struct {
+	RecipeID string
+	Roles    []string
+	Allowed  map[string][]string // DefaultRecipePermissions is used when it's empty
+}
+
+// CheckPermissionsMsgFromRef collect params of check_permissions action from reference string
+func CheckPermissionsMsgFromRef(ref string, t *testing.T) CheckPermissionsMsg {
+	byteValue := UpdateRecipeName(ReadFile(ref, t), t)
+	var msg CheckPermissionsMsg
+	err := json.Unmarshal(byteValue, &msg)
+	t.WithFields(testing.Fields{
+		"params": string(byteValue),
+	}).MustNil(err, "error reading params of permission check")
+	return msg
+}
+
+// RunCheckPermissions is a function to check only roles of permission matrix can update, disable and enable the recipe
+func RunCheckPermissions(step FixtureStep, t *testing.T) {
+	if FixtureTestOpts.VerifyOnly {
+		return
+	}
+	if step.ParamsRef != "" {
+		params := CheckPermissionsMsgFromRef(step.ParamsRef, t)
+		roles := Roles
+		if len(params.Roles) > 0 {
+			roles = []Role{}
+			for _, name := range params.Roles {
+				role, err := ParseRole(name)
+				t.MustNil(err, "error parsing role of permission check")
+				roles = append(roles, role)
+			}
+		}
+		if len(FixtureTestOpts.AdminKey) == 0 && len(params.Roles) == 0 {
+			// admin role is only checked when it's set up, or required explicitly
+			roles = []Role{RoleCookbookOwner, RolePlayer, RoleStranger}
+		}
+		matrix := DefaultRecipePermissions
+		if len(params.Allowed) > 0 {
+			matrix = PermissionMatrix{}
+			for action, names := range params.Allowed {
+				matrix[action] = []Role{}
+				for _, name := range names {
+					role, err := ParseRole(name)
+					t.MustNil(err, "error parsing role of permission matrix")
+					matrix[action] = append(matrix[action], role)
+				}
+			}
+		}
+
+		results := CheckRecipePermissions(params.RecipeID, matrix, roles, t)
+		t.AttachJSON("permission_matrix_"+step.ID+".json", results)
+		unexpected := []PermissionResult{}
+		for _, result := range results {
+			if result.Unexpected() {
+				unexpected = append(unexpected, result)
+			}
+		}
+		t.WithFields(testing.Fields{
+			"recipe_id":  params.RecipeID,
+			"unexpected": unexpected,
+		}).MustTrue(len(unexpected) == 0, "recipe actions are allowed to roles different from permission matrix")
+	}
+}
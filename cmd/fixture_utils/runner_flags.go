@@ -0,0 +1,187 @@
+package fixturetest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	originT "testing"
+
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+// runnerFlags is a struct to have command line flags of fixture runner
+// Flags are registered on flag.CommandLine so that go test entry point and cmd/fixturetest share them.
+type runnerFlags struct {
+	fixturesDir          string
+	runSerialMode        bool
+	useRest              bool
+	useKnownCookbook     bool
+	verifyOnly           bool
+	dryRun               bool
+	inMemory             bool
+	scenarios            string
+	accounts             string
+	statusAddr           string
+	nodeCapabilities     string
+	runQuarantined       bool
+	failOn               string
+	stateGuardAccounts   string
+	adminMnemonicFile    string
+	cleanup              bool
+	cleanupItemReceiver  string
+	fixtureTags          string
+	modelCheck           bool
+	skipExisting         bool
+	maxScenarioBlocks    int64
+	gasBudgetFile        string
+	genesisFixtures      string
+	keyringArchive       string
+	chaosRestartAfter    string
+	chaosRestartCmd      string
+	checkpointFile       string
+	resume               bool
+	scenarioPageSize     int
+	scenarioPage         int
+	auditExecutions      bool
+	failOnExecutionLeaks bool
+	executionGraceBlocks int64
+	addressBookFile      string
+}
+
+var runnerOpts = runnerFlags{}
+
+func init() {
+	flag.StringVar(&runnerOpts.fixturesDir, "fixtures-dir", ".", "directory having scenarios directory and params files of fixtures")
+	flag.BoolVar(&runnerOpts.runSerialMode, "runserial", false, "true/false value to check if test will be running in parallel")
+	flag.BoolVar(&runnerOpts.useRest, "userest", false, "use rest endpoint for Tx send")
+	flag.BoolVar(&runnerOpts.useKnownCookbook, "use-known-cookbook", false, "use existing cookbook or not")
+	flag.BoolVar(&runnerOpts.verifyOnly, "verify-only", false, "use this flag to only verify")
+	flag.BoolVar(&runnerOpts.dryRun, "dry-run", false, "validate fixtures and simulate transactions of steps to estimate gas without broadcasting them")
+	flag.BoolVar(&runnerOpts.inMemory, "in-memory", false, "run steps against an in-process mock chain seeded by test data seed instead of a live node, mock chain doesn't check signatures, fees, gas and tx results like a node so in memory runs don't replace runs against a node")
+	flag.StringVar(&runnerOpts.scenarios, "scenarios", "", "custom scenario file names")
+	flag.StringVar(&runnerOpts.fixtureTags, "fixture-tags", "", "run only scenarios having any of tags e.g. smoke,trade,slow")
+	flag.StringVar(&runnerOpts.accounts, "accounts", "", "custom account names")
+	flag.StringVar(&runnerOpts.statusAddr, "status-addr", "", "address to serve live run status e.g. localhost:8090")
+	flag.StringVar(&runnerOpts.nodeCapabilities, "node-capabilities", "", "capabilities of the node which steps can require")
+	flag.BoolVar(&runnerOpts.runQuarantined, "run-quarantined", false, "run quarantined steps")
+	flag.StringVar(&runnerOpts.failOn, "fail-on", "", "step states to fail the run on e.g. skipped,not_applicable,quarantined")
+	flag.StringVar(&runnerOpts.stateGuardAccounts, "state-guard-accounts", "", "account keys whose state should not be changed by scenarios")
+	flag.StringVar(&runnerOpts.adminMnemonicFile, "admin-mnemonic-file", "", "file having mnemonic of admin key, steps requiring admin_key run when it's loaded")
+	flag.BoolVar(&runnerOpts.cleanup, "cleanup", false, "disable recipes and trades created by scenarios after the run")
+	flag.StringVar(&runnerOpts.cleanupItemReceiver, "cleanup-item-receiver", "", "account name or address to send items created by scenarios to after the run")
+	flag.BoolVar(&runnerOpts.modelCheck, "model-check", false, "reconcile chain state after each block against a local model of transactions sent by scenarios")
+	flag.BoolVar(&runnerOpts.skipExisting, "skip-existing", false, "skip broadcasting create cookbook and recipe steps when identical ones exist on chain")
+	flag.Int64Var(&runnerOpts.maxScenarioBlocks, "max-scenario-blocks", 0, "fail scenarios when chain advances more blocks while each runs, 0 not to check")
+	flag.StringVar(&runnerOpts.gasBudgetFile, "gas-budget-file", "", "json file of max gas per msg type e.g. {\"create_cookbook\": 60000}, transactions using more gas fail the run")
+	flag.StringVar(&runnerOpts.genesisFixtures, "genesis-fixtures", "", "fixture files replayed in order when chain reset is detected e.g. scenarios/loud.json")
+	flag.StringVar(&runnerOpts.keyringArchive, "keyring-archive", "", "encrypted keyring archive file whose keys are imported before scenarios run, passphrase is read from $PYLONS_KEYRING_ARCHIVE_PASSPHRASE")
+	flag.StringVar(&runnerOpts.chaosRestartAfter, "chaos-restart-after", "", "IDs of steps the node is restarted after by chaos-restart-cmd")
+	flag.StringVar(&runnerOpts.checkpointFile, "checkpoint", "", "file passed steps and their outputs are recorded to, so that an interrupted run can be resumed")
+	flag.BoolVar(&runnerOpts.resume, "resume", false, "continue from checkpoint file without running steps which passed before")
+	flag.IntVar(&runnerOpts.scenarioPageSize, "scenario-page-size", 0, "number of scenario files of a page, 0 to run all scenarios")
+	flag.IntVar(&runnerOpts.scenarioPage, "scenario-page", 1, "1-based page of scenario files to run when scenario-page-size is set")
+	flag.BoolVar(&runnerOpts.auditExecutions, "audit-executions", false, "report executions of test accounts which are not completed when scenarios finish")
+	flag.BoolVar(&runnerOpts.failOnExecutionLeaks, "fail-on-execution-leaks", false, "fail the run on executions reported by audit-executions")
+	flag.Int64Var(&runnerOpts.executionGraceBlocks, "execution-grace-blocks", inttest.DefaultExecutionGraceBlocks, "blocks a ready execution can stay unchecked before audit-executions reports it as stuck")
+	flag.StringVar(&runnerOpts.addressBookFile, "address-book", "", "json file of account aliases to addresses e.g. {\"treasury\": \"cosmos1...\"} used as account names by fixtures")
+	flag.StringVar(&runnerOpts.chaosRestartCmd, "chaos-restart-cmd", "", "command restarting the node between steps e.g. \"docker restart pylonsd\"")
+}
+
+// splitFlagList is a function to split comma separated flag value, it's empty for empty value
+func splitFlagList(value string) []string {
+	if len(value) == 0 {
+		return []string{}
+	}
+	return strings.Split(value, ",")
+}
+
+// RunnerScenarioNames is a function to get scenario names selected by -scenarios flag, empty to run all scenarios
+func RunnerScenarioNames() []string {
+	return splitFlagList(runnerOpts.scenarios)
+}
+
+// ApplyRunnerFlags is a function to set FixtureTestOpts and harness options from runner flags after flags are parsed
+// Keyring archive and admin key are loaded here, so it should be called once before RunTestScenarios.
+func ApplyRunnerFlags(t *originT.T) error {
+	FixtureTestOpts.IsParallel = !runnerOpts.runSerialMode
+	FixtureTestOpts.CreateNewCookbook = !runnerOpts.useKnownCookbook
+	FixtureTestOpts.VerifyOnly = runnerOpts.verifyOnly
+	FixtureTestOpts.DryRun = runnerOpts.dryRun
+	if runnerOpts.inMemory {
+		EnableInMemory(inttest.GetTestDataSeed())
+	}
+	FixtureTestOpts.BaseDirectory = runnerOpts.fixturesDir
+	FixtureTestOpts.StatusServerAddr = runnerOpts.statusAddr
+	FixtureTestOpts.RunQuarantined = runnerOpts.runQuarantined
+	FixtureTestOpts.Cleanup = runnerOpts.cleanup
+	FixtureTestOpts.CleanupItemReceiver = runnerOpts.cleanupItemReceiver
+	FixtureTestOpts.ModelCheck = runnerOpts.modelCheck
+	FixtureTestOpts.SkipExisting = runnerOpts.skipExisting
+	FixtureTestOpts.MaxScenarioBlocks = runnerOpts.maxScenarioBlocks
+	FixtureTestOpts.NodeCapabilities = splitFlagList(runnerOpts.nodeCapabilities)
+	failOnStates, err := ParseStepStates(runnerOpts.failOn)
+	if err != nil {
+		return fmt.Errorf("error parsing fail-on option: %w", err)
+	}
+	FixtureTestOpts.FailOnStates = failOnStates
+	if len(runnerOpts.gasBudgetFile) > 0 {
+		budgets, err := ReadGasBudgets(runnerOpts.gasBudgetFile)
+		if err != nil {
+			return fmt.Errorf("error reading gas-budget-file option: %w", err)
+		}
+		FixtureTestOpts.GasBudgets = budgets
+	}
+	FixtureTestOpts.GenesisFixtures = splitFlagList(runnerOpts.genesisFixtures)
+	FixtureTestOpts.ChaosRestartSteps = splitFlagList(runnerOpts.chaosRestartAfter)
+	if len(runnerOpts.chaosRestartCmd) > 0 {
+		FixtureTestOpts.ChaosNode = inttest.CommandRestarter{Command: runnerOpts.chaosRestartCmd}
+	}
+	FixtureTestOpts.CheckpointFile = runnerOpts.checkpointFile
+	FixtureTestOpts.Resume = runnerOpts.resume
+	FixtureTestOpts.ScenarioPageSize = runnerOpts.scenarioPageSize
+	FixtureTestOpts.ScenarioPage = runnerOpts.scenarioPage
+	FixtureTestOpts.AuditExecutions = runnerOpts.auditExecutions || runnerOpts.failOnExecutionLeaks
+	FixtureTestOpts.FailOnExecutionLeaks = runnerOpts.failOnExecutionLeaks
+	FixtureTestOpts.ExecutionGraceBlocks = runnerOpts.executionGraceBlocks
+	if len(runnerOpts.addressBookFile) > 0 {
+		if err := inttest.LoadAddressBook(runnerOpts.addressBookFile); err != nil {
+			return fmt.Errorf("error reading address-book option: %w", err)
+		}
+	}
+	if runnerOpts.useRest {
+		inttest.CLIOpts.RestEndpoint = "http://localhost:1317"
+	}
+	inttest.CLIOpts.MaxBroadcast = 5
+	FixtureTestOpts.Tags = splitFlagList(runnerOpts.fixtureTags)
+	FixtureTestOpts.AccountNames = splitFlagList(runnerOpts.accounts)
+	FixtureTestOpts.StateGuardAccounts = splitFlagList(runnerOpts.stateGuardAccounts)
+	if len(runnerOpts.keyringArchive) > 0 || len(os.Getenv(inttest.KeyringArchiveEnv)) > 0 {
+		names, err := inttest.ImportKeyringArchiveFile(runnerOpts.keyringArchive)
+		if err != nil {
+			return fmt.Errorf("error importing keyring archive: %w", err)
+		}
+		t.Log("imported keys of keyring archive", names)
+	}
+	if len(runnerOpts.adminMnemonicFile) > 0 || len(os.Getenv(inttest.AdminMnemonicEnv)) > 0 {
+		adminKey, err := inttest.AdminKeyProvider{MnemonicFile: runnerOpts.adminMnemonicFile}.Load()
+		if err != nil {
+			return fmt.Errorf("error loading admin key: %w", err)
+		}
+		FixtureTestOpts.AdminKey = adminKey
+		FixtureTestOpts.NodeCapabilities = append(FixtureTestOpts.NodeCapabilities, "admin_key")
+	}
+	return nil
+}
+
+// SelectedScenarioFiles is a function to get scenario files of -fixtures-dir selected by -scenarios and -fixture-tags flags
+func SelectedScenarioFiles() ([]string, error) {
+	FixtureTestOpts.BaseDirectory = runnerOpts.fixturesDir
+	files, err := ScenarioFiles("scenarios", RunnerScenarioNames())
+	if err != nil {
+		return nil, err
+	}
+	selected, _, err := FilterScenariosByTags(files, splitFlagList(runnerOpts.fixtureTags))
+	return selected, err
+}
@@ -0,0 +1,64 @@
+package fixturetest
+
+import (
+	"path/filepath"
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestSelectedScenarioFilesOfFixturesDir(originT *originT.T) {
+	t := testing.NewT(originT)
+	defer func(opts runnerFlags, baseDir string) {
+		runnerOpts = opts
+		FixtureTestOpts.BaseDirectory = baseDir
+	}(runnerOpts, FixtureTestOpts.BaseDirectory)
+	RegisterDefaultActionRunners()
+
+	absDir, err := filepath.Abs("../fixtures_test")
+	t.MustNil(err, "error getting absolute fixtures dir")
+	for _, fixturesDir := range []string{"../fixtures_test", absDir} {
+		runnerOpts.fixturesDir = fixturesDir
+		runnerOpts.scenarios = ""
+		runnerOpts.fixtureTags = ""
+		files, err := SelectedScenarioFiles()
+		t.WithFields(testing.Fields{
+			"fixtures_dir": fixturesDir,
+		}).MustNil(err, "error selecting scenarios")
+		all, err := filepath.Glob(filepath.Join(fixturesDir, "scenarios", "*.json"))
+		t.MustNil(err, "error listing scenarios")
+		t.WithFields(testing.Fields{
+			"fixtures_dir": fixturesDir,
+			"files":        files,
+		}).MustTrue(len(files) == len(all) && len(files) > 0, "all scenarios should be selected without tags")
+		for _, file := range files {
+			t.WithFields(testing.Fields{
+				"file": file,
+			}).MustTrue(strings.HasPrefix(file, "scenarios/"), "scenario files should be relative to fixtures dir")
+			verrs := ValidateFixtureFile(file)
+			t.WithFields(testing.Fields{
+				"file":   file,
+				"errors": verrs,
+			}).MustTrue(len(verrs) == 0, "scenario of fixtures dir should be valid")
+		}
+
+		runnerOpts.fixtureTags = "smoke"
+		tagged, err := SelectedScenarioFiles()
+		t.MustNil(err, "error selecting scenarios by tags")
+		t.WithFields(testing.Fields{
+			"fixtures_dir": fixturesDir,
+			"files":        tagged,
+		}).MustTrue(len(tagged) > 0 && len(tagged) < len(files), "tags should select part of scenarios")
+		for _, file := range tagged {
+			scenario := ReadFixtureScenario(file, &t)
+			t.WithFields(testing.Fields{
+				"file": file,
+			}).MustTrue(scenario.MatchTags([]string{"smoke"}), "selected scenario should have the tag")
+		}
+	}
+
+	FixtureTestOpts.BaseDirectory = "../fixtures_test"
+	_, _, err = FilterScenariosByTags([]string{"scenarios/missing.json"}, []string{"smoke"})
+	t.MustTrue(err != nil, "scenario which can't be read should be an error instead of selected")
+}
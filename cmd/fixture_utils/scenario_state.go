@@ -0,0 +1,76 @@
+package fixturetest
+
+import (
+	"strings"
+	"sync"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// scenarioState is a struct to keep step outputs, registered results and rendered params files of a scenario run
+// Scenarios run in parallel and can have the same step IDs, result names and params files, so each scenario has its own state.
+type scenarioState struct {
+	mux           sync.RWMutex
+	stepOutputs   map[string]map[string]string
+	results       map[string]registeredResult
+	renderedFiles map[string][]byte
+}
+
+var scenarioStatesMux sync.Mutex
+var scenarioStates = make(map[string]*scenarioState)
+
+// scenarioTests are scenario files run by tests, keyed by test name
+var scenarioTestsMux sync.RWMutex
+var scenarioTests = make(map[string]string)
+
+// getScenarioState is a function to get state of scenario file, it's created on first use
+func getScenarioState(file string) *scenarioState {
+	scenarioStatesMux.Lock()
+	defer scenarioStatesMux.Unlock()
+	state, ok := scenarioStates[file]
+	if !ok {
+		state = &scenarioState{
+			stepOutputs:   make(map[string]map[string]string),
+			results:       make(map[string]registeredResult),
+			renderedFiles: make(map[string][]byte),
+		}
+		scenarioStates[file] = state
+	}
+	return state
+}
+
+// resetScenarioStates is a function to drop state of all scenarios
+func resetScenarioStates() {
+	scenarioStatesMux.Lock()
+	defer scenarioStatesMux.Unlock()
+	scenarioStates = make(map[string]*scenarioState)
+}
+
+// SetScenarioOfTest is a function to set scenario file run by test, steps run by its subtests share state of the scenario
+func SetScenarioOfTest(file string, t *testing.T) {
+	scenarioTestsMux.Lock()
+	defer scenarioTestsMux.Unlock()
+	scenarioTests[t.Name()] = file
+}
+
+// ScenarioOfTest is a function to get scenario file run by test or its parent tests, empty when no scenario is set
+func ScenarioOfTest(t *testing.T) string {
+	scenarioTestsMux.RLock()
+	defer scenarioTestsMux.RUnlock()
+	for name := t.Name(); len(name) > 0; {
+		if file, ok := scenarioTests[name]; ok {
+			return file
+		}
+		idx := strings.LastIndex(name, "/")
+		if idx < 0 {
+			break
+		}
+		name = name[:idx]
+	}
+	return ""
+}
+
+// testScenarioState is a function to get state of scenario run by test
+func testScenarioState(t *testing.T) *scenarioState {
+	return getScenarioState(ScenarioOfTest(t))
+}
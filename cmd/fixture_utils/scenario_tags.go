@@ -0,0 +1,142 @@
+package fixturetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// FixtureScenario is a struct to describe a fixture file.
+// A fixture file is either an array of steps or an object having "tags" and "steps" fields.
+type FixtureScenario struct {
+	Tags  []string      `json:"tags"`
+	Steps []FixtureStep `json:"steps"`
+}
+
+// UnmarshalJSON is a function to decode fixture file of both forms
+func (s *FixtureScenario) UnmarshalJSON(bz []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(bz), []byte("[")) {
+		s.Tags = nil
+		return json.Unmarshal(bz, &s.Steps)
+	}
+	type scenario FixtureScenario
+	return json.Unmarshal(bz, (*scenario)(s))
+}
+
+// ReadFixtureScenario is a function to read and decode fixture file, include entries are replaced by included steps
+func ReadFixtureScenario(file string, t *testing.T) FixtureScenario {
+	var scenario FixtureScenario
+	byteValue := ReadRawFile(file, t)
+
+	err := json.Unmarshal(byteValue, &scenario)
+	t.WithFields(testing.Fields{
+		"raw_json": string(byteValue),
+	}).MustNil(err, "error decoding fixture steps")
+
+	rawSteps, err := ExpandFixtureIncludes(file, byteValue)
+	t.WithFields(testing.Fields{
+		"file": file,
+	}).MustNil(err, "error including fixture fragments")
+	scenario.Steps = make([]FixtureStep, len(rawSteps))
+	for idx, rawStep := range rawSteps {
+		err = json.Unmarshal(rawStep, &scenario.Steps[idx])
+		t.WithFields(testing.Fields{
+			"raw_json": string(rawStep),
+		}).MustNil(err, "error decoding included fixture step")
+	}
+	return scenario
+}
+
+// MatchTags is a function to check if scenario has any of tags, all scenarios match empty tags
+func (s FixtureScenario) MatchTags(tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		for _, scenarioTag := range s.Tags {
+			if strings.EqualFold(tag, scenarioTag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FilterScenariosByTags is a function to split fixture files into the ones having any of tags and skipped ones.
+// Files which can't be decoded are selected so that fixture validation reports them, files which can't be read are an error.
+func FilterScenariosByTags(files []string, tags []string) ([]string, []string, error) {
+	selected := []string{}
+	skipped := []string{}
+	for _, file := range files {
+		bz, err := readFixtureFile(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading scenario %s to filter by tags: %w", file, err)
+		}
+		var scenario FixtureScenario
+		if err := json.Unmarshal(bz, &scenario); err != nil || scenario.MatchTags(tags) {
+			selected = append(selected, file)
+			continue
+		}
+		skipped = append(skipped, file)
+	}
+	return selected, skipped, nil
+}
+
+// splitScenarioObject is a function to get raw steps of fixture file having object form with their offsets in the file
+func splitScenarioObject(bz []byte, dec *json.Decoder) ([]int, []json.RawMessage, error) {
+	offsets := []int{}
+	rawSteps := []json.RawMessage{}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		switch token {
+		case "tags":
+			var tags []string
+			if err := dec.Decode(&tags); err != nil {
+				return nil, nil, fmt.Errorf("tags should be an array of strings: %w", err)
+			}
+		case "steps":
+			if token, err = dec.Token(); err != nil {
+				return nil, nil, err
+			}
+			if delim, ok := token.(json.Delim); !ok || delim != '[' {
+				return nil, nil, fmt.Errorf("steps should be an array of steps")
+			}
+			if offsets, rawSteps, err = splitStepArray(bz, dec); err != nil {
+				return nil, nil, err
+			}
+			if _, err = dec.Token(); err != nil {
+				return nil, nil, err
+			}
+		default:
+			return nil, nil, fmt.Errorf("unknown scenario field %v, available fields are tags and steps", token)
+		}
+	}
+	return offsets, rawSteps, nil
+}
+
+// PageScenarios is a function to split fixture files into the ones of 1-based page of pageSize and skipped ones
+// so that a long suite can run across several jobs, all files are selected when pageSize is 0.
+func PageScenarios(files []string, page int, pageSize int) ([]string, []string, error) {
+	if pageSize <= 0 {
+		return files, []string{}, nil
+	}
+	if page < 1 {
+		return nil, nil, fmt.Errorf("scenario page %d should be 1 or more", page)
+	}
+	start := (page - 1) * pageSize
+	if start > len(files) {
+		start = len(files)
+	}
+	end := start + pageSize
+	if end > len(files) {
+		end = len(files)
+	}
+	skipped := append(append([]string{}, files[:start]...), files[end:]...)
+	return files[start:end], skipped, nil
+}
@@ -0,0 +1,33 @@
+package fixturetest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+// GuardAccountsState is a function to snapshot state of accounts before scenarios run
+// and fail the test when their cookbooks, recipes or items changed after all scenarios finish
+func GuardAccountsState(accountKeys []string, t *originT.T, newT *testing.T) {
+	addresses := []string{}
+	for _, key := range accountKeys {
+		addresses = append(addresses, inttest.GetAccountAddr(key, newT))
+	}
+	before, err := inttest.TakeStateSnapshot(addresses)
+	newT.WithFields(testing.Fields{
+		"accounts": accountKeys,
+	}).MustNil(err, "error taking state snapshot before scenarios")
+
+	// parallel scenarios finish after RunTestScenarios returns, so diff the state on cleanup
+	t.Cleanup(func() {
+		after, err := inttest.TakeStateSnapshot(addresses)
+		if err != nil {
+			t.Errorf("error taking state snapshot after scenarios: %s", err.Error())
+			return
+		}
+		for _, change := range before.Diff(after) {
+			t.Errorf("unintended state change on guarded account: %s\nbefore: %s\nafter: %s", change, change.Before, change.After)
+		}
+	})
+}
@@ -0,0 +1,257 @@
+package fixturetest
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxRecentErrors is the number of latest errors kept for run status
+const maxRecentErrors = 20
+
+// StepStatus describes a step which is running or waiting for blocks
+type StepStatus struct {
+	File      string    `json:"file"`
+	StepID    string    `json:"step_id"`
+	Action    string    `json:"action"`
+	BlockWait int64     `json:"block_wait"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// StepError describes a failed step
+type StepError struct {
+	File     string    `json:"file"`
+	StepID   string    `json:"step_id"`
+	Action   string    `json:"action"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// RunStatus is a struct to manage live status of fixture test run
+type RunStatus struct {
+	mux           sync.Mutex
+	StartedAt     time.Time
+	Fixtures      []string
+	PendingSteps  int
+	RunningSteps  map[string]StepStatus
+	WaitingSteps  map[string]StepStatus
+	Results       map[string]StepState
+	Passed        int
+	Failed        int
+	Skipped       int
+	NotApplicable int
+	Quarantined   int
+	RecentErrors  []StepError
+	// SkippedFixtures are fixtures not selected to run by tags
+	SkippedFixtures []string
+}
+
+// RunStatusSnapshot is a copy of run status which is safe to render
+type RunStatusSnapshot struct {
+	StartedAt     time.Time    `json:"started_at"`
+	Fixtures      []string     `json:"fixtures"`
+	PendingSteps  int          `json:"pending_steps"`
+	RunningSteps  []StepStatus `json:"running_steps"`
+	WaitingSteps  []StepStatus `json:"waiting_steps"`
+	Passed        int          `json:"passed"`
+	Failed        int          `json:"failed"`
+	Skipped       int          `json:"skipped"`
+	NotApplicable int          `json:"not_applicable"`
+	Quarantined   int          `json:"quarantined"`
+	RecentErrors  []StepError  `json:"recent_errors"`
+	// CommandPool is usage of pylonsd command pool
+	CommandPool inttest.CommandPoolStats `json:"command_pool"`
+	// SkippedFixtures are fixtures not selected to run by tags
+	SkippedFixtures []string `json:"skipped_fixtures"`
+}
+
+// FixtureRunStatus is a variable to have live status of fixture test run
+var FixtureRunStatus = RunStatus{
+	StartedAt:    time.Now(),
+	RunningSteps: make(map[string]StepStatus),
+	WaitingSteps: make(map[string]StepStatus),
+	Results:      make(map[string]StepState),
+}
+
+func stepStatusKey(file, stepID string) string {
+	return file + "/" + stepID
+}
+
+// RegisterFixture is a function to add fixture and its steps into run status
+func (rs *RunStatus) RegisterFixture(file string, numSteps int) {
+	rs.mux.Lock()
+	defer rs.mux.Unlock()
+	rs.Fixtures = append(rs.Fixtures, file)
+	rs.PendingSteps += numSteps
+}
+
+// SkipFixture is a function to add fixture which is not selected to run into run status
+func (rs *RunStatus) SkipFixture(file string) {
+	rs.mux.Lock()
+	defer rs.mux.Unlock()
+	rs.SkippedFixtures = append(rs.SkippedFixtures, file)
+}
+
+// StepWaiting is a function to mark a step as waiting for blocks
+func (rs *RunStatus) StepWaiting(file string, step FixtureStep) {
+	rs.mux.Lock()
+	defer rs.mux.Unlock()
+	rs.PendingSteps--
+	rs.WaitingSteps[stepStatusKey(file, step.ID)] = StepStatus{
+		File:      file,
+		StepID:    step.ID,
+		Action:    step.Action,
+		BlockWait: step.RunAfter.BlockWait,
+		StartedAt: time.Now(),
+	}
+}
+
+// StepStarted is a function to mark a step as running
+func (rs *RunStatus) StepStarted(file string, step FixtureStep) {
+	rs.mux.Lock()
+	defer rs.mux.Unlock()
+	key := stepStatusKey(file, step.ID)
+	if _, ok := rs.WaitingSteps[key]; ok {
+		delete(rs.WaitingSteps, key)
+	} else {
+		rs.PendingSteps--
+	}
+	rs.RunningSteps[key] = StepStatus{
+		File:      file,
+		StepID:    step.ID,
+		Action:    step.Action,
+		StartedAt: time.Now(),
+	}
+}
+
+// StepFinished is a function to mark a step with its result state
+func (rs *RunStatus) StepFinished(file string, step FixtureStep, state StepState) {
+	rs.mux.Lock()
+	defer rs.mux.Unlock()
+	key := stepStatusKey(file, step.ID)
+	if _, ok := rs.WaitingSteps[key]; !ok {
+		if _, ok := rs.RunningSteps[key]; !ok {
+			rs.PendingSteps-- // step finished without being started
+		}
+	}
+	delete(rs.WaitingSteps, key)
+	delete(rs.RunningSteps, key)
+	rs.Results[key] = state
+	switch state {
+	case StepPassed:
+		rs.Passed++
+		return
+	case StepSkipped:
+		rs.Skipped++
+		return
+	case StepNotApplicable:
+		rs.NotApplicable++
+		return
+	case StepQuarantined:
+		rs.Quarantined++
+		return
+	}
+	rs.Failed++
+	rs.RecentErrors = append(rs.RecentErrors, StepError{
+		File:     file,
+		StepID:   step.ID,
+		Action:   step.Action,
+		FailedAt: time.Now(),
+	})
+	if len(rs.RecentErrors) > maxRecentErrors {
+		rs.RecentErrors = rs.RecentErrors[len(rs.RecentErrors)-maxRecentErrors:]
+	}
+}
+
+// GetStepState is a function to get result state of a finished step, empty if not finished
+func (rs *RunStatus) GetStepState(file, stepID string) StepState {
+	rs.mux.Lock()
+	defer rs.mux.Unlock()
+	return rs.Results[stepStatusKey(file, stepID)]
+}
+
+// Snapshot is a function to copy current run status
+func (rs *RunStatus) Snapshot() RunStatusSnapshot {
+	rs.mux.Lock()
+	defer rs.mux.Unlock()
+	snapshot := RunStatusSnapshot{
+		StartedAt:     rs.StartedAt,
+		Fixtures:      append([]string{}, rs.Fixtures...),
+		PendingSteps:  rs.PendingSteps,
+		RunningSteps:  sortedStepStatuses(rs.RunningSteps),
+		WaitingSteps:  sortedStepStatuses(rs.WaitingSteps),
+		Passed:        rs.Passed,
+		Failed:        rs.Failed,
+		Skipped:       rs.Skipped,
+		NotApplicable: rs.NotApplicable,
+		Quarantined:   rs.Quarantined,
+		RecentErrors:  append([]StepError{}, rs.RecentErrors...),
+		CommandPool:   inttest.GetCommandPoolStats(),
+	}
+	snapshot.SkippedFixtures = append([]string{}, rs.SkippedFixtures...)
+	return snapshot
+}
+
+func sortedStepStatuses(steps map[string]StepStatus) []StepStatus {
+	sorted := make([]StepStatus, 0, len(steps))
+	for _, step := range steps {
+		sorted = append(sorted, step)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartedAt.Before(sorted[j].StartedAt)
+	})
+	return sorted
+}
+
+var statusPageTemplate = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><meta http-equiv="refresh" content="5"><title>Fixture test status</title></head>
+<body>
+<h1>Fixture test status</h1>
+<p>started at {{.StartedAt.Format "2006-01-02 15:04:05"}}, passed {{.Passed}}, failed {{.Failed}}, skipped {{.Skipped}}, not applicable {{.NotApplicable}}, quarantined {{.Quarantined}}, pending {{.PendingSteps}}</p>
+<p>pylonsd commands: running {{.CommandPool.Running}}/{{.CommandPool.Concurrency}}, waiting {{.CommandPool.Waiting}}, completed {{.CommandPool.Completed}}, keyring writes {{.CommandPool.KeyringWrites}}</p>
+<h2>Running steps</h2>
+<ul>{{range .RunningSteps}}<li>{{.File}} {{.StepID}} ({{.Action}}) since {{.StartedAt.Format "15:04:05"}}</li>{{end}}</ul>
+<h2>Waiting for blocks</h2>
+<ul>{{range .WaitingSteps}}<li>{{.File}} {{.StepID}} ({{.Action}}) waiting {{.BlockWait}} blocks since {{.StartedAt.Format "15:04:05"}}</li>{{end}}</ul>
+<h2>Recent errors</h2>
+<ul>{{range .RecentErrors}}<li>{{.FailedAt.Format "15:04:05"}} {{.File}} {{.StepID}} ({{.Action}})</li>{{end}}</ul>
+<h2>Fixtures</h2>
+<ul>{{range .Fixtures}}<li>{{.}}</li>{{end}}</ul>
+{{if .SkippedFixtures}}<h2>Skipped fixtures</h2>
+<ul>{{range .SkippedFixtures}}<li>{{.}}</li>{{end}}</ul>{{end}}
+</body>
+</html>
+`))
+
+// StartStatusServer is a function to serve live run status as HTML on / and JSON on /status.json
+func StartStatusServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(FixtureRunStatus.Snapshot()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := statusPageTemplate.Execute(w, FixtureRunStatus.Snapshot()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	server := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.WithFields(log.Fields{
+				"addr":  addr,
+				"error": err,
+			}).Error("status server stopped")
+		}
+	}()
+	return server
+}
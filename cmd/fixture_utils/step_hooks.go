@@ -0,0 +1,101 @@
+package fixturetest
+
+import (
+	"sync"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// StepSelector is a struct to select steps hooks are called for, empty fields match any step
+type StepSelector struct {
+	// StepID is the ID of steps to select e.g. "CREATE_COOKBOOK"
+	StepID string
+	// Action is the action of steps to select e.g. "execute_recipe"
+	Action string
+}
+
+// Match is a function to check if step is selected
+func (s StepSelector) Match(step FixtureStep) bool {
+	return (len(s.StepID) == 0 || s.StepID == step.ID) && (len(s.Action) == 0 || s.Action == step.Action)
+}
+
+// StepHookInfo is a struct to describe the step a hook is called for
+// State and Duration are only set for AfterStep hooks.
+type StepHookInfo struct {
+	File     string
+	Step     FixtureStep
+	State    StepState
+	Duration time.Duration
+}
+
+// StepHook describes the type of function called before or after a step runs
+// Hooks run on the test of the step, so failing the test fails the step.
+type StepHook func(StepHookInfo, *testing.T)
+
+// stepHookEntry is a struct to keep hook with the selector of steps it's called for
+type stepHookEntry struct {
+	selector StepSelector
+	hook     StepHook
+}
+
+var (
+	stepHookMux     sync.RWMutex
+	beforeStepHooks []stepHookEntry
+	afterStepHooks  []stepHookEntry
+)
+
+// RegisterBeforeStep is a function to add hook called before action of selected steps runs
+// e.g. to inject chaos, hooks are called in registration order and not called for skipped steps.
+func RegisterBeforeStep(selector StepSelector, hook StepHook) {
+	stepHookMux.Lock()
+	defer stepHookMux.Unlock()
+	beforeStepHooks = append(beforeStepHooks, stepHookEntry{selector: selector, hook: hook})
+}
+
+// RegisterAfterStep is a function to add hook called after selected steps finish with their state
+// e.g. to record custom metrics, hooks are called in registration order for skipped and failed steps as well.
+func RegisterAfterStep(selector StepSelector, hook StepHook) {
+	stepHookMux.Lock()
+	defer stepHookMux.Unlock()
+	afterStepHooks = append(afterStepHooks, stepHookEntry{selector: selector, hook: hook})
+}
+
+// ResetStepHooks is a function to remove hooks added by RegisterBeforeStep and RegisterAfterStep
+func ResetStepHooks() {
+	stepHookMux.Lock()
+	defer stepHookMux.Unlock()
+	beforeStepHooks = nil
+	afterStepHooks = nil
+}
+
+// selectStepHooks is a function to get before or after step hooks selecting step
+func selectStepHooks(after bool, step FixtureStep) []StepHook {
+	stepHookMux.RLock()
+	defer stepHookMux.RUnlock()
+	entries := beforeStepHooks
+	if after {
+		entries = afterStepHooks
+	}
+	hooks := []StepHook{}
+	for _, entry := range entries {
+		if entry.selector.Match(step) {
+			hooks = append(hooks, entry.hook)
+		}
+	}
+	return hooks
+}
+
+// RunBeforeStepHooks is a function to call hooks registered by RegisterBeforeStep for step of file
+func RunBeforeStepHooks(file string, step FixtureStep, t *testing.T) {
+	for _, hook := range selectStepHooks(false, step) {
+		hook(StepHookInfo{File: file, Step: step}, t)
+	}
+}
+
+// RunAfterStepHooks is a function to call hooks registered by RegisterAfterStep for step of file finished with state
+func RunAfterStepHooks(file string, step FixtureStep, state StepState, duration time.Duration, t *testing.T) {
+	for _, hook := range selectStepHooks(true, step) {
+		hook(StepHookInfo{File: file, Step: step, State: state, Duration: duration}, t)
+	}
+}
@@ -0,0 +1,79 @@
+package fixturetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"runtime/debug"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// RecoverStepPanic is a function to convert panic of fixture step into failure of the step, it should be deferred directly
+// Step, action, params resolved so far and stack trace are attached to the failure, so the run goes on with other steps
+// and its report is written instead of the whole go test process being aborted.
+// Panics of goroutines started by the step are not recovered.
+func RecoverStepPanic(file string, step FixtureStep, t *testing.T) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	t.WithFields(testing.Fields{
+		"file":   file,
+		"step":   step.ID,
+		"action": step.Action,
+		"params": StepParamsOnFailure(file, step),
+		"panic":  fmt.Sprint(r),
+		"stack":  string(debug.Stack()),
+	}).Fatal("step panicked: ", r)
+}
+
+// StepParamsOnFailure is a function to get params files of step of scenario file with templates and result references resolved as far as they can be
+// It never fails the test, files which can't be read get their error and unregistered references are kept as they are.
+func StepParamsOnFailure(file string, step FixtureStep) map[string]string {
+	refs := []string{}
+	if step.ParamsRef != "" {
+		refs = append(refs, step.ParamsRef)
+	}
+	for _, msgRef := range step.MsgRefs {
+		refs = append(refs, msgRef.ParamsRef)
+	}
+	params := map[string]string{}
+	scenario := getScenarioState(file)
+	for _, ref := range refs {
+		bz, err := ioutil.ReadFile(FixturePath(ref))
+		if err != nil {
+			params[ref] = "error reading params: " + err.Error()
+			continue
+		}
+		scenario.mux.RLock()
+		if rendered, ok := scenario.renderedFiles[ref]; ok {
+			bz = rendered
+		}
+		scenario.mux.RUnlock()
+		params[ref] = string(scenario.resolveRegisteredResultRefs(bz))
+	}
+	return params
+}
+
+// resolveRegisteredResultRefs is a function to replace "@name" string values of json with results registered in scenario, leaving unregistered ones
+func (s *scenarioState) resolveRegisteredResultRefs(bz []byte) []byte {
+	var value interface{}
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return bz
+	}
+	s.mux.RLock()
+	value, resolved := s.resolveResultRefs(value, func(ref string) {})
+	s.mux.RUnlock()
+	if !resolved {
+		return bz
+	}
+	newBytes, err := json.Marshal(value)
+	if err != nil {
+		return bz
+	}
+	return newBytes
+}
@@ -0,0 +1,72 @@
+package fixturetest
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// stepPanicFixturesEnv is the environment variable which has fixtures dir of scenarios run by TestStepPanicScenarios
+// it's only set by TestRecoverStepPanic, which runs it in a child process since the panicking step fails the go test.
+const stepPanicFixturesEnv = "FIXTURETEST_STEP_PANIC_FIXTURES"
+
+var stepPanicFixtures = map[string]string{
+	"scenarios/a_panicking.json": `[
+    {"ID": "CREATE_ACCOUNT", "action": "create_account", "paramsRef": "account1"},
+    {"ID": "PANICKING_STEP", "runAfter": {"precondition": ["CREATE_ACCOUNT"]}, "action": "create_account", "paramsRef": "account1"},
+    {"ID": "AFTER_PANIC", "runAfter": {"precondition": ["CREATE_ACCOUNT"]}, "action": "create_account", "paramsRef": "account1"}
+]`,
+	"scenarios/b_sibling.json": `[
+    {"ID": "SIBLING_CREATE_ACCOUNT", "action": "create_account", "paramsRef": "account1"},
+    {"ID": "SIBLING_STEP", "runAfter": {"precondition": ["SIBLING_CREATE_ACCOUNT"]}, "action": "create_account", "paramsRef": "account1"}
+]`,
+}
+
+func TestStepPanicScenarios(originT *originT.T) {
+	fixturesDir := os.Getenv(stepPanicFixturesEnv)
+	if len(fixturesDir) == 0 {
+		originT.Skip("run by TestRecoverStepPanic")
+	}
+	FixtureTestOpts.BaseDirectory = fixturesDir
+	FixtureTestOpts.InMemory = true
+	FixtureTestOpts.AccountNames = nil
+	RegisterDefaultActionRunners()
+	RegisterBeforeStep(StepSelector{StepID: "PANICKING_STEP"}, func(info StepHookInfo, t *testing.T) {
+		var items map[string]int
+		items["sword"]++ // nolint: staticcheck
+	})
+	RunTestScenarios("scenarios", nil, originT)
+}
+
+func TestRecoverStepPanic(originT *originT.T) {
+	t := testing.NewT(originT)
+	fixturesDir := originT.TempDir()
+	writeFixtureFiles(fixturesDir, stepPanicFixtures, &t)
+
+	cmd := exec.Command(os.Args[0], "-test.run", "^TestStepPanicScenarios$", "-test.v", "-test.count", "1")
+	cmd.Env = append(os.Environ(), stepPanicFixturesEnv+"="+fixturesDir)
+	bz, err := cmd.CombinedOutput()
+	output := string(bz)
+	_, exited := err.(*exec.ExitError)
+	t.WithFields(testing.Fields{
+		"error": err,
+	}).MustTrue(exited, "scenario run having a panicking step should fail")
+
+	for _, line := range []string{
+		"--- FAIL: TestStepPanicScenarios/scenarios/a_panicking.json/0_CREATE_ACCOUNT/1_PANICKING_STEP",
+		"--- PASS: TestStepPanicScenarios/scenarios/a_panicking.json/0_CREATE_ACCOUNT/2_AFTER_PANIC",
+		"--- PASS: TestStepPanicScenarios/scenarios/b_sibling.json/0_SIBLING_CREATE_ACCOUNT/1_SIBLING_STEP",
+	} {
+		t.WithFields(testing.Fields{
+			"output": output,
+		}).MustContain(output, line, "panicking step should fail without stopping other steps and scenarios")
+	}
+	t.MustContain(output, "msg=step panicked:", "panic should be reported as failure of the step")
+	t.MustContain(output, "panic=assignment to entry in nil map", "failure should have the panic value")
+	t.MustContain(output, "step=PANICKING_STEP", "failure should have the panicking step")
+	t.MustContain(output, "step_panic_test.go", "failure should have stack of the panic")
+	t.MustTrue(!strings.Contains(output, "panic: assignment to entry in nil map"), "panic should not abort the go test process")
+}
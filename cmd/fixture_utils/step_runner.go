@@ -1,6 +1,7 @@
 package fixturetest
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 
@@ -8,12 +9,19 @@ import (
 	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
 	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/gogo/protobuf/proto"
 )
 
 // TxBroadcastErrorCheck check error is same as expected when it exist
 func TxBroadcastErrorCheck(err error, txhash string, step FixtureStep, t *testing.T) {
-	if step.Output.TxResult.BroadcastError != "" {
+	if step.ExpectError != nil {
+		t.WithFields(testing.Fields{
+			"txhash":         txhash,
+			"expected_error": *step.ExpectError,
+			"error":          err,
+		}).MustNil(step.ExpectError.Check(err), "broadcast error is different from expected one")
+	} else if step.Output.TxResult.BroadcastError != "" {
 		t.WithFields(testing.Fields{
 			"txhash": txhash,
 		}).MustContain(err.Error(), step.Output.TxResult.BroadcastError, "broadcast error is different from expected one")
@@ -24,6 +32,18 @@ func TxBroadcastErrorCheck(err error, txhash string, step FixtureStep, t *testin
 	}
 }
 
+// ValidateStepMsg is a function to validate msg read from params file before broadcast with field level errors.
+// Steps expecting an error are not checked here as the error is checked on broadcast.
+func ValidateStepMsg(step FixtureStep, paramsRef string, msg sdk.Msg, t *testing.T) {
+	if step.ExpectError != nil || step.Output.TxResult.BroadcastError != "" {
+		return
+	}
+	t.WithFields(testing.Fields{
+		"params_ref": paramsRef,
+		"msg_type":   msg.Type(),
+	}).MustNil(types.ValidateMsg(msg), "invalid msg params")
+}
+
 // TxErrorLogCheck check expected error log is produced correctly
 func TxErrorLogCheck(txhash string, ErrorLog string, t *testing.T) {
 	if len(ErrorLog) > 0 {
@@ -34,6 +54,26 @@ func TxErrorLogCheck(txhash string, ErrorLog string, t *testing.T) {
 	}
 }
 
+// TxFailureCheck check expected failure of transaction by errLog and expectError
+// It returns true when the step expects the transaction to fail
+func TxFailureCheck(txhash string, step FixtureStep, t *testing.T) bool {
+	TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
+	if step.ExpectError != nil {
+		txResult, err := inttest.NewClient().WaitForTxResult(context.Background(), t, txhash)
+		if err != nil && txResult.Code == 0 {
+			t.WithFields(testing.Fields{
+				"txhash": txhash,
+			}).MustNil(err, "error getting transaction result")
+		}
+		t.WithFields(testing.Fields{
+			"txhash":         txhash,
+			"expected_error": *step.ExpectError,
+			"raw_log":        txResult.RawLog,
+		}).MustNil(step.ExpectError.Check(err), "transaction error is different from expected one")
+	}
+	return len(step.Output.TxResult.ErrorLog) > 0 || step.ExpectError != nil
+}
+
 // TxResultStatusMessageCheck check result status and message
 func TxResultStatusMessageCheck(status, message, txhash string, step FixtureStep, t *testing.T) {
 	if len(step.Output.TxResult.Status) > 0 {
@@ -52,6 +92,61 @@ func TxResultStatusMessageCheck(status, message, txhash string, step FixtureStep
 	}
 }
 
+// TransferFeeBalances is a function to get expected pylon changes by transfer fees and pylon balances before the transfer
+// when step verifies transfer fee, expectedFees is not called otherwise.
+func TransferFeeBalances(step FixtureStep, expectedFees func() (inttest.PylonDeltas, error), t *testing.T) (inttest.PylonDeltas, map[string]sdk.Int) {
+	if !step.Output.VerifyTransferFee {
+		return nil, nil
+	}
+	deltas, err := expectedFees()
+	t.MustNil(err, "error getting expected transfer fees")
+	return deltas, inttest.GetPylonBalances(deltas, t)
+}
+
+// TransferFeeCheck check cookbook owners, Pylons LLC and previous owners got their cuts of the transfer
+func TransferFeeCheck(step FixtureStep, txhash string, deltas inttest.PylonDeltas, before map[string]sdk.Int, t *testing.T) {
+	if !step.Output.VerifyTransferFee {
+		return
+	}
+	err := inttest.CheckPylonDeltas(before, deltas, t)
+	t.WithFields(testing.Fields{
+		"txhash":       txhash,
+		"pylon_deltas": deltas,
+	}).MustNil(err, "transfer fee payouts are different from expected")
+}
+
+// SupplyBalances get total supplies of coins the recipe of execution can mint when the step verifies supply
+func SupplyBalances(step FixtureStep, recipeID string, t *testing.T) map[string]sdk.Int {
+	if !step.Output.VerifySupply {
+		return nil
+	}
+	recipe, err := inttest.GetRecipeByGUID(recipeID)
+	t.WithFields(testing.Fields{
+		"recipe_id": recipeID,
+	}).MustNil(err, "error getting recipe of execution")
+	supplies, err := inttest.GetTotalSupplies(inttest.RecipeCoinOutputDenoms(recipe))
+	t.MustNil(err, "error getting total supplies before execution")
+	return supplies
+}
+
+// SupplyCheck check total supplies changed by coins minted by straight execution
+func SupplyCheck(step FixtureStep, txhash string, output []byte, before map[string]sdk.Int, t *testing.T) {
+	if !step.Output.VerifySupply {
+		return
+	}
+	deltas := inttest.SupplyDeltas{}
+	for denom := range before {
+		deltas.Add(denom, 0)
+	}
+	err := deltas.AddExecutionOutput(output)
+	t.MustNil(err, "error getting minted coins of execution")
+	err = inttest.CheckSupplyDeltas(before, deltas, t)
+	t.WithFields(testing.Fields{
+		"txhash":        txhash,
+		"supply_deltas": deltas,
+	}).MustNil(err, "total supply changes are different from minted coins")
+}
+
 // TxResultDecodingErrorCheck check error for tx response data unmarshal
 func TxResultDecodingErrorCheck(err error, txhash string, t *testing.T) {
 	txErrorBytes, getTxLogErr := inttest.GetTxError(txhash, t)
@@ -64,7 +159,7 @@ func TxResultDecodingErrorCheck(err error, txhash string, t *testing.T) {
 
 // GetTxHandleResult check error on tx by hash and return handle result
 func GetTxHandleResult(txhash string, t *testing.T) []byte {
-	txHandleResBytes, err := inttest.WaitAndGetTxData(txhash, inttest.GetMaxWaitBlock(), t)
+	txHandleResBytes, err := inttest.NewClient().WaitForTx(context.Background(), t, txhash)
 	t.WithFields(testing.Fields{
 		"tx_result_bytes": string(txHandleResBytes),
 		"error":           err,
@@ -75,7 +170,7 @@ func GetTxHandleResult(txhash string, t *testing.T) []byte {
 
 // WaitForNextBlockWithErrorCheck wait 1 block and check the error result
 func WaitForNextBlockWithErrorCheck(t *testing.T) {
-	err := inttest.WaitForNextBlock()
+	err := inttest.WaitForNextBlockCtx(context.Background())
 	t.MustNil(err, "error waiting for next block")
 }
 
@@ -91,26 +186,32 @@ func RunCreateAccount(step FixtureStep, t *testing.T) {
 			"key":              caKey,
 			"local_key_result": localKeyResult,
 		}).MustNil(err, "error creating local Key")
-		result, logstr, err := inttest.CreateChainAccount(caKey)
-		t.WithFields(testing.Fields{
-			"result": result,
-			"logstr": logstr,
-		}).MustNil(err, "error creating account on chain")
-
-		caTxHash, err := inttest.GetTxHashFromJson(result)
-		t.MustNil(err, "error code detected parsing result json")
-		t.MustTrue(caTxHash != "", "error fetching txhash from result")
-		t.WithFields(testing.Fields{
-			"txhash": caTxHash,
-		}).Info("waiting for create account transaction")
-		txResponseBytes, err := inttest.WaitAndGetTxData(caTxHash, inttest.GetMaxWaitBlock(), t)
-		t.WithFields(testing.Fields{
-			"result": string(txResponseBytes),
-		}).MustNil(err, "error waiting for create account transaction")
-		inttest.GetAccountInfoFromAddr(localKeyResult["address"], t)
+		createChainAccount(caKey, localKeyResult["address"], t)
+		rememberChainAccount(step.ParamsRef, false)
 	}
 }
 
+// createChainAccount is a function to create account of key on chain and wait for it to be queried
+func createChainAccount(caKey, address string, t *testing.T) {
+	result, logstr, err := inttest.CreateChainAccount(caKey)
+	t.WithFields(testing.Fields{
+		"result": result,
+		"logstr": logstr,
+	}).MustNil(err, "error creating account on chain")
+
+	caTxHash, err := inttest.GetTxHashFromJson(result)
+	t.MustNil(err, "error code detected parsing result json")
+	t.MustTrue(caTxHash != "", "error fetching txhash from result")
+	t.WithFields(testing.Fields{
+		"txhash": caTxHash,
+	}).Info("waiting for create account transaction")
+	txResponseBytes, err := inttest.NewClient().WaitForTx(context.Background(), t, caTxHash)
+	t.WithFields(testing.Fields{
+		"result": string(txResponseBytes),
+	}).MustNil(err, "error waiting for create account transaction")
+	inttest.GetAccountInfoFromAddr(address, t)
+}
+
 // GetPylonsMsgFromRef is a function to get GetPylons message from reference
 func GetPylonsMsgFromRef(ref string, t *testing.T) types.MsgGetPylons {
 	gpAddr := GetAccountAddressFromTempName(ref, t)
@@ -131,13 +232,17 @@ func RunGetPylons(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		gpMsg := GetPylonsMsgFromRef(step.ParamsRef, t)
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &gpMsg, gpMsg.Requester, true)
+		ValidateStepMsg(step, step.ParamsRef, &gpMsg, t)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(gpMsg.Requester), &gpMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
 		}
 
 		WaitForNextBlockWithErrorCheck(t)
+		if TxFailureCheck(txhash, step, t) {
+			return
+		}
 
 		txHandleResBytes := GetTxHandleResult(txhash, t)
 		txMsgData := &sdk.TxMsgData{
@@ -150,6 +255,7 @@ func RunGetPylons(step FixtureStep, t *testing.T) {
 		resp := types.MsgGetPylonsResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		TxResultStatusMessageCheck(resp.Status, resp.Message, txhash, step, t)
 	}
 }
@@ -174,6 +280,11 @@ func GoogleIAPGetPylonsMsgFromRef(ref string, t *testing.T) types.MsgGoogleIAPGe
 		"new_bytes": string(newByteValue),
 	}).MustNil(err, "error reading using GetJSONMarshaler")
 
+	if len(gigpType.Signature) == 0 {
+		// receipts without signature are signed by test key which local testnet trusts
+		return LocalIAPGetPylonsMsg(gigpType.ProductID, gigpType.ReceiptData, gigpType.Requester, t)
+	}
+
 	receiptDataBase64 := base64.StdEncoding.EncodeToString([]byte(gigpType.ReceiptData))
 
 	return types.NewMsgGoogleIAPGetPylons(
@@ -185,6 +296,24 @@ func GoogleIAPGetPylonsMsgFromRef(ref string, t *testing.T) types.MsgGoogleIAPGe
 	)
 }
 
+// LocalIAPGetPylonsMsg is a function to get GoogleIAPGetPylons message of receipt signed by test iap key
+// A new receipt of product is created when receipt data is empty, so each run uses a new purchase token.
+func LocalIAPGetPylonsMsg(productID, receiptData, requester string, t *testing.T) types.MsgGoogleIAPGetPylons {
+	receipt, err := inttest.NewIAPReceipt(productID)
+	if len(receiptData) > 0 {
+		err = json.Unmarshal([]byte(receiptData), &receipt)
+	}
+	t.WithFields(testing.Fields{
+		"product_id":   productID,
+		"receipt_data": receiptData,
+	}).MustNil(err, "error creating iap receipt")
+	msg, err := inttest.LocalIAPSigner().GetPylonsMsg(receipt, requester)
+	t.WithFields(testing.Fields{
+		"product_id": productID,
+	}).MustNil(err, "error signing iap receipt by test key")
+	return msg
+}
+
 // RunGoogleIAPGetPylons is a function to run GoogleIAPGetPylons message
 func RunGoogleIAPGetPylons(step FixtureStep, t *testing.T) {
 	if FixtureTestOpts.VerifyOnly {
@@ -192,13 +321,17 @@ func RunGoogleIAPGetPylons(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		gigpMsg := GoogleIAPGetPylonsMsgFromRef(step.ParamsRef, t)
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &gigpMsg, gigpMsg.Requester, true)
+		ValidateStepMsg(step, step.ParamsRef, &gigpMsg, t)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(gigpMsg.Requester), &gigpMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
 		}
 
 		WaitForNextBlockWithErrorCheck(t)
+		if TxFailureCheck(txhash, step, t) {
+			return
+		}
 
 		txHandleResBytes := GetTxHandleResult(txhash, t)
 		txMsgData := &sdk.TxMsgData{
@@ -211,6 +344,7 @@ func RunGoogleIAPGetPylons(step FixtureStep, t *testing.T) {
 		resp := types.MsgGoogleIAPGetPylonsResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		TxResultStatusMessageCheck(resp.Status, resp.Message, txhash, step, t)
 	}
 }
@@ -248,7 +382,13 @@ func RunSendCoins(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		scMsg := SendCoinsMsgFromRef(step.ParamsRef, t)
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &scMsg, scMsg.Sender, true)
+		ValidateStepMsg(step, step.ParamsRef, &scMsg, t)
+		var senderBalance, receiverBalance banktypes.Balance
+		if step.Output.VerifyTransfer {
+			senderBalance = inttest.GetAccountBalanceFromAddr(scMsg.Sender, t)
+			receiverBalance = inttest.GetAccountBalanceFromAddr(scMsg.Receiver, t)
+		}
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(scMsg.Sender), &scMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -256,8 +396,7 @@ func RunSendCoins(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -272,7 +411,18 @@ func RunSendCoins(step FixtureStep, t *testing.T) {
 		resp := types.MsgGetPylonsResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		TxResultStatusMessageCheck(resp.Status, resp.Message, txhash, step, t)
+
+		if step.Output.VerifyTransfer {
+			err = inttest.CheckCoinsTransfer(senderBalance, receiverBalance, scMsg.Amount, t)
+			t.WithFields(testing.Fields{
+				"txhash":   txhash,
+				"sender":   scMsg.Sender,
+				"receiver": scMsg.Receiver,
+				"amount":   scMsg.Amount.String(),
+			}).MustNil(err, "coins transfer result is different from expected")
+		}
 	}
 }
 
@@ -284,7 +434,77 @@ func RunMockAccount(step FixtureStep, t *testing.T) {
 	if step.ParamsRef != "" {
 		RunCreateAccount(step, t)
 		RunGetPylons(step, t)
+		rememberChainAccount(step.ParamsRef, true)
+	}
+}
+
+// ActionMsgFromRef is a function to read msg of action from params file with its sender, msg is nil when action doesn't send a msg
+func ActionMsgFromRef(action string, ref string, t *testing.T) (sdk.Msg, string) {
+	switch action {
+	case "get_pylons":
+		msg := GetPylonsMsgFromRef(ref, t)
+		return &msg, msg.Requester
+	case "google_iap_get_pylons":
+		msg := GoogleIAPGetPylonsMsgFromRef(ref, t)
+		return &msg, msg.Requester
+	case "send_coins":
+		msg := SendCoinsMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "send_items":
+		msg := SendItemsMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "fiat_item":
+		msg := FiatItemMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "update_item_string":
+		msg := UpdateItemStringMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "create_cookbook":
+		msg := CreateCookbookMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "update_cookbook":
+		msg := UpdateCookbookMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "create_recipe":
+		msg := CreateRecipeMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "update_recipe":
+		msg := UpdateRecipeMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "enable_recipe":
+		msg := EnableRecipeMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "disable_recipe":
+		msg := DisableRecipeMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "execute_recipe":
+		msg := ExecuteRecipeMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "check_execution":
+		msg := CheckExecutionMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "create_trade":
+		msg := CreateTradeMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "create_coins_for_coins_trade", "create_coins_for_items_trade", "create_items_for_coins_trade", "create_items_for_items_trade", "create_mixed_trade":
+		msg := CreateTradeMsgOfShapeFromRef(ref, createTradeShapeActions[action], t)
+		return &msg, msg.Sender
+	case "fulfill_trade":
+		msg := FulfillTradeMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "auto_fulfill_trade":
+		msg := AutoFulfillTradeMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "disable_trade":
+		msg := DisableTradeMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "enable_trade":
+		msg := EnableTradeMsgFromRef(ref, t)
+		return &msg, msg.Sender
+	case "send_msg":
+		return MsgJSONFromRef(ref, t)
 	}
+	return nil, ""
 }
 
 // RunMultiMsgTx is a function to send multiple messages in a transaction
@@ -298,51 +518,12 @@ func RunMultiMsgTx(step FixtureStep, t *testing.T) {
 		var msgs []sdk.Msg
 		var sender string
 		for _, ref := range step.MsgRefs {
-			var newMsg sdk.Msg
-			switch ref.Action {
-			case "fiat_item":
-				msg := FiatItemMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "update_item_string":
-				msg := UpdateItemStringMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "create_cookbook":
-				msg := CreateCookbookMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "update_cookbook":
-				msg := UpdateCookbookMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "create_recipe":
-				msg := CreateRecipeMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "update_recipe":
-				msg := UpdateRecipeMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "enable_recipe":
-				msg := EnableRecipeMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "disable_recipe":
-				msg := DisableRecipeMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "execute_recipe":
-				msg := ExecuteRecipeMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "check_execution":
-				msg := CheckExecutionMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "create_trade":
-				msg := CreateTradeMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "fulfill_trade":
-				msg := FulfillTradeMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "disable_trade":
-				msg := DisableTradeMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			case "enable_trade":
-				msg := EnableTradeMsgFromRef(ref.ParamsRef, t)
-				newMsg, sender = &msg, msg.Sender
-			}
+			newMsg, msgSender := ActionMsgFromRef(ref.Action, ref.ParamsRef, t)
+			t.WithFields(testing.Fields{
+				"action": ref.Action,
+			}).MustTrue(newMsg != nil, "action can't be used in multi msg transaction")
+			sender = msgSender
+			ValidateStepMsg(step, ref.ParamsRef, newMsg, t)
 			msgs = append(msgs, newMsg)
 		}
 		t.WithFields(testing.Fields{
@@ -351,13 +532,16 @@ func RunMultiMsgTx(step FixtureStep, t *testing.T) {
 			"tx_msgs":  inttest.AminoCodecFormatter(msgs),
 			"msg_refs": step.MsgRefs,
 		}).AddFields(inttest.GetLogFieldsFromMsgs(msgs)).Debug("debug log")
-		txhash, err := inttest.SendMultiMsgTxWithNonce(t, msgs, sender, true)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(sender), msgs...)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
 		}
 
 		WaitForNextBlockWithErrorCheck(t)
+		if TxFailureCheck(txhash, step, t) {
+			return
+		}
 		GetTxHandleResult(txhash, t)
 	}
 }
@@ -395,7 +579,8 @@ func RunCheckExecution(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		chkExecMsg := CheckExecutionMsgFromRef(step.ParamsRef, t)
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &chkExecMsg, chkExecMsg.Sender, true)
+		ValidateStepMsg(step, step.ParamsRef, &chkExecMsg, t)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(chkExecMsg.Sender), &chkExecMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -403,8 +588,7 @@ func RunCheckExecution(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -419,7 +603,90 @@ func RunCheckExecution(step FixtureStep, t *testing.T) {
 		resp := types.MsgCheckExecutionResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		TxResultStatusMessageCheck(resp.Status, resp.Message, txhash, step, t)
+		if (hasExecutionItemSpecs(step) || step.Output.Golden != "") && len(resp.Output) > 0 {
+			exec, err := inttest.DecodeExecution(chkExecMsg.ExecID)
+			t.WithFields(testing.Fields{
+				"exec_id": chkExecMsg.ExecID,
+			}).MustNil(err, "error decoding execution")
+			ExecutionItemsCheck(step, exec.ItemInputs, resp.Output, t)
+			ExecutionGoldenCheck(step, exec.RecipeID, resp.Output, t)
+		}
+	}
+}
+
+// RunPayToComplete is a function to complete pending execution early by paying pylons and verify the charge and outputs
+func RunPayToComplete(step FixtureStep, t *testing.T) {
+	if FixtureTestOpts.VerifyOnly {
+		return
+	}
+	if step.ParamsRef != "" {
+		chkExecMsg := CheckExecutionMsgFromRef(step.ParamsRef, t)
+		ValidateStepMsg(step, step.ParamsRef, &chkExecMsg, t)
+		result, err := inttest.PayToCompleteAndVerify(t, chkExecMsg.ExecID, chkExecMsg.Sender)
+		t.WithFields(testing.Fields{
+			"exec_id":          chkExecMsg.ExecID,
+			"charged":          result.Charged.String(),
+			"completed_height": result.CompletedHeight,
+			"ready_height":     result.Execution.ReadyHeight,
+		}).MustNil(err, "pay to complete result is different from expected")
+		FixtureCleanup.RegisterItems(chkExecMsg.Sender, result.PaidItemIDs...)
+		RegisterStepResults(step, result, t)
+	}
+}
+
+// ExecutionRefundFromRef is a function to read refund expected from reference string
+// All coins and items locked by the execution are expected to be refunded unless PartialRefund is set.
+func ExecutionRefundFromRef(ref string, sender string, t *testing.T) func(inttest.ExecutionLocks) inttest.ExecutionRefund {
+	var refundType struct {
+		PartialRefund   bool
+		RefundCoins     string
+		RefundItemNames []string
+	}
+	byteValue := ReadFile(ref, t)
+	err := json.Unmarshal(byteValue, &refundType)
+	t.WithFields(testing.Fields{
+		"bytes": string(byteValue),
+	}).MustNil(err, "error reading using json Unmarshaler")
+	if !refundType.PartialRefund {
+		return inttest.FullRefund
+	}
+	refund := inttest.ExecutionRefund{Coins: sdk.Coins{}, ItemIDs: []string{}}
+	if len(refundType.RefundCoins) > 0 {
+		refund.Coins, err = sdk.ParseCoinsNormalized(refundType.RefundCoins)
+		t.WithFields(testing.Fields{
+			"refund_coins": refundType.RefundCoins,
+		}).MustNil(err, "error parsing refund coins")
+	}
+	for _, itemName := range refundType.RefundItemNames {
+		itemID, exist, err := inttest.GetItemIDFromName(sender, itemName, true, false)
+		t.WithFields(testing.Fields{
+			"item_name": itemName,
+		}).MustTrue(exist && err == nil, "refunded item should be locked by execution")
+		refund.ItemIDs = append(refund.ItemIDs, itemID)
+	}
+	return func(inttest.ExecutionLocks) inttest.ExecutionRefund {
+		return refund
+	}
+}
+
+// RunRefundExecution is a function to check execution which can't be completed and verify its locked coins and items are refunded
+func RunRefundExecution(step FixtureStep, t *testing.T) {
+	if FixtureTestOpts.VerifyOnly {
+		return
+	}
+	if step.ParamsRef != "" {
+		chkExecMsg := CheckExecutionMsgFromRef(step.ParamsRef, t)
+		ValidateStepMsg(step, step.ParamsRef, &chkExecMsg, t)
+		expected := ExecutionRefundFromRef(step.ParamsRef, chkExecMsg.Sender, t)
+		locks, err := inttest.RunExecutionRefund(inttest.TestContext(t), t, chkExecMsg.ExecID, inttest.CheckExecutionTrigger(chkExecMsg.PayToComplete), expected)
+		t.WithFields(testing.Fields{
+			"exec_id":      chkExecMsg.ExecID,
+			"locked_coins": locks.Coins.String(),
+			"locked_items": locks.LockedItemIDs(),
+		}).MustNil(err, "execution refund is different from expected")
+		RegisterStepResults(step, locks, t)
 	}
 }
 
@@ -455,7 +722,8 @@ func RunFiatItem(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		itmMsg := FiatItemMsgFromRef(step.ParamsRef, t)
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &itmMsg, itmMsg.Sender, true)
+		ValidateStepMsg(step, step.ParamsRef, &itmMsg, t)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(itmMsg.Sender), &itmMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -463,8 +731,7 @@ func RunFiatItem(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -479,7 +746,10 @@ func RunFiatItem(step FixtureStep, t *testing.T) {
 		resp := types.MsgFiatItemResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		t.MustTrue(resp.ItemID != "", "item id shouldn't be empty")
+		SetStepOutput(step.ID, "item_id", resp.ItemID, t)
+		FixtureCleanup.RegisterItems(itmMsg.Sender, resp.ItemID)
 	}
 }
 
@@ -516,7 +786,11 @@ func RunSendItems(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		siMsg := SendItemsMsgFromRef(step.ParamsRef, t)
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &siMsg, siMsg.Sender, true)
+		ValidateStepMsg(step, step.ParamsRef, &siMsg, t)
+		feeDeltas, feeBalances := TransferFeeBalances(step, func() (inttest.PylonDeltas, error) {
+			return inttest.ExpectedSendItemsTransferFees(siMsg)
+		}, t)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(siMsg.Sender), &siMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -524,8 +798,7 @@ func RunSendItems(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -540,7 +813,19 @@ func RunSendItems(step FixtureStep, t *testing.T) {
 		resp := types.MsgSendItemsResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		TxResultStatusMessageCheck(resp.Status, resp.Message, txhash, step, t)
+
+		if step.Output.VerifyTransfer {
+			err = inttest.CheckItemsOwnership(siMsg.ItemIDs, siMsg.Sender, siMsg.Receiver, t)
+			t.WithFields(testing.Fields{
+				"txhash":   txhash,
+				"sender":   siMsg.Sender,
+				"receiver": siMsg.Receiver,
+				"item_ids": siMsg.ItemIDs,
+			}).MustNil(err, "items transfer result is different from expected")
+		}
+		TransferFeeCheck(step, txhash, feeDeltas, feeBalances, t)
 	}
 }
 
@@ -569,15 +854,28 @@ func RunUpdateItemString(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		sTypeMsg := UpdateItemStringMsgFromRef(step.ParamsRef, t)
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &sTypeMsg, sTypeMsg.Sender, true)
+		ValidateStepMsg(step, step.ParamsRef, &sTypeMsg, t)
+		var itemBefore types.Item
+		var senderBalance banktypes.Balance
+		var updateFee sdk.Coins
+		if step.Output.VerifyUpdate {
+			var err error
+			itemBefore, err = inttest.GetItemByGUID(sTypeMsg.ItemID)
+			t.WithFields(testing.Fields{
+				"item_id": sTypeMsg.ItemID,
+			}).MustNil(err, "error getting item before update")
+			updateFee, err = inttest.UpdateItemStringFee()
+			t.MustNil(err, "error getting update item string fee")
+			senderBalance = inttest.GetAccountBalanceFromAddr(sTypeMsg.Sender, t)
+		}
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(sTypeMsg.Sender), &sTypeMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
 		}
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -592,6 +890,28 @@ func RunUpdateItemString(step FixtureStep, t *testing.T) {
 		resp := types.MsgUpdateItemStringResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
+
+		if step.Output.VerifyUpdate {
+			itemAfter, err := inttest.GetItemByGUID(sTypeMsg.ItemID)
+			t.WithFields(testing.Fields{
+				"item_id": sTypeMsg.ItemID,
+			}).MustNil(err, "error getting item after update")
+			changes, err := inttest.CheckItemUpdate(itemBefore, itemAfter, sTypeMsg.Field, sTypeMsg.Value)
+			t.WithFields(testing.Fields{
+				"txhash":  txhash,
+				"item_id": sTypeMsg.ItemID,
+				"field":   sTypeMsg.Field,
+				"value":   sTypeMsg.Value,
+				"changes": changes,
+			}).MustNil(err, "item attributes are different from expected after update")
+			err = inttest.CheckFeeCharged(senderBalance, updateFee, t)
+			t.WithFields(testing.Fields{
+				"txhash": txhash,
+				"sender": sTypeMsg.Sender,
+				"fee":    updateFee.String(),
+			}).MustNil(err, "update item string fee is not charged as expected")
+		}
 	}
 }
 
@@ -628,8 +948,12 @@ func RunCreateCookbook(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		cbMsg := CreateCookbookMsgFromRef(step.ParamsRef, t)
+		ValidateStepMsg(step, step.ParamsRef, &cbMsg, t)
+		if SkipIdenticalCookbook(step, cbMsg, t) {
+			return
+		}
 
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &cbMsg, cbMsg.Sender, true)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(cbMsg.Sender), &cbMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -637,8 +961,7 @@ func RunCreateCookbook(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -653,7 +976,10 @@ func RunCreateCookbook(step FixtureStep, t *testing.T) {
 		resp := types.MsgCreateCookbookResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		t.MustTrue(resp.CookbookID != "", "coookbook id shouldn't be empty")
+		SetStepOutput(step.ID, "cookbook_id", resp.CookbookID, t)
+		FixtureCleanup.RegisterCookbook(resp.CookbookID, cbMsg.Sender)
 	}
 }
 
@@ -687,8 +1013,12 @@ func RunUpdateCookbook(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		cbMsg := UpdateCookbookMsgFromRef(step.ParamsRef, t)
+		ValidateStepMsg(step, step.ParamsRef, &cbMsg, t)
+		if step.ExpectError == nil && step.Output.TxResult.BroadcastError == "" && step.Output.TxResult.ErrorLog == "" {
+			CheckCookbookUpdateVersion(cbMsg, t)
+		}
 
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &cbMsg, cbMsg.Sender, true)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(cbMsg.Sender), &cbMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -696,8 +1026,7 @@ func RunUpdateCookbook(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -712,7 +1041,9 @@ func RunUpdateCookbook(step FixtureStep, t *testing.T) {
 		resp := types.MsgUpdateCookbookResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		t.MustTrue(resp.CookbookID != "", "coookbook id shouldn't be empty")
+		CheckCookbookVersion(resp.CookbookID, cbMsg.Version, t)
 	}
 }
 
@@ -767,11 +1098,15 @@ func RunCreateRecipe(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		rcpMsg := CreateRecipeMsgFromRef(step.ParamsRef, t)
+		ValidateStepMsg(step, step.ParamsRef, &rcpMsg, t)
 		t.WithFields(testing.Fields{
 			"parsed_recipe": string(inttest.GetAminoCdc().MustMarshalJSON(rcpMsg)),
 		}).Info("recipe info")
+		if SkipIdenticalRecipe(step, rcpMsg, t) {
+			return
+		}
 
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &rcpMsg, rcpMsg.Sender, true)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(rcpMsg.Sender), &rcpMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -779,8 +1114,7 @@ func RunCreateRecipe(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -795,7 +1129,10 @@ func RunCreateRecipe(step FixtureStep, t *testing.T) {
 		resp := types.MsgCreateRecipeResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		t.MustTrue(resp.RecipeID != "", "recipe id shouldn't be empty")
+		SetStepOutput(step.ID, "recipe_id", resp.RecipeID, t)
+		FixtureCleanup.RegisterRecipe(resp.RecipeID, rcpMsg.Sender)
 	}
 }
 
@@ -838,8 +1175,9 @@ func RunUpdateRecipe(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		rcpMsg := UpdateRecipeMsgFromRef(step.ParamsRef, t)
+		ValidateStepMsg(step, step.ParamsRef, &rcpMsg, t)
 
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &rcpMsg, rcpMsg.Sender, true)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(rcpMsg.Sender), &rcpMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -847,8 +1185,7 @@ func RunUpdateRecipe(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -863,6 +1200,7 @@ func RunUpdateRecipe(step FixtureStep, t *testing.T) {
 		resp := types.MsgUpdateRecipeResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		t.MustTrue(resp.RecipeID != "", "recipe id shouldn't be empty")
 	}
 }
@@ -896,8 +1234,9 @@ func RunEnableRecipe(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		rcpMsg := EnableRecipeMsgFromRef(step.ParamsRef, t)
+		ValidateStepMsg(step, step.ParamsRef, &rcpMsg, t)
 
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &rcpMsg, rcpMsg.Sender, true)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(rcpMsg.Sender), &rcpMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -905,8 +1244,7 @@ func RunEnableRecipe(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -921,6 +1259,7 @@ func RunEnableRecipe(step FixtureStep, t *testing.T) {
 		resp := types.MsgEnableRecipeResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		TxResultStatusMessageCheck(resp.Status, resp.Message, txhash, step, t)
 	}
 }
@@ -954,8 +1293,9 @@ func RunDisableRecipe(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		rcpMsg := DisableRecipeMsgFromRef(step.ParamsRef, t)
+		ValidateStepMsg(step, step.ParamsRef, &rcpMsg, t)
 
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &rcpMsg, rcpMsg.Sender, true)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(rcpMsg.Sender), &rcpMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -963,8 +1303,7 @@ func RunDisableRecipe(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -979,6 +1318,7 @@ func RunDisableRecipe(step FixtureStep, t *testing.T) {
 		resp := types.MsgDisableRecipeResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		TxResultStatusMessageCheck(resp.Status, resp.Message, txhash, step, t)
 	}
 }
@@ -1002,8 +1342,8 @@ func ExecuteRecipeMsgFromRef(ref string, t *testing.T) types.MsgExecuteRecipe {
 		"execType":  inttest.AminoCodecFormatter(execType),
 		"new_bytes": string(newByteValue),
 	}).MustNil(err, "error reading using GetJSONMarshaler")
-	// translate itemNames to itemIDs
-	ItemIDs := GetItemIDsFromNames(newByteValue, execType.Sender, false, false, t)
+	// translate itemNames to itemIDs, item IDs can be set directly e.g. by "@itemID" reference
+	ItemIDs := append(execType.ItemIDs, GetItemIDsFromNames(newByteValue, execType.Sender, false, false, t)...)
 
 	return types.NewMsgExecuteRecipe(execType.RecipeID, execType.Sender, ItemIDs)
 }
@@ -1018,7 +1358,10 @@ func RunExecuteRecipe(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		execMsg := ExecuteRecipeMsgFromRef(step.ParamsRef, t)
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &execMsg, execMsg.Sender, true)
+		ValidateStepMsg(step, step.ParamsRef, &execMsg, t)
+		supplies := SupplyBalances(step, execMsg.RecipeID, t)
+		itemInputs := ExecutionItemInputs(step, execMsg.ItemIDs, t)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(execMsg.Sender), &execMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -1026,8 +1369,7 @@ func RunExecuteRecipe(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -1042,6 +1384,7 @@ func RunExecuteRecipe(step FixtureStep, t *testing.T) {
 		resp := types.MsgExecuteRecipeResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		TxResultStatusMessageCheck(resp.Status, resp.Message, txhash, step, t)
 
 		if resp.Message == "scheduled the recipe" { // delayed execution
@@ -1054,6 +1397,7 @@ func RunExecuteRecipe(step FixtureStep, t *testing.T) {
 			execIDRWMutex.Lock()
 			execIDs[step.ID] = scheduleRes.ExecID
 			execIDRWMutex.Unlock()
+			SetStepOutput(step.ID, "exec_id", scheduleRes.ExecID, t)
 			for _, itemID := range execMsg.ItemIDs {
 				item, err := inttest.GetItemByGUID(itemID)
 				t.WithFields(testing.Fields{
@@ -1065,14 +1409,51 @@ func RunExecuteRecipe(step FixtureStep, t *testing.T) {
 			t.WithFields(testing.Fields{
 				"exec_id": scheduleRes.ExecID,
 			}).Debug("scheduled execution")
+			if step.Output.VerifySupply {
+				t.WithFields(testing.Fields{
+					"exec_id": scheduleRes.ExecID,
+				}).Info("supply is not verified as coins of scheduled execution are minted when it's checked")
+			}
+			if hasExecutionItemSpecs(step) {
+				t.WithFields(testing.Fields{
+					"exec_id": scheduleRes.ExecID,
+				}).Info("items are not verified as scheduled execution pays them out when it's checked")
+			}
+			if step.Output.Golden != "" {
+				t.WithFields(testing.Fields{
+					"exec_id": scheduleRes.ExecID,
+				}).Info("golden is not compared as scheduled execution pays out when it's checked, set it on check_execution step")
+			}
 		} else { // straight execution
 			t.WithFields(testing.Fields{
 				"output": string(resp.Output),
 			}).Debug("straight execution result")
+			FixtureCleanup.RegisterExecutionOutput(execMsg.Sender, resp.Output)
+			SupplyCheck(step, txhash, resp.Output, supplies, t)
+			ExecutionItemsCheck(step, itemInputs, resp.Output, t)
+			ExecutionGoldenCheck(step, execMsg.RecipeID, resp.Output, t)
 		}
 	}
 }
 
+// RunExecuteDelayedRecipe is a function to create a recipe with block interval, execute it, wait and check the execution
+func RunExecuteDelayedRecipe(step FixtureStep, t *testing.T) {
+	if FixtureTestOpts.VerifyOnly {
+		return
+	}
+	if step.ParamsRef != "" {
+		rcpMsg := CreateRecipeMsgFromRef(step.ParamsRef, t)
+		ValidateStepMsg(step, step.ParamsRef, &rcpMsg, t)
+		result, err := inttest.ExecuteDelayedRecipeAndVerify(inttest.TestContext(t), t, rcpMsg, []string{}, nil)
+		t.WithFields(testing.Fields{
+			"recipe_name": rcpMsg.Name,
+			"recipe_id":   result.RecipeID,
+			"exec_id":     result.ExecID,
+		}).MustNil(err, "delayed execution result is different from expected")
+		RegisterStepResults(step, result, t)
+	}
+}
+
 // CreateTradeMsgFromRef collect create trade msg from reference
 func CreateTradeMsgFromRef(ref string, t *testing.T) types.MsgCreateTrade {
 	byteValue := ReadFile(ref, t)
@@ -1102,15 +1483,21 @@ func CreateTradeMsgFromRef(ref string, t *testing.T) types.MsgCreateTrade {
 
 // RunCreateTrade is a function to create trade
 func RunCreateTrade(step FixtureStep, t *testing.T) {
+	runCreateTrade(step, CreateTradeMsgFromRef, t)
+}
+
+// runCreateTrade is a function to create trade of msg read by msgFromRef
+func runCreateTrade(step FixtureStep, msgFromRef func(string, *testing.T) types.MsgCreateTrade, t *testing.T) {
 	if FixtureTestOpts.VerifyOnly {
 		return
 	}
 	if step.ParamsRef != "" {
-		createTrd := CreateTradeMsgFromRef(step.ParamsRef, t)
+		createTrd := msgFromRef(step.ParamsRef, t)
+		ValidateStepMsg(step, step.ParamsRef, &createTrd, t)
 		t.WithFields(testing.Fields{
 			"tx_msgs": inttest.AminoCodecFormatter(createTrd),
 		}).AddFields(inttest.GetLogFieldsFromMsgs([]sdk.Msg{&createTrd})).Debug("createTrd")
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &createTrd, createTrd.Sender, true)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(createTrd.Sender), &createTrd)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -1118,8 +1505,7 @@ func RunCreateTrade(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -1134,7 +1520,10 @@ func RunCreateTrade(step FixtureStep, t *testing.T) {
 		resp := types.MsgCreateTradeResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		t.MustTrue(resp.TradeID != "", "trade id shouldn't be empty")
+		SetStepOutput(step.ID, "trade_id", resp.TradeID, t)
+		FixtureCleanup.RegisterTrade(resp.TradeID, createTrd.Sender)
 	}
 }
 
@@ -1165,13 +1554,21 @@ func FulfillTradeMsgFromRef(ref string, t *testing.T) types.MsgFulfillTrade {
 
 // RunFulfillTrade is a function to fulfill trade
 func RunFulfillTrade(step FixtureStep, t *testing.T) {
+	runFulfillTrade(step, FulfillTradeMsgFromRef, t)
+}
 
+// runFulfillTrade is a function to fulfill trade by msg read by msgFromRef
+func runFulfillTrade(step FixtureStep, msgFromRef func(string, *testing.T) types.MsgFulfillTrade, t *testing.T) {
 	if FixtureTestOpts.VerifyOnly {
 		return
 	}
 	if step.ParamsRef != "" {
-		ffTrdMsg := FulfillTradeMsgFromRef(step.ParamsRef, t)
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &ffTrdMsg, ffTrdMsg.Sender, true)
+		ffTrdMsg := msgFromRef(step.ParamsRef, t)
+		ValidateStepMsg(step, step.ParamsRef, &ffTrdMsg, t)
+		feeDeltas, feeBalances := TransferFeeBalances(step, func() (inttest.PylonDeltas, error) {
+			return inttest.ExpectedFulfillTradeTransferFees(ffTrdMsg)
+		}, t)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(ffTrdMsg.Sender), &ffTrdMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -1179,8 +1576,7 @@ func RunFulfillTrade(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -1195,7 +1591,9 @@ func RunFulfillTrade(step FixtureStep, t *testing.T) {
 		resp := types.MsgFulfillTradeResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		TxResultStatusMessageCheck(resp.Status, resp.Message, txhash, step, t)
+		TransferFeeCheck(step, txhash, feeDeltas, feeBalances, t)
 	}
 }
 
@@ -1229,7 +1627,8 @@ func RunDisableTrade(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		dsTrdMsg := DisableTradeMsgFromRef(step.ParamsRef, t)
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &dsTrdMsg, dsTrdMsg.Sender, true)
+		ValidateStepMsg(step, step.ParamsRef, &dsTrdMsg, t)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(dsTrdMsg.Sender), &dsTrdMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -1237,8 +1636,7 @@ func RunDisableTrade(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -1253,6 +1651,7 @@ func RunDisableTrade(step FixtureStep, t *testing.T) {
 		resp := types.MsgDisableTradeResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		TxResultStatusMessageCheck(resp.Status, resp.Message, txhash, step, t)
 	}
 }
@@ -1287,7 +1686,8 @@ func RunEnableTrade(step FixtureStep, t *testing.T) {
 	}
 	if step.ParamsRef != "" {
 		dsTrdMsg := EnableTradeMsgFromRef(step.ParamsRef, t)
-		txhash, err := inttest.TestTxWithMsgWithNonce(t, &dsTrdMsg, dsTrdMsg.Sender, true)
+		ValidateStepMsg(step, step.ParamsRef, &dsTrdMsg, t)
+		txhash, err := inttest.NewClient().SendTx(context.Background(), t, inttest.SignerAddress(dsTrdMsg.Sender), &dsTrdMsg)
 		if err != nil {
 			TxBroadcastErrorCheck(err, txhash, step, t)
 			return
@@ -1295,8 +1695,7 @@ func RunEnableTrade(step FixtureStep, t *testing.T) {
 
 		WaitForNextBlockWithErrorCheck(t)
 
-		TxErrorLogCheck(txhash, step.Output.TxResult.ErrorLog, t)
-		if len(step.Output.TxResult.ErrorLog) > 0 {
+		if TxFailureCheck(txhash, step, t) {
 			return
 		}
 
@@ -1311,6 +1710,7 @@ func RunEnableTrade(step FixtureStep, t *testing.T) {
 		resp := types.MsgEnableTradeResponse{}
 		err = proto.Unmarshal(txMsgData.Data[0].Data, &resp)
 		TxResultDecodingErrorCheck(err, txhash, t)
+		RegisterStepResults(step, resp, t)
 		TxResultStatusMessageCheck(resp.Status, resp.Message, txhash, step, t)
 	}
 }
@@ -0,0 +1,106 @@
+package fixturetest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+// StepState describes the result state of a fixture step
+type StepState string
+
+// describes the result states of a fixture step
+const (
+	StepPassed        StepState = "passed"
+	StepFailed        StepState = "failed"
+	StepSkipped       StepState = "skipped"        // step is marked to skip or its precondition was skipped
+	StepNotApplicable StepState = "not_applicable" // node does not have capability required by the step
+	StepQuarantined   StepState = "quarantined"    // step is known to be flaky and not run by default
+)
+
+// ParseStepStates is a function to parse comma separated step states
+func ParseStepStates(states string) ([]StepState, error) {
+	parsed := []StepState{}
+	if len(states) == 0 {
+		return parsed, nil
+	}
+	for _, state := range strings.Split(states, ",") {
+		switch StepState(state) {
+		case StepFailed, StepSkipped, StepNotApplicable, StepQuarantined:
+			parsed = append(parsed, StepState(state))
+		default:
+			return parsed, fmt.Errorf("unknown step state %s", state)
+		}
+	}
+	return parsed, nil
+}
+
+// GetStepSkipState is a function to get the state and reason when a step should not run
+// It returns empty state when the step should run
+func GetStepSkipState(file string, step FixtureStep) (StepState, string) {
+	if inttest.ShuttingDown() {
+		return StepSkipped, "run is shutting down"
+	}
+	if step.Skip {
+		return StepSkipped, "step is marked to skip"
+	}
+	for _, capability := range step.Requires {
+		if !inttest.Exists(FixtureTestOpts.NodeCapabilities, capability) {
+			return StepNotApplicable, fmt.Sprintf("node does not have required capability %s", capability)
+		}
+	}
+	if step.Quarantined && !FixtureTestOpts.RunQuarantined {
+		return StepQuarantined, "step is quarantined"
+	}
+	for _, condition := range step.RunAfter.PreCondition {
+		switch state := FixtureRunStatus.GetStepState(file, condition); state {
+		case StepSkipped, StepNotApplicable, StepQuarantined:
+			return state, fmt.Sprintf("precondition %s is %s", condition, state)
+		}
+	}
+	return "", ""
+}
+
+// CheckStepStatePolicy is a function to fail the test when any step finished in one of the states to fail on
+func CheckStepStatePolicy(t *originT.T) {
+	snapshot := FixtureRunStatus.Snapshot()
+	t.Logf("fixture steps: passed=%d failed=%d skipped=%d not_applicable=%d quarantined=%d",
+		snapshot.Passed, snapshot.Failed, snapshot.Skipped, snapshot.NotApplicable, snapshot.Quarantined)
+	counts := map[StepState]int{
+		StepFailed:        snapshot.Failed,
+		StepSkipped:       snapshot.Skipped,
+		StepNotApplicable: snapshot.NotApplicable,
+		StepQuarantined:   snapshot.Quarantined,
+	}
+	for _, state := range FixtureTestOpts.FailOnStates {
+		if counts[state] > 0 {
+			t.Errorf("%d steps are %s and the run is configured to fail on it", counts[state], state)
+		}
+	}
+}
+
+// WriteStepSummary is a function to print one-line summary of finished step to console sink of evtesting
+func WriteStepSummary(file string, step FixtureStep, state StepState, duration time.Duration, skipReason string, t *testing.T) {
+	summary := testing.StepSummary{
+		Scenario: file,
+		Step:     step.ID,
+		Action:   step.Action,
+		Duration: duration,
+	}
+	switch state {
+	case StepPassed:
+		summary.State = string(testing.TestPassed)
+	case StepFailed:
+		summary.State = string(testing.TestFailed)
+		summary.Message = t.FailureCause()
+	default:
+		summary.State = testing.SummarySkipped
+		summary.Message = fmt.Sprintf("%s %s", state, skipReason)
+	}
+	testing.WriteStepSummary(summary)
+}
@@ -0,0 +1,142 @@
+package fixturetest
+
+import (
+	"time"
+
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestParseStepStates(originT *originT.T) {
+	t := testing.NewT(originT)
+	states, err := ParseStepStates("failed,not_applicable,quarantined")
+	t.MustNil(err, "error parsing step states")
+	t.WithFields(testing.Fields{
+		"states": states,
+	}).MustTrue(len(states) == 3 && states[1] == StepNotApplicable, "states should be parsed in order")
+	states, err = ParseStepStates("")
+	t.MustTrue(err == nil && len(states) == 0, "empty states should be parsed as no state")
+	_, err = ParseStepStates("failed,passed")
+	t.MustTrue(err != nil, "passed is not a state to fail on")
+	_, err = ParseStepStates("not-applicable")
+	t.MustTrue(err != nil, "unknown state should be an error")
+}
+
+// stepSkipStateCase is a step with the state and reason GetStepSkipState should get for it
+type stepSkipStateCase struct {
+	name   string
+	step   FixtureStep
+	state  StepState
+	reason string
+}
+
+func TestGetStepSkipState(originT *originT.T) {
+	t := testing.NewT(originT)
+	defer func(capabilities []string, runQuarantined bool) {
+		FixtureTestOpts.NodeCapabilities = capabilities
+		FixtureTestOpts.RunQuarantined = runQuarantined
+	}(FixtureTestOpts.NodeCapabilities, FixtureTestOpts.RunQuarantined)
+	FixtureTestOpts.NodeCapabilities = []string{"fiat_items"}
+	FixtureTestOpts.RunQuarantined = false
+
+	file := "scenarios/step_state_test.json"
+	for stepID, state := range map[string]StepState{
+		"PASSED":         StepPassed,
+		"FAILED":         StepFailed,
+		"SKIPPED":        StepSkipped,
+		"NOT_APPLICABLE": StepNotApplicable,
+		"QUARANTINED":    StepQuarantined,
+	} {
+		FixtureRunStatus.StepFinished(file, FixtureStep{ID: stepID}, state)
+	}
+	withPreCondition := func(step FixtureStep, conditions ...string) FixtureStep {
+		step.RunAfter.PreCondition = conditions
+		return step
+	}
+
+	for _, tc := range []stepSkipStateCase{
+		{name: "step to run", step: FixtureStep{ID: "RUN", Requires: []string{"fiat_items"}}},
+		{name: "marked to skip", step: FixtureStep{ID: "RUN", Skip: true}, state: StepSkipped, reason: "step is marked to skip"},
+		{name: "missing capability", step: FixtureStep{ID: "RUN", Requires: []string{"fiat_items", "authz"}}, state: StepNotApplicable, reason: "node does not have required capability authz"},
+		{name: "quarantined", step: FixtureStep{ID: "RUN", Quarantined: true}, state: StepQuarantined, reason: "step is quarantined"},
+		{name: "skip before capability", step: FixtureStep{ID: "RUN", Skip: true, Requires: []string{"authz"}}, state: StepSkipped, reason: "step is marked to skip"},
+		{name: "passed precondition", step: withPreCondition(FixtureStep{ID: "RUN"}, "PASSED")},
+		{name: "skipped precondition", step: withPreCondition(FixtureStep{ID: "RUN"}, "PASSED", "SKIPPED"), state: StepSkipped, reason: "precondition SKIPPED is skipped"},
+		{name: "not applicable precondition", step: withPreCondition(FixtureStep{ID: "RUN"}, "NOT_APPLICABLE"), state: StepNotApplicable, reason: "precondition NOT_APPLICABLE is not_applicable"},
+		{name: "quarantined precondition", step: withPreCondition(FixtureStep{ID: "RUN"}, "QUARANTINED"), state: StepQuarantined, reason: "precondition QUARANTINED is quarantined"},
+		// steps of failed preconditions are not started by the work queue, see TestFailedPreconditionStep
+		{name: "failed precondition", step: withPreCondition(FixtureStep{ID: "RUN"}, "FAILED")},
+	} {
+		state, reason := GetStepSkipState(file, tc.step)
+		t.WithFields(testing.Fields{
+			"case":   tc.name,
+			"state":  state,
+			"reason": reason,
+		}).MustTrue(state == tc.state && reason == tc.reason, "skip state of step is different")
+	}
+
+	FixtureTestOpts.RunQuarantined = true
+	state, _ := GetStepSkipState(file, FixtureStep{ID: "RUN", Quarantined: true})
+	t.MustTrue(state == "", "quarantined step should run when quarantined steps are selected to run")
+}
+
+func TestFailedPreconditionStep(originT *originT.T) {
+	t := testing.NewT(originT)
+	defer func(queues []QueueItem) {
+		workQueues = queues
+	}(workQueues)
+	file := "scenarios/step_state_test.json"
+	workQueues = []QueueItem{
+		{fixtureFileName: file, idx: 0, stepID: "CREATE_COOKBOOK", status: Done},
+		{fixtureFileName: file, idx: 1, stepID: "CREATE_RECIPE", status: InProgress},
+		{fixtureFileName: file, idx: 2, stepID: "EXECUTE_RECIPE", status: NotStarted},
+	}
+	step := FixtureStep{ID: "EXECUTE_RECIPE"}
+	step.RunAfter.PreCondition = []string{"CREATE_COOKBOOK", "CREATE_RECIPE"}
+	// a failed step is not marked as done, so steps depending on it are not started
+	t.MustTrue(!GoodToGoForStep(file, 2, step, &t), "step should not start before its preconditions are done")
+	workQueues[1].status = Done
+	t.MustTrue(GoodToGoForStep(file, 2, step, &t), "step should start when its preconditions are done")
+}
+
+func TestRunStatusStepStates(originT *originT.T) {
+	t := testing.NewT(originT)
+	rs := &RunStatus{
+		StartedAt:    time.Now(),
+		RunningSteps: make(map[string]StepStatus),
+		WaitingSteps: make(map[string]StepStatus),
+		Results:      make(map[string]StepState),
+	}
+	file := "scenarios/step_state_test.json"
+	rs.RegisterFixture(file, 6)
+	steps := []FixtureStep{}
+	for _, stepID := range []string{"PASSED", "WAITED", "FAILED", "SKIPPED", "NOT_APPLICABLE", "QUARANTINED"} {
+		steps = append(steps, FixtureStep{ID: stepID, Action: "create_account"})
+	}
+	rs.StepStarted(file, steps[0])
+	rs.StepWaiting(file, steps[1])
+	rs.StepStarted(file, steps[1])
+	rs.StepStarted(file, steps[2])
+	snapshot := rs.Snapshot()
+	t.WithFields(testing.Fields{
+		"snapshot": snapshot,
+	}).MustTrue(snapshot.PendingSteps == 3 && len(snapshot.RunningSteps) == 3 && len(snapshot.WaitingSteps) == 0,
+		"started steps should be running, and waiting step should not be counted twice")
+
+	rs.StepFinished(file, steps[0], StepPassed)
+	rs.StepFinished(file, steps[1], StepPassed)
+	rs.StepFinished(file, steps[2], StepFailed)
+	// skipped steps finish without being started
+	rs.StepFinished(file, steps[3], StepSkipped)
+	rs.StepFinished(file, steps[4], StepNotApplicable)
+	rs.StepFinished(file, steps[5], StepQuarantined)
+	snapshot = rs.Snapshot()
+	t.WithFields(testing.Fields{
+		"snapshot": snapshot,
+	}).MustTrue(snapshot.PendingSteps == 0 && len(snapshot.RunningSteps) == 0, "all steps should be finished")
+	t.MustTrue(snapshot.Passed == 2 && snapshot.Failed == 1 && snapshot.Skipped == 1 && snapshot.NotApplicable == 1 && snapshot.Quarantined == 1,
+		"steps should be counted by their states")
+	t.MustTrue(len(snapshot.RecentErrors) == 1 && snapshot.RecentErrors[0].StepID == "FAILED", "only failed step should be a recent error")
+	t.MustTrue(rs.GetStepState(file, "NOT_APPLICABLE") == StepNotApplicable && rs.GetStepState(file, "RUNNING") == "", "state of finished steps should be kept")
+}
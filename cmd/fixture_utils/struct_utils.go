@@ -5,21 +5,33 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"path"
+	"path/filepath"
 	"sync"
 	"time"
 
 	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
 	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
 	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 var execIDRWMutex sync.Mutex
 var execIDs = make(map[string]string)
 
-// ReadFile is a function to read file
+// FixturePath is a function to get path of fixture file reference relative to base directory
+// References are written with slashes e.g. "./recipes/knife.json", they're converted to separators of the platform.
+func FixturePath(fileURL string) string {
+	return filepath.Join(FixtureTestOpts.BaseDirectory, filepath.FromSlash(fileURL))
+}
+
+// ReadFile is a function to read file, go template placeholders and "@name" references to registered results are resolved
 func ReadFile(fileURL string, t *testing.T) []byte {
-	jsonFile, err := os.Open(path.Join(FixtureTestOpts.BaseDirectory, fileURL))
+	return ResolveResultRefs(RenderFixtureTemplate(fileURL, ReadRawFile(fileURL, t), t), t)
+}
+
+// ReadRawFile is a function to read file without resolving go template placeholders
+func ReadRawFile(fileURL string, t *testing.T) []byte {
+	jsonFile, err := os.Open(FixturePath(fileURL))
 	t.MustNil(err, "fatal log reading file")
 
 	defer jsonFile.Close()
@@ -38,6 +50,9 @@ func UnmarshalIntoEmptyInterface(bytes []byte, t *testing.T) map[string]interfac
 	return raw
 }
 
+// AdminTempName is the account temp name of admin key e.g. sender of fiat_item steps
+const AdminTempName = "admin"
+
 // RegisterDefaultAccountKeys register the accounts configured on FixtureTestOpts.AccountNames
 func RegisterDefaultAccountKeys() {
 	runtimeKeyGenMux.Lock()
@@ -45,6 +60,9 @@ func RegisterDefaultAccountKeys() {
 	for idx, key := range FixtureTestOpts.AccountNames {
 		runtimeAccountKeys[fmt.Sprintf("account%d", idx+1)] = key
 	}
+	if len(FixtureTestOpts.AdminKey) > 0 {
+		runtimeAccountKeys[AdminTempName] = FixtureTestOpts.AdminKey
+	}
 }
 
 // GetAccountKeyFromTempName is a function to get account key from temp name
@@ -62,8 +80,30 @@ func GetAccountKeyFromTempName(tempName string, t *testing.T) string {
 
 // GetAccountAddressFromTempName is a function to get account address from temp name
 func GetAccountAddressFromTempName(tempName string, t *testing.T) string {
+	// address resolved by fixture template e.g. {{.account1.address}}
+	if _, err := sdk.AccAddressFromBech32(tempName); err == nil {
+		return tempName
+	}
+	if inttest.LooksLikeAddress(tempName) {
+		err := inttest.ValidateAccountAddress(tempName)
+		t.WithFields(testing.Fields{
+			"address": tempName,
+		}).MustNil(err, "invalid account address")
+	}
+	// account alias of address book e.g. treasury account of other chain services
+	if addr, ok := inttest.GlobalAddressBook.Address(tempName); ok {
+		return addr
+	}
+	if FixtureTestOpts.InMemory {
+		addr := inMemoryAddress(tempName)
+		_ = inttest.GlobalAddressBook.Add(tempName, addr)
+		return addr
+	}
 	accountKey := GetAccountKeyFromTempName(tempName, t)
-	return inttest.GetAccountAddr(accountKey, t)
+	addr := inttest.GetAccountAddr(accountKey, t)
+	// keep alias of test accounts so that logs can print them by name
+	_ = inttest.GlobalAddressBook.Add(tempName, addr)
+	return addr
 }
 
 // GetSenderKeyFromRef is a function to get create cookbook message from reference
@@ -126,7 +166,7 @@ func UpdateCBNameToID(bytes []byte, t *testing.T) []byte {
 	if !ok {
 		return bytes
 	}
-	cbID, exist, err := inttest.GetCookbookIDFromName(cbName, "")
+	cbID, exist, err := inttest.GetCookbookIDFromName(cbName, "", inttest.WithEnv(FixtureEnv()))
 	if exist && err != nil {
 		raw["CookbookID"] = cbID
 		newBytes, err := json.Marshal(raw)
@@ -142,9 +182,13 @@ func UpdateCBNameToID(bytes []byte, t *testing.T) []byte {
 func UpdateRecipeName(bytes []byte, t *testing.T) []byte {
 	raw := UnmarshalIntoEmptyInterface(bytes, t)
 
+	if _, ok := raw["RecipeName"]; !ok && raw["RecipeID"] != nil {
+		// recipe id is set directly e.g. by "@recipeID" reference
+		return bytes
+	}
 	rcpName, ok := raw["RecipeName"].(string)
 	t.MustTrue(ok, "recipe name field is empty")
-	rcpID, exist, err := inttest.GetRecipeIDFromName(rcpName)
+	rcpID, exist, err := inttest.GetRecipeIDFromName(rcpName, inttest.WithEnv(FixtureEnv()))
 	t.WithFields(testing.Fields{
 		"recipe_name": rcpName,
 	}).MustTrue(exist, "there's no recipe id with specific recipe name")
@@ -161,9 +205,13 @@ func UpdateRecipeName(bytes []byte, t *testing.T) []byte {
 func UpdateTradeExtraInfoToID(bytes []byte, t *testing.T) []byte {
 	raw := UnmarshalIntoEmptyInterface(bytes, t)
 
+	if _, ok := raw["TradeInfo"]; !ok && raw["TradeID"] != nil {
+		// trade id is set directly e.g. by "@tradeID" reference
+		return bytes
+	}
 	trdInfo, ok := raw["TradeInfo"].(string)
 	t.MustTrue(ok, "trade info does not exist in json")
-	trdID, exist, err := inttest.GetTradeIDFromExtraInfo(trdInfo)
+	trdID, exist, err := inttest.GetTradeIDFromExtraInfo(trdInfo, inttest.WithEnv(FixtureEnv()))
 	t.WithFields(testing.Fields{
 		"trade_info": trdInfo,
 	}).MustTrue(exist, "there's not trade id with specific info")
@@ -180,6 +228,10 @@ func UpdateTradeExtraInfoToID(bytes []byte, t *testing.T) []byte {
 func UpdateExecID(bytes []byte, t *testing.T) []byte {
 	raw := UnmarshalIntoEmptyInterface(bytes, t)
 
+	if _, ok := raw["ExecRef"]; !ok && raw["ExecID"] != nil {
+		// exec id is set directly e.g. by "@execID" reference
+		return bytes
+	}
 	var execRefReader struct {
 		ExecRef string
 	}
@@ -211,7 +263,7 @@ func UpdateItemIDFromName(bytes []byte, includeLockedByRecipe, includeLockedByTr
 	sender, ok := raw["Sender"].(string)
 	t.MustTrue(ok, "sender address does not exist in json")
 
-	itemID, exist, err := inttest.GetItemIDFromName(sender, itemName, includeLockedByRecipe, includeLockedByTrade)
+	itemID, exist, err := inttest.GetItemIDFromName(sender, itemName, includeLockedByRecipe, includeLockedByTrade, inttest.WithEnv(FixtureEnv()))
 	if !exist {
 		t.WithFields(testing.Fields{
 			"item_name":                itemName,
@@ -244,7 +296,7 @@ func GetItemIDsFromNames(bytes []byte, sender string, includeLockedByRecipe, inc
 	ItemIDs := []string{}
 
 	for _, itemName := range itemNamesResp.ItemNames {
-		itemID, exist, err := inttest.GetItemIDFromName(sender, itemName, includeLockedByRecipe, includeLockedByTrade)
+		itemID, exist, err := inttest.GetItemIDFromName(sender, itemName, includeLockedByRecipe, includeLockedByTrade, inttest.WithEnv(FixtureEnv()))
 		if !exist {
 			t.WithFields(testing.Fields{
 				"item_name":                itemName,
@@ -344,12 +396,12 @@ func GetItemOutputsFromBytes(bytes []byte, sender string, t *testing.T) types.It
 
 	for _, iN := range itemOutputNamesReader.ItemOutputNames {
 		var io types.Item
-		iID, ok, err := inttest.GetItemIDFromName(sender, iN, false, false)
+		iID, ok, err := inttest.GetItemIDFromName(sender, iN, false, false, inttest.WithEnv(FixtureEnv()))
 		t.MustTrue(ok, "item id with specific name does not exist")
 		t.WithFields(testing.Fields{
 			"item_name": iN,
 		}).MustNil(err, "error getting item id from name")
-		io, err = inttest.GetItemByGUID(iID)
+		io, err = getItemByGUID(iID)
 		t.WithFields(testing.Fields{
 			"item_id": iID,
 		}).MustNil(err, "error getting item from id")
@@ -0,0 +1,165 @@
+package fixturetest
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+// suiteFlags is a struct to have command line flags of reports, result sinks and harness services of a fixture run
+type suiteFlags struct {
+	reportFile                string
+	verbosity                 string
+	metricsAddr               string
+	metricsFile               string
+	explorerTxURL             string
+	artifactsDir              string
+	failureSnapshotTxs        int
+	nodeLogFile               string
+	resultJSONFile            string
+	runHistoryDir             string
+	resultWebhook             string
+	resultWebhookFailuresOnly bool
+	otlpEndpoint              string
+	traceServiceName          string
+	shutdownGrace             time.Duration
+}
+
+var suiteOpts = suiteFlags{}
+
+func init() {
+	flag.StringVar(&suiteOpts.verbosity, "verbosity", "debug", "amount of test logs, one of quiet, normal, debug or trace, quiet and normal print one-line step summaries")
+	flag.StringVar(&suiteOpts.reportFile, "report-file", "", "file to write test result summary, .html and .md files get report with transactions and captured state")
+	flag.StringVar(&suiteOpts.explorerTxURL, "explorer-tx-url", "", "explorer url of transaction page to link transactions of report e.g. https://explorer.example.com/txs/%s")
+	flag.StringVar(&suiteOpts.artifactsDir, "artifacts-dir", "", "directory to write files attached by tests e.g. failed tx results, next to report file by default")
+	flag.IntVar(&suiteOpts.failureSnapshotTxs, "failure-snapshot-txs", 5, "number of latest transactions of each involved account in chain context snapshot attached to failures, 0 disables the snapshot")
+	flag.StringVar(&suiteOpts.metricsAddr, "metrics-addr", "", "address to serve prometheus metrics of test harness on /metrics")
+	flag.StringVar(&suiteOpts.metricsFile, "metrics-file", "", "file to write prometheus metrics of test harness when tests finish")
+	flag.StringVar(&suiteOpts.resultJSONFile, "result-json-file", "", "file to write a json line per test start, test end and suite end as tests run")
+	flag.StringVar(&suiteOpts.runHistoryDir, "run-history-dir", "", "directory to keep a result json file per run, trends of the last runs are reported by fixturetest report trends")
+	flag.StringVar(&suiteOpts.resultWebhook, "result-webhook", "", "url to post json of finished tests and suite summary to e.g. a Slack incoming webhook")
+	flag.BoolVar(&suiteOpts.resultWebhookFailuresOnly, "result-webhook-failures-only", false, "post only failed tests and suite summary to result-webhook")
+	flag.StringVar(&suiteOpts.nodeLogFile, "node-log", "", "log file of locally bootstrapped node to attach its lines logged while a test ran to failures")
+	flag.StringVar(&suiteOpts.otlpEndpoint, "otlp-endpoint", os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"), "OTLP/HTTP collector url to export spans of scenarios, steps and chain calls e.g. http://localhost:4318")
+	flag.StringVar(&suiteOpts.traceServiceName, "trace-service-name", "pylons-fixture-test", "service name of exported spans")
+	flag.DurationVar(&suiteOpts.shutdownGrace, "shutdown-grace", inttest.DefaultShutdownGrace, "time steps in flight have to conclude after SIGINT or SIGTERM before report, metrics and checkpoint are flushed and the run exits, 0 to wait until they conclude")
+}
+
+// FixtureSuite is a struct to manage harness services started for a fixture run by StartSuite
+type FixtureSuite struct {
+	// ReportFile is the report file set by -report-file, it's written by Finish
+	ReportFile    string
+	chaos         *inttest.ChaosHook
+	nodeLogTailer *inttest.NodeLogTailer
+	stopSignals   func()
+}
+
+// StartSuite is a function to apply chain profile and suite flags after flags are parsed
+// It sets verbosity and report options, adds result sinks and starts metrics, tracing, chaos and node log services.
+// SIGINT and SIGTERM stop starting steps, and report, checkpoint and metrics are flushed by Finish or by inttest.GlobalShutdown
+// when steps in flight don't conclude in -shutdown-grace.
+func StartSuite() (*FixtureSuite, error) {
+	if err := inttest.ApplyChainProfile(); err != nil {
+		return nil, fmt.Errorf("error applying chain profile: %w", err)
+	}
+	v, err := testing.ParseVerbosity(suiteOpts.verbosity)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing verbosity: %w", err)
+	}
+	testing.SetVerbosity(v)
+	if v == testing.VerbosityQuiet || v == testing.VerbosityNormal {
+		testing.SetConsoleSink(testing.NewConsoleSink(os.Stdout))
+	}
+	if len(suiteOpts.metricsAddr) > 0 {
+		inttest.StartMetricsServer(suiteOpts.metricsAddr)
+	}
+	if len(suiteOpts.otlpEndpoint) > 0 {
+		inttest.EnableTracing(inttest.TracingOptions{
+			Endpoint:    suiteOpts.otlpEndpoint,
+			ServiceName: suiteOpts.traceServiceName,
+		})
+	}
+	suite := &FixtureSuite{ReportFile: suiteOpts.reportFile}
+	if suite.chaos, err = inttest.EnableChaos(inttest.CLIOpts.Chaos); err != nil {
+		return nil, fmt.Errorf("error enabling chaos: %w", err)
+	}
+	if len(suiteOpts.resultJSONFile) > 0 {
+		sink, err := testing.NewJSONFileResultSink(suiteOpts.resultJSONFile)
+		if err != nil {
+			return nil, fmt.Errorf("error creating result json file: %w", err)
+		}
+		testing.AddResultSink(sink)
+	}
+	if len(suiteOpts.runHistoryDir) > 0 {
+		if err := os.MkdirAll(suiteOpts.runHistoryDir, 0755); err != nil {
+			return nil, fmt.Errorf("error creating run history directory: %w", err)
+		}
+		sink, err := testing.NewJSONFileResultSink(testing.RunHistoryFile(suiteOpts.runHistoryDir, time.Now()))
+		if err != nil {
+			return nil, fmt.Errorf("error creating run history file: %w", err)
+		}
+		testing.AddResultSink(sink)
+	}
+	if len(suiteOpts.resultWebhook) > 0 {
+		testing.AddResultSink(testing.NewWebhookResultSink(suiteOpts.resultWebhook, suiteOpts.resultWebhookFailuresOnly))
+	}
+	testing.ReportOpts.ExplorerTxURL = suiteOpts.explorerTxURL
+	testing.ReportOpts.ArtifactsDir = suiteOpts.artifactsDir
+	testing.ReportOpts.FailureSnapshotTxs = suiteOpts.failureSnapshotTxs
+	testing.ReportOpts.DisableFailureSnapshot = suiteOpts.failureSnapshotTxs == 0
+	if len(suiteOpts.nodeLogFile) > 0 {
+		if suite.nodeLogTailer, err = inttest.StartNodeLogTailer(suiteOpts.nodeLogFile); err != nil {
+			return nil, fmt.Errorf("error following node log: %w", err)
+		}
+	}
+	testing.StartReport(suite.ReportFile)
+	// hooks run in reverse order, so report is written first and services are stopped last
+	inttest.GlobalShutdown.Grace = suiteOpts.shutdownGrace
+	inttest.GlobalShutdown.OnShutdown("suite services", func(ctx context.Context) error {
+		suite.stopServices()
+		return nil
+	})
+	inttest.GlobalShutdown.OnShutdown("checkpoint", func(ctx context.Context) error {
+		return WriteCheckpoint()
+	})
+	inttest.GlobalShutdown.OnShutdown("report", func(ctx context.Context) error {
+		testing.FinishReport(suite.ReportFile)
+		return nil
+	})
+	suite.stopSignals = inttest.GlobalShutdown.Notify()
+	return suite, nil
+}
+
+// Finish is a function to write report and checkpoint, stop services of the suite and export its spans and metrics after tests finish
+func (s *FixtureSuite) Finish() {
+	s.stopSignals()
+	inttest.GlobalShutdown.Flush(context.Background())
+}
+
+// ExitCode is a function to get exit code of the run from exit code of tests, inttest.ShutdownExitCode when it's interrupted
+func (s *FixtureSuite) ExitCode(code int) int {
+	return inttest.GlobalShutdown.ExitCode(code)
+}
+
+// stopServices is a function to stop services of the suite and export its spans and metrics
+func (s *FixtureSuite) stopServices() {
+	if s.chaos != nil {
+		fmt.Printf("chaos injected faults %+v\n", s.chaos.Stats())
+	}
+	if s.nodeLogTailer != nil {
+		s.nodeLogTailer.Stop()
+	}
+	if err := inttest.FlushTraces(context.Background()); err != nil {
+		fmt.Println("error exporting spans", err)
+	}
+	if len(suiteOpts.metricsFile) > 0 {
+		if err := inttest.WriteMetricsFile(suiteOpts.metricsFile); err != nil {
+			fmt.Println("error writing metrics file", err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package fixturetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+var (
+	scenarioSpanMux sync.RWMutex
+	scenarioSpans   = map[string]*inttest.Span{}
+)
+
+// StartScenarioSpan is a function to start span of scenario file which is parent of spans of its steps
+// The span ends when t and its steps finish, it's nil when tracing is disabled.
+func StartScenarioSpan(file string, t *testing.T) *inttest.Span {
+	_, span := inttest.StartSpan(context.Background(), "scenario "+filepath.Base(file), inttest.SpanKindInternal)
+	if span == nil {
+		return nil
+	}
+	span.SetAttribute("scenario.file", file)
+	inttest.BindTestSpan(t, span)
+	scenarioSpanMux.Lock()
+	scenarioSpans[file] = span
+	scenarioSpanMux.Unlock()
+	t.Cleanup(func() {
+		var err error
+		if t.Failed() {
+			err = errors.New("scenario failed")
+		}
+		span.End(err)
+	})
+	return span
+}
+
+// StartStepSpan is a function to start span of fixture step as child of its scenario span
+// Chain calls done with t are traced as children of the step span.
+func StartStepSpan(file string, step FixtureStep, t *testing.T) *inttest.Span {
+	scenarioSpanMux.RLock()
+	parent := scenarioSpans[file]
+	scenarioSpanMux.RUnlock()
+	_, span := inttest.StartSpan(inttest.ContextWithSpan(context.Background(), parent), "step "+step.ID, inttest.SpanKindInternal)
+	if span == nil {
+		return nil
+	}
+	span.SetAttribute("scenario.file", file)
+	span.SetAttribute("step.id", step.ID)
+	span.SetAttribute("step.action", step.Action)
+	inttest.BindTestSpan(t, span)
+	return span
+}
+
+// EndStepSpan is a function to end span of fixture step with its result state
+func EndStepSpan(span *inttest.Span, state StepState) {
+	span.SetAttribute("step.state", string(state))
+	var err error
+	if state == StepFailed {
+		err = fmt.Errorf("step %s", state)
+	}
+	span.End(err)
+}
@@ -0,0 +1,75 @@
+package fixturetest
+
+import (
+	"encoding/json"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// createTradeShapeActions is the trade shape each create trade action of a shape should create
+var createTradeShapeActions = map[string]inttest.TradeShape{
+	"create_coins_for_coins_trade": inttest.TradeShapeCoinsForCoins,
+	"create_coins_for_items_trade": inttest.TradeShapeCoinsForItems,
+	"create_items_for_coins_trade": inttest.TradeShapeItemsForCoins,
+	"create_items_for_items_trade": inttest.TradeShapeItemsForItems,
+	"create_mixed_trade":           inttest.TradeShapeMixed,
+}
+
+// CreateTradeMsgOfShapeFromRef is a function to collect create trade msg from reference and check its shape
+func CreateTradeMsgOfShapeFromRef(ref string, shape inttest.TradeShape, t *testing.T) types.MsgCreateTrade {
+	msg := CreateTradeMsgFromRef(ref, t)
+	actual, err := inttest.GetTradeShape(inttest.TradeOfCreateMsg(msg))
+	t.WithFields(testing.Fields{
+		"ref":      ref,
+		"expected": shape,
+		"actual":   actual,
+	}).MustTrue(err == nil && actual == shape, "trade should be of the shape of action")
+	return msg
+}
+
+// RunCreateTradeOfShape is a function to get action runner creating trade which should be of shape
+func RunCreateTradeOfShape(shape inttest.TradeShape) ActFunc {
+	return func(step FixtureStep, t *testing.T) {
+		runCreateTrade(step, func(ref string, t *testing.T) types.MsgCreateTrade {
+			return CreateTradeMsgOfShapeFromRef(ref, shape, t)
+		}, t)
+	}
+}
+
+// AutoFulfillTradeMsgFromRef is a function to collect fulfill trade msg from reference
+// Items are picked from sender's inventory to satisfy item inputs of the trade, so params only have TradeInfo and Sender.
+func AutoFulfillTradeMsgFromRef(ref string, t *testing.T) types.MsgFulfillTrade {
+	byteValue := ReadFile(ref, t)
+	// translate sender from account name to account address
+	newByteValue := UpdateSenderKeyToAddress(byteValue, t)
+	// translate extra info to trade id
+	newByteValue = UpdateTradeExtraInfoToID(newByteValue, t)
+
+	var trdType struct {
+		TradeID string
+		Sender  sdk.AccAddress
+	}
+	err := json.Unmarshal(newByteValue, &trdType)
+	t.WithFields(testing.Fields{
+		"new_bytes": string(newByteValue),
+	}).MustNil(err, "error reading using GetJSONMarshaler")
+
+	trade, err := inttest.GetTradeByGUID(trdType.TradeID, inttest.WithEnv(FixtureEnv()))
+	t.WithFields(testing.Fields{
+		"trade_id": trdType.TradeID,
+	}).MustNil(err, "error getting trade to fulfill")
+	msg, err := inttest.AutoFulfillTradeMsg(trdType.Sender.String(), trade, inttest.WithEnv(FixtureEnv()))
+	t.WithFields(testing.Fields{
+		"trade_id": trdType.TradeID,
+		"sender":   trdType.Sender.String(),
+	}).MustNil(err, "error picking items of sender for trade")
+	return msg
+}
+
+// RunAutoFulfillTrade is a function to fulfill trade with items picked from sender's inventory
+func RunAutoFulfillTrade(step FixtureStep, t *testing.T) {
+	runFulfillTrade(step, AutoFulfillTradeMsgFromRef, t)
+}
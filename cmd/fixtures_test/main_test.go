@@ -0,0 +1,80 @@
+package fixturetest
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	evtesting "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	fixturetestSDK "github.com/Pylons-tech/pylons_sdk/cmd/fixture_utils"
+)
+
+var matrixFile = ""
+var matrixParallel = false
+var matrixDir = ""
+var matrixReport = ""
+
+func init() {
+	flag.StringVar(&matrixFile, "matrix", "", "json file of targets e.g. [{\"name\": \"v2-devnet\", \"args\": [\"-chain-profile=devnet\"]}] to run the suite against each of them")
+	flag.BoolVar(&matrixParallel, "matrix-parallel", false, "run matrix targets at once instead of one by one")
+	flag.StringVar(&matrixDir, "matrix-dir", "matrix", "directory to write result json file and log of each matrix target")
+	flag.StringVar(&matrixReport, "matrix-report", "", "file to write combined matrix report, .md files get markdown and json otherwise, default matrix.md of matrix-dir")
+}
+
+// runMatrix is a function to run the test binary again against each matrix target and write combined report
+func runMatrix() int {
+	targets, err := evtesting.LoadMatrixTargets(matrixFile)
+	if err != nil {
+		fmt.Println("error reading matrix targets", err)
+		return 1
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		fmt.Println("error getting test binary", err)
+		return 1
+	}
+	args := evtesting.StripFlagArgs(os.Args[1:], "matrix", "matrix-parallel", "matrix-dir", "matrix-report", "result-json-file", "report-file")
+	report, err := evtesting.RunMatrix(context.Background(), executable, args, evtesting.MatrixOptions{
+		Targets:  targets,
+		Parallel: matrixParallel,
+		Dir:      matrixDir,
+		Output:   os.Stdout,
+	})
+	if err != nil {
+		fmt.Println("error running matrix", err)
+		return 1
+	}
+	if err := report.WriteMarkdown(os.Stdout); err != nil {
+		fmt.Println("error writing matrix report", err)
+	}
+	if len(matrixReport) == 0 {
+		matrixReport = filepath.Join(matrixDir, "matrix.md")
+	}
+	if err := report.WriteFile(matrixReport); err != nil {
+		fmt.Println("error writing matrix report file", err)
+	}
+	if report.Failed() {
+		return 1
+	}
+	return 0
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if len(matrixFile) > 0 {
+		os.Exit(runMatrix())
+	}
+	// suite flags e.g. -report-file and -verbosity are registered by fixture_utils and shared with cmd/fixturetest
+	suite, err := fixturetestSDK.StartSuite()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	// report, checkpoint and metrics are flushed here or by SIGINT and SIGTERM handler of the suite
+	suite.Finish()
+	os.Exit(suite.ExitCode(code))
+}
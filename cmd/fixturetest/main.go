@@ -0,0 +1,320 @@
+// fixturetest runs fixture scenarios without go test wrappers, and validates, records, lists and reports them and dumps objects
+// Flags of fixture tests (-scenarios, -accounts, -report-file, -chain-profile, ...) are accepted by all commands.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	evtesting "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	fixturetest "github.com/Pylons-tech/pylons_sdk/cmd/fixture_utils"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+// searchPageSize is the number of transactions fetched per page while recording
+const searchPageSize = 100
+
+// command is a struct to describe a subcommand
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) int
+}
+
+var commands = []command{
+	{"run", "run scenarios of -fixtures-dir against the chain, -test.v prints logs of passing steps", runCommand},
+	{"validate", "validate fixture files of selected scenarios without running them", validateCommand},
+	{"record", "record transactions sent by -senders into scenario and params files of -fixtures-dir", recordCommand},
+	{"report", "render report file of -output from result json file of -result-json-file, usage: report trends [flags] renders trends of -run-history-dir", reportCommand},
+	{"list-scenarios", "list selected scenarios with their tags and number of steps", listScenariosCommand},
+	{"dump", "print raw json and annotated view of object, usage: dump [flags] <cookbook|recipe|item|trade|execution> <id>", dumpCommand},
+}
+
+// usage is a function to print commands and flags
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: fixturetest <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintln(os.Stderr, "\nflags:")
+	flag.PrintDefaults()
+}
+
+// parseFlags is a function to parse args of a command by flag.CommandLine with extra flags of the command
+func parseFlags(args []string, extra func(fs *flag.FlagSet)) error {
+	if extra != nil {
+		extra(flag.CommandLine)
+	}
+	return flag.CommandLine.Parse(args)
+}
+
+// matchString is a function to match test names by -test.run patterns
+func matchString(pat, str string) (bool, error) {
+	return regexp.MatchString(pat, str)
+}
+
+func runCommand(args []string) int {
+	// testing flags e.g. -test.v and -test.run are registered before parsing so that they can be passed
+	testing.Init()
+	if err := parseFlags(args, nil); err != nil {
+		return 2
+	}
+	suite, err := fixturetest.StartSuite()
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	// test name is the same as go test entry point so that results of both can be compared e.g. by matrix reports
+	testing.Main(matchString, []testing.InternalTest{{
+		Name: "TestFixturesViaCLI",
+		F: func(t *testing.T) {
+			// cleanup runs after parallel scenarios finish, testing.Main exits without returning
+			t.Cleanup(suite.Finish)
+			if err := fixturetest.ApplyRunnerFlags(t); err != nil {
+				t.Fatal(err)
+			}
+			fixturetest.RegisterDefaultActionRunners()
+			fixturetest.RunTestScenarios("scenarios", fixturetest.RunnerScenarioNames(), t)
+		},
+	}}, nil, nil)
+	return 0
+}
+
+func validateCommand(args []string) int {
+	if err := parseFlags(args, nil); err != nil {
+		return 2
+	}
+	files, err := fixturetest.SelectedScenarioFiles()
+	if err != nil {
+		fmt.Println("error selecting scenarios", err)
+		return 1
+	}
+	fixturetest.RegisterDefaultActionRunners()
+	numErrors := 0
+	for _, file := range files {
+		for _, verr := range fixturetest.ValidateFixtureFile(file) {
+			fmt.Println(verr.Error())
+			numErrors++
+		}
+	}
+	if numErrors > 0 {
+		fmt.Printf("%d errors in %d scenario files\n", numErrors, len(files))
+		return 1
+	}
+	fmt.Printf("%d scenario files are valid\n", len(files))
+	return 0
+}
+
+func recordCommand(args []string) int {
+	name := ""
+	senders := ""
+	fromHeight := int64(0)
+	err := parseFlags(args, func(fs *flag.FlagSet) {
+		fs.StringVar(&name, "name", "", "name of recorded scenario file and params directories")
+		fs.StringVar(&senders, "senders", "", "comma separated addresses or aliases of address-book whose transactions are recorded")
+		fs.Int64Var(&fromHeight, "from-height", 0, "record only transactions committed at this height or later")
+	})
+	if err != nil {
+		return 2
+	}
+	if len(name) == 0 || len(senders) == 0 {
+		fmt.Println("-name and -senders should be set to record")
+		return 2
+	}
+	if err := inttest.ApplyChainProfile(); err != nil {
+		fmt.Println("error applying chain profile", err)
+		return 1
+	}
+	recorder := fixturetest.NewRecorder(name)
+	txs := []inttest.HistoryTx{}
+	seen := map[string]bool{}
+	for _, sender := range strings.Split(senders, ",") {
+		addr, err := inttest.GlobalAddressBook.Resolve(sender)
+		if err != nil {
+			fmt.Println("error resolving sender", err)
+			return 1
+		}
+		if alias, ok := inttest.GlobalAddressBook.Alias(addr); ok {
+			recorder.AddAccount(alias, addr)
+		}
+		for page := 1; ; page++ {
+			pageTxs, total, err := inttest.SearchTxsBySender(addr, page, searchPageSize)
+			if err != nil {
+				fmt.Println("error searching transactions", err)
+				return 1
+			}
+			for _, tx := range pageTxs {
+				if tx.Height >= fromHeight && !seen[tx.TxHash] {
+					seen[tx.TxHash] = true
+					txs = append(txs, tx)
+				}
+			}
+			if len(pageTxs) == 0 || page*searchPageSize >= total {
+				break
+			}
+		}
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		if txs[i].Height != txs[j].Height {
+			return txs[i].Height < txs[j].Height
+		}
+		return txs[i].Index < txs[j].Index
+	})
+	recorder.RecordHistory(txs)
+	scenarioFile, err := recorder.Save(flag.Lookup("fixtures-dir").Value.String())
+	if err != nil {
+		fmt.Println("error saving recorded scenario", err)
+		return 1
+	}
+	fmt.Printf("recorded %d steps of %d transactions into %s\n", len(recorder.Steps()), len(txs), scenarioFile)
+	return 0
+}
+
+func reportCommand(args []string) int {
+	if len(args) > 0 && args[0] == "trends" {
+		return reportTrendsCommand(args[1:])
+	}
+	output := ""
+	err := parseFlags(args, func(fs *flag.FlagSet) {
+		fs.StringVar(&output, "output", "", "report file to write, .html and .md files get rich report and json otherwise")
+	})
+	if err != nil {
+		return 2
+	}
+	resultFile := flag.Lookup("result-json-file").Value.String()
+	if len(resultFile) == 0 || len(output) == 0 {
+		fmt.Println("-result-json-file and -output should be set to render report")
+		return 2
+	}
+	results, err := evtesting.ReadResultFile(resultFile)
+	if err != nil {
+		fmt.Println("error reading result json file", err)
+		return 1
+	}
+	for _, result := range results {
+		evtesting.GlobalReporter.AddResult(result)
+	}
+	explorerTxURL := flag.Lookup("explorer-tx-url").Value.String()
+	evtesting.ReportOpts.ExplorerTxURL = explorerTxURL
+	if err := evtesting.GlobalReporter.WriteReportFile(output); err != nil {
+		fmt.Println("error writing report file", err)
+		return 1
+	}
+	if err := evtesting.GlobalReporter.WriteText(os.Stdout); err != nil {
+		fmt.Println("error writing summary", err)
+	}
+	if evtesting.GlobalReporter.Summary().Failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+func reportTrendsCommand(args []string) int {
+	output := ""
+	runs := 0
+	err := parseFlags(args, func(fs *flag.FlagSet) {
+		fs.StringVar(&output, "output", "", "trend report file to write, .html and .md files get rendered report and json otherwise")
+		fs.IntVar(&runs, "runs", 10, "number of the last runs of run history to report, 0 for all runs")
+	})
+	if err != nil {
+		return 2
+	}
+	historyDir := flag.Lookup("run-history-dir").Value.String()
+	if len(historyDir) == 0 || len(output) == 0 {
+		fmt.Println("-run-history-dir and -output should be set to render trend report")
+		return 2
+	}
+	report, err := evtesting.ReadRunHistory(historyDir, runs)
+	if err != nil {
+		fmt.Println("error reading run history", err)
+		return 1
+	}
+	if len(report.Runs) == 0 {
+		fmt.Println("no runs in run history", historyDir)
+		return 1
+	}
+	if err := report.WriteFile(output); err != nil {
+		fmt.Println("error writing trend report", err)
+		return 1
+	}
+	fmt.Printf("trends of %d runs are written into %s\n", len(report.Runs), output)
+	return 0
+}
+
+func listScenariosCommand(args []string) int {
+	if err := parseFlags(args, nil); err != nil {
+		return 2
+	}
+	files, err := fixturetest.SelectedScenarioFiles()
+	if err != nil {
+		fmt.Println("error selecting scenarios", err)
+		return 1
+	}
+	for _, file := range files {
+		bz, err := ioutil.ReadFile(fixturetest.FixturePath(file))
+		if err != nil {
+			fmt.Println("error reading scenario", err)
+			return 1
+		}
+		scenario := fixturetest.FixtureScenario{}
+		if err := scenario.UnmarshalJSON(bz); err != nil {
+			fmt.Printf("%s\tinvalid: %s\n", file, err.Error())
+			continue
+		}
+		// steps of included fragments are counted
+		rawSteps, err := fixturetest.ExpandFixtureIncludes(file, bz)
+		if err != nil {
+			fmt.Printf("%s\tinvalid: %s\n", file, err.Error())
+			continue
+		}
+		fmt.Printf("%s\ttags=%s\tsteps=%d\n", file, strings.Join(scenario.Tags, ","), len(rawSteps))
+	}
+	return 0
+}
+
+func dumpCommand(args []string) int {
+	if err := parseFlags(args, nil); err != nil {
+		return 2
+	}
+	if flag.NArg() != 2 {
+		fmt.Println("object type and id should be given to dump")
+		return 2
+	}
+	objectType, err := inttest.ParseDumpObjectType(flag.Arg(0))
+	if err != nil {
+		fmt.Println(err)
+		return 2
+	}
+	if err := inttest.ApplyChainProfile(); err != nil {
+		fmt.Println("error applying chain profile", err)
+		return 1
+	}
+	if err := inttest.Dump(objectType, flag.Arg(1), os.Stdout); err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	return 0
+}
+
+func main() {
+	flag.Usage = usage
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			os.Exit(cmd.run(os.Args[2:]))
+		}
+	}
+	fmt.Fprintf(os.Stderr, "unknown command %s\n\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
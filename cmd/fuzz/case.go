@@ -0,0 +1,77 @@
+package fuzz
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Expectation is a type to describe how a generated msg should be handled
+type Expectation int
+
+const (
+	// ExpectAccepted is an expectation of structurally valid msg which node should accept
+	ExpectAccepted Expectation = iota
+	// ExpectLocalReject is an expectation of msg which should be rejected by ValidateBasic before broadcast
+	ExpectLocalReject
+	// ExpectNodeReject is an expectation of msg which passes ValidateBasic but node should reject
+	ExpectNodeReject
+)
+
+func (e Expectation) String() string {
+	switch e {
+	case ExpectAccepted:
+		return "accepted"
+	case ExpectLocalReject:
+		return "local_reject"
+	case ExpectNodeReject:
+		return "node_reject"
+	}
+	return fmt.Sprintf("expectation(%d)", int(e))
+}
+
+// Case is a struct to describe a generated msg and how it should be handled
+type Case struct {
+	Name   string
+	Msg    sdk.Msg
+	Expect Expectation
+}
+
+// Verify is a function to check result of sending the case, it returns error when the expectation is broken
+func (c Case) Verify(err error) error {
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "panic") {
+		return fmt.Errorf("%s: node panicked handling msg: %w", c.Name, err)
+	}
+	if errors.Is(err, inttest.ErrNodeUnavailable) {
+		return fmt.Errorf("%s: node became unavailable: %w", c.Name, err)
+	}
+	if c.Expect == ExpectAccepted {
+		if err != nil {
+			return fmt.Errorf("%s: valid msg was rejected: %w", c.Name, err)
+		}
+		return nil
+	}
+	if err == nil {
+		return fmt.Errorf("%s: invalid msg was accepted, expected %s", c.Name, c.Expect)
+	}
+	return nil
+}
+
+// VerifyLocal is a function to check ValidateBasic result of the case
+func (c Case) VerifyLocal() error {
+	err := c.Msg.ValidateBasic()
+	switch c.Expect {
+	case ExpectLocalReject:
+		if err == nil {
+			return fmt.Errorf("%s: msg passed basic validation", c.Name)
+		}
+	default:
+		if err != nil {
+			return fmt.Errorf("%s: msg does not pass basic validation: %w", c.Name, err)
+		}
+	}
+	return nil
+}
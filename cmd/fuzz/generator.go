@@ -0,0 +1,172 @@
+package fuzz
+
+import (
+	"fmt"
+
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/config"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Generator is a struct to generate randomized but structurally valid pylons msgs reproducible from a seed
+type Generator struct {
+	*inttest.TestDataGenerator
+}
+
+// NewGenerator is a function to create msg generator with a fixed seed
+func NewGenerator(seed int64) *Generator {
+	return &Generator{inttest.NewTestDataGeneratorWithSeed(seed)}
+}
+
+// NewGeneratorFromData is a function to create msg generator from test data generator e.g. seeded by test name
+func NewGeneratorFromData(data *inttest.TestDataGenerator) *Generator {
+	return &Generator{data}
+}
+
+func (g *Generator) bool() bool {
+	return g.Int64(0, 1) == 1
+}
+
+// countProgram is a function to generate a program returning positive int
+func (g *Generator) countProgram() string {
+	switch g.Int64(0, 2) {
+	case 0:
+		return fmt.Sprintf("%d", g.Int64(1, 10))
+	case 1:
+		return fmt.Sprintf("rand_int(%d) + 1", g.Int64(1, 10))
+	}
+	return fmt.Sprintf("max_int(%d, rand_int(%d))", g.Int64(1, 5), g.Int64(1, 10))
+}
+
+// itemOutput is a function to generate item output entry with random attributes
+func (g *Generator) itemOutput(id string) types.ItemOutput {
+	output := types.ItemOutput{ID: id}
+	lower := g.Int64(0, 50)
+	output.Longs = []types.LongParam{{
+		Key:          "level",
+		Rate:         sdk.NewDec(1),
+		WeightRanges: []types.IntWeightRange{{Lower: lower, Upper: lower + g.Int64(0, 50), Weight: 1}},
+	}}
+	if g.bool() {
+		output.Longs = append(output.Longs, types.LongParam{
+			Key:     "power",
+			Rate:    sdk.NewDec(1),
+			Program: g.countProgram(),
+		})
+	}
+	if g.bool() {
+		dLower := sdk.NewDec(g.Int64(0, 50))
+		output.Doubles = []types.DoubleParam{{
+			Key:          "attack",
+			Rate:         sdk.NewDec(1),
+			WeightRanges: []types.DoubleWeightRange{{Lower: dLower, Upper: dLower.Add(sdk.NewDec(g.Int64(1, 50))), Weight: 1}},
+		}}
+	}
+	output.Strings = []types.StringParam{{Key: "Name", Rate: sdk.NewDec(1), Value: g.Name("fuzz_item")}}
+	return output
+}
+
+// Recipe is a function to generate valid create recipe msg, recipes without item inputs are executable without items
+func (g *Generator) Recipe(sender, cookbookID string, withItemInputs bool) types.MsgCreateRecipe {
+	itemInputs := types.ItemInputList{}
+	if withItemInputs {
+		count := g.Int64(1, 2)
+		for idx := int64(0); idx < count; idx++ {
+			minLevel := g.Int64(0, 10)
+			itemInputs = append(itemInputs, types.ItemInput{
+				ID:    fmt.Sprintf("fuzz_input_%d", idx),
+				Longs: []types.LongInputParam{{Key: "level", MinValue: minLevel, MaxValue: minLevel + g.Int64(0, 100)}},
+			})
+		}
+	}
+
+	entries := types.EntriesList{}
+	entryIDs := []string{}
+	coinCount := g.Int64(1, 3)
+	for idx := int64(0); idx < coinCount; idx++ {
+		id := fmt.Sprintf("coin_%d", idx)
+		entries.CoinOutputs = append(entries.CoinOutputs, types.CoinOutput{
+			ID:    id,
+			Coin:  fmt.Sprintf("fuzzcoin%s", g.String(4)),
+			Count: g.countProgram(),
+		})
+		entryIDs = append(entryIDs, id)
+	}
+	itemCount := g.Int64(0, 2)
+	for idx := int64(0); idx < itemCount; idx++ {
+		id := fmt.Sprintf("item_%d", idx)
+		entries.ItemOutputs = append(entries.ItemOutputs, g.itemOutput(id))
+		entryIDs = append(entryIDs, id)
+	}
+	for idx, ii := range itemInputs {
+		id := fmt.Sprintf("modify_%d", idx)
+		program := fmt.Sprintf("%s.level + 1", ii.ID)
+		entries.ItemModifyOutputs = append(entries.ItemModifyOutputs, types.ItemModifyOutput{
+			ID:           id,
+			ItemInputRef: ii.ID,
+			Longs:        []types.LongParam{{Key: "level", Rate: sdk.NewDec(1), Program: program}},
+		})
+		entryIDs = append(entryIDs, id)
+	}
+
+	outputs := types.WeightedOutputsList{}
+	outputCount := g.Int64(1, 3)
+	for idx := int64(0); idx < outputCount; idx++ {
+		// pick a non empty subset of entries without double use
+		picked := []string{}
+		for _, id := range entryIDs {
+			if g.bool() {
+				picked = append(picked, id)
+			}
+		}
+		if len(picked) == 0 {
+			picked = append(picked, entryIDs[g.Int64(0, int64(len(entryIDs)-1))])
+		}
+		weight := fmt.Sprintf("%d", g.Int64(1, 10))
+		if len(itemInputs) > 0 && g.bool() {
+			weight = "max_int(level, 1)"
+		}
+		outputs = append(outputs, types.WeightedOutputs{EntryIDs: picked, Weight: weight})
+	}
+
+	return types.NewMsgCreateRecipe(
+		g.Name("fuzz_recipe"),
+		cookbookID,
+		g.Name("fuzz_recipe_id"),
+		fmt.Sprintf("recipe generated by fuzz harness %s", g.String(8)),
+		types.CoinInputList{},
+		itemInputs,
+		entries,
+		outputs,
+		0,
+		sender,
+	)
+}
+
+// Trade is a function to generate valid create trade msg, sender should have pylons to pay trade outputs
+func (g *Generator) Trade(sender, cookbookID string) types.MsgCreateTrade {
+	itemInputs := types.TradeItemInputList{}
+	if g.bool() {
+		itemInputs = append(itemInputs, types.TradeItemInput{
+			ItemInput: types.ItemInput{
+				ID:    "fuzz_trade_input",
+				Longs: []types.LongInputParam{{Key: "level", MinValue: 0, MaxValue: g.Int64(1, 100)}},
+			},
+			CookbookID: cookbookID,
+		})
+	}
+	return types.NewMsgCreateTrade(
+		types.CoinInputList{{Coin: types.Pylon, Count: g.Int64(config.Config.Fee.MinTradePrice, 1000)}},
+		itemInputs,
+		sdk.Coins{sdk.NewInt64Coin(types.Pylon, g.Int64(1, 10))},
+		types.ItemList{},
+		g.Name("fuzz_trade"),
+		sender,
+	)
+}
+
+// ExecuteRecipe is a function to generate valid execute recipe msg
+func (g *Generator) ExecuteRecipe(sender, recipeID string, itemIDs []string) types.MsgExecuteRecipe {
+	return types.NewMsgExecuteRecipe(recipeID, sender, itemIDs)
+}
@@ -0,0 +1,52 @@
+package fuzz
+
+import (
+	"errors"
+	"reflect"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+const fuzzSender = "cosmos105wr8t6y97rwv90xzhxd4juj4lsajtjaass6h7"
+
+func TestGeneratedCasesValidateBasic(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	for seed := int64(1); seed <= 50; seed++ {
+		g := NewGenerator(seed)
+		cases := g.RecipeCases(fuzzSender, "fuzz_cookbook", seed%2 == 0)
+		cases = append(cases, g.TradeCases(fuzzSender, "fuzz_cookbook")...)
+		cases = append(cases, g.ExecuteCases(fuzzSender, "fuzz_recipe", []string{})...)
+		for _, c := range cases {
+			t.WithFields(testing.Fields{
+				"seed":   seed,
+				"case":   c.Name,
+				"expect": c.Expect.String(),
+			}).MustNil(c.VerifyLocal(), "generated msg should match its expectation on basic validation")
+		}
+	}
+
+	recipe1 := NewGenerator(7).Recipe(fuzzSender, "fuzz_cookbook", true)
+	recipe2 := NewGenerator(7).Recipe(fuzzSender, "fuzz_cookbook", true)
+	t.MustTrue(reflect.DeepEqual(recipe1, recipe2), "generated msg should be reproducible from seed")
+}
+
+func TestCaseVerify(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	msg := types.NewMsgExecuteRecipe("fuzz_recipe", fuzzSender, []string{})
+	valid := Case{Name: "valid", Msg: &msg, Expect: ExpectAccepted}
+	invalid := Case{Name: "invalid", Msg: &msg, Expect: ExpectNodeReject}
+	rejection := inttest.NewTxError("pylons", 18, "recipe does not exist")
+
+	t.MustNil(valid.Verify(nil), "accepted valid msg should pass")
+	t.MustTrue(valid.Verify(rejection) != nil, "rejected valid msg should fail")
+	t.MustNil(invalid.Verify(rejection), "rejected invalid msg should pass")
+	t.MustTrue(invalid.Verify(nil) != nil, "accepted invalid msg should fail")
+	t.MustTrue(invalid.Verify(inttest.NewTxError("", 111222, "panic: runtime error: index out of range")) != nil, "panic should fail even if msg is rejected")
+	t.MustTrue(invalid.Verify(inttest.NewTxError("", 0, "connection refused")) != nil, "unavailable node should fail")
+	t.MustTrue(errors.Is(inttest.NewTxError("", 0, "connection refused"), inttest.ErrNodeUnavailable), "connection refused should be classified as node unavailable")
+}
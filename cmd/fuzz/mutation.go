@@ -0,0 +1,159 @@
+package fuzz
+
+import (
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RecipeMutation is a struct to describe an adversarial change to a valid create recipe msg
+type RecipeMutation struct {
+	Name   string
+	Expect Expectation
+	Apply  func(g *Generator, msg *types.MsgCreateRecipe)
+}
+
+// TradeMutation is a struct to describe an adversarial change to a valid create trade msg
+type TradeMutation struct {
+	Name   string
+	Expect Expectation
+	Apply  func(g *Generator, msg *types.MsgCreateTrade)
+}
+
+// ExecuteMutation is a struct to describe an adversarial change to a valid execute recipe msg
+type ExecuteMutation struct {
+	Name   string
+	Expect Expectation
+	Apply  func(g *Generator, msg *types.MsgExecuteRecipe)
+}
+
+// RecipeMutations is a list of near valid create recipe msgs
+var RecipeMutations = []RecipeMutation{
+	{"empty_sender", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateRecipe) {
+		msg.Sender = ""
+	}},
+	{"short_description", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateRecipe) {
+		msg.Description = g.String(8)
+	}},
+	{"invalid_entry_id", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateRecipe) {
+		msg.Entries.CoinOutputs[0].ID = "0-" + g.String(4)
+	}},
+	{"duplicate_entry_id", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateRecipe) {
+		msg.Entries.CoinOutputs = append(msg.Entries.CoinOutputs, msg.Entries.CoinOutputs[0])
+	}},
+	{"unknown_output_entry", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateRecipe) {
+		msg.Outputs[0].EntryIDs = append(msg.Outputs[0].EntryIDs, g.Name("unknown_entry"))
+	}},
+	{"double_use_of_entry", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateRecipe) {
+		msg.Outputs[0].EntryIDs = append(msg.Outputs[0].EntryIDs, msg.Outputs[0].EntryIDs[0])
+	}},
+	{"pylon_coin_output", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateRecipe) {
+		msg.Entries.CoinOutputs[0].Coin = types.Pylon
+	}},
+	{"invalid_program", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateRecipe) {
+		msg.Entries.CoinOutputs[0].Count = "rand_int(" + g.String(2)
+	}},
+	{"program_type_mismatch", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateRecipe) {
+		msg.Outputs[0].Weight = "1.5 * 2.0"
+	}},
+	{"duplicate_item_input_id", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateRecipe) {
+		ii := types.ItemInput{ID: "fuzz_duplicate_input"}
+		msg.ItemInputs = append(msg.ItemInputs, ii, ii)
+	}},
+	{"invalid_item_input_ref", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateRecipe) {
+		msg.Entries.ItemModifyOutputs = append(msg.Entries.ItemModifyOutputs, types.ItemModifyOutput{
+			ID:           "modify_unknown",
+			ItemInputRef: g.Name("unknown_input"),
+		})
+	}},
+	{"unknown_cookbook", ExpectNodeReject, func(g *Generator, msg *types.MsgCreateRecipe) {
+		msg.CookbookID = g.CookbookID()
+	}},
+}
+
+// TradeMutations is a list of near valid create trade msgs
+var TradeMutations = []TradeMutation{
+	{"empty_sender", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateTrade) {
+		msg.Sender = ""
+	}},
+	{"zero_coin_output", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateTrade) {
+		msg.CoinOutputs = sdk.Coins{sdk.NewInt64Coin(types.Pylon, 0)}
+	}},
+	{"zero_coin_input", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateTrade) {
+		msg.CoinInputs = append(msg.CoinInputs, types.CoinInput{Coin: "fuzzcoin", Count: 0})
+	}},
+	{"empty_inputs", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateTrade) {
+		msg.CoinInputs = nil
+		msg.ItemInputs = nil
+	}},
+	{"below_min_trade_price", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateTrade) {
+		msg.CoinInputs = types.CoinInputList{{Coin: "fuzzcoin", Count: g.Int64(1, 100)}}
+		msg.CoinOutputs = sdk.Coins{sdk.NewInt64Coin("fuzzcoin", g.Int64(1, 100))}
+	}},
+	{"empty_cookbook_item_input", ExpectLocalReject, func(g *Generator, msg *types.MsgCreateTrade) {
+		msg.ItemInputs = append(msg.ItemInputs, types.TradeItemInput{ItemInput: types.ItemInput{ID: "fuzz_no_cookbook"}})
+	}},
+	{"unowned_coin_output", ExpectNodeReject, func(g *Generator, msg *types.MsgCreateTrade) {
+		msg.CoinOutputs = sdk.Coins{sdk.NewInt64Coin(types.Pylon, g.Int64(1e15, 1e16))}
+	}},
+	{"unowned_item_output", ExpectNodeReject, func(g *Generator, msg *types.MsgCreateTrade) {
+		msg.ItemOutputs = types.ItemList{{
+			ID:         g.Name("fuzz_unowned_item"),
+			CookbookID: g.CookbookID(),
+			Sender:     msg.Sender,
+		}}
+	}},
+}
+
+// ExecuteMutations is a list of near valid execute recipe msgs
+var ExecuteMutations = []ExecuteMutation{
+	{"empty_sender", ExpectLocalReject, func(g *Generator, msg *types.MsgExecuteRecipe) {
+		msg.Sender = ""
+	}},
+	{"unknown_recipe", ExpectNodeReject, func(g *Generator, msg *types.MsgExecuteRecipe) {
+		msg.RecipeID = g.Name("fuzz_unknown_recipe")
+	}},
+	{"unknown_item_ids", ExpectNodeReject, func(g *Generator, msg *types.MsgExecuteRecipe) {
+		msg.ItemIDs = append(msg.ItemIDs, g.Name("fuzz_unknown_item"))
+	}},
+	{"duplicate_item_ids", ExpectNodeReject, func(g *Generator, msg *types.MsgExecuteRecipe) {
+		itemID := g.Name("fuzz_unknown_item")
+		msg.ItemIDs = append(msg.ItemIDs, itemID, itemID)
+	}},
+}
+
+// RecipeCases is a function to generate a valid create recipe case and a case per mutation
+func (g *Generator) RecipeCases(sender, cookbookID string, withItemInputs bool) []Case {
+	valid := g.Recipe(sender, cookbookID, withItemInputs)
+	cases := []Case{{Name: "create_recipe/valid", Msg: &valid, Expect: ExpectAccepted}}
+	for _, mutation := range RecipeMutations {
+		msg := g.Recipe(sender, cookbookID, withItemInputs)
+		mutation.Apply(g, &msg)
+		cases = append(cases, Case{Name: "create_recipe/" + mutation.Name, Msg: &msg, Expect: mutation.Expect})
+	}
+	return cases
+}
+
+// TradeCases is a function to generate a valid create trade case and a case per mutation
+func (g *Generator) TradeCases(sender, cookbookID string) []Case {
+	valid := g.Trade(sender, cookbookID)
+	cases := []Case{{Name: "create_trade/valid", Msg: &valid, Expect: ExpectAccepted}}
+	for _, mutation := range TradeMutations {
+		msg := g.Trade(sender, cookbookID)
+		mutation.Apply(g, &msg)
+		cases = append(cases, Case{Name: "create_trade/" + mutation.Name, Msg: &msg, Expect: mutation.Expect})
+	}
+	return cases
+}
+
+// ExecuteCases is a function to generate a valid execute recipe case and a case per mutation
+// recipe should exist and be executable by sender with itemIDs
+func (g *Generator) ExecuteCases(sender, recipeID string, itemIDs []string) []Case {
+	valid := g.ExecuteRecipe(sender, recipeID, itemIDs)
+	cases := []Case{{Name: "execute_recipe/valid", Msg: &valid, Expect: ExpectAccepted}}
+	for _, mutation := range ExecuteMutations {
+		msg := g.ExecuteRecipe(sender, recipeID, append([]string{}, itemIDs...))
+		mutation.Apply(g, &msg)
+		cases = append(cases, Case{Name: "execute_recipe/" + mutation.Name, Msg: &msg, Expect: mutation.Expect})
+	}
+	return cases
+}
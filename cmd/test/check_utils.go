@@ -1,6 +1,7 @@
 package inttest
 
 import (
+	"context"
 	"strings"
 
 	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
@@ -34,7 +35,7 @@ func TxResBytesUnmarshalErrorCheck(txhash string, err error, txHandleResBytes []
 
 // GetTxHandleResult check error on tx by hash and return handle result
 func GetTxHandleResult(txhash string, t *testing.T) []byte {
-	txHandleResBytes, err := inttestSDK.WaitAndGetTxData(txhash, 3, t)
+	txHandleResBytes, err := inttestSDK.NewClient(inttestSDK.WithMaxWaitBlock(3)).WaitForTx(context.Background(), t, txhash)
 	t.WithFields(testing.Fields{
 		"txhash":          txhash,
 		"tx_result_bytes": string(txHandleResBytes),
@@ -74,7 +75,7 @@ func TxBroadcastErrorExpected(txhash string, err error, desiredError string, t *
 
 // WaitOneBlockWithErrorCheck wait for a block with error checking
 func WaitOneBlockWithErrorCheck(t *testing.T) {
-	err := inttestSDK.WaitForNextBlock()
+	err := inttestSDK.WaitForNextBlockCtx(context.Background())
 	t.MustNil(err, "error waiting for next block")
 }
 
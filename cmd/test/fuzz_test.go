@@ -0,0 +1,72 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+
+	"github.com/Pylons-tech/pylons_sdk/cmd/fuzz"
+	inttestSDK "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+func TestFuzzMsgsViaCLI(originT *originT.T) {
+	t := testing.NewT(originT)
+	t.Parallel()
+
+	t.Run("randomized and adversarial msgs", func(t *testing.T) {
+		key := fmt.Sprintf("TestFuzzMsgsViaCLI_%d", time.Now().Unix())
+		MockAccount(key, t)
+		cbID := MockCookbook(key, true, t)
+		sender := GetSDKAddressFromKey(key, t).String()
+		g := fuzz.NewGeneratorFromData(inttestSDK.NewTestDataGenerator(t))
+
+		for iteration := 0; iteration < fuzzIterations; iteration++ {
+			// recipes without item inputs are created on even iterations to be executed
+			executable := iteration%2 == 0
+			recipeCases := g.RecipeCases(sender, cbID, !executable)
+			recipeCreated := runFuzzCases(t, iteration, key, recipeCases)
+			runFuzzCases(t, iteration, key, g.TradeCases(sender, cbID))
+			if executable && recipeCreated {
+				recipe := recipeCases[0].Msg.(*types.MsgCreateRecipe)
+				runFuzzCases(t, iteration, key, g.ExecuteCases(sender, recipe.RecipeID, []string{}))
+			}
+		}
+	})
+}
+
+// runFuzzCases is a function to send fuzz cases as sub tests, it returns true if the first case passed
+func runFuzzCases(t *testing.T, iteration int, key string, cases []fuzz.Case) bool {
+	client := inttestSDK.NewClient()
+	passed := make([]bool, len(cases))
+	for idx, c := range cases {
+		c := c
+		passed[idx] = t.Run(fmt.Sprintf("iteration_%d/%s", iteration, c.Name), func(t *testing.T) {
+			t.WithFields(testing.Fields{
+				"case":   c.Name,
+				"expect": c.Expect.String(),
+			}).MustNil(c.VerifyLocal(), "generated msg does not match its expectation on basic validation")
+
+			var err error
+			if c.Expect == fuzz.ExpectLocalReject {
+				// client validates msgs before broadcast, it should never reach the node
+				_, err = client.SendTx(context.Background(), t, inttestSDK.SignerKey(key), c.Msg)
+			} else {
+				_, err = client.SendTxAndWait(context.Background(), t, inttestSDK.SignerKey(key), c.Msg)
+			}
+			t.WithFields(testing.Fields{
+				"case":    c.Name,
+				"tx_msgs": inttestSDK.AminoCodecFormatter(c.Msg),
+			}).MustNil(c.Verify(err), "node did not handle fuzz msg as expected")
+
+			if c.Expect != fuzz.ExpectLocalReject {
+				// node should keep producing blocks after handling adversarial msgs
+				t.MustNil(inttestSDK.WaitForNextBlockCtx(context.Background()), "node should be alive after fuzz msg")
+			}
+		})
+	}
+	return len(passed) > 0 && passed[0]
+}
@@ -1,6 +1,7 @@
 package inttest
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	originT "testing"
@@ -85,10 +86,9 @@ func TestGoogleIAPGetPylonsViaCLI(originT *originT.T) {
 			receiptDataBase64 := base64.StdEncoding.EncodeToString([]byte(tc.receiptData))
 
 			msgGoogleIAPGetPylons := types.NewMsgGoogleIAPGetPylons(tc.productID, tc.purchaseToken, receiptDataBase64, tc.signature, getPylonsSdkAddr.String())
-			txhash, err := inttestSDK.TestTxWithMsgWithNonce(t,
+			txhash, err := inttestSDK.NewClient().SendTx(context.Background(), t,
+				inttestSDK.SignerKey(getPylonsKey),
 				&msgGoogleIAPGetPylons,
-				getPylonsKey,
-				false,
 			)
 			if err != nil {
 				TxBroadcastErrorExpected(txhash, err, tc.desiredError, t)
@@ -109,10 +109,9 @@ func TestGoogleIAPGetPylonsViaCLI(originT *originT.T) {
 			}
 
 			if tc.tryReuseOrderID {
-				hash, err := inttestSDK.TestTxWithMsgWithNonce(t,
+				hash, err := inttestSDK.NewClient().SendTx(context.Background(), t,
+					inttestSDK.SignerKey(getPylonsKey),
 					&msgGoogleIAPGetPylons,
-					getPylonsKey,
-					false,
 				)
 				t.MustNil(err, hash)
 				errdata, err := inttestSDK.WaitAndGetTxError(hash, inttestSDK.GetMaxWaitBlock(), t)
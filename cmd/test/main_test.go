@@ -0,0 +1,101 @@
+package inttest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+
+	evtesting "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttestSDK "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+)
+
+var reportFile = ""
+var verbosity = ""
+var metricsAddr = ""
+var metricsFile = ""
+var explorerTxURL = ""
+var artifactsDir = ""
+var failureSnapshotTxs = 5
+var nodeLogFile = ""
+var resultJSONFile = ""
+var resultWebhook = ""
+var resultWebhookFailuresOnly = false
+var fuzzIterations = 2
+
+func init() {
+	flag.StringVar(&verbosity, "verbosity", "debug", "amount of test logs, one of quiet, normal, debug or trace, quiet and normal print one-line test summaries")
+	flag.StringVar(&reportFile, "report-file", "", "file to write test result summary, .html and .md files get report with transactions and captured state")
+	flag.StringVar(&explorerTxURL, "explorer-tx-url", "", "explorer url of transaction page to link transactions of report e.g. https://explorer.example.com/txs/%s")
+	flag.StringVar(&artifactsDir, "artifacts-dir", "", "directory to write files attached by tests e.g. failed tx results, next to report file by default")
+	flag.IntVar(&failureSnapshotTxs, "failure-snapshot-txs", 5, "number of latest transactions of each involved account in chain context snapshot attached to failures, 0 disables the snapshot")
+	flag.StringVar(&metricsAddr, "metrics-addr", "", "address to serve prometheus metrics of test harness on /metrics")
+	flag.StringVar(&metricsFile, "metrics-file", "", "file to write prometheus metrics of test harness when tests finish")
+	flag.StringVar(&resultJSONFile, "result-json-file", "", "file to write a json line per test start, test end and suite end as tests run")
+	flag.StringVar(&resultWebhook, "result-webhook", "", "url to post json of finished tests and suite summary to e.g. a Slack incoming webhook")
+	flag.BoolVar(&resultWebhookFailuresOnly, "result-webhook-failures-only", false, "post only failed tests and suite summary to result-webhook")
+	flag.StringVar(&nodeLogFile, "node-log", "", "log file of locally bootstrapped node to attach its lines logged while a test ran to failures")
+	flag.IntVar(&fuzzIterations, "fuzz-iterations", 2, "number of randomized msg sets sent by fuzz test")
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if err := inttestSDK.ApplyChainProfile(); err != nil {
+		fmt.Println("error applying chain profile", err)
+		os.Exit(1)
+	}
+	v, err := evtesting.ParseVerbosity(verbosity)
+	if err != nil {
+		fmt.Println("error parsing verbosity", err)
+		os.Exit(1)
+	}
+	evtesting.SetVerbosity(v)
+	if v == evtesting.VerbosityQuiet || v == evtesting.VerbosityNormal {
+		evtesting.NewConsoleSink(os.Stdout).WatchTests()
+	}
+	fmt.Println("test data seed", inttestSDK.GetTestDataSeed())
+	if len(metricsAddr) > 0 {
+		inttestSDK.StartMetricsServer(metricsAddr)
+	}
+	chaos, err := inttestSDK.EnableChaos(inttestSDK.CLIOpts.Chaos)
+	if err != nil {
+		fmt.Println("error enabling chaos", err)
+		os.Exit(1)
+	}
+	if len(resultJSONFile) > 0 {
+		sink, err := evtesting.NewJSONFileResultSink(resultJSONFile)
+		if err != nil {
+			fmt.Println("error creating result json file", err)
+			os.Exit(1)
+		}
+		evtesting.AddResultSink(sink)
+	}
+	if len(resultWebhook) > 0 {
+		evtesting.AddResultSink(evtesting.NewWebhookResultSink(resultWebhook, resultWebhookFailuresOnly))
+	}
+	evtesting.ReportOpts.ExplorerTxURL = explorerTxURL
+	evtesting.ReportOpts.ArtifactsDir = artifactsDir
+	evtesting.ReportOpts.FailureSnapshotTxs = failureSnapshotTxs
+	evtesting.ReportOpts.DisableFailureSnapshot = failureSnapshotTxs == 0
+	var nodeLogTailer *inttestSDK.NodeLogTailer
+	if len(nodeLogFile) > 0 {
+		var err error
+		if nodeLogTailer, err = inttestSDK.StartNodeLogTailer(nodeLogFile); err != nil {
+			fmt.Println("error following node log", err)
+			os.Exit(1)
+		}
+	}
+	code := evtesting.RunWithReport(m, reportFile)
+	if chaos != nil {
+		fmt.Printf("chaos injected faults %+v\n", chaos.Stats())
+	}
+	if nodeLogTailer != nil {
+		nodeLogTailer.Stop()
+	}
+	if len(metricsFile) > 0 {
+		if err := inttestSDK.WriteMetricsFile(metricsFile); err != nil {
+			fmt.Println("error writing metrics file", err)
+		}
+	}
+	os.Exit(code)
+}
@@ -1,6 +1,7 @@
 package inttest
 
 import (
+	"context"
 	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
 
 	"github.com/Pylons-tech/pylons_sdk/x/pylons/handlers"
@@ -15,6 +16,11 @@ import (
 
 // MockAccount generate local key and do initial get-pylons to create cookbook
 func MockAccount(key string, t *testing.T) {
+	MockAccountWithMnemonic(key, t)
+}
+
+// MockAccountWithMnemonic generate local key, do initial get-pylons and returns mnemonic of the key
+func MockAccountWithMnemonic(key string, t *testing.T) string {
 	// add local key
 	localKeyResult, err := inttestSDK.AddNewLocalKey(key)
 	t.WithFields(testing.Fields{
@@ -40,7 +46,7 @@ func MockAccount(key string, t *testing.T) {
 	}).Info("started waiting for create account transaction")
 
 	// wait for txhash to be confirmed
-	txResponseBytes, err := inttestSDK.WaitAndGetTxData(caTxHash, inttestSDK.GetMaxWaitBlock(), t)
+	txResponseBytes, err := inttestSDK.NewClient().WaitForTx(context.Background(), t, caTxHash)
 	t.WithFields(testing.Fields{
 		"result": string(txResponseBytes),
 	}).MustNil(err, "error waiting for create account transaction")
@@ -50,15 +56,16 @@ func MockAccount(key string, t *testing.T) {
 	sdkAddr, err := sdk.AccAddressFromBech32(addr)
 	t.MustNil(err, "error converting string cosmos address to sdk struct")
 	getPylonsMsg := types.NewMsgGetPylons(types.PremiumTier.Fee, sdkAddr.String())
-	txhash, err := inttestSDK.TestTxWithMsgWithNonce(t, &getPylonsMsg, key, false)
+	txhash, err := inttestSDK.NewClient().SendTx(context.Background(), t, inttestSDK.SignerKey(key), &getPylonsMsg)
 	t.WithFields(testing.Fields{
 		"txhash": txhash,
 	}).MustNil(err, "error sending transaction")
 
-	txResponseBytes, err = inttestSDK.WaitAndGetTxData(txhash, inttestSDK.GetMaxWaitBlock(), t)
+	txResponseBytes, err = inttestSDK.NewClient().WaitForTx(context.Background(), t, txhash)
 	t.WithFields(testing.Fields{
 		"result": string(txResponseBytes),
 	}).MustNil(err, "error waiting for get pylons transaction")
+	return localKeyResult["mnemonic"]
 }
 
 // FaucetGameCoins get faucet game coins from faucet server
@@ -67,12 +74,12 @@ func FaucetGameCoins(key string, amount sdk.Coins, t *testing.T) {
 	toSdkAddr := GetSDKAddressFromKey(key, t)
 
 	sendCoinsMsg := banktypes.NewMsgSend(fromSdkAddr, toSdkAddr, amount)
-	txhash, err := inttestSDK.TestTxWithMsgWithNonce(t, sendCoinsMsg, "node0", false)
+	txhash, err := inttestSDK.NewClient().SendTx(context.Background(), t, inttestSDK.SignerKey("node0"), sendCoinsMsg)
 	t.WithFields(testing.Fields{
 		"txhash": txhash,
 	}).MustNil(err, "error sending transaction")
 
-	txResponseBytes, err := inttestSDK.WaitAndGetTxData(txhash, inttestSDK.GetMaxWaitBlock(), t)
+	txResponseBytes, err := inttestSDK.NewClient().WaitForTx(context.Background(), t, txhash)
 	t.WithFields(testing.Fields{
 		"result": string(txResponseBytes),
 	}).MustNil(err, "error waiting for getting faucet transaction")
@@ -100,7 +107,7 @@ func MockCookbook(ownerKey string, createNew bool, t *testing.T) string {
 		0,
 		types.DefaultCostPerBlock,
 		cbOwnerSdkAddr.String())
-	txhash, err := inttestSDK.TestTxWithMsgWithNonce(t, &cbMsg, ownerKey, false)
+	txhash, err := inttestSDK.NewClient().SendTx(context.Background(), t, inttestSDK.SignerKey(ownerKey), &cbMsg)
 	if err != nil {
 		TxBroadcastErrorCheck(txhash, err, t)
 		return ""
@@ -210,7 +217,7 @@ func MockDetailedRecipeGUID(
 		interval,
 		sdkAddr.String(),
 	)
-	txhash, err := inttestSDK.TestTxWithMsgWithNonce(t, &createRecipeMsg, cbOwnerKey, false)
+	txhash, err := inttestSDK.NewClient().SendTx(context.Background(), t, inttestSDK.SignerKey(cbOwnerKey), &createRecipeMsg)
 	if err != nil {
 		TxBroadcastErrorCheck(txhash, err, t)
 		return ""
@@ -244,7 +251,7 @@ func MockItemGUID(cbID, sender, name string, t *testing.T) string {
 		sdkAddr.String(),
 		0,
 	)
-	txhash, err := inttestSDK.TestTxWithMsgWithNonce(t, &fiatItemMsg, sender, false)
+	txhash, err := inttestSDK.NewClient().SendTx(context.Background(), t, inttestSDK.SignerKey(sender), &fiatItemMsg)
 	if err != nil {
 		TxBroadcastErrorCheck(txhash, err, t)
 		return ""
@@ -277,7 +284,7 @@ func MockItemGUIDWithFee(cbID, sender, name string, transferFee int64, t *testin
 		itemOwnerSdkAddr.String(),
 		0,
 	)
-	txhash, err := inttestSDK.TestTxWithMsgWithNonce(t, &fiatItemMsg, sender, false)
+	txhash, err := inttestSDK.NewClient().SendTx(context.Background(), t, inttestSDK.SignerKey(sender), &fiatItemMsg)
 	if err != nil {
 		TxBroadcastErrorCheck(txhash, err, t)
 		return ""
@@ -328,10 +335,9 @@ func MockDetailedTradeGUID(
 		extraInfo,
 		sdkAddr.String(),
 	)
-	txhash, err := inttestSDK.TestTxWithMsgWithNonce(t,
+	txhash, err := inttestSDK.NewClient().SendTx(context.Background(), t,
+		inttestSDK.SignerKey(tradeCreatorKey),
 		&createTradeMsg,
-		tradeCreatorKey,
-		false,
 	)
 	if err != nil {
 		TxBroadcastErrorCheck(txhash, err, t)
@@ -0,0 +1,58 @@
+package inttest
+
+import (
+	"fmt"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+
+	inttestSDK "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestMultisigCookbookViaCLI(originT *originT.T) {
+	t := testing.NewT(originT)
+	t.Parallel()
+
+	t.Run("team owned cookbook created by 2 of 3 multisig", func(t *testing.T) {
+		memberKeys := []string{}
+		for idx := 0; idx < 3; idx++ {
+			memberKey := fmt.Sprintf("TestMultisigCookbookViaCLI_member%d_%d", idx, time.Now().Unix())
+			MockAccount(memberKey, t)
+			memberKeys = append(memberKeys, memberKey)
+		}
+		multisigKey := fmt.Sprintf("TestMultisigCookbookViaCLI_team_%d", time.Now().Unix())
+		multisigAddr, err := inttestSDK.CreateMultisigAccount(t, multisigKey, memberKeys, 2, "node0", sdk.Coins{sdk.NewInt64Coin(types.Pylon, 100000)})
+		t.WithFields(testing.Fields{
+			"multisig_key": multisigKey,
+		}).MustNil(err, "error creating multisig account")
+
+		cbMsg := types.NewMsgCreateCookbook(
+			"COOKBOOK_MULTISIG_"+multisigKey,
+			"",
+			"this has to meet character limits lol",
+			"SketchyCo",
+			"1.0.0",
+			"example@example.com",
+			0,
+			types.DefaultCostPerBlock,
+			multisigAddr)
+		txhash, err := inttestSDK.SendMultisigTx(t, []sdk.Msg{&cbMsg}, multisigKey, memberKeys[:2])
+		t.WithFields(testing.Fields{
+			"txhash": txhash,
+		}).MustNil(err, "error sending multisig transaction")
+		txResult, err := inttestSDK.WaitAndGetTxResult(txhash, t)
+		t.WithFields(testing.Fields{
+			"txhash": txhash,
+		}).MustNil(err, "multisig transaction should succeed")
+
+		resp := types.MsgCreateCookbookResponse{}
+		err = txResult.GetMsgResponse(0, cbMsg.Type(), &resp)
+		t.MustNil(err, "error getting create cookbook response")
+		cookbook, err := inttestSDK.GetCookbookByGUID(resp.CookbookID)
+		t.MustNil(err, "error getting cookbook")
+		t.MustTrue(cookbook.Sender == multisigAddr, "cookbook should be owned by multisig account")
+	})
+}
@@ -0,0 +1,57 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+
+	inttestSDK "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+func TestAccountRecoveryViaCLI(originT *originT.T) {
+	t := testing.NewT(originT)
+	t.Parallel()
+
+	t.Run("restored account keeps items and pending executions", func(t *testing.T) {
+		key := fmt.Sprintf("TestAccountRecoveryViaCLI_%d", time.Now().Unix())
+		gen := inttestSDK.NewTestDataGenerator(t)
+		mnemonic := MockAccountWithMnemonic(key, t)
+		t.MustTrue(len(mnemonic) > 0, "mnemonic should be returned when key is created")
+
+		cbID := MockCookbook(key, true, t)
+		itemID := MockItemGUID(cbID, key, gen.Name("TestAccountRecoveryViaCLI_item"), t)
+		rcpID := MockRecipeGUID(key, 10, false, gen.Name("TestAccountRecoveryViaCLI_recipe"), "", gen.Name("TestAccountRecoveryViaCLI_output"), t)
+
+		sdkAddr := GetAccountAddress(key, t)
+		execMsg := types.NewMsgExecuteRecipe(rcpID, sdkAddr.String(), []string{})
+		txhash, err := inttestSDK.NewClient().SendTx(context.Background(), t, inttestSDK.SignerKey(key), &execMsg)
+		TxBroadcastErrorCheck(txhash, err, t)
+		txResult, err := inttestSDK.WaitAndGetTxResult(txhash, t)
+		t.WithFields(testing.Fields{
+			"txhash": txhash,
+		}).MustNil(err, "error executing delayed recipe")
+		execID, err := txResult.GetExecID()
+		t.MustNil(err, "error getting execution id")
+
+		snapshot, err := inttestSDK.RunAccountRecoveryFlow(key, mnemonic, t)
+		t.WithFields(testing.Fields{
+			"key":     key,
+			"address": snapshot.Address,
+		}).MustNil(err, "account should be recovered from mnemonic")
+		t.MustTrue(inttestSDK.Exists(snapshot.CookbookIDs, cbID), "cookbook should be verified after recovery")
+		t.MustTrue(inttestSDK.Exists(snapshot.ItemIDs, itemID), "item should be verified after recovery")
+		t.MustTrue(inttestSDK.Exists(snapshot.ExecIDs, execID), "execution should be verified after recovery")
+		t.MustTrue(inttestSDK.Exists(snapshot.PendingExecIDs, execID), "pending execution should be verified after recovery")
+
+		migrated, err := inttestSDK.RunAccountMigrationFlow(key, key+"_migrated", mnemonic, t)
+		t.WithFields(testing.Fields{
+			"key":     migrated.Key,
+			"address": migrated.Address,
+		}).MustNil(err, "account should be migrated to new key")
+		t.MustTrue(inttestSDK.Exists(migrated.CookbookIDs, cbID), "cookbook should be verified after migration")
+	})
+}
@@ -0,0 +1,72 @@
+package inttest
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+
+	inttestSDK "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+var soakPlayers = 0
+var soakDuration = time.Hour
+var soakInterval = 30 * time.Second
+var soakCheckpoint = ""
+var soakRecipeID = ""
+
+func init() {
+	flag.IntVar(&soakPlayers, "soak-players", 0, "number of simulated players of soak test, soak test is skipped when it's 0")
+	flag.DurationVar(&soakDuration, "soak-duration", time.Hour, "total duration of soak test including resumed runs")
+	flag.DurationVar(&soakInterval, "soak-interval", 30*time.Second, "average time between actions of a simulated player")
+	flag.StringVar(&soakCheckpoint, "soak-checkpoint", "", "checkpoint file of soak test, run is resumed from it and player keys are kept next to it")
+	flag.StringVar(&soakRecipeID, "soak-recipe", "", "recipe executed by simulated players, a recipe generating an item is created when it's empty and a resumed run keeps recipe of checkpoint")
+}
+
+func TestSoakPlayersViaCLI(originT *originT.T) {
+	t := testing.NewT(originT)
+	if soakPlayers == 0 {
+		t.Skip("soak test runs only when -soak-players is set")
+		return
+	}
+
+	t.Run("simulated players keep invariants", func(t *testing.T) {
+		recipeID := soakRecipeID
+		if len(recipeID) == 0 {
+			key := fmt.Sprintf("TestSoakPlayersViaCLI_%d", time.Now().Unix())
+			MockAccount(key, t)
+			MockCookbook(key, true, t)
+			recipeID = MockNoDelayItemGenRecipeGUID(key, "TestSoakPlayersViaCLI_recipe", "TestSoakPlayersViaCLI_item", t)
+		}
+		keyringDir := soakCheckpoint + "_keyring"
+		if len(soakCheckpoint) == 0 {
+			m := inttestSDK.NewTestAccountManager(t)
+			keyringDir = m.Dir
+		} else {
+			t.MustNil(os.MkdirAll(keyringDir, 0755), "error creating keyring directory of soak players")
+		}
+
+		report, err := inttestSDK.SoakTest{
+			Players:        soakPlayers,
+			Duration:       soakDuration,
+			ActionInterval: soakInterval,
+			Seed:           inttestSDK.GetTestDataSeed(),
+			RecipeID:       recipeID,
+			GetPylons:      types.PremiumTier.Fee,
+			Price:          10,
+			KeyringDir:     keyringDir,
+			CheckpointFile: soakCheckpoint,
+		}.Run(context.Background(), t)
+		t.WithFields(testing.Fields{
+			"report": report.String(),
+		}).MustNil(err, "error running soak test")
+		t.WithFields(testing.Fields{
+			"violations": report.Violations,
+		}).MustTrue(len(report.Violations) == 0, "invariants should hold after soak test")
+	})
+}
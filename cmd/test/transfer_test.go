@@ -0,0 +1,61 @@
+package inttest
+
+import (
+	"fmt"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+
+	inttestSDK "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSendItemsViaCLI(originT *originT.T) {
+	t := testing.NewT(originT)
+	t.Parallel()
+
+	t.Run("successful items transfer", func(t *testing.T) {
+		senderKey := fmt.Sprintf("TestSendItemsViaCLI_sender_%d", time.Now().Unix())
+		receiverKey := fmt.Sprintf("TestSendItemsViaCLI_receiver_%d", time.Now().Unix())
+		MockAccount(senderKey, t)
+		MockAccount(receiverKey, t)
+
+		cbID := MockCookbook(senderKey, true, t)
+		itemID := MockItemGUID(cbID, senderKey, "TestSendItemsViaCLI_item", t)
+
+		senderSdkAddr := GetAccountAddress(senderKey, t)
+		receiverSdkAddr := GetAccountAddress(receiverKey, t)
+		txhash, err := inttestSDK.TestMsgSendItems(t, []string{itemID}, senderSdkAddr.String(), receiverSdkAddr.String())
+		t.WithFields(testing.Fields{
+			"txhash":   txhash,
+			"item_id":  itemID,
+			"sender":   senderSdkAddr.String(),
+			"receiver": receiverSdkAddr.String(),
+		}).MustNil(err, "item should be transferred to receiver")
+	})
+}
+
+func TestSendCoinsViaCLI(originT *originT.T) {
+	t := testing.NewT(originT)
+	t.Parallel()
+
+	t.Run("successful coins transfer", func(t *testing.T) {
+		senderKey := fmt.Sprintf("TestSendCoinsViaCLI_sender_%d", time.Now().Unix())
+		receiverKey := fmt.Sprintf("TestSendCoinsViaCLI_receiver_%d", time.Now().Unix())
+		MockAccount(senderKey, t)
+		MockAccount(receiverKey, t)
+
+		senderSdkAddr := GetAccountAddress(senderKey, t)
+		receiverSdkAddr := GetAccountAddress(receiverKey, t)
+		amount := sdk.Coins{sdk.NewInt64Coin(types.Pylon, 1000)}
+		txhash, err := inttestSDK.TestMsgSendCoins(t, amount, senderSdkAddr.String(), receiverSdkAddr.String())
+		t.WithFields(testing.Fields{
+			"txhash":   txhash,
+			"amount":   amount.String(),
+			"sender":   senderSdkAddr.String(),
+			"receiver": receiverSdkAddr.String(),
+		}).MustNil(err, "coins should be transferred to receiver")
+	})
+}
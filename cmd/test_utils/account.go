@@ -1,33 +1,43 @@
 package inttest
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 )
 
 // AddNewLocalKey is a function to add key cli
 func AddNewLocalKey(key string) (map[string]string, error) {
+	return addLocalKey(GetKeyringProvider(), key)
+}
+
+func addLocalKey(provider KeyringProvider, key string) (map[string]string, error) {
 	result := make(map[string]string)
 	if len(key) == 0 {
 		return result, errors.New("key is empty")
 	}
-	params := []string{"keys", "add", key}
-	output, logstr, err := RunPylonsd(params, "")
+	output, logstr, err := Keys().Add(key).WithKeyring(provider).Run(context.Background())
 	if err != nil {
 		result["logstr"] = logstr
 		result["output"] = string(output)
 		return result, err
 	}
-	err = json.Unmarshal(output, &result)
-	return result, err
+	if err = json.Unmarshal(output, &result); err != nil {
+		return result, err
+	}
+	rememberMnemonic(provider, key, result["mnemonic"])
+	return result, nil
 }
 
 // CreateChainAccount is a function to create account on chain
 func CreateChainAccount(key string) (string, string, error) {
+	return createChainAccount(GetKeyringProvider(), key)
+}
+
+func createChainAccount(provider KeyringProvider, key string) (string, string, error) {
 	if len(key) == 0 {
 		return "", "", errors.New("key is empty")
 	}
-	params := []string{"tx", "pylons", "create-account", "--from", key}
-	output, logstr, err := RunPylonsd(params, "y\n")
+	output, logstr, err := Tx().Pylons().CreateAccount().From(key).WithKeyring(provider).Stdin("y\n").Run(context.Background())
 	return string(output), logstr, err
 }
@@ -0,0 +1,130 @@
+package inttest
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/cosmos/cosmos-sdk/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// exportPassphrase is used to export private key from keyring of account manager for native signing
+const exportPassphrase = "account_manager_export"
+
+// AccountManager is a struct to manage keys of a test run in its own test keyring directory
+// Tests running in parallel with their own account managers can use the same key names without collision
+type AccountManager struct {
+	Dir string
+}
+
+// NewAccountManager is a function to create account manager on a temporary keyring directory
+// The directory should be removed by Cleanup
+func NewAccountManager() (*AccountManager, error) {
+	dir, err := ioutil.TempDir("", "pylons_keyring")
+	if err != nil {
+		return nil, err
+	}
+	return &AccountManager{Dir: dir}, nil
+}
+
+// NewTestAccountManager is a function to create account manager whose keyring directory is removed when test finishes
+func NewTestAccountManager(t *testing.T) *AccountManager {
+	m, err := NewAccountManager()
+	t.MustNil(err, "error creating keyring directory of account manager")
+	t.Cleanup(func() {
+		if err := m.Cleanup(); err != nil {
+			t.WithFields(testing.Fields{
+				"keyring_dir": m.Dir,
+				"error":       err,
+			}).Warn("error removing keyring directory of account manager")
+		}
+	})
+	return m
+}
+
+// Keyring is a function to get keyring provider of the managed keyring directory
+func (m *AccountManager) Keyring() KeyringProvider {
+	return TestKeyring{Dir: m.Dir}
+}
+
+// RunPylonsdCtx is a function to run pylonsd with keys of the managed keyring
+func (m *AccountManager) RunPylonsdCtx(ctx context.Context, args []string, stdinInput string) ([]byte, string, error) {
+	return RunPylonsdWithKeyring(ctx, m.Keyring(), args, stdinInput)
+}
+
+// AddKey is a function to add new key into the managed keyring
+func (m *AccountManager) AddKey(key string) (map[string]string, error) {
+	return addLocalKey(m.Keyring(), key)
+}
+
+// RestoreKey is a function to restore key into the managed keyring from mnemonic
+func (m *AccountManager) RestoreKey(key, mnemonic string) (map[string]string, error) {
+	return restoreLocalKey(m.Keyring(), key, mnemonic)
+}
+
+// CreateAccountFromMnemonic is a function to add key of mnemonic derived at hdPath into the managed keyring
+func (m *AccountManager) CreateAccountFromMnemonic(key, mnemonic, hdPath string) (map[string]string, error) {
+	return createAccountFromMnemonic(m.Keyring(), key, mnemonic, hdPath)
+}
+
+// ExportMnemonic is a function to get mnemonic of key added into the managed keyring
+func (m *AccountManager) ExportMnemonic(key string) (string, error) {
+	return exportMnemonic(m.Keyring(), key)
+}
+
+// CreateChainAccount is a function to create account of key in the managed keyring on chain
+func (m *AccountManager) CreateChainAccount(key string) (string, string, error) {
+	return createChainAccount(m.Keyring(), key)
+}
+
+// ExportArchive is a function to export keys of names in the managed keyring as encrypted archive
+func (m *AccountManager) ExportArchive(names []string, passphrase string) ([]byte, error) {
+	return exportKeyringArchive(m.Keyring(), names, passphrase)
+}
+
+// ImportArchive is a function to restore keys of encrypted archive into the managed keyring and get their names
+func (m *AccountManager) ImportArchive(bz []byte, passphrase string) ([]string, error) {
+	return importKeyringArchive(m.Keyring(), bz, passphrase)
+}
+
+// GetAccountAddr is a function to get account address of key in the managed keyring
+func (m *AccountManager) GetAccountAddr(key string, t *testing.T) string {
+	return GetAccountAddrWithKeyring(m.Keyring(), key, t)
+}
+
+// Client is a function to create client which signs transactions with keys of the managed keyring
+func (m *AccountManager) Client(opts ...ClientOption) *Client {
+	return NewClient(append([]ClientOption{WithKeyring(m.Keyring())}, opts...)...)
+}
+
+// PrivKey is a function to get private key of key in the managed keyring for native signing
+func (m *AccountManager) PrivKey(key string) (cryptotypes.PrivKey, error) {
+	kr, err := keyring.New(sdk.KeyringServiceName(), keyring.BackendTest, m.Dir, nil)
+	if err != nil {
+		return nil, err
+	}
+	armor, err := kr.ExportPrivKeyArmor(key, exportPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	privKey, _, err := crypto.UnarmorDecryptPrivKey(armor, exportPassphrase)
+	return privKey, err
+}
+
+// SignTxOffline is a function to sign transaction of msgs natively with key in the managed keyring
+func (m *AccountManager) SignTxOffline(msgs []sdk.Msg, key string, accountNumber, sequence uint64, chainID string) ([]byte, error) {
+	privKey, err := m.PrivKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return SignTxOffline(msgs, accountNumber, sequence, chainID, privKey)
+}
+
+// Cleanup is a function to remove the managed keyring directory
+func (m *AccountManager) Cleanup() error {
+	return os.RemoveAll(m.Dir)
+}
@@ -0,0 +1,40 @@
+package inttest
+
+import (
+	"os"
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestAccountManager(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	keyringDir := ""
+	t.Run("keyring directory is removed after test", func(t *testing.T) {
+		m := NewTestAccountManager(t)
+		keyringDir = m.Dir
+		args := strings.Join(KeyringBackendSetupWithProvider([]string{"keys", "show", "eugen", "-a"}, m.Keyring()), " ")
+		t.WithFields(testing.Fields{
+			"args": args,
+		}).MustContain(args, "--keyring-dir="+m.Dir, "keyring directory of account manager should be used")
+
+		mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+		kr, err := keyring.New(sdk.KeyringServiceName(), keyring.BackendTest, m.Dir, nil)
+		t.MustNil(err, "error opening keyring of account manager")
+		_, err = kr.NewAccount("eugen", mnemonic, "", hd.CreateHDPath(sdk.CoinType, 0, 0).String(), hd.Secp256k1)
+		t.MustNil(err, "error adding key into keyring of account manager")
+
+		privKey, err := m.PrivKey("eugen")
+		t.MustNil(err, "error getting private key from keyring of account manager")
+		expectedKey, err := PrivKeyFromMnemonic(mnemonic)
+		t.MustNil(err, "error deriving private key from mnemonic")
+		t.MustTrue(privKey.Equals(expectedKey), "private key from keyring should be the key of mnemonic")
+	})
+	_, err := os.Stat(keyringDir)
+	t.MustTrue(os.IsNotExist(err), "keyring directory should be removed when test finishes")
+}
@@ -0,0 +1,219 @@
+package inttest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// AddressKind describes the kind of bech32 address told by its prefix
+type AddressKind string
+
+// describes the kinds of bech32 addresses
+const (
+	AccountAddress   AddressKind = "account"
+	ValidatorAddress AddressKind = "validator"
+	ConsensusAddress AddressKind = "consensus"
+)
+
+// ErrWrongAddressPrefix is returned when an address has prefix of another chain or of another kind of address
+var ErrWrongAddressPrefix = errors.New("wrong address prefix")
+
+// bech32Regexp matches strings meant to be bech32 addresses, including ones with bad checksum
+var bech32Regexp = regexp.MustCompile(`^[a-z]{1,83}1[02-9ac-hj-np-z]{38,}$`)
+
+// LooksLikeAddress is a function to check if s is meant to be a bech32 address rather than e.g. a key name
+func LooksLikeAddress(s string) bool {
+	return bech32Regexp.MatchString(s)
+}
+
+// AddressPrefix is a function to get bech32 prefix of kind configured for the chain e.g. "cosmosvaloper"
+func AddressPrefix(kind AddressKind) string {
+	config := sdk.GetConfig()
+	switch kind {
+	case ValidatorAddress:
+		return config.GetBech32ValidatorAddrPrefix()
+	case ConsensusAddress:
+		return config.GetBech32ConsensusAddrPrefix()
+	}
+	return config.GetBech32AccountAddrPrefix()
+}
+
+// ParseAddress is a function to decode bech32 address and tell its kind by prefix
+func ParseAddress(addr string) (AddressKind, sdk.AccAddress, error) {
+	hrp, bz, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid bech32 address %s: %w", addr, err)
+	}
+	if err := sdk.VerifyAddressFormat(bz); err != nil {
+		return "", nil, fmt.Errorf("invalid address %s: %w", addr, err)
+	}
+	for _, kind := range []AddressKind{AccountAddress, ValidatorAddress, ConsensusAddress} {
+		if hrp == AddressPrefix(kind) {
+			return kind, bz, nil
+		}
+	}
+	return "", bz, fmt.Errorf("%w: address %s has prefix %s, but it should be %s", ErrWrongAddressPrefix, addr, hrp, AddressPrefix(AccountAddress))
+}
+
+// validateAddressKind is a function to check address is valid bech32 address of kind
+func validateAddressKind(addr string, expected AddressKind) error {
+	kind, _, err := ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	if kind != expected {
+		return fmt.Errorf("%w: %s is %s address, but %s address with prefix %s is expected", ErrWrongAddressPrefix, addr, kind, expected, AddressPrefix(expected))
+	}
+	return nil
+}
+
+// ValidateAccountAddress is a function to check addr is an account address of the chain, not e.g. a validator address
+func ValidateAccountAddress(addr string) error {
+	return validateAddressKind(addr, AccountAddress)
+}
+
+// ValidateValidatorAddress is a function to check addr is a validator operator address of the chain
+func ValidateValidatorAddress(addr string) error {
+	return validateAddressKind(addr, ValidatorAddress)
+}
+
+// ConvertAddress is a function to encode the same address bytes with prefix of kind
+// e.g. to get validator operator address of the account of a validator
+func ConvertAddress(addr string, kind AddressKind) (string, error) {
+	_, bz, err := ParseAddress(addr)
+	if err != nil {
+		return "", err
+	}
+	return bech32.ConvertAndEncode(AddressPrefix(kind), bz)
+}
+
+// ShortAddress is a function to abbreviate address for logs e.g. cosmos1qy35…9kd7
+func ShortAddress(addr string) string {
+	sep := strings.LastIndex(addr, "1")
+	if sep < 0 || len(addr)-sep <= 10 {
+		return addr
+	}
+	return addr[:sep+5] + "…" + addr[len(addr)-4:]
+}
+
+// AddressBook is a struct to map human readable aliases of accounts to their addresses
+type AddressBook struct {
+	mux     sync.RWMutex
+	byAlias map[string]string
+	byAddr  map[string]string
+}
+
+// GlobalAddressBook is the address book shared by tests of the suite
+var GlobalAddressBook = NewAddressBook()
+
+// NewAddressBook is a function to create an empty address book
+func NewAddressBook() *AddressBook {
+	return &AddressBook{
+		byAlias: make(map[string]string),
+		byAddr:  make(map[string]string),
+	}
+}
+
+// Add is a function to map alias to account address, alias of another address is refused
+func (b *AddressBook) Add(alias string, addr string) error {
+	if len(alias) == 0 || LooksLikeAddress(alias) {
+		return fmt.Errorf("alias %s should be a non-empty name", alias)
+	}
+	if err := ValidateAccountAddress(addr); err != nil {
+		return fmt.Errorf("error adding alias %s: %w", alias, err)
+	}
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if prev, ok := b.byAlias[alias]; ok && prev != addr {
+		return fmt.Errorf("alias %s is already mapped to %s", alias, prev)
+	}
+	b.byAlias[alias] = addr
+	if _, ok := b.byAddr[addr]; !ok {
+		b.byAddr[addr] = alias
+	}
+	return nil
+}
+
+// Address is a function to get address of alias
+func (b *AddressBook) Address(alias string) (string, bool) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	addr, ok := b.byAlias[alias]
+	return addr, ok
+}
+
+// Alias is a function to get the first alias added for address
+func (b *AddressBook) Alias(addr string) (string, bool) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	alias, ok := b.byAddr[addr]
+	return alias, ok
+}
+
+// Aliases is a function to get sorted aliases of the book
+func (b *AddressBook) Aliases() []string {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	aliases := []string{}
+	for alias := range b.byAlias {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// Resolve is a function to get address of alias or validated address
+func (b *AddressBook) Resolve(aliasOrAddr string) (string, error) {
+	if LooksLikeAddress(aliasOrAddr) {
+		return aliasOrAddr, ValidateAccountAddress(aliasOrAddr)
+	}
+	if addr, ok := b.Address(aliasOrAddr); ok {
+		return addr, nil
+	}
+	return "", fmt.Errorf("alias %s is not in address book", aliasOrAddr)
+}
+
+// Format is a function to pretty-print address with its alias e.g. "eugen (cosmos1qy35…9kd7)"
+func (b *AddressBook) Format(addr string) string {
+	if alias, ok := b.Alias(addr); ok {
+		return fmt.Sprintf("%s (%s)", alias, ShortAddress(addr))
+	}
+	return ShortAddress(addr)
+}
+
+// LoadAddressBook is a function to add aliases of json file to the address book shared by the suite
+func LoadAddressBook(file string) error {
+	return GlobalAddressBook.LoadFile(file)
+}
+
+// LoadFile is a function to add aliases of json file e.g. {"treasury": "cosmos1..."} to address book
+func (b *AddressBook) LoadFile(file string) error {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("error reading address book: %w", err)
+	}
+	var entries map[string]string
+	if err := json.Unmarshal(bz, &entries); err != nil {
+		return fmt.Errorf("error decoding address book %s: %w", file, err)
+	}
+	aliases := []string{}
+	for alias := range entries {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		if err := b.Add(alias, entries[alias]); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return nil
+}
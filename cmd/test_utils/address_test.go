@@ -0,0 +1,82 @@
+package inttest
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+func TestAddressUtilities(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	bz := []byte("address_bytes_of_a_test_account")[:20]
+	accAddr := sdk.AccAddress(bz).String()
+	valAddr := sdk.ValAddress(bz).String()
+	otherChainAddr, err := bech32.ConvertAndEncode("osmo", bz)
+	t.MustNil(err, "error encoding address of other chain")
+
+	kind, parsed, err := ParseAddress(valAddr)
+	t.MustTrue(err == nil && kind == ValidatorAddress && parsed.Equals(sdk.AccAddress(bz)), "validator address should be parsed with its kind")
+	t.MustNil(ValidateAccountAddress(accAddr), "account address should be valid")
+	t.MustNil(ValidateValidatorAddress(valAddr), "validator address should be valid")
+	t.MustTrue(errors.Is(ValidateAccountAddress(valAddr), ErrWrongAddressPrefix), "validator address should be refused as account address")
+	t.MustTrue(errors.Is(ValidateAccountAddress(otherChainAddr), ErrWrongAddressPrefix), "address of other chain should be refused")
+	badChecksum := accAddr[:len(accAddr)-1] + "q"
+	if badChecksum == accAddr {
+		badChecksum = accAddr[:len(accAddr)-1] + "p"
+	}
+	err = ValidateAccountAddress(badChecksum)
+	t.MustTrue(err != nil && !errors.Is(err, ErrWrongAddressPrefix), "address with bad checksum should be invalid")
+
+	converted, err := ConvertAddress(accAddr, ValidatorAddress)
+	t.MustTrue(err == nil && converted == valAddr, "account address should be converted to validator address")
+	converted, err = ConvertAddress(valAddr, AccountAddress)
+	t.MustTrue(err == nil && converted == accAddr, "validator address should be converted to account address")
+
+	t.MustTrue(LooksLikeAddress(accAddr) && LooksLikeAddress(badChecksum), "bech32 strings should look like addresses")
+	t.MustTrue(!LooksLikeAddress("eugen") && !LooksLikeAddress("LOUD-v0.1.0-1579053457"), "names should not look like addresses")
+	short := ShortAddress(accAddr)
+	t.WithFields(testing.Fields{
+		"short": short,
+	}).MustTrue(short == accAddr[:11]+"…"+accAddr[len(accAddr)-4:], "address should be abbreviated after prefix")
+	t.MustTrue(ShortAddress("eugen") == "eugen", "names should not be abbreviated")
+}
+
+func TestAddressBook(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	treasury := sdk.AccAddress([]byte("treasury_account_add")).String()
+	player := sdk.AccAddress([]byte("player_account_addre")).String()
+	book := NewAddressBook()
+	t.MustNil(book.Add("treasury", treasury), "error adding alias")
+	t.MustNil(book.Add("treasury", treasury), "adding the same alias again should be allowed")
+	t.MustTrue(book.Add("treasury", player) != nil, "alias should not be remapped")
+	t.MustTrue(book.Add("validator", sdk.ValAddress([]byte("treasury_account_add")).String()) != nil, "validator address should be refused")
+	t.MustTrue(book.Add(player, player) != nil, "address should not be an alias")
+
+	addr, err := book.Resolve("treasury")
+	t.MustTrue(err == nil && addr == treasury, "alias should be resolved")
+	addr, err = book.Resolve(player)
+	t.MustTrue(err == nil && addr == player, "address should be resolved to itself")
+	_, err = book.Resolve("unknown")
+	t.MustTrue(err != nil, "unknown alias should not be resolved")
+	t.MustTrue(book.Format(treasury) == "treasury ("+ShortAddress(treasury)+")", "address should be printed with alias")
+	t.MustTrue(book.Format(player) == ShortAddress(player), "address without alias should be abbreviated")
+
+	file := filepath.Join(originT.TempDir(), "address_book.json")
+	err = ioutil.WriteFile(file, []byte(`{"player": "`+player+`", "reserve": "`+treasury+`"}`), 0644)
+	t.MustNil(err, "error writing address book")
+	t.MustNil(book.LoadFile(file), "error loading address book")
+	t.MustTrue(len(book.Aliases()) == 3 && book.Aliases()[0] == "player", "aliases of file should be added in order")
+	alias, _ := book.Alias(treasury)
+	t.MustTrue(alias == "treasury", "first alias of address should be kept")
+
+	err = ioutil.WriteFile(file, []byte(`{"player": "cosmosvaloper1invalid"}`), 0644)
+	t.MustNil(err, "error writing address book")
+	t.MustTrue(NewAddressBook().LoadFile(file) != nil, "invalid address of file should be refused")
+}
@@ -0,0 +1,116 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/config"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AdminKeyName is the default key name of admin account in keyring
+const AdminKeyName = "pylons_admin"
+
+// AdminMnemonicEnv is the environment variable to set mnemonic of admin key
+const AdminMnemonicEnv = "PYLONS_ADMIN_MNEMONIC"
+
+// AdminKeyProvider is a struct to load the privileged key configured for local testnet into keyring
+// e.g. to send FiatItem msgs minting items. The key should be the pylons_llc validator of pylons.yml,
+// and its account should be added to local testnet genesis by GenesisAccount.
+type AdminKeyProvider struct {
+	// Name is the key name in keyring, AdminKeyName is used when it's empty
+	Name string
+	// Mnemonic is the mnemonic of admin key, MnemonicFile or AdminMnemonicEnv is used when it's empty
+	Mnemonic string
+	// MnemonicFile is the file having mnemonic of admin key
+	MnemonicFile string
+	// Address is the expected admin address, pylons_llc validator of fee configuration is used when it's empty
+	Address string
+	// Keyring is the keyring the key is loaded into, GetKeyringProvider is used when it's nil
+	Keyring KeyringProvider
+}
+
+func (p AdminKeyProvider) name() string {
+	if len(p.Name) == 0 {
+		return AdminKeyName
+	}
+	return p.Name
+}
+
+func (p AdminKeyProvider) keyring() KeyringProvider {
+	if p.Keyring == nil {
+		return GetKeyringProvider()
+	}
+	return p.Keyring
+}
+
+// AdminAddress is a function to get address the admin key should have
+func (p AdminKeyProvider) AdminAddress() string {
+	if len(p.Address) == 0 {
+		return config.Config.Validators.PylonsLLC
+	}
+	return p.Address
+}
+
+// mnemonic is a function to get configured mnemonic of admin key
+func (p AdminKeyProvider) mnemonic() (string, error) {
+	if len(p.Mnemonic) > 0 {
+		RegisterSecret(p.Mnemonic)
+		return p.Mnemonic, nil
+	}
+	if len(p.MnemonicFile) > 0 {
+		bz, err := ioutil.ReadFile(p.MnemonicFile)
+		if err != nil {
+			return "", fmt.Errorf("error reading admin mnemonic file: %w", err)
+		}
+		RegisterSecret(string(bz))
+		return strings.TrimSpace(string(bz)), nil
+	}
+	if mnemonic := strings.TrimSpace(os.Getenv(AdminMnemonicEnv)); len(mnemonic) > 0 {
+		RegisterSecret(mnemonic)
+		return mnemonic, nil
+	}
+	return "", fmt.Errorf("admin mnemonic is not configured, set MnemonicFile or %s", AdminMnemonicEnv)
+}
+
+// keyAddress is a function to get address of key in keyring, it's empty when key does not exist
+func (p AdminKeyProvider) keyAddress() string {
+	output, _, err := Keys().Show(p.name(), true).WithKeyring(p.keyring()).Run(context.Background())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// Load is a function to make admin key available in keyring and get its key name
+// The key is restored from mnemonic when keyring doesn't have it, and it fails when the key is not of admin address.
+func (p AdminKeyProvider) Load() (string, error) {
+	addr := p.keyAddress()
+	if len(addr) == 0 {
+		mnemonic, err := p.mnemonic()
+		if err != nil {
+			return "", err
+		}
+		result, err := restoreLocalKey(p.keyring(), p.name(), mnemonic)
+		if err != nil {
+			return "", fmt.Errorf("error restoring admin key: %w; %s", err, result["logstr"])
+		}
+		addr = result["address"]
+	}
+	if addr != p.AdminAddress() {
+		return "", fmt.Errorf("key %s has address %s, but admin address is %s", p.name(), addr, p.AdminAddress())
+	}
+	return p.name(), nil
+}
+
+// GenesisAccount is a function to add admin account of coins to local testnet genesis
+func (p AdminKeyProvider) GenesisAccount(builder *GenesisBuilder, coins sdk.Coins) error {
+	if len(p.AdminAddress()) == 0 {
+		return errors.New("admin address is not configured")
+	}
+	return builder.AddAccount(p.AdminAddress(), coins)
+}
@@ -0,0 +1,52 @@
+package inttest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/config"
+)
+
+func TestAdminKeyProviderMnemonic(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	provider := AdminKeyProvider{}
+	t.MustTrue(provider.name() == AdminKeyName, "default key name should be used")
+	t.MustTrue(provider.AdminAddress() == config.Config.Validators.PylonsLLC, "pylons llc validator should be admin by default")
+
+	mnemonicFile := filepath.Join(originT.TempDir(), "admin_mnemonic")
+	err := ioutil.WriteFile(mnemonicFile, []byte("file mnemonic\n"), 0600)
+	t.MustNil(err, "error writing mnemonic file")
+	prevEnv, hadEnv := os.LookupEnv(AdminMnemonicEnv)
+	defer func() {
+		if hadEnv {
+			os.Setenv(AdminMnemonicEnv, prevEnv)
+		} else {
+			os.Unsetenv(AdminMnemonicEnv)
+		}
+	}()
+	os.Setenv(AdminMnemonicEnv, "env mnemonic")
+
+	for _, tc := range []struct {
+		provider AdminKeyProvider
+		expected string
+	}{
+		{AdminKeyProvider{Mnemonic: "given mnemonic", MnemonicFile: mnemonicFile}, "given mnemonic"},
+		{AdminKeyProvider{MnemonicFile: mnemonicFile}, "file mnemonic"},
+		{AdminKeyProvider{}, "env mnemonic"},
+	} {
+		mnemonic, err := tc.provider.mnemonic()
+		t.MustNil(err, "error getting mnemonic")
+		t.WithFields(testing.Fields{
+			"expected": tc.expected,
+			"actual":   mnemonic,
+		}).MustTrue(mnemonic == tc.expected, "mnemonic should be taken from the first configured source")
+	}
+
+	os.Unsetenv(AdminMnemonicEnv)
+	_, err = AdminKeyProvider{}.mnemonic()
+	t.MustTrue(err != nil, "missing mnemonic should be reported")
+}
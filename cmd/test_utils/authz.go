@@ -0,0 +1,161 @@
+package inttest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// AuthzGrant is a struct to describe generic authorization of grantee to send msgs of a type on behalf of granter
+// e.g. allowing a game server key to execute recipes of a player
+type AuthzGrant struct {
+	Granter    Signer
+	Grantee    string // bech32 address
+	MsgTypeURL string
+	// Expiration is the time grant expires at, default expiration of the chain is used when it's zero
+	Expiration time.Time
+}
+
+// MsgTypeURL is a function to get type url of msg which authz grants refer to e.g. /pylons.MsgExecuteRecipe
+func MsgTypeURL(msg sdk.Msg) string {
+	return "/" + proto.MessageName(msg)
+}
+
+// GrantAuthz is a function to grant generic authorization by granter and broadcast it, it returns txhash
+// Authz transactions are built by pylonsd, so it requires pylonsd and chain having authz module (cosmos-sdk v0.43 or later).
+func (c *Client) GrantAuthz(ctx context.Context, t *testing.T, grant AuthzGrant) (string, error) {
+	if len(grant.MsgTypeURL) == 0 {
+		return "", errors.New("msg type url of grant is empty")
+	}
+	// pylonsd tx authz grant <grantee> generic --msg-type <type_url> --from <granter> --generate-only
+	cmd := Tx().Sub("authz", "grant", grant.Grantee, "generic").Flag("msg-type", grant.MsgTypeURL)
+	if !grant.Expiration.IsZero() {
+		cmd.Flag("expiration", strconv.FormatInt(grant.Expiration.Unix(), 10))
+	}
+	return c.sendAuthzTx(ctx, t, grant.Granter, cmd, []string{"authz_grant"})
+}
+
+// RevokeAuthz is a function to revoke authorization of grantee to send msgs of type url on behalf of granter, it returns txhash
+func (c *Client) RevokeAuthz(ctx context.Context, t *testing.T, granter Signer, grantee string, msgTypeURL string) (string, error) {
+	if len(msgTypeURL) == 0 {
+		return "", errors.New("msg type url of grant is empty")
+	}
+	// pylonsd tx authz revoke <grantee> <type_url> --from <granter> --generate-only
+	return c.sendAuthzTx(ctx, t, granter, Tx().Sub("authz", "revoke", grantee, msgTypeURL), []string{"authz_revoke"})
+}
+
+// ExecAuthz is a function to send msgs of granters wrapped in MsgExec signed by grantee, it returns txhash
+// Senders of msgs are granters which should have granted grantee to send msgs of their types.
+func (c *Client) ExecAuthz(ctx context.Context, t *testing.T, grantee Signer, msgs ...sdk.Msg) (string, error) {
+	if len(msgs) == 0 {
+		return "", errors.New("length of msgs shouldn't be zero")
+	}
+	txModel, err := GenTxWithMsg(msgs)
+	if err != nil {
+		return "", err
+	}
+	output, err := GetTxJSONEncoder()(txModel)
+	if err != nil {
+		return "", err
+	}
+	tmpDir, err := ioutil.TempDir("", "pylons")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+	msgsTxFile := filepath.Join(tmpDir, "exec_msgs.json")
+	if err = ioutil.WriteFile(msgsTxFile, output, 0644); err != nil {
+		return "", err
+	}
+	msgTypes := []string{"authz_exec"}
+	for _, msg := range msgs {
+		msgTypes = append(msgTypes, msg.Type())
+	}
+	t.WithFields(testing.Fields{
+		"grantee": grantee.String(),
+		"tx_msgs": AminoCodecFormatter(msgs),
+	}).AddFields(GetLogFieldsFromMsgs(msgs)).Debug("authz exec msgs")
+	// pylonsd tx authz exec exec_msgs.json --from <grantee> --generate-only
+	return c.sendAuthzTx(ctx, t, grantee, Tx().Sub("authz", "exec", msgsTxFile), msgTypes)
+}
+
+// sendAuthzTx is a function to generate authz transaction by pylonsd, sign it by signer with managed nonce and broadcast
+// Msgs wrapped by authz transactions are not passed to tx recorder as their senders are not signers of the transaction.
+func (c *Client) sendAuthzTx(ctx context.Context, t *testing.T, signer Signer, cmd *CommandBuilder, msgTypes []string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	ctx = c.withContext(ctx)
+	if err := NodeVersionCheck(ctx, t); err != nil {
+		return "", err
+	}
+	address := signer.value
+	if !signer.isAddress {
+		address = GetAccountAddrWithKeyring(c.keyring, signer.value, t)
+	}
+	ctx, span := StartSpan(withTestSpan(ctx, t), "tx broadcast", SpanKindInternal)
+	span.SetAttribute("tx.signer", signer.String())
+	span.SetAttribute("tx.msg_types", strings.Join(msgTypes, ","))
+
+	profile, _ := c.env.ChainProfile()
+	cmd.From(address).
+		Gas(profile.TxGasLimit()).
+		ChainID(c.env.ChainID()).
+		BoolFlag("generate-only").
+		WithKeyring(c.keyring)
+	strategy := c.txOpts.FeeStrategy
+	if strategy == nil {
+		strategy = c.env.FeeStrategy()
+	}
+	fees, err := strategy.Fee(ctx, profile.TxGasLimit())
+	if err != nil {
+		span.End(err)
+		return "", fmt.Errorf("error deciding transaction fees: %w", err)
+	}
+	if !fees.Empty() {
+		cmd.Fees(fees.String())
+	}
+	if len(c.txOpts.Memo) > 0 {
+		cmd.Flag("note", c.txOpts.Memo)
+	}
+	if len(c.txOpts.FeeGranter) > 0 {
+		cmd.Flag("fee-account", c.txOpts.FeeGranter)
+	}
+	if len(c.txOpts.FeePayer) > 0 {
+		cmd.Flag("fee-payer", c.txOpts.FeePayer)
+	}
+	// output is txhash if it's a success transaction, if fail, it's output log
+	output := "error generating authz transaction"
+	rawTx, logstr, err := cmd.Run(ctx)
+	if err != nil {
+		err = fmt.Errorf("%w; %s; %s", err, string(rawTx), logstr)
+	} else {
+		output, err = signAndBroadcastRawTx(ctx, t, bytes.TrimSpace(rawTx), address, c.maxBroadcast, c.keyring, nil)
+	}
+	if err == nil {
+		span.SetAttribute("tx.hash", output)
+	}
+	span.End(err)
+	if err != nil {
+		t.WithFields(testing.Fields{
+			"output":  output,
+			"command": cmd.String(),
+			"error":   err,
+			"func":    "Client.sendAuthzTx",
+		}).Error("error log")
+		return output, err
+	}
+	t.RecordTx(testing.TxRecord{TxHash: output, Msgs: msgTypes})
+	return output, nil
+}
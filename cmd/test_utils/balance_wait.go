@@ -0,0 +1,125 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// BalanceWaitOptions is a struct to configure how WaitForBalanceChange measures and accepts balance change
+type BalanceWaitOptions struct {
+	WaitOptions
+	// Before is balance the change is measured from e.g. taken by GetAccountBalanceFromAddr before sending transaction,
+	// balance at the start of waiting is used when it's nil
+	Before *banktypes.Balance
+	// Fee is gas fee paid by the address in addition to expected change, only amount of awaited denom is accounted
+	Fee sdk.Coins
+	// Tolerance is maximum difference of actual change from expected change e.g. for gas fee which is not known exactly
+	Tolerance sdk.Int
+}
+
+// BalanceChange is a struct to describe balance change of denom observed by WaitForBalanceChange
+type BalanceChange struct {
+	Address  string
+	Denom    string
+	Before   sdk.Int
+	After    sdk.Int
+	Delta    sdk.Int
+	Expected sdk.Int // expected delta with fee deducted
+	Wait     WaitResult
+}
+
+// Fields is a function to get log fields describing the balance change
+func (c BalanceChange) Fields() testing.Fields {
+	fields := c.Wait.Fields()
+	fields["address"] = c.Address
+	fields["denom"] = c.Denom
+	fields["balance_before"] = c.Before.String()
+	fields["balance_after"] = c.After.String()
+	fields["balance_delta"] = c.Delta.String()
+	fields["expected_delta"] = c.Expected.String()
+	return fields
+}
+
+// balanceChangeReached is a function to check if delta is expected delta within tolerance
+func balanceChangeReached(delta, expected, tolerance sdk.Int) bool {
+	diff := delta.Sub(expected)
+	if diff.IsNegative() {
+		diff = diff.Neg()
+	}
+	return diff.LTE(tolerance)
+}
+
+// WaitForBalanceChange is a function to poll balance of denom of address until it's changed by expectedDelta
+// expectedDelta is negative for spending, fee is subtracted from it and actual change can differ by tolerance.
+// It replaces sleeping a fixed time before checking balances, and returns ErrWaitTimeout with last observed change.
+// Unknown denom is refused by CheckBalanceDenom up front instead of waiting for a balance which never changes.
+func WaitForBalanceChange(ctx context.Context, addr, denom string, expectedDelta sdk.Int, opts BalanceWaitOptions) (BalanceChange, error) {
+	transport, err := GetTransport()
+	if err != nil {
+		return BalanceChange{Address: addr, Denom: denom}, err
+	}
+	if _, ok := GlobalDenomRegistry.Lookup(denom); !ok {
+		held, err := transport.Balances(ctx, addr)
+		if err == nil {
+			err = CheckBalanceDenom(ctx, denom, held)
+		}
+		if err != nil {
+			return BalanceChange{Address: addr, Denom: denom}, err
+		}
+	}
+	return waitForBalanceChange(ctx, transport.Balances, addr, denom, expectedDelta, opts)
+}
+
+func waitForBalanceChange(ctx context.Context, balances func(ctx context.Context, addr string) (sdk.Coins, error), addr, denom string, expectedDelta sdk.Int, opts BalanceWaitOptions) (BalanceChange, error) {
+	change := BalanceChange{
+		Address:  addr,
+		Denom:    denom,
+		Expected: expectedDelta.Sub(opts.Fee.AmountOf(denom)),
+	}
+	if opts.Tolerance.IsNil() {
+		opts.Tolerance = sdk.ZeroInt()
+	}
+	if opts.Before != nil {
+		change.Before = opts.Before.Coins.AmountOf(denom)
+	} else {
+		coins, err := balances(ctx, addr)
+		if err != nil {
+			return change, fmt.Errorf("error getting balance of %s: %w", addr, err)
+		}
+		change.Before = coins.AmountOf(denom)
+	}
+	change.After = change.Before
+	change.Delta = sdk.ZeroInt()
+	if len(opts.Name) == 0 {
+		opts.Name = fmt.Sprintf("balance of %s in %s changed by %s", addr, denom, change.Expected)
+	}
+	if opts.MaxBlocks == 0 && opts.MaxWait == 0 {
+		opts.MaxBlocks = GetMaxWaitBlock()
+	}
+
+	var err error
+	change.Wait, err = WaitFor(ctx, func() (bool, error) {
+		coins, err := balances(ctx, addr)
+		if err != nil {
+			return false, fmt.Errorf("error getting balance of %s: %w", addr, err)
+		}
+		change.After = coins.AmountOf(denom)
+		change.Delta = change.After.Sub(change.Before)
+		return balanceChangeReached(change.Delta, change.Expected, opts.Tolerance), nil
+	}, opts.WaitOptions)
+	if err != nil {
+		return change, fmt.Errorf("%w, last change %s", err, change.Delta)
+	}
+	return change, nil
+}
+
+// MustWaitForBalanceChange is a function to wait for balance change and fail the test when it doesn't happen
+func MustWaitForBalanceChange(ctx context.Context, t *testing.T, addr, denom string, expectedDelta sdk.Int, opts BalanceWaitOptions) BalanceChange {
+	change, err := WaitForBalanceChange(withTestSpan(ctx, t), addr, denom, expectedDelta, opts)
+	t.WithFields(change.Fields()).MustNil(err, "error waiting for balance change")
+	return change
+}
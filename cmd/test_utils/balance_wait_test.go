@@ -0,0 +1,53 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+func TestWaitForBalanceChange(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	// receiver balance grows by 100 on the third poll and sender pays 2 as gas fee
+	polls := 0
+	balances := func(ctx context.Context, addr string) (sdk.Coins, error) {
+		polls++
+		if polls < 3 {
+			return sdk.NewCoins(sdk.NewInt64Coin("pylon", 1000), sdk.NewInt64Coin("stake", 10)), nil
+		}
+		return sdk.NewCoins(sdk.NewInt64Coin("pylon", 1098), sdk.NewInt64Coin("stake", 10)), nil
+	}
+	before := &banktypes.Balance{Address: "eugen", Coins: sdk.NewCoins(sdk.NewInt64Coin("pylon", 1000))}
+	opts := BalanceWaitOptions{
+		WaitOptions: WaitOptions{PollInterval: time.Millisecond, MaxWait: time.Second},
+		Before:      before,
+		Fee:         sdk.NewCoins(sdk.NewInt64Coin("pylon", 2)),
+	}
+	change, err := waitForBalanceChange(context.Background(), balances, "eugen", "pylon", sdk.NewInt(100), opts)
+	t.WithFields(change.Fields()).MustNil(err, "error waiting for balance change with fee")
+	t.WithFields(change.Fields()).MustTrue(change.Delta.Equal(sdk.NewInt(98)) && change.Wait.Polls == 3, "balance should be polled until it changes")
+
+	polls = 0
+	opts.Fee = nil
+	opts.Tolerance = sdk.NewInt(5)
+	_, err = waitForBalanceChange(context.Background(), balances, "eugen", "pylon", sdk.NewInt(100), opts)
+	t.MustNil(err, "change within tolerance should be accepted")
+
+	polls = 0
+	opts.Tolerance = sdk.ZeroInt()
+	opts.MaxWait = 20 * time.Millisecond
+	change, err = waitForBalanceChange(context.Background(), balances, "eugen", "pylon", sdk.NewInt(100), opts)
+	t.WithFields(change.Fields()).MustTrue(errors.Is(err, ErrWaitTimeout), "change out of tolerance should time out")
+
+	polls = 0
+	opts.Before = nil
+	opts.MaxWait = 20 * time.Millisecond
+	change, err = waitForBalanceChange(context.Background(), balances, "eugen", "stake", sdk.NewInt(-1), opts)
+	t.WithFields(change.Fields()).MustTrue(errors.Is(err, ErrWaitTimeout) && change.Before.Equal(sdk.NewInt(10)), "only balance of awaited denom should be compared")
+}
@@ -1,23 +1,21 @@
 package inttest
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
-	"math/rand"
 	"os"
 	"os/exec"
-	"path"
+	"reflect"
+	"runtime"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/Pylons-tech/pylons_sdk/app"
 	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
 	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
-	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
@@ -27,7 +25,6 @@ import (
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	log "github.com/sirupsen/logrus"
 	"github.com/tendermint/tendermint/libs/bytes"
-	tmcli "github.com/tendermint/tendermint/libs/cli"
 	"github.com/tendermint/tendermint/p2p"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
@@ -38,30 +35,101 @@ type CLIOptions struct {
 	RestEndpoint string
 	MaxWaitBlock int64
 	MaxBroadcast int
+	// ConfirmationDepth is the number of blocks required on top of the inclusion block before a tx is final
+	ConfirmationDepth int64
+	// Keyring is the keyring provider of test keys, keyring flags are used when it's nil
+	Keyring KeyringProvider
+	// CLIConcurrency is the number of pylonsd commands running at once, number of CPUs is used when it's 0
+	CLIConcurrency int
+	// RetryPolicy is the retry policy of queries and broadcasts, DefaultRetryPolicy is used when it's nil
+	RetryPolicy *RetryPolicy
+	// TxRecorder observes transactions sent by clients, nothing is recorded when it's nil
+	TxRecorder TxRecorder
+	// PylonsdPath is the path of pylonsd binary, it's resolved by ResolvePylonsd when it's empty
+	PylonsdPath string
+	// PylonsdVersion is the pylonsd version pinned for the run, pylonsd version of chain profile is used when it's empty
+	PylonsdVersion string
+	// PylonsdDownload is whether pinned pylonsd release is downloaded when local binary is missing or has different version
+	PylonsdDownload bool
+	// PylonsdSHA256 is the sha256 hex pylonsd binary should have, it's not checked for local binaries when it's empty
+	PylonsdSHA256 string
+	// PylonsdCacheDir is the directory downloaded pylonsd releases are kept in
+	PylonsdCacheDir string
+	// Encoding is the json encoding of node outputs, it's detected per output when it's EncodingAuto
+	Encoding Encoding
+	// Transport is the node interface queries and broadcasts go through, pylonsd cli is used when it's empty
+	Transport TransportKind
+	// GRPCEndpoint is the grpc endpoint of node used by grpc transport
+	GRPCEndpoint string
+	// QueryCacheTTL is the time to reuse results of idempotent queries, results are not cached when it's 0
+	QueryCacheTTL time.Duration
+	// Profile is the chain profile tests run against, broadcasts are refused when node reports different chain id
+	Profile string
+	// ChainID is the chain id transactions are signed for, chain id of profile is used when it's empty
+	ChainID string
+	// NodeVersionCheck is how node version out of SupportedNodeVersions is handled, it's warned when it's empty
+	NodeVersionCheck NodeVersionCheckMode
+	// WaitStrategy is how helpers wait for blocks, the strategy named by WaitStrategyName is used when it's nil
+	WaitStrategy WaitStrategy
+	// WaitStrategyName is name of wait strategy, one of polling, websocket and fixed
+	WaitStrategyName string
+	// BlockTimeout is the time budget per block of wait strategy, it adapts to observed block time when it's 0
+	BlockTimeout time.Duration
+	// BroadcastRate is the number of transactions broadcast per second by all accounts, broadcasts are not limited when it's 0
+	BroadcastRate float64
+	// AccountBroadcastRate is the number of transactions broadcast per second by each signer account, not limited when it's 0
+	AccountBroadcastRate float64
+	// BroadcastBurst is the number of transactions broadcast at once before rate limits apply
+	BroadcastBurst int
+	// FeeStrategy decides fees of transactions, the one of GasPrices is used when it's nil
+	FeeStrategy FeeStrategy
+	// GasPrices are gas prices fees are taken from e.g. "0.025pylon", "auto" queries min-gas-prices of node
+	// Fees of chain profile are paid when it's empty.
+	GasPrices string
+	// BroadcastMode is how node handles broadcast transactions before responding, sync is used when it's empty
+	BroadcastMode BroadcastMode
+	// DetectChainReset clears cached nonces, statuses and broadcasts when node height goes back or chain id changes
+	DetectChainReset bool
+	// Chaos are faults injected into requests to node by EnableChaos
+	Chaos ChaosOptions
+	// Clock is the time waits and retries use, SystemClock is used when it's nil
+	Clock Clock
 }
 
 // CLIOpts is a variable to manage pylonsd options
 var CLIOpts CLIOptions
-var cliMux sync.Mutex
 
 func init() {
 	flag.StringVar(&CLIOpts.CustomNode, "node", "tcp://localhost:26657", "custom node url")
+	flag.IntVar(&CLIOpts.CLIConcurrency, "cli-concurrency", 0, "number of pylonsd commands running at once, default number of CPUs")
+	flag.Int64Var(&CLIOpts.ConfirmationDepth, "confirmation-depth", 0, "number of blocks on top of inclusion block before tx is treated as final")
+	flag.StringVar(&CLIOpts.PylonsdPath, "pylonsd-path", "", "path of pylonsd binary, default $PYLONSD_PATH, pylonsd of $GOBIN, $GOPATH/bin or PATH")
+	flag.Var(&CLIOpts.Encoding, "encoding", "json encoding of node outputs, one of auto, proto and amino")
+}
+
+// GetPylonsdPath is a function to get path of pylonsd binary resolved by ResolvePylonsd
+// Binary name e.g. pylonsd.exe on windows is returned when it can't be resolved, commands report the resolution error.
+func GetPylonsdPath() string {
+	binary, err := ResolvePylonsd(context.Background())
+	if err != nil {
+		return pylonsdBinaryName(runtime.GOOS)
+	}
+	return binary.Path
 }
 
 // GetMaxWaitBlock is a function to get configuration for maximum wait block, default 3
 func GetMaxWaitBlock() int64 {
-	if CLIOpts.MaxWaitBlock == 0 {
-		return 3
-	}
-	return CLIOpts.MaxWaitBlock
+	return DefaultEnv().MaxWaitBlock()
+}
+
+// GetConfirmationDepth is a function to get configuration for confirmation depth, default 0
+func GetConfirmationDepth() int64 {
+	return DefaultEnv().ConfirmationDepth()
 }
 
 // GetMaxBroadcastRetry is a function to get configuration for maximum retry for transactio broadcast
 func GetMaxBroadcastRetry() int {
-	if CLIOpts.MaxBroadcast == 0 {
-		return 50
-	}
-	return CLIOpts.MaxBroadcast
+	return DefaultEnv().MaxBroadcastRetry()
 }
 
 // ReadFile is a utility function to read file
@@ -101,139 +169,198 @@ func GetTxJSONDecoder() sdk.TxDecoder {
 
 // KeyringBackendSetup is a utility function to setup keyring backend for pylonsd command
 func KeyringBackendSetup(args []string) []string {
-	if len(args) == 0 {
-		return args
-	}
-	switch args[0] {
-	case "keys":
-		if args[1] == "show" {
-			return append(args,
-				fmt.Sprintf("--%s=test", flags.FlagKeyringBackend),
-			)
-		}
-		return append(args,
-			fmt.Sprintf("--%s=test", flags.FlagKeyringBackend),
-			fmt.Sprintf("--%s=json", tmcli.OutputFlag),
-		)
-	case "query":
-		return append(args,
-			fmt.Sprintf("--%s=json", tmcli.OutputFlag),
-		)
-	case "tx":
-		argsWithTxCmd := append(args,
-			fmt.Sprintf("--%s=test", flags.FlagKeyringBackend),
-			fmt.Sprintf("--%s=pylonschain", flags.FlagChainID),
-			fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
-		)
-		if args[1] == "sign" {
-			return argsWithTxCmd
+	return KeyringBackendSetupWithProvider(args, GetKeyringProvider())
+}
+
+// hasFlag is a function to check if pylonsd arguments already set flag
+func hasFlag(args []string, name string) bool {
+	for _, arg := range args {
+		if arg == "--"+name || strings.HasPrefix(arg, "--"+name+"=") {
+			return true
 		}
-		if args[1] == "pylons" && args[2] == "create-account" {
-			return argsWithTxCmd
+	}
+	return false
+}
+
+// appendMissingFlags is a function to append flags rendered as "--name=value" or "--name" which are not set on args yet
+func appendMissingFlags(args []string, flagArgs ...string) []string {
+	for _, flagArg := range flagArgs {
+		name := strings.SplitN(strings.TrimPrefix(flagArg, "--"), "=", 2)[0]
+		if !hasFlag(args, name) {
+			args = append(args, flagArg)
 		}
-		return args
-	default:
+	}
+	return args
+}
+
+// KeyringBackendSetupWithProvider is a utility function to setup keyring backend of provider for pylonsd command
+// Json output is requested from commands supporting --output flag. Flags already set on args are kept.
+// Args not fitting the supported command grammar are returned as they are, see KeyringBackendSetupStrict.
+func KeyringBackendSetupWithProvider(args []string, provider KeyringProvider) []string {
+	setup, err := KeyringBackendSetupStrict(args, provider)
+	if err != nil {
 		return args
 	}
+	return setup
 }
 
-// NodeFlagSetup is a utility function to setup configured custom node
+// NodeFlagSetup is a utility function to setup configured custom node, node set on args is kept
+// Args not fitting the supported command grammar are returned as they are, see NodeFlagSetupStrict.
 func NodeFlagSetup(args []string) []string {
-	if len(CLIOpts.CustomNode) > 0 {
-		if args[0] == "query" || args[0] == "tx" || args[0] == "status" {
-			customNodes := strings.Split(CLIOpts.CustomNode, ",")
-			randNodeIndex := rand.Intn(len(customNodes))
-			randNode := customNodes[randNodeIndex]
-			args = append(args, "--node", randNode)
-		}
+	return DefaultEnv().nodeFlagSetup(args)
+}
+
+// RunPylonsdCtx is a function to run pylonsd, the command is killed when ctx is done
+func RunPylonsdCtx(ctx context.Context, args []string, stdinInput string) ([]byte, string, error) {
+	return RunPylonsdWithKeyring(ctx, EnvFromContext(ctx).Keyring(), args, stdinInput)
+}
+
+// RunPylonsdWithKeyring is a function to run pylonsd with keys of keyring provider
+// Queries and broadcasts are retried by retry policy when they fail with transient node errors
+func RunPylonsdWithKeyring(ctx context.Context, provider KeyringProvider, args []string, stdinInput string) ([]byte, string, error) {
+	if !isRetryableCommand(args) {
+		return runPylonsd(ctx, provider, args, stdinInput)
 	}
-	return args
+	var res []byte
+	var logstr string
+	err := EnvFromContext(ctx).RetryPolicy().Do(ctx, func() error {
+		var err error
+		// args are copied as flags are appended on each run
+		res, logstr, err = runPylonsd(ctx, provider, append([]string{}, args...), stdinInput)
+		return err
+	})
+	return res, logstr, err
 }
 
-// RunPylonsd is a function to run pylonsd
-func RunPylonsd(args []string, stdinInput string) ([]byte, string, error) {
-	args = NodeFlagSetup(args)
-	args = KeyringBackendSetup(args)
-	cliMux.Lock()
-	cmd := exec.Command(path.Join(os.Getenv("GOPATH"), "/bin/pylonsd"), args...)
-	cmd.Stdin = strings.NewReader(stdinInput)
-	res, err := cmd.CombinedOutput()
-	cliMux.Unlock()
+func runPylonsd(ctx context.Context, provider KeyringProvider, args []string, stdinInput string) ([]byte, string, error) {
+	command := cliCommandName(args)
+	if usesKeyring(args) {
+		stdinInput = provider.StdinInput() + stdinInput
+	}
+	args, err := EnvFromContext(ctx).nodeFlagSetupStrict(args)
+	if err == nil {
+		args, err = EnvFromContext(ctx).keyringBackendSetupStrict(args, provider)
+	}
+	if err != nil {
+		observeCLIInvocation(command, err)
+		return nil, fmt.Sprintf("\"pylonsd %s\" ==>\n%s\n", strings.Join(args, " "), err.Error()), err
+	}
+	req := TransportRequest{Transport: TransportCLI, Method: command, Args: args, Stdin: stdinInput}
+	res := withTransportHooks(ctx, req, func() TransportResponse {
+		output, logstr, err := execPylonsd(ctx, provider, command, args, stdinInput)
+		res := TransportResponse{Output: output, Log: logstr, Err: err}
+		if err == nil && (args[0] == "tx" || args[0] == "query" && args[1] == "tx") {
+			res.TxHash, res.Height = txResponseInfo(output)
+		}
+		return res
+	})
+	return res.Output, res.Log, res.Err
+}
+
+// execPylonsd is a function to run pylonsd command with arguments having flags set up
+func execPylonsd(ctx context.Context, provider KeyringProvider, command string, args []string, stdinInput string) ([]byte, string, error) {
+	if signer, ok := provider.(TxSigner); ok && isTxSignCommand(args) {
+		res, err := signer.SignTx(args)
+		return res, fmt.Sprintf("\"remote signer %s\" ==>\n%s\n", strings.Join(args, " "), string(res)), err
+	}
+	binary, err := ResolvePylonsd(ctx)
+	if err != nil {
+		observeCLIInvocation(command, err)
+		return nil, fmt.Sprintf("\"pylonsd %s\" ==>\nerror resolving pylonsd binary: %s\n", strings.Join(args, " "), err.Error()), err
+	}
+	var res []byte
+	poolErr := getCommandPool().run(ctx, writesKeyring(args), func() {
+		cmd := exec.CommandContext(ctx, binary.Path, args...)
+		cmd.Stdin = strings.NewReader(stdinInput)
+		res, err = cmd.CombinedOutput()
+	})
+	if writesKeyring(args) {
+		invalidateKeyAddresses()
+	}
+	switch {
+	case poolErr != nil:
+		err = poolErr
+	case ctx.Err() != nil:
+		err = ctx.Err()
+	case err != nil:
+		err = NewCommandError(err, string(res))
+	case supportsJSONOutput(args) && outputFlagValue(args) == string(OutputFormatJSON):
+		// nodes of older cli ignore --output flag or print log lines before json
+		if normalized, _, normErr := NormalizeJSONOutput(res); normErr == nil {
+			res = normalized
+		}
+	}
+	observeCLIInvocation(command, err)
 	return res, fmt.Sprintf("\"pylonsd %s\" ==>\n%s\n", strings.Join(args, " "), string(res)), err
 }
 
 // GetAccountAddr is a function to get account address from key
 func GetAccountAddr(account string, t *testing.T) string {
-	addrBytes, logstr, err := RunPylonsd([]string{"keys", "show", account, "-a"}, "")
-	addr := strings.Trim(string(addrBytes), "\n ")
+	return GetAccountAddrWithKeyring(GetKeyringProvider(), account, t)
+}
+
+// LookupAccountAddr is a function to get account address from key, it returns error instead of failing the test
+// e.g. for keys of temp names which may not be created.
+func LookupAccountAddr(account string) (string, error) {
+	return keyringAddress(GetKeyringProvider(), account)
+}
+
+// GetAccountAddrWithKeyring is a function to get account address from key of keyring provider
+func GetAccountAddrWithKeyring(provider KeyringProvider, account string, t *testing.T) string {
+	cacheKey := addressCacheKey(DefaultEnv(), provider, account)
+	if cached, ok := queryResults.get(cacheKey, 0); ok {
+		return cached.(string)
+	}
+	addrBytes, logstr, err := Keys().Show(account, true).WithKeyring(provider).Run(context.Background())
+	// output ends with \r\n on windows
+	addr := strings.TrimSpace(string(addrBytes))
 	t.WithFields(testing.Fields{
 		"account": account,
 		"log":     logstr,
 	}).MustNil(err, "error getting account address")
+	if err == nil {
+		queryResults.set(cacheKey, addr, 0)
+	}
 	return addr
 }
 
 // GetAccountInfoFromAddr is a function to get account information from address
-func GetAccountInfoFromAddr(addr string, t *testing.T) authtypes.AccountI {
+func GetAccountInfoFromAddr(addr string, t *testing.T, opts ...QueryOption) authtypes.AccountI {
+	t.InvolveAccounts(addr)
 	var accountI authtypes.AccountI
-	accBytes, logstr, err := RunPylonsd([]string{"query", "account", addr}, "")
+	ctx := queryContext(opts)
+	transport, err := EnvFromContext(ctx).Transport()
+	if err == nil {
+		accountI, err = transport.Account(ctx, addr)
+	}
 	t.WithFields(testing.Fields{
 		"address": addr,
-		"log":     logstr,
 	}).MustNil(err, "error getting account info")
-	if err != nil {
-		return accountI
-	}
-
-	var any codectypes.Any
-	cdc := codec.NewProtoCodec(GetInterfaceRegistry())
-	err = cdc.UnmarshalJSON(accBytes, &any)
-	t.WithFields(testing.Fields{
-		"acc_bytes": string(accBytes),
-	}).MustNil(err, "error decoding raw json")
-
-	err = cdc.UnpackAny(&any, &accountI)
-	// err = account.UnpackInterfaces(GetInterfaceRegistry())
-	t.MustNil(err, "error unpacking any")
-
-	// t.WithFields(testing.Fields{
-	// 	"account_info": accInfo,
-	// }).Debug("debug log")
 	return accountI
 }
 
-// GetAccountInfoFromAddr is a function to get account information from address
-func GetAccountBalanceFromAddr(addr string, t *testing.T) banktypes.Balance {
-	var queryRes banktypes.QueryAllBalancesResponse
-	accBytes, logstr, err := RunPylonsd([]string{"query", "bank", "balances", addr}, "")
+// GetAccountBalanceFromAddr is a function to get account balance from address
+func GetAccountBalanceFromAddr(addr string, t *testing.T, opts ...QueryOption) banktypes.Balance {
+	t.InvolveAccounts(addr)
+	var coins sdk.Coins
+	ctx := queryContext(opts)
+	transport, err := EnvFromContext(ctx).Transport()
+	if err == nil {
+		coins, err = transport.Balances(ctx, addr)
+	}
 	t.WithFields(testing.Fields{
 		"address": addr,
-		"log":     logstr,
 	}).MustNil(err, "error getting account balance")
-	if err != nil {
-		return banktypes.Balance{
-			Address: addr,
-			Coins:   queryRes.Balances,
-		}
-	}
-	err = GetJSONMarshaler().UnmarshalJSON(accBytes, &queryRes)
-	t.WithFields(testing.Fields{
-		"acc_bytes": string(accBytes),
-	}).MustNil(err, "error decoding raw json")
-	// t.WithFields(testing.Fields{
-	// 	"address":      addr,
-	// 	"account_info": queryRes.Balances,
-	// }).Debug("debug log")
 	return banktypes.Balance{
 		Address: addr,
-		Coins:   queryRes.Balances,
+		Coins:   coins,
 	}
 }
 
 // GetAccountInfoFromName is a function to get account information from account key
-func GetAccountInfoFromName(account string, t *testing.T) authtypes.AccountI {
+func GetAccountInfoFromName(account string, t *testing.T, opts ...QueryOption) authtypes.AccountI {
 	addr := GetAccountAddr(account, t)
-	return GetAccountInfoFromAddr(addr, t)
+	return GetAccountInfoFromAddr(addr, t, opts...)
 }
 
 // ValidatorInfo is info about the node's validator, same as Tendermint,
@@ -252,16 +379,20 @@ type resultStatus struct {
 	ValidatorInfo validatorInfo
 }
 
-// GetDaemonStatus is a function to get daemon status
-func GetDaemonStatus() (*ctypes.ResultStatus, string, error) {
+// queryDaemonStatusFromNode is a function to get daemon status of node by running pylonsd status, one of nodes of env is used when node is empty
+func queryDaemonStatusFromNode(ctx context.Context, node string) (*ctypes.ResultStatus, string, error) {
 	var ds resultStatus
 
-	dsBytes, logstr, err := RunPylonsd([]string{"status"}, "")
+	cmd := Status()
+	if len(node) > 0 {
+		cmd.Node(node)
+	}
+	dsBytes, logstr, err := cmd.Run(ctx)
 
 	if err != nil {
 		return nil, logstr, err
 	}
-	err = GetAminoCdc().UnmarshalJSON(dsBytes, &ds)
+	err = EnvFromContext(ctx).Codec().Decode(dsBytes, &ds)
 
 	if err != nil {
 		return nil, logstr, err
@@ -283,32 +414,9 @@ func GetDaemonStatus() (*ctypes.ResultStatus, string, error) {
 	}, logstr, nil
 }
 
-// WaitForNextBlock is a function to wait until next block
-func WaitForNextBlock() error {
-	return WaitForBlockInterval(1)
-}
-
-// WaitForBlockInterval is a function to wait until block heights to flow
-func WaitForBlockInterval(interval int64) error {
-	ds, _, err := GetDaemonStatus()
-	if err != nil {
-		return err // couldn't get daemon status.
-	}
-	currentBlock := ds.SyncInfo.LatestBlockHeight
-
-	counter := int64(1)
-	for counter < 300*interval {
-		ds, _, err = GetDaemonStatus()
-		if err != nil {
-			return err
-		}
-		if ds.SyncInfo.LatestBlockHeight >= currentBlock+interval {
-			return nil
-		}
-		time.Sleep(100 * time.Millisecond)
-		counter++
-	}
-	return errors.New("You are waiting too long time for interval")
+// WaitForNextBlockCtx is a function to wait until next block
+func WaitForNextBlockCtx(ctx context.Context) error {
+	return WaitForBlockIntervalCtx(ctx, 1)
 }
 
 // CleanFile is a function to remove file
@@ -322,10 +430,9 @@ func CleanFile(filePath string, t *testing.T) {
 	}
 }
 
-// AminoCodecFormatter format structs better by encoding in amino codec
+// AminoCodecFormatter format structs better by encoding in codec of node outputs
 func AminoCodecFormatter(param interface{}) string {
-	cdc := GetAminoCdc()
-	output, err := cdc.MarshalJSON(param)
+	output, err := GetCodec().Encode(param)
 	if err == nil {
 		return string(output)
 	}
@@ -388,6 +495,55 @@ func GetLogFieldsFromMsgs(txMsgs []sdk.Msg) log.Fields {
 			fields[ikeypref+"type"] = "MsgUpdateItemString"
 			fields[ikeypref+"item_id"] = msg.ItemID
 			fields[ikeypref+"sender"] = msg.Sender
+		default:
+			for key, value := range getLogFieldsFromMsgReflect(msg) {
+				fields[ikeypref+key] = value
+			}
+		}
+	}
+	return fields
+}
+
+// senderFieldNamesFromMsg is the list of msg struct fields which can keep msg sender, in priority order
+var senderFieldNamesFromMsg = []string{"Sender", "FromAddress", "Requester"}
+
+// logFieldNamesFromMsg is the list of msg struct fields to be logged for msgs not listed on GetLogFieldsFromMsgs
+var logFieldNamesFromMsg = map[string]string{
+	"ID":         "id",
+	"Name":       "name",
+	"CookbookID": "cb_id",
+	"RecipeID":   "rcp_id",
+	"ExecID":     "exec_id",
+	"TradeID":    "trade_id",
+	"ItemID":     "item_id",
+	"ItemIDs":    "item_ids",
+	"Receiver":   "receiver",
+	"Amount":     "amount",
+}
+
+// getLogFieldsFromMsgReflect fetch type, sender and identifying fields from any msg by reflection
+func getLogFieldsFromMsgReflect(msg sdk.Msg) log.Fields {
+	fields := log.Fields{}
+	msgValue := reflect.Indirect(reflect.ValueOf(msg))
+	fields["type"] = msgValue.Type().Name()
+	if msgValue.Kind() != reflect.Struct {
+		return fields
+	}
+	for _, fieldName := range senderFieldNamesFromMsg {
+		if sender := msgValue.FieldByName(fieldName); sender.IsValid() && sender.Kind() == reflect.String {
+			fields["sender"] = sender.String()
+			break
+		}
+	}
+	for fieldName, key := range logFieldNamesFromMsg {
+		field := msgValue.FieldByName(fieldName)
+		if !field.IsValid() || field.IsZero() {
+			continue
+		}
+		if stringer, ok := field.Interface().(fmt.Stringer); ok {
+			fields[key] = stringer.String()
+		} else {
+			fields[key] = field.Interface()
 		}
 	}
 	return fields
@@ -414,15 +570,12 @@ func Exists(slice []string, val string) bool {
 
 // GetTxHashFromJson parse txhash and error code from json format of transaction log
 func GetTxHashFromJson(result string) (string, error) {
-	jsonMap := make(map[string]interface{})
-	err := json.Unmarshal([]byte(result), &jsonMap)
+	txResult, err := ParseTxResult([]byte(result))
 	if err != nil {
-		panic(err)
+		return "", err
 	}
-
-	if jsonMap["code"].(float64) != 0 {
-		return "", errors.New(jsonMap["raw_log"].(string))
+	if err = txResult.Err(); err != nil {
+		return "", err
 	}
-
-	return jsonMap["txhash"].(string), nil
+	return txResult.TxHash, nil
 }
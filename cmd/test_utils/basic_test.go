@@ -0,0 +1,23 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestGetLogFieldsFromMsgs(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	sendItemsMsg := types.NewMsgSendItems([]string{"item001"}, "sender001", "receiver001")
+	createCookbookMsg := types.NewMsgCreateCookbook("cookbook001", "", "", "", "", "", 0, 0, "sender002")
+	fields := GetLogFieldsFromMsgs([]sdk.Msg{&sendItemsMsg, &createCookbookMsg})
+
+	t.WithFields(testing.Fields(fields)).MustTrue(fields["tx_msg0_type"] == "MsgSendItems", "unlisted msg type should be fetched by reflection")
+	t.WithFields(testing.Fields(fields)).MustTrue(fields["tx_msg0_sender"] == "sender001", "unlisted msg sender should be fetched by reflection")
+	t.WithFields(testing.Fields(fields)).MustTrue(fields["tx_msg0_receiver"] == "receiver001", "unlisted msg receiver should be fetched by reflection")
+	t.WithFields(testing.Fields(fields)).MustTrue(fields["tx_msg1_type"] == "MsgCreateCookbook", "listed msg type should be kept")
+	t.WithFields(testing.Fields(fields)).MustTrue(fields["tx_msg1_cb_name"] == "cookbook001", "listed msg fields should be kept")
+}
@@ -0,0 +1,379 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ThinkTime is a function to sample time an account takes before its next transaction
+type ThinkTime func(rnd *rand.Rand) time.Duration
+
+// FixedThinkTime is a function to get think time of constant d, e.g. for bots acting on a timer
+func FixedThinkTime(d time.Duration) ThinkTime {
+	return func(rnd *rand.Rand) time.Duration {
+		return d
+	}
+}
+
+// UniformThinkTime is a function to get think time uniformly distributed in [min, max)
+func UniformThinkTime(min, max time.Duration) ThinkTime {
+	return func(rnd *rand.Rand) time.Duration {
+		if max <= min {
+			return min
+		}
+		return min + time.Duration(rnd.Int63n(int64(max-min)))
+	}
+}
+
+// ExponentialThinkTime is a function to get exponentially distributed think time of mean, so transactions of
+// an account arrive as a poisson process
+func ExponentialThinkTime(mean time.Duration) ThinkTime {
+	return func(rnd *rand.Rand) time.Duration {
+		return time.Duration(rnd.ExpFloat64() * float64(mean))
+	}
+}
+
+// LogNormalThinkTime is a function to get log-normally distributed think time of median, sigma is the standard
+// deviation of its logarithm, e.g. 1 makes humans wait from a third of median to three times of it most of the time
+func LogNormalThinkTime(median time.Duration, sigma float64) ThinkTime {
+	return func(rnd *rand.Rand) time.Duration {
+		return time.Duration(float64(median) * math.Exp(sigma*rnd.NormFloat64()))
+	}
+}
+
+// WeightedSelector is a struct to pick indices of weights randomly in proportion to them
+type WeightedSelector struct {
+	cumulative []int
+}
+
+// NewWeightedSelector is a function to create selector of weights, weights should not be negative and should not sum up to 0
+func NewWeightedSelector(weights []int) (WeightedSelector, error) {
+	selector := WeightedSelector{}
+	total := 0
+	for idx, weight := range weights {
+		if weight < 0 {
+			return WeightedSelector{}, fmt.Errorf("weight %d is negative: %d", idx, weight)
+		}
+		total += weight
+		selector.cumulative = append(selector.cumulative, total)
+	}
+	if total == 0 {
+		return WeightedSelector{}, errors.New("weights sum up to 0")
+	}
+	return selector, nil
+}
+
+// Pick is a function to get a random index, index of weight w is picked w/total of the time
+func (s WeightedSelector) Pick(rnd *rand.Rand) int {
+	slot := rnd.Intn(s.cumulative[len(s.cumulative)-1])
+	return sort.SearchInts(s.cumulative, slot+1)
+}
+
+// BehaviorAction is a struct to describe a kind of transaction sent by accounts of a behavior profile and its share
+type BehaviorAction struct {
+	Name   string
+	Weight int
+	// Build returns msgs of seq-th transaction of account, they are signed by account
+	Build func(account string, seq int64) ([]sdk.Msg, error)
+}
+
+// BehaviorProfile is a struct to describe how a kind of user e.g. whale, casual player or trader bot sends transactions
+type BehaviorProfile struct {
+	Name string
+	// Weight is the share of accounts having the profile
+	Weight int
+	// Actions is the msg mix of the profile, each transaction picks an action randomly by weight
+	Actions []BehaviorAction
+	// ThinkTime is time between the result of a transaction of an account and its next transaction
+	ThinkTime ThinkTime
+}
+
+// validate is a function to check behavior profile configuration
+func (p BehaviorProfile) validate() error {
+	if p.Weight <= 0 || p.ThinkTime == nil {
+		return fmt.Errorf("behavior profile %s should have positive weight and think time", p.Name)
+	}
+	if len(p.Actions) == 0 {
+		return fmt.Errorf("behavior profile %s has no action", p.Name)
+	}
+	for _, action := range p.Actions {
+		if action.Weight <= 0 || action.Build == nil {
+			return fmt.Errorf("action %s of behavior profile %s should have positive weight and msg builder", action.Name, p.Name)
+		}
+	}
+	return nil
+}
+
+// actionSelector is a function to get selector of actions of the profile
+func (p BehaviorProfile) actionSelector() (WeightedSelector, error) {
+	weights := []int{}
+	for _, action := range p.Actions {
+		weights = append(weights, action.Weight)
+	}
+	return NewWeightedSelector(weights)
+}
+
+// ExecuteRecipeAction is a function to create action executing a recipe without item inputs
+func ExecuteRecipeAction(weight int, recipeID string) BehaviorAction {
+	return BehaviorAction{
+		Name:   "execute_recipe",
+		Weight: weight,
+		Build: func(account string, seq int64) ([]sdk.Msg, error) {
+			msg := types.NewMsgExecuteRecipe(recipeID, account, []string{})
+			return []sdk.Msg{&msg}, nil
+		},
+	}
+}
+
+// CreateTradeAction is a function to create action creating trade of coinOutputs for pylons
+func CreateTradeAction(weight int, pylons int64, coinOutputs sdk.Coins) BehaviorAction {
+	return BehaviorAction{
+		Name:   "create_trade",
+		Weight: weight,
+		Build: func(account string, seq int64) ([]sdk.Msg, error) {
+			msg := types.NewMsgCreateTrade(
+				types.CoinInputList{{Coin: types.Pylon, Count: pylons}},
+				types.TradeItemInputList{},
+				coinOutputs,
+				types.ItemList{},
+				fmt.Sprintf("load trade %s %d", account, seq),
+				account,
+			)
+			return []sdk.Msg{&msg}, nil
+		},
+	}
+}
+
+// GetPylonsAction is a function to create action getting pylons, it works on chains allowing get pylons e.g. devnets
+func GetPylonsAction(weight int, amount sdk.Coins) BehaviorAction {
+	return BehaviorAction{
+		Name:   "get_pylons",
+		Weight: weight,
+		Build: func(account string, seq int64) ([]sdk.Msg, error) {
+			msg := types.NewMsgGetPylons(amount, account)
+			return []sdk.Msg{&msg}, nil
+		},
+	}
+}
+
+// SendCoinsAction is a function to create action sending amount to receivers in turn
+func SendCoinsAction(weight int, amount sdk.Coins, receivers ...string) BehaviorAction {
+	return BehaviorAction{
+		Name:   "send_coins",
+		Weight: weight,
+		Build: func(account string, seq int64) ([]sdk.Msg, error) {
+			if len(receivers) == 0 {
+				return nil, errors.New("sending coins requires receivers")
+			}
+			msg := types.NewMsgSendCoins(amount, account, roundRobin(receivers, seq))
+			return []sdk.Msg{&msg}, nil
+		},
+	}
+}
+
+// WhaleProfile is a function to create profile of few accounts executing recipe often and topping up pylons in bulk
+func WhaleProfile(weight int, recipeID string) BehaviorProfile {
+	return BehaviorProfile{
+		Name:   "whale",
+		Weight: weight,
+		Actions: []BehaviorAction{
+			ExecuteRecipeAction(8, recipeID),
+			GetPylonsAction(2, types.NewPylon(100000)),
+		},
+		ThinkTime: LogNormalThinkTime(5*time.Second, 0.5),
+	}
+}
+
+// CasualPlayerProfile is a function to create profile of many accounts executing recipe now and then
+func CasualPlayerProfile(weight int, recipeID string) BehaviorProfile {
+	return BehaviorProfile{
+		Name:   "casual",
+		Weight: weight,
+		Actions: []BehaviorAction{
+			ExecuteRecipeAction(9, recipeID),
+			GetPylonsAction(1, types.NewPylon(500)),
+		},
+		ThinkTime: LogNormalThinkTime(time.Minute, 1),
+	}
+}
+
+// TraderBotProfile is a function to create profile of accounts creating trades of coinOutputs for pylons at a steady pace
+func TraderBotProfile(weight int, pylons int64, coinOutputs sdk.Coins) BehaviorProfile {
+	return BehaviorProfile{
+		Name:      "trader_bot",
+		Weight:    weight,
+		Actions:   []BehaviorAction{CreateTradeAction(1, pylons, coinOutputs)},
+		ThinkTime: UniformThinkTime(time.Second, 3*time.Second),
+	}
+}
+
+// BehaviorLoad is a struct to send transactions of accounts acting by behavior profiles, so that traffic resembles
+// production where few whales and bots send most transactions, instead of uniform transactions at fixed rate
+// Each account sends a transaction, waits for it and thinks before the next one, so accounts bound concurrency.
+type BehaviorLoad struct {
+	// Accounts are addresses of accounts, each is assigned a profile randomly by weight of profiles
+	Accounts []string
+	// Profiles are behavior profiles of accounts
+	Profiles []BehaviorProfile
+	// Duration is how long transactions are started
+	Duration time.Duration
+	// Seed makes profiles of accounts, actions and think times reproducible
+	Seed int64
+	// WaitResult makes latency include the wait for the transaction result, otherwise it's broadcast latency
+	WaitResult bool
+	// Client sends transactions, NewClient() is used when it's nil
+	Client *Client
+	// Stop stops starting transactions when it's closed while in flight ones conclude, GlobalShutdown.Done() when it's nil
+	Stop <-chan struct{}
+}
+
+// validate is a function to check behavior load configuration
+func (bl BehaviorLoad) validate() error {
+	if bl.Duration <= 0 {
+		return errors.New("duration should be positive")
+	}
+	if len(bl.Accounts) == 0 {
+		return errors.New("no account is given")
+	}
+	if len(bl.Profiles) == 0 {
+		return errors.New("no behavior profile is given")
+	}
+	for _, profile := range bl.Profiles {
+		if err := profile.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssignProfiles is a function to get index of profile of each account, profiles are picked by weight with Seed
+func (bl BehaviorLoad) AssignProfiles() ([]int, error) {
+	weights := []int{}
+	for _, profile := range bl.Profiles {
+		weights = append(weights, profile.Weight)
+	}
+	selector, err := NewWeightedSelector(weights)
+	if err != nil {
+		return nil, err
+	}
+	rnd := rand.New(rand.NewSource(bl.Seed))
+	assigned := []int{}
+	for range bl.Accounts {
+		assigned = append(assigned, selector.Pick(rnd))
+	}
+	return assigned, nil
+}
+
+// Run is a function to run accounts by their profiles for Duration and report throughput, latency and failures
+// SentByMix of the report is keyed by profile and action e.g. "whale/execute_recipe".
+// Canceling ctx stops the run early and aborts transactions in flight, while closing Stop lets them conclude.
+func (bl BehaviorLoad) Run(ctx context.Context, t *testing.T) (LoadReport, error) {
+	if err := bl.validate(); err != nil {
+		return LoadReport{}, err
+	}
+	assigned, err := bl.AssignProfiles()
+	if err != nil {
+		return LoadReport{}, err
+	}
+	selectors := []WeightedSelector{}
+	for _, profile := range bl.Profiles {
+		selector, err := profile.actionSelector()
+		if err != nil {
+			return LoadReport{}, err
+		}
+		selectors = append(selectors, selector)
+	}
+	client := bl.Client
+	if client == nil {
+		client = NewClient()
+	}
+	stop := bl.Stop
+	if stop == nil {
+		stop = GlobalShutdown.Done()
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	samples := []loadSample{}
+	deadline := time.After(bl.Duration)
+	done := make(chan struct{})
+	stopped := false
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stop:
+			mu.Lock()
+			stopped = true
+			mu.Unlock()
+		case <-deadline:
+		}
+		close(done)
+	}()
+
+	act := func(account string, profile BehaviorProfile, action BehaviorAction, seq int64) loadSample {
+		sample := loadSample{mix: profile.Name + "/" + action.Name}
+		msgs, err := action.Build(account, seq)
+		if err != nil {
+			sample.failure = "build"
+			return sample
+		}
+		start := time.Now()
+		txResult := TxResult{}
+		if bl.WaitResult {
+			txResult, err = client.SendTxAndWait(ctx, t, SignerAddress(account), msgs...)
+		} else {
+			_, err = client.SendTx(ctx, t, SignerAddress(account), msgs...)
+		}
+		sample.latency = time.Since(start)
+		if err != nil {
+			sample.failure = failureName(txResult, err)
+		}
+		return sample
+	}
+
+	start := time.Now()
+	for idx, account := range bl.Accounts {
+		wg.Add(1)
+		go func(idx int, account string) {
+			defer wg.Done()
+			profile, selector := bl.Profiles[assigned[idx]], selectors[assigned[idx]]
+			rnd := rand.New(rand.NewSource(bl.Seed + int64(idx) + 1))
+			for seq := int64(0); ; seq++ {
+				select {
+				case <-done:
+					return
+				case <-time.After(profile.ThinkTime(rnd)):
+				}
+				action := profile.Actions[selector.Pick(rnd)]
+				sample := act(account, profile, action, seq)
+				result := "success"
+				if len(sample.failure) > 0 {
+					result = "failure"
+				} else {
+					loadTxLatency.WithLabelValues(sample.mix).Observe(sample.latency.Seconds())
+				}
+				loadTxs.WithLabelValues(sample.mix, result).Inc()
+				mu.Lock()
+				samples = append(samples, sample)
+				mu.Unlock()
+			}
+		}(idx, account)
+	}
+	wg.Wait()
+	report := newLoadReport(samples, 0, time.Since(start))
+	report.Stopped = stopped
+	t.WithFields(testing.Fields{
+		"report": report.String(),
+	}).Info("behavior load test finished")
+	return report, nil
+}
@@ -0,0 +1,108 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestWeightedSelector(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	selector, err := NewWeightedSelector([]int{1, 0, 3})
+	t.MustNil(err, "error creating weighted selector")
+	rnd := rand.New(rand.NewSource(1))
+	picked := map[int]int{}
+	for idx := 0; idx < 4000; idx++ {
+		picked[selector.Pick(rnd)]++
+	}
+	t.WithFields(testing.Fields{
+		"picked": picked,
+	}).MustTrue(picked[1] == 0 && picked[0] > 850 && picked[0] < 1150 && picked[2] > 2850 && picked[2] < 3150, "indices should be picked in proportion to weights")
+
+	_, err = NewWeightedSelector([]int{0, 0})
+	t.MustTrue(err != nil, "weights summing up to 0 should be rejected")
+	_, err = NewWeightedSelector([]int{2, -1})
+	t.MustTrue(err != nil, "negative weight should be rejected")
+}
+
+func TestThinkTime(originT *originT.T) {
+	t := testing.NewT(originT)
+	rnd := rand.New(rand.NewSource(1))
+	sample := func(thinkTime ThinkTime) []time.Duration {
+		durations := []time.Duration{}
+		for idx := 0; idx < 2001; idx++ {
+			durations = append(durations, thinkTime(rnd))
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		return durations
+	}
+
+	uniform := sample(UniformThinkTime(time.Second, 3*time.Second))
+	t.MustTrue(uniform[0] >= time.Second && uniform[len(uniform)-1] < 3*time.Second, "uniform think time should be in range")
+	median := sample(LogNormalThinkTime(time.Minute, 1))[1000]
+	t.WithFields(testing.Fields{
+		"median": median.String(),
+	}).MustTrue(median > 50*time.Second && median < 70*time.Second, "log-normal think time should have median")
+	total := time.Duration(0)
+	for _, d := range sample(ExponentialThinkTime(time.Second)) {
+		total += d
+	}
+	mean := total / 2001
+	t.WithFields(testing.Fields{
+		"mean": mean.String(),
+	}).MustTrue(mean > 900*time.Millisecond && mean < 1100*time.Millisecond, "exponential think time should have mean")
+}
+
+func TestBehaviorLoad(originT *originT.T) {
+	t := testing.NewT(originT)
+	failing := func(name string, weight int) BehaviorAction {
+		return BehaviorAction{Name: name, Weight: weight, Build: func(account string, seq int64) ([]sdk.Msg, error) {
+			return nil, errors.New("no msg")
+		}}
+	}
+	accounts := []string{}
+	for idx := 0; idx < 40; idx++ {
+		accounts = append(accounts, string(rune('a'+idx%26))+string(rune('a'+idx/26)))
+	}
+	load := BehaviorLoad{
+		Accounts: accounts,
+		Profiles: []BehaviorProfile{
+			{Name: "whale", Weight: 1, Actions: []BehaviorAction{failing("execute_recipe", 1)}, ThinkTime: FixedThinkTime(time.Millisecond)},
+			{Name: "casual", Weight: 3, Actions: []BehaviorAction{failing("execute_recipe", 1), failing("get_pylons", 1)}, ThinkTime: FixedThinkTime(time.Hour)},
+		},
+		Duration: 100 * time.Millisecond,
+		Seed:     7,
+		Client:   NewClient(),
+	}
+	assigned, err := load.AssignProfiles()
+	t.MustNil(err, "error assigning profiles")
+	whales := 0
+	for _, profile := range assigned {
+		if profile == 0 {
+			whales++
+		}
+	}
+	t.WithFields(testing.Fields{
+		"whales": whales,
+	}).MustTrue(whales > 0 && whales < 20, "accounts should be assigned profiles by weight")
+	again, _ := load.AssignProfiles()
+	t.MustTrue(len(again) == len(assigned) && again[0] == assigned[0] && again[39] == assigned[39], "assignment should be reproducible by seed")
+
+	report, err := load.Run(context.Background(), &t)
+	t.MustNil(err, "error running behavior load")
+	t.WithFields(testing.Fields{
+		"report": report.String(),
+	}).MustTrue(report.SentByMix["whale/execute_recipe"] > whales && report.SentByMix["casual/execute_recipe"] == 0 && report.Failures["build"] == report.Sent,
+		"accounts should act by think time of their profile")
+
+	load.Profiles[0].Weight = 0
+	_, err = load.Run(context.Background(), &t)
+	t.MustTrue(err != nil, "profile without weight should be rejected")
+}
@@ -0,0 +1,200 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// BroadcastMode is how a node handles broadcast transaction before it responds
+type BroadcastMode string
+
+// describes the broadcast modes and how their transactions are followed up
+const (
+	// BroadcastModeSync returns after CheckTx, CheckTx code is checked and the transaction is waited by polling
+	BroadcastModeSync BroadcastMode = "sync"
+	// BroadcastModeAsync returns right away without CheckTx, the transaction is waited by tx event subscription
+	BroadcastModeAsync BroadcastMode = "async"
+	// BroadcastModeBlock returns after the transaction is committed, its result is parsed from the response without waiting
+	BroadcastModeBlock BroadcastMode = "block"
+)
+
+// BroadcastModes is the list of all broadcast modes
+var BroadcastModes = []BroadcastMode{BroadcastModeSync, BroadcastModeAsync, BroadcastModeBlock}
+
+func init() {
+	flag.StringVar((*string)(&CLIOpts.BroadcastMode), "broadcast-mode", string(BroadcastModeSync), "broadcast mode of transactions, one of sync, async and block")
+}
+
+// ParseBroadcastMode is a function to get broadcast mode from its name, sync when name is empty
+func ParseBroadcastMode(name string) (BroadcastMode, error) {
+	if len(name) == 0 {
+		return BroadcastModeSync, nil
+	}
+	for _, mode := range BroadcastModes {
+		if strings.EqualFold(name, string(mode)) {
+			return mode, nil
+		}
+	}
+	return BroadcastModeSync, fmt.Errorf("unknown broadcast mode %s, it should be one of sync, async and block", name)
+}
+
+// cliFlag is a function to get value of --broadcast-mode flag of pylonsd
+func (m BroadcastMode) cliFlag() string {
+	switch m {
+	case BroadcastModeAsync:
+		return flags.BroadcastAsync
+	case BroadcastModeBlock:
+		return flags.BroadcastBlock
+	}
+	return flags.BroadcastSync
+}
+
+// txMode is a function to get broadcast mode of grpc and grpc-gateway broadcast requests
+func (m BroadcastMode) txMode() txtypes.BroadcastMode {
+	switch m {
+	case BroadcastModeAsync:
+		return txtypes.BroadcastMode_BROADCAST_MODE_ASYNC
+	case BroadcastModeBlock:
+		return txtypes.BroadcastMode_BROADCAST_MODE_BLOCK
+	}
+	return txtypes.BroadcastMode_BROADCAST_MODE_SYNC
+}
+
+// BroadcastMode is a function to get broadcast mode of env, sync when it's not set
+func (e *Env) BroadcastMode() BroadcastMode {
+	mode, err := ParseBroadcastMode(string(e.opts.BroadcastMode))
+	if err != nil {
+		return BroadcastModeSync
+	}
+	return mode
+}
+
+type broadcastModeKey struct{}
+
+// ContextWithBroadcastMode is a function to get context which transactions are broadcast in mode with
+func ContextWithBroadcastMode(ctx context.Context, mode BroadcastMode) context.Context {
+	return context.WithValue(ctx, broadcastModeKey{}, mode)
+}
+
+// BroadcastModeFromContext is a function to get broadcast mode of ctx, broadcast mode of env of ctx when ctx has none
+func BroadcastModeFromContext(ctx context.Context) BroadcastMode {
+	if mode, ok := ctx.Value(broadcastModeKey{}).(BroadcastMode); ok && len(mode) > 0 {
+		return mode
+	}
+	return EnvFromContext(ctx).BroadcastMode()
+}
+
+// CheckBroadcastResponse is a function to check response of transaction broadcast in mode
+// Async responses have no CheckTx code, and block responses of committed transactions are not errors
+// even when DeliverTx failed, so that the failure is reported by the transaction result like other modes.
+func CheckBroadcastResponse(mode BroadcastMode, txResponse sdk.TxResponse) error {
+	if len(txResponse.TxHash) == 0 {
+		return fmt.Errorf("%s broadcast response has no txhash", mode)
+	}
+	switch {
+	case mode == BroadcastModeAsync:
+		return nil
+	case mode == BroadcastModeBlock && txResponse.Height > 0:
+		return nil
+	case txResponse.Code != 0:
+		return NewTxError(txResponse.Codespace, txResponse.Code, txResponse.RawLog)
+	}
+	return nil
+}
+
+// broadcastTx is a struct to describe how a transaction is broadcast until it's followed up
+type broadcastTx struct {
+	mode     BroadcastMode
+	response sdk.TxResponse
+	stateKey string // key of chain state of env broadcasting the transaction
+}
+
+var broadcastTxs sync.Map // broadcastTx per txhash
+
+// rememberBroadcast is a function to keep broadcast mode and response of transaction for its follow-up
+func rememberBroadcast(env *Env, mode BroadcastMode, txResponse sdk.TxResponse) {
+	if mode == BroadcastModeSync || len(txResponse.TxHash) == 0 {
+		return
+	}
+	broadcastTxs.Store(strings.ToUpper(txResponse.TxHash), broadcastTx{mode: mode, response: txResponse, stateKey: env.stateKey()})
+}
+
+// lookupBroadcast is a function to get broadcast mode and response of transaction, false for sync mode
+func lookupBroadcast(txhash string) (broadcastTx, bool) {
+	tx, ok := broadcastTxs.Load(strings.ToUpper(txhash))
+	if !ok {
+		return broadcastTx{}, false
+	}
+	return tx.(broadcastTx), true
+}
+
+func forgetBroadcast(txhash string) {
+	broadcastTxs.Delete(strings.ToUpper(txhash))
+}
+
+// committedTxResponse is a function to get response of transaction committed by block mode broadcast
+func committedTxResponse(txhash string) (sdk.TxResponse, bool) {
+	tx, ok := lookupBroadcast(txhash)
+	if !ok || tx.mode != BroadcastModeBlock || tx.response.Height == 0 {
+		return sdk.TxResponse{}, false
+	}
+	return tx.response, true
+}
+
+// txSubscriberSeq is used to make subscriber names of concurrent tx subscriptions unique
+var txSubscriberSeq int64
+
+// WaitForTxEvent is a function to wait for transaction to be committed by subscribing its tx event on the first node
+// It returns ErrNodeUnavailable when websocket can't be connected, so callers can fall back to polling.
+func WaitForTxEvent(ctx context.Context, txhash string, maxWaitBlock int64) error {
+	env := EnvFromContext(ctx)
+	rpcClient, err := rpchttp.New(env.firstNode(), "/websocket")
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrNodeUnavailable, err.Error())
+	}
+	if err = rpcClient.Start(); err != nil {
+		return fmt.Errorf("%w: %s", ErrNodeUnavailable, err.Error())
+	}
+	defer func() {
+		_ = rpcClient.Stop()
+	}()
+
+	waitCtx, cancel := context.WithTimeout(ctx, blockWaitTimeout(env.opts.BlockTimeout, maxWaitBlock))
+	defer cancel()
+	subscriber := fmt.Sprintf("pylons_sdk_tx_%d", atomic.AddInt64(&txSubscriberSeq, 1))
+	query := fmt.Sprintf("%s AND %s='%s'", tmtypes.EventQueryTx.String(), tmtypes.TxHashKey, strings.ToUpper(txhash))
+	events, err := rpcClient.Subscribe(waitCtx, subscriber, query)
+	if err != nil {
+		return fmt.Errorf("%w: error subscribing tx %s: %s", ErrNodeUnavailable, txhash, err.Error())
+	}
+	defer func() {
+		_ = rpcClient.UnsubscribeAll(context.Background(), subscriber)
+	}()
+	// transaction may be committed before subscription started
+	if _, err := getTxResult(ctx, txhash); err == nil {
+		return nil
+	}
+	select {
+	case <-waitCtx.Done():
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("%w: tx %s is not committed", ErrWaitTimeout, txhash)
+	case _, ok := <-events:
+		if !ok {
+			return errors.New("tx subscription is closed")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,56 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+func TestBroadcastMode(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	for name, expected := range map[string]BroadcastMode{
+		"":      BroadcastModeSync,
+		"sync":  BroadcastModeSync,
+		"ASYNC": BroadcastModeAsync,
+		"block": BroadcastModeBlock,
+	} {
+		mode, err := ParseBroadcastMode(name)
+		t.WithFields(testing.Fields{
+			"name": name,
+		}).MustTrue(err == nil && mode == expected, "broadcast mode should be parsed")
+	}
+	_, err := ParseBroadcastMode("commit")
+	t.MustTrue(err != nil, "unknown broadcast mode should fail")
+	t.MustTrue(BroadcastModeBlock.cliFlag() == "block" && BroadcastModeAsync.txMode() == txtypes.BroadcastMode_BROADCAST_MODE_ASYNC,
+		"broadcast mode should be converted for cli and grpc")
+
+	env := NewEnv(CLIOptions{BroadcastMode: BroadcastModeAsync}, nil)
+	ctx := ContextWithEnv(context.Background(), env)
+	t.MustTrue(BroadcastModeFromContext(ctx) == BroadcastModeAsync, "broadcast mode of env should be used")
+	ctx = ContextWithBroadcastMode(ctx, BroadcastModeBlock)
+	t.MustTrue(BroadcastModeFromContext(ctx) == BroadcastModeBlock, "broadcast mode of context should override env")
+
+	checkTxFailure := sdk.TxResponse{TxHash: "HASH", Code: 5, Codespace: "sdk", RawLog: "insufficient funds"}
+	deliverTxFailure := sdk.TxResponse{TxHash: "HASH", Height: 10, Code: 5, Codespace: "sdk", RawLog: "insufficient funds"}
+	var txErr *CommandError
+	t.MustTrue(errors.As(CheckBroadcastResponse(BroadcastModeSync, checkTxFailure), &txErr), "sync response should be checked by CheckTx code")
+	t.MustNil(CheckBroadcastResponse(BroadcastModeAsync, checkTxFailure), "async response has no CheckTx code to check")
+	t.MustTrue(CheckBroadcastResponse(BroadcastModeBlock, checkTxFailure) != nil, "block response not committed should fail")
+	t.MustNil(CheckBroadcastResponse(BroadcastModeBlock, deliverTxFailure), "committed block response should be reported by tx result")
+	t.MustTrue(CheckBroadcastResponse(BroadcastModeSync, sdk.TxResponse{}) != nil, "response without txhash should fail")
+
+	rememberBroadcast(DefaultEnv(), BroadcastModeSync, sdk.TxResponse{TxHash: "sync_hash"})
+	_, ok := lookupBroadcast("sync_hash")
+	t.MustTrue(!ok, "sync broadcast should not be remembered")
+	rememberBroadcast(DefaultEnv(), BroadcastModeBlock, deliverTxFailure)
+	committed, ok := committedTxResponse("hash")
+	t.MustTrue(ok && committed.Height == 10, "committed block response should be found by txhash of any case")
+	forgetBroadcast("HASH")
+	_, ok = committedTxResponse("HASH")
+	t.MustTrue(!ok, "forgotten broadcast should not be found")
+}
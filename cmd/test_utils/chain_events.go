@@ -0,0 +1,93 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// eventSubscriberSeq is used to make subscriber names of concurrent event subscriptions unique
+var eventSubscriberSeq int64
+
+// SubscribeChainEvents is a function to observe events of transactions matching query for ExpectEvent of t
+// through tendermint websocket of the first node of env, until returned stop function is called or the test finishes.
+// query is added to tm.event='Tx' e.g. "recipe_executed.recipe_id='recipe1'", every transaction is observed when it's empty.
+// Events of a transaction are observed once, whether the subscription or its tx result of WaitForTxResult comes first.
+func (c *Client) SubscribeChainEvents(ctx context.Context, t *testing.T, query string) (func(), error) {
+	rpcClient, err := rpchttp.New(c.env.firstNode(), "/websocket")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeUnavailable, err.Error())
+	}
+	if err = rpcClient.Start(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeUnavailable, err.Error())
+	}
+	subscriber := fmt.Sprintf("pylons_sdk_events_%d", atomic.AddInt64(&eventSubscriberSeq, 1))
+	txQuery := tmtypes.EventQueryTx.String()
+	if len(query) > 0 {
+		txQuery += " AND " + query
+	}
+	events, err := rpcClient.Subscribe(ctx, subscriber, txQuery)
+	if err != nil {
+		_ = rpcClient.Stop()
+		return nil, fmt.Errorf("%w: error subscribing events of %s: %s", ErrNodeUnavailable, txQuery, err.Error())
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		observeSubscribedEvents(t, events, done)
+	}()
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+			_ = rpcClient.UnsubscribeAll(context.Background(), subscriber)
+			_ = rpcClient.Stop()
+		})
+	}
+	// events observed after the test finishes would be lost, so the subscription ends with the test
+	t.Cleanup(stop)
+	return stop, nil
+}
+
+// observeSubscribedEvents is a function to observe events of transactions from subscription until done is closed
+func observeSubscribedEvents(t *testing.T, events <-chan ctypes.ResultEvent, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			txEvent, ok := event.Data.(tmtypes.EventDataTx)
+			if !ok {
+				continue
+			}
+			txhash := fmt.Sprintf("%X", tmtypes.Tx(txEvent.Tx).Hash())
+			t.ObserveTxEvents(txhash, abciChainEvents(txEvent.Result.Events))
+		}
+	}
+}
+
+// abciChainEvents is a function to convert events of DeliverTx result into chain events of tests
+func abciChainEvents(events []abci.Event) []testing.ChainEvent {
+	chainEvents := []testing.ChainEvent{}
+	for _, event := range events {
+		chainEvent := testing.ChainEvent{Type: event.Type}
+		for _, attr := range event.Attributes {
+			chainEvent.Attributes = append(chainEvent.Attributes, testing.EventAttribute{Key: string(attr.Key), Value: string(attr.Value)})
+		}
+		chainEvents = append(chainEvents, chainEvent)
+	}
+	return chainEvents
+}
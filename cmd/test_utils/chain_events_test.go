@@ -0,0 +1,49 @@
+package inttest
+
+import (
+	"fmt"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestObserveSubscribedEvents(originT *originT.T) {
+	t := testing.NewT(originT)
+	tx := tmtypes.Tx("subscribed transaction")
+	txEvent := tmtypes.EventDataTx{TxResult: abci.TxResult{
+		Height: 10,
+		Tx:     tx,
+		Result: abci.ResponseDeliverTx{Events: []abci.Event{
+			{Type: "recipe_executed", Attributes: []abci.EventAttribute{{Key: []byte("recipe_id"), Value: []byte("recipe1")}}},
+			{Type: "item_created", Attributes: []abci.EventAttribute{{Key: []byte("item_id"), Value: []byte("item1")}}},
+		}},
+	}}
+
+	t.Run("subscribed", func(t *testing.T) {
+		t.ExpectEvent("recipe_executed", map[string]string{"recipe_id": "recipe1"})
+		t.ExpectEvent("item_created", map[string]string{"item_id": "item1"})
+		events := make(chan ctypes.ResultEvent, 2)
+		events <- ctypes.ResultEvent{Data: tmtypes.EventDataNewBlock{}}
+		events <- ctypes.ResultEvent{Data: txEvent}
+		close(events)
+		observeSubscribedEvents(t, events, make(chan struct{}))
+
+		// tx result of the same transaction waited by the client is not observed again
+		t.ObserveTxEvents(fmt.Sprintf("%x", tx.Hash()), []testing.ChainEvent{{Type: "recipe_executed"}})
+		observed := t.ObservedEvents()
+		t.WithFields(testing.Fields{
+			"observed": observed,
+		}).MustTrue(len(observed) == 2 && observed[0].String() == "recipe_executed{recipe_id=recipe1}",
+			"events of subscribed transaction should be observed once")
+	})
+
+	done := make(chan struct{})
+	close(done)
+	t.Run("stopped", func(t *testing.T) {
+		observeSubscribedEvents(t, make(chan ctypes.ResultEvent), done)
+		t.MustTrue(len(t.ObservedEvents()) == 0, "stopped subscription should not observe events")
+	})
+}
@@ -0,0 +1,52 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// ErrChainAdvanced is an error of chain which advanced more blocks than expected or changed between fingerprints
+var ErrChainAdvanced = errors.New("chain advanced unexpectedly")
+
+// GetChainFingerprint is a function to get block height, chain id, node version and app hash of the latest block
+// Daemon status is queried instead of reused from cache, node version is empty when node doesn't report it.
+func GetChainFingerprint(ctx context.Context, stage string) (testing.ChainFingerprint, error) {
+	ds, logstr, err := queryDaemonStatus(ctx)
+	if err != nil {
+		return testing.ChainFingerprint{}, fmt.Errorf("%s: %w", logstr, err)
+	}
+	fingerprint := testing.ChainFingerprint{
+		Stage:   stage,
+		Height:  ds.SyncInfo.LatestBlockHeight,
+		ChainID: ds.NodeInfo.Network,
+		AppHash: strings.ToUpper(ds.SyncInfo.LatestAppHash.String()),
+		Time:    ds.SyncInfo.LatestBlockTime,
+	}
+	if version, err := GetNodeVersionCtx(ctx); err == nil {
+		fingerprint.NodeVersion = version
+	}
+	return fingerprint, nil
+}
+
+// CheckChainAdvance is a function to check chain advanced at most maxBlocks blocks from start to end fingerprint
+// Chain id and node version should not change in between, advance is not checked when maxBlocks is 0.
+// Advancing far more blocks than a scenario needs is a sign of external interference e.g. on shared devnets.
+func CheckChainAdvance(start, end testing.ChainFingerprint, maxBlocks int64) error {
+	if start.ChainID != end.ChainID {
+		return fmt.Errorf("%w: chain id changed from %s to %s", ErrChainAdvanced, start.ChainID, end.ChainID)
+	}
+	if start.NodeVersion != end.NodeVersion {
+		return fmt.Errorf("%w: node version changed from %s to %s", ErrChainAdvanced, start.NodeVersion, end.NodeVersion)
+	}
+	if end.Height < start.Height {
+		return fmt.Errorf("%w: height went back from %d to %d", ErrChainAdvanced, start.Height, end.Height)
+	}
+	if maxBlocks > 0 && end.Height-start.Height > maxBlocks {
+		return fmt.Errorf("%w: %d blocks from height %d to %d, at most %d are expected", ErrChainAdvanced, end.Height-start.Height, start.Height, end.Height, maxBlocks)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package inttest
+
+import (
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestCheckChainAdvance(originT *originT.T) {
+	t := testing.NewT(originT)
+	start := testing.ChainFingerprint{Stage: "start", Height: 100, ChainID: "pylons-testnet", NodeVersion: "v0.3.0", AppHash: "A1"}
+	end := start
+	end.Stage, end.Height, end.AppHash = "end", 110, "B2"
+
+	t.MustNil(CheckChainAdvance(start, end, 10), "advance within limit should pass")
+	t.MustNil(CheckChainAdvance(start, end, 0), "advance should not be checked without limit")
+	err := CheckChainAdvance(start, end, 5)
+	t.MustTrue(errors.Is(err, ErrChainAdvanced), "advance over limit should fail")
+	t.MustContain(err.Error(), "10 blocks from height 100 to 110, at most 5 are expected", "error should describe the advance")
+
+	end.ChainID = "pylons-devnet"
+	t.MustTrue(errors.Is(CheckChainAdvance(start, end, 0), ErrChainAdvanced), "changed chain id should fail")
+	end.ChainID, end.NodeVersion = start.ChainID, "v0.4.0"
+	t.MustTrue(errors.Is(CheckChainAdvance(start, end, 0), ErrChainAdvanced), "changed node version should fail")
+	end.NodeVersion, end.Height = start.NodeVersion, 90
+	t.MustTrue(errors.Is(CheckChainAdvance(start, end, 0), ErrChainAdvanced), "height going back should fail")
+}
@@ -0,0 +1,270 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// names of built-in chain profiles
+const (
+	ChainProfileLocal   = "local"
+	ChainProfileDevnet  = "devnet"
+	ChainProfileTestnet = "testnet"
+	ChainProfileMainnet = "mainnet"
+)
+
+// DefaultChainID is the chain id of local node transactions are signed for without chain profile
+const DefaultChainID = "pylonschain"
+
+// defaultGasLimit is the gas limit of transactions when chain profile doesn't set it
+const defaultGasLimit = 10000000
+
+// ErrChainIDMismatch is an error of node reporting different chain id from the selected chain profile
+var ErrChainIDMismatch = errors.New("chain id mismatch")
+
+// ChainProfile is a struct to describe a chain tests run against
+type ChainProfile struct {
+	Name string `json:"name"`
+	// Nodes are tendermint rpc addresses of the chain, -node flag is kept when it's empty
+	Nodes []string `json:"nodes"`
+	// ChainID is the chain id nodes should report, it's detected from node status when it's empty except for mainnet
+	ChainID      string `json:"chain_id"`
+	RestEndpoint string `json:"rest_endpoint"`
+	GRPCEndpoint string `json:"grpc_endpoint"`
+	// FeeDenom is the denom of transaction fees and StakeDenom is the denom of staking and governance deposits
+	FeeDenom   string `json:"fee_denom"`
+	StakeDenom string `json:"stake_denom"`
+	// Denoms are other denoms issued on the chain e.g. ibc tokens, balance checks of them are not refused as typos
+	Denoms []string `json:"denoms"`
+	// Fees are paid by each transaction e.g. "100upylon", transactions are free when it's empty
+	Fees string `json:"fees"`
+	// GasLimit is the gas limit of transactions, 10000000 is used when it's 0
+	GasLimit uint64 `json:"gas_limit"`
+	// MaxTxBytes is max encoded bytes of transactions, it's taken from consensus params of nodes when it's 0, see GetTxLimits
+	MaxTxBytes int64 `json:"max_tx_bytes"`
+	// MaxTxMsgs is max number of msgs in a transaction e.g. to keep gas of batches under GasLimit, not checked when it's 0
+	MaxTxMsgs int `json:"max_tx_msgs"`
+	// GasPrices are min-gas-prices of nodes e.g. "0.025upylon", used by GasPriceFee when nodes don't expose them
+	GasPrices string `json:"gas_prices"`
+	// PylonsdVersion is the pylonsd version of the chain commands should run with, see ResolvePylonsd
+	PylonsdVersion string `json:"pylonsd_version"`
+}
+
+// ChainProfiles is a variable to have chain profiles by name
+// Only local profile has endpoints built in, endpoints of other chains are set by -chain-profiles file.
+var ChainProfiles = map[string]ChainProfile{
+	ChainProfileLocal: {
+		Name:       ChainProfileLocal,
+		Nodes:      []string{"tcp://localhost:26657"},
+		ChainID:    DefaultChainID,
+		FeeDenom:   "pylon",
+		StakeDenom: "stake",
+	},
+	ChainProfileDevnet:  {Name: ChainProfileDevnet},
+	ChainProfileTestnet: {Name: ChainProfileTestnet},
+	ChainProfileMainnet: {Name: ChainProfileMainnet},
+}
+
+var chainProfilesFile = ""
+
+// detectedChainIDs are chain ids reported by nodes by node list and verifiedChainIDs are node list and chain id pairs checked by CheckChainID
+var (
+	chainIDMux       sync.Mutex
+	detectedChainIDs = map[string]string{}
+	verifiedChainIDs = map[string]bool{}
+)
+
+func init() {
+	flag.StringVar(&CLIOpts.Profile, "chain-profile", "", "chain profile to run against, one of local, devnet, testnet and mainnet")
+	flag.StringVar(&CLIOpts.ChainID, "chain-id", "", "chain id transactions are signed for, node should report the same chain id")
+	flag.StringVar(&chainProfilesFile, "chain-profiles", "", "json file of chain profiles by name overriding built-in profiles")
+}
+
+// LoadChainProfiles is a function to read json file of chain profiles by name and add them to ChainProfiles
+func LoadChainProfiles(file string) error {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	profiles := map[string]ChainProfile{}
+	if err = json.Unmarshal(bz, &profiles); err != nil {
+		return fmt.Errorf("error parsing chain profiles %s: %w", file, err)
+	}
+	for name, profile := range profiles {
+		profile.Name = name
+		ChainProfiles[name] = profile
+	}
+	return nil
+}
+
+// Validate is a function to check fees of chain profile and that mainnet profile has chain id to compare with
+func (p ChainProfile) Validate() error {
+	if len(p.Fees) > 0 {
+		if _, err := sdk.ParseCoinsNormalized(p.Fees); err != nil {
+			return fmt.Errorf("invalid fees %s of chain profile %s: %w", p.Fees, p.Name, err)
+		}
+	}
+	if p.Name == ChainProfileMainnet && len(p.ChainID) == 0 {
+		return fmt.Errorf("chain profile %s should set chain id, it's not detected from nodes not to broadcast to unexpected chain", p.Name)
+	}
+	return nil
+}
+
+// FeeCoins is a function to get fees paid by each transaction of chain profile
+func (p ChainProfile) FeeCoins() sdk.Coins {
+	fees, err := sdk.ParseCoinsNormalized(p.Fees)
+	if err != nil {
+		return sdk.Coins{}
+	}
+	return fees
+}
+
+// TxGasLimit is a function to get gas limit of transactions of chain profile
+func (p ChainProfile) TxGasLimit() uint64 {
+	if p.GasLimit == 0 {
+		return defaultGasLimit
+	}
+	return p.GasLimit
+}
+
+// flagPassed is a function to check if flag is set on command line
+func flagPassed(name string) bool {
+	passed := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			passed = true
+		}
+	})
+	return passed
+}
+
+// SelectedChainProfile is a function to get chain profile selected by CLIOpts.Profile, it's empty without profile
+func SelectedChainProfile() (ChainProfile, bool) {
+	return DefaultEnv().ChainProfile()
+}
+
+// ChainProfile is a function to get chain profile selected by profile option of env, it's empty without profile
+func (e *Env) ChainProfile() (ChainProfile, bool) {
+	if len(e.opts.Profile) == 0 {
+		return ChainProfile{}, false
+	}
+	profile, ok := ChainProfiles[e.opts.Profile]
+	return profile, ok
+}
+
+// UseChainProfile is a function to point pylonsd options to nodes and chain id of chain profile
+// Node, grpc endpoint and chain id set by flags are kept.
+func UseChainProfile(name string) error {
+	if len(chainProfilesFile) > 0 {
+		if err := LoadChainProfiles(chainProfilesFile); err != nil {
+			return err
+		}
+	}
+	profile, ok := ChainProfiles[name]
+	if !ok {
+		return fmt.Errorf("unknown chain profile %s", name)
+	}
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	CLIOpts.Profile = name
+	if len(profile.Nodes) > 0 && !flagPassed("node") {
+		CLIOpts.CustomNode = strings.Join(profile.Nodes, ",")
+	}
+	if len(profile.GRPCEndpoint) > 0 && !flagPassed("grpc") {
+		CLIOpts.GRPCEndpoint = profile.GRPCEndpoint
+	}
+	if len(profile.RestEndpoint) > 0 && len(CLIOpts.RestEndpoint) == 0 {
+		CLIOpts.RestEndpoint = profile.RestEndpoint
+	}
+	if len(CLIOpts.ChainID) == 0 {
+		CLIOpts.ChainID = profile.ChainID
+	}
+	chainIDMux.Lock()
+	detectedChainIDs = map[string]string{}
+	verifiedChainIDs = map[string]bool{}
+	chainIDMux.Unlock()
+	return nil
+}
+
+// ApplyChainProfile is a function to use chain profile selected by -chain-profile flag, it's no-op without the flag
+func ApplyChainProfile() error {
+	if len(CLIOpts.Profile) == 0 {
+		return nil
+	}
+	return UseChainProfile(CLIOpts.Profile)
+}
+
+// DetectChainID is a function to get chain id reported by node status
+func DetectChainID(ctx context.Context) (string, error) {
+	ds, logstr, err := queryDaemonStatus(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error detecting chain id: %s: %w", logstr, err)
+	}
+	return ds.NodeInfo.Network, nil
+}
+
+// GetChainID is a function to get chain id transactions are signed for by DefaultEnv, see Env.ChainID
+func GetChainID() string {
+	return DefaultEnv().ChainID()
+}
+
+// ChainID is a function to get chain id transactions of env are signed for
+// Chain id of env options is used, it's detected from nodes of env when profile doesn't set it and pylonschain is used without profile.
+func (e *Env) ChainID() string {
+	if len(e.opts.ChainID) > 0 {
+		return e.opts.ChainID
+	}
+	if len(e.opts.Profile) == 0 {
+		return DefaultChainID
+	}
+	chainIDMux.Lock()
+	defer chainIDMux.Unlock()
+	if chainID, ok := detectedChainIDs[e.opts.CustomNode]; ok {
+		return chainID
+	}
+	chainID, err := DetectChainID(ContextWithEnv(context.Background(), e))
+	if err != nil {
+		// broadcast is refused by CheckChainID when node can't be reached
+		return DefaultChainID
+	}
+	detectedChainIDs[e.opts.CustomNode] = chainID
+	return chainID
+}
+
+// CheckChainID is a function to check nodes of env of ctx report the chain id transactions of the env are signed for
+// It's checked once per node list when chain profile or chain id is selected, so tests don't broadcast to an unexpected chain e.g. mainnet.
+func CheckChainID(ctx context.Context) error {
+	env := EnvFromContext(ctx)
+	if len(env.opts.Profile) == 0 && len(env.opts.ChainID) == 0 {
+		return nil
+	}
+	expected := env.ChainID()
+	key := env.opts.CustomNode + "|" + expected
+	chainIDMux.Lock()
+	verified := verifiedChainIDs[key]
+	chainIDMux.Unlock()
+	if verified {
+		return nil
+	}
+	actual, err := DetectChainID(ctx)
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("%w: node %s reports chain id %s, but chain profile %s expects %s",
+			ErrChainIDMismatch, env.opts.CustomNode, actual, env.opts.Profile, expected)
+	}
+	chainIDMux.Lock()
+	verifiedChainIDs[key] = true
+	chainIDMux.Unlock()
+	return nil
+}
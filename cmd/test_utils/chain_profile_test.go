@@ -0,0 +1,78 @@
+package inttest
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestChainProfile(originT *originT.T) {
+	t := testing.NewT(originT)
+	prevOpts, prevProfiles, prevFile := CLIOpts, ChainProfiles, chainProfilesFile
+	defer func() {
+		CLIOpts, ChainProfiles, chainProfilesFile = prevOpts, prevProfiles, prevFile
+	}()
+	ChainProfiles = map[string]ChainProfile{}
+	for name, profile := range prevProfiles {
+		ChainProfiles[name] = profile
+	}
+
+	CLIOpts.Profile, CLIOpts.ChainID = "", ""
+	t.MustTrue(GetChainID() == DefaultChainID, "pylonschain should be used without profile")
+	t.MustNil(CheckChainID(context.Background()), "chain id should not be checked without profile")
+
+	dir, err := ioutil.TempDir("", "chain_profiles")
+	t.MustNil(err, "error creating temp dir")
+	defer os.RemoveAll(dir)
+	chainProfilesFile = filepath.Join(dir, "profiles.json")
+	err = ioutil.WriteFile(chainProfilesFile, []byte(`{
+		"testnet": {"nodes": ["tcp://node1:26657", "tcp://node2:26657"], "chain_id": "pylons-test", "fees": "10upylon", "gas_limit": 400000},
+		"mainnet": {"nodes": ["tcp://main:26657"]}
+	}`), 0644)
+	t.MustNil(err, "error writing chain profiles")
+
+	t.MustNil(UseChainProfile(ChainProfileTestnet), "error using testnet profile")
+	profile, ok := SelectedChainProfile()
+	t.WithFields(testing.Fields{
+		"profile": profile,
+	}).MustTrue(ok && profile.TxGasLimit() == 400000 && profile.FeeCoins().String() == "10upylon", "profile fees and gas limit should be loaded")
+	t.MustTrue(CLIOpts.CustomNode == "tcp://node1:26657,tcp://node2:26657", "nodes of profile should be used")
+	t.MustTrue(GetChainID() == "pylons-test", "chain id of profile should be used")
+
+	t.MustTrue(UseChainProfile(ChainProfileMainnet) != nil, "mainnet profile without chain id should be refused")
+	t.MustTrue(UseChainProfile("unknown") != nil, "unknown profile should be refused")
+	t.MustTrue(ChainProfile{Name: "local", Fees: "10"}.Validate() != nil, "fees without denom should be invalid")
+}
+
+func TestEnvChainID(originT *originT.T) {
+	t := testing.NewT(originT)
+	envA := NewEnv(CLIOptions{ChainID: "pylons-a"}, nil)
+	envB := NewEnv(CLIOptions{ChainID: "pylons-b", Profile: ChainProfileTestnet}, nil)
+	t.MustTrue(envA.ChainID() == "pylons-a" && envB.ChainID() == "pylons-b", "chain id of env options should be used")
+	t.MustTrue(DefaultEnv().ChainID() == GetChainID(), "package level chain id should be the one of default env")
+
+	sendArgs := []string{"tx", "bank", "send", "alice", "bob", "1pylon", "--from", "alice"}
+	argsA, err := envA.keyringBackendSetupStrict(sendArgs, TestKeyring{})
+	t.MustNil(err, "error setting up keyring flags of env a")
+	argsB, err := envB.keyringBackendSetupStrict(sendArgs, TestKeyring{})
+	t.MustNil(err, "error setting up keyring flags of env b")
+	t.WithFields(testing.Fields{
+		"args_a": argsA,
+		"args_b": argsB,
+	}).MustTrue(hasFlagValue(argsA, "--chain-id=pylons-a") && hasFlagValue(argsB, "--chain-id=pylons-b"),
+		"transactions of each env should be signed for its own chain id")
+}
+
+// hasFlagValue is a function to check args have the flag with value set by "--name=value"
+func hasFlagValue(args []string, flagValue string) bool {
+	for _, arg := range args {
+		if arg == flagValue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+package inttest
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// chainResetHeightTolerance is the number of blocks height can go back without a reset
+// as nodes of the same chain can lag a few blocks behind each other
+const chainResetHeightTolerance = 2
+
+func init() {
+	flag.BoolVar(&CLIOpts.DetectChainReset, "detect-chain-reset", true, "clear cached nonces, statuses and broadcasts when node height goes back or chain id changes")
+}
+
+// ChainReset is a struct to describe a reset of chain observed by status queries e.g. a local devnet started from genesis again
+type ChainReset struct {
+	Node        string // node whose statuses showed the reset, empty when the reset is not detected from statuses
+	PrevChainID string
+	PrevHeight  int64
+	ChainID     string
+	Height      int64
+}
+
+func (r ChainReset) String() string {
+	if r.PrevChainID != r.ChainID {
+		return fmt.Sprintf("chain id changed from %s to %s at height %d", r.PrevChainID, r.ChainID, r.Height)
+	}
+	return fmt.Sprintf("height of %s went back from %d to %d", r.ChainID, r.PrevHeight, r.Height)
+}
+
+// chainResetWatcher is a struct to keep chain id and the latest height observed to detect chain resets
+type chainResetWatcher struct {
+	mux     sync.Mutex
+	chainID string
+	height  int64
+	resets  int
+}
+
+// observe is a function to update baseline by chain id and height of a status, true when they show the chain was reset
+func (w *chainResetWatcher) observe(chainID string, height int64) (ChainReset, bool) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if w.height == 0 {
+		w.chainID, w.height = chainID, height
+		return ChainReset{}, false
+	}
+	reset := ChainReset{PrevChainID: w.chainID, PrevHeight: w.height, ChainID: chainID, Height: height}
+	if (len(chainID) > 0 && len(w.chainID) > 0 && chainID != w.chainID) || height+chainResetHeightTolerance < w.height {
+		w.chainID, w.height = chainID, height
+		w.resets++
+		return reset, true
+	}
+	if height > w.height {
+		w.height = height
+	}
+	if len(w.chainID) == 0 {
+		w.chainID = chainID
+	}
+	return ChainReset{}, false
+}
+
+// watcher is a function to get chain reset watcher of node, statuses are only compared with statuses of the same node
+func (s *chainState) watcher(node string) *chainResetWatcher {
+	s.watchersMux.Lock()
+	defer s.watchersMux.Unlock()
+	w, ok := s.watchers[node]
+	if !ok {
+		w = &chainResetWatcher{}
+		s.watchers[node] = w
+	}
+	return w
+}
+
+// forgetWatchers is a function to drop baselines of nodes other than node after a reset, they take the next status as baseline
+func (s *chainState) forgetWatchers(node string) {
+	s.watchersMux.Lock()
+	defer s.watchersMux.Unlock()
+	for watched := range s.watchers {
+		if watched != node {
+			delete(s.watchers, watched)
+		}
+	}
+}
+
+// GetChainResetCount is a function to get number of chain resets detected so far on nodes of DefaultEnv
+func GetChainResetCount() int {
+	state := DefaultEnv().state()
+	state.watchersMux.Lock()
+	defer state.watchersMux.Unlock()
+	return state.resets
+}
+
+var (
+	chainResetHandlerMux sync.RWMutex
+	chainResetHandlers   []func(ChainReset)
+)
+
+// OnChainReset is a function to add handler called after caches are cleared for a detected chain reset
+// e.g. to create accounts on chain again and replay setup fixtures, handlers should not block for long.
+func OnChainReset(handler func(ChainReset)) {
+	chainResetHandlerMux.Lock()
+	defer chainResetHandlerMux.Unlock()
+	chainResetHandlers = append(chainResetHandlers, handler)
+}
+
+// ResetChainResetHandlers is a function to remove handlers added by OnChainReset
+func ResetChainResetHandlers() {
+	chainResetHandlerMux.Lock()
+	defer chainResetHandlerMux.Unlock()
+	chainResetHandlers = nil
+}
+
+// detectChainReset is a function to re-baseline state of env when chain id and height of a status of node show the chain was reset
+// It's no-op when chain reset detection is disabled by options of env.
+func detectChainReset(env *Env, node string, chainID string, height int64) (ChainReset, bool) {
+	if !env.opts.DetectChainReset {
+		return ChainReset{}, false
+	}
+	state := env.state()
+	reset, ok := state.watcher(node).observe(chainID, height)
+	if !ok {
+		return reset, false
+	}
+	reset.Node = node
+	state.watchersMux.Lock()
+	state.resets++
+	state.watchersMux.Unlock()
+	log.WithFields(log.Fields{
+		"node":          node,
+		"prev_chain_id": reset.PrevChainID,
+		"prev_height":   reset.PrevHeight,
+		"chain_id":      reset.ChainID,
+		"height":        reset.Height,
+	}).Warn("chain reset detected, clearing cached state")
+	env.rebaselineChain(reset)
+	return reset, true
+}
+
+// RebaselineChain is a function to drop state cached for the previous chain of DefaultEnv and call chain reset handlers
+// Cached status and key addresses, observed block heights, nonces of signers and pending broadcasts are dropped,
+// average block time is kept as the chain is expected to run with the same config.
+func RebaselineChain(reset ChainReset) {
+	DefaultEnv().rebaselineChain(reset)
+}
+
+// rebaselineChain is a function to drop state of env cached for the previous chain, state of envs of other chains is kept
+func (e *Env) rebaselineChain(reset ChainReset) {
+	for _, node := range strings.Split(e.opts.CustomNode, ",") {
+		queryResults.invalidate(e.statusCacheKey(node))
+	}
+	invalidateKeyAddresses()
+	state := e.state()
+	state.forgetWatchers(reset.Node)
+	state.blocks.reset()
+	atomic.StoreInt32(&state.nonceFileStale, 1)
+	stateKey := e.stateKey()
+	broadcastTxs.Range(func(txhash, tx interface{}) bool {
+		if tx.(broadcastTx).stateKey == stateKey {
+			broadcastTxs.Delete(txhash)
+		}
+		return true
+	})
+	chainResetHandlerMux.RLock()
+	handlers := append([]func(ChainReset){}, chainResetHandlers...)
+	chainResetHandlerMux.RUnlock()
+	for _, handler := range handlers {
+		handler(reset)
+	}
+}
+
+// removeStaleNonceFile is a function to remove nonce file of the previous chain of env, it should be called while nonceMux of env is locked
+func removeStaleNonceFile(env *Env) error {
+	if !atomic.CompareAndSwapInt32(&env.state().nonceFileStale, 1, 0) || !fileExists(env.nonceFilePath()) {
+		return nil
+	}
+	return os.Remove(env.nonceFilePath())
+}
+
+// reset is a function to forget observed heights, the average block interval is kept
+func (bt *blockTimeTracker) reset() {
+	bt.mux.Lock()
+	defer bt.mux.Unlock()
+	bt.lastHeight = 0
+	bt.lastTime = time.Time{}
+}
+
+// CheckChainReset is a function to query daemon status and check whether chain was reset since the last status
+// Chain resets are detected by every status query, it's used to check explicitly e.g. between scenarios.
+func CheckChainReset(ctx context.Context) (ChainReset, bool, error) {
+	env := EnvFromContext(ctx)
+	node := env.randomNode()
+	ds, logstr, err := queryDaemonStatusFromNode(ctx, node)
+	if err != nil {
+		return ChainReset{}, false, fmt.Errorf("%s: %w", logstr, err)
+	}
+	reset, ok := observeDaemonStatus(env, node, ds)
+	return reset, ok, nil
+}
+
+// observeDaemonStatus is a function to detect chain reset from status of node of env, observe its block and cache it
+func observeDaemonStatus(env *Env, node string, ds *ctypes.ResultStatus) (ChainReset, bool) {
+	reset, ok := detectChainReset(env, node, ds.NodeInfo.Network, ds.SyncInfo.LatestBlockHeight)
+	env.state().blocks.observe(ds.SyncInfo.LatestBlockHeight, ds.SyncInfo.LatestBlockTime)
+	queryResults.set(env.statusCacheKey(node), ds, ds.SyncInfo.LatestBlockHeight)
+	return reset, ok
+}
@@ -0,0 +1,109 @@
+package inttest
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestChainReset(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	var w chainResetWatcher
+	_, reset := w.observe("pylons-devnet", 100)
+	t.MustTrue(!reset, "first status should set baseline")
+	_, reset = w.observe("pylons-devnet", 99)
+	t.MustTrue(!reset, "lagging node should not be a reset")
+	_, reset = w.observe("pylons-devnet", 120)
+	t.MustTrue(!reset, "advancing height should not be a reset")
+	r, reset := w.observe("pylons-devnet", 3)
+	t.WithFields(testing.Fields{
+		"reset": r.String(),
+	}).MustTrue(reset && r.PrevHeight == 120 && r.Height == 3, "height going back should be a reset")
+	_, reset = w.observe("pylons-devnet", 5)
+	t.MustTrue(!reset, "height after reset should be compared against the new baseline")
+	r, reset = w.observe("pylons-devnet-2", 6)
+	t.MustTrue(reset && r.PrevChainID == "pylons-devnet" && r.ChainID == "pylons-devnet-2", "chain id change should be a reset")
+	t.MustTrue(w.resets == 2, "resets should be counted")
+
+	prevNonceFile := nonceFile
+	nonceFile = filepath.Join(originT.TempDir(), "nonce.json")
+	defer func() {
+		nonceFile = prevNonceFile
+		ResetChainResetHandlers()
+	}()
+	t.MustNil(ioutil.WriteFile(nonceFile, []byte(`{"pylo1signer": 7}`), 0644), "error writing nonce file")
+	rememberBroadcast(DefaultEnv(), BroadcastModeAsync, sdk.TxResponse{TxHash: "RESET_HASH"})
+	handled := []ChainReset{}
+	OnChainReset(func(reset ChainReset) {
+		handled = append(handled, reset)
+	})
+	RebaselineChain(ChainReset{PrevChainID: "pylons-devnet", PrevHeight: 120, ChainID: "pylons-devnet", Height: 3})
+	t.MustTrue(len(handled) == 1 && handled[0].Height == 3, "chain reset handlers should be called")
+	_, ok := lookupBroadcast("RESET_HASH")
+	t.MustTrue(!ok, "pending broadcasts should be dropped")
+	nonceMap, err := readNonceMap(DefaultEnv(), &t)
+	t.MustTrue(err == nil && len(nonceMap) == 0 && !fileExists(nonceFile), "nonce file of the previous chain should be removed")
+}
+
+func TestChainStatePerEnv(originT *originT.T) {
+	t := testing.NewT(originT)
+	prevNonceFile := nonceFile
+	nonceFile = filepath.Join(originT.TempDir(), "nonce.json")
+	defer func() { nonceFile = prevNonceFile }()
+
+	envA := NewEnv(CLIOptions{CustomNode: "tcp://node-a:26657", ChainID: "pylons-a"}, nil)
+	envB := NewEnv(CLIOptions{CustomNode: "tcp://node-b:26657", ChainID: "pylons-b"}, nil)
+	t.MustTrue(envA.state() != envB.state() && envA.state() == NewEnv(*envA.Options(), nil).state(),
+		"envs should share chain state only with envs of the same nodes and chain id")
+	t.WithFields(testing.Fields{
+		"nonce_file_a": envA.nonceFilePath(),
+		"nonce_file_b": envB.nonceFilePath(),
+	}).MustTrue(envA.nonceFilePath() != envB.nonceFilePath() && envA.nonceFilePath() != nonceFile, "envs of different chains should have their own nonce files")
+	t.MustTrue(envA.statusCacheKey("tcp://node-a:26657") != envB.statusCacheKey("tcp://node-a:26657"), "envs of different chains should have their own status cache keys")
+
+	_, err := writeNonceMap(envA, map[string]uint64{"pylo1signer": 3})
+	t.MustNil(err, "error writing nonce file of env a")
+	_, err = writeNonceMap(envB, map[string]uint64{"pylo1signer": 9})
+	t.MustNil(err, "error writing nonce file of env b")
+	rememberBroadcast(envA, BroadcastModeAsync, sdk.TxResponse{TxHash: "ENV_A_HASH"})
+	rememberBroadcast(envB, BroadcastModeAsync, sdk.TxResponse{TxHash: "ENV_B_HASH"})
+	envA.state().blocks.observe(50, time.Now())
+	envB.state().blocks.observe(70, time.Now())
+
+	envA.rebaselineChain(ChainReset{PrevChainID: "pylons-a", PrevHeight: 50, ChainID: "pylons-a", Height: 1})
+	_, okA := lookupBroadcast("ENV_A_HASH")
+	_, okB := lookupBroadcast("ENV_B_HASH")
+	t.MustTrue(!okA && okB, "reset of env a should drop only its pending broadcasts")
+	t.MustTrue(envA.state().blocks.latestHeight() == 0 && envB.state().blocks.latestHeight() == 70, "reset of env a should keep heights of env b")
+	nonceA, err := readNonceMap(envA, &t)
+	t.MustTrue(err == nil && len(nonceA) == 0, "nonce file of env a should be removed")
+	nonceB, err := readNonceMap(envB, &t)
+	t.MustTrue(err == nil && nonceB["pylo1signer"] == 9, "nonce file of env b should be kept")
+	forgetBroadcast("ENV_B_HASH")
+}
+
+func TestChainResetPerNode(originT *originT.T) {
+	t := testing.NewT(originT)
+	defer ResetChainResetHandlers()
+	env := NewEnv(CLIOptions{CustomNode: "tcp://node-a:26657,tcp://node-b:26657", ChainID: "pylons-nodes", DetectChainReset: true}, nil)
+	_, reset := detectChainReset(env, "tcp://node-a:26657", "pylons-nodes", 100)
+	t.MustTrue(!reset, "first status of node a should set its baseline")
+	_, reset = detectChainReset(env, "tcp://node-b:26657", "pylons-nodes", 40)
+	t.MustTrue(!reset, "status of a syncing node should not be compared with statuses of another node")
+	r, reset := detectChainReset(env, "tcp://node-a:26657", "pylons-nodes", 3)
+	t.WithFields(testing.Fields{
+		"reset": r.String(),
+	}).MustTrue(reset && r.Node == "tcp://node-a:26657" && r.PrevHeight == 100, "height of node a going back should be a reset")
+	_, reset = detectChainReset(env, "tcp://node-b:26657", "pylons-nodes", 4)
+	t.MustTrue(!reset, "node b should take a new baseline after the reset instead of detecting it again")
+
+	disabled := NewEnv(CLIOptions{CustomNode: "tcp://node-c:26657", ChainID: "pylons-nodes"}, nil)
+	detectChainReset(disabled, "tcp://node-c:26657", "pylons-nodes", 100)
+	_, reset = detectChainReset(disabled, "tcp://node-c:26657", "pylons-nodes", 3)
+	t.MustTrue(!reset, "chain reset should not be detected when options of env disable it")
+}
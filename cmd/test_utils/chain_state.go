@@ -0,0 +1,61 @@
+package inttest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+)
+
+// chainState is a struct to keep state observed from nodes of a chain e.g. block heights and nonce file of signers
+// Envs of the same nodes and chain id share it, so envs running against different chains in a process don't mix their state.
+type chainState struct {
+	blocks blockTimeTracker
+	// nonceMux serializes signers of nonce file and nonceFileStale is set when the chain is reset,
+	// nonce file is removed by the next reader holding nonceMux
+	nonceMux       sync.Mutex
+	nonceFileStale int32
+	// watchers keep chain reset baseline by node and resets count resets detected on the chain
+	watchersMux sync.Mutex
+	watchers    map[string]*chainResetWatcher
+	resets      int
+}
+
+var (
+	chainStatesMux sync.Mutex
+	chainStates    = map[string]*chainState{}
+)
+
+// stateKey is a function to get key of chain state of env by its nodes and chain id
+func (e *Env) stateKey() string {
+	return e.opts.CustomNode + "|" + e.opts.ChainID
+}
+
+// state is a function to get chain state of env, it's created on first use
+func (e *Env) state() *chainState {
+	key := e.stateKey()
+	chainStatesMux.Lock()
+	defer chainStatesMux.Unlock()
+	state, ok := chainStates[key]
+	if !ok {
+		state = &chainState{watchers: map[string]*chainResetWatcher{}}
+		chainStates[key] = state
+	}
+	return state
+}
+
+// nonceFilePath is a function to get nonce file of env, envs of other nodes or chain id than DefaultEnv get files named by them
+func (e *Env) nonceFilePath() string {
+	key := e.stateKey()
+	if key == defaultEnv.stateKey() {
+		return nonceFile
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return fmt.Sprintf("%s_%08x.json", strings.TrimSuffix(nonceFile, ".json"), h.Sum32())
+}
+
+// statusCacheKey is a function to get query cache key of daemon status of node of env
+func (e *Env) statusCacheKey(node string) string {
+	return statusCachePrefix + e.opts.ChainID + "|" + node
+}
@@ -0,0 +1,180 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrBroadcastDropped is returned for broadcasts dropped by ChaosHook, it's retried as an unavailable node
+var ErrBroadcastDropped = fmt.Errorf("%w: broadcast dropped by chaos", ErrNodeUnavailable)
+
+// ChaosOptions is a struct to configure faults injected into requests to node
+// so that retry and recovery of the harness are exercised while scenarios run
+type ChaosOptions struct {
+	// Latency is the delay added before each request to node
+	Latency time.Duration
+	// LatencyJitter is the max random delay added on top of Latency
+	LatencyJitter time.Duration
+	// DropBroadcastRate is the fraction of broadcasts failed before they're sent, from 0 to 1
+	DropBroadcastRate float64
+	// Seed is the seed of random faults, test data seed is used when it's 0
+	Seed int64
+}
+
+func init() {
+	flag.DurationVar(&CLIOpts.Chaos.Latency, "chaos-latency", 0, "delay added before each request to node to test retry and recovery")
+	flag.DurationVar(&CLIOpts.Chaos.LatencyJitter, "chaos-latency-jitter", 0, "max random delay added on top of chaos latency")
+	flag.Float64Var(&CLIOpts.Chaos.DropBroadcastRate, "chaos-drop-broadcast-rate", 0, "fraction of broadcasts failed before they're sent, from 0 to 1")
+}
+
+// Enabled is a function to check if options inject any fault
+func (o ChaosOptions) Enabled() bool {
+	return o.Latency > 0 || o.LatencyJitter > 0 || o.DropBroadcastRate > 0
+}
+
+// Validate is a function to check options are in range
+func (o ChaosOptions) Validate() error {
+	if o.Latency < 0 || o.LatencyJitter < 0 {
+		return errors.New("chaos latency should not be negative")
+	}
+	if o.DropBroadcastRate < 0 || o.DropBroadcastRate > 1 {
+		return fmt.Errorf("chaos drop broadcast rate %f should be from 0 to 1", o.DropBroadcastRate)
+	}
+	return nil
+}
+
+// ChaosStats is a struct to describe faults injected by ChaosHook
+type ChaosStats struct {
+	Requests          int
+	Delayed           time.Duration
+	DroppedBroadcasts int
+}
+
+// ChaosHook is a transport hook injecting latency into requests to node and dropping broadcasts
+type ChaosHook struct {
+	opts  ChaosOptions
+	mux   sync.Mutex
+	rand  *rand.Rand
+	stats ChaosStats
+}
+
+// NewChaosHook is a function to create chaos hook of options, random faults are reproducible by seed
+func NewChaosHook(opts ChaosOptions) *ChaosHook {
+	seed := opts.Seed
+	if seed == 0 {
+		seed = GetTestDataSeed()
+	}
+	return &ChaosHook{opts: opts, rand: rand.New(rand.NewSource(seed))}
+}
+
+// EnableChaos is a function to register chaos hook of options on requests to node, nil when options inject no fault
+func EnableChaos(opts ChaosOptions) (*ChaosHook, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if !opts.Enabled() {
+		return nil, nil
+	}
+	hook := NewChaosHook(opts)
+	RegisterTransportHook(hook)
+	return hook, nil
+}
+
+// isBroadcastRequest is a function to check if request broadcasts a transaction by pylonsd or other transports
+func isBroadcastRequest(req *TransportRequest) bool {
+	return req.Method == "Broadcast" || req.Method == "tx broadcast"
+}
+
+// fault is a function to decide delay and drop of a request
+func (h *ChaosHook) fault(broadcast bool) (time.Duration, bool) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	delay := h.opts.Latency
+	if h.opts.LatencyJitter > 0 {
+		delay += time.Duration(h.rand.Int63n(int64(h.opts.LatencyJitter) + 1))
+	}
+	drop := broadcast && h.opts.DropBroadcastRate > 0 && h.rand.Float64() < h.opts.DropBroadcastRate
+	h.stats.Requests++
+	h.stats.Delayed += delay
+	if drop {
+		h.stats.DroppedBroadcasts++
+	}
+	return delay, drop
+}
+
+// BeforeRequest is a function to delay request and fail broadcasts to drop
+func (h *ChaosHook) BeforeRequest(ctx context.Context, req *TransportRequest) {
+	delay, drop := h.fault(isBroadcastRequest(req))
+	if delay > 0 {
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
+	}
+	if drop {
+		req.Err = ErrBroadcastDropped
+	}
+}
+
+// AfterResponse is a function to do nothing as faults are injected before requests
+func (h *ChaosHook) AfterResponse(ctx context.Context, req *TransportRequest, res *TransportResponse) {
+}
+
+// Stats is a function to get faults injected so far
+func (h *ChaosHook) Stats() ChaosStats {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	return h.stats
+}
+
+// NodeRestarter is an interface of nodes which chaos restarts between fixture steps
+type NodeRestarter interface {
+	// Restart stops the node and starts it again, it returns when the node answers status queries
+	Restart(ctx context.Context) error
+}
+
+// Restart is a function to stop node and start it on the same home, binary and endpoints again
+// The same NodeProcess keeps managing the restarted node.
+func (n *NodeProcess) Restart(ctx context.Context) error {
+	if err := n.Stop(30 * time.Second); err != nil && !n.Exited() {
+		return fmt.Errorf("error stopping node: %w", err)
+	}
+	if err := n.start(); err != nil {
+		return fmt.Errorf("error starting node again: %w", err)
+	}
+	return WaitForNodeReady(ctx, n)
+}
+
+// CommandRestarter is a node restarter running a command e.g. "docker restart pylonsd" for nodes not launched by StartNode
+type CommandRestarter struct {
+	Command string
+}
+
+// Restart is a function to run restart command and wait until node answers status query with a block
+func (r CommandRestarter) Restart(ctx context.Context) error {
+	args := strings.Fields(r.Command)
+	if len(args) == 0 {
+		return errors.New("restart command is empty")
+	}
+	if output, err := exec.CommandContext(ctx, args[0], args[1:]...).CombinedOutput(); err != nil {
+		return fmt.Errorf("error running restart command %s: %w; %s", r.Command, err, string(output))
+	}
+	for {
+		status, _, err := queryDaemonStatus(ctx)
+		if err == nil && status.SyncInfo.LatestBlockHeight > 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
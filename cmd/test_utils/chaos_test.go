@@ -0,0 +1,45 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestChaosHook(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	hook, err := EnableChaos(ChaosOptions{})
+	t.MustTrue(err == nil && hook == nil, "chaos without faults should not be enabled")
+	_, err = EnableChaos(ChaosOptions{DropBroadcastRate: 1.5})
+	t.MustTrue(err != nil, "drop broadcast rate over 1 should be refused")
+
+	defer ResetTransportHooks()
+	hook, err = EnableChaos(ChaosOptions{Latency: 10 * time.Millisecond, DropBroadcastRate: 1, Seed: 1})
+	t.MustNil(err, "error enabling chaos")
+
+	sent := 0
+	run := func() TransportResponse {
+		sent++
+		return TransportResponse{}
+	}
+	res := withTransportHooks(context.Background(), TransportRequest{Transport: TransportCLI, Method: "tx broadcast"}, run)
+	t.WithFields(testing.Fields{
+		"error": res.Err,
+	}).MustTrue(errors.Is(res.Err, ErrBroadcastDropped) && sent == 0, "broadcast should be dropped without being sent")
+	t.MustTrue(DefaultRetryPolicy().IsRetryable(res.Err), "dropped broadcast should be retried")
+	res = withTransportHooks(context.Background(), TransportRequest{Transport: TransportGRPC, Method: "Broadcast"}, run)
+	t.MustTrue(errors.Is(res.Err, ErrBroadcastDropped) && sent == 0, "broadcast of other transports should be dropped")
+	start := time.Now()
+	res = withTransportHooks(context.Background(), TransportRequest{Transport: TransportCLI, Method: "query pylons"}, run)
+	t.MustTrue(res.Err == nil && sent == 1, "queries should not be dropped")
+	t.MustTrue(time.Since(start) >= 10*time.Millisecond, "latency should be injected")
+
+	stats := hook.Stats()
+	t.WithFields(testing.Fields{
+		"stats": stats,
+	}).MustTrue(stats.Requests == 3 && stats.DroppedBroadcasts == 2 && stats.Delayed == 30*time.Millisecond, "injected faults should be counted")
+}
@@ -0,0 +1,359 @@
+package inttest
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/service"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Client is a struct to send transactions and wait for their results with its own options
+// It is the stable harness API, package level transaction helpers are deprecated wrappers of it
+type Client struct {
+	env               *Env
+	maxWaitBlock      int64
+	maxBroadcast      int
+	confirmationDepth int64
+	keyring           KeyringProvider
+	recorder          TxRecorder
+	txOpts            TxOptions
+	broadcastMode     BroadcastMode
+	clock             Clock
+	msgService        service.PylonsMsgService
+}
+
+// ClientOption is a function to set an option of Client
+type ClientOption func(*Client)
+
+// WithMaxWaitBlock is a function to set number of blocks to wait for a transaction to be included
+func WithMaxWaitBlock(maxWaitBlock int64) ClientOption {
+	return func(c *Client) {
+		c.maxWaitBlock = maxWaitBlock
+	}
+}
+
+// WithMaxBroadcastRetry is a function to set maximum retry of transaction broadcast
+func WithMaxBroadcastRetry(maxBroadcast int) ClientOption {
+	return func(c *Client) {
+		c.maxBroadcast = maxBroadcast
+	}
+}
+
+// WithConfirmationDepth is a function to set number of blocks required on top of the inclusion block
+func WithConfirmationDepth(depth int64) ClientOption {
+	return func(c *Client) {
+		c.confirmationDepth = depth
+	}
+}
+
+// WithKeyring is a function to set keyring provider which has keys of signers
+func WithKeyring(provider KeyringProvider) ClientOption {
+	return func(c *Client) {
+		c.keyring = provider
+	}
+}
+
+// WithTxRecorder is a function to set recorder which observes transactions sent by client
+func WithTxRecorder(recorder TxRecorder) ClientOption {
+	return func(c *Client) {
+		c.recorder = recorder
+	}
+}
+
+// WithMemo is a function to set memo of transactions sent by client
+func WithMemo(memo string) ClientOption {
+	return func(c *Client) {
+		c.txOpts.Memo = memo
+	}
+}
+
+// WithFeeGranter is a function to set account whose fee allowance pays fees of transactions sent by client
+// It's used to test gasless flows where players are granted fee allowance, the chain should support fee grants.
+func WithFeeGranter(granter string) ClientOption {
+	return func(c *Client) {
+		c.txOpts.FeeGranter = granter
+	}
+}
+
+// WithFeePayer is a function to set account paying fees of transactions sent by client instead of the signer
+// Fee payer should be a signer of the transaction.
+func WithFeePayer(payer string) ClientOption {
+	return func(c *Client) {
+		c.txOpts.FeePayer = payer
+	}
+}
+
+// WithFeeStrategy is a function to set fee strategy of transactions sent by client
+// e.g. PriorityFee to get transactions of a race ordered first by nodes prioritizing higher fees
+func WithFeeStrategy(strategy FeeStrategy) ClientOption {
+	return func(c *Client) {
+		c.txOpts.FeeStrategy = strategy
+	}
+}
+
+// WithBroadcastMode is a function to set broadcast mode of transactions sent by client, see BroadcastMode for their follow-up
+func WithBroadcastMode(mode BroadcastMode) ClientOption {
+	return func(c *Client) {
+		c.broadcastMode = mode
+	}
+}
+
+// WithClock is a function to set clock waits and retries of client use, e.g. FakeClock in unit tests
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// WithMsgService is a function to set msg service MsgService of client returns instead of sending transactions
+// e.g. mockchain.Chain to run helpers built on msg service without a node in unit tests.
+func WithMsgService(msgService service.PylonsMsgService) ClientOption {
+	return func(c *Client) {
+		c.msgService = msgService
+	}
+}
+
+// NewClient is a function to create client of DefaultEnv, options not set are taken from CLIOpts
+func NewClient(opts ...ClientOption) *Client {
+	return DefaultEnv().NewClient(opts...)
+}
+
+// Env is a function to get env client runs commands and queries with
+func (c *Client) Env() *Env {
+	return c.env
+}
+
+// withContext is a function to set env and clock of client on ctx
+func (c *Client) withContext(ctx context.Context) context.Context {
+	ctx = ContextWithEnv(ctx, c.env)
+	if c.clock != nil {
+		ctx = ContextWithClock(ctx, c.clock)
+	}
+	return ctx
+}
+
+// TxRecorder is an interface to observe transactions sent by Client e.g. to record a session as fixture scenario
+type TxRecorder interface {
+	// RecordTx is called after msgs are broadcast, output is txhash on success and output log on failure
+	RecordTx(msgs []sdk.Msg, output string, err error)
+	// RecordTxResult is called after result of the transaction is parsed by WaitForTxResult
+	RecordTxResult(txhash string, txResult TxResult, err error)
+}
+
+// TxRecorders is a type of recorders which observe the same transactions in order, nil recorders are skipped
+type TxRecorders []TxRecorder
+
+// RecordTx is a function to pass broadcast transaction to all recorders
+func (rs TxRecorders) RecordTx(msgs []sdk.Msg, output string, err error) {
+	for _, r := range rs {
+		if r != nil {
+			r.RecordTx(msgs, output, err)
+		}
+	}
+}
+
+// RecordTxResult is a function to pass transaction result to all recorders
+func (rs TxRecorders) RecordTxResult(txhash string, txResult TxResult, err error) {
+	for _, r := range rs {
+		if r != nil {
+			r.RecordTxResult(txhash, txResult, err)
+		}
+	}
+}
+
+// Signer is a struct to describe signer of a transaction by key name or bech32 address
+type Signer struct {
+	value     string
+	isAddress bool
+}
+
+// SignerKey is a function to get signer by key name of keyring
+func SignerKey(name string) Signer {
+	return Signer{value: name}
+}
+
+// SignerAddress is a function to get signer by bech32 address
+func SignerAddress(address string) Signer {
+	return Signer{value: address, isAddress: true}
+}
+
+// String is a function to get key name or address of signer
+func (s Signer) String() string {
+	return s.value
+}
+
+// SendTx is a function to sign msgs by signer in one transaction and broadcast it, it returns txhash
+// Node version is checked on the first transaction to the node, see NodeVersionCheck.
+func (c *Client) SendTx(ctx context.Context, t *testing.T, signer Signer, msgs ...sdk.Msg) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	ctx = c.withContext(ctx)
+	if len(c.broadcastMode) > 0 {
+		ctx = ContextWithBroadcastMode(ctx, c.broadcastMode)
+	}
+	if err := NodeVersionCheck(ctx, t); err != nil {
+		return "", err
+	}
+	msgTypes := []string{}
+	for _, msg := range msgs {
+		msgTypes = append(msgTypes, msg.Type())
+	}
+	t.InvolveAccounts(msgSigners(msgs)...)
+	ctx, span := StartSpan(withTestSpan(ctx, t), "tx broadcast", SpanKindInternal)
+	span.SetAttribute("tx.signer", signer.String())
+	span.SetAttribute("tx.msg_types", strings.Join(msgTypes, ","))
+	span.SetAttribute("tx.broadcast_mode", string(BroadcastModeFromContext(ctx)))
+	output, err := sendMultiMsgTx(ctx, t, msgs, signer.value, signer.isAddress, c.maxBroadcast, c.keyring, c.txOpts)
+	if err == nil {
+		span.SetAttribute("tx.hash", output)
+	}
+	span.End(err)
+	if err != nil {
+		// output is txhash if it's a success transaction, if fail, it's output log
+		t.WithFields(testing.Fields{
+			"output": output,
+			"error":  err,
+			"func":   "Client.SendTx",
+		}).Error("error log")
+	}
+	if err == nil {
+		t.RecordTx(testing.TxRecord{TxHash: output, Msgs: msgTypes})
+	}
+	if c.recorder != nil {
+		c.recorder.RecordTx(msgs, output, err)
+	}
+	return output, err
+}
+
+// WaitForTx is a function to get transaction data after transaction is processed and confirmed
+// Transaction dropped from mempool while waiting is broadcast again up to RetryPolicy.MaxRebroadcasts.
+// Transaction committed by block mode broadcast is not waited, and async one is waited by its tx event.
+func (c *Client) WaitForTx(ctx context.Context, t *testing.T, txhash string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return []byte{}, err
+	}
+	ctx = c.withContext(ctx)
+	txHandleResBytes := []byte{}
+	defer droppedTxWatchdog.forget(txhash)
+	defer forgetBroadcast(txhash)
+	if txResponse, ok := committedTxResponse(txhash); ok {
+		t.RecordTx(testing.TxRecord{TxHash: txhash, Height: txResponse.Height, Code: txResponse.Code, GasUsed: txResponse.GasUsed})
+		data, err := hex.DecodeString(txResponse.Data)
+		if err != nil {
+			return data, err
+		}
+		return data, WaitForTxConfirmationCtx(ctx, txhash, c.confirmationDepth, t)
+	}
+	if tx, ok := lookupBroadcast(txhash); ok && tx.mode == BroadcastModeAsync {
+		err := WaitForTxEvent(ctx, txhash, c.maxWaitBlock)
+		t.WithFields(testing.Fields{
+			"txhash": txhash,
+			"error":  err,
+		}).Debug("waited for tx event")
+		if errors.Is(err, ErrWaitTimeout) {
+			return txHandleResBytes, errors.New("didn't get result waiting for maximum wait block")
+		}
+		if err != nil && !errors.Is(err, ErrNodeUnavailable) {
+			return txHandleResBytes, err
+		}
+		// polling below gets the committed transaction right away, or waits for it when subscription is unavailable
+	}
+	policy := c.env.RetryPolicy()
+	waited, err := waitBlocks(ctx, func() (bool, error) {
+		var err error
+		txHandleResBytes, err = getTxData(ctx, txhash, t)
+		t.WithFields(testing.Fields{
+			"action": "GetTxData",
+			"error":  err,
+		}).Debug(string(txHandleResBytes))
+		if err != nil {
+			// maybe transaction is not contained in block, or it's dropped from mempool by eviction or node restart
+			if _, err = droppedTxWatchdog.check(ctx, t, policy, txhash); err != nil {
+				return false, err
+			}
+			return false, nil
+		}
+		return true, nil
+	}, "tx "+txhash, c.maxWaitBlock)
+	t.WithFields(waited.Fields()).Debug("waited for tx")
+	if errors.Is(err, ErrWaitTimeout) {
+		t.WithFields(testing.Fields{
+			"action": "func_end",
+		}).Error("didn't get result waiting for maximum wait block")
+		return txHandleResBytes, errors.New("didn't get result waiting for maximum wait block")
+	}
+	if err != nil {
+		return txHandleResBytes, err
+	}
+	return txHandleResBytes, WaitForTxConfirmationCtx(ctx, txhash, c.confirmationDepth, t)
+}
+
+// WaitForTxResult is a function to wait for transaction to be processed and parse its result
+func (c *Client) WaitForTxResult(ctx context.Context, t *testing.T, txhash string) (txResult TxResult, err error) {
+	ctx = c.withContext(ctx)
+	ctx, span := StartSpan(withTestSpan(ctx, t), "tx result", SpanKindInternal)
+	span.SetAttribute("tx.hash", txhash)
+	defer func() {
+		span.SetAttribute("block.height", txResult.Height)
+		span.End(err)
+	}()
+	committed, isCommitted := committedTxResponse(txhash)
+	if _, err = c.WaitForTx(ctx, t, txhash); err != nil {
+		return TxResult{}, err
+	}
+	if isCommitted {
+		// result of block mode broadcast is parsed from its response
+		txResult, err = NewTxResult(committed)
+	} else {
+		txResult, err = getTxResult(ctx, txhash)
+	}
+	if err == nil {
+		// events of processed tx are observed for ExpectEvent of the test unless a subscriber observed them
+		t.ObserveTxEvents(txhash, txResult.ChainEvents())
+		err = txResult.Err()
+		if err != nil {
+			// failed result is attached for debugging failures of CI runs without rerunning
+			if output, jsonErr := GetJSONMarshaler().MarshalJSON(&txResult.TxResponse); jsonErr == nil {
+				t.AttachFile("tx_"+txhash+".json", output)
+			}
+		}
+	}
+	if c.recorder != nil {
+		c.recorder.RecordTxResult(txhash, txResult, err)
+	}
+	return txResult, err
+}
+
+// SendTxAndWait is a function to send msgs in one transaction and wait for its result
+func (c *Client) SendTxAndWait(ctx context.Context, t *testing.T, signer Signer, msgs ...sdk.Msg) (TxResult, error) {
+	txhash, err := c.SendTx(ctx, t, signer, msgs...)
+	if err != nil {
+		return TxResult{}, err
+	}
+	txResult, err := c.WaitForTxResult(ctx, t, txhash)
+	if err != nil && txResult.Code != 0 {
+		observeTxFailure(msgs, err)
+	}
+	return txResult, err
+}
+
+// MsgService is a function to get pylons msg service sending each msg as a transaction of signer by the client
+// so that code written against service.PylonsMsgService can be run on the test chain as well as with its mock.
+// The msg service of WithMsgService is returned for every signer when it's set.
+func (c *Client) MsgService(t *testing.T, signer Signer) service.PylonsMsgService {
+	if c.msgService != nil {
+		return c.msgService
+	}
+	return service.NewTxMsgService(func(ctx context.Context, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+		txResult, err := c.SendTxAndWait(ctx, t, signer, msgs...)
+		if err != nil && txResult.Code == 0 {
+			return nil, err
+		}
+		return &txResult.TxResponse, nil
+	})
+}
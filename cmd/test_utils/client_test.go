@@ -0,0 +1,89 @@
+package inttest
+
+import (
+	"context"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestNewClient(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	originOpts := CLIOpts
+	defer func() {
+		CLIOpts = originOpts
+	}()
+
+	CLIOpts.MaxWaitBlock = 5
+	CLIOpts.ConfirmationDepth = 2
+	client := NewClient()
+	t.MustTrue(client.maxWaitBlock == 5 && client.confirmationDepth == 2, "options should be taken from CLIOpts by default")
+	t.MustTrue(client.maxBroadcast == GetMaxBroadcastRetry(), "max broadcast retry should be taken from CLIOpts by default")
+
+	client = NewClient(WithMaxWaitBlock(0), WithConfirmationDepth(0), WithMaxBroadcastRetry(1))
+	t.MustTrue(client.maxWaitBlock == 0 && client.confirmationDepth == 0, "zero options should override CLIOpts")
+	t.MustTrue(client.maxBroadcast == 1, "max broadcast retry should be set by option")
+
+	recorder := &nopTxRecorder{}
+	CLIOpts.TxRecorder = recorder
+	t.MustTrue(NewClient().recorder == recorder, "tx recorder should be taken from CLIOpts by default")
+	t.MustTrue(NewClient(WithTxRecorder(nil)).recorder == nil, "tx recorder should be set by option")
+
+	granter := sdk.AccAddress([]byte("fee_granter_________")).String()
+	client = NewClient(WithMemo("gasless"), WithFeeGranter(granter))
+	t.MustTrue(client.txOpts == TxOptions{Memo: "gasless", FeeGranter: granter, FeeStrategy: ProfileFee{}}, "tx options should be set by options")
+
+	t.MustTrue(!SignerKey("eugen").isAddress && SignerAddress("cosmos1...").isAddress, "signer should keep its kind")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.SendTx(ctx, &t, SignerKey("eugen"))
+	t.MustTrue(err == context.Canceled, "canceled context should stop sending transaction")
+	_, err = client.WaitForTx(ctx, &t, "txhash")
+	t.MustTrue(err == context.Canceled, "canceled context should stop waiting for transaction")
+}
+
+type nopTxRecorder struct{}
+
+func (r *nopTxRecorder) RecordTx(msgs []sdk.Msg, output string, err error) {}
+
+func (r *nopTxRecorder) RecordTxResult(txhash string, txResult TxResult, err error) {}
+
+func TestGenTxWithOptions(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	player := sdk.AccAddress([]byte("gasless_player______")).String()
+	granter := sdk.AccAddress([]byte("fee_granter_________")).String()
+	msgs := []sdk.Msg{&types.MsgGetPylons{Amount: sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 10)), Requester: player}}
+	tx, err := GenTxWithOptions(msgs, TxOptions{Memo: "gasless", FeeGranter: granter, FeePayer: player})
+	t.MustNil(err, "error generating transaction with options")
+	feeTx, ok := tx.(sdk.FeeTx)
+	t.MustTrue(ok, "transaction should have fee")
+	t.MustTrue(tx.GetMemo() == "gasless", "memo should be set")
+	t.MustTrue(feeTx.FeeGranter().String() == granter && feeTx.FeePayer().String() == player, "fee granter and payer should be set")
+
+	_, err = GenTxWithOptions(msgs, TxOptions{FeeGranter: "granter"})
+	t.MustTrue(err != nil, "invalid fee granter should be rejected")
+}
+
+func TestAuthzHelpers(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	t.MustTrue(MsgTypeURL(&types.MsgExecuteRecipe{}) == "/pylons.MsgExecuteRecipe", "type url should be proto name of msg")
+
+	client := NewClient()
+	granter := SignerAddress(sdk.AccAddress([]byte("authz_player________")).String())
+	grantee := sdk.AccAddress([]byte("authz_game_server___")).String()
+	_, err := client.GrantAuthz(context.Background(), &t, AuthzGrant{Granter: granter, Grantee: grantee})
+	t.MustTrue(err != nil, "grant without msg type should be rejected")
+	_, err = client.ExecAuthz(context.Background(), &t, SignerAddress(grantee))
+	t.MustTrue(err != nil, "exec without msgs should be rejected")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.RevokeAuthz(ctx, &t, granter, grantee, MsgTypeURL(&types.MsgExecuteRecipe{}))
+	t.MustTrue(err == context.Canceled, "canceled context should stop revoke before running pylonsd")
+}
@@ -0,0 +1,110 @@
+package inttest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock is an interface of time used by waiting and retry helpers instead of time package
+// Unit tests set FakeClock by WithClock or ContextWithClock so that waits finish instantly.
+type Clock interface {
+	// Now returns the current time of clock
+	Now() time.Time
+	// After returns channel receiving the time of clock after d passes
+	After(d time.Duration) <-chan time.Time
+	// Sleep pauses until d passes
+	Sleep(d time.Duration)
+}
+
+// SystemClock is a clock of wall time by time package
+type SystemClock struct{}
+
+// Now is a function to get current wall time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After is a function to get channel receiving wall time after d
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Sleep is a function to pause for d
+func (SystemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// FakeClock is a clock whose time passes only by Advance and by waits on it
+// After and Sleep move the time forward by their duration at once instead of waiting, and waits are recorded.
+type FakeClock struct {
+	mux   sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+// NewFakeClock is a function to create fake clock starting at start
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now is a function to get current time of fake clock
+func (c *FakeClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.now
+}
+
+// Advance is a function to move time of fake clock forward by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// After is a function to move time forward by d and get channel which already received the new time
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.now = c.now.Add(d)
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// Sleep is a function to move time forward by d without waiting
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// Waits is a function to get durations waited on fake clock by After and Sleep in order
+func (c *FakeClock) Waits() []time.Duration {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return append([]time.Duration{}, c.waits...)
+}
+
+// GetClock is a function to get global clock, CLIOpts.Clock or SystemClock when it's not set
+func GetClock() Clock {
+	if CLIOpts.Clock != nil {
+		return CLIOpts.Clock
+	}
+	return SystemClock{}
+}
+
+// clockKey is the context key of clock
+type clockKey struct{}
+
+// ContextWithClock is a function to make waits and retries done with ctx use clock instead of the global one
+func ContextWithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+// ClockFromContext is a function to get clock of ctx, global clock when ctx has none
+func ClockFromContext(ctx context.Context) Clock {
+	if clock, ok := ctx.Value(clockKey{}).(Clock); ok && clock != nil {
+		return clock
+	}
+	return GetClock()
+}
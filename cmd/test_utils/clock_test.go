@@ -0,0 +1,79 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestFakeClock(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	start := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	clock.Advance(time.Minute)
+	fired := <-clock.After(time.Second)
+	clock.Sleep(2 * time.Second)
+	t.MustTrue(fired.Equal(start.Add(61*time.Second)), "after should fire at time moved forward")
+	t.MustTrue(clock.Now().Equal(start.Add(63*time.Second)), "waits should move time forward")
+	t.MustTrue(len(clock.Waits()) == 2 && clock.Waits()[1] == 2*time.Second, "waits should be recorded in order")
+
+	ctx := ContextWithClock(context.Background(), clock)
+	t.MustTrue(ClockFromContext(ctx) == clock, "clock of ctx should be used")
+	_, isSystem := ClockFromContext(context.Background()).(SystemClock)
+	t.MustTrue(isSystem, "system clock should be used without clock of ctx")
+	client := NewClient(WithClock(clock))
+	t.MustTrue(ClockFromContext(client.withContext(context.Background())) == clock, "clock of client should be set on ctx")
+}
+
+func TestWaitForFakeClock(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	clock := NewFakeClock(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC))
+	ctx := ContextWithClock(context.Background(), clock)
+	realStart := time.Now()
+	never := func() (bool, error) { return false, nil }
+	result, err := WaitFor(ctx, never, WaitOptions{Name: "never", PollInterval: time.Second, MaxWait: time.Minute})
+	t.WithFields(result.Fields()).MustTrue(errors.Is(err, ErrWaitTimeout), "wait should time out after max wait of clock")
+	t.WithFields(result.Fields()).MustTrue(result.Polls == 61 && result.Elapsed == time.Minute, "condition should be checked every poll interval of clock")
+	t.MustTrue(time.Since(realStart) < time.Second, "wait on fake clock should finish instantly")
+
+	polls := 0
+	result, err = WaitFor(ctx, func() (bool, error) {
+		polls++
+		return polls == 5, nil
+	}, WaitOptions{Name: "fifth poll", PollInterval: 10 * time.Second, MaxWait: time.Minute})
+	t.MustTrue(err == nil && result.Elapsed == 40*time.Second, "elapsed time should be measured by clock")
+
+	err = FixedDelayStrategy{BlockTime: 6 * time.Second}.WaitForBlockInterval(ctx, 3)
+	waits := clock.Waits()
+	t.MustTrue(err == nil && waits[len(waits)-1] == 18*time.Second, "fixed delay should wait block time per block")
+}
+
+func TestRetryPolicyFakeClock(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	clock := NewFakeClock(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC))
+	ctx := ContextWithClock(context.Background(), clock)
+	policy := RetryPolicy{
+		MaxAttempts:     5,
+		InitialBackoff:  500 * time.Millisecond,
+		MaxBackoff:      2 * time.Second,
+		Multiplier:      2,
+		RetryableErrors: []error{ErrMempoolFull},
+	}
+	realStart := time.Now()
+	attempts := 0
+	err := policy.Do(ctx, func() error {
+		attempts++
+		return NewTxError("sdk", 20, "mempool is full")
+	})
+	t.MustTrue(errors.Is(err, ErrMempoolFull) && attempts == 5, "retry should stop at max attempts")
+	t.WithFields(testing.Fields{
+		"waits": clock.Waits(),
+	}).MustTrue(len(clock.Waits()) == 4 && clock.Waits()[0] == 500*time.Millisecond && clock.Waits()[3] == 2*time.Second, "backoffs should be waited on clock")
+	t.MustTrue(time.Since(realStart) < time.Second, "retry on fake clock should finish instantly")
+}
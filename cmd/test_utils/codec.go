@@ -0,0 +1,118 @@
+package inttest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Encoding is a type of json encoding of node outputs
+type Encoding int
+
+// describes json encodings of node outputs
+const (
+	// EncodingAuto detects encoding of each output, proto json is tried before amino json
+	EncodingAuto Encoding = iota
+	// EncodingProto is proto json encoding of newer nodes
+	EncodingProto
+	// EncodingAmino is legacy amino json encoding
+	EncodingAmino
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case EncodingProto:
+		return "proto"
+	case EncodingAmino:
+		return "amino"
+	}
+	return "auto"
+}
+
+// Set is a function to set encoding from its name as flag value
+func (e *Encoding) Set(name string) error {
+	encoding, err := ParseEncoding(name)
+	if err != nil {
+		return err
+	}
+	*e = encoding
+	return nil
+}
+
+// ParseEncoding is a function to get encoding from its name
+func ParseEncoding(name string) (Encoding, error) {
+	switch strings.ToLower(name) {
+	case "", "auto":
+		return EncodingAuto, nil
+	case "proto", "protobuf":
+		return EncodingProto, nil
+	case "amino":
+		return EncodingAmino, nil
+	}
+	return EncodingAuto, fmt.Errorf("unknown encoding %s, it should be one of auto, proto and amino", name)
+}
+
+// Codec is a struct to decode node outputs into the same typed structs regardless of node's json encoding
+type Codec struct {
+	encoding Encoding
+	amino    *codec.LegacyAmino
+	proto    codec.Marshaler
+}
+
+// NewCodec is a function to create codec decoding outputs by encoding
+func NewCodec(encoding Encoding) *Codec {
+	return &Codec{
+		encoding: encoding,
+		amino:    GetAminoCdc(),
+		proto:    GetJSONMarshaler(),
+	}
+}
+
+// GetCodec is a function to get codec of encoding configured by CLIOpts
+func GetCodec() *Codec {
+	return DefaultEnv().Codec()
+}
+
+// Encoding is a function to get configured encoding of codec
+func (c *Codec) Encoding() Encoding {
+	return c.encoding
+}
+
+// DetectEncoding is a function to detect json encoding of output by decoding it into ptr
+func (c *Codec) DetectEncoding(bz []byte, ptr interface{}) (Encoding, error) {
+	msg, isProto := ptr.(proto.Message)
+	switch {
+	case c.encoding == EncodingProto && !isProto:
+		return EncodingProto, fmt.Errorf("%T is not a proto message to decode proto json", ptr)
+	case c.encoding == EncodingProto:
+		return EncodingProto, c.proto.UnmarshalJSON(bz, msg)
+	case c.encoding == EncodingAmino || !isProto:
+		return EncodingAmino, c.amino.UnmarshalJSON(bz, ptr)
+	}
+	protoErr := c.proto.UnmarshalJSON(bz, msg)
+	if protoErr == nil {
+		return EncodingProto, nil
+	}
+	// proto decoding can leave fields set before it fails
+	msg.Reset()
+	if aminoErr := c.amino.UnmarshalJSON(bz, ptr); aminoErr != nil {
+		return EncodingAuto, fmt.Errorf("output is neither proto json (%s) nor amino json (%s)", protoErr.Error(), aminoErr.Error())
+	}
+	return EncodingAmino, nil
+}
+
+// Decode is a function to decode json output of node into ptr
+func (c *Codec) Decode(bz []byte, ptr interface{}) error {
+	_, err := c.DetectEncoding(bz, ptr)
+	return err
+}
+
+// Encode is a function to encode o into json, proto messages are encoded in proto json unless codec is amino
+func (c *Codec) Encode(o interface{}) ([]byte, error) {
+	if msg, ok := o.(proto.Message); ok && c.encoding != EncodingAmino {
+		return c.proto.MarshalJSON(msg)
+	}
+	return c.amino.MarshalJSON(o)
+}
@@ -0,0 +1,46 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestCodecDetectEncoding(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	// amino wraps interfaces by type and value while proto json expects @type of Any
+	aminoOutput := []byte(`{"height":"10","txhash":"AB","tx":{"type":"cosmos-sdk/StdTx","value":{"msg":[],"memo":""}}}`)
+	// amino expects quoted int64 values
+	protoOutput := []byte(`{"height":10,"txhash":"AB"}`)
+
+	var resp sdk.TxResponse
+	encoding, err := NewCodec(EncodingAuto).DetectEncoding(aminoOutput, &resp)
+	t.MustNil(err, "amino output should be decoded by auto codec")
+	t.MustTrue(encoding == EncodingAmino && resp.Height == 10 && resp.TxHash == "AB", "amino output should be detected")
+
+	resp = sdk.TxResponse{}
+	encoding, err = NewCodec(EncodingAuto).DetectEncoding(protoOutput, &resp)
+	t.MustNil(err, "proto output should be decoded by auto codec")
+	t.MustTrue(encoding == EncodingProto && resp.Height == 10, "proto output should be detected")
+
+	t.MustTrue(NewCodec(EncodingProto).Decode(aminoOutput, &resp) != nil, "proto codec should not decode amino output")
+	t.MustTrue(NewCodec(EncodingAmino).Decode(protoOutput, &resp) != nil, "amino codec should not decode proto output")
+	t.MustTrue(NewCodec(EncodingAuto).Decode([]byte(`{"height":`), &resp) != nil, "invalid json should fail")
+
+	var status resultStatus
+	t.MustNil(NewCodec(EncodingAuto).Decode([]byte(`{"SyncInfo":{"latest_block_height":"5"}}`), &status), "non proto struct should be decoded by amino")
+	t.MustTrue(status.SyncInfo.LatestBlockHeight == 5, "non proto struct should be decoded")
+
+	output, err := NewCodec(EncodingAuto).Encode(&sdk.TxResponse{Height: 3})
+	t.MustNil(err, "error encoding proto message")
+	t.MustTrue(string(output) != "" && NewCodec(EncodingProto).Decode(output, &resp) == nil, "proto message should be encoded in proto json")
+
+	for name, expected := range map[string]Encoding{"": EncodingAuto, "Proto": EncodingProto, "amino": EncodingAmino} {
+		encoding, err := ParseEncoding(name)
+		t.MustTrue(err == nil && encoding == expected, "encoding should be parsed")
+	}
+	_, err = ParseEncoding("xml")
+	t.MustTrue(err != nil, "unknown encoding should be rejected")
+}
@@ -0,0 +1,211 @@
+package inttest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+)
+
+// ErrUnknownCommand is an error of pylonsd arguments which are not a command of the supported command grammar
+var ErrUnknownCommand = errors.New("unknown pylonsd command")
+
+// ErrInvalidCommandArgs is an error of pylonsd arguments not fitting the command e.g. missing positional arguments
+var ErrInvalidCommandArgs = errors.New("invalid pylonsd command arguments")
+
+// CommandSpec is a struct to describe a pylonsd command of the supported command grammar
+type CommandSpec struct {
+	Path        []string // subcommands e.g. ["query", "pylons", "get_recipe"]
+	MinArgs     int      // minimum number of positional arguments
+	MaxArgs     int      // maximum number of positional arguments, -1 when it's not limited
+	Node        bool     // the command connects to node of --node flag
+	Keyring     bool     // the command always accesses keyring
+	KeyringFrom bool     // the command accesses keyring when the transaction is signed by key of --from flag
+}
+
+// Name is a function to get command name e.g. "query pylons get_recipe"
+func (spec CommandSpec) Name() string {
+	return strings.Join(spec.Path, " ")
+}
+
+// commandGrammar is the supported pylonsd commands, RegisterCommand adds commands to it
+var commandGrammar = []CommandSpec{
+	{Path: []string{"status"}, Node: true},
+
+	{Path: []string{"query", "account"}, MinArgs: 1, MaxArgs: 1, Node: true},
+	{Path: []string{"query", "tx"}, MinArgs: 1, MaxArgs: 1, Node: true},
+	{Path: []string{"query", "bank", "balances"}, MinArgs: 1, MaxArgs: 1, Node: true},
+	{Path: []string{"query", "bank", "total"}, Node: true},
+	{Path: []string{"query", "gov", "proposal"}, MinArgs: 1, MaxArgs: 1, Node: true},
+	{Path: []string{"query", "params", "subspace"}, MinArgs: 2, MaxArgs: 2, Node: true},
+	{Path: []string{"query", "pylons", "get_cookbook"}, MinArgs: 1, MaxArgs: 1, Node: true},
+	{Path: []string{"query", "pylons", "get_recipe"}, MinArgs: 1, MaxArgs: 1, Node: true},
+	{Path: []string{"query", "pylons", "get_execution"}, MinArgs: 1, MaxArgs: 1, Node: true},
+	{Path: []string{"query", "pylons", "get_item"}, MinArgs: 1, MaxArgs: 1, Node: true},
+	{Path: []string{"query", "pylons", "get_locked_coins"}, Node: true},
+	{Path: []string{"query", "pylons", "get_locked_coin_details"}, Node: true},
+	{Path: []string{"query", "pylons", "list_cookbook"}, Node: true},
+	{Path: []string{"query", "pylons", "list_recipe"}, Node: true},
+	{Path: []string{"query", "pylons", "list_trade"}, Node: true},
+	{Path: []string{"query", "pylons", "list_executions"}, Node: true},
+	{Path: []string{"query", "pylons", "items_by_sender"}, Node: true},
+
+	{Path: []string{"tx", "sign"}, MinArgs: 1, MaxArgs: 1, Node: true, Keyring: true},
+	{Path: []string{"tx", "multisign"}, MinArgs: 3, MaxArgs: -1, Node: true, Keyring: true},
+	{Path: []string{"tx", "broadcast"}, MinArgs: 1, MaxArgs: 1, Node: true},
+	{Path: []string{"tx", "bank", "send"}, MinArgs: 3, MaxArgs: 3, Node: true, KeyringFrom: true},
+	{Path: []string{"tx", "authz", "grant"}, MinArgs: 2, MaxArgs: 2, Node: true, KeyringFrom: true},
+	{Path: []string{"tx", "authz", "revoke"}, MinArgs: 2, MaxArgs: 2, Node: true, KeyringFrom: true},
+	{Path: []string{"tx", "authz", "exec"}, MinArgs: 1, MaxArgs: 1, Node: true, KeyringFrom: true},
+	{Path: []string{"tx", "pylons", "create-account"}, Node: true, Keyring: true},
+	{Path: []string{"tx", "pylons", "execute-recipe"}, MinArgs: 1, MaxArgs: 2, Node: true, KeyringFrom: true},
+
+	{Path: []string{"keys", "add"}, MinArgs: 1, MaxArgs: 1, Keyring: true},
+	{Path: []string{"keys", "show"}, MinArgs: 1, MaxArgs: -1, Keyring: true},
+	{Path: []string{"keys", "list"}, Keyring: true},
+	{Path: []string{"keys", "delete"}, MinArgs: 1, MaxArgs: -1, Keyring: true},
+	{Path: []string{"keys", "import"}, MinArgs: 2, MaxArgs: 2, Keyring: true},
+	{Path: []string{"keys", "export"}, MinArgs: 1, MaxArgs: 1, Keyring: true},
+	{Path: []string{"keys", "rename"}, MinArgs: 2, MaxArgs: 2, Keyring: true},
+	{Path: []string{"keys", "migrate"}, MaxArgs: 1, Keyring: true},
+}
+
+var commandGrammarMux sync.RWMutex
+
+// boolFlags are flags of supported commands which take no value, other flags take the next argument as value
+// unless the value is set by "--name=value".
+var boolFlags = map[string]bool{
+	"-a": true, "--address": true, "-y": true, "--" + flags.FlagSkipConfirmation: true,
+	"--" + flags.FlagOffline: true, "--" + flags.FlagGenerateOnly: true, "--" + flags.FlagDryRun: true,
+	"--recover": true, "--no-backup": true, "--" + flags.FlagUseLedger: true, "--interactive": true,
+	"--signature-only": true, "--append": true, "--force": true,
+}
+
+// RegisterCommand is a function to add a pylonsd command to the supported command grammar
+// e.g. for node binaries having more modules, a registered command with the same path replaces the former one.
+func RegisterCommand(spec CommandSpec) {
+	commandGrammarMux.Lock()
+	defer commandGrammarMux.Unlock()
+	for idx, registered := range commandGrammar {
+		if registered.Name() == spec.Name() {
+			commandGrammar[idx] = spec
+			return
+		}
+	}
+	commandGrammar = append(commandGrammar, spec)
+}
+
+// SupportedCommands is a function to get the supported pylonsd commands
+func SupportedCommands() []CommandSpec {
+	commandGrammarMux.RLock()
+	defer commandGrammarMux.RUnlock()
+	return append([]CommandSpec{}, commandGrammar...)
+}
+
+// CommandArgs is a struct to manage pylonsd arguments parsed by the supported command grammar
+type CommandArgs struct {
+	Spec        CommandSpec
+	Positionals []string // positional arguments after subcommands
+	Flags       []string // flags with their values as they are set on arguments
+}
+
+// UsesKeyring is a function to check if the command accesses keyring with the flags set on it
+func (c CommandArgs) UsesKeyring() bool {
+	return c.Spec.Keyring || (c.Spec.KeyringFrom && hasFlag(c.Flags, flags.FlagFrom))
+}
+
+// ParseCommandArgs is a function to parse pylonsd arguments by the supported command grammar
+// ErrUnknownCommand is returned when subcommands are not supported and ErrInvalidCommandArgs when positional
+// arguments or flag values don't fit the command.
+func ParseCommandArgs(args []string) (CommandArgs, error) {
+	if len(args) == 0 {
+		return CommandArgs{}, fmt.Errorf("%w: no command", ErrInvalidCommandArgs)
+	}
+	words := []string{}
+	parsed := CommandArgs{Flags: []string{}}
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			words = append(words, arg)
+			continue
+		}
+		parsed.Flags = append(parsed.Flags, arg)
+		if strings.Contains(arg, "=") || boolFlags[arg] {
+			continue
+		}
+		if idx+1 == len(args) || strings.HasPrefix(args[idx+1], "-") {
+			return parsed, fmt.Errorf("%w: flag %s has no value", ErrInvalidCommandArgs, arg)
+		}
+		idx++
+		parsed.Flags = append(parsed.Flags, args[idx])
+	}
+
+	found := false
+	for _, spec := range SupportedCommands() {
+		if len(spec.Path) > len(words) || (found && len(spec.Path) <= len(parsed.Spec.Path)) {
+			continue
+		}
+		if strings.Join(words[:len(spec.Path)], " ") == spec.Name() {
+			parsed.Spec = spec
+			found = true
+		}
+	}
+	if !found {
+		return parsed, fmt.Errorf("%w: %s", ErrUnknownCommand, strings.Join(words, " "))
+	}
+	parsed.Positionals = words[len(parsed.Spec.Path):]
+	if len(parsed.Positionals) < parsed.Spec.MinArgs || (parsed.Spec.MaxArgs >= 0 && len(parsed.Positionals) > parsed.Spec.MaxArgs) {
+		return parsed, fmt.Errorf("%w: %s takes %s positional arguments, got %d", ErrInvalidCommandArgs, parsed.Spec.Name(), parsed.Spec.argsRange(), len(parsed.Positionals))
+	}
+	return parsed, nil
+}
+
+// argsRange is a function to describe number of positional arguments the command takes e.g. "1 to 2"
+func (spec CommandSpec) argsRange() string {
+	switch {
+	case spec.MaxArgs < 0:
+		return fmt.Sprintf("at least %d", spec.MinArgs)
+	case spec.MinArgs == spec.MaxArgs:
+		return fmt.Sprintf("%d", spec.MinArgs)
+	default:
+		return fmt.Sprintf("%d to %d", spec.MinArgs, spec.MaxArgs)
+	}
+}
+
+// KeyringBackendSetupStrict is a function to setup keyring backend of provider for pylonsd command validated by the supported command grammar
+// Keyring flags are set on commands accessing keyring, chain id and confirmation skip on transactions signed by keyring and
+// json output on commands supporting --output flag. Flags already set on args are kept.
+func KeyringBackendSetupStrict(args []string, provider KeyringProvider) ([]string, error) {
+	return DefaultEnv().keyringBackendSetupStrict(args, provider)
+}
+
+// keyringBackendSetupStrict is a function to setup keyring backend of provider for pylonsd command signing transactions for chain id of env
+func (e *Env) keyringBackendSetupStrict(args []string, provider KeyringProvider) ([]string, error) {
+	cmd, err := ParseCommandArgs(args)
+	if err != nil {
+		return args, err
+	}
+	if cmd.UsesKeyring() {
+		args = appendMissingFlags(args, provider.KeyringArgs()...)
+		if cmd.Spec.Path[0] == "tx" {
+			args = appendMissingFlags(args,
+				fmt.Sprintf("--%s=%s", flags.FlagChainID, e.ChainID()),
+				fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
+			)
+		}
+	}
+	if supportsJSONOutput(args) {
+		args = appendMissingFlags(args,
+			fmt.Sprintf("--%s=json", tmcli.OutputFlag),
+		)
+	}
+	return args, nil
+}
+
+// NodeFlagSetupStrict is a function to setup configured custom node on pylonsd command validated by the supported command grammar
+func NodeFlagSetupStrict(args []string) ([]string, error) {
+	return DefaultEnv().nodeFlagSetupStrict(args)
+}
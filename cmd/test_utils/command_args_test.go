@@ -0,0 +1,80 @@
+package inttest
+
+import (
+	"errors"
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestCommandArgsSetup(originT *originT.T) {
+	t := testing.NewT(originT)
+	env := NewEnv(CLIOptions{CustomNode: "tcp://node0:26657"}, testing.NewReporter())
+	provider := TestKeyring{Dir: "/tmp/keys"}
+
+	for _, spec := range SupportedCommands() {
+		args := append([]string{}, spec.Path...)
+		for idx := 0; idx < spec.MinArgs; idx++ {
+			args = append(args, "arg")
+		}
+		args = append(args, "--from", "eugen")
+		cmd, err := ParseCommandArgs(args)
+		t.WithFields(testing.Fields{
+			"command": spec.Name(),
+		}).MustNil(err, "supported command should be parsed")
+		t.MustTrue(cmd.Spec.Name() == spec.Name() && len(cmd.Positionals) == spec.MinArgs, "command should be matched with its positional args")
+
+		setup, err := env.nodeFlagSetupStrict(args)
+		t.MustNil(err, "error setting up node flag")
+		t.WithFields(testing.Fields{
+			"command": spec.Name(),
+			"args":    strings.Join(setup, " "),
+		}).MustTrue(hasFlag(setup, "node") == spec.Node, "node flag should be set on commands connecting to node")
+
+		setup, err = KeyringBackendSetupStrict(args, provider)
+		t.MustNil(err, "error setting up keyring backend")
+		t.WithFields(testing.Fields{
+			"command": spec.Name(),
+			"args":    strings.Join(setup, " "),
+		}).MustTrue(hasFlag(setup, "keyring-dir") == (spec.Keyring || spec.KeyringFrom), "keyring flags should be set on commands accessing keyring")
+		t.MustTrue(hasFlag(setup, "chain-id") == (spec.Path[0] == "tx" && (spec.Keyring || spec.KeyringFrom)), "chain id should be set on transactions signed by keyring")
+
+		if spec.MinArgs > 0 {
+			_, err = ParseCommandArgs(spec.Path)
+			t.WithFields(testing.Fields{
+				"command": spec.Name(),
+			}).MustTrue(errors.Is(err, ErrInvalidCommandArgs), "missing positional args should be rejected")
+		}
+		if spec.MaxArgs >= 0 {
+			_, err = ParseCommandArgs(append(append([]string{}, spec.Path...), strings.Split(strings.Repeat("arg ", spec.MaxArgs+1), " ")[:spec.MaxArgs+1]...))
+			t.WithFields(testing.Fields{
+				"command": spec.Name(),
+			}).MustTrue(errors.Is(err, ErrInvalidCommandArgs), "extra positional args should be rejected")
+		}
+	}
+
+	args, err := KeyringBackendSetupStrict([]string{"tx", "pylons", "execute-recipe", "rcp1"}, provider)
+	t.MustNil(err, "error setting up keyring backend")
+	t.MustTrue(!hasFlag(args, "keyring-backend"), "keyring flags should not be set on transactions without signer")
+	cmd, err := ParseCommandArgs([]string{"tx", "sign", "--from", "eugen", "raw_tx.json", "--offline", "--multisig", "cosmos1abc"})
+	t.MustNil(err, "flags should be allowed between positional args")
+	t.MustTrue(len(cmd.Positionals) == 1 && cmd.Positionals[0] == "raw_tx.json", "flag values should not be positional args")
+
+	for _, args := range [][]string{nil, {}, {"query"}, {"query", "pylons"}, {"tx", "staking", "delegate"}, {"version"}} {
+		_, err := KeyringBackendSetupStrict(args, provider)
+		t.WithFields(testing.Fields{
+			"args": strings.Join(args, " "),
+		}).MustTrue(errors.Is(err, ErrUnknownCommand) || errors.Is(err, ErrInvalidCommandArgs), "unknown command should be rejected")
+		_, err = env.nodeFlagSetupStrict(args)
+		t.MustTrue(err != nil, "unknown command should be rejected by node flag setup")
+		t.MustTrue(len(env.nodeFlagSetup(args)) == len(args) && len(KeyringBackendSetupWithProvider(args, provider)) == len(args), "lenient setup should keep args of unknown command")
+	}
+	_, err = ParseCommandArgs([]string{"query", "tx", "ABC", "--node"})
+	t.MustTrue(errors.Is(err, ErrInvalidCommandArgs), "flag without value should be rejected")
+
+	RegisterCommand(CommandSpec{Path: []string{"query", "staking", "validators"}, Node: true})
+	args, err = env.nodeFlagSetupStrict([]string{"query", "staking", "validators"})
+	t.MustNil(err, "registered command should be supported")
+	t.MustTrue(hasFlag(args, "node"), "registered command should get node flag")
+}
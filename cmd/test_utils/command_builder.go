@@ -0,0 +1,297 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+)
+
+// CommandBuilder is a struct to build pylonsd command arguments with typed subcommands and flags
+// Node, keyring, chain id and output format flags are rendered by Args unless they are set explicitly.
+type CommandBuilder struct {
+	args    []string
+	flags   []string
+	stdin   string
+	keyring KeyringProvider
+	err     error
+}
+
+// newCommand is a function to start command builder with pylonsd subcommand
+func newCommand(args ...string) *CommandBuilder {
+	return &CommandBuilder{args: args}
+}
+
+// Query is a function to start building "pylonsd query" command
+func Query() *CommandBuilder {
+	return newCommand("query")
+}
+
+// Tx is a function to start building "pylonsd tx" command
+func Tx() *CommandBuilder {
+	return newCommand("tx")
+}
+
+// Keys is a function to start building "pylonsd keys" command
+func Keys() *CommandBuilder {
+	return newCommand("keys")
+}
+
+// Status is a function to build "pylonsd status" command
+func Status() *CommandBuilder {
+	return newCommand("status")
+}
+
+// Sub is a function to add subcommands or positional arguments
+func (b *CommandBuilder) Sub(args ...string) *CommandBuilder {
+	b.args = append(b.args, args...)
+	return b
+}
+
+// Pylons is a function to select pylons module commands
+func (b *CommandBuilder) Pylons() *CommandBuilder {
+	return b.Sub("pylons")
+}
+
+// Account is a function to query account of address
+func (b *CommandBuilder) Account(addr string) *CommandBuilder {
+	return b.Sub("account", addr)
+}
+
+// TxByHash is a function to query committed transaction by hash
+func (b *CommandBuilder) TxByHash(txhash string) *CommandBuilder {
+	return b.Sub("tx", txhash)
+}
+
+// Cookbook is a function to query pylons cookbook by id
+func (b *CommandBuilder) Cookbook(id string) *CommandBuilder {
+	return b.Sub("get_cookbook", id)
+}
+
+// Recipe is a function to query pylons recipe by id
+func (b *CommandBuilder) Recipe(id string) *CommandBuilder {
+	return b.Sub("get_recipe", id)
+}
+
+// Execution is a function to query pylons execution by id
+func (b *CommandBuilder) Execution(id string) *CommandBuilder {
+	return b.Sub("get_execution", id)
+}
+
+// Item is a function to query pylons item by id
+func (b *CommandBuilder) Item(id string) *CommandBuilder {
+	return b.Sub("get_item", id)
+}
+
+// checkID is a function to keep the first id validation error, it's returned by Run
+func (b *CommandBuilder) checkID(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// CookbookByID is a function to query pylons cookbook by validated id
+func (b *CommandBuilder) CookbookByID(id CookbookID) *CommandBuilder {
+	b.checkID(id.Validate())
+	return b.Cookbook(id.String())
+}
+
+// RecipeByID is a function to query pylons recipe by validated id
+func (b *CommandBuilder) RecipeByID(id RecipeID) *CommandBuilder {
+	b.checkID(id.Validate())
+	return b.Recipe(id.String())
+}
+
+// ExecutionByID is a function to query pylons execution by validated id
+func (b *CommandBuilder) ExecutionByID(id ExecutionID) *CommandBuilder {
+	b.checkID(id.Validate())
+	return b.Execution(id.String())
+}
+
+// ItemByID is a function to query pylons item by validated id
+func (b *CommandBuilder) ItemByID(id ItemID) *CommandBuilder {
+	b.checkID(id.Validate())
+	return b.Item(id.String())
+}
+
+// List is a function to run pylons list query filtered by account when it's not empty e.g. list_recipe
+func (b *CommandBuilder) List(query string, account string) *CommandBuilder {
+	b.Sub(query)
+	if len(account) != 0 {
+		b.Flag("account", account)
+	}
+	return b
+}
+
+// CreateAccount is a function to create account of key set by From on chain
+func (b *CommandBuilder) CreateAccount() *CommandBuilder {
+	return b.Sub("create-account")
+}
+
+// ExecuteRecipe is a function to execute pylons recipe with input items
+func (b *CommandBuilder) ExecuteRecipe(recipeID string, itemIDs ...string) *CommandBuilder {
+	b.Sub("execute-recipe", recipeID)
+	if len(itemIDs) > 0 {
+		b.Sub(strings.Join(itemIDs, ","))
+	}
+	return b
+}
+
+// ExecuteRecipeByID is a function to execute pylons recipe of validated id with input items of validated ids
+func (b *CommandBuilder) ExecuteRecipeByID(recipeID RecipeID, itemIDs ...ItemID) *CommandBuilder {
+	b.checkID(recipeID.Validate())
+	strs, err := ItemIDStrings(itemIDs)
+	b.checkID(err)
+	return b.ExecuteRecipe(recipeID.String(), strs...)
+}
+
+// Sign is a function to sign transaction file
+func (b *CommandBuilder) Sign(txFile string) *CommandBuilder {
+	return b.Sub("sign", txFile)
+}
+
+// Multisign is a function to combine signatures of multisig key on transaction file
+func (b *CommandBuilder) Multisign(txFile string, multisigKey string, signatureFiles ...string) *CommandBuilder {
+	return b.Sub(append([]string{"multisign", txFile, multisigKey}, signatureFiles...)...)
+}
+
+// Broadcast is a function to broadcast signed transaction file with broadcast mode
+func (b *CommandBuilder) Broadcast(txFile string, mode string) *CommandBuilder {
+	return b.Sub("broadcast", txFile).Flag(flags.FlagBroadcastMode, mode)
+}
+
+// Show is a function to show key, only address is shown when addressOnly is set
+func (b *CommandBuilder) Show(key string, addressOnly bool) *CommandBuilder {
+	b.Sub("show", key)
+	if addressOnly {
+		b.Sub("-a")
+	}
+	return b
+}
+
+// Add is a function to add new key
+func (b *CommandBuilder) Add(key string) *CommandBuilder {
+	return b.Sub("add", key)
+}
+
+// Recover is a function to add key recovered from mnemonic
+func (b *CommandBuilder) Recover(key, mnemonic string) *CommandBuilder {
+	return b.Sub("add", key, "--recover").Stdin(mnemonic + "\n")
+}
+
+// HDPath is a function to set BIP44 derivation path of key e.g. m/44'/118'/0'/0/1
+func (b *CommandBuilder) HDPath(hdPath string) *CommandBuilder {
+	return b.Flag("hd-path", hdPath)
+}
+
+// Delete is a function to delete key without confirmation
+func (b *CommandBuilder) Delete(key string) *CommandBuilder {
+	return b.Sub("delete", key, "-y")
+}
+
+// Flag is a function to set flag with value
+func (b *CommandBuilder) Flag(name, value string) *CommandBuilder {
+	b.flags = append(b.flags, fmt.Sprintf("--%s=%s", name, value))
+	return b
+}
+
+// BoolFlag is a function to set boolean flag
+func (b *CommandBuilder) BoolFlag(name string) *CommandBuilder {
+	b.flags = append(b.flags, "--"+name)
+	return b
+}
+
+// From is a function to set key name or address of transaction signer
+func (b *CommandBuilder) From(account string) *CommandBuilder {
+	return b.Flag(flags.FlagFrom, account)
+}
+
+// Gas is a function to set gas limit of transaction
+func (b *CommandBuilder) Gas(gas uint64) *CommandBuilder {
+	return b.Flag(flags.FlagGas, strconv.FormatUint(gas, 10))
+}
+
+// GasAuto is a function to estimate gas limit of transaction by simulation
+func (b *CommandBuilder) GasAuto() *CommandBuilder {
+	return b.Flag(flags.FlagGas, flags.GasFlagAuto)
+}
+
+// Fees is a function to set fees of transaction e.g. "100upylon"
+func (b *CommandBuilder) Fees(fees string) *CommandBuilder {
+	return b.Flag(flags.FlagFees, fees)
+}
+
+// Offline is a function to sign transaction with account number and sequence without querying node
+func (b *CommandBuilder) Offline(accountNumber, sequence uint64) *CommandBuilder {
+	return b.BoolFlag(flags.FlagOffline).
+		Flag(flags.FlagAccountNumber, strconv.FormatUint(accountNumber, 10)).
+		Flag(flags.FlagSequence, strconv.FormatUint(sequence, 10))
+}
+
+// Height is a function to query state at block height
+func (b *CommandBuilder) Height(height int64) *CommandBuilder {
+	return b.Flag(flags.FlagHeight, strconv.FormatInt(height, 10))
+}
+
+// ChainID is a function to set chain id instead of GetChainID
+func (b *CommandBuilder) ChainID(chainID string) *CommandBuilder {
+	return b.Flag(flags.FlagChainID, chainID)
+}
+
+// Node is a function to set node instead of one of CLIOpts.CustomNode
+func (b *CommandBuilder) Node(node string) *CommandBuilder {
+	return b.Flag(flags.FlagNode, node)
+}
+
+// Output is a function to set output format instead of json
+func (b *CommandBuilder) Output(format string) *CommandBuilder {
+	return b.Flag(tmcli.OutputFlag, format)
+}
+
+// Stdin is a function to set input of the command
+func (b *CommandBuilder) Stdin(input string) *CommandBuilder {
+	b.stdin += input
+	return b
+}
+
+// WithKeyring is a function to run the command with keys of keyring provider instead of GetKeyringProvider
+func (b *CommandBuilder) WithKeyring(provider KeyringProvider) *CommandBuilder {
+	b.keyring = provider
+	return b
+}
+
+// provider is a function to get keyring provider the command runs with
+func (b *CommandBuilder) provider() KeyringProvider {
+	if b.keyring == nil {
+		return GetKeyringProvider()
+	}
+	return b.keyring
+}
+
+// Args is a function to render pylonsd arguments with node, keyring, chain id and output format flags
+func (b *CommandBuilder) Args() []string {
+	args := append(append([]string{}, b.args...), b.flags...)
+	return KeyringBackendSetupWithProvider(NodeFlagSetup(args), b.provider())
+}
+
+// String is a function to get the command line
+func (b *CommandBuilder) String() string {
+	return "pylonsd " + strings.Join(b.Args(), " ")
+}
+
+// Err is a function to get the first invalid id passed to the builder
+func (b *CommandBuilder) Err() error {
+	return b.err
+}
+
+// Run is a function to run the command, it's killed when ctx is done
+// The command is not run when an invalid id was passed to the builder.
+func (b *CommandBuilder) Run(ctx context.Context) ([]byte, string, error) {
+	if b.err != nil {
+		return nil, "", fmt.Errorf("%w; command %s", b.err, b.String())
+	}
+	return RunPylonsdWithKeyring(ctx, b.provider(), append(append([]string{}, b.args...), b.flags...), b.stdin)
+}
@@ -0,0 +1,59 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestCommandBuilderArgs(originT *originT.T) {
+	t := testing.NewT(originT)
+	prevNode, prevChainID, prevProfile := CLIOpts.CustomNode, CLIOpts.ChainID, CLIOpts.Profile
+	defer func() {
+		CLIOpts.CustomNode, CLIOpts.ChainID, CLIOpts.Profile = prevNode, prevChainID, prevProfile
+	}()
+	CLIOpts.CustomNode = "tcp://node0:26657"
+	CLIOpts.ChainID = "pylons-testnet"
+	CLIOpts.Profile = ""
+	provider := TestKeyring{}
+
+	for _, tc := range []struct {
+		desc     string
+		cmd      *CommandBuilder
+		expected []string
+	}{
+		{
+			"query renders node and json output",
+			Query().Pylons().Recipe("rcp1"),
+			[]string{"query", "pylons", "get_recipe", "rcp1", "--node", "tcp://node0:26657", "--output=json"},
+		},
+		{
+			"explicit output and node are kept",
+			Query().Pylons().List("list_recipe", "cosmos1abc").Output("text").Node("tcp://node1:26657"),
+			[]string{"query", "pylons", "list_recipe", "--account=cosmos1abc", "--output=text", "--node=tcp://node1:26657"},
+		},
+		{
+			"tx from key renders keyring and chain id",
+			Tx().Pylons().ExecuteRecipe("rcp1", "item1", "item2").From("eugen").GasAuto(),
+			[]string{"tx", "pylons", "execute-recipe", "rcp1", "item1,item2", "--from=eugen", "--gas=auto",
+				"--node", "tcp://node0:26657", "--keyring-backend=test", "--chain-id=pylons-testnet", "--yes=true", "--output=json"},
+		},
+		{
+			"broadcast does not use keyring",
+			Tx().Broadcast("signed.json", "sync"),
+			[]string{"tx", "broadcast", "signed.json", "--broadcast-mode=sync", "--node", "tcp://node0:26657", "--output=json"},
+		},
+		{
+			"keys show address",
+			Keys().Show("eugen", true),
+			[]string{"keys", "show", "eugen", "-a", "--keyring-backend=test"},
+		},
+	} {
+		args := tc.cmd.WithKeyring(provider).Args()
+		t.WithFields(testing.Fields{
+			"desc":     tc.desc,
+			"args":     args,
+			"expected": tc.expected,
+		}).MustTrue(JSONFormatter(args) == JSONFormatter(tc.expected), "rendered args are different from expected")
+	}
+}
@@ -0,0 +1,133 @@
+package inttest
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CommandPoolStats is a struct to describe usage of pylonsd command pool
+type CommandPoolStats struct {
+	Concurrency   int           `json:"concurrency"`
+	Running       int64         `json:"running"`
+	Waiting       int64         `json:"waiting"`
+	MaxRunning    int64         `json:"max_running"`
+	Completed     int64         `json:"completed"`
+	KeyringWrites int64         `json:"keyring_writes"`
+	TotalWait     time.Duration `json:"total_wait"`
+}
+
+// commandPool is a struct to limit number of pylonsd commands running at once
+// Commands which don't conflict run concurrently and only keyring writes are serialized
+type commandPool struct {
+	slots         chan struct{}
+	keyringSlot   chan struct{} // 1-buffered, held by the running keyring write
+	running       int64
+	waiting       int64
+	maxRunning    int64
+	completed     int64
+	keyringWrites int64
+	totalWait     int64 // nanoseconds
+}
+
+var cmdPool *commandPool
+var cmdPoolOnce sync.Once
+
+// GetCLIConcurrency is a function to get configuration for number of pylonsd commands running at once, default number of CPUs
+func GetCLIConcurrency() int {
+	if CLIOpts.CLIConcurrency <= 0 {
+		return runtime.NumCPU()
+	}
+	return CLIOpts.CLIConcurrency
+}
+
+// getCommandPool is a function to get command pool, it's created on first use with configured concurrency
+func getCommandPool() *commandPool {
+	cmdPoolOnce.Do(func() {
+		cmdPool = newCommandPool(GetCLIConcurrency())
+	})
+	return cmdPool
+}
+
+func newCommandPool(concurrency int) *commandPool {
+	return &commandPool{
+		slots:       make(chan struct{}, concurrency),
+		keyringSlot: make(chan struct{}, 1),
+	}
+}
+
+// run is a function to run f when a slot is free, f is serialized with other keyring writes when writesKeyring is true
+// It returns ctx error without running f when ctx is done before a slot is free or while waiting for other keyring writes
+func (p *commandPool) run(ctx context.Context, writesKeyring bool, f func()) error {
+	waitStart := time.Now()
+	atomic.AddInt64(&p.waiting, 1)
+	if writesKeyring {
+		select {
+		case p.keyringSlot <- struct{}{}:
+		case <-ctx.Done():
+			atomic.AddInt64(&p.waiting, -1)
+			return ctx.Err()
+		}
+		defer func() { <-p.keyringSlot }()
+	}
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		atomic.AddInt64(&p.waiting, -1)
+		return ctx.Err()
+	}
+	if writesKeyring {
+		atomic.AddInt64(&p.keyringWrites, 1)
+	}
+	atomic.AddInt64(&p.waiting, -1)
+	atomic.AddInt64(&p.totalWait, int64(time.Since(waitStart)))
+	running := atomic.AddInt64(&p.running, 1)
+	for {
+		maxRunning := atomic.LoadInt64(&p.maxRunning)
+		if running <= maxRunning || atomic.CompareAndSwapInt64(&p.maxRunning, maxRunning, running) {
+			break
+		}
+	}
+
+	defer func() {
+		atomic.AddInt64(&p.running, -1)
+		atomic.AddInt64(&p.completed, 1)
+		<-p.slots
+	}()
+	f()
+	return nil
+}
+
+// stats is a function to get current usage of the pool
+func (p *commandPool) stats() CommandPoolStats {
+	return CommandPoolStats{
+		Concurrency:   cap(p.slots),
+		Running:       atomic.LoadInt64(&p.running),
+		Waiting:       atomic.LoadInt64(&p.waiting),
+		MaxRunning:    atomic.LoadInt64(&p.maxRunning),
+		Completed:     atomic.LoadInt64(&p.completed),
+		KeyringWrites: atomic.LoadInt64(&p.keyringWrites),
+		TotalWait:     time.Duration(atomic.LoadInt64(&p.totalWait)),
+	}
+}
+
+// GetCommandPoolStats is a function to get usage of pylonsd command pool
+func GetCommandPoolStats() CommandPoolStats {
+	return getCommandPool().stats()
+}
+
+// writesKeyring is a function to check if pylonsd command changes keyring
+// Keyring files are not safe for concurrent writes, so these commands are serialized
+func writesKeyring(args []string) bool {
+	if len(args) < 2 || args[0] != "keys" {
+		return false
+	}
+	switch args[1] {
+	case "add", "delete", "import", "rename", "migrate":
+		return true
+	default:
+		return false
+	}
+}
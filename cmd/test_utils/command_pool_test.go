@@ -0,0 +1,88 @@
+package inttest
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestCommandPool(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	pool := newCommandPool(2)
+	var wg sync.WaitGroup
+	var keyringWriting, maxKeyringWriting int64
+	for idx := 0; idx < 6; idx++ {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			isKeyringWrite := idx%2 == 0
+			_ = pool.run(context.Background(), isKeyringWrite, func() {
+				if isKeyringWrite {
+					if writing := atomic.AddInt64(&keyringWriting, 1); writing > atomic.LoadInt64(&maxKeyringWriting) {
+						atomic.StoreInt64(&maxKeyringWriting, writing)
+					}
+					defer atomic.AddInt64(&keyringWriting, -1)
+				}
+				time.Sleep(20 * time.Millisecond)
+			})
+		}(idx)
+	}
+	wg.Wait()
+
+	stats := pool.stats()
+	t.WithFields(testing.Fields{
+		"stats": stats,
+	}).MustTrue(stats.MaxRunning == 2, "commands should run concurrently up to concurrency")
+	t.MustTrue(stats.Completed == 6 && stats.KeyringWrites == 3, "all commands should be counted")
+	t.MustTrue(stats.Running == 0 && stats.Waiting == 0, "no command should be left running")
+	t.MustTrue(maxKeyringWriting == 1, "keyring writes should be serialized")
+
+	blocked := make(chan struct{})
+	busyPool := newCommandPool(1)
+	go func() {
+		_ = busyPool.run(context.Background(), false, func() {
+			<-blocked
+		})
+	}()
+	defer close(blocked)
+	for busyPool.stats().Running == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ran := false
+	err := busyPool.run(ctx, false, func() {
+		ran = true
+	})
+	t.MustTrue(err == context.DeadlineExceeded && !ran, "command should not run when ctx is done before a slot is free")
+	t.MustTrue(busyPool.stats().Waiting == 0, "canceled command should not be left waiting")
+
+	writing := make(chan struct{})
+	keyringPool := newCommandPool(2)
+	go func() {
+		_ = keyringPool.run(context.Background(), true, func() {
+			<-writing
+		})
+	}()
+	defer close(writing)
+	for keyringPool.stats().Running == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	writeCtx, cancelWrite := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelWrite()
+	ran = false
+	err = keyringPool.run(writeCtx, true, func() {
+		ran = true
+	})
+	t.MustTrue(err == context.DeadlineExceeded && !ran, "keyring write should not run when ctx is done while another keyring write runs")
+	t.MustTrue(keyringPool.stats().Waiting == 0, "canceled keyring write should not be left waiting")
+
+	t.MustTrue(writesKeyring([]string{"keys", "add", "eugen"}), "keys add should write keyring")
+	t.MustTrue(!writesKeyring([]string{"keys", "show", "eugen", "-a"}), "keys show should not write keyring")
+	t.MustTrue(!writesKeyring([]string{"tx", "sign", "raw_tx.json"}), "tx sign should not write keyring")
+}
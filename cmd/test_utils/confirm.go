@@ -0,0 +1,158 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+const (
+	// defaultBlockPollInterval is used for polling until a block interval is observed
+	defaultBlockPollInterval = 100 * time.Millisecond
+	// defaultBlockWaitTimeout is the per block timeout used until a block interval is observed
+	defaultBlockWaitTimeout = 30 * time.Second
+	minBlockPollInterval    = 50 * time.Millisecond
+	maxBlockPollInterval    = 2 * time.Second
+	minBlockWaitTimeout     = 5 * time.Second
+	// pollsPerBlock is the number of status queries done within an average block interval
+	pollsPerBlock = 10
+	// blockWaitTimeoutFactor is the number of average block intervals to wait for a block before timeout
+	blockWaitTimeoutFactor = 5
+	// blockTimeSmoothing is the weight of latest observed block interval on the average
+	blockTimeSmoothing = 0.3
+)
+
+// blockTimeTracker is a struct to keep observed block intervals of the chain
+type blockTimeTracker struct {
+	mux          sync.Mutex
+	lastHeight   int64
+	lastTime     time.Time
+	avgBlockTime time.Duration
+}
+
+// ErrTxReorged is returned when a transaction disappears from chain after inclusion
+var ErrTxReorged = errors.New("transaction is reorged after inclusion")
+
+// observe is a function to update the average block interval from a newly seen block
+func (bt *blockTimeTracker) observe(height int64, blockTime time.Time) {
+	bt.mux.Lock()
+	defer bt.mux.Unlock()
+	if height <= bt.lastHeight {
+		return
+	}
+	if bt.lastHeight > 0 && blockTime.After(bt.lastTime) {
+		interval := blockTime.Sub(bt.lastTime) / time.Duration(height-bt.lastHeight)
+		if bt.avgBlockTime == 0 {
+			bt.avgBlockTime = interval
+		} else {
+			bt.avgBlockTime = time.Duration(blockTimeSmoothing*float64(interval) + (1-blockTimeSmoothing)*float64(bt.avgBlockTime))
+		}
+	}
+	bt.lastHeight = height
+	bt.lastTime = blockTime
+}
+
+// average is a function to get the average block interval, 0 if not observed yet
+func (bt *blockTimeTracker) average() time.Duration {
+	bt.mux.Lock()
+	defer bt.mux.Unlock()
+	return bt.avgBlockTime
+}
+
+// GetAverageBlockTime is a function to get average block interval observed so far, 0 if not observed yet
+func GetAverageBlockTime() time.Duration {
+	return DefaultEnv().state().blocks.average()
+}
+
+// GetBlockPollInterval is a function to get the interval between status queries while waiting for blocks
+func GetBlockPollInterval() time.Duration {
+	avg := DefaultEnv().state().blocks.average()
+	if avg == 0 {
+		return defaultBlockPollInterval
+	}
+	pollInterval := avg / pollsPerBlock
+	if pollInterval < minBlockPollInterval {
+		return minBlockPollInterval
+	}
+	if pollInterval > maxBlockPollInterval {
+		return maxBlockPollInterval
+	}
+	return pollInterval
+}
+
+// GetBlockWaitTimeout is a function to get the time budget for waiting block heights to flow
+func GetBlockWaitTimeout(interval int64) time.Duration {
+	avg := DefaultEnv().state().blocks.average()
+	if avg == 0 {
+		return defaultBlockWaitTimeout * time.Duration(interval)
+	}
+	timeout := avg * blockWaitTimeoutFactor
+	if timeout < minBlockWaitTimeout {
+		timeout = minBlockWaitTimeout
+	}
+	return timeout * time.Duration(interval)
+}
+
+// WaitForBlockIntervalCtx is a function to wait until block heights to flow by wait strategy of ctx, see WaitStrategy
+// It returns ctx error as soon as ctx is done
+func WaitForBlockIntervalCtx(ctx context.Context, interval int64) error {
+	defer observeDuration(blockWaitDuration, time.Now())
+	return WaitStrategyFromContext(ctx).WaitForBlockInterval(ctx, interval)
+}
+
+// WaitForBlockHeightCtx is a function to wait until chain reaches block height
+func WaitForBlockHeightCtx(ctx context.Context, height int64) error {
+	ds, _, err := queryDaemonStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if ds.SyncInfo.LatestBlockHeight >= height {
+		return nil
+	}
+	return WaitForBlockIntervalCtx(ctx, height-ds.SyncInfo.LatestBlockHeight)
+}
+
+// WaitForTxConfirmationCtx is a function to wait until depth blocks are built on top of the inclusion block of transaction
+// It returns error wrapping ErrTxReorged when the transaction is not at its inclusion height anymore
+// The transaction is queried from node of env of ctx, so clients of other envs confirm against their own nodes.
+func WaitForTxConfirmationCtx(ctx context.Context, txhash string, depth int64, t *testing.T) error {
+	if depth <= 0 {
+		return nil
+	}
+	included, err := getTxResult(ctx, txhash)
+	if err != nil {
+		return err
+	}
+	if err = WaitForBlockHeightCtx(ctx, included.Height+depth); err != nil {
+		return err
+	}
+	confirmed, err := getTxResult(ctx, txhash)
+	if err = checkTxConfirmation(txhash, included, confirmed, err); err != nil {
+		return err
+	}
+	t.WithFields(testing.Fields{
+		"txhash": txhash,
+		"height": included.Height,
+		"depth":  depth,
+	}).Debug("tx is confirmed")
+	return nil
+}
+
+// checkTxConfirmation is a function to compare transaction query result after confirmation depth with the inclusion result
+func checkTxConfirmation(txhash string, included, confirmed TxResult, queryErr error) error {
+	if queryErr != nil {
+		if strings.Contains(queryErr.Error(), "not found") {
+			return fmt.Errorf("%w: tx %s included at height %d is not found", ErrTxReorged, txhash, included.Height)
+		}
+		return queryErr
+	}
+	if confirmed.Height != included.Height {
+		return fmt.Errorf("%w: tx %s included at height %d moved to height %d", ErrTxReorged, txhash, included.Height, confirmed.Height)
+	}
+	return nil
+}
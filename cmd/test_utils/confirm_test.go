@@ -0,0 +1,54 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestBlockTimeTracker(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	bt := blockTimeTracker{}
+	start := time.Now()
+	bt.observe(10, start)
+	t.MustTrue(bt.average() == 0, "average block time should be unknown after first block")
+
+	bt.observe(12, start.Add(10*time.Second))
+	t.WithFields(testing.Fields{
+		"average": bt.average().String(),
+	}).MustTrue(bt.average() == 5*time.Second, "average block time should be interval per block")
+
+	bt.observe(11, start.Add(20*time.Second))
+	t.MustTrue(bt.average() == 5*time.Second, "stale heights should be ignored")
+
+	bt.observe(13, start.Add(11*time.Second))
+	t.WithFields(testing.Fields{
+		"average": bt.average().String(),
+	}).MustTrue(bt.average() == 3800*time.Millisecond, "average block time should be smoothed")
+}
+
+func TestCheckTxConfirmation(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	included := TxResult{}
+	included.Height = 10
+	confirmed := TxResult{}
+	confirmed.Height = 10
+	t.MustNil(checkTxConfirmation("hash", included, confirmed, nil), "tx at inclusion height should be confirmed")
+
+	confirmed.Height = 12
+	err := checkTxConfirmation("hash", included, confirmed, nil)
+	t.MustTrue(errors.Is(err, ErrTxReorged), "tx moved to another height should be reorged")
+
+	err = checkTxConfirmation("hash", included, TxResult{}, errors.New("tx (hash) not found"))
+	t.MustTrue(errors.Is(err, ErrTxReorged), "tx not found after inclusion should be reorged")
+
+	err = checkTxConfirmation("hash", included, TxResult{}, errors.New("connection refused"))
+	t.MustTrue(err != nil && !errors.Is(err, ErrTxReorged), "query failure should not be reorged")
+
+	t.MustNil(WaitForTxConfirmationCtx(context.Background(), "hash", 0, &t), "zero depth should not wait for confirmation")
+}
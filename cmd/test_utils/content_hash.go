@@ -0,0 +1,151 @@
+package inttest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// cookbookStateFields are fields of cookbook which are not part of its definition
+var cookbookStateFields = []string{"NodeVersion", "ID", "Sender"}
+
+// contentHash is a function to get sha256 of canonical json of definition fields, map keys are sorted by json encoding
+func contentHash(fields map[string]interface{}) (string, error) {
+	bz, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bz)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// cookbookDefinition is a function to get fields of cookbook definition as generic json values
+func cookbookDefinition(cookbook types.Cookbook) (map[string]interface{}, error) {
+	bz, err := GetJSONMarshaler().MarshalJSON(&cookbook)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err = json.Unmarshal(bz, &fields); err != nil {
+		return nil, err
+	}
+	for _, field := range cookbookStateFields {
+		delete(fields, field)
+	}
+	return fields, nil
+}
+
+// CookbookContentHash is a function to get content hash of cookbook definition, id, sender and node version are not hashed
+func CookbookContentHash(cookbook types.Cookbook) (string, error) {
+	fields, err := cookbookDefinition(cookbook)
+	if err != nil {
+		return "", err
+	}
+	return contentHash(fields)
+}
+
+// RecipeContentHash is a function to get content hash of recipe definition, fields compared by DiffRecipe are hashed
+func RecipeContentHash(recipe types.Recipe) (string, error) {
+	fields, err := recipeDefinition(recipe)
+	if err != nil {
+		return "", err
+	}
+	return contentHash(fields)
+}
+
+// cookbookOfMsg is a function to get cookbook msg would create
+func cookbookOfMsg(msg types.MsgCreateCookbook) types.Cookbook {
+	return types.Cookbook{
+		ID:           msg.CookbookID,
+		Name:         msg.Name,
+		Description:  msg.Description,
+		Version:      msg.Version,
+		Developer:    msg.Developer,
+		Level:        msg.Level,
+		SupportEmail: msg.SupportEmail,
+		CostPerBlock: msg.CostPerBlock,
+		Sender:       msg.Sender,
+	}
+}
+
+// recipeOfMsg is a function to get recipe msg would create
+func recipeOfMsg(msg types.MsgCreateRecipe) types.Recipe {
+	return types.Recipe{
+		ID:            msg.RecipeID,
+		CookbookID:    msg.CookbookID,
+		Name:          msg.Name,
+		CoinInputs:    msg.CoinInputs,
+		ItemInputs:    msg.ItemInputs,
+		Entries:       msg.Entries,
+		Outputs:       msg.Outputs,
+		Description:   msg.Description,
+		BlockInterval: msg.BlockInterval,
+		ExtraInfo:     msg.ExtraInfo,
+		Sender:        msg.Sender,
+	}
+}
+
+// findCookbookByContent is a function to find cookbook of msg sender having the same content hash as msg
+// Cookbook of msg id is the only candidate when id is set, as another cookbook can't be created with the id.
+func findCookbookByContent(msg types.MsgCreateCookbook, cookbooks []types.Cookbook) (types.Cookbook, bool, error) {
+	hash, err := CookbookContentHash(cookbookOfMsg(msg))
+	if err != nil {
+		return types.Cookbook{}, false, err
+	}
+	for _, cookbook := range cookbooks {
+		if cookbook.Sender != msg.Sender || (len(msg.CookbookID) > 0 && cookbook.ID != msg.CookbookID) {
+			continue
+		}
+		chainHash, err := CookbookContentHash(cookbook)
+		if err != nil {
+			return types.Cookbook{}, false, err
+		}
+		if chainHash == hash {
+			return cookbook, true, nil
+		}
+	}
+	return types.Cookbook{}, false, nil
+}
+
+// findRecipeByContent is a function to find enabled recipe of msg sender having the same content hash as msg
+// Recipe of msg id is the only candidate when id is set, as another recipe can't be created with the id.
+func findRecipeByContent(msg types.MsgCreateRecipe, recipes []types.Recipe) (types.Recipe, bool, error) {
+	hash, err := RecipeContentHash(recipeOfMsg(msg))
+	if err != nil {
+		return types.Recipe{}, false, err
+	}
+	for _, recipe := range recipes {
+		if recipe.Sender != msg.Sender || recipe.Disabled || (len(msg.RecipeID) > 0 && recipe.ID != msg.RecipeID) {
+			continue
+		}
+		chainHash, err := RecipeContentHash(recipe)
+		if err != nil {
+			return types.Recipe{}, false, err
+		}
+		if chainHash == hash {
+			return recipe, true, nil
+		}
+	}
+	return types.Recipe{}, false, nil
+}
+
+// FindIdenticalCookbook is a function to find cookbook on chain which msg would create again, so that broadcasting it can be skipped
+func FindIdenticalCookbook(msg types.MsgCreateCookbook) (types.Cookbook, bool, error) {
+	cookbooks, err := ListCookbookViaCLI(msg.Sender)
+	if err != nil {
+		return types.Cookbook{}, false, fmt.Errorf("error listing cookbooks of %s: %w", msg.Sender, err)
+	}
+	return findCookbookByContent(msg, cookbooks)
+}
+
+// FindIdenticalRecipe is a function to find enabled recipe on chain which msg would create again, so that broadcasting it can be skipped
+func FindIdenticalRecipe(msg types.MsgCreateRecipe) (types.Recipe, bool, error) {
+	recipes, err := ListRecipesViaCLI(msg.Sender)
+	if err != nil {
+		return types.Recipe{}, false, fmt.Errorf("error listing recipes of %s: %w", msg.Sender, err)
+	}
+	return findRecipeByContent(msg, recipes)
+}
@@ -0,0 +1,44 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+func TestContentHash(originT *originT.T) {
+	t := testing.NewT(originT)
+	cbMsg := types.NewMsgCreateCookbook("Wizard", "", "cookbook of wizards", "SketchyCo", "1.0.0", "example@example.com", types.Basic, 50, "player")
+	existing := cookbookOfMsg(cbMsg)
+	existing.ID = "wizard-cookbook"
+	existing.NodeVersion = "0.0.1"
+	changed := existing
+	changed.Version = "1.0.1"
+
+	cookbook, found, err := findCookbookByContent(cbMsg, []types.Cookbook{changed, existing})
+	t.MustNil(err, "error finding cookbook")
+	t.MustTrue(found && cookbook.Version == "1.0.0", "cookbook with the same content should be found regardless of id and node version")
+	cbMsg.CookbookID = "another-cookbook"
+	_, found, err = findCookbookByContent(cbMsg, []types.Cookbook{existing})
+	t.MustNil(err, "error finding cookbook")
+	t.MustTrue(!found, "cookbook of another id should not be identical when id is set")
+
+	rcpMsg := types.NewMsgCreateRecipe("Knife", "wizard-cookbook", "", "recipe of knife", types.GenCoinInputList(types.Pylon, 5), nil, types.EntriesList{}, nil, 0, "player")
+	recipe := recipeOfMsg(rcpMsg)
+	recipe.ID = "knife-recipe"
+	recipeHash, err := RecipeContentHash(recipe)
+	t.MustNil(err, "error hashing recipe")
+	msgHash, err := RecipeContentHash(recipeOfMsg(rcpMsg))
+	t.MustNil(err, "error hashing recipe msg")
+	t.MustTrue(recipeHash == msgHash && len(recipeHash) == 64, "recipe id should not change content hash")
+	recipe.Disabled = true
+	_, found, err = findRecipeByContent(rcpMsg, []types.Recipe{recipe})
+	t.MustNil(err, "error finding recipe")
+	t.MustTrue(!found, "disabled recipe should not be reused")
+	recipe.Disabled = false
+	recipe.Sender = "other"
+	_, found, err = findRecipeByContent(rcpMsg, []types.Recipe{recipe})
+	t.MustNil(err, "error finding recipe")
+	t.MustTrue(!found, "recipe of other sender should not be reused")
+}
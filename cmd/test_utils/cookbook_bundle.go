@@ -0,0 +1,192 @@
+package inttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CookbookBundleVersion is the version of cookbook bundle format written by ExportCookbook
+const CookbookBundleVersion = 1
+
+// CookbookBundle is a struct to have a cookbook and all its recipes in portable json
+// Entries are encoded in proto json so that bundles can be read by nodes of any json encoding.
+type CookbookBundle struct {
+	Version  int
+	Cookbook types.Cookbook
+	Recipes  []types.Recipe
+}
+
+// cookbookBundleJSON is a struct to describe json format of cookbook bundle
+type cookbookBundleJSON struct {
+	Version  int               `json:"version"`
+	Cookbook json.RawMessage   `json:"cookbook"`
+	Recipes  []json.RawMessage `json:"recipes"`
+}
+
+// CookbookImport is a struct to describe cookbook and recipes created by ImportCookbook with their ids in the bundle
+type CookbookImport struct {
+	CookbookID string            `json:"cookbook_id"`
+	RecipeIDs  map[string]string `json:"recipe_ids"` // new recipe ids keyed by ids in the bundle
+}
+
+// MarshalJSON is a function to encode bundle into json
+func (b CookbookBundle) MarshalJSON() ([]byte, error) {
+	marshaler := GetJSONMarshaler()
+	cookbook, err := marshaler.MarshalJSON(&b.Cookbook)
+	if err != nil {
+		return nil, err
+	}
+	bundle := cookbookBundleJSON{Version: b.Version, Cookbook: cookbook, Recipes: []json.RawMessage{}}
+	for idx := range b.Recipes {
+		recipe, err := marshaler.MarshalJSON(&b.Recipes[idx])
+		if err != nil {
+			return nil, err
+		}
+		bundle.Recipes = append(bundle.Recipes, recipe)
+	}
+	return json.Marshal(bundle)
+}
+
+// UnmarshalJSON is a function to decode bundle from json
+func (b *CookbookBundle) UnmarshalJSON(bz []byte) error {
+	bundle := cookbookBundleJSON{}
+	if err := json.Unmarshal(bz, &bundle); err != nil {
+		return err
+	}
+	if bundle.Version > CookbookBundleVersion {
+		return fmt.Errorf("cookbook bundle version %d is newer than supported version %d", bundle.Version, CookbookBundleVersion)
+	}
+	marshaler := GetJSONMarshaler()
+	decoded := CookbookBundle{Version: bundle.Version}
+	if err := marshaler.UnmarshalJSON(bundle.Cookbook, &decoded.Cookbook); err != nil {
+		return fmt.Errorf("error decoding cookbook of bundle: %w", err)
+	}
+	for _, raw := range bundle.Recipes {
+		recipe := types.Recipe{}
+		if err := marshaler.UnmarshalJSON(raw, &recipe); err != nil {
+			return fmt.Errorf("error decoding recipe of bundle: %w", err)
+		}
+		decoded.Recipes = append(decoded.Recipes, recipe)
+	}
+	*b = decoded
+	return nil
+}
+
+// ExportCookbook is a function to pull cookbook and all its recipes from the chain into a bundle
+func ExportCookbook(id string) (CookbookBundle, error) {
+	cookbook, err := GetCookbookByGUID(id)
+	if err != nil {
+		return CookbookBundle{}, fmt.Errorf("error getting cookbook %s: %w", id, err)
+	}
+	recipes, err := ListRecipesViaCLI(cookbook.Sender)
+	if err != nil {
+		return CookbookBundle{}, fmt.Errorf("error listing recipes of cookbook %s: %w", id, err)
+	}
+	bundle := CookbookBundle{Version: CookbookBundleVersion, Cookbook: cookbook, Recipes: []types.Recipe{}}
+	for _, recipe := range recipes {
+		if recipe.CookbookID == id {
+			bundle.Recipes = append(bundle.Recipes, recipe)
+		}
+	}
+	sort.Slice(bundle.Recipes, func(i, j int) bool {
+		return bundle.Recipes[i].ID < bundle.Recipes[j].ID
+	})
+	return bundle, nil
+}
+
+// WriteCookbookBundle is a function to write bundle into json file
+func WriteCookbookBundle(bundle CookbookBundle, filename string) error {
+	bz, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, bz, 0644)
+}
+
+// ReadCookbookBundle is a function to read bundle written by WriteCookbookBundle
+func ReadCookbookBundle(filename string) (CookbookBundle, error) {
+	bundle := CookbookBundle{}
+	bz, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return bundle, err
+	}
+	err = json.Unmarshal(bz, &bundle)
+	return bundle, err
+}
+
+// rewriteRecipeID is a function to get id of recipe in the imported cookbook
+// Recipe ids starting with old cookbook id get new cookbook id as prefix, others are prefixed by it.
+func rewriteRecipeID(recipeID, oldCookbookID, newCookbookID string) string {
+	if strings.HasPrefix(recipeID, oldCookbookID) {
+		return newCookbookID + strings.TrimPrefix(recipeID, oldCookbookID)
+	}
+	return newCookbookID + "_" + recipeID
+}
+
+// Rewrite is a function to get msgs creating bundle as new cookbook of newSender
+// Disabled recipes are created and disabled afterwards so that the imported cookbook keeps their state.
+func (b CookbookBundle) Rewrite(newCookbookID, newSender string) (types.MsgCreateCookbook, []types.MsgCreateRecipe, []types.MsgDisableRecipe, CookbookImport) {
+	cb := b.Cookbook
+	createCookbook := types.NewMsgCreateCookbook(cb.Name, newCookbookID, cb.Description, cb.Developer, cb.Version, cb.SupportEmail, cb.Level, cb.CostPerBlock, newSender)
+	imported := CookbookImport{CookbookID: newCookbookID, RecipeIDs: map[string]string{}}
+	createRecipes := []types.MsgCreateRecipe{}
+	disableRecipes := []types.MsgDisableRecipe{}
+	for _, rcp := range b.Recipes {
+		recipeID := rewriteRecipeID(rcp.ID, cb.ID, newCookbookID)
+		imported.RecipeIDs[rcp.ID] = recipeID
+		createRecipe := types.NewMsgCreateRecipe(rcp.Name, newCookbookID, recipeID, rcp.Description, rcp.CoinInputs, rcp.ItemInputs, rcp.Entries, rcp.Outputs, rcp.BlockInterval, newSender)
+		createRecipe.ExtraInfo = rcp.ExtraInfo
+		createRecipes = append(createRecipes, createRecipe)
+		if rcp.Disabled {
+			disableRecipes = append(disableRecipes, types.NewMsgDisableRecipe(recipeID, newSender))
+		}
+	}
+	return createCookbook, createRecipes, disableRecipes, imported
+}
+
+// ImportCookbook is a function to re-create cookbook and recipes of bundle owned by newSender with new ids
+// New cookbook id is bundle cookbook id with a suffix generated from the run seed and test name,
+// use ImportCookbookWithID to import the same bundle more than once in a test.
+func ImportCookbook(bundle CookbookBundle, newSender string, t *testing.T) (CookbookImport, error) {
+	return ImportCookbookWithID(bundle, NewTestDataGenerator(t).Name(bundle.Cookbook.ID), newSender, t)
+}
+
+// ImportCookbookWithID is a function to re-create cookbook and recipes of bundle as cookbook of newCookbookID owned by newSender
+func ImportCookbookWithID(bundle CookbookBundle, newCookbookID, newSender string, t *testing.T) (CookbookImport, error) {
+	createCookbook, createRecipes, disableRecipes, imported := bundle.Rewrite(newCookbookID, newSender)
+	client := NewClient()
+	ctx := TestContext(t)
+	if _, err := client.SendTxAndWait(ctx, t, SignerAddress(newSender), &createCookbook); err != nil {
+		return imported, fmt.Errorf("error creating cookbook %s: %w", newCookbookID, err)
+	}
+	// large cookbooks have more recipes than a transaction can have, so they're sent in batches by tx limits
+	recipeMsgs := []sdk.Msg{}
+	for idx := range createRecipes {
+		recipeMsgs = append(recipeMsgs, &createRecipes[idx])
+	}
+	if _, err := client.SendTxBatchesAndWait(ctx, t, SignerAddress(newSender), recipeMsgs...); err != nil {
+		return imported, fmt.Errorf("error creating recipes of cookbook %s: %w", newCookbookID, err)
+	}
+	disableMsgs := []sdk.Msg{}
+	for idx := range disableRecipes {
+		disableMsgs = append(disableMsgs, &disableRecipes[idx])
+	}
+	if _, err := client.SendTxBatchesAndWait(ctx, t, SignerAddress(newSender), disableMsgs...); err != nil {
+		return imported, fmt.Errorf("error disabling recipes of cookbook %s: %w", newCookbookID, err)
+	}
+	t.WithFields(testing.Fields{
+		"cookbook_id":        bundle.Cookbook.ID,
+		"cookbook_imported":  newCookbookID,
+		"recipe_ids":         imported.RecipeIDs,
+		"disabled_recipes":   len(disableRecipes),
+		"new_cookbook_owner": newSender,
+	}).Info("cookbook imported")
+	return imported, nil
+}
@@ -0,0 +1,64 @@
+package inttest
+
+import (
+	"encoding/json"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+func TestCookbookBundleRoundTrip(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	bundle := CookbookBundle{
+		Version: CookbookBundleVersion,
+		Cookbook: types.Cookbook{
+			ID:           "LOUD-v0.1.0-1579053457",
+			Name:         "Legend of Undead Dragon",
+			Developer:    "Pylons Inc",
+			Version:      "1.0.0",
+			SupportEmail: "test@pylons.tech",
+			Level:        1,
+			CostPerBlock: 5,
+			Sender:       "cosmos1devnet",
+		},
+		Recipes: []types.Recipe{
+			{
+				ID:         "LOUD-v0.1.0-1579053457-buy-gold",
+				CookbookID: "LOUD-v0.1.0-1579053457",
+				Name:       "LOUD's buy gold recipe",
+				CoinInputs: types.CoinInputList{{Coin: "pylon", Count: 100}},
+				Entries: types.EntriesList{
+					CoinOutputs: []types.CoinOutput{{ID: "coin", Coin: "loudcoin", Count: "100"}},
+				},
+				Outputs:   types.WeightedOutputsList{{EntryIDs: []string{"coin"}, Weight: "1"}},
+				ExtraInfo: "extra",
+				Sender:    "cosmos1devnet",
+			},
+			{
+				ID:         "old-recipe",
+				CookbookID: "LOUD-v0.1.0-1579053457",
+				Name:       "LOUD's old recipe",
+				Disabled:   true,
+				Sender:     "cosmos1devnet",
+			},
+		},
+	}
+
+	bz, err := json.Marshal(bundle)
+	t.MustNil(err, "bundle should be encoded")
+	decoded := CookbookBundle{}
+	t.MustNil(json.Unmarshal(bz, &decoded), "bundle should be decoded")
+	t.MustTrue(decoded.Cookbook.Name == bundle.Cookbook.Name && len(decoded.Recipes) == 2, "bundle should keep cookbook and recipes")
+	t.MustTrue(decoded.Recipes[0].Entries.CoinOutputs[0].Count == "100" && decoded.Recipes[1].Disabled, "bundle should keep recipe entries and state")
+
+	createCookbook, createRecipes, disableRecipes, imported := decoded.Rewrite("LOUD-testnet", "cosmos1testnet")
+	t.MustTrue(createCookbook.CookbookID == "LOUD-testnet" && createCookbook.Sender == "cosmos1testnet" && createCookbook.CostPerBlock == 5, "cookbook should be rewritten")
+	t.MustTrue(len(createRecipes) == 2 && createRecipes[0].RecipeID == "LOUD-testnet-buy-gold" && createRecipes[1].RecipeID == "LOUD-testnet_old-recipe", "recipe ids should be rewritten")
+	t.MustTrue(createRecipes[0].CookbookID == "LOUD-testnet" && createRecipes[0].Sender == "cosmos1testnet" && createRecipes[0].ExtraInfo == "extra", "recipes should belong to new cookbook")
+	t.MustTrue(len(disableRecipes) == 1 && disableRecipes[0].RecipeID == "LOUD-testnet_old-recipe", "disabled recipes should be disabled after import")
+	t.MustTrue(imported.RecipeIDs["old-recipe"] == "LOUD-testnet_old-recipe", "imported ids should be mapped from bundle ids")
+
+	t.MustTrue(json.Unmarshal([]byte(`{"version":2,"cookbook":{}}`), &decoded) != nil, "newer bundle version should be rejected")
+}
@@ -0,0 +1,53 @@
+package inttest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// ErrVersionNotIncreased is an error of update which doesn't carry a higher version than on-chain one
+var ErrVersionNotIncreased = errors.New("version is not increased")
+
+// CheckVersionIncreased is a function to check next version has higher semVer precedence than current version
+func CheckVersionIncreased(current, next string) error {
+	c, err := types.CompareVersions(next, current)
+	if err != nil {
+		return err
+	}
+	if c <= 0 {
+		return fmt.Errorf("%w: %s is not higher than %s", ErrVersionNotIncreased, next, current)
+	}
+	return nil
+}
+
+// CheckCookbookUpdateVersion is a function to check update carries a higher version than the on-chain cookbook
+// Recipes don't have version, so only cookbook updates are checked.
+func CheckCookbookUpdateVersion(msg types.MsgUpdateCookbook, opts ...QueryOption) error {
+	cookbook, err := GetCookbookByGUID(msg.ID, opts...)
+	if err != nil {
+		return fmt.Errorf("error getting cookbook %s: %w", msg.ID, err)
+	}
+	if err = CheckVersionIncreased(cookbook.Version, msg.Version); err != nil {
+		return fmt.Errorf("cookbook %s update: %w", msg.ID, err)
+	}
+	return nil
+}
+
+// NextCookbookVersion is a function to get on-chain version of cookbook bumped at part, e.g. for MsgUpdateCookbook
+func NextCookbookVersion(cookbookID string, part types.SemVerPart, opts ...QueryOption) (string, error) {
+	cookbook, err := GetCookbookByGUID(cookbookID, opts...)
+	if err != nil {
+		return "", fmt.Errorf("error getting cookbook %s: %w", cookbookID, err)
+	}
+	version, err := types.ParseSemVer(cookbook.Version)
+	if err != nil {
+		return "", fmt.Errorf("cookbook %s: %w", cookbookID, err)
+	}
+	next, err := version.Bump(part)
+	if err != nil {
+		return "", err
+	}
+	return next.String(), nil
+}
@@ -0,0 +1,26 @@
+package inttest
+
+import (
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestCheckVersionIncreased(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	t.MustNil(CheckVersionIncreased("1.0.0", "1.0.1"), "patch bump should increase version")
+	t.MustNil(CheckVersionIncreased("1.1.0-rc.1", "1.1.0"), "release should be higher than its pre-release")
+
+	err := CheckVersionIncreased("1.0.0", "1.0.0+build2")
+	t.MustTrue(errors.Is(err, ErrVersionNotIncreased), "build metadata should not increase version")
+	err = CheckVersionIncreased("1.2.0", "1.10.0-alpha")
+	t.MustNil(err, "versions should be compared numerically")
+	err = CheckVersionIncreased("2.0.0", "1.9.9")
+	t.MustTrue(errors.Is(err, ErrVersionNotIncreased), "lower version should be rejected")
+	t.MustContain(err.Error(), "1.9.9 is not higher than 2.0.0", "error should have both versions")
+
+	err = CheckVersionIncreased("1.0.0", "v1.1")
+	t.MustTrue(err != nil && !errors.Is(err, ErrVersionNotIncreased), "invalid version should be reported")
+}
@@ -0,0 +1,174 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DelayedExecutionResult is a struct to manage result of delayed recipe execution
+type DelayedExecutionResult struct {
+	RecipeID    string
+	ExecID      string
+	Execution   ExecutionResult
+	PaidCoins   sdk.Coins
+	PaidItemIDs []string
+}
+
+// WaitAndGetTxResult is a function to wait for transaction to be processed and parse its result
+func WaitAndGetTxResult(txhash string, t *testing.T) (TxResult, error) {
+	return NewClient().WaitForTxResult(context.Background(), t, txhash)
+}
+
+// ExecuteDelayedRecipeAndVerify is a function to create a recipe with block interval, schedule its execution,
+// check the execution is pending, wait for block interval, check execution and verify the payout by a client of DefaultEnv
+// expectedCoins is not verified when it is nil
+func ExecuteDelayedRecipeAndVerify(ctx context.Context, t *testing.T, rcpMsg types.MsgCreateRecipe, itemIDs []string, expectedCoins sdk.Coins) (DelayedExecutionResult, error) {
+	return NewClient().ExecuteDelayedRecipeAndVerify(ctx, t, rcpMsg, itemIDs, expectedCoins)
+}
+
+// ExecuteDelayedRecipeAndVerify is a function to run delayed execution of recipe by msg service of the client and verify the payout
+// Msgs are sent by the sender of recipe, executions and items are queried through transport of env of the client, and blocks of
+// the interval are waited by new block events unless ctx has its wait strategy, polling is used when events can't be subscribed.
+func (c *Client) ExecuteDelayedRecipeAndVerify(ctx context.Context, t *testing.T, rcpMsg types.MsgCreateRecipe, itemIDs []string, expectedCoins sdk.Coins) (DelayedExecutionResult, error) {
+	result := DelayedExecutionResult{}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	if rcpMsg.BlockInterval <= 0 {
+		return result, errors.New("recipe should have positive block interval for delayed execution")
+	}
+	ctx = c.withContext(ctx)
+	sender := rcpMsg.Sender
+	msgService := c.MsgService(t, SignerAddress(sender))
+	transport, err := c.env.Transport()
+	if err != nil {
+		return result, err
+	}
+
+	// create recipe
+	rcpResp, err := msgService.CreateRecipe(ctx, &rcpMsg)
+	if err != nil {
+		return result, fmt.Errorf("error creating recipe: %w", err)
+	}
+	result.RecipeID = rcpResp.RecipeID
+	rcp := types.Recipe{
+		ID:            result.RecipeID,
+		CookbookID:    rcpMsg.CookbookID,
+		BlockInterval: rcpMsg.BlockInterval,
+		Entries:       rcpMsg.Entries,
+	}
+
+	// schedule execution
+	execMsg := types.NewMsgExecuteRecipe(result.RecipeID, sender, itemIDs)
+	execResp, err := msgService.ExecuteRecipe(ctx, &execMsg)
+	if err != nil {
+		return result, fmt.Errorf("error executing recipe: %w", err)
+	}
+	var scheduleRes types.ExecuteRecipeScheduleOutput
+	if err = json.Unmarshal(execResp.Output, &scheduleRes); err != nil || len(scheduleRes.ExecID) == 0 {
+		return result, fmt.Errorf("no scheduled execution found from execute recipe output %s", string(execResp.Output))
+	}
+	result.ExecID = scheduleRes.ExecID
+
+	// execution should be pending until block interval passes
+	result.Execution, err = transportExecution(ctx, transport, sender, result.ExecID, rcp)
+	if err != nil {
+		return result, err
+	}
+	if result.Execution.Status != ExecutionPending {
+		return result, fmt.Errorf("execution %s should be pending but it's %s", result.ExecID, result.Execution.Status)
+	}
+	if err = waitForTransportHeight(ctx, transport, result.Execution.ReadyHeight); err != nil {
+		return result, err
+	}
+
+	// check execution
+	chkExecMsg := types.NewMsgCheckExecution(result.ExecID, false, sender)
+	chkResp, err := msgService.CheckExecution(ctx, &chkExecMsg)
+	if err != nil {
+		return result, fmt.Errorf("error checking execution: %w", err)
+	}
+	if chkResp.Status != "Success" {
+		return result, fmt.Errorf("check execution status is %s: %s", chkResp.Status, chkResp.Message)
+	}
+	result.PaidCoins, result.PaidItemIDs, err = DecodeExecutionOutput(chkResp.Output)
+	if err != nil {
+		return result, err
+	}
+
+	// verify payout
+	result.Execution, err = transportExecution(ctx, transport, sender, result.ExecID, rcp)
+	if err != nil {
+		return result, err
+	}
+	if result.Execution.Status != ExecutionCompleted {
+		return result, fmt.Errorf("execution %s should be completed after check execution", result.ExecID)
+	}
+	result.Execution.PaidCoins, result.Execution.PaidItemIDs = result.PaidCoins, result.PaidItemIDs
+	if expectedCoins != nil && !(result.PaidCoins.IsAllGTE(expectedCoins) && expectedCoins.IsAllGTE(result.PaidCoins)) {
+		return result, fmt.Errorf("paid coins are %s, expected %s", result.PaidCoins, expectedCoins)
+	}
+	if len(result.PaidItemIDs) > 0 {
+		items, err := transport.ItemsBySender(ctx, sender)
+		if err != nil {
+			return result, err
+		}
+		owned := map[string]bool{}
+		for _, item := range items {
+			owned[item.ID] = item.Sender == sender
+		}
+		for _, itemID := range result.PaidItemIDs {
+			if !owned[itemID] {
+				return result, fmt.Errorf("paid item %s is not owned by %s", itemID, sender)
+			}
+		}
+	}
+	t.WithFields(testing.Fields{
+		"recipe_id":     result.RecipeID,
+		"exec_id":       result.ExecID,
+		"paid_coins":    result.PaidCoins.String(),
+		"paid_item_ids": result.PaidItemIDs,
+	}).Info("verified delayed execution")
+	return result, nil
+}
+
+// transportExecution is a function to get execution of sender through transport and decode it with entries of its recipe
+func transportExecution(ctx context.Context, transport Transport, sender, execID string, rcp types.Recipe) (ExecutionResult, error) {
+	executions, err := transport.ListExecutions(ctx, sender)
+	if err != nil {
+		return ExecutionResult{}, fmt.Errorf("error getting execution %s: %w", execID, err)
+	}
+	for _, exec := range executions {
+		if exec.ID == execID {
+			return decodeExecution(exec, rcp), nil
+		}
+	}
+	return ExecutionResult{}, fmt.Errorf("execution %s of %s does not exist", execID, sender)
+}
+
+// waitForTransportHeight is a function to wait until latest height of transport reaches height
+// New block events are subscribed unless ctx has its wait strategy, and polling is used when they can't be subscribed.
+func waitForTransportHeight(ctx context.Context, transport Transport, height int64) error {
+	latest, err := transport.LatestHeight(ctx)
+	if err != nil {
+		return err
+	}
+	if latest >= height {
+		return nil
+	}
+	if _, ok := ctx.Value(waitStrategyKey{}).(WaitStrategy); ok {
+		return WaitForBlockIntervalCtx(ctx, height-latest)
+	}
+	env := EnvFromContext(ctx)
+	err = WaitForBlockIntervalCtx(ContextWithWaitStrategy(ctx, WebSocketStrategy{BlockTimeout: env.opts.BlockTimeout}), height-latest)
+	if errors.Is(err, ErrNodeUnavailable) {
+		return WaitForBlockIntervalCtx(ContextWithWaitStrategy(ctx, PollingStrategy{BlockTimeout: env.opts.BlockTimeout}), height-latest)
+	}
+	return err
+}
@@ -0,0 +1,61 @@
+package inttest
+
+import (
+	"context"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/mockchain"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// chainBlocksStrategy is a wait strategy building blocks of mock chain instead of waiting for them
+type chainBlocksStrategy struct {
+	chain  *mockchain.Chain
+	waited *int64
+}
+
+// WaitForBlockInterval is a function to advance mock chain by interval blocks
+func (s chainBlocksStrategy) WaitForBlockInterval(ctx context.Context, interval int64) error {
+	*s.waited += interval
+	s.chain.AdvanceBlocks(interval)
+	return nil
+}
+
+func TestExecuteDelayedRecipeAndVerify(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	chain := mockchain.New(1)
+	RegisterTransport(TransportMemory, func() (Transport, error) { return NewMemoryTransport(chain), nil })
+	env := NewEnv(CLIOptions{Transport: TransportMemory}, nil)
+	client := env.NewClient(WithMsgService(chain))
+
+	sender := sdk.AccAddress([]byte("delayed_executor____")).String()
+	chain.AddCookbook(types.Cookbook{ID: "delayed-cookbook", Sender: sender})
+	entries := types.EntriesList{CoinOutputs: []types.CoinOutput{{ID: "gold", Coin: "gold", Count: "10"}}}
+	outputs := types.WeightedOutputsList{{EntryIDs: []string{"gold"}, Weight: "1"}}
+	rcpMsg := types.NewMsgCreateRecipe("delayed gold recipe", "delayed-cookbook", "", "recipe paying gold after a few blocks",
+		types.CoinInputList{}, types.ItemInputList{}, entries, outputs, 3, sender)
+
+	waited := int64(0)
+	ctx := ContextWithWaitStrategy(context.Background(), chainBlocksStrategy{chain: chain, waited: &waited})
+	result, err := client.ExecuteDelayedRecipeAndVerify(ctx, &t, rcpMsg, []string{}, sdk.NewCoins(sdk.NewInt64Coin("gold", 10)))
+	t.WithFields(testing.Fields{
+		"recipe_id": result.RecipeID,
+		"exec_id":   result.ExecID,
+	}).MustNil(err, "error verifying delayed execution on memory transport")
+	t.MustTrue(result.Execution.Status == ExecutionCompleted, "execution should be completed")
+	t.WithFields(testing.Fields{
+		"waited": waited,
+	}).MustTrue(waited == 3, "blocks of recipe interval should be waited by wait strategy of ctx")
+	t.MustTrue(chain.Balance(sender).AmountOf("gold").Int64() == 10, "gold should be paid to sender")
+
+	_, err = client.ExecuteDelayedRecipeAndVerify(ctx, &t, rcpMsg, []string{}, sdk.NewCoins(sdk.NewInt64Coin("gold", 11)))
+	t.MustTrue(err != nil, "payout different from expected coins should fail")
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = client.ExecuteDelayedRecipeAndVerify(cancelled, &t, rcpMsg, []string{}, nil)
+	t.MustTrue(err != nil, "cancelled ctx should stop delayed execution")
+}
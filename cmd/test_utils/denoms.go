@@ -0,0 +1,245 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/coins"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ErrUnknownDenom is an error of denom which is not issued by the chain nor minted by any recipe
+var ErrUnknownDenom = errors.New("unknown denom")
+
+// maxDenomTypoDistance is the max edit distance of a denom from known one to be suggested as its typo
+const maxDenomTypoDistance = 2
+
+// DenomSource is a type to describe where a denom of registry comes from
+type DenomSource string
+
+// sources of registered denoms
+const (
+	// DenomSourceBuiltin is pylon denom issued by the pylons module
+	DenomSourceBuiltin DenomSource = "builtin"
+	// DenomSourceProfile is fee, stake or extra denom of selected chain profile
+	DenomSourceProfile DenomSource = "profile"
+	// DenomSourceRecipe is cookbook coin minted by coin output of a recipe on chain
+	DenomSourceRecipe DenomSource = "recipe"
+	// DenomSourceManual is denom registered by tests e.g. coin of a recipe which is not created yet
+	DenomSourceManual DenomSource = "manual"
+)
+
+// DenomInfo is a struct to describe a denom known to tests, CookbookID is set for cookbook coins
+type DenomInfo struct {
+	Denom      string
+	Source     DenomSource
+	CookbookID string
+}
+
+// DenomRegistry is a struct to have denoms tests can use, it guards balance checks against typo'd denoms
+type DenomRegistry struct {
+	mux    sync.RWMutex
+	denoms map[string]DenomInfo
+}
+
+// GlobalDenomRegistry is the denom registry shared by tests of the suite
+var GlobalDenomRegistry = NewDenomRegistry()
+
+// NewDenomRegistry is a function to create a registry having only pylon denom
+func NewDenomRegistry() *DenomRegistry {
+	return &DenomRegistry{
+		denoms: map[string]DenomInfo{
+			types.Pylon: {Denom: types.Pylon, Source: DenomSourceBuiltin},
+		},
+	}
+}
+
+// Register is a function to add denom to the registry, denom of recipe source should be a valid cookbook coin
+// A denom can be minted by recipes of several cookbooks, the cookbook registered first is kept.
+func (r *DenomRegistry) Register(info DenomInfo) error {
+	validate := sdk.ValidateDenom
+	if info.Source == DenomSourceRecipe {
+		validate = coins.ValidateCookbookDenom
+	}
+	if err := validate(info.Denom); err != nil {
+		return fmt.Errorf("error registering denom %s: %w", info.Denom, err)
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if _, ok := r.denoms[info.Denom]; !ok {
+		r.denoms[info.Denom] = info
+	}
+	return nil
+}
+
+// Lookup is a function to get info of registered denom
+func (r *DenomRegistry) Lookup(denom string) (DenomInfo, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	info, ok := r.denoms[denom]
+	return info, ok
+}
+
+// Denoms is a function to get sorted denoms of the registry
+func (r *DenomRegistry) Denoms() []string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	denoms := []string{}
+	for denom := range r.denoms {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+	return denoms
+}
+
+// CookbookDenoms is a function to get sorted denoms minted by recipes of cookbook
+func (r *DenomRegistry) CookbookDenoms(cookbookID string) []string {
+	denoms := []string{}
+	for _, denom := range r.Denoms() {
+		if info, _ := r.Lookup(denom); info.CookbookID == cookbookID {
+			denoms = append(denoms, denom)
+		}
+	}
+	return denoms
+}
+
+// RegisterProfile is a function to add fee, stake and extra denoms of chain profile
+func (r *DenomRegistry) RegisterProfile(profile ChainProfile) error {
+	denoms := append([]string{profile.FeeDenom, profile.StakeDenom}, profile.Denoms...)
+	for _, denom := range denoms {
+		if len(denom) == 0 {
+			continue
+		}
+		if err := r.Register(DenomInfo{Denom: denom, Source: DenomSourceProfile}); err != nil {
+			return fmt.Errorf("error registering denoms of chain profile %s: %w", profile.Name, err)
+		}
+	}
+	return nil
+}
+
+// RegisterRecipes is a function to add cookbook coins minted by coin outputs of recipes
+// Denoms which can't be minted by node are skipped, so it returns the first error after registering valid ones.
+func (r *DenomRegistry) RegisterRecipes(recipes []types.Recipe) error {
+	var firstErr error
+	for _, recipe := range recipes {
+		for _, denom := range coins.RecipeDenoms(recipe) {
+			err := r.Register(DenomInfo{Denom: denom, Source: DenomSourceRecipe, CookbookID: recipe.CookbookID})
+			if err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("recipe %s: %w", recipe.ID, err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// Discover is a function to add cookbook coins minted by all recipes on chain
+func (r *DenomRegistry) Discover(ctx context.Context, transport Transport) error {
+	recipes, err := transport.ListRecipes(ctx, "")
+	if err != nil {
+		return fmt.Errorf("error listing recipes to discover denoms: %w", err)
+	}
+	return r.RegisterRecipes(recipes)
+}
+
+// Suggest is a function to get the registered denom closest to denom, e.g. "pylon" for "pylons"
+// It's not found when no registered denom is within typo distance.
+func (r *DenomRegistry) Suggest(denom string) (string, bool) {
+	best, bestDistance := "", maxDenomTypoDistance+1
+	for _, known := range r.Denoms() {
+		distance := editDistance(strings.ToLower(denom), strings.ToLower(known))
+		if distance < bestDistance {
+			best, bestDistance = known, distance
+		}
+	}
+	return best, len(best) > 0
+}
+
+// Validate is a function to check denom is registered, error wraps ErrUnknownDenom and suggests a close denom
+func (r *DenomRegistry) Validate(denom string) error {
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return err
+	}
+	if _, ok := r.Lookup(denom); ok {
+		return nil
+	}
+	if suggestion, ok := r.Suggest(denom); ok {
+		return fmt.Errorf("%w %s, did you mean %s?", ErrUnknownDenom, denom, suggestion)
+	}
+	return fmt.Errorf("%w %s, known denoms are %s", ErrUnknownDenom, denom, strings.Join(r.Denoms(), ","))
+}
+
+// ValidateCoins is a function to check denoms of coins are registered
+func (r *DenomRegistry) ValidateCoins(amount sdk.Coins) error {
+	for _, coin := range amount {
+		if err := r.Validate(coin.Denom); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// editDistance is a function to get levenshtein distance of two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// DiscoverDenoms is a function to add denoms of chain profile of env and recipes on chain to the registry shared by the suite
+func DiscoverDenoms(ctx context.Context) error {
+	env := EnvFromContext(ctx)
+	profile, _ := env.ChainProfile()
+	if err := GlobalDenomRegistry.RegisterProfile(profile); err != nil {
+		return err
+	}
+	transport, err := env.Transport()
+	if err != nil {
+		return err
+	}
+	return GlobalDenomRegistry.Discover(ctx, transport)
+}
+
+// CheckBalanceDenom is a function to check denom of balance assertion is not a typo before comparing amounts
+// Denoms held by the balance are real, others are looked up in the shared registry after discovering recipes
+// created since the last discovery, so that expecting a misspelled denom fails with a suggestion instead of a zero balance.
+func CheckBalanceDenom(ctx context.Context, denom string, balance sdk.Coins) error {
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return err
+	}
+	if !balance.AmountOf(denom).IsZero() {
+		return nil
+	}
+	if _, ok := GlobalDenomRegistry.Lookup(denom); ok {
+		return nil
+	}
+	if err := DiscoverDenoms(ctx); err != nil {
+		return fmt.Errorf("error discovering denoms to check %s: %w", denom, err)
+	}
+	return GlobalDenomRegistry.Validate(denom)
+}
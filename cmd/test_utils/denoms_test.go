@@ -0,0 +1,53 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestDenomRegistry(originT *originT.T) {
+	t := testing.NewT(originT)
+	registry := NewDenomRegistry()
+	t.MustNil(registry.RegisterProfile(ChainProfiles[ChainProfileLocal]), "error registering denoms of local profile")
+	t.MustNil(registry.RegisterRecipes([]types.Recipe{
+		{ID: "rcp001", CookbookID: "cbk001", Entries: types.EntriesList{CoinOutputs: []types.CoinOutput{{Coin: "loudcoin"}, {Coin: "goldcoin"}}}},
+		{ID: "rcp002", CookbookID: "cbk002", Entries: types.EntriesList{CoinOutputs: []types.CoinOutput{{Coin: "loudcoin"}}}},
+	}), "error registering recipe denoms")
+	err := registry.RegisterRecipes([]types.Recipe{{ID: "rcp003", Entries: types.EntriesList{CoinOutputs: []types.CoinOutput{{Coin: types.Pylon}}}}})
+	t.MustTrue(err != nil && strings.Contains(err.Error(), "rcp003"), "recipe can't mint pylon denom")
+
+	info, ok := registry.Lookup("loudcoin")
+	t.MustTrue(ok && info.Source == DenomSourceRecipe && info.CookbookID == "cbk001", "cookbook registered first should be kept")
+	denoms := registry.CookbookDenoms("cbk001")
+	t.MustTrue(len(denoms) == 2 && denoms[0] == "goldcoin" && denoms[1] == "loudcoin", "denoms of cookbook should be listed")
+	info, _ = registry.Lookup("stake")
+	t.MustTrue(info.Source == DenomSourceProfile, "stake denom of profile should be registered")
+
+	t.MustNil(registry.ValidateCoins(sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 1), sdk.NewInt64Coin("loudcoin", 1))), "registered denoms should be valid")
+	err = registry.Validate("loudcoins")
+	t.WithFields(testing.Fields{
+		"error": err,
+	}).MustTrue(errors.Is(err, ErrUnknownDenom) && strings.Contains(err.Error(), "did you mean loudcoin"), "typo should be refused with suggestion")
+	err = registry.Validate("Pylon")
+	t.MustTrue(err != nil && strings.Contains(err.Error(), "did you mean pylon"), "case mismatch should be suggested")
+	_, ok = registry.Suggest("silvercoin")
+	t.MustTrue(!ok, "denom far from known denoms should not be suggested")
+}
+
+func TestCheckBalanceDenom(originT *originT.T) {
+	t := testing.NewT(originT)
+	registry := GlobalDenomRegistry
+	defer func() { GlobalDenomRegistry = registry }()
+	GlobalDenomRegistry = NewDenomRegistry()
+
+	balance := sdk.NewCoins(sdk.NewInt64Coin("airdropcoin", 5))
+	t.MustNil(CheckBalanceDenom(context.Background(), "airdropcoin", balance), "denom held by balance should be valid")
+	t.MustNil(CheckBalanceDenom(context.Background(), types.Pylon, balance), "registered denom should be valid without balance")
+	t.MustTrue(CheckBalanceDenom(context.Background(), "1pylon", balance) != nil, "invalid denom should be refused")
+}
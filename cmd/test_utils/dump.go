@@ -0,0 +1,349 @@
+package inttest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/events"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// DumpObjectType is a type of pylons object which can be dumped
+type DumpObjectType string
+
+// types of pylons objects stored on chain
+const (
+	DumpCookbook  DumpObjectType = "cookbook"
+	DumpRecipe    DumpObjectType = "recipe"
+	DumpItem      DumpObjectType = "item"
+	DumpTrade     DumpObjectType = "trade"
+	DumpExecution DumpObjectType = "execution"
+)
+
+// DumpObjectTypes is the list of all object types which can be dumped
+var DumpObjectTypes = []DumpObjectType{DumpCookbook, DumpRecipe, DumpItem, DumpTrade, DumpExecution}
+
+// ParseDumpObjectType is a function to get object type from its name
+func ParseDumpObjectType(name string) (DumpObjectType, error) {
+	for _, objectType := range DumpObjectTypes {
+		if string(objectType) == strings.ToLower(name) {
+			return objectType, nil
+		}
+	}
+	return "", fmt.Errorf("unknown object type %s, it should be one of %v", name, DumpObjectTypes)
+}
+
+// ObjectDump is a struct to describe an object stored on chain, Raw is json output of node as it is
+type ObjectDump struct {
+	Type      DumpObjectType  `json:"type"`
+	ID        string          `json:"id"`
+	Raw       json.RawMessage `json:"raw"`
+	Annotated []string        `json:"annotated"`
+}
+
+// NewObjectDump is a function to create dump of object decoded from raw output
+// Raw output which is not json is kept as json string so that dump can be encoded into json.
+func NewObjectDump(objectType DumpObjectType, id string, raw []byte, obj interface{}) ObjectDump {
+	dump := ObjectDump{Type: objectType, ID: id, Raw: raw, Annotated: annotateObject(obj)}
+	if !json.Valid(raw) {
+		dump.Raw, _ = json.Marshal(string(raw))
+	}
+	return dump
+}
+
+// WriteTo is a function to write raw json indented and annotated view of the dump to w
+func (d ObjectDump) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "== %s %s ==\n-- raw --\n", d.Type, d.ID)
+	if err := json.Indent(&buf, d.Raw, "", "  "); err != nil {
+		buf.Write(d.Raw)
+	}
+	fmt.Fprintf(&buf, "\n-- annotated --\n%s\n", strings.Join(d.Annotated, "\n"))
+	return buf.WriteTo(w)
+}
+
+// fetchObjectDump is a function to fetch object by its id and get its dump
+// Trades can't be queried by id, so raw output of a trade is the encoding of the trade found in trade list.
+func fetchObjectDump(ctx context.Context, objectType DumpObjectType, id string) (ObjectDump, error) {
+	var cmd *CommandBuilder
+	var obj interface{}
+	switch objectType {
+	case DumpCookbook:
+		cmd, obj = Query().Pylons().Cookbook(id), &types.Cookbook{}
+	case DumpRecipe:
+		cmd, obj = Query().Pylons().Recipe(id), &types.Recipe{}
+	case DumpItem:
+		cmd, obj = Query().Pylons().Item(id), &types.Item{}
+	case DumpExecution:
+		cmd, obj = Query().Pylons().Execution(id), &types.GetExecutionResponse{}
+	case DumpTrade:
+		transport, err := GetTransport()
+		if err != nil {
+			return ObjectDump{}, err
+		}
+		trades, err := transport.ListTrades(ctx, "")
+		if err != nil {
+			return ObjectDump{}, err
+		}
+		for _, trade := range trades {
+			if trade.ID == id {
+				raw, err := GetCodec().Encode(&trade)
+				if err != nil {
+					return ObjectDump{}, err
+				}
+				return NewObjectDump(objectType, id, raw, &trade), nil
+			}
+		}
+		return ObjectDump{}, fmt.Errorf("trade %s does not exist", id)
+	default:
+		return ObjectDump{}, fmt.Errorf("unknown object type %s", objectType)
+	}
+	raw, _, err := withContextHeight(ctx, cmd).Run(ctx)
+	if err != nil {
+		return ObjectDump{}, err
+	}
+	if err := GetCodec().Decode(raw, obj); err != nil {
+		return ObjectDump{}, fmt.Errorf("%s: %s_output %s", err.Error(), objectType, string(raw))
+	}
+	return NewObjectDump(objectType, id, raw, obj), nil
+}
+
+// Dump is a function to write raw stored json and annotated view of cookbook, recipe, item, trade or execution to w
+// Programs of recipes are pretty-printed and weights of outputs are shown with probabilities they are selected.
+func Dump(objectType DumpObjectType, id string, w io.Writer) error {
+	return DumpCtx(context.Background(), objectType, id, w)
+}
+
+// DumpCtx is a function to dump object to w, see Dump, it's canceled when ctx is done
+func DumpCtx(ctx context.Context, objectType DumpObjectType, id string, w io.Writer) error {
+	dump, err := fetchObjectDump(ctx, objectType, id)
+	if err != nil {
+		return fmt.Errorf("error fetching %s %s: %w", objectType, id, err)
+	}
+	_, err = dump.WriteTo(w)
+	return err
+}
+
+// annotator is a struct to build annotated lines of object
+type annotator struct {
+	lines []string
+}
+
+func (a *annotator) add(indent int, format string, args ...interface{}) {
+	a.lines = append(a.lines, strings.Repeat("  ", indent)+fmt.Sprintf(format, args...))
+}
+
+// program is a function to add program pretty-printed, invalid program is added as it is with its error
+func (a *annotator) program(indent int, name string, program string) {
+	formatted, err := types.FormatProgram(program)
+	if err != nil {
+		a.add(indent, "%s = %s (invalid: %s)", name, program, err.Error())
+		return
+	}
+	a.add(indent, "%s = %s", name, formatted)
+}
+
+// params is a function to add item params, params having no program are shown with their weight ranges
+func (a *annotator) params(indent int, doubles []types.DoubleParam, longs []types.LongParam, strs []types.StringParam, transferFee int64) {
+	for _, param := range doubles {
+		if len(param.Program) > 0 {
+			a.program(indent, "double "+param.Key, param.Program)
+			continue
+		}
+		ranges := []string{}
+		for _, wr := range param.WeightRanges {
+			ranges = append(ranges, fmt.Sprintf("%s~%s (weight %d)", wr.Lower, wr.Upper, wr.Weight))
+		}
+		a.add(indent, "double %s in %s, rate %s", param.Key, strings.Join(ranges, ", "), param.Rate)
+	}
+	for _, param := range longs {
+		if len(param.Program) > 0 {
+			a.program(indent, "long "+param.Key, param.Program)
+			continue
+		}
+		ranges := []string{}
+		for _, wr := range param.WeightRanges {
+			ranges = append(ranges, fmt.Sprintf("%d~%d (weight %d)", wr.Lower, wr.Upper, wr.Weight))
+		}
+		a.add(indent, "long %s in %s, rate %s", param.Key, strings.Join(ranges, ", "), param.Rate)
+	}
+	for _, param := range strs {
+		if len(param.Program) > 0 {
+			a.program(indent, "string "+param.Key, param.Program)
+			continue
+		}
+		a.add(indent, "string %s = %q, rate %s", param.Key, param.Value, param.Rate)
+	}
+	if transferFee > 0 {
+		a.add(indent, "transfer fee %d", transferFee)
+	}
+}
+
+// recipe is a function to add recipe with its programs pretty-printed and probabilities of outputs
+func (a *annotator) recipe(rcp types.Recipe) {
+	a.add(0, "recipe %s %q of cookbook %s by %s", rcp.ID, rcp.Name, rcp.CookbookID, GlobalAddressBook.Format(rcp.Sender))
+	a.add(0, "disabled %t, block interval %d", rcp.Disabled, rcp.BlockInterval)
+	a.add(0, "coin inputs: %s", types.CoinInputList(rcp.CoinInputs).ToCoins())
+	a.add(0, "item inputs:")
+	for idx, input := range rcp.ItemInputs {
+		a.add(1, "input%d %s: %d doubles, %d longs, %d strings", idx, input.ID, len(input.Doubles), len(input.Longs), len(input.Strings))
+	}
+	a.add(0, "entries:")
+	for _, entry := range rcp.Entries.CoinOutputs {
+		a.program(1, fmt.Sprintf("coin output %s: %s count", entry.ID, entry.Coin), entry.Count)
+	}
+	for _, entry := range rcp.Entries.ItemOutputs {
+		a.add(1, "item output %s:", entry.ID)
+		a.params(2, entry.Doubles, entry.Longs, entry.Strings, entry.TransferFee)
+	}
+	for _, entry := range rcp.Entries.ItemModifyOutputs {
+		a.add(1, "item modify output %s of %s:", entry.ID, entry.ItemInputRef)
+		a.params(2, entry.Doubles, entry.Longs, entry.Strings, entry.TransferFee)
+	}
+	a.add(0, "outputs:")
+	// weights are evaluated without input items, weights referring to them can't be shown as probabilities
+	table, err := types.NewLootTable(rcp, []types.Item{})
+	if err != nil {
+		a.add(1, "weights can't be evaluated without input items: %s", err.Error())
+	}
+	for idx, output := range rcp.Outputs {
+		weight, err := types.FormatProgram(output.Weight)
+		if err != nil {
+			weight = output.Weight
+		}
+		if idx < len(table.Rows) {
+			row := table.Rows[idx]
+			a.add(1, "[%s] weight %s = %d (%.2f%%)", strings.Join(output.EntryIDs, ", "), weight, row.Weight, row.Probability*100)
+			continue
+		}
+		a.add(1, "[%s] weight %s", strings.Join(output.EntryIDs, ", "), weight)
+	}
+}
+
+// item is a function to add item with its attributes
+func (a *annotator) item(item types.Item) {
+	a.add(0, "item %s %q of cookbook %s owned by %s", item.ID, itemLabel(item), item.CookbookID, GlobalAddressBook.Format(item.Sender))
+	a.add(0, "tradable %t, transfer fee %d, last update %d", item.Tradable, item.TransferFee, item.LastUpdate)
+	if len(item.OwnerRecipeID) > 0 {
+		a.add(0, "locked by recipe %s", item.OwnerRecipeID)
+	}
+	if len(item.OwnerTradeID) > 0 {
+		a.add(0, "locked by trade %s", item.OwnerTradeID)
+	}
+	for _, kv := range item.Doubles {
+		a.add(1, "double %s = %s", kv.Key, kv.Value)
+	}
+	for _, kv := range item.Longs {
+		a.add(1, "long %s = %d", kv.Key, kv.Value)
+	}
+	for _, kv := range item.Strings {
+		a.add(1, "string %s = %q", kv.Key, kv.Value)
+	}
+}
+
+// annotateObject is a function to get annotated lines of decoded object
+func annotateObject(obj interface{}) []string {
+	a := &annotator{}
+	switch obj := obj.(type) {
+	case *types.Cookbook:
+		a.add(0, "cookbook %s %q version %s by %s", obj.ID, obj.Name, obj.Version, GlobalAddressBook.Format(obj.Sender))
+		a.add(0, "developer %s, level %d, cost per block %d", obj.Developer, obj.Level, obj.CostPerBlock)
+	case *types.Recipe:
+		a.recipe(*obj)
+	case *types.Item:
+		a.item(*obj)
+	case *types.Trade:
+		a.add(0, "trade %s by %s, disabled %t, completed %t", obj.ID, GlobalAddressBook.Format(obj.Sender), obj.Disabled, obj.Completed)
+		if len(obj.FulFiller) > 0 {
+			a.add(0, "fulfilled by %s", GlobalAddressBook.Format(obj.FulFiller))
+		}
+		a.add(0, "coin inputs: %s", types.CoinInputList(obj.CoinInputs).ToCoins())
+		for _, input := range obj.ItemInputs {
+			a.add(0, "item input %s of cookbook %s", input.ItemInput.ID, input.CookbookID)
+		}
+		a.add(0, "coin outputs: %s", obj.CoinOutputs)
+		for _, item := range obj.ItemOutputs {
+			a.add(0, "item output %s %q", item.ID, itemLabel(item))
+		}
+	case *types.GetExecutionResponse:
+		a.add(0, "execution %s of recipe %s of cookbook %s by %s", obj.ID, obj.RecipeID, obj.CookbookID, GlobalAddressBook.Format(obj.Sender))
+		a.add(0, "block height %d, completed %t", obj.BlockHeight, obj.Completed)
+		a.add(0, "coin inputs: %s", obj.CoinsInput)
+		for _, item := range obj.ItemInputs {
+			a.add(0, "item input %s %q", item.ID, itemLabel(item))
+		}
+	}
+	return a.lines
+}
+
+// dumpRef is a struct to describe an object referred by transaction
+type dumpRef struct {
+	objectType DumpObjectType
+	id         string
+}
+
+// dumpRefsOfEvents is a function to get objects created or changed by events of transaction
+// Input items of recipes are not referred as they are usually consumed.
+func dumpRefsOfEvents(evs []events.Event) []dumpRef {
+	refs := []dumpRef{}
+	items := func(ids []string) {
+		for _, id := range ids {
+			refs = append(refs, dumpRef{DumpItem, id})
+		}
+	}
+	for _, event := range evs {
+		switch ev := event.(type) {
+		case events.EventCookbookCreated:
+			refs = append(refs, dumpRef{DumpCookbook, ev.CookbookID})
+		case events.EventRecipeCreated:
+			refs = append(refs, dumpRef{DumpRecipe, ev.RecipeID})
+		case events.EventRecipeExecuted:
+			refs = append(refs, dumpRef{DumpRecipe, ev.RecipeID})
+			if len(ev.ExecID) > 0 {
+				refs = append(refs, dumpRef{DumpExecution, ev.ExecID})
+			}
+			items(ev.OutputItemIDs)
+		case events.EventExecutionChecked:
+			refs = append(refs, dumpRef{DumpExecution, ev.ExecID})
+			items(ev.OutputItemIDs)
+		case events.EventItemCreated:
+			items([]string{ev.ItemID})
+		case events.EventTradeCreated:
+			refs = append(refs, dumpRef{DumpTrade, ev.TradeID})
+		case events.EventTradeFulfilled:
+			refs = append(refs, dumpRef{DumpTrade, ev.TradeID})
+			items(ev.InputItemIDs)
+			items(ev.OutputItemIDs)
+		}
+	}
+	return refs
+}
+
+// dumpRefsOfTxs is a function to get distinct objects referred by transactions in send order, up to limit objects
+func dumpRefsOfTxs(ctx context.Context, rpc historyRPC, txHashes []string, limit int) ([]dumpRef, error) {
+	refs := []dumpRef{}
+	seen := map[dumpRef]bool{}
+	for _, txhash := range txHashes {
+		txs, _, err := searchTxs(ctx, rpc, fmt.Sprintf("tx.hash='%s'", txhash), 1, 1)
+		if err != nil {
+			return refs, fmt.Errorf("error searching transaction %s: %w", txhash, err)
+		}
+		for _, tx := range txs {
+			for _, ref := range dumpRefsOfEvents(tx.Events) {
+				if len(ref.id) == 0 || seen[ref] {
+					continue
+				}
+				if len(refs) >= limit {
+					return refs, nil
+				}
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs, nil
+}
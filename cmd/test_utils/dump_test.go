@@ -0,0 +1,70 @@
+package inttest
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/events"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestObjectDump(originT *originT.T) {
+	t := testing.NewT(originT)
+	rcp := types.Recipe{
+		ID:         "rcp001",
+		CookbookID: "cbk001",
+		Name:       "Lucky Box",
+		Entries: types.EntriesList{
+			CoinOutputs: []types.CoinOutput{{ID: "gold", Coin: "goldcoin", Count: "rand_int(10)+1"}},
+			ItemOutputs: []types.ItemOutput{{ID: "sword", Longs: []types.LongParam{{Key: "level", Program: "1+ 2"}}}},
+		},
+		Outputs: []types.WeightedOutputs{
+			{EntryIDs: []string{"gold"}, Weight: "3"},
+			{EntryIDs: []string{"gold", "sword"}, Weight: "1"},
+		},
+	}
+	dump := NewObjectDump(DumpRecipe, rcp.ID, []byte(`{"ID":"rcp001"}`), &rcp)
+	annotated := strings.Join(dump.Annotated, "\n")
+	t.WithFields(testing.Fields{
+		"annotated": annotated,
+	}).MustTrue(strings.Contains(annotated, "coin output gold: goldcoin count = rand_int(10) + 1") &&
+		strings.Contains(annotated, "long level = 1 + 2"), "programs should be pretty-printed")
+	t.WithFields(testing.Fields{
+		"annotated": annotated,
+	}).MustTrue(strings.Contains(annotated, "[gold] weight 3 = 3 (75.00%)") &&
+		strings.Contains(annotated, "[gold, sword] weight 1 = 1 (25.00%)"), "weights should be shown as percentages")
+
+	var buf bytes.Buffer
+	_, err := dump.WriteTo(&buf)
+	t.MustNil(err, "error writing dump")
+	t.MustTrue(strings.Contains(buf.String(), "== recipe rcp001 ==\n-- raw --\n{\n  \"ID\": \"rcp001\"\n}\n-- annotated --\n"), "raw json should be indented before annotated view")
+
+	dump = NewObjectDump(DumpItem, "item001", []byte("item not found"), nil)
+	t.MustTrue(string(dump.Raw) == `"item not found"`, "raw output which is not json should be kept as json string")
+}
+
+func TestDumpRefsOfTxs(originT *originT.T) {
+	t := testing.NewT(originT)
+	rpc := &fakeHistoryRPC{
+		blocks: map[int64]tmtypes.Txs{3: {[]byte("execute recipe")}},
+		results: map[int64][]*abci.ResponseDeliverTx{3: {{Events: []abci.Event{
+			itemHistoryEvent(events.EventTypeRecipeExecuted, events.AttributeKeyRecipeID, "rcp001", events.AttributeKeyExecID, "exec001",
+				events.AttributeKeyInputItemID, "item001", events.AttributeKeyOutputItemID, "item002"),
+			itemHistoryEvent(events.EventTypeItemCreated, events.AttributeKeyItemID, "item002"),
+		}}}},
+	}
+	refs, err := dumpRefsOfTxs(context.Background(), rpc, []string{"ABCD"}, 10)
+	t.MustNil(err, "error getting objects of transactions")
+	t.MustTrue(rpc.query == "tx.hash='ABCD'", "transaction should be searched by hash")
+	t.MustTrue(len(refs) == 3 && refs[0] == dumpRef{DumpRecipe, "rcp001"} && refs[1] == dumpRef{DumpExecution, "exec001"} &&
+		refs[2] == dumpRef{DumpItem, "item002"}, "distinct objects should be referred except consumed input items")
+
+	refs, err = dumpRefsOfTxs(context.Background(), rpc, []string{"ABCD"}, 2)
+	t.MustNil(err, "error getting objects of transactions")
+	t.MustTrue(len(refs) == 2, "objects should be limited")
+}
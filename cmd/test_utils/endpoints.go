@@ -0,0 +1,104 @@
+package inttest
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+)
+
+// NodeEndpoints is a struct to manage listen addresses of a locally launched node
+type NodeEndpoints struct {
+	Host     string
+	RPCPort  int
+	P2PPort  int
+	GRPCPort int
+	RESTPort int
+}
+
+// GetFreePort is a function to get a free tcp port on host from the OS
+func GetFreePort(host string) (int, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, "0"))
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// AllocateNodeEndpoints is a function to assign free ports for rpc, p2p, grpc and rest endpoints of a node
+// so that several nodes launched on one machine do not collide on default ports
+func AllocateNodeEndpoints(host string) (NodeEndpoints, error) {
+	endpoints := NodeEndpoints{Host: host}
+	ports := []*int{&endpoints.RPCPort, &endpoints.P2PPort, &endpoints.GRPCPort, &endpoints.RESTPort}
+	used := map[int]bool{}
+	for _, port := range ports {
+		for {
+			freePort, err := GetFreePort(host)
+			if err != nil {
+				return endpoints, err
+			}
+			if !used[freePort] {
+				used[freePort] = true
+				*port = freePort
+				break
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// RPCAddress is a function to get tendermint rpc address of the node
+func (e NodeEndpoints) RPCAddress() string {
+	return fmt.Sprintf("tcp://%s", net.JoinHostPort(e.Host, fmt.Sprint(e.RPCPort)))
+}
+
+// GRPCAddress is a function to get grpc address of the node
+func (e NodeEndpoints) GRPCAddress() string {
+	return net.JoinHostPort(e.Host, fmt.Sprint(e.GRPCPort))
+}
+
+// RESTAddress is a function to get rest endpoint of the node
+func (e NodeEndpoints) RESTAddress() string {
+	return fmt.Sprintf("http://%s", net.JoinHostPort(e.Host, fmt.Sprint(e.RESTPort)))
+}
+
+// StartArgs is a function to get "pylonsd start" flags listening on the allocated ports
+// rest server has no start flag, it's enabled on the allocated port by StartEnv.
+func (e NodeEndpoints) StartArgs() []string {
+	return []string{
+		"--rpc.laddr", e.RPCAddress(),
+		"--p2p.laddr", fmt.Sprintf("tcp://%s", net.JoinHostPort(e.Host, fmt.Sprint(e.P2PPort))),
+		"--grpc.address", e.GRPCAddress(),
+	}
+}
+
+// StartEnv is a function to get environment variables of "pylonsd start" of binary enabling rest server on the allocated port
+// Start command reads api.enable and api.address of app.toml overridden by environment variables prefixed by name of binary.
+func (e NodeEndpoints) StartEnv(binary string) []string {
+	prefix := strings.NewReplacer(".", "_", "-", "_").Replace(strings.ToUpper(filepath.Base(binary)))
+	return []string{
+		prefix + "_API_ENABLE=true",
+		fmt.Sprintf("%s_API_ADDRESS=tcp://%s", prefix, net.JoinHostPort(e.Host, fmt.Sprint(e.RESTPort))),
+	}
+}
+
+// UseNodeEndpoints is a function to point options of env to the node endpoints
+// Commands use rpc addresses of all nodes, and grpc and rest transports use the first node.
+// Rest endpoint is only set when useRest is true, as transactions are broadcast through rest when it's set.
+func UseNodeEndpoints(env *Env, endpoints []NodeEndpoints, useRest bool) {
+	customNode := ""
+	for idx, e := range endpoints {
+		if idx > 0 {
+			customNode += ","
+		}
+		customNode += e.RPCAddress()
+	}
+	env.opts.CustomNode = customNode
+	if len(endpoints) > 0 {
+		env.opts.GRPCEndpoint = endpoints[0].GRPCAddress()
+	}
+	if useRest && len(endpoints) > 0 {
+		env.opts.RestEndpoint = endpoints[0].RESTAddress()
+	}
+}
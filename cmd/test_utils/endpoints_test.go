@@ -0,0 +1,39 @@
+package inttest
+
+import (
+	"fmt"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestAllocateNodeEndpoints(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	endpoints, err := AllocateNodeEndpoints("127.0.0.1")
+	t.MustNil(err, "error allocating node endpoints")
+	ports := map[int]bool{
+		endpoints.RPCPort:  true,
+		endpoints.P2PPort:  true,
+		endpoints.GRPCPort: true,
+		endpoints.RESTPort: true,
+	}
+	t.WithFields(testing.Fields{
+		"endpoints": JSONFormatter(endpoints),
+	}).MustTrue(len(ports) == 4, "allocated ports should be different")
+
+	env := NewEnv(CLIOptions{}, nil)
+	UseNodeEndpoints(env, []NodeEndpoints{endpoints, endpoints}, false)
+	t.MustTrue(env.Options().CustomNode == endpoints.RPCAddress()+","+endpoints.RPCAddress(), "custom node should be set to rpc addresses")
+	t.MustTrue(env.Options().GRPCEndpoint == endpoints.GRPCAddress(), "grpc endpoint should be set to the first node")
+	t.MustTrue(env.Options().RestEndpoint == "", "rest endpoint should not be set unless rest is used")
+	UseNodeEndpoints(env, []NodeEndpoints{endpoints}, true)
+	t.MustTrue(env.Options().RestEndpoint == endpoints.RESTAddress(), "rest endpoint should be set")
+	t.MustTrue(CLIOpts.CustomNode != env.Options().CustomNode, "options of default env should not be changed")
+
+	startEnv := endpoints.StartEnv("/usr/local/bin/pylonsd-v0.2.0")
+	t.WithFields(testing.Fields{
+		"start_env": startEnv,
+	}).MustTrue(len(startEnv) == 2 && startEnv[0] == "PYLONSD_V0_2_0_API_ENABLE=true" &&
+		startEnv[1] == fmt.Sprintf("PYLONSD_V0_2_0_API_ADDRESS=tcp://127.0.0.1:%d", endpoints.RESTPort), "rest server should be enabled by env of binary")
+}
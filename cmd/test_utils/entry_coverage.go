@@ -0,0 +1,176 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// outputSignature is a struct to describe what a weighted output pays out
+// Outputs are told apart only by their signature as execution output does not refer to entry IDs
+type outputSignature struct {
+	coinDenoms  string // sorted and comma separated
+	itemCount   int
+	modifyCount int
+}
+
+// EntryCoverage is a struct to track which weighted outputs of a recipe are observed over executions
+type EntryCoverage struct {
+	Recipe     types.Recipe
+	Observed   []int // number of executions matched per weighted output index
+	Unmatched  int   // number of executions which match no weighted output
+	Executions int
+	signatures []outputSignature
+}
+
+// NewEntryCoverage is a function to create entry coverage tracker of recipe
+func NewEntryCoverage(rcp types.Recipe) *EntryCoverage {
+	coverage := &EntryCoverage{
+		Recipe:   rcp,
+		Observed: make([]int, len(rcp.Outputs)),
+	}
+	for _, output := range rcp.Outputs {
+		coverage.signatures = append(coverage.signatures, getOutputSignature(rcp.Entries, output))
+	}
+	return coverage
+}
+
+func getOutputSignature(entries types.EntriesList, output types.WeightedOutputs) outputSignature {
+	signature := outputSignature{}
+	denoms := []string{}
+	for _, entryID := range output.EntryIDs {
+		for _, coinOutput := range entries.CoinOutputs {
+			if coinOutput.ID == entryID {
+				denoms = append(denoms, coinOutput.Coin)
+			}
+		}
+		for _, itemOutput := range entries.ItemOutputs {
+			if itemOutput.ID == entryID {
+				signature.itemCount++
+			}
+		}
+		for _, modifyOutput := range entries.ItemModifyOutputs {
+			if modifyOutput.ID == entryID {
+				signature.modifyCount++
+			}
+		}
+	}
+	sort.Strings(denoms)
+	signature.coinDenoms = strings.Join(denoms, ",")
+	return signature
+}
+
+// Observe is a function to record payout of an execution
+// inputItemIDs are the item IDs used as recipe inputs, so that modified items are told apart from new items
+func (c *EntryCoverage) Observe(coins sdk.Coins, itemIDs []string, inputItemIDs []string) {
+	c.Executions++
+	observed := outputSignature{}
+	denoms := []string{}
+	for _, coin := range coins {
+		denoms = append(denoms, coin.Denom)
+	}
+	sort.Strings(denoms)
+	observed.coinDenoms = strings.Join(denoms, ",")
+	for _, itemID := range itemIDs {
+		if Exists(inputItemIDs, itemID) {
+			observed.modifyCount++
+		} else {
+			observed.itemCount++
+		}
+	}
+	matched := false
+	for idx, signature := range c.signatures {
+		if signature == observed {
+			c.Observed[idx]++
+			matched = true
+		}
+	}
+	if !matched {
+		c.Unmatched++
+	}
+}
+
+// DescribeOutput is a function to get readable description of weighted output by index
+func (c *EntryCoverage) DescribeOutput(idx int) string {
+	output := c.Recipe.Outputs[idx]
+	signature := c.signatures[idx]
+	kinds := []string{}
+	if len(signature.coinDenoms) > 0 {
+		kinds = append(kinds, fmt.Sprintf("coin output %s", signature.coinDenoms))
+	}
+	if signature.itemCount > 0 {
+		kinds = append(kinds, fmt.Sprintf("%d item output", signature.itemCount))
+	}
+	if signature.modifyCount > 0 {
+		kinds = append(kinds, fmt.Sprintf("%d item modify output", signature.modifyCount))
+	}
+	if len(kinds) == 0 {
+		kinds = append(kinds, "no-op")
+	}
+	return fmt.Sprintf("output %d entries=%v weight=%s (%s)", idx, output.EntryIDs, output.Weight, strings.Join(kinds, ", "))
+}
+
+// UnobservedOutputs is a function to get indexes of weighted outputs which are never observed
+func (c *EntryCoverage) UnobservedOutputs() []int {
+	unobserved := []int{}
+	for idx, count := range c.Observed {
+		if count == 0 {
+			unobserved = append(unobserved, idx)
+		}
+	}
+	return unobserved
+}
+
+// MustObserveAllOutputs is a function to fail the test when any weighted output of the recipe was never observed
+func (c *EntryCoverage) MustObserveAllOutputs(t *testing.T) {
+	unobserved := []string{}
+	for _, idx := range c.UnobservedOutputs() {
+		unobserved = append(unobserved, c.DescribeOutput(idx))
+	}
+	t.WithFields(testing.Fields{
+		"recipe_id":  c.Recipe.ID,
+		"executions": c.Executions,
+		"unmatched":  c.Unmatched,
+		"unobserved": unobserved,
+	}).MustTrue(len(unobserved) == 0, "recipe has outputs which were not observed, they may be unreachable by weight or program error")
+}
+
+// ExecuteRecipeForEntryCoverage is a function to execute recipe without item inputs count times and track observed outputs
+// Only recipes without block interval are supported as payout should be in execute recipe response
+func ExecuteRecipeForEntryCoverage(t *testing.T, rcpID, sender string, count int) (*EntryCoverage, error) {
+	rcp, err := GetRecipeByGUID(rcpID)
+	if err != nil {
+		return nil, err
+	}
+	if rcp.BlockInterval > 0 {
+		return nil, errors.New("recipe with block interval is not supported for entry coverage")
+	}
+	coverage := NewEntryCoverage(rcp)
+	for idx := 0; idx < count; idx++ {
+		execMsg := types.NewMsgExecuteRecipe(rcpID, sender, []string{})
+		txhash, err := NewClient().SendTx(context.Background(), t, SignerAddress(sender), &execMsg)
+		if err != nil {
+			return coverage, err
+		}
+		txResult, err := WaitAndGetTxResult(txhash, t)
+		if err != nil {
+			return coverage, fmt.Errorf("error executing recipe: %w", err)
+		}
+		execResp := types.MsgExecuteRecipeResponse{}
+		if err = txResult.GetMsgResponse(0, execMsg.Type(), &execResp); err != nil {
+			return coverage, err
+		}
+		coins, itemIDs, err := DecodeExecutionOutput(execResp.Output)
+		if err != nil {
+			return coverage, err
+		}
+		coverage.Observe(coins, itemIDs, []string{})
+	}
+	return coverage, nil
+}
@@ -0,0 +1,45 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestEntryCoverage(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	rcp := types.Recipe{
+		ID: "recipe1",
+		Entries: types.EntriesList{
+			CoinOutputs:       []types.CoinOutput{{ID: "coin", Coin: "gold", Count: "10"}},
+			ItemOutputs:       []types.ItemOutput{{ID: "sword"}},
+			ItemModifyOutputs: []types.ItemModifyOutput{{ID: "upgrade", ItemInputRef: "input"}},
+		},
+		Outputs: []types.WeightedOutputs{
+			{EntryIDs: []string{"coin"}, Weight: "10"},
+			{EntryIDs: []string{"sword"}, Weight: "10"},
+			{EntryIDs: []string{"upgrade"}, Weight: "1"},
+			{EntryIDs: []string{}, Weight: "1"},
+		},
+	}
+	coverage := NewEntryCoverage(rcp)
+	coverage.Observe(sdk.Coins{sdk.NewInt64Coin("gold", 10)}, []string{}, []string{})
+	coverage.Observe(sdk.Coins{}, []string{"newItem"}, []string{"inputItem"})
+	coverage.Observe(sdk.Coins{sdk.NewInt64Coin("silver", 1)}, []string{}, []string{})
+
+	unobserved := coverage.UnobservedOutputs()
+	t.WithFields(testing.Fields{
+		"unobserved": unobserved,
+	}).MustTrue(len(unobserved) == 2 && unobserved[0] == 2 && unobserved[1] == 3, "upgrade and no-op outputs should be unobserved")
+	t.MustTrue(coverage.Unmatched == 1, "payout of undeclared output should be unmatched")
+	t.MustContain(coverage.DescribeOutput(2), "1 item modify output", "modify output should be described")
+	t.MustContain(coverage.DescribeOutput(3), "no-op", "empty output should be described as no-op")
+
+	coverage.Observe(sdk.Coins{}, []string{"inputItem"}, []string{"inputItem"})
+	coverage.Observe(sdk.Coins{}, []string{}, []string{})
+	t.MustTrue(len(coverage.UnobservedOutputs()) == 0, "all outputs should be observed")
+	coverage.MustObserveAllOutputs(&t)
+}
@@ -0,0 +1,153 @@
+package inttest
+
+import (
+	"context"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+)
+
+// Env is a struct to carry configuration, codec, transport, keyring and reporter of one client of the harness
+// Several envs can run in a process against different nodes, package level helpers use DefaultEnv.
+type Env struct {
+	opts     *CLIOptions
+	reporter *testing.Reporter
+}
+
+// defaultEnv is the env of package level helpers, it reads CLIOpts and flags as they are changed
+var defaultEnv = &Env{opts: &CLIOpts, reporter: testing.GlobalReporter}
+
+// DefaultEnv is a function to get env of CLIOpts which package level helpers use
+func DefaultEnv() *Env {
+	return defaultEnv
+}
+
+// NewEnv is a function to create env of its own copy of opts, results are reported to reporter
+// GlobalReporter is used when reporter is nil.
+func NewEnv(opts CLIOptions, reporter *testing.Reporter) *Env {
+	if reporter == nil {
+		reporter = testing.GlobalReporter
+	}
+	return &Env{opts: &opts, reporter: reporter}
+}
+
+// Options is a function to get options of env, changes of them apply to later calls
+func (e *Env) Options() *CLIOptions {
+	return e.opts
+}
+
+// Reporter is a function to get reporter results of env are collected by
+func (e *Env) Reporter() *testing.Reporter {
+	return e.reporter
+}
+
+// MaxWaitBlock is a function to get maximum wait block of env, default 3
+func (e *Env) MaxWaitBlock() int64 {
+	if e.opts.MaxWaitBlock == 0 {
+		return 3
+	}
+	return e.opts.MaxWaitBlock
+}
+
+// ConfirmationDepth is a function to get confirmation depth of env, default 0
+func (e *Env) ConfirmationDepth() int64 {
+	return e.opts.ConfirmationDepth
+}
+
+// MaxBroadcastRetry is a function to get maximum retry for transaction broadcast of env, default 50
+func (e *Env) MaxBroadcastRetry() int {
+	if e.opts.MaxBroadcast == 0 {
+		return 50
+	}
+	return e.opts.MaxBroadcast
+}
+
+// Keyring is a function to get keyring provider of env, the one of keyring flags when it's not set
+func (e *Env) Keyring() KeyringProvider {
+	if e.opts.Keyring != nil {
+		return e.opts.Keyring
+	}
+	return flagKeyringProvider()
+}
+
+// Codec is a function to get codec of encoding configured by env
+func (e *Env) Codec() *Codec {
+	return NewCodec(e.opts.Encoding)
+}
+
+// NewClient is a function to create client of env, options not set are taken from env
+func (e *Env) NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		env:               e,
+		maxWaitBlock:      e.MaxWaitBlock(),
+		maxBroadcast:      e.MaxBroadcastRetry(),
+		confirmationDepth: e.ConfirmationDepth(),
+		keyring:           e.Keyring(),
+		recorder:          e.opts.TxRecorder,
+		txOpts:            TxOptions{FeeStrategy: e.FeeStrategy()},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// firstNode is a function to get the first tendermint rpc address of CustomNode of env which can list several nodes
+func (e *Env) firstNode() string {
+	return strings.Split(e.opts.CustomNode, ",")[0]
+}
+
+// nodeFlagSetup is a function to set one of nodes of env on commands connecting to node, node set on args is kept
+// Args not fitting the supported command grammar are returned as they are.
+func (e *Env) nodeFlagSetup(args []string) []string {
+	setup, err := e.nodeFlagSetupStrict(args)
+	if err != nil {
+		return args
+	}
+	return setup
+}
+
+// nodeFlagSetupStrict is a function to set one of nodes of env on command validated by the supported command grammar
+func (e *Env) nodeFlagSetupStrict(args []string) ([]string, error) {
+	cmd, err := ParseCommandArgs(args)
+	if err != nil {
+		return args, err
+	}
+	if len(e.opts.CustomNode) > 0 && cmd.Spec.Node && !hasFlag(args, flags.FlagNode) {
+		args = append(args, "--node", e.randomNode())
+	}
+	return args, nil
+}
+
+// randomNode is a function to select one of nodes of env by the run seed, it's empty when env has no custom node
+func (e *Env) randomNode() string {
+	if len(e.opts.CustomNode) == 0 {
+		return ""
+	}
+	customNodes := strings.Split(e.opts.CustomNode, ",")
+	return customNodes[randNodeIndex(len(customNodes))]
+}
+
+// envKey is a context key of env
+type envKey struct{}
+
+// ContextWithEnv is a function to make pylonsd commands, transports and tx helpers run with ctx use env
+func ContextWithEnv(ctx context.Context, env *Env) context.Context {
+	return context.WithValue(ctx, envKey{}, env)
+}
+
+// WithEnv is a function to run query through transport of env instead of DefaultEnv e.g. an env of in-process chain
+func WithEnv(env *Env) QueryOption {
+	return func(o *queryOptions) {
+		o.env = env
+	}
+}
+
+// EnvFromContext is a function to get env of ctx, DefaultEnv when ctx has no env
+func EnvFromContext(ctx context.Context) *Env {
+	if env, ok := ctx.Value(envKey{}).(*Env); ok && env != nil {
+		return env
+	}
+	return defaultEnv
+}
@@ -0,0 +1,95 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+func TestEnv(originT *originT.T) {
+	t := testing.NewT(originT)
+	addr := "pylo1gcq3wf0eqw6ahg38aqtlkhq4mnpc2dk3y2ejwu"
+	txhash := "0F1A8C7DD5AF3F1C5DD2D1E4C7B6C3B1A9E6F9D2C4B8A7E5D3C1B9A7F5E3D1C9"
+	envs := []*Env{}
+	for _, height := range []int64{10, 20} {
+		server := newRESTServer(&t, map[string]proto.Message{
+			"/cosmos/bank/v1beta1/balances/" + addr: &banktypes.QueryAllBalancesResponse{Balances: sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, height*10))},
+			"/cosmos/tx/v1beta1/txs/" + txhash:      &txtypes.GetTxResponse{TxResponse: &sdk.TxResponse{TxHash: txhash, Height: height}},
+		})
+		defer server.Close()
+		envs = append(envs, NewEnv(CLIOptions{Transport: TransportREST, RestEndpoint: server.URL, MaxWaitBlock: height}, nil))
+	}
+
+	for idx, env := range envs {
+		transport, err := env.Transport()
+		t.MustNil(err, "error getting transport of env")
+		coins, err := transport.Balances(context.Background(), addr)
+		t.MustNil(err, "error getting balances through env")
+		t.WithFields(testing.Fields{
+			"env":   idx,
+			"coins": coins.String(),
+		}).MustTrue(coins.AmountOf(types.Pylon).Int64() == int64(100*(idx+1)), "env should query its own node")
+
+		txResult, err := getTxResult(ContextWithEnv(context.Background(), env), txhash)
+		t.MustNil(err, "error getting tx result through env of context")
+		t.MustTrue(txResult.Height == int64(10*(idx+1)), "tx result should be queried from node of env of context")
+
+		client := env.NewClient()
+		t.MustTrue(client.Env() == env && client.maxWaitBlock == int64(10*(idx+1)), "client should take options of its env")
+	}
+
+	prevNode := CLIOpts.CustomNode
+	defer func() { CLIOpts.CustomNode = prevNode }()
+	CLIOpts.CustomNode = "tcp://default:26657"
+	env := NewEnv(CLIOptions{CustomNode: "tcp://other:26657"}, testing.NewReporter())
+	t.MustTrue(EnvFromContext(context.Background()) == DefaultEnv(), "context without env should use default env")
+	t.MustTrue(DefaultEnv().Options().CustomNode == "tcp://default:26657", "default env should read CLIOpts")
+	t.MustContain(strings.Join(NodeFlagSetup([]string{"status"}), " "), "tcp://default:26657", "package helpers should use CLIOpts")
+	t.MustContain(strings.Join(env.nodeFlagSetup([]string{"status"}), " "), "tcp://other:26657", "env should use its own node")
+	t.MustTrue(env.Reporter() != testing.GlobalReporter, "env should report to its own reporter")
+	t.MustTrue(NewClient().Env() == DefaultEnv(), "package client should use default env")
+}
+
+func TestEnvChainProfileAndRetryPolicy(originT *originT.T) {
+	t := testing.NewT(originT)
+	prevProfile, prevProfiles := CLIOpts.Profile, ChainProfiles
+	defer func() {
+		CLIOpts.Profile, ChainProfiles = prevProfile, prevProfiles
+	}()
+	ChainProfiles = map[string]ChainProfile{
+		"env_test": {Name: "env_test", Fees: "10upylon", GasLimit: 400000, MaxTxBytes: 100000, MaxTxMsgs: 2},
+	}
+	CLIOpts.Profile = ""
+	env := NewEnv(CLIOptions{Profile: "env_test", RetryPolicy: &RetryPolicy{MaxAttempts: 1, MaxRebroadcasts: 2}}, nil)
+	ctx := ContextWithEnv(context.Background(), env)
+
+	msg := types.NewMsgCreateAccount(sdk.AccAddress([]byte("env_profile_account_")).String())
+	tx, err := genTx(ctx, []sdk.Msg{&msg}, TxOptions{})
+	t.MustNil(err, "error generating transaction with env")
+	t.WithFields(testing.Fields{
+		"fee": tx.GetFee().String(),
+		"gas": tx.GetGas(),
+	}).MustTrue(tx.GetFee().AmountOf("upylon").Int64() == 10 && tx.GetGas() == 400000, "transaction should take fee and gas of chain profile of env")
+	tx, err = GenTxWithOptions([]sdk.Msg{&msg}, TxOptions{})
+	t.MustNil(err, "error generating transaction")
+	t.MustTrue(tx.GetFee().Empty() && tx.GetGas() == defaultGasLimit, "package helpers should not use chain profile of other env")
+
+	fees, err := PriorityFee{Boost: sdk.NewDec(2)}.Fee(ctx, 400000)
+	t.MustNil(err, "error getting priority fee")
+	t.MustTrue(fees.AmountOf("upylon").Int64() == 20, "priority fee should boost fee strategy of env of ctx")
+
+	limits := GetTxLimits(ctx)
+	t.MustTrue(limits.MaxMsgs == 2 && limits.MaxBytes == 100000, "tx limits should be taken from chain profile of env")
+	t.MustTrue(errors.Is(limits.Check([]sdk.Msg{&msg, &msg, &msg}, TxOptions{}), ErrTxTooLarge), "tx limits of env should be checked")
+
+	t.MustTrue(EnvFromContext(ctx).RetryPolicy().MaxAttempts == 1 && env.RetryPolicy().MaxRebroadcasts == 2, "retry policy should be taken from options of env")
+	t.MustTrue(GetRetryPolicy().MaxAttempts == DefaultRetryPolicy().MaxAttempts, "package helpers should use default retry policy")
+}
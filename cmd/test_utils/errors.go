@@ -0,0 +1,137 @@
+package inttest
+
+import (
+	"errors"
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// describes the classes of cli and rpc failures, use errors.Is to check class of an error
+var (
+	ErrNodeUnavailable   = errors.New("node is unavailable")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrSequenceMismatch  = errors.New("account sequence mismatch")
+	ErrRecipeNotFound    = errors.New("recipe not found")
+	ErrOutOfGas          = errors.New("out of gas")
+	ErrMempoolFull       = errors.New("mempool is full")
+	ErrUnauthorized      = errors.New("unauthorized")
+)
+
+// errorClass is a struct to describe how a failure class is detected from abci code or output
+type errorClass struct {
+	Class      error
+	ABCIError  *sdkerrors.Error // nil when the class has no abci code
+	Signatures []string         // lowercase substrings of output to match
+}
+
+var errorClasses = []errorClass{
+	{
+		Class:      ErrNodeUnavailable,
+		Signatures: []string{"connection refused", "no such host", "connection reset by peer", "i/o timeout", "post failed"},
+	},
+	{
+		Class:      ErrInsufficientFunds,
+		ABCIError:  sdkerrors.ErrInsufficientFunds,
+		Signatures: []string{"insufficient funds", "insufficient account funds"},
+	},
+	{
+		Class:      ErrSequenceMismatch,
+		ABCIError:  sdkerrors.ErrWrongSequence,
+		Signatures: []string{"account sequence mismatch", "incorrect account sequence"},
+	},
+	{
+		Class:      ErrRecipeNotFound,
+		Signatures: []string{"recipe doesn't exist", "recipe does not exist", "recipe not found"},
+	},
+	{
+		Class:      ErrOutOfGas,
+		ABCIError:  sdkerrors.ErrOutOfGas,
+		Signatures: []string{"out of gas"},
+	},
+	{
+		Class:      ErrMempoolFull,
+		ABCIError:  sdkerrors.ErrMempoolIsFull,
+		Signatures: []string{"mempool is full"},
+	},
+	{
+		Class:      ErrUnauthorized,
+		ABCIError:  sdkerrors.ErrUnauthorized,
+		Signatures: []string{"unauthorized"},
+	},
+}
+
+// ClassifyError is a function to get failure class from abci codespace, code and output
+// It returns nil when the failure does not belong to a known class
+func ClassifyError(codespace string, code uint32, output string) error {
+	for _, ec := range errorClasses {
+		if ec.ABCIError != nil && code != 0 && ec.ABCIError.Codespace() == codespace && ec.ABCIError.ABCICode() == code {
+			return ec.Class
+		}
+	}
+	message := strings.ToLower(output)
+	for _, ec := range errorClasses {
+		for _, signature := range ec.Signatures {
+			if strings.Contains(message, signature) {
+				return ec.Class
+			}
+		}
+	}
+	return nil
+}
+
+// ErrorClassByName is a function to get failure class by its message with spaces or underscores e.g. insufficient_funds
+func ErrorClassByName(name string) (error, bool) {
+	name = strings.ToLower(strings.Replace(name, "_", " ", -1))
+	for _, ec := range errorClasses {
+		if ec.Class.Error() == name {
+			return ec.Class, true
+		}
+	}
+	return nil, false
+}
+
+// CommandError is a struct to describe failure of pylonsd command or transaction with its failure class
+// errors.Is matches both the failure class and the underlying error
+type CommandError struct {
+	Class     error // nil when the failure does not belong to a known class
+	Codespace string
+	Code      uint32
+	Output    string // command output or raw log of transaction
+	Err       error
+}
+
+// NewCommandError is a function to wrap error of pylonsd command with failure class detected from its output
+func NewCommandError(err error, output string) *CommandError {
+	return &CommandError{
+		Class:  ClassifyError("", 0, output),
+		Output: output,
+		Err:    err,
+	}
+}
+
+// NewTxError is a function to get error of failed transaction with failure class detected from abci code and raw log
+func NewTxError(codespace string, code uint32, rawLog string) *CommandError {
+	return &CommandError{
+		Class:     ClassifyError(codespace, code, rawLog),
+		Codespace: codespace,
+		Code:      code,
+		Output:    rawLog,
+		Err:       errors.New(rawLog),
+	}
+}
+
+// Error is a function to get message of the underlying error
+func (e *CommandError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap is a function to get the underlying error
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// Is is a function to check if target is the failure class of the error
+func (e *CommandError) Is(target error) bool {
+	return e.Class != nil && e.Class == target
+}
@@ -0,0 +1,46 @@
+package inttest
+
+import (
+	"errors"
+	"fmt"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func TestErrorClasses(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	err := TxResult{}.Err()
+	t.MustNil(err, "successful transaction should not have error")
+
+	result := TxResult{}
+	result.Codespace = sdkerrors.ErrWrongSequence.Codespace()
+	result.Code = sdkerrors.ErrWrongSequence.ABCICode()
+	result.RawLog = "account sequence mismatch, expected 5, got 4"
+	err = fmt.Errorf("error sending transaction: %w", result.Err())
+	t.MustTrue(errors.Is(err, ErrSequenceMismatch), "sequence mismatch should be detected from abci code through wrapping")
+	t.MustTrue(!errors.Is(err, ErrInsufficientFunds), "error should not match other classes")
+	var cmdErr *CommandError
+	t.MustTrue(errors.As(err, &cmdErr) && cmdErr.Code == 32, "command error should keep abci code")
+	t.MustTrue(result.Err().Error() == result.RawLog, "message of transaction error should be raw log")
+
+	err = NewTxError(sdkerrors.ErrOutOfGas.Codespace(), sdkerrors.ErrOutOfGas.ABCICode(), "out of gas in location: WriteFlat")
+	t.MustTrue(errors.Is(err, ErrOutOfGas), "out of gas should be detected from abci code")
+
+	err = NewCommandError(errors.New("exit status 1"), "Error: post failed: Post \"http://localhost:26657\": dial tcp 127.0.0.1:26657: connect: connection refused")
+	t.MustTrue(errors.Is(err, ErrNodeUnavailable), "node unavailable should be detected from cli output")
+	t.MustTrue(err.Error() == "exit status 1", "message of command error should be underlying error")
+
+	t.MustTrue(ClassifyError("", 0, "The recipe doesn't exist") == ErrRecipeNotFound, "recipe not found should be detected from output")
+	t.MustTrue(ClassifyError("", 0, "0upylon is smaller than 100upylon: insufficient funds") == ErrInsufficientFunds, "insufficient funds should be detected from output")
+	t.MustTrue(!errors.Is(NewCommandError(errors.New("exit status 1"), "unknown failure"), ErrNodeUnavailable), "unknown failure should not have class")
+
+	class, ok := ErrorClassByName("insufficient_funds")
+	t.MustTrue(ok && class == ErrInsufficientFunds, "error class should be found by name with underscores")
+	class, ok = ErrorClassByName("Account sequence mismatch")
+	t.MustTrue(ok && class == ErrSequenceMismatch, "error class should be found by name with spaces")
+	_, ok = ErrorClassByName("invalid_recipe")
+	t.MustTrue(!ok, "unknown error class name should not be found")
+}
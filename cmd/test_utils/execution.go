@@ -0,0 +1,186 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/events"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// describes the status of decoded recipe execution
+const (
+	ExecutionPending   = "Pending"
+	ExecutionCompleted = "Completed"
+)
+
+// ExecutionResult is a struct to manage decoded recipe execution
+// Recipe outputs are the entries of the executed recipe, which are the outputs the execution can pay out, and
+// PaidCoins and PaidItemIDs are the outputs it paid out, decoded from output of the check execution which completed it.
+type ExecutionResult struct {
+	ExecID                  string
+	RecipeID                string
+	CookbookID              string
+	Sender                  string
+	Status                  string
+	Message                 string
+	BlockHeight             int64
+	ReadyHeight             int64 // block height from which the execution can be checked
+	RecipeCoinOutputs       []types.CoinOutput
+	RecipeItemOutputs       []types.ItemOutput
+	RecipeItemModifyOutputs []types.ItemModifyOutput
+	PaidCoins               sdk.Coins // empty until output is decoded, see DecodeOutput
+	PaidItemIDs             []string
+	ItemInputs              []types.Item // item inputs as they were when the recipe was executed, see DecodeExecutionItems
+}
+
+// DecodeOutput is a function to set coins and item IDs paid out by the execution from execute recipe or check execution output
+func (r *ExecutionResult) DecodeOutput(output []byte) error {
+	coins, itemIDs, err := DecodeExecutionOutput(output)
+	if err != nil {
+		return fmt.Errorf("error decoding output of execution %s: %w", r.ExecID, err)
+	}
+	r.PaidCoins, r.PaidItemIDs = coins, itemIDs
+	return nil
+}
+
+// DecodeExecutionOutput is a function to decode coins and item IDs paid out from execute recipe or check execution output
+func DecodeExecutionOutput(output []byte) (sdk.Coins, []string, error) {
+	coins := sdk.Coins{}
+	itemIDs := []string{}
+	var entries []types.ExecuteRecipeSerialize
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return coins, itemIDs, err
+	}
+	for _, entry := range entries {
+		switch entry.Type {
+		case "COIN":
+			coins = coins.Add(sdk.NewInt64Coin(entry.Coin, entry.Amount))
+		case "ITEM":
+			itemIDs = append(itemIDs, entry.ItemID)
+		}
+	}
+	return coins, itemIDs, nil
+}
+
+// DecodeExecution is a function to get execution and its recipe entries by execution ID
+func DecodeExecution(execID string) (ExecutionResult, error) {
+	exec, err := GetExecutionByGUID(execID)
+	if err != nil {
+		return ExecutionResult{}, fmt.Errorf("error getting execution %s: %w", execID, err)
+	}
+	rcp, err := GetRecipeByGUID(exec.RecipeID)
+	if err != nil {
+		return ExecutionResult{}, fmt.Errorf("error getting recipe %s: %w", exec.RecipeID, err)
+	}
+	return decodeExecution(types.Execution{
+		ID:          exec.ID,
+		RecipeID:    exec.RecipeID,
+		CookbookID:  exec.CookbookID,
+		CoinInputs:  exec.CoinsInput,
+		ItemInputs:  exec.ItemInputs,
+		BlockHeight: exec.BlockHeight,
+		Sender:      exec.Sender,
+		Completed:   exec.Completed,
+	}, rcp), nil
+}
+
+// decodeExecution is a function to decode execution with entries and block interval of its recipe
+func decodeExecution(exec types.Execution, rcp types.Recipe) ExecutionResult {
+	result := ExecutionResult{
+		ExecID:                  exec.ID,
+		RecipeID:                exec.RecipeID,
+		CookbookID:              rcp.CookbookID,
+		Sender:                  exec.Sender,
+		BlockHeight:             exec.BlockHeight,
+		ReadyHeight:             exec.BlockHeight + rcp.BlockInterval,
+		RecipeCoinOutputs:       rcp.Entries.CoinOutputs,
+		RecipeItemOutputs:       rcp.Entries.ItemOutputs,
+		RecipeItemModifyOutputs: rcp.Entries.ItemModifyOutputs,
+		ItemInputs:              exec.ItemInputs,
+	}
+	if exec.Completed {
+		result.Status = ExecutionCompleted
+		result.Message = "execution is completed"
+	} else {
+		result.Status = ExecutionPending
+		result.Message = fmt.Sprintf("execution is pending until block %d", result.ReadyHeight)
+	}
+	return result
+}
+
+// executionCheckedQuery is a function to get tx search query of the check execution which completed execution
+func executionCheckedQuery(execID string) string {
+	return fmt.Sprintf("%s.%s='%s'", events.EventTypeExecutionChecked, events.AttributeKeyExecID, execID)
+}
+
+// getExecutionOutput is a function to get output of the check execution which completed execution by searching transactions
+func getExecutionOutput(ctx context.Context, rpc historyRPC, execID string) ([]byte, error) {
+	page, limit := 1, 1
+	res, err := rpc.TxSearch(ctx, executionCheckedQuery(execID), false, &page, &limit, "asc")
+	if err != nil {
+		return nil, fmt.Errorf("error searching check execution of %s: %w", execID, err)
+	}
+	if len(res.Txs) == 0 {
+		return nil, fmt.Errorf("no check execution of %s is found", execID)
+	}
+	tx := res.Txs[0]
+	historyTx := decodeHistoryTx(tx.Height, int(tx.Index), tx.Tx, tx.TxResult)
+	txMsgData := sdk.TxMsgData{}
+	if err := proto.Unmarshal(tx.TxResult.Data, &txMsgData); err != nil {
+		return nil, fmt.Errorf("error decoding result of check execution %s: %w", historyTx.TxHash, err)
+	}
+	for idx, msg := range historyTx.Msgs {
+		chkExecMsg, ok := msg.(*types.MsgCheckExecution)
+		if !ok || chkExecMsg.ExecID != execID || idx >= len(txMsgData.Data) {
+			continue
+		}
+		resp := types.MsgCheckExecutionResponse{}
+		if err := proto.Unmarshal(txMsgData.Data[idx].Data, &resp); err != nil {
+			return nil, fmt.Errorf("error decoding response of check execution %s: %w", historyTx.TxHash, err)
+		}
+		return resp.Output, nil
+	}
+	return nil, fmt.Errorf("check execution of %s is not found in transaction %s", execID, historyTx.TxHash)
+}
+
+// WaitForExecutionAndDecode is a function to wait for execution to be completed for maximum wait block and decode it
+// Status is ExecutionPending when the execution is not completed after waiting, and paid outputs of a completed execution
+// are decoded from output of the check execution which completed it.
+func WaitForExecutionAndDecode(execID string, maxWaitBlock int64, t *testing.T) (ExecutionResult, error) {
+	ctx := context.Background()
+	waited, err := waitBlocks(ctx, UntilExecutionCompleted(execID), "execution "+execID, maxWaitBlock)
+	if err != nil && !errors.Is(err, ErrWaitTimeout) {
+		return ExecutionResult{}, err
+	}
+	result, err := DecodeExecution(execID)
+	if err != nil {
+		return result, err
+	}
+	if result.Status == ExecutionCompleted {
+		rpc, err := newHistoryRPC()
+		if err != nil {
+			return result, err
+		}
+		output, err := getExecutionOutput(ctx, rpc, execID)
+		if err != nil {
+			return result, err
+		}
+		if err := result.DecodeOutput(output); err != nil {
+			return result, err
+		}
+	}
+	t.WithFields(waited.Fields()).WithFields(testing.Fields{
+		"exec_id":       execID,
+		"status":        result.Status,
+		"ready_height":  result.ReadyHeight,
+		"paid_coins":    result.PaidCoins.String(),
+		"paid_item_ids": result.PaidItemIDs,
+	}).Debug("decoded execution")
+	return result, nil
+}
@@ -0,0 +1,134 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// DefaultExecutionGraceBlocks is the number of blocks an execution can stay unchecked after it's ready before it's stuck
+const DefaultExecutionGraceBlocks int64 = 5
+
+// ExecutionAuditState describes the state an execution is classified into by AuditExecutions
+type ExecutionAuditState string
+
+// describes the states of audited executions
+const (
+	ExecutionAuditCompleted ExecutionAuditState = "completed"
+	ExecutionAuditPending   ExecutionAuditState = "pending" // not ready yet, or ready for less than grace blocks
+	ExecutionAuditStuck     ExecutionAuditState = "stuck"   // ready for grace blocks or more, but nobody checked it
+)
+
+// AuditedExecution is a struct to describe an execution with its audit state
+type AuditedExecution struct {
+	ExecutionResult
+	State ExecutionAuditState
+	// BlocksOverdue is the number of blocks since the execution is ready, 0 when it's completed or not ready
+	BlocksOverdue int64
+}
+
+// ExecutionAudit is a struct to describe executions of accounts scheduled since a height
+type ExecutionAudit struct {
+	Height      int64
+	SinceHeight int64
+	Executions  []AuditedExecution
+}
+
+// ClassifyExecution is a function to get audit state and blocks overdue of execution at block height
+func ClassifyExecution(exec ExecutionResult, height int64, graceBlocks int64) (ExecutionAuditState, int64) {
+	if exec.Status == ExecutionCompleted {
+		return ExecutionAuditCompleted, 0
+	}
+	if height <= exec.ReadyHeight {
+		return ExecutionAuditPending, 0
+	}
+	overdue := height - exec.ReadyHeight
+	if overdue >= graceBlocks {
+		return ExecutionAuditStuck, overdue
+	}
+	return ExecutionAuditPending, overdue
+}
+
+// Count is a function to get the number of executions in state
+func (a ExecutionAudit) Count(state ExecutionAuditState) int {
+	count := 0
+	for _, exec := range a.Executions {
+		if exec.State == state {
+			count++
+		}
+	}
+	return count
+}
+
+// Leaks is a function to get executions which are not completed, they're scheduled by scenarios which never check them
+func (a ExecutionAudit) Leaks() []AuditedExecution {
+	leaks := []AuditedExecution{}
+	for _, exec := range a.Executions {
+		if exec.State != ExecutionAuditCompleted {
+			leaks = append(leaks, exec)
+		}
+	}
+	return leaks
+}
+
+// String is a function to get counts of audit states and leaked executions
+func (a ExecutionAudit) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "executions since block %d at block %d: completed=%d pending=%d stuck=%d",
+		a.SinceHeight, a.Height, a.Count(ExecutionAuditCompleted), a.Count(ExecutionAuditPending), a.Count(ExecutionAuditStuck))
+	for _, exec := range a.Leaks() {
+		fmt.Fprintf(&sb, "\n  %s execution %s of recipe %s by %s scheduled at block %d, ready at block %d",
+			exec.State, exec.ExecID, exec.RecipeID, exec.Sender, exec.BlockHeight, exec.ReadyHeight)
+	}
+	return sb.String()
+}
+
+// AuditExecutions is a function to classify executions of addrs scheduled at sinceHeight or later
+// Executions ready for graceBlocks or more without being checked are stuck, DefaultExecutionGraceBlocks is used when it's 0.
+func AuditExecutions(ctx context.Context, addrs []string, sinceHeight int64, graceBlocks int64) (ExecutionAudit, error) {
+	audit := ExecutionAudit{SinceHeight: sinceHeight, Executions: []AuditedExecution{}}
+	if graceBlocks <= 0 {
+		graceBlocks = DefaultExecutionGraceBlocks
+	}
+	transport, err := GetTransport()
+	if err != nil {
+		return audit, err
+	}
+	if audit.Height, err = transport.LatestHeight(ctx); err != nil {
+		return audit, fmt.Errorf("error getting latest height: %w", err)
+	}
+	recipes := map[string]types.Recipe{}
+	for _, addr := range addrs {
+		execs, err := transport.ListExecutions(ctx, addr)
+		if err != nil {
+			return audit, fmt.Errorf("error listing executions of %s: %w", addr, err)
+		}
+		for _, exec := range execs {
+			if exec.Sender != addr || exec.BlockHeight < sinceHeight {
+				continue
+			}
+			rcp, ok := recipes[exec.RecipeID]
+			if !ok {
+				if rcp, err = GetRecipeByGUID(exec.RecipeID); err != nil {
+					return audit, fmt.Errorf("error getting recipe %s: %w", exec.RecipeID, err)
+				}
+				recipes[exec.RecipeID] = rcp
+			}
+			result := decodeExecution(exec, rcp)
+			state, overdue := ClassifyExecution(result, audit.Height, graceBlocks)
+			audit.Executions = append(audit.Executions, AuditedExecution{ExecutionResult: result, State: state, BlocksOverdue: overdue})
+		}
+	}
+	// same order as the execution queue, by ready height and by id
+	sort.SliceStable(audit.Executions, func(i, j int) bool {
+		a, b := audit.Executions[i], audit.Executions[j]
+		if a.ReadyHeight != b.ReadyHeight {
+			return a.ReadyHeight < b.ReadyHeight
+		}
+		return a.ExecID < b.ExecID
+	})
+	return audit, nil
+}
@@ -0,0 +1,45 @@
+package inttest
+
+import (
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestClassifyExecution(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	for _, tc := range []struct {
+		desc     string
+		exec     ExecutionResult
+		height   int64
+		expected ExecutionAuditState
+		overdue  int64
+	}{
+		{"completed", ExecutionResult{Status: ExecutionCompleted, ReadyHeight: 10}, 100, ExecutionAuditCompleted, 0},
+		{"not ready", ExecutionResult{Status: ExecutionPending, ReadyHeight: 10}, 8, ExecutionAuditPending, 0},
+		{"ready within grace", ExecutionResult{Status: ExecutionPending, ReadyHeight: 10}, 14, ExecutionAuditPending, 4},
+		{"ready beyond grace", ExecutionResult{Status: ExecutionPending, ReadyHeight: 10}, 15, ExecutionAuditStuck, 5},
+	} {
+		state, overdue := ClassifyExecution(tc.exec, tc.height, DefaultExecutionGraceBlocks)
+		t.WithFields(testing.Fields{
+			"desc":    tc.desc,
+			"state":   state,
+			"overdue": overdue,
+		}).MustTrue(state == tc.expected && overdue == tc.overdue, "execution should be classified by ready height and grace blocks")
+	}
+
+	audit := ExecutionAudit{Height: 30, SinceHeight: 10, Executions: []AuditedExecution{
+		{ExecutionResult: ExecutionResult{ExecID: "exec1", Status: ExecutionCompleted}, State: ExecutionAuditCompleted},
+		{ExecutionResult: ExecutionResult{ExecID: "exec2", RecipeID: "rcp1", Sender: "cosmos1abc", BlockHeight: 12, ReadyHeight: 22}, State: ExecutionAuditStuck, BlocksOverdue: 8},
+		{ExecutionResult: ExecutionResult{ExecID: "exec3", ReadyHeight: 40}, State: ExecutionAuditPending},
+	}}
+	leaks := audit.Leaks()
+	t.MustTrue(len(leaks) == 2 && leaks[0].ExecID == "exec2" && leaks[1].ExecID == "exec3", "executions not completed should be leaks")
+	t.MustTrue(audit.Count(ExecutionAuditStuck) == 1, "stuck executions should be counted")
+	report := audit.String()
+	t.MustContain(report, "completed=1 pending=1 stuck=1")
+	t.MustTrue(!strings.Contains(report, "exec1"), "completed executions should not be reported as leaks")
+	t.MustContain(report, "stuck execution exec2 of recipe rcp1 by cosmos1abc scheduled at block 12, ready at block 22")
+}
@@ -0,0 +1,185 @@
+package inttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RandomValue is the value recorded into execution snapshot for coin amounts and attributes decided randomly by recipe
+const RandomValue = "<random>"
+
+// ExecutionSnapshot is a struct to manage decoded output of recipe execution which is stored as golden file
+// Execution specific fields like IDs, sender and block heights are left out, so the same recipe gets the same snapshot.
+type ExecutionSnapshot struct {
+	Recipe string                  `json:"recipe"`
+	Coins  map[string]string       `json:"coins"`
+	Items  []ExecutionSnapshotItem `json:"items"`
+}
+
+// ExecutionSnapshotItem is a struct to manage attributes of item paid out by recipe execution
+type ExecutionSnapshotItem struct {
+	Doubles     map[string]string `json:"doubles"`
+	Longs       map[string]string `json:"longs"`
+	Strings     map[string]string `json:"strings"`
+	Tradable    bool              `json:"tradable"`
+	TransferFee int64             `json:"transferFee"`
+}
+
+// randomParams is a struct to manage attribute keys of recipe outputs whose values or presence are random
+type randomParams struct {
+	values   map[string]bool
+	presence map[string]bool
+}
+
+// isRandomProgram is a function to check program calls random functions like rand_int
+func isRandomProgram(program string) bool {
+	return strings.Contains(program, "rand")
+}
+
+// recipeRandomParams is a function to collect attribute keys of item outputs and item modify outputs which are random
+// A key is random in every output of the recipe once an entry decides it randomly, as items don't refer their entries.
+func recipeRandomParams(rcp types.Recipe) randomParams {
+	random := randomParams{values: map[string]bool{}, presence: map[string]bool{}}
+	addParams := func(doubles types.DoubleParamList, longs types.LongParamList, strs types.StringParamList) {
+		for _, param := range doubles {
+			random.presence["d:"+param.Key] = random.presence["d:"+param.Key] || param.Rate.LT(sdk.OneDec())
+			random.values["d:"+param.Key] = random.values["d:"+param.Key] || isRandomProgram(param.Program) ||
+				(param.Program == "" && (len(param.WeightRanges) > 1 || (len(param.WeightRanges) == 1 && !param.WeightRanges[0].Lower.Equal(param.WeightRanges[0].Upper))))
+		}
+		for _, param := range longs {
+			random.presence["l:"+param.Key] = random.presence["l:"+param.Key] || param.Rate.LT(sdk.OneDec())
+			random.values["l:"+param.Key] = random.values["l:"+param.Key] || isRandomProgram(param.Program) ||
+				(param.Program == "" && (len(param.WeightRanges) > 1 || (len(param.WeightRanges) == 1 && param.WeightRanges[0].Lower != param.WeightRanges[0].Upper)))
+		}
+		for _, param := range strs {
+			random.presence["s:"+param.Key] = random.presence["s:"+param.Key] || param.Rate.LT(sdk.OneDec())
+			random.values["s:"+param.Key] = random.values["s:"+param.Key] || isRandomProgram(param.Program)
+		}
+	}
+	for _, entry := range rcp.Entries.ItemOutputs {
+		addParams(entry.Doubles, entry.Longs, entry.Strings)
+	}
+	for _, entry := range rcp.Entries.ItemModifyOutputs {
+		addParams(entry.Doubles, entry.Longs, entry.Strings)
+	}
+	return random
+}
+
+// snapshotValue is a function to get value of attribute for snapshot, ok is false when presence of the attribute is random
+func (random randomParams) snapshotValue(key, value string) (string, bool) {
+	if random.presence[key] {
+		return "", false
+	}
+	if random.values[key] {
+		return RandomValue, true
+	}
+	return value, true
+}
+
+// NewExecutionSnapshot is a function to create snapshot of execution output by the recipe and the items paid out
+// Coin amounts and attributes decided by random programs or weight ranges are recorded as RandomValue, and attributes
+// applied by rate lower than 1 are left out. Recipes choosing outputs by weights should be snapshotted only when
+// the weights leave a single choice, otherwise the snapshot changes from execution to execution.
+func NewExecutionSnapshot(rcp types.Recipe, output []byte, items []types.Item) (ExecutionSnapshot, error) {
+	coins, _, err := DecodeExecutionOutput(output)
+	if err != nil {
+		return ExecutionSnapshot{}, err
+	}
+	randomCoins := map[string]bool{}
+	for _, entry := range rcp.Entries.CoinOutputs {
+		randomCoins[entry.Coin] = randomCoins[entry.Coin] || isRandomProgram(entry.Count)
+	}
+	snapshot := ExecutionSnapshot{
+		Recipe: rcp.Name,
+		Coins:  map[string]string{},
+		Items:  []ExecutionSnapshotItem{},
+	}
+	for _, coin := range coins {
+		if randomCoins[coin.Denom] {
+			snapshot.Coins[coin.Denom] = RandomValue
+		} else {
+			snapshot.Coins[coin.Denom] = coin.Amount.String()
+		}
+	}
+	random := recipeRandomParams(rcp)
+	for _, item := range items {
+		snapshotItem := ExecutionSnapshotItem{
+			Doubles:     map[string]string{},
+			Longs:       map[string]string{},
+			Strings:     map[string]string{},
+			Tradable:    item.Tradable,
+			TransferFee: item.TransferFee,
+		}
+		for _, kv := range item.Doubles {
+			if value, ok := random.snapshotValue("d:"+kv.Key, kv.Value.String()); ok {
+				snapshotItem.Doubles[kv.Key] = value
+			}
+		}
+		for _, kv := range item.Longs {
+			if value, ok := random.snapshotValue("l:"+kv.Key, fmt.Sprintf("%d", kv.Value)); ok {
+				snapshotItem.Longs[kv.Key] = value
+			}
+		}
+		for _, kv := range item.Strings {
+			if value, ok := random.snapshotValue("s:"+kv.Key, kv.Value); ok {
+				snapshotItem.Strings[kv.Key] = value
+			}
+		}
+		snapshot.Items = append(snapshot.Items, snapshotItem)
+	}
+	// items are sorted by their attributes as item IDs paid out don't keep the order of recipe outputs
+	sort.SliceStable(snapshot.Items, func(i, j int) bool {
+		return string(snapshot.Items[i].Golden()) < string(snapshot.Items[j].Golden())
+	})
+	return snapshot, nil
+}
+
+// Golden is a function to encode item snapshot as it's written into golden file
+func (item ExecutionSnapshotItem) Golden() []byte {
+	bz, _ := json.Marshal(item)
+	return bz
+}
+
+// Golden is a function to encode snapshot as golden file content, maps are encoded with sorted keys
+func (snapshot ExecutionSnapshot) Golden() []byte {
+	bz, _ := json.MarshalIndent(snapshot, "", "  ")
+	return append(bz, '\n')
+}
+
+// SnapshotExecution is a function to create snapshot of execute recipe or check execution output by querying the recipe and the items
+func SnapshotExecution(recipeID string, output []byte, opts ...QueryOption) (ExecutionSnapshot, error) {
+	rcp, err := GetRecipeByGUID(recipeID, opts...)
+	if err != nil {
+		return ExecutionSnapshot{}, fmt.Errorf("error getting recipe %s: %w", recipeID, err)
+	}
+	_, itemIDs, err := DecodeExecutionOutput(output)
+	if err != nil {
+		return ExecutionSnapshot{}, err
+	}
+	items := []types.Item{}
+	for _, itemID := range itemIDs {
+		item, err := GetItemByGUID(itemID, opts...)
+		if err != nil {
+			return ExecutionSnapshot{}, fmt.Errorf("error getting item %s: %w", itemID, err)
+		}
+		items = append(items, item)
+	}
+	return NewExecutionSnapshot(rcp, output, items)
+}
+
+// MustMatchExecutionGolden is a function to fail the test when snapshot of execution output differs from golden file
+// Golden file is written instead when UpdateGoldens is set by -update-goldens flag.
+func MustMatchExecutionGolden(t *testing.T, goldenPath, recipeID string, output []byte) {
+	snapshot, err := SnapshotExecution(recipeID, output)
+	t.WithFields(testing.Fields{
+		"recipe_id": recipeID,
+		"output":    string(output),
+	}).MustNil(err, "error creating snapshot of execution output")
+	MustMatchGolden(t, goldenPath, snapshot.Golden())
+}
@@ -0,0 +1,72 @@
+package inttest
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestExecutionSnapshot(originT *originT.T) {
+	t := testing.NewT(originT)
+	rcp := types.Recipe{
+		Name: "Lucky Sword",
+		Entries: types.EntriesList{
+			CoinOutputs: []types.CoinOutput{
+				{ID: "gold", Coin: "goldcoin", Count: "rand_int(10)+1"},
+				{ID: "silver", Coin: "silvercoin", Count: "5"},
+			},
+			ItemOutputs: []types.ItemOutput{{
+				ID: "sword",
+				Doubles: []types.DoubleParam{
+					{Key: "attack", Rate: sdk.OneDec(), WeightRanges: []types.DoubleWeightRange{{Lower: sdk.NewDec(1), Upper: sdk.NewDec(3), Weight: 1}}},
+					{Key: "speed", Rate: sdk.OneDec(), WeightRanges: []types.DoubleWeightRange{{Lower: sdk.NewDec(2), Upper: sdk.NewDec(2), Weight: 1}}},
+				},
+				Longs: []types.LongParam{
+					{Key: "level", Rate: sdk.OneDec(), Program: "1"},
+					{Key: "bonus", Rate: sdk.NewDecWithPrec(5, 1), Program: "3"},
+				},
+				Strings: []types.StringParam{{Key: "Name", Rate: sdk.OneDec(), Value: "Sword"}},
+			}},
+		},
+	}
+	output := []byte(`[{"Type":"COIN","Coin":"goldcoin","Amount":7},{"Type":"COIN","Coin":"silvercoin","Amount":5},{"Type":"ITEM","ItemID":"item001"}]`)
+	item := types.Item{
+		ID:         "item001",
+		Sender:     "cosmos1sender",
+		LastUpdate: 42,
+		Doubles:    []types.DoubleKeyValue{{Key: "attack", Value: sdk.MustNewDecFromStr("2.5")}, {Key: "speed", Value: sdk.NewDec(2)}},
+		Longs:      []types.LongKeyValue{{Key: "level", Value: 1}, {Key: "bonus", Value: 3}},
+		Strings:    []types.StringKeyValue{{Key: "Name", Value: "Sword"}},
+		Tradable:   true,
+	}
+	snapshot, err := NewExecutionSnapshot(rcp, output, []types.Item{item})
+	t.MustNil(err, "error creating execution snapshot")
+	golden := string(snapshot.Golden())
+	t.WithFields(testing.Fields{
+		"golden": golden,
+	}).MustTrue(snapshot.Coins["goldcoin"] == RandomValue && snapshot.Coins["silvercoin"] == "5", "random coin amounts should be masked")
+	t.WithFields(testing.Fields{
+		"golden": golden,
+	}).MustTrue(len(snapshot.Items) == 1 && snapshot.Items[0].Doubles["attack"] == RandomValue && snapshot.Items[0].Doubles["speed"] == "2.000000000000000000" &&
+		snapshot.Items[0].Longs["level"] == "1" && snapshot.Items[0].Strings["Name"] == "Sword", "random attributes should be masked")
+	_, hasBonus := snapshot.Items[0].Longs["bonus"]
+	t.MustTrue(!hasBonus, "attributes applied by rate should be left out")
+	t.MustTrue(!strings.Contains(golden, "item001") && !strings.Contains(golden, "cosmos1sender"), "execution specific fields should be left out")
+
+	goldenPath := filepath.Join(originT.TempDir(), "lucky_sword.json")
+	UpdateGoldens = true
+	err = CompareGolden(goldenPath, snapshot.Golden())
+	UpdateGoldens = false
+	t.MustNil(err, "error writing golden")
+	item.Doubles[0].Value = sdk.NewDec(1)
+	snapshot, _ = NewExecutionSnapshot(rcp, output, []types.Item{item})
+	t.MustNil(CompareGolden(goldenPath, snapshot.Golden()), "random attribute change should match golden")
+	item.Longs[0].Value = 2
+	snapshot, _ = NewExecutionSnapshot(rcp, output, []types.Item{item})
+	t.MustTrue(errors.Is(CompareGolden(goldenPath, snapshot.Golden()), ErrGoldenMismatch), "deterministic attribute change should differ from golden")
+}
@@ -0,0 +1,74 @@
+package inttest
+
+import (
+	"fmt"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// ModifiedItem is a struct to describe an input item modified in place by recipe execution with its attribute changes
+type ModifiedItem struct {
+	ItemID  string
+	Before  types.Item
+	After   types.Item
+	Changes []ItemAttributeChange
+}
+
+// ChangedKeys is a function to get sorted keys of attributes changed by execution
+func (m ModifiedItem) ChangedKeys() []string {
+	keys := []string{}
+	for _, change := range m.Changes {
+		keys = append(keys, change.Key)
+	}
+	return keys
+}
+
+// ExecutionItems is a struct to describe items paid out by recipe execution
+// Execution output lists IDs of both kinds of items, they're told apart by IDs of the execution's item inputs.
+type ExecutionItems struct {
+	// NewItems are items minted by item outputs of the recipe
+	NewItems []types.Item
+	// ModifiedItems are input items kept with their IDs by item modify outputs of the recipe
+	ModifiedItems []ModifiedItem
+}
+
+// ClassifyExecutionItems is a function to split items of execution output into new items and input items modified in place
+// inputs are the item inputs as they were before execution, getItem gets the items after execution.
+func ClassifyExecutionItems(inputs []types.Item, outputItemIDs []string, getItem func(string) (types.Item, error)) (ExecutionItems, error) {
+	result := ExecutionItems{NewItems: []types.Item{}, ModifiedItems: []ModifiedItem{}}
+	inputByID := map[string]types.Item{}
+	for _, input := range inputs {
+		inputByID[input.ID] = input
+	}
+	for _, itemID := range outputItemIDs {
+		after, err := getItem(itemID)
+		if err != nil {
+			return result, fmt.Errorf("error getting output item %s: %w", itemID, err)
+		}
+		before, ok := inputByID[itemID]
+		if !ok {
+			result.NewItems = append(result.NewItems, after)
+			continue
+		}
+		result.ModifiedItems = append(result.ModifiedItems, ModifiedItem{
+			ItemID:  itemID,
+			Before:  before,
+			After:   after,
+			Changes: ItemAttributeChanges(before, after),
+		})
+	}
+	return result, nil
+}
+
+// DecodeExecutionItems is a function to classify items of execute recipe or check execution output
+// inputs should be queried before a straight execution, as modified items are updated by the execution,
+// ExecutionResult.ItemInputs keeps them for scheduled executions.
+func DecodeExecutionItems(inputs []types.Item, output []byte) (ExecutionItems, error) {
+	_, itemIDs, err := DecodeExecutionOutput(output)
+	if err != nil {
+		return ExecutionItems{}, fmt.Errorf("error decoding execution output: %w", err)
+	}
+	return ClassifyExecutionItems(inputs, itemIDs, func(itemID string) (types.Item, error) {
+		return GetItemByGUID(itemID)
+	})
+}
@@ -0,0 +1,52 @@
+package inttest
+
+import (
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestClassifyExecutionItems(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	before := types.Item{
+		ID:      "sword1",
+		Doubles: []types.DoubleKeyValue{{Key: "attack", Value: sdk.NewDec(3)}},
+		Longs:   []types.LongKeyValue{{Key: "level", Value: 1}},
+		Strings: []types.StringKeyValue{{Key: "Name", Value: "Wooden sword"}},
+	}
+	after := before
+	after.Doubles = []types.DoubleKeyValue{{Key: "attack", Value: sdk.NewDec(6)}}
+	after.Longs = []types.LongKeyValue{{Key: "level", Value: 2}}
+	minted := types.Item{ID: "shield1", Strings: []types.StringKeyValue{{Key: "Name", Value: "Shield"}}}
+	unchanged := types.Item{ID: "coin1"}
+
+	items := map[string]types.Item{"sword1": after, "shield1": minted, "coin1": unchanged}
+	getItem := func(itemID string) (types.Item, error) {
+		item, ok := items[itemID]
+		if !ok {
+			return item, errors.New("item does not exist")
+		}
+		return item, nil
+	}
+	result, err := ClassifyExecutionItems([]types.Item{before, unchanged}, []string{"shield1", "sword1", "coin1"}, getItem)
+	t.MustNil(err, "error classifying execution items")
+	t.WithFields(testing.Fields{
+		"result": result,
+	}).MustTrue(len(result.NewItems) == 1 && result.NewItems[0].ID == "shield1", "item not in inputs should be new")
+	t.MustTrue(len(result.ModifiedItems) == 2 && result.ModifiedItems[0].ItemID == "sword1", "input items of output should be modified")
+
+	sword := result.ModifiedItems[0]
+	t.WithFields(testing.Fields{
+		"changes": sword.Changes,
+	}).MustTrue(len(sword.Changes) == 2 && sword.Changes[0].Key == "attack" && sword.Changes[0].Before == "3.000000000000000000" && sword.Changes[1].After == "2",
+		"changes should have before and after values sorted by key")
+	t.MustTrue(len(sword.ChangedKeys()) == 2 && sword.ChangedKeys()[1] == "level", "changed keys should be listed")
+	t.MustTrue(len(result.ModifiedItems[1].Changes) == 0, "item kept as it was should have no changes")
+
+	_, err = ClassifyExecutionItems(nil, []string{"burnt1"}, getItem)
+	t.MustTrue(err != nil, "missing output item should be an error")
+}
@@ -0,0 +1,109 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// ExecutionETA is a struct to describe when pending execution can be checked
+// TimeRemaining is estimated from average block time observed so far and is 0 when no block interval is observed yet.
+type ExecutionETA struct {
+	ExecID          string
+	Status          string
+	CurrentHeight   int64
+	ReadyHeight     int64
+	BlocksRemaining int64
+	TimeRemaining   time.Duration
+}
+
+// Ready is a function to check if execution can be checked at current height
+func (eta ExecutionETA) Ready() bool {
+	return eta.BlocksRemaining == 0
+}
+
+// String is a function to get time remaining description of execution e.g. for a progress message
+func (eta ExecutionETA) String() string {
+	if eta.Status == ExecutionCompleted {
+		return fmt.Sprintf("execution %s is completed", eta.ExecID)
+	}
+	if eta.Ready() {
+		return fmt.Sprintf("execution %s is ready since block %d", eta.ExecID, eta.ReadyHeight)
+	}
+	if eta.TimeRemaining == 0 {
+		return fmt.Sprintf("execution %s is ready in %d blocks", eta.ExecID, eta.BlocksRemaining)
+	}
+	return fmt.Sprintf("execution %s is ready in %d blocks (about %s)", eta.ExecID, eta.BlocksRemaining, eta.TimeRemaining.Round(time.Second))
+}
+
+// ComputeExecutionETA is a function to get blocks and time remaining until execution is ready at block height
+func ComputeExecutionETA(exec ExecutionResult, height int64, avgBlockTime time.Duration) ExecutionETA {
+	eta := ExecutionETA{
+		ExecID:        exec.ExecID,
+		Status:        exec.Status,
+		CurrentHeight: height,
+		ReadyHeight:   exec.ReadyHeight,
+	}
+	if exec.Status == ExecutionCompleted || exec.ReadyHeight <= height {
+		return eta
+	}
+	eta.BlocksRemaining = exec.ReadyHeight - height
+	eta.TimeRemaining = avgBlockTime * time.Duration(eta.BlocksRemaining)
+	return eta
+}
+
+// GetExecutionETA is a function to get blocks and time remaining until execution of execID is ready at latest block height
+func GetExecutionETA(execID string) (ExecutionETA, error) {
+	exec, err := DecodeExecution(execID)
+	if err != nil {
+		return ExecutionETA{ExecID: execID}, err
+	}
+	if _, _, err = queryDaemonStatus(context.Background()); err != nil {
+		return ExecutionETA{ExecID: execID}, err
+	}
+	return ComputeExecutionETA(exec, DefaultEnv().state().blocks.latestHeight(), GetAverageBlockTime()), nil
+}
+
+// ListPendingExecutions is a function to list executions of addr which are not completed, in order they become ready
+// Executions of all senders are listed when addr is empty.
+func ListPendingExecutions(addr string) ([]ExecutionResult, error) {
+	transport, err := GetTransport()
+	if err != nil {
+		return nil, err
+	}
+	execs, err := transport.ListExecutions(context.Background(), addr)
+	if err != nil {
+		return nil, fmt.Errorf("error listing executions of %s: %w", addr, err)
+	}
+	recipes := map[string]types.Recipe{}
+	pending := []ExecutionResult{}
+	for _, exec := range execs {
+		if exec.Completed || (len(addr) > 0 && exec.Sender != addr) {
+			continue
+		}
+		rcp, ok := recipes[exec.RecipeID]
+		if !ok {
+			rcp, err = GetRecipeByGUID(exec.RecipeID)
+			if err != nil {
+				return nil, fmt.Errorf("error getting recipe %s: %w", exec.RecipeID, err)
+			}
+			recipes[exec.RecipeID] = rcp
+		}
+		pending = append(pending, decodeExecution(exec, rcp))
+	}
+	sortExecutionQueue(pending)
+	return pending, nil
+}
+
+// sortExecutionQueue is a function to sort executions by ready height, executions ready at same height by id
+func sortExecutionQueue(execs []ExecutionResult) {
+	sort.SliceStable(execs, func(i, j int) bool {
+		if execs[i].ReadyHeight != execs[j].ReadyHeight {
+			return execs[i].ReadyHeight < execs[j].ReadyHeight
+		}
+		return execs[i].ExecID < execs[j].ExecID
+	})
+}
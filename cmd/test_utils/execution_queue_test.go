@@ -0,0 +1,36 @@
+package inttest
+
+import (
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+func TestExecutionQueue(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	rcp := types.Recipe{ID: "rcp1", CookbookID: "cb1", BlockInterval: 5}
+	execs := []ExecutionResult{
+		decodeExecution(types.Execution{ID: "exec2", RecipeID: "rcp1", BlockHeight: 12}, rcp),
+		decodeExecution(types.Execution{ID: "exec3", RecipeID: "rcp1", BlockHeight: 10}, rcp),
+		decodeExecution(types.Execution{ID: "exec1", RecipeID: "rcp1", BlockHeight: 10}, rcp),
+	}
+	sortExecutionQueue(execs)
+	t.WithFields(testing.Fields{
+		"queue": execs,
+	}).MustTrue(execs[0].ExecID == "exec1" && execs[1].ExecID == "exec3" && execs[2].ExecID == "exec2", "executions should be sorted by ready height and id")
+
+	eta := ComputeExecutionETA(execs[2], 14, 2*time.Second)
+	t.WithFields(testing.Fields{
+		"eta": eta.String(),
+	}).MustTrue(eta.ReadyHeight == 17 && eta.BlocksRemaining == 3 && eta.TimeRemaining == 6*time.Second, "remaining blocks and time should be computed from ready height")
+
+	eta = ComputeExecutionETA(execs[0], 20, 2*time.Second)
+	t.MustTrue(eta.Ready() && eta.TimeRemaining == 0, "execution past ready height should be ready")
+
+	completed := decodeExecution(types.Execution{ID: "exec4", RecipeID: "rcp1", BlockHeight: 12, Completed: true}, rcp)
+	eta = ComputeExecutionETA(completed, 14, 0)
+	t.MustTrue(eta.Ready() && eta.Status == ExecutionCompleted, "completed execution should have nothing remaining")
+}
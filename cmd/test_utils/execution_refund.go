@@ -0,0 +1,169 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ExecutionRefundCapability is the node capability of refunding inputs of executions which are not completed
+// Pylons nodes have no msg to abort a pending execution, so refunds are only checked against nodes having it.
+const ExecutionRefundCapability = "execution_refund"
+
+// ExecutionLocks is a struct to manage coins and items locked by an execution and spendable coins of its sender
+type ExecutionLocks struct {
+	ExecID string
+	Sender string
+	// Coins are the coins locked for the execution, empty when they are released
+	Coins sdk.Coins
+	// Items are item inputs of the execution found on chain, items which don't exist any more are left out
+	Items []types.Item
+	// Spendable is the balance of sender minus all coins locked for sender
+	Spendable sdk.Coins
+}
+
+// LockedItemIDs is a function to get IDs of item inputs which are still locked by a recipe
+func (l ExecutionLocks) LockedItemIDs() []string {
+	itemIDs := []string{}
+	for _, item := range l.Items {
+		if len(item.OwnerRecipeID) > 0 {
+			itemIDs = append(itemIDs, item.ID)
+		}
+	}
+	return itemIDs
+}
+
+// findItem is a function to get item input of id
+func (l ExecutionLocks) findItem(itemID string) (types.Item, bool) {
+	for _, item := range l.Items {
+		if item.ID == itemID {
+			return item, true
+		}
+	}
+	return types.Item{}, false
+}
+
+// ExecutionRefund is a struct to describe coins and items which should be given back to sender of an execution
+// Locked coins and items which are not refunded are consumed e.g. by a partial refund.
+type ExecutionRefund struct {
+	Coins   sdk.Coins
+	ItemIDs []string
+}
+
+// FullRefund is a function to get refund of all coins and items locked by execution
+func FullRefund(locks ExecutionLocks) ExecutionRefund {
+	return ExecutionRefund{Coins: locks.Coins, ItemIDs: locks.LockedItemIDs()}
+}
+
+// RefundTrigger is a function to make node stop a pending execution and refund its inputs
+type RefundTrigger func(ctx context.Context, t *testing.T, locks ExecutionLocks) error
+
+// CheckExecutionTrigger is a function to get refund trigger sending check execution of sender
+// The check execution transaction should succeed, refund is expected when the execution fails.
+func CheckExecutionTrigger(payToComplete bool) RefundTrigger {
+	return func(ctx context.Context, t *testing.T, locks ExecutionLocks) error {
+		chkExecMsg := types.NewMsgCheckExecution(locks.ExecID, payToComplete, locks.Sender)
+		if _, err := NewClient().SendTxAndWait(ctx, t, SignerAddress(locks.Sender), &chkExecMsg); err != nil {
+			return fmt.Errorf("error checking execution %s for refund: %w", locks.ExecID, err)
+		}
+		return nil
+	}
+}
+
+// GetExecutionLocks is a function to get coins and items locked by execution with spendable coins of its sender
+func GetExecutionLocks(execID string) (ExecutionLocks, error) {
+	exec, err := GetExecutionByGUID(execID)
+	if err != nil {
+		return ExecutionLocks{}, fmt.Errorf("error getting execution %s: %w", execID, err)
+	}
+	locks := ExecutionLocks{ExecID: execID, Sender: exec.Sender, Coins: sdk.Coins{}}
+	details, err := GetLockedCoinDetailsViaCLI(exec.Sender)
+	if err != nil {
+		return locks, err
+	}
+	for _, lc := range details.LockCoinExecs {
+		if lc.ID == execID {
+			locks.Coins = locks.Coins.Add(lc.Amount...)
+		}
+	}
+	for _, input := range exec.ItemInputs {
+		item, err := GetItemByGUID(input.ID)
+		if err != nil {
+			continue
+		}
+		locks.Items = append(locks.Items, item)
+	}
+	balance, err := getBalances(exec.Sender)
+	if err != nil {
+		return locks, err
+	}
+	var negative bool
+	if locks.Spendable, negative = balance.SafeSub(details.Amount); negative {
+		return locks, fmt.Errorf("locked coins %s of %s are more than balance %s", details.Amount, exec.Sender, balance)
+	}
+	return locks, nil
+}
+
+// CheckExecutionRefund is a function to check expected coins and items are given back to sender after execution is stopped
+// Coins are checked by spendable coins of sender, so no other transaction should touch its balance in between.
+func CheckExecutionRefund(before, after ExecutionLocks, expected ExecutionRefund) error {
+	if !before.Coins.IsAllGTE(expected.Coins) {
+		return fmt.Errorf("refund %s is more than %s locked by execution %s", expected.Coins, before.Coins, before.ExecID)
+	}
+	if !after.Coins.Empty() {
+		return fmt.Errorf("execution %s still locks %s", before.ExecID, after.Coins)
+	}
+	spendable := before.Spendable.Add(expected.Coins...)
+	if !(spendable.IsAllGTE(after.Spendable) && after.Spendable.IsAllGTE(spendable)) {
+		return fmt.Errorf("spendable coins of %s are %s after refund, expected %s refunded to %s",
+			before.Sender, after.Spendable, expected.Coins, before.Spendable)
+	}
+	lockedItemIDs := before.LockedItemIDs()
+	for _, itemID := range expected.ItemIDs {
+		if !Exists(lockedItemIDs, itemID) {
+			return fmt.Errorf("refunded item %s is not locked by execution %s", itemID, before.ExecID)
+		}
+	}
+	for _, itemID := range lockedItemIDs {
+		item, found := after.findItem(itemID)
+		refunded := found && item.Sender == before.Sender && len(item.OwnerRecipeID) == 0
+		switch {
+		case Exists(expected.ItemIDs, itemID) && !refunded:
+			return fmt.Errorf("item %s is not refunded to %s", itemID, before.Sender)
+		case !Exists(expected.ItemIDs, itemID) && refunded:
+			return fmt.Errorf("item %s is refunded to %s, it should be consumed", itemID, before.Sender)
+		case found && item.Sender == before.Sender && len(item.OwnerRecipeID) > 0:
+			return fmt.Errorf("item %s is still locked by recipe %s", itemID, item.OwnerRecipeID)
+		}
+	}
+	return nil
+}
+
+// RunExecutionRefund is a function to take locks of pending execution, trigger its refund and check expected refund
+// e.g. RunExecutionRefund(ctx, t, execID, CheckExecutionTrigger(false), FullRefund)
+func RunExecutionRefund(ctx context.Context, t *testing.T, execID string, trigger RefundTrigger, expected func(ExecutionLocks) ExecutionRefund) (ExecutionLocks, error) {
+	before, err := GetExecutionLocks(execID)
+	if err != nil {
+		return before, err
+	}
+	if err = trigger(ctx, t, before); err != nil {
+		return before, err
+	}
+	after, err := GetExecutionLocks(execID)
+	if err != nil {
+		return before, err
+	}
+	refund := expected(before)
+	if err = CheckExecutionRefund(before, after, refund); err != nil {
+		return before, err
+	}
+	t.WithFields(testing.Fields{
+		"exec_id":         execID,
+		"refund_coins":    refund.Coins.String(),
+		"refund_item_ids": refund.ItemIDs,
+	}).Info("verified execution refund")
+	return before, nil
+}
@@ -0,0 +1,70 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestCheckExecutionRefund(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	pylons := func(amount int64) sdk.Coins {
+		return sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, amount))
+	}
+	before := ExecutionLocks{
+		ExecID: "exec1",
+		Sender: "owner",
+		Coins:  pylons(100),
+		Items: []types.Item{
+			{ID: "item1", Sender: "owner", OwnerRecipeID: "recipe1"},
+			{ID: "item2", Sender: "owner", OwnerRecipeID: "recipe1"},
+		},
+		Spendable: pylons(50),
+	}
+	full := FullRefund(before)
+	t.MustTrue(full.Coins.IsEqual(pylons(100)) && len(full.ItemIDs) == 2, "full refund should have all locked coins and items")
+
+	refunded := ExecutionLocks{
+		ExecID: "exec1",
+		Sender: "owner",
+		Coins:  sdk.Coins{},
+		Items: []types.Item{
+			{ID: "item1", Sender: "owner"},
+			{ID: "item2", Sender: "owner"},
+		},
+		Spendable: pylons(150),
+	}
+	t.MustNil(CheckExecutionRefund(before, refunded, full), "full refund should be verified")
+
+	// partial refund gives back 40 pylons and item1, the rest is consumed
+	partial := ExecutionRefund{Coins: pylons(40), ItemIDs: []string{"item1"}}
+	partiallyRefunded := ExecutionLocks{
+		ExecID:    "exec1",
+		Sender:    "owner",
+		Coins:     sdk.Coins{},
+		Items:     []types.Item{{ID: "item1", Sender: "owner"}},
+		Spendable: pylons(90),
+	}
+	t.MustNil(CheckExecutionRefund(before, partiallyRefunded, partial), "partial refund should be verified")
+
+	for _, tc := range []struct {
+		name     string
+		after    ExecutionLocks
+		expected ExecutionRefund
+	}{
+		{"refund more than locked", refunded, ExecutionRefund{Coins: pylons(200)}},
+		{"coins still locked", ExecutionLocks{Coins: pylons(100), Items: refunded.Items, Spendable: pylons(150)}, full},
+		{"coins not refunded", ExecutionLocks{Items: refunded.Items, Spendable: pylons(50)}, full},
+		{"item not refunded", partiallyRefunded, ExecutionRefund{Coins: pylons(40), ItemIDs: []string{"item1", "item2"}}},
+		{"consumed item refunded", refunded, ExecutionRefund{Coins: pylons(100), ItemIDs: []string{"item1"}}},
+		{"item still locked", ExecutionLocks{Items: before.Items, Spendable: pylons(150)}, ExecutionRefund{Coins: pylons(100)}},
+		{"item not locked by execution", refunded, ExecutionRefund{Coins: pylons(100), ItemIDs: []string{"item1", "item2", "item3"}}},
+	} {
+		t.WithFields(testing.Fields{
+			"case": tc.name,
+		}).MustTrue(CheckExecutionRefund(before, tc.after, tc.expected) != nil, "wrong refund should fail")
+	}
+}
@@ -0,0 +1,68 @@
+package inttest
+
+import (
+	"context"
+	originT "testing"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestExecutionOutput(originT *originT.T) {
+	t := testing.NewT(originT)
+	sender := sdk.AccAddress([]byte("execution_sender____")).String()
+	outputs := map[string][]byte{
+		"exec001": []byte(`[{"type":"COIN","coin":"pylon","amount":100}]`),
+		"exec002": []byte(`[{"type":"ITEM","itemID":"item001"},{"type":"COIN","coin":"loudcoin","amount":5}]`),
+	}
+	msgs := []sdk.Msg{}
+	txMsgData := sdk.TxMsgData{}
+	for _, execID := range []string{"exec001", "exec002"} {
+		msg := types.NewMsgCheckExecution(execID, false, sender)
+		msgs = append(msgs, &msg)
+		resp, err := proto.Marshal(&types.MsgCheckExecutionResponse{Status: "Success", Output: outputs[execID]})
+		t.MustNil(err, "error encoding check execution response")
+		txMsgData.Data = append(txMsgData.Data, &sdk.MsgData{MsgType: msg.Type(), Data: resp})
+	}
+	txModel, err := GenTxWithOptions(msgs, TxOptions{})
+	t.MustNil(err, "error generating transaction")
+	txBytes, err := app.MakeEncodingConfig().TxConfig.TxEncoder()(txModel)
+	t.MustNil(err, "error encoding transaction")
+	data, err := proto.Marshal(&txMsgData)
+	t.MustNil(err, "error encoding transaction result data")
+	rpc := &fakeHistoryRPC{
+		blocks:  map[int64]tmtypes.Txs{12: {txBytes}},
+		results: map[int64][]*abci.ResponseDeliverTx{12: {{Code: 0, Data: data}}},
+	}
+
+	output, err := getExecutionOutput(context.Background(), rpc, "exec002")
+	t.MustNil(err, "error getting execution output")
+	t.MustTrue(rpc.query == "execution_checked.exec_id='exec002'", "check execution should be searched by exec ID of its event")
+	t.WithFields(testing.Fields{
+		"output": string(output),
+	}).MustTrue(string(output) == string(outputs["exec002"]), "output of check execution of the execution should be got")
+
+	rcp := types.Recipe{
+		ID:            "rcp001",
+		BlockInterval: 2,
+		Entries:       types.EntriesList{CoinOutputs: []types.CoinOutput{{ID: "coin", Coin: "loudcoin", Count: "5"}}},
+	}
+	result := decodeExecution(types.Execution{ID: "exec002", RecipeID: "rcp001", BlockHeight: 10, Completed: true}, rcp)
+	t.MustTrue(len(result.RecipeCoinOutputs) == 1 && result.PaidCoins.Empty() && len(result.PaidItemIDs) == 0,
+		"recipe outputs should be decoded from recipe and paid outputs should be empty before output is decoded")
+	t.MustNil(result.DecodeOutput(output), "error decoding execution output")
+	t.WithFields(testing.Fields{
+		"paid_coins":    result.PaidCoins.String(),
+		"paid_item_ids": result.PaidItemIDs,
+	}).MustTrue(result.PaidCoins.AmountOf("loudcoin").Int64() == 5 && len(result.PaidItemIDs) == 1 && result.PaidItemIDs[0] == "item001",
+		"paid outputs should be decoded from output")
+	t.MustTrue(result.DecodeOutput([]byte(`{"ExecID":"exec002"}`)) != nil, "scheduled execution output should not be decoded as paid outputs")
+
+	_, err = getExecutionOutput(context.Background(), &fakeHistoryRPC{}, "exec003")
+	t.MustTrue(err != nil, "execution which is not checked should not have output")
+}
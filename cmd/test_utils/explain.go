@@ -0,0 +1,64 @@
+package inttest
+
+import (
+	"fmt"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// ErrorExplanation is a struct to manage human readable explanation of a common chain failure
+type ErrorExplanation struct {
+	Signatures   []string // lowercase substrings of error message to match
+	Explanation  string
+	SuggestedFix string
+}
+
+var errorExplanations = []ErrorExplanation{
+	{
+		Signatures:   []string{"insufficient fee"},
+		Explanation:  "transaction fee is lower than the minimum gas price of the node",
+		SuggestedFix: "set --fees on the transaction or lower minimum-gas-prices in app.toml of the node",
+	},
+	{
+		Signatures:   []string{"account sequence mismatch", "incorrect account sequence"},
+		Explanation:  "transaction is signed with a sequence different from the account sequence on chain, usually another transaction of the same account was processed in between",
+		SuggestedFix: "do not send transactions of the same account in parallel, or remove nonce.json when it is stale after a chain reset",
+	},
+	{
+		Signatures:   []string{"recipe is disabled", "disabled recipe"},
+		Explanation:  "recipe is disabled by its cookbook owner and can't be executed",
+		SuggestedFix: "enable the recipe with enable_recipe before executing it",
+	},
+	{
+		Signatures:   []string{"item is owned by a recipe", "item is owned by a trade", "item is not owned by the trade"},
+		Explanation:  "item is locked by a pending recipe execution or an open trade",
+		SuggestedFix: "check the pending execution or disable the trade before using the item",
+	},
+	{
+		Signatures:   []string{"insufficient funds", "does not have enough amount to lock"},
+		Explanation:  "sender does not have enough spendable coins, coins locked by trades and executions are not spendable",
+		SuggestedFix: "fund the account with get_pylons or send_coins before the step",
+	},
+}
+
+// ExplainError is a function to get human readable explanation and suggested fix of a common chain failure
+// It returns empty string when the error does not match a known failure signature
+func ExplainError(err error) string {
+	if err == nil {
+		return ""
+	}
+	message := strings.ToLower(err.Error())
+	for _, explanation := range errorExplanations {
+		for _, signature := range explanation.Signatures {
+			if strings.Contains(message, signature) {
+				return fmt.Sprintf("%s; suggested fix: %s", explanation.Explanation, explanation.SuggestedFix)
+			}
+		}
+	}
+	return ""
+}
+
+func init() {
+	testing.SetErrorExplainer(ExplainError)
+}
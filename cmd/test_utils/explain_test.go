@@ -0,0 +1,22 @@
+package inttest
+
+import (
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestExplainError(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	explanation := ExplainError(errors.New("account sequence mismatch, expected 5, got 4: incorrect account sequence"))
+	t.MustContain(explanation, "sequence different from the account sequence", "sequence mismatch should be explained")
+	t.MustContain(explanation, "suggested fix:", "explanation should have suggested fix")
+
+	explanation = ExplainError(errors.New("Item is owned by a recipe"))
+	t.MustContain(explanation, "item is locked", "locked item should be explained")
+
+	t.MustTrue(ExplainError(errors.New("unknown failure")) == "", "unknown error should not be explained")
+	t.MustTrue(ExplainError(nil) == "", "nil error should not be explained")
+}
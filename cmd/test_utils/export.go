@@ -0,0 +1,199 @@
+package inttest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// ExportFormat is a type of format account data is exported in for analytics
+type ExportFormat string
+
+// describes export formats
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportJSON ExportFormat = "json"
+)
+
+// inventoryCSVHeader is the header of inventory csv, coins and items of an account are rows of the same columns
+var inventoryCSVHeader = []string{
+	"address", "kind", "id", "cookbook_id", "name", "amount", "tradable", "owner_recipe_id", "owner_trade_id",
+	"last_update", "transfer_fee", "strings", "longs", "doubles",
+}
+
+// executionCSVHeader is the header of execution csv
+var executionCSVHeader = []string{
+	"id", "sender", "recipe_id", "cookbook_id", "block_height", "completed", "coin_inputs", "item_inputs",
+}
+
+// ExecutionRecord is a struct to describe an execution in flat form for analytics
+type ExecutionRecord struct {
+	ID          string   `json:"id"`
+	Sender      string   `json:"sender"`
+	RecipeID    string   `json:"recipe_id"`
+	CookbookID  string   `json:"cookbook_id"`
+	BlockHeight int64    `json:"block_height"`
+	Completed   bool     `json:"completed"`
+	CoinInputs  string   `json:"coin_inputs"`
+	ItemInputs  []string `json:"item_inputs"`
+}
+
+// NewExecutionRecord is a function to flatten execution, item inputs are referred by their IDs
+func NewExecutionRecord(exec types.Execution) ExecutionRecord {
+	record := ExecutionRecord{
+		ID:          exec.ID,
+		Sender:      exec.Sender,
+		RecipeID:    exec.RecipeID,
+		CookbookID:  exec.CookbookID,
+		BlockHeight: exec.BlockHeight,
+		Completed:   exec.Completed,
+		CoinInputs:  exec.CoinInputs.String(),
+		ItemInputs:  []string{},
+	}
+	for _, item := range exec.ItemInputs {
+		record.ItemInputs = append(record.ItemInputs, item.ID)
+	}
+	return record
+}
+
+// QueryInventory is a function to query items and coins of address through configured transport without a test
+func QueryInventory(addr string, opts ...QueryOption) (Inventory, error) {
+	items, err := ListItemsViaCLI(addr, opts...)
+	if err != nil {
+		return Inventory{}, fmt.Errorf("error listing items of %s: %w", addr, err)
+	}
+	transport, err := GetTransport()
+	if err != nil {
+		return Inventory{}, err
+	}
+	coins, err := transport.Balances(queryContext(opts), addr)
+	if err != nil {
+		return Inventory{}, fmt.Errorf("error querying balances of %s: %w", addr, err)
+	}
+	return NewInventory(addr, items, coins), nil
+}
+
+// ExportInventoryCSV is a function to write coins and items of address to w in csv with header
+func ExportInventoryCSV(addr string, w io.Writer, opts ...QueryOption) error {
+	return ExportInventoriesCSV([]string{addr}, w, opts...)
+}
+
+// ExportInventoriesCSV is a function to write coins and items of addresses to w in a csv having a single header
+func ExportInventoriesCSV(addrs []string, w io.Writer, opts ...QueryOption) error {
+	inventories := []Inventory{}
+	for _, addr := range addrs {
+		inventory, err := QueryInventory(addr, opts...)
+		if err != nil {
+			return err
+		}
+		inventories = append(inventories, inventory)
+	}
+	return writeInventoriesCSV(inventories, w)
+}
+
+// writeInventoriesCSV is a function to write rows of inventories to w, a row per coin and per item
+func writeInventoriesCSV(inventories []Inventory, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(inventoryCSVHeader); err != nil {
+		return err
+	}
+	for _, inventory := range inventories {
+		for _, coin := range inventory.Coins {
+			record := []string{inventory.Address, "coin", coin.Denom, "", "", coin.Amount.String(), "", "", "", "", "", "", "", ""}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		for _, item := range inventory.Items {
+			if err := writer.Write(itemCSVRecord(inventory.Address, item)); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// itemCSVRecord is a function to get csv row of item, attributes are key=value pairs separated by ; in key order
+func itemCSVRecord(addr string, item types.Item) []string {
+	name, _ := item.FindString("Name")
+	strs := []string{}
+	for _, kv := range item.Strings {
+		strs = append(strs, kv.Key+"="+kv.Value)
+	}
+	longs := []string{}
+	for _, kv := range item.Longs {
+		longs = append(longs, kv.Key+"="+strconv.FormatInt(kv.Value, 10))
+	}
+	doubles := []string{}
+	for _, kv := range item.Doubles {
+		doubles = append(doubles, kv.Key+"="+kv.Value.String())
+	}
+	return []string{
+		addr, "item", item.ID, item.CookbookID, name, "1", strconv.FormatBool(item.Tradable), item.OwnerRecipeID,
+		item.OwnerTradeID, strconv.FormatInt(item.LastUpdate, 10), strconv.FormatInt(item.TransferFee, 10),
+		joinSorted(strs), joinSorted(longs), joinSorted(doubles),
+	}
+}
+
+// joinSorted is a function to join key=value pairs in key order
+func joinSorted(pairs []string) string {
+	sort.Strings(pairs)
+	return strings.Join(pairs, ";")
+}
+
+// ExportExecutions is a function to get executions of sender in format, all executions when sender is empty
+func ExportExecutions(addr string, format ExportFormat, opts ...QueryOption) ([]byte, error) {
+	transport, err := GetTransport()
+	if err != nil {
+		return nil, err
+	}
+	executions, err := transport.ListExecutions(queryContext(opts), addr)
+	if err != nil {
+		return nil, fmt.Errorf("error listing executions of %s: %w", addr, err)
+	}
+	return encodeExecutions(executions, format)
+}
+
+// encodeExecutions is a function to encode executions in format ordered by block height and ID
+func encodeExecutions(executions []types.Execution, format ExportFormat) ([]byte, error) {
+	records := []ExecutionRecord{}
+	for _, exec := range executions {
+		records = append(records, NewExecutionRecord(exec))
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].BlockHeight != records[j].BlockHeight {
+			return records[i].BlockHeight < records[j].BlockHeight
+		}
+		return records[i].ID < records[j].ID
+	})
+
+	switch format {
+	case ExportJSON:
+		return json.MarshalIndent(records, "", "  ")
+	case ExportCSV:
+		var builder strings.Builder
+		writer := csv.NewWriter(&builder)
+		if err := writer.Write(executionCSVHeader); err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			row := []string{
+				record.ID, record.Sender, record.RecipeID, record.CookbookID, strconv.FormatInt(record.BlockHeight, 10),
+				strconv.FormatBool(record.Completed), record.CoinInputs, strings.Join(record.ItemInputs, ";"),
+			}
+			if err := writer.Write(row); err != nil {
+				return nil, err
+			}
+		}
+		writer.Flush()
+		return []byte(builder.String()), writer.Error()
+	}
+	return nil, fmt.Errorf("unknown export format %q, it should be one of csv and json", format)
+}
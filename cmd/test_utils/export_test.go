@@ -0,0 +1,80 @@
+package inttest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+func TestExportInventoryCSV(originT *originT.T) {
+	t := testing.NewT(originT)
+	addr := "pylo1gcq3wf0eqw6ahg38aqtlkhq4mnpc2dk3y2ejwu"
+	item := types.Item{
+		ID:         "item1",
+		CookbookID: "cookbook1",
+		Sender:     addr,
+		Tradable:   true,
+		Strings:    []types.StringKeyValue{{Key: "Name", Value: "Knife, sharp"}, {Key: "Color", Value: "red"}},
+		Longs:      []types.LongKeyValue{{Key: "level", Value: 2}},
+		Doubles:    []types.DoubleKeyValue{{Key: "attack", Value: sdk.NewDec(3)}},
+	}
+	server := newRESTServer(&t, map[string]proto.Message{
+		"/cosmos/bank/v1beta1/balances/" + addr:  &banktypes.QueryAllBalancesResponse{Balances: sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 100))},
+		"/custom/pylons/items_by_sender/" + addr: &types.ItemsBySenderResponse{Items: []types.Item{item}},
+	})
+	defer server.Close()
+
+	prevTransport, prevRestEndpoint := CLIOpts.Transport, CLIOpts.RestEndpoint
+	defer func() { CLIOpts.Transport, CLIOpts.RestEndpoint = prevTransport, prevRestEndpoint }()
+	CLIOpts.Transport, CLIOpts.RestEndpoint = TransportREST, server.URL
+
+	buf := bytes.Buffer{}
+	t.MustNil(ExportInventoryCSV(addr, &buf), "error exporting inventory")
+	records, err := csv.NewReader(&buf).ReadAll()
+	t.MustNil(err, "exported inventory should be csv")
+	t.WithFields(testing.Fields{
+		"records": records,
+	}).MustTrue(len(records) == 3, "inventory csv should have header, coin and item rows")
+	t.MustTrue(records[1][1] == "coin" && records[1][2] == types.Pylon && records[1][5] == "100", "coin row should have denom and amount")
+	t.MustTrue(records[2][4] == "Knife, sharp", "item row should have name having comma")
+	t.MustTrue(records[2][11] == "Color=red;Name=Knife, sharp", "string attributes should be in key order")
+	t.MustTrue(records[2][12] == "level=2" && records[2][13] == "attack=3.000000000000000000", "long and double attributes should be exported")
+
+	buf.Reset()
+	t.MustNil(ExportInventoriesCSV([]string{addr, addr}, &buf), "error exporting inventories")
+	records, err = csv.NewReader(&buf).ReadAll()
+	t.MustNil(err, "exported inventories should be csv")
+	t.MustTrue(len(records) == 5, "inventories csv should have a single header")
+}
+
+func TestEncodeExecutions(originT *originT.T) {
+	t := testing.NewT(originT)
+	executions := []types.Execution{
+		{ID: "exec2", Sender: "sender1", RecipeID: "recipe1", BlockHeight: 12, CoinInputs: sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 5))},
+		{ID: "exec1", Sender: "sender1", RecipeID: "recipe1", BlockHeight: 10, Completed: true, ItemInputs: []types.Item{{ID: "item1"}, {ID: "item2"}}},
+	}
+
+	output, err := encodeExecutions(executions, ExportJSON)
+	t.MustNil(err, "error exporting executions in json")
+	records := []ExecutionRecord{}
+	t.MustNil(json.Unmarshal(output, &records), "exported executions should be json")
+	t.MustTrue(len(records) == 2 && records[0].ID == "exec1", "executions should be ordered by block height")
+	t.MustTrue(len(records[0].ItemInputs) == 2 && records[1].CoinInputs == "5pylon", "inputs should be exported")
+
+	output, err = encodeExecutions(executions, ExportCSV)
+	t.MustNil(err, "error exporting executions in csv")
+	rows, err := csv.NewReader(bytes.NewReader(output)).ReadAll()
+	t.MustNil(err, "exported executions should be csv")
+	t.MustTrue(len(rows) == 3 && rows[0][0] == "id", "executions csv should have header")
+	t.MustTrue(rows[1][5] == "true" && rows[1][7] == "item1;item2", "execution row should have completion and item inputs")
+
+	_, err = encodeExecutions(executions, ExportFormat("xml"))
+	t.MustTrue(err != nil, "unknown format should fail")
+}
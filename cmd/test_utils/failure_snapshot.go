@@ -0,0 +1,133 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// names of failure snapshot sections captured by inttest, other sections can be added by testing.AddFailureSnapshotCapturer
+const (
+	SnapshotLatestHeight = "latest_height"
+	SnapshotBalances     = "balances"
+	SnapshotInventories  = "inventories"
+	SnapshotLatestTxs    = "latest_txs"
+	SnapshotObjects      = "objects"
+)
+
+// maxSnapshotObjects is the max number of objects dumped into failure snapshot
+const maxSnapshotObjects = 20
+
+// SnapshotTx is a struct to describe a transaction of failure snapshot with its msg types
+type SnapshotTx struct {
+	Height   int64    `json:"height"`
+	TxHash   string   `json:"txhash"`
+	Code     uint32   `json:"code"`
+	Log      string   `json:"log,omitempty"`
+	MsgTypes []string `json:"msgs"`
+}
+
+// latestTxsBySender is a function to get latest transactions sent by address in reverse commit order
+func latestTxsBySender(ctx context.Context, rpc historyRPC, addr string, limit int) ([]SnapshotTx, error) {
+	page := 1
+	res, err := rpc.TxSearch(ctx, senderTxQuery(addr), false, &page, &limit, "desc")
+	if err != nil {
+		return nil, fmt.Errorf("error searching transactions of %s: %w", addr, err)
+	}
+	txs := []SnapshotTx{}
+	for _, tx := range res.Txs {
+		historyTx := decodeHistoryTx(tx.Height, int(tx.Index), tx.Tx, tx.TxResult)
+		snapshotTx := SnapshotTx{
+			Height:   historyTx.Height,
+			TxHash:   historyTx.TxHash,
+			Code:     historyTx.Code,
+			MsgTypes: []string{},
+		}
+		if historyTx.Code != 0 {
+			snapshotTx.Log = historyTx.Log
+		}
+		for _, msg := range historyTx.Msgs {
+			snapshotTx.MsgTypes = append(snapshotTx.MsgTypes, msg.Type())
+		}
+		txs = append(txs, snapshotTx)
+	}
+	return txs, nil
+}
+
+// snapshotPerAccount is a function to capture a section of each involved account, errors of accounts are kept in the section
+func snapshotPerAccount(ctx context.Context, req testing.FailureSnapshotRequest, capture func(ctx context.Context, addr string) (interface{}, error)) (interface{}, error) {
+	section := make(map[string]interface{})
+	for _, addr := range req.Accounts {
+		value, err := capture(ctx, addr)
+		if err != nil {
+			section[addr] = map[string]string{"error": err.Error()}
+			continue
+		}
+		section[addr] = value
+	}
+	return section, nil
+}
+
+func init() {
+	testing.AddFailureSnapshotCapturer(SnapshotLatestHeight, func(ctx context.Context, req testing.FailureSnapshotRequest) (interface{}, error) {
+		transport, err := GetTransport()
+		if err != nil {
+			return nil, err
+		}
+		return transport.LatestHeight(ctx)
+	})
+	testing.AddFailureSnapshotCapturer(SnapshotBalances, func(ctx context.Context, req testing.FailureSnapshotRequest) (interface{}, error) {
+		transport, err := GetTransport()
+		if err != nil {
+			return nil, err
+		}
+		return snapshotPerAccount(ctx, req, func(ctx context.Context, addr string) (interface{}, error) {
+			coins, err := transport.Balances(ctx, addr)
+			return coins.String(), err
+		})
+	})
+	testing.AddFailureSnapshotCapturer(SnapshotInventories, func(ctx context.Context, req testing.FailureSnapshotRequest) (interface{}, error) {
+		transport, err := GetTransport()
+		if err != nil {
+			return nil, err
+		}
+		return snapshotPerAccount(ctx, req, func(ctx context.Context, addr string) (interface{}, error) {
+			items, err := transport.ItemsBySender(ctx, addr)
+			labels := []string{}
+			for _, item := range NewInventory(addr, items, nil).Items {
+				labels = append(labels, fmt.Sprintf("%s (%s)", itemLabel(item), item.ID))
+			}
+			return labels, err
+		})
+	})
+	testing.AddFailureSnapshotCapturer(SnapshotLatestTxs, func(ctx context.Context, req testing.FailureSnapshotRequest) (interface{}, error) {
+		rpc, err := newHistoryRPC()
+		if err != nil {
+			return nil, err
+		}
+		return snapshotPerAccount(ctx, req, func(ctx context.Context, addr string) (interface{}, error) {
+			return latestTxsBySender(ctx, rpc, addr, req.TxLimit)
+		})
+	})
+	testing.AddFailureSnapshotCapturer(SnapshotObjects, func(ctx context.Context, req testing.FailureSnapshotRequest) (interface{}, error) {
+		rpc, err := newHistoryRPC()
+		if err != nil {
+			return nil, err
+		}
+		refs, err := dumpRefsOfTxs(ctx, rpc, req.TxHashes, maxSnapshotObjects)
+		if err != nil {
+			return nil, err
+		}
+		dumps := []interface{}{}
+		for _, ref := range refs {
+			dump, err := fetchObjectDump(ctx, ref.objectType, ref.id)
+			if err != nil {
+				dumps = append(dumps, map[string]string{"type": string(ref.objectType), "id": ref.id, "error": err.Error()})
+				continue
+			}
+			dumps = append(dumps, dump)
+		}
+		return dumps, nil
+	})
+}
@@ -0,0 +1,36 @@
+package inttest
+
+import (
+	"context"
+	originT "testing"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestFailureSnapshotTxs(originT *originT.T) {
+	t := testing.NewT(originT)
+	player := sdk.AccAddress([]byte("snapshot_player_____")).String()
+	txModel, err := GenTxWithOptions([]sdk.Msg{&types.MsgGetPylons{Amount: sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 10)), Requester: player}}, TxOptions{})
+	t.MustNil(err, "error generating transaction")
+	txBytes, err := app.MakeEncodingConfig().TxConfig.TxEncoder()(txModel)
+	t.MustNil(err, "error encoding transaction")
+
+	rpc := &fakeHistoryRPC{
+		blocks:  map[int64]tmtypes.Txs{7: {txBytes}},
+		results: map[int64][]*abci.ResponseDeliverTx{7: {{Code: 5, Log: "insufficient funds"}}},
+	}
+	txs, err := latestTxsBySender(context.Background(), rpc, player, 5)
+	t.MustNil(err, "error getting latest transactions")
+	t.MustTrue(rpc.query == senderTxQuery(player), "transactions should be searched by sender")
+	t.WithFields(testing.Fields{
+		"txs": txs,
+	}).MustTrue(len(txs) == 1 && txs[0].Height == 7 && txs[0].MsgTypes[0] == (types.MsgGetPylons{}).Type() && txs[0].Log == "insufficient funds", "failed transaction should be described with msg types and log")
+
+	t.InvolveAccounts(player)
+	t.MustTrue(t.InvolvedAccounts()[0] == player, "account should be involved in failure snapshot")
+}
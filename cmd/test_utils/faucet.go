@@ -0,0 +1,309 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// describes the errors of faucet requests which are refused before sending coins
+var (
+	ErrFaucetAddress     = errors.New("invalid faucet address")
+	ErrFaucetNotAllowed  = errors.New("address is not in faucet allowlist")
+	ErrFaucetRateLimited = errors.New("faucet request is rate limited")
+	ErrFaucetAmount      = errors.New("invalid faucet amount")
+)
+
+// FaucetOptions is a struct to configure faucet funding addresses on local and devnet chains
+type FaucetOptions struct {
+	// Key is the key name of the funded account coins are sent from
+	Key string
+	// Amount is the coins sent for requests without amount
+	Amount sdk.Coins
+	// MaxAmount is the maximum coins of a request, Amount is the maximum when it's empty
+	MaxAmount sdk.Coins
+	// Interval is the time an address should wait between requests, requests are not limited when it's 0
+	Interval time.Duration
+	// IPInterval is the time a client ip of Handler should wait between requests for any address, not limited when it's 0
+	IPInterval time.Duration
+	// TrustProxy takes client ip of Handler from the first X-Forwarded-For address, set it only behind a proxy setting it
+	TrustProxy bool
+	// MaxPerHour is the number of requests of all addresses funded in an hour, not limited when it's 0
+	MaxPerHour int
+	// Allowlist are addresses or aliases of GlobalAddressBook which can be funded.
+	// The faucet is open to any address when it's empty, so IPInterval and MaxPerHour should be set on shared chains.
+	Allowlist []string
+}
+
+// Faucet is a struct to send coins of a funded key to addresses requesting test funds
+// Coins are sent by Client the same way tests send transactions.
+type Faucet struct {
+	client  *Client
+	opts    FaucetOptions
+	allowed map[string]bool
+	now     func() time.Time
+
+	mux          sync.Mutex
+	lastFunded   map[string]time.Time
+	lastFundedIP map[string]time.Time
+	hourly       *tokenBucket // budget of MaxPerHour, nil when it's not limited
+}
+
+// FaucetResponse is a struct to describe json response of faucet http handler
+type FaucetResponse struct {
+	Address string `json:"address,omitempty"`
+	Amount  string `json:"amount,omitempty"`
+	TxHash  string `json:"txhash,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// NewFaucet is a function to create faucet sending coins of opts.Key by client, NewClient() is used when client is nil
+func NewFaucet(client *Client, opts FaucetOptions) (*Faucet, error) {
+	if client == nil {
+		client = NewClient()
+	}
+	if len(opts.Key) == 0 {
+		return nil, errors.New("faucet key should be set")
+	}
+	if !opts.Amount.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrFaucetAmount, opts.Amount)
+	}
+	if opts.MaxAmount.Empty() {
+		opts.MaxAmount = opts.Amount
+	}
+	if !opts.MaxAmount.IsValid() || !opts.Amount.IsAllLTE(opts.MaxAmount) {
+		return nil, fmt.Errorf("%w: amount %s should not be more than max amount %s", ErrFaucetAmount, opts.Amount, opts.MaxAmount)
+	}
+	allowed := map[string]bool{}
+	for _, aliasOrAddr := range opts.Allowlist {
+		addr, err := GlobalAddressBook.Resolve(aliasOrAddr)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving faucet allowlist: %w", err)
+		}
+		allowed[addr] = true
+	}
+	if opts.MaxPerHour < 0 {
+		return nil, fmt.Errorf("faucet max per hour should not be negative: %d", opts.MaxPerHour)
+	}
+	f := &Faucet{
+		client:       client,
+		opts:         opts,
+		allowed:      allowed,
+		now:          time.Now,
+		lastFunded:   map[string]time.Time{},
+		lastFundedIP: map[string]time.Time{},
+	}
+	if opts.MaxPerHour > 0 {
+		// bucket is full from the first request, whatever clock the faucet runs with
+		f.hourly = newTokenBucket(float64(opts.MaxPerHour)/time.Hour.Seconds(), opts.MaxPerHour, time.Time{})
+	}
+	return f, nil
+}
+
+// check is a function to validate address and amount of request, it returns amount to send
+func (f *Faucet) check(addr string, amount sdk.Coins) (sdk.Coins, error) {
+	if err := ValidateAccountAddress(addr); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrFaucetAddress, err.Error())
+	}
+	if len(f.allowed) > 0 && !f.allowed[addr] {
+		return nil, fmt.Errorf("%w: %s", ErrFaucetNotAllowed, addr)
+	}
+	if amount.Empty() {
+		amount = f.opts.Amount
+	}
+	if !amount.IsValid() || !amount.IsAllLTE(f.opts.MaxAmount) {
+		return nil, fmt.Errorf("%w: %s should be valid coins of at most %s", ErrFaucetAmount, amount, f.opts.MaxAmount)
+	}
+	return amount, nil
+}
+
+// reserve is a function to record request of addr from client ip for rate limits, it fails when addr or ip is funded
+// within their intervals or the hourly budget of the faucet is used up. ip is empty for requests not made by Handler.
+// It returns function to release the reservation when coins are not sent.
+func (f *Faucet) reserve(addr, ip string) (func(), error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	now := f.now()
+	if wait := waitInterval(f.lastFunded, addr, f.opts.Interval, now); wait > 0 {
+		return nil, fmt.Errorf("%w: %s can be funded again in %s", ErrFaucetRateLimited, addr, wait.Round(time.Second))
+	}
+	if wait := waitInterval(f.lastFundedIP, ip, f.opts.IPInterval, now); wait > 0 {
+		return nil, fmt.Errorf("%w: requests of %s can be funded again in %s", ErrFaucetRateLimited, ip, wait.Round(time.Second))
+	}
+	if f.hourly != nil {
+		if wait := f.hourly.reserve(now); wait > 0 {
+			f.hourly.cancel()
+			return nil, fmt.Errorf("%w: faucet funds %d requests per hour, try again in %s", ErrFaucetRateLimited, f.opts.MaxPerHour, wait.Round(time.Second))
+		}
+	}
+	releaseAddr := recordFunded(f.lastFunded, addr, now)
+	releaseIP := func() {}
+	if len(ip) > 0 {
+		releaseIP = recordFunded(f.lastFundedIP, ip, now)
+	}
+	return func() {
+		f.mux.Lock()
+		defer f.mux.Unlock()
+		releaseAddr()
+		releaseIP()
+		if f.hourly != nil {
+			f.hourly.cancel()
+		}
+	}, nil
+}
+
+// waitInterval is a function to get the time key should wait until interval passes since it's funded, 0 when it can be funded
+func waitInterval(lastFunded map[string]time.Time, key string, interval time.Duration, now time.Time) time.Duration {
+	prev, ok := lastFunded[key]
+	if !ok || interval <= 0 || now.Sub(prev) >= interval {
+		return 0
+	}
+	return prev.Add(interval).Sub(now)
+}
+
+// recordFunded is a function to record key funded at now, it returns function to restore the previous record
+func recordFunded(lastFunded map[string]time.Time, key string, now time.Time) func() {
+	prev, ok := lastFunded[key]
+	lastFunded[key] = now
+	return func() {
+		if ok {
+			lastFunded[key] = prev
+		} else {
+			delete(lastFunded, key)
+		}
+	}
+}
+
+// clientIP is a function to get ip of client of faucet request, X-Forwarded-For is used only with TrustProxy
+func (f *Faucet) clientIP(r *http.Request) string {
+	if f.opts.TrustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); len(forwarded) > 0 {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Fund is a function to send amount to addr and wait for the transaction, Amount of options is sent when amount is empty
+// Requests are limited per address and by MaxPerHour, IPInterval applies to requests of Handler.
+func (f *Faucet) Fund(ctx context.Context, t *testing.T, addr string, amount sdk.Coins) (TxResult, sdk.Coins, error) {
+	return f.fund(ctx, t, addr, "", amount)
+}
+
+// fund is a function to send amount to addr requested from client ip, ip is empty when it's not an http request
+func (f *Faucet) fund(ctx context.Context, t *testing.T, addr, ip string, amount sdk.Coins) (TxResult, sdk.Coins, error) {
+	amount, err := f.check(addr, amount)
+	if err != nil {
+		return TxResult{}, amount, err
+	}
+	release, err := f.reserve(addr, ip)
+	if err != nil {
+		return TxResult{}, amount, err
+	}
+	from, err := LookupAccountAddr(f.opts.Key)
+	if err != nil {
+		release()
+		return TxResult{}, amount, fmt.Errorf("error getting address of faucet key %s: %w", f.opts.Key, err)
+	}
+	fromAddr, err := sdk.AccAddressFromBech32(from)
+	if err != nil {
+		release()
+		return TxResult{}, amount, err
+	}
+	toAddr, err := sdk.AccAddressFromBech32(addr)
+	if err != nil {
+		release()
+		return TxResult{}, amount, err
+	}
+	txhash, err := f.client.SendTx(ctx, t, SignerKey(f.opts.Key), banktypes.NewMsgSend(fromAddr, toAddr, amount))
+	if err != nil {
+		// nothing is broadcast, so the address can request again right away
+		release()
+		return TxResult{}, amount, err
+	}
+	txResult, err := f.client.WaitForTxResult(ctx, t, txhash)
+	txResult.TxHash = txhash
+	return txResult, amount, err
+}
+
+// faucetStatusCode is a function to get http status of faucet error
+func faucetStatusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrFaucetRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, ErrFaucetNotAllowed):
+		return http.StatusForbidden
+	case errors.Is(err, ErrFaucetAddress), errors.Is(err, ErrFaucetAmount):
+		return http.StatusBadRequest
+	}
+	return http.StatusBadGateway
+}
+
+// Handler is a function to get http handler funding address of "address" parameter with coins of "amount" e.g. "100pylon"
+// Parameters are read from query or form of GET and POST requests, and from json body of POST requests.
+func (f *Faucet) Handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Address string `json:"address"`
+			Amount  string `json:"amount"`
+		}
+		switch {
+		case r.Method == http.MethodPost && r.Header.Get("Content-Type") == "application/json":
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeFaucetResponse(w, http.StatusBadRequest, FaucetResponse{Error: "error decoding request: " + err.Error()})
+				return
+			}
+		case r.Method == http.MethodGet || r.Method == http.MethodPost:
+			req.Address = r.FormValue("address")
+			req.Amount = r.FormValue("amount")
+		default:
+			writeFaucetResponse(w, http.StatusMethodNotAllowed, FaucetResponse{Error: "method should be GET or POST"})
+			return
+		}
+		res := FaucetResponse{Address: req.Address}
+		amount, err := sdk.ParseCoinsNormalized(req.Amount)
+		if err != nil {
+			res.Error = fmt.Errorf("%w: %s", ErrFaucetAmount, err.Error()).Error()
+			writeFaucetResponse(w, http.StatusBadRequest, res)
+			return
+		}
+		ip := f.clientIP(r)
+		txResult, amount, err := f.fund(r.Context(), t, req.Address, ip, amount)
+		res.Amount = amount.String()
+		res.TxHash = txResult.TxHash
+		status := http.StatusOK
+		if err != nil {
+			res.Error = err.Error()
+			status = faucetStatusCode(err)
+		}
+		t.WithFields(testing.Fields{
+			"address": req.Address,
+			"ip":      ip,
+			"amount":  res.Amount,
+			"txhash":  res.TxHash,
+			"error":   res.Error,
+		}).Info("faucet request")
+		writeFaucetResponse(w, status, res)
+	})
+}
+
+// writeFaucetResponse is a function to write json response of faucet handler
+func writeFaucetResponse(w http.ResponseWriter, status int, res FaucetResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,122 @@
+package inttest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestFaucetLimits(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	frontend := sdk.AccAddress([]byte("frontend_dev_account")).String()
+	stranger := sdk.AccAddress([]byte("stranger_account_add")).String()
+	book := GlobalAddressBook
+	GlobalAddressBook = NewAddressBook()
+	defer func() {
+		GlobalAddressBook = book
+	}()
+	t.MustNil(GlobalAddressBook.Add("frontend", frontend), "error adding alias")
+
+	_, err := NewFaucet(nil, FaucetOptions{Key: "faucet", Amount: sdk.NewCoins(sdk.NewInt64Coin("pylon", 100)), MaxAmount: sdk.NewCoins(sdk.NewInt64Coin("pylon", 10))})
+	t.MustTrue(errors.Is(err, ErrFaucetAmount), "amount above max amount should be refused")
+	_, err = NewFaucet(nil, FaucetOptions{Key: "faucet", Amount: sdk.NewCoins(sdk.NewInt64Coin("pylon", 100)), Allowlist: []string{"unknown"}})
+	t.MustTrue(err != nil, "unknown alias of allowlist should be refused")
+
+	faucet, err := NewFaucet(nil, FaucetOptions{
+		Key:       "faucet",
+		Amount:    sdk.NewCoins(sdk.NewInt64Coin("pylon", 100)),
+		MaxAmount: sdk.NewCoins(sdk.NewInt64Coin("pylon", 500), sdk.NewInt64Coin("loudcoin", 50)),
+		Interval:  time.Hour,
+		Allowlist: []string{"frontend"},
+	})
+	t.MustNil(err, "error creating faucet")
+	now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	faucet.now = func() time.Time { return now }
+
+	amount, err := faucet.check(frontend, nil)
+	t.MustTrue(err == nil && amount.String() == "100pylon", "default amount should be sent without amount")
+	_, err = faucet.check(frontend, sdk.NewCoins(sdk.NewInt64Coin("pylon", 501)))
+	t.MustTrue(errors.Is(err, ErrFaucetAmount), "amount above max amount should be refused")
+	_, err = faucet.check(stranger, nil)
+	t.MustTrue(errors.Is(err, ErrFaucetNotAllowed), "address out of allowlist should be refused")
+	_, err = faucet.check(sdk.ValAddress([]byte("frontend_dev_account")).String(), nil)
+	t.MustTrue(errors.Is(err, ErrFaucetAddress), "validator address should be refused")
+
+	release, err := faucet.reserve(frontend, "")
+	t.MustNil(err, "first request should be reserved")
+	_, err = faucet.reserve(frontend, "")
+	t.MustTrue(errors.Is(err, ErrFaucetRateLimited), "request within interval should be rate limited")
+	release()
+	_, err = faucet.reserve(frontend, "")
+	t.MustNil(err, "released request should not limit the address")
+	now = now.Add(time.Hour)
+	_, err = faucet.reserve(frontend, "")
+	t.MustNil(err, "request after interval should be reserved")
+
+	// an open faucet is limited per client ip and per hour
+	open, err := NewFaucet(nil, FaucetOptions{
+		Key:        "faucet",
+		Amount:     sdk.NewCoins(sdk.NewInt64Coin("pylon", 100)),
+		IPInterval: time.Minute,
+		MaxPerHour: 2,
+	})
+	t.MustNil(err, "error creating faucet")
+	open.now = func() time.Time { return now }
+	_, err = open.check(stranger, nil)
+	t.MustNil(err, "any address should be funded by faucet without allowlist")
+	_, err = open.reserve(frontend, "10.0.0.1")
+	t.MustNil(err, "first request of ip should be reserved")
+	_, err = open.reserve(stranger, "10.0.0.1")
+	t.MustTrue(errors.Is(err, ErrFaucetRateLimited), "request of another address from the same ip within ip interval should be rate limited")
+	release, err = open.reserve(stranger, "10.0.0.2")
+	t.MustNil(err, "request of another ip should be reserved")
+	release()
+	_, err = open.reserve(stranger, "")
+	t.MustNil(err, "released request should not use hourly budget")
+	third := sdk.AccAddress([]byte("third_account_addres")).String()
+	_, err = open.reserve(third, "10.0.0.3")
+	t.WithFields(testing.Fields{
+		"error": err,
+	}).MustTrue(errors.Is(err, ErrFaucetRateLimited), "request above hourly budget should be rate limited")
+	now = now.Add(30 * time.Minute)
+	_, err = open.reserve(third, "10.0.0.3")
+	t.MustNil(err, "hourly budget should be refilled over time")
+
+	req := httptest.NewRequest(http.MethodPost, "/fund", nil)
+	req.RemoteAddr = "10.0.0.4:52100"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	t.MustTrue(open.clientIP(req) == "10.0.0.4", "forwarded address should not be trusted without proxy")
+	open.opts.TrustProxy = true
+	t.MustTrue(open.clientIP(req) == "203.0.113.7", "client address should be taken from forwarded address behind proxy")
+
+	server := httptest.NewServer(faucet.Handler(&t))
+	defer server.Close()
+	for _, tc := range []struct {
+		body   string
+		status int
+	}{
+		{`{"address": "` + stranger + `"}`, http.StatusForbidden},
+		{`{"address": "` + frontend + `", "amount": "1000pylon"}`, http.StatusBadRequest},
+		{`{"address": "` + frontend + `", "amount": "pylon"}`, http.StatusBadRequest},
+		{`{"address": "` + frontend + `"}`, http.StatusTooManyRequests},
+	} {
+		res, err := http.Post(server.URL, "application/json", strings.NewReader(tc.body))
+		t.MustNil(err, "error requesting faucet")
+		var faucetRes FaucetResponse
+		err = json.NewDecoder(res.Body).Decode(&faucetRes)
+		res.Body.Close()
+		t.WithFields(testing.Fields{
+			"body":     tc.body,
+			"status":   res.StatusCode,
+			"response": faucetRes,
+		}).MustTrue(err == nil && res.StatusCode == tc.status && len(faucetRes.Error) > 0, "faucet should refuse request with status")
+	}
+}
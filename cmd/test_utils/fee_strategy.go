@@ -0,0 +1,196 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ErrMinGasPricesUnavailable is an error of node and chain profile which don't tell min-gas-prices
+var ErrMinGasPricesUnavailable = errors.New("min-gas-prices are not available")
+
+// GasPricesAuto is the gas prices flag value to query min-gas-prices from node
+const GasPricesAuto = "auto"
+
+// minGasPricesCacheKey is the query cache key prefix of min-gas-prices of rest endpoints
+const minGasPricesCacheKey = "min_gas_prices|"
+
+func init() {
+	flag.StringVar(&CLIOpts.GasPrices, "gas-prices", "", "gas prices fees are taken from instead of fees of chain profile e.g. 0.025pylon, \"auto\" to query min-gas-prices of node")
+}
+
+// FeeStrategy is an interface to decide fees of a transaction from its gas limit before it's signed
+type FeeStrategy interface {
+	Fee(ctx context.Context, gasLimit uint64) (sdk.Coins, error)
+}
+
+// ProfileFee is a fee strategy paying fees of chain profile of env, transactions are free without chain profile
+type ProfileFee struct{}
+
+// Fee is a function to get fees of chain profile of env of ctx
+func (ProfileFee) Fee(ctx context.Context, gasLimit uint64) (sdk.Coins, error) {
+	profile, _ := EnvFromContext(ctx).ChainProfile()
+	return profile.FeeCoins(), nil
+}
+
+// FixedFee is a fee strategy paying the same amount for every transaction
+type FixedFee struct {
+	Amount sdk.Coins
+}
+
+// Fee is a function to get fixed fee amount
+func (f FixedFee) Fee(ctx context.Context, gasLimit uint64) (sdk.Coins, error) {
+	return f.Amount, nil
+}
+
+// GasPriceFee is a fee strategy paying gas limit times gas prices, rounded up
+// min-gas-prices of node are queried when GasPrices is empty, see QueryMinGasPrices.
+type GasPriceFee struct {
+	GasPrices sdk.DecCoins
+}
+
+// Fee is a function to get fees of gas limit at gas prices
+func (f GasPriceFee) Fee(ctx context.Context, gasLimit uint64) (sdk.Coins, error) {
+	prices := f.GasPrices
+	if prices.Empty() {
+		var err error
+		if prices, err = QueryMinGasPrices(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return feesOfGasPrices(prices, gasLimit), nil
+}
+
+// PriorityFee is a fee strategy paying fees of base strategy multiplied by boost, rounded up
+// It's used for a transaction of a race to be ordered first by nodes prioritizing higher fees.
+type PriorityFee struct {
+	// Base is the strategy fees are boosted from, fee strategy of env of ctx is used when it's nil
+	Base  FeeStrategy
+	Boost sdk.Dec
+}
+
+// Fee is a function to get boosted fees of base strategy
+func (f PriorityFee) Fee(ctx context.Context, gasLimit uint64) (sdk.Coins, error) {
+	base := f.Base
+	if base == nil {
+		base = EnvFromContext(ctx).FeeStrategy()
+	}
+	fees, err := base.Fee(ctx, gasLimit)
+	if err != nil {
+		return nil, err
+	}
+	if f.Boost.IsNil() || !f.Boost.IsPositive() {
+		return nil, fmt.Errorf("priority fee boost should be positive")
+	}
+	boosted := sdk.Coins{}
+	for _, fee := range fees {
+		boosted = boosted.Add(sdk.NewCoin(fee.Denom, fee.Amount.ToDec().Mul(f.Boost).Ceil().TruncateInt()))
+	}
+	return boosted, nil
+}
+
+// feesOfGasPrices is a function to get gas limit times each gas price, rounded up
+func feesOfGasPrices(prices sdk.DecCoins, gasLimit uint64) sdk.Coins {
+	limit := sdk.NewDec(int64(gasLimit))
+	fees := sdk.Coins{}
+	for _, price := range prices {
+		fees = fees.Add(sdk.NewCoin(price.Denom, price.Amount.Mul(limit).Ceil().TruncateInt()))
+	}
+	return fees
+}
+
+// nodeConfigResponse is the response of node config route of newer nodes
+type nodeConfigResponse struct {
+	MinimumGasPrice string `json:"minimum_gas_price"`
+}
+
+// QueryMinGasPrices is a function to get min-gas-prices of node by its rest config route
+// Gas prices of chain profile of env are used when node doesn't expose its config.
+func QueryMinGasPrices(ctx context.Context) (sdk.DecCoins, error) {
+	env := EnvFromContext(ctx)
+	endpoint := env.opts.RestEndpoint
+	if cached, ok := queryResults.get(minGasPricesCacheKey+endpoint, 0); ok {
+		return cached.(sdk.DecCoins), nil
+	}
+	prices, nodeErr := queryNodeMinGasPrices(ctx, endpoint)
+	if nodeErr != nil {
+		profile, _ := env.ChainProfile()
+		if len(profile.GasPrices) == 0 {
+			return nil, fmt.Errorf("%w: %s and chain profile has no gas prices", ErrMinGasPricesUnavailable, nodeErr.Error())
+		}
+		var err error
+		if prices, err = sdk.ParseDecCoins(profile.GasPrices); err != nil {
+			return nil, fmt.Errorf("invalid gas prices of chain profile %s: %w", profile.Name, err)
+		}
+	}
+	queryResults.set(minGasPricesCacheKey+endpoint, prices, 0)
+	return prices, nil
+}
+
+// queryNodeMinGasPrices is a function to get min-gas-prices from config route of rest endpoint
+func queryNodeMinGasPrices(ctx context.Context, endpoint string) (sdk.DecCoins, error) {
+	if len(endpoint) == 0 {
+		return nil, errors.New("rest endpoint is not configured")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint, "/")+"/cosmos/base/node/v1beta1/config", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeUnavailable, err.Error())
+	}
+	defer resp.Body.Close()
+	output, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("node config route returned %s", resp.Status)
+	}
+	var config nodeConfigResponse
+	if err = json.Unmarshal(output, &config); err != nil {
+		return nil, err
+	}
+	return sdk.ParseDecCoins(config.MinimumGasPrice)
+}
+
+// GetFeeStrategy is a function to get fee strategy of DefaultEnv
+func GetFeeStrategy() FeeStrategy {
+	return DefaultEnv().FeeStrategy()
+}
+
+// invalidFee is a fee strategy of invalid gas prices option, transactions fail to be generated with its error
+type invalidFee struct {
+	err error
+}
+
+// Fee is a function to get error of invalid option
+func (f invalidFee) Fee(ctx context.Context, gasLimit uint64) (sdk.Coins, error) {
+	return nil, f.err
+}
+
+// FeeStrategy is a function to get fee strategy of env, CLIOptions.FeeStrategy, the one of gas prices or ProfileFee
+func (e *Env) FeeStrategy() FeeStrategy {
+	if e.opts.FeeStrategy != nil {
+		return e.opts.FeeStrategy
+	}
+	switch e.opts.GasPrices {
+	case "":
+		return ProfileFee{}
+	case GasPricesAuto:
+		return GasPriceFee{}
+	}
+	prices, err := sdk.ParseDecCoins(e.opts.GasPrices)
+	if err != nil {
+		return invalidFee{fmt.Errorf("invalid gas prices %s: %w", e.opts.GasPrices, err)}
+	}
+	return GasPriceFee{GasPrices: prices}
+}
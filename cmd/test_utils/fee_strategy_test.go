@@ -0,0 +1,77 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestFeeStrategies(originT *originT.T) {
+	t := testing.NewT(originT)
+	ctx := context.Background()
+
+	fixed := FixedFee{Amount: sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 7))}
+	fees, err := fixed.Fee(ctx, 200000)
+	t.MustNil(err, "error getting fixed fee")
+	t.MustTrue(fees.IsEqual(fixed.Amount), "fixed fee should not depend on gas")
+
+	gasPrice := GasPriceFee{GasPrices: sdk.NewDecCoins(sdk.NewDecCoinFromDec(types.Pylon, sdk.NewDecWithPrec(25, 3)))}
+	fees, err = gasPrice.Fee(ctx, 200001)
+	t.MustNil(err, "error getting gas price fee")
+	t.WithFields(testing.Fields{
+		"fees": fees.String(),
+	}).MustTrue(fees.AmountOf(types.Pylon).Int64() == 5001, "gas price fee should be gas limit times price rounded up")
+
+	priority := PriorityFee{Base: fixed, Boost: sdk.NewDecWithPrec(15, 1)}
+	fees, err = priority.Fee(ctx, 200000)
+	t.MustNil(err, "error getting priority fee")
+	t.MustTrue(fees.AmountOf(types.Pylon).Int64() == 11, "priority fee should boost base fee rounded up")
+	_, err = PriorityFee{Base: fixed}.Fee(ctx, 200000)
+	t.MustTrue(err != nil, "priority fee without boost should fail")
+
+	prevOpts := CLIOpts
+	defer func() { CLIOpts = prevOpts }()
+	t.MustTrue(GetFeeStrategy() == ProfileFee{}, "chain profile fees should be paid by default")
+	CLIOpts.GasPrices = "0.5pylon"
+	strategy, ok := GetFeeStrategy().(GasPriceFee)
+	t.MustTrue(ok && strategy.GasPrices.AmountOf(types.Pylon).Equal(sdk.NewDecWithPrec(5, 1)), "gas prices option should select gas price fee")
+	CLIOpts.GasPrices = "pylon"
+	_, err = GetFeeStrategy().Fee(ctx, 1)
+	t.MustTrue(err != nil, "invalid gas prices should fail")
+	CLIOpts.GasPrices = ""
+	CLIOpts.FeeStrategy = fixed
+	txBldr, err := GenTxBuilderWithOptions([]sdk.Msg{}, TxOptions{})
+	t.MustNil(err, "error generating transaction")
+	t.MustTrue(txBldr.GetTx().GetFee().IsEqual(fixed.Amount), "transaction should pay fees of configured strategy")
+	txBldr, err = GenTxBuilderWithOptions([]sdk.Msg{}, TxOptions{FeeStrategy: priority})
+	t.MustNil(err, "error generating transaction")
+	t.MustTrue(txBldr.GetTx().GetFee().AmountOf(types.Pylon).Int64() == 11, "fee strategy of tx options should be used")
+	_, ok = NewClient(WithFeeStrategy(priority)).txOpts.FeeStrategy.(PriorityFee)
+	t.MustTrue(ok, "client should use fee strategy option")
+}
+
+func TestQueryMinGasPrices(originT *originT.T) {
+	t := testing.NewT(originT)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/base/node/v1beta1/config" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"minimum_gas_price":"0.025pylon"}`))
+	}))
+	defer server.Close()
+
+	env := NewEnv(CLIOptions{RestEndpoint: server.URL}, nil)
+	prices, err := QueryMinGasPrices(ContextWithEnv(context.Background(), env))
+	t.MustNil(err, "error querying min-gas-prices")
+	t.MustTrue(prices.AmountOf(types.Pylon).Equal(sdk.NewDecWithPrec(25, 3)), "min-gas-prices should be taken from node config")
+
+	_, err = QueryMinGasPrices(ContextWithEnv(context.Background(), NewEnv(CLIOptions{}, nil)))
+	t.MustTrue(errors.Is(err, ErrMinGasPricesUnavailable), "min-gas-prices should be unavailable without node config and chain profile")
+}
@@ -0,0 +1,224 @@
+package inttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// GenesisBuilder is a struct to build genesis of a local chain which already has accounts, cookbooks, recipes and items
+// so that a test scenario can start from the state instead of creating it by transactions
+type GenesisBuilder struct {
+	cdc      codec.Marshaler
+	genDoc   *tmtypes.GenesisDoc
+	appState map[string]json.RawMessage
+
+	accounts  map[string]sdk.Coins
+	addresses []string
+	cookbooks map[string]bool
+	recipes   map[string]bool
+	items     map[string]bool
+	pylons    *types.GenesisState
+}
+
+// NewGenesisBuilder is a function to create genesis builder from default genesis of all modules
+func NewGenesisBuilder(chainID string) *GenesisBuilder {
+	cdc := GetJSONMarshaler()
+	genDoc := &tmtypes.GenesisDoc{
+		ChainID:     chainID,
+		GenesisTime: time.Now().UTC(),
+	}
+	return newGenesisBuilder(cdc, genDoc, app.ModuleBasics.DefaultGenesis(cdc), types.DefaultGenesis())
+}
+
+// LoadGenesisBuilder is a function to create genesis builder from genesis file e.g. created by "pylonsd init"
+func LoadGenesisBuilder(genesisFile string) (*GenesisBuilder, error) {
+	genDoc, err := tmtypes.GenesisDocFromFile(genesisFile)
+	if err != nil {
+		return nil, err
+	}
+	appState := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(genDoc.AppState, &appState); err != nil {
+		return nil, fmt.Errorf("error parsing app state of genesis: %w", err)
+	}
+	cdc := GetJSONMarshaler()
+	pylonsState := types.DefaultGenesis()
+	if raw, ok := appState[types.ModuleName]; ok {
+		if err = cdc.UnmarshalJSON(raw, pylonsState); err != nil {
+			return nil, fmt.Errorf("error parsing pylons genesis state: %w", err)
+		}
+	}
+	return newGenesisBuilder(cdc, genDoc, appState, pylonsState), nil
+}
+
+func newGenesisBuilder(cdc codec.Marshaler, genDoc *tmtypes.GenesisDoc, appState map[string]json.RawMessage, pylonsState *types.GenesisState) *GenesisBuilder {
+	b := &GenesisBuilder{
+		cdc:       cdc,
+		genDoc:    genDoc,
+		appState:  appState,
+		accounts:  make(map[string]sdk.Coins),
+		cookbooks: make(map[string]bool),
+		recipes:   make(map[string]bool),
+		items:     make(map[string]bool),
+		pylons:    pylonsState,
+	}
+	for _, cb := range pylonsState.Cookbooks {
+		b.cookbooks[cb.ID] = true
+	}
+	for _, rcp := range pylonsState.Recipes {
+		b.recipes[rcp.ID] = true
+	}
+	for _, item := range pylonsState.Items {
+		b.items[item.ID] = true
+	}
+	authState := authtypes.GetGenesisStateFromAppState(cdc, appState)
+	accounts, err := authtypes.UnpackAccounts(authState.Accounts)
+	if err == nil {
+		for _, acc := range accounts {
+			b.accounts[acc.GetAddress().String()] = sdk.Coins{}
+		}
+	}
+	return b
+}
+
+// AddAccount is a function to add account with its initial balance
+func (b *GenesisBuilder) AddAccount(address string, coins sdk.Coins) error {
+	if _, err := sdk.AccAddressFromBech32(address); err != nil {
+		return fmt.Errorf("invalid account address %s: %w", address, err)
+	}
+	if _, ok := b.accounts[address]; ok {
+		return fmt.Errorf("account %s is already in genesis", address)
+	}
+	if !coins.IsValid() {
+		return fmt.Errorf("invalid balance of account %s: %s", address, coins.String())
+	}
+	b.accounts[address] = coins
+	b.addresses = append(b.addresses, address)
+	return nil
+}
+
+// AddCookbook is a function to add cookbook owned by an account of genesis
+func (b *GenesisBuilder) AddCookbook(cb types.Cookbook) error {
+	if cb.ID == "" {
+		return fmt.Errorf("cookbook ID should not be empty: name=%s", cb.Name)
+	}
+	if b.cookbooks[cb.ID] {
+		return fmt.Errorf("cookbook %s is already in genesis", cb.ID)
+	}
+	if _, ok := b.accounts[cb.Sender]; !ok {
+		return fmt.Errorf("sender %s of cookbook %s is not an account of genesis", cb.Sender, cb.ID)
+	}
+	b.cookbooks[cb.ID] = true
+	b.pylons.Cookbooks = append(b.pylons.Cookbooks, cb)
+	return nil
+}
+
+// AddRecipe is a function to add recipe of a cookbook of genesis, it's validated as create recipe msg
+func (b *GenesisBuilder) AddRecipe(rcp types.Recipe) error {
+	if rcp.ID == "" {
+		return fmt.Errorf("recipe ID should not be empty: name=%s", rcp.Name)
+	}
+	if b.recipes[rcp.ID] {
+		return fmt.Errorf("recipe %s is already in genesis", rcp.ID)
+	}
+	if !b.cookbooks[rcp.CookbookID] {
+		return fmt.Errorf("cookbook %s of recipe %s is not in genesis", rcp.CookbookID, rcp.ID)
+	}
+	msg := types.NewMsgCreateRecipe(rcp.Name, rcp.CookbookID, rcp.ID, rcp.Description,
+		rcp.CoinInputs, rcp.ItemInputs, rcp.Entries, rcp.Outputs, rcp.BlockInterval, rcp.Sender)
+	if err := msg.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid recipe %s: %w", rcp.ID, err)
+	}
+	b.recipes[rcp.ID] = true
+	b.pylons.Recipes = append(b.pylons.Recipes, rcp)
+	return nil
+}
+
+// AddItem is a function to add item of a cookbook of genesis owned by an account of genesis
+func (b *GenesisBuilder) AddItem(item types.Item) error {
+	if item.ID == "" {
+		return fmt.Errorf("item ID should not be empty: cookbook=%s", item.CookbookID)
+	}
+	if b.items[item.ID] {
+		return fmt.Errorf("item %s is already in genesis", item.ID)
+	}
+	if !b.cookbooks[item.CookbookID] {
+		return fmt.Errorf("cookbook %s of item %s is not in genesis", item.CookbookID, item.ID)
+	}
+	if _, ok := b.accounts[item.Sender]; !ok {
+		return fmt.Errorf("sender %s of item %s is not an account of genesis", item.Sender, item.ID)
+	}
+	b.items[item.ID] = true
+	b.pylons.Items = append(b.pylons.Items, item)
+	return nil
+}
+
+// Build is a function to get genesis document with the accounts and pylons state added to the builder
+func (b *GenesisBuilder) Build() (*tmtypes.GenesisDoc, error) {
+	authState := authtypes.GetGenesisStateFromAppState(b.cdc, b.appState)
+	accounts, err := authtypes.UnpackAccounts(authState.Accounts)
+	if err != nil {
+		return nil, fmt.Errorf("error unpacking genesis accounts: %w", err)
+	}
+	bankState := banktypes.GetGenesisStateFromAppState(b.cdc, b.appState)
+	for _, address := range b.addresses {
+		addr, err := sdk.AccAddressFromBech32(address)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, authtypes.NewBaseAccount(addr, nil, 0, 0))
+		coins := b.accounts[address]
+		bankState.Balances = append(bankState.Balances, banktypes.Balance{Address: address, Coins: coins})
+		bankState.Supply = bankState.Supply.Add(coins...)
+	}
+	accounts = authtypes.SanitizeGenesisAccounts(accounts)
+	packedAccounts, err := authtypes.PackAccounts(accounts)
+	if err != nil {
+		return nil, fmt.Errorf("error packing genesis accounts: %w", err)
+	}
+	authState.Accounts = packedAccounts
+	bankState.Balances = banktypes.SanitizeGenesisBalances(bankState.Balances)
+
+	appState := make(map[string]json.RawMessage)
+	for module, raw := range b.appState {
+		appState[module] = raw
+	}
+	if appState[authtypes.ModuleName], err = b.cdc.MarshalJSON(&authState); err != nil {
+		return nil, err
+	}
+	if appState[banktypes.ModuleName], err = b.cdc.MarshalJSON(bankState); err != nil {
+		return nil, err
+	}
+	if appState[types.ModuleName], err = b.cdc.MarshalJSON(b.pylons); err != nil {
+		return nil, err
+	}
+	if err = app.ModuleBasics.ValidateGenesis(b.cdc, app.MakeEncodingConfig().TxConfig, appState); err != nil {
+		return nil, fmt.Errorf("invalid genesis state: %w", err)
+	}
+
+	genDoc := *b.genDoc
+	if genDoc.AppState, err = json.MarshalIndent(appState, "", " "); err != nil {
+		return nil, err
+	}
+	if err = genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+	return &genDoc, nil
+}
+
+// WriteFile is a function to build genesis and write it to genesis file e.g. config/genesis.json of node home
+func (b *GenesisBuilder) WriteFile(genesisFile string) error {
+	genDoc, err := b.Build()
+	if err != nil {
+		return err
+	}
+	return genDoc.SaveAs(genesisFile)
+}
@@ -0,0 +1,64 @@
+package inttest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestGenesisBuilder(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	owner := "cosmos105wr8t6y97rwv90xzhxd4juj4lsajtjaass6h7"
+	b := NewGenesisBuilder("pylonschain")
+	t.MustNil(b.AddAccount(owner, sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 1000000))), "error adding account")
+	t.MustTrue(b.AddAccount(owner, sdk.NewCoins()) != nil, "duplicated account should be rejected")
+	t.MustTrue(b.AddAccount("invalid_address", sdk.NewCoins()) != nil, "invalid address should be rejected")
+
+	t.MustTrue(b.AddCookbook(types.Cookbook{ID: "genesis_cookbook", Sender: "cosmos1unknown"}) != nil, "cookbook of unknown account should be rejected")
+	t.MustNil(b.AddCookbook(types.Cookbook{ID: "genesis_cookbook", Name: "genesis cookbook", Sender: owner, Level: 1}), "error adding cookbook")
+
+	recipe := types.Recipe{
+		ID:          "genesis_recipe",
+		CookbookID:  "genesis_cookbook",
+		Name:        "genesis recipe",
+		Description: "recipe created on genesis of the chain",
+		Entries:     types.EntriesList{CoinOutputs: []types.CoinOutput{{ID: "coin", Coin: "genesiscoin", Count: "1"}}},
+		Outputs:     types.WeightedOutputsList{{EntryIDs: []string{"coin"}, Weight: "1"}},
+		Sender:      owner,
+	}
+	t.MustNil(b.AddRecipe(recipe), "error adding recipe")
+	invalidRecipe := recipe
+	invalidRecipe.ID = "invalid_recipe"
+	invalidRecipe.Description = "short"
+	t.MustTrue(b.AddRecipe(invalidRecipe) != nil, "recipe not passing basic validation should be rejected")
+	invalidRecipe.CookbookID = "unknown_cookbook"
+	t.MustTrue(b.AddRecipe(invalidRecipe) != nil, "recipe of unknown cookbook should be rejected")
+
+	item := types.Item{ID: "genesis_item", CookbookID: "genesis_cookbook", Sender: owner, Tradable: true}
+	t.MustNil(b.AddItem(item), "error adding item")
+	t.MustTrue(b.AddItem(item) != nil, "duplicated item should be rejected")
+
+	tmpDir, err := ioutil.TempDir("", "pylons_genesis")
+	t.MustNil(err, "error creating temp directory")
+	defer os.RemoveAll(tmpDir)
+	genesisFile := filepath.Join(tmpDir, "genesis.json")
+	t.MustNil(b.WriteFile(genesisFile), "error writing genesis file")
+
+	loaded, err := LoadGenesisBuilder(genesisFile)
+	t.MustNil(err, "error loading genesis file")
+	t.MustTrue(loaded.pylons.Cookbooks[0].ID == "genesis_cookbook", "cookbook should be loaded from genesis file")
+	t.MustTrue(loaded.pylons.Recipes[0].ID == "genesis_recipe", "recipe should be loaded from genesis file")
+	t.MustTrue(loaded.pylons.Items[0].ID == "genesis_item", "item should be loaded from genesis file")
+	t.MustTrue(loaded.AddAccount(owner, sdk.NewCoins()) != nil, "account of loaded genesis should be rejected as duplicate")
+	t.MustTrue(loaded.AddItem(item) != nil, "item of loaded genesis should be rejected as duplicate")
+
+	genDoc, err := loaded.Build()
+	t.MustNil(err, "error building loaded genesis")
+	t.MustTrue(genDoc.ChainID == "pylonschain", "chain ID should be kept")
+}
@@ -0,0 +1,66 @@
+package inttest
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// ErrGoldenMismatch is an error of output which differs from its golden file
+var ErrGoldenMismatch = errors.New("output differs from golden file")
+
+// UpdateGoldens is a variable to write outputs into golden files instead of comparing them, set by -update-goldens flag
+var UpdateGoldens bool
+
+func init() {
+	flag.BoolVar(&UpdateGoldens, "update-goldens", false, "write outputs into golden files instead of comparing them e.g. after intended encoding changes")
+}
+
+// CompareGolden is a function to compare output against golden file, golden file is written when UpdateGoldens is set
+// Line endings are normalized so goldens checked out on windows still match.
+func CompareGolden(goldenPath string, output []byte) error {
+	if UpdateGoldens {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0755); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(goldenPath, output, 0644)
+	}
+	golden, err := ioutil.ReadFile(goldenPath)
+	if err != nil {
+		return fmt.Errorf("error reading golden file, run with -update-goldens to create it: %w", err)
+	}
+	golden = bytes.ReplaceAll(golden, []byte("\r\n"), []byte("\n"))
+	if bytes.Equal(golden, output) {
+		return nil
+	}
+	expectedLines := strings.Split(string(golden), "\n")
+	actualLines := strings.Split(string(output), "\n")
+	for idx := 0; idx < len(expectedLines) || idx < len(actualLines); idx++ {
+		expected, actual := "<none>", "<none>"
+		if idx < len(expectedLines) {
+			expected = expectedLines[idx]
+		}
+		if idx < len(actualLines) {
+			actual = actualLines[idx]
+		}
+		if expected != actual {
+			return fmt.Errorf("%w %s at line %d: expected %s, got %s", ErrGoldenMismatch, goldenPath, idx+1, strings.TrimSpace(expected), strings.TrimSpace(actual))
+		}
+	}
+	return fmt.Errorf("%w %s", ErrGoldenMismatch, goldenPath)
+}
+
+// MustMatchGolden is a function to fail the test when output differs from golden file
+func MustMatchGolden(t *testing.T, goldenPath string, output []byte) {
+	t.WithFields(testing.Fields{
+		"golden": goldenPath,
+		"update": "rerun with -update-goldens when the change is intended",
+	}).MustNil(CompareGolden(goldenPath, output), "output should match golden file")
+}
@@ -0,0 +1,77 @@
+package inttest
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+func TestCompareGolden(originT *originT.T) {
+	t := testing.NewT(originT)
+	tmpDir, err := ioutil.TempDir("", "pylons_golden")
+	t.MustNil(err, "error creating temp dir")
+	defer os.RemoveAll(tmpDir)
+	goldenPath := filepath.Join(tmpDir, "payload.golden.json")
+	prevUpdate := UpdateGoldens
+	defer func() { UpdateGoldens = prevUpdate }()
+	UpdateGoldens = false
+
+	err = CompareGolden(goldenPath, []byte("{}\n"))
+	t.MustTrue(err != nil && !errors.Is(err, ErrGoldenMismatch), "missing golden file should fail to be read")
+
+	UpdateGoldens = true
+	t.MustNil(CompareGolden(goldenPath, []byte("{\n  \"a\": 1\n}\n")), "golden file should be written on update")
+	UpdateGoldens = false
+
+	t.MustNil(ioutil.WriteFile(goldenPath, []byte("{\r\n  \"a\": 1\r\n}\r\n"), 0644), "error writing golden file")
+	t.MustNil(CompareGolden(goldenPath, []byte("{\n  \"a\": 1\n}\n")), "golden file of crlf line endings should match")
+	err = CompareGolden(goldenPath, []byte("{\n  \"a\": 2\n}\n"))
+	t.MustTrue(errors.Is(err, ErrGoldenMismatch), "different output should not match")
+	t.MustContain(err.Error(), `line 2: expected "a": 1, got "a": 2`, "mismatch should describe the first differing line")
+}
+
+func TestTxPayloadGoldens(originT *originT.T) {
+	t := testing.NewT(originT)
+	prevProfile := CLIOpts.Profile
+	defer func() { CLIOpts.Profile = prevProfile }()
+	CLIOpts.Profile = "" // fee and gas of goldens are those without chain profile
+
+	privKey := secp256k1.GenPrivKeyFromSecret([]byte("pylons_sdk tx payload golden"))
+	sender := sdk.AccAddress(privKey.PubKey().Address()).String()
+	receiver := sdk.AccAddress(secp256k1.GenPrivKeyFromSecret([]byte("pylons_sdk receiver")).PubKey().Address()).String()
+	signerData := authsigning.SignerData{
+		ChainID:       "pylons-testnet",
+		AccountNumber: 3,
+		Sequence:      7,
+	}
+	getPylons := types.NewMsgGetPylons(types.PremiumTier.Fee, sender)
+	createCookbook := types.NewMsgCreateCookbook("Golden Cookbook", "golden_cookbook", "cookbook of tx payload goldens", "SDKCookbookTester", "1.0.0", "example@example.com", 1, types.DefaultCostPerBlock, sender)
+	sendCoins := types.NewMsgSendCoins(sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 10)), sender, receiver)
+
+	cases := []struct {
+		name string
+		msgs []sdk.Msg
+		opts TxOptions
+	}{
+		{"get_pylons", []sdk.Msg{&getPylons}, TxOptions{}},
+		{"create_cookbook", []sdk.Msg{&createCookbook}, TxOptions{}},
+		{"send_coins_with_memo", []sdk.Msg{&sendCoins, &getPylons}, TxOptions{Memo: "golden memo"}},
+	}
+	for _, c := range cases {
+		payload, err := GenTxPayload(c.msgs, privKey.PubKey(), signerData, c.opts)
+		t.WithFields(testing.Fields{
+			"case": c.name,
+		}).MustNil(err, "error generating tx payload")
+		output, err := payload.MarshalGolden()
+		t.MustNil(err, "error encoding tx payload")
+		MustMatchGolden(&t, filepath.Join("testdata", "tx_payload", c.name+".golden.json"), output)
+	}
+}
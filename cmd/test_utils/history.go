@@ -0,0 +1,229 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/events"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// HistoryTx is a struct to describe committed transaction with its decoded msgs for post-mortem analysis
+type HistoryTx struct {
+	Height  int64
+	Index   int
+	TxHash  string
+	Code    uint32
+	Log     string
+	Memo    string
+	Signers []string
+	Msgs    []sdk.Msg
+	// Events are typed events of the transaction result, events which can't be decoded are skipped
+	Events []events.Event
+}
+
+// historyRPC is an interface of tendermint rpc queries used to fetch history, implemented by rpc http client
+type historyRPC interface {
+	Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error)
+	BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
+	TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (*ctypes.ResultTxSearch, error)
+}
+
+// newHistoryRPC is a function to connect tendermint rpc of the first node
+func newHistoryRPC() (historyRPC, error) {
+	rpcClient, err := rpchttp.New(firstNode(), "/websocket")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeUnavailable, err.Error())
+	}
+	return rpcClient, nil
+}
+
+// decodeHistoryTx is a function to decode transaction bytes with its result into HistoryTx
+// Transactions which can't be decoded e.g. of other apps are kept without msgs, and the decoding error is put in Log.
+func decodeHistoryTx(height int64, index int, tx tmtypes.Tx, result abci.ResponseDeliverTx) HistoryTx {
+	historyTx := HistoryTx{
+		Height: height,
+		Index:  index,
+		TxHash: fmt.Sprintf("%X", tx.Hash()),
+		Code:   result.Code,
+		Log:    result.Log,
+		Events: decodeHistoryEvents(result.Events),
+	}
+	decoded, err := app.MakeEncodingConfig().TxConfig.TxDecoder()(tx)
+	if err != nil {
+		historyTx.Log = fmt.Sprintf("error decoding transaction: %s", err.Error())
+		return historyTx
+	}
+	historyTx.Msgs = decoded.GetMsgs()
+	if memoTx, ok := decoded.(sdk.TxWithMemo); ok {
+		historyTx.Memo = memoTx.GetMemo()
+	}
+	if sigTx, ok := decoded.(authsigning.SigVerifiableTx); ok {
+		for _, signer := range sigTx.GetSigners() {
+			historyTx.Signers = append(historyTx.Signers, signer.String())
+		}
+	}
+	return historyTx
+}
+
+// decodeHistoryEvents is a function to decode abci events of transaction result one by one
+// An event which can't be decoded is skipped so that other events of the transaction are kept.
+func decodeHistoryEvents(abciEvents []abci.Event) []events.Event {
+	decoded := []events.Event{}
+	for _, abciEvent := range abciEvents {
+		typed, err := events.DecodeABCIEvents([]abci.Event{abciEvent})
+		if err != nil {
+			continue
+		}
+		decoded = append(decoded, typed...)
+	}
+	return decoded
+}
+
+// getBlockTxs is a function to get decoded transactions of block at height in block order
+func getBlockTxs(ctx context.Context, rpc historyRPC, height int64) ([]HistoryTx, error) {
+	block, err := rpc.Block(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("error getting block %d: %w", height, err)
+	}
+	results, err := rpc.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("error getting results of block %d: %w", height, err)
+	}
+	historyTxs := []HistoryTx{}
+	for idx, tx := range block.Block.Data.Txs {
+		result := abci.ResponseDeliverTx{}
+		if idx < len(results.TxsResults) && results.TxsResults[idx] != nil {
+			result = *results.TxsResults[idx]
+		}
+		historyTxs = append(historyTxs, decodeHistoryTx(height, idx, tx, result))
+	}
+	return historyTxs, nil
+}
+
+// GetBlockTxs is a function to get transactions of block at height with decoded msgs and results
+func GetBlockTxs(height int64) ([]HistoryTx, error) {
+	return GetBlockTxsCtx(context.Background(), height)
+}
+
+// GetBlockTxsCtx is a function to get transactions of block at height, it's canceled when ctx is done
+func GetBlockTxsCtx(ctx context.Context, height int64) ([]HistoryTx, error) {
+	rpc, err := newHistoryRPC()
+	if err != nil {
+		return nil, err
+	}
+	return getBlockTxs(ctx, rpc, height)
+}
+
+// senderTxQuery is a function to get tx search query of transactions having msgs sent by address
+func senderTxQuery(addr string) string {
+	return fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeySender, addr)
+}
+
+// searchTxs is a function to get a page of transactions matching tx search query in commit order
+func searchTxs(ctx context.Context, rpc historyRPC, query string, page, limit int) ([]HistoryTx, int, error) {
+	res, err := rpc.TxSearch(ctx, query, false, &page, &limit, "asc")
+	if err != nil {
+		return nil, 0, err
+	}
+	historyTxs := []HistoryTx{}
+	for _, tx := range res.Txs {
+		historyTxs = append(historyTxs, decodeHistoryTx(tx.Height, int(tx.Index), tx.Tx, tx.TxResult))
+	}
+	return historyTxs, res.TotalCount, nil
+}
+
+// searchTxsBySender is a function to get a page of transactions having msgs sent by address in commit order
+func searchTxsBySender(ctx context.Context, rpc historyRPC, addr string, page, limit int) ([]HistoryTx, int, error) {
+	historyTxs, total, err := searchTxs(ctx, rpc, senderTxQuery(addr), page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error searching transactions of %s: %w", addr, err)
+	}
+	return historyTxs, total, nil
+}
+
+// SearchTxsBySender is a function to get a page of transactions sent by address, page starts from 1
+// It returns total count of the transactions so that callers can fetch next pages.
+func SearchTxsBySender(addr string, page, limit int) ([]HistoryTx, int, error) {
+	return SearchTxsBySenderCtx(context.Background(), addr, page, limit)
+}
+
+// SearchTxsBySenderCtx is a function to get a page of transactions sent by address, it's canceled when ctx is done
+func SearchTxsBySenderCtx(ctx context.Context, addr string, page, limit int) ([]HistoryTx, int, error) {
+	rpc, err := newHistoryRPC()
+	if err != nil {
+		return nil, 0, err
+	}
+	return searchTxsBySender(ctx, rpc, addr, page, limit)
+}
+
+// getTxHistory is a function to get transactions of blocks from fromHeight to toHeight inclusively
+func getTxHistory(ctx context.Context, rpc historyRPC, fromHeight, toHeight int64) ([]HistoryTx, error) {
+	historyTxs := []HistoryTx{}
+	for height := fromHeight; height <= toHeight; height++ {
+		blockTxs, err := getBlockTxs(ctx, rpc, height)
+		if err != nil {
+			return historyTxs, err
+		}
+		historyTxs = append(historyTxs, blockTxs...)
+	}
+	return historyTxs, nil
+}
+
+// historyArtifactTx is a struct to write HistoryTx with msgs in proto json
+type historyArtifactTx struct {
+	Height  int64             `json:"height"`
+	Index   int               `json:"index"`
+	TxHash  string            `json:"txhash"`
+	Code    uint32            `json:"code"`
+	Log     string            `json:"log,omitempty"`
+	Memo    string            `json:"memo,omitempty"`
+	Signers []string          `json:"signers,omitempty"`
+	Msgs    []json.RawMessage `json:"msgs"`
+}
+
+// AttachTxHistory is a function to attach transactions committed from fromHeight to toHeight to the test report
+// e.g. blocks of a failed scenario, so that what happened on chain can be reconstructed after the run.
+func AttachTxHistory(ctx context.Context, t *testing.T, fromHeight, toHeight int64) error {
+	rpc, err := newHistoryRPC()
+	if err != nil {
+		return err
+	}
+	return attachTxHistory(ctx, t, rpc, fromHeight, toHeight)
+}
+
+func attachTxHistory(ctx context.Context, t *testing.T, rpc historyRPC, fromHeight, toHeight int64) error {
+	historyTxs, err := getTxHistory(ctx, rpc, fromHeight, toHeight)
+	artifactTxs := []historyArtifactTx{}
+	for _, historyTx := range historyTxs {
+		artifactTx := historyArtifactTx{
+			Height:  historyTx.Height,
+			Index:   historyTx.Index,
+			TxHash:  historyTx.TxHash,
+			Code:    historyTx.Code,
+			Log:     historyTx.Log,
+			Memo:    historyTx.Memo,
+			Signers: historyTx.Signers,
+			Msgs:    []json.RawMessage{},
+		}
+		for _, msg := range historyTx.Msgs {
+			msgJSON, err := GetJSONMarshaler().MarshalJSON(msg)
+			if err != nil {
+				msgJSON, _ = json.Marshal(AminoCodecFormatter(msg))
+			}
+			artifactTx.Msgs = append(artifactTx.Msgs, msgJSON)
+		}
+		artifactTxs = append(artifactTxs, artifactTx)
+	}
+	// blocks fetched before an error are attached as they are still useful
+	t.AttachJSON(fmt.Sprintf("tx_history_%d_%d.json", fromHeight, toHeight), artifactTxs)
+	return err
+}
@@ -0,0 +1,72 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+	originT "testing"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+type fakeHistoryRPC struct {
+	blocks  map[int64]tmtypes.Txs
+	results map[int64][]*abci.ResponseDeliverTx
+	query   string
+}
+
+func (r *fakeHistoryRPC) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	return &ctypes.ResultBlock{Block: &tmtypes.Block{Data: tmtypes.Data{Txs: r.blocks[*height]}}}, nil
+}
+
+func (r *fakeHistoryRPC) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	return &ctypes.ResultBlockResults{Height: *height, TxsResults: r.results[*height]}, nil
+}
+
+func (r *fakeHistoryRPC) TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	r.query = query
+	res := &ctypes.ResultTxSearch{}
+	for height, txs := range r.blocks {
+		for idx, tx := range txs {
+			res.Txs = append(res.Txs, &ctypes.ResultTx{Height: height, Index: uint32(idx), Tx: tx, TxResult: *r.results[height][idx]})
+		}
+	}
+	res.TotalCount = len(res.Txs)
+	return res, nil
+}
+
+func TestTxHistory(originT *originT.T) {
+	t := testing.NewT(originT)
+	player := sdk.AccAddress([]byte("history_player______")).String()
+	txModel, err := GenTxWithOptions([]sdk.Msg{&types.MsgGetPylons{Amount: sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 10)), Requester: player}}, TxOptions{Memo: "scenario"})
+	t.MustNil(err, "error generating transaction")
+	txBytes, err := app.MakeEncodingConfig().TxConfig.TxEncoder()(txModel)
+	t.MustNil(err, "error encoding transaction")
+
+	rpc := &fakeHistoryRPC{
+		blocks:  map[int64]tmtypes.Txs{5: {txBytes, []byte("not a tx")}},
+		results: map[int64][]*abci.ResponseDeliverTx{5: {{Code: 0}, {Code: 2, Log: "tx parse error"}}},
+	}
+	historyTxs, err := getBlockTxs(context.Background(), rpc, 5)
+	t.MustNil(err, "error getting block transactions")
+	t.MustTrue(len(historyTxs) == 2, "all transactions of block should be returned")
+	t.MustTrue(historyTxs[0].Memo == "scenario" && historyTxs[0].Signers[0] == player, "memo and signers should be decoded")
+	msg, ok := historyTxs[0].Msgs[0].(*types.MsgGetPylons)
+	t.MustTrue(ok && msg.Requester == player, "pylons msg should be decoded")
+	t.MustTrue(historyTxs[0].TxHash == fmt.Sprintf("%X", tmtypes.Tx(txBytes).Hash()), "txhash should be hash of transaction bytes")
+	t.MustTrue(historyTxs[1].Code == 2 && len(historyTxs[1].Msgs) == 0, "transaction which can't be decoded should be kept with its result")
+
+	historyTxs, total, err := searchTxsBySender(context.Background(), rpc, player, 1, 10)
+	t.MustNil(err, "error searching transactions")
+	t.MustTrue(rpc.query == "message.sender='"+player+"'", "transactions should be searched by sender of message event")
+	t.MustTrue(total == 2 && historyTxs[0].Height == 5, "search should return transactions with heights")
+
+	historyTxs, err = getTxHistory(context.Background(), rpc, 4, 6)
+	t.MustNil(err, "error getting transaction history")
+	t.MustTrue(len(historyTxs) == 2, "history should have transactions of all blocks in the window")
+}
@@ -0,0 +1,26 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestIAPSigner(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	signer := LocalIAPSigner()
+	receipt, err := NewIAPReceipt("pylons_55000")
+	t.MustNil(err, "error creating receipt of configured product")
+	t.MustTrue(receipt.PackageName == "com.pylons.loud", "package name should come from product configuration")
+	msg, err := signer.GetPylonsMsg(receipt, "cosmos105wr8t6y97rwv90xzhxd4juj4lsajtjaass6h7")
+	t.MustNil(err, "error signing receipt")
+	t.MustTrue(msg.ValidateBasic() != nil, "receipt signed by untrusted key should fail validation")
+
+	restore := signer.Trust()
+	t.MustNil(msg.ValidateBasic(), "receipt signed by trusted key should pass validation")
+	restore()
+
+	_, err = NewIAPReceipt("unknown_product")
+	t.MustTrue(err != nil, "receipt of unknown product should not be created")
+}
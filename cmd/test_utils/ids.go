@@ -0,0 +1,244 @@
+package inttest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// maxIDLength is the max length of ids accepted by ParseXXXID, generated ids are address and uuid
+const maxIDLength = 128
+
+// ErrInvalidID is returned when an id is empty or has characters which break commands and urls using it
+var ErrInvalidID = errors.New("invalid id")
+
+// CookbookID is the id of a cookbook
+type CookbookID string
+
+// RecipeID is the id of a recipe
+type RecipeID string
+
+// ItemID is the id of an item
+type ItemID string
+
+// TradeID is the id of a trade
+type TradeID string
+
+// ExecutionID is the id of an execution
+type ExecutionID string
+
+// validateID is a function to check id of kind e.g. "recipe" can be passed to pylonsd and rest queries
+// Whitespace, commas and slashes are refused as they split command arguments, item lists and url paths.
+func validateID(kind string, id string) error {
+	if len(id) == 0 {
+		return fmt.Errorf("%w: %s id is empty", ErrInvalidID, kind)
+	}
+	if len(id) > maxIDLength {
+		return fmt.Errorf("%w: %s id %s is longer than %d", ErrInvalidID, kind, id, maxIDLength)
+	}
+	for _, r := range id {
+		if unicode.IsSpace(r) || !unicode.IsPrint(r) || r == ',' || r == '/' {
+			return fmt.Errorf("%w: %s id %q has character %q", ErrInvalidID, kind, id, r)
+		}
+	}
+	return nil
+}
+
+// parseID is a function to trim and validate id of kind
+func parseID(kind string, s string) (string, error) {
+	id := strings.TrimSpace(s)
+	return id, validateID(kind, id)
+}
+
+// unmarshalID is a function to decode id of kind from json string as fixtures write it
+// Empty string is accepted so that optional ids of fixtures can be left out.
+func unmarshalID(kind string, bz []byte) (string, error) {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return "", fmt.Errorf("%s id should be a string: %w", kind, err)
+	}
+	if len(s) == 0 {
+		return s, nil
+	}
+	return parseID(kind, s)
+}
+
+// ParseCookbookID is a function to parse and validate cookbook id
+func ParseCookbookID(s string) (CookbookID, error) {
+	id, err := parseID("cookbook", s)
+	return CookbookID(id), err
+}
+
+// String is a function to get cookbook id as string
+func (id CookbookID) String() string {
+	return string(id)
+}
+
+// Validate is a function to check cookbook id
+func (id CookbookID) Validate() error {
+	return validateID("cookbook", string(id))
+}
+
+// UnmarshalJSON is a function to decode cookbook id from json string
+func (id *CookbookID) UnmarshalJSON(bz []byte) error {
+	s, err := unmarshalID("cookbook", bz)
+	*id = CookbookID(s)
+	return err
+}
+
+// ParseRecipeID is a function to parse and validate recipe id
+func ParseRecipeID(s string) (RecipeID, error) {
+	id, err := parseID("recipe", s)
+	return RecipeID(id), err
+}
+
+// String is a function to get recipe id as string
+func (id RecipeID) String() string {
+	return string(id)
+}
+
+// Validate is a function to check recipe id
+func (id RecipeID) Validate() error {
+	return validateID("recipe", string(id))
+}
+
+// UnmarshalJSON is a function to decode recipe id from json string
+func (id *RecipeID) UnmarshalJSON(bz []byte) error {
+	s, err := unmarshalID("recipe", bz)
+	*id = RecipeID(s)
+	return err
+}
+
+// ParseItemID is a function to parse and validate item id
+func ParseItemID(s string) (ItemID, error) {
+	id, err := parseID("item", s)
+	return ItemID(id), err
+}
+
+// String is a function to get item id as string
+func (id ItemID) String() string {
+	return string(id)
+}
+
+// Validate is a function to check item id
+func (id ItemID) Validate() error {
+	return validateID("item", string(id))
+}
+
+// UnmarshalJSON is a function to decode item id from json string
+func (id *ItemID) UnmarshalJSON(bz []byte) error {
+	s, err := unmarshalID("item", bz)
+	*id = ItemID(s)
+	return err
+}
+
+// ParseTradeID is a function to parse and validate trade id
+func ParseTradeID(s string) (TradeID, error) {
+	id, err := parseID("trade", s)
+	return TradeID(id), err
+}
+
+// String is a function to get trade id as string
+func (id TradeID) String() string {
+	return string(id)
+}
+
+// Validate is a function to check trade id
+func (id TradeID) Validate() error {
+	return validateID("trade", string(id))
+}
+
+// UnmarshalJSON is a function to decode trade id from json string
+func (id *TradeID) UnmarshalJSON(bz []byte) error {
+	s, err := unmarshalID("trade", bz)
+	*id = TradeID(s)
+	return err
+}
+
+// ParseExecutionID is a function to parse and validate execution id
+func ParseExecutionID(s string) (ExecutionID, error) {
+	id, err := parseID("execution", s)
+	return ExecutionID(id), err
+}
+
+// String is a function to get execution id as string
+func (id ExecutionID) String() string {
+	return string(id)
+}
+
+// Validate is a function to check execution id
+func (id ExecutionID) Validate() error {
+	return validateID("execution", string(id))
+}
+
+// UnmarshalJSON is a function to decode execution id from json string
+func (id *ExecutionID) UnmarshalJSON(bz []byte) error {
+	s, err := unmarshalID("execution", bz)
+	*id = ExecutionID(s)
+	return err
+}
+
+// ItemIDStrings is a function to validate item ids and get them as strings
+func ItemIDStrings(ids []ItemID) ([]string, error) {
+	strs := []string{}
+	for _, id := range ids {
+		if err := id.Validate(); err != nil {
+			return strs, err
+		}
+		strs = append(strs, id.String())
+	}
+	return strs, nil
+}
+
+// GetCookbook is a function to get cookbook of validated id
+func GetCookbook(id CookbookID, opts ...QueryOption) (types.Cookbook, error) {
+	if err := id.Validate(); err != nil {
+		return types.Cookbook{}, err
+	}
+	return GetCookbookByGUID(id.String(), opts...)
+}
+
+// GetRecipe is a function to get recipe of validated id
+func GetRecipe(id RecipeID, opts ...QueryOption) (types.Recipe, error) {
+	if err := id.Validate(); err != nil {
+		return types.Recipe{}, err
+	}
+	return GetRecipeByGUID(id.String(), opts...)
+}
+
+// GetItem is a function to get item of validated id
+func GetItem(id ItemID, opts ...QueryOption) (types.Item, error) {
+	if err := id.Validate(); err != nil {
+		return types.Item{}, err
+	}
+	return GetItemByGUID(id.String(), opts...)
+}
+
+// GetItems is a function to get items of validated ids in the same order
+func GetItems(ids []ItemID) ([]types.Item, error) {
+	strs, err := ItemIDStrings(ids)
+	if err != nil {
+		return nil, err
+	}
+	return GetItemsByGUID(strs)
+}
+
+// GetTrade is a function to get trade of validated id
+func GetTrade(id TradeID) (types.Trade, error) {
+	if err := id.Validate(); err != nil {
+		return types.Trade{}, err
+	}
+	return GetTradeByGUID(id.String())
+}
+
+// GetExecution is a function to get execution of validated id
+func GetExecution(id ExecutionID, opts ...QueryOption) (types.GetExecutionResponse, error) {
+	if err := id.Validate(); err != nil {
+		return types.GetExecutionResponse{}, err
+	}
+	return GetExecutionByGUID(id.String(), opts...)
+}
@@ -0,0 +1,67 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestParseIDs(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	rcpID, err := ParseRecipeID(" cosmos1sender6f1b2c6e-1f1e-4b5a-9b35-7a5b6a0c3d21\n")
+	t.MustNil(err, "error parsing generated recipe id")
+	t.MustTrue(rcpID.String() == "cosmos1sender6f1b2c6e-1f1e-4b5a-9b35-7a5b6a0c3d21", "parsed id should be trimmed")
+	cbID, err := ParseCookbookID("LOUD-v0.1.0-1579053457")
+	t.MustTrue(err == nil && cbID.Validate() == nil, "named cookbook id should be valid")
+
+	for _, invalid := range []string{"", "  ", "item1,item2", "rcp 1", "../get_item", "rcp\x00"} {
+		_, err := ParseItemID(invalid)
+		t.WithFields(testing.Fields{
+			"id":    invalid,
+			"error": err,
+		}).MustTrue(errors.Is(err, ErrInvalidID), "invalid id should be refused")
+	}
+	t.MustTrue(errors.Is(TradeID("").Validate(), ErrInvalidID), "empty trade id should be invalid")
+	_, err = GetExecution(ExecutionID("exec 1"))
+	t.MustTrue(errors.Is(err, ErrInvalidID), "query of invalid id should fail before querying")
+}
+
+func TestIDJSON(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	var fixture struct {
+		CookbookID CookbookID `json:"CookbookID"`
+		RecipeID   RecipeID   `json:"RecipeID"`
+		ItemIDs    []ItemID   `json:"ItemIDs"`
+	}
+	err := json.Unmarshal([]byte(`{"CookbookID": "cb1", "RecipeID": "", "ItemIDs": ["item1", "item2"]}`), &fixture)
+	t.MustNil(err, "error decoding ids of fixture")
+	t.MustTrue(fixture.CookbookID == "cb1" && fixture.RecipeID == "" && len(fixture.ItemIDs) == 2, "ids should be decoded from strings")
+	bz, err := json.Marshal(fixture)
+	t.MustNil(err, "error encoding ids")
+	t.MustTrue(string(bz) == `{"CookbookID":"cb1","RecipeID":"","ItemIDs":["item1","item2"]}`, "ids should be encoded as plain strings")
+
+	err = json.Unmarshal([]byte(`{"ItemIDs": ["item1,item2"]}`), &fixture)
+	t.MustTrue(errors.Is(err, ErrInvalidID), "invalid id of fixture should be refused")
+	err = json.Unmarshal([]byte(`{"CookbookID": 1}`), &fixture)
+	t.MustTrue(err != nil, "id of number should be refused")
+}
+
+func TestCommandBuilderIDs(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	cmd := Tx().Pylons().ExecuteRecipeByID("rcp1", "item1", "item2")
+	t.MustNil(cmd.Err(), "valid ids should be accepted")
+	t.MustTrue(cmd.args[3] == "rcp1" && cmd.args[4] == "item1,item2", "ids should be rendered as by ExecuteRecipe")
+
+	cmd = Tx().Pylons().ExecuteRecipeByID("rcp1", "item1,item2")
+	t.MustTrue(errors.Is(cmd.Err(), ErrInvalidID), "item id with comma should be refused")
+	_, _, err := cmd.Run(context.Background())
+	t.MustTrue(errors.Is(err, ErrInvalidID), "command of invalid id should not run")
+	cmd = Query().Pylons().ItemByID("")
+	t.MustTrue(errors.Is(cmd.Err(), ErrInvalidID), "empty item id should be refused")
+}
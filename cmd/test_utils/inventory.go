@@ -0,0 +1,219 @@
+package inttest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Inventory is a struct to manage items and coins of an account at a moment
+type Inventory struct {
+	Address string
+	Items   []types.Item
+	Coins   sdk.Coins
+}
+
+// InventoryDelta is a struct to describe change of an inventory
+// Items are referred by their Name string attribute, or by ID when they don't have name
+type InventoryDelta struct {
+	Coins        map[string]int64 // amount change per denom, zero changes are ignored
+	AddedItems   []string
+	RemovedItems []string
+}
+
+// TakeInventory is a function to query items and coins of address
+func TakeInventory(t *testing.T, addr string) Inventory {
+	t.InvolveAccounts(addr)
+	items, err := ListItemsViaCLI(addr)
+	t.WithFields(testing.Fields{
+		"address": addr,
+	}).MustNil(err, "error listing items of inventory")
+	return NewInventory(addr, items, GetAccountBalanceFromAddr(addr, t).Coins)
+}
+
+// CaptureInventory is a function to add items and coins of address to test report, owner is the name address is referred by
+// It's no-op unless report captures state, and query errors are only logged not to fail the test by reporting.
+func CaptureInventory(t *testing.T, owner, addr string, opts ...QueryOption) {
+	t.InvolveAccounts(addr)
+	if !testing.ReportOpts.CaptureState {
+		return
+	}
+	ctx := queryContext(opts)
+	items, err := ListItemsViaCLI(addr, opts...)
+	var coins sdk.Coins
+	if err == nil {
+		var transport Transport
+		if transport, err = EnvFromContext(ctx).Transport(); err == nil {
+			coins, err = transport.Balances(ctx, addr)
+		}
+	}
+	if err != nil {
+		t.WithFields(testing.Fields{
+			"address": addr,
+			"error":   err.Error(),
+		}).Warn("error capturing inventory for report")
+		return
+	}
+	capture := testing.StateCapture{
+		Owner:    owner,
+		Address:  addr,
+		Height:   EnvFromContext(ctx).state().blocks.latestHeight(),
+		Balances: coins.String(),
+	}
+	for _, item := range NewInventory(addr, items, coins).Items {
+		capture.Items = append(capture.Items, itemLabel(item))
+	}
+	t.CaptureState(capture)
+}
+
+// NewInventory is a function to create inventory of items owned by address and its coins
+func NewInventory(addr string, items []types.Item, coins sdk.Coins) Inventory {
+	inventory := Inventory{Address: addr, Coins: coins}
+	for _, item := range items {
+		if item.Sender == addr {
+			inventory.Items = append(inventory.Items, item)
+		}
+	}
+	sort.Slice(inventory.Items, func(i, j int) bool { return inventory.Items[i].ID < inventory.Items[j].ID })
+	return inventory
+}
+
+// itemLabel is a function to get name of item to refer it in inventory delta, ID is used for items without name
+func itemLabel(item types.Item) string {
+	if name, ok := item.FindString("Name"); ok && len(name) > 0 {
+		return name
+	}
+	return item.ID
+}
+
+// Diff is a function to get change from inventory to after inventory
+func (inv Inventory) Diff(after Inventory) InventoryDelta {
+	delta := InventoryDelta{Coins: make(map[string]int64)}
+	for _, coin := range after.Coins {
+		delta.Coins[coin.Denom] += coin.Amount.Int64()
+	}
+	for _, coin := range inv.Coins {
+		delta.Coins[coin.Denom] -= coin.Amount.Int64()
+	}
+	for denom, amount := range delta.Coins {
+		if amount == 0 {
+			delete(delta.Coins, denom)
+		}
+	}
+	beforeItems := make(map[string]bool)
+	for _, item := range inv.Items {
+		beforeItems[item.ID] = true
+	}
+	afterItems := make(map[string]bool)
+	for _, item := range after.Items {
+		afterItems[item.ID] = true
+		if !beforeItems[item.ID] {
+			delta.AddedItems = append(delta.AddedItems, itemLabel(item))
+		}
+	}
+	for _, item := range inv.Items {
+		if !afterItems[item.ID] {
+			delta.RemovedItems = append(delta.RemovedItems, itemLabel(item))
+		}
+	}
+	return delta
+}
+
+// String is a function to get readable description of inventory delta
+func (d InventoryDelta) String() string {
+	lines := []string{}
+	denoms := []string{}
+	for denom, amount := range d.Coins {
+		if amount != 0 {
+			denoms = append(denoms, denom)
+		}
+	}
+	sort.Strings(denoms)
+	for _, denom := range denoms {
+		lines = append(lines, fmt.Sprintf("coin %s %+d", denom, d.Coins[denom]))
+	}
+	for _, label := range sortedLabels(d.AddedItems) {
+		lines = append(lines, "item + "+label)
+	}
+	for _, label := range sortedLabels(d.RemovedItems) {
+		lines = append(lines, "item - "+label)
+	}
+	if len(lines) == 0 {
+		return "no change"
+	}
+	return strings.Join(lines, "\n")
+}
+
+func sortedLabels(labels []string) []string {
+	sorted := append([]string{}, labels...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// Mismatches is a function to describe how actual delta differs from expected delta, empty when they are the same
+func (d InventoryDelta) Mismatches(actual InventoryDelta) []string {
+	mismatches := []string{}
+	denoms := []string{}
+	for denom := range d.Coins {
+		denoms = append(denoms, denom)
+	}
+	for denom := range actual.Coins {
+		if _, ok := d.Coins[denom]; !ok {
+			denoms = append(denoms, denom)
+		}
+	}
+	for _, denom := range sortedLabels(denoms) {
+		if d.Coins[denom] != actual.Coins[denom] {
+			mismatches = append(mismatches, fmt.Sprintf("coin %s: expected %+d, got %+d", denom, d.Coins[denom], actual.Coins[denom]))
+		}
+	}
+	mismatches = append(mismatches, labelMismatches("added", d.AddedItems, actual.AddedItems)...)
+	mismatches = append(mismatches, labelMismatches("removed", d.RemovedItems, actual.RemovedItems)...)
+	return mismatches
+}
+
+// labelMismatches is a function to compare item labels as multisets
+func labelMismatches(change string, expected, actual []string) []string {
+	counts := make(map[string]int)
+	for _, label := range expected {
+		counts[label]++
+	}
+	for _, label := range actual {
+		counts[label]--
+	}
+	labels := []string{}
+	for label := range counts {
+		labels = append(labels, label)
+	}
+	mismatches := []string{}
+	for _, label := range sortedLabels(labels) {
+		switch count := counts[label]; {
+		case count > 0:
+			mismatches = append(mismatches, fmt.Sprintf("item %s should be %s %d more time(s)", label, change, count))
+		case count < 0:
+			mismatches = append(mismatches, fmt.Sprintf("item %s should not be %s, %d unexpected", label, change, -count))
+		}
+	}
+	return mismatches
+}
+
+// AssertInventoryChange is a function to query inventory of address and fail the test when its change from before
+// is not the expected delta, the failure shows readable expected and actual changes
+// It returns the queried inventory so that following actions can be asserted from it.
+func AssertInventoryChange(t *testing.T, addr string, before Inventory, expectedDelta InventoryDelta) Inventory {
+	after := TakeInventory(t, addr)
+	actual := before.Diff(after)
+	if mismatches := expectedDelta.Mismatches(actual); len(mismatches) > 0 {
+		t.WithFields(testing.Fields{
+			"address":    addr,
+			"expected":   "\n" + expectedDelta.String(),
+			"actual":     "\n" + actual.String(),
+			"mismatches": "\n" + strings.Join(mismatches, "\n"),
+		}).Fatal("inventory change is different from expected")
+	}
+	return after
+}
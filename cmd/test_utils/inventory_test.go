@@ -0,0 +1,46 @@
+package inttest
+
+import (
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestInventoryDiff(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	named := func(id, name string) types.Item {
+		return types.Item{ID: id, Sender: "player", Strings: []types.StringKeyValue{{Key: "Name", Value: name}}}
+	}
+	before := NewInventory("player", []types.Item{
+		named("item1", "Wooden sword"), named("item2", "Wooden sword"), {ID: "item3", Sender: "player"}, named("item4", "Shield"),
+	}, sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 100), sdk.NewInt64Coin("gold", 5)))
+	after := NewInventory("player", []types.Item{
+		named("item2", "Wooden sword"), named("item4", "Shield"), named("item5", "Copper sword"), {ID: "item6", Sender: "other"},
+	}, sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 90), sdk.NewInt64Coin("gold", 5), sdk.NewInt64Coin("silver", 3)))
+
+	delta := before.Diff(after)
+	expected := InventoryDelta{
+		Coins:        map[string]int64{types.Pylon: -10, "silver": 3},
+		AddedItems:   []string{"Copper sword"},
+		RemovedItems: []string{"item3", "Wooden sword"},
+	}
+	t.WithFields(testing.Fields{
+		"delta": delta.String(),
+	}).MustTrue(len(expected.Mismatches(delta)) == 0, "delta should have coin changes and items referred by name or ID")
+	t.MustContain(delta.String(), "coin pylon -10\ncoin silver +3\nitem + Copper sword\nitem - Wooden sword\nitem - item3", "delta should be readable")
+
+	wrong := InventoryDelta{
+		Coins:        map[string]int64{types.Pylon: -20},
+		AddedItems:   []string{"Copper sword", "Copper sword"},
+		RemovedItems: []string{"Wooden sword"},
+	}
+	mismatches := strings.Join(wrong.Mismatches(delta), "\n")
+	t.MustContain(mismatches, "coin pylon: expected -20, got -10", "coin mismatch should be shown")
+	t.MustContain(mismatches, "coin silver: expected +0, got +3", "unexpected coin change should be shown")
+	t.MustContain(mismatches, "item Copper sword should be added 1 more time(s)", "missing item addition should be shown")
+	t.MustContain(mismatches, "item item3 should not be removed, 1 unexpected", "unexpected item removal should be shown")
+}
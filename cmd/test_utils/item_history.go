@@ -0,0 +1,351 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/events"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// itemHistoryPageSize is the number of transactions fetched per tx search page while tracing items
+const itemHistoryPageSize = 100
+
+// ItemHistoryKind is a type to describe what happened to an item in an entry of its history
+type ItemHistoryKind string
+
+// kinds of item history entries
+const (
+	// ItemHistoryCreated is the entry of item created by recipe execution or fiat item
+	ItemHistoryCreated ItemHistoryKind = "created"
+	// ItemHistoryModified is the entry of item input of recipe kept as output e.g. by item modify output
+	ItemHistoryModified ItemHistoryKind = "modified"
+	// ItemHistoryLocked is the entry of item input of scheduled execution, it's modified or consumed when the execution is checked
+	ItemHistoryLocked ItemHistoryKind = "locked"
+	// ItemHistoryConsumed is the entry of item input of recipe which is not an output, item has no owner afterwards
+	ItemHistoryConsumed ItemHistoryKind = "consumed"
+	// ItemHistoryTraded is the entry of item moved by fulfilled trade
+	ItemHistoryTraded ItemHistoryKind = "traded"
+	// ItemHistoryTransferred is the entry of item sent by send items
+	ItemHistoryTransferred ItemHistoryKind = "transferred"
+	// ItemHistoryUpdated is the entry of string field of item updated by its owner
+	ItemHistoryUpdated ItemHistoryKind = "updated"
+)
+
+// ItemHistoryEntry is a struct to describe a change of item owner or item itself by a committed transaction
+// From is the owner before the entry and Owner is the one after it, Owner is empty when item is consumed.
+type ItemHistoryEntry struct {
+	Kind     ItemHistoryKind
+	Height   int64
+	TxHash   string
+	From     string
+	Owner    string
+	RecipeID string
+	ExecID   string
+	TradeID  string
+	Field    string // field and value of updated item string
+	Value    string
+}
+
+// String is a function to describe the entry e.g. "created by recipe rcp001 for cosmos1... at height 10"
+func (e ItemHistoryEntry) String() string {
+	var sb strings.Builder
+	sb.WriteString(string(e.Kind))
+	switch e.Kind {
+	case ItemHistoryCreated, ItemHistoryModified, ItemHistoryLocked, ItemHistoryConsumed:
+		if len(e.RecipeID) > 0 {
+			fmt.Fprintf(&sb, " by recipe %s", e.RecipeID)
+		}
+	case ItemHistoryTraded:
+		fmt.Fprintf(&sb, " by trade %s", e.TradeID)
+	case ItemHistoryUpdated:
+		fmt.Fprintf(&sb, " %s=%s", e.Field, e.Value)
+	}
+	if len(e.Owner) > 0 && e.Owner != e.From {
+		fmt.Fprintf(&sb, " to %s", GlobalAddressBook.Format(e.Owner))
+	}
+	fmt.Fprintf(&sb, " at height %d", e.Height)
+	return sb.String()
+}
+
+// ItemTrace is a struct to describe ownership and mutation timeline of an item in commit order
+type ItemTrace struct {
+	ItemID     string
+	CookbookID string
+	Entries    []ItemHistoryEntry
+}
+
+// Created is a function to get creation entry of the item, it's not found when creation is out of traced history
+func (t ItemTrace) Created() (ItemHistoryEntry, bool) {
+	for _, entry := range t.Entries {
+		if entry.Kind == ItemHistoryCreated {
+			return entry, true
+		}
+	}
+	return ItemHistoryEntry{}, false
+}
+
+// Owner is a function to get owner of the item after the last entry, empty when it's consumed or not traced
+func (t ItemTrace) Owner() string {
+	return t.OwnerAt(-1)
+}
+
+// OwnerAt is a function to get owner of the item after entries committed at height or earlier, -1 for the latest owner
+func (t ItemTrace) OwnerAt(height int64) string {
+	owner := ""
+	for _, entry := range t.Entries {
+		if height >= 0 && entry.Height > height {
+			break
+		}
+		owner = entry.Owner
+	}
+	return owner
+}
+
+// Owners is a function to get distinct owners of the item in the order they owned it
+func (t ItemTrace) Owners() []string {
+	owners := []string{}
+	for _, entry := range t.Entries {
+		if len(entry.Owner) > 0 && (len(owners) == 0 || owners[len(owners)-1] != entry.Owner) {
+			owners = append(owners, entry.Owner)
+		}
+	}
+	return owners
+}
+
+// Kinds is a function to get kinds of entries in order, e.g. to assert provenance like created, traded, modified
+func (t ItemTrace) Kinds() []ItemHistoryKind {
+	kinds := []ItemHistoryKind{}
+	for _, entry := range t.Entries {
+		kinds = append(kinds, entry.Kind)
+	}
+	return kinds
+}
+
+// String is a function to describe timeline of the item one entry per line
+func (t ItemTrace) String() string {
+	lines := []string{fmt.Sprintf("item %s of cookbook %s", t.ItemID, t.CookbookID)}
+	for _, entry := range t.Entries {
+		lines = append(lines, "  "+entry.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// itemTracer is a struct to build trace of an item from transactions in commit order
+type itemTracer struct {
+	trace   ItemTrace
+	owner   string
+	created bool
+}
+
+// add is a function to append entry with owner before it, the entry becomes current owner
+func (tr *itemTracer) add(tx HistoryTx, entry ItemHistoryEntry) {
+	entry.Height = tx.Height
+	entry.TxHash = tx.TxHash
+	entry.From = tr.owner
+	tr.owner = entry.Owner
+	tr.trace.Entries = append(tr.trace.Entries, entry)
+}
+
+// lockedBy is a function to check if the item is locked by scheduled execution of execID
+func (tr *itemTracer) lockedBy(execID string) bool {
+	entries := tr.trace.Entries
+	return len(entries) > 0 && entries[len(entries)-1].Kind == ItemHistoryLocked && entries[len(entries)-1].ExecID == execID
+}
+
+// create is a function to add creation entry, creation of the same transaction found by other event is merged into it
+func (tr *itemTracer) create(tx HistoryTx, entry ItemHistoryEntry) {
+	if tr.created {
+		last := &tr.trace.Entries[len(tr.trace.Entries)-1]
+		if last.Kind == ItemHistoryCreated && last.TxHash == tx.TxHash && len(last.RecipeID) == 0 {
+			last.RecipeID = entry.RecipeID
+			last.ExecID = entry.ExecID
+		}
+		return
+	}
+	tr.created = true
+	entry.Kind = ItemHistoryCreated
+	tr.add(tx, entry)
+}
+
+func (tr *itemTracer) applyEvent(tx HistoryTx, event events.Event) {
+	itemID := tr.trace.ItemID
+	switch ev := event.(type) {
+	case events.EventItemCreated:
+		if ev.ItemID == itemID {
+			tr.trace.CookbookID = ev.CookbookID
+			tr.create(tx, ItemHistoryEntry{Owner: ev.Sender})
+		}
+	case events.EventRecipeExecuted:
+		input, output := Exists(ev.InputItemIDs, itemID), Exists(ev.OutputItemIDs, itemID)
+		entry := ItemHistoryEntry{RecipeID: ev.RecipeID, ExecID: ev.ExecID, Owner: ev.Sender}
+		if len(tr.trace.CookbookID) == 0 && (input || output) {
+			tr.trace.CookbookID = ev.CookbookID
+		}
+		switch {
+		case input && output:
+			entry.Kind = ItemHistoryModified
+			tr.add(tx, entry)
+		case input && len(ev.ExecID) > 0:
+			entry.Kind = ItemHistoryLocked
+			tr.add(tx, entry)
+		case input:
+			entry.Kind = ItemHistoryConsumed
+			entry.Owner = ""
+			tr.add(tx, entry)
+		case output:
+			tr.create(tx, entry)
+		}
+	case events.EventExecutionChecked:
+		output := Exists(ev.OutputItemIDs, itemID)
+		entry := ItemHistoryEntry{RecipeID: ev.RecipeID, ExecID: ev.ExecID, Owner: ev.Sender}
+		switch {
+		case output && tr.created:
+			entry.Kind = ItemHistoryModified
+			tr.add(tx, entry)
+		case output:
+			tr.create(tx, entry)
+		case tr.lockedBy(ev.ExecID):
+			entry.Kind = ItemHistoryConsumed
+			entry.Owner = ""
+			tr.add(tx, entry)
+		}
+	case events.EventTradeFulfilled:
+		entry := ItemHistoryEntry{Kind: ItemHistoryTraded, TradeID: ev.TradeID}
+		if Exists(ev.InputItemIDs, itemID) {
+			entry.Owner = ev.Sender
+			tr.add(tx, entry)
+		} else if Exists(ev.OutputItemIDs, itemID) {
+			entry.Owner = ev.Fulfiller
+			tr.add(tx, entry)
+		}
+	}
+}
+
+func (tr *itemTracer) applyMsgs(tx HistoryTx) {
+	itemID := tr.trace.ItemID
+	for _, msg := range tx.Msgs {
+		switch msg := msg.(type) {
+		case *types.MsgSendItems:
+			if Exists(msg.ItemIDs, itemID) {
+				tr.add(tx, ItemHistoryEntry{Kind: ItemHistoryTransferred, Owner: msg.Receiver})
+			}
+		case *types.MsgUpdateItemString:
+			if msg.ItemID == itemID {
+				tr.add(tx, ItemHistoryEntry{Kind: ItemHistoryUpdated, Owner: msg.Sender, Field: msg.Field, Value: msg.Value})
+			}
+		}
+	}
+}
+
+// buildItemTrace is a function to build trace of item from transactions sorted in commit order, failed ones are skipped
+// Creation, recipes and trades are found by events, and send items and item string updates by msgs which have no events.
+func buildItemTrace(itemID string, txs []HistoryTx) ItemTrace {
+	tr := itemTracer{trace: ItemTrace{ItemID: itemID, Entries: []ItemHistoryEntry{}}}
+	for _, tx := range txs {
+		if tx.Code != 0 {
+			continue
+		}
+		for _, event := range tx.Events {
+			tr.applyEvent(tx, event)
+		}
+		tr.applyMsgs(tx)
+	}
+	return tr.trace
+}
+
+// itemTxQueries is a function to get tx search queries of events having item id
+func itemTxQueries(itemID string) []string {
+	attributes := [][2]string{
+		{events.EventTypeItemCreated, events.AttributeKeyItemID},
+		{events.EventTypeRecipeExecuted, events.AttributeKeyInputItemID},
+		{events.EventTypeRecipeExecuted, events.AttributeKeyOutputItemID},
+		{events.EventTypeExecutionChecked, events.AttributeKeyOutputItemID},
+		{events.EventTypeTradeFulfilled, events.AttributeKeyInputItemID},
+		{events.EventTypeTradeFulfilled, events.AttributeKeyOutputItemID},
+	}
+	queries := []string{}
+	for _, attr := range attributes {
+		queries = append(queries, fmt.Sprintf("%s.%s='%s'", attr[0], attr[1], itemID))
+	}
+	return queries
+}
+
+// searchAllTxs is a function to get transactions of all pages of tx search query into txs keyed by txhash
+func searchAllTxs(ctx context.Context, rpc historyRPC, query string, txs map[string]HistoryTx) error {
+	for page := 1; ; page++ {
+		pageTxs, total, err := searchTxs(ctx, rpc, query, page, itemHistoryPageSize)
+		if err != nil {
+			return fmt.Errorf("error searching transactions of %s: %w", query, err)
+		}
+		for _, tx := range pageTxs {
+			txs[tx.TxHash] = tx
+		}
+		if len(pageTxs) == 0 || page*itemHistoryPageSize >= total {
+			return nil
+		}
+	}
+}
+
+// sortedHistoryTxs is a function to get transactions in commit order
+func sortedHistoryTxs(txs map[string]HistoryTx) []HistoryTx {
+	sorted := []HistoryTx{}
+	for _, tx := range txs {
+		sorted = append(sorted, tx)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Height != sorted[j].Height {
+			return sorted[i].Height < sorted[j].Height
+		}
+		return sorted[i].Index < sorted[j].Index
+	})
+	return sorted
+}
+
+// traceItemHistory is a function to find transactions of item by its events and by msgs of its owners until no new owner is found
+// Send items and item string updates don't have item events, so transactions of each owner are searched for them.
+func traceItemHistory(ctx context.Context, rpc historyRPC, itemID string) (ItemTrace, error) {
+	txs := map[string]HistoryTx{}
+	for _, query := range itemTxQueries(itemID) {
+		if err := searchAllTxs(ctx, rpc, query, txs); err != nil {
+			return ItemTrace{ItemID: itemID}, err
+		}
+	}
+	searched := map[string]bool{}
+	for {
+		trace := buildItemTrace(itemID, sortedHistoryTxs(txs))
+		owners := []string{}
+		for _, owner := range trace.Owners() {
+			if !searched[owner] {
+				owners = append(owners, owner)
+			}
+		}
+		if len(owners) == 0 {
+			return trace, nil
+		}
+		for _, owner := range owners {
+			searched[owner] = true
+			if err := searchAllTxs(ctx, rpc, senderTxQuery(owner), txs); err != nil {
+				return trace, err
+			}
+		}
+	}
+}
+
+// TraceItemHistory is a function to reconstruct ownership and mutation timeline of item from transaction history
+// e.g. created by recipe X, traded to B and updated at height H, for provenance assertions and debugging.
+// Node should index transactions, history pruned by the node is missing from the trace.
+func TraceItemHistory(itemID string) (ItemTrace, error) {
+	return TraceItemHistoryCtx(context.Background(), itemID)
+}
+
+// TraceItemHistoryCtx is a function to reconstruct timeline of item, it's canceled when ctx is done
+func TraceItemHistoryCtx(ctx context.Context, itemID string) (ItemTrace, error) {
+	rpc, err := newHistoryRPC()
+	if err != nil {
+		return ItemTrace{ItemID: itemID}, err
+	}
+	return traceItemHistory(ctx, rpc, itemID)
+}
@@ -0,0 +1,80 @@
+package inttest
+
+import (
+	"context"
+	originT "testing"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/events"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func itemHistoryEvent(eventType string, attrs ...string) abci.Event {
+	event := abci.Event{Type: eventType}
+	for idx := 0; idx+1 < len(attrs); idx += 2 {
+		event.Attributes = append(event.Attributes, abci.EventAttribute{Key: []byte(attrs[idx]), Value: []byte(attrs[idx+1])})
+	}
+	return event
+}
+
+func TestTraceItemHistory(originT *originT.T) {
+	t := testing.NewT(originT)
+	creator := sdk.AccAddress([]byte("item_history_creator")).String()
+	trader := sdk.AccAddress([]byte("item_history_trader_")).String()
+	receiver := sdk.AccAddress([]byte("item_history_receive")).String()
+	encodeTx := func(msgs ...sdk.Msg) []byte {
+		txModel, err := GenTxWithOptions(msgs, TxOptions{})
+		t.MustNil(err, "error generating transaction")
+		txBytes, err := app.MakeEncodingConfig().TxConfig.TxEncoder()(txModel)
+		t.MustNil(err, "error encoding transaction")
+		return txBytes
+	}
+
+	sendMsg := types.NewMsgSendItems([]string{"item001"}, trader, receiver)
+	updateMsg := types.NewMsgUpdateItemString("item001", "Name", "Excalibur", receiver)
+
+	rpc := &fakeHistoryRPC{
+		blocks: map[int64]tmtypes.Txs{
+			3: {[]byte("execute recipe")},
+			4: {[]byte("fulfill trade"), []byte("failed trade")},
+			6: {encodeTx(&sendMsg)},
+			7: {encodeTx(&updateMsg)},
+			9: {[]byte("consume item")},
+		},
+		results: map[int64][]*abci.ResponseDeliverTx{
+			3: {{Events: []abci.Event{
+				itemHistoryEvent(events.EventTypeRecipeExecuted, events.AttributeKeyRecipeID, "rcp001", events.AttributeKeyCookbookID, "cbk001",
+					events.AttributeKeySender, creator, events.AttributeKeyOutputItemID, "item001"),
+				itemHistoryEvent(events.EventTypeItemCreated, events.AttributeKeyItemID, "item001", events.AttributeKeyCookbookID, "cbk001",
+					events.AttributeKeySender, creator),
+			}}},
+			4: {
+				{Events: []abci.Event{itemHistoryEvent(events.EventTypeTradeFulfilled, events.AttributeKeyTradeID, "trd001",
+					events.AttributeKeySender, creator, events.AttributeKeyFulfiller, trader, events.AttributeKeyOutputItemID, "item001")}},
+				{Code: 1, Events: []abci.Event{itemHistoryEvent(events.EventTypeTradeFulfilled, events.AttributeKeyTradeID, "trd002",
+					events.AttributeKeySender, trader, events.AttributeKeyFulfiller, creator, events.AttributeKeyInputItemID, "item001")}},
+			},
+			6: {{}},
+			7: {{}},
+			9: {{Events: []abci.Event{itemHistoryEvent(events.EventTypeRecipeExecuted, events.AttributeKeyRecipeID, "rcp002",
+				events.AttributeKeyCookbookID, "cbk001", events.AttributeKeySender, receiver, events.AttributeKeyInputItemID, "item001")}}},
+		},
+	}
+	trace, err := traceItemHistory(context.Background(), rpc, "item001")
+	t.MustNil(err, "error tracing item history")
+	t.MustTrue(trace.CookbookID == "cbk001", "cookbook of item should be found from its events")
+	kinds := trace.Kinds()
+	t.MustTrue(len(kinds) == 5 && kinds[0] == ItemHistoryCreated && kinds[1] == ItemHistoryTraded && kinds[2] == ItemHistoryTransferred &&
+		kinds[3] == ItemHistoryUpdated && kinds[4] == ItemHistoryConsumed, "failed transaction should be skipped and entries should be in commit order")
+	created, ok := trace.Created()
+	t.MustTrue(ok && created.RecipeID == "rcp001" && created.Owner == creator && created.Height == 3, "creation should be merged with recipe execution of the same transaction")
+	t.MustTrue(trace.Entries[1].TradeID == "trd001" && trace.Entries[1].From == creator && trace.Entries[1].Owner == trader, "trade output item should move to fulfiller")
+	t.MustTrue(trace.Entries[3].Field == "Name" && trace.Entries[3].Value == "Excalibur", "updated item string should be recorded")
+	t.MustTrue(trace.OwnerAt(5) == trader && trace.OwnerAt(8) == receiver && trace.Owner() == "", "owner should follow entries and consumed item should have no owner")
+	owners := trace.Owners()
+	t.MustTrue(len(owners) == 3 && owners[0] == creator && owners[2] == receiver, "owners should be in the order they owned the item")
+}
@@ -0,0 +1,310 @@
+package inttest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// itemCondition is a struct to describe a condition of item matcher
+type itemCondition struct {
+	description string
+	check       func(item types.Item) bool
+}
+
+// ItemMatcher is a struct to select and assert items by attributes instead of their index in item list
+// e.g. Match().StringAttr("Name", "sword").DoubleAttrGTE("attack", 10)
+type ItemMatcher struct {
+	conditions []itemCondition
+}
+
+// Match is a function to create item matcher which matches all items until conditions are added
+func Match() *ItemMatcher {
+	return &ItemMatcher{}
+}
+
+// with is a function to get new matcher having condition added, so a base matcher can be shared
+func (m *ItemMatcher) with(description string, check func(item types.Item) bool) *ItemMatcher {
+	conditions := append([]itemCondition{}, m.conditions...)
+	return &ItemMatcher{conditions: append(conditions, itemCondition{description, check})}
+}
+
+// floatDec is a function to convert float used in matcher to decimal of item double attribute
+func floatDec(value float64) sdk.Dec {
+	return sdk.MustNewDecFromStr(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// ID is a function to match item of id
+func (m *ItemMatcher) ID(id string) *ItemMatcher {
+	return m.with("ID == "+id, func(item types.Item) bool {
+		return item.ID == id
+	})
+}
+
+// CookbookID is a function to match items of cookbook
+func (m *ItemMatcher) CookbookID(cookbookID string) *ItemMatcher {
+	return m.with("CookbookID == "+cookbookID, func(item types.Item) bool {
+		return item.CookbookID == cookbookID
+	})
+}
+
+// Owner is a function to match items owned by address
+func (m *ItemMatcher) Owner(addr string) *ItemMatcher {
+	return m.with("Sender == "+addr, func(item types.Item) bool {
+		return item.Sender == addr
+	})
+}
+
+// Unlocked is a function to match items which are not locked by recipe execution or trade
+func (m *ItemMatcher) Unlocked() *ItemMatcher {
+	return m.with("unlocked", func(item types.Item) bool {
+		return len(item.OwnerRecipeID) == 0 && len(item.OwnerTradeID) == 0
+	})
+}
+
+// Tradable is a function to match tradable items
+func (m *ItemMatcher) Tradable() *ItemMatcher {
+	return m.with("tradable", func(item types.Item) bool {
+		return item.Tradable
+	})
+}
+
+// StringAttr is a function to match items having string attribute of value
+func (m *ItemMatcher) StringAttr(key, value string) *ItemMatcher {
+	return m.with(fmt.Sprintf("%s == %q", key, value), func(item types.Item) bool {
+		actual, ok := item.FindString(key)
+		return ok && actual == value
+	})
+}
+
+// HasAttr is a function to match items having string, long or double attribute of key
+func (m *ItemMatcher) HasAttr(key string) *ItemMatcher {
+	return m.with("has "+key, func(item types.Item) bool {
+		_, isString := item.FindString(key)
+		_, isLong := item.FindLong(key)
+		_, isDouble := item.FindDouble(key)
+		return isString || isLong || isDouble
+	})
+}
+
+// LongAttr is a function to match items having long attribute of value
+func (m *ItemMatcher) LongAttr(key string, value int64) *ItemMatcher {
+	return m.LongAttrRange(key, value, value)
+}
+
+// LongAttrGTE is a function to match items having long attribute greater than or equal to min
+func (m *ItemMatcher) LongAttrGTE(key string, min int64) *ItemMatcher {
+	return m.with(fmt.Sprintf("%s >= %d", key, min), func(item types.Item) bool {
+		actual, ok := item.FindLong(key)
+		return ok && int64(actual) >= min
+	})
+}
+
+// LongAttrLTE is a function to match items having long attribute less than or equal to max
+func (m *ItemMatcher) LongAttrLTE(key string, max int64) *ItemMatcher {
+	return m.with(fmt.Sprintf("%s <= %d", key, max), func(item types.Item) bool {
+		actual, ok := item.FindLong(key)
+		return ok && int64(actual) <= max
+	})
+}
+
+// LongAttrRange is a function to match items having long attribute between min and max inclusive
+func (m *ItemMatcher) LongAttrRange(key string, min, max int64) *ItemMatcher {
+	description := fmt.Sprintf("%d <= %s <= %d", min, key, max)
+	if min == max {
+		description = fmt.Sprintf("%s == %d", key, min)
+	}
+	return m.with(description, func(item types.Item) bool {
+		actual, ok := item.FindLong(key)
+		return ok && int64(actual) >= min && int64(actual) <= max
+	})
+}
+
+// DoubleAttrGTE is a function to match items having double attribute greater than or equal to min
+func (m *ItemMatcher) DoubleAttrGTE(key string, min float64) *ItemMatcher {
+	minDec := floatDec(min)
+	return m.with(fmt.Sprintf("%s >= %s", key, minDec), func(item types.Item) bool {
+		actual, ok := item.FindDouble(key)
+		return ok && actual.GTE(minDec)
+	})
+}
+
+// DoubleAttrLTE is a function to match items having double attribute less than or equal to max
+func (m *ItemMatcher) DoubleAttrLTE(key string, max float64) *ItemMatcher {
+	maxDec := floatDec(max)
+	return m.with(fmt.Sprintf("%s <= %s", key, maxDec), func(item types.Item) bool {
+		actual, ok := item.FindDouble(key)
+		return ok && actual.LTE(maxDec)
+	})
+}
+
+// DoubleAttrRange is a function to match items having double attribute between min and max inclusive
+func (m *ItemMatcher) DoubleAttrRange(key string, min, max float64) *ItemMatcher {
+	return m.DoubleAttrGTE(key, min).DoubleAttrLTE(key, max)
+}
+
+// Where is a function to match items by custom check described by description
+func (m *ItemMatcher) Where(description string, check func(item types.Item) bool) *ItemMatcher {
+	return m.with(description, check)
+}
+
+// Mismatches is a function to get descriptions of conditions the item does not satisfy
+func (m *ItemMatcher) Mismatches(item types.Item) []string {
+	mismatches := []string{}
+	for _, condition := range m.conditions {
+		if !condition.check(item) {
+			mismatches = append(mismatches, condition.description)
+		}
+	}
+	return mismatches
+}
+
+// Matches is a function to check if item satisfies all conditions of matcher
+func (m *ItemMatcher) Matches(item types.Item) bool {
+	return len(m.Mismatches(item)) == 0
+}
+
+// String is a function to get readable description of matcher conditions
+func (m *ItemMatcher) String() string {
+	if len(m.conditions) == 0 {
+		return "any item"
+	}
+	descriptions := []string{}
+	for _, condition := range m.conditions {
+		descriptions = append(descriptions, condition.description)
+	}
+	return strings.Join(descriptions, " && ")
+}
+
+// Filter is a function to get items matched by matcher in the same order
+func (m *ItemMatcher) Filter(items []types.Item) []types.Item {
+	matched := []types.Item{}
+	for _, item := range items {
+		if m.Matches(item) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+// First is a function to get first item matched by matcher
+func (m *ItemMatcher) First(items []types.Item) (types.Item, bool) {
+	for _, item := range items {
+		if m.Matches(item) {
+			return item, true
+		}
+	}
+	return types.Item{}, false
+}
+
+// MustMatch is a function to fail the test when item does not satisfy matcher, failure shows unsatisfied conditions
+func (m *ItemMatcher) MustMatch(t *testing.T, item types.Item) {
+	t.WithFields(testing.Fields{
+		"item_id":    item.ID,
+		"matcher":    m.String(),
+		"mismatches": m.Mismatches(item),
+	}).MustTrue(m.Matches(item), "item does not match")
+}
+
+// MustMatchAny is a function to get first item matched by matcher, it fails the test when no item matches
+func (m *ItemMatcher) MustMatchAny(t *testing.T, items []types.Item) types.Item {
+	item, ok := m.First(items)
+	t.WithFields(testing.Fields{
+		"matcher":    m.String(),
+		"item_count": len(items),
+	}).MustTrue(ok, "no item matches")
+	return item
+}
+
+// SelectItemIDs is a function to pick a different item for each matcher e.g. to feed item inputs of recipe execution
+// Items are picked in order of matchers, when a matcher has no item left, items picked by previous matchers are
+// swapped if possible, so a broad matcher listed first does not take the only item a narrow matcher accepts.
+func SelectItemIDs(items []types.Item, matchers ...*ItemMatcher) ([]string, error) {
+	pickedBy := make([]int, len(items)) // index of matcher + 1 which picked the item, 0 when not picked
+	picks := make([]int, len(matchers))
+	var pick func(matcherIdx int, visited []bool) bool
+	pick = func(matcherIdx int, visited []bool) bool {
+		for idx, item := range items {
+			if pickedBy[idx] == 0 && matchers[matcherIdx].Matches(item) {
+				pickedBy[idx], picks[matcherIdx] = matcherIdx+1, idx
+				return true
+			}
+		}
+		for idx, item := range items {
+			if visited[idx] || !matchers[matcherIdx].Matches(item) {
+				continue
+			}
+			visited[idx] = true
+			if pick(pickedBy[idx]-1, visited) {
+				pickedBy[idx], picks[matcherIdx] = matcherIdx+1, idx
+				return true
+			}
+		}
+		return false
+	}
+	for idx, matcher := range matchers {
+		if !pick(idx, make([]bool, len(items))) {
+			return nil, fmt.Errorf("no item left for matcher %d: %s", idx, matcher)
+		}
+	}
+	itemIDs := []string{}
+	for _, itemIdx := range picks {
+		itemIDs = append(itemIDs, items[itemIdx].ID)
+	}
+	return itemIDs, nil
+}
+
+// MatchItemInput is a function to create matcher of items satisfying attribute ranges, conditions and transfer fee
+// range of item input
+func MatchItemInput(itemInput types.ItemInput) *ItemMatcher {
+	m := Match().with("item input "+itemInput.ID, func(item types.Item) bool {
+		return types.CheckItemInput(item, itemInput) == nil
+	})
+	fee := itemInput.TransferFee
+	if fee.MinValue != 0 || fee.MaxValue != 0 {
+		m = m.with(fmt.Sprintf("%d <= TransferFee <= %d", fee.MinValue, fee.MaxValue), func(item types.Item) bool {
+			return item.TransferFee >= fee.MinValue && item.TransferFee <= fee.MaxValue
+		})
+	}
+	return m
+}
+
+// SelectItemsForRecipe is a function to pick unlocked items of address satisfying item inputs of recipe
+// It returns item ids in order of item inputs, ready to be used for MsgExecuteRecipe.
+func SelectItemsForRecipe(addr string, rcp types.Recipe) ([]string, error) {
+	if len(rcp.ItemInputs) == 0 {
+		return []string{}, nil
+	}
+	items, err := ListItemsViaCLI(addr)
+	if err != nil {
+		return nil, err
+	}
+	owned := Match().Owner(addr).Unlocked().CookbookID(rcp.CookbookID).Filter(items)
+	matchers := []*ItemMatcher{}
+	for _, itemInput := range rcp.ItemInputs {
+		matchers = append(matchers, MatchItemInput(itemInput))
+	}
+	itemIDs, err := SelectItemIDs(owned, matchers...)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting items of %s for recipe %s: %w", addr, rcp.ID, err)
+	}
+	return itemIDs, nil
+}
+
+// MustSelectItemIDs is a function to pick unlocked items of address for matchers, it fails the test when an item is not found
+func MustSelectItemIDs(t *testing.T, addr string, matchers ...*ItemMatcher) []string {
+	items, err := ListItemsViaCLI(addr)
+	t.WithFields(testing.Fields{
+		"address": addr,
+	}).MustNil(err, "error listing items to select")
+	unlocked := Match().Owner(addr).Unlocked().Filter(items)
+	itemIDs, err := SelectItemIDs(unlocked, matchers...)
+	t.WithFields(testing.Fields{
+		"address": addr,
+	}).MustNil(err, "error selecting items")
+	return itemIDs
+}
@@ -0,0 +1,70 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestItemMatcher(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	newItem := func(id, name string, attack string, level int64) types.Item {
+		return types.Item{
+			ID:      id,
+			Strings: []types.StringKeyValue{{Key: "Name", Value: name}},
+			Doubles: []types.DoubleKeyValue{{Key: "attack", Value: sdk.MustNewDecFromStr(attack)}},
+			Longs:   []types.LongKeyValue{{Key: "level", Value: level}},
+		}
+	}
+	items := []types.Item{
+		newItem("item1", "shield", "20", 1),
+		newItem("item2", "sword", "5.5", 1),
+		newItem("item3", "sword", "10", 2),
+		newItem("item4", "sword", "12.5", 3),
+	}
+
+	swords := Match().StringAttr("Name", "sword")
+	strongSword := swords.DoubleAttrGTE("attack", 10)
+	t.MustTrue(len(swords.Filter(items)) == 3, "base matcher should not be changed by derived matcher")
+	item, ok := strongSword.First(items)
+	t.MustTrue(ok && item.ID == "item3", "first strong sword should be matched")
+	t.WithFields(testing.Fields{
+		"matcher": strongSword.String(),
+	}).MustTrue(strongSword.String() == `Name == "sword" && attack >= 10.000000000000000000`, "matcher should be described")
+
+	mismatches := strongSword.LongAttr("level", 3).Mismatches(items[1])
+	t.WithFields(testing.Fields{
+		"mismatches": mismatches,
+	}).MustTrue(len(mismatches) == 2, "unsatisfied conditions should be listed")
+
+	itemIDs, err := SelectItemIDs(items, strongSword, strongSword, swords)
+	t.MustNil(err, "error selecting items")
+	t.WithFields(testing.Fields{
+		"item_ids": itemIDs,
+	}).MustTrue(len(itemIDs) == 3 && itemIDs[0] == "item3" && itemIDs[1] == "item4" && itemIDs[2] == "item2", "each matcher should pick a different item")
+	_, err = SelectItemIDs(items, strongSword, strongSword, strongSword)
+	t.MustTrue(err != nil, "selecting more items than matched should fail")
+}
+
+func TestSelectItemIDsSwapsPicks(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	items := []types.Item{
+		{ID: "item1", Longs: []types.LongKeyValue{{Key: "level", Value: 5}}, TransferFee: 10},
+		{ID: "item2", Longs: []types.LongKeyValue{{Key: "level", Value: 1}}, TransferFee: 10},
+	}
+	anyLevel := MatchItemInput(types.ItemInput{ID: "any", Longs: []types.LongInputParam{{Key: "level", MinValue: 1, MaxValue: 10}}})
+	highLevel := MatchItemInput(types.ItemInput{ID: "high", Longs: []types.LongInputParam{{Key: "level", MinValue: 5, MaxValue: 10}}})
+	itemIDs, err := SelectItemIDs(items, anyLevel, highLevel)
+	t.MustNil(err, "error selecting items")
+	t.WithFields(testing.Fields{
+		"item_ids": itemIDs,
+	}).MustTrue(len(itemIDs) == 2 && itemIDs[0] == "item2" && itemIDs[1] == "item1", "broad matcher should give up the only item narrow matcher accepts")
+
+	lowFee := MatchItemInput(types.ItemInput{ID: "low_fee", TransferFee: types.FeeInputParam{MinValue: 0, MaxValue: 5}})
+	_, err = SelectItemIDs(items, lowFee)
+	t.MustTrue(err != nil, "transfer fee range of item input should be checked")
+}
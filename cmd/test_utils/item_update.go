@@ -0,0 +1,96 @@
+package inttest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// ItemAttributeChange is a struct to describe change of an item attribute, values are formatted as strings
+// and Before or After is empty when attribute is added or removed
+type ItemAttributeChange struct {
+	Key    string
+	Before string
+	After  string
+}
+
+// itemAttributes is a function to get string, double and long attributes of item formatted as strings
+func itemAttributes(item types.Item) map[string]string {
+	attributes := make(map[string]string)
+	for _, kv := range item.Strings {
+		attributes[kv.Key] = kv.Value
+	}
+	for _, kv := range item.Doubles {
+		attributes[kv.Key] = kv.Value.String()
+	}
+	for _, kv := range item.Longs {
+		attributes[kv.Key] = strconv.FormatInt(kv.Value, 10)
+	}
+	return attributes
+}
+
+// ItemAttributeChanges is a function to get attributes which are changed from before item to after item sorted by key
+func ItemAttributeChanges(before, after types.Item) []ItemAttributeChange {
+	beforeAttrs := itemAttributes(before)
+	afterAttrs := itemAttributes(after)
+	changes := []ItemAttributeChange{}
+	for key, value := range afterAttrs {
+		if prev, ok := beforeAttrs[key]; !ok || prev != value {
+			changes = append(changes, ItemAttributeChange{Key: key, Before: prev, After: value})
+		}
+	}
+	for key, prev := range beforeAttrs {
+		if _, ok := afterAttrs[key]; !ok {
+			changes = append(changes, ItemAttributeChange{Key: key, Before: prev})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// CheckItemUpdate is a function to verify field is the only attribute changed from before item to after item and it's set to value
+// Value of numeric attribute should be formatted the same as ItemAttributeChange.
+func CheckItemUpdate(before, after types.Item, field, value string) ([]ItemAttributeChange, error) {
+	changes := ItemAttributeChanges(before, after)
+	prev, ok := itemAttributes(before)[field]
+	if !ok {
+		return changes, fmt.Errorf("item %s does not have field %s to update", before.ID, field)
+	}
+	if prev == value {
+		// updating field to its current value changes nothing
+		if len(changes) != 0 {
+			return changes, fmt.Errorf("item %s should not change by setting %s to its value, but changed %+v", before.ID, field, changes)
+		}
+		return changes, nil
+	}
+	if len(changes) != 1 || changes[0].Key != field || changes[0].After != value {
+		return changes, fmt.Errorf("item %s should only change %s from %s to %s, but changed %+v", before.ID, field, prev, value, changes)
+	}
+	return changes, nil
+}
+
+// UpdateItemStringFee is a function to get pylons charged to update a string field of item
+func UpdateItemStringFee() (sdk.Coins, error) {
+	params, err := GetPylonsParams()
+	if err != nil {
+		return nil, err
+	}
+	return sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, params.Fee.UpdateItemFieldString)), nil
+}
+
+// CheckFeeCharged is a function to verify balance of sender is decreased by fee from before balance
+func CheckFeeCharged(senderBefore banktypes.Balance, fee sdk.Coins, t *testing.T) error {
+	senderAfter := GetAccountBalanceFromAddr(senderBefore.Address, t)
+	for _, coin := range fee {
+		senderDelta := senderBefore.Coins.AmountOf(coin.Denom).Sub(senderAfter.Coins.AmountOf(coin.Denom))
+		if !senderDelta.Equal(coin.Amount) {
+			return fmt.Errorf("sender balance of %s changed by %s, expected fee %s", coin.Denom, senderDelta, coin.Amount)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestCheckItemUpdate(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	before := types.Item{
+		ID:      "item1",
+		Strings: []types.StringKeyValue{{Key: "Name", Value: "Knife"}, {Key: "Owner", Value: "alice"}},
+		Doubles: []types.DoubleKeyValue{{Key: "attack", Value: sdk.NewDec(3)}},
+		Longs:   []types.LongKeyValue{{Key: "level", Value: 1}},
+	}
+	after := before
+	after.Strings = []types.StringKeyValue{{Key: "Name", Value: "Sharp knife"}, {Key: "Owner", Value: "alice"}}
+
+	changes, err := CheckItemUpdate(before, after, "Name", "Sharp knife")
+	t.MustNil(err, "only updated field should be changed")
+	t.WithFields(testing.Fields{
+		"changes": changes,
+	}).MustTrue(len(changes) == 1 && changes[0] == ItemAttributeChange{Key: "Name", Before: "Knife", After: "Sharp knife"}, "change should have before and after values")
+
+	_, err = CheckItemUpdate(before, after, "Name", "Blunt knife")
+	t.MustTrue(err != nil, "field changed to different value should fail")
+
+	_, err = CheckItemUpdate(before, after, "Title", "Sharp knife")
+	t.MustTrue(err != nil, "missing field should fail")
+
+	after.Longs = []types.LongKeyValue{{Key: "level", Value: 2}}
+	changes, err = CheckItemUpdate(before, after, "Name", "Sharp knife")
+	t.WithFields(testing.Fields{
+		"changes": changes,
+	}).MustTrue(err != nil && len(changes) == 2, "change of other attribute should fail")
+
+	_, err = CheckItemUpdate(before, before, "Owner", "alice")
+	t.MustNil(err, "setting field to its value should change nothing")
+}
@@ -0,0 +1,175 @@
+package inttest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+)
+
+// describes the keyring providers which can be set by keyring-backend flag
+const (
+	KeyringProviderTest         = keyring.BackendTest
+	KeyringProviderFile         = keyring.BackendFile
+	KeyringProviderOS           = keyring.BackendOS
+	KeyringProviderRemoteSigner = "remote-signer"
+)
+
+// KeyringPassphraseEnv is the environment variable to read passphrase of file keyring from
+const KeyringPassphraseEnv = "PYLONS_KEYRING_PASSPHRASE"
+
+// KeyringProvider is an interface to configure how pylonsd accesses keys
+type KeyringProvider interface {
+	// KeyringArgs returns flags added to pylonsd commands which access keyring
+	KeyringArgs() []string
+	// StdinInput returns input to answer keyring prompts, it's put before input of the command
+	StdinInput() string
+}
+
+// TxSigner is an interface for keyring providers which sign transactions outside of pylonsd
+type TxSigner interface {
+	// SignTx signs transaction with "pylonsd tx sign" arguments and returns signed transaction
+	SignTx(args []string) ([]byte, error)
+}
+
+// TestKeyring is a keyring provider for unencrypted test keyring of pylonsd
+type TestKeyring struct {
+	Dir string
+}
+
+// KeyringArgs is a function to get flags for test keyring
+func (k TestKeyring) KeyringArgs() []string {
+	return keyringArgs(KeyringProviderTest, k.Dir)
+}
+
+// StdinInput is a function to get input for test keyring which never prompts
+func (k TestKeyring) StdinInput() string {
+	return ""
+}
+
+// FileKeyring is a keyring provider for passphrase encrypted file keyring
+// Keyring should be created with the passphrase before, as first access asks passphrase twice
+type FileKeyring struct {
+	Dir        string
+	Passphrase string
+}
+
+// KeyringArgs is a function to get flags for file keyring
+func (k FileKeyring) KeyringArgs() []string {
+	return keyringArgs(KeyringProviderFile, k.Dir)
+}
+
+// StdinInput is a function to get passphrase input for file keyring
+func (k FileKeyring) StdinInput() string {
+	return k.Passphrase + "\n"
+}
+
+// OSKeyring is a keyring provider for keyring of the operating system e.g. keychain or a hardware backed store
+type OSKeyring struct {
+	Dir string
+}
+
+// KeyringArgs is a function to get flags for os keyring
+func (k OSKeyring) KeyringArgs() []string {
+	return keyringArgs(KeyringProviderOS, k.Dir)
+}
+
+// StdinInput is a function to get input for os keyring which prompts by the operating system
+func (k OSKeyring) StdinInput() string {
+	return ""
+}
+
+// RemoteSignerKeyring is a keyring provider whose keyring only has public key references of the keys
+// e.g. added by "pylonsd keys add --pubkey" or "--ledger", and transactions are signed by an external command
+// SignCommand is run with "pylonsd tx sign" arguments and should print signed transaction
+type RemoteSignerKeyring struct {
+	Dir         string
+	SignCommand string
+}
+
+// KeyringArgs is a function to get flags for keyring of public key references
+func (k RemoteSignerKeyring) KeyringArgs() []string {
+	return keyringArgs(KeyringProviderTest, k.Dir)
+}
+
+// StdinInput is a function to get input for keyring of public key references
+func (k RemoteSignerKeyring) StdinInput() string {
+	return ""
+}
+
+// SignTx is a function to sign transaction with the external sign command
+func (k RemoteSignerKeyring) SignTx(args []string) ([]byte, error) {
+	if len(k.SignCommand) == 0 {
+		return nil, fmt.Errorf("sign command of remote signer is not set")
+	}
+	cmd := exec.Command(k.SignCommand, args...)
+	cmd.Stderr = os.Stderr
+	return cmd.Output()
+}
+
+func keyringArgs(backend, dir string) []string {
+	args := []string{fmt.Sprintf("--%s=%s", flags.FlagKeyringBackend, backend)}
+	if len(dir) > 0 {
+		args = append(args, fmt.Sprintf("--%s=%s", flags.FlagKeyringDir, dir))
+	}
+	return args
+}
+
+var keyringBackend string
+var keyringDir string
+var remoteSignerCmd string
+
+func init() {
+	flag.StringVar(&keyringBackend, "keyring-backend", KeyringProviderTest, "keyring provider of test keys: test|file|os|remote-signer")
+	flag.StringVar(&keyringDir, "keyring-dir", "", "keyring directory, pylonsd home is used when empty")
+	flag.StringVar(&remoteSignerCmd, "remote-signer-cmd", "", "command to sign transactions for remote-signer keyring provider")
+}
+
+// GetKeyringProvider is a function to get keyring provider set on CLIOpts or by keyring flags, default test keyring
+func GetKeyringProvider() KeyringProvider {
+	return DefaultEnv().Keyring()
+}
+
+// flagKeyringProvider is a function to get keyring provider selected by keyring flags
+func flagKeyringProvider() KeyringProvider {
+	switch keyringBackend {
+	case KeyringProviderFile:
+		return FileKeyring{Dir: keyringDir, Passphrase: os.Getenv(KeyringPassphraseEnv)}
+	case KeyringProviderOS:
+		return OSKeyring{Dir: keyringDir}
+	case KeyringProviderRemoteSigner:
+		return RemoteSignerKeyring{Dir: keyringDir, SignCommand: remoteSignerCmd}
+	default:
+		return TestKeyring{Dir: keyringDir}
+	}
+}
+
+// usesKeyring is a function to check if pylonsd command accesses keyring
+func usesKeyring(args []string) bool {
+	if len(args) < 2 {
+		return false
+	}
+	switch args[0] {
+	case "keys":
+		return true
+	case "tx":
+		if args[1] == "sign" || args[1] == "multisign" {
+			return true
+		}
+		if len(args) > 2 && args[1] == "pylons" && args[2] == "create-account" {
+			return true
+		}
+		// transactions generated by pylonsd are signed by key of --from
+		return args[1] != "broadcast" && hasFlag(args, flags.FlagFrom)
+	default:
+		return false
+	}
+}
+
+// isTxSignCommand is a function to check if pylonsd command is "tx sign"
+func isTxSignCommand(args []string) bool {
+	return len(args) > 1 && args[0] == "tx" && args[1] == "sign"
+}
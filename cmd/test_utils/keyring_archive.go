@@ -0,0 +1,208 @@
+package inttest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KeyringArchiveEnv is the environment variable to read encrypted keyring archive from e.g. a CI secret
+const KeyringArchiveEnv = "PYLONS_KEYRING_ARCHIVE"
+
+// KeyringArchivePassphraseEnv is the environment variable to read passphrase of encrypted keyring archive from
+const KeyringArchivePassphraseEnv = "PYLONS_KEYRING_ARCHIVE_PASSPHRASE"
+
+// keyringArchivePrefix is put before base64 of encrypted archive to recognize its format
+const keyringArchivePrefix = "pylons-keyring-v1:"
+
+const (
+	archiveSaltSize  = 16
+	archiveNonceSize = 24
+	archiveKeySize   = 32
+	// scrypt cost parameters recommended for interactive logins
+	archiveScryptN = 1 << 15
+	archiveScryptR = 8
+	archiveScryptP = 1
+)
+
+// ErrKeyringArchiveDecrypt is returned when keyring archive can't be decrypted by passphrase or is tampered
+var ErrKeyringArchiveDecrypt = errors.New("keyring archive can't be decrypted, passphrase is wrong or archive is corrupted")
+
+// ArchivedKey is a struct to describe a key of keyring archive restored by its mnemonic
+type ArchivedKey struct {
+	Name     string `json:"name"`
+	Address  string `json:"address"`
+	Mnemonic string `json:"mnemonic"`
+}
+
+// KeyringArchive is a struct to describe keys exported from test keyring
+// so that CI jobs can reuse funded testnet accounts across runs
+type KeyringArchive struct {
+	Keys []ArchivedKey `json:"keys"`
+}
+
+// archiveKey is a function to derive secretbox key of archive from passphrase and salt
+func archiveKey(passphrase string, salt []byte) (*[archiveKeySize]byte, error) {
+	derived, err := scrypt.Key([]byte(passphrase), salt, archiveScryptN, archiveScryptR, archiveScryptP, archiveKeySize)
+	if err != nil {
+		return nil, err
+	}
+	var key [archiveKeySize]byte
+	copy(key[:], derived)
+	return &key, nil
+}
+
+// EncryptKeyringArchive is a function to encrypt archive with passphrase by NaCl secretbox keyed by scrypt
+// The result is printable text which can be kept in a file of repository or a CI variable.
+func EncryptKeyringArchive(archive KeyringArchive, passphrase string) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase of keyring archive is empty")
+	}
+	plain, err := json.Marshal(archive)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, archiveSaltSize+archiveNonceSize)
+	if _, err := io.ReadFull(rand.Reader, header); err != nil {
+		return nil, fmt.Errorf("error generating salt and nonce: %w", err)
+	}
+	key, err := archiveKey(passphrase, header[:archiveSaltSize])
+	if err != nil {
+		return nil, err
+	}
+	var nonce [archiveNonceSize]byte
+	copy(nonce[:], header[archiveSaltSize:])
+	sealed := secretbox.Seal(header, plain, &nonce, key)
+	return []byte(keyringArchivePrefix + base64.StdEncoding.EncodeToString(sealed) + "\n"), nil
+}
+
+// DecryptKeyringArchive is a function to decrypt archive encrypted by EncryptKeyringArchive
+// Mnemonics of decrypted keys are registered as secrets so that they're redacted from logs.
+func DecryptKeyringArchive(bz []byte, passphrase string) (KeyringArchive, error) {
+	var archive KeyringArchive
+	text := strings.TrimSpace(string(bz))
+	if !strings.HasPrefix(text, keyringArchivePrefix) {
+		return archive, fmt.Errorf("keyring archive should start with %s", keyringArchivePrefix)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(text, keyringArchivePrefix))
+	if err != nil {
+		return archive, fmt.Errorf("invalid keyring archive encoding: %w", err)
+	}
+	if len(sealed) < archiveSaltSize+archiveNonceSize+secretbox.Overhead {
+		return archive, ErrKeyringArchiveDecrypt
+	}
+	key, err := archiveKey(passphrase, sealed[:archiveSaltSize])
+	if err != nil {
+		return archive, err
+	}
+	var nonce [archiveNonceSize]byte
+	copy(nonce[:], sealed[archiveSaltSize:archiveSaltSize+archiveNonceSize])
+	plain, ok := secretbox.Open(nil, sealed[archiveSaltSize+archiveNonceSize:], &nonce, key)
+	if !ok {
+		return archive, ErrKeyringArchiveDecrypt
+	}
+	if err := json.Unmarshal(plain, &archive); err != nil {
+		return archive, fmt.Errorf("invalid keyring archive content: %w", err)
+	}
+	for _, key := range archive.Keys {
+		RegisterSecret(key.Mnemonic)
+	}
+	return archive, nil
+}
+
+// ExportKeyringArchive is a function to export keys of names as encrypted archive
+// Keys should be created or imported by test utils, as keyring doesn't keep mnemonics, see ExportMnemonic.
+func ExportKeyringArchive(names []string, passphrase string) ([]byte, error) {
+	return exportKeyringArchive(GetKeyringProvider(), names, passphrase)
+}
+
+func exportKeyringArchive(provider KeyringProvider, names []string, passphrase string) ([]byte, error) {
+	archive := KeyringArchive{Keys: []ArchivedKey{}}
+	for _, name := range names {
+		mnemonic, err := exportMnemonic(provider, name)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := keyringAddress(provider, name)
+		if err != nil {
+			return nil, err
+		}
+		archive.Keys = append(archive.Keys, ArchivedKey{Name: name, Address: addr, Mnemonic: mnemonic})
+	}
+	return EncryptKeyringArchive(archive, passphrase)
+}
+
+// keyringAddress is a function to get address of key in keyring
+func keyringAddress(provider KeyringProvider, name string) (string, error) {
+	output, logstr, err := Keys().Show(name, true).WithKeyring(provider).Run(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("error showing key %s: %w; %s", name, err, logstr)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ImportKeyringArchive is a function to restore keys of encrypted archive into keyring and get their names
+// Keys already in keyring with the same address are kept, a key of the same name with different address fails the import.
+func ImportKeyringArchive(bz []byte, passphrase string) ([]string, error) {
+	return importKeyringArchive(GetKeyringProvider(), bz, passphrase)
+}
+
+func importKeyringArchive(provider KeyringProvider, bz []byte, passphrase string) ([]string, error) {
+	archive, err := DecryptKeyringArchive(bz, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, key := range archive.Keys {
+		if addr, err := keyringAddress(provider, key.Name); err == nil {
+			if addr != key.Address {
+				return names, fmt.Errorf("key %s has address %s in keyring, but %s in archive", key.Name, addr, key.Address)
+			}
+			rememberMnemonic(provider, key.Name, key.Mnemonic)
+			names = append(names, key.Name)
+			continue
+		}
+		result, err := createAccountFromMnemonic(provider, key.Name, key.Mnemonic, "")
+		if err != nil {
+			return names, fmt.Errorf("error restoring key %s: %w; %s", key.Name, err, result["logstr"])
+		}
+		if result["address"] != key.Address {
+			return names, fmt.Errorf("key %s is restored with address %s, but %s in archive", key.Name, result["address"], key.Address)
+		}
+		names = append(names, key.Name)
+	}
+	return names, nil
+}
+
+// ImportKeyringArchiveFile is a function to restore keys of archive file, or of KeyringArchiveEnv when file is empty
+// Passphrase is read from KeyringArchivePassphraseEnv, so that neither of them is stored as plain text.
+func ImportKeyringArchiveFile(file string) ([]string, error) {
+	var bz []byte
+	if len(file) > 0 {
+		var err error
+		if bz, err = ioutil.ReadFile(file); err != nil {
+			return nil, fmt.Errorf("error reading keyring archive: %w", err)
+		}
+	} else {
+		bz = []byte(os.Getenv(KeyringArchiveEnv))
+	}
+	if len(strings.TrimSpace(string(bz))) == 0 {
+		return nil, fmt.Errorf("keyring archive is not configured, set archive file or %s", KeyringArchiveEnv)
+	}
+	passphrase := os.Getenv(KeyringArchivePassphraseEnv)
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase of keyring archive is not configured, set %s", KeyringArchivePassphraseEnv)
+	}
+	RegisterSecret(passphrase)
+	return ImportKeyringArchive(bz, passphrase)
+}
@@ -0,0 +1,47 @@
+package inttest
+
+import (
+	"errors"
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestKeyringArchive(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	mnemonic := "shed cabbage ugly sorry gospel yard crew elbow enroll pudding spot wrap vague disorder fuel tooth yard fossil awake chat flame easy armor topple"
+	archive := KeyringArchive{Keys: []ArchivedKey{
+		{Name: "funded_eugen", Address: "pylo1archivedaddress", Mnemonic: mnemonic},
+	}}
+	bz, err := EncryptKeyringArchive(archive, "ci-passphrase")
+	t.MustNil(err, "error encrypting keyring archive")
+	t.MustTrue(strings.HasPrefix(string(bz), keyringArchivePrefix), "encrypted archive should have format prefix")
+	t.MustTrue(!strings.Contains(string(bz), "cabbage") && !strings.Contains(string(bz), "funded_eugen"), "encrypted archive should not have plain text of keys")
+
+	decrypted, err := DecryptKeyringArchive(bz, "ci-passphrase")
+	t.MustNil(err, "error decrypting keyring archive")
+	t.MustTrue(len(decrypted.Keys) == 1 && decrypted.Keys[0] == archive.Keys[0], "decrypted archive should have the same keys")
+	t.MustTrue(!strings.Contains(RedactSensitiveData("restored "+mnemonic), "cabbage"), "mnemonics of decrypted archive should be redacted")
+
+	again, err := EncryptKeyringArchive(archive, "ci-passphrase")
+	t.MustNil(err, "error encrypting keyring archive again")
+	t.MustTrue(string(again) != string(bz), "archives should be encrypted with random salt and nonce")
+
+	_, err = DecryptKeyringArchive(bz, "wrong-passphrase")
+	t.MustTrue(errors.Is(err, ErrKeyringArchiveDecrypt), "wrong passphrase should fail decryption")
+	tampered := append([]byte{}, bz...)
+	pos := len(keyringArchivePrefix) + 60
+	if tampered[pos] == 'A' {
+		tampered[pos] = 'B'
+	} else {
+		tampered[pos] = 'A'
+	}
+	_, err = DecryptKeyringArchive(tampered, "ci-passphrase")
+	t.MustTrue(errors.Is(err, ErrKeyringArchiveDecrypt), "tampered archive should fail decryption")
+	_, err = DecryptKeyringArchive([]byte("{\"keys\": []}"), "ci-passphrase")
+	t.MustTrue(err != nil, "plain text archive should be refused")
+	_, err = EncryptKeyringArchive(archive, "")
+	t.MustTrue(err != nil, "archive should not be encrypted with empty passphrase")
+}
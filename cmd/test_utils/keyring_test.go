@@ -0,0 +1,34 @@
+package inttest
+
+import (
+	"strings"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestKeyringBackendSetup(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	originOpts := CLIOpts
+	defer func() {
+		CLIOpts = originOpts
+	}()
+
+	args := strings.Join(KeyringBackendSetup([]string{"keys", "show", "eugen", "-a"}), " ")
+	t.MustTrue(args == "keys show eugen -a --keyring-backend=test", "test keyring should be used by default")
+
+	CLIOpts.Keyring = FileKeyring{Dir: "/tmp/keys", Passphrase: "secret"}
+	args = strings.Join(KeyringBackendSetup([]string{"tx", "sign", "raw_tx.json", "--from", "eugen"}), " ")
+	t.WithFields(testing.Fields{
+		"args": args,
+	}).MustContain(args, "--keyring-backend=file --keyring-dir=/tmp/keys", "file keyring flags should be set on tx sign")
+	t.MustTrue(GetKeyringProvider().StdinInput() == "secret\n", "passphrase should be input for file keyring")
+
+	args = strings.Join(KeyringBackendSetup([]string{"tx", "broadcast", "signed_tx.json"}), " ")
+	t.MustTrue(args == "tx broadcast signed_tx.json --output=json", "keyring flags should not be set on commands not accessing keyring")
+
+	CLIOpts.Keyring = RemoteSignerKeyring{}
+	_, ok := GetKeyringProvider().(TxSigner)
+	t.MustTrue(ok, "remote signer should sign transactions")
+}
@@ -0,0 +1,123 @@
+//go:build !nolegacy
+// +build !nolegacy
+
+package inttest
+
+import (
+	"context"
+	"sync"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// Legacy transaction helpers are kept as thin wrappers of Client for existing test suites.
+// They are excluded from build with "nolegacy" build tag to check a test suite is migrated.
+
+// deprecationWarned keeps deprecated function names which are already warned
+var deprecationWarned sync.Map
+
+// warnDeprecated is a function to emit structured deprecation warning once per deprecated function
+// It returns true when the warning is emitted
+func warnDeprecated(t *testing.T, deprecated, replacement, migrationHint string) bool {
+	if _, warned := deprecationWarned.LoadOrStore(deprecated, true); warned {
+		return false
+	}
+	t.WithFields(testing.Fields{
+		"deprecated":     deprecated,
+		"replacement":    replacement,
+		"migration_hint": migrationHint,
+	}).Warn("deprecated test harness function is used, it is removed by nolegacy build tag")
+	return true
+}
+
+// legacySigner is a function to get signer from legacy signer and isBech32Addr params
+func legacySigner(signer string, isBech32Addr bool) Signer {
+	if isBech32Addr {
+		return SignerAddress(signer)
+	}
+	return SignerKey(signer)
+}
+
+// TestTxWithMsg is a function to send transaction with message
+//
+// Deprecated: use Client.SendTx
+func TestTxWithMsg(t *testing.T, msgValue sdk.Msg, signer string) string {
+	warnDeprecated(t, "TestTxWithMsg", "Client.SendTx",
+		"txhash, err := inttest.NewClient().SendTx(ctx, t, inttest.SignerKey(signer), msg)")
+	txhash, err := NewClient().SendTx(context.Background(), t, SignerKey(signer), msgValue)
+	if err != nil {
+		return ""
+	}
+	return txhash
+}
+
+// SendMultiMsgTxWithNonce is a function to send multiple messages in one transaction
+//
+// Deprecated: use Client.SendTx
+func SendMultiMsgTxWithNonce(t *testing.T, msgs []sdk.Msg, signer string, isBech32Addr bool) (string, error) {
+	warnDeprecated(t, "SendMultiMsgTxWithNonce", "Client.SendTx",
+		"inttest.NewClient().SendTx(ctx, t, inttest.SignerAddress(signer), msgs...), use SignerKey when isBech32Addr is false")
+	return sendMultiMsgTx(context.Background(), t, msgs, signer, isBech32Addr, GetMaxBroadcastRetry(), GetKeyringProvider(), TxOptions{})
+}
+
+// TestTxWithMsgWithNonce is a function to send transaction with message and nonce
+//
+// Deprecated: use Client.SendTx
+func TestTxWithMsgWithNonce(t *testing.T, msgValue sdk.Msg, signer string, isBech32Addr bool) (string, error) {
+	warnDeprecated(t, "TestTxWithMsgWithNonce", "Client.SendTx",
+		"inttest.NewClient().SendTx(ctx, t, inttest.SignerAddress(signer), msg), use SignerKey when isBech32Addr is false")
+	return NewClient().SendTx(context.Background(), t, legacySigner(signer, isBech32Addr), msgValue)
+}
+
+// WaitAndGetTxData is a function to get transaction data after transaction is processed and confirmed
+//
+// Deprecated: use Client.WaitForTx
+func WaitAndGetTxData(txhash string, maxWaitBlock int64, t *testing.T) ([]byte, error) {
+	warnDeprecated(t, "WaitAndGetTxData", "Client.WaitForTx",
+		"inttest.NewClient(inttest.WithMaxWaitBlock(maxWaitBlock)).WaitForTx(ctx, t, txhash), max wait block option can be omitted")
+	return NewClient(WithMaxWaitBlock(maxWaitBlock)).WaitForTx(context.Background(), t, txhash)
+}
+
+// RunPylonsd is a function to run pylonsd
+//
+// Deprecated: use RunPylonsdCtx
+func RunPylonsd(args []string, stdinInput string) ([]byte, string, error) {
+	return RunPylonsdCtx(context.Background(), args, stdinInput)
+}
+
+// GetDaemonStatus is a function to get daemon status
+//
+// Deprecated: use GetDaemonStatusCtx
+func GetDaemonStatus() (*ctypes.ResultStatus, string, error) {
+	return GetDaemonStatusCtx(context.Background())
+}
+
+// WaitForNextBlock is a function to wait until next block
+//
+// Deprecated: use WaitForNextBlockCtx
+func WaitForNextBlock() error {
+	return WaitForNextBlockCtx(context.Background())
+}
+
+// WaitForBlockInterval is a function to wait until block heights to flow
+//
+// Deprecated: use WaitForBlockIntervalCtx
+func WaitForBlockInterval(interval int64) error {
+	return WaitForBlockIntervalCtx(context.Background(), interval)
+}
+
+// WaitForBlockHeight is a function to wait until chain reaches block height
+//
+// Deprecated: use WaitForBlockHeightCtx
+func WaitForBlockHeight(height int64) error {
+	return WaitForBlockHeightCtx(context.Background(), height)
+}
+
+// WaitForTxConfirmation is a function to wait until depth blocks are built on top of the inclusion block of transaction
+//
+// Deprecated: use WaitForTxConfirmationCtx
+func WaitForTxConfirmation(txhash string, depth int64, t *testing.T) error {
+	return WaitForTxConfirmationCtx(context.Background(), txhash, depth, t)
+}
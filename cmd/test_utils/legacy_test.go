@@ -0,0 +1,19 @@
+//go:build !nolegacy
+// +build !nolegacy
+
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestWarnDeprecated(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	t.MustTrue(warnDeprecated(&t, "TestWarnDeprecatedFunc", "Client.SendTx", "hint"), "first use should be warned")
+	t.MustTrue(!warnDeprecated(&t, "TestWarnDeprecatedFunc", "Client.SendTx", "hint"), "deprecated function should be warned only once")
+	t.MustTrue(legacySigner("cosmos1...", true) == SignerAddress("cosmos1..."), "bech32 signer should be signer address")
+	t.MustTrue(legacySigner("eugen", false) == SignerKey("eugen"), "key signer should be signer key")
+}
@@ -0,0 +1,345 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	loadTxLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "load_tx_latency_seconds",
+		Help:      "Latency of transactions sent by load test from broadcast to result by msg mix entry.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, []string{"mix"})
+	loadTxs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "load_txs_total",
+		Help:      "Number of transactions sent by load test by msg mix entry and result.",
+	}, []string{"mix", "result"})
+)
+
+func init() {
+	MetricsRegistry.MustRegister(loadTxLatency, loadTxs)
+}
+
+// LoadMsgBuilder is a function to build signer and msgs of seq-th transaction of a load mix entry
+type LoadMsgBuilder func(seq int64) (Signer, []sdk.Msg, error)
+
+// LoadMix is a struct to describe a kind of transaction sent by load test and its share of transactions
+type LoadMix struct {
+	Name   string
+	Weight int
+	Build  LoadMsgBuilder
+}
+
+// LoadTest is a struct to send transactions of a msg mix at a fixed rate to benchmark pylons module of a node
+// Transactions of the same signer are sent one by one to keep account sequence, so signers limit throughput.
+type LoadTest struct {
+	// TPS is the number of transactions started per second
+	TPS float64
+	// Duration is how long transactions are started
+	Duration time.Duration
+	// Mix is the kinds of transactions, each transaction picks an entry by weight
+	Mix []LoadMix
+	// WaitResult makes latency include the wait for the transaction result, otherwise it's broadcast latency
+	WaitResult bool
+	// MaxInFlight is the maximum number of transactions not finished, ticks are skipped when it's reached
+	// 0 means no limit
+	MaxInFlight int
+	// Client sends transactions, NewClient() is used when it's nil
+	Client *Client
+	// Stop stops starting transactions when it's closed while in flight ones conclude, GlobalShutdown.Done() when it's nil
+	Stop <-chan struct{}
+}
+
+// loadSample is a struct to describe the result of a transaction sent by load test
+type loadSample struct {
+	mix     string
+	latency time.Duration
+	failure string // empty on success
+}
+
+// LoadReport is a struct to describe throughput, latency percentiles and failures of a load test run
+type LoadReport struct {
+	Sent       int
+	Succeeded  int
+	Failed     int
+	Skipped    int
+	Elapsed    time.Duration
+	Throughput float64 // succeeded transactions per second
+	P50        time.Duration
+	P90        time.Duration
+	P99        time.Duration
+	Max        time.Duration
+	// Failures is number of failed transactions by abci code e.g. "code_5" or by error class
+	Failures map[string]int
+	// SentByMix is number of transactions sent by msg mix entry
+	SentByMix map[string]int
+	// Stopped is true when the run is stopped by Stop before Duration
+	Stopped bool
+}
+
+// String is a function to get readable summary of load report
+func (r LoadReport) String() string {
+	return fmt.Sprintf("sent=%d succeeded=%d failed=%d skipped=%d elapsed=%s throughput=%.2ftps p50=%s p90=%s p99=%s max=%s failures=%v stopped=%t",
+		r.Sent, r.Succeeded, r.Failed, r.Skipped, r.Elapsed, r.Throughput, r.P50, r.P90, r.P99, r.Max, r.Failures, r.Stopped)
+}
+
+// percentile is a function to get nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// newLoadReport is a function to summarize samples of a load test run
+func newLoadReport(samples []loadSample, skipped int, elapsed time.Duration) LoadReport {
+	report := LoadReport{
+		Sent:      len(samples),
+		Skipped:   skipped,
+		Elapsed:   elapsed,
+		Failures:  map[string]int{},
+		SentByMix: map[string]int{},
+	}
+	latencies := []time.Duration{}
+	for _, sample := range samples {
+		report.SentByMix[sample.mix]++
+		if len(sample.failure) > 0 {
+			report.Failed++
+			report.Failures[sample.failure]++
+			continue
+		}
+		report.Succeeded++
+		latencies = append(latencies, sample.latency)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	report.P50 = percentile(latencies, 50)
+	report.P90 = percentile(latencies, 90)
+	report.P99 = percentile(latencies, 99)
+	report.Max = percentile(latencies, 100)
+	if elapsed > 0 {
+		report.Throughput = float64(report.Succeeded) / elapsed.Seconds()
+	}
+	return report
+}
+
+// pickMix is a function to get mix entry of seq-th transaction, entries are interleaved by weight deterministically
+func pickMix(mix []LoadMix, seq int64) LoadMix {
+	total := 0
+	for _, entry := range mix {
+		total += entry.Weight
+	}
+	slot := int(seq % int64(total))
+	for _, entry := range mix {
+		if slot < entry.Weight {
+			return entry
+		}
+		slot -= entry.Weight
+	}
+	return mix[len(mix)-1]
+}
+
+// failureName is a function to get failure label of transaction error, abci code is used when the node returned one
+func failureName(txResult TxResult, err error) string {
+	if txResult.Code != 0 {
+		return fmt.Sprintf("code_%d", txResult.Code)
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return "canceled"
+	}
+	return errorClassName(err)
+}
+
+// validate is a function to check load test configuration
+func (lt LoadTest) validate() error {
+	if lt.TPS <= 0 {
+		return errors.New("tps should be positive")
+	}
+	if lt.Duration <= 0 {
+		return errors.New("duration should be positive")
+	}
+	if len(lt.Mix) == 0 {
+		return errors.New("msg mix is empty")
+	}
+	for _, entry := range lt.Mix {
+		if entry.Weight <= 0 || entry.Build == nil {
+			return fmt.Errorf("mix entry %s should have positive weight and msg builder", entry.Name)
+		}
+	}
+	return nil
+}
+
+// Run is a function to send transactions of the msg mix at TPS for Duration and report throughput, latency and failures
+// It waits for transactions in flight to finish before reporting, canceling ctx stops the run early and aborts them,
+// while closing Stop e.g. by SIGINT through GlobalShutdown stops the run early and lets them conclude.
+func (lt LoadTest) Run(ctx context.Context, t *testing.T) (LoadReport, error) {
+	if err := lt.validate(); err != nil {
+		return LoadReport{}, err
+	}
+	client := lt.Client
+	if client == nil {
+		client = NewClient()
+	}
+	stop := lt.Stop
+	if stop == nil {
+		stop = GlobalShutdown.Done()
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	samples := []loadSample{}
+	signerLocks := map[string]*sync.Mutex{}
+	signerLock := func(signer Signer) *sync.Mutex {
+		mu.Lock()
+		defer mu.Unlock()
+		if _, ok := signerLocks[signer.String()]; !ok {
+			signerLocks[signer.String()] = &sync.Mutex{}
+		}
+		return signerLocks[signer.String()]
+	}
+	inFlight := make(chan struct{}, math.MaxInt16)
+	if lt.MaxInFlight > 0 {
+		inFlight = make(chan struct{}, lt.MaxInFlight)
+	}
+
+	send := func(entry LoadMix, seq int64) {
+		defer wg.Done()
+		defer func() { <-inFlight }()
+		sample := loadSample{mix: entry.Name}
+		signer, msgs, err := entry.Build(seq)
+		if err != nil {
+			sample.failure = "build"
+		} else {
+			lock := signerLock(signer)
+			lock.Lock()
+			start := time.Now()
+			txResult := TxResult{}
+			if lt.WaitResult {
+				txResult, err = client.SendTxAndWait(ctx, t, signer, msgs...)
+			} else {
+				_, err = client.SendTx(ctx, t, signer, msgs...)
+			}
+			sample.latency = time.Since(start)
+			lock.Unlock()
+			if err != nil {
+				sample.failure = failureName(txResult, err)
+			}
+		}
+		result := "success"
+		if len(sample.failure) > 0 {
+			result = "failure"
+		} else {
+			loadTxLatency.WithLabelValues(entry.Name).Observe(sample.latency.Seconds())
+		}
+		loadTxs.WithLabelValues(entry.Name, result).Inc()
+		mu.Lock()
+		samples = append(samples, sample)
+		mu.Unlock()
+	}
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / lt.TPS))
+	defer ticker.Stop()
+	deadline := time.After(lt.Duration)
+	start := time.Now()
+	skipped := 0
+	seq := int64(0)
+	stopped := false
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-stop:
+			stopped = true
+			break loop
+		case <-deadline:
+			break loop
+		case <-ticker.C:
+			select {
+			case inFlight <- struct{}{}:
+				wg.Add(1)
+				go send(pickMix(lt.Mix, seq), seq)
+				seq++
+			default:
+				skipped++
+			}
+		}
+	}
+	wg.Wait()
+	report := newLoadReport(samples, skipped, time.Since(start))
+	report.Stopped = stopped
+	t.WithFields(testing.Fields{
+		"report": report.String(),
+	}).Info("load test finished")
+	return report, nil
+}
+
+// roundRobin is a function to get seq-th element of values
+func roundRobin(values []string, seq int64) string {
+	return values[seq%int64(len(values))]
+}
+
+// ExecuteRecipeLoad is a function to create mix entry executing a recipe without item inputs by senders in turn
+func ExecuteRecipeLoad(weight int, recipeID string, senders ...string) LoadMix {
+	return LoadMix{
+		Name:   "execute_recipe",
+		Weight: weight,
+		Build: func(seq int64) (Signer, []sdk.Msg, error) {
+			sender := roundRobin(senders, seq)
+			msg := types.NewMsgExecuteRecipe(recipeID, sender, []string{})
+			return SignerAddress(sender), []sdk.Msg{&msg}, nil
+		},
+	}
+}
+
+// CreateTradeLoad is a function to create mix entry creating trades of pylons for coins by senders in turn
+func CreateTradeLoad(weight int, pylons int64, coinOutputs sdk.Coins, senders ...string) LoadMix {
+	return LoadMix{
+		Name:   "create_trade",
+		Weight: weight,
+		Build: func(seq int64) (Signer, []sdk.Msg, error) {
+			sender := roundRobin(senders, seq)
+			msg := types.NewMsgCreateTrade(
+				types.CoinInputList{{Coin: types.Pylon, Count: pylons}},
+				types.TradeItemInputList{},
+				coinOutputs,
+				types.ItemList{},
+				fmt.Sprintf("load trade %d", seq),
+				sender,
+			)
+			return SignerAddress(sender), []sdk.Msg{&msg}, nil
+		},
+	}
+}
+
+// SendCoinsLoad is a function to create mix entry transferring coins from each sender to the next one in turn
+func SendCoinsLoad(weight int, amount sdk.Coins, senders ...string) LoadMix {
+	return LoadMix{
+		Name:   "send_coins",
+		Weight: weight,
+		Build: func(seq int64) (Signer, []sdk.Msg, error) {
+			if len(senders) < 2 {
+				return Signer{}, nil, errors.New("sending coins requires at least 2 senders")
+			}
+			sender, receiver := roundRobin(senders, seq), roundRobin(senders, seq+1)
+			msg := types.NewMsgSendCoins(amount, sender, receiver)
+			return SignerAddress(sender), []sdk.Msg{&msg}, nil
+		},
+	}
+}
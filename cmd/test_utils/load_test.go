@@ -0,0 +1,63 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestLoadReport(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	samples := []loadSample{}
+	for idx := 1; idx <= 100; idx++ {
+		samples = append(samples, loadSample{mix: "execute_recipe", latency: time.Duration(idx) * time.Millisecond})
+	}
+	samples = append(samples,
+		loadSample{mix: "create_trade", failure: "code_5"},
+		loadSample{mix: "create_trade", failure: "code_5"},
+		loadSample{mix: "send_coins", failure: ErrSequenceMismatch.Error()},
+	)
+	report := newLoadReport(samples, 1, 10*time.Second)
+	t.WithFields(testing.Fields{
+		"report": report.String(),
+	}).MustTrue(report.Sent == 103 && report.Succeeded == 100 && report.Failed == 3 && report.Throughput == 10, "transactions should be counted")
+	t.WithFields(testing.Fields{
+		"report": report.String(),
+	}).MustTrue(report.P50 == 50*time.Millisecond && report.P90 == 90*time.Millisecond && report.P99 == 99*time.Millisecond && report.Max == 100*time.Millisecond, "latency percentiles should be of successful transactions")
+	t.WithFields(testing.Fields{
+		"failures": report.Failures,
+	}).MustTrue(report.Failures["code_5"] == 2 && report.Failures[ErrSequenceMismatch.Error()] == 1, "failures should be grouped by code and error class")
+
+	mix := []LoadMix{{Name: "execute_recipe", Weight: 3}, {Name: "create_trade", Weight: 1}}
+	picked := map[string]int{}
+	for seq := int64(0); seq < 8; seq++ {
+		picked[pickMix(mix, seq).Name]++
+	}
+	t.WithFields(testing.Fields{
+		"picked": picked,
+	}).MustTrue(picked["execute_recipe"] == 6 && picked["create_trade"] == 2, "mix entries should be picked by weight")
+}
+
+func TestLoadTestStop(originT *originT.T) {
+	t := testing.NewT(originT)
+	stop := make(chan struct{})
+	time.AfterFunc(50*time.Millisecond, func() { close(stop) })
+	report, err := LoadTest{
+		TPS:      100,
+		Duration: time.Hour,
+		Mix: []LoadMix{{Name: "build_failure", Weight: 1, Build: func(seq int64) (Signer, []sdk.Msg, error) {
+			return Signer{}, nil, errors.New("no signer")
+		}}},
+		Client: NewClient(),
+		Stop:   stop,
+	}.Run(context.Background(), &t)
+	t.MustNil(err, "error running load test")
+	t.WithFields(testing.Fields{
+		"report": report.String(),
+	}).MustTrue(report.Stopped && report.Elapsed < time.Minute && report.Failures["build"] == report.Sent, "load test should stop starting transactions when stop is closed")
+}
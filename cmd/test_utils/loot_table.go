@@ -0,0 +1,96 @@
+package inttest
+
+import (
+	"fmt"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// LootTableBin is a struct to compare observed executions of outputs against their designed probability
+// Outputs paying out the same coins and number of items can't be told apart by execution output, so they share a bin.
+type LootTableBin struct {
+	Outputs     []int
+	Probability float64
+	Observed    int
+}
+
+// LootTableAnalysis is a struct to describe empirical distribution of recipe outputs against its loot table
+type LootTableAnalysis struct {
+	Table      types.LootTable
+	Bins       []LootTableBin
+	Executions int
+	Unmatched  int // executions which match no weighted output
+	Test       types.ChiSquaredTest
+}
+
+// AnalyzeLootTable is a function to compare outputs observed by coverage against loot table of its recipe
+func AnalyzeLootTable(coverage *EntryCoverage) (LootTableAnalysis, error) {
+	table, err := types.NewLootTable(coverage.Recipe, nil)
+	if err != nil {
+		return LootTableAnalysis{}, err
+	}
+	analysis := LootTableAnalysis{
+		Table:      table,
+		Executions: coverage.Executions,
+		Unmatched:  coverage.Unmatched,
+	}
+	binOf := map[outputSignature]int{}
+	for idx, signature := range coverage.signatures {
+		bin, ok := binOf[signature]
+		if !ok {
+			bin = len(analysis.Bins)
+			binOf[signature] = bin
+			// executions of a signature are counted for each output having it
+			analysis.Bins = append(analysis.Bins, LootTableBin{Observed: coverage.Observed[idx]})
+		}
+		analysis.Bins[bin].Outputs = append(analysis.Bins[bin].Outputs, idx)
+		analysis.Bins[bin].Probability += table.Rows[idx].Probability
+	}
+	observed := []int{}
+	probabilities := []float64{}
+	for _, bin := range analysis.Bins {
+		observed = append(observed, bin.Observed)
+		probabilities = append(probabilities, bin.Probability)
+	}
+	if analysis.Unmatched > 0 {
+		observed = append(observed, analysis.Unmatched)
+		probabilities = append(probabilities, 0)
+	}
+	analysis.Test, err = types.ChiSquared(observed, probabilities)
+	return analysis, err
+}
+
+// Fields is a function to get fields describing analysis for logs
+func (a LootTableAnalysis) Fields() testing.Fields {
+	bins := []string{}
+	for _, bin := range a.Bins {
+		bins = append(bins, fmt.Sprintf("outputs=%v expected=%.4f observed=%.4f", bin.Outputs, bin.Probability, float64(bin.Observed)/float64(a.Executions)))
+	}
+	return testing.Fields{
+		"recipe_id":   a.Table.RecipeID,
+		"executions":  a.Executions,
+		"unmatched":   a.Unmatched,
+		"bins":        bins,
+		"chi_squared": a.Test.Statistic,
+		"dof":         a.Test.DegreesOfFreedom,
+		"p_value":     a.Test.PValue,
+	}
+}
+
+// MustMatchDesign is a function to fail the test when observed outputs are unlikely under the loot table
+// alpha is the significance level e.g. 0.01, a correct recipe fails with probability alpha.
+func (a LootTableAnalysis) MustMatchDesign(t *testing.T, alpha float64) {
+	t.WithFields(a.Fields()).MustTrue(a.Test.Passes(alpha), "observed outputs don't match loot table of recipe")
+}
+
+// ExecuteRecipeForLootTable is a function to execute recipe runs times on chain and analyze distribution of its outputs
+// The same restrictions as ExecuteRecipeForEntryCoverage apply, and expected count of each bin should be 5 or more
+// for chi-squared test to be accurate.
+func ExecuteRecipeForLootTable(t *testing.T, rcpID, sender string, runs int) (LootTableAnalysis, error) {
+	coverage, err := ExecuteRecipeForEntryCoverage(t, rcpID, sender, runs)
+	if err != nil {
+		return LootTableAnalysis{}, err
+	}
+	return AnalyzeLootTable(coverage)
+}
@@ -0,0 +1,60 @@
+package inttest
+
+import (
+	"math"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestAnalyzeLootTable(originT *originT.T) {
+	t := testing.NewT(originT)
+	rcp := types.Recipe{
+		ID: "recipe1",
+		Entries: types.EntriesList{
+			CoinOutputs: []types.CoinOutput{{ID: "gold", Coin: "gold", Count: "10"}, {ID: "more_gold", Coin: "gold", Count: "20"}},
+			ItemOutputs: []types.ItemOutput{{ID: "sword"}},
+		},
+		Outputs: []types.WeightedOutputs{
+			{EntryIDs: []string{"gold"}, Weight: "5"},
+			{EntryIDs: []string{"more_gold"}, Weight: "1"},
+			{EntryIDs: []string{"sword"}, Weight: "3"},
+			{EntryIDs: []string{}, Weight: "1"},
+		},
+	}
+	observe := func(coverage *EntryCoverage, gold, sword, none int) {
+		for i := 0; i < gold; i++ {
+			coverage.Observe(sdk.Coins{sdk.NewInt64Coin("gold", 10)}, nil, nil)
+		}
+		for i := 0; i < sword; i++ {
+			coverage.Observe(sdk.Coins{}, []string{"sword1"}, nil)
+		}
+		for i := 0; i < none; i++ {
+			coverage.Observe(sdk.Coins{}, nil, nil)
+		}
+	}
+
+	coverage := NewEntryCoverage(rcp)
+	observe(coverage, 58, 31, 11)
+	analysis, err := AnalyzeLootTable(coverage)
+	t.MustNil(err, "error analyzing loot table")
+	t.WithFields(analysis.Fields()).MustTrue(len(analysis.Bins) == 3, "outputs paying out the same coin should share a bin")
+	t.MustTrue(len(analysis.Bins[0].Outputs) == 2 && math.Abs(analysis.Bins[0].Probability-0.6) < 1e-9, "probability of bin should sum up its outputs")
+	t.MustTrue(analysis.Bins[0].Observed == 58, "executions of shared bin should be counted once")
+	analysis.MustMatchDesign(&t, 0.01)
+
+	coverage = NewEntryCoverage(rcp)
+	observe(coverage, 30, 60, 10)
+	analysis, err = AnalyzeLootTable(coverage)
+	t.MustNil(err, "error analyzing loot table")
+	t.WithFields(analysis.Fields()).MustTrue(!analysis.Test.Passes(0.01), "skewed outputs should not match loot table")
+
+	coverage = NewEntryCoverage(rcp)
+	observe(coverage, 60, 30, 10)
+	coverage.Observe(sdk.Coins{sdk.NewInt64Coin("silver", 1)}, nil, nil)
+	analysis, err = AnalyzeLootTable(coverage)
+	t.MustNil(err, "error analyzing loot table")
+	t.WithFields(analysis.Fields()).MustTrue(!analysis.Test.Passes(0.01), "output out of loot table should not match design")
+}
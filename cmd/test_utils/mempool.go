@@ -0,0 +1,164 @@
+package inttest
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// maxUnconfirmedTxs is the maximum number of transactions tendermint rpc returns from mempool at once
+const maxUnconfirmedTxs = 100
+
+// TxMempoolStatus is a type to describe where a broadcast transaction is
+type TxMempoolStatus string
+
+// statuses of broadcast transaction
+const (
+	// TxCommitted is the status of transaction included in a block, successful or not
+	TxCommitted TxMempoolStatus = "committed"
+	// TxInMempool is the status of transaction accepted by the node and waiting for a block
+	TxInMempool TxMempoolStatus = "in_mempool"
+	// TxNotFound is the status of transaction which was never accepted or was evicted from mempool
+	TxNotFound TxMempoolStatus = "not_found"
+)
+
+// UnconfirmedTxs is a struct to describe transactions waiting in mempool of the node
+// Total is the number of all transactions in mempool, Txs has up to 100 of them in mempool order.
+type UnconfirmedTxs struct {
+	Count      int
+	Total      int
+	TotalBytes int64
+	Txs        []HistoryTx // Height and Code are not set as transactions are not processed yet
+}
+
+// Contains is a function to check if transaction of txhash is in the transactions, txhash is case insensitive
+func (u UnconfirmedTxs) Contains(txhash string) bool {
+	for _, tx := range u.Txs {
+		if strings.EqualFold(tx.TxHash, txhash) {
+			return true
+		}
+	}
+	return false
+}
+
+// mempoolRPC is an interface of tendermint rpc queries used to inspect mempool, implemented by rpc http client
+type mempoolRPC interface {
+	UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
+	NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error)
+	Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
+}
+
+// newMempoolRPC is a function to connect tendermint rpc of the first node of env of ctx
+func newMempoolRPC(ctx context.Context) (mempoolRPC, error) {
+	rpcClient, err := rpchttp.New(EnvFromContext(ctx).firstNode(), "/websocket")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeUnavailable, err.Error())
+	}
+	return rpcClient, nil
+}
+
+func getUnconfirmedTxs(ctx context.Context, rpc mempoolRPC, limit int) (UnconfirmedTxs, error) {
+	if limit <= 0 || limit > maxUnconfirmedTxs {
+		limit = maxUnconfirmedTxs
+	}
+	res, err := rpc.UnconfirmedTxs(ctx, &limit)
+	if err != nil {
+		return UnconfirmedTxs{}, fmt.Errorf("error getting unconfirmed transactions: %w", err)
+	}
+	unconfirmed := UnconfirmedTxs{
+		Count:      res.Count,
+		Total:      res.Total,
+		TotalBytes: res.TotalBytes,
+		Txs:        []HistoryTx{},
+	}
+	for idx, tx := range res.Txs {
+		unconfirmed.Txs = append(unconfirmed.Txs, decodeHistoryTx(0, idx, tx, abci.ResponseDeliverTx{}))
+	}
+	return unconfirmed, nil
+}
+
+// GetUnconfirmedTxs is a function to get transactions waiting in mempool of the node with decoded msgs
+func GetUnconfirmedTxs() (UnconfirmedTxs, error) {
+	return GetUnconfirmedTxsCtx(context.Background(), maxUnconfirmedTxs)
+}
+
+// GetUnconfirmedTxsCtx is a function to get up to limit transactions waiting in mempool, it's canceled when ctx is done
+func GetUnconfirmedTxsCtx(ctx context.Context, limit int) (UnconfirmedTxs, error) {
+	rpc, err := newMempoolRPC(ctx)
+	if err != nil {
+		return UnconfirmedTxs{}, err
+	}
+	return getUnconfirmedTxs(ctx, rpc, limit)
+}
+
+// GetMempoolSize is a function to get number and total bytes of transactions in mempool without fetching them
+func GetMempoolSize(ctx context.Context) (int, int64, error) {
+	rpc, err := newMempoolRPC(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	res, err := rpc.NumUnconfirmedTxs(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error getting number of unconfirmed transactions: %w", err)
+	}
+	return res.Total, res.TotalBytes, nil
+}
+
+// isTxInMempool is a function to check if txhash is in the first 100 transactions of mempool
+// It returns error when txhash is not found and mempool has more transactions than rpc returns, as it can't be told.
+func isTxInMempool(ctx context.Context, rpc mempoolRPC, txhash string) (bool, error) {
+	unconfirmed, err := getUnconfirmedTxs(ctx, rpc, maxUnconfirmedTxs)
+	if err != nil {
+		return false, err
+	}
+	if unconfirmed.Contains(txhash) {
+		return true, nil
+	}
+	if unconfirmed.Total > len(unconfirmed.Txs) {
+		return false, fmt.Errorf("tx %s is not in the first %d of %d unconfirmed transactions", txhash, len(unconfirmed.Txs), unconfirmed.Total)
+	}
+	return false, nil
+}
+
+// IsTxInMempool is a function to check if transaction of txhash is accepted by the node and waiting for a block
+func IsTxInMempool(txhash string) (bool, error) {
+	rpc, err := newMempoolRPC(context.Background())
+	if err != nil {
+		return false, err
+	}
+	return isTxInMempool(context.Background(), rpc, txhash)
+}
+
+func getTxMempoolStatus(ctx context.Context, rpc mempoolRPC, txhash string) (TxMempoolStatus, error) {
+	hash, err := hex.DecodeString(txhash)
+	if err != nil {
+		return "", fmt.Errorf("error decoding txhash %s: %w", txhash, err)
+	}
+	if _, err = rpc.Tx(ctx, hash, false); err == nil {
+		return TxCommitted, nil
+	} else if !strings.Contains(err.Error(), "not found") {
+		return "", fmt.Errorf("error getting tx %s: %w", txhash, err)
+	}
+	inMempool, err := isTxInMempool(ctx, rpc, txhash)
+	if err != nil {
+		return "", err
+	}
+	if inMempool {
+		return TxInMempool, nil
+	}
+	return TxNotFound, nil
+}
+
+// GetTxMempoolStatus is a function to tell if broadcast transaction is committed, stuck in mempool or never accepted by the node
+func GetTxMempoolStatus(ctx context.Context, txhash string) (TxMempoolStatus, error) {
+	rpc, err := newMempoolRPC(ctx)
+	if err != nil {
+		return "", err
+	}
+	return getTxMempoolStatus(ctx, rpc, txhash)
+}
@@ -0,0 +1,64 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	originT "testing"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+type fakeMempoolRPC struct {
+	txs       tmtypes.Txs
+	total     int
+	committed map[string]bool
+}
+
+func (r *fakeMempoolRPC) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
+	return &ctypes.ResultUnconfirmedTxs{Count: len(r.txs), Total: r.total, Txs: r.txs}, nil
+}
+
+func (r *fakeMempoolRPC) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
+	return &ctypes.ResultUnconfirmedTxs{Total: r.total}, nil
+}
+
+func (r *fakeMempoolRPC) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	if r.committed[fmt.Sprintf("%X", hash)] {
+		return &ctypes.ResultTx{}, nil
+	}
+	return nil, errors.New("tx not found")
+}
+
+func TestMempool(originT *originT.T) {
+	t := testing.NewT(originT)
+	player := sdk.AccAddress([]byte("mempool_player______")).String()
+	txModel, err := GenTxWithMsg([]sdk.Msg{&types.MsgGetPylons{Amount: sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 10)), Requester: player}})
+	t.MustNil(err, "error generating transaction")
+	txBytes, err := app.MakeEncodingConfig().TxConfig.TxEncoder()(txModel)
+	t.MustNil(err, "error encoding transaction")
+	pending := fmt.Sprintf("%X", tmtypes.Tx(txBytes).Hash())
+	committed := fmt.Sprintf("%X", tmtypes.Tx("committed").Hash())
+	missing := fmt.Sprintf("%X", tmtypes.Tx("missing").Hash())
+
+	rpc := &fakeMempoolRPC{txs: tmtypes.Txs{txBytes}, total: 1, committed: map[string]bool{committed: true}}
+	unconfirmed, err := getUnconfirmedTxs(context.Background(), rpc, 0)
+	t.MustNil(err, "error getting unconfirmed transactions")
+	t.MustTrue(unconfirmed.Total == 1 && unconfirmed.Txs[0].Signers[0] == player, "unconfirmed transactions should be decoded")
+	t.MustTrue(unconfirmed.Contains(pending) && !unconfirmed.Contains(missing), "mempool should contain pending transaction only")
+
+	for txhash, expected := range map[string]TxMempoolStatus{pending: TxInMempool, committed: TxCommitted, missing: TxNotFound} {
+		status, err := getTxMempoolStatus(context.Background(), rpc, txhash)
+		t.MustNil(err, "error getting mempool status")
+		t.WithFields(testing.Fields{"txhash": txhash, "status": status}).MustTrue(status == expected, "unexpected mempool status")
+	}
+
+	rpc.total = 150
+	_, err = isTxInMempool(context.Background(), rpc, missing)
+	t.MustTrue(err != nil, "missing tx should not be reported as not in mempool when mempool is larger than rpc page")
+}
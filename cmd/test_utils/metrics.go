@@ -0,0 +1,119 @@
+package inttest
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+)
+
+// metricsNamespace is the prefix of test harness metric names
+const metricsNamespace = "pylons_test"
+
+// MetricsRegistry is a registry of test harness metrics
+var MetricsRegistry = prometheus.NewRegistry()
+
+var (
+	txBroadcastDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "tx_broadcast_duration_seconds",
+		Help:      "Latency of signed transaction broadcast including retries.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+	})
+	blockWaitDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "block_wait_duration_seconds",
+		Help:      "Time spent waiting for block intervals.",
+		Buckets:   prometheus.ExponentialBuckets(0.5, 2, 10),
+	})
+	cliInvocations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "cli_invocations_total",
+		Help:      "Number of pylonsd command invocations by command and result.",
+	}, []string{"command", "result"})
+	retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "retries_total",
+		Help:      "Number of retries of operations failed by transient node errors by error class.",
+	}, []string{"error_class"})
+	txFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "tx_failures_total",
+		Help:      "Number of failed transactions by msg type and error class.",
+	}, []string{"msg_type", "error_class"})
+)
+
+func init() {
+	MetricsRegistry.MustRegister(txBroadcastDuration, blockWaitDuration, cliInvocations, retries, txFailures)
+}
+
+// StartMetricsServer is a function to serve test harness metrics on /metrics in prometheus text format
+func StartMetricsServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(MetricsRegistry, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.WithFields(log.Fields{
+				"addr":  addr,
+				"error": err,
+			}).Errorln("error serving metrics")
+		}
+	}()
+	return server
+}
+
+// WriteMetricsFile is a function to dump test harness metrics into file in prometheus text format
+// The file can be collected by node exporter textfile collector after nightly runs
+func WriteMetricsFile(filename string) error {
+	return prometheus.WriteToTextfile(filename, MetricsRegistry)
+}
+
+// errorClassName is a function to get name of failure class of error for metric labels
+func errorClassName(err error) string {
+	for _, ec := range errorClasses {
+		if errors.Is(err, ec.Class) {
+			return ec.Class.Error()
+		}
+	}
+	return "unknown"
+}
+
+// cliCommandName is a function to get command name of pylonsd args for metric labels e.g. "tx broadcast"
+func cliCommandName(args []string) string {
+	name := []string{}
+	for _, arg := range args {
+		if len(name) == 2 || strings.HasPrefix(arg, "-") || strings.ContainsAny(arg, "./\\") {
+			break
+		}
+		name = append(name, arg)
+	}
+	return strings.Join(name, " ")
+}
+
+func observeCLIInvocation(command string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	cliInvocations.WithLabelValues(command, result).Inc()
+}
+
+func observeRetry(err error) {
+	retries.WithLabelValues(errorClassName(err)).Inc()
+}
+
+func observeTxFailure(msgs []sdk.Msg, err error) {
+	for _, msg := range msgs {
+		txFailures.WithLabelValues(msg.Type(), errorClassName(err)).Inc()
+	}
+}
+
+func observeDuration(histogram prometheus.Histogram, start time.Time) {
+	histogram.Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,36 @@
+package inttest
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestMetrics(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	t.MustTrue(cliCommandName([]string{"tx", "broadcast", "/tmp/signed_tx.json", "--node", "tcp://localhost:26657"}) == "tx broadcast", "command name should not have file path and flags")
+	t.MustTrue(cliCommandName([]string{"query", "pylons", "get_recipe", "id"}) == "query pylons", "command name should have two args")
+	t.MustTrue(cliCommandName([]string{"status", "--node", "tcp://localhost:26657"}) == "status", "command name should not have flags")
+	t.MustTrue(cliCommandName([]string{"keys", `C:\Users\dev\keyring`}) == "keys", "command name should not have windows path")
+
+	t.MustTrue(errorClassName(NewTxError("sdk", 20, "mempool is full")) == ErrMempoolFull.Error(), "error class name should be detected")
+	t.MustTrue(errorClassName(errors.New("unknown failure")) == "unknown", "unknown error should have unknown class")
+
+	observeCLIInvocation("query tx", nil)
+	observeRetry(NewTxError("sdk", 20, "mempool is full"))
+
+	tmpDir, err := ioutil.TempDir("", "pylons")
+	t.MustNil(err, "error creating temp directory")
+	defer os.RemoveAll(tmpDir)
+	metricsFile := filepath.Join(tmpDir, "metrics.prom")
+	t.MustNil(WriteMetricsFile(metricsFile), "error writing metrics file")
+	metrics, err := ioutil.ReadFile(metricsFile)
+	t.MustNil(err, "error reading metrics file")
+	t.MustContain(string(metrics), `pylons_test_cli_invocations_total{command="query tx",result="success"}`, "cli invocation should be dumped")
+	t.MustContain(string(metrics), `pylons_test_retries_total{error_class="mempool is full"}`, "retry should be dumped")
+}
@@ -0,0 +1,92 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/go-bip39"
+)
+
+// keyring doesn't keep mnemonics of keys, so mnemonics of keys created or imported by this package are kept here
+var (
+	mnemonicMux sync.RWMutex
+	mnemonics   = map[string]string{}
+)
+
+// DefaultHDPath is a function to get derivation path of the first account used when hd path is not set
+func DefaultHDPath() string {
+	return hd.CreateHDPath(sdk.CoinType, 0, 0).String()
+}
+
+// mnemonicKey is a function to get key of mnemonic store, same key names of different keyrings don't collide
+func mnemonicKey(provider KeyringProvider, name string) string {
+	return strings.Join(provider.KeyringArgs(), " ") + "/" + name
+}
+
+// rememberMnemonic is a function to keep mnemonic of key to be exported by ExportMnemonic
+func rememberMnemonic(provider KeyringProvider, name, mnemonic string) {
+	if len(mnemonic) == 0 {
+		return
+	}
+	RegisterSecret(mnemonic)
+	mnemonicMux.Lock()
+	defer mnemonicMux.Unlock()
+	mnemonics[mnemonicKey(provider, name)] = mnemonic
+}
+
+// CreateAccountFromMnemonic is a function to add key of mnemonic derived at hdPath into keyring
+// Well known mnemonics give the same address on every run and machine, default hd path is used when hdPath is empty.
+func CreateAccountFromMnemonic(name, mnemonic, hdPath string) (map[string]string, error) {
+	return createAccountFromMnemonic(GetKeyringProvider(), name, mnemonic, hdPath)
+}
+
+func createAccountFromMnemonic(provider KeyringProvider, name, mnemonic, hdPath string) (map[string]string, error) {
+	result := make(map[string]string)
+	if len(name) == 0 {
+		return result, errors.New("key is empty")
+	}
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return result, fmt.Errorf("mnemonic of key %s is not a valid bip39 mnemonic", name)
+	}
+	if len(hdPath) == 0 {
+		hdPath = DefaultHDPath()
+	}
+	if _, err := hd.NewParamsFromPath(hdPath); err != nil {
+		return result, fmt.Errorf("invalid hd path %s: %w", hdPath, err)
+	}
+	RegisterSecret(mnemonic)
+	output, logstr, err := Keys().Recover(name, mnemonic).HDPath(hdPath).WithKeyring(provider).Run(context.Background())
+	if err != nil {
+		result["logstr"] = logstr
+		result["output"] = string(output)
+		return result, err
+	}
+	if err = json.Unmarshal(output, &result); err != nil {
+		return result, err
+	}
+	rememberMnemonic(provider, name, mnemonic)
+	return result, nil
+}
+
+// ExportMnemonic is a function to get mnemonic of key created by AddNewLocalKey or CreateAccountFromMnemonic
+// Keyring doesn't store mnemonics, so keys added outside of this package can't be exported.
+func ExportMnemonic(name string) (string, error) {
+	return exportMnemonic(GetKeyringProvider(), name)
+}
+
+func exportMnemonic(provider KeyringProvider, name string) (string, error) {
+	mnemonicMux.RLock()
+	defer mnemonicMux.RUnlock()
+	mnemonic, ok := mnemonics[mnemonicKey(provider, name)]
+	if !ok {
+		return "", fmt.Errorf("mnemonic of key %s is unknown, only keys created or imported by test utils can be exported", name)
+	}
+	return mnemonic, nil
+}
@@ -0,0 +1,43 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestMnemonicStore(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	first := TestKeyring{Dir: "first"}
+	second := TestKeyring{Dir: "second"}
+	rememberMnemonic(first, "eugen", mnemonic)
+
+	exported, err := exportMnemonic(first, "eugen")
+	t.MustNil(err, "error exporting mnemonic of remembered key")
+	t.MustTrue(exported == mnemonic, "exported mnemonic should be the remembered one")
+
+	_, err = exportMnemonic(second, "eugen")
+	t.MustTrue(err != nil, "mnemonic of key in another keyring should be unknown")
+
+	_, err = createAccountFromMnemonic(first, "eugen", "not a mnemonic", "")
+	t.MustTrue(err != nil, "invalid mnemonic should be rejected before running pylonsd")
+	_, err = createAccountFromMnemonic(first, "eugen", mnemonic, "m/44'/118'")
+	t.MustTrue(err != nil, "invalid hd path should be rejected before running pylonsd")
+}
+
+func TestPrivKeyFromMnemonicWithHDPath(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	defaultKey, err := PrivKeyFromMnemonic(mnemonic)
+	t.MustNil(err, "error deriving key of default hd path")
+	sameKey, err := PrivKeyFromMnemonicWithHDPath(mnemonic, DefaultHDPath())
+	t.MustNil(err, "error deriving key of default hd path")
+	t.MustTrue(defaultKey.Equals(sameKey), "same mnemonic and hd path should derive same key")
+
+	otherKey, err := PrivKeyFromMnemonicWithHDPath(mnemonic, "m/44'/118'/0'/0/1")
+	t.MustNil(err, "error deriving key of second account")
+	t.MustTrue(!defaultKey.Equals(otherKey), "different hd path should derive different key")
+}
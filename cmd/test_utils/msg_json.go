@@ -0,0 +1,120 @@
+package inttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// describes the errors of turning user-authored json into msgs
+var (
+	ErrUnknownMsgType = errors.New("unknown msg type")
+	ErrInvalidMsgJSON = errors.New("invalid msg json")
+)
+
+// MsgSchema is a struct to describe json of a msg type accepted by ParseMsgJSON
+// Fields are checked strictly by proto json names of the msg, so unknown and misspelled fields are refused at any depth.
+type MsgSchema struct {
+	// New creates empty msg json is decoded into
+	New func() sdk.Msg
+	// Required are top level fields which should be set, the rest is left to validation of the msg
+	Required []string
+}
+
+// msgSchemas is schemas of msg types by msg type name and type url
+var msgSchemas = map[string]MsgSchema{}
+
+func init() {
+	for _, schema := range []MsgSchema{
+		{New: func() sdk.Msg { return &types.MsgCreateAccount{} }, Required: []string{"Requester"}},
+		{New: func() sdk.Msg { return &types.MsgGetPylons{} }, Required: []string{"Amount", "Requester"}},
+		{New: func() sdk.Msg { return &types.MsgGoogleIAPGetPylons{} }, Required: []string{"ProductID", "PurchaseToken", "ReceiptDataBase64", "Signature", "Requester"}},
+		{New: func() sdk.Msg { return &types.MsgSendCoins{} }, Required: []string{"Amount", "Sender", "Receiver"}},
+		{New: func() sdk.Msg { return &types.MsgSendItems{} }, Required: []string{"ItemIDs", "Sender", "Receiver"}},
+		{New: func() sdk.Msg { return &types.MsgCreateCookbook{} }, Required: []string{"Name", "Sender"}},
+		{New: func() sdk.Msg { return &types.MsgUpdateCookbook{} }, Required: []string{"ID", "Sender"}},
+		{New: func() sdk.Msg { return &types.MsgCreateRecipe{} }, Required: []string{"Name", "CookbookID", "Sender"}},
+		{New: func() sdk.Msg { return &types.MsgUpdateRecipe{} }, Required: []string{"ID", "Name", "CookbookID", "Sender"}},
+		{New: func() sdk.Msg { return &types.MsgExecuteRecipe{} }, Required: []string{"RecipeID", "Sender"}},
+		{New: func() sdk.Msg { return &types.MsgCheckExecution{} }, Required: []string{"ExecID", "Sender"}},
+		{New: func() sdk.Msg { return &types.MsgEnableRecipe{} }, Required: []string{"RecipeID", "Sender"}},
+		{New: func() sdk.Msg { return &types.MsgDisableRecipe{} }, Required: []string{"RecipeID", "Sender"}},
+		{New: func() sdk.Msg { return &types.MsgFiatItem{} }, Required: []string{"CookbookID", "Sender"}},
+		{New: func() sdk.Msg { return &types.MsgUpdateItemString{} }, Required: []string{"ItemID", "Field", "Sender"}},
+		{New: func() sdk.Msg { return &types.MsgCreateTrade{} }, Required: []string{"Sender"}},
+		{New: func() sdk.Msg { return &types.MsgFulfillTrade{} }, Required: []string{"TradeID", "Sender"}},
+		{New: func() sdk.Msg { return &types.MsgEnableTrade{} }, Required: []string{"TradeID", "Sender"}},
+		{New: func() sdk.Msg { return &types.MsgDisableTrade{} }, Required: []string{"TradeID", "Sender"}},
+		{New: func() sdk.Msg { return &banktypes.MsgSend{} }, Required: []string{"from_address", "to_address", "amount"}},
+	} {
+		RegisterMsgSchema(schema)
+	}
+}
+
+// RegisterMsgSchema is a function to make msgs of schema parsable by their type name e.g. create_cookbook and type url
+// e.g. /pylons.MsgCreateCookbook, schema of the same msg type is replaced
+func RegisterMsgSchema(schema MsgSchema) {
+	msg := schema.New()
+	msgSchemas[msg.Type()] = schema
+	msgSchemas[MsgTypeURL(msg)] = schema
+}
+
+// MsgTypes is a function to get sorted type names and type urls which ParseMsgJSON accepts
+func MsgTypes() []string {
+	msgTypes := []string{}
+	for msgType := range msgSchemas {
+		msgTypes = append(msgTypes, msgType)
+	}
+	sort.Strings(msgTypes)
+	return msgTypes
+}
+
+// DecodeMsgJSON is a function to decode json object of msg type strictly without validating the msg
+// Unknown fields at any depth, missing required fields and data after the object are refused with ErrInvalidMsgJSON.
+// It's used to build msgs which are expected to be invalid e.g. by fixture steps expecting errors.
+func DecodeMsgJSON(msgType string, raw []byte) (sdk.Msg, error) {
+	schema, ok := msgSchemas[msgType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMsgType, msgType)
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	var fields map[string]json.RawMessage
+	if err := dec.Decode(&fields); err != nil || fields == nil {
+		return nil, fmt.Errorf("%w: %s msg should be a json object", ErrInvalidMsgJSON, msgType)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("%w: %s msg has data after json object", ErrInvalidMsgJSON, msgType)
+	}
+	for _, field := range schema.Required {
+		value, ok := fields[field]
+		if !ok || string(value) == "null" {
+			return nil, fmt.Errorf("%w: %s is required in %s msg", ErrInvalidMsgJSON, field, msgType)
+		}
+	}
+	msg := schema.New()
+	if err := GetJSONMarshaler().UnmarshalJSON(raw, msg); err != nil {
+		return nil, fmt.Errorf("%w: %s msg: %s", ErrInvalidMsgJSON, msgType, err.Error())
+	}
+	return msg, nil
+}
+
+// ParseMsgJSON is a function to turn user-authored json of msg type into validated msg
+// msgType is a type name e.g. execute_recipe or a type url e.g. /pylons.MsgExecuteRecipe, see MsgTypes.
+// Json is decoded by DecodeMsgJSON and the msg is validated by types.ValidateMsg, errors of invalid fields are MsgValidationError.
+func ParseMsgJSON(msgType string, raw []byte) (sdk.Msg, error) {
+	msg, err := DecodeMsgJSON(msgType, raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := types.ValidateMsg(msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
@@ -0,0 +1,56 @@
+package inttest
+
+import (
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+func TestParseMsgJSON(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	sender := sdk.AccAddress([]byte("msg_json_test_sender")).String()
+	receiver := sdk.AccAddress([]byte("msg_json_test_receiv")).String()
+
+	msg, err := ParseMsgJSON("execute_recipe", []byte(`{"RecipeID": "recipe-1", "Sender": "`+sender+`", "ItemIDs": ["item-1"]}`))
+	t.MustNil(err, "error parsing execute recipe msg")
+	execMsg, ok := msg.(*types.MsgExecuteRecipe)
+	t.MustTrue(ok && execMsg.RecipeID == "recipe-1" && len(execMsg.ItemIDs) == 1, "msg should be decoded by its type")
+
+	msg, err = ParseMsgJSON(MsgTypeURL(&types.MsgSendCoins{}), []byte(`{"Amount": [{"denom": "pylon", "amount": "10"}], "Sender": "`+sender+`", "Receiver": "`+receiver+`"}`))
+	t.MustTrue(err == nil && msg.Type() == "send_coins", "type url should be accepted as msg type")
+	msg, err = ParseMsgJSON("send", []byte(`{"from_address": "`+sender+`", "to_address": "`+receiver+`", "amount": [{"denom": "pylon", "amount": "10"}]}`))
+	_, ok = msg.(*banktypes.MsgSend)
+	t.MustTrue(err == nil && ok, "bank send should be parsable")
+
+	for _, tc := range []struct {
+		msgType string
+		raw     string
+		want    error
+	}{
+		{"burn_item", `{}`, ErrUnknownMsgType},
+		{"execute_recipe", `{"RecipeID": "recipe-1", "Sender": "` + sender + `", "sender": "eugen"}`, ErrInvalidMsgJSON},
+		{"execute_recipe", `{"RecipeID": "recipe-1"}`, ErrInvalidMsgJSON},
+		{"execute_recipe", `["recipe-1"]`, ErrInvalidMsgJSON},
+		{"execute_recipe", `{"RecipeID": "recipe-1", "Sender": "` + sender + `"} {}`, ErrInvalidMsgJSON},
+		{"send_coins", `{"Amount": [{"denom": "pylon", "amount": "10", "extra": 1}], "Sender": "` + sender + `", "Receiver": "` + receiver + `"}`, ErrInvalidMsgJSON},
+	} {
+		_, err = ParseMsgJSON(tc.msgType, []byte(tc.raw))
+		t.WithFields(testing.Fields{
+			"msg_type": tc.msgType,
+			"raw":      tc.raw,
+			"error":    err,
+		}).MustTrue(errors.Is(err, tc.want), "msg json should be refused")
+	}
+
+	raw := []byte(`{"RecipeID": "recipe-1", "Sender": "eugen"}`)
+	_, err = ParseMsgJSON("execute_recipe", raw)
+	var validationErr *types.MsgValidationError
+	t.MustTrue(errors.As(err, &validationErr), "invalid msg should be refused by validation")
+	msg, err = DecodeMsgJSON("execute_recipe", raw)
+	t.MustTrue(err == nil && msg != nil, "decode should not validate msg")
+}
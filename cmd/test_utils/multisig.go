@@ -0,0 +1,142 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// CreateMultisigKey is a function to add multisig key composed of keys with threshold into test keyring
+// It returns address of the multisig key
+func CreateMultisigKey(name string, keys []string, threshold int) (string, error) {
+	if len(name) == 0 {
+		return "", errors.New("key is empty")
+	}
+	if threshold <= 0 || threshold > len(keys) {
+		return "", fmt.Errorf("threshold should be between 1 and %d", len(keys))
+	}
+	params := []string{"keys", "add", name,
+		"--multisig", strings.Join(keys, ","),
+		"--multisig-threshold", strconv.Itoa(threshold),
+	}
+	output, logstr, err := RunPylonsdCtx(context.Background(), params, "")
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", logstr, err)
+	}
+	var keyInfo struct {
+		Address string `json:"address"`
+	}
+	err = json.Unmarshal(output, &keyInfo)
+	return keyInfo.Address, err
+}
+
+// CreateMultisigAccount is a function to create multisig key and its account on chain
+// funder sends amount to the multisig address so that the account exists and is able to pay for its transactions
+func CreateMultisigAccount(t *testing.T, name string, keys []string, threshold int, funderKey string, amount sdk.Coins) (string, error) {
+	address, err := CreateMultisigKey(name, keys, threshold)
+	if err != nil {
+		return "", err
+	}
+	funderAddr, err := sdk.AccAddressFromBech32(GetAccountAddr(funderKey, t))
+	if err != nil {
+		return address, err
+	}
+	multisigAddr, err := sdk.AccAddressFromBech32(address)
+	if err != nil {
+		return address, err
+	}
+	sendMsg := banktypes.NewMsgSend(funderAddr, multisigAddr, amount)
+	txhash, err := NewClient().SendTx(context.Background(), t, SignerKey(funderKey), sendMsg)
+	if err != nil {
+		return address, err
+	}
+	if _, err = WaitAndGetTxResult(txhash, t); err != nil {
+		return address, fmt.Errorf("error funding multisig account: %w", err)
+	}
+	t.WithFields(testing.Fields{
+		"multisig_key": name,
+		"address":      address,
+		"keys":         keys,
+		"threshold":    threshold,
+	}).Info("created multisig account")
+	return address, nil
+}
+
+// SendMultisigTx is a function to gather partial signatures of msgs from signer keys, combine them and broadcast
+// Sequence is fetched from chain, so transactions of a multisig account should not be sent in parallel
+func SendMultisigTx(t *testing.T, msgs []sdk.Msg, multisigKey string, signerKeys []string) (string, error) {
+	return SendMultisigTxCtx(TestContext(t), t, msgs, multisigKey, signerKeys)
+}
+
+// SendMultisigTxCtx is a function to send msgs of multisig account signed for chain id of env of ctx
+func SendMultisigTxCtx(ctx context.Context, t *testing.T, msgs []sdk.Msg, multisigKey string, signerKeys []string) (string, error) {
+	multisigAddr := GetAccountAddr(multisigKey, t)
+	accInfo := GetAccountInfoFromAddr(multisigAddr, t)
+	accountArgs := []string{
+		"--offline",
+		"--chain-id", EnvFromContext(ctx).ChainID(),
+		"--sequence", strconv.FormatUint(accInfo.GetSequence(), 10),
+		"--account-number", strconv.FormatUint(accInfo.GetAccountNumber(), 10),
+	}
+
+	txModel, err := GenTxWithMsg(msgs)
+	if err != nil {
+		return "", err
+	}
+	output, err := GetTxJSONEncoder()(txModel)
+	if err != nil {
+		return "", err
+	}
+	tmpDir, err := ioutil.TempDir("", "pylons")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+	rawTxFile := filepath.Join(tmpDir, "raw_tx.json")
+	if err = ioutil.WriteFile(rawTxFile, output, 0644); err != nil {
+		return "", err
+	}
+
+	// pylonsd tx sign raw_tx.json --from k1 --multisig <multisig_address> --output-document k1sig.json
+	sigFiles := []string{}
+	for _, signerKey := range signerKeys {
+		sigFile := filepath.Join(tmpDir, signerKey+"_sig.json")
+		txSignArgs := append([]string{"tx", "sign", rawTxFile,
+			"--from", signerKey,
+			"--multisig", multisigAddr,
+			"--output-document", sigFile,
+		}, accountArgs...)
+		if _, logstr, err := RunPylonsdCtx(ctx, txSignArgs, ""); err != nil {
+			return "", fmt.Errorf("error signing by %s: %s: %w", signerKey, logstr, err)
+		}
+		sigFiles = append(sigFiles, sigFile)
+	}
+
+	// pylonsd tx multisign raw_tx.json <multisig_key> k1sig.json k2sig.json --output-document signed_tx.json
+	signedTxFile := filepath.Join(tmpDir, "signed_tx.json")
+	txMultisignArgs := append([]string{"tx", "multisign", rawTxFile, multisigKey}, sigFiles...)
+	txMultisignArgs = append(txMultisignArgs, "--output-document", signedTxFile)
+	txMultisignArgs = append(txMultisignArgs, accountArgs...)
+	if _, logstr, err := RunPylonsdCtx(ctx, txMultisignArgs, ""); err != nil {
+		return "", fmt.Errorf("error combining signatures: %s: %w", logstr, err)
+	}
+
+	txhash, err := broadcastTxFile(ctx, signedTxFile, EnvFromContext(ctx).MaxBroadcastRetry(), t)
+	t.WithFields(testing.Fields{
+		"multisig_key": multisigKey,
+		"signer_keys":  signerKeys,
+		"sequence":     accInfo.GetSequence(),
+		"txhash":       txhash,
+	}).AddFields(GetLogFieldsFromMsgs(msgs)).Debug("multisig transaction broadcast")
+	return txhash, err
+}
@@ -0,0 +1,323 @@
+package inttest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// SnapshotNodeLog is the name of failure snapshot section having node log excerpts of the failed test
+const SnapshotNodeLog = "node_log"
+
+// nodeLogPollInterval is the interval node log file is read for new lines
+var nodeLogPollInterval = 200 * time.Millisecond
+
+// defaultNodeLogLines is the number of latest node log lines kept by tailer
+const defaultNodeLogLines = 10000
+
+// nodeLogExcerptLines is the maximum number of lines logged while a failed test runs in its excerpt
+const nodeLogExcerptLines = 200
+
+// nodeLogSlack is the time before start of a test whose node log lines are in its excerpt, e.g. block committing its first tx
+const nodeLogSlack = 2 * time.Second
+
+// tendermintLogTime matches timestamp of tendermint plain log lines e.g. I[2021-03-10|09:54:23.917] Executed block
+var tendermintLogTime = regexp.MustCompile(`^[A-Z]\[(\d{4}-\d{2}-\d{2}\|\d{2}:\d{2}:\d{2}\.\d{3})\]`)
+
+// tendermintLogTimeLayout is the layout of timestamp of tendermint plain log lines, it's in local time
+const tendermintLogTimeLayout = "2006-01-02|15:04:05.000"
+
+// NodeLogLine is a struct to describe a line of node log with the time it's logged
+// Time is the timestamp of tendermint log line, or the time tailer read it for lines without timestamp.
+type NodeLogLine struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
+}
+
+// NodeLogExcerpt is a struct to describe node log lines relevant to a test
+type NodeLogExcerpt struct {
+	File  string    `json:"file"`
+	Since time.Time `json:"since"`
+	Lines []string  `json:"lines"`
+	// Omitted is the number of lines logged while the test ran which are left out not to flood the failure
+	Omitted int `json:"omitted,omitempty"`
+}
+
+// NodeLogTailer is a struct to follow log file of locally bootstrapped node and keep its latest lines
+// Lines are correlated to tests by time and txhashes, see Excerpt.
+type NodeLogTailer struct {
+	File     string
+	MaxLines int // defaultNodeLogLines is used when it's 0
+
+	mux     sync.Mutex
+	lines   []NodeLogLine
+	offset  int64
+	partial []byte
+	now     func() time.Time
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewNodeLogTailer is a function to create tailer of node log file, it's not read until Start
+func NewNodeLogTailer(file string) *NodeLogTailer {
+	return &NodeLogTailer{
+		File: file,
+		now:  time.Now,
+	}
+}
+
+// StartNodeLogTailer is a function to follow node log file from its current end and attach excerpts to failures
+func StartNodeLogTailer(file string) (*NodeLogTailer, error) {
+	tailer := NewNodeLogTailer(file)
+	if err := tailer.Start(false); err != nil {
+		return nil, err
+	}
+	return tailer, nil
+}
+
+// Start is a function to follow log file in background and add its excerpts to failure snapshots of tests
+// Lines already in the file are skipped unless fromStart is set, file which doesn't exist yet is read once it's created.
+func (l *NodeLogTailer) Start(fromStart bool) error {
+	l.mux.Lock()
+	if !fromStart {
+		info, err := os.Stat(l.File)
+		if err != nil && !os.IsNotExist(err) {
+			l.mux.Unlock()
+			return err
+		}
+		if err == nil {
+			l.offset = info.Size()
+		}
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	l.stop, l.done = stop, done
+	l.mux.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(nodeLogPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				// read errors e.g. by rotation are retried on next tick
+				l.poll()
+			}
+		}
+	}()
+	registerNodeLogTailer(l)
+	return nil
+}
+
+// Stop is a function to stop following log file, lines written until now are still read
+func (l *NodeLogTailer) Stop() {
+	unregisterNodeLogTailer(l)
+	l.mux.Lock()
+	stop, done := l.stop, l.done
+	l.stop = nil
+	l.mux.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+	l.poll()
+}
+
+// poll is a function to read lines appended to log file since the last read
+// File smaller than the read offset is treated as truncated or rotated and read from start.
+func (l *NodeLogTailer) poll() error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	f, err := os.Open(l.File)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() < l.offset {
+		l.offset = 0
+		l.partial = nil
+	}
+	if info.Size() == l.offset {
+		return nil
+	}
+	if _, err = f.Seek(l.offset, io.SeekStart); err != nil {
+		return err
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(f, info.Size()-l.offset))
+	if err != nil {
+		return err
+	}
+	l.offset += int64(len(data))
+	l.addData(data)
+	return nil
+}
+
+// addData is a function to add complete lines of data, the last line without newline is kept until the rest is read
+func (l *NodeLogTailer) addData(data []byte) {
+	data = append(l.partial, data...)
+	lines := bytes.Split(data, []byte("\n"))
+	l.partial = append([]byte{}, lines[len(lines)-1]...)
+	readAt := l.now()
+	maxLines := l.MaxLines
+	if maxLines <= 0 {
+		maxLines = defaultNodeLogLines
+	}
+	for _, line := range lines[:len(lines)-1] {
+		text := strings.TrimRight(string(line), "\r")
+		if len(strings.TrimSpace(text)) == 0 {
+			continue
+		}
+		l.lines = append(l.lines, NodeLogLine{Time: nodeLogLineTime(text, readAt), Text: text})
+	}
+	// lines are compacted once they're double the limit not to copy them on every line
+	if len(l.lines) >= 2*maxLines {
+		l.lines = append([]NodeLogLine{}, l.lines[len(l.lines)-maxLines:]...)
+	}
+}
+
+// nodeLogLineTime is a function to get timestamp of tendermint log line, readAt is used for lines without timestamp
+func nodeLogLineTime(text string, readAt time.Time) time.Time {
+	match := tendermintLogTime.FindStringSubmatch(text)
+	if match == nil {
+		return readAt
+	}
+	logTime, err := time.ParseInLocation(tendermintLogTimeLayout, match[1], time.Local)
+	if err != nil {
+		return readAt
+	}
+	return logTime
+}
+
+// Lines is a function to get kept lines logged since the time, all kept lines when since is zero
+func (l *NodeLogTailer) Lines(since time.Time) []NodeLogLine {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	maxLines := l.MaxLines
+	if maxLines <= 0 {
+		maxLines = defaultNodeLogLines
+	}
+	lines := l.lines
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	result := []NodeLogLine{}
+	for _, line := range lines {
+		if !line.Time.Before(since) {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// Excerpt is a function to get lines relevant to a test which started at since and sent txhashes
+// Lines mentioning the txhashes e.g. mempool and abci logs of the transactions are always kept, and the latest limit
+// lines logged while the test ran are kept with them in log order.
+func (l *NodeLogTailer) Excerpt(since time.Time, txhashes []string, limit int) NodeLogExcerpt {
+	excerpt := NodeLogExcerpt{File: l.File, Since: since, Lines: []string{}}
+	if !since.IsZero() {
+		since = since.Add(-nodeLogSlack)
+	}
+	hashes := []string{}
+	for _, txhash := range txhashes {
+		if len(txhash) > 0 {
+			hashes = append(hashes, strings.ToUpper(txhash))
+		}
+	}
+	lines := l.Lines(time.Time{})
+	inWindow := 0
+	for _, line := range lines {
+		if !line.Time.Before(since) {
+			inWindow++
+		}
+	}
+	// lines of the time window beyond the limit are omitted from the oldest
+	skip := inWindow - limit
+	for _, line := range lines {
+		if mentionsAny(line.Text, hashes) {
+			excerpt.Lines = append(excerpt.Lines, line.Text)
+			continue
+		}
+		if line.Time.Before(since) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			excerpt.Omitted++
+			continue
+		}
+		excerpt.Lines = append(excerpt.Lines, line.Text)
+	}
+	return excerpt
+}
+
+// mentionsAny is a function to check if log text has any of uppercase txhashes, tendermint logs hashes in uppercase
+func mentionsAny(text string, hashes []string) bool {
+	upper := strings.ToUpper(text)
+	for _, hash := range hashes {
+		if strings.Contains(upper, hash) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	nodeLogTailersMux sync.Mutex
+	nodeLogTailers    []*NodeLogTailer
+)
+
+// registerNodeLogTailer is a function to add tailer to failure snapshots, node log section is captured while any tailer runs
+func registerNodeLogTailer(l *NodeLogTailer) {
+	nodeLogTailersMux.Lock()
+	defer nodeLogTailersMux.Unlock()
+	nodeLogTailers = append(nodeLogTailers, l)
+	if len(nodeLogTailers) == 1 {
+		testing.AddFailureSnapshotCapturer(SnapshotNodeLog, captureNodeLogs)
+	}
+}
+
+func unregisterNodeLogTailer(l *NodeLogTailer) {
+	nodeLogTailersMux.Lock()
+	defer nodeLogTailersMux.Unlock()
+	for idx, tailer := range nodeLogTailers {
+		if tailer == l {
+			nodeLogTailers = append(nodeLogTailers[:idx:idx], nodeLogTailers[idx+1:]...)
+			if len(nodeLogTailers) == 0 {
+				testing.RemoveFailureSnapshotCapturer(SnapshotNodeLog)
+			}
+			return
+		}
+	}
+}
+
+// captureNodeLogs is a function to get excerpt of each followed node log for the failed test
+func captureNodeLogs(ctx context.Context, req testing.FailureSnapshotRequest) (interface{}, error) {
+	nodeLogTailersMux.Lock()
+	tailers := append([]*NodeLogTailer{}, nodeLogTailers...)
+	nodeLogTailersMux.Unlock()
+	excerpts := []NodeLogExcerpt{}
+	for _, tailer := range tailers {
+		// lines written since the last tick are relevant to the failure which just happened
+		tailer.poll()
+		excerpts = append(excerpts, tailer.Excerpt(req.StartedAt, req.TxHashes, nodeLogExcerptLines))
+	}
+	return excerpts, nil
+}
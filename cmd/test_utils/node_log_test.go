@@ -0,0 +1,85 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func appendNodeLog(t *testing.T, file string, text string) {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	t.MustNil(err, "error opening node log")
+	defer f.Close()
+	_, err = f.WriteString(text)
+	t.MustNil(err, "error writing node log")
+}
+
+func tendermintLogLine(at time.Time, msg string) string {
+	return fmt.Sprintf("I[%s] %s\n", at.Format(tendermintLogTimeLayout), msg)
+}
+
+func TestNodeLogTailer(originT *originT.T) {
+	t := testing.NewT(originT)
+	dir, err := ioutil.TempDir("", "node_log")
+	t.MustNil(err, "error creating temp dir")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "node.log")
+
+	start := time.Now().Truncate(time.Millisecond)
+	appendNodeLog(&t, file, tendermintLogLine(start.Add(-time.Hour), "line of earlier run"))
+
+	tailer := NewNodeLogTailer(file)
+	t.MustNil(tailer.Start(false), "error starting tailer")
+	appendNodeLog(&t, file, tendermintLogLine(start.Add(-time.Minute), "Added good transaction tx=ABCDEF height=9"))
+	appendNodeLog(&t, file, tendermintLogLine(start.Add(time.Second), "Executed block height=10"))
+	// last line is written in two parts
+	appendNodeLog(&t, file, "panic: consensus ")
+	t.MustNil(tailer.poll(), "error reading node log")
+	appendNodeLog(&t, file, "failure\r\n")
+	tailer.Stop()
+
+	lines := tailer.Lines(time.Time{})
+	t.MustTrue(len(lines) == 3, "lines of earlier run should be skipped")
+	t.MustTrue(lines[0].Time.Equal(start.Add(-time.Minute)), "time of tendermint log line should be parsed")
+	t.MustTrue(lines[2].Text == "panic: consensus failure", "line written in parts should be joined")
+
+	excerpt := tailer.Excerpt(start, []string{"abcdef"}, 1)
+	t.MustTrue(len(excerpt.Lines) == 2, "excerpt should have lines mentioning txhash and latest line of the test")
+	t.MustContain(excerpt.Lines[0], "tx=ABCDEF", "line of txhash should be kept even before the test started")
+	t.MustTrue(excerpt.Lines[1] == "panic: consensus failure", "latest line should be kept by limit")
+	t.MustTrue(excerpt.Omitted == 1, "lines beyond limit should be counted")
+
+	excerpt = tailer.Excerpt(start, nil, 10)
+	t.MustTrue(len(excerpt.Lines) == 2, "excerpt should only have lines logged while the test ran")
+
+	// truncated log is read from start
+	t.MustNil(ioutil.WriteFile(file, []byte("restarted\n"), 0644), "error truncating node log")
+	t.MustNil(tailer.poll(), "error reading truncated node log")
+	lines = tailer.Lines(time.Time{})
+	t.MustTrue(lines[len(lines)-1].Text == "restarted", "truncated log should be read from start")
+}
+
+func TestNodeLogFailureSnapshot(originT *originT.T) {
+	t := testing.NewT(originT)
+	dir, err := ioutil.TempDir("", "node_log")
+	t.MustNil(err, "error creating temp dir")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "node.log")
+
+	tailer, err := StartNodeLogTailer(file)
+	t.MustNil(err, "tailer should start before node log is created")
+	appendNodeLog(&t, file, "E[2021-03-10|09:54:23.917] CONSENSUS FAILURE!!! err=\"oops\"\n")
+	excerpts, err := captureNodeLogs(context.Background(), testing.FailureSnapshotRequest{})
+	t.MustNil(err, "error capturing node logs")
+	t.MustTrue(len(excerpts.([]NodeLogExcerpt)) == 1, "excerpt of each running tailer should be captured")
+	t.MustContain(excerpts.([]NodeLogExcerpt)[0].Lines[0], "CONSENSUS FAILURE", "lines not read by ticker yet should be captured")
+
+	tailer.Stop()
+	t.MustTrue(!testing.RemoveFailureSnapshotCapturer(SnapshotNodeLog), "node log capturer should be removed with the last tailer")
+}
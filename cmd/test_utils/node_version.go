@@ -0,0 +1,169 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+// nodeVersionTimeout is the maximum time to get node version, node is treated as unreachable after it
+const nodeVersionTimeout = 5 * time.Second
+
+// GetNodeVersionCtx is a function to get application version of the first node by abci info
+func GetNodeVersionCtx(ctx context.Context) (string, error) {
+	rpcClient, err := rpchttp.New(firstNode(), "/websocket")
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrNodeUnavailable, err.Error())
+	}
+	res, err := rpcClient.ABCIInfo(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrNodeUnavailable, err.Error())
+	}
+	if len(res.Response.Version) == 0 {
+		return "", fmt.Errorf("node %s doesn't report application version", firstNode())
+	}
+	return res.Response.Version, nil
+}
+
+// VersionRange is a struct to describe range of versions, Max is exclusive and empty bound is not checked
+type VersionRange struct {
+	Min string
+	Max string
+}
+
+// Contains is a function to check version is in the range
+func (r VersionRange) Contains(version string) bool {
+	if len(r.Min) > 0 && testing.CompareVersions(version, r.Min) < 0 {
+		return false
+	}
+	if len(r.Max) > 0 && testing.CompareVersions(version, r.Max) >= 0 {
+		return false
+	}
+	return true
+}
+
+// String is a function to describe the range e.g. >= v0.1.0, < v1.0.0
+func (r VersionRange) String() string {
+	bounds := []string{}
+	if len(r.Min) > 0 {
+		bounds = append(bounds, ">= "+r.Min)
+	}
+	if len(r.Max) > 0 {
+		bounds = append(bounds, "< "+r.Max)
+	}
+	if len(bounds) == 0 {
+		return "any"
+	}
+	return strings.Join(bounds, ", ")
+}
+
+// SupportedNodeVersions is the range of node versions whose pylons types this sdk is generated from
+// It should be updated together with x/pylons/types when types of the chain change.
+var SupportedNodeVersions = VersionRange{Min: "v0.1.0", Max: "v1.0.0"}
+
+// NodeVersionCheckMode is a type of how node version out of SupportedNodeVersions is handled
+type NodeVersionCheckMode string
+
+// describes how node version out of supported range is handled
+const (
+	// NodeVersionCheckOff doesn't check node version
+	NodeVersionCheckOff NodeVersionCheckMode = "off"
+	// NodeVersionCheckWarn logs a warning once per node
+	NodeVersionCheckWarn NodeVersionCheckMode = "warn"
+	// NodeVersionCheckFail refuses to send transactions to the node
+	NodeVersionCheckFail NodeVersionCheckMode = "fail"
+)
+
+// ErrNodeVersionUnsupported is the error of node version out of SupportedNodeVersions
+var ErrNodeVersionUnsupported = errors.New("node version is not supported")
+
+// releaseVersionRegexp matches versions comparable with the range, other versions e.g. commit hash of dev builds are unknown
+var releaseVersionRegexp = regexp.MustCompile(`^v?[0-9]+(\.[0-9]+)*([-+].*)?$`)
+
+// CheckNodeVersion is a function to check version of node is in supported range
+// It returns error wrapping ErrNodeVersionUnsupported when it's out of range, and other errors for versions which
+// are not comparable e.g. commit hash of dev builds.
+func CheckNodeVersion(version string, supported VersionRange) error {
+	if !releaseVersionRegexp.MatchString(strings.TrimSpace(version)) {
+		return fmt.Errorf("node version %s is not a release version, it can't be compared with supported versions %s", version, supported)
+	}
+	if !supported.Contains(version) {
+		return fmt.Errorf("%w: node runs %s but this sdk supports %s, pylons types may differ from the chain and "+
+			"decoding its outputs can fail, use sdk version built for the node or set -node-version-check=warn", ErrNodeVersionUnsupported, version, supported)
+	}
+	return nil
+}
+
+// nodeVersionCheckResult is a struct to keep result of node version check of a node
+type nodeVersionCheckResult struct {
+	version string
+	err     error
+}
+
+var (
+	nodeVersionCheckMux     sync.Mutex
+	nodeVersionCheckResults = map[string]nodeVersionCheckResult{}
+)
+
+// GetNodeVersionCheckMode is a function to get node version check mode, it's warn by default
+func GetNodeVersionCheckMode() NodeVersionCheckMode {
+	switch CLIOpts.NodeVersionCheck {
+	case NodeVersionCheckOff, NodeVersionCheckFail:
+		return CLIOpts.NodeVersionCheck
+	}
+	return NodeVersionCheckWarn
+}
+
+// NodeVersionCheck is a function to check version of the first node against SupportedNodeVersions once per node
+// The result is logged on first check, in fail mode error is returned for unsupported version so that callers fail
+// early with a clear message instead of cryptic decoding errors. Unknown version is only warned.
+func NodeVersionCheck(ctx context.Context, t *testing.T) error {
+	mode := GetNodeVersionCheckMode()
+	if mode == NodeVersionCheckOff {
+		return nil
+	}
+	node := firstNode()
+	nodeVersionCheckMux.Lock()
+	defer nodeVersionCheckMux.Unlock()
+	result, checked := nodeVersionCheckResults[node]
+	if !checked {
+		result.version, result.err = GetNodeVersionCtx(ctx)
+		if result.err == nil {
+			result.err = CheckNodeVersion(result.version, SupportedNodeVersions)
+		} else if ctx.Err() != nil {
+			// version is checked again when the check is canceled
+			return nil
+		}
+		nodeVersionCheckResults[node] = result
+		if result.err != nil {
+			t.WithFields(testing.Fields{
+				"node":      node,
+				"version":   result.version,
+				"supported": SupportedNodeVersions.String(),
+				"mode":      mode,
+				"error":     result.err,
+			}).Warn("node version check failed")
+		}
+	}
+	if mode == NodeVersionCheckFail && errors.Is(result.err, ErrNodeVersionUnsupported) {
+		return result.err
+	}
+	return nil
+}
+
+func init() {
+	flag.StringVar((*string)(&CLIOpts.NodeVersionCheck), "node-version-check", string(NodeVersionCheckWarn), "how node version out of range supported by sdk is handled, one of off, warn and fail")
+	testing.SetNodeVersionProvider(func() (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), nodeVersionTimeout)
+		defer cancel()
+		return GetNodeVersionCtx(ctx)
+	})
+}
@@ -0,0 +1,49 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestCheckNodeVersion(originT *originT.T) {
+	t := testing.NewT(originT)
+	supported := VersionRange{Min: "v0.2.0", Max: "v0.4.0"}
+
+	t.MustTrue(supported.String() == ">= v0.2.0, < v0.4.0", "range should be described with its bounds")
+	t.MustNil(CheckNodeVersion("v0.2.0", supported), "min version should be supported")
+	t.MustNil(CheckNodeVersion("0.3.9-rc1", supported), "version without v prefix and with suffix should be supported")
+	err := CheckNodeVersion("v0.4.0", supported)
+	t.MustTrue(errors.Is(err, ErrNodeVersionUnsupported), "max version should not be supported")
+	t.MustContain(err.Error(), "node runs v0.4.0 but this sdk supports >= v0.2.0, < v0.4.0", "error should describe version and supported range")
+	err = CheckNodeVersion("a1b2c3d", supported)
+	t.MustTrue(err != nil && !errors.Is(err, ErrNodeVersionUnsupported), "commit hash version should be unknown instead of unsupported")
+}
+
+func TestNodeVersionCheckMode(originT *originT.T) {
+	t := testing.NewT(originT)
+	mode := CLIOpts.NodeVersionCheck
+	defer func() {
+		CLIOpts.NodeVersionCheck = mode
+		nodeVersionCheckMux.Lock()
+		delete(nodeVersionCheckResults, firstNode())
+		nodeVersionCheckMux.Unlock()
+	}()
+	nodeVersionCheckMux.Lock()
+	nodeVersionCheckResults[firstNode()] = nodeVersionCheckResult{
+		version: "v9.0.0",
+		err:     CheckNodeVersion("v9.0.0", SupportedNodeVersions),
+	}
+	nodeVersionCheckMux.Unlock()
+
+	CLIOpts.NodeVersionCheck = ""
+	t.MustTrue(GetNodeVersionCheckMode() == NodeVersionCheckWarn, "node version should be warned by default")
+	t.MustNil(NodeVersionCheck(context.Background(), &t), "unsupported version should only be warned in warn mode")
+	CLIOpts.NodeVersionCheck = NodeVersionCheckFail
+	err := NodeVersionCheck(context.Background(), &t)
+	t.MustTrue(errors.Is(err, ErrNodeVersionUnsupported), "unsupported version should fail in fail mode")
+	_, err = NewClient().SendTx(context.Background(), &t, SignerAddress("addr"))
+	t.MustTrue(errors.Is(err, ErrNodeVersionUnsupported), "transaction should not be sent to unsupported node in fail mode")
+}
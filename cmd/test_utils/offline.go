@@ -0,0 +1,86 @@
+package inttest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// PrivKeyFromMnemonic is a function to derive secp256k1 private key of the first account from mnemonic
+// as "pylonsd keys add --recover" does
+func PrivKeyFromMnemonic(mnemonic string) (cryptotypes.PrivKey, error) {
+	return PrivKeyFromMnemonicWithHDPath(mnemonic, DefaultHDPath())
+}
+
+// PrivKeyFromMnemonicWithHDPath is a function to derive secp256k1 private key of hdPath from mnemonic
+// as "pylonsd keys add --recover --hd-path" does
+func PrivKeyFromMnemonicWithHDPath(mnemonic, hdPath string) (cryptotypes.PrivKey, error) {
+	derivedPriv, err := hd.Secp256k1.Derive()(mnemonic, "", hdPath)
+	if err != nil {
+		return nil, err
+	}
+	return hd.Secp256k1.Generate()(derivedPriv), nil
+}
+
+// SignTxOffline is a function to build and sign transaction of msgs with key without contacting the node
+// It returns json encoded signed transaction which can be broadcast by BroadcastSignedTx from another host
+func SignTxOffline(msgs []sdk.Msg, accountNumber, sequence uint64, chainID string, key cryptotypes.PrivKey) ([]byte, error) {
+	signMode := signing.SignMode_SIGN_MODE_DIRECT
+	signerData := authsigning.SignerData{
+		ChainID:       chainID,
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
+	}
+	txBldr, err := GenTxBuilderWithMsg(msgs)
+	if err != nil {
+		return nil, err
+	}
+	// signer infos are part of sign bytes, so set them with empty signature before signing
+	sigData := signing.SingleSignatureData{
+		SignMode:  signMode,
+		Signature: nil,
+	}
+	sig := signing.SignatureV2{
+		PubKey:   key.PubKey(),
+		Data:     &sigData,
+		Sequence: sequence,
+	}
+	if err = txBldr.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+	signBytes, err := app.MakeEncodingConfig().TxConfig.SignModeHandler().GetSignBytes(signMode, signerData, txBldr.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	sigData.Signature, err = key.Sign(signBytes)
+	if err != nil {
+		return nil, err
+	}
+	if err = txBldr.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+	return GetTxJSONEncoder()(txBldr.GetTx())
+}
+
+// BroadcastSignedTx is a function to broadcast json encoded signed transaction
+// Nonce file is not updated, so the signing side should manage sequence of the signer
+func BroadcastSignedTx(t *testing.T, signedTx []byte) (string, error) {
+	tmpDir, err := ioutil.TempDir("", "pylons")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+	signedTxFile := filepath.Join(tmpDir, "signed_tx.json")
+	if err = ioutil.WriteFile(signedTxFile, signedTx, 0644); err != nil {
+		return "", err
+	}
+	return broadcastTxFile(TestContext(t), signedTxFile, GetMaxBroadcastRetry(), t)
+}
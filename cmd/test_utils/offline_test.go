@@ -0,0 +1,62 @@
+package inttest
+
+import (
+	originT "testing"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+func TestSignTxOffline(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	privKey := secp256k1.GenPrivKey()
+	sender := sdk.AccAddress(privKey.PubKey().Address()).String()
+	msg := types.NewMsgGetPylons(types.PremiumTier.Fee, sender)
+
+	signedTx, err := SignTxOffline([]sdk.Msg{&msg}, 3, 7, "pylonschain", privKey)
+	t.MustNil(err, "error signing transaction offline")
+
+	decodedTx, err := GetTxJSONDecoder()(signedTx)
+	t.WithFields(testing.Fields{
+		"signed_tx": string(signedTx),
+	}).MustNil(err, "error decoding signed transaction")
+	sigTx, ok := decodedTx.(authsigning.SigVerifiableTx)
+	t.MustTrue(ok, "signed transaction should be verifiable")
+	sigs, err := sigTx.GetSignaturesV2()
+	t.MustNil(err, "error getting signatures")
+	t.MustTrue(len(sigs) == 1 && sigs[0].Sequence == 7, "signed transaction should have one signature with sequence")
+
+	signerData := authsigning.SignerData{
+		ChainID:       "pylonschain",
+		AccountNumber: 3,
+		Sequence:      7,
+	}
+	signBytes, err := app.MakeEncodingConfig().TxConfig.SignModeHandler().GetSignBytes(signing.SignMode_SIGN_MODE_DIRECT, signerData, sigTx)
+	t.MustNil(err, "error getting sign bytes")
+	sigData := sigs[0].Data.(*signing.SingleSignatureData)
+	t.MustTrue(privKey.PubKey().VerifySignature(signBytes, sigData.Signature), "signature should be valid for sign bytes")
+}
+
+func TestPrivKeyFromMnemonic(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	privKey, err := PrivKeyFromMnemonic(mnemonic)
+	t.MustNil(err, "error deriving private key from mnemonic")
+	sameKey, err := PrivKeyFromMnemonic(mnemonic)
+	t.MustNil(err, "error deriving private key from mnemonic")
+	t.MustTrue(privKey.Equals(sameKey), "same mnemonic should derive same key")
+	address := sdk.AccAddress(privKey.PubKey().Address()).String()
+	t.WithFields(testing.Fields{
+		"address": address,
+	}).MustTrue(address == "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4", "key should be derived from default hd path")
+
+	_, err = PrivKeyFromMnemonic("invalid mnemonic")
+	t.MustTrue(err != nil, "invalid mnemonic should not derive key")
+}
@@ -0,0 +1,117 @@
+package inttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+	"gopkg.in/yaml.v2"
+)
+
+// OutputFormat is a type of pylonsd command output format
+type OutputFormat string
+
+// describes output formats of pylonsd commands
+const (
+	OutputFormatJSON OutputFormat = "json"
+	OutputFormatYAML OutputFormat = "yaml"
+	OutputFormatText OutputFormat = "text"
+)
+
+// ErrUnstructuredOutput is an error of command output which is neither json nor yaml
+var ErrUnstructuredOutput = errors.New("command output is not json or yaml")
+
+// outputFlagValue is a function to get value of --output flag set on pylonsd arguments, it's empty when it's not set
+func outputFlagValue(args []string) string {
+	for idx, arg := range args {
+		switch {
+		case arg == "--"+tmcli.OutputFlag || arg == "-o":
+			if idx+1 < len(args) {
+				return args[idx+1]
+			}
+		case strings.HasPrefix(arg, "--"+tmcli.OutputFlag+"="):
+			return strings.TrimPrefix(arg, "--"+tmcli.OutputFlag+"=")
+		}
+	}
+	return ""
+}
+
+// supportsJSONOutput is a function to check if pylonsd command prints its result in format of --output flag
+// "keys show -a" prints bare address, "tx sign" and "tx multisign" print transaction json regardless of the flag and status has no flag.
+func supportsJSONOutput(args []string) bool {
+	if len(args) < 2 {
+		return false
+	}
+	switch args[0] {
+	case "query":
+		return true
+	case "keys":
+		return args[1] != "show" || !(Exists(args, "-a") || hasFlag(args, "address"))
+	case "tx":
+		return args[1] != "sign" && args[1] != "multisign"
+	default:
+		return false
+	}
+}
+
+// DetectOutputFormat is a function to detect format of pylonsd command output
+func DetectOutputFormat(output []byte) OutputFormat {
+	_, format, _ := NormalizeJSONOutput(output)
+	return format
+}
+
+// jsonDocument is a function to get json document of output skipping log lines printed before it e.g. "gas estimate: 100"
+func jsonDocument(output []byte) ([]byte, bool) {
+	lines := bytes.SplitAfter(output, []byte("\n"))
+	for idx, line := range lines {
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+			continue
+		}
+		doc := bytes.TrimSpace(bytes.Join(lines[idx:], nil))
+		if json.Valid(doc) {
+			return doc, true
+		}
+	}
+	return nil, false
+}
+
+// yamlToJSONValue is a function to convert yaml value into value encoding/json can marshal
+func yamlToJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = yamlToJSONValue(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for idx, item := range v {
+			converted[idx] = yamlToJSONValue(item)
+		}
+		return converted
+	default:
+		return v
+	}
+}
+
+// NormalizeJSONOutput is a function to convert pylonsd command output into json
+// Log lines before json are dropped and yaml output of nodes ignoring --output flag is converted.
+func NormalizeJSONOutput(output []byte) ([]byte, OutputFormat, error) {
+	if doc, ok := jsonDocument(output); ok {
+		return doc, OutputFormatJSON, nil
+	}
+	var doc interface{}
+	if err := yaml.Unmarshal(output, &doc); err == nil {
+		switch doc.(type) {
+		case map[interface{}]interface{}, []interface{}:
+			converted, err := json.Marshal(yamlToJSONValue(doc))
+			return converted, OutputFormatYAML, err
+		}
+	}
+	return output, OutputFormatText, ErrUnstructuredOutput
+}
@@ -0,0 +1,37 @@
+package inttest
+
+import (
+	"encoding/json"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestNormalizeJSONOutput(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	output, format, err := NormalizeJSONOutput([]byte("gas estimate: 120000\n{\"txhash\":\"ABC\",\"code\":0}\n"))
+	t.MustNil(err, "json output should be normalized")
+	t.MustTrue(format == OutputFormatJSON && string(output) == `{"txhash":"ABC","code":0}`, "log lines before json should be dropped")
+
+	output, format, err = NormalizeJSONOutput([]byte("height: \"12\"\ntxhash: ABC\nlogs:\n- msg_index: 0\n  log: \"\"\n"))
+	t.MustNil(err, "yaml output should be converted")
+	t.MustTrue(format == OutputFormatYAML, "yaml output should be detected")
+	res := struct {
+		Height string `json:"height"`
+		TxHash string `json:"txhash"`
+		Logs   []struct {
+			MsgIndex int `json:"msg_index"`
+		} `json:"logs"`
+	}{}
+	t.MustNil(json.Unmarshal(output, &res), "converted output should be json")
+	t.MustTrue(res.Height == "12" && res.TxHash == "ABC" && len(res.Logs) == 1, "converted json should keep values")
+
+	_, format, err = NormalizeJSONOutput([]byte("cosmos1abcdef\n"))
+	t.MustTrue(err != nil && format == OutputFormatText, "bare text should not be converted")
+
+	t.MustTrue(supportsJSONOutput([]string{"keys", "show", "eugen"}), "keys show should print json")
+	t.MustTrue(!supportsJSONOutput([]string{"keys", "show", "eugen", "-a"}), "keys show -a prints bare address")
+	t.MustTrue(!supportsJSONOutput([]string{"tx", "sign", "raw_tx.json"}), "tx sign prints transaction document")
+	t.MustTrue(outputFlagValue([]string{"query", "tx", "ABC", "--output", "text"}) == "text", "separate output flag value should be read")
+}
@@ -0,0 +1,204 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/config"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	paramproposal "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+)
+
+// PylonsParamsSubspace is the params subspace of pylons module
+const PylonsParamsSubspace = types.ModuleName
+
+// status of governance proposals
+const (
+	ProposalStatusPassed   = "PROPOSAL_STATUS_PASSED"
+	ProposalStatusRejected = "PROPOSAL_STATUS_REJECTED"
+	ProposalStatusFailed   = "PROPOSAL_STATUS_FAILED"
+)
+
+// PylonsParams is a struct to manage pylons module params
+type PylonsParams struct {
+	Fee config.FeeConfiguration
+	// LocalKeys are param keys not found on chain, their values come from local fee configuration
+	LocalKeys []string
+}
+
+// ParamChangeProposal is a struct to describe a governance proposal changing params on a local testnet
+type ParamChangeProposal struct {
+	Title       string
+	Description string
+	Changes     []paramproposal.ParamChange
+	Deposit     sdk.Coins
+	// Proposer is the address submitting the proposal with deposit
+	Proposer string
+	// Voters are the validator addresses voting yes, they should have enough voting power to pass the proposal
+	Voters []string
+}
+
+// proposalInfo is a struct to parse status of proposal from cli output
+type proposalInfo struct {
+	ProposalID string `json:"proposal_id"`
+	Status     string `json:"status"`
+}
+
+// pylonsFeeParamFields is a function to get fee configuration field index per param key
+// Param keys are the same as keys of fee configuration in pylons.yml.
+func pylonsFeeParamFields() map[string]int {
+	fields := make(map[string]int)
+	feeType := reflect.TypeOf(config.FeeConfiguration{})
+	for idx := 0; idx < feeType.NumField(); idx++ {
+		fields[feeType.Field(idx).Tag.Get("yaml")] = idx
+	}
+	return fields
+}
+
+// PylonsParamKeys is a function to get sorted param keys of pylons module
+func PylonsParamKeys() []string {
+	keys := []string{}
+	for key := range pylonsFeeParamFields() {
+		keys = append(keys, key)
+	}
+	return sortedLabels(keys)
+}
+
+// FeeParamChange is a function to create param change setting a pylons fee param
+func FeeParamChange(key string, value int64) paramproposal.ParamChange {
+	// int64 params are encoded as json string by amino
+	return paramproposal.NewParamChange(PylonsParamsSubspace, key, strconv.Quote(strconv.FormatInt(value, 10)))
+}
+
+// parseInt64ParamValue is a function to parse int64 param value encoded as json string or json number
+func parseInt64ParamValue(value string) (int64, error) {
+	var str string
+	if err := json.Unmarshal([]byte(value), &str); err == nil {
+		return strconv.ParseInt(str, 10, 64)
+	}
+	var num int64
+	err := json.Unmarshal([]byte(value), &num)
+	return num, err
+}
+
+// GetPylonsParam is a function to query a param of pylons subspace, value is empty when param is not on chain
+func GetPylonsParam(key string) (string, error) {
+	output, logstr, err := Query().Sub("params", "subspace", PylonsParamsSubspace, key).Run(context.Background())
+	if err != nil {
+		// nodes reading fees from pylons.yml don't register pylons subspace
+		if strings.Contains(logstr, paramproposal.ErrUnknownSubspace.Error()) {
+			return "", nil
+		}
+		return "", fmt.Errorf("%s: %w", logstr, err)
+	}
+	param := paramproposal.ParamChange{}
+	if err = json.Unmarshal(output, &param); err != nil {
+		return "", fmt.Errorf("error parsing param %s: %s; %s", key, err.Error(), string(output))
+	}
+	return param.Value, nil
+}
+
+// GetPylonsParams is a function to get pylons module params (fees and cookbook tier fees)
+// Nodes reading fees from pylons.yml don't have all params on chain, local fee configuration is used for those keys.
+func GetPylonsParams() (PylonsParams, error) {
+	params := PylonsParams{Fee: config.Config.Fee}
+	feeValue := reflect.ValueOf(&params.Fee).Elem()
+	fields := pylonsFeeParamFields()
+	for _, key := range PylonsParamKeys() {
+		value, err := GetPylonsParam(key)
+		if err != nil {
+			return params, err
+		}
+		if len(value) == 0 {
+			params.LocalKeys = append(params.LocalKeys, key)
+			continue
+		}
+		amount, err := parseInt64ParamValue(value)
+		if err != nil {
+			return params, fmt.Errorf("error parsing param %s value %s: %w", key, value, err)
+		}
+		feeValue.Field(fields[key]).SetInt(amount)
+	}
+	return params, nil
+}
+
+// GetProposalStatus is a function to query status of governance proposal
+func GetProposalStatus(proposalID uint64) (string, error) {
+	output, logstr, err := Query().Sub("gov", "proposal", strconv.FormatUint(proposalID, 10)).Run(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", logstr, err)
+	}
+	info := proposalInfo{}
+	if err = json.Unmarshal(output, &info); err != nil {
+		return "", fmt.Errorf("error parsing proposal: %s; %s", err.Error(), string(output))
+	}
+	return info.Status, nil
+}
+
+// GetSubmittedProposalID is a function to get id of proposal submitted by transaction
+func (r TxResult) GetSubmittedProposalID() (uint64, error) {
+	ids := r.GetEventAttributes(govtypes.EventTypeSubmitProposal, govtypes.AttributeKeyProposalID)
+	if len(ids) == 0 {
+		return 0, errors.New("proposal id is not found in transaction events")
+	}
+	return strconv.ParseUint(ids[0], 10, 64)
+}
+
+// Run is a function to submit param change proposal, vote yes by voters and wait until the proposal is passed
+// The voting period of local testnet should be short as the proposal is passed only after it ends.
+// Local fee configuration is updated by changed params, so fee helpers compute payouts of new params.
+func (p ParamChangeProposal) Run(ctx context.Context, t *testing.T, client *Client) (PylonsParams, error) {
+	proposer, err := sdk.AccAddressFromBech32(p.Proposer)
+	if err != nil {
+		return PylonsParams{}, err
+	}
+	content := paramproposal.NewParameterChangeProposal(p.Title, p.Description, p.Changes)
+	submitMsg, err := govtypes.NewMsgSubmitProposal(content, p.Deposit, proposer)
+	if err != nil {
+		return PylonsParams{}, err
+	}
+	txResult, err := client.SendTxAndWait(ctx, t, SignerAddress(p.Proposer), submitMsg)
+	if err != nil {
+		return PylonsParams{}, fmt.Errorf("error submitting param change proposal: %w", err)
+	}
+	proposalID, err := txResult.GetSubmittedProposalID()
+	if err != nil {
+		return PylonsParams{}, err
+	}
+	for _, voter := range p.Voters {
+		voterAddr, err := sdk.AccAddressFromBech32(voter)
+		if err != nil {
+			return PylonsParams{}, err
+		}
+		voteMsg := govtypes.NewMsgVote(voterAddr, proposalID, govtypes.OptionYes)
+		if _, err = client.SendTxAndWait(ctx, t, SignerAddress(voter), voteMsg); err != nil {
+			return PylonsParams{}, fmt.Errorf("error voting proposal %d by %s: %w", proposalID, voter, err)
+		}
+	}
+	if err = WaitForProposalCtx(ctx, t, proposalID); err != nil {
+		return PylonsParams{}, err
+	}
+	params, err := GetPylonsParams()
+	if err != nil {
+		return params, err
+	}
+	config.Config.Fee = params.Fee
+	return params, nil
+}
+
+// WaitForProposalCtx is a function to wait for proposal to finish voting period, it fails when proposal is not passed
+func WaitForProposalCtx(ctx context.Context, t *testing.T, proposalID uint64) error {
+	waited, err := WaitFor(ctx, UntilProposalPassed(proposalID), WaitOptions{Name: fmt.Sprintf("proposal %d", proposalID)})
+	t.WithFields(waited.Fields()).WithFields(testing.Fields{
+		"proposal_id": proposalID,
+	}).Debug("waited for proposal voting period to end")
+	return err
+}
@@ -0,0 +1,40 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestFeeParamChange(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	keys := PylonsParamKeys()
+	t.WithFields(testing.Fields{
+		"keys": keys,
+	}).MustTrue(len(keys) == 9 && Exists(keys, "recipe_fee_percentage"), "param keys should be keys of fee configuration")
+
+	change := FeeParamChange("recipe_fee_percentage", 20)
+	t.WithFields(testing.Fields{
+		"value": change.Value,
+	}).MustTrue(change.Subspace == PylonsParamsSubspace && change.Value == `"20"`, "int64 param should be encoded as json string")
+	value, err := parseInt64ParamValue(change.Value)
+	t.MustNil(err, "error parsing json string param value")
+	t.MustTrue(value == 20, "param value should be parsed")
+	value, err = parseInt64ParamValue("35")
+	t.MustNil(err, "error parsing json number param value")
+	t.MustTrue(value == 35, "param value should be parsed")
+}
+
+func TestGetSubmittedProposalID(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	txResult, err := ParseTxResult([]byte(`{"txhash":"ABCD","code":0,"logs":[{"msg_index":0,"events":[{"type":"submit_proposal","attributes":[{"key":"proposal_id","value":"7"},{"key":"proposal_type","value":"ParameterChange"}]}]}]}`))
+	t.MustNil(err, "error parsing tx result")
+	proposalID, err := txResult.GetSubmittedProposalID()
+	t.MustNil(err, "error getting proposal id")
+	t.MustTrue(proposalID == 7, "proposal id should be parsed from submit_proposal event")
+
+	_, err = TxResult{}.GetSubmittedProposalID()
+	t.MustTrue(err != nil, "tx result without submit_proposal event should fail")
+}
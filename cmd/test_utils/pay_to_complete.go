@@ -0,0 +1,147 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PayToCompleteResult is a struct to manage result of completing delayed execution early by paying pylons
+type PayToCompleteResult struct {
+	ExecID string
+	// Execution is the execution before it's completed
+	Execution       ExecutionResult
+	CostPerBlock    int64
+	CompletedHeight int64
+	RemainingBlocks int64
+	Charged         sdk.Coins
+	PaidCoins       sdk.Coins
+	PaidItemIDs     []string
+}
+
+// PayToCompleteCost is a function to get pylons charged to complete execution at block height,
+// which is cost per block of the cookbook for each block left until the execution is ready
+func PayToCompleteCost(exec ExecutionResult, costPerBlock int64, height int64) sdk.Coins {
+	remaining := exec.ReadyHeight - height
+	if remaining <= 0 || costPerBlock <= 0 {
+		return sdk.Coins{}
+	}
+	return sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, remaining*costPerBlock))
+}
+
+// EstimatePayToCompleteCost is a function to get pylons required to complete execution by a transaction included in next block
+func EstimatePayToCompleteCost(execID string) (sdk.Coins, error) {
+	exec, err := DecodeExecution(execID)
+	if err != nil {
+		return nil, err
+	}
+	cb, err := GetCookbookByGUID(exec.CookbookID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting cookbook %s: %w", exec.CookbookID, err)
+	}
+	if _, _, err = queryDaemonStatus(context.Background()); err != nil {
+		return nil, err
+	}
+	return PayToCompleteCost(exec, cb.CostPerBlock, DefaultEnv().state().blocks.latestHeight()+1), nil
+}
+
+// getBalances is a function to get balances of address without failing the test
+func getBalances(addr string) (sdk.Coins, error) {
+	transport, err := GetTransport()
+	if err != nil {
+		return nil, err
+	}
+	return transport.Balances(context.Background(), addr)
+}
+
+// PayToCompleteAndVerify is a function to complete pending execution before its block interval elapses by check execution with PayToComplete,
+// and verify the sender is charged cost per block for remaining blocks and outputs are paid before the execution gets ready
+func PayToCompleteAndVerify(t *testing.T, execID string, sender string) (PayToCompleteResult, error) {
+	result := PayToCompleteResult{ExecID: execID}
+	var err error
+	result.Execution, err = DecodeExecution(execID)
+	if err != nil {
+		return result, err
+	}
+	if result.Execution.Status != ExecutionPending {
+		return result, fmt.Errorf("execution %s should be pending to pay to complete but it's %s", execID, result.Execution.Status)
+	}
+	cb, err := GetCookbookByGUID(result.Execution.CookbookID)
+	if err != nil {
+		return result, fmt.Errorf("error getting cookbook %s: %w", result.Execution.CookbookID, err)
+	}
+	result.CostPerBlock = cb.CostPerBlock
+	balanceBefore, err := getBalances(sender)
+	if err != nil {
+		return result, err
+	}
+
+	chkExecMsg := types.NewMsgCheckExecution(execID, true, sender)
+	txResult, err := NewClient().SendTxAndWait(context.Background(), t, SignerAddress(sender), &chkExecMsg)
+	if err != nil {
+		return result, fmt.Errorf("error paying to complete execution: %w", err)
+	}
+	chkResp := types.MsgCheckExecutionResponse{}
+	if err = txResult.GetMsgResponse(0, chkExecMsg.Type(), &chkResp); err != nil {
+		return result, err
+	}
+	if chkResp.Status != "Success" {
+		return result, fmt.Errorf("pay to complete status is %s: %s", chkResp.Status, chkResp.Message)
+	}
+	result.PaidCoins, result.PaidItemIDs, err = DecodeExecutionOutput(chkResp.Output)
+	if err != nil {
+		return result, err
+	}
+
+	// outputs should arrive before the execution gets ready, otherwise nothing is paid for
+	result.CompletedHeight = txResult.Height
+	result.RemainingBlocks = result.Execution.ReadyHeight - result.CompletedHeight
+	if result.RemainingBlocks <= 0 {
+		return result, fmt.Errorf("execution %s is completed at height %d after it got ready at %d", execID, result.CompletedHeight, result.Execution.ReadyHeight)
+	}
+	result.Charged = PayToCompleteCost(result.Execution, result.CostPerBlock, result.CompletedHeight)
+
+	completed, err := DecodeExecution(execID)
+	if err != nil {
+		return result, err
+	}
+	if completed.Status != ExecutionCompleted {
+		return result, fmt.Errorf("execution %s should be completed after paying to complete", execID)
+	}
+	balanceAfter, err := getBalances(sender)
+	if err != nil {
+		return result, err
+	}
+	// charge goes to cookbook owner, so it's not paid when sender owns the cookbook
+	charged := result.Charged
+	if cb.Sender == sender {
+		charged = sdk.Coins{}
+	}
+	expected := balanceBefore.Add(result.PaidCoins...)
+	actual := balanceAfter.Add(charged...)
+	if !(expected.IsAllGTE(actual) && actual.IsAllGTE(expected)) {
+		return result, fmt.Errorf("balance of %s is %s after paying to complete, expected %s charged and %s paid from %s",
+			sender, balanceAfter, charged, result.PaidCoins, balanceBefore)
+	}
+	for _, itemID := range result.PaidItemIDs {
+		item, err := GetItemByGUID(itemID)
+		if err != nil {
+			return result, err
+		}
+		if item.Sender != sender {
+			return result, fmt.Errorf("paid item %s is owned by %s, expected %s", itemID, item.Sender, sender)
+		}
+	}
+	t.WithFields(testing.Fields{
+		"exec_id":          execID,
+		"completed_height": result.CompletedHeight,
+		"ready_height":     result.Execution.ReadyHeight,
+		"charged":          result.Charged.String(),
+		"paid_coins":       result.PaidCoins.String(),
+		"paid_item_ids":    result.PaidItemIDs,
+	}).Info("verified pay to complete execution")
+	return result, nil
+}
@@ -0,0 +1,20 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+func TestPayToCompleteCost(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	exec := ExecutionResult{BlockHeight: 10, ReadyHeight: 15}
+	cost := PayToCompleteCost(exec, 50, 12)
+	t.WithFields(testing.Fields{
+		"cost": cost.String(),
+	}).MustTrue(cost.AmountOf(types.Pylon).Int64() == 150, "cost per block should be charged for each remaining block")
+	t.MustTrue(PayToCompleteCost(exec, 50, 15).Empty(), "ready execution should not be charged")
+	t.MustTrue(PayToCompleteCost(exec, 0, 12).Empty(), "free cookbook should not charge")
+}
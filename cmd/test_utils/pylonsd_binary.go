@@ -0,0 +1,391 @@
+package inttest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/build"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// pylonsdPathEnv is the environment variable of pylonsd binary path, it's used when -pylonsd-path is not set
+const pylonsdPathEnv = "PYLONSD_PATH"
+
+// pylonsdBinaryName is a function to get file name of pylonsd binary on goos, pylonsd.exe on windows
+func pylonsdBinaryName(goos string) string {
+	if goos == "windows" {
+		return "pylonsd.exe"
+	}
+	return "pylonsd"
+}
+
+// sources of resolved pylonsd binary
+const (
+	PylonsdSourceFlag     = "flag"
+	PylonsdSourceEnv      = "env"
+	PylonsdSourceGOPATH   = "gopath"
+	PylonsdSourcePATH     = "path"
+	PylonsdSourceDownload = "download"
+)
+
+// ErrPylonsdNotFound is the error of pylonsd binary not found by any of resolution steps
+var ErrPylonsdNotFound = errors.New("pylonsd binary is not found")
+
+// ErrPylonsdChecksumMismatch is the error of pylonsd binary whose sha256 differs from the pinned checksum
+var ErrPylonsdChecksumMismatch = errors.New("pylonsd checksum mismatch")
+
+// ErrPylonsdVersionMismatch is the error of local pylonsd binary whose version differs from the pinned version
+var ErrPylonsdVersionMismatch = errors.New("pylonsd version mismatch")
+
+// PylonsdRelease is a struct to describe published pylonsd binaries of a version
+type PylonsdRelease struct {
+	Version string `json:"version"`
+	// URL is download url of the binary itself, {version}, {os} and {arch} are replaced e.g. linux and amd64
+	URL string `json:"url"`
+	// Checksums are sha256 hex of binaries by platform e.g. "linux/amd64"
+	Checksums map[string]string `json:"checksums"`
+}
+
+// PylonsdReleases is a variable to have pinned pylonsd releases by version
+// Releases are added with checksums of published binaries, -pylonsd-releases file adds releases not built in.
+var PylonsdReleases = map[string]PylonsdRelease{}
+
+var pylonsdReleasesFile = ""
+
+func init() {
+	flag.StringVar(&CLIOpts.PylonsdVersion, "pylonsd-version", "", "pylonsd version pinned for the run, pylonsd_version of chain profile is used when it's empty")
+	flag.BoolVar(&CLIOpts.PylonsdDownload, "pylonsd-download", false, "download pinned pylonsd release when local binary is missing or has different version")
+	flag.StringVar(&CLIOpts.PylonsdSHA256, "pylonsd-sha256", "", "sha256 hex pylonsd binary should have, checksum of pinned release is used for downloads when it's empty")
+	flag.StringVar(&CLIOpts.PylonsdCacheDir, "pylonsd-cache-dir", "", "directory downloaded pylonsd releases are kept in, default pylons_sdk/pylonsd of user cache directory")
+	flag.StringVar(&pylonsdReleasesFile, "pylonsd-releases", "", "json file of pinned pylonsd releases by version added to built-in releases")
+}
+
+// LoadPylonsdReleases is a function to read json file of pylonsd releases by version and add them to PylonsdReleases
+func LoadPylonsdReleases(file string) error {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	releases := map[string]PylonsdRelease{}
+	if err = json.Unmarshal(bz, &releases); err != nil {
+		return fmt.Errorf("error parsing pylonsd releases %s: %w", file, err)
+	}
+	for version, release := range releases {
+		release.Version = version
+		PylonsdReleases[version] = release
+	}
+	return nil
+}
+
+// DownloadURL is a function to get download url of release binary for platform os and arch
+func (r PylonsdRelease) DownloadURL(goos, goarch string) string {
+	return strings.NewReplacer("{version}", r.Version, "{os}", goos, "{arch}", goarch).Replace(r.URL)
+}
+
+// PylonsdBinary is a struct to describe resolved pylonsd binary
+type PylonsdBinary struct {
+	Path    string
+	Source  string
+	Version string // empty when version is not pinned, as it's not checked
+}
+
+// pylonsdConfig is a struct to describe options of pylonsd resolution, binary is resolved again when it's changed
+type pylonsdConfig struct {
+	path     string
+	version  string
+	download bool
+	sha256   string
+	cacheDir string
+}
+
+// pylonsdResolver is a struct to resolve pylonsd binary with replaceable lookups of environment and network
+type pylonsdResolver struct {
+	getenv   func(key string) string
+	lookPath func(file string) (string, error)
+	version  func(ctx context.Context, binary string) (string, error)
+	client   *http.Client
+	goos     string
+	goarch   string
+}
+
+var defaultPylonsdResolver = pylonsdResolver{
+	getenv:   os.Getenv,
+	lookPath: exec.LookPath,
+	version:  pylonsdVersion,
+	client:   http.DefaultClient,
+	goos:     runtime.GOOS,
+	goarch:   runtime.GOARCH,
+}
+
+var (
+	pylonsdMux      sync.Mutex
+	resolvedConfig  *pylonsdConfig
+	resolvedPylonsd PylonsdBinary
+	resolveErr      error
+)
+
+// currentPylonsdConfig is a function to get pylonsd options of CLIOpts, pinned version of chain profile is used without -pylonsd-version
+func currentPylonsdConfig() pylonsdConfig {
+	config := pylonsdConfig{
+		path:     CLIOpts.PylonsdPath,
+		version:  CLIOpts.PylonsdVersion,
+		download: CLIOpts.PylonsdDownload,
+		sha256:   strings.ToLower(CLIOpts.PylonsdSHA256),
+		cacheDir: CLIOpts.PylonsdCacheDir,
+	}
+	if profile, ok := SelectedChainProfile(); ok && len(config.version) == 0 {
+		config.version = profile.PylonsdVersion
+	}
+	return config
+}
+
+// ResolvePylonsd is a function to find pylonsd binary commands run with, it's resolved once per options
+// Binary is resolved in order of -pylonsd-path, $PYLONSD_PATH, $GOBIN, $GOPATH/bin and PATH. When a version is pinned by
+// -pylonsd-version or chain profile, binaries found in $GOBIN, $GOPATH/bin and PATH should report it by "pylonsd version",
+// otherwise the pinned release is downloaded with -pylonsd-download or resolution fails, so a stale local build is not
+// tested silently. Paths set explicitly are trusted to be the intended version.
+func ResolvePylonsd(ctx context.Context) (PylonsdBinary, error) {
+	config := currentPylonsdConfig()
+	pylonsdMux.Lock()
+	defer pylonsdMux.Unlock()
+	if resolvedConfig != nil && *resolvedConfig == config {
+		return resolvedPylonsd, resolveErr
+	}
+	if len(pylonsdReleasesFile) > 0 {
+		if err := LoadPylonsdReleases(pylonsdReleasesFile); err != nil {
+			return PylonsdBinary{}, err
+		}
+	}
+	resolvedPylonsd, resolveErr = defaultPylonsdResolver.resolve(ctx, config)
+	if ctx.Err() != nil {
+		// canceled resolution is tried again by the next command
+		return resolvedPylonsd, resolveErr
+	}
+	resolvedConfig = &config
+	if resolveErr == nil {
+		log.WithFields(log.Fields{
+			"path":    resolvedPylonsd.Path,
+			"source":  resolvedPylonsd.Source,
+			"version": resolvedPylonsd.Version,
+		}).Info("resolved pylonsd binary")
+	}
+	return resolvedPylonsd, resolveErr
+}
+
+func (r pylonsdResolver) resolve(ctx context.Context, config pylonsdConfig) (PylonsdBinary, error) {
+	if len(config.path) > 0 {
+		return r.verified(PylonsdBinary{Path: config.path, Source: PylonsdSourceFlag}, config)
+	}
+	if envPath := r.getenv(pylonsdPathEnv); len(envPath) > 0 {
+		return r.verified(PylonsdBinary{Path: envPath, Source: PylonsdSourceEnv}, config)
+	}
+
+	var found *PylonsdBinary
+	binaryName := pylonsdBinaryName(r.goos)
+	for _, dir := range r.goBinDirs() {
+		if binary := filepath.Join(dir, binaryName); fileExists(binary) {
+			found = &PylonsdBinary{Path: binary, Source: PylonsdSourceGOPATH}
+			break
+		}
+	}
+	if found == nil {
+		if binary, err := r.lookPath(binaryName); err == nil {
+			found = &PylonsdBinary{Path: binary, Source: PylonsdSourcePATH}
+		}
+	}
+
+	if len(config.version) == 0 {
+		if found == nil {
+			return PylonsdBinary{}, fmt.Errorf("%w in $GOBIN, $GOPATH/bin and PATH, set -pylonsd-path or $%s", ErrPylonsdNotFound, pylonsdPathEnv)
+		}
+		return r.verified(*found, config)
+	}
+	mismatch := ""
+	if found != nil {
+		version, err := r.version(ctx, found.Path)
+		if err != nil {
+			return PylonsdBinary{}, fmt.Errorf("error getting version of pylonsd at %s: %w", found.Path, err)
+		}
+		if sameVersion(version, config.version) {
+			found.Version = version
+			return r.verified(*found, config)
+		}
+		mismatch = fmt.Sprintf("pylonsd at %s is %s but %s is pinned", found.Path, version, config.version)
+	}
+	if !config.download {
+		if len(mismatch) > 0 {
+			return PylonsdBinary{}, fmt.Errorf("%w: %s, rebuild it or set -pylonsd-download", ErrPylonsdVersionMismatch, mismatch)
+		}
+		return PylonsdBinary{}, fmt.Errorf("%w in $GOBIN, $GOPATH/bin and PATH, set -pylonsd-path or -pylonsd-download to get pinned %s",
+			ErrPylonsdNotFound, config.version)
+	}
+	return r.download(ctx, config)
+}
+
+// goBinDirs is a function to get directories "go install" puts binaries in, $GOBIN or bin of each $GOPATH entry
+// Default GOPATH of go is used when it's not set, e.g. module mode builds without GOPATH configured.
+func (r pylonsdResolver) goBinDirs() []string {
+	if gobin := r.getenv("GOBIN"); len(gobin) > 0 {
+		return []string{gobin}
+	}
+	gopath := r.getenv("GOPATH")
+	if len(gopath) == 0 {
+		gopath = build.Default.GOPATH
+	}
+	dirs := []string{}
+	for _, dir := range filepath.SplitList(gopath) {
+		if len(dir) > 0 {
+			dirs = append(dirs, filepath.Join(dir, "bin"))
+		}
+	}
+	return dirs
+}
+
+// verified is a function to check sha256 of binary when -pylonsd-sha256 is set, binary name without directory is looked up in PATH
+func (r pylonsdResolver) verified(binary PylonsdBinary, config pylonsdConfig) (PylonsdBinary, error) {
+	if filepath.Base(binary.Path) == binary.Path {
+		if lookedUp, err := r.lookPath(binary.Path); err == nil {
+			binary.Path = lookedUp
+		}
+	}
+	if r.goos == "windows" && filepath.Ext(binary.Path) == "" && !fileExists(binary.Path) && fileExists(binary.Path+".exe") {
+		// paths are often written without .exe on windows, exec doesn't add it to paths having directory
+		binary.Path += ".exe"
+	}
+	if !fileExists(binary.Path) {
+		return PylonsdBinary{}, fmt.Errorf("%w at %s of %s", ErrPylonsdNotFound, binary.Path, binary.Source)
+	}
+	if len(config.sha256) == 0 {
+		return binary, nil
+	}
+	if err := verifyFileSHA256(binary.Path, config.sha256); err != nil {
+		return PylonsdBinary{}, err
+	}
+	return binary, nil
+}
+
+// download is a function to get pinned release of config into cache directory, binary cached by earlier runs is reused
+// Download is refused when neither the release nor -pylonsd-sha256 has a checksum for the platform.
+func (r pylonsdResolver) download(ctx context.Context, config pylonsdConfig) (PylonsdBinary, error) {
+	release, ok := PylonsdReleases[config.version]
+	if !ok {
+		return PylonsdBinary{}, fmt.Errorf("pylonsd %s is not a pinned release, add it by -pylonsd-releases", config.version)
+	}
+	if len(release.Version) == 0 {
+		release.Version = config.version
+	}
+	platform := r.goos + "/" + r.goarch
+	checksum := config.sha256
+	if len(checksum) == 0 {
+		checksum = strings.ToLower(release.Checksums[platform])
+	}
+	if len(checksum) == 0 {
+		return PylonsdBinary{}, fmt.Errorf("pylonsd %s has no checksum for %s, binaries are not downloaded without checksum", config.version, platform)
+	}
+	cacheDir := config.cacheDir
+	if len(cacheDir) == 0 {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return PylonsdBinary{}, fmt.Errorf("error getting user cache directory, set -pylonsd-cache-dir: %w", err)
+		}
+		cacheDir = filepath.Join(userCacheDir, "pylons_sdk", "pylonsd")
+	}
+	binary := PylonsdBinary{
+		Path:    filepath.Join(cacheDir, release.Version, r.goos+"_"+r.goarch, pylonsdBinaryName(r.goos)),
+		Source:  PylonsdSourceDownload,
+		Version: release.Version,
+	}
+	if fileExists(binary.Path) && verifyFileSHA256(binary.Path, checksum) == nil {
+		return binary, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(binary.Path), 0755); err != nil {
+		return PylonsdBinary{}, err
+	}
+	url := release.DownloadURL(r.goos, r.goarch)
+	if err := r.downloadFile(ctx, url, binary.Path, checksum); err != nil {
+		return PylonsdBinary{}, fmt.Errorf("error downloading pylonsd %s from %s: %w", release.Version, url, err)
+	}
+	return binary, nil
+}
+
+// downloadFile is a function to download url into dst when its sha256 is checksum, dst is not touched on mismatch
+func (r pylonsdResolver) downloadFile(ctx context.Context, url, dst, checksum string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	tmpFile, err := ioutil.TempFile(filepath.Dir(dst), "pylonsd-download-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmpFile, hash), resp.Body)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != checksum {
+		return fmt.Errorf("%w: downloaded binary has sha256 %s, expected %s", ErrPylonsdChecksumMismatch, sum, checksum)
+	}
+	if err = os.Chmod(tmpFile.Name(), 0755); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), dst)
+}
+
+// verifyFileSHA256 is a function to check sha256 hex of file is checksum
+func verifyFileSHA256(file, checksum string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, f); err != nil {
+		return err
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != strings.ToLower(checksum) {
+		return fmt.Errorf("%w: %s has sha256 %s, expected %s", ErrPylonsdChecksumMismatch, file, sum, checksum)
+	}
+	return nil
+}
+
+// sameVersion is a function to check versions are the same, with or without "v" prefix
+// Pre-release and build suffixes are compared as well, as a pinned version is an exact build.
+func sameVersion(a, b string) bool {
+	return strings.TrimPrefix(strings.TrimSpace(a), "v") == strings.TrimPrefix(strings.TrimSpace(b), "v")
+}
+
+// pylonsdVersion is a function to get version reported by "pylonsd version" of binary
+func pylonsdVersion(ctx context.Context, binary string) (string, error) {
+	output, err := exec.CommandContext(ctx, binary, "version").CombinedOutput()
+	if err != nil {
+		return "", NewCommandError(err, string(output))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
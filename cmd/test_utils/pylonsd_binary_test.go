@@ -0,0 +1,167 @@
+package inttest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func fakePylonsdResolver(env map[string]string, version string) pylonsdResolver {
+	return pylonsdResolver{
+		getenv: func(key string) string { return env[key] },
+		lookPath: func(file string) (string, error) {
+			return "", errors.New("executable file not found in $PATH")
+		},
+		version: func(ctx context.Context, binary string) (string, error) { return version, nil },
+		client:  http.DefaultClient,
+		goos:    "linux",
+		goarch:  "amd64",
+	}
+}
+
+func writeFakePylonsd(t *testing.T, path string, content string) string {
+	t.MustNil(os.MkdirAll(filepath.Dir(path), 0755), "error creating directory of fake pylonsd")
+	t.MustNil(ioutil.WriteFile(path, []byte(content), 0755), "error writing fake pylonsd")
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestResolvePylonsdLocal(originT *originT.T) {
+	t := testing.NewT(originT)
+	dir, err := ioutil.TempDir("", "pylonsd_resolve")
+	t.MustNil(err, "error creating temp dir")
+	defer os.RemoveAll(dir)
+
+	gopathBinary := filepath.Join(dir, "go", "bin", "pylonsd")
+	checksum := writeFakePylonsd(&t, gopathBinary, "gopath build")
+	envBinary := filepath.Join(dir, "env", "pylonsd")
+	writeFakePylonsd(&t, envBinary, "env build")
+
+	resolver := fakePylonsdResolver(map[string]string{"GOPATH": filepath.Join(dir, "go")}, "v0.2.0")
+	binary, err := resolver.resolve(context.Background(), pylonsdConfig{})
+	t.MustNil(err, "binary of GOPATH should be resolved")
+	t.MustTrue(binary.Path == gopathBinary && binary.Source == PylonsdSourceGOPATH, "binary should be resolved from GOPATH")
+
+	binary, err = resolver.resolve(context.Background(), pylonsdConfig{version: "0.2.0", sha256: checksum})
+	t.MustNil(err, "binary of pinned version and checksum should be resolved")
+	t.MustTrue(binary.Version == "v0.2.0", "version of binary should be set when it's pinned")
+
+	_, err = resolver.resolve(context.Background(), pylonsdConfig{version: "v0.3.0"})
+	t.MustTrue(errors.Is(err, ErrPylonsdVersionMismatch), "stale local build should not be used when version is pinned")
+	t.MustContain(err.Error(), "-pylonsd-download", "error should tell how to get pinned version")
+
+	_, err = resolver.resolve(context.Background(), pylonsdConfig{sha256: "00"})
+	t.MustTrue(errors.Is(err, ErrPylonsdChecksumMismatch), "binary of different checksum should not be used")
+
+	resolver = fakePylonsdResolver(map[string]string{"GOPATH": filepath.Join(dir, "go"), pylonsdPathEnv: envBinary}, "v0.2.0")
+	binary, err = resolver.resolve(context.Background(), pylonsdConfig{})
+	t.MustNil(err, "binary of environment variable should be resolved")
+	t.MustTrue(binary.Path == envBinary && binary.Source == PylonsdSourceEnv, "environment variable should take precedence over GOPATH")
+
+	binary, err = resolver.resolve(context.Background(), pylonsdConfig{path: gopathBinary, version: "v0.3.0"})
+	t.MustNil(err, "explicit path should be trusted to be the pinned version")
+	t.MustTrue(binary.Source == PylonsdSourceFlag, "flag should take precedence over environment variable")
+
+	resolver = fakePylonsdResolver(map[string]string{"GOPATH": filepath.Join(dir, "empty")}, "")
+	_, err = resolver.resolve(context.Background(), pylonsdConfig{})
+	t.MustTrue(errors.Is(err, ErrPylonsdNotFound), "missing binary should be reported")
+}
+
+func TestResolvePylonsdDownload(originT *originT.T) {
+	t := testing.NewT(originT)
+	dir, err := ioutil.TempDir("", "pylonsd_download")
+	t.MustNil(err, "error creating temp dir")
+	defer os.RemoveAll(dir)
+
+	content := "release build"
+	sum := sha256.Sum256([]byte(content))
+	checksum := hex.EncodeToString(sum[:])
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/v0.3.0/pylonsd-linux-amd64" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	prevReleases := PylonsdReleases
+	defer func() { PylonsdReleases = prevReleases }()
+	PylonsdReleases = map[string]PylonsdRelease{
+		"v0.3.0": {
+			Version:   "v0.3.0",
+			URL:       server.URL + "/{version}/pylonsd-{os}-{arch}",
+			Checksums: map[string]string{"linux/amd64": checksum},
+		},
+		"v0.4.0": {
+			Version:   "v0.4.0",
+			URL:       server.URL + "/v0.3.0/pylonsd-{os}-{arch}",
+			Checksums: map[string]string{"linux/amd64": "00"},
+		},
+	}
+
+	gopath := filepath.Join(dir, "go")
+	writeFakePylonsd(&t, filepath.Join(gopath, "bin", "pylonsd"), "stale build")
+	resolver := fakePylonsdResolver(map[string]string{"GOPATH": gopath}, "v0.2.0")
+	config := pylonsdConfig{version: "v0.3.0", download: true, cacheDir: filepath.Join(dir, "cache")}
+
+	binary, err := resolver.resolve(context.Background(), config)
+	t.MustNil(err, "pinned release should be downloaded for stale local build")
+	t.MustTrue(binary.Source == PylonsdSourceDownload && binary.Version == "v0.3.0", "binary should be the downloaded release")
+	downloaded, err := ioutil.ReadFile(binary.Path)
+	t.MustNil(err, "downloaded binary should be in cache directory")
+	t.MustTrue(string(downloaded) == content, "downloaded binary should be the release binary")
+
+	_, err = resolver.resolve(context.Background(), config)
+	t.MustNil(err, "cached release should be resolved")
+	t.MustTrue(requests == 1, "cached release should not be downloaded again")
+
+	config.version = "v0.4.0"
+	_, err = resolver.resolve(context.Background(), config)
+	t.MustTrue(errors.Is(err, ErrPylonsdChecksumMismatch), "binary of different checksum should not be downloaded")
+	_, err = os.Stat(filepath.Join(dir, "cache", "v0.4.0", "linux_amd64", "pylonsd"))
+	t.MustTrue(os.IsNotExist(err), "binary of different checksum should not be kept")
+
+	config.version = "v0.5.0"
+	_, err = resolver.resolve(context.Background(), config)
+	t.MustContain(err.Error(), "not a pinned release", "version without release should not be downloaded")
+
+	PylonsdReleases["v0.5.0"] = PylonsdRelease{Version: "v0.5.0", URL: server.URL + "/v0.5.0"}
+	_, err = resolver.resolve(context.Background(), config)
+	t.MustContain(err.Error(), "no checksum", "release without checksum should not be downloaded")
+}
+
+func TestResolvePylonsdWindows(originT *originT.T) {
+	t := testing.NewT(originT)
+	dir, err := ioutil.TempDir("", "pylonsd_windows")
+	t.MustNil(err, "error creating temp dir")
+	defer os.RemoveAll(dir)
+
+	gobinBinary := filepath.Join(dir, "gobin", "pylonsd.exe")
+	writeFakePylonsd(&t, gobinBinary, "gobin build")
+	writeFakePylonsd(&t, filepath.Join(dir, "go", "bin", "pylonsd.exe"), "gopath build")
+
+	resolver := fakePylonsdResolver(map[string]string{"GOPATH": filepath.Join(dir, "go"), "GOBIN": filepath.Join(dir, "gobin")}, "")
+	resolver.goos = "windows"
+	binary, err := resolver.resolve(context.Background(), pylonsdConfig{})
+	t.MustNil(err, "pylonsd.exe should be resolved on windows")
+	t.MustTrue(binary.Path == gobinBinary, "GOBIN should take precedence over GOPATH")
+
+	binary, err = resolver.resolve(context.Background(), pylonsdConfig{path: filepath.Join(dir, "gobin", "pylonsd")})
+	t.MustNil(err, "path without .exe should be resolved on windows")
+	t.MustTrue(binary.Path == gobinBinary, ".exe should be added to path on windows")
+
+	t.MustTrue(pylonsdBinaryName("windows") == "pylonsd.exe", "binary name should have .exe on windows")
+	t.MustTrue(pylonsdBinaryName("linux") == "pylonsd", "binary name should not have extension on linux")
+}
@@ -1,6 +1,7 @@
 package inttest
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -8,27 +9,21 @@ import (
 
 	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
 	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
-	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// ListTradeViaCLI is a function to get list of trades from cli
-func ListTradeViaCLI(account string) ([]types.Trade, error) {
-	queryParams := []string{"query", "pylons", "list_trade"}
-	if len(account) != 0 {
-		queryParams = append(queryParams, "--account", account)
-	}
-	output, logstr, err := RunPylonsd(queryParams, "")
+// ListTradeViaCLI is a function to get list of trades through configured transport, pylonsd cli by default
+func ListTradeViaCLI(account string, opts ...QueryOption) ([]types.Trade, error) {
+	ctx := queryContext(opts)
+	transport, err := EnvFromContext(ctx).Transport()
 	if err != nil {
-		return []types.Trade{}, fmt.Errorf("%s: %s", logstr, err.Error())
+		return []types.Trade{}, err
 	}
-	listTradesResp := types.ListTradeResponse{}
-	err = GetJSONMarshaler().UnmarshalJSON(output, &listTradesResp)
-	return listTradesResp.Trades, err
+	return transport.ListTrades(ctx, account)
 }
 
 // GetTradeIDFromExtraInfo is a function to get trade id from trade extra info
-func GetTradeIDFromExtraInfo(tradeExtraInfo string) (string, bool, error) {
-	trdList, err := ListTradeViaCLI("")
+func GetTradeIDFromExtraInfo(tradeExtraInfo string, opts ...QueryOption) (string, bool, error) {
+	trdList, err := ListTradeViaCLI("", opts...)
 	if err != nil {
 		return "", false, err
 	}
@@ -36,117 +31,85 @@ func GetTradeIDFromExtraInfo(tradeExtraInfo string) (string, bool, error) {
 	return trade.ID, exist, nil
 }
 
-// ListCookbookViaCLI is a function to list cookbooks via cli
-func ListCookbookViaCLI(account string) ([]types.Cookbook, error) {
-	listCBResp := types.ListCookbookResponse{}
-	queryParams := []string{"query", "pylons", "list_cookbook"}
-	if len(account) != 0 {
-		queryParams = append(queryParams, "--account", account)
-	}
-	output, logstr, err := RunPylonsd(queryParams, "")
+// ListCookbookViaCLI is a function to list cookbooks through configured transport, pylonsd cli by default
+func ListCookbookViaCLI(account string, opts ...QueryOption) ([]types.Cookbook, error) {
+	ctx := queryContext(opts)
+	transport, err := EnvFromContext(ctx).Transport()
 	if err != nil {
-		return listCBResp.Cookbooks, fmt.Errorf("%s: %s", logstr, err.Error())
+		return nil, err
 	}
-	err = GetJSONMarshaler().UnmarshalJSON(output, &listCBResp)
-	return listCBResp.Cookbooks, err
+	return transport.ListCookbooks(ctx, account)
 }
 
 // GetLockedCoinsViaCLI is a function to list locked coins via cli
 func GetLockedCoinsViaCLI(account string) (types.GetLockedCoinsResponse, error) {
 	lcResp := types.GetLockedCoinsResponse{}
-	queryParams := []string{"query", "pylons", "get_locked_coins"}
-	if len(account) != 0 {
-		queryParams = append(queryParams, "--account", account)
-	}
-	output, logstr, err := RunPylonsd(queryParams, "")
+	output, logstr, err := Query().Pylons().List("get_locked_coins", account).Run(context.Background())
 	if err != nil {
-		return lcResp, fmt.Errorf("%s: %s", logstr, err.Error())
+		return lcResp, fmt.Errorf("%s: %w", logstr, err)
 	}
-	err = GetJSONMarshaler().UnmarshalJSON(output, &lcResp)
+	err = GetCodec().Decode(output, &lcResp)
 	return lcResp, err
 }
 
 // GetLockedCoinDetailsViaCLI is a function to list locked coins via cli
 func GetLockedCoinDetailsViaCLI(account string) (types.GetLockedCoinDetailsResponse, error) {
 	lcdResp := types.GetLockedCoinDetailsResponse{}
-	queryParams := []string{"query", "pylons", "get_locked_coin_details"}
-	if len(account) != 0 {
-		queryParams = append(queryParams, "--account", account)
-	}
-	output, logstr, err := RunPylonsd(queryParams, "")
+	output, logstr, err := Query().Pylons().List("get_locked_coin_details", account).Run(context.Background())
 	if err != nil {
-		return lcdResp, fmt.Errorf("%s: %s", logstr, err.Error())
+		return lcdResp, fmt.Errorf("%s: %w", logstr, err)
 	}
-	err = GetJSONMarshaler().UnmarshalJSON(output, &lcdResp)
+	err = GetCodec().Decode(output, &lcdResp)
 	return lcdResp, err
 }
 
-// ListRecipesViaCLI is a function to list recipes via cli
-func ListRecipesViaCLI(account string) ([]types.Recipe, error) {
-	queryParams := []string{"query", "pylons", "list_recipe"}
-	if len(account) != 0 {
-		queryParams = append(queryParams, "--account", account)
-	}
-	output, _, err := RunPylonsd(queryParams, "")
+// ListRecipesViaCLI is a function to list recipes through configured transport, pylonsd cli by default
+func ListRecipesViaCLI(account string, opts ...QueryOption) ([]types.Recipe, error) {
+	ctx := queryContext(opts)
+	transport, err := EnvFromContext(ctx).Transport()
 	if err != nil {
 		return []types.Recipe{}, err
 	}
-	listRCPResp := types.ListRecipeResponse{}
-	err = GetJSONMarshaler().UnmarshalJSON(output, &listRCPResp)
-	return listRCPResp.Recipes, err
+	return transport.ListRecipes(ctx, account)
 }
 
-// ListExecutionsViaCLI is a function to list executions via cli
-func ListExecutionsViaCLI(account string, t *testing.T) ([]types.Execution, error) {
-	queryParams := []string{"query", "pylons", "list_executions"}
-	if len(account) != 0 {
-		queryParams = append(queryParams, "--account", account)
-	}
-	output, _, err := RunPylonsd(queryParams, "")
-	if err != nil {
-		t.MustNil(err, "error running list_executions cli command")
-		return []types.Execution{}, err
+// ListExecutionsViaCLI is a function to list executions through configured transport, pylonsd cli by default
+func ListExecutionsViaCLI(account string, t *testing.T, opts ...QueryOption) ([]types.Execution, error) {
+	ctx := queryContext(opts)
+	transport, err := EnvFromContext(ctx).Transport()
+	if err == nil {
+		var executions []types.Execution
+		executions, err = transport.ListExecutions(ctx, account)
+		if err == nil {
+			return executions, nil
+		}
 	}
-	var listExecutionsResp types.ListExecutionsResponse
-	err = GetJSONMarshaler().UnmarshalJSON(output, &listExecutionsResp)
 	t.WithFields(testing.Fields{
-		"list_executions_output": string(output),
-	}).MustNil(err, "error unmarshaling list executions")
-	return listExecutionsResp.Executions, err
+		"account": account,
+	}).MustNil(err, "error listing executions")
+	return []types.Execution{}, err
 }
 
-// ListItemsViaCLI is a function to list items via cli
-func ListItemsViaCLI(account string) ([]types.Item, error) {
-	queryParams := []string{"query", "pylons", "items_by_sender"}
-	if len(account) != 0 {
-		queryParams = append(queryParams, "--account", account)
-	}
-	output, logstr, err := RunPylonsd(queryParams, "")
+// ListItemsViaCLI is a function to list items through configured transport, pylonsd cli by default
+func ListItemsViaCLI(account string, opts ...QueryOption) ([]types.Item, error) {
+	ctx := queryContext(opts)
+	transport, err := EnvFromContext(ctx).Transport()
 	if err != nil {
-		return []types.Item{}, fmt.Errorf("%s: %s", logstr, err.Error())
+		return []types.Item{}, err
 	}
-	var ItemResponse types.ItemsBySenderResponse
-	err = GetJSONMarshaler().UnmarshalJSON(output, &ItemResponse)
-	return ItemResponse.Items, err
+	return transport.ItemsBySender(ctx, account)
 }
 
 // WaitAndGetTxError is a function to wait and get transaction error from hash
 func WaitAndGetTxError(txhash string, maxWaitBlock int64, t *testing.T) ([]byte, error) {
-	txErrorResBytes, err := GetTxError(txhash, t)
+	waited, err := waitBlocks(context.Background(), UntilTxCommitted(txhash), "tx "+txhash, maxWaitBlock)
 	if err != nil { // maybe transaction is not contained in block
-		if maxWaitBlock == 0 {
-			t.WithFields(testing.Fields{
-				"output": string(txErrorResBytes),
-				"error":  err,
-			}).Error("didn't get result waiting for maximum wait block")
-			return txErrorResBytes, errors.New("didn't get result waiting for maximum wait block")
-		}
-		if err = WaitForNextBlock(); err != nil {
-			return txErrorResBytes, err
-		}
-		return WaitAndGetTxError(txhash, maxWaitBlock-1, t)
+		t.WithFields(waited.Fields()).WithFields(testing.Fields{
+			"error": err,
+		}).Error("didn't get result waiting for maximum wait block")
+		return []byte{}, errors.New("didn't get result waiting for maximum wait block")
 	}
-	return txErrorResBytes, nil
+	return GetTxError(txhash, t)
 }
 
 // IsJSON checks if bytes is in json
@@ -157,12 +120,7 @@ func IsJSON(str string) bool {
 
 // GetTxError is a function to get transaction error from txhash
 func GetTxError(txhash string, t *testing.T) ([]byte, error) {
-	output, logstr, err := RunPylonsd([]string{"query", "tx", txhash}, "")
-	if err != nil {
-		return []byte{}, fmt.Errorf("%s: %s", logstr, err.Error())
-	}
-	var tx sdk.TxResponse
-	err = GetJSONMarshaler().UnmarshalJSON([]byte(output), &tx)
+	tx, err := GetTxResult(txhash)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -184,45 +142,24 @@ func GetHumanReadableErrorFromTxHash(txhash string, t *testing.T) string {
 
 // GetTxData is a function to get transaction result data by txhash
 func GetTxData(txhash string, t *testing.T) ([]byte, error) {
-	output, _, err := RunPylonsd([]string{"query", "tx", txhash}, "")
+	return getTxData(TestContext(t), txhash, t)
+}
+
+// getTxData is a function to get transaction result data by txhash through transport of env of ctx
+func getTxData(ctx context.Context, txhash string, t *testing.T) ([]byte, error) {
+	tx, err := getTxResult(ctx, txhash)
 	if err != nil {
 		t.WithFields(testing.Fields{
-			"output": string(output),
+			"txhash": txhash,
 			"error":  err,
 		}).Debug("query for tx") // do debug as in this step, transaction could be in mempool
-		return output, err
-	}
-	var tx sdk.TxResponse
-	err = GetJSONMarshaler().UnmarshalJSON([]byte(output), &tx)
-	if err != nil {
 		return []byte{}, err
 	}
+	t.RecordTx(testing.TxRecord{TxHash: txhash, Height: tx.Height, Code: tx.Code, GasUsed: tx.GasUsed})
 	bs, err := hex.DecodeString(tx.Data)
 	return bs, err
 }
 
-// WaitAndGetTxData is a function to get transaction data after transaction is processed
-func WaitAndGetTxData(txhash string, maxWaitBlock int64, t *testing.T) ([]byte, error) {
-	txHandleResBytes, err := GetTxData(txhash, t)
-	t.WithFields(testing.Fields{
-		"action": "GetTxData",
-		"error":  err,
-	}).Debug(string(txHandleResBytes))
-	if err != nil { // maybe transaction is not contained in block
-		if maxWaitBlock == 0 {
-			t.WithFields(testing.Fields{
-				"action": "func_end",
-			}).Error("didn't get result waiting for maximum wait block")
-			return txHandleResBytes, errors.New("didn't get result waiting for maximum wait block")
-		}
-		if err = WaitForNextBlock(); err != nil {
-			return txHandleResBytes, err
-		}
-		return WaitAndGetTxData(txhash, maxWaitBlock-1, t)
-	}
-	return txHandleResBytes, nil
-}
-
 // FindTradeFromArrayByExtraInfo is a function to find trade from extra info
 func FindTradeFromArrayByExtraInfo(trades []types.Trade, extraInfo string) (types.Trade, bool) {
 	for _, trade := range trades {
@@ -285,19 +222,20 @@ func FindItemFromArrayByName(
 }
 
 // GetCookbookByGUID is to get Cookbook from ID
-func GetCookbookByGUID(guid string) (types.Cookbook, error) {
-	output, _, err := RunPylonsd([]string{"query", "pylons", "get_cookbook", guid}, "")
+func GetCookbookByGUID(guid string, opts ...QueryOption) (types.Cookbook, error) {
+	ctx := queryContext(opts)
+	output, _, err := withContextHeight(ctx, Query().Pylons().Cookbook(guid)).Run(ctx)
 	if err != nil {
 		return types.Cookbook{}, err
 	}
 	var cookbook types.Cookbook
-	err = GetJSONMarshaler().UnmarshalJSON(output, &cookbook)
+	err = GetCodec().Decode(output, &cookbook)
 	return cookbook, err
 }
 
 // GetCookbookIDFromName is a function to get cookbook id from name
-func GetCookbookIDFromName(cbName string, account string) (string, bool, error) {
-	cbList, err := ListCookbookViaCLI(account)
+func GetCookbookIDFromName(cbName string, account string, opts ...QueryOption) (string, bool, error) {
+	cbList, err := ListCookbookViaCLI(account, opts...)
 	if err != nil {
 		return "", false, err
 	}
@@ -307,8 +245,8 @@ func GetCookbookIDFromName(cbName string, account string) (string, bool, error)
 }
 
 // GetRecipeIDFromName is a function to get recipe id from name
-func GetRecipeIDFromName(rcpName string) (string, bool, error) {
-	rcpList, err := ListRecipesViaCLI("")
+func GetRecipeIDFromName(rcpName string, opts ...QueryOption) (string, bool, error) {
+	rcpList, err := ListRecipesViaCLI("", opts...)
 	if err != nil {
 		return "", false, err
 	}
@@ -317,8 +255,8 @@ func GetRecipeIDFromName(rcpName string) (string, bool, error) {
 }
 
 // GetItemIDFromName is a function to get item id from name
-func GetItemIDFromName(sender string, itemName string, includeLockedByRecipe bool, includeLockedByTrade bool) (string, bool, error) {
-	itemList, err := ListItemsViaCLI(sender)
+func GetItemIDFromName(sender string, itemName string, includeLockedByRecipe bool, includeLockedByTrade bool, opts ...QueryOption) (string, bool, error) {
+	itemList, err := ListItemsViaCLI(sender, opts...)
 	if err != nil {
 		return "", false, err
 	}
@@ -327,35 +265,38 @@ func GetItemIDFromName(sender string, itemName string, includeLockedByRecipe boo
 }
 
 // GetRecipeByGUID is to get Recipe from ID
-func GetRecipeByGUID(guid string) (types.Recipe, error) {
-	output, _, err := RunPylonsd([]string{"query", "pylons", "get_recipe", guid}, "")
+func GetRecipeByGUID(guid string, opts ...QueryOption) (types.Recipe, error) {
+	ctx := queryContext(opts)
+	output, _, err := withContextHeight(ctx, Query().Pylons().Recipe(guid)).Run(ctx)
 	if err != nil {
 		return types.Recipe{}, err
 	}
 	var rcp types.Recipe
-	err = GetJSONMarshaler().UnmarshalJSON(output, &rcp)
+	err = GetCodec().Decode(output, &rcp)
 	return rcp, err
 }
 
 // GetExecutionByGUID is to get Execution from ID
-func GetExecutionByGUID(guid string) (types.GetExecutionResponse, error) {
-	output, _, err := RunPylonsd([]string{"query", "pylons", "get_execution", guid}, "")
+func GetExecutionByGUID(guid string, opts ...QueryOption) (types.GetExecutionResponse, error) {
+	ctx := queryContext(opts)
+	output, _, err := withContextHeight(ctx, Query().Pylons().Execution(guid)).Run(ctx)
 	if err != nil {
 		return types.GetExecutionResponse{}, err
 	}
 	var exec types.GetExecutionResponse
-	err = GetJSONMarshaler().UnmarshalJSON(output, &exec)
+	err = GetCodec().Decode(output, &exec)
 	return exec, err
 }
 
 // GetItemByGUID is to get Item from ID
-func GetItemByGUID(guid string) (types.Item, error) {
-	output, _, err := RunPylonsd([]string{"query", "pylons", "get_item", guid}, "")
+func GetItemByGUID(guid string, opts ...QueryOption) (types.Item, error) {
+	ctx := queryContext(opts)
+	output, _, err := withContextHeight(ctx, Query().Pylons().Item(guid)).Run(ctx)
 	if err != nil {
 		return types.Item{}, err
 	}
 	var item types.Item
-	err = GetJSONMarshaler().UnmarshalJSON(output, &item)
+	err = GetCodec().Decode(output, &item)
 	if err != nil {
 		return item, fmt.Errorf("%s: item_output %s", err.Error(), string(output))
 	}
@@ -363,8 +304,8 @@ func GetItemByGUID(guid string) (types.Item, error) {
 }
 
 // GetRecipeGUIDFromName is a function to get recipe id from name
-func GetRecipeGUIDFromName(name string, account string) (string, error) {
-	rcpList, err := ListRecipesViaCLI(account)
+func GetRecipeGUIDFromName(name string, account string, opts ...QueryOption) (string, error) {
+	rcpList, err := ListRecipesViaCLI(account, opts...)
 	if err != nil {
 		return "", err
 	}
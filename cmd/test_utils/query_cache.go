@@ -0,0 +1,127 @@
+package inttest
+
+import (
+	"context"
+	"flag"
+	"strings"
+	"sync"
+	"time"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+func init() {
+	flag.DurationVar(&CLIOpts.QueryCacheTTL, "query-cache-ttl", 0, "time to reuse results of idempotent queries e.g. key address and daemon status per node, 0 disables caching")
+}
+
+// queryCacheEntry is a struct to keep a query result with block height it was observed at
+type queryCacheEntry struct {
+	value   interface{}
+	height  int64
+	expires time.Time
+}
+
+// queryCache is a struct to keep results of idempotent queries for a short time
+// so hot lookups in tight loops don't run pylonsd every time
+type queryCache struct {
+	mux     sync.Mutex
+	entries map[string]queryCacheEntry
+}
+
+var queryResults = queryCache{entries: map[string]queryCacheEntry{}}
+
+// GetQueryCacheTTL is a function to get time to reuse results of idempotent queries, 0 when caching is disabled
+func GetQueryCacheTTL() time.Duration {
+	if CLIOpts.QueryCacheTTL < 0 {
+		return 0
+	}
+	return CLIOpts.QueryCacheTTL
+}
+
+// get is a function to get cached value of key which is not expired and observed at minHeight or later
+func (c *queryCache) get(key string, minHeight int64) (interface{}, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) || entry.height < minHeight {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set is a function to cache value of key observed at height, nothing is cached when caching is disabled
+func (c *queryCache) set(key string, value interface{}, height int64) {
+	ttl := GetQueryCacheTTL()
+	if ttl == 0 {
+		return
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.entries[key] = queryCacheEntry{value: value, height: height, expires: time.Now().Add(ttl)}
+}
+
+// invalidate is a function to drop cached values of keys having prefix
+func (c *queryCache) invalidate(prefix string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// ResetQueryCache is a function to drop all cached query results e.g. after switching node or keyring outside of harness
+func ResetQueryCache() {
+	queryResults.invalidate("")
+}
+
+// latestHeight is a function to get the latest block height observed by status queries, 0 if not observed yet
+func (bt *blockTimeTracker) latestHeight() int64 {
+	bt.mux.Lock()
+	defer bt.mux.Unlock()
+	return bt.lastHeight
+}
+
+const (
+	addressCachePrefix = "address/"
+	statusCachePrefix  = "status/"
+)
+
+// addressCacheKey is a function to get cache key of key address for nodes of env, it's per keyring as the same name can have different keys
+func addressCacheKey(env *Env, provider KeyringProvider, account string) string {
+	return addressCachePrefix + env.opts.CustomNode + "/" + strings.Join(provider.KeyringArgs(), " ") + "/" + account
+}
+
+// invalidateKeyAddresses is a function to drop cached key addresses after a command changes keyring
+func invalidateKeyAddresses() {
+	queryResults.invalidate(addressCachePrefix)
+}
+
+// queryDaemonStatus is a function to get daemon status from node bypassing cache, the result is cached for GetDaemonStatusCtx
+// Waits for blocks use it as they need the latest height.
+func queryDaemonStatus(ctx context.Context) (*ctypes.ResultStatus, string, error) {
+	env := EnvFromContext(ctx)
+	return queryObservedDaemonStatus(ctx, env, env.randomNode())
+}
+
+// queryObservedDaemonStatus is a function to get daemon status from node of env bypassing cache and observe it
+func queryObservedDaemonStatus(ctx context.Context, env *Env, node string) (*ctypes.ResultStatus, string, error) {
+	ds, logstr, err := queryDaemonStatusFromNode(ctx, node)
+	if err == nil {
+		observeDaemonStatus(env, node, ds)
+	}
+	return ds, logstr, err
+}
+
+// GetDaemonStatusCtx is a function to get daemon status
+// The status of a node of env of ctx is reused for query cache TTL unless a newer block was observed since it was queried,
+// statuses of other nodes are never used for it.
+func GetDaemonStatusCtx(ctx context.Context) (*ctypes.ResultStatus, string, error) {
+	env := EnvFromContext(ctx)
+	node := env.randomNode()
+	if cached, ok := queryResults.get(env.statusCacheKey(node), env.state().blocks.latestHeight()); ok {
+		return cached.(*ctypes.ResultStatus), "cached daemon status", nil
+	}
+	return queryObservedDaemonStatus(ctx, env, node)
+}
@@ -0,0 +1,45 @@
+package inttest
+
+import (
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestQueryCache(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	ttl := CLIOpts.QueryCacheTTL
+	defer func() { CLIOpts.QueryCacheTTL = ttl }()
+	CLIOpts.QueryCacheTTL = time.Minute
+
+	statusKey := DefaultEnv().statusCacheKey("tcp://node-a:26657")
+	cache := queryCache{entries: map[string]queryCacheEntry{}}
+	cache.set(addressCachePrefix+"eugen", "pylo1eugen", 0)
+	cache.set(statusKey, "status at 10", 10)
+
+	_, ok := cache.get(statusKey, 10)
+	t.MustTrue(ok, "value of the latest height should be cached")
+	_, ok = cache.get(statusKey, 11)
+	t.MustTrue(!ok, "value observed before the latest height should not be used")
+
+	cache.invalidate(addressCachePrefix)
+	_, ok = cache.get(addressCachePrefix+"eugen", 0)
+	t.MustTrue(!ok, "invalidated value should not be used")
+	_, ok = cache.get(statusKey, 0)
+	t.MustTrue(ok, "value of other prefix should be kept")
+	_, ok = cache.get(DefaultEnv().statusCacheKey("tcp://node-b:26657"), 0)
+	t.MustTrue(!ok, "status of a node should not be used for other nodes")
+
+	envA := NewEnv(CLIOptions{CustomNode: "tcp://node-a:26657"}, nil)
+	envB := NewEnv(CLIOptions{CustomNode: "tcp://node-b:26657"}, nil)
+	provider := FileKeyring{Dir: "/tmp/keyring"}
+	t.MustTrue(addressCacheKey(envA, provider, "eugen") != addressCacheKey(envB, provider, "eugen"),
+		"key addresses of envs of different nodes should have their own cache keys")
+
+	CLIOpts.QueryCacheTTL = 0
+	cache.set(addressCachePrefix+"eugen", "pylo1eugen", 0)
+	_, ok = cache.get(addressCachePrefix+"eugen", 0)
+	t.MustTrue(!ok, "nothing should be cached when caching is disabled")
+}
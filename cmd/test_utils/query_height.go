@@ -0,0 +1,85 @@
+package inttest
+
+import (
+	"context"
+	"strconv"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	gogogrpc "github.com/gogo/protobuf/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// QueryOption is a function to change how query helpers query the node
+type QueryOption func(*queryOptions)
+
+// queryOptions is a struct to keep options of a query
+type queryOptions struct {
+	height int64
+	page   *queryPage
+	env    *Env
+}
+
+// WithHeight is a function to query state committed at block height instead of the latest state
+// It lets tests assert state before and after a transaction without racing against new blocks, the node should keep
+// state of the height i.e. it should not be pruned.
+func WithHeight(height int64) QueryOption {
+	return func(o *queryOptions) {
+		o.height = height
+	}
+}
+
+// queryHeightKey is the context key of query height
+type queryHeightKey struct{}
+
+// ContextWithHeight is a function to make transports query state at height, 0 queries the latest state
+func ContextWithHeight(ctx context.Context, height int64) context.Context {
+	return context.WithValue(ctx, queryHeightKey{}, height)
+}
+
+// HeightFromContext is a function to get query height of ctx, it's 0 for the latest state
+func HeightFromContext(ctx context.Context) int64 {
+	height, _ := ctx.Value(queryHeightKey{}).(int64)
+	return height
+}
+
+// queryContext is a function to get context of query helpers applying query options
+func queryContext(opts []QueryOption) context.Context {
+	o := queryOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	ctx := context.Background()
+	if o.height > 0 {
+		ctx = ContextWithHeight(ctx, o.height)
+	}
+	if o.page != nil {
+		ctx = ContextWithPage(ctx, o.page.page, o.page.res)
+	}
+	if o.env != nil {
+		ctx = ContextWithEnv(ctx, o.env)
+	}
+	return ctx
+}
+
+// withContextHeight is a function to add --height flag of ctx to pylonsd query
+func withContextHeight(ctx context.Context, cmd *CommandBuilder) *CommandBuilder {
+	if height := HeightFromContext(ctx); height > 0 {
+		cmd.Height(height)
+	}
+	return cmd
+}
+
+// heightClientConn is a client connection sending height of request context in block height header
+// so that grpc endpoint and abci queries of tendermint rpc return state at the height
+type heightClientConn struct {
+	gogogrpc.ClientConn
+}
+
+// Invoke is a function to call grpc method with height header of ctx
+func (c heightClientConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	if height := HeightFromContext(ctx); height > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
+	}
+	return c.ClientConn.Invoke(ctx, method, args, reply, opts...)
+}
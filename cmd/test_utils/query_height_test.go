@@ -0,0 +1,67 @@
+package inttest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// heightRecordingConn is a client connection recording height header of the last request
+type heightRecordingConn struct {
+	heights *[]string
+}
+
+func (c heightRecordingConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	*c.heights = md.Get(grpctypes.GRPCBlockHeightHeader)
+	return nil
+}
+
+func (c heightRecordingConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, nil
+}
+
+func TestQueryHeight(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	t.MustTrue(HeightFromContext(queryContext(nil)) == 0, "query without options should be at the latest height")
+	ctx := queryContext([]QueryOption{WithHeight(42)})
+	t.MustTrue(HeightFromContext(ctx) == 42, "query height should be set by WithHeight")
+	t.MustContain(withContextHeight(ctx, Query().Pylons().Recipe("recipe0")).String(), "--height=42", "cli query should have height flag")
+	t.MustTrue(withContextHeight(context.Background(), Query().Pylons().Recipe("recipe0")).String() == Query().Pylons().Recipe("recipe0").String(), "latest height query should not have height flag")
+
+	heights := []string{}
+	transport := newQueryClientTransport(heightRecordingConn{heights: &heights})
+	_, err := transport.Balances(ctx, "addr")
+	t.MustNil(err, "error querying balances")
+	t.MustTrue(len(heights) == 1 && heights[0] == "42", "grpc query should have block height header")
+	_, err = transport.Balances(context.Background(), "addr")
+	t.MustNil(err, "error querying balances")
+	t.MustTrue(len(heights) == 0, "latest height grpc query should not have block height header")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res := banktypes.QueryAllBalancesResponse{Balances: sdk.NewCoins(sdk.NewInt64Coin("pylon", 10))}
+		if r.Header.Get(grpctypes.GRPCBlockHeightHeader) == "42" {
+			res.Balances = sdk.NewCoins(sdk.NewInt64Coin("pylon", 5))
+		}
+		output, err := GetJSONMarshaler().MarshalJSON(&res)
+		t.MustNil(err, "error encoding response")
+		_, _ = w.Write(output)
+	}))
+	defer server.Close()
+	rest := newRESTTransport(server.URL)
+	balances, err := rest.Balances(ctx, "addr")
+	t.MustNil(err, "error querying balances at height")
+	t.MustTrue(balances.AmountOf("pylon").Int64() == 5, "rest query should return balances at height")
+	balances, err = rest.Balances(context.Background(), "addr")
+	t.MustNil(err, "error querying latest balances")
+	t.MustTrue(balances.AmountOf("pylon").Int64() == 10, "rest query should return latest balances")
+}
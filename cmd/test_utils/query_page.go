@@ -0,0 +1,205 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// Page is a struct to describe a page of list query
+// Key is ID of the first object of the page i.e. NextKey of the previous page, Offset is used when Key is empty.
+// Limit 0 lists all objects from the start of the page, Reverse lists objects in descending order of their IDs.
+type Page struct {
+	Key     string
+	Offset  uint64
+	Limit   uint64
+	Reverse bool
+}
+
+// PageResponse is a struct to describe listed page, NextKey is empty on the last page
+type PageResponse struct {
+	NextKey string
+	Total   uint64
+}
+
+// WithPage is a function to list a page of objects instead of all objects, res is filled when it's not nil
+// Pylons list queries of node don't take pagination, so transports cut the page from objects node returns in order of
+// their IDs, which keeps pages stable while new objects are added.
+func WithPage(page Page, res *PageResponse) QueryOption {
+	return func(o *queryOptions) {
+		o.page = &queryPage{page: page, res: res}
+	}
+}
+
+// queryPage is a struct to keep page of a query with its response
+type queryPage struct {
+	page Page
+	res  *PageResponse
+}
+
+// queryPageKey is the context key of query page
+type queryPageKey struct{}
+
+// ContextWithPage is a function to make transports list a page of objects, res is filled when it's not nil
+func ContextWithPage(ctx context.Context, page Page, res *PageResponse) context.Context {
+	return context.WithValue(ctx, queryPageKey{}, &queryPage{page: page, res: res})
+}
+
+// PageFromContext is a function to get query page of ctx, ok is false when all objects are listed
+func PageFromContext(ctx context.Context) (page Page, ok bool) {
+	qp, ok := ctx.Value(queryPageKey{}).(*queryPage)
+	if !ok {
+		return Page{}, false
+	}
+	return qp.page, true
+}
+
+// ForEachPage is a function to call list with each page from first until the last page
+// e.g. ForEachPage(Page{Limit: 100}, func(page QueryOption) error { items, err := ListItemsViaCLI(addr, page); ... })
+func ForEachPage(first Page, list func(page QueryOption) error) error {
+	page := first
+	for {
+		res := PageResponse{}
+		if err := list(WithPage(page, &res)); err != nil {
+			return err
+		}
+		if len(res.NextKey) == 0 {
+			return nil
+		}
+		page.Key = res.NextKey
+		page.Offset = 0
+	}
+}
+
+// pageIndexes is a function to get indexes of ids in the page of ctx, ok is false when ctx has no page
+func pageIndexes(ctx context.Context, ids []string) (indexes []int, ok bool, err error) {
+	qp, ok := ctx.Value(queryPageKey{}).(*queryPage)
+	if !ok {
+		return nil, false, nil
+	}
+	page := qp.page
+	if len(page.Key) > 0 && page.Offset > 0 {
+		return nil, true, errors.New("either offset or key is expected for page, got both")
+	}
+	sorted := make([]int, len(ids))
+	for idx := range ids {
+		sorted[idx] = idx
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if page.Reverse {
+			return ids[sorted[i]] > ids[sorted[j]]
+		}
+		return ids[sorted[i]] < ids[sorted[j]]
+	})
+
+	start := int(page.Offset)
+	if len(page.Key) > 0 {
+		start = sort.Search(len(sorted), func(i int) bool {
+			if page.Reverse {
+				return ids[sorted[i]] <= page.Key
+			}
+			return ids[sorted[i]] >= page.Key
+		})
+	}
+	if start > len(sorted) {
+		start = len(sorted)
+	}
+	end := len(sorted)
+	if page.Limit > 0 && uint64(end-start) > page.Limit {
+		end = start + int(page.Limit)
+	}
+	if qp.res != nil {
+		*qp.res = PageResponse{Total: uint64(len(ids))}
+		if end < len(sorted) {
+			qp.res.NextKey = ids[sorted[end]]
+		}
+	}
+	return sorted[start:end], true, nil
+}
+
+// pageCookbooks is a function to get cookbooks in the page of ctx
+func pageCookbooks(ctx context.Context, cookbooks []types.Cookbook) ([]types.Cookbook, error) {
+	ids := make([]string, len(cookbooks))
+	for idx, cookbook := range cookbooks {
+		ids[idx] = cookbook.ID
+	}
+	indexes, ok, err := pageIndexes(ctx, ids)
+	if !ok || err != nil {
+		return cookbooks, err
+	}
+	paged := make([]types.Cookbook, 0, len(indexes))
+	for _, idx := range indexes {
+		paged = append(paged, cookbooks[idx])
+	}
+	return paged, nil
+}
+
+// pageRecipes is a function to get recipes in the page of ctx
+func pageRecipes(ctx context.Context, recipes []types.Recipe) ([]types.Recipe, error) {
+	ids := make([]string, len(recipes))
+	for idx, recipe := range recipes {
+		ids[idx] = recipe.ID
+	}
+	indexes, ok, err := pageIndexes(ctx, ids)
+	if !ok || err != nil {
+		return recipes, err
+	}
+	paged := make([]types.Recipe, 0, len(indexes))
+	for _, idx := range indexes {
+		paged = append(paged, recipes[idx])
+	}
+	return paged, nil
+}
+
+// pageTrades is a function to get trades in the page of ctx
+func pageTrades(ctx context.Context, trades []types.Trade) ([]types.Trade, error) {
+	ids := make([]string, len(trades))
+	for idx, trade := range trades {
+		ids[idx] = trade.ID
+	}
+	indexes, ok, err := pageIndexes(ctx, ids)
+	if !ok || err != nil {
+		return trades, err
+	}
+	paged := make([]types.Trade, 0, len(indexes))
+	for _, idx := range indexes {
+		paged = append(paged, trades[idx])
+	}
+	return paged, nil
+}
+
+// pageExecutions is a function to get executions in the page of ctx
+func pageExecutions(ctx context.Context, executions []types.Execution) ([]types.Execution, error) {
+	ids := make([]string, len(executions))
+	for idx, execution := range executions {
+		ids[idx] = execution.ID
+	}
+	indexes, ok, err := pageIndexes(ctx, ids)
+	if !ok || err != nil {
+		return executions, err
+	}
+	paged := make([]types.Execution, 0, len(indexes))
+	for _, idx := range indexes {
+		paged = append(paged, executions[idx])
+	}
+	return paged, nil
+}
+
+// pageItems is a function to get items in the page of ctx
+func pageItems(ctx context.Context, items []types.Item) ([]types.Item, error) {
+	ids := make([]string, len(items))
+	for idx, item := range items {
+		ids[idx] = item.ID
+	}
+	indexes, ok, err := pageIndexes(ctx, ids)
+	if !ok || err != nil {
+		return items, err
+	}
+	paged := make([]types.Item, 0, len(indexes))
+	for _, idx := range indexes {
+		paged = append(paged, items[idx])
+	}
+	return paged, nil
+}
@@ -0,0 +1,100 @@
+package inttest
+
+import (
+	"context"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+// itemsConn is a client connection responding items to ItemsBySender queries
+type itemsConn struct {
+	items []types.Item
+}
+
+func (c itemsConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	if res, ok := reply.(*types.ItemsBySenderResponse); ok {
+		res.Items = c.items
+	}
+	return nil
+}
+
+func (c itemsConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, nil
+}
+
+func itemIDs(items []types.Item) []string {
+	ids := []string{}
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestQueryPage(originT *originT.T) {
+	t := testing.NewT(originT)
+	items := []types.Item{{ID: "item3"}, {ID: "item1"}, {ID: "item5"}, {ID: "item2"}, {ID: "item4"}}
+
+	paged, err := pageItems(context.Background(), items)
+	t.MustNil(err, "error listing items without page")
+	t.MustTrue(len(paged) == 5 && paged[0].ID == "item3", "items should be kept as node returns them without page")
+
+	res := PageResponse{}
+	paged, err = pageItems(queryContext([]QueryOption{WithPage(Page{Limit: 2}, &res)}), items)
+	t.MustNil(err, "error listing first page")
+	t.WithFields(testing.Fields{
+		"ids": itemIDs(paged),
+	}).MustTrue(len(paged) == 2 && paged[0].ID == "item1" && paged[1].ID == "item2", "first page should have the first items by id")
+	t.MustTrue(res.NextKey == "item3" && res.Total == 5, "next key should be id of the first item of the next page")
+
+	paged, err = pageItems(ContextWithPage(context.Background(), Page{Key: "item4"}, &res), items)
+	t.MustNil(err, "error listing page from key")
+	t.MustTrue(len(paged) == 2 && paged[0].ID == "item4" && len(res.NextKey) == 0, "page without limit should have items until the last one")
+
+	paged, err = pageItems(ContextWithPage(context.Background(), Page{Offset: 1, Limit: 2, Reverse: true}, &res), items)
+	t.MustNil(err, "error listing reverse page")
+	t.WithFields(testing.Fields{
+		"ids": itemIDs(paged),
+	}).MustTrue(len(paged) == 2 && paged[0].ID == "item4" && paged[1].ID == "item3", "reverse page should be in descending order of id")
+	t.MustTrue(res.NextKey == "item2", "next key of reverse page should be the next smaller id")
+
+	paged, err = pageItems(ContextWithPage(context.Background(), Page{Offset: 10}, nil), items)
+	t.MustNil(err, "error listing page beyond the last item")
+	t.MustTrue(len(paged) == 0, "page beyond the last item should be empty")
+
+	_, err = pageItems(ContextWithPage(context.Background(), Page{Key: "item2", Offset: 1}, nil), items)
+	t.MustTrue(err != nil, "page of both key and offset should fail")
+}
+
+func TestForEachPage(originT *originT.T) {
+	t := testing.NewT(originT)
+	addr := "pylo1gcq3wf0eqw6ahg38aqtlkhq4mnpc2dk3y2ejwu"
+	items := []types.Item{{ID: "item3"}, {ID: "item1"}, {ID: "item5"}, {ID: "item2"}, {ID: "item4"}}
+
+	server := newRESTServer(&t, map[string]proto.Message{
+		"/custom/pylons/items_by_sender/" + addr: &types.ItemsBySenderResponse{Items: items},
+	})
+	defer server.Close()
+
+	transports := []interface {
+		ItemsBySender(ctx context.Context, sender string) ([]types.Item, error)
+	}{
+		newRESTTransport(server.URL),
+		newQueryClientTransport(itemsConn{items: items}),
+	}
+	for _, transport := range transports {
+		pages := [][]string{}
+		err := ForEachPage(Page{Limit: 2, Reverse: true}, func(page QueryOption) error {
+			paged, err := transport.ItemsBySender(queryContext([]QueryOption{page}), addr)
+			pages = append(pages, itemIDs(paged))
+			return err
+		})
+		t.MustNil(err, "error listing all pages")
+		t.WithFields(testing.Fields{
+			"pages": pages,
+		}).MustTrue(len(pages) == 3 && pages[0][0] == "item5" && pages[2][0] == "item1", "all pages should be listed in order")
+	}
+}
@@ -0,0 +1,297 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RaceTx is a struct to describe one of conflicting transactions broadcast in the same block
+type RaceTx struct {
+	Signer Signer
+	Msgs   []sdk.Msg
+	// FeeStrategy decides fees of the transaction instead of the one of client e.g. PriorityFee to let it win the race
+	FeeStrategy FeeStrategy
+}
+
+// NewRaceTx is a function to get race transaction of msgs signed by signer
+func NewRaceTx(signer Signer, msgs ...sdk.Msg) RaceTx {
+	return RaceTx{Signer: signer, Msgs: msgs}
+}
+
+// RaceTxsOf is a function to get a race transaction for each msg, signed by the first signer of the msg
+// e.g. RaceTxsOf(&createTrade1, &createTrade2) to put the same item in two trades
+func RaceTxsOf(msgs ...sdk.Msg) []RaceTx {
+	txs := []RaceTx{}
+	for _, msg := range msgs {
+		signer := ""
+		if signers := msg.GetSigners(); len(signers) > 0 {
+			signer = signers[0].String()
+		}
+		txs = append(txs, NewRaceTx(SignerAddress(signer), msg))
+	}
+	return txs
+}
+
+// ExecuteRecipeRace is a function to get executions of recipe by each sender consuming the same items
+// A sender can be repeated to execute the recipe twice with its own items, they're signed with consecutive sequences.
+func ExecuteRecipeRace(recipeID string, itemIDs []string, senders ...string) []RaceTx {
+	msgs := []sdk.Msg{}
+	for _, sender := range senders {
+		msg := types.NewMsgExecuteRecipe(recipeID, sender, itemIDs)
+		msgs = append(msgs, &msg)
+	}
+	return RaceTxsOf(msgs...)
+}
+
+// FulfillTradeRace is a function to get fulfillments of the same trade by each sender with items
+func FulfillTradeRace(tradeID string, itemIDs []string, senders ...string) []RaceTx {
+	msgs := []sdk.Msg{}
+	for _, sender := range senders {
+		msg := types.NewMsgFulfillTrade(tradeID, sender, itemIDs)
+		msgs = append(msgs, &msg)
+	}
+	return RaceTxsOf(msgs...)
+}
+
+// RaceResult is a struct to describe results of race transactions in the order they're broadcast
+// TxHashes are empty and Heights are zero for transactions rejected on broadcast.
+type RaceResult struct {
+	TxHashes []string
+	Heights  []int64
+	Errors   []error // nil for successful transactions
+}
+
+// Winners is a function to get indexes of successful transactions
+func (r RaceResult) Winners() []int {
+	winners := []int{}
+	for idx, err := range r.Errors {
+		if err == nil {
+			winners = append(winners, idx)
+		}
+	}
+	return winners
+}
+
+// SameBlock is a function to check if all transactions accepted on broadcast are included in the same block
+// A race in different blocks is not a race, the later transaction just sees the state of the earlier one.
+func (r RaceResult) SameBlock() bool {
+	height := int64(0)
+	for _, h := range r.Heights {
+		if h == 0 {
+			continue
+		}
+		if height != 0 && h != height {
+			return false
+		}
+		height = h
+	}
+	return true
+}
+
+// CheckExactlyOneSucceeded is a function to check only one of conflicting transactions succeeded
+func (r RaceResult) CheckExactlyOneSucceeded() error {
+	winners := r.Winners()
+	if len(winners) == 1 {
+		return nil
+	}
+	failures := []string{}
+	for idx, err := range r.Errors {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%d: %s", idx, err.Error()))
+		}
+	}
+	return fmt.Errorf("%d of %d conflicting transactions succeeded, exactly one should succeed; failures: [%s]",
+		len(winners), len(r.Errors), strings.Join(failures, ", "))
+}
+
+// Fields is a function to get log fields of race result
+func (r RaceResult) Fields() testing.Fields {
+	errs := []string{}
+	for _, err := range r.Errors {
+		if err == nil {
+			errs = append(errs, "")
+		} else {
+			errs = append(errs, err.Error())
+		}
+	}
+	return testing.Fields{
+		"txhashes":   r.TxHashes,
+		"heights":    r.Heights,
+		"errors":     errs,
+		"winners":    r.Winners(),
+		"same_block": r.SameBlock(),
+	}
+}
+
+// signedRaceTx is a struct to keep signed transaction file of race transaction with its signer and sequence
+type signedRaceTx struct {
+	signer       string
+	sequence     uint64
+	signedTxFile string
+}
+
+// signRaceTxs is a function to sign all race transactions before any of them is broadcast, it should be called while nonceMux of env is locked
+// Transactions of the same signer get consecutive sequences from the nonce file.
+func (c *Client) signRaceTxs(ctx context.Context, t *testing.T, tmpDir string, nonceMap map[string]uint64, txs []RaceTx) ([]signedRaceTx, error) {
+	next := make(map[string]uint64)
+	signed := []signedRaceTx{}
+	for idx, tx := range txs {
+		if len(tx.Msgs) == 0 {
+			return signed, fmt.Errorf("race transaction %d has no msgs", idx)
+		}
+		signer := tx.Signer.value
+		if !tx.Signer.isAddress {
+			signer = GetAccountAddrWithKeyring(c.keyring, signer, t)
+		}
+		accInfo := GetAccountInfoFromAddr(signer, t)
+		sequence, ok := next[signer]
+		if !ok {
+			sequence = accInfo.GetSequence()
+			if existNonce, ok := nonceMap[signer]; ok {
+				sequence = existNonce
+			}
+		}
+		next[signer] = sequence + 1
+
+		txOpts := c.txOpts
+		if tx.FeeStrategy != nil {
+			txOpts.FeeStrategy = tx.FeeStrategy
+		}
+		txModel, err := genTx(ctx, tx.Msgs, txOpts)
+		if err != nil {
+			return signed, fmt.Errorf("error generating race transaction %d: %w", idx, err)
+		}
+		rawTx, err := GetTxJSONEncoder()(txModel)
+		if err != nil {
+			return signed, fmt.Errorf("error marshaling race transaction %d: %w", idx, err)
+		}
+		rawTxFile := filepath.Join(tmpDir, "raw_race_tx_"+strconv.Itoa(idx)+".json")
+		signedTxFile := filepath.Join(tmpDir, "signed_race_tx_"+strconv.Itoa(idx)+".json")
+		if err = ioutil.WriteFile(rawTxFile, rawTx, 0644); err != nil {
+			return signed, err
+		}
+		if errMsg, err := signRawTxFile(ctx, rawTxFile, signedTxFile, signer, accInfo.GetAccountNumber(), sequence, c.keyring); err != nil {
+			return signed, fmt.Errorf("%s of race transaction %d: %w", errMsg, idx, err)
+		}
+		signed = append(signed, signedRaceTx{signer: signer, sequence: sequence, signedTxFile: signedTxFile})
+	}
+	return signed, nil
+}
+
+// broadcastRaceTxs is a function to sign race transactions and broadcast them back to back at the start of a block
+// It returns txhash of each transaction, empty with its error for transactions rejected on broadcast.
+func (c *Client) broadcastRaceTxs(ctx context.Context, t *testing.T, txs []RaceTx) ([]string, []error, error) {
+	tmpDir, err := ioutil.TempDir("", "pylons")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	c.env.state().nonceMux.Lock()
+	defer c.env.state().nonceMux.Unlock()
+	nonceMap, err := readNonceMap(c.env, t)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error unmarshaling nonce map: %w", err)
+	}
+	signed, err := c.signRaceTxs(ctx, t, tmpDir, nonceMap, txs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// signing takes a while per transaction, so broadcasts start on a new block to be included in the same one
+	if err = WaitForNextBlockCtx(ctx); err != nil {
+		return nil, nil, err
+	}
+	txhashes := make([]string, len(signed))
+	broadcastErrs := make([]error, len(signed))
+	for idx, tx := range signed {
+		txhash, err := broadcastTxFile(ctx, tx.signedTxFile, 0, t)
+		var cmdErr *CommandError
+		if err != nil && !errors.As(err, &cmdErr) {
+			return txhashes, broadcastErrs, fmt.Errorf("error broadcasting race transaction %d: %w", idx, err)
+		}
+		if err != nil {
+			// rejected by check tx e.g. by sequence mismatch after an earlier transaction of the same signer is rejected
+			broadcastErrs[idx] = err
+			continue
+		}
+		txhashes[idx] = txhash
+		// sequence is only consumed by transactions accepted on broadcast
+		if next, ok := nonceMap[tx.signer]; !ok || next == tx.sequence {
+			nonceMap[tx.signer] = tx.sequence + 1
+		}
+	}
+	if errMsg, err := writeNonceMap(c.env, nonceMap); err != nil {
+		return txhashes, broadcastErrs, fmt.Errorf("%s: %w", errMsg, err)
+	}
+	return txhashes, broadcastErrs, nil
+}
+
+// SendRace is a function to broadcast conflicting transactions in the same block on purpose and wait for their results
+// All transactions are signed first and broadcast back to back right after a new block, -broadcast-rate can still split them
+// into different blocks, which RaceResult.SameBlock tells. Failed transactions are results of the race rather than errors,
+// error is returned when the race can't be run e.g. by signing or network failures.
+func (c *Client) SendRace(ctx context.Context, t *testing.T, txs ...RaceTx) (RaceResult, error) {
+	if err := ctx.Err(); err != nil {
+		return RaceResult{}, err
+	}
+	if len(txs) < 2 {
+		return RaceResult{}, errors.New("race needs at least 2 transactions")
+	}
+	ctx = c.withContext(ctx)
+	if err := NodeVersionCheck(ctx, t); err != nil {
+		return RaceResult{}, err
+	}
+	for _, tx := range txs {
+		t.InvolveAccounts(msgSigners(tx.Msgs)...)
+	}
+	txhashes, broadcastErrs, err := c.broadcastRaceTxs(ctx, t, txs)
+	if err != nil {
+		return RaceResult{}, err
+	}
+	result := RaceResult{
+		TxHashes: txhashes,
+		Heights:  make([]int64, len(txs)),
+		Errors:   broadcastErrs,
+	}
+	for idx, txhash := range txhashes {
+		if len(txhash) == 0 {
+			continue
+		}
+		txResult, err := c.WaitForTxResult(ctx, t, txhash)
+		var cmdErr *CommandError
+		if err != nil && !errors.As(err, &cmdErr) {
+			return result, fmt.Errorf("error waiting for race transaction %d: %w", idx, err)
+		}
+		result.Heights[idx] = txResult.Height
+		result.Errors[idx] = err
+	}
+	t.WithFields(result.Fields()).Info("race transactions are processed")
+	return result, nil
+}
+
+// SendRaceExactlyOne is a function to run race of conflicting transactions and check exactly one of them succeeded
+// It returns index of the winner, e.g. the same item can't be sold by two trades fulfilled in the same block.
+func (c *Client) SendRaceExactlyOne(ctx context.Context, t *testing.T, txs ...RaceTx) (int, error) {
+	result, err := c.SendRace(ctx, t, txs...)
+	if err != nil {
+		return -1, err
+	}
+	if !result.SameBlock() {
+		t.WithFields(result.Fields()).Warn("race transactions are included in different blocks")
+	}
+	if err = result.CheckExactlyOneSucceeded(); err != nil {
+		return -1, err
+	}
+	return result.Winners()[0], nil
+}
@@ -0,0 +1,71 @@
+package inttest
+
+import (
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestRaceTxs(originT *originT.T) {
+	t := testing.NewT(originT)
+	player := sdk.AccAddress([]byte("race_player_________")).String()
+	other := sdk.AccAddress([]byte("race_other__________")).String()
+
+	txs := ExecuteRecipeRace("recipe1", []string{"item1"}, player, player)
+	t.MustTrue(len(txs) == 2, "race should have a transaction for each sender")
+	for _, tx := range txs {
+		t.MustTrue(tx.Signer == SignerAddress(player), "execution should be signed by its sender")
+		msg, ok := tx.Msgs[0].(*types.MsgExecuteRecipe)
+		t.MustTrue(ok, "msg should be execute recipe")
+		t.MustTrue(msg.RecipeID == "recipe1" && msg.ItemIDs[0] == "item1", "executions should consume the same item")
+	}
+
+	txs = FulfillTradeRace("trade1", nil, player, other)
+	t.MustTrue(txs[0].Signer == SignerAddress(player), "first fulfillment should be signed by player")
+	t.MustTrue(txs[1].Signer == SignerAddress(other), "second fulfillment should be signed by other")
+}
+
+func TestRaceResult(originT *originT.T) {
+	t := testing.NewT(originT)
+	lost := NewTxError("pylons", 2, "item is already locked")
+
+	result := RaceResult{
+		TxHashes: []string{"A", "B"},
+		Heights:  []int64{10, 10},
+		Errors:   []error{nil, lost},
+	}
+	t.MustTrue(result.SameBlock(), "transactions of the same height are in the same block")
+	t.MustNil(result.CheckExactlyOneSucceeded(), "one of two transactions succeeded")
+	t.MustTrue(len(result.Winners()) == 1 && result.Winners()[0] == 0, "first transaction should win")
+
+	// rejected on broadcast, it's not included in any block
+	result = RaceResult{
+		TxHashes: []string{"A", ""},
+		Heights:  []int64{10, 0},
+		Errors:   []error{nil, NewTxError("sdk", 32, "account sequence mismatch")},
+	}
+	t.MustTrue(result.SameBlock(), "transaction rejected on broadcast should not split the race")
+	t.MustNil(result.CheckExactlyOneSucceeded(), "transaction rejected on broadcast lost the race")
+
+	result = RaceResult{
+		TxHashes: []string{"A", "B"},
+		Heights:  []int64{10, 11},
+		Errors:   []error{nil, nil},
+	}
+	t.MustTrue(!result.SameBlock(), "transactions of different heights are in different blocks")
+	err := result.CheckExactlyOneSucceeded()
+	t.MustTrue(err != nil, "double spend should fail the check")
+	t.MustContain(err.Error(), "2 of 2 conflicting transactions succeeded", "error should tell number of winners")
+
+	result = RaceResult{
+		TxHashes: []string{"A", "B"},
+		Heights:  []int64{10, 10},
+		Errors:   []error{lost, errors.New("recipe is disabled")},
+	}
+	err = result.CheckExactlyOneSucceeded()
+	t.MustTrue(err != nil, "race without winner should fail the check")
+	t.MustContain(err.Error(), "item is already locked", "error should have failures of transactions")
+}
@@ -0,0 +1,194 @@
+package inttest
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	flag.Float64Var(&CLIOpts.BroadcastRate, "broadcast-rate", 0, "number of transactions broadcast per second by all accounts, 0 for no limit")
+	flag.Float64Var(&CLIOpts.AccountBroadcastRate, "account-broadcast-rate", 0, "number of transactions broadcast per second by each signer account, 0 for no limit")
+	flag.IntVar(&CLIOpts.BroadcastBurst, "broadcast-burst", 1, "number of transactions broadcast at once before rate limits apply")
+	MetricsRegistry.MustRegister(broadcastRateLimitWait)
+}
+
+var broadcastRateLimitWait = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Namespace: metricsNamespace,
+	Name:      "broadcast_rate_limit_wait_seconds",
+	Help:      "Time broadcasts waited for broadcast rate limits.",
+	Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+})
+
+// tokenBucket is a struct to limit events to rate per second with burst events at once
+// Tokens can go negative by reservations, so waiters are served in reservation order.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+// reserve is a function to take a token and get the time to wait until the token is available
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel is a function to give back a reserved token which is not used
+func (b *tokenBucket) cancel() {
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// broadcastLimiterConfig is a struct to describe rates of broadcast limiter, limiter is created again when it's changed
+type broadcastLimiterConfig struct {
+	rate        float64
+	accountRate float64
+	burst       int
+}
+
+// broadcastLimiter is a struct to limit broadcasts of all accounts and of each signer account
+type broadcastLimiter struct {
+	mux      sync.Mutex
+	config   broadcastLimiterConfig
+	global   *tokenBucket
+	accounts map[string]*tokenBucket
+	now      func() time.Time
+}
+
+var broadcastLimiterMux sync.Mutex
+var currentBroadcastLimiter *broadcastLimiter
+
+func newBroadcastLimiter(config broadcastLimiterConfig, now func() time.Time) *broadcastLimiter {
+	limiter := &broadcastLimiter{
+		config:   config,
+		accounts: make(map[string]*tokenBucket),
+		now:      now,
+	}
+	if config.rate > 0 {
+		limiter.global = newTokenBucket(config.rate, config.burst, now())
+	}
+	return limiter
+}
+
+// getBroadcastLimiter is a function to get broadcast limiter of CLIOpts, nil when no rate is set
+func getBroadcastLimiter() *broadcastLimiter {
+	config := broadcastLimiterConfig{
+		rate:        CLIOpts.BroadcastRate,
+		accountRate: CLIOpts.AccountBroadcastRate,
+		burst:       CLIOpts.BroadcastBurst,
+	}
+	if config.rate <= 0 && config.accountRate <= 0 {
+		return nil
+	}
+	if config.burst < 1 {
+		config.burst = 1
+	}
+	broadcastLimiterMux.Lock()
+	defer broadcastLimiterMux.Unlock()
+	if currentBroadcastLimiter == nil || currentBroadcastLimiter.config != config {
+		currentBroadcastLimiter = newBroadcastLimiter(config, time.Now)
+	}
+	return currentBroadcastLimiter
+}
+
+// reserve is a function to take tokens of global and signer buckets, it returns the longest wait and a function to give them back
+func (l *broadcastLimiter) reserve(signers []string) (time.Duration, func()) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	now := l.now()
+	buckets := []*tokenBucket{}
+	if l.global != nil {
+		buckets = append(buckets, l.global)
+	}
+	if l.config.accountRate > 0 {
+		reserved := make(map[string]bool)
+		for _, signer := range signers {
+			if reserved[signer] {
+				continue
+			}
+			reserved[signer] = true
+			bucket, ok := l.accounts[signer]
+			if !ok {
+				bucket = newTokenBucket(l.config.accountRate, l.config.burst, now)
+				l.accounts[signer] = bucket
+			}
+			buckets = append(buckets, bucket)
+		}
+	}
+	var delay time.Duration
+	for _, bucket := range buckets {
+		if wait := bucket.reserve(now); wait > delay {
+			delay = wait
+		}
+	}
+	return delay, func() {
+		l.mux.Lock()
+		defer l.mux.Unlock()
+		for _, bucket := range buckets {
+			bucket.cancel()
+		}
+	}
+}
+
+// wait is a function to wait until a transaction of signers can be broadcast, it returns the time waited
+// Reserved tokens are given back when ctx is done before the wait ends.
+func (l *broadcastLimiter) wait(ctx context.Context, signers []string) (time.Duration, error) {
+	delay, cancel := l.reserve(signers)
+	if delay <= 0 {
+		return 0, nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return delay, nil
+	case <-ctx.Done():
+		cancel()
+		return 0, ctx.Err()
+	}
+}
+
+// txFileSigners is a function to get signer addresses of signed transaction file, nil when it's not decodable
+func txFileSigners(signedTxFile string) []string {
+	bz, err := ioutil.ReadFile(signedTxFile)
+	if err != nil {
+		return nil
+	}
+	tx, err := app.MakeEncodingConfig().TxConfig.TxJSONDecoder()(bz)
+	if err != nil {
+		return nil
+	}
+	return msgSigners(tx.GetMsgs())
+}
+
+// WaitForBroadcastRateLimit is a function to wait until signers can broadcast a transaction by -broadcast-rate and -account-broadcast-rate
+// It returns immediately when no rate is set, broadcasts of inttest wait by themselves.
+func WaitForBroadcastRateLimit(ctx context.Context, signers ...string) (time.Duration, error) {
+	limiter := getBroadcastLimiter()
+	if limiter == nil {
+		return 0, nil
+	}
+	waited, err := limiter.wait(ctx, signers)
+	if waited > 0 {
+		broadcastRateLimitWait.Observe(waited.Seconds())
+	}
+	return waited, err
+}
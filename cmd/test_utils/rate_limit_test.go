@@ -0,0 +1,43 @@
+package inttest
+
+import (
+	"context"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestBroadcastLimiter(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	now := time.Unix(1600000000, 0)
+	limiter := newBroadcastLimiter(broadcastLimiterConfig{rate: 10, accountRate: 1, burst: 2}, func() time.Time { return now })
+
+	delay, _ := limiter.reserve([]string{"alice"})
+	t.MustTrue(delay == 0, "first broadcast should be in burst")
+	delay, _ = limiter.reserve([]string{"alice", "alice"})
+	t.MustTrue(delay == 0, "signer should be limited once per transaction")
+	delay, cancel := limiter.reserve([]string{"alice"})
+	t.MustTrue(delay == time.Second, "third broadcast of account should wait for account rate")
+	cancel()
+	delay, _ = limiter.reserve([]string{"bob"})
+	t.MustTrue(delay == 100*time.Millisecond, "broadcast of another account should wait for global rate only")
+
+	now = now.Add(time.Second)
+	delay, _ = limiter.reserve([]string{"alice"})
+	t.MustTrue(delay == 0, "cancelled token should be given back and refilled after a second")
+
+	globalOnly := newBroadcastLimiter(broadcastLimiterConfig{rate: 1, burst: 1}, func() time.Time { return now })
+	delay, _ = globalOnly.reserve([]string{"alice"})
+	t.MustTrue(delay == 0 && len(globalOnly.accounts) == 0, "accounts should not be limited without account rate")
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+	_, err := globalOnly.wait(ctx, nil)
+	t.MustTrue(err == context.Canceled, "wait should end with ctx error")
+	delay, _ = globalOnly.reserve(nil)
+	t.MustTrue(delay == time.Second, "token of cancelled wait should be given back")
+
+	t.MustTrue(getBroadcastLimiter() == nil, "no limiter should be used without rates")
+}
@@ -0,0 +1,161 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// ReadOnlyClient is a struct to query node without keyring, GOPATH and pylonsd binary
+// It has only the query surface of the SDK, so it can be used from lightweight services and dashboards.
+type ReadOnlyClient struct {
+	transport Transport
+	pylons    types.QueryClient
+}
+
+// pylonsQuerier is an interface of transports having pylons grpc query client
+type pylonsQuerier interface {
+	pylonsQueryClient() types.QueryClient
+}
+
+// pylonsQueryClient is a function to get pylons query client of the connection
+func (t queryClientTransport) pylonsQueryClient() types.QueryClient {
+	return t.pylons
+}
+
+// NewReadOnlyClient is a function to create read only client querying tendermint rpc of node e.g. tcp://localhost:26657
+func NewReadOnlyClient(node string) (*ReadOnlyClient, error) {
+	transport, err := newRPCTransport(node)
+	if err != nil {
+		return nil, err
+	}
+	return newReadOnlyClient(transport), nil
+}
+
+// NewReadOnlyGRPCClient is a function to create read only client querying grpc endpoint of node e.g. localhost:9090
+func NewReadOnlyGRPCClient(endpoint string) (*ReadOnlyClient, error) {
+	transport, err := newGRPCTransport(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return newReadOnlyClient(transport), nil
+}
+
+// newReadOnlyClient is a function to create read only client of transport, requests are traced by transport hooks
+func newReadOnlyClient(transport Transport) *ReadOnlyClient {
+	return &ReadOnlyClient{
+		transport: hookedTransport{Transport: transport},
+		pylons:    transport.(pylonsQuerier).pylonsQueryClient(),
+	}
+}
+
+// convertQueryResponse is a function to convert query response into the type of pylons state it describes
+// Cookbook, recipe and trade responses have json fields of state types as cli query output is decoded into state types.
+func convertQueryResponse(res proto.Message, out proto.Message) error {
+	marshaler := GetJSONMarshaler()
+	bz, err := marshaler.MarshalJSON(res)
+	if err != nil {
+		return err
+	}
+	return marshaler.UnmarshalJSON(bz, out)
+}
+
+// LatestHeight is a function to get latest block height of node
+func (c *ReadOnlyClient) LatestHeight(ctx context.Context) (int64, error) {
+	return c.transport.LatestHeight(ctx)
+}
+
+// Tx is a function to get result of committed transaction
+func (c *ReadOnlyClient) Tx(ctx context.Context, txhash string) (TxResult, error) {
+	return c.transport.Tx(ctx, txhash)
+}
+
+// Account is a function to get account of address
+func (c *ReadOnlyClient) Account(ctx context.Context, addr string) (authtypes.AccountI, error) {
+	return c.transport.Account(ctx, addr)
+}
+
+// Balances is a function to get all balances of address
+func (c *ReadOnlyClient) Balances(ctx context.Context, addr string) (sdk.Coins, error) {
+	return c.transport.Balances(ctx, addr)
+}
+
+// Cookbooks is a function to list cookbooks of address, all cookbooks when address is empty
+func (c *ReadOnlyClient) Cookbooks(ctx context.Context, addr string) ([]types.Cookbook, error) {
+	return c.transport.ListCookbooks(ctx, addr)
+}
+
+// Recipes is a function to list recipes of address, all recipes when address is empty
+func (c *ReadOnlyClient) Recipes(ctx context.Context, addr string) ([]types.Recipe, error) {
+	return c.transport.ListRecipes(ctx, addr)
+}
+
+// Trades is a function to list trades of address, all trades when address is empty
+func (c *ReadOnlyClient) Trades(ctx context.Context, addr string) ([]types.Trade, error) {
+	return c.transport.ListTrades(ctx, addr)
+}
+
+// Executions is a function to list executions of sender, all executions when sender is empty
+func (c *ReadOnlyClient) Executions(ctx context.Context, sender string) ([]types.Execution, error) {
+	return c.transport.ListExecutions(ctx, sender)
+}
+
+// Items is a function to list items of sender, all items when sender is empty
+func (c *ReadOnlyClient) Items(ctx context.Context, sender string) ([]types.Item, error) {
+	return c.transport.ItemsBySender(ctx, sender)
+}
+
+// Cookbook is a function to get cookbook by id
+func (c *ReadOnlyClient) Cookbook(ctx context.Context, id string) (types.Cookbook, error) {
+	cookbook := types.Cookbook{}
+	res, err := c.pylons.GetCookbook(ctx, &types.GetCookbookRequest{CookbookID: id})
+	if err != nil {
+		return cookbook, fmt.Errorf("error getting cookbook %s: %w", id, err)
+	}
+	err = convertQueryResponse(res, &cookbook)
+	return cookbook, err
+}
+
+// Recipe is a function to get recipe by id
+func (c *ReadOnlyClient) Recipe(ctx context.Context, id string) (types.Recipe, error) {
+	recipe := types.Recipe{}
+	res, err := c.pylons.GetRecipe(ctx, &types.GetRecipeRequest{RecipeID: id})
+	if err != nil {
+		return recipe, fmt.Errorf("error getting recipe %s: %w", id, err)
+	}
+	err = convertQueryResponse(res, &recipe)
+	return recipe, err
+}
+
+// Item is a function to get item by id
+func (c *ReadOnlyClient) Item(ctx context.Context, id string) (types.Item, error) {
+	res, err := c.pylons.GetItem(ctx, &types.GetItemRequest{ItemID: id})
+	if err != nil {
+		return types.Item{}, fmt.Errorf("error getting item %s: %w", id, err)
+	}
+	return res.Item, nil
+}
+
+// Trade is a function to get trade by id
+func (c *ReadOnlyClient) Trade(ctx context.Context, id string) (types.Trade, error) {
+	trade := types.Trade{}
+	res, err := c.pylons.GetTrade(ctx, &types.GetTradeRequest{TradeID: id})
+	if err != nil {
+		return trade, fmt.Errorf("error getting trade %s: %w", id, err)
+	}
+	err = convertQueryResponse(res, &trade)
+	return trade, err
+}
+
+// Execution is a function to get execution by id
+func (c *ReadOnlyClient) Execution(ctx context.Context, id string) (types.GetExecutionResponse, error) {
+	res, err := c.pylons.GetExecution(ctx, &types.GetExecutionRequest{ExecutionID: id})
+	if err != nil {
+		return types.GetExecutionResponse{}, fmt.Errorf("error getting execution %s: %w", id, err)
+	}
+	return *res, nil
+}
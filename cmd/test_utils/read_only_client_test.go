@@ -0,0 +1,54 @@
+package inttest
+
+import (
+	"context"
+	"net"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"google.golang.org/grpc"
+)
+
+type readOnlyQueryServer struct {
+	types.UnimplementedQueryServer
+}
+
+func (readOnlyQueryServer) GetCookbook(ctx context.Context, req *types.GetCookbookRequest) (*types.GetCookbookResponse, error) {
+	return &types.GetCookbookResponse{ID: req.CookbookID, Name: "Legend of Undead Dragon", Level: 1, Sender: "cosmos1devnet"}, nil
+}
+
+func (readOnlyQueryServer) ListCookbook(ctx context.Context, req *types.ListCookbookRequest) (*types.ListCookbookResponse, error) {
+	return &types.ListCookbookResponse{Cookbooks: []types.Cookbook{{ID: "LOUD", Sender: req.Address}}}, nil
+}
+
+func TestReadOnlyClient(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	t.MustNil(err, "error listening grpc endpoint")
+	server := grpc.NewServer()
+	types.RegisterQueryServer(server, &readOnlyQueryServer{})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	client, err := NewReadOnlyGRPCClient(listener.Addr().String())
+	t.MustNil(err, "read only client should be created without keyring and pylonsd")
+	_, canBroadcast := interface{}(client).(interface {
+		Broadcast(ctx context.Context, txBytes []byte) error
+	})
+	t.MustTrue(!canBroadcast, "read only client should not broadcast")
+
+	cookbook, err := client.Cookbook(context.Background(), "LOUD")
+	t.MustNil(err, "cookbook should be queried")
+	t.MustTrue(cookbook.ID == "LOUD" && cookbook.Name == "Legend of Undead Dragon" && cookbook.Level == 1, "cookbook response should be converted")
+
+	cookbooks, err := client.Cookbooks(context.Background(), "cosmos1devnet")
+	t.MustNil(err, "cookbooks should be listed")
+	t.MustTrue(len(cookbooks) == 1 && cookbooks[0].Sender == "cosmos1devnet", "cookbooks of address should be listed")
+
+	_, err = client.Recipe(context.Background(), "unknown")
+	t.MustTrue(err != nil, "unimplemented query should fail")
+}
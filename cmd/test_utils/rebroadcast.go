@@ -0,0 +1,136 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ErrTxDropped is returned when a transaction is dropped from mempool without being committed more times than re-broadcast
+var ErrTxDropped = errors.New("transaction is dropped from mempool")
+
+// droppedTxChecks is the number of consecutive checks a transaction should be missing from mempool and chain to be treated as dropped
+// A single miss can be a transaction committed between the chain query and the mempool query.
+const droppedTxChecks = 2
+
+// watchedTx is a struct to keep signed transaction bytes to broadcast again with the same sequence
+type watchedTx struct {
+	mux          sync.Mutex
+	txBytes      []byte
+	missing      int
+	rebroadcasts int
+}
+
+// txWatchdog is a struct to detect transactions dropped from mempool while they're waited and to broadcast them again
+type txWatchdog struct {
+	mux       sync.Mutex
+	txs       map[string]*watchedTx
+	status    func(ctx context.Context, txhash string) (TxMempoolStatus, error)
+	broadcast func(ctx context.Context, txBytes []byte) (sdk.TxResponse, error)
+}
+
+var droppedTxWatchdog = &txWatchdog{
+	txs:    make(map[string]*watchedTx),
+	status: GetTxMempoolStatus,
+	broadcast: func(ctx context.Context, txBytes []byte) (sdk.TxResponse, error) {
+		transport, err := EnvFromContext(ctx).Transport()
+		if err != nil {
+			return sdk.TxResponse{}, err
+		}
+		return transport.Broadcast(ctx, txBytes, BroadcastModeSync)
+	},
+}
+
+// watchTxFile is a function to keep signed transaction file of txhash for re-broadcast, it's no-op when re-broadcast
+// is disabled by retry policy of env of ctx
+func watchTxFile(ctx context.Context, txhash string, signedTxFile string) {
+	if EnvFromContext(ctx).RetryPolicy().MaxRebroadcasts <= 0 || len(txhash) == 0 {
+		return
+	}
+	bz, err := ioutil.ReadFile(signedTxFile)
+	if err != nil {
+		return
+	}
+	txConfig := app.MakeEncodingConfig().TxConfig
+	tx, err := txConfig.TxJSONDecoder()(bz)
+	if err != nil {
+		return
+	}
+	txBytes, err := txConfig.TxEncoder()(tx)
+	if err != nil {
+		return
+	}
+	droppedTxWatchdog.watch(txhash, txBytes)
+}
+
+func (w *txWatchdog) watch(txhash string, txBytes []byte) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.txs[strings.ToUpper(txhash)] = &watchedTx{txBytes: txBytes}
+}
+
+func (w *txWatchdog) forget(txhash string) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	delete(w.txs, strings.ToUpper(txhash))
+}
+
+// check is a function to broadcast transaction again with the same signature when it's dropped from mempool
+// It returns true when the transaction is broadcast again, and ErrTxDropped when it's dropped after policy.MaxRebroadcasts.
+// Error of the transaction is returned when it's rejected on re-broadcast e.g. by sequence mismatch.
+// Transactions which are not watched e.g. broadcast by other processes are not checked.
+func (w *txWatchdog) check(ctx context.Context, t *testing.T, policy RetryPolicy, txhash string) (bool, error) {
+	w.mux.Lock()
+	tx, ok := w.txs[strings.ToUpper(txhash)]
+	w.mux.Unlock()
+	if !ok {
+		return false, nil
+	}
+	tx.mux.Lock()
+	defer tx.mux.Unlock()
+	status, err := w.status(ctx, txhash)
+	if err != nil {
+		// mempool can't be inspected for now, the transaction is checked again on next poll
+		t.WithFields(testing.Fields{
+			"txhash": txhash,
+			"error":  err,
+		}).Debug("error checking if tx is dropped")
+		return false, nil
+	}
+	if status != TxNotFound {
+		tx.missing = 0
+		return false, nil
+	}
+	tx.missing++
+	if tx.missing < droppedTxChecks {
+		return false, nil
+	}
+	if tx.rebroadcasts >= policy.MaxRebroadcasts {
+		return false, fmt.Errorf("%w: tx %s after %d re-broadcasts", ErrTxDropped, txhash, tx.rebroadcasts)
+	}
+	tx.missing = 0
+	tx.rebroadcasts++
+	txResponse, err := w.broadcast(ctx, tx.txBytes)
+	t.WithFields(testing.Fields{
+		"txhash":           txhash,
+		"rebroadcast":      tx.rebroadcasts,
+		"max_rebroadcasts": policy.MaxRebroadcasts,
+		"raw_log":          txResponse.RawLog,
+		"error":            err,
+	}).Warn("re-broadcasting tx dropped from mempool")
+	if err != nil {
+		// node can be restarting, re-broadcast is counted and tried again when it's still missing
+		return true, nil
+	}
+	if txResponse.Code != 0 {
+		return false, fmt.Errorf("tx %s dropped from mempool is rejected on re-broadcast: %w", txhash, NewTxError(txResponse.Codespace, txResponse.Code, txResponse.RawLog))
+	}
+	return true, nil
+}
@@ -0,0 +1,58 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestDroppedTxWatchdog(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	status := TxInMempool
+	broadcasts := [][]byte{}
+	broadcastResponse := sdk.TxResponse{}
+	watchdog := &txWatchdog{
+		txs: make(map[string]*watchedTx),
+		status: func(ctx context.Context, txhash string) (TxMempoolStatus, error) {
+			return status, nil
+		},
+		broadcast: func(ctx context.Context, txBytes []byte) (sdk.TxResponse, error) {
+			broadcasts = append(broadcasts, txBytes)
+			return broadcastResponse, nil
+		},
+	}
+	policy := RetryPolicy{MaxRebroadcasts: 1}
+	check := func() (bool, error) {
+		return watchdog.check(context.Background(), &t, policy, "abcd")
+	}
+
+	rebroadcast, err := check()
+	t.MustTrue(!rebroadcast && err == nil, "unwatched tx should not be checked")
+	watchdog.watch("ABCD", []byte("signed tx"))
+
+	rebroadcast, err = check()
+	t.MustTrue(!rebroadcast && err == nil, "tx in mempool should not be broadcast again")
+	status = TxNotFound
+	rebroadcast, err = check()
+	t.MustTrue(!rebroadcast && err == nil, "tx should be missing on consecutive checks to be dropped")
+	rebroadcast, err = check()
+	t.MustNil(err, "error re-broadcasting dropped tx")
+	t.MustTrue(rebroadcast && len(broadcasts) == 1 && string(broadcasts[0]) == "signed tx", "dropped tx should be broadcast again with the same bytes")
+
+	check()
+	_, err = check()
+	t.MustTrue(errors.Is(err, ErrTxDropped), "tx dropped after max re-broadcasts should fail")
+
+	watchdog.watch("ABCD", []byte("signed tx"))
+	broadcastResponse = sdk.TxResponse{Code: 32, Codespace: "sdk", RawLog: "account sequence mismatch"}
+	check()
+	_, err = check()
+	t.MustTrue(errors.Is(err, ErrSequenceMismatch), "rejected re-broadcast should fail with its error class")
+
+	watchdog.forget("abcd")
+	t.MustTrue(len(watchdog.txs) == 0, "forgotten tx should not be watched")
+}
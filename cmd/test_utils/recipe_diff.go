@@ -0,0 +1,259 @@
+package inttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// describes sections of recipe definition compared by CompareRecipe
+const (
+	RecipeSectionRecipe     = "recipe"
+	RecipeSectionCoinInputs = "coin_inputs"
+	RecipeSectionItemInputs = "item_inputs"
+	RecipeSectionEntries    = "entries"
+	RecipeSectionOutputs    = "outputs"
+	RecipeSectionPrograms   = "programs" // programs of entries
+)
+
+// recipeSections are sections of recipe fields, other fields are in RecipeSectionRecipe
+var recipeSections = map[string]string{
+	"CoinInputs": RecipeSectionCoinInputs,
+	"ItemInputs": RecipeSectionItemInputs,
+	"Entries":    RecipeSectionEntries,
+	"Outputs":    RecipeSectionOutputs,
+}
+
+// recipeStateFields are fields of recipe which are not part of its definition
+var recipeStateFields = []string{"NodeVersion", "ID", "Sender", "Disabled"}
+
+// RecipeFieldChange is a struct to describe a field of recipe definition which differs between local and chain recipe
+// Change is StateAdded when field is only in local recipe and StateRemoved when it's only on chain.
+type RecipeFieldChange struct {
+	Section string `json:"section"`
+	Path    string `json:"path"`
+	Change  string `json:"change"`
+	Local   string `json:"local,omitempty"`
+	Chain   string `json:"chain,omitempty"`
+}
+
+// String is a function to get readable description of recipe field change
+func (c RecipeFieldChange) String() string {
+	switch c.Change {
+	case StateAdded:
+		return fmt.Sprintf("%s %s only in local recipe: %s", c.Section, c.Path, c.Local)
+	case StateRemoved:
+		return fmt.Sprintf("%s %s only on chain: %s", c.Section, c.Path, c.Chain)
+	default:
+		return fmt.Sprintf("%s %s: local %s, chain %s", c.Section, c.Path, c.Local, c.Chain)
+	}
+}
+
+// RecipeDiff is a struct to describe differences of local recipe definition from recipe on chain sorted by section and path
+type RecipeDiff struct {
+	RecipeID string              `json:"recipe_id"`
+	Changes  []RecipeFieldChange `json:"changes"`
+}
+
+// Equal is a function to check if local and chain recipe definitions are same
+func (d RecipeDiff) Equal() bool {
+	return len(d.Changes) == 0
+}
+
+// Section is a function to get changes of a section e.g. RecipeSectionEntries
+func (d RecipeDiff) Section(section string) []RecipeFieldChange {
+	changes := []RecipeFieldChange{}
+	for _, change := range d.Changes {
+		if change.Section == section {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// String is a function to get readable description of recipe diff
+func (d RecipeDiff) String() string {
+	if d.Equal() {
+		return fmt.Sprintf("recipe %s has no difference", d.RecipeID)
+	}
+	lines := []string{fmt.Sprintf("recipe %s has %d differences", d.RecipeID, len(d.Changes))}
+	for _, change := range d.Changes {
+		lines = append(lines, change.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CompareRecipe is a function to compare local recipe definition e.g. of fixture with recipe of chainRecipeID
+func CompareRecipe(local types.MsgCreateRecipe, chainRecipeID string) (RecipeDiff, error) {
+	chain, err := GetRecipeByGUID(chainRecipeID)
+	if err != nil {
+		return RecipeDiff{RecipeID: chainRecipeID}, fmt.Errorf("error getting recipe %s: %w", chainRecipeID, err)
+	}
+	return DiffRecipe(local, chain)
+}
+
+// DiffRecipe is a function to compare local recipe definition with chain recipe
+// Id, sender and disabled state are not compared, list elements having ID or Key are matched by it instead of position.
+func DiffRecipe(local types.MsgCreateRecipe, chain types.Recipe) (RecipeDiff, error) {
+	diff := RecipeDiff{RecipeID: chain.ID, Changes: []RecipeFieldChange{}}
+	localFields, err := recipeDefinition(recipeOfMsg(local))
+	if err != nil {
+		return diff, err
+	}
+	chainFields, err := recipeDefinition(chain)
+	if err != nil {
+		return diff, err
+	}
+	diffRecipeValues("", localFields, chainFields, &diff.Changes)
+	for idx := range diff.Changes {
+		diff.Changes[idx].Section = recipeSection(diff.Changes[idx].Path)
+	}
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		if diff.Changes[i].Section != diff.Changes[j].Section {
+			return diff.Changes[i].Section < diff.Changes[j].Section
+		}
+		return diff.Changes[i].Path < diff.Changes[j].Path
+	})
+	return diff, nil
+}
+
+// recipeDefinition is a function to get fields of recipe definition as generic json values
+func recipeDefinition(recipe types.Recipe) (map[string]interface{}, error) {
+	bz, err := GetJSONMarshaler().MarshalJSON(&recipe)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err = json.Unmarshal(bz, &fields); err != nil {
+		return nil, err
+	}
+	for _, field := range recipeStateFields {
+		delete(fields, field)
+	}
+	return fields, nil
+}
+
+// recipeSection is a function to get section of recipe field path
+func recipeSection(path string) string {
+	root := strings.SplitN(strings.SplitN(path, ".", 2)[0], "[", 2)[0]
+	section, ok := recipeSections[root]
+	if !ok {
+		return RecipeSectionRecipe
+	}
+	if section == RecipeSectionEntries && strings.HasSuffix(path, ".Program") {
+		return RecipeSectionPrograms
+	}
+	return section
+}
+
+// isEmptyJSONValue is a function to check if json value is omitted or default so that it's same as missing field
+func isEmptyJSONValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(v) == 0 || v == "0"
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// jsonValueString is a function to render json value of a change
+func jsonValueString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	bz, _ := json.Marshal(value)
+	return string(bz)
+}
+
+// elementKey is a function to get key of list element, entries and inputs are keyed by ID and item params by Key
+func elementKey(value interface{}, idx int) string {
+	if obj, ok := value.(map[string]interface{}); ok {
+		for _, field := range []string{"ID", "Key"} {
+			if id, ok := obj[field].(string); ok && len(id) > 0 {
+				return id
+			}
+		}
+	}
+	return fmt.Sprint(idx)
+}
+
+// diffRecipeValues is a function to collect changes between local and chain json values of path
+func diffRecipeValues(path string, local, chain interface{}, changes *[]RecipeFieldChange) {
+	localList, localIsList := local.([]interface{})
+	chainList, chainIsList := chain.([]interface{})
+	// elements of list only on one side are reported one by one
+	if (localIsList || chainIsList) && (localIsList || isEmptyJSONValue(local)) && (chainIsList || isEmptyJSONValue(chain)) {
+		diffRecipeLists(path, localList, chainList, changes)
+		return
+	}
+	switch {
+	case isEmptyJSONValue(local) && isEmptyJSONValue(chain):
+		return
+	case isEmptyJSONValue(chain):
+		*changes = append(*changes, RecipeFieldChange{Path: path, Change: StateAdded, Local: jsonValueString(local)})
+		return
+	case isEmptyJSONValue(local):
+		*changes = append(*changes, RecipeFieldChange{Path: path, Change: StateRemoved, Chain: jsonValueString(chain)})
+		return
+	}
+	localMap, localIsMap := local.(map[string]interface{})
+	chainMap, chainIsMap := chain.(map[string]interface{})
+	if localIsMap && chainIsMap {
+		keys := map[string]bool{}
+		for key := range localMap {
+			keys[key] = true
+		}
+		for key := range chainMap {
+			keys[key] = true
+		}
+		for key := range keys {
+			diffRecipeValues(joinFieldPath(path, key), localMap[key], chainMap[key], changes)
+		}
+		return
+	}
+	if jsonValueString(local) != jsonValueString(chain) {
+		*changes = append(*changes, RecipeFieldChange{Path: path, Change: StateModified, Local: jsonValueString(local), Chain: jsonValueString(chain)})
+	}
+}
+
+// diffRecipeLists is a function to collect changes between elements of local and chain lists matched by elementKey
+func diffRecipeLists(path string, local, chain []interface{}, changes *[]RecipeFieldChange) {
+	localElements := map[string]interface{}{}
+	keys := []string{}
+	for idx, element := range local {
+		key := elementKey(element, idx)
+		localElements[key] = element
+		keys = append(keys, key)
+	}
+	chainElements := map[string]interface{}{}
+	for idx, element := range chain {
+		key := elementKey(element, idx)
+		chainElements[key] = element
+		if _, ok := localElements[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		diffRecipeValues(fmt.Sprintf("%s[%s]", path, key), localElements[key], chainElements[key], changes)
+	}
+}
+
+// joinFieldPath is a function to get path of field of object at path
+func joinFieldPath(path, field string) string {
+	if len(path) == 0 {
+		return field
+	}
+	return path + "." + field
+}
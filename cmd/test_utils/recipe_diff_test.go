@@ -0,0 +1,67 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestDiffRecipe(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	swordOutput := func(program string) types.ItemOutput {
+		return types.ItemOutput{
+			ID: "sword",
+			Longs: []types.LongParam{
+				{Key: "level", Rate: sdk.OneDec(), Program: program},
+			},
+		}
+	}
+	local := types.NewMsgCreateRecipe("LOUD's make sword recipe", "LOUD", "", "make sword recipe",
+		types.CoinInputList{{Coin: "loudcoin", Count: 120}},
+		types.ItemInputList{},
+		types.EntriesList{
+			CoinOutputs: []types.CoinOutput{{ID: "coin", Coin: "loudcoin", Count: "1"}},
+			ItemOutputs: []types.ItemOutput{swordOutput("level + 1")},
+		},
+		types.WeightedOutputsList{{EntryIDs: []string{"sword"}, Weight: "1"}},
+		0, "cosmos1local")
+	chain := types.Recipe{
+		ID:          "LOUD-make-sword",
+		CookbookID:  "LOUD",
+		Name:        "LOUD's make sword recipe",
+		Description: "make sword recipe",
+		CoinInputs:  types.CoinInputList{{Coin: "loudcoin", Count: 100}},
+		ItemInputs:  types.ItemInputList{{ID: "shield"}},
+		Entries: types.EntriesList{
+			// entries are matched by ID regardless of their order
+			ItemOutputs: []types.ItemOutput{swordOutput("level")},
+			CoinOutputs: []types.CoinOutput{{ID: "coin", Coin: "loudcoin", Count: "1"}},
+		},
+		Outputs: types.WeightedOutputsList{{EntryIDs: []string{"sword"}, Weight: "1"}},
+		Sender:  "cosmos1chain",
+	}
+
+	diff, err := DiffRecipe(local, chain)
+	t.MustNil(err, "recipes should be compared")
+	t.WithFields(testing.Fields{
+		"diff": diff.String(),
+	}).MustTrue(len(diff.Changes) == 3, "only definition changes should be found")
+
+	coinInputs := diff.Section(RecipeSectionCoinInputs)
+	t.MustTrue(len(coinInputs) == 1 && coinInputs[0].Change == StateModified && coinInputs[0].Local == "120" && coinInputs[0].Chain == "100", "coin input count change should be found")
+	itemInputs := diff.Section(RecipeSectionItemInputs)
+	t.MustTrue(len(itemInputs) == 1 && itemInputs[0].Change == StateRemoved && itemInputs[0].Path == "ItemInputs[shield]", "item input only on chain should be found")
+	programs := diff.Section(RecipeSectionPrograms)
+	t.MustTrue(len(programs) == 1 && programs[0].Path == "Entries.ItemOutputs[sword].Longs[level].Program" && programs[0].Local == "level + 1", "program change should be found")
+	t.MustTrue(len(diff.Section(RecipeSectionEntries)) == 0 && len(diff.Section(RecipeSectionOutputs)) == 0, "same entries and outputs should not be changes")
+
+	chain.CoinInputs = local.CoinInputs
+	chain.ItemInputs = nil
+	chain.Entries = local.Entries
+	diff, err = DiffRecipe(local, chain)
+	t.MustNil(err, "recipes should be compared")
+	t.MustTrue(diff.Equal(), "same recipe definitions should be equal")
+}
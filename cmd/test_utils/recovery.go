@@ -0,0 +1,250 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// AccountRecoverySnapshot is a struct to manage account state which should survive key recovery
+type AccountRecoverySnapshot struct {
+	Key            string
+	Address        string
+	Mnemonic       string
+	CookbookIDs    []string
+	ItemIDs        []string
+	ExecIDs        []string
+	PendingExecIDs []string
+}
+
+// DeleteLocalKey is a function to delete key from keyring to simulate key loss
+func DeleteLocalKey(key string) error {
+	if len(key) == 0 {
+		return errors.New("key is empty")
+	}
+	_, logstr, err := Keys().Delete(key).Run(context.Background())
+	if err != nil {
+		return fmt.Errorf("%s: %w", logstr, err)
+	}
+	return nil
+}
+
+// RestoreLocalKey is a function to restore key into keyring from mnemonic
+func RestoreLocalKey(key, mnemonic string) (map[string]string, error) {
+	return restoreLocalKey(GetKeyringProvider(), key, mnemonic)
+}
+
+func restoreLocalKey(provider KeyringProvider, key, mnemonic string) (map[string]string, error) {
+	result := make(map[string]string)
+	if len(key) == 0 {
+		return result, errors.New("key is empty")
+	}
+	if len(mnemonic) == 0 {
+		return result, errors.New("mnemonic is empty")
+	}
+	RegisterSecret(mnemonic)
+	output, logstr, err := Keys().Recover(key, mnemonic).WithKeyring(provider).Run(context.Background())
+	if err != nil {
+		result["logstr"] = logstr
+		result["output"] = string(output)
+		return result, err
+	}
+	if err = json.Unmarshal(output, &result); err != nil {
+		return result, err
+	}
+	rememberMnemonic(provider, key, mnemonic)
+	return result, nil
+}
+
+// GetPendingExecutionIDs is a function to get IDs of executions of sender which are not completed yet
+func GetPendingExecutionIDs(execs []types.Execution, sender string) []string {
+	execIDs := []string{}
+	for _, exec := range execs {
+		if exec.Sender == sender && !exec.Completed {
+			execIDs = append(execIDs, exec.ID)
+		}
+	}
+	return execIDs
+}
+
+// GetExecutionIDs is a function to get IDs of executions of sender
+func GetExecutionIDs(execs []types.Execution, sender string) []string {
+	execIDs := []string{}
+	for _, exec := range execs {
+		if exec.Sender == sender {
+			execIDs = append(execIDs, exec.ID)
+		}
+	}
+	return execIDs
+}
+
+// GetOwnedCookbookIDs is a function to get IDs of cookbooks owned by sender
+func GetOwnedCookbookIDs(cookbooks []types.Cookbook, sender string) []string {
+	cbIDs := []string{}
+	for _, cb := range cookbooks {
+		if cb.Sender == sender {
+			cbIDs = append(cbIDs, cb.ID)
+		}
+	}
+	return cbIDs
+}
+
+// TakeAccountRecoverySnapshot is a function to record cookbooks, items and executions owned by key before recovery
+func TakeAccountRecoverySnapshot(key, mnemonic string, t *testing.T) (AccountRecoverySnapshot, error) {
+	snapshot := AccountRecoverySnapshot{
+		Key:      key,
+		Address:  GetAccountAddr(key, t),
+		Mnemonic: mnemonic,
+	}
+	cookbooks, err := ListCookbookViaCLI(snapshot.Address)
+	if err != nil {
+		return snapshot, err
+	}
+	snapshot.CookbookIDs = GetOwnedCookbookIDs(cookbooks, snapshot.Address)
+	items, err := ListItemsViaCLI(snapshot.Address)
+	if err != nil {
+		return snapshot, err
+	}
+	for _, item := range items {
+		snapshot.ItemIDs = append(snapshot.ItemIDs, item.ID)
+	}
+	execs, err := ListExecutionsViaCLI(snapshot.Address, t)
+	if err != nil {
+		return snapshot, err
+	}
+	snapshot.ExecIDs = GetExecutionIDs(execs, snapshot.Address)
+	snapshot.PendingExecIDs = GetPendingExecutionIDs(execs, snapshot.Address)
+	return snapshot, nil
+}
+
+// RecoverAccountFromMnemonic is a function to delete key from keyring and restore it from mnemonic
+// It checks the key is really lost before restore and the restored key resolves to the same address
+func RecoverAccountFromMnemonic(snapshot AccountRecoverySnapshot, t *testing.T) error {
+	if err := DeleteLocalKey(snapshot.Key); err != nil {
+		return err
+	}
+	if _, _, err := Keys().Show(snapshot.Key, true).Run(context.Background()); err == nil {
+		return fmt.Errorf("key %s still exists after delete", snapshot.Key)
+	}
+	restored, err := RestoreLocalKey(snapshot.Key, snapshot.Mnemonic)
+	if err != nil {
+		return fmt.Errorf("error restoring key %s: %w", snapshot.Key, err)
+	}
+	if restored["address"] != snapshot.Address {
+		return fmt.Errorf("restored key address is %s, expected %s", restored["address"], snapshot.Address)
+	}
+	t.WithFields(testing.Fields{
+		"key":     snapshot.Key,
+		"address": snapshot.Address,
+	}).Info("restored key from mnemonic")
+	return nil
+}
+
+// VerifyRecoveredAccount is a function to check restored account still owns cookbooks, items and executions,
+// pending executions are not completed and restored key signs for the account
+func VerifyRecoveredAccount(snapshot AccountRecoverySnapshot, t *testing.T) error {
+	for _, cbID := range snapshot.CookbookIDs {
+		cb, err := GetCookbookByGUID(cbID)
+		if err != nil {
+			return err
+		}
+		if cb.Sender != snapshot.Address {
+			return fmt.Errorf("cookbook %s is owned by %s after recovery, expected %s", cbID, cb.Sender, snapshot.Address)
+		}
+	}
+	for _, itemID := range snapshot.ItemIDs {
+		item, err := GetItemByGUID(itemID)
+		if err != nil {
+			return err
+		}
+		if item.Sender != snapshot.Address {
+			return fmt.Errorf("item %s is owned by %s after recovery, expected %s", itemID, item.Sender, snapshot.Address)
+		}
+	}
+	for _, execID := range snapshot.ExecIDs {
+		exec, err := GetExecutionByGUID(execID)
+		if err != nil {
+			return err
+		}
+		if exec.Sender != snapshot.Address {
+			return fmt.Errorf("execution %s is owned by %s after recovery, expected %s", execID, exec.Sender, snapshot.Address)
+		}
+	}
+	for _, execID := range snapshot.PendingExecIDs {
+		exec, err := GetExecutionByGUID(execID)
+		if err != nil {
+			return err
+		}
+		if exec.Completed {
+			return fmt.Errorf("execution %s is completed during recovery", execID)
+		}
+	}
+	// ExportSignDoc verifies pylonsd signature of restored key against account pubkey on chain
+	checkMsg := types.NewMsgCheckExecution("", false, snapshot.Address)
+	if _, err := ExportSignDoc(t, []sdk.Msg{&checkMsg}, snapshot.Key, signing.SignMode_SIGN_MODE_DIRECT); err != nil {
+		return fmt.Errorf("restored key can't sign for account: %w", err)
+	}
+	t.WithFields(testing.Fields{
+		"key":              snapshot.Key,
+		"cookbook_ids":     snapshot.CookbookIDs,
+		"item_ids":         snapshot.ItemIDs,
+		"exec_ids":         snapshot.ExecIDs,
+		"pending_exec_ids": snapshot.PendingExecIDs,
+	}).Info("verified recovered account")
+	return nil
+}
+
+// RunAccountRecoveryFlow is a function to simulate key loss, restore key from mnemonic
+// and verify the restored account still owns its cookbooks, items and executions
+func RunAccountRecoveryFlow(key, mnemonic string, t *testing.T) (AccountRecoverySnapshot, error) {
+	snapshot, err := TakeAccountRecoverySnapshot(key, mnemonic, t)
+	if err != nil {
+		return snapshot, err
+	}
+	if err = RecoverAccountFromMnemonic(snapshot, t); err != nil {
+		return snapshot, err
+	}
+	return snapshot, VerifyRecoveredAccount(snapshot, t)
+}
+
+// MigrateAccountToKey is a function to restore account of snapshot from mnemonic under another key name
+// as a user moving to a new device does, the old key is kept and the returned snapshot refers to the new key
+func MigrateAccountToKey(snapshot AccountRecoverySnapshot, newKey string, t *testing.T) (AccountRecoverySnapshot, error) {
+	if newKey == snapshot.Key {
+		return snapshot, fmt.Errorf("key %s is migrated to itself", newKey)
+	}
+	restored, err := RestoreLocalKey(newKey, snapshot.Mnemonic)
+	if err != nil {
+		return snapshot, fmt.Errorf("error restoring key %s: %w", newKey, err)
+	}
+	if restored["address"] != snapshot.Address {
+		return snapshot, fmt.Errorf("migrated key address is %s, expected %s", restored["address"], snapshot.Address)
+	}
+	migrated := snapshot
+	migrated.Key = newKey
+	t.WithFields(testing.Fields{
+		"key":     snapshot.Key,
+		"new_key": newKey,
+		"address": snapshot.Address,
+	}).Info("migrated account to new key")
+	return migrated, nil
+}
+
+// RunAccountMigrationFlow is a function to migrate account of key to newKey from mnemonic
+// and verify the new key still owns cookbooks, items and executions of the account
+func RunAccountMigrationFlow(key, newKey, mnemonic string, t *testing.T) (AccountRecoverySnapshot, error) {
+	snapshot, err := TakeAccountRecoverySnapshot(key, mnemonic, t)
+	if err != nil {
+		return snapshot, err
+	}
+	if snapshot, err = MigrateAccountToKey(snapshot, newKey, t); err != nil {
+		return snapshot, err
+	}
+	return snapshot, VerifyRecoveredAccount(snapshot, t)
+}
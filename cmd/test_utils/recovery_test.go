@@ -0,0 +1,46 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+func TestGetPendingExecutionIDs(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	execs := []types.Execution{
+		{ID: "exec1", Sender: "owner", Completed: false},
+		{ID: "exec2", Sender: "owner", Completed: true},
+		{ID: "exec3", Sender: "other", Completed: false},
+		{ID: "exec4", Sender: "owner", Completed: false},
+	}
+	execIDs := GetPendingExecutionIDs(execs, "owner")
+	t.WithFields(testing.Fields{
+		"exec_ids": execIDs,
+	}).MustTrue(len(execIDs) == 2 && execIDs[0] == "exec1" && execIDs[1] == "exec4", "only pending executions of sender should be returned")
+}
+
+func TestGetOwnedIDs(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	execs := []types.Execution{
+		{ID: "exec1", Sender: "owner", Completed: true},
+		{ID: "exec2", Sender: "other", Completed: false},
+		{ID: "exec3", Sender: "owner", Completed: false},
+	}
+	execIDs := GetExecutionIDs(execs, "owner")
+	t.WithFields(testing.Fields{
+		"exec_ids": execIDs,
+	}).MustTrue(len(execIDs) == 2 && execIDs[0] == "exec1" && execIDs[1] == "exec3", "completed and pending executions of sender should be returned")
+
+	cookbooks := []types.Cookbook{
+		{ID: "cb1", Sender: "other"},
+		{ID: "cb2", Sender: "owner"},
+	}
+	cbIDs := GetOwnedCookbookIDs(cookbooks, "owner")
+	t.WithFields(testing.Fields{
+		"cookbook_ids": cbIDs,
+	}).MustTrue(len(cbIDs) == 1 && cbIDs[0] == "cb2", "only cookbooks of sender should be returned")
+}
@@ -0,0 +1,76 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// RedactedText is the text sensitive data is replaced with
+const RedactedText = "[REDACTED]"
+
+var (
+	secretMux    sync.RWMutex
+	knownSecrets []string
+)
+
+// sensitiveDataPatterns are patterns of mnemonics and private keys in json, yaml and text output of pylonsd
+var sensitiveDataPatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)("(?:mnemonic|priv_?key|private_?key)"\s*:\s*)"[^"]*"`), `${1}"` + RedactedText + `"`},
+	{regexp.MustCompile(`(?im)^(\s*(?:mnemonic|priv_?key|private_?key):[ \t]*)\S.*$`), "${1}" + RedactedText},
+	{regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`), RedactedText},
+	// mnemonic printed on its own line by "keys add"
+	{regexp.MustCompile(`(?m)^[ \t]*(?:[a-z]+ ){11,23}[a-z]+[ \t]*$`), RedactedText},
+}
+
+func init() {
+	ResetTransportHooks()
+	testing.SetRedactor(RedactSensitiveData)
+}
+
+// RegisterSecret is a function to redact secret e.g. mnemonic read from file wherever it appears in logs
+func RegisterSecret(secret string) {
+	secret = strings.TrimSpace(secret)
+	if len(secret) == 0 {
+		return
+	}
+	secretMux.Lock()
+	defer secretMux.Unlock()
+	knownSecrets = append(knownSecrets, secret)
+}
+
+// RedactSensitiveData is a function to replace mnemonics, private keys and registered secrets in text
+func RedactSensitiveData(text string) string {
+	secretMux.RLock()
+	for _, secret := range knownSecrets {
+		text = strings.ReplaceAll(text, secret, RedactedText)
+	}
+	secretMux.RUnlock()
+	for _, p := range sensitiveDataPatterns {
+		text = p.pattern.ReplaceAllString(text, p.replacement)
+	}
+	return text
+}
+
+// RedactionHook is a transport hook to redact sensitive data from logs and command errors of requests
+// Output is kept as callers parse it e.g. mnemonic of new key, test logs are redacted by evtesting redactor.
+type RedactionHook struct{}
+
+// BeforeRequest is a function to do nothing before request
+func (RedactionHook) BeforeRequest(ctx context.Context, req *TransportRequest) {}
+
+// AfterResponse is a function to redact log and error output of response
+func (RedactionHook) AfterResponse(ctx context.Context, req *TransportRequest, res *TransportResponse) {
+	res.Log = RedactSensitiveData(res.Log)
+	var cmdErr *CommandError
+	if errors.As(res.Err, &cmdErr) {
+		cmdErr.Output = RedactSensitiveData(cmdErr.Output)
+	}
+}
@@ -0,0 +1,120 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// RetryPolicy is a struct to configure retry of operations failed by transient node errors
+type RetryPolicy struct {
+	MaxAttempts     int           // number of attempts including the first one, 1 disables retry
+	InitialBackoff  time.Duration // wait before the first retry
+	MaxBackoff      time.Duration // upper bound of wait between retries
+	Multiplier      float64       // growth of wait between retries
+	RetryableErrors []error       // failure classes to retry, checked by errors.Is
+	MaxRebroadcasts int           // number of re-broadcasts of a waited transaction dropped from mempool, 0 disables the watchdog
+}
+
+// DefaultRetryPolicy is a function to get retry policy used when CLIOpts.RetryPolicy is not set
+// Connection failures and full mempool are retried up to max attempts set by retry-max-attempts flag
+// and dropped transactions are broadcast again up to max-rebroadcasts flag
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:     retryMaxAttempts,
+		InitialBackoff:  500 * time.Millisecond,
+		MaxBackoff:      5 * time.Second,
+		Multiplier:      2,
+		RetryableErrors: []error{ErrNodeUnavailable, ErrMempoolFull},
+		MaxRebroadcasts: maxRebroadcasts,
+	}
+}
+
+var retryMaxAttempts int
+var maxRebroadcasts int
+
+func init() {
+	flag.IntVar(&retryMaxAttempts, "retry-max-attempts", 3, "number of attempts of queries and broadcasts failed by transient node errors")
+	flag.IntVar(&maxRebroadcasts, "max-rebroadcasts", 0, "number of re-broadcasts of a waited transaction dropped from mempool without being committed, 0 to disable")
+}
+
+// GetRetryPolicy is a function to get retry policy set on CLIOpts, default DefaultRetryPolicy
+func GetRetryPolicy() RetryPolicy {
+	return DefaultEnv().RetryPolicy()
+}
+
+// RetryPolicy is a function to get retry policy set on options of env, default DefaultRetryPolicy
+func (e *Env) RetryPolicy() RetryPolicy {
+	if e.opts.RetryPolicy != nil {
+		return *e.opts.RetryPolicy
+	}
+	return DefaultRetryPolicy()
+}
+
+// IsRetryable is a function to check if error belongs to one of retryable failure classes
+func (p RetryPolicy) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, retryable := range p.RetryableErrors {
+		if errors.Is(err, retryable) {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff is a function to get wait before retry of attempt, attempt starts from 1
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for idx := 1; idx < attempt; idx++ {
+		backoff = time.Duration(float64(backoff) * p.Multiplier)
+		if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return backoff
+}
+
+// Do is a function to run f and retry it while it fails with retryable error
+// It returns the last error of f, or ctx error when ctx is done while waiting for retry
+func (p RetryPolicy) Do(ctx context.Context, f func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if attempt >= p.MaxAttempts || !p.IsRetryable(err) {
+			return err
+		}
+		observeRetry(err)
+		backoff := p.Backoff(attempt)
+		log.WithFields(log.Fields{
+			"attempt":      attempt,
+			"max_attempts": p.MaxAttempts,
+			"backoff":      backoff.String(),
+			"error":        err,
+		}).Warnln("retrying after transient node error")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ClockFromContext(ctx).After(backoff):
+		}
+	}
+}
+
+// isRetryableCommand is a function to check if pylonsd command can be run again without side effects
+// Broadcast of the same signed transaction is not applied twice by the chain
+func isRetryableCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "query", "status":
+		return true
+	case "tx":
+		return len(args) > 1 && args[1] == "broadcast"
+	default:
+		return false
+	}
+}
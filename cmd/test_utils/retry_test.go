@@ -0,0 +1,59 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestRetryPolicy(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	policy := RetryPolicy{
+		MaxAttempts:     3,
+		InitialBackoff:  time.Millisecond,
+		MaxBackoff:      3 * time.Millisecond,
+		Multiplier:      2,
+		RetryableErrors: []error{ErrNodeUnavailable, ErrMempoolFull},
+	}
+	t.MustTrue(policy.Backoff(1) == time.Millisecond && policy.Backoff(2) == 2*time.Millisecond, "backoff should grow exponentially")
+	t.MustTrue(policy.Backoff(5) == 3*time.Millisecond, "backoff should be capped by max backoff")
+
+	attempts := 0
+	err := policy.Do(context.Background(), func() error {
+		attempts++
+		if attempts < 2 {
+			return NewCommandError(errors.New("exit status 1"), "dial tcp 127.0.0.1:26657: connect: connection refused")
+		}
+		return nil
+	})
+	t.MustTrue(err == nil && attempts == 2, "transient error should be retried until success")
+
+	attempts = 0
+	err = policy.Do(context.Background(), func() error {
+		attempts++
+		return NewTxError("sdk", 20, "mempool is full")
+	})
+	t.MustTrue(errors.Is(err, ErrMempoolFull) && attempts == 3, "retry should stop at max attempts with last error")
+
+	attempts = 0
+	err = policy.Do(context.Background(), func() error {
+		attempts++
+		return NewTxError("sdk", 5, "insufficient funds")
+	})
+	t.MustTrue(errors.Is(err, ErrInsufficientFunds) && attempts == 1, "non retryable error should not be retried")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = policy.Do(ctx, func() error {
+		return NewTxError("sdk", 20, "mempool is full")
+	})
+	t.MustTrue(err == context.Canceled, "retry should stop when ctx is done")
+
+	t.MustTrue(isRetryableCommand([]string{"query", "tx", "hash"}), "query should be retryable")
+	t.MustTrue(isRetryableCommand([]string{"tx", "broadcast", "signed_tx.json"}), "broadcast should be retryable")
+	t.MustTrue(!isRetryableCommand([]string{"keys", "add", "eugen"}), "keys add should not be retryable")
+}
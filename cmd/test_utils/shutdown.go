@@ -0,0 +1,161 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ShutdownExitCode is the exit code of runs stopped by SIGINT or SIGTERM, as shells report for SIGINT
+const ShutdownExitCode = 130
+
+// DefaultShutdownGrace is the time in flight transactions have to conclude after shutdown signal
+const DefaultShutdownGrace = 30 * time.Second
+
+// ErrShutdown is an error of run stopped by shutdown signal before it's completed, it can be resumed from checkpoint
+var ErrShutdown = errors.New("run is stopped by shutdown signal")
+
+// shutdownHook is a struct to describe a function run when suite finishes or is stopped
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Shutdown is a struct to stop long-running suites gracefully on SIGINT or SIGTERM
+// The first signal closes Done so that runners stop issuing new transactions while in flight ones conclude.
+// Hooks flushing reports, metrics and checkpoints run once by Flush when the suite finishes, or right away
+// when in flight transactions don't conclude in grace period or the second signal is received, and then it exits.
+type Shutdown struct {
+	// Grace is the time to wait for the suite to finish after the first signal, it's not limited when it's 0
+	Grace time.Duration
+
+	mux       sync.Mutex
+	done      chan struct{}
+	sig       os.Signal
+	hooks     []shutdownHook
+	flushOnce sync.Once
+	exit      func(code int)
+}
+
+// GlobalShutdown is the shutdown shared by the suite, runners installed by Notify stop on its signals
+var GlobalShutdown = NewShutdown(DefaultShutdownGrace)
+
+// NewShutdown is a function to create shutdown waiting grace for in flight transactions after the first signal
+func NewShutdown(grace time.Duration) *Shutdown {
+	return &Shutdown{
+		Grace: grace,
+		done:  make(chan struct{}),
+		exit:  os.Exit,
+	}
+}
+
+// Done is a function to get channel closed by the first signal, runners stop starting transactions when it's closed
+func (s *Shutdown) Done() <-chan struct{} {
+	return s.done
+}
+
+// Signal is a function to get the signal shutdown is started by, nil when it's not stopping
+func (s *Shutdown) Signal() os.Signal {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.sig
+}
+
+// Stopping is a function to check if shutdown is started
+func (s *Shutdown) Stopping() bool {
+	return s.Signal() != nil
+}
+
+// OnShutdown is a function to register hook run by Flush, hooks run in reverse order of registration like defers
+func (s *Shutdown) OnShutdown(name string, fn func(ctx context.Context) error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.hooks = append(s.hooks, shutdownHook{name: name, fn: fn})
+}
+
+// Flush is a function to run registered hooks once, errors are printed as the suite is finishing
+func (s *Shutdown) Flush(ctx context.Context) {
+	s.flushOnce.Do(func() {
+		s.mux.Lock()
+		hooks := append([]shutdownHook{}, s.hooks...)
+		s.mux.Unlock()
+		for idx := len(hooks) - 1; idx >= 0; idx-- {
+			if err := hooks[idx].fn(ctx); err != nil {
+				fmt.Println("error running shutdown hook", hooks[idx].name, err)
+			}
+		}
+	})
+}
+
+// Stop is a function to start shutdown by sig, the first call closes Done and the next ones flush hooks and exit
+func (s *Shutdown) Stop(sig os.Signal) {
+	s.mux.Lock()
+	first := s.sig == nil
+	if first {
+		s.sig = sig
+		close(s.done)
+	}
+	s.mux.Unlock()
+	if !first {
+		fmt.Printf("received %s again, exiting without waiting for in flight transactions\n", sig)
+		s.forceExit()
+		return
+	}
+	fmt.Printf("received %s, waiting for in flight transactions to conclude, send it again to exit now\n", sig)
+	if s.Grace > 0 {
+		time.AfterFunc(s.Grace, func() {
+			fmt.Printf("in flight transactions didn't conclude in %s, exiting\n", s.Grace)
+			s.forceExit()
+		})
+	}
+}
+
+// forceExit is a function to flush hooks and exit before the suite finishes
+func (s *Shutdown) forceExit() {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultShutdownGrace)
+	defer cancel()
+	s.Flush(ctx)
+	s.exit(ShutdownExitCode)
+}
+
+// Notify is a function to stop on SIGINT and SIGTERM, calling the returned function stops listening
+func (s *Shutdown) Notify() func() {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	stopped := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				s.Stop(sig)
+			case <-stopped:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(stopped)
+		})
+	}
+}
+
+// ShuttingDown is a function to check if the suite is stopping by a signal, new transactions shouldn't be issued then
+func ShuttingDown() bool {
+	return GlobalShutdown.Stopping()
+}
+
+// ExitCode is a function to get exit code of finished suite, ShutdownExitCode when it's stopped by a signal
+func (s *Shutdown) ExitCode(code int) int {
+	if s.Stopping() {
+		return ShutdownExitCode
+	}
+	return code
+}
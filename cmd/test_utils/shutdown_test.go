@@ -0,0 +1,73 @@
+package inttest
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// flushRecorder is a struct to collect names of hooks run by shutdown, hooks run on the grace period timer goroutine
+type flushRecorder struct {
+	mux   sync.Mutex
+	names []string
+}
+
+func (r *flushRecorder) hook(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		r.mux.Lock()
+		defer r.mux.Unlock()
+		r.names = append(r.names, name)
+		return nil
+	}
+}
+
+func (r *flushRecorder) flushed() []string {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return append([]string{}, r.names...)
+}
+
+func TestShutdown(originT *originT.T) {
+	t := testing.NewT(originT)
+	// grace period doesn't end during the test, so hooks only run by the second signal
+	shutdown := NewShutdown(time.Hour)
+	exitCodes := make(chan int, 2)
+	shutdown.exit = func(code int) { exitCodes <- code }
+	recorder := &flushRecorder{}
+	for _, name := range []string{"metrics", "checkpoint", "report"} {
+		shutdown.OnShutdown(name, recorder.hook(name))
+	}
+	t.MustTrue(!shutdown.Stopping() && shutdown.ExitCode(1) == 1, "shutdown should not be started before signal")
+
+	shutdown.Stop(syscall.SIGTERM)
+	select {
+	case <-shutdown.Done():
+	default:
+		t.Fatal("done should be closed by the first signal")
+	}
+	t.MustTrue(shutdown.Signal() == syscall.SIGTERM && len(recorder.flushed()) == 0, "hooks should wait for in flight transactions")
+	shutdown.Stop(syscall.SIGTERM)
+	t.MustTrue(<-exitCodes == ShutdownExitCode, "second signal should exit without waiting")
+	flushed := recorder.flushed()
+	t.WithFields(testing.Fields{
+		"flushed": flushed,
+	}).MustTrue(len(flushed) == 3 && flushed[0] == "report" && flushed[2] == "metrics", "hooks should run in reverse order of registration")
+
+	shutdown.Flush(context.Background())
+	t.MustTrue(len(recorder.flushed()) == 3, "hooks should run once")
+	t.MustTrue(shutdown.ExitCode(0) == ShutdownExitCode, "stopped run should exit with shutdown exit code")
+
+	shutdown = NewShutdown(time.Millisecond)
+	shutdown.exit = func(code int) { exitCodes <- code }
+	recorder = &flushRecorder{}
+	shutdown.OnShutdown("report", recorder.hook("report"))
+	shutdown.Stop(os.Interrupt)
+	// exit is called after hooks are flushed, so receiving the exit code means flush has finished
+	t.MustTrue(<-exitCodes == ShutdownExitCode, "it should exit when grace period ends")
+	t.MustTrue(len(recorder.flushed()) == 1, "hooks should be flushed when grace period ends")
+}
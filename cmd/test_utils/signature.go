@@ -0,0 +1,125 @@
+package inttest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// ErrInvalidSignature is returned when a transaction or msg is not signed by its signers
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// AccountNumberSource describes the type of function to get account number of signer address, which is part of sign bytes
+type AccountNumberSource func(ctx context.Context, addr string) (uint64, error)
+
+// SignatureVerifyOptions is a struct to configure verification of signed transactions
+type SignatureVerifyOptions struct {
+	// ChainID is the chain transactions are signed for, chain id of env of ctx is used when it's empty
+	ChainID string
+	// AccountNumbers gets account numbers of signers, they're queried from node when it's nil
+	AccountNumbers AccountNumberSource
+}
+
+// accountNumberOfNode is a function to get account number of addr from node
+func accountNumberOfNode(ctx context.Context, addr string) (uint64, error) {
+	transport, err := GetTransport()
+	if err != nil {
+		return 0, err
+	}
+	acc, err := transport.Account(ctx, addr)
+	if err != nil {
+		return 0, fmt.Errorf("error getting account %s: %w", addr, err)
+	}
+	return acc.GetAccountNumber(), nil
+}
+
+// DecodeTx is a function to decode signed transaction of protobuf bytes, as broadcast to node, or of json as SignTxOffline makes
+func DecodeTx(txBytes []byte) (sdk.Tx, error) {
+	if trimmed := bytes.TrimSpace(txBytes); len(trimmed) > 0 && trimmed[0] == '{' {
+		return GetTxJSONDecoder()(trimmed)
+	}
+	return app.MakeEncodingConfig().TxConfig.TxDecoder()(txBytes)
+}
+
+// VerifyTxSignature is a function to check signed transaction of game clients is signed by all its signers
+// for the chain and account numbers of node, so that back-end services can validate it before relaying it.
+// It returns the decoded transaction whose msgs can be checked by VerifyMsgSignedBy.
+func VerifyTxSignature(txBytes []byte) (sdk.Tx, error) {
+	return VerifyTxSignatureWithOptions(context.Background(), txBytes, SignatureVerifyOptions{})
+}
+
+// VerifyTxSignatureWithOptions is a function to check signed transaction with chain id and account numbers of options
+// Sequences are taken from the signatures, so transactions signed ahead of pending ones are accepted.
+func VerifyTxSignatureWithOptions(ctx context.Context, txBytes []byte, opts SignatureVerifyOptions) (sdk.Tx, error) {
+	tx, err := DecodeTx(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding transaction: %w", err)
+	}
+	if err := tx.ValidateBasic(); err != nil {
+		return tx, err
+	}
+	sigTx, ok := tx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return tx, fmt.Errorf("%w: transaction %T has no signatures", ErrInvalidSignature, tx)
+	}
+	chainID := opts.ChainID
+	if len(chainID) == 0 {
+		chainID = EnvFromContext(ctx).ChainID()
+	}
+	accountNumbers := opts.AccountNumbers
+	if accountNumbers == nil {
+		accountNumbers = accountNumberOfNode
+	}
+	signers := sigTx.GetSigners()
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return tx, fmt.Errorf("error getting signatures: %w", err)
+	}
+	if len(sigs) != len(signers) {
+		return tx, fmt.Errorf("%w: transaction has %d signatures for %d signers", ErrInvalidSignature, len(sigs), len(signers))
+	}
+	handler := app.MakeEncodingConfig().TxConfig.SignModeHandler()
+	for idx, sig := range sigs {
+		signer := signers[idx]
+		if sig.PubKey == nil {
+			return tx, fmt.Errorf("%w: signature of %s has no public key", ErrInvalidSignature, signer)
+		}
+		if !bytes.Equal(sig.PubKey.Address(), signer) {
+			return tx, fmt.Errorf("%w: public key of signature %d is of %s, but signer is %s",
+				ErrInvalidSignature, idx, sdk.AccAddress(sig.PubKey.Address()), signer)
+		}
+		accountNumber, err := accountNumbers(ctx, signer.String())
+		if err != nil {
+			return tx, err
+		}
+		signerData := authsigning.SignerData{
+			ChainID:       chainID,
+			AccountNumber: accountNumber,
+			Sequence:      sig.Sequence,
+		}
+		if err := authsigning.VerifySignature(sig.PubKey, signerData, sig.Data, handler, sigTx); err != nil {
+			return tx, fmt.Errorf("%w: signature of %s on chain %s: %s", ErrInvalidSignature, signer, chainID, err.Error())
+		}
+	}
+	return tx, nil
+}
+
+// VerifyMsgSignedBy is a function to check if addr is a signer of msg
+// Use it on msgs of transaction verified by VerifyTxSignature, e.g. to check execute recipe was sent by the player.
+func VerifyMsgSignedBy(msg sdk.Msg, addr string) error {
+	accAddr, err := sdk.AccAddressFromBech32(addr)
+	if err != nil {
+		return fmt.Errorf("invalid address %s: %w", addr, err)
+	}
+	for _, signer := range msg.GetSigners() {
+		if signer.Equals(accAddr) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: msg %s is not signed by %s", ErrInvalidSignature, msg.Type(), addr)
+}
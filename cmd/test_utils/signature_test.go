@@ -0,0 +1,58 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestVerifyTxSignature(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	privKey := secp256k1.GenPrivKey()
+	sender := sdk.AccAddress(privKey.PubKey().Address()).String()
+	msg := types.NewMsgGetPylons(types.PremiumTier.Fee, sender)
+	signedTx, err := SignTxOffline([]sdk.Msg{&msg}, 3, 7, "pylonschain", privKey)
+	t.MustNil(err, "error signing transaction offline")
+
+	opts := SignatureVerifyOptions{
+		ChainID: "pylonschain",
+		AccountNumbers: func(ctx context.Context, addr string) (uint64, error) {
+			return 3, nil
+		},
+	}
+	tx, err := VerifyTxSignatureWithOptions(context.Background(), signedTx, opts)
+	t.MustNil(err, "error verifying json transaction")
+	t.MustTrue(len(tx.GetMsgs()) == 1, "verified transaction should have msgs")
+	t.MustNil(VerifyMsgSignedBy(tx.GetMsgs()[0], sender), "msg should be signed by sender")
+	other := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String()
+	t.MustTrue(errors.Is(VerifyMsgSignedBy(tx.GetMsgs()[0], other), ErrInvalidSignature), "msg should not be signed by other address")
+	t.MustTrue(VerifyMsgSignedBy(tx.GetMsgs()[0], "not-an-address") != nil, "invalid address should be refused")
+
+	protoTx, err := app.MakeEncodingConfig().TxConfig.TxEncoder()(tx)
+	t.MustNil(err, "error encoding transaction as protobuf")
+	_, err = VerifyTxSignatureWithOptions(context.Background(), protoTx, opts)
+	t.MustNil(err, "error verifying protobuf transaction")
+
+	_, err = VerifyTxSignatureWithOptions(context.Background(), signedTx, SignatureVerifyOptions{ChainID: "other-chain", AccountNumbers: opts.AccountNumbers})
+	t.MustTrue(errors.Is(err, ErrInvalidSignature), "signature for another chain should be refused")
+	_, err = VerifyTxSignatureWithOptions(context.Background(), signedTx, SignatureVerifyOptions{
+		ChainID: "pylonschain",
+		AccountNumbers: func(ctx context.Context, addr string) (uint64, error) {
+			return 4, nil
+		},
+	})
+	t.MustTrue(errors.Is(err, ErrInvalidSignature), "signature of another account number should be refused")
+
+	otherMsg := types.NewMsgGetPylons(types.PremiumTier.Fee, other)
+	forged, err := SignTxOffline([]sdk.Msg{&otherMsg}, 3, 7, "pylonschain", privKey)
+	t.MustNil(err, "error signing transaction of other sender")
+	_, err = VerifyTxSignatureWithOptions(context.Background(), forged, opts)
+	t.MustTrue(errors.Is(err, ErrInvalidSignature), "transaction signed by key of another signer should be refused")
+}
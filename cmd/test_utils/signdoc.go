@@ -0,0 +1,238 @@
+package inttest
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// SignDocExport is a struct to export the exact signing payload of a pending transaction
+// All byte fields are hex encoded
+type SignDocExport struct {
+	ChainID       string `json:"chain_id"`
+	AccountNumber uint64 `json:"account_number"`
+	Sequence      uint64 `json:"sequence"`
+	SignMode      string `json:"sign_mode"`
+	PubKey        string `json:"pubkey"`
+	BodyBytes     string `json:"body_bytes,omitempty"`      // only for SIGN_MODE_DIRECT
+	AuthInfoBytes string `json:"auth_info_bytes,omitempty"` // only for SIGN_MODE_DIRECT
+	SignBytes     string `json:"sign_bytes"`
+	Signature     string `json:"signature,omitempty"` // signature made by pylonsd for sign bytes
+}
+
+// GenSignDoc is a function to generate signing payload for msgs signed by pubKey
+func GenSignDoc(msgs []sdk.Msg, pubKey cryptotypes.PubKey, signerData authsigning.SignerData, signMode signing.SignMode) (SignDocExport, error) {
+	export, _, err := genSignDoc(context.Background(), msgs, pubKey, signerData, signMode, TxOptions{})
+	return export, err
+}
+
+// genSignDoc is a function to generate signing payload and the transaction having signer info without signature it's made of
+// Gas limit and fees are taken from env of ctx.
+func genSignDoc(ctx context.Context, msgs []sdk.Msg, pubKey cryptotypes.PubKey, signerData authsigning.SignerData, signMode signing.SignMode, opts TxOptions) (SignDocExport, authsigning.Tx, error) {
+	export := SignDocExport{
+		ChainID:       signerData.ChainID,
+		AccountNumber: signerData.AccountNumber,
+		Sequence:      signerData.Sequence,
+		SignMode:      signMode.String(),
+		PubKey:        hex.EncodeToString(pubKey.Bytes()),
+	}
+	txBldr, err := genTxBuilder(ctx, msgs, opts)
+	if err != nil {
+		return export, nil, err
+	}
+	// signer infos are part of SIGN_MODE_DIRECT sign bytes, set them with empty signature as pylonsd does
+	err = txBldr.SetSignatures(signing.SignatureV2{
+		PubKey: pubKey,
+		Data: &signing.SingleSignatureData{
+			SignMode:  signMode,
+			Signature: nil,
+		},
+		Sequence: signerData.Sequence,
+	})
+	if err != nil {
+		return export, nil, err
+	}
+	tx := txBldr.GetTx()
+	signBytes, err := app.MakeEncodingConfig().TxConfig.SignModeHandler().GetSignBytes(signMode, signerData, tx)
+	if err != nil {
+		return export, tx, err
+	}
+	export.SignBytes = hex.EncodeToString(signBytes)
+	if signMode == signing.SignMode_SIGN_MODE_DIRECT {
+		var signDoc txtypes.SignDoc
+		if err = signDoc.Unmarshal(signBytes); err != nil {
+			return export, tx, err
+		}
+		export.BodyBytes = hex.EncodeToString(signDoc.BodyBytes)
+		export.AuthInfoBytes = hex.EncodeToString(signDoc.AuthInfoBytes)
+	}
+	return export, tx, nil
+}
+
+// TxPayload is a struct to describe the exact json transaction and sign bytes produced for msgs
+// It's compared against golden files so encoding changes of sdk upgrades are caught before breaking integrations.
+type TxPayload struct {
+	// TxJSON is proto json of the transaction having signer info without signature, as pylonsd signs it
+	TxJSON json.RawMessage `json:"tx_json"`
+	// AminoSignDoc is the legacy amino json document signed in SIGN_MODE_LEGACY_AMINO_JSON
+	AminoSignDoc    json.RawMessage `json:"amino_sign_doc"`
+	Direct          SignDocExport   `json:"direct"`
+	LegacyAminoJSON SignDocExport   `json:"legacy_amino_json"`
+}
+
+// GenTxPayload is a function to render transaction json and sign bytes of both sign modes for msgs signed by pubKey
+// Fee and gas are taken from selected chain profile, so payloads are deterministic for the same profile and inputs.
+func GenTxPayload(msgs []sdk.Msg, pubKey cryptotypes.PubKey, signerData authsigning.SignerData, opts TxOptions) (TxPayload, error) {
+	payload := TxPayload{}
+	for _, signMode := range []signing.SignMode{signing.SignMode_SIGN_MODE_DIRECT, signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON} {
+		export, tx, err := genSignDoc(context.Background(), msgs, pubKey, signerData, signMode, opts)
+		if err != nil {
+			return payload, err
+		}
+		if signMode == signing.SignMode_SIGN_MODE_DIRECT {
+			payload.Direct = export
+			if payload.TxJSON, err = GetTxJSONEncoder()(tx); err != nil {
+				return payload, err
+			}
+			continue
+		}
+		payload.LegacyAminoJSON = export
+		signBytes, err := hex.DecodeString(export.SignBytes)
+		if err != nil {
+			return payload, err
+		}
+		payload.AminoSignDoc = signBytes
+	}
+	return payload, nil
+}
+
+// MarshalGolden is a function to encode payload as indented json for golden files
+func (p TxPayload) MarshalGolden() ([]byte, error) {
+	output, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(output, '\n'), nil
+}
+
+// ExportSignDoc is a function to export signing payload of msgs for signer key with the signature made by pylonsd
+// Sequence is fetched from chain, so nonce file of pending transactions is not applied
+func ExportSignDoc(t *testing.T, msgs []sdk.Msg, signer string, signMode signing.SignMode) (SignDocExport, error) {
+	return ExportSignDocCtx(context.Background(), t, msgs, signer, signMode)
+}
+
+// ExportSignDocCtx is a function to export signing payload of msgs for signer key for chain id of env of ctx
+func ExportSignDocCtx(ctx context.Context, t *testing.T, msgs []sdk.Msg, signer string, signMode signing.SignMode) (SignDocExport, error) {
+	keyOutput, logstr, err := Keys().Show(signer, false).Run(ctx)
+	if err != nil {
+		return SignDocExport{}, fmt.Errorf("%s: %w", logstr, err)
+	}
+	var keyInfo struct {
+		Address string `json:"address"`
+		PubKey  string `json:"pubkey"`
+	}
+	if err = json.Unmarshal(keyOutput, &keyInfo); err != nil {
+		return SignDocExport{}, err
+	}
+	pubKey, err := sdk.GetPubKeyFromBech32(sdk.Bech32PubKeyTypeAccPub, keyInfo.PubKey)
+	if err != nil {
+		return SignDocExport{}, err
+	}
+	accInfo := GetAccountInfoFromAddr(keyInfo.Address, t)
+	signerData := authsigning.SignerData{
+		ChainID:       EnvFromContext(ctx).ChainID(),
+		AccountNumber: accInfo.GetAccountNumber(),
+		Sequence:      accInfo.GetSequence(),
+	}
+	export, _, err := genSignDoc(ctx, msgs, pubKey, signerData, signMode, TxOptions{})
+	if err != nil {
+		return export, err
+	}
+	signature, err := signTxViaCLI(ctx, msgs, signer, signerData, signMode)
+	if err != nil {
+		return export, err
+	}
+	if err = VerifySignDocSignature(export, pubKey, signature); err != nil {
+		return export, fmt.Errorf("pylonsd signature does not match exported sign bytes: %w", err)
+	}
+	export.Signature = hex.EncodeToString(signature)
+	return export, nil
+}
+
+// VerifySignDocSignature is a function to check if signature is valid for exported sign bytes
+func VerifySignDocSignature(export SignDocExport, pubKey cryptotypes.PubKey, signature []byte) error {
+	signBytes, err := hex.DecodeString(export.SignBytes)
+	if err != nil {
+		return err
+	}
+	if !pubKey.VerifySignature(signBytes, signature) {
+		return fmt.Errorf("signature is not valid for sign bytes")
+	}
+	return nil
+}
+
+func signTxViaCLI(ctx context.Context, msgs []sdk.Msg, signer string, signerData authsigning.SignerData, signMode signing.SignMode) ([]byte, error) {
+	txModel, err := GenTxWithMsg(msgs)
+	if err != nil {
+		return nil, err
+	}
+	output, err := GetTxJSONEncoder()(txModel)
+	if err != nil {
+		return nil, err
+	}
+	tmpDir, err := ioutil.TempDir("", "pylons")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	rawTxFile := filepath.Join(tmpDir, "raw_tx.json")
+	if err = ioutil.WriteFile(rawTxFile, output, 0644); err != nil {
+		return nil, err
+	}
+	cliSignMode := flags.SignModeDirect
+	if signMode == signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON {
+		cliSignMode = flags.SignModeLegacyAminoJSON
+	}
+	output, logstr, err := Tx().Sign(rawTxFile).
+		From(signer).
+		Offline(signerData.AccountNumber, signerData.Sequence).
+		ChainID(signerData.ChainID).
+		Flag(flags.FlagSignMode, cliSignMode).
+		Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", logstr, err)
+	}
+	signedTx, err := GetTxJSONDecoder()(output)
+	if err != nil {
+		return nil, err
+	}
+	sigTx, ok := signedTx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return nil, fmt.Errorf("signed tx does not have signatures")
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return nil, err
+	}
+	if len(sigs) != 1 {
+		return nil, fmt.Errorf("signed tx has %d signatures, expected 1", len(sigs))
+	}
+	sigData, ok := sigs[0].Data.(*signing.SingleSignatureData)
+	if !ok {
+		return nil, fmt.Errorf("signed tx does not have single signature")
+	}
+	return sigData.Signature, nil
+}
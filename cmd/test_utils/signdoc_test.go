@@ -0,0 +1,48 @@
+package inttest
+
+import (
+	"encoding/hex"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+func TestGenSignDoc(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	privKey := secp256k1.GenPrivKey()
+	sender := sdk.AccAddress(privKey.PubKey().Address()).String()
+	msg := types.NewMsgGetPylons(types.PremiumTier.Fee, sender)
+	signerData := authsigning.SignerData{
+		ChainID:       "pylonschain",
+		AccountNumber: 3,
+		Sequence:      7,
+	}
+
+	for _, signMode := range []signing.SignMode{signing.SignMode_SIGN_MODE_DIRECT, signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON} {
+		export, err := GenSignDoc([]sdk.Msg{&msg}, privKey.PubKey(), signerData, signMode)
+		t.WithFields(testing.Fields{
+			"sign_mode": signMode.String(),
+		}).MustNil(err, "error generating sign doc")
+		t.MustTrue(len(export.SignBytes) > 0, "sign bytes should be exported")
+		if signMode == signing.SignMode_SIGN_MODE_DIRECT {
+			t.MustTrue(len(export.BodyBytes) > 0 && len(export.AuthInfoBytes) > 0, "body and auth info bytes should be exported for direct mode")
+		}
+
+		sameExport, err := GenSignDoc([]sdk.Msg{&msg}, privKey.PubKey(), signerData, signMode)
+		t.MustNil(err, "error generating sign doc")
+		t.MustTrue(export.SignBytes == sameExport.SignBytes, "sign bytes should be deterministic")
+
+		signBytes, err := hex.DecodeString(export.SignBytes)
+		t.MustNil(err, "error decoding sign bytes")
+		signature, err := privKey.Sign(signBytes)
+		t.MustNil(err, "error signing sign bytes")
+		t.MustNil(VerifySignDocSignature(export, privKey.PubKey(), signature), "signature should be valid for sign bytes")
+		t.MustTrue(VerifySignDocSignature(export, secp256k1.GenPrivKey().PubKey(), signature) != nil, "signature should be invalid for other key")
+	}
+}
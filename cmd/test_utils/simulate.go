@@ -0,0 +1,82 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+// simulateQueryPath is the abci query path of tx service simulating transactions
+const simulateQueryPath = "/cosmos.tx.v1beta1.Service/Simulate"
+
+// SimulationResult is a struct to manage result of simulating transaction without committing it
+type SimulationResult struct {
+	GasWanted uint64
+	GasUsed   uint64
+	Log       string
+}
+
+// SimulateTx is a function to run transaction of msgs signed by signer address through CheckTx of node without broadcasting it
+// Signature is left empty as node doesn't verify signatures of simulated transactions, but sequence of signer should match.
+func SimulateTx(ctx context.Context, signer string, msgs ...sdk.Msg) (SimulationResult, error) {
+	txBldr, err := GenTxBuilderWithMsg(msgs)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	transport, err := GetTransport()
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	acc, err := transport.Account(ctx, signer)
+	if err != nil {
+		return SimulationResult{}, fmt.Errorf("error getting account of signer %s: %w", signer, err)
+	}
+	err = txBldr.SetSignatures(signing.SignatureV2{
+		PubKey:   &secp256k1.PubKey{},
+		Data:     &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_DIRECT},
+		Sequence: acc.GetSequence(),
+	})
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	protoTx, ok := txBldr.(authtx.ProtoTxProvider)
+	if !ok {
+		return SimulationResult{}, fmt.Errorf("transaction builder %T can't provide proto transaction", txBldr)
+	}
+	simReq := txtypes.SimulateRequest{Tx: protoTx.GetProtoTx()}
+	reqBytes, err := simReq.Marshal()
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	rpcClient, err := rpchttp.New(firstNode(), "/websocket")
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	res, err := rpcClient.ABCIQuery(ctx, simulateQueryPath, reqBytes)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	if !res.Response.IsOK() {
+		return SimulationResult{}, NewTxError(res.Response.Codespace, res.Response.Code, res.Response.Log)
+	}
+	simRes := txtypes.SimulateResponse{}
+	if err = simRes.Unmarshal(res.Response.Value); err != nil {
+		return SimulationResult{}, fmt.Errorf("error decoding simulation response: %w", err)
+	}
+	result := SimulationResult{}
+	if simRes.GasInfo != nil {
+		result.GasWanted = simRes.GasInfo.GasWanted
+		result.GasUsed = simRes.GasInfo.GasUsed
+	}
+	if simRes.Result != nil {
+		result.Log = simRes.Result.Log
+	}
+	return result, nil
+}
@@ -0,0 +1,762 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/config"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SoakCheckpointVersion is the version of checkpoint file written by soak test
+const SoakCheckpointVersion = 1
+
+// describes lifecycle stages of simulated players
+const (
+	SoakStageNew     = "new"
+	SoakStageCreated = "created" // account is created on chain
+	SoakStageFunded  = "funded"  // pylons are granted, player trades and executes recipes from here
+)
+
+// describes actions of simulated players
+const (
+	SoakActionCreateAccount  = "create_account"
+	SoakActionGetPylons      = "get_pylons"
+	SoakActionExecuteRecipe  = "execute_recipe"
+	SoakActionCheckExecution = "check_execution"
+	SoakActionSell           = "sell"
+	SoakActionBuy            = "buy"
+)
+
+// SoakTrade is a struct to describe an item listed for pylons by a simulated player
+type SoakTrade struct {
+	TradeID string `json:"trade_id"`
+	ItemID  string `json:"item_id"`
+	Price   int64  `json:"price"`
+}
+
+// SoakPlayer is a struct to describe state of a simulated player kept in checkpoint
+type SoakPlayer struct {
+	Key            string      `json:"key"`
+	Address        string      `json:"address"`
+	Stage          string      `json:"stage"`
+	Actions        int         `json:"actions"`
+	Failures       int         `json:"failures"`
+	ItemIDs        []string    `json:"item_ids"`
+	PendingExecIDs []string    `json:"pending_exec_ids"`
+	OpenTrades     []SoakTrade `json:"open_trades"`
+}
+
+// SoakCheckpoint is a struct to describe progress of soak test so that an interrupted run can be resumed
+// Granted and PaidOut are coins minted by get pylons and recipe outputs, which are expected on top of SinkBalances.
+// Fees are tx fees paid by players, which leave players and sinks to fee collector. FeeUnknownTxs counts committed
+// transactions whose fees couldn't be read from their tx results, total coins can't be checked when it's not zero.
+type SoakCheckpoint struct {
+	Version       int            `json:"version"`
+	Seed          int64          `json:"seed"`
+	RecipeID      string         `json:"recipe_id"`
+	Elapsed       time.Duration  `json:"elapsed"`
+	Players       []SoakPlayer   `json:"players"`
+	Sinks         []string       `json:"sinks"`
+	SinkBalances  sdk.Coins      `json:"sink_balances"`
+	Granted       sdk.Coins      `json:"granted"`
+	PaidOut       sdk.Coins      `json:"paid_out"`
+	Fees          sdk.Coins      `json:"fees"`
+	FeeUnknownTxs int            `json:"fee_unknown_txs"`
+	Actions       map[string]int `json:"actions"`
+	Failures      map[string]int `json:"failures"`
+}
+
+// SoakViolation is a struct to describe invariant which doesn't hold after soak test
+type SoakViolation struct {
+	Invariant string
+	Message   string
+}
+
+// SoakReport is a struct to describe actions, failures and invariant violations of soak test
+type SoakReport struct {
+	Players    int
+	Elapsed    time.Duration
+	Actions    map[string]int
+	Failures   map[string]int
+	Violations []SoakViolation
+}
+
+// String is a function to get readable summary of soak report
+func (r SoakReport) String() string {
+	return fmt.Sprintf("players=%d elapsed=%s actions=%v failures=%v violations=%d", r.Players, r.Elapsed, r.Actions, r.Failures, len(r.Violations))
+}
+
+// SoakTest is a struct to simulate players creating accounts, getting pylons, executing recipes and trading items for hours
+// Progress is checkpointed so that a devnet soak can be resumed, and coin conservation and stuck executions are checked at the end.
+// Recipe should be executable without item inputs, its coin inputs go to the cookbook owner which is a sink of coin conservation.
+type SoakTest struct {
+	// Players is the number of simulated players
+	Players int
+	// Duration is total simulated time including resumed runs
+	Duration time.Duration
+	// ActionInterval is average time between actions of a player, actual intervals are jittered by half of it
+	ActionInterval time.Duration
+	// Seed makes choices of players reproducible, it's kept in checkpoint
+	Seed int64
+	// RecipeID is the recipe executed by players, its outputs are items sold to other players
+	RecipeID string
+	// GetPylons is the amount of pylons each player gets after creating account
+	GetPylons sdk.Coins
+	// Price is pylons asked for an item
+	Price int64
+	// KeyringDir keeps keys of players, it should be kept with checkpoint file to resume
+	KeyringDir string
+	// CheckpointFile is where progress is saved, the run is resumed from it when it exists
+	CheckpointFile string
+	// CheckpointInterval is time between checkpoints, 1 minute when it's 0
+	CheckpointInterval time.Duration
+	// StuckBlocks is the number of blocks after ready height from which pending execution is stuck, 10 when it's 0
+	StuckBlocks int64
+	// Stop stops players starting actions when it's closed, the run is checkpointed after started actions finish
+	// and returns ErrShutdown to be resumed later, GlobalShutdown.Done() is used when it's nil
+	Stop <-chan struct{}
+}
+
+// soakRun is a struct to keep state of a running soak test shared by players
+type soakRun struct {
+	st      SoakTest
+	client  *Client
+	mu      sync.Mutex
+	cp      SoakCheckpoint
+	players []*SoakPlayer
+	market  map[string]string // owner address of trades open for players
+}
+
+// validate is a function to check soak test configuration
+func (st SoakTest) validate() error {
+	if st.Players <= 0 {
+		return errors.New("number of players should be positive")
+	}
+	if st.Duration <= 0 || st.ActionInterval <= 0 {
+		return errors.New("duration and action interval should be positive")
+	}
+	if len(st.RecipeID) == 0 {
+		return errors.New("recipe of players is not set")
+	}
+	if len(st.KeyringDir) == 0 {
+		return errors.New("keyring directory of players is not set")
+	}
+	if st.Price <= 0 || st.GetPylons.AmountOf(types.Pylon).Int64() < st.Price {
+		return errors.New("price should be positive and players should get pylons to pay it")
+	}
+	return nil
+}
+
+// ReadSoakCheckpoint is a function to read checkpoint of soak test, nil checkpoint when file doesn't exist
+func ReadSoakCheckpoint(filename string) (*SoakCheckpoint, error) {
+	bz, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cp := SoakCheckpoint{}
+	if err = json.Unmarshal(bz, &cp); err != nil {
+		return nil, fmt.Errorf("error decoding soak checkpoint %s: %w", filename, err)
+	}
+	if cp.Version > SoakCheckpointVersion {
+		return nil, fmt.Errorf("soak checkpoint version %d is newer than supported version %d", cp.Version, SoakCheckpointVersion)
+	}
+	return &cp, nil
+}
+
+// WriteSoakCheckpoint is a function to write checkpoint of soak test, the file is replaced atomically
+func WriteSoakCheckpoint(cp SoakCheckpoint, filename string) error {
+	bz, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpFile := filename + ".tmp"
+	if err = ioutil.WriteFile(tmpFile, bz, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, filename)
+}
+
+// newSoakCheckpoint is a function to create checkpoint of a new soak test with players not created yet
+func (st SoakTest) newSoakCheckpoint() SoakCheckpoint {
+	cp := SoakCheckpoint{
+		Version:  SoakCheckpointVersion,
+		Seed:     st.Seed,
+		RecipeID: st.RecipeID,
+		Players:  []SoakPlayer{},
+		Actions:  map[string]int{},
+		Failures: map[string]int{},
+	}
+	for idx := 0; idx < st.Players; idx++ {
+		cp.Players = append(cp.Players, SoakPlayer{Key: fmt.Sprintf("soak_player_%d", idx), Stage: SoakStageNew})
+	}
+	return cp
+}
+
+// soakSinks is a function to get addresses receiving coins of players besides players, the cookbook owner and pylons llc
+func soakSinks(recipeID string) ([]string, error) {
+	rcp, err := GetRecipeByGUID(recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting recipe %s: %w", recipeID, err)
+	}
+	cb, err := GetCookbookByGUID(rcp.CookbookID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting cookbook %s: %w", rcp.CookbookID, err)
+	}
+	sinks := []string{cb.Sender}
+	if llc := config.Config.Validators.PylonsLLC; llc != cb.Sender {
+		sinks = append(sinks, llc)
+	}
+	return sinks, nil
+}
+
+// totalBalances is a function to get sum of balances of addresses
+func totalBalances(ctx context.Context, addresses []string) (sdk.Coins, error) {
+	transport, err := GetTransport()
+	if err != nil {
+		return nil, err
+	}
+	total := sdk.Coins{}
+	for _, addr := range addresses {
+		coins, err := transport.Balances(ctx, addr)
+		if err != nil {
+			return nil, fmt.Errorf("error getting balance of %s: %w", addr, err)
+		}
+		total = total.Add(coins...)
+	}
+	return total, nil
+}
+
+// Run is a function to run simulated players until Duration including resumed runs passes or ctx is done
+// It writes checkpoint periodically and when it stops, and checks invariants only when Duration is reached.
+// Players, seed and recipe of a resumed run are the ones in checkpoint, and canceling ctx interrupts actions in flight.
+func (st SoakTest) Run(ctx context.Context, t *testing.T) (SoakReport, error) {
+	if err := st.validate(); err != nil {
+		return SoakReport{}, err
+	}
+	if st.CheckpointInterval == 0 {
+		st.CheckpointInterval = time.Minute
+	}
+	if st.StuckBlocks == 0 {
+		st.StuckBlocks = 10
+	}
+	if st.Stop == nil {
+		st.Stop = GlobalShutdown.Done()
+	}
+	run := &soakRun{
+		st:     st,
+		client: NewClient(WithKeyring(TestKeyring{Dir: st.KeyringDir})),
+		market: map[string]string{},
+	}
+	cp, err := ReadSoakCheckpoint(st.CheckpointFile)
+	if err != nil {
+		return SoakReport{}, err
+	}
+	if cp == nil {
+		run.cp = st.newSoakCheckpoint()
+		if run.cp.Sinks, err = soakSinks(st.RecipeID); err != nil {
+			return SoakReport{}, err
+		}
+		if run.cp.SinkBalances, err = totalBalances(ctx, run.cp.Sinks); err != nil {
+			return SoakReport{}, err
+		}
+	} else {
+		run.cp = *cp
+		run.st.RecipeID = cp.RecipeID
+		if run.cp.Actions == nil {
+			run.cp.Actions = map[string]int{}
+		}
+		if run.cp.Failures == nil {
+			run.cp.Failures = map[string]int{}
+		}
+		t.WithFields(testing.Fields{
+			"checkpoint": st.CheckpointFile,
+			"elapsed":    cp.Elapsed.String(),
+		}).Info("resuming soak test")
+	}
+	for idx := range run.cp.Players {
+		player := &run.cp.Players[idx]
+		run.players = append(run.players, player)
+		for _, trade := range player.OpenTrades {
+			run.market[trade.TradeID] = player.Address
+		}
+	}
+
+	remaining := st.Duration - run.cp.Elapsed
+	runCtx, cancel := context.WithTimeout(ctx, remaining)
+	defer cancel()
+	start := time.Now()
+	elapsedBefore := run.cp.Elapsed
+	checkpoint := func() {
+		if len(st.CheckpointFile) == 0 {
+			return
+		}
+		run.mu.Lock()
+		run.cp.Elapsed = elapsedBefore + time.Since(start)
+		err := WriteSoakCheckpoint(run.cp, st.CheckpointFile)
+		run.mu.Unlock()
+		if err != nil {
+			t.WithFields(testing.Fields{
+				"checkpoint": st.CheckpointFile,
+				"error":      err,
+			}).Warn("error writing soak checkpoint")
+		}
+	}
+
+	var wg sync.WaitGroup
+	for idx, player := range run.players {
+		wg.Add(1)
+		go func(idx int, player *SoakPlayer) {
+			defer wg.Done()
+			run.playerLoop(runCtx, ctx, t, player, rand.New(rand.NewSource(run.cp.Seed+int64(idx))))
+		}(idx, player)
+	}
+	ticker := time.NewTicker(st.CheckpointInterval)
+	defer ticker.Stop()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+loop:
+	for {
+		select {
+		case <-done:
+			break loop
+		case <-ticker.C:
+			checkpoint()
+		}
+	}
+	checkpoint()
+
+	report := SoakReport{
+		Players:  len(run.players),
+		Elapsed:  elapsedBefore + time.Since(start),
+		Actions:  run.cp.Actions,
+		Failures: run.cp.Failures,
+	}
+	if ctx.Err() != nil {
+		// interrupted run is resumed from checkpoint, invariants are checked when it completes
+		return report, ctx.Err()
+	}
+	select {
+	case <-st.Stop:
+		t.WithFields(testing.Fields{
+			"checkpoint": st.CheckpointFile,
+			"report":     report.String(),
+		}).Info("soak test is stopped, resume it from checkpoint")
+		return report, ErrShutdown
+	default:
+	}
+	run.drain(ctx, t)
+	checkpoint()
+	report.Violations, err = run.checkInvariants(ctx)
+	t.WithFields(testing.Fields{
+		"report": report.String(),
+	}).Info("soak test finished")
+	return report, err
+}
+
+// playerLoop is a function to run actions of a player at jittered intervals until runCtx is done or the run is stopped
+// Actions run with ctx so that an action started before the end of run is finished and recorded in checkpoint.
+func (run *soakRun) playerLoop(runCtx, ctx context.Context, t *testing.T, player *SoakPlayer, rnd *rand.Rand) {
+	for {
+		jitter := time.Duration(rnd.Int63n(int64(run.st.ActionInterval))) - run.st.ActionInterval/2
+		select {
+		case <-runCtx.Done():
+			return
+		case <-run.st.Stop:
+			return
+		case <-time.After(run.st.ActionInterval + jitter):
+		}
+		action := run.nextAction(player, rnd)
+		err := run.act(ctx, t, player, action)
+		if ctx.Err() != nil {
+			// action interrupted by canceling the run is not counted
+			return
+		}
+		run.mu.Lock()
+		player.Actions++
+		run.cp.Actions[action]++
+		if err != nil {
+			player.Failures++
+			run.cp.Failures[action]++
+		}
+		run.mu.Unlock()
+		if err != nil {
+			t.WithFields(testing.Fields{
+				"player": player.Key,
+				"action": action,
+				"error":  err,
+			}).Debug("soak action failed")
+		}
+	}
+}
+
+// nextAction is a function to choose next action of player by its stage and holdings
+func (run *soakRun) nextAction(player *SoakPlayer, rnd *rand.Rand) string {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	switch player.Stage {
+	case SoakStageNew:
+		return SoakActionCreateAccount
+	case SoakStageCreated:
+		return SoakActionGetPylons
+	}
+	actions := []string{SoakActionExecuteRecipe, SoakActionExecuteRecipe}
+	if len(player.PendingExecIDs) > 0 {
+		actions = append(actions, SoakActionCheckExecution)
+	}
+	if len(player.ItemIDs) > 0 {
+		actions = append(actions, SoakActionSell)
+	}
+	for _, owner := range run.market {
+		if owner != player.Address {
+			actions = append(actions, SoakActionBuy, SoakActionBuy)
+			break
+		}
+	}
+	return actions[rnd.Intn(len(actions))]
+}
+
+// act is a function to run an action of player
+func (run *soakRun) act(ctx context.Context, t *testing.T, player *SoakPlayer, action string) error {
+	switch action {
+	case SoakActionCreateAccount:
+		return run.createAccount(ctx, t, player)
+	case SoakActionGetPylons:
+		msg := types.NewMsgGetPylons(run.st.GetPylons, player.Address)
+		if _, err := run.sendTx(ctx, t, SignerAddress(player.Address), &msg); err != nil {
+			return err
+		}
+		run.mu.Lock()
+		defer run.mu.Unlock()
+		run.cp.Granted = run.cp.Granted.Add(run.st.GetPylons...)
+		player.Stage = SoakStageFunded
+		return nil
+	case SoakActionExecuteRecipe:
+		msg := types.NewMsgExecuteRecipe(run.st.RecipeID, player.Address, []string{})
+		return run.sendExecution(ctx, t, player, &msg)
+	case SoakActionCheckExecution:
+		run.mu.Lock()
+		execID := player.PendingExecIDs[0]
+		run.mu.Unlock()
+		return run.checkExecution(ctx, t, player, execID)
+	case SoakActionSell:
+		return run.sell(ctx, t, player)
+	case SoakActionBuy:
+		return run.buy(ctx, t, player)
+	}
+	return fmt.Errorf("unknown soak action %s", action)
+}
+
+// checkExecution is a function to check pending execution of player when it's ready
+// Execution is no longer pending for player once it's completed, not ready execution is kept to be checked later.
+func (run *soakRun) checkExecution(ctx context.Context, t *testing.T, player *SoakPlayer, execID string) error {
+	exec, err := DecodeExecution(execID)
+	if err != nil {
+		return err
+	}
+	if exec.Status == ExecutionPending {
+		if _, _, err = queryDaemonStatus(ctx); err != nil || EnvFromContext(ctx).state().blocks.latestHeight() < exec.ReadyHeight {
+			return err
+		}
+		msg := types.NewMsgCheckExecution(execID, false, player.Address)
+		if err = run.sendExecution(ctx, t, player, &msg); err != nil {
+			return err
+		}
+	}
+	run.mu.Lock()
+	player.PendingExecIDs = removeString(player.PendingExecIDs, execID)
+	run.mu.Unlock()
+	return nil
+}
+
+// drain is a function to check ready executions left by players so that only stuck executions stay pending
+func (run *soakRun) drain(ctx context.Context, t *testing.T) {
+	for _, player := range run.players {
+		run.mu.Lock()
+		execIDs := append([]string{}, player.PendingExecIDs...)
+		run.mu.Unlock()
+		for _, execID := range execIDs {
+			if err := run.checkExecution(ctx, t, player, execID); err != nil {
+				t.WithFields(testing.Fields{
+					"player":  player.Key,
+					"exec_id": execID,
+					"error":   err,
+				}).Debug("error checking execution before invariant check")
+			}
+		}
+	}
+}
+
+// createAccount is a function to create key of player natively in keyring and its account on chain
+func (run *soakRun) createAccount(ctx context.Context, t *testing.T, player *SoakPlayer) error {
+	kr, err := keyring.New(sdk.KeyringServiceName(), keyring.BackendTest, run.st.KeyringDir, nil)
+	if err != nil {
+		return err
+	}
+	info, err := kr.Key(player.Key)
+	if err != nil {
+		// key is created once, a resumed run reuses it
+		var mnemonic string
+		info, mnemonic, err = kr.NewMnemonic(player.Key, keyring.English, DefaultHDPath(), hd.Secp256k1)
+		if err != nil {
+			return err
+		}
+		rememberMnemonic(TestKeyring{Dir: run.st.KeyringDir}, player.Key, mnemonic)
+	}
+	msg := types.NewMsgCreateAccount(info.GetAddress().String())
+	if _, err = run.sendTx(ctx, t, SignerKey(player.Key), &msg); err != nil {
+		return err
+	}
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	player.Address = info.GetAddress().String()
+	player.Stage = SoakStageCreated
+	return nil
+}
+
+// sendTx is a function to send transaction of signer and record fee paid by it when it's committed
+// A transaction failing in DeliverTx pays fee as well, transactions refused by CheckTx don't.
+func (run *soakRun) sendTx(ctx context.Context, t *testing.T, signer Signer, msgs ...sdk.Msg) (TxResult, error) {
+	txResult, err := run.client.SendTxAndWait(ctx, t, signer, msgs...)
+	if txResult.Height == 0 {
+		return txResult, err
+	}
+	fee, feeErr := txResult.Fee()
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	if feeErr != nil {
+		t.WithFields(testing.Fields{
+			"txhash": txResult.TxHash,
+			"error":  feeErr,
+		}).Warn("fee of soak transaction is unknown")
+		run.cp.FeeUnknownTxs++
+	} else {
+		run.cp.Fees = run.cp.Fees.Add(fee...)
+	}
+	return txResult, err
+}
+
+// sendExecution is a function to send execute recipe or check execution and record its outputs for player
+func (run *soakRun) sendExecution(ctx context.Context, t *testing.T, player *SoakPlayer, msg sdk.Msg) error {
+	txResult, err := run.sendTx(ctx, t, SignerAddress(player.Address), msg)
+	if err != nil {
+		return err
+	}
+	itemIDs, err := txResult.GetCreatedItemIDs()
+	if err != nil {
+		return err
+	}
+	paid := executionPaidCoins(txResult)
+	execID, execErr := txResult.GetExecID()
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	player.ItemIDs = append(player.ItemIDs, itemIDs...)
+	run.cp.PaidOut = run.cp.PaidOut.Add(paid...)
+	if execErr == nil {
+		player.PendingExecIDs = append(player.PendingExecIDs, execID)
+	}
+	return nil
+}
+
+// executionPaidCoins is a function to get coins paid out by execute recipe and check execution msgs of transaction
+func executionPaidCoins(txResult TxResult) sdk.Coins {
+	paid := sdk.Coins{}
+	for idx, msgData := range txResult.MsgData {
+		var output []byte
+		switch msgData.MsgType {
+		case (types.MsgExecuteRecipe{}).Type():
+			resp := types.MsgExecuteRecipeResponse{}
+			if txResult.GetMsgResponse(idx, msgData.MsgType, &resp) != nil {
+				continue
+			}
+			output = resp.Output
+		case (types.MsgCheckExecution{}).Type():
+			resp := types.MsgCheckExecutionResponse{}
+			if txResult.GetMsgResponse(idx, msgData.MsgType, &resp) != nil {
+				continue
+			}
+			output = resp.Output
+		default:
+			continue
+		}
+		coins, _, err := DecodeExecutionOutput(output)
+		if err != nil {
+			continue // scheduled execution
+		}
+		paid = paid.Add(coins...)
+	}
+	return paid
+}
+
+// sell is a function to list first item of player for price
+func (run *soakRun) sell(ctx context.Context, t *testing.T, player *SoakPlayer) error {
+	run.mu.Lock()
+	itemID := player.ItemIDs[0]
+	run.mu.Unlock()
+	item, err := GetItemByGUID(itemID)
+	if err != nil {
+		return err
+	}
+	msg := types.NewMsgCreateTrade(
+		types.CoinInputList{{Coin: types.Pylon, Count: run.st.Price}},
+		types.TradeItemInputList{},
+		sdk.Coins{},
+		types.ItemList{item},
+		"soak trade",
+		player.Address,
+	)
+	txResult, err := run.sendTx(ctx, t, SignerAddress(player.Address), &msg)
+	if err != nil {
+		return err
+	}
+	resp := types.MsgCreateTradeResponse{}
+	if err = txResult.GetMsgResponse(0, msg.Type(), &resp); err != nil {
+		return err
+	}
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	player.ItemIDs = removeString(player.ItemIDs, itemID)
+	player.OpenTrades = append(player.OpenTrades, SoakTrade{TradeID: resp.TradeID, ItemID: itemID, Price: run.st.Price})
+	run.market[resp.TradeID] = player.Address
+	return nil
+}
+
+// buy is a function to fulfill a trade of another player, the trade is taken off the market while it's being fulfilled
+func (run *soakRun) buy(ctx context.Context, t *testing.T, player *SoakPlayer) error {
+	run.mu.Lock()
+	tradeIDs := []string{}
+	for tradeID, owner := range run.market {
+		if owner != player.Address {
+			tradeIDs = append(tradeIDs, tradeID)
+		}
+	}
+	if len(tradeIDs) == 0 {
+		run.mu.Unlock()
+		return nil
+	}
+	sort.Strings(tradeIDs)
+	tradeID := tradeIDs[player.Actions%len(tradeIDs)]
+	seller := run.market[tradeID]
+	delete(run.market, tradeID)
+	run.mu.Unlock()
+
+	msg := types.NewMsgFulfillTrade(tradeID, player.Address, []string{})
+	if _, err := run.sendTx(ctx, t, SignerAddress(player.Address), &msg); err != nil {
+		run.mu.Lock()
+		run.market[tradeID] = seller
+		run.mu.Unlock()
+		return err
+	}
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	for _, sellerPlayer := range run.players {
+		if sellerPlayer.Address != seller {
+			continue
+		}
+		for idx, trade := range sellerPlayer.OpenTrades {
+			if trade.TradeID == tradeID {
+				player.ItemIDs = append(player.ItemIDs, trade.ItemID)
+				sellerPlayer.OpenTrades = append(sellerPlayer.OpenTrades[:idx], sellerPlayer.OpenTrades[idx+1:]...)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// removeString is a function to remove first occurrence of value from values
+func removeString(values []string, value string) []string {
+	for idx, v := range values {
+		if v == value {
+			return append(values[:idx:idx], values[idx+1:]...)
+		}
+	}
+	return values
+}
+
+// checkCoinConservation is a function to compare total balances of players and sinks plus fees they paid with expected total
+func checkCoinConservation(expected, actual, fees sdk.Coins) []SoakViolation {
+	violations := []SoakViolation{}
+	denoms := map[string]bool{}
+	for _, coin := range expected.Add(actual...).Add(fees...) {
+		denoms[coin.Denom] = true
+	}
+	sorted := []string{}
+	for denom := range denoms {
+		sorted = append(sorted, denom)
+	}
+	sort.Strings(sorted)
+	for _, denom := range sorted {
+		if !expected.AmountOf(denom).Equal(actual.AmountOf(denom).Add(fees.AmountOf(denom))) {
+			violations = append(violations, SoakViolation{
+				Invariant: "coins_conserved",
+				Message: fmt.Sprintf("total %s of players and sinks is %s with %s paid as fees, expected %s",
+					denom, actual.AmountOf(denom), fees.AmountOf(denom), expected.AmountOf(denom)),
+			})
+		}
+	}
+	return violations
+}
+
+// checkInvariants is a function to check total coins are conserved and no execution is stuck after the run
+func (run *soakRun) checkInvariants(ctx context.Context) ([]SoakViolation, error) {
+	addresses := append([]string{}, run.cp.Sinks...)
+	for _, player := range run.players {
+		if len(player.Address) > 0 {
+			addresses = append(addresses, player.Address)
+		}
+	}
+	actual, err := totalBalances(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+	expected := run.cp.SinkBalances.Add(run.cp.Granted...).Add(run.cp.PaidOut...)
+	violations := checkCoinConservation(expected, actual, run.cp.Fees)
+	if run.cp.FeeUnknownTxs > 0 {
+		violations = append(violations, SoakViolation{
+			Invariant: "coins_conserved",
+			Message:   fmt.Sprintf("fees of %d transactions are unknown, total coins can't be checked", run.cp.FeeUnknownTxs),
+		})
+	}
+
+	if _, _, err = queryDaemonStatus(ctx); err != nil {
+		return violations, err
+	}
+	height := EnvFromContext(ctx).state().blocks.latestHeight()
+	for _, player := range run.players {
+		if len(player.Address) == 0 {
+			continue
+		}
+		pending, err := ListPendingExecutions(player.Address)
+		if err != nil {
+			return violations, err
+		}
+		for _, exec := range pending {
+			if height-exec.ReadyHeight >= run.st.StuckBlocks {
+				violations = append(violations, SoakViolation{
+					Invariant: "no_stuck_executions",
+					Message:   fmt.Sprintf("execution %s of %s is pending %d blocks after ready height %d", exec.ExecID, player.Key, height-exec.ReadyHeight, exec.ReadyHeight),
+				})
+			}
+		}
+	}
+	return violations, nil
+}
@@ -0,0 +1,75 @@
+package inttest
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSoakCheckpoint(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	dir, err := ioutil.TempDir("", "soak")
+	t.MustNil(err, "error creating temp dir")
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "checkpoint.json")
+
+	cp, err := ReadSoakCheckpoint(filename)
+	t.MustTrue(err == nil && cp == nil, "missing checkpoint should start a new run")
+
+	st := SoakTest{Players: 3, Seed: 7, RecipeID: "recipe1"}
+	written := st.newSoakCheckpoint()
+	written.Elapsed = 90 * time.Minute
+	written.Granted = sdk.NewCoins(sdk.NewInt64Coin("pylon", 3000))
+	written.Fees = sdk.NewCoins(sdk.NewInt64Coin("pylon", 20))
+	written.Players[1].OpenTrades = []SoakTrade{{TradeID: "trade1", ItemID: "item1", Price: 10}}
+	t.MustNil(WriteSoakCheckpoint(written, filename), "error writing checkpoint")
+
+	cp, err = ReadSoakCheckpoint(filename)
+	t.MustNil(err, "error reading checkpoint")
+	t.WithFields(testing.Fields{
+		"checkpoint": cp,
+	}).MustTrue(cp.Elapsed == written.Elapsed && cp.RecipeID == "recipe1" && len(cp.Players) == 3 && cp.Players[2].Key == "soak_player_2" &&
+		cp.Players[1].OpenTrades[0].TradeID == "trade1" && cp.Granted.IsEqual(written.Granted) && cp.Fees.IsEqual(written.Fees), "checkpoint should be restored as written")
+}
+
+func TestSoakNextAction(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	seller := &SoakPlayer{Key: "seller", Address: "seller_addr", Stage: SoakStageFunded, ItemIDs: []string{"item1"}}
+	buyer := &SoakPlayer{Key: "buyer", Address: "buyer_addr", Stage: SoakStageFunded}
+	run := &soakRun{players: []*SoakPlayer{seller, buyer}, market: map[string]string{"trade1": "seller_addr"}}
+	rnd := rand.New(rand.NewSource(1))
+
+	t.MustTrue(run.nextAction(&SoakPlayer{Stage: SoakStageNew}, rnd) == SoakActionCreateAccount, "new player should create account")
+	t.MustTrue(run.nextAction(&SoakPlayer{Stage: SoakStageCreated}, rnd) == SoakActionGetPylons, "created player should get pylons")
+	for i := 0; i < 50; i++ {
+		action := run.nextAction(seller, rnd)
+		t.MustTrue(action == SoakActionExecuteRecipe || action == SoakActionSell, "seller should not buy own trade")
+		action = run.nextAction(buyer, rnd)
+		t.MustTrue(action == SoakActionExecuteRecipe || action == SoakActionBuy, "buyer without items should not sell")
+	}
+}
+
+func TestCheckCoinConservation(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	expected := sdk.NewCoins(sdk.NewInt64Coin("pylon", 1000), sdk.NewInt64Coin("gold", 5))
+	t.MustTrue(len(checkCoinConservation(expected, expected, sdk.Coins{})) == 0, "same totals should be conserved")
+	fees := sdk.NewCoins(sdk.NewInt64Coin("pylon", 10))
+	t.MustTrue(len(checkCoinConservation(expected, expected.Sub(fees), fees)) == 0, "fees paid should be conserved")
+
+	violations := checkCoinConservation(expected, sdk.NewCoins(sdk.NewInt64Coin("pylon", 990), sdk.NewInt64Coin("gold", 5), sdk.NewInt64Coin("silver", 1)), sdk.Coins{})
+	t.WithFields(testing.Fields{
+		"violations": violations,
+	}).MustTrue(len(violations) == 2 && violations[0].Invariant == "coins_conserved", "changed and unexpected denoms should be violations")
+
+	values := removeString([]string{"a", "b", "a"}, "a")
+	t.MustTrue(len(values) == 2 && values[0] == "b" && values[1] == "a", "only first occurrence should be removed")
+}
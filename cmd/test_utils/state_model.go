@@ -0,0 +1,766 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/coins"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/config"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/events"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/gogo/protobuf/proto"
+)
+
+// StateKindBalance is the kind of balance entries compared by StateModel
+const StateKindBalance = "balance"
+
+// describes the fields of a divergence between model and chain when an entry exists only on one side
+const (
+	ModelFieldMissing    = "missing"    // entry is in the model but not on chain
+	ModelFieldUnexpected = "unexpected" // entry is on chain but not in the model
+)
+
+// ModelDivergence is a struct to describe a difference of chain state from state expected by StateModel
+type ModelDivergence struct {
+	Address  string `json:"address"`
+	Kind     string `json:"kind"`
+	ID       string `json:"id"`
+	Field    string `json:"field"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// String is a function to get readable description of divergence
+func (d ModelDivergence) String() string {
+	switch d.Field {
+	case ModelFieldMissing:
+		return fmt.Sprintf("%s %s of %s is missing on chain", d.Kind, d.ID, d.Address)
+	case ModelFieldUnexpected:
+		return fmt.Sprintf("%s %s of %s is on chain but not expected", d.Kind, d.ID, d.Address)
+	}
+	return fmt.Sprintf("%s %s of %s has %s %q, expected %q", d.Kind, d.ID, d.Address, d.Field, d.Actual, d.Expected)
+}
+
+// ModelReport is a struct to describe result of reconciling StateModel against chain
+type ModelReport struct {
+	Height      int64
+	Divergences []ModelDivergence
+	// InFlight are addresses not compared because transactions involving them are not committed yet
+	InFlight []string
+	// Resynced are addresses whose balance was not predictable by the model and is taken from chain
+	Resynced []string
+}
+
+// modelTx is a struct to keep transaction sent by a tracked client until its result is applied
+type modelTx struct {
+	msgs     []sdk.Msg
+	attempts int64
+}
+
+// modelExecution is a struct to keep scheduled execution until it's checked
+type modelExecution struct {
+	recipeID string
+	itemIDs  []string
+}
+
+// StateModel is a struct to keep local model of cookbooks, recipes, items and balances expected from transactions sent
+// It implements TxRecorder, msgs of recorded transactions are applied when their results are committed
+// and Reconcile compares the model against chain queries of tracked addresses.
+// Signers of recorded transactions are tracked automatically, state of a tracked address is taken from chain when it's first reconciled.
+// Balance changes the model can't predict e.g. item transfer fees make the address balance resynced from chain instead of compared.
+type StateModel struct {
+	mu         sync.Mutex
+	cookbooks  map[string]types.Cookbook
+	recipes    map[string]types.Recipe
+	items      map[string]string // item id to owner
+	balances   map[string]sdk.Coins
+	tracked    map[string]bool // address to whether it's seeded from chain
+	pending    map[string]*modelTx
+	executions map[string]modelExecution
+}
+
+// NewStateModel is a function to create empty state model
+func NewStateModel() *StateModel {
+	return &StateModel{
+		cookbooks:  make(map[string]types.Cookbook),
+		recipes:    make(map[string]types.Recipe),
+		items:      make(map[string]string),
+		balances:   make(map[string]sdk.Coins),
+		tracked:    make(map[string]bool),
+		pending:    make(map[string]*modelTx),
+		executions: make(map[string]modelExecution),
+	}
+}
+
+// Track is a function to add addresses compared by Reconcile, their state is taken from chain on next reconcile
+func (m *StateModel) Track(addresses ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, addr := range addresses {
+		if _, ok := m.tracked[addr]; !ok {
+			m.tracked[addr] = false
+		}
+	}
+}
+
+// RecordTx is a function to keep msgs of broadcast transaction until its result is committed
+func (m *StateModel) RecordTx(msgs []sdk.Msg, output string, err error) {
+	if err != nil || len(msgs) == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending[output] = &modelTx{msgs: msgs}
+	for _, addr := range msgSigners(msgs) {
+		if _, ok := m.tracked[addr]; !ok {
+			m.tracked[addr] = false
+		}
+	}
+}
+
+// RecordTxResult is a function to apply transaction result parsed by WaitForTxResult
+func (m *StateModel) RecordTxResult(txhash string, txResult TxResult, err error) {
+	if len(txResult.TxHash) == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if tx, ok := m.pending[txhash]; ok {
+		delete(m.pending, txhash)
+		m.applyTx(tx.msgs, txResult)
+	}
+}
+
+// stateModelChain is an interface of chain queries used to reconcile the model, implemented by Transport
+type stateModelChain interface {
+	LatestHeight(ctx context.Context) (int64, error)
+	Tx(ctx context.Context, txhash string) (TxResult, error)
+	Balances(ctx context.Context, addr string) (sdk.Coins, error)
+	ListCookbooks(ctx context.Context, addr string) ([]types.Cookbook, error)
+	ListRecipes(ctx context.Context, addr string) ([]types.Recipe, error)
+	ItemsBySender(ctx context.Context, sender string) ([]types.Item, error)
+}
+
+// modelAccountState is a struct to keep chain state of an address queried by reconcile
+type modelAccountState struct {
+	cookbooks []types.Cookbook
+	recipes   []types.Recipe
+	items     []types.Item
+	balance   sdk.Coins
+}
+
+// Reconcile is a function to apply committed transactions and compare the model against chain state of tracked addresses
+// Divergences are sorted by address, kind, ID and field.
+func (m *StateModel) Reconcile(ctx context.Context) (ModelReport, error) {
+	transport, err := GetTransport()
+	if err != nil {
+		return ModelReport{}, err
+	}
+	return m.reconcile(ctx, transport)
+}
+
+func (m *StateModel) reconcile(ctx context.Context, chain stateModelChain) (ModelReport, error) {
+	report := ModelReport{}
+	m.resolvePending(ctx, chain)
+
+	m.mu.Lock()
+	addresses := []string{}
+	for addr := range m.tracked {
+		addresses = append(addresses, addr)
+	}
+	m.mu.Unlock()
+	sort.Strings(addresses)
+
+	var err error
+	if report.Height, err = chain.LatestHeight(ctx); err != nil {
+		return report, fmt.Errorf("error getting latest height: %w", err)
+	}
+	states := make(map[string]modelAccountState)
+	for _, addr := range addresses {
+		state, err := queryModelAccountState(ctx, chain, addr)
+		if err != nil {
+			return report, err
+		}
+		states[addr] = state
+	}
+	// transactions committed while state was queried make the state newer than the model, so resolve them again
+	// and skip addresses they involve until next reconcile
+	committed := m.resolvePending(ctx, chain)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	inFlight := m.inFlightAddresses()
+	for addr := range committed {
+		inFlight[addr] = true
+	}
+	for _, addr := range addresses {
+		if inFlight[addr] {
+			report.InFlight = append(report.InFlight, addr)
+			continue
+		}
+		state := states[addr]
+		if !m.tracked[addr] {
+			m.seed(addr, state)
+			continue
+		}
+		report.Divergences = append(report.Divergences, m.diff(addr, state)...)
+		if _, ok := m.balances[addr]; !ok {
+			m.balances[addr] = state.balance
+			report.Resynced = append(report.Resynced, addr)
+		}
+	}
+	sortModelDivergences(report.Divergences)
+	return report, nil
+}
+
+// WatchBlocks is a function to reconcile the model after each block until ctx is done, report is passed to onReport
+func (m *StateModel) WatchBlocks(ctx context.Context, onReport func(report ModelReport, err error)) {
+	for {
+		if err := WaitForNextBlockCtx(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			onReport(ModelReport{}, err)
+			continue
+		}
+		report, err := m.Reconcile(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		onReport(report, err)
+	}
+}
+
+// resolvePending is a function to apply results of committed pending transactions, it returns addresses they involve
+// Transactions not found for maximum wait blocks are dropped and addresses they involve are taken from chain again.
+func (m *StateModel) resolvePending(ctx context.Context, chain stateModelChain) map[string]bool {
+	m.mu.Lock()
+	hashes := []string{}
+	for txhash := range m.pending {
+		hashes = append(hashes, txhash)
+	}
+	m.mu.Unlock()
+	sort.Strings(hashes)
+
+	committed := make(map[string]bool)
+	for _, txhash := range hashes {
+		txResult, err := chain.Tx(ctx, txhash)
+		m.mu.Lock()
+		tx, ok := m.pending[txhash]
+		if !ok {
+			// applied by RecordTxResult meanwhile
+			m.mu.Unlock()
+			continue
+		}
+		if err != nil {
+			tx.attempts++
+			if tx.attempts > GetMaxWaitBlock() {
+				delete(m.pending, txhash)
+				for _, addr := range m.involvedAddresses(tx.msgs) {
+					m.untrack(addr)
+				}
+			}
+			m.mu.Unlock()
+			continue
+		}
+		delete(m.pending, txhash)
+		for _, addr := range m.involvedAddresses(tx.msgs) {
+			committed[addr] = true
+		}
+		m.applyTx(tx.msgs, txResult)
+		m.mu.Unlock()
+	}
+	return committed
+}
+
+// inFlightAddresses is a function to get addresses involved in pending transactions
+func (m *StateModel) inFlightAddresses() map[string]bool {
+	addresses := make(map[string]bool)
+	for _, tx := range m.pending {
+		for _, addr := range m.involvedAddresses(tx.msgs) {
+			addresses[addr] = true
+		}
+	}
+	return addresses
+}
+
+// involvedAddresses is a function to get addresses whose state msgs can change
+// Cookbook owners get recipe fees and item transfer fees, and pylons llc gets its share of them.
+func (m *StateModel) involvedAddresses(msgs []sdk.Msg) []string {
+	addresses := append(msgSigners(msgs), config.Config.Validators.PylonsLLC)
+	for _, msg := range msgs {
+		switch msg := msg.(type) {
+		case *types.MsgSendCoins:
+			addresses = append(addresses, msg.Receiver)
+		case *types.MsgSendItems:
+			addresses = append(append(addresses, msg.Receiver), m.cookbookOwners()...)
+		case *types.MsgFulfillTrade:
+			addresses = append(addresses, m.cookbookOwners()...)
+		case *types.MsgExecuteRecipe:
+			addresses = append(addresses, m.cookbookOwner(msg.RecipeID))
+		case *types.MsgCheckExecution:
+			addresses = append(addresses, m.cookbookOwner(m.executions[msg.ExecID].recipeID))
+		}
+	}
+	return addresses
+}
+
+func queryModelAccountState(ctx context.Context, chain stateModelChain, addr string) (modelAccountState, error) {
+	state := modelAccountState{}
+	var err error
+	if state.cookbooks, err = chain.ListCookbooks(ctx, addr); err != nil {
+		return state, fmt.Errorf("error listing cookbooks of %s: %w", addr, err)
+	}
+	if state.recipes, err = chain.ListRecipes(ctx, addr); err != nil {
+		return state, fmt.Errorf("error listing recipes of %s: %w", addr, err)
+	}
+	if state.items, err = chain.ItemsBySender(ctx, addr); err != nil {
+		return state, fmt.Errorf("error listing items of %s: %w", addr, err)
+	}
+	if state.balance, err = chain.Balances(ctx, addr); err != nil {
+		return state, fmt.Errorf("error getting balance of %s: %w", addr, err)
+	}
+	return state, nil
+}
+
+// seed is a function to take state of address from chain when it's first tracked
+func (m *StateModel) seed(addr string, state modelAccountState) {
+	m.untrack(addr)
+	for _, cb := range state.cookbooks {
+		m.cookbooks[cb.ID] = cb
+	}
+	for _, rcp := range state.recipes {
+		m.recipes[rcp.ID] = rcp
+	}
+	for _, item := range state.items {
+		m.items[item.ID] = addr
+	}
+	m.balances[addr] = state.balance
+	m.tracked[addr] = true
+}
+
+// untrack is a function to forget state of address so that it's taken from chain again on next reconcile
+func (m *StateModel) untrack(addr string) {
+	for id, cb := range m.cookbooks {
+		if cb.Sender == addr {
+			delete(m.cookbooks, id)
+		}
+	}
+	for id, rcp := range m.recipes {
+		if rcp.Sender == addr {
+			delete(m.recipes, id)
+		}
+	}
+	for id, owner := range m.items {
+		if owner == addr {
+			delete(m.items, id)
+		}
+	}
+	delete(m.balances, addr)
+	if _, ok := m.tracked[addr]; ok {
+		m.tracked[addr] = false
+	}
+}
+
+// diff is a function to compare model of address with its chain state
+func (m *StateModel) diff(addr string, state modelAccountState) []ModelDivergence {
+	divergences := []ModelDivergence{}
+	entry := func(kind, id, field, expected, actual string) {
+		divergences = append(divergences, ModelDivergence{Address: addr, Kind: kind, ID: id, Field: field, Expected: expected, Actual: actual})
+	}
+
+	chainCookbooks := make(map[string]types.Cookbook)
+	for _, cb := range state.cookbooks {
+		chainCookbooks[cb.ID] = cb
+	}
+	for id, expected := range m.cookbooks {
+		if expected.Sender != addr {
+			continue
+		}
+		actual, ok := chainCookbooks[id]
+		if !ok {
+			entry(StateKindCookbook, id, ModelFieldMissing, "", "")
+			continue
+		}
+		delete(chainCookbooks, id)
+		for _, f := range cookbookModelFields(expected, actual) {
+			entry(StateKindCookbook, id, f[0], f[1], f[2])
+		}
+	}
+	for id := range chainCookbooks {
+		entry(StateKindCookbook, id, ModelFieldUnexpected, "", "")
+	}
+
+	chainRecipes := make(map[string]types.Recipe)
+	for _, rcp := range state.recipes {
+		chainRecipes[rcp.ID] = rcp
+	}
+	for id, expected := range m.recipes {
+		if expected.Sender != addr {
+			continue
+		}
+		actual, ok := chainRecipes[id]
+		if !ok {
+			entry(StateKindRecipe, id, ModelFieldMissing, "", "")
+			continue
+		}
+		delete(chainRecipes, id)
+		for _, f := range recipeModelFields(expected, actual) {
+			entry(StateKindRecipe, id, f[0], f[1], f[2])
+		}
+	}
+	for id := range chainRecipes {
+		entry(StateKindRecipe, id, ModelFieldUnexpected, "", "")
+	}
+
+	chainItems := make(map[string]bool)
+	for _, item := range state.items {
+		chainItems[item.ID] = true
+	}
+	for id, owner := range m.items {
+		if owner != addr {
+			continue
+		}
+		if !chainItems[id] {
+			entry(StateKindItem, id, ModelFieldMissing, "", "")
+		}
+		delete(chainItems, id)
+	}
+	for id := range chainItems {
+		entry(StateKindItem, id, ModelFieldUnexpected, "", "")
+	}
+
+	if expected, ok := m.balances[addr]; ok {
+		denoms := make(map[string]bool)
+		for _, coin := range expected.Add(state.balance...) {
+			denoms[coin.Denom] = true
+		}
+		for denom := range denoms {
+			if e, a := expected.AmountOf(denom), state.balance.AmountOf(denom); !e.Equal(a) {
+				entry(StateKindBalance, denom, "amount", e.String(), a.String())
+			}
+		}
+		m.balances[addr] = state.balance
+	}
+	return divergences
+}
+
+// cookbookModelFields is a function to get name, expected and actual value of cookbook fields which differ
+func cookbookModelFields(expected, actual types.Cookbook) [][3]string {
+	return differentModelFields([][3]string{
+		{"Name", expected.Name, actual.Name},
+		{"Description", expected.Description, actual.Description},
+		{"Version", expected.Version, actual.Version},
+		{"Developer", expected.Developer, actual.Developer},
+		{"SupportEmail", expected.SupportEmail, actual.SupportEmail},
+		{"Level", strconv.FormatInt(expected.Level, 10), strconv.FormatInt(actual.Level, 10)},
+		{"CostPerBlock", strconv.FormatInt(expected.CostPerBlock, 10), strconv.FormatInt(actual.CostPerBlock, 10)},
+	})
+}
+
+// recipeModelFields is a function to get name, expected and actual value of recipe fields which differ
+func recipeModelFields(expected, actual types.Recipe) [][3]string {
+	return differentModelFields([][3]string{
+		{"Name", expected.Name, actual.Name},
+		{"CookbookID", expected.CookbookID, actual.CookbookID},
+		{"Description", expected.Description, actual.Description},
+		{"BlockInterval", strconv.FormatInt(expected.BlockInterval, 10), strconv.FormatInt(actual.BlockInterval, 10)},
+		{"Disabled", strconv.FormatBool(expected.Disabled), strconv.FormatBool(actual.Disabled)},
+		{"CoinInputs", recipeCoinInputs(expected).String(), recipeCoinInputs(actual).String()},
+	})
+}
+
+func differentModelFields(fields [][3]string) [][3]string {
+	different := [][3]string{}
+	for _, f := range fields {
+		if f[1] != f[2] {
+			different = append(different, f)
+		}
+	}
+	return different
+}
+
+func sortModelDivergences(divergences []ModelDivergence) {
+	sort.Slice(divergences, func(i, j int) bool {
+		a, b := divergences[i], divergences[j]
+		if a.Address != b.Address {
+			return a.Address < b.Address
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Field < b.Field
+	})
+}
+
+// recipeCoinInputs is a function to get coins paid to execute recipe
+func recipeCoinInputs(rcp types.Recipe) sdk.Coins {
+	inputs := sdk.Coins{}
+	for _, input := range rcp.CoinInputs {
+		inputs = inputs.Add(sdk.NewInt64Coin(input.Coin, input.Count))
+	}
+	return inputs
+}
+
+// msgSigners is a function to get signers of msgs in bech32
+func msgSigners(msgs []sdk.Msg) []string {
+	signers := []string{}
+	for _, msg := range msgs {
+		for _, signer := range msg.GetSigners() {
+			signers = append(signers, signer.String())
+		}
+	}
+	return signers
+}
+
+// txFee is a function to get fee and payer of transaction, payer is empty when transaction is not decodable
+func txFee(txResult TxResult, msgs []sdk.Msg) (sdk.Coins, string) {
+	if txResult.Tx == nil {
+		return nil, ""
+	}
+	tx := txtypes.Tx{}
+	if err := proto.Unmarshal(txResult.Tx.Value, &tx); err != nil || tx.AuthInfo == nil || tx.AuthInfo.Fee == nil {
+		return nil, ""
+	}
+	payer := tx.AuthInfo.Fee.Payer
+	if len(payer) == 0 {
+		if signers := msgSigners(msgs); len(signers) > 0 {
+			payer = signers[0]
+		}
+	}
+	return tx.AuthInfo.Fee.Amount, payer
+}
+
+// applyTx is a function to apply msgs of committed transaction, msgs of failed transaction only pay the fee
+func (m *StateModel) applyTx(msgs []sdk.Msg, txResult TxResult) {
+	fee, payer := txFee(txResult, msgs)
+	if len(payer) == 0 {
+		for _, addr := range msgSigners(msgs) {
+			delete(m.balances, addr)
+		}
+	} else {
+		m.addBalance(payer, fee, true)
+	}
+	if txResult.Code != 0 {
+		return
+	}
+	for idx, msg := range msgs {
+		msgEvents := []events.Event{}
+		if idx < len(txResult.Logs) {
+			// undecodable events leave the entries they describe unexpected
+			msgEvents, _ = events.DefaultRegistry.DecodeEvents(txResult.Logs[idx].Events)
+		}
+		m.applyMsg(msg, msgEvents)
+	}
+}
+
+// addBalance is a function to add coins to expected balance of address, or subtract them when negative is set
+func (m *StateModel) addBalance(addr string, amount sdk.Coins, negative bool) {
+	balance, ok := m.balances[addr]
+	if !ok || amount.Empty() {
+		return
+	}
+	if !negative {
+		m.balances[addr] = balance.Add(amount...)
+		return
+	}
+	// balance going below zero is a divergence the diff reports, so keep the expected balance as close as possible
+	result, isNegative := balance.SafeSub(amount)
+	if isNegative {
+		delete(m.balances, addr)
+		return
+	}
+	m.balances[addr] = result
+}
+
+// unknownBalances is a function to make balances of addresses resynced from chain on next reconcile
+func (m *StateModel) unknownBalances(addresses ...string) {
+	for _, addr := range addresses {
+		delete(m.balances, addr)
+	}
+}
+
+// cookbookOwner is a function to get owner of cookbook of recipe, empty when the recipe is not in the model
+func (m *StateModel) cookbookOwner(recipeID string) string {
+	rcp, ok := m.recipes[recipeID]
+	if !ok {
+		return ""
+	}
+	return m.cookbooks[rcp.CookbookID].Sender
+}
+
+// cookbookOwners is a function to get owners of cookbooks in the model
+func (m *StateModel) cookbookOwners() []string {
+	owners := []string{}
+	for _, cb := range m.cookbooks {
+		owners = append(owners, cb.Sender)
+	}
+	return owners
+}
+
+// applyMsg is a function to apply successful msg with its decoded events
+func (m *StateModel) applyMsg(msg sdk.Msg, msgEvents []events.Event) {
+	llc := config.Config.Validators.PylonsLLC
+	switch msg := msg.(type) {
+	case *types.MsgGetPylons:
+		m.addBalance(msg.Requester, msg.Amount, false)
+	case *types.MsgSendCoins:
+		m.addBalance(msg.Sender, msg.Amount, true)
+		m.addBalance(msg.Receiver, msg.Amount, false)
+	case *types.MsgCreateCookbook:
+		cb := types.Cookbook{
+			ID:           msg.CookbookID,
+			Name:         msg.Name,
+			Description:  msg.Description,
+			Version:      msg.Version,
+			Developer:    msg.Developer,
+			SupportEmail: msg.SupportEmail,
+			Level:        msg.Level,
+			CostPerBlock: msg.CostPerBlock,
+			Sender:       msg.Sender,
+		}
+		for _, event := range msgEvents {
+			if e, ok := event.(events.EventCookbookCreated); ok {
+				cb.ID = e.CookbookID
+			}
+		}
+		m.cookbooks[cb.ID] = cb
+		if fee, err := coins.CookbookFee(msg.Level); err == nil {
+			m.addBalance(msg.Sender, coins.Pylons(fee), true)
+		} else {
+			m.unknownBalances(msg.Sender)
+		}
+		m.unknownBalances(llc)
+	case *types.MsgUpdateCookbook:
+		if cb, ok := m.cookbooks[msg.ID]; ok {
+			cb.Description = msg.Description
+			cb.Version = msg.Version
+			cb.Developer = msg.Developer
+			cb.SupportEmail = msg.SupportEmail
+			m.cookbooks[msg.ID] = cb
+		}
+	case *types.MsgCreateRecipe:
+		rcp := types.Recipe{
+			ID:            msg.RecipeID,
+			CookbookID:    msg.CookbookID,
+			Name:          msg.Name,
+			CoinInputs:    msg.CoinInputs,
+			Description:   msg.Description,
+			BlockInterval: msg.BlockInterval,
+			Sender:        msg.Sender,
+		}
+		for _, event := range msgEvents {
+			if e, ok := event.(events.EventRecipeCreated); ok {
+				rcp.ID = e.RecipeID
+			}
+		}
+		m.recipes[rcp.ID] = rcp
+	case *types.MsgUpdateRecipe:
+		if rcp, ok := m.recipes[msg.ID]; ok {
+			rcp.Name = msg.Name
+			rcp.CookbookID = msg.CookbookID
+			rcp.CoinInputs = msg.CoinInputs
+			rcp.Description = msg.Description
+			rcp.BlockInterval = msg.BlockInterval
+			m.recipes[msg.ID] = rcp
+		}
+	case *types.MsgEnableRecipe:
+		m.setRecipeDisabled(msg.RecipeID, false)
+	case *types.MsgDisableRecipe:
+		m.setRecipeDisabled(msg.RecipeID, true)
+	case *types.MsgExecuteRecipe:
+		m.applyExecuteRecipe(msg, msgEvents)
+	case *types.MsgCheckExecution:
+		for _, event := range msgEvents {
+			if e, ok := event.(events.EventExecutionChecked); ok {
+				exec := m.executions[e.ExecID]
+				delete(m.executions, e.ExecID)
+				m.moveItems(exec.itemIDs, "")
+				m.moveItems(e.OutputItemIDs, msg.Sender)
+				m.unknownBalances(msg.Sender, m.cookbookOwner(e.RecipeID), llc)
+			}
+		}
+	case *types.MsgSendItems:
+		m.moveItems(msg.ItemIDs, msg.Receiver)
+		// transfer fees of items are paid to their cookbook owners which the model may not know
+		m.unknownBalances(append(m.cookbookOwners(), msg.Sender, llc)...)
+	case *types.MsgFulfillTrade:
+		for _, event := range msgEvents {
+			if e, ok := event.(events.EventTradeFulfilled); ok {
+				m.moveItems(e.InputItemIDs, e.Sender)
+				m.moveItems(e.OutputItemIDs, e.Fulfiller)
+				m.unknownBalances(append(m.cookbookOwners(), e.Sender, e.Fulfiller, llc)...)
+			}
+		}
+	case *types.MsgFiatItem:
+		for _, event := range msgEvents {
+			if e, ok := event.(events.EventItemCreated); ok {
+				m.items[e.ItemID] = msg.Sender
+			}
+		}
+	case *types.MsgCreateTrade, *types.MsgEnableTrade, *types.MsgDisableTrade, *types.MsgUpdateItemString, *types.MsgCreateAccount:
+		// trades are not modeled and items they lock keep their owner
+	default:
+		m.unknownBalances(msgSigners([]sdk.Msg{msg})...)
+	}
+}
+
+// applyExecuteRecipe is a function to apply recipe execution, outputs of scheduled execution are applied when it's checked
+func (m *StateModel) applyExecuteRecipe(msg *types.MsgExecuteRecipe, msgEvents []events.Event) {
+	llc := config.Config.Validators.PylonsLLC
+	owner := m.cookbookOwner(msg.RecipeID)
+	for _, event := range msgEvents {
+		e, ok := event.(events.EventRecipeExecuted)
+		if !ok {
+			continue
+		}
+		if len(e.ExecID) > 0 {
+			// coin inputs are locked until the execution is checked
+			m.executions[e.ExecID] = modelExecution{recipeID: msg.RecipeID, itemIDs: msg.ItemIDs}
+			m.unknownBalances(msg.Sender, owner, llc)
+			continue
+		}
+		m.moveItems(e.InputItemIDs, "")
+		m.moveItems(e.OutputItemIDs, msg.Sender)
+		m.addBalance(msg.Sender, e.OutputCoins, false)
+		rcp, ok := m.recipes[msg.RecipeID]
+		if !ok {
+			m.unknownBalances(msg.Sender, llc)
+			continue
+		}
+		inputs := recipeCoinInputs(rcp)
+		m.addBalance(msg.Sender, inputs, true)
+		split := coins.RecipeFee(coins.PylonsOf(inputs))
+		m.addBalance(owner, coins.Pylons(split.Receiver), false)
+		m.addBalance(llc, coins.Pylons(split.PylonsLLC), false)
+		if len(inputs) > 1 || (len(inputs) == 1 && inputs[0].Denom != types.Pylon) {
+			m.unknownBalances(owner)
+		}
+	}
+}
+
+// moveItems is a function to set owner of items, items are removed when owner is empty
+func (m *StateModel) moveItems(itemIDs []string, owner string) {
+	for _, id := range itemIDs {
+		if len(owner) == 0 {
+			delete(m.items, id)
+			continue
+		}
+		m.items[id] = owner
+	}
+}
+
+func (m *StateModel) setRecipeDisabled(recipeID string, disabled bool) {
+	if rcp, ok := m.recipes[recipeID]; ok {
+		rcp.Disabled = disabled
+		m.recipes[recipeID] = rcp
+	}
+}
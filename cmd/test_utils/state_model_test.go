@@ -0,0 +1,141 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/coins"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/gogo/protobuf/proto"
+)
+
+type fakeModelChain struct {
+	height    int64
+	txs       map[string]TxResult
+	balances  map[string]sdk.Coins
+	cookbooks map[string][]types.Cookbook
+	items     map[string][]types.Item
+}
+
+func (c *fakeModelChain) LatestHeight(ctx context.Context) (int64, error) { return c.height, nil }
+
+func (c *fakeModelChain) Tx(ctx context.Context, txhash string) (TxResult, error) {
+	if txResult, ok := c.txs[txhash]; ok {
+		return txResult, nil
+	}
+	return TxResult{}, errors.New("tx not found")
+}
+
+func (c *fakeModelChain) Balances(ctx context.Context, addr string) (sdk.Coins, error) {
+	return c.balances[addr], nil
+}
+
+func (c *fakeModelChain) ListCookbooks(ctx context.Context, addr string) ([]types.Cookbook, error) {
+	return c.cookbooks[addr], nil
+}
+
+func (c *fakeModelChain) ListRecipes(ctx context.Context, addr string) ([]types.Recipe, error) {
+	return nil, nil
+}
+
+func (c *fakeModelChain) ItemsBySender(ctx context.Context, sender string) ([]types.Item, error) {
+	return c.items[sender], nil
+}
+
+func modelTxResult(txhash string, fee sdk.Coins, logs sdk.ABCIMessageLogs) TxResult {
+	bz, err := proto.Marshal(&txtypes.Tx{AuthInfo: &txtypes.AuthInfo{Fee: &txtypes.Fee{Amount: fee}}})
+	if err != nil {
+		panic(err)
+	}
+	return TxResult{TxResponse: sdk.TxResponse{
+		TxHash: txhash,
+		Logs:   logs,
+		Tx:     &codectypes.Any{TypeUrl: "/cosmos.tx.v1beta1.Tx", Value: bz},
+	}}
+}
+
+func TestStateModelReconcile(originT *originT.T) {
+	t := testing.NewT(originT)
+	ctx := context.Background()
+	player := sdk.AccAddress([]byte("model_player________")).String()
+	fee := coins.Pylons(1)
+	chain := &fakeModelChain{
+		txs:       map[string]TxResult{},
+		balances:  map[string]sdk.Coins{player: coins.Pylons(100000)},
+		cookbooks: map[string][]types.Cookbook{},
+		items:     map[string][]types.Item{player: {{ID: "item0", Sender: player}}},
+	}
+
+	model := NewStateModel()
+	model.Track(player)
+	report, err := model.reconcile(ctx, chain)
+	t.MustNil(err, "error seeding model")
+	t.MustTrue(len(report.Divergences) == 0 && model.tracked[player], "tracked address should be seeded from chain")
+
+	// get pylons is in flight until its result is committed
+	model.RecordTx([]sdk.Msg{&types.MsgGetPylons{Amount: coins.Pylons(500), Requester: player}}, "tx1", nil)
+	report, err = model.reconcile(ctx, chain)
+	t.MustNil(err, "error reconciling in flight tx")
+	t.MustTrue(len(report.InFlight) > 0 && report.InFlight[0] == player && len(report.Divergences) == 0, "address of pending tx should not be compared")
+
+	chain.txs["tx1"] = modelTxResult("tx1", fee, nil)
+	chain.balances[player] = coins.Pylons(100499)
+	report, err = model.reconcile(ctx, chain)
+	t.MustNil(err, "error reconciling committed tx")
+	t.WithFields(testing.Fields{"divergences": report.Divergences}).MustTrue(len(report.Divergences) == 0, "committed tx should be applied with fee")
+
+	// cookbook is created with id of the event, chain keeps a wrong version and charges no fee
+	msg := &types.MsgCreateCookbook{Name: "model cookbook", Version: "1.0.0", Level: 0, Sender: player}
+	model.RecordTx([]sdk.Msg{msg}, "tx2", nil)
+	cookbookFee, err := coins.CookbookFee(0)
+	t.MustNil(err, "error getting cookbook fee")
+	model.RecordTxResult("tx2", modelTxResult("tx2", fee, sdk.ABCIMessageLogs{{Events: sdk.StringEvents{{
+		Type:       "cookbook_created",
+		Attributes: []sdk.Attribute{{Key: "cookbook_id", Value: "cb1"}, {Key: "sender", Value: player}},
+	}}}}), nil)
+	chain.cookbooks[player] = []types.Cookbook{{ID: "cb1", Name: "model cookbook", Version: "1.0.1", Sender: player}}
+	chain.items[player] = append(chain.items[player], types.Item{ID: "item1", Sender: player})
+	chain.balances[player] = coins.Pylons(100498)
+	report, err = model.reconcile(ctx, chain)
+	t.MustNil(err, "error reconciling divergent state")
+	t.WithFields(testing.Fields{"divergences": report.Divergences}).MustTrue(len(report.Divergences) == 3, "version, balance and unexpected item should diverge")
+	expectedBalance := coins.Pylons(100498 - cookbookFee)
+	t.MustTrue(report.Divergences[0] == ModelDivergence{Address: player, Kind: StateKindBalance, ID: types.Pylon, Field: "amount", Expected: expectedBalance.AmountOf(types.Pylon).String(), Actual: "100498"}, "balance divergence should be precise")
+	t.MustTrue(report.Divergences[1] == ModelDivergence{Address: player, Kind: StateKindCookbook, ID: "cb1", Field: "Version", Expected: "1.0.0", Actual: "1.0.1"}, "cookbook divergence should be precise")
+	t.MustTrue(report.Divergences[2] == ModelDivergence{Address: player, Kind: StateKindItem, ID: "item1", Field: ModelFieldUnexpected}, "item divergence should be precise")
+
+	// diverged balance is taken from chain so that it's reported once
+	report, err = model.reconcile(ctx, chain)
+	t.MustNil(err, "error reconciling again")
+	t.MustTrue(len(report.Divergences) == 2, "only persisting entry divergences should be reported again")
+}
+
+func TestStateModelUnpredictableBalance(originT *originT.T) {
+	t := testing.NewT(originT)
+	model := NewStateModel()
+	sender := sdk.AccAddress([]byte("model_sender________")).String()
+	receiver := sdk.AccAddress([]byte("model_receiver______")).String()
+	model.tracked[sender] = true
+	model.tracked[receiver] = true
+	model.balances[sender] = coins.Pylons(100)
+	model.balances[receiver] = coins.Pylons(100)
+	model.items["item1"] = sender
+
+	model.applyTx([]sdk.Msg{&types.MsgSendItems{ItemIDs: []string{"item1"}, Sender: sender, Receiver: receiver}}, modelTxResult("tx1", nil, nil))
+	_, senderKnown := model.balances[sender]
+	t.MustTrue(model.items["item1"] == receiver, "sent item should be owned by receiver")
+	t.MustTrue(!senderKnown, "balance of item sender should be resynced as transfer fee is not modeled")
+
+	// failed transaction only pays the fee
+	send := &types.MsgSendCoins{Amount: coins.Pylons(50), Sender: receiver, Receiver: sender}
+	model.applyTx([]sdk.Msg{send}, modelTxResult("tx2", coins.Pylons(1), nil))
+	failed := modelTxResult("tx3", coins.Pylons(1), nil)
+	failed.Code = 5
+	model.applyTx([]sdk.Msg{send}, failed)
+	t.MustTrue(model.balances[receiver].IsEqual(coins.Pylons(48)), "fees of both transactions and coins of successful one should be paid")
+}
@@ -0,0 +1,146 @@
+package inttest
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// describes the kinds of state entries in a snapshot
+const (
+	StateKindCookbook = "cookbook"
+	StateKindRecipe   = "recipe"
+	StateKindItem     = "item"
+)
+
+// describes the changes of a state entry between snapshots
+const (
+	StateAdded    = "added"
+	StateRemoved  = "removed"
+	StateModified = "modified"
+)
+
+// AccountState is a struct to manage pylons state owned by an account
+// Entries are json encoded and keyed by ID
+type AccountState struct {
+	Cookbooks map[string]string
+	Recipes   map[string]string
+	Items     map[string]string
+}
+
+// StateSnapshot is a struct to manage pylons state of accounts keyed by address
+type StateSnapshot struct {
+	Accounts map[string]AccountState
+}
+
+// StateChange is a struct to describe a change of a state entry between snapshots
+type StateChange struct {
+	Address string `json:"address"`
+	Kind    string `json:"kind"`
+	ID      string `json:"id"`
+	Change  string `json:"change"`
+	Before  string `json:"before,omitempty"`
+	After   string `json:"after,omitempty"`
+}
+
+// String is a function to get readable description of state change
+func (c StateChange) String() string {
+	return fmt.Sprintf("%s %s %s of %s", c.Kind, c.ID, c.Change, c.Address)
+}
+
+// TakeStateSnapshot is a function to export cookbooks, recipes and items of addresses
+func TakeStateSnapshot(addresses []string) (StateSnapshot, error) {
+	snapshot := StateSnapshot{
+		Accounts: make(map[string]AccountState),
+	}
+	for _, address := range addresses {
+		state := AccountState{
+			Cookbooks: make(map[string]string),
+			Recipes:   make(map[string]string),
+			Items:     make(map[string]string),
+		}
+		cookbooks, err := ListCookbookViaCLI(address)
+		if err != nil {
+			return snapshot, err
+		}
+		for idx := range cookbooks {
+			if err = addStateEntry(state.Cookbooks, cookbooks[idx].ID, &cookbooks[idx]); err != nil {
+				return snapshot, err
+			}
+		}
+		recipes, err := ListRecipesViaCLI(address)
+		if err != nil {
+			return snapshot, err
+		}
+		for idx := range recipes {
+			if err = addStateEntry(state.Recipes, recipes[idx].ID, &recipes[idx]); err != nil {
+				return snapshot, err
+			}
+		}
+		items, err := ListItemsViaCLI(address)
+		if err != nil {
+			return snapshot, err
+		}
+		for idx := range items {
+			if err = addStateEntry(state.Items, items[idx].ID, &items[idx]); err != nil {
+				return snapshot, err
+			}
+		}
+		snapshot.Accounts[address] = state
+	}
+	return snapshot, nil
+}
+
+func addStateEntry(entries map[string]string, id string, entry proto.Message) error {
+	output, err := GetJSONMarshaler().MarshalJSON(entry)
+	if err != nil {
+		return err
+	}
+	entries[id] = string(output)
+	return nil
+}
+
+// Diff is a function to get changes from snapshot to after snapshot sorted by address, kind and ID
+// Only addresses in both snapshots are compared
+func (s StateSnapshot) Diff(after StateSnapshot) []StateChange {
+	changes := []StateChange{}
+	for address, before := range s.Accounts {
+		afterState, ok := after.Accounts[address]
+		if !ok {
+			continue
+		}
+		changes = append(changes, diffStateEntries(address, StateKindCookbook, before.Cookbooks, afterState.Cookbooks)...)
+		changes = append(changes, diffStateEntries(address, StateKindRecipe, before.Recipes, afterState.Recipes)...)
+		changes = append(changes, diffStateEntries(address, StateKindItem, before.Items, afterState.Items)...)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Address != changes[j].Address {
+			return changes[i].Address < changes[j].Address
+		}
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		return changes[i].ID < changes[j].ID
+	})
+	return changes
+}
+
+func diffStateEntries(address, kind string, before, after map[string]string) []StateChange {
+	changes := []StateChange{}
+	for id, beforeEntry := range before {
+		afterEntry, ok := after[id]
+		switch {
+		case !ok:
+			changes = append(changes, StateChange{Address: address, Kind: kind, ID: id, Change: StateRemoved, Before: beforeEntry})
+		case afterEntry != beforeEntry:
+			changes = append(changes, StateChange{Address: address, Kind: kind, ID: id, Change: StateModified, Before: beforeEntry, After: afterEntry})
+		}
+	}
+	for id, afterEntry := range after {
+		if _, ok := before[id]; !ok {
+			changes = append(changes, StateChange{Address: address, Kind: kind, ID: id, Change: StateAdded, After: afterEntry})
+		}
+	}
+	return changes
+}
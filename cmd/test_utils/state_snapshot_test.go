@@ -0,0 +1,40 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestStateSnapshotDiff(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	before := StateSnapshot{
+		Accounts: map[string]AccountState{
+			"addr1": {
+				Cookbooks: map[string]string{"cb1": `{"ID":"cb1"}`},
+				Recipes:   map[string]string{"rcp1": `{"ID":"rcp1","Disabled":false}`},
+				Items:     map[string]string{"item1": `{"ID":"item1"}`},
+			},
+			"addr2": {},
+		},
+	}
+	after := StateSnapshot{
+		Accounts: map[string]AccountState{
+			"addr1": {
+				Cookbooks: map[string]string{"cb1": `{"ID":"cb1"}`},
+				Recipes:   map[string]string{"rcp1": `{"ID":"rcp1","Disabled":true}`},
+				Items:     map[string]string{"item2": `{"ID":"item2"}`},
+			},
+		},
+	}
+	changes := before.Diff(after)
+	t.WithFields(testing.Fields{
+		"changes": changes,
+	}).MustTrue(len(changes) == 3, "changed entries of addresses in both snapshots should be listed")
+	t.MustTrue(changes[0].Kind == StateKindItem && changes[0].ID == "item1" && changes[0].Change == StateRemoved, "removed item should be listed")
+	t.MustTrue(changes[1].Kind == StateKindItem && changes[1].ID == "item2" && changes[1].Change == StateAdded, "added item should be listed")
+	t.MustTrue(changes[2].Kind == StateKindRecipe && changes[2].ID == "rcp1" && changes[2].Change == StateModified, "modified recipe should be listed")
+
+	t.MustTrue(len(before.Diff(before)) == 0, "same snapshots should not have changes")
+}
@@ -0,0 +1,112 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SupplyDeltas is a type to describe expected change of total supply by denom
+type SupplyDeltas map[string]int64
+
+// Add is a function to add amount to expected change of denom supply
+func (d SupplyDeltas) Add(denom string, amount int64) {
+	d[denom] += amount
+}
+
+// Denoms is a function to get denoms of deltas in sorted order
+func (d SupplyDeltas) Denoms() []string {
+	denoms := []string{}
+	for denom := range d {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+	return denoms
+}
+
+// GetTotalSupply is a function to get total supply of denom, zero when denom is not issued
+func GetTotalSupply(denom string) (sdk.Int, error) {
+	return GetTotalSupplyCtx(context.Background(), denom)
+}
+
+// GetTotalSupplyCtx is a function to get total supply of denom, it's canceled when ctx is done
+func GetTotalSupplyCtx(ctx context.Context, denom string) (sdk.Int, error) {
+	transport, err := GetTransport()
+	if err != nil {
+		return sdk.ZeroInt(), err
+	}
+	supply, err := transport.SupplyOf(ctx, denom)
+	if err != nil {
+		return sdk.ZeroInt(), fmt.Errorf("error getting total supply of %s: %w", denom, err)
+	}
+	if supply.Amount.IsNil() {
+		return sdk.ZeroInt(), nil
+	}
+	return supply.Amount, nil
+}
+
+// GetTotalSupplies is a function to get total supply of each denom of deltas to check them after transaction
+func GetTotalSupplies(deltas SupplyDeltas) (map[string]sdk.Int, error) {
+	supplies := make(map[string]sdk.Int)
+	for _, denom := range deltas.Denoms() {
+		supply, err := GetTotalSupply(denom)
+		if err != nil {
+			return supplies, err
+		}
+		supplies[denom] = supply
+	}
+	return supplies, nil
+}
+
+// RecipeCoinOutputDenoms is a function to get denoms recipe can mint by its coin outputs, each with zero delta
+// Supplies of the denoms are taken before execution, as which entries are selected is only known after it.
+func RecipeCoinOutputDenoms(recipe types.Recipe) SupplyDeltas {
+	deltas := SupplyDeltas{}
+	for _, coinOutput := range recipe.Entries.CoinOutputs {
+		deltas.Add(coinOutput.Coin, 0)
+	}
+	return deltas
+}
+
+// AddExecutionOutput is a function to add coins minted by recipe execution to deltas from output of execute recipe response
+// Coin inputs are paid to cookbook owner and Pylons LLC, so they don't change total supply.
+func (d SupplyDeltas) AddExecutionOutput(output []byte) error {
+	var entries []types.ExecuteRecipeSerialize
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return fmt.Errorf("error decoding execution output %s: %w", string(output), err)
+	}
+	for _, entry := range entries {
+		if entry.Type == "COIN" {
+			d.Add(entry.Coin, entry.Amount)
+		}
+	}
+	return nil
+}
+
+// CheckSupplyDeltas is a function to verify total supply of each denom changed by deltas from before supplies
+// Denoms issued by other transactions in the meantime e.g. staking denom by inflation can't be checked.
+func CheckSupplyDeltas(before map[string]sdk.Int, deltas SupplyDeltas, t *testing.T) error {
+	for _, denom := range deltas.Denoms() {
+		prev, ok := before[denom]
+		if !ok {
+			return fmt.Errorf("total supply of %s before transaction is not known", denom)
+		}
+		after, err := GetTotalSupply(denom)
+		if err != nil {
+			return err
+		}
+		delta := after.Sub(prev)
+		if !delta.Equal(sdk.NewInt(deltas[denom])) {
+			return fmt.Errorf("total supply of %s changed by %s, expected %d", denom, delta, deltas[denom])
+		}
+	}
+	t.WithFields(testing.Fields{
+		"supply_deltas": deltas,
+	}).Info("checked total supply changes")
+	return nil
+}
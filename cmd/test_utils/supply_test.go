@@ -0,0 +1,39 @@
+package inttest
+
+import (
+	"context"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+func TestSupplyDeltas(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	recipe := types.Recipe{Entries: types.EntriesList{CoinOutputs: []types.CoinOutput{
+		types.GenCoinOnlyEntry("chair"),
+		types.GenCoinOnlyEntry("wood"),
+	}}}
+	deltas := RecipeCoinOutputDenoms(recipe)
+	t.MustTrue(len(deltas.Denoms()) == 2 && deltas.Denoms()[0] == "chair" && deltas["chair"] == 0, "coin output denoms should be listed without change")
+
+	err := deltas.AddExecutionOutput([]byte(`[{"type":"COIN","coin":"chair","amount":3},{"type":"ITEM","itemID":"item1"}]`))
+	t.MustNil(err, "error adding execution output")
+	t.MustTrue(deltas["chair"] == 3 && deltas["wood"] == 0, "minted coins should be added to deltas")
+	t.MustTrue(deltas.AddExecutionOutput([]byte("scheduled")) != nil, "undecodable output should fail")
+
+	err = CheckSupplyDeltas(map[string]sdk.Int{}, deltas, &t)
+	t.MustTrue(err != nil, "supply which is not taken before should fail the check")
+
+	server := newRESTServer(&t, map[string]proto.Message{
+		"/cosmos/bank/v1beta1/supply/chair": &banktypes.QuerySupplyOfResponse{Amount: sdk.NewInt64Coin("chair", 42)},
+	})
+	defer server.Close()
+	supply, err := newRESTTransport(server.URL).SupplyOf(context.Background(), "chair")
+	t.MustNil(err, "error querying supply")
+	t.MustTrue(supply.Amount.Int64() == 42, "supply should be decoded")
+}
@@ -0,0 +1,143 @@
+package inttest
+
+import (
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const testDataCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+var testDataSeed int64
+var testDataSeedOnce sync.Once
+
+var nodeRand *rand.Rand
+var nodeRandMux sync.Mutex
+
+func init() {
+	flag.Int64Var(&testDataSeed, "seed", 0, "seed for pseudo-random test data and node selection, 0 to seed from current time")
+}
+
+// GetTestDataSeed is a function to get the seed of the run, seeded from current time when -seed is not set
+func GetTestDataSeed() int64 {
+	testDataSeedOnce.Do(func() {
+		if testDataSeed == 0 {
+			testDataSeed = time.Now().UnixNano()
+		}
+	})
+	return testDataSeed
+}
+
+// randNodeIndex is a function to select a custom node deterministically from the run seed
+func randNodeIndex(n int) int {
+	nodeRandMux.Lock()
+	defer nodeRandMux.Unlock()
+	if nodeRand == nil {
+		nodeRand = rand.New(rand.NewSource(GetTestDataSeed()))
+	}
+	return nodeRand.Intn(n)
+}
+
+// TestDataGenerator is a struct to generate pseudo-random test data reproducible from a seed
+type TestDataGenerator struct {
+	mux  sync.Mutex
+	rnd  *rand.Rand
+	Seed int64
+}
+
+// NewTestDataGenerator is a function to create a generator seeded from the run seed and test name
+// so that a test gets the same data regardless of the order tests run in
+func NewTestDataGenerator(t *testing.T) *TestDataGenerator {
+	hash := fnv.New64a()
+	hash.Write([]byte(t.Name()))
+	seed := GetTestDataSeed() ^ int64(hash.Sum64())
+	t.WithFields(testing.Fields{
+		"seed":      GetTestDataSeed(),
+		"test_seed": seed,
+	}).Info("seeded test data generator, rerun with -seed to reproduce")
+	return NewTestDataGeneratorWithSeed(seed)
+}
+
+// NewTestDataGeneratorWithSeed is a function to create a generator with a fixed seed
+func NewTestDataGeneratorWithSeed(seed int64) *TestDataGenerator {
+	return &TestDataGenerator{
+		rnd:  rand.New(rand.NewSource(seed)),
+		Seed: seed,
+	}
+}
+
+// Int64 is a function to generate a number in [min, max]
+func (g *TestDataGenerator) Int64(min, max int64) int64 {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	return min + g.rnd.Int63n(max-min+1)
+}
+
+// String is a function to generate a lowercase alphanumeric string of length
+func (g *TestDataGenerator) String(length int) string {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	bytes := make([]byte, length)
+	for idx := range bytes {
+		bytes[idx] = testDataCharset[g.rnd.Intn(len(testDataCharset))]
+	}
+	return string(bytes)
+}
+
+// Name is a function to generate a name with prefix
+func (g *TestDataGenerator) Name(prefix string) string {
+	return fmt.Sprintf("%s_%s", prefix, g.String(8))
+}
+
+// CookbookID is a function to generate a cookbook ID
+func (g *TestDataGenerator) CookbookID() string {
+	return fmt.Sprintf("cookbook_%s", g.String(12))
+}
+
+// CoinAmount is a function to generate a coin of denom with amount in [min, max]
+func (g *TestDataGenerator) CoinAmount(denom string, min, max int64) sdk.Coin {
+	return sdk.NewInt64Coin(denom, g.Int64(min, max))
+}
+
+// ItemDoubles is a function to generate count double attributes of an item
+func (g *TestDataGenerator) ItemDoubles(count int) types.DoubleKeyValueList {
+	doubles := types.DoubleKeyValueList{}
+	for idx := 0; idx < count; idx++ {
+		doubles = append(doubles, types.DoubleKeyValue{
+			Key:   fmt.Sprintf("double%d", idx),
+			Value: sdk.NewDecWithPrec(g.Int64(0, 100000), 3),
+		})
+	}
+	return doubles
+}
+
+// ItemLongs is a function to generate count long attributes of an item
+func (g *TestDataGenerator) ItemLongs(count int) types.LongKeyValueList {
+	longs := types.LongKeyValueList{}
+	for idx := 0; idx < count; idx++ {
+		longs = append(longs, types.LongKeyValue{
+			Key:   fmt.Sprintf("long%d", idx),
+			Value: g.Int64(0, 1000),
+		})
+	}
+	return longs
+}
+
+// ItemStrings is a function to generate count string attributes of an item
+func (g *TestDataGenerator) ItemStrings(count int) types.StringKeyValueList {
+	strs := types.StringKeyValueList{}
+	for idx := 0; idx < count; idx++ {
+		strs = append(strs, types.StringKeyValue{
+			Key:   fmt.Sprintf("string%d", idx),
+			Value: g.String(10),
+		})
+	}
+	return strs
+}
@@ -0,0 +1,31 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestTestDataGenerator(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	gen1 := NewTestDataGeneratorWithSeed(42)
+	gen2 := NewTestDataGeneratorWithSeed(42)
+	name1, name2 := gen1.Name("item"), gen2.Name("item")
+	t.WithFields(testing.Fields{
+		"name1": name1,
+		"name2": name2,
+	}).MustTrue(name1 == name2, "same seed should generate same name")
+	t.MustTrue(gen1.CoinAmount("pylon", 1, 100).IsEqual(gen2.CoinAmount("pylon", 1, 100)), "same seed should generate same coin")
+	t.MustTrue(gen1.ItemStrings(2)[1].Value == gen2.ItemStrings(2)[1].Value, "same seed should generate same item attributes")
+
+	for idx := 0; idx < 100; idx++ {
+		value := gen1.Int64(5, 7)
+		t.MustTrue(value >= 5 && value <= 7, "generated number should be in range")
+	}
+
+	t.Run("per test seed", func(t *testing.T) {
+		seed := NewTestDataGenerator(t).Seed
+		t.MustTrue(seed == NewTestDataGenerator(t).Seed, "generator of same test should have same seed")
+	})
+}
@@ -0,0 +1,367 @@
+package inttest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// describes kinds of spans in OTLP
+const (
+	SpanKindInternal = 1
+	SpanKindClient   = 3
+)
+
+// describes status codes of spans in OTLP
+const (
+	spanStatusUnset = 0
+	spanStatusError = 2
+)
+
+// maxBufferedSpans is the number of ended spans exported at once
+const maxBufferedSpans = 512
+
+// TracingOptions is a struct to configure export of spans of scenarios, steps and chain calls
+type TracingOptions struct {
+	// Endpoint is base url of OTLP/HTTP collector e.g. http://localhost:4318, spans are posted to Endpoint/v1/traces
+	Endpoint    string
+	ServiceName string
+	Timeout     time.Duration
+}
+
+// Span is a struct to describe a timed operation of a trace, methods of nil span do nothing
+type Span struct {
+	traceID    [16]byte
+	spanID     [8]byte
+	parentID   [8]byte
+	hasParent  bool
+	name       string
+	kind       int
+	start      time.Time
+	mux        sync.Mutex
+	end        time.Time
+	attributes map[string]interface{}
+	err        error
+}
+
+// tracer is a struct to collect ended spans and export them to OTLP collector
+type tracer struct {
+	opts   TracingOptions
+	client *http.Client
+	mux    sync.Mutex
+	spans  []*Span
+}
+
+type spanContextKey struct{}
+
+var (
+	tracerMux     sync.RWMutex
+	activeTracer  *tracer
+	testSpanMux   sync.RWMutex
+	testSpans     = map[string]*Span{}
+	requestSpanMu sync.Mutex
+	requestSpans  = map[*TransportRequest]*Span{}
+)
+
+// EnableTracing is a function to start exporting spans to OTLP collector and tracing requests to node
+func EnableTracing(opts TracingOptions) {
+	if len(opts.ServiceName) == 0 {
+		opts.ServiceName = "pylons-sdk-test"
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	opts.Endpoint = strings.TrimSuffix(opts.Endpoint, "/")
+	tracerMux.Lock()
+	enabled := activeTracer != nil
+	activeTracer = &tracer{opts: opts, client: &http.Client{Timeout: opts.Timeout}}
+	tracerMux.Unlock()
+	if !enabled {
+		RegisterTransportHook(TracingHook{})
+	}
+}
+
+// TracingEnabled is a function to check if spans are exported
+func TracingEnabled() bool {
+	return getTracer() != nil
+}
+
+// getTracer is a function to get tracer enabled by EnableTracing, it's nil when tracing is disabled
+func getTracer() *tracer {
+	tracerMux.RLock()
+	defer tracerMux.RUnlock()
+	return activeTracer
+}
+
+// StartSpan is a function to start span as child of span of ctx, it returns nil span when tracing is disabled
+func StartSpan(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	if getTracer() == nil {
+		return ctx, nil
+	}
+	span := &Span{
+		name:       name,
+		kind:       kind,
+		start:      time.Now(),
+		attributes: map[string]interface{}{},
+	}
+	if parent := SpanFromContext(ctx); parent != nil {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+		span.hasParent = true
+	} else {
+		_, _ = rand.Read(span.traceID[:])
+	}
+	_, _ = rand.Read(span.spanID[:])
+	return ContextWithSpan(ctx, span), span
+}
+
+// SpanFromContext is a function to get span started by StartSpan from ctx
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// ContextWithSpan is a function to make span parent of spans started with returned context
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// BindTestSpan is a function to make span parent of spans of chain calls done with t or its subtests
+func BindTestSpan(t *testing.T, span *Span) {
+	if span == nil {
+		return
+	}
+	name := t.Name()
+	testSpanMux.Lock()
+	testSpans[name] = span
+	testSpanMux.Unlock()
+	t.Cleanup(func() {
+		testSpanMux.Lock()
+		delete(testSpans, name)
+		testSpanMux.Unlock()
+	})
+}
+
+// TestContext is a function to get context having span bound to t or to the closest parent test of it
+func TestContext(t *testing.T) context.Context {
+	ctx := context.Background()
+	if t == nil || getTracer() == nil {
+		return ctx
+	}
+	testSpanMux.RLock()
+	defer testSpanMux.RUnlock()
+	for name := t.Name(); len(name) > 0; {
+		if span, ok := testSpans[name]; ok {
+			return ContextWithSpan(ctx, span)
+		}
+		idx := strings.LastIndex(name, "/")
+		if idx < 0 {
+			break
+		}
+		name = name[:idx]
+	}
+	return ctx
+}
+
+// withTestSpan is a function to use span of t as parent when ctx has no span
+func withTestSpan(ctx context.Context, t *testing.T) context.Context {
+	if SpanFromContext(ctx) != nil {
+		return ctx
+	}
+	return ContextWithSpan(ctx, SpanFromContext(TestContext(t)))
+}
+
+// SetAttribute is a function to set attribute of span e.g. tx hash or block height
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.attributes[key] = value
+}
+
+// End is a function to end span with error status when err is not nil and queue it for export
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.mux.Lock()
+	if !s.end.IsZero() {
+		s.mux.Unlock()
+		return
+	}
+	s.end = time.Now()
+	s.err = err
+	s.mux.Unlock()
+	if tr := getTracer(); tr != nil {
+		tr.add(s)
+	}
+}
+
+// add is a function to queue ended span, spans are exported when buffer is full
+func (tr *tracer) add(span *Span) {
+	tr.mux.Lock()
+	tr.spans = append(tr.spans, span)
+	full := len(tr.spans) >= maxBufferedSpans
+	tr.mux.Unlock()
+	if full {
+		if err := tr.flush(context.Background()); err != nil {
+			fmt.Println("error exporting spans", err)
+		}
+	}
+}
+
+// flush is a function to export queued spans
+func (tr *tracer) flush(ctx context.Context) error {
+	tr.mux.Lock()
+	spans := tr.spans
+	tr.spans = nil
+	tr.mux.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(tr.exportRequest(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tr.opts.Endpoint+"/v1/traces", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := tr.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting spans to %s: %w", tr.opts.Endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("OTLP collector responded %s", resp.Status)
+	}
+	return nil
+}
+
+// FlushTraces is a function to export spans ended so far, it should be called before the test binary exits
+func FlushTraces(ctx context.Context) error {
+	tr := getTracer()
+	if tr == nil {
+		return nil
+	}
+	return tr.flush(ctx)
+}
+
+// otlpAttribute is a function to convert attribute into OTLP json key value
+func otlpAttribute(key string, value interface{}) map[string]interface{} {
+	var anyValue map[string]interface{}
+	switch v := value.(type) {
+	case bool:
+		anyValue = map[string]interface{}{"boolValue": v}
+	case int:
+		anyValue = map[string]interface{}{"intValue": strconv.Itoa(v)}
+	case int64:
+		anyValue = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+	case uint64:
+		anyValue = map[string]interface{}{"intValue": strconv.FormatUint(v, 10)}
+	case float64:
+		anyValue = map[string]interface{}{"doubleValue": v}
+	default:
+		anyValue = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+	}
+	return map[string]interface{}{"key": key, "value": anyValue}
+}
+
+// otlpSpan is a function to convert span into OTLP json span
+func (s *Span) otlpSpan() map[string]interface{} {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	attributes := []map[string]interface{}{}
+	for key, value := range s.attributes {
+		attributes = append(attributes, otlpAttribute(key, value))
+	}
+	status := map[string]interface{}{"code": spanStatusUnset}
+	if s.err != nil {
+		status = map[string]interface{}{"code": spanStatusError, "message": testing.Redact(s.err.Error())}
+	}
+	span := map[string]interface{}{
+		"traceId":           hex.EncodeToString(s.traceID[:]),
+		"spanId":            hex.EncodeToString(s.spanID[:]),
+		"name":              s.name,
+		"kind":              s.kind,
+		"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+		"attributes":        attributes,
+		"status":            status,
+	}
+	if s.hasParent {
+		span["parentSpanId"] = hex.EncodeToString(s.parentID[:])
+	}
+	return span
+}
+
+// exportRequest is a function to build OTLP json export request of spans
+func (tr *tracer) exportRequest(spans []*Span) map[string]interface{} {
+	otlpSpans := []map[string]interface{}{}
+	for _, span := range spans {
+		otlpSpans = append(otlpSpans, span.otlpSpan())
+	}
+	return map[string]interface{}{
+		"resourceSpans": []map[string]interface{}{{
+			"resource": map[string]interface{}{
+				"attributes": []map[string]interface{}{otlpAttribute("service.name", tr.opts.ServiceName)},
+			},
+			"scopeSpans": []map[string]interface{}{{
+				"scope": map[string]interface{}{"name": "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"},
+				"spans": otlpSpans,
+			}},
+		}},
+	}
+}
+
+// TracingHook is a transport hook to trace requests to node as child spans of span of request context
+type TracingHook struct{}
+
+// BeforeRequest is a function to start span of request
+func (TracingHook) BeforeRequest(ctx context.Context, req *TransportRequest) {
+	_, span := StartSpan(ctx, string(req.Transport)+" "+req.Method, SpanKindClient)
+	if span == nil {
+		return
+	}
+	span.SetAttribute("transport", string(req.Transport))
+	if len(req.Args) > 0 {
+		span.SetAttribute("args", testing.Redact(strings.Join(req.Args, " ")))
+	}
+	requestSpanMu.Lock()
+	requestSpans[req] = span
+	requestSpanMu.Unlock()
+}
+
+// AfterResponse is a function to end span of request with tx hash and block height of response
+func (TracingHook) AfterResponse(ctx context.Context, req *TransportRequest, res *TransportResponse) {
+	requestSpanMu.Lock()
+	span := requestSpans[req]
+	delete(requestSpans, req)
+	requestSpanMu.Unlock()
+	if len(res.TxHash) > 0 {
+		span.SetAttribute("tx.hash", res.TxHash)
+	}
+	if res.Height > 0 {
+		span.SetAttribute("block.height", res.Height)
+	}
+	span.End(res.Err)
+}
@@ -0,0 +1,71 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestTracingExportsSpans(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	var exported struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID      string `json:"traceId"`
+					SpanID       string `json:"spanId"`
+					ParentSpanID string `json:"parentSpanId"`
+					Name         string `json:"name"`
+					Attributes   []struct {
+						Key   string                 `json:"key"`
+						Value map[string]interface{} `json:"value"`
+					} `json:"attributes"`
+					Status struct {
+						Code int `json:"code"`
+					} `json:"status"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	path := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		t.MustNil(json.NewDecoder(r.Body).Decode(&exported), "export request should be json")
+	}))
+	defer server.Close()
+
+	EnableTracing(TracingOptions{Endpoint: server.URL + "/"})
+	defer func() {
+		tracerMux.Lock()
+		activeTracer = nil
+		tracerMux.Unlock()
+		ResetTransportHooks()
+	}()
+
+	ctx, parent := StartSpan(context.Background(), "step create_cookbook", SpanKindInternal)
+	withTransportHooks(ctx, TransportRequest{Transport: TransportCLI, Method: "tx broadcast"}, func() TransportResponse {
+		return TransportResponse{TxHash: "ABC", Height: 12, Err: errors.New("out of gas")}
+	})
+	parent.End(nil)
+	t.MustNil(FlushTraces(context.Background()), "spans should be exported")
+
+	t.MustTrue(path == "/v1/traces" && len(exported.ResourceSpans) == 1, "spans should be posted to OTLP traces path")
+	spans := exported.ResourceSpans[0].ScopeSpans[0].Spans
+	t.MustTrue(len(spans) == 2, "request and parent spans should be exported")
+	child, root := spans[0], spans[1]
+	t.MustTrue(child.TraceID == root.TraceID && child.ParentSpanID == root.SpanID && len(root.ParentSpanID) == 0, "request span should be child of step span")
+	t.MustTrue(child.Name == "cli tx broadcast" && child.Status.Code == spanStatusError, "request span should have error status")
+	attributes := map[string]interface{}{}
+	for _, attr := range child.Attributes {
+		for _, value := range attr.Value {
+			attributes[attr.Key] = value
+		}
+	}
+	t.MustTrue(attributes["tx.hash"] == "ABC" && attributes["block.height"] == "12", "request span should have tx hash and height")
+}
@@ -0,0 +1,76 @@
+package inttest
+
+import (
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// TradeFilter is a struct to select trades like a marketplace front-end does, zero value matches all open trades
+type TradeFilter struct {
+	// Creator is the address of trade creator, trades of all creators match when it's empty
+	Creator string
+	// CoinDenoms match trades having any of denoms in coin inputs or coin outputs
+	CoinDenoms []string
+	// ItemOutput matches trades offering an item which satisfies attribute ranges and values of the item input
+	ItemOutput *types.ItemInput
+	// IncludeCompleted makes completed trades match, they are ignored by default
+	IncludeCompleted bool
+	// IncludeDisabled makes disabled trades match, they are ignored by default
+	IncludeDisabled bool
+}
+
+// hasDenom is a function to check if trade has any of denoms in coin inputs or coin outputs
+func hasDenom(trade types.Trade, denoms []string) bool {
+	for _, denom := range denoms {
+		for _, coin := range trade.CoinInputs {
+			if coin.Coin == denom {
+				return true
+			}
+		}
+		if trade.CoinOutputs.AmountOf(denom).IsPositive() {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches is a function to check if trade is selected by filter
+func (f TradeFilter) Matches(trade types.Trade) bool {
+	if (trade.Completed && !f.IncludeCompleted) || (trade.Disabled && !f.IncludeDisabled) {
+		return false
+	}
+	if len(f.Creator) > 0 && trade.Sender != f.Creator {
+		return false
+	}
+	if len(f.CoinDenoms) > 0 && !hasDenom(trade, f.CoinDenoms) {
+		return false
+	}
+	if f.ItemOutput != nil {
+		for _, item := range trade.ItemOutputs {
+			if types.CheckItemInput(item, *f.ItemOutput) == nil {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// FilterTrades is a function to get trades selected by filter in the same order
+func FilterTrades(trades []types.Trade, filter TradeFilter) []types.Trade {
+	filtered := []types.Trade{}
+	for _, trade := range trades {
+		if filter.Matches(trade) {
+			filtered = append(filtered, trade)
+		}
+	}
+	return filtered
+}
+
+// ListTradesFiltered is a function to list trades selected by filter via cli
+func ListTradesFiltered(filter TradeFilter) ([]types.Trade, error) {
+	trades, err := ListTradeViaCLI(filter.Creator)
+	if err != nil {
+		return trades, err
+	}
+	return FilterTrades(trades, filter), nil
+}
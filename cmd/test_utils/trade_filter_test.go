@@ -0,0 +1,54 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestFilterTrades(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	sword := func(level int64) types.Item {
+		return types.Item{
+			Longs:   []types.LongKeyValue{{Key: "level", Value: level}},
+			Strings: []types.StringKeyValue{{Key: "Name", Value: "sword"}},
+		}
+	}
+	trades := []types.Trade{
+		{ID: "trade1", Sender: "alice", CoinInputs: []types.CoinInput{{Coin: types.Pylon, Count: 100}}, ItemOutputs: []types.Item{sword(3)}},
+		{ID: "trade2", Sender: "bob", CoinInputs: []types.CoinInput{{Coin: types.Pylon, Count: 50}}, ItemOutputs: []types.Item{sword(8)}},
+		{ID: "trade3", Sender: "alice", CoinOutputs: sdk.NewCoins(sdk.NewInt64Coin("gold", 10))},
+		{ID: "trade4", Sender: "alice", CoinOutputs: sdk.NewCoins(sdk.NewInt64Coin("gold", 10)), Completed: true},
+	}
+	tradeIDs := func(trades []types.Trade) []string {
+		ids := []string{}
+		for _, trade := range trades {
+			ids = append(ids, trade.ID)
+		}
+		return ids
+	}
+
+	for _, tc := range []struct {
+		name     string
+		filter   TradeFilter
+		expected []string
+	}{
+		{"all open trades", TradeFilter{}, []string{"trade1", "trade2", "trade3"}},
+		{"creator", TradeFilter{Creator: "alice"}, []string{"trade1", "trade3"}},
+		{"coin denom", TradeFilter{CoinDenoms: []string{"gold"}, IncludeCompleted: true}, []string{"trade3", "trade4"}},
+		{"item attribute range", TradeFilter{ItemOutput: &types.ItemInput{
+			Longs:   []types.LongInputParam{{Key: "level", MinValue: 5, MaxValue: 10}},
+			Strings: []types.StringInputParam{{Key: "Name", Value: "sword"}},
+		}}, []string{"trade2"}},
+	} {
+		ids := tradeIDs(FilterTrades(trades, tc.filter))
+		t.WithFields(testing.Fields{
+			"filter":   tc.name,
+			"expected": tc.expected,
+			"actual":   ids,
+		}).MustTrue(len(labelMismatches("filtered", tc.expected, ids)) == 0, "filtered trades should be the expected ones")
+	}
+}
@@ -0,0 +1,105 @@
+package inttest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// TradeShape is the kind of sides of a trade named by what fulfiller pays for what trade creator offers
+// e.g. coins_for_items is a trade of coin inputs and item outputs
+type TradeShape string
+
+const (
+	// TradeShapeCoinsForCoins is a trade of coin inputs and coin outputs
+	TradeShapeCoinsForCoins TradeShape = "coins_for_coins"
+	// TradeShapeCoinsForItems is a trade of coin inputs and item outputs
+	TradeShapeCoinsForItems TradeShape = "coins_for_items"
+	// TradeShapeItemsForCoins is a trade of item inputs and coin outputs
+	TradeShapeItemsForCoins TradeShape = "items_for_coins"
+	// TradeShapeItemsForItems is a trade of item inputs and item outputs
+	TradeShapeItemsForItems TradeShape = "items_for_items"
+	// TradeShapeMixed is a trade having both coins and items on input or output side
+	TradeShapeMixed TradeShape = "mixed"
+)
+
+// TradeShapes is the list of trade shapes, a full trade matrix covers each of them
+var TradeShapes = []TradeShape{
+	TradeShapeCoinsForCoins,
+	TradeShapeCoinsForItems,
+	TradeShapeItemsForCoins,
+	TradeShapeItemsForItems,
+	TradeShapeMixed,
+}
+
+// ErrEmptyTradeSide is an error of trade which has nothing on input or output side
+var ErrEmptyTradeSide = errors.New("trade side is empty")
+
+// GetTradeShape is a function to get shape of trade from its coin and item inputs and outputs
+func GetTradeShape(trade types.Trade) (TradeShape, error) {
+	hasCoinInputs, hasItemInputs := len(trade.CoinInputs) > 0, len(trade.ItemInputs) > 0
+	hasCoinOutputs, hasItemOutputs := !trade.CoinOutputs.Empty(), len(trade.ItemOutputs) > 0
+	switch {
+	case !hasCoinInputs && !hasItemInputs:
+		return "", fmt.Errorf("%w: trade %s has no inputs", ErrEmptyTradeSide, trade.ID)
+	case !hasCoinOutputs && !hasItemOutputs:
+		return "", fmt.Errorf("%w: trade %s has no outputs", ErrEmptyTradeSide, trade.ID)
+	case (hasCoinInputs && hasItemInputs) || (hasCoinOutputs && hasItemOutputs):
+		return TradeShapeMixed, nil
+	case hasCoinInputs && hasCoinOutputs:
+		return TradeShapeCoinsForCoins, nil
+	case hasCoinInputs:
+		return TradeShapeCoinsForItems, nil
+	case hasCoinOutputs:
+		return TradeShapeItemsForCoins, nil
+	}
+	return TradeShapeItemsForItems, nil
+}
+
+// TradeOfCreateMsg is a function to get trade which is created by msg, it doesn't have ID yet
+func TradeOfCreateMsg(msg types.MsgCreateTrade) types.Trade {
+	return types.Trade{
+		CoinInputs:  msg.CoinInputs,
+		ItemInputs:  msg.ItemInputs,
+		CoinOutputs: msg.CoinOutputs,
+		ItemOutputs: msg.ItemOutputs,
+		ExtraInfo:   msg.ExtraInfo,
+		Sender:      msg.Sender,
+	}
+}
+
+// SelectItemsForTrade is a function to pick unlocked items of address satisfying item inputs of trade
+// It returns item ids in order of item inputs, ready to be used for MsgFulfillTrade.
+func SelectItemsForTrade(addr string, trade types.Trade, opts ...QueryOption) ([]string, error) {
+	if len(trade.ItemInputs) == 0 {
+		return []string{}, nil
+	}
+	items, err := ListItemsViaCLI(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	owned := Match().Owner(addr).Unlocked().Filter(items)
+	matchers := []*ItemMatcher{}
+	for _, tii := range trade.ItemInputs {
+		matcher := MatchItemInput(tii.ItemInput)
+		if len(tii.CookbookID) > 0 {
+			matcher = matcher.CookbookID(tii.CookbookID)
+		}
+		matchers = append(matchers, matcher)
+	}
+	itemIDs, err := SelectItemIDs(owned, matchers...)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting items of %s for trade %s: %w", addr, trade.ID, err)
+	}
+	return itemIDs, nil
+}
+
+// AutoFulfillTradeMsg is a function to get msg fulfilling trade by address with items picked from its inventory
+func AutoFulfillTradeMsg(addr string, trade types.Trade, opts ...QueryOption) (types.MsgFulfillTrade, error) {
+	itemIDs, err := SelectItemsForTrade(addr, trade, opts...)
+	if err != nil {
+		return types.MsgFulfillTrade{}, err
+	}
+	return types.NewMsgFulfillTrade(trade.ID, addr, itemIDs), nil
+}
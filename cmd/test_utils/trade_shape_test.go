@@ -0,0 +1,47 @@
+package inttest
+
+import (
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestGetTradeShape(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	coinInputs := []types.CoinInput{{Coin: types.Pylon, Count: 100}}
+	itemInputs := []types.TradeItemInput{{ItemInput: types.ItemInput{ID: "sword"}}}
+	coinOutputs := sdk.NewCoins(sdk.NewInt64Coin("gold", 10))
+	itemOutputs := []types.Item{{ID: "shield"}}
+
+	for _, tc := range []struct {
+		name     string
+		trade    types.Trade
+		expected TradeShape
+	}{
+		{"coins for coins", types.Trade{CoinInputs: coinInputs, CoinOutputs: coinOutputs}, TradeShapeCoinsForCoins},
+		{"coins for items", types.Trade{CoinInputs: coinInputs, ItemOutputs: itemOutputs}, TradeShapeCoinsForItems},
+		{"items for coins", types.Trade{ItemInputs: itemInputs, CoinOutputs: coinOutputs}, TradeShapeItemsForCoins},
+		{"items for items", types.Trade{ItemInputs: itemInputs, ItemOutputs: itemOutputs}, TradeShapeItemsForItems},
+		{"mixed inputs", types.Trade{CoinInputs: coinInputs, ItemInputs: itemInputs, ItemOutputs: itemOutputs}, TradeShapeMixed},
+		{"mixed outputs", types.Trade{CoinInputs: coinInputs, CoinOutputs: coinOutputs, ItemOutputs: itemOutputs}, TradeShapeMixed},
+	} {
+		shape, err := GetTradeShape(tc.trade)
+		t.WithFields(testing.Fields{
+			"case":  tc.name,
+			"shape": shape,
+		}).MustTrue(err == nil && shape == tc.expected, "trade shape should be decided by its sides")
+	}
+
+	_, err := GetTradeShape(types.Trade{CoinInputs: coinInputs})
+	t.MustTrue(errors.Is(err, ErrEmptyTradeSide), "trade without outputs should have no shape")
+	_, err = GetTradeShape(types.Trade{ItemOutputs: itemOutputs})
+	t.MustTrue(errors.Is(err, ErrEmptyTradeSide), "trade without inputs should have no shape")
+
+	msg := types.NewMsgCreateTrade(coinInputs, itemInputs, nil, itemOutputs, "mixed", "alice")
+	shape, err := GetTradeShape(TradeOfCreateMsg(msg))
+	t.MustTrue(err == nil && shape == TradeShapeMixed, "trade of create msg should keep its sides")
+}
@@ -0,0 +1,93 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// TestMsgSendItems is a function to send items from sender to receiver and wait for the transaction to be processed
+func TestMsgSendItems(t *testing.T, itemIDs []string, sender, receiver string) (string, error) {
+	msg := types.NewMsgSendItems(itemIDs, sender, receiver)
+	txhash, err := NewClient().SendTx(context.Background(), t, SignerAddress(sender), &msg)
+	if err != nil {
+		return txhash, err
+	}
+	_, err = NewClient().WaitForTx(context.Background(), t, txhash)
+	if err != nil {
+		return txhash, err
+	}
+	return txhash, CheckItemsOwnership(itemIDs, sender, receiver, t)
+}
+
+// TestMsgSendCoins is a function to send coins from sender to receiver and wait for the transaction to be processed
+func TestMsgSendCoins(t *testing.T, amount sdk.Coins, sender, receiver string) (string, error) {
+	senderBefore := GetAccountBalanceFromAddr(sender, t)
+	receiverBefore := GetAccountBalanceFromAddr(receiver, t)
+
+	msg := types.NewMsgSendCoins(amount, sender, receiver)
+	txhash, err := NewClient().SendTx(context.Background(), t, SignerAddress(sender), &msg)
+	if err != nil {
+		return txhash, err
+	}
+	_, err = NewClient().WaitForTx(context.Background(), t, txhash)
+	if err != nil {
+		return txhash, err
+	}
+	return txhash, CheckCoinsTransfer(senderBefore, receiverBefore, amount, t)
+}
+
+// CheckItemsOwnership checks that items are owned by new owner and are no longer listed for previous owner
+func CheckItemsOwnership(itemIDs []string, prevOwner, newOwner string, t *testing.T) error {
+	for _, itemID := range itemIDs {
+		item, err := GetItemByGUID(itemID)
+		if err != nil {
+			return err
+		}
+		if item.Sender != newOwner {
+			return fmt.Errorf("item %s is owned by %s, expected %s", itemID, item.Sender, newOwner)
+		}
+	}
+	if prevOwner == newOwner {
+		return nil
+	}
+	prevOwnerItems, err := ListItemsViaCLI(prevOwner)
+	if err != nil {
+		return err
+	}
+	for _, item := range prevOwnerItems {
+		for _, itemID := range itemIDs {
+			if item.ID == itemID {
+				return fmt.Errorf("item %s is still listed for previous owner %s", itemID, prevOwner)
+			}
+		}
+	}
+	t.WithFields(testing.Fields{
+		"item_ids":   itemIDs,
+		"prev_owner": prevOwner,
+		"new_owner":  newOwner,
+	}).Info("checked items ownership")
+	return nil
+}
+
+// CheckCoinsTransfer checks that sender and receiver balances changed by amount compared to balances before transfer
+// Transactions are sent without fees, and the accounts must not be touched by other transactions in the meantime
+func CheckCoinsTransfer(senderBefore, receiverBefore banktypes.Balance, amount sdk.Coins, t *testing.T) error {
+	senderAfter := GetAccountBalanceFromAddr(senderBefore.Address, t)
+	receiverAfter := GetAccountBalanceFromAddr(receiverBefore.Address, t)
+	for _, coin := range amount {
+		senderDelta := senderBefore.Coins.AmountOf(coin.Denom).Sub(senderAfter.Coins.AmountOf(coin.Denom))
+		if !senderDelta.Equal(coin.Amount) {
+			return fmt.Errorf("sender balance of %s changed by %s, expected %s", coin.Denom, senderDelta, coin.Amount)
+		}
+		receiverDelta := receiverAfter.Coins.AmountOf(coin.Denom).Sub(receiverBefore.Coins.AmountOf(coin.Denom))
+		if !receiverDelta.Equal(coin.Amount) {
+			return fmt.Errorf("receiver balance of %s changed by %s, expected %s", coin.Denom, receiverDelta, coin.Amount)
+		}
+	}
+	return nil
+}
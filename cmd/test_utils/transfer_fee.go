@@ -0,0 +1,190 @@
+package inttest
+
+import (
+	"fmt"
+	"sort"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/coins"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/config"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PylonDeltas is a map of address to expected change of its pylon balance, negative for payers
+type PylonDeltas map[string]int64
+
+// add is a function to add amount to expected change of address, zero amounts are not recorded
+func (d PylonDeltas) add(addr string, amount int64) {
+	if amount == 0 {
+		return
+	}
+	d[addr] += amount
+}
+
+// Addresses is a function to get addresses of deltas in sorted order
+func (d PylonDeltas) Addresses() []string {
+	addrs := []string{}
+	for addr := range d {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	return addrs
+}
+
+// addItemTransferFees is a function to add transfer fee splits of items paid by payer to cookbook owners and Pylons LLC
+func (d PylonDeltas) addItemTransferFees(items []types.Item, cookbookOwners map[string]string, payer string) {
+	llc := config.Config.Validators.PylonsLLC
+	for _, item := range items {
+		split := coins.ItemTransferFeeSplit(item.TransferFee)
+		d.add(payer, -split.Total)
+		d.add(cookbookOwners[item.CookbookID], split.Receiver)
+		d.add(llc, split.PylonsLLC)
+	}
+}
+
+// SendItemsTransferFees is a function to get expected pylon changes of sending items by sender
+// Sender pays transfer fee of each item, cookbook owner of the item gets its cut and Pylons LLC gets the rest.
+// cookbookOwners is cookbook id to owner address e.g. from GetCookbookOwners.
+func SendItemsTransferFees(items []types.Item, cookbookOwners map[string]string, sender string) PylonDeltas {
+	deltas := PylonDeltas{}
+	deltas.addItemTransferFees(items, cookbookOwners, sender)
+	return deltas
+}
+
+// FulfillTradeTransferFees is a function to get expected pylon changes of fulfilling trade with input items of fulfiller
+// Pylon price of trade is paid by fulfiller when it's in coin inputs and by trade creator when it's in coin outputs.
+// The previous owner receiving the price gets its cut after trade fee and transfer fees of all traded items,
+// which go to cookbook owners and Pylons LLC. Previous owners pay transfer fees of their items when trade has no pylon price.
+func FulfillTradeTransferFees(trade types.Trade, inputItems []types.Item, cookbookOwners map[string]string, fulfiller string) PylonDeltas {
+	deltas := PylonDeltas{}
+	llc := config.Config.Validators.PylonsLLC
+	payer, payee := fulfiller, trade.Sender
+	price := coins.PylonsOf(types.CoinInputList(trade.CoinInputs).ToCoins())
+	if price == 0 {
+		payer, payee = trade.Sender, fulfiller
+		price = coins.PylonsOf(trade.CoinOutputs)
+	}
+	if price == 0 {
+		deltas.addItemTransferFees(trade.ItemOutputs, cookbookOwners, trade.Sender)
+		deltas.addItemTransferFees(inputItems, cookbookOwners, fulfiller)
+		return deltas
+	}
+	split := coins.TradeFee(price)
+	deltas.add(payer, -split.Total)
+	deltas.add(payee, split.Receiver)
+	deltas.add(llc, split.PylonsLLC)
+	deltas.addItemTransferFees(append(append([]types.Item{}, trade.ItemOutputs...), inputItems...), cookbookOwners, payee)
+	return deltas
+}
+
+// ExpectedSendItemsTransferFees is a function to get expected pylon changes of send items msg from items and cookbooks on chain
+func ExpectedSendItemsTransferFees(msg types.MsgSendItems) (PylonDeltas, error) {
+	items, err := GetItemsByGUID(msg.ItemIDs)
+	if err != nil {
+		return nil, err
+	}
+	owners, err := GetCookbookOwners(items)
+	if err != nil {
+		return nil, err
+	}
+	return SendItemsTransferFees(items, owners, msg.Sender), nil
+}
+
+// ExpectedFulfillTradeTransferFees is a function to get expected pylon changes of fulfill trade msg from trade, items and cookbooks on chain
+func ExpectedFulfillTradeTransferFees(msg types.MsgFulfillTrade) (PylonDeltas, error) {
+	trade, err := GetTradeByGUID(msg.TradeID)
+	if err != nil {
+		return nil, err
+	}
+	// items locked by trade are read again as their transfer fees can be updated after trade creation
+	outputIDs := []string{}
+	for _, item := range trade.ItemOutputs {
+		outputIDs = append(outputIDs, item.ID)
+	}
+	trade.ItemOutputs, err = GetItemsByGUID(outputIDs)
+	if err != nil {
+		return nil, err
+	}
+	inputItems, err := GetItemsByGUID(msg.ItemIDs)
+	if err != nil {
+		return nil, err
+	}
+	owners, err := GetCookbookOwners(append(append([]types.Item{}, trade.ItemOutputs...), inputItems...))
+	if err != nil {
+		return nil, err
+	}
+	return FulfillTradeTransferFees(trade, inputItems, owners, msg.Sender), nil
+}
+
+// GetCookbookOwners is a function to get owners of cookbooks of items keyed by cookbook id
+func GetCookbookOwners(items []types.Item) (map[string]string, error) {
+	owners := make(map[string]string)
+	for _, item := range items {
+		if _, ok := owners[item.CookbookID]; ok {
+			continue
+		}
+		cb, err := GetCookbookByGUID(item.CookbookID)
+		if err != nil {
+			return owners, fmt.Errorf("error getting cookbook %s of item %s: %w", item.CookbookID, item.ID, err)
+		}
+		owners[item.CookbookID] = cb.Sender
+	}
+	return owners, nil
+}
+
+// GetItemsByGUID is a function to get items of ids in the same order
+func GetItemsByGUID(itemIDs []string) ([]types.Item, error) {
+	items := []types.Item{}
+	for _, itemID := range itemIDs {
+		item, err := GetItemByGUID(itemID)
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// GetTradeByGUID is a function to get trade from id
+func GetTradeByGUID(guid string, opts ...QueryOption) (types.Trade, error) {
+	trades, err := ListTradeViaCLI("", opts...)
+	if err != nil {
+		return types.Trade{}, err
+	}
+	for _, trade := range trades {
+		if trade.ID == guid {
+			return trade, nil
+		}
+	}
+	return types.Trade{}, fmt.Errorf("trade %s does not exist", guid)
+}
+
+// GetPylonBalances is a function to get pylon balances of addresses of deltas, taken before the transfer to check them with CheckPylonDeltas
+func GetPylonBalances(deltas PylonDeltas, t *testing.T) map[string]sdk.Int {
+	balances := make(map[string]sdk.Int)
+	for _, addr := range deltas.Addresses() {
+		balances[addr] = GetAccountBalanceFromAddr(addr, t).Coins.AmountOf(types.Pylon)
+	}
+	return balances
+}
+
+// CheckPylonDeltas is a function to verify pylon balances changed by deltas from before balances
+// Transactions are sent without fees, and the accounts must not be touched by other transactions in the meantime
+func CheckPylonDeltas(before map[string]sdk.Int, deltas PylonDeltas, t *testing.T) error {
+	for _, addr := range deltas.Addresses() {
+		prev, ok := before[addr]
+		if !ok {
+			return fmt.Errorf("pylon balance of %s before transfer is not known", addr)
+		}
+		after := GetAccountBalanceFromAddr(addr, t).Coins.AmountOf(types.Pylon)
+		delta := after.Sub(prev)
+		if !delta.Equal(sdk.NewInt(deltas[addr])) {
+			return fmt.Errorf("pylon balance of %s changed by %s, expected %d", addr, delta, deltas[addr])
+		}
+	}
+	t.WithFields(testing.Fields{
+		"pylon_deltas": deltas,
+	}).Info("checked transfer fee payouts")
+	return nil
+}
@@ -0,0 +1,55 @@
+package inttest
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/config"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestTransferFees(originT *originT.T) {
+	t := testing.NewT(originT)
+	fee := config.Config.Fee
+	defer func() { config.Config.Fee = fee }()
+	config.Config.Fee.PylonsTradePercent = 10
+	config.Config.Fee.ItemTransferCookbookOwnerProfitPercent = 90
+	config.Config.Fee.MinItemTransferFee = 1
+	config.Config.Fee.MaxItemTransferFee = 100000
+	llc := config.Config.Validators.PylonsLLC
+	owners := map[string]string{"cb1": "owner1", "cb2": "owner2"}
+	sword := types.Item{ID: "sword", CookbookID: "cb1", TransferFee: 100}
+	shield := types.Item{ID: "shield", CookbookID: "cb2"}
+
+	deltas := SendItemsTransferFees([]types.Item{sword, shield}, owners, "sender")
+	t.WithFields(testing.Fields{
+		"deltas": deltas,
+	}).MustTrue(len(deltas) == 3 && deltas["sender"] == -101 && deltas["owner1"] == 90 && deltas[llc] == 11, "sender should pay limited transfer fees shared by cookbook owners and Pylons LLC")
+
+	trade := types.Trade{
+		Sender:      "seller",
+		CoinInputs:  []types.CoinInput{{Coin: types.Pylon, Count: 1000}},
+		ItemOutputs: []types.Item{sword},
+	}
+	deltas = FulfillTradeTransferFees(trade, nil, owners, "buyer")
+	t.WithFields(testing.Fields{
+		"deltas": deltas,
+	}).MustTrue(deltas["buyer"] == -1000 && deltas["seller"] == 800 && deltas["owner1"] == 90 && deltas[llc] == 110, "seller should get price after trade fee and transfer fee")
+
+	trade = types.Trade{
+		Sender:      "buyer",
+		CoinOutputs: sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 500)),
+	}
+	deltas = FulfillTradeTransferFees(trade, []types.Item{sword}, owners, "seller")
+	t.WithFields(testing.Fields{
+		"deltas": deltas,
+	}).MustTrue(deltas["buyer"] == -500 && deltas["seller"] == 350 && deltas[llc] == 60, "fulfiller selling items should get price of coin outputs after fees")
+
+	trade = types.Trade{Sender: "seller", ItemOutputs: []types.Item{shield}}
+	deltas = FulfillTradeTransferFees(trade, []types.Item{sword}, owners, "buyer")
+	t.WithFields(testing.Fields{
+		"deltas": deltas,
+	}).MustTrue(deltas["seller"] == -1 && deltas["buyer"] == -100 && deltas["owner1"] == 90 && deltas[llc] == 11, "previous owners should pay transfer fees of their items in item swap")
+	t.MustTrue(len(deltas.Addresses()) == 4 && deltas.Addresses()[0] == "buyer", "addresses should be sorted")
+}
@@ -0,0 +1,228 @@
+package inttest
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// TransportKind is a type of node interface used by Transport
+type TransportKind string
+
+// describes node interfaces chain interaction can go through
+const (
+	// TransportCLI runs pylonsd commands against CustomNode
+	TransportCLI TransportKind = "cli"
+	// TransportRPC queries tendermint rpc of CustomNode directly with abci queries
+	TransportRPC TransportKind = "rpc"
+	// TransportGRPC queries grpc endpoint of GRPCEndpoint
+	TransportGRPC TransportKind = "grpc"
+	// TransportREST queries grpc-gateway routes of RestEndpoint
+	TransportREST TransportKind = "rest"
+)
+
+// TransportKinds is the list of all node interfaces
+var TransportKinds = []TransportKind{TransportCLI, TransportRPC, TransportGRPC, TransportREST}
+
+func init() {
+	flag.StringVar((*string)(&CLIOpts.Transport), "transport", string(TransportCLI), "node interface to query and broadcast through, one of cli, rpc, grpc and rest")
+	flag.StringVar(&CLIOpts.GRPCEndpoint, "grpc", "localhost:9090", "grpc endpoint of node used by grpc transport")
+}
+
+// Transport is an interface to query node and broadcast transactions through one of node interfaces
+// Implementations return the same typed results, so fixtures can validate all interfaces return consistent results.
+type Transport interface {
+	// Kind returns node interface of the transport
+	Kind() TransportKind
+	// LatestHeight returns latest block height of node
+	LatestHeight(ctx context.Context) (int64, error)
+	// Tx returns result of committed transaction, it fails when transaction is not found
+	Tx(ctx context.Context, txhash string) (TxResult, error)
+	// Broadcast sends signed transaction bytes in mode, see CheckBroadcastResponse for the response of each mode
+	Broadcast(ctx context.Context, txBytes []byte, mode BroadcastMode) (sdk.TxResponse, error)
+	// Account returns account of address
+	Account(ctx context.Context, addr string) (authtypes.AccountI, error)
+	// Balances returns all balances of address
+	Balances(ctx context.Context, addr string) (sdk.Coins, error)
+	// SupplyOf returns total supply of denom, zero coin when denom is not issued
+	SupplyOf(ctx context.Context, denom string) (sdk.Coin, error)
+	// ListCookbooks returns cookbooks of address, all cookbooks when address is empty
+	ListCookbooks(ctx context.Context, addr string) ([]types.Cookbook, error)
+	// ListRecipes returns recipes of address, all recipes when address is empty
+	ListRecipes(ctx context.Context, addr string) ([]types.Recipe, error)
+	// ListTrades returns trades of address, all trades when address is empty
+	ListTrades(ctx context.Context, addr string) ([]types.Trade, error)
+	// ListExecutions returns executions of sender, all executions when sender is empty
+	ListExecutions(ctx context.Context, sender string) ([]types.Execution, error)
+	// ItemsBySender returns items of sender, all items when sender is empty
+	ItemsBySender(ctx context.Context, sender string) ([]types.Item, error)
+}
+
+// ParseTransportKind is a function to get node interface from its name
+func ParseTransportKind(name string) (TransportKind, error) {
+	kind := TransportKind(strings.ToLower(name))
+	if len(kind) == 0 {
+		return TransportCLI, nil
+	}
+	for _, k := range TransportKinds {
+		if k == kind {
+			return kind, nil
+		}
+	}
+	if _, ok := registeredTransport(kind); ok {
+		return kind, nil
+	}
+	return TransportCLI, fmt.Errorf("unknown transport %s, it should be one of cli, rpc, grpc and rest", name)
+}
+
+// firstNode is a function to get the first tendermint rpc address of CustomNode of DefaultEnv
+func firstNode() string {
+	return DefaultEnv().firstNode()
+}
+
+// NewTransport is a function to create transport of node interface from CLIOpts endpoints
+func NewTransport(kind TransportKind) (Transport, error) {
+	return DefaultEnv().newTransport(kind)
+}
+
+// newTransport is a function to create transport of node interface from endpoints of env
+func (e *Env) newTransport(kind TransportKind) (Transport, error) {
+	switch kind {
+	case TransportCLI:
+		return cliTransport{}, nil
+	case TransportRPC:
+		return newRPCTransport(e.firstNode())
+	case TransportGRPC:
+		return newGRPCTransport(e.opts.GRPCEndpoint)
+	case TransportREST:
+		if len(e.opts.RestEndpoint) == 0 {
+			return nil, fmt.Errorf("rest endpoint is not configured for rest transport")
+		}
+		return newRESTTransport(e.opts.RestEndpoint), nil
+	}
+	if newTransport, ok := registeredTransport(kind); ok {
+		return newTransport()
+	}
+	_, err := ParseTransportKind(string(kind))
+	return nil, err
+}
+
+var (
+	transportMux sync.Mutex
+	transports   = map[string]Transport{}
+
+	registeredTransportMux sync.Mutex
+	registeredTransports   = map[TransportKind]func() (Transport, error){}
+)
+
+// RegisterTransport is a function to add node interface of kind served by transports of newTransport e.g. an in-process chain
+// Cached transports of kind are dropped, so queries selecting kind by CLIOptions.Transport go through the new transport.
+func RegisterTransport(kind TransportKind, newTransport func() (Transport, error)) {
+	registeredTransportMux.Lock()
+	registeredTransports[kind] = newTransport
+	registeredTransportMux.Unlock()
+
+	transportMux.Lock()
+	defer transportMux.Unlock()
+	for key := range transports {
+		if strings.HasPrefix(key, string(kind)+"|") {
+			delete(transports, key)
+		}
+	}
+}
+
+// registeredTransport is a function to get constructor of transports registered for kind
+func registeredTransport(kind TransportKind) (func() (Transport, error), bool) {
+	registeredTransportMux.Lock()
+	defer registeredTransportMux.Unlock()
+	newTransport, ok := registeredTransports[kind]
+	return newTransport, ok
+}
+
+// GetTransport is a function to get transport selected by CLIOpts.Transport, pylonsd cli by default
+func GetTransport() (Transport, error) {
+	return DefaultEnv().Transport()
+}
+
+// Transport is a function to get transport selected by env, pylonsd cli by default
+// Transports are shared by envs of the same endpoints and call registered TransportHook on requests.
+func (e *Env) Transport() (Transport, error) {
+	kind, err := ParseTransportKind(string(e.opts.Transport))
+	if err != nil {
+		return nil, err
+	}
+	key := strings.Join([]string{string(kind), e.firstNode(), e.opts.GRPCEndpoint, e.opts.RestEndpoint}, "|")
+	transportMux.Lock()
+	defer transportMux.Unlock()
+	if transport, ok := transports[key]; ok {
+		return transport, nil
+	}
+	transport, err := e.newTransport(kind)
+	if err != nil {
+		return nil, err
+	}
+	if kind != TransportCLI {
+		// hooks of pylonsd cli are called for each command
+		transport = hookedTransport{Transport: transport}
+	}
+	transports[key] = transport
+	return transport, nil
+}
+
+// transportQuery is a struct to describe a query compared across transports
+type transportQuery struct {
+	name  string
+	query func(transport Transport) (interface{}, error)
+}
+
+// CheckTransportConsistency is a function to run the same queries of address through transports and
+// describe results differing from the first transport, it's empty when all transports agree
+// Results can differ when a block is committed between queries, so it's best run while chain is idle.
+func CheckTransportConsistency(ctx context.Context, addr string, transports ...Transport) []string {
+	queries := []transportQuery{
+		{"balances", func(tr Transport) (interface{}, error) { return tr.Balances(ctx, addr) }},
+		{"cookbooks", func(tr Transport) (interface{}, error) { return tr.ListCookbooks(ctx, addr) }},
+		{"recipes", func(tr Transport) (interface{}, error) { return tr.ListRecipes(ctx, addr) }},
+		{"trades", func(tr Transport) (interface{}, error) { return tr.ListTrades(ctx, addr) }},
+		{"executions", func(tr Transport) (interface{}, error) { return tr.ListExecutions(ctx, addr) }},
+		{"items", func(tr Transport) (interface{}, error) { return tr.ItemsBySender(ctx, addr) }},
+	}
+	mismatches := []string{}
+	if len(transports) < 2 {
+		return mismatches
+	}
+	for _, q := range queries {
+		expected, err := q.query(transports[0])
+		if err != nil {
+			mismatches = append(mismatches, fmt.Sprintf("%s via %s failed: %s", q.name, transports[0].Kind(), err))
+			continue
+		}
+		for _, transport := range transports[1:] {
+			actual, err := q.query(transport)
+			switch {
+			case err != nil:
+				mismatches = append(mismatches, fmt.Sprintf("%s via %s failed: %s", q.name, transport.Kind(), err))
+			case !reflect.DeepEqual(normalizeEmpty(expected), normalizeEmpty(actual)):
+				mismatches = append(mismatches, fmt.Sprintf("%s via %s differs from %s: %s != %s",
+					q.name, transport.Kind(), transports[0].Kind(), AminoCodecFormatter(actual), AminoCodecFormatter(expected)))
+			}
+		}
+	}
+	return mismatches
+}
+
+// normalizeEmpty is a function to treat nil and empty slices as the same result as decoders differ on them
+func normalizeEmpty(result interface{}) interface{} {
+	value := reflect.ValueOf(result)
+	if value.Kind() == reflect.Slice && value.Len() == 0 {
+		return nil
+	}
+	return result
+}
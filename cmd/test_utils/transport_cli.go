@@ -0,0 +1,163 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// cliTransport is a transport running pylonsd commands
+type cliTransport struct{}
+
+// Kind is a function to get node interface of transport
+func (cliTransport) Kind() TransportKind {
+	return TransportCLI
+}
+
+// runQuery is a function to run pylonsd query at height of ctx and decode its output into ptr
+func (cliTransport) runQuery(ctx context.Context, cmd *CommandBuilder, ptr interface{}) error {
+	output, logstr, err := withContextHeight(ctx, cmd).Run(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", logstr, err)
+	}
+	return EnvFromContext(ctx).Codec().Decode(output, ptr)
+}
+
+// LatestHeight is a function to get latest block height of node
+func (cliTransport) LatestHeight(ctx context.Context) (int64, error) {
+	ds, logstr, err := queryDaemonStatusFromNode(ctx, "")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", logstr, err)
+	}
+	return ds.SyncInfo.LatestBlockHeight, nil
+}
+
+// Tx is a function to get result of committed transaction
+func (cliTransport) Tx(ctx context.Context, txhash string) (TxResult, error) {
+	output, logstr, err := Query().TxByHash(txhash).Run(ctx)
+	if err != nil {
+		return TxResult{}, fmt.Errorf("%s: %w", logstr, err)
+	}
+	return ParseTxResult(output)
+}
+
+// Broadcast is a function to broadcast signed transaction bytes in mode
+func (cliTransport) Broadcast(ctx context.Context, txBytes []byte, mode BroadcastMode) (sdk.TxResponse, error) {
+	txResponse := sdk.TxResponse{}
+	txConfig := app.MakeEncodingConfig().TxConfig
+	tx, err := txConfig.TxDecoder()(txBytes)
+	if err != nil {
+		return txResponse, err
+	}
+	txJSON, err := txConfig.TxJSONEncoder()(tx)
+	if err != nil {
+		return txResponse, err
+	}
+	txFile, err := ioutil.TempFile("", "pylons_signed_tx_*.json")
+	if err != nil {
+		return txResponse, err
+	}
+	defer os.Remove(txFile.Name())
+	if _, err = txFile.Write(txJSON); err != nil {
+		txFile.Close()
+		return txResponse, err
+	}
+	if err = txFile.Close(); err != nil {
+		return txResponse, err
+	}
+	output, logstr, err := Tx().Broadcast(txFile.Name(), mode.cliFlag()).Run(ctx)
+	if err != nil {
+		return txResponse, fmt.Errorf("%s: %w", logstr, err)
+	}
+	err = EnvFromContext(ctx).Codec().Decode(output, &txResponse)
+	return txResponse, err
+}
+
+// Account is a function to get account of address
+func (cliTransport) Account(ctx context.Context, addr string) (authtypes.AccountI, error) {
+	var accountI authtypes.AccountI
+	accBytes, logstr, err := withContextHeight(ctx, Query().Account(addr)).Run(ctx)
+	if err != nil {
+		return accountI, fmt.Errorf("%s: %w", logstr, err)
+	}
+	var any codectypes.Any
+	cdc := codec.NewProtoCodec(GetInterfaceRegistry())
+	if err = cdc.UnmarshalJSON(accBytes, &any); err != nil {
+		return accountI, fmt.Errorf("error decoding account %s: %w", string(accBytes), err)
+	}
+	err = cdc.UnpackAny(&any, &accountI)
+	return accountI, err
+}
+
+// Balances is a function to get all balances of address
+func (t cliTransport) Balances(ctx context.Context, addr string) (sdk.Coins, error) {
+	var queryRes banktypes.QueryAllBalancesResponse
+	err := t.runQuery(ctx, Query().Sub("bank", "balances", addr), &queryRes)
+	return queryRes.Balances, err
+}
+
+// SupplyOf is a function to get total supply of denom
+func (t cliTransport) SupplyOf(ctx context.Context, denom string) (sdk.Coin, error) {
+	var supply sdk.Coin
+	err := t.runQuery(ctx, Query().Sub("bank", "total").Flag("denom", denom), &supply)
+	return supply, err
+}
+
+// ListCookbooks is a function to list cookbooks of address
+func (t cliTransport) ListCookbooks(ctx context.Context, addr string) ([]types.Cookbook, error) {
+	listCBResp := types.ListCookbookResponse{}
+	err := t.runQuery(ctx, Query().Pylons().List("list_cookbook", addr), &listCBResp)
+	if err != nil {
+		return listCBResp.Cookbooks, err
+	}
+	return pageCookbooks(ctx, listCBResp.Cookbooks)
+}
+
+// ListRecipes is a function to list recipes of address
+func (t cliTransport) ListRecipes(ctx context.Context, addr string) ([]types.Recipe, error) {
+	listRCPResp := types.ListRecipeResponse{}
+	err := t.runQuery(ctx, Query().Pylons().List("list_recipe", addr), &listRCPResp)
+	if err != nil {
+		return listRCPResp.Recipes, err
+	}
+	return pageRecipes(ctx, listRCPResp.Recipes)
+}
+
+// ListTrades is a function to list trades of address
+func (t cliTransport) ListTrades(ctx context.Context, addr string) ([]types.Trade, error) {
+	listTradesResp := types.ListTradeResponse{}
+	err := t.runQuery(ctx, Query().Pylons().List("list_trade", addr), &listTradesResp)
+	if err != nil {
+		return listTradesResp.Trades, err
+	}
+	return pageTrades(ctx, listTradesResp.Trades)
+}
+
+// ListExecutions is a function to list executions of sender
+func (t cliTransport) ListExecutions(ctx context.Context, sender string) ([]types.Execution, error) {
+	listExecutionsResp := types.ListExecutionsResponse{}
+	err := t.runQuery(ctx, Query().Pylons().List("list_executions", sender), &listExecutionsResp)
+	if err != nil {
+		return listExecutionsResp.Executions, err
+	}
+	return pageExecutions(ctx, listExecutionsResp.Executions)
+}
+
+// ItemsBySender is a function to list items of sender
+func (t cliTransport) ItemsBySender(ctx context.Context, sender string) ([]types.Item, error) {
+	itemResponse := types.ItemsBySenderResponse{}
+	err := t.runQuery(ctx, Query().Pylons().List("items_by_sender", sender), &itemResponse)
+	if err != nil {
+		return itemResponse.Items, err
+	}
+	return pageItems(ctx, itemResponse.Items)
+}
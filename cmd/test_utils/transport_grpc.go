@@ -0,0 +1,157 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	gogogrpc "github.com/gogo/protobuf/grpc"
+	"google.golang.org/grpc"
+)
+
+// queryClientTransport is a struct to query modules through grpc query clients of a client connection
+// It's shared by grpc and tendermint rpc transports which differ in the connection, queries are done at height of context.
+type queryClientTransport struct {
+	pylons types.QueryClient
+	auth   authtypes.QueryClient
+	bank   banktypes.QueryClient
+}
+
+func newQueryClientTransport(conn gogogrpc.ClientConn) queryClientTransport {
+	conn = heightClientConn{conn}
+	return queryClientTransport{
+		pylons: types.NewQueryClient(conn),
+		auth:   authtypes.NewQueryClient(conn),
+		bank:   banktypes.NewQueryClient(conn),
+	}
+}
+
+// Account is a function to get account of address
+func (t queryClientTransport) Account(ctx context.Context, addr string) (authtypes.AccountI, error) {
+	res, err := t.auth.Account(ctx, &authtypes.QueryAccountRequest{Address: addr})
+	if err != nil {
+		return nil, err
+	}
+	var accountI authtypes.AccountI
+	err = GetInterfaceRegistry().UnpackAny(res.Account, &accountI)
+	return accountI, err
+}
+
+// Balances is a function to get all balances of address
+func (t queryClientTransport) Balances(ctx context.Context, addr string) (sdk.Coins, error) {
+	res, err := t.bank.AllBalances(ctx, &banktypes.QueryAllBalancesRequest{Address: addr})
+	if err != nil {
+		return nil, err
+	}
+	return res.Balances, nil
+}
+
+// SupplyOf is a function to get total supply of denom
+func (t queryClientTransport) SupplyOf(ctx context.Context, denom string) (sdk.Coin, error) {
+	res, err := t.bank.SupplyOf(ctx, &banktypes.QuerySupplyOfRequest{Denom: denom})
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	return res.Amount, nil
+}
+
+// ListCookbooks is a function to list cookbooks of address
+func (t queryClientTransport) ListCookbooks(ctx context.Context, addr string) ([]types.Cookbook, error) {
+	res, err := t.pylons.ListCookbook(ctx, &types.ListCookbookRequest{Address: addr})
+	if err != nil {
+		return nil, err
+	}
+	return pageCookbooks(ctx, res.Cookbooks)
+}
+
+// ListRecipes is a function to list recipes of address
+func (t queryClientTransport) ListRecipes(ctx context.Context, addr string) ([]types.Recipe, error) {
+	res, err := t.pylons.ListRecipe(ctx, &types.ListRecipeRequest{Address: addr})
+	if err != nil {
+		return nil, err
+	}
+	return pageRecipes(ctx, res.Recipes)
+}
+
+// ListTrades is a function to list trades of address
+func (t queryClientTransport) ListTrades(ctx context.Context, addr string) ([]types.Trade, error) {
+	res, err := t.pylons.ListTrade(ctx, &types.ListTradeRequest{Address: addr})
+	if err != nil {
+		return nil, err
+	}
+	return pageTrades(ctx, res.Trades)
+}
+
+// ListExecutions is a function to list executions of sender
+func (t queryClientTransport) ListExecutions(ctx context.Context, sender string) ([]types.Execution, error) {
+	res, err := t.pylons.ListExecutions(ctx, &types.ListExecutionsRequest{Sender: sender})
+	if err != nil {
+		return nil, err
+	}
+	return pageExecutions(ctx, res.Executions)
+}
+
+// ItemsBySender is a function to list items of sender
+func (t queryClientTransport) ItemsBySender(ctx context.Context, sender string) ([]types.Item, error) {
+	res, err := t.pylons.ItemsBySender(ctx, &types.ItemsBySenderRequest{Sender: sender})
+	if err != nil {
+		return nil, err
+	}
+	return pageItems(ctx, res.Items)
+}
+
+// grpcTransport is a transport querying grpc endpoint of node
+type grpcTransport struct {
+	queryClientTransport
+	tx         txtypes.ServiceClient
+	tendermint tmservice.ServiceClient
+}
+
+func newGRPCTransport(endpoint string) (Transport, error) {
+	conn, err := grpc.Dial(endpoint, grpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("error connecting grpc endpoint %s: %w", endpoint, err)
+	}
+	return grpcTransport{
+		queryClientTransport: newQueryClientTransport(conn),
+		tx:                   txtypes.NewServiceClient(conn),
+		tendermint:           tmservice.NewServiceClient(conn),
+	}, nil
+}
+
+// Kind is a function to get node interface of transport
+func (grpcTransport) Kind() TransportKind {
+	return TransportGRPC
+}
+
+// LatestHeight is a function to get latest block height of node
+func (t grpcTransport) LatestHeight(ctx context.Context) (int64, error) {
+	res, err := t.tendermint.GetLatestBlock(ctx, &tmservice.GetLatestBlockRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return res.Block.Header.Height, nil
+}
+
+// Tx is a function to get result of committed transaction
+func (t grpcTransport) Tx(ctx context.Context, txhash string) (TxResult, error) {
+	res, err := t.tx.GetTx(ctx, &txtypes.GetTxRequest{Hash: txhash})
+	if err != nil {
+		return TxResult{}, err
+	}
+	return NewTxResult(*res.TxResponse)
+}
+
+// Broadcast is a function to broadcast signed transaction bytes in mode
+func (t grpcTransport) Broadcast(ctx context.Context, txBytes []byte, mode BroadcastMode) (sdk.TxResponse, error) {
+	res, err := t.tx.BroadcastTx(ctx, &txtypes.BroadcastTxRequest{TxBytes: txBytes, Mode: mode.txMode()})
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return *res.TxResponse, nil
+}
@@ -0,0 +1,239 @@
+package inttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// TransportRequest is a struct to describe a request to node, Args are pylonsd arguments or method arguments of other transports
+type TransportRequest struct {
+	Transport TransportKind
+	Method    string
+	Args      []string
+	Stdin     string
+	// Err set by a BeforeRequest hook fails the request without sending it e.g. broadcasts dropped by ChaosHook
+	Err error
+}
+
+// TransportResponse is a struct to describe response of a request to node
+type TransportResponse struct {
+	// Output is pylonsd command output which is parsed by the caller, it's empty for other transports
+	Output []byte
+	// Log is the command line and output which is put in test logs
+	Log string
+	// TxHash and Height are set for requests broadcasting or getting a transaction
+	TxHash   string
+	Height   int64
+	Err      error
+	Duration time.Duration
+}
+
+// TransportHook is an interface to observe requests to node e.g. to log, trace or redact them
+// Hooks are called in registration order and can change Log of response before it's returned to the caller.
+type TransportHook interface {
+	BeforeRequest(ctx context.Context, req *TransportRequest)
+	AfterResponse(ctx context.Context, req *TransportRequest, res *TransportResponse)
+}
+
+// TransportHookFuncs is a struct to make transport hook from functions, nil functions are skipped
+type TransportHookFuncs struct {
+	Before func(ctx context.Context, req *TransportRequest)
+	After  func(ctx context.Context, req *TransportRequest, res *TransportResponse)
+}
+
+// BeforeRequest is a function to call Before function
+func (h TransportHookFuncs) BeforeRequest(ctx context.Context, req *TransportRequest) {
+	if h.Before != nil {
+		h.Before(ctx, req)
+	}
+}
+
+// AfterResponse is a function to call After function
+func (h TransportHookFuncs) AfterResponse(ctx context.Context, req *TransportRequest, res *TransportResponse) {
+	if h.After != nil {
+		h.After(ctx, req, res)
+	}
+}
+
+var (
+	transportHookMux sync.RWMutex
+	transportHooks   []TransportHook
+)
+
+// RegisterTransportHook is a function to add hook called on each request to node
+func RegisterTransportHook(hook TransportHook) {
+	transportHookMux.Lock()
+	defer transportHookMux.Unlock()
+	transportHooks = append(transportHooks, hook)
+}
+
+// ResetTransportHooks is a function to remove hooks registered by RegisterTransportHook, redaction hook is kept
+func ResetTransportHooks() {
+	transportHookMux.Lock()
+	defer transportHookMux.Unlock()
+	transportHooks = []TransportHook{RedactionHook{}}
+}
+
+// getTransportHooks is a function to get registered hooks
+func getTransportHooks() []TransportHook {
+	transportHookMux.RLock()
+	defer transportHookMux.RUnlock()
+	return append([]TransportHook{}, transportHooks...)
+}
+
+// withTransportHooks is a function to run request with registered hooks
+func withTransportHooks(ctx context.Context, req TransportRequest, run func() TransportResponse) TransportResponse {
+	hooks := getTransportHooks()
+	for _, hook := range hooks {
+		hook.BeforeRequest(ctx, &req)
+	}
+	start := time.Now()
+	var res TransportResponse
+	if req.Err != nil {
+		res = TransportResponse{Log: fmt.Sprintf("%s %s ==> %s", req.Method, strings.Join(req.Args, " "), req.Err.Error()), Err: req.Err}
+	} else {
+		res = run()
+	}
+	res.Duration = time.Since(start)
+	for _, hook := range hooks {
+		hook.AfterResponse(ctx, &req, &res)
+	}
+	return res
+}
+
+// hookedTransport is a transport calling registered hooks around requests of wrapped transport
+// pylonsd cli transport is not wrapped as hooks are called for each pylonsd command.
+type hookedTransport struct {
+	Transport
+}
+
+// call is a function to run method of wrapped transport with hooks
+// run sets Err of response and TxHash and Height when they are known.
+func (h hookedTransport) call(ctx context.Context, method string, run func(res *TransportResponse), args ...string) error {
+	res := withTransportHooks(ctx, TransportRequest{Transport: h.Kind(), Method: method, Args: args}, func() TransportResponse {
+		res := TransportResponse{Log: method + " " + strings.Join(args, " ")}
+		run(&res)
+		return res
+	})
+	return res.Err
+}
+
+// txResponseInfo is a function to get tx hash and height from json output of pylonsd tx commands
+func txResponseInfo(output []byte) (string, int64) {
+	info := struct {
+		TxHash string      `json:"txhash"`
+		Height json.Number `json:"height"`
+	}{}
+	if err := json.Unmarshal(output, &info); err != nil {
+		return "", 0
+	}
+	height, _ := info.Height.Int64()
+	return info.TxHash, height
+}
+
+// LatestHeight is a function to get latest block height with hooks
+func (h hookedTransport) LatestHeight(ctx context.Context) (height int64, err error) {
+	err = h.call(ctx, "LatestHeight", func(res *TransportResponse) {
+		height, err = h.Transport.LatestHeight(ctx)
+		res.Err = err
+	})
+	return height, err
+}
+
+// Tx is a function to get committed transaction with hooks
+func (h hookedTransport) Tx(ctx context.Context, txhash string) (result TxResult, err error) {
+	err = h.call(ctx, "Tx", func(res *TransportResponse) {
+		result, err = h.Transport.Tx(ctx, txhash)
+		res.TxHash, res.Height, res.Err = txhash, result.Height, err
+	}, txhash)
+	return result, err
+}
+
+// Broadcast is a function to broadcast signed transaction with hooks
+func (h hookedTransport) Broadcast(ctx context.Context, txBytes []byte, mode BroadcastMode) (txResponse sdk.TxResponse, err error) {
+	err = h.call(ctx, "Broadcast", func(res *TransportResponse) {
+		txResponse, err = h.Transport.Broadcast(ctx, txBytes, mode)
+		res.TxHash, res.Height, res.Err = txResponse.TxHash, txResponse.Height, err
+	})
+	return txResponse, err
+}
+
+// Account is a function to get account with hooks
+func (h hookedTransport) Account(ctx context.Context, addr string) (account authtypes.AccountI, err error) {
+	err = h.call(ctx, "Account", func(res *TransportResponse) {
+		account, err = h.Transport.Account(ctx, addr)
+		res.Err = err
+	}, addr)
+	return account, err
+}
+
+// Balances is a function to get balances with hooks
+func (h hookedTransport) Balances(ctx context.Context, addr string) (balances sdk.Coins, err error) {
+	err = h.call(ctx, "Balances", func(res *TransportResponse) {
+		balances, err = h.Transport.Balances(ctx, addr)
+		res.Err = err
+	}, addr)
+	return balances, err
+}
+
+// SupplyOf is a function to get total supply with hooks
+func (h hookedTransport) SupplyOf(ctx context.Context, denom string) (supply sdk.Coin, err error) {
+	err = h.call(ctx, "SupplyOf", func(res *TransportResponse) {
+		supply, err = h.Transport.SupplyOf(ctx, denom)
+		res.Err = err
+	}, denom)
+	return supply, err
+}
+
+// ListCookbooks is a function to list cookbooks with hooks
+func (h hookedTransport) ListCookbooks(ctx context.Context, addr string) (cookbooks []types.Cookbook, err error) {
+	err = h.call(ctx, "ListCookbooks", func(res *TransportResponse) {
+		cookbooks, err = h.Transport.ListCookbooks(ctx, addr)
+		res.Err = err
+	}, addr)
+	return cookbooks, err
+}
+
+// ListRecipes is a function to list recipes with hooks
+func (h hookedTransport) ListRecipes(ctx context.Context, addr string) (recipes []types.Recipe, err error) {
+	err = h.call(ctx, "ListRecipes", func(res *TransportResponse) {
+		recipes, err = h.Transport.ListRecipes(ctx, addr)
+		res.Err = err
+	}, addr)
+	return recipes, err
+}
+
+// ListTrades is a function to list trades with hooks
+func (h hookedTransport) ListTrades(ctx context.Context, addr string) (trades []types.Trade, err error) {
+	err = h.call(ctx, "ListTrades", func(res *TransportResponse) {
+		trades, err = h.Transport.ListTrades(ctx, addr)
+		res.Err = err
+	}, addr)
+	return trades, err
+}
+
+// ListExecutions is a function to list executions with hooks
+func (h hookedTransport) ListExecutions(ctx context.Context, sender string) (execs []types.Execution, err error) {
+	err = h.call(ctx, "ListExecutions", func(res *TransportResponse) {
+		execs, err = h.Transport.ListExecutions(ctx, sender)
+		res.Err = err
+	}, sender)
+	return execs, err
+}
+
+// ItemsBySender is a function to list items with hooks
+func (h hookedTransport) ItemsBySender(ctx context.Context, sender string) (items []types.Item, err error) {
+	err = h.call(ctx, "ItemsBySender", func(res *TransportResponse) {
+		items, err = h.Transport.ItemsBySender(ctx, sender)
+		res.Err = err
+	}, sender)
+	return items, err
+}
@@ -0,0 +1,121 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/service"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// TransportMemory is the node interface of in-process mock chain, it's selected after transports of it are added by RegisterTransport
+// e.g. RegisterTransport(TransportMemory, func() (Transport, error) { return NewMemoryTransport(chain), nil })
+const TransportMemory TransportKind = "memory"
+
+// MemoryChain is an interface of in-process chain memory transport queries e.g. mockchain.Chain
+type MemoryChain interface {
+	service.PylonsQueryService
+	// Height returns current block height of the chain
+	Height() int64
+	// Balance returns all coins of address
+	Balance(address string) sdk.Coins
+}
+
+// NewMemoryTransport is a function to create transport querying in-process chain e.g. mockchain.Chain
+func NewMemoryTransport(chain MemoryChain) Transport {
+	return memoryTransport{chain: chain}
+}
+
+// memoryTransport is a transport querying in-process mock chain
+// Transactions are not kept by mock chain, so they can't be queried or broadcast through it.
+type memoryTransport struct {
+	chain MemoryChain
+}
+
+var errMemoryTransactions = errors.New("transactions are applied at once and not kept by in-memory chain")
+
+// Kind is a function to get node interface of the transport
+func (memoryTransport) Kind() TransportKind {
+	return TransportMemory
+}
+
+// LatestHeight is a function to get current block height of the chain
+func (t memoryTransport) LatestHeight(ctx context.Context) (int64, error) {
+	return t.chain.Height(), nil
+}
+
+// Tx is a function to get committed transaction, it always fails as transactions are not kept
+func (memoryTransport) Tx(ctx context.Context, txhash string) (TxResult, error) {
+	return TxResult{}, errMemoryTransactions
+}
+
+// Broadcast is a function to broadcast signed transaction, it always fails as msgs are applied by msg service of the chain
+func (memoryTransport) Broadcast(ctx context.Context, txBytes []byte, mode BroadcastMode) (sdk.TxResponse, error) {
+	return sdk.TxResponse{}, errMemoryTransactions
+}
+
+// Account is a function to get account of address, every valid address has an account in memory
+func (memoryTransport) Account(ctx context.Context, addr string) (authtypes.AccountI, error) {
+	accAddr, err := sdk.AccAddressFromBech32(addr)
+	if err != nil {
+		return nil, err
+	}
+	return authtypes.NewBaseAccountWithAddress(accAddr), nil
+}
+
+// Balances is a function to get all balances of address
+func (t memoryTransport) Balances(ctx context.Context, addr string) (sdk.Coins, error) {
+	return t.chain.Balance(addr), nil
+}
+
+// SupplyOf is a function to get total supply of denom, it's not tracked in memory
+func (memoryTransport) SupplyOf(ctx context.Context, denom string) (sdk.Coin, error) {
+	return sdk.Coin{}, errors.New("supply is not tracked by in-memory chain")
+}
+
+// ListCookbooks is a function to list cookbooks of address
+func (t memoryTransport) ListCookbooks(ctx context.Context, addr string) ([]types.Cookbook, error) {
+	res, err := t.chain.ListCookbook(ctx, &types.ListCookbookRequest{Address: addr})
+	if err != nil {
+		return nil, err
+	}
+	return res.Cookbooks, nil
+}
+
+// ListRecipes is a function to list recipes of address
+func (t memoryTransport) ListRecipes(ctx context.Context, addr string) ([]types.Recipe, error) {
+	res, err := t.chain.ListRecipe(ctx, &types.ListRecipeRequest{Address: addr})
+	if err != nil {
+		return nil, err
+	}
+	return res.Recipes, nil
+}
+
+// ListTrades is a function to list trades of address
+func (t memoryTransport) ListTrades(ctx context.Context, addr string) ([]types.Trade, error) {
+	res, err := t.chain.ListTrade(ctx, &types.ListTradeRequest{Address: addr})
+	if err != nil {
+		return nil, err
+	}
+	return res.Trades, nil
+}
+
+// ListExecutions is a function to list executions of sender
+func (t memoryTransport) ListExecutions(ctx context.Context, sender string) ([]types.Execution, error) {
+	res, err := t.chain.ListExecutions(ctx, &types.ListExecutionsRequest{Sender: sender})
+	if err != nil {
+		return nil, err
+	}
+	return res.Executions, nil
+}
+
+// ItemsBySender is a function to list items of sender
+func (t memoryTransport) ItemsBySender(ctx context.Context, sender string) ([]types.Item, error) {
+	res, err := t.chain.ItemsBySender(ctx, &types.ItemsBySenderRequest{Sender: sender})
+	if err != nil {
+		return nil, err
+	}
+	return res.Items, nil
+}
@@ -0,0 +1,176 @@
+package inttest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// restTransport is a transport querying grpc-gateway routes of node rest server
+type restTransport struct {
+	endpoint string
+	client   *http.Client
+}
+
+func newRESTTransport(endpoint string) Transport {
+	return restTransport{endpoint: strings.TrimSuffix(endpoint, "/"), client: http.DefaultClient}
+}
+
+// Kind is a function to get node interface of transport
+func (restTransport) Kind() TransportKind {
+	return TransportREST
+}
+
+// do is a function to send request to route at height of ctx and decode proto json response into res
+func (t restTransport) do(ctx context.Context, method, route string, body proto.Message, res proto.Message) error {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		if reqBody, err = GetJSONMarshaler().MarshalJSON(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, t.endpoint+route, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if height := HeightFromContext(ctx); height > 0 {
+		req.Header.Set(grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrNodeUnavailable, err.Error())
+	}
+	defer resp.Body.Close()
+	output, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s returned %s: %s", method, route, resp.Status, string(output))
+	}
+	return GetJSONMarshaler().UnmarshalJSON(output, res)
+}
+
+// pylonsRoute is a function to get grpc-gateway route of pylons query, its parameter can be empty to query all
+func pylonsRoute(query, param string) string {
+	return fmt.Sprintf("/custom/pylons/%s/%s", query, url.PathEscape(param))
+}
+
+// LatestHeight is a function to get latest block height of node
+func (t restTransport) LatestHeight(ctx context.Context) (int64, error) {
+	res := tmservice.GetLatestBlockResponse{}
+	if err := t.do(ctx, http.MethodGet, "/cosmos/base/tendermint/v1beta1/blocks/latest", nil, &res); err != nil {
+		return 0, err
+	}
+	return res.Block.Header.Height, nil
+}
+
+// Tx is a function to get result of committed transaction
+func (t restTransport) Tx(ctx context.Context, txhash string) (TxResult, error) {
+	res := txtypes.GetTxResponse{}
+	if err := t.do(ctx, http.MethodGet, "/cosmos/tx/v1beta1/txs/"+txhash, nil, &res); err != nil {
+		return TxResult{}, err
+	}
+	return NewTxResult(*res.TxResponse)
+}
+
+// Broadcast is a function to broadcast signed transaction bytes in mode
+func (t restTransport) Broadcast(ctx context.Context, txBytes []byte, mode BroadcastMode) (sdk.TxResponse, error) {
+	res := txtypes.BroadcastTxResponse{}
+	req := txtypes.BroadcastTxRequest{TxBytes: txBytes, Mode: mode.txMode()}
+	if err := t.do(ctx, http.MethodPost, "/cosmos/tx/v1beta1/txs", &req, &res); err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return *res.TxResponse, nil
+}
+
+// Account is a function to get account of address
+func (t restTransport) Account(ctx context.Context, addr string) (authtypes.AccountI, error) {
+	res := authtypes.QueryAccountResponse{}
+	if err := t.do(ctx, http.MethodGet, "/cosmos/auth/v1beta1/accounts/"+addr, nil, &res); err != nil {
+		return nil, err
+	}
+	var accountI authtypes.AccountI
+	err := GetInterfaceRegistry().UnpackAny(res.Account, &accountI)
+	return accountI, err
+}
+
+// Balances is a function to get all balances of address
+func (t restTransport) Balances(ctx context.Context, addr string) (sdk.Coins, error) {
+	res := banktypes.QueryAllBalancesResponse{}
+	err := t.do(ctx, http.MethodGet, "/cosmos/bank/v1beta1/balances/"+addr, nil, &res)
+	return res.Balances, err
+}
+
+// SupplyOf is a function to get total supply of denom
+func (t restTransport) SupplyOf(ctx context.Context, denom string) (sdk.Coin, error) {
+	res := banktypes.QuerySupplyOfResponse{}
+	err := t.do(ctx, http.MethodGet, "/cosmos/bank/v1beta1/supply/"+denom, nil, &res)
+	return res.Amount, err
+}
+
+// ListCookbooks is a function to list cookbooks of address
+func (t restTransport) ListCookbooks(ctx context.Context, addr string) ([]types.Cookbook, error) {
+	res := types.ListCookbookResponse{}
+	err := t.do(ctx, http.MethodGet, pylonsRoute("list_cookbook", addr), nil, &res)
+	if err != nil {
+		return res.Cookbooks, err
+	}
+	return pageCookbooks(ctx, res.Cookbooks)
+}
+
+// ListRecipes is a function to list recipes of address
+func (t restTransport) ListRecipes(ctx context.Context, addr string) ([]types.Recipe, error) {
+	res := types.ListRecipeResponse{}
+	err := t.do(ctx, http.MethodGet, pylonsRoute("list_recipe", addr), nil, &res)
+	if err != nil {
+		return res.Recipes, err
+	}
+	return pageRecipes(ctx, res.Recipes)
+}
+
+// ListTrades is a function to list trades of address
+func (t restTransport) ListTrades(ctx context.Context, addr string) ([]types.Trade, error) {
+	res := types.ListTradeResponse{}
+	err := t.do(ctx, http.MethodGet, pylonsRoute("list_trade", addr), nil, &res)
+	if err != nil {
+		return res.Trades, err
+	}
+	return pageTrades(ctx, res.Trades)
+}
+
+// ListExecutions is a function to list executions of sender
+func (t restTransport) ListExecutions(ctx context.Context, sender string) ([]types.Execution, error) {
+	res := types.ListExecutionsResponse{}
+	err := t.do(ctx, http.MethodGet, pylonsRoute("list_executions", sender), nil, &res)
+	if err != nil {
+		return res.Executions, err
+	}
+	return pageExecutions(ctx, res.Executions)
+}
+
+// ItemsBySender is a function to list items of sender
+func (t restTransport) ItemsBySender(ctx context.Context, sender string) ([]types.Item, error) {
+	res := types.ItemsBySenderResponse{}
+	err := t.do(ctx, http.MethodGet, pylonsRoute("items_by_sender", sender), nil, &res)
+	if err != nil {
+		return res.Items, err
+	}
+	return pageItems(ctx, res.Items)
+}
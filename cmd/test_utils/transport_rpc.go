@@ -0,0 +1,68 @@
+package inttest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+// rpcTransport is a transport querying tendermint rpc of node with abci queries
+type rpcTransport struct {
+	queryClientTransport
+	clientCtx client.Context
+}
+
+func newRPCTransport(node string) (Transport, error) {
+	rpcClient, err := rpchttp.New(node, "/websocket")
+	if err != nil {
+		return nil, fmt.Errorf("error connecting tendermint rpc %s: %w", node, err)
+	}
+	encodingConfig := app.MakeEncodingConfig()
+	clientCtx := client.Context{}.
+		WithClient(rpcClient).
+		WithNodeURI(node).
+		WithTxConfig(encodingConfig.TxConfig).
+		WithJSONMarshaler(encodingConfig.Marshaler).
+		WithInterfaceRegistry(encodingConfig.InterfaceRegistry)
+	return rpcTransport{
+		queryClientTransport: newQueryClientTransport(clientCtx),
+		clientCtx:            clientCtx,
+	}, nil
+}
+
+// Kind is a function to get node interface of transport
+func (rpcTransport) Kind() TransportKind {
+	return TransportRPC
+}
+
+// LatestHeight is a function to get latest block height of node
+func (t rpcTransport) LatestHeight(ctx context.Context) (int64, error) {
+	status, err := t.clientCtx.Client.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// Tx is a function to get result of committed transaction
+func (t rpcTransport) Tx(ctx context.Context, txhash string) (TxResult, error) {
+	res, err := authclient.QueryTx(t.clientCtx, txhash)
+	if err != nil {
+		return TxResult{}, err
+	}
+	return NewTxResult(*res)
+}
+
+// Broadcast is a function to broadcast signed transaction bytes in mode
+func (t rpcTransport) Broadcast(ctx context.Context, txBytes []byte, mode BroadcastMode) (sdk.TxResponse, error) {
+	res, err := t.clientCtx.WithBroadcastMode(mode.cliFlag()).BroadcastTx(txBytes)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return *res, nil
+}
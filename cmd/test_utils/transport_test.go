@@ -0,0 +1,104 @@
+package inttest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// newRESTServer is a function to serve proto json responses of grpc-gateway routes
+func newRESTServer(t *testing.T, responses map[string]proto.Message) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		output, err := GetJSONMarshaler().MarshalJSON(res)
+		t.MustNil(err, "error encoding response")
+		_, _ = w.Write(output)
+	}))
+}
+
+func TestRESTTransport(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	addr := "pylo1gcq3wf0eqw6ahg38aqtlkhq4mnpc2dk3y2ejwu"
+	responses := map[string]proto.Message{
+		"/cosmos/bank/v1beta1/balances/" + addr:  &banktypes.QueryAllBalancesResponse{Balances: sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 100))},
+		"/custom/pylons/list_cookbook/" + addr:   &types.ListCookbookResponse{Cookbooks: []types.Cookbook{{ID: "cookbook1", Sender: addr}}},
+		"/custom/pylons/list_recipe/" + addr:     &types.ListRecipeResponse{},
+		"/custom/pylons/list_trade/" + addr:      &types.ListTradeResponse{},
+		"/custom/pylons/list_executions/" + addr: &types.ListExecutionsResponse{},
+		"/custom/pylons/items_by_sender/" + addr: &types.ItemsBySenderResponse{Items: []types.Item{{ID: "item1", Sender: addr}}},
+	}
+	server := newRESTServer(&t, responses)
+	defer server.Close()
+
+	transport := newRESTTransport(server.URL + "/")
+	coins, err := transport.Balances(context.Background(), addr)
+	t.MustNil(err, "error querying balances")
+	t.WithFields(testing.Fields{
+		"coins": coins.String(),
+	}).MustTrue(coins.AmountOf(types.Pylon).Int64() == 100, "balances should be decoded")
+	items, err := transport.ItemsBySender(context.Background(), addr)
+	t.MustNil(err, "error querying items")
+	t.MustTrue(len(items) == 1 && items[0].ID == "item1", "items should be decoded")
+	_, err = transport.ListCookbooks(context.Background(), "unknown")
+	t.MustTrue(err != nil, "error status should fail the query")
+
+	same := newRESTTransport(server.URL)
+	mismatches := CheckTransportConsistency(context.Background(), addr, transport, same)
+	t.WithFields(testing.Fields{
+		"mismatches": mismatches,
+	}).MustTrue(len(mismatches) == 0, "transports of the same node should agree")
+
+	staleResponses := map[string]proto.Message{}
+	for route, res := range responses {
+		staleResponses[route] = res
+	}
+	staleResponses["/custom/pylons/items_by_sender/"+addr] = &types.ItemsBySenderResponse{}
+	stale := newRESTServer(&t, staleResponses)
+	defer stale.Close()
+	mismatches = CheckTransportConsistency(context.Background(), addr, transport, newRESTTransport(stale.URL))
+	t.WithFields(testing.Fields{
+		"mismatches": mismatches,
+	}).MustTrue(len(mismatches) == 1, "differing items should be reported")
+}
+
+func TestParseTransportKind(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	kind, err := ParseTransportKind("")
+	t.MustTrue(err == nil && kind == TransportCLI, "cli should be default transport")
+	kind, err = ParseTransportKind("GRPC")
+	t.MustTrue(err == nil && kind == TransportGRPC, "transport name should be case insensitive")
+	_, err = ParseTransportKind("websocket")
+	t.MustTrue(err != nil, "unknown transport should fail")
+}
+
+func TestRegisterTransport(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	kind := TransportKind("stub")
+	_, err := ParseTransportKind(string(kind))
+	t.MustTrue(err != nil, "unregistered transport should fail")
+
+	RegisterTransport(kind, func() (Transport, error) { return newRESTTransport("http://first/"), nil })
+	env := NewEnv(CLIOptions{Transport: kind}, nil)
+	first, err := env.Transport()
+	t.MustTrue(err == nil && first.Kind() == TransportREST, "registered transport should be created")
+	cached, _ := env.Transport()
+	t.MustTrue(cached == first, "registered transport should be cached")
+
+	RegisterTransport(kind, func() (Transport, error) { return newRESTTransport("http://second/"), nil })
+	second, err := env.Transport()
+	t.MustTrue(err == nil && second != first, "registering kind again should replace cached transport")
+}
@@ -2,6 +2,7 @@ package inttest
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,11 +12,12 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/Pylons-tech/pylons_sdk/app"
 	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/cosmos/cosmos-sdk/client"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
@@ -23,8 +25,6 @@ import (
 	"github.com/spf13/viper"
 )
 
-var nonceMux sync.Mutex
-
 func fileExists(filename string) bool {
 	info, err := os.Stat(filename)
 	if os.IsNotExist(err) {
@@ -33,17 +33,71 @@ func fileExists(filename string) bool {
 	return !info.IsDir()
 }
 
-// GenTxWithMsg is a function to generate transaction from msg
-func GenTxWithMsg(messages []sdk.Msg) (authsigning.Tx, error) {
+// TxOptions is a struct to set optional fields of transactions
+type TxOptions struct {
+	Memo string
+	// FeeGranter is bech32 address of account granting fee allowance to the signer, it pays the fee instead of the signer
+	FeeGranter string
+	// FeePayer is bech32 address paying the fee instead of the first signer, it should be a signer of the transaction
+	FeePayer string
+	// FeeStrategy decides fees of the transaction, fee strategy of env is used when it's nil
+	FeeStrategy FeeStrategy
+}
+
+// feeAccountsSetter is an interface of transaction builders setting fee payer and fee granter
+// Protobuf transaction builder has them though client.TxBuilder doesn't expose them yet.
+type feeAccountsSetter interface {
+	SetFeePayer(feePayer sdk.AccAddress)
+	SetFeeGranter(feeGranter sdk.AccAddress)
+}
+
+// Apply is a function to set optional fields of transaction builder
+func (opts TxOptions) Apply(txBldr client.TxBuilder) error {
+	txBldr.SetMemo(opts.Memo)
+	if len(opts.FeeGranter) == 0 && len(opts.FeePayer) == 0 {
+		return nil
+	}
+	setter, ok := txBldr.(feeAccountsSetter)
+	if !ok {
+		return errors.New("transaction builder does not support fee payer and fee granter")
+	}
+	if len(opts.FeeGranter) > 0 {
+		granter, err := sdk.AccAddressFromBech32(opts.FeeGranter)
+		if err != nil {
+			return fmt.Errorf("invalid fee granter %s: %w", opts.FeeGranter, err)
+		}
+		setter.SetFeeGranter(granter)
+	}
+	if len(opts.FeePayer) > 0 {
+		payer, err := sdk.AccAddressFromBech32(opts.FeePayer)
+		if err != nil {
+			return fmt.Errorf("invalid fee payer %s: %w", opts.FeePayer, err)
+		}
+		setter.SetFeePayer(payer)
+	}
+	return nil
+}
+
+// GenTxBuilderWithMsg is a function to generate transaction builder from msg
+func GenTxBuilderWithMsg(messages []sdk.Msg) (client.TxBuilder, error) {
+	return GenTxBuilderWithOptions(messages, TxOptions{})
+}
+
+// GenTxBuilderWithOptions is a function to generate transaction builder from msg with optional fields e.g. memo
+func GenTxBuilderWithOptions(messages []sdk.Msg, opts TxOptions) (client.TxBuilder, error) {
+	return genTxBuilder(context.Background(), messages, opts)
+}
+
+// genTxBuilder is a function to generate transaction builder from msg with gas limit and fee strategy of env of ctx
+func genTxBuilder(ctx context.Context, messages []sdk.Msg, opts TxOptions) (client.TxBuilder, error) {
 	var err error
 	for i, msg := range messages {
-		if err = msg.ValidateBasic(); err != nil {
-			return nil, fmt.Errorf("%dth msg does not pass basic validation for %s", i, err.Error())
+		if err = types.ValidateMsg(msg); err != nil {
+			return nil, fmt.Errorf("%dth msg does not pass validation for %w", i, err)
 		}
 	}
 
 	viper.Set("keyring-backend", "test")
-	viper.Set("chain-id", "pylonschain")
 
 	txBldr := app.MakeEncodingConfig().TxConfig.NewTxBuilder()
 	err = txBldr.SetMsgs(messages...)
@@ -51,15 +105,132 @@ func GenTxWithMsg(messages []sdk.Msg) (authsigning.Tx, error) {
 		return nil, err
 	}
 
-	txBldr.SetGasLimit(10000000)
+	env := EnvFromContext(ctx)
+	profile, _ := env.ChainProfile()
+	txBldr.SetGasLimit(profile.TxGasLimit())
+	strategy := opts.FeeStrategy
+	if strategy == nil {
+		strategy = env.FeeStrategy()
+	}
+	fees, err := strategy.Fee(ctx, profile.TxGasLimit())
+	if err != nil {
+		return nil, fmt.Errorf("error deciding transaction fees: %w", err)
+	}
+	txBldr.SetFeeAmount(fees)
+	if err = opts.Apply(txBldr); err != nil {
+		return nil, err
+	}
+	return txBldr, nil
+}
+
+// GenTxWithMsg is a function to generate transaction from msg
+func GenTxWithMsg(messages []sdk.Msg) (authsigning.Tx, error) {
+	return GenTxWithOptions(messages, TxOptions{})
+}
+
+// GenTxWithOptions is a function to generate transaction from msg with optional fields e.g. memo
+func GenTxWithOptions(messages []sdk.Msg, opts TxOptions) (authsigning.Tx, error) {
+	return genTx(context.Background(), messages, opts)
+}
+
+// genTx is a function to generate transaction from msg with gas limit and fee strategy of env of ctx
+func genTx(ctx context.Context, messages []sdk.Msg, opts TxOptions) (authsigning.Tx, error) {
+	txBldr, err := genTxBuilder(ctx, messages, opts)
+	if err != nil {
+		return nil, err
+	}
 	return txBldr.GetTx(), nil
 }
 
-func broadcastTxFile(signedTxFile string, maxRetry int, t *testing.T) (string, error) {
-	if len(CLIOpts.RestEndpoint) == 0 { // broadcast using cli
+// broadcastTxFile is a function to broadcast signed transaction file, it's retried by retry policy when mempool is full
+// Transaction is not broadcast when node reports different chain id from the selected chain profile.
+// Broadcast waits for rate limits of all accounts and of signers, see WaitForBroadcastRateLimit.
+func broadcastTxFile(ctx context.Context, signedTxFile string, maxRetry int, t *testing.T) (string, error) {
+	if err := CheckChainID(ctx); err != nil {
+		return "", err
+	}
+	var signers []string
+	if EnvFromContext(ctx).opts.AccountBroadcastRate > 0 {
+		signers = txFileSigners(signedTxFile)
+	}
+	waited, err := WaitForBroadcastRateLimit(ctx, signers...)
+	if err != nil {
+		return "", err
+	}
+	if waited > 0 {
+		t.WithFields(testing.Fields{
+			"signers": signers,
+			"waited":  waited.String(),
+		}).Debug("waited for broadcast rate limit")
+	}
+	defer observeDuration(txBroadcastDuration, time.Now())
+	var txhash string
+	err = EnvFromContext(ctx).RetryPolicy().Do(ctx, func() error {
+		var err error
+		txhash, err = broadcastTxFileOnce(ctx, signedTxFile, maxRetry, t)
+		return err
+	})
+	if err == nil {
+		watchTxFile(ctx, txhash, signedTxFile)
+	}
+	return txhash, err
+}
+
+// broadcastTxFileViaTransport is a function to broadcast signed transaction file through rpc, grpc or rest transport
+func broadcastTxFileViaTransport(ctx context.Context, transport Transport, signedTxFile string, maxRetry int, t *testing.T) (string, error) {
+	txConfig := app.MakeEncodingConfig().TxConfig
+	tx, err := txConfig.TxJSONDecoder()(ReadFile(signedTxFile, t))
+	if err != nil {
+		return "", fmt.Errorf("error decoding signed transaction: %w", err)
+	}
+	txBytes, err := txConfig.TxEncoder()(tx)
+	if err != nil {
+		return "", fmt.Errorf("error encoding signed transaction: %w", err)
+	}
+	mode := BroadcastModeFromContext(ctx)
+	txResponse, err := transport.Broadcast(ctx, txBytes, mode)
+	if err != nil && EnvFromContext(ctx).RetryPolicy().IsRetryable(err) {
+		// broadcast of the same signed transaction is retried by broadcastTxFile
+		return "", err
+	}
+	t.WithFields(testing.Fields{
+		"transport":        transport.Kind(),
+		"broadcast_mode":   mode,
+		"broadcast_output": AminoCodecFormatter(txResponse),
+	}).MustNil(err, "error broadcasting transaction")
+	if err != nil {
+		return txResponse.TxHash, err
+	}
+	if txResponse.Code == sdkerrors.ErrUnauthorized.ABCICode() &&
+		strings.Contains(txResponse.RawLog, "signature verification failed") && maxRetry > 0 {
+		t.WithFields(testing.Fields{
+			"raw_log":   txResponse.RawLog,
+			"max_retry": maxRetry,
+		}).Info("rebroadcasting after 1s...")
+		ClockFromContext(ctx).Sleep(1 * time.Second)
+		return broadcastTxFileViaTransport(ctx, transport, signedTxFile, maxRetry-1, t)
+	}
+	if err = CheckBroadcastResponse(mode, txResponse); err != nil {
+		return txResponse.TxHash, err
+	}
+	rememberBroadcast(EnvFromContext(ctx), mode, txResponse)
+	return txResponse.TxHash, nil
+}
+
+func broadcastTxFileOnce(ctx context.Context, signedTxFile string, maxRetry int, t *testing.T) (string, error) {
+	env := EnvFromContext(ctx)
+	transport, err := env.Transport()
+	if err != nil {
+		return "", err
+	}
+	if transport.Kind() != TransportCLI {
+		return broadcastTxFileViaTransport(ctx, transport, signedTxFile, maxRetry, t)
+	}
+	if len(env.opts.RestEndpoint) == 0 { // broadcast using cli
 		// pylonsd tx broadcast signedCreateCookbookTx.json
-		txBroadcastArgs := []string{"tx", "broadcast", signedTxFile, "--broadcast-mode=async"}
-		output, logstr, err := RunPylonsd(txBroadcastArgs, "")
+		mode := BroadcastModeFromContext(ctx)
+		broadcastCmd := Tx().Broadcast(signedTxFile, mode.cliFlag())
+		output, logstr, err := broadcastCmd.Run(ctx)
 		// output2, logstr2, err := RunPylonsd([]string{"query", "account", "cosmos10xgn8t2auxskrf2qjcht0hwq2h5chnrpx87dus"}, "")
 		// t.WithFields(testing.Fields{
 		// 	"query_account": logstr2,
@@ -67,13 +238,13 @@ func broadcastTxFile(signedTxFile string, maxRetry int, t *testing.T) (string, e
 		// }).Debug("debug log")
 
 		t.WithFields(testing.Fields{
-			"broadcast_args":   txBroadcastArgs,
+			"broadcast_args":   broadcastCmd.String(),
 			"broadcast_output": string(output),
 			"broadcast_log":    logstr,
 		}).MustNil(err, "error running pylonsd broadcast command")
 		txResponse := sdk.TxResponse{}
 
-		err = GetAminoCdc().UnmarshalJSON(output, &txResponse)
+		err = env.Codec().Decode(output, &txResponse)
 		// This can happen when "pylonsd config output json" is not set or when real issue is available
 		t.WithFields(testing.Fields{
 			"broadcast_output":  string(output),
@@ -91,42 +262,44 @@ func broadcastTxFile(signedTxFile string, maxRetry int, t *testing.T) (string, e
 				"output":    string(output),
 				"max_retry": maxRetry,
 			}).Info("rebroadcasting after 1s...")
-			time.Sleep(1 * time.Second)
-			return broadcastTxFile(signedTxFile, maxRetry-1, t)
+			ClockFromContext(ctx).Sleep(1 * time.Second)
+			return broadcastTxFileOnce(ctx, signedTxFile, maxRetry-1, t)
 		}
-		if txResponse.Code != 0 {
-			return txResponse.TxHash, errors.New(txResponse.RawLog)
+		if err = CheckBroadcastResponse(mode, txResponse); err != nil {
+			return txResponse.TxHash, err
 		}
 		t.WithFields(testing.Fields{
 			"txhash": txResponse.TxHash,
 		}).MustTrue(len(txResponse.TxHash) == 64, "txhash length should have length of 64")
+		rememberBroadcast(EnvFromContext(ctx), mode, txResponse)
 		return txResponse.TxHash, nil
 	}
 	// broadcast using rest endpoint
 	signedTx := ReadFile(signedTxFile, t)
 	postBodyJSON := make(map[string]interface{})
 
-	err := json.Unmarshal(signedTx, &postBodyJSON)
+	err = json.Unmarshal(signedTx, &postBodyJSON)
 	t.WithFields(testing.Fields{
 		"signed_tx": string(signedTx),
 	}).MustNil(err, "error decoding raw json")
 
 	postBodyJSON["tx"] = postBodyJSON["value"]
 	postBodyJSON["value"] = nil
-	postBodyJSON["mode"] = "sync"
+	postBodyJSON["mode"] = string(BroadcastModeFromContext(ctx))
 	postBody, err := json.Marshal(postBodyJSON)
 
 	if err != nil {
 		t.MustNil(err, "fatal log")
 		return "", err
 	}
-	resp, err := http.Post(CLIOpts.RestEndpoint+"/txs", "application/json", bytes.NewBuffer(postBody))
+	resp, err := http.Post(env.opts.RestEndpoint+"/txs", "application/json", bytes.NewBuffer(postBody))
 	if err != nil {
 		t.MustNil(err, "fatal log")
 		return "", err
 	}
 
-	var result map[string]string
+	// block mode response has logs besides txhash
+	var result map[string]interface{}
 
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	t.MustNil(err, "error decoding raw json")
@@ -134,71 +307,55 @@ func broadcastTxFile(signedTxFile string, maxRetry int, t *testing.T) (string, e
 	t.WithFields(testing.Fields{
 		"get_pylons_api_response": result,
 	}).Info("info log")
+	txhash, _ := result["txhash"].(string)
 	t.WithFields(testing.Fields{
-		"txhash": result["txhash"],
-	}).MustTrue(len(result["txhash"]) == 64, "txhash length should have length of 64")
-	return result["txhash"], nil
+		"txhash": txhash,
+	}).MustTrue(len(txhash) == 64, "txhash length should have length of 64")
+	return txhash, nil
 }
 
-// TestTxWithMsg is a function to send transaction with message
-func TestTxWithMsg(t *testing.T, msgValue sdk.Msg, signer string) string {
-	tmpDir, err := ioutil.TempDir("", "pylons")
-	if err != nil {
-		panic(err.Error())
-	}
-	rawTxFile := filepath.Join(tmpDir, "raw_tx.json")
-	signedTxFile := filepath.Join(tmpDir, "signed_tx.json")
-
-	txModel, err := GenTxWithMsg([]sdk.Msg{msgValue})
-	t.MustNil(err, "error while building transaction model from messages")
-	output, err := GetTxJSONEncoder()(txModel)
-	t.MustNil(err, "error encoding transaction model")
+// sendMultiMsgTx is a function to send multiple messages in one transaction with managed nonce
+// it returns output log instead of txhash on error
+func sendMultiMsgTx(ctx context.Context, t *testing.T, msgs []sdk.Msg, signer string, isBech32Addr bool, maxBroadcast int, provider KeyringProvider, txOpts TxOptions) (string, error) {
+	t.WithFields(testing.Fields{
+		"action":    "func_start",
+		"signer":    signer,
+		"is_bech32": isBech32Addr,
+	}).
+		AddFields(GetLogFieldsFromMsgs(msgs)).
+		AddFields(log.Fields{
+			"tx_msgs": AminoCodecFormatter(msgs),
+		}).
+		SetFieldsOrder(testing.SortCustomKey, []string{"action", "signer", "is_bech32"}).
+		Debug("debug log")
 
-	err = ioutil.WriteFile(rawTxFile, output, 0644)
-	if err != nil {
-		t.WithFields(testing.Fields{
-			"tx_model_json": string(output),
-		}).MustNil(err, "error writing raw transaction")
-		return ""
+	if len(msgs) == 0 {
+		return "msgs validation error", errors.New("length of msgs shouldn't be zero")
 	}
-
-	// pylonsd tx sign raw_tx.json --from eugen --chain-id pylonschain > signed_tx.json
-	txSignArgs := []string{"tx", "sign", rawTxFile,
-		"--from", signer,
-		"--chain-id", "pylonschain",
+	if !isBech32Addr {
+		signer = GetAccountAddrWithKeyring(provider, signer, t)
 	}
-	output, _, err = RunPylonsd(txSignArgs, "")
+	txModel, err := genTx(ctx, msgs, txOpts)
 	if err != nil {
-		t.WithFields(testing.Fields{
-			"signed_tx_json": string(output),
-		}).MustNil(err, "error signing transaction")
-		return ""
+		return "error generating transaction with messages", err
 	}
-
-	err = ioutil.WriteFile(signedTxFile, output, 0644)
+	// oversized transaction fails at mempool with opaque error, so it's checked before signing
+	if err = GetTxLimits(ctx).Check(msgs, txOpts); err != nil {
+		return "transaction exceeds tx limits", err
+	}
+	output, err := GetTxJSONEncoder()(txModel)
 	if err != nil {
-		t.MustNil(err, "error writing signed transaction")
-		return ""
+		return "error marshaling transaction into json", err
 	}
 
-	txhash, err := broadcastTxFile(signedTxFile, GetMaxBroadcastRetry(), t)
+	txhash, err := signAndBroadcastRawTx(ctx, t, output, signer, maxBroadcast, provider, msgs)
 	if err != nil {
-		t.WithFields(testing.Fields{
-			"error": err,
-		}).Error("transaction broadcast failure")
-		return ""
+		return txhash, err
 	}
 
-	CleanFile(rawTxFile, t)
-	CleanFile(signedTxFile, t)
-
-	return txhash
-}
-
-// SendMultiMsgTxWithNonce is a function to send multiple messages in one transaction
-func SendMultiMsgTxWithNonce(t *testing.T, msgs []sdk.Msg, signer string, isBech32Addr bool) (string, error) {
 	t.WithFields(testing.Fields{
-		"action":    "func_start",
+		"action":    "func_end",
+		"txhash":    txhash,
 		"signer":    signer,
 		"is_bech32": isBech32Addr,
 	}).
@@ -206,113 +363,103 @@ func SendMultiMsgTxWithNonce(t *testing.T, msgs []sdk.Msg, signer string, isBech
 		AddFields(log.Fields{
 			"tx_msgs": AminoCodecFormatter(msgs),
 		}).
-		SetFieldsOrder(testing.SortCustomKey, []string{"action", "signer", "is_bech32"}).
+		SetFieldsOrder(testing.SortCustomKey, []string{"action", "txhash", "signer", "is_bech32"}).
 		Debug("debug log")
+	return txhash, nil
+}
 
-	if len(msgs) == 0 {
-		return "msgs validation error", errors.New("length of msgs shouldn't be zero")
-	}
+// signAndBroadcastRawTx is a function to sign raw transaction json by signer address with managed nonce and broadcast it
+// msgs are only used for logs and metrics, it returns output log instead of txhash on error
+func signAndBroadcastRawTx(ctx context.Context, t *testing.T, rawTx []byte, signer string, maxBroadcast int, provider KeyringProvider, msgs []sdk.Msg) (string, error) {
 	t.Trace("tx_with_nonce.step.A")
 	tmpDir, err := ioutil.TempDir("", "pylons")
 	if err != nil {
 		return "error creating pylons directory on temp folder", err
 	}
 	t.Trace("tx_with_nonce.step.B")
-	nonceRootDir := "./"
-	nonceFile := filepath.Join(nonceRootDir, "nonce.json")
-	if !isBech32Addr {
-		signer = GetAccountAddr(signer, t)
-	}
-
 	t.Trace("tx_with_nonce.step.C")
 	accInfo := GetAccountInfoFromAddr(signer, t)
 	nonce := accInfo.GetSequence()
 
-	nonceMap := make(map[string]uint64)
-
-	nonceMux.Lock()
-	defer nonceMux.Unlock()
-
-	if fileExists(nonceFile) {
-		nonceBytes := ReadFile(nonceFile, t)
-		err := json.Unmarshal(nonceBytes, &nonceMap)
-		if err != nil {
-			return "error unmarshaling nonce map", err
-		}
-		if existNonce, ok := nonceMap[signer]; ok {
-			nonce = existNonce
-		}
-	}
-	t.Trace("tx_with_nonce.step.D")
+	env := EnvFromContext(ctx)
+	env.state().nonceMux.Lock()
+	defer env.state().nonceMux.Unlock()
 
-	txModel, err := GenTxWithMsg(msgs)
+	nonceMap, err := readNonceMap(env, t)
 	if err != nil {
-		return "error generating transaction with messages", err
+		return "error unmarshaling nonce map", err
 	}
-	t.Trace("tx_with_nonce.step.E")
-	output, err := GetTxJSONEncoder()(txModel)
-	if err != nil {
-		return "error marshaling transaction into json", err
+	if existNonce, ok := nonceMap[signer]; ok {
+		nonce = existNonce
 	}
-
 	t.Trace("tx_with_nonce.step.F")
 	rawTxFile := filepath.Join(tmpDir, "raw_tx_"+strconv.FormatUint(nonce, 10)+".json")
 	signedTxFile := filepath.Join(tmpDir, "signed_tx_"+strconv.FormatUint(nonce, 10)+".json")
-	err = ioutil.WriteFile(rawTxFile, output, 0644)
+	err = ioutil.WriteFile(rawTxFile, rawTx, 0644)
 	if err != nil {
 		t.WithFields(testing.Fields{
-			"tx_model_json": string(output),
+			"tx_model_json": string(rawTx),
 		}).MustNil(err, "error writing raw transaction")
 		return "error writing raw transaction", err
 	}
 
 	t.Trace("tx_with_nonce.step.G")
-	// pylonsd tx sign sample_transaction.json --account-number 2 --sequence 10 --offline --from eugen
-	txSignArgs := []string{"tx", "sign", rawTxFile,
-		"--from", signer,
-		"--offline",
-		"--chain-id", "pylonschain",
-		"--sequence", strconv.FormatUint(nonce, 10),
-		"--account-number", strconv.FormatUint(accInfo.GetAccountNumber(), 10),
-	}
-	output, logstr, err := RunPylonsd(txSignArgs, "")
-	// output, logstr, err := RunPylonsd(txSignArgs, "")
-	// t.WithFields(testing.Fields{
-	// 	"error": err,
-	// 	"log":   logstr,
-	// }).Debug("TX sign result")
-	if err != nil {
-		return "error signing transaction", fmt.Errorf("%s; %s; %s", err.Error(), string(output), logstr)
+	if errMsg, err := signRawTxFile(ctx, rawTxFile, signedTxFile, signer, accInfo.GetAccountNumber(), nonce, provider); err != nil {
+		return errMsg, err
 	}
 	t.Trace("tx_with_nonce.step.H")
-
-	err = ioutil.WriteFile(signedTxFile, output, 0644)
-	if err != nil {
-		return "error writing signed transaction", err
-	}
-
 	t.Trace("tx_with_nonce.step.I")
 
-	txhash, err := broadcastTxFile(signedTxFile, GetMaxBroadcastRetry(), t)
+	txhash, err := broadcastTxFile(ctx, signedTxFile, maxBroadcast, t)
 	t.WithFields(testing.Fields{
 		"sequence":       strconv.FormatUint(nonce, 10),
 		"account-number": strconv.FormatUint(accInfo.GetAccountNumber(), 10),
-		"max-retry":      GetMaxBroadcastRetry(),
+		"max-retry":      maxBroadcast,
 		"tx_msgs":        AminoCodecFormatter(msgs),
 		"error":          err,
 	}).Debug("transaction broadcast debug")
 	if err != nil {
+		observeTxFailure(msgs, err)
 		return "error broadcasting tx file", err
 	}
 	// increase nonce file
 	t.Trace("tx_with_nonce.step.J")
 	nonceMap[signer] = nonce + 1
+	t.Trace("tx_with_nonce.step.K")
+	if errMsg, err := writeNonceMap(env, nonceMap); err != nil {
+		return errMsg, err
+	}
+	t.Trace("tx_with_nonce.step.L")
+
+	CleanFile(rawTxFile, t)
+	CleanFile(signedTxFile, t)
+	return txhash, nil
+}
+
+// nonceFile is the file keeping next sequence of each signer of DefaultEnv, so that transactions can be sent before previous ones are committed
+// Envs of other nodes or chain id keep their sequences in files next to it, see Env.nonceFilePath.
+var nonceFile = filepath.Join("./", "nonce.json")
+
+// readNonceMap is a function to get next sequence of signers from nonce file of env, it should be called while nonceMux of env is locked
+func readNonceMap(env *Env, t *testing.T) (map[string]uint64, error) {
+	nonceMap := make(map[string]uint64)
+	if err := removeStaleNonceFile(env); err != nil {
+		return nonceMap, err
+	}
+	if !fileExists(env.nonceFilePath()) {
+		return nonceMap, nil
+	}
+	err := json.Unmarshal(ReadFile(env.nonceFilePath(), t), &nonceMap)
+	return nonceMap, err
+}
+
+// writeNonceMap is a function to save next sequence of signers into nonce file of env, it returns output log on error
+func writeNonceMap(env *Env, nonceMap map[string]uint64) (string, error) {
 	nonceOutput, err := json.Marshal(nonceMap)
 	if err != nil {
 		return "error marshaling nonceMap", err
 	}
-	t.Trace("tx_with_nonce.step.K")
-	err = ioutil.WriteFile(nonceFile, nonceOutput, 0644)
+	err = ioutil.WriteFile(env.nonceFilePath(), nonceOutput, 0644)
 	if err != nil {
 		exPath := ""
 		ex, err := os.Executable()
@@ -323,36 +470,25 @@ func SendMultiMsgTxWithNonce(t *testing.T, msgs []sdk.Msg, signer string, isBech
 		}
 		return fmt.Sprintf("error writing nonce output file at %s", exPath), err
 	}
-	t.Trace("tx_with_nonce.step.L")
-
-	CleanFile(rawTxFile, t)
-	CleanFile(signedTxFile, t)
-
-	t.WithFields(testing.Fields{
-		"action":    "func_end",
-		"txhash":    txhash,
-		"signer":    signer,
-		"is_bech32": isBech32Addr,
-	}).
-		AddFields(GetLogFieldsFromMsgs(msgs)).
-		AddFields(log.Fields{
-			"tx_msgs": AminoCodecFormatter(msgs),
-		}).
-		SetFieldsOrder(testing.SortCustomKey, []string{"action", "txhash", "signer", "is_bech32"}).
-		Debug("debug log")
-	return txhash, nil
+	return "", nil
 }
 
-// TestTxWithMsgWithNonce is a function to send transaction with message and nonce
-func TestTxWithMsgWithNonce(t *testing.T, msgValue sdk.Msg, signer string, isBech32Addr bool) (string, error) {
-	output, err := SendMultiMsgTxWithNonce(t, []sdk.Msg{msgValue}, signer, isBech32Addr)
+// signRawTxFile is a function to sign raw transaction file offline by signer address with sequence into signed transaction file
+// it returns output log on error
+func signRawTxFile(ctx context.Context, rawTxFile, signedTxFile, signer string, accountNumber, sequence uint64, provider KeyringProvider) (string, error) {
+	// pylonsd tx sign sample_transaction.json --account-number 2 --sequence 10 --offline --from eugen
+	output, logstr, err := Tx().Sign(rawTxFile).
+		From(signer).
+		Offline(accountNumber, sequence).
+		ChainID(EnvFromContext(ctx).ChainID()).
+		WithKeyring(provider).
+		Run(ctx)
 	if err != nil {
-		// output is txhash if it's a success transaction, if fail, it's output log
-		t.WithFields(testing.Fields{
-			"output": output,
-			"error":  err,
-			"func":   "TestTxWithMsgWithNonce",
-		}).Error("error log")
+		return "error signing transaction", fmt.Errorf("%w; %s; %s", err, string(output), logstr)
+	}
+	err = ioutil.WriteFile(signedTxFile, output, 0644)
+	if err != nil {
+		return "error writing signed transaction", err
 	}
-	return output, err
+	return "", nil
 }
@@ -0,0 +1,217 @@
+package inttest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// msgCondition is a struct to describe a condition of msg matcher
+type msgCondition struct {
+	description string
+	check       func(msg sdk.Msg) bool
+}
+
+// MsgMatcher is a struct to describe an expected msg of account transaction history
+// e.g. MatchMsgType("execute_recipe").Where("recipe rcp001", func(msg sdk.Msg) bool { ... }) or MatchMsg(&msg)
+type MsgMatcher struct {
+	conditions []msgCondition
+}
+
+// with is a function to get new matcher having condition added, so a base matcher can be shared
+func (m MsgMatcher) with(description string, check func(msg sdk.Msg) bool) MsgMatcher {
+	conditions := append([]msgCondition{}, m.conditions...)
+	return MsgMatcher{conditions: append(conditions, msgCondition{description, check})}
+}
+
+// MatchAnyMsg is a function to create msg matcher which matches any msg, e.g. for msgs whose content is not asserted
+func MatchAnyMsg() MsgMatcher {
+	return MsgMatcher{}
+}
+
+// MatchMsgType is a function to create msg matcher of msg type e.g. "execute_recipe"
+func MatchMsgType(msgType string) MsgMatcher {
+	return MatchAnyMsg().with("type == "+msgType, func(msg sdk.Msg) bool {
+		return msg.Type() == msgType
+	})
+}
+
+// MatchMsg is a function to create msg matcher of msgs equal to expected msg
+func MatchMsg(expected sdk.Msg) MsgMatcher {
+	return MatchMsgType(expected.Type()).with("equal to "+expected.String(), func(msg sdk.Msg) bool {
+		return msgsEqual(msg, expected)
+	})
+}
+
+// msgsEqual is a function to compare msgs by their encoding, as decoded sdk.Int and sdk.Dec differ from constructed ones in memory
+func msgsEqual(a, b sdk.Msg) bool {
+	if a.Type() != b.Type() {
+		return false
+	}
+	bzA, errA := proto.Marshal(a)
+	bzB, errB := proto.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(bzA, bzB)
+}
+
+// Where is a function to match msgs by custom check described by description
+func (m MsgMatcher) Where(description string, check func(msg sdk.Msg) bool) MsgMatcher {
+	return m.with(description, check)
+}
+
+// Mismatches is a function to get description of the first condition the msg does not satisfy
+// Conditions after it are not checked, so checks added to MatchMsgType can assert msg type.
+func (m MsgMatcher) Mismatches(msg sdk.Msg) []string {
+	for _, condition := range m.conditions {
+		if !condition.check(msg) {
+			return []string{condition.description}
+		}
+	}
+	return []string{}
+}
+
+// Matches is a function to check if msg satisfies all conditions of matcher
+func (m MsgMatcher) Matches(msg sdk.Msg) bool {
+	return len(m.Mismatches(msg)) == 0
+}
+
+// String is a function to get readable description of matcher conditions
+func (m MsgMatcher) String() string {
+	if len(m.conditions) == 0 {
+		return "any msg"
+	}
+	descriptions := []string{}
+	for _, condition := range m.conditions {
+		descriptions = append(descriptions, condition.description)
+	}
+	return strings.Join(descriptions, " && ")
+}
+
+// HistoryMsg is a struct to describe a msg of committed transaction in account history
+type HistoryMsg struct {
+	Height   int64
+	TxHash   string
+	MsgIndex int
+	Msg      sdk.Msg
+}
+
+// String is a function to describe msg with transaction it's sent by
+func (m HistoryMsg) String() string {
+	return fmt.Sprintf("%s at height %d (tx %s msg %d)", m.Msg.Type(), m.Height, m.TxHash, m.MsgIndex)
+}
+
+// accountTxQuery is a function to get tx search query of transactions sent by address from height
+func accountTxQuery(addr string, sinceHeight int64) string {
+	return fmt.Sprintf("%s AND tx.height>=%d", senderTxQuery(addr), sinceHeight)
+}
+
+// signedBy is a function to check if msg is signed by address
+func signedBy(msg sdk.Msg, addr string) bool {
+	for _, signer := range msg.GetSigners() {
+		if signer.String() == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// getAccountMsgs is a function to get msgs signed by address in successful transactions committed from sinceHeight in commit order
+// Msgs of other signers in the same transactions are excluded, and failed transactions are returned separately.
+func getAccountMsgs(ctx context.Context, rpc historyRPC, addr string, sinceHeight int64) ([]HistoryMsg, []HistoryTx, error) {
+	txs := map[string]HistoryTx{}
+	if err := searchAllTxs(ctx, rpc, accountTxQuery(addr, sinceHeight), txs); err != nil {
+		return nil, nil, err
+	}
+	msgs := []HistoryMsg{}
+	failedTxs := []HistoryTx{}
+	for _, tx := range sortedHistoryTxs(txs) {
+		if tx.Height < sinceHeight {
+			continue
+		}
+		if tx.Code != 0 {
+			failedTxs = append(failedTxs, tx)
+			continue
+		}
+		for idx, msg := range tx.Msgs {
+			if signedBy(msg, addr) {
+				msgs = append(msgs, HistoryMsg{Height: tx.Height, TxHash: tx.TxHash, MsgIndex: idx, Msg: msg})
+			}
+		}
+	}
+	return msgs, failedTxs, nil
+}
+
+// txHistoryMismatches is a function to compare msgs with expected matchers in order
+// Identical msgs of different transactions are pointed out as they are usually broadcast twice by retries.
+func txHistoryMismatches(msgs []HistoryMsg, expected []MsgMatcher) []string {
+	mismatches := []string{}
+	for idx := 0; idx < len(msgs) || idx < len(expected); idx++ {
+		switch {
+		case idx >= len(msgs):
+			mismatches = append(mismatches, fmt.Sprintf("msg %d is missing, expected %s", idx, expected[idx]))
+		case idx >= len(expected):
+			mismatches = append(mismatches, fmt.Sprintf("msg %d %s is not expected", idx, msgs[idx]))
+		default:
+			if failed := expected[idx].Mismatches(msgs[idx].Msg); len(failed) > 0 {
+				mismatches = append(mismatches, fmt.Sprintf("msg %d %s doesn't match %s", idx, msgs[idx], strings.Join(failed, " && ")))
+			}
+		}
+		if idx > 0 && idx < len(msgs) && msgs[idx].TxHash != msgs[idx-1].TxHash && msgsEqual(msgs[idx].Msg, msgs[idx-1].Msg) {
+			mismatches = append(mismatches, fmt.Sprintf("msg %d is identical to msg %d of another transaction, it may be a duplicate broadcast", idx, idx-1))
+		}
+	}
+	return mismatches
+}
+
+// CheckTxHistory is a function to get msgs signed by address since sinceHeight and mismatches of them from expected in order
+// It returns no mismatch when exactly the expected msgs were committed, failed transactions are not counted.
+func CheckTxHistory(ctx context.Context, addr string, sinceHeight int64, expected []MsgMatcher) ([]HistoryMsg, []string, error) {
+	rpc, err := newHistoryRPC()
+	if err != nil {
+		return nil, nil, err
+	}
+	msgs, _, err := getAccountMsgs(ctx, rpc, addr, sinceHeight)
+	if err != nil {
+		return nil, nil, err
+	}
+	return msgs, txHistoryMismatches(msgs, expected), nil
+}
+
+// AssertTxHistory is a function to fail the test when msgs committed for address since sinceHeight are not exactly
+// the expected ones in order, e.g. to catch a transaction broadcast twice by retry logic
+// Node should index transactions, failure shows the actual msg sequence with transactions they were sent by.
+func AssertTxHistory(t *testing.T, addr string, sinceHeight int64, expected []MsgMatcher) []HistoryMsg {
+	rpc, err := newHistoryRPC()
+	t.MustNil(err, "error connecting node to get transaction history")
+	return assertTxHistory(TestContext(t), t, rpc, addr, sinceHeight, expected)
+}
+
+func assertTxHistory(ctx context.Context, t *testing.T, rpc historyRPC, addr string, sinceHeight int64, expected []MsgMatcher) []HistoryMsg {
+	msgs, failedTxs, err := getAccountMsgs(ctx, rpc, addr, sinceHeight)
+	t.MustNil(err, "error getting transaction history of account")
+	if mismatches := txHistoryMismatches(msgs, expected); len(mismatches) > 0 {
+		expectedLines, actualLines, failedLines := []string{}, []string{}, []string{}
+		for _, matcher := range expected {
+			expectedLines = append(expectedLines, matcher.String())
+		}
+		for _, msg := range msgs {
+			actualLines = append(actualLines, msg.String())
+		}
+		for _, tx := range failedTxs {
+			failedLines = append(failedLines, fmt.Sprintf("tx %s at height %d: %s", tx.TxHash, tx.Height, tx.Log))
+		}
+		t.WithFields(testing.Fields{
+			"address":      addr,
+			"since_height": sinceHeight,
+			"expected":     "\n" + strings.Join(expectedLines, "\n"),
+			"actual":       "\n" + strings.Join(actualLines, "\n"),
+			"failed_txs":   "\n" + strings.Join(failedLines, "\n"),
+			"mismatches":   "\n" + strings.Join(mismatches, "\n"),
+		}).Fatal("transaction history is different from expected")
+	}
+	return msgs
+}
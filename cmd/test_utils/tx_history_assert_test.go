@@ -0,0 +1,70 @@
+package inttest
+
+import (
+	"context"
+	"strings"
+	originT "testing"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestAssertTxHistory(originT *originT.T) {
+	t := testing.NewT(originT)
+	player := sdk.AccAddress([]byte("tx_history_player___")).String()
+	other := sdk.AccAddress([]byte("tx_history_other____")).String()
+	getPylons := types.NewMsgGetPylons(sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 10)), player)
+	otherPylons := types.NewMsgGetPylons(sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 10)), other)
+	sendCoins := types.NewMsgSendCoins(sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 5)), player, other)
+	encodeTx := func(memo string, msgs ...sdk.Msg) []byte {
+		txModel, err := GenTxWithOptions(msgs, TxOptions{Memo: memo})
+		t.MustNil(err, "error generating transaction")
+		txBytes, err := app.MakeEncodingConfig().TxConfig.TxEncoder()(txModel)
+		t.MustNil(err, "error encoding transaction")
+		return txBytes
+	}
+
+	rpc := &fakeHistoryRPC{
+		blocks: map[int64]tmtypes.Txs{
+			4: {encodeTx("before", &getPylons)},
+			5: {encodeTx("first", &getPylons, &otherPylons)},
+			6: {encodeTx("failed", &sendCoins)},
+			7: {encodeTx("send", &sendCoins)},
+		},
+		results: map[int64][]*abci.ResponseDeliverTx{
+			4: {{}},
+			5: {{}},
+			6: {{Code: 5, Log: "insufficient funds"}},
+			7: {{}},
+		},
+	}
+	msgs, failedTxs, err := getAccountMsgs(context.Background(), rpc, player, 5)
+	t.MustNil(err, "error getting account msgs")
+	t.MustTrue(rpc.query == accountTxQuery(player, 5), "transactions should be searched by sender from height")
+	t.MustTrue(len(msgs) == 2 && msgs[0].Height == 5 && msgs[1].Height == 7, "msgs of account should be in commit order from height, msgs of other signers excluded")
+	t.MustTrue(len(failedTxs) == 1 && failedTxs[0].Height == 6, "failed transaction should not be counted")
+
+	expected := []MsgMatcher{
+		MatchMsg(&getPylons),
+		MatchMsgType(sendCoins.Type()).Where("receiver is other", func(msg sdk.Msg) bool {
+			return msg.(*types.MsgSendCoins).Receiver == other
+		}),
+	}
+	assertTxHistory(context.Background(), &t, rpc, player, 5, expected)
+
+	mismatches := txHistoryMismatches(msgs, expected[1:])
+	t.WithFields(testing.Fields{
+		"mismatches": mismatches,
+	}).MustTrue(len(mismatches) == 2 && strings.Contains(mismatches[0], "type == send_coins") && strings.Contains(mismatches[1], "is not expected"), "extra msg should be reported")
+
+	duplicated := append([]HistoryMsg{msgs[0]}, msgs...)
+	duplicated[1].TxHash = "retried"
+	mismatches = txHistoryMismatches(duplicated, append([]MsgMatcher{MatchAnyMsg()}, expected...))
+	t.WithFields(testing.Fields{
+		"mismatches": mismatches,
+	}).MustTrue(len(mismatches) == 1 && strings.Contains(mismatches[0], "duplicate broadcast"), "identical msgs of different transactions should be pointed out")
+}
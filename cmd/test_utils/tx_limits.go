@@ -0,0 +1,188 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// DefaultMaxTxBytes is the default max_tx_bytes of tendermint mempool, transactions larger than it are rejected by nodes
+// even when consensus params allow larger blocks.
+const DefaultMaxTxBytes int64 = 1048576
+
+// txSignatureOverhead is the bytes a secp256k1 signature and signer info add to encoded unsigned transaction
+const txSignatureOverhead = 160
+
+// ErrTxTooLarge is an error of transaction exceeding max bytes or max msgs, it's returned before broadcast
+var ErrTxTooLarge = errors.New("tx exceeds tx limits")
+
+// TxLimits is a struct to describe the largest transaction nodes accept, MaxMsgs is not checked when it's 0
+type TxLimits struct {
+	MaxBytes int64
+	MaxMsgs  int
+	env      *Env // env transactions are generated with to be measured, DefaultEnv when it's nil
+}
+
+// consensusParamsRPC is an interface of tendermint rpc query of consensus params, implemented by rpc http client
+type consensusParamsRPC interface {
+	ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error)
+}
+
+var (
+	maxTxBytesMux     sync.Mutex
+	maxTxBytesResults = map[string]int64{}
+)
+
+// getMaxTxBytes is a function to get max bytes of transaction from block max bytes of consensus params
+// It's capped by DefaultMaxTxBytes as mempool limit of nodes can't be queried.
+func getMaxTxBytes(ctx context.Context, rpc consensusParamsRPC) (int64, error) {
+	res, err := rpc.ConsensusParams(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error getting consensus params: %w", err)
+	}
+	maxBytes := res.ConsensusParams.Block.MaxBytes
+	if maxBytes <= 0 || maxBytes > DefaultMaxTxBytes {
+		return DefaultMaxTxBytes, nil
+	}
+	return maxBytes, nil
+}
+
+// GetTxLimits is a function to get tx limits of the first node, max bytes is queried once per node
+// Max bytes of chain profile of env overrides consensus params, DefaultMaxTxBytes is used when node can't be queried.
+func GetTxLimits(ctx context.Context) TxLimits {
+	env := EnvFromContext(ctx)
+	profile, _ := env.ChainProfile()
+	limits := TxLimits{MaxBytes: profile.MaxTxBytes, MaxMsgs: profile.MaxTxMsgs, env: env}
+	if limits.MaxBytes > 0 {
+		return limits
+	}
+	node := env.firstNode()
+	maxTxBytesMux.Lock()
+	defer maxTxBytesMux.Unlock()
+	if maxBytes, ok := maxTxBytesResults[node]; ok {
+		limits.MaxBytes = maxBytes
+		return limits
+	}
+	limits.MaxBytes = DefaultMaxTxBytes
+	rpcClient, err := rpchttp.New(node, "/websocket")
+	if err != nil {
+		return limits
+	}
+	if maxBytes, err := getMaxTxBytes(ctx, rpcClient); err == nil {
+		maxTxBytesResults[node] = maxBytes
+		limits.MaxBytes = maxBytes
+	}
+	return limits
+}
+
+// TxSize is a function to get encoded bytes of transaction of msgs after it's signed by a single signer
+func TxSize(msgs []sdk.Msg, opts TxOptions) (int64, error) {
+	return txSize(context.Background(), msgs, opts)
+}
+
+// txSize is a function to get encoded bytes of signed transaction of msgs generated with env of ctx
+func txSize(ctx context.Context, msgs []sdk.Msg, opts TxOptions) (int64, error) {
+	tx, err := genTx(ctx, msgs, opts)
+	if err != nil {
+		return 0, err
+	}
+	txBytes, err := app.MakeEncodingConfig().TxConfig.TxEncoder()(tx)
+	if err != nil {
+		return 0, fmt.Errorf("error encoding transaction: %w", err)
+	}
+	return int64(len(txBytes)) + txSignatureOverhead, nil
+}
+
+// Check is a function to check transaction of msgs fits in tx limits, error wraps ErrTxTooLarge when it doesn't
+func (l TxLimits) Check(msgs []sdk.Msg, opts TxOptions) error {
+	if l.MaxMsgs > 0 && len(msgs) > l.MaxMsgs {
+		return fmt.Errorf("%w: transaction has %d msgs, max is %d", ErrTxTooLarge, len(msgs), l.MaxMsgs)
+	}
+	if l.MaxBytes <= 0 {
+		return nil
+	}
+	size, err := txSize(ContextWithEnv(context.Background(), l.env), msgs, opts)
+	if err != nil {
+		return err
+	}
+	if size > l.MaxBytes {
+		return fmt.Errorf("%w: transaction of %d msgs has %d bytes, max is %d", ErrTxTooLarge, len(msgs), size, l.MaxBytes)
+	}
+	return nil
+}
+
+// Split is a function to split msgs into batches in order, each batch fits in tx limits
+// Error wraps ErrTxTooLarge when a single msg doesn't fit in a transaction.
+func (l TxLimits) Split(msgs []sdk.Msg, opts TxOptions) ([][]sdk.Msg, error) {
+	batches := [][]sdk.Msg{}
+	batch := []sdk.Msg{}
+	for idx, msg := range msgs {
+		candidate := append(append([]sdk.Msg{}, batch...), msg)
+		err := l.Check(candidate, opts)
+		if err == nil {
+			batch = candidate
+			continue
+		}
+		if !errors.Is(err, ErrTxTooLarge) {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return nil, fmt.Errorf("%dth msg doesn't fit in a transaction: %w", idx, err)
+		}
+		batches = append(batches, batch)
+		batch = []sdk.Msg{msg}
+		if err = l.Check(batch, opts); err != nil {
+			return nil, fmt.Errorf("%dth msg doesn't fit in a transaction: %w", idx, err)
+		}
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+// SendTxBatches is a function to send msgs in as few transactions as tx limits of the node allow, it returns txhashes in order
+// Msgs are not atomic across batches, sending stops at the first batch failing to broadcast.
+func (c *Client) SendTxBatches(ctx context.Context, t *testing.T, signer Signer, msgs ...sdk.Msg) ([]string, error) {
+	batches, err := GetTxLimits(c.withContext(ctx)).Split(msgs, c.txOpts)
+	if err != nil {
+		return []string{}, err
+	}
+	if len(batches) > 1 {
+		t.WithFields(testing.Fields{
+			"signer":    signer.String(),
+			"len_msgs":  len(msgs),
+			"len_batch": len(batches),
+		}).Info("msgs are split into transactions by tx limits")
+	}
+	txhashes := []string{}
+	for _, batch := range batches {
+		txhash, err := c.SendTx(ctx, t, signer, batch...)
+		if err != nil {
+			return txhashes, err
+		}
+		txhashes = append(txhashes, txhash)
+	}
+	return txhashes, nil
+}
+
+// SendTxBatchesAndWait is a function to send msgs split by tx limits and wait for results of all transactions
+func (c *Client) SendTxBatchesAndWait(ctx context.Context, t *testing.T, signer Signer, msgs ...sdk.Msg) ([]TxResult, error) {
+	txhashes, err := c.SendTxBatches(ctx, t, signer, msgs...)
+	txResults := []TxResult{}
+	for _, txhash := range txhashes {
+		txResult, waitErr := c.WaitForTxResult(ctx, t, txhash)
+		txResults = append(txResults, txResult)
+		if waitErr != nil {
+			return txResults, waitErr
+		}
+	}
+	return txResults, err
+}
@@ -0,0 +1,59 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+type fakeConsensusParamsRPC struct {
+	maxBytes int64
+}
+
+func (f fakeConsensusParamsRPC) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
+	return &ctypes.ResultConsensusParams{
+		ConsensusParams: tmproto.ConsensusParams{Block: tmproto.BlockParams{MaxBytes: f.maxBytes}},
+	}, nil
+}
+
+func TestGetMaxTxBytes(originT *originT.T) {
+	t := testing.NewT(originT)
+	maxBytes, err := getMaxTxBytes(context.Background(), fakeConsensusParamsRPC{maxBytes: 200000})
+	t.MustNil(err, "consensus params should be queried")
+	t.MustTrue(maxBytes == 200000, "block max bytes should limit transactions")
+	maxBytes, err = getMaxTxBytes(context.Background(), fakeConsensusParamsRPC{maxBytes: 22020096})
+	t.MustNil(err, "consensus params should be queried")
+	t.MustTrue(maxBytes == DefaultMaxTxBytes, "mempool max tx bytes should cap block max bytes")
+}
+
+func TestTxLimitsSplit(originT *originT.T) {
+	t := testing.NewT(originT)
+	sender := sdk.AccAddress([]byte("tx_limits_sender____")).String()
+	msgs := []sdk.Msg{}
+	for idx := 0; idx < 5; idx++ {
+		msg := types.NewMsgGetPylons(types.PremiumTier.Fee, sender)
+		msgs = append(msgs, &msg)
+	}
+	size, err := TxSize(msgs[:2], TxOptions{})
+	t.MustNil(err, "size of transaction should be computed")
+	t.MustNil(TxLimits{MaxBytes: size}.Check(msgs[:2], TxOptions{}), "transaction of max bytes should fit")
+	err = TxLimits{MaxBytes: size}.Check(msgs[:3], TxOptions{})
+	t.MustTrue(errors.Is(err, ErrTxTooLarge), "transaction over max bytes should not fit")
+
+	batches, err := TxLimits{MaxBytes: size}.Split(msgs, TxOptions{})
+	t.MustNil(err, "msgs should be split by max bytes")
+	t.MustTrue(len(batches) == 3 && len(batches[0]) == 2 && len(batches[2]) == 1, "msgs should be split into batches of 2 msgs in order")
+	batches, err = TxLimits{MaxBytes: DefaultMaxTxBytes, MaxMsgs: 4}.Split(msgs, TxOptions{})
+	t.MustNil(err, "msgs should be split by max msgs")
+	t.MustTrue(len(batches) == 2 && len(batches[0]) == 4, "batches should have up to max msgs")
+
+	_, err = TxLimits{MaxBytes: 10}.Split(msgs, TxOptions{})
+	t.MustTrue(errors.Is(err, ErrTxTooLarge), "msg larger than max bytes should not be split")
+	t.MustContain(err.Error(), "0th msg doesn't fit in a transaction", "error should tell which msg doesn't fit")
+}
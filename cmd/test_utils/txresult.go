@@ -0,0 +1,200 @@
+package inttest
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/events"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/gogo/protobuf/proto"
+)
+
+// TxResult is a struct to manage parsed transaction result from cli or rpc output
+type TxResult struct {
+	sdk.TxResponse
+	MsgData []*sdk.MsgData
+}
+
+// ParseTxResult is a function to parse cli or rpc json output into transaction result
+func ParseTxResult(output []byte) (TxResult, error) {
+	txResponse := sdk.TxResponse{}
+	// broadcast output of older nodes is encoded by amino json
+	if err := GetCodec().Decode(output, &txResponse); err != nil {
+		return TxResult{TxResponse: txResponse}, fmt.Errorf("error parsing tx result: %s; %s", err.Error(), string(output))
+	}
+	return NewTxResult(txResponse)
+}
+
+// NewTxResult is a function to create transaction result from tx response parsing msg data of it
+func NewTxResult(txResponse sdk.TxResponse) (TxResult, error) {
+	result := TxResult{TxResponse: txResponse}
+	if len(result.Data) == 0 {
+		return result, nil
+	}
+	dataBytes, err := hex.DecodeString(result.Data)
+	if err != nil {
+		return result, err
+	}
+	txMsgData := sdk.TxMsgData{}
+	if err = proto.Unmarshal(dataBytes, &txMsgData); err != nil {
+		return result, err
+	}
+	result.MsgData = txMsgData.Data
+	return result, nil
+}
+
+// GetTxResult is a function to query transaction and parse it into transaction result
+func GetTxResult(txhash string) (TxResult, error) {
+	return getTxResult(context.Background(), txhash)
+}
+
+// getTxResult is a function to get result of committed transaction by txhash, span of ctx is parent of the query span
+func getTxResult(ctx context.Context, txhash string) (TxResult, error) {
+	transport, err := EnvFromContext(ctx).Transport()
+	if err != nil {
+		return TxResult{}, err
+	}
+	return transport.Tx(ctx, txhash)
+}
+
+// Err is a function to get error from transaction result code and raw log
+func (r TxResult) Err() error {
+	if r.Code == 0 {
+		return nil
+	}
+	return NewTxError(r.Codespace, r.Code, r.RawLog)
+}
+
+// Fee is a function to get fee paid by committed transaction from the tx of the result
+func (r TxResult) Fee() (sdk.Coins, error) {
+	if r.Tx == nil {
+		return nil, fmt.Errorf("tx result %s does not have tx", r.TxHash)
+	}
+	tx := txtypes.Tx{}
+	if err := proto.Unmarshal(r.Tx.Value, &tx); err != nil {
+		return nil, fmt.Errorf("error decoding tx of tx result %s: %w", r.TxHash, err)
+	}
+	if tx.AuthInfo == nil || tx.AuthInfo.Fee == nil {
+		return sdk.Coins{}, nil
+	}
+	return tx.AuthInfo.Fee.Amount, nil
+}
+
+// GetEvents is a function to get events of a type from all msg logs
+func (r TxResult) GetEvents(eventType string) []sdk.StringEvent {
+	events := []sdk.StringEvent{}
+	for _, msgLog := range r.Logs {
+		for _, event := range msgLog.Events {
+			if event.Type == eventType {
+				events = append(events, event)
+			}
+		}
+	}
+	return events
+}
+
+// ChainEvents is a function to get events of all msg logs in order to be observed by tests
+func (r TxResult) ChainEvents() []testing.ChainEvent {
+	events := []testing.ChainEvent{}
+	for _, msgLog := range r.Logs {
+		for _, event := range msgLog.Events {
+			chainEvent := testing.ChainEvent{Type: event.Type}
+			for _, attr := range event.Attributes {
+				chainEvent.Attributes = append(chainEvent.Attributes, testing.EventAttribute{Key: attr.Key, Value: attr.Value})
+			}
+			events = append(events, chainEvent)
+		}
+	}
+	return events
+}
+
+// PylonsEvents is a function to decode events of all msg logs into typed events of events package
+func (r TxResult) PylonsEvents() ([]events.Event, error) {
+	return events.DecodeTxResponse(r.TxResponse)
+}
+
+// GetEventAttributes is a function to get attribute values of a key from events of a type
+func (r TxResult) GetEventAttributes(eventType, key string) []string {
+	values := []string{}
+	for _, event := range r.GetEvents(eventType) {
+		for _, attr := range event.Attributes {
+			if attr.Key == key {
+				values = append(values, attr.Value)
+			}
+		}
+	}
+	return values
+}
+
+// GetMsgResponse is a function to decode idx-th msg response after checking msg type
+func (r TxResult) GetMsgResponse(idx int, msgType string, resp proto.Message) error {
+	if idx >= len(r.MsgData) {
+		return fmt.Errorf("tx result has %d msg data, requested %dth", len(r.MsgData), idx)
+	}
+	if r.MsgData[idx].MsgType != msgType {
+		return fmt.Errorf("%dth msg type is %s, expected %s", idx, r.MsgData[idx].MsgType, msgType)
+	}
+	return proto.Unmarshal(r.MsgData[idx].Data, resp)
+}
+
+// GetExecID is a function to get scheduled execution ID from execute recipe transaction result
+func (r TxResult) GetExecID() (string, error) {
+	for idx, msgData := range r.MsgData {
+		if msgData.MsgType != (types.MsgExecuteRecipe{}).Type() {
+			continue
+		}
+		resp := types.MsgExecuteRecipeResponse{}
+		if err := r.GetMsgResponse(idx, msgData.MsgType, &resp); err != nil {
+			return "", err
+		}
+		var scheduleRes types.ExecuteRecipeScheduleOutput
+		if err := json.Unmarshal(resp.Output, &scheduleRes); err != nil || len(scheduleRes.ExecID) == 0 {
+			continue // straight execution
+		}
+		return scheduleRes.ExecID, nil
+	}
+	return "", errors.New("no scheduled execution found from tx result")
+}
+
+// GetCreatedItemIDs is a function to get IDs of items created by fiat item, execute recipe or check execution
+func (r TxResult) GetCreatedItemIDs() ([]string, error) {
+	itemIDs := []string{}
+	for idx, msgData := range r.MsgData {
+		var output []byte
+		switch msgData.MsgType {
+		case (types.MsgFiatItem{}).Type():
+			resp := types.MsgFiatItemResponse{}
+			if err := r.GetMsgResponse(idx, msgData.MsgType, &resp); err != nil {
+				return itemIDs, err
+			}
+			itemIDs = append(itemIDs, resp.ItemID)
+			continue
+		case (types.MsgExecuteRecipe{}).Type():
+			resp := types.MsgExecuteRecipeResponse{}
+			if err := r.GetMsgResponse(idx, msgData.MsgType, &resp); err != nil {
+				return itemIDs, err
+			}
+			output = resp.Output
+		case (types.MsgCheckExecution{}).Type():
+			resp := types.MsgCheckExecutionResponse{}
+			if err := r.GetMsgResponse(idx, msgData.MsgType, &resp); err != nil {
+				return itemIDs, err
+			}
+			output = resp.Output
+		default:
+			continue
+		}
+		_, outputItemIDs, err := DecodeExecutionOutput(output)
+		if err != nil {
+			continue // scheduled execution or non-entry output
+		}
+		itemIDs = append(itemIDs, outputItemIDs...)
+	}
+	return itemIDs, nil
+}
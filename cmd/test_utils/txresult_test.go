@@ -0,0 +1,88 @@
+package inttest
+
+import (
+	"encoding/hex"
+	"fmt"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+func TestParseTxResult(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	respBytes, err := proto.Marshal(&types.MsgFiatItemResponse{ItemID: "item001", Status: "Success"})
+	t.MustNil(err, "error encoding msg response")
+	dataBytes, err := proto.Marshal(&sdk.TxMsgData{
+		Data: []*sdk.MsgData{{MsgType: (types.MsgFiatItem{}).Type(), Data: respBytes}},
+	})
+	t.MustNil(err, "error encoding tx msg data")
+
+	output := fmt.Sprintf(`{"txhash":"ABCD","code":0,"data":"%s","gas_used":"1000","logs":[{"msg_index":0,"events":[{"type":"message","attributes":[{"key":"action","value":"fiat_item"}]}]}]}`, hex.EncodeToString(dataBytes))
+	txResult, err := ParseTxResult([]byte(output))
+	t.WithFields(testing.Fields{
+		"output": output,
+	}).MustNil(err, "error parsing tx result")
+	t.MustNil(txResult.Err(), "successful tx result should not have error")
+	t.MustTrue(txResult.TxHash == "ABCD", "txhash should be parsed")
+	t.MustTrue(txResult.GasUsed == 1000, "gas used should be parsed")
+
+	actions := txResult.GetEventAttributes("message", "action")
+	t.WithFields(testing.Fields{
+		"actions": actions,
+	}).MustTrue(len(actions) == 1 && actions[0] == "fiat_item", "event attributes should be parsed")
+	t.ExpectEvent("message", map[string]string{"action": "fiat_item"})
+	for _, event := range txResult.ChainEvents() {
+		t.ObserveEvent(event)
+	}
+
+	itemIDs, err := txResult.GetCreatedItemIDs()
+	t.MustNil(err, "error getting created item ids")
+	t.WithFields(testing.Fields{
+		"item_ids": itemIDs,
+	}).MustTrue(len(itemIDs) == 1 && itemIDs[0] == "item001", "created item id should be parsed")
+
+	failedResult, err := ParseTxResult([]byte(`{"txhash":"ABCD","code":5,"raw_log":"insufficient funds"}`))
+	t.MustNil(err, "error parsing failed tx result")
+	t.MustTrue(failedResult.Err() != nil && failedResult.Err().Error() == "insufficient funds", "failed tx result should have raw log error")
+}
+
+func TestDecodeExecutionOutput(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	output := []byte(`[{"type":"COIN","coin":"pylon","amount":100},{"type":"ITEM","itemID":"item001"},{"type":"COIN","coin":"pylon","amount":50}]`)
+	coins, itemIDs, err := DecodeExecutionOutput(output)
+	t.MustNil(err, "error decoding execution output")
+	t.WithFields(testing.Fields{
+		"coins": coins.String(),
+	}).MustTrue(coins.AmountOf(types.Pylon).Int64() == 150, "coin outputs should be summed")
+	t.WithFields(testing.Fields{
+		"item_ids": itemIDs,
+	}).MustTrue(len(itemIDs) == 1 && itemIDs[0] == "item001", "item outputs should be decoded")
+
+	_, _, err = DecodeExecutionOutput([]byte(`{"ExecID":"exec001"}`))
+	t.MustTrue(err != nil, "scheduled execution output should not be decoded as entries")
+}
+
+func TestTxResultFee(originT *originT.T) {
+	t := testing.NewT(originT)
+	msg := types.NewMsgCreateAccount(sdk.AccAddress([]byte("fee_payer___________")).String())
+	fee := sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, 10))
+	txModel, err := GenTxWithOptions([]sdk.Msg{&msg}, TxOptions{FeeStrategy: FixedFee{Amount: fee}})
+	t.MustNil(err, "error generating transaction")
+	// tx of tx response is packed the same way by tx query of every transport
+	anyTx := txModel.(interface{ AsAny() *codectypes.Any }).AsAny()
+
+	paid, err := TxResult{TxResponse: sdk.TxResponse{TxHash: "ABCD", Tx: anyTx}}.Fee()
+	t.MustNil(err, "error getting fee of tx result")
+	t.WithFields(testing.Fields{
+		"fee": paid.String(),
+	}).MustTrue(paid.IsEqual(fee), "fee of tx should be got")
+
+	_, err = TxResult{TxResponse: sdk.TxResponse{TxHash: "ABCD"}}.Fee()
+	t.MustTrue(err != nil, "tx result without tx should not have fee")
+}
@@ -0,0 +1,250 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// NodeProcess is a struct to manage a pylonsd node launched on local machine
+type NodeProcess struct {
+	Binary    string
+	Home      string
+	Endpoints NodeEndpoints
+
+	extraArgs []string
+	cmd       *exec.Cmd
+	logFile   *os.File
+	logTailer *NodeLogTailer
+	exited    chan struct{}
+	err       error
+}
+
+// StartNode is a function to launch "pylonsd start" of binary on home, output of node is written to node.log of home
+// Node log is followed while the node runs, so failures of tests get its lines logged while they ran.
+func StartNode(binary, home string, endpoints NodeEndpoints, extraArgs ...string) (*NodeProcess, error) {
+	n := &NodeProcess{
+		Binary:    binary,
+		Home:      home,
+		Endpoints: endpoints,
+		extraArgs: extraArgs,
+	}
+	if err := n.start(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// start is a function to launch process of node, it's called again to restart the node after it exited
+func (n *NodeProcess) start() error {
+	logFile, err := os.OpenFile(filepath.Join(n.Home, "node.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	// lines of earlier runs on the same home are skipped
+	logTailer, err := StartNodeLogTailer(logFile.Name())
+	if err != nil {
+		logFile.Close()
+		return err
+	}
+	args := append([]string{"start", "--home", n.Home}, n.Endpoints.StartArgs()...)
+	args = append(args, n.extraArgs...)
+	cmd := exec.Command(n.Binary, args...)
+	cmd.Env = append(os.Environ(), n.Endpoints.StartEnv(n.Binary)...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err = cmd.Start(); err != nil {
+		logTailer.Stop()
+		logFile.Close()
+		return err
+	}
+	exited := make(chan struct{})
+	n.cmd, n.logFile, n.logTailer, n.exited, n.err = cmd, logFile, logTailer, exited, nil
+	go func() {
+		n.err = cmd.Wait()
+		logFile.Close()
+		close(exited)
+	}()
+	return nil
+}
+
+// Exited is a function to check if node process has exited
+func (n *NodeProcess) Exited() bool {
+	select {
+	case <-n.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait is a function to wait for node to exit by itself e.g. on halt height
+func (n *NodeProcess) Wait(ctx context.Context) error {
+	select {
+	case <-n.exited:
+		return n.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// LogTailer is a function to get tailer following node.log of the node
+func (n *NodeProcess) LogTailer() *NodeLogTailer {
+	return n.logTailer
+}
+
+// Stop is a function to interrupt node and wait for it to exit, it's killed when it does not exit in timeout
+// Node log is followed until node is stopped even after node exits by itself, as its last lines tell why.
+func (n *NodeProcess) Stop(timeout time.Duration) error {
+	defer n.logTailer.Stop()
+	if n.Exited() {
+		return nil
+	}
+	if err := n.cmd.Process.Signal(os.Interrupt); err != nil {
+		return err
+	}
+	select {
+	case <-n.exited:
+		return nil
+	case <-time.After(timeout):
+		if err := n.cmd.Process.Kill(); err != nil {
+			return err
+		}
+		<-n.exited
+		return fmt.Errorf("node did not exit in %s after interrupt and was killed", timeout)
+	}
+}
+
+// WaitForNodeReady is a function to wait until node answers status query with a block, it fails when node exits
+func WaitForNodeReady(ctx context.Context, n *NodeProcess) error {
+	for {
+		status, _, err := queryDaemonStatus(ctx)
+		if err == nil && status.SyncInfo.LatestBlockHeight > 0 {
+			return nil
+		}
+		select {
+		case <-n.exited:
+			return fmt.Errorf("node exited before getting ready: %v", n.err)
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// UpgradeTest is a struct to check that pylons state survives swapping node binary on the same home
+type UpgradeTest struct {
+	Home       string
+	FromBinary string
+	ToBinary   string
+	Endpoints  NodeEndpoints
+	// HaltHeight is the height old node halts at, old node is stopped after pre upgrade suite when it's 0
+	HaltHeight int64
+	// Addresses are the accounts whose cookbooks, recipes and items are compared across the upgrade
+	Addresses []string
+	// StopTimeout is the time to wait for node to exit after interrupt, 30 seconds when it's 0
+	StopTimeout time.Duration
+}
+
+// UpgradeResult is a struct to describe pylons state before and after the upgrade
+type UpgradeResult struct {
+	Before  StateSnapshot
+	After   StateSnapshot
+	Changes []StateChange
+}
+
+func (u UpgradeTest) stopTimeout() time.Duration {
+	if u.StopTimeout == 0 {
+		return 30 * time.Second
+	}
+	return u.StopTimeout
+}
+
+// startNode is a function to start binary on upgrade home and point pylonsd commands to it
+func (u UpgradeTest) startNode(ctx context.Context, binary string, extraArgs ...string) (*NodeProcess, error) {
+	CLIOpts.PylonsdPath = binary
+	UseNodeEndpoints(DefaultEnv(), []NodeEndpoints{u.Endpoints}, false)
+	node, err := StartNode(binary, u.Home, u.Endpoints, extraArgs...)
+	if err != nil {
+		return nil, err
+	}
+	if err = WaitForNodeReady(ctx, node); err != nil {
+		node.Stop(u.stopTimeout())
+		return nil, err
+	}
+	return node, nil
+}
+
+// Run is a function to run beforeUpgrade against FromBinary, halt the node, restart home with ToBinary and run afterUpgrade
+// pylons state of addresses is taken before halt and after restart, pylonsd commands use binary of the running node
+func (u UpgradeTest) Run(ctx context.Context, t *testing.T, beforeUpgrade, afterUpgrade func(t *testing.T)) (UpgradeResult, error) {
+	result := UpgradeResult{}
+	prevOpts := CLIOpts
+	defer func() {
+		CLIOpts.PylonsdPath = prevOpts.PylonsdPath
+		CLIOpts.CustomNode = prevOpts.CustomNode
+		CLIOpts.GRPCEndpoint = prevOpts.GRPCEndpoint
+		CLIOpts.RestEndpoint = prevOpts.RestEndpoint
+	}()
+
+	extraArgs := []string{}
+	if u.HaltHeight > 0 {
+		extraArgs = append(extraArgs, "--halt-height", strconv.FormatInt(u.HaltHeight, 10))
+	}
+	node, err := u.startNode(ctx, u.FromBinary, extraArgs...)
+	if err != nil {
+		return result, fmt.Errorf("error starting node before upgrade: %w", err)
+	}
+	// suites can exit the goroutine on failure, running node is stopped anyway
+	defer func() {
+		if node != nil {
+			node.Stop(u.stopTimeout())
+		}
+	}()
+	t.WithFields(testing.Fields{
+		"binary": u.FromBinary,
+		"home":   u.Home,
+	}).Info("started node before upgrade")
+	if beforeUpgrade != nil {
+		beforeUpgrade(t)
+	}
+	if result.Before, err = TakeStateSnapshot(u.Addresses); err != nil {
+		return result, fmt.Errorf("error taking state snapshot before upgrade: %w", err)
+	}
+
+	if u.HaltHeight > 0 {
+		err = node.Wait(ctx)
+		var exitErr *exec.ExitError
+		if err != nil && !errors.As(err, &exitErr) {
+			return result, fmt.Errorf("error waiting for node to halt: %w", err)
+		}
+		// halted node has exited, it only stops following its log
+		node.Stop(u.stopTimeout())
+	} else if err = node.Stop(u.stopTimeout()); err != nil {
+		return result, fmt.Errorf("error stopping node before upgrade: %w", err)
+	}
+
+	node, err = u.startNode(ctx, u.ToBinary)
+	if err != nil {
+		return result, fmt.Errorf("error starting node after upgrade: %w", err)
+	}
+	t.WithFields(testing.Fields{
+		"binary": u.ToBinary,
+		"home":   u.Home,
+	}).Info("started node after upgrade")
+	if result.After, err = TakeStateSnapshot(u.Addresses); err != nil {
+		return result, fmt.Errorf("error taking state snapshot after upgrade: %w", err)
+	}
+	result.Changes = result.Before.Diff(result.After)
+	if afterUpgrade != nil {
+		afterUpgrade(t)
+	}
+	return result, nil
+}
@@ -0,0 +1,56 @@
+package inttest
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func writeFakeNode(t *testing.T, dir, name, script string) string {
+	binary := filepath.Join(dir, name)
+	err := ioutil.WriteFile(binary, []byte("#!/bin/sh\n"+script+"\n"), 0755)
+	t.MustNil(err, "error writing fake node binary")
+	return binary
+}
+
+func TestNodeProcess(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	home, err := ioutil.TempDir("", "pylons_node")
+	t.MustNil(err, "error creating node home")
+	defer os.RemoveAll(home)
+	endpoints := NodeEndpoints{Host: "127.0.0.1", RPCPort: 26657, P2PPort: 26656, GRPCPort: 9090, RESTPort: 1317}
+
+	running := writeFakeNode(&t, home, "running_node", "echo \"$@\"\necho \"api $RUNNING_NODE_API_ENABLE $RUNNING_NODE_API_ADDRESS\"\nexec sleep 30")
+	node, err := StartNode(running, home, endpoints, "--halt-height", "10")
+	t.MustNil(err, "error starting node")
+	time.Sleep(200 * time.Millisecond)
+	t.MustTrue(!node.Exited(), "node should be running")
+	t.MustNil(node.Stop(5*time.Second), "node should exit on interrupt")
+	t.MustTrue(node.Exited(), "node should be exited after stop")
+
+	output, err := ioutil.ReadFile(filepath.Join(home, "node.log"))
+	t.MustNil(err, "error reading node log")
+	t.WithFields(testing.Fields{
+		"output": string(output),
+	}).MustTrue(strings.Contains(string(output), "start --home "+home+" --rpc.laddr tcp://127.0.0.1:26657") &&
+		strings.Contains(string(output), "--halt-height 10"), "node should be started on home with endpoints and extra flags")
+	t.WithFields(testing.Fields{
+		"output": string(output),
+	}).MustContain(string(output), "api true tcp://127.0.0.1:1317", "rest server of node should be enabled on rest port")
+
+	halting := writeFakeNode(&t, home, "halting_node", "exit 0")
+	node, err = StartNode(halting, home, endpoints)
+	t.MustNil(err, "error starting node")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	t.MustNil(node.Wait(ctx), "node exiting by itself should be waited")
+	t.MustNil(node.Stop(time.Second), "stopping exited node should be no-op")
+	t.MustTrue(WaitForNodeReady(ctx, node) != nil, "exited node should never get ready")
+}
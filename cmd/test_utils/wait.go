@@ -0,0 +1,189 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// ErrWaitTimeout is returned by WaitFor when condition is not satisfied within maximum blocks or wall time
+var ErrWaitTimeout = errors.New("condition is not satisfied in time")
+
+// WaitCondition is a function to check if the awaited state is reached
+// An error stops waiting, so conditions return false without error for states which can still change.
+type WaitCondition func() (bool, error)
+
+// WaitOptions is a struct to limit how long WaitFor waits and how often it checks condition
+type WaitOptions struct {
+	// Name describes the awaited state in logs and errors
+	Name string
+	// MaxBlocks is the number of blocks to pass before giving up, 0 means no block limit
+	MaxBlocks int64
+	// MaxWait is the wall time to wait before giving up, 0 means no time limit
+	MaxWait time.Duration
+	// PollInterval is the time between checks, condition is checked once per new block when it's 0
+	PollInterval time.Duration
+}
+
+// WaitResult is a struct to describe how long WaitFor actually waited
+type WaitResult struct {
+	Polls       int
+	StartHeight int64
+	EndHeight   int64
+	Elapsed     time.Duration
+}
+
+// Blocks is a function to get number of blocks passed while waiting
+func (r WaitResult) Blocks() int64 {
+	return r.EndHeight - r.StartHeight
+}
+
+// Fields is a function to get log fields describing the wait
+func (r WaitResult) Fields() testing.Fields {
+	return testing.Fields{
+		"waited_ms":     r.Elapsed.Milliseconds(),
+		"waited_blocks": r.Blocks(),
+		"wait_polls":    r.Polls,
+	}
+}
+
+// WaitFor is a function to check condition until it's satisfied, anchored to block heights observed from node
+// It returns ErrWaitTimeout when MaxBlocks pass or MaxWait elapses first, and ctx error when ctx is done.
+func WaitFor(ctx context.Context, cond WaitCondition, opts WaitOptions) (WaitResult, error) {
+	clock := ClockFromContext(ctx)
+	start := clock.Now()
+	result := WaitResult{}
+	waitCtx := ctx
+	if opts.MaxWait > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, opts.MaxWait)
+		defer cancel()
+	}
+	timeout := func() (WaitResult, error) {
+		result.Elapsed = clock.Now().Sub(start)
+		return result, fmt.Errorf("%w: %s after %d blocks and %s", ErrWaitTimeout, opts.Name, result.Blocks(), result.Elapsed)
+	}
+	waitErr := func(err error) (WaitResult, error) {
+		if ctx.Err() == nil && waitCtx.Err() != nil {
+			return timeout()
+		}
+		result.Elapsed = clock.Now().Sub(start)
+		return result, err
+	}
+
+	if opts.MaxBlocks > 0 || opts.PollInterval == 0 {
+		if _, _, err := queryDaemonStatus(waitCtx); err != nil {
+			return waitErr(err)
+		}
+		result.StartHeight = EnvFromContext(waitCtx).state().blocks.latestHeight()
+		result.EndHeight = result.StartHeight
+	}
+	for {
+		result.Polls++
+		ok, err := cond()
+		result.Elapsed = clock.Now().Sub(start)
+		if err != nil || ok {
+			return result, err
+		}
+		if opts.MaxBlocks > 0 && result.Blocks() >= opts.MaxBlocks {
+			return timeout()
+		}
+		// max wait is checked by clock as well so that waits on fake clocks time out
+		if opts.MaxWait > 0 && result.Elapsed >= opts.MaxWait {
+			return timeout()
+		}
+		if opts.PollInterval == 0 {
+			err = WaitForNextBlockCtx(waitCtx)
+		} else {
+			select {
+			case <-waitCtx.Done():
+				err = waitCtx.Err()
+			case <-clock.After(opts.PollInterval):
+				if opts.MaxBlocks > 0 {
+					_, _, err = queryDaemonStatus(waitCtx)
+				}
+			}
+		}
+		if err != nil {
+			return waitErr(err)
+		}
+		result.EndHeight = EnvFromContext(waitCtx).state().blocks.latestHeight()
+	}
+}
+
+// waitBlocks is a function to wait for condition for maximum wait block as legacy helpers do,
+// condition is checked only once when maxWaitBlock is not positive
+func waitBlocks(ctx context.Context, cond WaitCondition, name string, maxWaitBlock int64) (WaitResult, error) {
+	if maxWaitBlock > 0 {
+		return WaitFor(ctx, cond, WaitOptions{Name: name, MaxBlocks: maxWaitBlock})
+	}
+	clock := ClockFromContext(ctx)
+	start := clock.Now()
+	ok, err := cond()
+	result := WaitResult{Polls: 1, Elapsed: clock.Now().Sub(start)}
+	if err == nil && !ok {
+		err = fmt.Errorf("%w: %s", ErrWaitTimeout, name)
+	}
+	return result, err
+}
+
+// MustWaitFor is a function to wait for condition and fail the test when it's not satisfied, waited time is logged as fields
+func MustWaitFor(ctx context.Context, t *testing.T, cond WaitCondition, opts WaitOptions) WaitResult {
+	result, err := WaitFor(ctx, cond, opts)
+	t.WithFields(result.Fields()).WithFields(testing.Fields{
+		"wait_for": opts.Name,
+	}).MustNil(err, "error waiting for condition")
+	return result
+}
+
+// UntilTxCommitted is a condition satisfied when transaction is found in a block, failed transactions included
+func UntilTxCommitted(txhash string) WaitCondition {
+	return func() (bool, error) {
+		_, err := GetTxResult(txhash)
+		// transaction can be in mempool yet
+		return err == nil, nil
+	}
+}
+
+// UntilExecutionCompleted is a condition satisfied when execution is completed
+func UntilExecutionCompleted(execID string) WaitCondition {
+	return func() (bool, error) {
+		result, err := DecodeExecution(execID)
+		if err != nil {
+			return false, err
+		}
+		return result.Status == ExecutionCompleted, nil
+	}
+}
+
+// UntilBlockHeight is a condition satisfied when chain reaches block height
+func UntilBlockHeight(height int64) WaitCondition {
+	return func() (bool, error) {
+		ds, _, err := queryDaemonStatus(context.Background())
+		if err != nil {
+			return false, err
+		}
+		return ds.SyncInfo.LatestBlockHeight >= height, nil
+	}
+}
+
+// UntilProposalPassed is a condition satisfied when proposal is passed, it fails when proposal is rejected
+func UntilProposalPassed(proposalID uint64) WaitCondition {
+	return func() (bool, error) {
+		status, err := GetProposalStatus(proposalID)
+		if err != nil {
+			return false, err
+		}
+		switch status {
+		case ProposalStatusPassed:
+			return true, nil
+		case ProposalStatusRejected, ProposalStatusFailed:
+			return false, fmt.Errorf("proposal %d is not passed: %s", proposalID, strings.ToLower(strings.TrimPrefix(status, "PROPOSAL_STATUS_")))
+		}
+		return false, nil
+	}
+}
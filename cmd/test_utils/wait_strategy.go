@@ -0,0 +1,213 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// defaultFixedBlockTime is the delay per block of FixedDelayStrategy until a block interval is observed
+const defaultFixedBlockTime = 5 * time.Second
+
+// WaitStrategy is an interface of how helpers wait for block heights to flow
+// Strategy is selected by CLIOpts globally and by ContextWithWaitStrategy per call.
+type WaitStrategy interface {
+	// WaitForBlockInterval waits until interval blocks are built on top of the latest block, it returns ctx error when ctx is done
+	WaitForBlockInterval(ctx context.Context, interval int64) error
+}
+
+// PollingStrategy is a wait strategy querying node status until block height is reached
+// Poll interval and timeout adapt to the observed block time when they're 0.
+type PollingStrategy struct {
+	PollInterval time.Duration
+	// BlockTimeout is the time budget per block, it should be raised for chains with slow block times
+	BlockTimeout time.Duration
+}
+
+// WaitForBlockInterval is a function to poll node status until interval blocks are built
+func (s PollingStrategy) WaitForBlockInterval(ctx context.Context, interval int64) error {
+	ds, _, err := queryDaemonStatus(ctx)
+	if err != nil {
+		return err // couldn't get daemon status.
+	}
+	currentBlock := ds.SyncInfo.LatestBlockHeight
+
+	clock := ClockFromContext(ctx)
+	deadline := clock.Now().Add(blockWaitTimeout(s.BlockTimeout, interval))
+	for clock.Now().Before(deadline) {
+		pollInterval := s.PollInterval
+		if pollInterval == 0 {
+			pollInterval = GetBlockPollInterval()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(pollInterval):
+		}
+		ds, _, err = queryDaemonStatus(ctx)
+		if err != nil {
+			return err
+		}
+		if ds.SyncInfo.LatestBlockHeight >= currentBlock+interval {
+			return nil
+		}
+	}
+	return errors.New("You are waiting too long time for interval")
+}
+
+// WebSocketStrategy is a wait strategy subscribing new block events of tendermint rpc of the first node
+// It returns as soon as the block is built without polling, BlockTimeout adapts to observed block time when it's 0.
+type WebSocketStrategy struct {
+	BlockTimeout time.Duration
+}
+
+// webSocketSubscriberSeq is used to make subscriber names of concurrent waits unique
+var webSocketSubscriberSeq int64
+
+// WaitForBlockInterval is a function to wait for new block events until interval blocks are built
+func (s WebSocketStrategy) WaitForBlockInterval(ctx context.Context, interval int64) error {
+	env := EnvFromContext(ctx)
+	rpcClient, err := rpchttp.New(env.firstNode(), "/websocket")
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrNodeUnavailable, err.Error())
+	}
+	if err = rpcClient.Start(); err != nil {
+		return fmt.Errorf("%w: %s", ErrNodeUnavailable, err.Error())
+	}
+	defer func() {
+		_ = rpcClient.Stop()
+	}()
+
+	waitCtx, cancel := context.WithTimeout(ctx, blockWaitTimeout(s.BlockTimeout, interval))
+	defer cancel()
+	subscriber := fmt.Sprintf("pylons_sdk_wait_%d", atomic.AddInt64(&webSocketSubscriberSeq, 1))
+	// subscribed before getting the latest height so that no block is missed
+	events, err := rpcClient.Subscribe(waitCtx, subscriber, tmtypes.EventQueryNewBlock.String())
+	if err != nil {
+		return fmt.Errorf("error subscribing new blocks: %w", err)
+	}
+	defer func() {
+		_ = rpcClient.UnsubscribeAll(context.Background(), subscriber)
+	}()
+	ds, _, err := queryDaemonStatus(waitCtx)
+	if err != nil {
+		return err
+	}
+	targetHeight := ds.SyncInfo.LatestBlockHeight + interval
+	for {
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return errors.New("You are waiting too long time for interval")
+		case event, ok := <-events:
+			if !ok {
+				return errors.New("new block subscription is closed")
+			}
+			newBlock, ok := event.Data.(tmtypes.EventDataNewBlock)
+			if !ok || newBlock.Block == nil {
+				continue
+			}
+			detectChainReset(env, env.firstNode(), newBlock.Block.ChainID, newBlock.Block.Height)
+			env.state().blocks.observe(newBlock.Block.Height, newBlock.Block.Time)
+			if newBlock.Block.Height >= targetHeight {
+				return nil
+			}
+		}
+	}
+}
+
+// FixedDelayStrategy is a wait strategy sleeping block time per block without querying node
+// Blocks are not checked to be built, so it fits chains with steady block time where status queries are expensive.
+// Observed average block time or 5s is used when BlockTime is 0.
+type FixedDelayStrategy struct {
+	BlockTime time.Duration
+}
+
+// WaitForBlockInterval is a function to sleep for interval blocks
+func (s FixedDelayStrategy) WaitForBlockInterval(ctx context.Context, interval int64) error {
+	blockTime := s.BlockTime
+	if blockTime == 0 {
+		blockTime = GetAverageBlockTime()
+	}
+	if blockTime == 0 {
+		blockTime = defaultFixedBlockTime
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-ClockFromContext(ctx).After(blockTime * time.Duration(interval)):
+		return nil
+	}
+}
+
+// blockWaitTimeout is a function to get time budget of interval blocks, adaptive timeout is used when blockTimeout is 0
+func blockWaitTimeout(blockTimeout time.Duration, interval int64) time.Duration {
+	if blockTimeout > 0 {
+		return blockTimeout * time.Duration(interval)
+	}
+	return GetBlockWaitTimeout(interval)
+}
+
+// names of wait strategies of -wait-strategy flag
+const (
+	WaitStrategyPolling   = "polling"
+	WaitStrategyWebSocket = "websocket"
+	WaitStrategyFixed     = "fixed"
+)
+
+func init() {
+	flag.StringVar(&CLIOpts.WaitStrategyName, "wait-strategy", WaitStrategyPolling, "how to wait for blocks, one of polling, websocket and fixed")
+	flag.DurationVar(&CLIOpts.BlockTimeout, "block-timeout", 0, "time budget per block while waiting for blocks and delay per block of fixed strategy, adapts to observed block time when it's 0")
+}
+
+// NewWaitStrategy is a function to create wait strategy by name with time budget per block
+// blockTimeout is the delay per block of fixed strategy.
+func NewWaitStrategy(name string, blockTimeout time.Duration) (WaitStrategy, error) {
+	switch strings.ToLower(name) {
+	case "", WaitStrategyPolling:
+		return PollingStrategy{BlockTimeout: blockTimeout}, nil
+	case WaitStrategyWebSocket:
+		return WebSocketStrategy{BlockTimeout: blockTimeout}, nil
+	case WaitStrategyFixed:
+		return FixedDelayStrategy{BlockTime: blockTimeout}, nil
+	}
+	return nil, fmt.Errorf("unknown wait strategy %s, it should be one of polling, websocket and fixed", name)
+}
+
+// GetWaitStrategy is a function to get global wait strategy, CLIOpts.WaitStrategy or the one named by -wait-strategy flag
+// Polling is used when the name is unknown.
+func GetWaitStrategy() WaitStrategy {
+	if CLIOpts.WaitStrategy != nil {
+		return CLIOpts.WaitStrategy
+	}
+	strategy, err := NewWaitStrategy(CLIOpts.WaitStrategyName, CLIOpts.BlockTimeout)
+	if err != nil {
+		return PollingStrategy{BlockTimeout: CLIOpts.BlockTimeout}
+	}
+	return strategy
+}
+
+// waitStrategyKey is the context key of wait strategy
+type waitStrategyKey struct{}
+
+// ContextWithWaitStrategy is a function to make waits done with ctx use strategy instead of the global one
+func ContextWithWaitStrategy(ctx context.Context, strategy WaitStrategy) context.Context {
+	return context.WithValue(ctx, waitStrategyKey{}, strategy)
+}
+
+// WaitStrategyFromContext is a function to get wait strategy of ctx, global wait strategy when ctx has none
+func WaitStrategyFromContext(ctx context.Context) WaitStrategy {
+	if strategy, ok := ctx.Value(waitStrategyKey{}).(WaitStrategy); ok && strategy != nil {
+		return strategy
+	}
+	return GetWaitStrategy()
+}
@@ -0,0 +1,49 @@
+package inttest
+
+import (
+	"context"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+// recordingWaitStrategy is a wait strategy recording requested intervals without waiting
+type recordingWaitStrategy struct {
+	intervals []int64
+}
+
+func (s *recordingWaitStrategy) WaitForBlockInterval(ctx context.Context, interval int64) error {
+	s.intervals = append(s.intervals, interval)
+	return nil
+}
+
+func TestWaitStrategy(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	strategy, err := NewWaitStrategy("WebSocket", 20*time.Second)
+	t.MustNil(err, "error creating wait strategy")
+	t.MustTrue(strategy == WebSocketStrategy{BlockTimeout: 20 * time.Second}, "strategy should be created by name case insensitively")
+	_, err = NewWaitStrategy("sleep", 0)
+	t.MustTrue(err != nil, "unknown strategy should be rejected")
+
+	global := CLIOpts.WaitStrategy
+	defer func() {
+		CLIOpts.WaitStrategy = global
+	}()
+	globalStrategy := &recordingWaitStrategy{}
+	CLIOpts.WaitStrategy = globalStrategy
+	callStrategy := &recordingWaitStrategy{}
+	t.MustNil(WaitForNextBlockCtx(context.Background()), "error waiting by global strategy")
+	t.MustNil(WaitForBlockIntervalCtx(ContextWithWaitStrategy(context.Background(), callStrategy), 3), "error waiting by strategy of call")
+	t.MustTrue(len(globalStrategy.intervals) == 1 && globalStrategy.intervals[0] == 1, "global strategy should be used by default")
+	t.MustTrue(len(callStrategy.intervals) == 1 && callStrategy.intervals[0] == 3, "strategy of context should override global one")
+
+	start := time.Now()
+	t.MustNil(FixedDelayStrategy{BlockTime: 5 * time.Millisecond}.WaitForBlockInterval(context.Background(), 2), "error waiting fixed delay")
+	t.MustTrue(time.Since(start) >= 10*time.Millisecond, "fixed delay should sleep block time per block")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = FixedDelayStrategy{BlockTime: time.Hour}.WaitForBlockInterval(ctx, 1)
+	t.MustTrue(err == context.Canceled, "fixed delay should stop when ctx is done")
+}
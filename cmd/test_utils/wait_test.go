@@ -0,0 +1,40 @@
+package inttest
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestWaitFor(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	polls := 0
+	thirdPoll := func() (bool, error) {
+		polls++
+		return polls == 3, nil
+	}
+	result, err := WaitFor(context.Background(), thirdPoll, WaitOptions{Name: "third poll", PollInterval: time.Millisecond})
+	t.MustNil(err, "error waiting for condition")
+	t.WithFields(result.Fields()).MustTrue(result.Polls == 3, "condition should be checked until satisfied")
+
+	never := func() (bool, error) { return false, nil }
+	result, err = WaitFor(context.Background(), never, WaitOptions{Name: "never", PollInterval: 5 * time.Millisecond, MaxWait: 30 * time.Millisecond})
+	t.WithFields(result.Fields()).MustTrue(errors.Is(err, ErrWaitTimeout), "wait should time out after max wait")
+	t.WithFields(result.Fields()).MustTrue(result.Elapsed >= 30*time.Millisecond, "actual wait should be reported")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = WaitFor(ctx, never, WaitOptions{Name: "canceled", PollInterval: time.Millisecond, MaxWait: time.Second})
+	t.MustTrue(errors.Is(err, context.Canceled), "canceled ctx should not be reported as timeout")
+
+	failed := errors.New("proposal is rejected")
+	result, err = WaitFor(context.Background(), func() (bool, error) { return false, failed }, WaitOptions{PollInterval: time.Millisecond})
+	t.MustTrue(errors.Is(err, failed) && result.Polls == 1, "condition error should stop waiting")
+
+	result, err = waitBlocks(context.Background(), never, "no wait", 0)
+	t.MustTrue(errors.Is(err, ErrWaitTimeout) && result.Polls == 1, "condition should be checked once without wait block")
+}
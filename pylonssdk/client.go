@@ -0,0 +1,298 @@
+// Package pylonssdk provides a single entry point for applications talking to a pylons chain
+// NewClient composes keyring, transaction broadcaster, query and msg services and event subscriber behind
+// functional options, so applications don't need to wire cosmos client context and pylons services themselves.
+package pylonssdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Pylons-tech/pylons_sdk/app"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/events"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/service"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+const (
+	// DefaultNode is the tendermint rpc address of node used when WithNode is not set
+	DefaultNode = "tcp://localhost:26657"
+	// DefaultChainID is the chain id used when WithChainID is not set
+	DefaultChainID = "pylonschain"
+	// DefaultGas is the gas limit of transactions used when WithGas is not set
+	DefaultGas = 400000
+)
+
+// ErrNoSigner is an error of broadcasting transactions by client without signer, set it by WithFrom
+var ErrNoSigner = errors.New("client has no signer")
+
+// options is a struct to manage options of NewClient
+type options struct {
+	node           string
+	chainID        string
+	keyring        keyring.Keyring
+	from           string
+	gas            uint64
+	gasAdjustment  float64
+	gasPrices      string
+	fees           string
+	broadcastMode  string
+	rpc            rpcclient.Client
+	broadcaster    service.Broadcaster
+	queryService   service.PylonsQueryService
+	msgService     service.PylonsMsgService
+	eventsRegistry *events.Registry
+}
+
+// Option is a function to set an option of NewClient
+type Option func(*options)
+
+// WithNode is a function to set tendermint rpc address of node e.g. tcp://localhost:26657
+func WithNode(node string) Option {
+	return func(o *options) {
+		o.node = node
+	}
+}
+
+// WithChainID is a function to set chain id transactions are signed for
+func WithChainID(chainID string) Option {
+	return func(o *options) {
+		o.chainID = chainID
+	}
+}
+
+// WithKeyring is a function to set keyring having key of signer, keys are kept in memory when it's not set
+func WithKeyring(kr keyring.Keyring) Option {
+	return func(o *options) {
+		o.keyring = kr
+	}
+}
+
+// WithFrom is a function to set name of keyring key signing transactions of client
+func WithFrom(keyName string) Option {
+	return func(o *options) {
+		o.from = keyName
+	}
+}
+
+// WithGas is a function to set gas limit of transactions, 0 estimates gas by simulation adjusted by WithGasAdjustment
+func WithGas(gas uint64) Option {
+	return func(o *options) {
+		o.gas = gas
+	}
+}
+
+// WithGasAdjustment is a function to set factor multiplied to simulated gas of transactions, default 1
+func WithGasAdjustment(gasAdjustment float64) Option {
+	return func(o *options) {
+		o.gasAdjustment = gasAdjustment
+	}
+}
+
+// WithGasPrices is a function to set gas prices of transactions e.g. "0.01pylon", it can't be set with WithFees
+func WithGasPrices(gasPrices string) Option {
+	return func(o *options) {
+		o.gasPrices = gasPrices
+	}
+}
+
+// WithFees is a function to set fees of transactions e.g. "10pylon", it can't be set with WithGasPrices
+func WithFees(fees string) Option {
+	return func(o *options) {
+		o.fees = fees
+	}
+}
+
+// WithBroadcastMode is a function to set broadcast mode of transactions, default block
+// Msg services need block mode to get msg responses, they return service.ErrNoMsgResponse on other modes.
+func WithBroadcastMode(mode string) Option {
+	return func(o *options) {
+		o.broadcastMode = mode
+	}
+}
+
+// WithRPCClient is a function to set tendermint rpc client instead of connecting to node of WithNode
+func WithRPCClient(rpc rpcclient.Client) Option {
+	return func(o *options) {
+		o.rpc = rpc
+	}
+}
+
+// WithBroadcaster is a function to set broadcaster of transactions instead of signing by keyring and broadcasting to node
+func WithBroadcaster(broadcaster service.Broadcaster) Option {
+	return func(o *options) {
+		o.broadcaster = broadcaster
+	}
+}
+
+// WithQueryService is a function to set query service instead of querying node e.g. mockchain.Chain in unit tests
+func WithQueryService(queryService service.PylonsQueryService) Option {
+	return func(o *options) {
+		o.queryService = queryService
+	}
+}
+
+// WithMsgService is a function to set msg service instead of sending msgs as transactions e.g. mockchain.Chain in unit tests
+func WithMsgService(msgService service.PylonsMsgService) Option {
+	return func(o *options) {
+		o.msgService = msgService
+	}
+}
+
+// WithEventsRegistry is a function to set registry decoding events of subscriptions e.g. having decoders of new event types
+func WithEventsRegistry(registry *events.Registry) Option {
+	return func(o *options) {
+		o.eventsRegistry = registry
+	}
+}
+
+// Client is a struct to talk to pylons chain by keyring, broadcaster, query and msg services and event subscriber
+type Client struct {
+	clientCtx    client.Context
+	txf          tx.Factory
+	rpc          rpcclient.Client
+	broadcaster  service.Broadcaster
+	queryService service.PylonsQueryService
+	msgService   service.PylonsMsgService
+	registry     *events.Registry
+}
+
+// NewClient is a function to create client by options, options which are not set take defaults e.g. DefaultNode
+// It doesn't connect to node, connection errors are returned by the first query, transaction or subscription.
+func NewClient(opts ...Option) (*Client, error) {
+	o := options{
+		node:          DefaultNode,
+		chainID:       DefaultChainID,
+		gas:           DefaultGas,
+		gasAdjustment: flags.DefaultGasAdjustment,
+		broadcastMode: flags.BroadcastBlock,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.gasPrices != "" && o.fees != "" {
+		return nil, errors.New("gas prices and fees can't be set together")
+	}
+	// factory panics on invalid fees and gas prices, so they're validated first
+	if _, err := sdk.ParseCoinsNormalized(o.fees); err != nil {
+		return nil, fmt.Errorf("invalid fees %s: %w", o.fees, err)
+	}
+	if _, err := sdk.ParseDecCoins(o.gasPrices); err != nil {
+		return nil, fmt.Errorf("invalid gas prices %s: %w", o.gasPrices, err)
+	}
+	if o.keyring == nil {
+		o.keyring = keyring.NewInMemory()
+	}
+	if o.eventsRegistry == nil {
+		o.eventsRegistry = events.NewRegistry()
+	}
+	if o.rpc == nil {
+		rpc, err := rpchttp.New(o.node, "/websocket")
+		if err != nil {
+			return nil, fmt.Errorf("error creating rpc client of %s: %w", o.node, err)
+		}
+		o.rpc = rpc
+	}
+
+	encodingConfig := app.MakeEncodingConfig()
+	clientCtx := client.Context{}.
+		WithJSONMarshaler(encodingConfig.Marshaler).
+		WithInterfaceRegistry(encodingConfig.InterfaceRegistry).
+		WithTxConfig(encodingConfig.TxConfig).
+		WithLegacyAmino(encodingConfig.Amino).
+		WithAccountRetriever(authtypes.AccountRetriever{}).
+		WithBroadcastMode(o.broadcastMode).
+		WithChainID(o.chainID).
+		WithKeyring(o.keyring).
+		WithNodeURI(o.node).
+		WithClient(o.rpc).
+		WithSkipConfirmation(true)
+	if o.from != "" {
+		info, err := o.keyring.Key(o.from)
+		if err != nil {
+			return nil, fmt.Errorf("error getting key %s of signer: %w", o.from, err)
+		}
+		clientCtx = clientCtx.WithFromName(info.GetName()).WithFromAddress(info.GetAddress()).WithFrom(o.from)
+	}
+	txf := tx.Factory{}.
+		WithTxConfig(encodingConfig.TxConfig).
+		WithAccountRetriever(authtypes.AccountRetriever{}).
+		WithKeybase(o.keyring).
+		WithChainID(o.chainID).
+		WithGas(o.gas).
+		WithSimulateAndExecute(o.gas == 0).
+		WithGasAdjustment(o.gasAdjustment).
+		WithGasPrices(o.gasPrices).
+		WithFees(o.fees).
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
+
+	c := &Client{
+		clientCtx:    clientCtx,
+		txf:          txf,
+		rpc:          o.rpc,
+		broadcaster:  o.broadcaster,
+		queryService: o.queryService,
+		msgService:   o.msgService,
+		registry:     o.eventsRegistry,
+	}
+	if c.broadcaster == nil {
+		signerBroadcast := service.ClientBroadcaster(clientCtx, txf)
+		c.broadcaster = func(ctx context.Context, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+			if o.from == "" {
+				return nil, ErrNoSigner
+			}
+			return signerBroadcast(ctx, msgs...)
+		}
+	}
+	if c.queryService == nil {
+		// client context runs grpc queries through abci queries of node
+		c.queryService = service.NewPylonsQueryService(clientCtx)
+	}
+	if c.msgService == nil {
+		c.msgService = service.NewTxMsgService(c.broadcaster)
+	}
+	return c, nil
+}
+
+// Query is a function to get pylons query service of client
+func (c *Client) Query() service.PylonsQueryService {
+	return c.queryService
+}
+
+// Msg is a function to get pylons msg service of client, msgs are sent as transactions signed by key of WithFrom
+func (c *Client) Msg() service.PylonsMsgService {
+	return c.msgService
+}
+
+// Broadcast is a function to send msgs in a transaction signed by key of WithFrom
+func (c *Client) Broadcast(ctx context.Context, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+	return c.broadcaster(ctx, msgs...)
+}
+
+// Address is a function to get address of signer, it's empty when WithFrom is not set
+func (c *Client) Address() sdk.AccAddress {
+	return c.clientCtx.GetFromAddress()
+}
+
+// Keyring is a function to get keyring of client
+func (c *Client) Keyring() keyring.Keyring {
+	return c.clientCtx.Keyring
+}
+
+// ClientContext is a function to get cosmos client context of client for modules which pylonssdk doesn't cover
+func (c *Client) ClientContext() client.Context {
+	return c.clientCtx
+}
@@ -0,0 +1,100 @@
+package pylonssdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	originT "testing"
+	"time"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/events"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/mockchain"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// fakeRPC is a tendermint rpc client delivering events of its channel to subscriptions
+type fakeRPC struct {
+	rpcclient.Client
+	running      bool
+	events       chan ctypes.ResultEvent
+	query        string
+	unsubscribed chan string
+}
+
+func (r *fakeRPC) IsRunning() bool { return r.running }
+
+func (r *fakeRPC) Start() error {
+	r.running = true
+	return nil
+}
+
+func (r *fakeRPC) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan ctypes.ResultEvent, error) {
+	r.query = query
+	return r.events, nil
+}
+
+func (r *fakeRPC) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	r.unsubscribed <- subscriber
+	return nil
+}
+
+func TestNewClient(originT *originT.T) {
+	t := testing.NewT(originT)
+	kr := keyring.NewInMemory()
+	info, _, err := kr.NewMnemonic("alice", keyring.English, sdk.FullFundraiserPath, hd.Secp256k1)
+	t.MustNil(err, "error creating key")
+
+	rpc := &fakeRPC{events: make(chan ctypes.ResultEvent, 1), unsubscribed: make(chan string, 1)}
+	client, err := NewClient(WithKeyring(kr), WithFrom("alice"), WithFees("10pylon"), WithRPCClient(rpc))
+	t.MustNil(err, "error creating client")
+	t.MustTrue(client.Address().Equals(info.GetAddress()), "signer should be key of WithFrom")
+	t.MustTrue(client.ClientContext().ChainID == DefaultChainID, "default chain id should be used")
+
+	_, err = NewClient(WithKeyring(kr), WithFrom("bob"), WithRPCClient(rpc))
+	t.MustTrue(err != nil, "signer missing in keyring should be rejected")
+	_, err = NewClient(WithFees("ten pylons"), WithRPCClient(rpc))
+	t.MustTrue(err != nil, "invalid fees should be rejected")
+
+	noSigner, err := NewClient(WithRPCClient(rpc))
+	t.MustNil(err, "error creating client without signer")
+	msg := types.NewMsgGetPylons(types.NewPylon(10), info.GetAddress().String())
+	_, err = noSigner.Broadcast(context.Background(), &msg)
+	t.MustTrue(errors.Is(err, ErrNoSigner), "client without signer should not broadcast")
+
+	chain := mockchain.New(1)
+	mocked, err := NewClient(WithQueryService(chain), WithMsgService(chain), WithRPCClient(rpc))
+	t.MustNil(err, "error creating client of mock chain")
+	t.MustTrue(mocked.Query() == chain && mocked.Msg() == chain, "services set by options should be used")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	subscription, err := client.Subscribe(ctx, "message.sender='alice'")
+	t.MustNil(err, "error subscribing events")
+	t.MustTrue(rpc.running && rpc.query == "tm.event='Tx' AND message.sender='alice'", "transactions should be subscribed by query")
+	txBytes := tmtypes.Tx("fulfill trade")
+	rpc.events <- ctypes.ResultEvent{Data: tmtypes.EventDataTx{TxResult: abci.TxResult{Height: 7, Tx: txBytes, Result: abci.ResponseDeliverTx{
+		Events: []abci.Event{{Type: events.EventTypeItemCreated, Attributes: []abci.EventAttribute{{Key: []byte(events.AttributeKeyItemID), Value: []byte("item001")}}}},
+	}}}}
+	select {
+	case txEvents := <-subscription:
+		t.MustNil(txEvents.Err, "error decoding events")
+		t.MustTrue(txEvents.TxHash == fmt.Sprintf("%X", txBytes.Hash()) && txEvents.Height == 7, "transaction of events should be delivered")
+		created := events.ItemCreated(txEvents.Events)
+		t.MustTrue(len(created) == 1 && created[0].ItemID == "item001", "events should be decoded")
+	case <-time.After(5 * time.Second):
+		t.Fatal("events of transaction are not delivered")
+	}
+	cancel()
+	select {
+	case <-rpc.unsubscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription is not ended by context")
+	}
+}
@@ -0,0 +1,76 @@
+package pylonssdk
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/events"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// TxEvents is a struct to manage decoded events of a committed transaction delivered by Subscribe
+type TxEvents struct {
+	TxHash string
+	Height int64
+	Code   uint32
+	Events []events.Event
+	Err    error // error decoding events, Events has the events decoded before it
+}
+
+var subscriberSeq int64
+
+// Subscribe is a function to get decoded events of transactions committed from now on which match query
+// Query is a tendermint event query added to "tm.event='Tx'" e.g. "message.sender='cosmos1...'", empty query matches all.
+// The channel is closed when ctx is done or the subscription ends.
+func (c *Client) Subscribe(ctx context.Context, query string) (<-chan TxEvents, error) {
+	if !c.rpc.IsRunning() {
+		if err := c.rpc.Start(); err != nil {
+			return nil, fmt.Errorf("error connecting to node: %w", err)
+		}
+	}
+	txQuery := tmtypes.EventQueryTx.String()
+	if query != "" {
+		txQuery += " AND " + query
+	}
+	subscriber := fmt.Sprintf("pylonssdk_%d", atomic.AddInt64(&subscriberSeq, 1))
+	results, err := c.rpc.Subscribe(ctx, subscriber, txQuery)
+	if err != nil {
+		return nil, fmt.Errorf("error subscribing %s: %w", txQuery, err)
+	}
+	out := make(chan TxEvents)
+	go func() {
+		defer close(out)
+		defer func() {
+			_ = c.rpc.UnsubscribeAll(context.Background(), subscriber)
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case result, ok := <-results:
+				if !ok {
+					return
+				}
+				data, ok := result.Data.(tmtypes.EventDataTx)
+				if !ok {
+					continue
+				}
+				decoded, err := c.registry.DecodeABCIEvents(data.Result.Events)
+				txEvents := TxEvents{
+					TxHash: fmt.Sprintf("%X", tmtypes.Tx(data.Tx).Hash()),
+					Height: data.Height,
+					Code:   data.Result.Code,
+					Events: decoded,
+					Err:    err,
+				}
+				select {
+				case out <- txEvents:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,173 @@
+package coins
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/config"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeSplit is a struct to describe how a pylon amount paid by a user is shared
+// between the receiver (cookbook owner or trade creator) and Pylons LLC
+type FeeSplit struct {
+	Total     int64
+	Receiver  int64
+	PylonsLLC int64
+}
+
+// ParsePylons is a function to parse pylon amount from string like "100pylon"
+func ParsePylons(s string) (int64, error) {
+	coin, err := sdk.ParseCoinNormalized(s)
+	if err != nil {
+		return 0, err
+	}
+	if coin.Denom != types.Pylon {
+		return 0, fmt.Errorf("%s is not %s denom", coin.Denom, types.Pylon)
+	}
+	return coin.Amount.Int64(), nil
+}
+
+// MustParsePylons is a function to parse pylon amount from string, it panics on invalid string
+func MustParsePylons(s string) int64 {
+	amount, err := ParsePylons(s)
+	if err != nil {
+		panic(err)
+	}
+	return amount
+}
+
+// Pylons is a function to get pylon coins of amount, zero amount gives empty coins
+func Pylons(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, amount))
+}
+
+// PylonsOf is a function to get pylon amount of coins
+func PylonsOf(coins sdk.Coins) int64 {
+	return coins.AmountOf(types.Pylon).Int64()
+}
+
+// Coins is a function to get coins of any denom e.g. cookbook coin minted by recipes, zero amount gives empty coins
+func Coins(denom string, amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(denom, amount))
+}
+
+// ParseCoin is a function to parse amount of any valid denom from string like "100loudcoin"
+func ParseCoin(s string) (sdk.Coin, error) {
+	return sdk.ParseCoinNormalized(s)
+}
+
+// ValidateCookbookDenom is a function to check denom can be minted by coin output of recipe as node does
+// Pylon is only issued by get pylons and google iap, so recipes can't output it.
+func ValidateCookbookDenom(denom string) error {
+	if denom == types.Pylon {
+		return fmt.Errorf("%s denom can't be a recipe coin output", types.Pylon)
+	}
+	return sdk.ValidateDenom(denom)
+}
+
+// RecipeDenoms is a function to get sorted distinct denoms minted by coin outputs of recipe
+func RecipeDenoms(recipe types.Recipe) []string {
+	seen := map[string]bool{}
+	denoms := []string{}
+	for _, coinOutput := range recipe.Entries.CoinOutputs {
+		if !seen[coinOutput.Coin] {
+			seen[coinOutput.Coin] = true
+			denoms = append(denoms, coinOutput.Coin)
+		}
+	}
+	sort.Strings(denoms)
+	return denoms
+}
+
+// TierByLevel is a function to get cookbook tier of level
+func TierByLevel(level int64) (types.Tier, error) {
+	switch level {
+	case types.Basic:
+		return types.Tier{Level: types.Basic, Fee: Pylons(config.Config.Fee.BasicTierCookbook)}, nil
+	case types.Premium:
+		return types.Tier{Level: types.Premium, Fee: Pylons(config.Config.Fee.PremiumTireCookbook)}, nil
+	}
+	return types.Tier{}, types.ValidateLevel(level)
+}
+
+// TierByName is a function to get cookbook tier from level name used by fixtures e.g. "Basic" or "Premium"
+func TierByName(name string) (types.Tier, error) {
+	switch name {
+	case "Basic":
+		return TierByLevel(types.Basic)
+	case "Premium":
+		return TierByLevel(types.Premium)
+	}
+	return types.Tier{}, fmt.Errorf("%s is not a cookbook tier name", name)
+}
+
+// CookbookFee is a function to get pylon amount charged to create cookbook of level
+func CookbookFee(level int64) (int64, error) {
+	tier, err := TierByLevel(level)
+	if err != nil {
+		return 0, err
+	}
+	return PylonsOf(tier.Fee), nil
+}
+
+// split is a function to share total with Pylons LLC by percent, the receiver gets the rest
+func split(total, llcPercent int64) FeeSplit {
+	llc := total * llcPercent / 100
+	return FeeSplit{
+		Total:     total,
+		Receiver:  total - llc,
+		PylonsLLC: llc,
+	}
+}
+
+// RecipeFee is a function to split pylon inputs of a recipe execution between cookbook owner and Pylons LLC
+func RecipeFee(pylonInputs int64) FeeSplit {
+	return split(pylonInputs, config.Config.Fee.RecipePercent)
+}
+
+// TradeFee is a function to split pylon price of a trade between trade creator and Pylons LLC
+func TradeFee(pylonPrice int64) FeeSplit {
+	return split(pylonPrice, config.Config.Fee.PylonsTradePercent)
+}
+
+// ItemTransferFee is a function to get transfer fee charged for an item, limited to configured min and max
+func ItemTransferFee(transferFee int64) int64 {
+	fee := config.Config.Fee
+	if transferFee < fee.MinItemTransferFee {
+		return fee.MinItemTransferFee
+	}
+	if transferFee > fee.MaxItemTransferFee {
+		return fee.MaxItemTransferFee
+	}
+	return transferFee
+}
+
+// ItemTransferFeeSplit is a function to split transfer fee of an item between cookbook owner and Pylons LLC
+func ItemTransferFeeSplit(transferFee int64) FeeSplit {
+	total := ItemTransferFee(transferFee)
+	owner := total * config.Config.Fee.ItemTransferCookbookOwnerProfitPercent / 100
+	return FeeSplit{
+		Total:     total,
+		Receiver:  owner,
+		PylonsLLC: total - owner,
+	}
+}
+
+// ItemsTransferFeeSplit is a function to get total split of transfer fees of items sent or traded together
+func ItemsTransferFeeSplit(items []types.Item) FeeSplit {
+	total := FeeSplit{}
+	for _, item := range items {
+		itemSplit := ItemTransferFeeSplit(item.TransferFee)
+		total.Total += itemSplit.Total
+		total.Receiver += itemSplit.Receiver
+		total.PylonsLLC += itemSplit.PylonsLLC
+	}
+	return total
+}
+
+// String is a function to get readable description of fee split
+func (s FeeSplit) String() string {
+	return fmt.Sprintf("total=%d%s receiver=%d%s pylons_llc=%d%s", s.Total, types.Pylon, s.Receiver, types.Pylon, s.PylonsLLC, types.Pylon)
+}
@@ -0,0 +1,78 @@
+package coins
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/config"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+func TestParsePylons(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	amount, err := ParsePylons("100pylon")
+	t.MustNil(err, "pylon amount should be parsed")
+	t.MustTrue(amount == 100, "amount should be 100")
+
+	_, err = ParsePylons("100gold")
+	t.MustTrue(err != nil, "other denom should be refused")
+	_, err = ParsePylons("pylon")
+	t.MustTrue(err != nil, "string without amount should be refused")
+}
+
+func TestTierByLevel(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	fee, err := CookbookFee(types.Premium)
+	t.MustNil(err, "premium tier should exist")
+	t.MustTrue(fee == config.Config.Fee.PremiumTireCookbook, "premium fee should come from fee configuration")
+
+	tier, err := TierByName("Basic")
+	t.MustNil(err, "basic tier name should be known")
+	t.MustTrue(tier.Level == types.Basic && PylonsOf(tier.Fee) == config.Config.Fee.BasicTierCookbook, "basic tier should be found by name")
+
+	_, err = TierByLevel(5)
+	t.MustTrue(err != nil, "unknown level should be refused")
+}
+
+func TestFeeSplit(originT *originT.T) {
+	t := testing.NewT(originT)
+	fee := config.Config.Fee
+	defer func() { config.Config.Fee = fee }()
+	config.Config.Fee.RecipePercent = 10
+	config.Config.Fee.PylonsTradePercent = 20
+	config.Config.Fee.ItemTransferCookbookOwnerProfitPercent = 90
+	config.Config.Fee.MinItemTransferFee = 5
+	config.Config.Fee.MaxItemTransferFee = 1000
+
+	split := RecipeFee(105)
+	t.WithFields(testing.Fields{
+		"split": split.String(),
+	}).MustTrue(split == FeeSplit{Total: 105, Receiver: 95, PylonsLLC: 10}, "recipe fee should give percent to Pylons LLC")
+
+	split = TradeFee(50)
+	t.MustTrue(split == FeeSplit{Total: 50, Receiver: 40, PylonsLLC: 10}, "trade fee should give percent to Pylons LLC")
+
+	split = ItemsTransferFeeSplit([]types.Item{{TransferFee: 1}, {TransferFee: 100}, {TransferFee: 5000}})
+	t.WithFields(testing.Fields{
+		"split": split.String(),
+	}).MustTrue(split == FeeSplit{Total: 1105, Receiver: 4 + 90 + 900, PylonsLLC: 1 + 10 + 100}, "transfer fees should be limited and shared with cookbook owner")
+}
+
+func TestCookbookCoins(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	coin, err := ParseCoin("100loudcoin")
+	t.MustNil(err, "cookbook coin should be parsed")
+	t.MustTrue(Coins("loudcoin", 100).AmountOf("loudcoin").Equal(coin.Amount), "coins should have amount of denom")
+	t.MustTrue(Coins("loudcoin", 0).Empty(), "zero amount should give empty coins")
+
+	t.MustNil(ValidateCookbookDenom("loudcoin"), "cookbook coin should be a valid recipe output")
+	t.MustTrue(ValidateCookbookDenom(types.Pylon) != nil, "pylon should not be a recipe output")
+	t.MustTrue(ValidateCookbookDenom("1coin") != nil, "invalid denom should be refused")
+
+	recipe := types.Recipe{Entries: types.EntriesList{CoinOutputs: []types.CoinOutput{{Coin: "loudcoin"}, {Coin: "goldcoin"}, {Coin: "loudcoin"}}}}
+	denoms := RecipeDenoms(recipe)
+	t.MustTrue(len(denoms) == 2 && denoms[0] == "goldcoin" && denoms[1] == "loudcoin", "recipe denoms should be sorted and distinct")
+}
@@ -0,0 +1,288 @@
+package events
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// describes types of events emitted by pylons module
+const (
+	EventTypeRecipeExecuted   = "recipe_executed"
+	EventTypeExecutionChecked = "execution_checked"
+	EventTypeItemCreated      = "item_created"
+	EventTypeTradeCreated     = "trade_created"
+	EventTypeTradeFulfilled   = "trade_fulfilled"
+	EventTypeCookbookCreated  = "cookbook_created"
+	EventTypeRecipeCreated    = "recipe_created"
+	EventTypeGooglePurchased  = "google_iap_purchased"
+)
+
+// describes attribute keys of events emitted by pylons module, repeated keys are decoded into lists
+const (
+	AttributeKeySender        = "sender"
+	AttributeKeyCookbookID    = "cookbook_id"
+	AttributeKeyRecipeID      = "recipe_id"
+	AttributeKeyExecID        = "exec_id"
+	AttributeKeyItemID        = "item_id"
+	AttributeKeyTradeID       = "trade_id"
+	AttributeKeyFulfiller     = "fulfiller"
+	AttributeKeyInputItemID   = "input_item_id"
+	AttributeKeyOutputItemID  = "output_item_id"
+	AttributeKeyOutputCoins   = "output_coins"
+	AttributeKeyCoinInputs    = "coin_inputs"
+	AttributeKeyCoinOutputs   = "coin_outputs"
+	AttributeKeyProductID     = "product_id"
+	AttributeKeyPurchaseToken = "purchase_token"
+)
+
+// Event is an interface of decoded events, type switch on it to get the typed event
+type Event interface {
+	// EventType returns type of the event as emitted by chain
+	EventType() string
+}
+
+// EventRecipeExecuted is a struct to describe execution of recipe, ExecID is set when the execution is scheduled
+type EventRecipeExecuted struct {
+	RecipeID      string
+	CookbookID    string
+	Sender        string
+	ExecID        string
+	InputItemIDs  []string
+	OutputItemIDs []string
+	OutputCoins   sdk.Coins
+}
+
+// EventType is a function to get type of recipe executed event
+func (EventRecipeExecuted) EventType() string { return EventTypeRecipeExecuted }
+
+// EventExecutionChecked is a struct to describe completion of scheduled execution
+type EventExecutionChecked struct {
+	ExecID        string
+	RecipeID      string
+	Sender        string
+	OutputItemIDs []string
+	OutputCoins   sdk.Coins
+}
+
+// EventType is a function to get type of execution checked event
+func (EventExecutionChecked) EventType() string { return EventTypeExecutionChecked }
+
+// EventItemCreated is a struct to describe item created by recipe execution or fiat item
+type EventItemCreated struct {
+	ItemID     string
+	CookbookID string
+	Sender     string
+}
+
+// EventType is a function to get type of item created event
+func (EventItemCreated) EventType() string { return EventTypeItemCreated }
+
+// EventTradeCreated is a struct to describe created trade
+type EventTradeCreated struct {
+	TradeID string
+	Sender  string
+}
+
+// EventType is a function to get type of trade created event
+func (EventTradeCreated) EventType() string { return EventTypeTradeCreated }
+
+// EventTradeFulfilled is a struct to describe fulfilled trade, coins and items are the ones moved by the trade
+type EventTradeFulfilled struct {
+	TradeID       string
+	Sender        string // creator of the trade
+	Fulfiller     string
+	InputItemIDs  []string // items of fulfiller sent to trade creator
+	OutputItemIDs []string // items of trade creator sent to fulfiller
+	CoinInputs    sdk.Coins
+	CoinOutputs   sdk.Coins
+}
+
+// EventType is a function to get type of trade fulfilled event
+func (EventTradeFulfilled) EventType() string { return EventTypeTradeFulfilled }
+
+// EventCookbookCreated is a struct to describe created cookbook
+type EventCookbookCreated struct {
+	CookbookID string
+	Sender     string
+}
+
+// EventType is a function to get type of cookbook created event
+func (EventCookbookCreated) EventType() string { return EventTypeCookbookCreated }
+
+// EventRecipeCreated is a struct to describe created recipe
+type EventRecipeCreated struct {
+	RecipeID   string
+	CookbookID string
+	Sender     string
+}
+
+// EventType is a function to get type of recipe created event
+func (EventRecipeCreated) EventType() string { return EventTypeRecipeCreated }
+
+// EventGooglePurchased is a struct to describe pylons bought by google in app purchase
+type EventGooglePurchased struct {
+	ProductID     string
+	PurchaseToken string
+	Sender        string
+}
+
+// EventType is a function to get type of google iap purchased event
+func (EventGooglePurchased) EventType() string { return EventTypeGooglePurchased }
+
+// UnknownEvent is a struct to keep event of a type which has no registered decoder
+// Events added by newer chains are decoded into it so that decoding never fails on them.
+type UnknownEvent struct {
+	Type       string
+	Attributes []sdk.Attribute
+}
+
+// EventType is a function to get type of unknown event
+func (e UnknownEvent) EventType() string { return e.Type }
+
+// Attribute is a function to get first value of attribute key of unknown event
+func (e UnknownEvent) Attribute(key string) (string, bool) {
+	for _, attr := range e.Attributes {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// attributes is a struct to read attributes of an event by key
+type attributes map[string][]string
+
+// newAttributes is a function to group attribute values of event by key in order
+func newAttributes(event sdk.StringEvent) attributes {
+	attrs := attributes{}
+	for _, attr := range event.Attributes {
+		attrs[attr.Key] = append(attrs[attr.Key], attr.Value)
+	}
+	return attrs
+}
+
+// get is a function to get first value of key
+func (a attributes) get(key string) string {
+	if values := a[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// list is a function to get all values of key
+func (a attributes) list(key string) []string {
+	return append([]string{}, a[key]...)
+}
+
+// coins is a function to parse coins of all values of key e.g. "10pylon,2gold"
+func (a attributes) coins(key string) (sdk.Coins, error) {
+	coins := sdk.Coins{}
+	for _, value := range a[key] {
+		if len(value) == 0 {
+			continue
+		}
+		parsed, err := sdk.ParseCoinsNormalized(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s attribute %s: %w", key, value, err)
+		}
+		coins = coins.Add(parsed...)
+	}
+	return coins, nil
+}
+
+func decodeRecipeExecuted(event sdk.StringEvent) (Event, error) {
+	attrs := newAttributes(event)
+	coins, err := attrs.coins(AttributeKeyOutputCoins)
+	if err != nil {
+		return nil, err
+	}
+	return EventRecipeExecuted{
+		RecipeID:      attrs.get(AttributeKeyRecipeID),
+		CookbookID:    attrs.get(AttributeKeyCookbookID),
+		Sender:        attrs.get(AttributeKeySender),
+		ExecID:        attrs.get(AttributeKeyExecID),
+		InputItemIDs:  attrs.list(AttributeKeyInputItemID),
+		OutputItemIDs: attrs.list(AttributeKeyOutputItemID),
+		OutputCoins:   coins,
+	}, nil
+}
+
+func decodeExecutionChecked(event sdk.StringEvent) (Event, error) {
+	attrs := newAttributes(event)
+	coins, err := attrs.coins(AttributeKeyOutputCoins)
+	if err != nil {
+		return nil, err
+	}
+	return EventExecutionChecked{
+		ExecID:        attrs.get(AttributeKeyExecID),
+		RecipeID:      attrs.get(AttributeKeyRecipeID),
+		Sender:        attrs.get(AttributeKeySender),
+		OutputItemIDs: attrs.list(AttributeKeyOutputItemID),
+		OutputCoins:   coins,
+	}, nil
+}
+
+func decodeItemCreated(event sdk.StringEvent) (Event, error) {
+	attrs := newAttributes(event)
+	return EventItemCreated{
+		ItemID:     attrs.get(AttributeKeyItemID),
+		CookbookID: attrs.get(AttributeKeyCookbookID),
+		Sender:     attrs.get(AttributeKeySender),
+	}, nil
+}
+
+func decodeTradeCreated(event sdk.StringEvent) (Event, error) {
+	attrs := newAttributes(event)
+	return EventTradeCreated{
+		TradeID: attrs.get(AttributeKeyTradeID),
+		Sender:  attrs.get(AttributeKeySender),
+	}, nil
+}
+
+func decodeTradeFulfilled(event sdk.StringEvent) (Event, error) {
+	attrs := newAttributes(event)
+	coinInputs, err := attrs.coins(AttributeKeyCoinInputs)
+	if err != nil {
+		return nil, err
+	}
+	coinOutputs, err := attrs.coins(AttributeKeyCoinOutputs)
+	if err != nil {
+		return nil, err
+	}
+	return EventTradeFulfilled{
+		TradeID:       attrs.get(AttributeKeyTradeID),
+		Sender:        attrs.get(AttributeKeySender),
+		Fulfiller:     attrs.get(AttributeKeyFulfiller),
+		InputItemIDs:  attrs.list(AttributeKeyInputItemID),
+		OutputItemIDs: attrs.list(AttributeKeyOutputItemID),
+		CoinInputs:    coinInputs,
+		CoinOutputs:   coinOutputs,
+	}, nil
+}
+
+func decodeCookbookCreated(event sdk.StringEvent) (Event, error) {
+	attrs := newAttributes(event)
+	return EventCookbookCreated{
+		CookbookID: attrs.get(AttributeKeyCookbookID),
+		Sender:     attrs.get(AttributeKeySender),
+	}, nil
+}
+
+func decodeRecipeCreated(event sdk.StringEvent) (Event, error) {
+	attrs := newAttributes(event)
+	return EventRecipeCreated{
+		RecipeID:   attrs.get(AttributeKeyRecipeID),
+		CookbookID: attrs.get(AttributeKeyCookbookID),
+		Sender:     attrs.get(AttributeKeySender),
+	}, nil
+}
+
+func decodeGooglePurchased(event sdk.StringEvent) (Event, error) {
+	attrs := newAttributes(event)
+	return EventGooglePurchased{
+		ProductID:     attrs.get(AttributeKeyProductID),
+		PurchaseToken: attrs.get(AttributeKeyPurchaseToken),
+		Sender:        attrs.get(AttributeKeySender),
+	}, nil
+}
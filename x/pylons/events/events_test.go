@@ -0,0 +1,84 @@
+package events
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestDecodeTxResponse(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	res := sdk.TxResponse{Logs: sdk.ABCIMessageLogs{{
+		MsgIndex: 0,
+		Events: sdk.StringEvents{
+			{Type: "message", Attributes: []sdk.Attribute{{Key: "action", Value: "execute_recipe"}}},
+			{Type: EventTypeRecipeExecuted, Attributes: []sdk.Attribute{
+				{Key: AttributeKeyRecipeID, Value: "recipe1"},
+				{Key: AttributeKeySender, Value: "eugen"},
+				{Key: AttributeKeyOutputItemID, Value: "item1"},
+				{Key: AttributeKeyOutputItemID, Value: "item2"},
+				{Key: AttributeKeyOutputCoins, Value: "10pylon,2gold"},
+			}},
+		},
+	}, {
+		MsgIndex: 1,
+		Events: sdk.StringEvents{
+			{Type: EventTypeTradeFulfilled, Attributes: []sdk.Attribute{
+				{Key: AttributeKeyTradeID, Value: "trade1"},
+				{Key: AttributeKeyFulfiller, Value: "mike"},
+				{Key: AttributeKeyCoinInputs, Value: "100pylon"},
+			}},
+		},
+	}}}
+	decoded, err := DecodeTxResponse(res)
+	t.MustNil(err, "error decoding events of tx response")
+	t.MustTrue(len(decoded) == 3, "all events should be decoded in order")
+
+	unknown, ok := decoded[0].(UnknownEvent)
+	t.MustTrue(ok, "event without decoder should be decoded as unknown event")
+	action, _ := unknown.Attribute("action")
+	t.MustTrue(action == "execute_recipe", "attributes of unknown event should be kept")
+
+	executed := RecipeExecuted(decoded)
+	t.MustTrue(len(executed) == 1, "recipe executed event should be typed")
+	t.WithFields(testing.Fields{
+		"event": executed[0],
+	}).MustTrue(executed[0].RecipeID == "recipe1" && len(executed[0].OutputItemIDs) == 2 && executed[0].OutputCoins.AmountOf("gold").Int64() == 2,
+		"repeated attributes should be decoded into lists and coins")
+
+	fulfilled := TradeFulfilled(decoded)
+	t.MustTrue(len(fulfilled) == 1 && fulfilled[0].Fulfiller == "mike" && fulfilled[0].CoinInputs.AmountOf("pylon").Int64() == 100, "trade fulfilled event should be typed")
+
+	_, err = Decode(sdk.StringEvent{Type: EventTypeRecipeExecuted, Attributes: []sdk.Attribute{{Key: AttributeKeyOutputCoins, Value: "-1pylon"}}})
+	t.MustTrue(err != nil, "invalid coins attribute should fail decoding")
+}
+
+func TestRegistry(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	type eventLevelUp struct {
+		UnknownEvent
+		Level string
+	}
+	r := NewRegistry()
+	r.Register("level_up", func(event sdk.StringEvent) (Event, error) {
+		e := eventLevelUp{UnknownEvent: UnknownEvent{Type: event.Type, Attributes: event.Attributes}}
+		e.Level, _ = e.Attribute("level")
+		return e, nil
+	})
+	decoded, err := r.DecodeABCIEvents([]abci.Event{{
+		Type:       "level_up",
+		Attributes: []abci.EventAttribute{{Key: []byte("level"), Value: []byte("2")}},
+	}})
+	t.MustNil(err, "error decoding abci events")
+	levelUp, ok := decoded[0].(eventLevelUp)
+	t.MustTrue(ok && levelUp.Level == "2", "registered decoder should decode new event type")
+
+	decoded, err = DefaultRegistry.DecodeABCIEvents([]abci.Event{{Type: "level_up"}})
+	t.MustNil(err, "error decoding abci events")
+	_, ok = decoded[0].(UnknownEvent)
+	t.MustTrue(ok, "decoder registered on another registry should not be used")
+}
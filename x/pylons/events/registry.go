@@ -0,0 +1,142 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// Decoder is a function to decode event of a type into typed event
+type Decoder func(event sdk.StringEvent) (Event, error)
+
+// Registry is a struct to keep decoders of event types
+// Events of types without decoder are decoded into UnknownEvent, so consumers keep working when chain adds events.
+type Registry struct {
+	mux      sync.RWMutex
+	decoders map[string]Decoder
+}
+
+// NewRegistry is a function to create registry having decoders of pylons events
+func NewRegistry() *Registry {
+	r := &Registry{decoders: map[string]Decoder{}}
+	r.Register(EventTypeRecipeExecuted, decodeRecipeExecuted)
+	r.Register(EventTypeExecutionChecked, decodeExecutionChecked)
+	r.Register(EventTypeItemCreated, decodeItemCreated)
+	r.Register(EventTypeTradeCreated, decodeTradeCreated)
+	r.Register(EventTypeTradeFulfilled, decodeTradeFulfilled)
+	r.Register(EventTypeCookbookCreated, decodeCookbookCreated)
+	r.Register(EventTypeRecipeCreated, decodeRecipeCreated)
+	r.Register(EventTypeGooglePurchased, decodeGooglePurchased)
+	return r
+}
+
+// DefaultRegistry is the registry used by package level decode functions
+var DefaultRegistry = NewRegistry()
+
+// Register is a function to set decoder of event type, decoder of the type registered before is replaced
+func (r *Registry) Register(eventType string, decoder Decoder) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.decoders[eventType] = decoder
+}
+
+// Decode is a function to decode event by decoder of its type, UnknownEvent when no decoder is registered
+func (r *Registry) Decode(event sdk.StringEvent) (Event, error) {
+	r.mux.RLock()
+	decoder, ok := r.decoders[event.Type]
+	r.mux.RUnlock()
+	if !ok {
+		return UnknownEvent{Type: event.Type, Attributes: append([]sdk.Attribute{}, event.Attributes...)}, nil
+	}
+	decoded, err := decoder(event)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s event: %w", event.Type, err)
+	}
+	return decoded, nil
+}
+
+// DecodeEvents is a function to decode events in order
+func (r *Registry) DecodeEvents(events sdk.StringEvents) ([]Event, error) {
+	decoded := []Event{}
+	for _, event := range events {
+		e, err := r.Decode(event)
+		if err != nil {
+			return decoded, err
+		}
+		decoded = append(decoded, e)
+	}
+	return decoded, nil
+}
+
+// DecodeABCIEvents is a function to decode raw abci events e.g. of block results or websocket subscription
+func (r *Registry) DecodeABCIEvents(events []abci.Event) ([]Event, error) {
+	return r.DecodeEvents(sdk.StringifyEvents(events))
+}
+
+// DecodeTxResponse is a function to decode events of all msg logs of transaction response in order
+func (r *Registry) DecodeTxResponse(res sdk.TxResponse) ([]Event, error) {
+	decoded := []Event{}
+	for _, msgLog := range res.Logs {
+		events, err := r.DecodeEvents(msgLog.Events)
+		decoded = append(decoded, events...)
+		if err != nil {
+			return decoded, fmt.Errorf("error decoding events of msg %d: %w", msgLog.MsgIndex, err)
+		}
+	}
+	return decoded, nil
+}
+
+// Register is a function to set decoder of event type on the default registry
+func Register(eventType string, decoder Decoder) {
+	DefaultRegistry.Register(eventType, decoder)
+}
+
+// Decode is a function to decode event by the default registry
+func Decode(event sdk.StringEvent) (Event, error) {
+	return DefaultRegistry.Decode(event)
+}
+
+// DecodeABCIEvents is a function to decode raw abci events by the default registry
+func DecodeABCIEvents(events []abci.Event) ([]Event, error) {
+	return DefaultRegistry.DecodeABCIEvents(events)
+}
+
+// DecodeTxResponse is a function to decode events of transaction response by the default registry
+func DecodeTxResponse(res sdk.TxResponse) ([]Event, error) {
+	return DefaultRegistry.DecodeTxResponse(res)
+}
+
+// RecipeExecuted is a function to get recipe executed events of decoded events
+func RecipeExecuted(events []Event) []EventRecipeExecuted {
+	typed := []EventRecipeExecuted{}
+	for _, event := range events {
+		if e, ok := event.(EventRecipeExecuted); ok {
+			typed = append(typed, e)
+		}
+	}
+	return typed
+}
+
+// ItemCreated is a function to get item created events of decoded events
+func ItemCreated(events []Event) []EventItemCreated {
+	typed := []EventItemCreated{}
+	for _, event := range events {
+		if e, ok := event.(EventItemCreated); ok {
+			typed = append(typed, e)
+		}
+	}
+	return typed
+}
+
+// TradeFulfilled is a function to get trade fulfilled events of decoded events
+func TradeFulfilled(events []Event) []EventTradeFulfilled {
+	typed := []EventTradeFulfilled{}
+	for _, event := range events {
+		if e, ok := event.(EventTradeFulfilled); ok {
+			typed = append(typed, e)
+		}
+	}
+	return typed
+}
@@ -0,0 +1,258 @@
+// Package mockchain provides an in-memory pylons chain implementing query and msg service interfaces
+// so that applications built on pylons_sdk can unit test without docker or a live node.
+// Msg service methods play the role of tx broadcasting, each call is applied at once as a single msg tx.
+package mockchain
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/coins"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/config"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/service"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NodeVersion is the node version set on entities created by mock chain
+const NodeVersion = "0.0.1"
+
+// failure is a struct to describe a programmed failure of a method
+type failure struct {
+	err   error
+	times int // remaining count of failures, negative for failing always
+}
+
+// Chain is a struct to keep pylons state in memory and serve query and msg services on it
+// Entity IDs are generated from a counter and recipe executions are simulated with seeds derived from
+// the chain seed, so the same calls on chains of the same seed give the same results.
+type Chain struct {
+	mux        sync.Mutex
+	seed       int64
+	height     int64
+	counter    int64
+	cookbooks  map[string]types.Cookbook
+	recipes    map[string]types.Recipe
+	items      map[string]types.Item
+	trades     map[string]types.Trade
+	executions map[string]types.Execution
+	balances   map[string]sdk.Coins
+	iapOrders  map[string]bool // purchase tokens of google iap orders already used
+	failures   map[string]*failure
+}
+
+var _ service.PylonsQueryService = &Chain{}
+var _ service.PylonsMsgService = &Chain{}
+
+// New is a function to create empty mock chain at block height 1
+func New(seed int64) *Chain {
+	return &Chain{
+		seed:       seed,
+		height:     1,
+		cookbooks:  make(map[string]types.Cookbook),
+		recipes:    make(map[string]types.Recipe),
+		items:      make(map[string]types.Item),
+		trades:     make(map[string]types.Trade),
+		executions: make(map[string]types.Execution),
+		balances:   make(map[string]sdk.Coins),
+		iapOrders:  make(map[string]bool),
+		failures:   make(map[string]*failure),
+	}
+}
+
+// Height is a function to get current block height of the chain
+func (c *Chain) Height() int64 {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.height
+}
+
+// AdvanceBlocks is a function to move block height forward so that delayed executions get ready
+func (c *Chain) AdvanceBlocks(count int64) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.height += count
+}
+
+// SetBalance is a function to set coins of an address
+func (c *Chain) SetBalance(address string, coins sdk.Coins) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.balances[address] = coins
+}
+
+// Balance is a function to get coins of an address
+func (c *Chain) Balance(address string) sdk.Coins {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.balances[address]
+}
+
+// AddCookbook is a function to add cookbook to chain state, ID is generated when empty
+func (c *Chain) AddCookbook(cb types.Cookbook) string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if len(cb.ID) == 0 {
+		cb.ID = c.nextID(types.TypeCookbook)
+	}
+	c.cookbooks[cb.ID] = cb
+	return cb.ID
+}
+
+// AddRecipe is a function to add recipe to chain state, ID is generated when empty
+func (c *Chain) AddRecipe(rcp types.Recipe) string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if len(rcp.ID) == 0 {
+		rcp.ID = c.nextID(types.TypeRecipe)
+	}
+	c.recipes[rcp.ID] = rcp
+	return rcp.ID
+}
+
+// AddItem is a function to add item to chain state, ID is generated when empty
+func (c *Chain) AddItem(item types.Item) string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if len(item.ID) == 0 {
+		item.ID = c.nextID(types.TypeItem)
+	}
+	c.items[item.ID] = item
+	return item.ID
+}
+
+// AddTrade is a function to add trade to chain state, ID is generated when empty
+func (c *Chain) AddTrade(trd types.Trade) string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if len(trd.ID) == 0 {
+		trd.ID = c.nextID(types.TypeTrade)
+	}
+	c.trades[trd.ID] = trd
+	return trd.ID
+}
+
+// LoadGenesis is a function to add cookbooks, recipes and items of genesis state
+func (c *Chain) LoadGenesis(gs types.GenesisState) {
+	for _, cb := range gs.Cookbooks {
+		c.AddCookbook(cb)
+	}
+	for _, rcp := range gs.Recipes {
+		c.AddRecipe(rcp)
+	}
+	for _, item := range gs.Items {
+		c.AddItem(item)
+	}
+}
+
+// Fail is a function to make the next times calls of method fail with err before changing state
+// method is the name of service method e.g. "ExecuteRecipe", negative times makes every call fail
+func (c *Chain) Fail(method string, err error, times int) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.failures[method] = &failure{err: err, times: times}
+}
+
+// ClearFailures is a function to remove all programmed failures
+func (c *Chain) ClearFailures() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.failures = make(map[string]*failure)
+}
+
+// begin is a function to lock chain state for a method call and get its programmed failure if any
+// The caller should unlock the chain when done.
+func (c *Chain) begin(method string) error {
+	c.mux.Lock()
+	f, ok := c.failures[method]
+	if !ok || f.times == 0 {
+		return nil
+	}
+	if f.times > 0 {
+		f.times--
+	}
+	return f.err
+}
+
+// nextID is a function to generate deterministic ID of entity type
+func (c *Chain) nextID(entityType string) string {
+	c.counter++
+	return fmt.Sprintf("%s%06d", entityType, c.counter)
+}
+
+// lockedCoins is a function to get coins locked by pending executions and enabled trades of address
+func (c *Chain) lockedCoins(address string) types.GetLockedCoinDetailsResponse {
+	resp := types.GetLockedCoinDetailsResponse{Sender: address, Amount: sdk.NewCoins()}
+	for _, exec := range sortedExecutions(c.executions) {
+		if exec.Sender == address && !exec.Completed {
+			resp.Amount = resp.Amount.Add(exec.CoinInputs...)
+			resp.LockCoinExecs = append(resp.LockCoinExecs, types.LockedCoinDescribe{ID: exec.ID, Amount: exec.CoinInputs})
+		}
+	}
+	for _, trd := range sortedTrades(c.trades) {
+		if trd.Sender == address && !trd.Completed && !trd.Disabled && !trd.CoinOutputs.Empty() {
+			resp.Amount = resp.Amount.Add(trd.CoinOutputs...)
+			resp.LockCoinTrades = append(resp.LockCoinTrades, types.LockedCoinDescribe{ID: trd.ID, Amount: trd.CoinOutputs})
+		}
+	}
+	return resp
+}
+
+// spendableCoins is a function to get coins of address which are not locked
+func (c *Chain) spendableCoins(address string) sdk.Coins {
+	spendable, _ := c.balances[address].SafeSub(c.lockedCoins(address).Amount)
+	return spendable
+}
+
+// pay is a function to move coins from sender to receiver, only unlocked coins of sender can be paid
+func (c *Chain) pay(sender, receiver string, coins sdk.Coins) error {
+	if coins.Empty() {
+		return nil
+	}
+	if !c.spendableCoins(sender).IsAllGTE(coins) {
+		return fmt.Errorf("%s does not have enough coins, %s is required", sender, coins)
+	}
+	c.balances[sender] = c.balances[sender].Sub(coins)
+	c.balances[receiver] = c.balances[receiver].Add(coins...)
+	return nil
+}
+
+// payFee is a function to move pylon fee of split from payer to receiver and Pylons LLC like node does
+func (c *Chain) payFee(payer, receiver string, split coins.FeeSplit) error {
+	if !c.spendableCoins(payer).IsAllGTE(pylons(split.Total)) {
+		return fmt.Errorf("%s does not have enough coins, %s is required", payer, pylons(split.Total))
+	}
+	if err := c.pay(payer, receiver, pylons(split.Receiver)); err != nil {
+		return err
+	}
+	return c.pay(payer, config.Config.Validators.PylonsLLC, pylons(split.PylonsLLC))
+}
+
+// payTransferFees is a function to pay transfer fees of items from payer to owners of their cookbooks and Pylons LLC
+func (c *Chain) payTransferFees(payer string, items []types.Item) error {
+	if !c.spendableCoins(payer).IsAllGTE(pylons(coins.ItemsTransferFeeSplit(items).Total)) {
+		return fmt.Errorf("%s does not have enough coins for transfer fee %s", payer, pylons(coins.ItemsTransferFeeSplit(items).Total))
+	}
+	for _, item := range items {
+		if err := c.payFee(payer, c.cookbooks[item.CookbookID].Sender, coins.ItemTransferFeeSplit(item.TransferFee)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ownedItems is a function to get items by IDs checking they are owned by sender
+func (c *Chain) ownedItems(sender string, itemIDs []string) ([]types.Item, error) {
+	items := []types.Item{}
+	for _, itemID := range itemIDs {
+		item, ok := c.items[itemID]
+		if !ok {
+			return nil, fmt.Errorf("item %s does not exist", itemID)
+		}
+		if item.Sender != sender {
+			return nil, fmt.Errorf("item %s is not owned by %s", itemID, sender)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,157 @@
+package mockchain
+
+import (
+	"context"
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	inttest "github.com/Pylons-tech/pylons_sdk/cmd/test_utils"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/coins"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	developer = sdk.AccAddress([]byte("mock_chain_developer")).String()
+	player    = sdk.AccAddress([]byte("mock_chain_player___")).String()
+)
+
+// newGameChain is a function to create chain with a cookbook, a straight recipe and a delayed recipe
+func newGameChain(seed int64) *Chain {
+	chain := New(seed)
+	chain.SetBalance(player, types.NewPylon(100))
+	chain.AddCookbook(types.Cookbook{ID: "cookbook", Sender: developer, CostPerBlock: 2})
+	entries := types.EntriesList{
+		CoinOutputs: []types.CoinOutput{{ID: "gold", Coin: "gold", Count: "10 + rand_int(90)"}},
+		ItemOutputs: []types.ItemOutput{{
+			ID:    "sword",
+			Longs: []types.LongParam{{Key: "attack", WeightRanges: []types.IntWeightRange{{Lower: 1, Upper: 100, Weight: 1}}}},
+		}},
+	}
+	outputs := []types.WeightedOutputs{{EntryIDs: []string{"gold", "sword"}, Weight: "1"}}
+	chain.AddRecipe(types.Recipe{ID: "straight", CookbookID: "cookbook", Sender: developer,
+		CoinInputs: []types.CoinInput{{Coin: types.Pylon, Count: 10}}, Entries: entries, Outputs: outputs})
+	chain.AddRecipe(types.Recipe{ID: "delayed", CookbookID: "cookbook", Sender: developer, BlockInterval: 5,
+		CoinInputs: []types.CoinInput{{Coin: types.Pylon, Count: 10}}, Entries: entries, Outputs: outputs})
+	return chain
+}
+
+func TestExecuteRecipe(originT *originT.T) {
+	t := testing.NewT(originT)
+	ctx := context.Background()
+
+	outputs := [][]byte{}
+	for run := 0; run < 2; run++ {
+		chain := newGameChain(7)
+		resp, err := chain.ExecuteRecipe(ctx, &types.MsgExecuteRecipe{RecipeID: "straight", Sender: player})
+		t.MustNil(err, "error executing recipe")
+		outputs = append(outputs, resp.Output)
+
+		items, err := chain.ItemsBySender(ctx, &types.ItemsBySenderRequest{Sender: player})
+		t.MustNil(err, "error listing items")
+		t.MustTrue(len(items.Items) == 1 && items.Items[0].CookbookID == "cookbook", "sword should be given to executor")
+		t.MustTrue(chain.Balance(player).AmountOf(types.Pylon).Int64() == 90 &&
+			chain.Balance(developer).AmountOf(types.Pylon).Int64() == coins.RecipeFee(10).Receiver, "coin inputs should be paid to cookbook owner after recipe fee")
+		t.MustTrue(chain.Balance(player).AmountOf("gold").Int64() >= 10, "gold should be given to executor")
+	}
+	t.WithFields(testing.Fields{
+		"first":  string(outputs[0]),
+		"second": string(outputs[1]),
+	}).MustTrue(string(outputs[0]) == string(outputs[1]), "chains of the same seed should give the same results")
+}
+
+func TestDelayedExecution(originT *originT.T) {
+	t := testing.NewT(originT)
+	ctx := context.Background()
+
+	chain := newGameChain(1)
+	resp, err := chain.ExecuteRecipe(ctx, &types.MsgExecuteRecipe{RecipeID: "delayed", Sender: player})
+	t.MustNil(err, "error executing recipe")
+	t.MustTrue(resp.Message == "scheduled the recipe", "recipe with block interval should be scheduled")
+	locked, err := chain.GetLockedCoins(ctx, &types.GetLockedCoinsRequest{Address: player})
+	t.MustNil(err, "error getting locked coins")
+	t.MustTrue(locked.Amount.AmountOf(types.Pylon).Int64() == 10, "coin inputs of pending execution should be locked")
+
+	execs, err := chain.ListExecutions(ctx, &types.ListExecutionsRequest{Sender: player})
+	t.MustNil(err, "error listing executions")
+	execID := execs.Executions[0].ID
+	check, err := chain.CheckExecution(ctx, &types.MsgCheckExecution{ExecID: execID, Sender: player})
+	t.MustNil(err, "error checking execution")
+	t.MustTrue(check.Status == StatusPending, "execution should be pending before block interval")
+
+	chain.AdvanceBlocks(2)
+	check, err = chain.CheckExecution(ctx, &types.MsgCheckExecution{ExecID: execID, Sender: player, PayToComplete: true})
+	t.MustNil(err, "error paying to complete execution")
+	t.MustTrue(check.Status == StatusSuccess, "paid execution should be completed")
+	t.WithFields(testing.Fields{
+		"balance": chain.Balance(player).String(),
+	}).MustTrue(chain.Balance(player).AmountOf(types.Pylon).Int64() == 84, "coin inputs and cost of 3 remaining blocks should be paid")
+}
+
+func TestProgrammedFailures(originT *originT.T) {
+	t := testing.NewT(originT)
+	ctx := context.Background()
+
+	chain := newGameChain(1)
+	nodeErr := errors.New("node is unavailable")
+	chain.Fail("ExecuteRecipe", nodeErr, 1)
+	_, err := chain.ExecuteRecipe(ctx, &types.MsgExecuteRecipe{RecipeID: "straight", Sender: player})
+	t.MustTrue(errors.Is(err, nodeErr), "programmed failure should be returned")
+	t.MustTrue(chain.Balance(player).AmountOf(types.Pylon).Int64() == 100, "failed call should not change state")
+	_, err = chain.ExecuteRecipe(ctx, &types.MsgExecuteRecipe{RecipeID: "straight", Sender: player})
+	t.MustNil(err, "failure should be used up after given times")
+
+	chain.Fail("GetItem", nodeErr, -1)
+	for idx := 0; idx < 3; idx++ {
+		_, err = chain.GetItem(ctx, &types.GetItemRequest{ItemID: "item000002"})
+		t.MustTrue(errors.Is(err, nodeErr), "negative times should fail every call")
+	}
+	chain.ClearFailures()
+	_, err = chain.GetItem(ctx, &types.GetItemRequest{ItemID: "item000002"})
+	t.MustNil(err, "cleared failure should not be returned")
+
+	_, err = chain.ExecuteRecipe(ctx, &types.MsgExecuteRecipe{Sender: ""})
+	t.MustTrue(err != nil, "msg failing basic validation should be rejected")
+}
+
+func TestGoogleIAPGetPylons(originT *originT.T) {
+	t := testing.NewT(originT)
+	ctx := context.Background()
+
+	chain := New(1)
+	signer := inttest.LocalIAPSigner()
+	receipt, err := inttest.NewIAPReceipt("pylons_1000")
+	t.MustNil(err, "error creating iap receipt")
+	msg, err := signer.GetPylonsMsg(receipt, player)
+	t.MustNil(err, "error signing iap receipt")
+
+	_, err = chain.GoogleIAPGetPylons(ctx, &msg)
+	t.MustTrue(err != nil, "receipt signed by untrusted key should be rejected")
+
+	defer signer.Trust()()
+	_, err = chain.GoogleIAPGetPylons(ctx, &msg)
+	t.MustNil(err, "receipt signed by trusted test key should be accepted")
+	t.MustTrue(chain.Balance(player).AmountOf(types.Pylon).Int64() == 1000, "pylons of product should be given")
+	order, err := chain.CheckGoogleIAPOrder(ctx, &types.CheckGoogleIAPOrderRequest{PurchaseToken: receipt.PurchaseToken})
+	t.MustNil(err, "error checking iap order")
+	t.MustTrue(order.Exist, "used iap order should exist")
+	_, err = chain.GoogleIAPGetPylons(ctx, &msg)
+	t.MustTrue(err != nil, "iap order should not be used twice")
+}
+
+func TestDeliver(originT *originT.T) {
+	t := testing.NewT(originT)
+	ctx := context.Background()
+
+	chain := newGameChain(1)
+	res, err := chain.Deliver(ctx, &types.MsgExecuteRecipe{RecipeID: "straight", Sender: player})
+	t.MustNil(err, "error delivering execute recipe msg")
+	execResp, ok := res.(*types.MsgExecuteRecipeResponse)
+	t.MustTrue(ok && execResp.Status == StatusSuccess, "response of msg service method should be returned")
+
+	res, err = chain.Deliver(ctx, &types.MsgExecuteRecipe{RecipeID: "unknown", Sender: player})
+	t.MustTrue(err != nil && res == nil, "failed msg should return no response")
+	_, err = chain.Deliver(ctx, &sdk.ServiceMsg{})
+	t.MustTrue(errors.Is(err, ErrUnsupportedMsg), "msg without msg service method should be refused")
+}
@@ -0,0 +1,68 @@
+package mockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// ErrUnsupportedMsg describes msgs which have no msg service method on mock chain
+var ErrUnsupportedMsg = errors.New("msg is not supported by mock chain")
+
+// response is a function to drop typed nil response of failed msg service method
+func response(res proto.Message, err error) (proto.Message, error) {
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Deliver is a function to apply msg by its msg service method as a single msg tx, it returns response of the method
+// It lets callers holding msgs of any type e.g. msgs read from fixture files use the chain like a node broadcasting them.
+func (c *Chain) Deliver(ctx context.Context, msg sdk.Msg) (proto.Message, error) {
+	switch msg := msg.(type) {
+	case *types.MsgCreateAccount:
+		return response(c.CreateAccount(ctx, msg))
+	case *types.MsgGetPylons:
+		return response(c.GetPylons(ctx, msg))
+	case *types.MsgGoogleIAPGetPylons:
+		return response(c.GoogleIAPGetPylons(ctx, msg))
+	case *types.MsgSendCoins:
+		return response(c.SendCoins(ctx, msg))
+	case *types.MsgSendItems:
+		return response(c.SendItems(ctx, msg))
+	case *types.MsgCreateCookbook:
+		return response(c.CreateCookbook(ctx, msg))
+	case *types.MsgUpdateCookbook:
+		return response(c.HandlerMsgUpdateCookbook(ctx, msg))
+	case *types.MsgCreateRecipe:
+		return response(c.CreateRecipe(ctx, msg))
+	case *types.MsgUpdateRecipe:
+		return response(c.HandlerMsgUpdateRecipe(ctx, msg))
+	case *types.MsgExecuteRecipe:
+		return response(c.ExecuteRecipe(ctx, msg))
+	case *types.MsgCheckExecution:
+		return response(c.CheckExecution(ctx, msg))
+	case *types.MsgEnableRecipe:
+		return response(c.EnableRecipe(ctx, msg))
+	case *types.MsgDisableRecipe:
+		return response(c.DisableRecipe(ctx, msg))
+	case *types.MsgFiatItem:
+		return response(c.FiatItem(ctx, msg))
+	case *types.MsgUpdateItemString:
+		return response(c.UpdateItemString(ctx, msg))
+	case *types.MsgCreateTrade:
+		return response(c.CreateTrade(ctx, msg))
+	case *types.MsgFulfillTrade:
+		return response(c.FulfillTrade(ctx, msg))
+	case *types.MsgEnableTrade:
+		return response(c.EnableTrade(ctx, msg))
+	case *types.MsgDisableTrade:
+		return response(c.DisableTrade(ctx, msg))
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedMsg, msg)
+}
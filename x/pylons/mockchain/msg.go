@@ -0,0 +1,682 @@
+package mockchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/coins"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/config"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// describes the status of msg responses
+const (
+	StatusSuccess = "Success"
+	StatusPending = "Pending"
+)
+
+// beginMsg is a function to lock chain state for a msg and check its programmed failure and basic validation
+// The caller should unlock the chain when done.
+func (c *Chain) beginMsg(method string, msg sdk.Msg) error {
+	if err := c.begin(method); err != nil {
+		return err
+	}
+	return msg.ValidateBasic()
+}
+
+// pylons is a function to get pylon coins of amount, zero amount gives empty coins
+func pylons(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(types.Pylon, amount))
+}
+
+// charge is a function to remove unlocked coins from address
+func (c *Chain) charge(address string, coins sdk.Coins) error {
+	if !c.spendableCoins(address).IsAllGTE(coins) {
+		return fmt.Errorf("%s does not have enough coins, %s is required", address, coins)
+	}
+	c.balances[address] = c.balances[address].Sub(coins)
+	return nil
+}
+
+// ownedCookbook is a function to get cookbook checking it is owned by sender
+func (c *Chain) ownedCookbook(sender, cookbookID string) (types.Cookbook, error) {
+	cb, ok := c.cookbooks[cookbookID]
+	if !ok {
+		return cb, fmt.Errorf("The cookbook with the id %s does not exist", cookbookID)
+	}
+	if cb.Sender != sender {
+		return cb, errors.New("cookbook not owned by the sender")
+	}
+	return cb, nil
+}
+
+// ownedRecipe is a function to get recipe checking it is owned by sender
+func (c *Chain) ownedRecipe(sender, recipeID string) (types.Recipe, error) {
+	rcp, ok := c.recipes[recipeID]
+	if !ok {
+		return rcp, fmt.Errorf("The recipe with the id %s does not exist", recipeID)
+	}
+	if rcp.Sender != sender {
+		return rcp, errors.New("msg sender is not the owner of the recipe")
+	}
+	return rcp, nil
+}
+
+// ownedTrade is a function to get trade checking it is owned by sender
+func (c *Chain) ownedTrade(sender, tradeID string) (types.Trade, error) {
+	trd, ok := c.trades[tradeID]
+	if !ok {
+		return trd, fmt.Errorf("The trade with the id %s does not exist", tradeID)
+	}
+	if trd.Sender != sender {
+		return trd, errors.New("msg sender is not the owner of the trade")
+	}
+	if trd.Completed {
+		return trd, errors.New("trade is already completed")
+	}
+	return trd, nil
+}
+
+// completeExecution is a function to pay coin inputs of execution and apply its simulated outputs to chain state
+// It returns output of the execution in the format of the node.
+func (c *Chain) completeExecution(exec types.Execution) ([]byte, error) {
+	rcp := c.recipes[exec.RecipeID]
+	sim, err := types.NewSimulator(rcp, c.seed+c.counter)
+	if err != nil {
+		return nil, err
+	}
+	sim.BlockHeight = c.height
+	result, err := sim.Execute(exec.ItemInputs, exec.CoinInputs)
+	if err != nil {
+		return nil, err
+	}
+	// coins of pending execution are unlocked once it's completed
+	exec.Completed = true
+	c.executions[exec.ID] = exec
+	// pylon inputs are split between cookbook owner and Pylons LLC, other coins go to cookbook owner
+	pylonInputs := coins.PylonsOf(exec.CoinInputs)
+	err = c.pay(exec.Sender, c.cookbooks[rcp.CookbookID].Sender, exec.CoinInputs.Sub(pylons(pylonInputs)))
+	if err == nil {
+		err = c.payFee(exec.Sender, c.cookbooks[rcp.CookbookID].Sender, coins.RecipeFee(pylonInputs))
+	}
+	if err != nil {
+		exec.Completed = false
+		c.executions[exec.ID] = exec
+		return nil, err
+	}
+
+	output := []types.ExecuteRecipeSerialize{}
+	for _, coin := range result.Coins {
+		output = append(output, types.ExecuteRecipeSerialize{Type: "COIN", Coin: coin.Denom, Amount: coin.Amount.Int64()})
+	}
+	c.balances[exec.Sender] = c.balances[exec.Sender].Add(result.Coins...)
+	for _, item := range exec.ItemInputs {
+		delete(c.items, item.ID)
+	}
+	for _, item := range result.Items {
+		item.ID = c.nextID(types.TypeItem)
+		item.Sender = exec.Sender
+		c.items[item.ID] = item
+		output = append(output, types.ExecuteRecipeSerialize{Type: "ITEM", ItemID: item.ID})
+	}
+	for _, item := range result.ModifiedItems {
+		item.OwnerRecipeID = ""
+		c.items[item.ID] = item
+		output = append(output, types.ExecuteRecipeSerialize{Type: "ITEM", ItemID: item.ID})
+	}
+	return json.Marshal(output)
+}
+
+// CheckExecution is a function to complete delayed execution when it's ready or paid to complete
+func (c *Chain) CheckExecution(ctx context.Context, in *types.MsgCheckExecution) (*types.MsgCheckExecutionResponse, error) {
+	err := c.beginMsg("CheckExecution", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	exec, ok := c.executions[in.ExecID]
+	if !ok {
+		return nil, fmt.Errorf("The execution with the id %s does not exist", in.ExecID)
+	}
+	if exec.Sender != in.Sender {
+		return nil, errors.New("The current sender is different from the executor")
+	}
+	if exec.Completed {
+		return nil, errors.New("execution already completed")
+	}
+	rcp := c.recipes[exec.RecipeID]
+	remaining := exec.BlockHeight + rcp.BlockInterval - c.height
+	if remaining > 0 {
+		if !in.PayToComplete {
+			return &types.MsgCheckExecutionResponse{Message: "execution pending", Status: StatusPending}, nil
+		}
+		cb := c.cookbooks[rcp.CookbookID]
+		if err = c.pay(exec.Sender, cb.Sender, pylons(remaining*cb.CostPerBlock)); err != nil {
+			return nil, err
+		}
+	}
+	output, err := c.completeExecution(exec)
+	if err != nil {
+		return nil, err
+	}
+	return &types.MsgCheckExecutionResponse{Message: "successfully completed the execution", Status: StatusSuccess, Output: output}, nil
+}
+
+// CreateAccount is a function to create an empty account of requester
+func (c *Chain) CreateAccount(ctx context.Context, in *types.MsgCreateAccount) (*types.MsgCreateExecutionResponse, error) {
+	err := c.beginMsg("CreateAccount", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := c.balances[in.Requester]; ok {
+		return nil, errors.New("account already exists")
+	}
+	c.balances[in.Requester] = sdk.NewCoins()
+	return &types.MsgCreateExecutionResponse{Message: "successfully created the account", Status: StatusSuccess}, nil
+}
+
+// CreateCookbook is a function to create cookbook charging fee of its tier
+func (c *Chain) CreateCookbook(ctx context.Context, in *types.MsgCreateCookbook) (*types.MsgCreateCookbookResponse, error) {
+	err := c.beginMsg("CreateCookbook", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	cb := types.Cookbook{
+		NodeVersion:  NodeVersion,
+		ID:           in.CookbookID,
+		Name:         in.Name,
+		Description:  in.Description,
+		Version:      in.Version,
+		Developer:    in.Developer,
+		Level:        in.Level,
+		SupportEmail: in.SupportEmail,
+		CostPerBlock: in.CostPerBlock,
+		Sender:       in.Sender,
+	}
+	if _, ok := c.cookbooks[cb.ID]; ok {
+		return nil, fmt.Errorf("A cookbook with CookbookID %s already exists", cb.ID)
+	}
+	fee := types.BasicFee
+	if cb.Level == types.Premium {
+		fee = types.PremiumFee
+	}
+	if err = c.charge(in.Sender, fee); err != nil {
+		return nil, err
+	}
+	if len(cb.ID) == 0 {
+		cb.ID = c.nextID(types.TypeCookbook)
+	}
+	c.cookbooks[cb.ID] = cb
+	return &types.MsgCreateCookbookResponse{CookbookID: cb.ID, Message: "successfully created a cookbook", Status: StatusSuccess}, nil
+}
+
+// CreateRecipe is a function to create recipe on cookbook owned by sender
+func (c *Chain) CreateRecipe(ctx context.Context, in *types.MsgCreateRecipe) (*types.MsgCreateRecipeResponse, error) {
+	err := c.beginMsg("CreateRecipe", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.ownedCookbook(in.Sender, in.CookbookID); err != nil {
+		return nil, err
+	}
+	if _, ok := c.recipes[in.RecipeID]; ok {
+		return nil, fmt.Errorf("The recipeID %s is already present in CookbookID %s", in.RecipeID, in.CookbookID)
+	}
+	rcp := types.Recipe{
+		NodeVersion:   NodeVersion,
+		ID:            in.RecipeID,
+		CookbookID:    in.CookbookID,
+		Name:          in.Name,
+		CoinInputs:    in.CoinInputs,
+		ItemInputs:    in.ItemInputs,
+		Entries:       in.Entries,
+		Outputs:       in.Outputs,
+		Description:   in.Description,
+		BlockInterval: in.BlockInterval,
+		Sender:        in.Sender,
+		ExtraInfo:     in.ExtraInfo,
+	}
+	if len(rcp.ID) == 0 {
+		rcp.ID = c.nextID(types.TypeRecipe)
+	}
+	c.recipes[rcp.ID] = rcp
+	return &types.MsgCreateRecipeResponse{RecipeID: rcp.ID, Message: "successfully created a recipe", Status: StatusSuccess}, nil
+}
+
+// CreateTrade is a function to create trade locking its coin outputs and item outputs
+func (c *Chain) CreateTrade(ctx context.Context, in *types.MsgCreateTrade) (*types.MsgCreateTradeResponse, error) {
+	err := c.beginMsg("CreateTrade", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if !c.spendableCoins(in.Sender).IsAllGTE(in.CoinOutputs) {
+		return nil, errors.New("sender doesn't have enough coins for the trade")
+	}
+	itemIDs := []string{}
+	for _, item := range in.ItemOutputs {
+		itemIDs = append(itemIDs, item.ID)
+	}
+	items, err := c.ownedItems(in.Sender, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if err = item.NewTradeError(); err != nil {
+			return nil, fmt.Errorf("%s is not tradable: %s", item.ID, err.Error())
+		}
+	}
+	trd := types.Trade{
+		NodeVersion: NodeVersion,
+		ID:          c.nextID(types.TypeTrade),
+		CoinInputs:  in.CoinInputs,
+		ItemInputs:  in.ItemInputs,
+		CoinOutputs: in.CoinOutputs,
+		ItemOutputs: items,
+		ExtraInfo:   in.ExtraInfo,
+		Sender:      in.Sender,
+	}
+	for _, item := range items {
+		item.OwnerTradeID = trd.ID
+		c.items[item.ID] = item
+	}
+	c.trades[trd.ID] = trd
+	return &types.MsgCreateTradeResponse{TradeID: trd.ID, Message: "successfully created a trade", Status: StatusSuccess}, nil
+}
+
+// DisableRecipe is a function to disable recipe owned by sender
+func (c *Chain) DisableRecipe(ctx context.Context, in *types.MsgDisableRecipe) (*types.MsgDisableRecipeResponse, error) {
+	err := c.beginMsg("DisableRecipe", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	rcp, err := c.ownedRecipe(in.Sender, in.RecipeID)
+	if err != nil {
+		return nil, err
+	}
+	rcp.Disabled = true
+	c.recipes[rcp.ID] = rcp
+	return &types.MsgDisableRecipeResponse{Message: "successfully disabled the recipe", Status: StatusSuccess}, nil
+}
+
+// DisableTrade is a function to disable trade owned by sender
+func (c *Chain) DisableTrade(ctx context.Context, in *types.MsgDisableTrade) (*types.MsgDisableTradeResponse, error) {
+	err := c.beginMsg("DisableTrade", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	trd, err := c.ownedTrade(in.Sender, in.TradeID)
+	if err != nil {
+		return nil, err
+	}
+	trd.Disabled = true
+	c.trades[trd.ID] = trd
+	return &types.MsgDisableTradeResponse{Message: "successfully disabled the trade", Status: StatusSuccess}, nil
+}
+
+// EnableRecipe is a function to enable recipe owned by sender
+func (c *Chain) EnableRecipe(ctx context.Context, in *types.MsgEnableRecipe) (*types.MsgEnableRecipeResponse, error) {
+	err := c.beginMsg("EnableRecipe", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	rcp, err := c.ownedRecipe(in.Sender, in.RecipeID)
+	if err != nil {
+		return nil, err
+	}
+	rcp.Disabled = false
+	c.recipes[rcp.ID] = rcp
+	return &types.MsgEnableRecipeResponse{Message: "successfully enabled the recipe", Status: StatusSuccess}, nil
+}
+
+// EnableTrade is a function to enable trade owned by sender when its coin outputs can be locked again
+func (c *Chain) EnableTrade(ctx context.Context, in *types.MsgEnableTrade) (*types.MsgEnableTradeResponse, error) {
+	err := c.beginMsg("EnableTrade", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	trd, err := c.ownedTrade(in.Sender, in.TradeID)
+	if err != nil {
+		return nil, err
+	}
+	if trd.Disabled && !c.spendableCoins(in.Sender).IsAllGTE(trd.CoinOutputs) {
+		return nil, errors.New("sender doesn't have enough coins for the trade")
+	}
+	trd.Disabled = false
+	c.trades[trd.ID] = trd
+	return &types.MsgEnableTradeResponse{Message: "successfully enabled the trade", Status: StatusSuccess}, nil
+}
+
+// ExecuteRecipe is a function to execute recipe at once or schedule it when recipe has block interval
+func (c *Chain) ExecuteRecipe(ctx context.Context, in *types.MsgExecuteRecipe) (*types.MsgExecuteRecipeResponse, error) {
+	err := c.beginMsg("ExecuteRecipe", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	rcp, ok := c.recipes[in.RecipeID]
+	if !ok {
+		return nil, fmt.Errorf("The recipe with the id %s does not exist", in.RecipeID)
+	}
+	if rcp.Disabled {
+		return nil, errors.New("the recipe is disabled")
+	}
+	items, err := c.ownedItems(in.Sender, in.ItemIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if err = item.NewRecipeExecutionError(); err != nil {
+			return nil, fmt.Errorf("%s is not available for execution: %s", item.ID, err.Error())
+		}
+	}
+	coinInputs := types.CoinInputList(rcp.CoinInputs).ToCoins()
+	if rcp.BlockInterval > 0 && !c.spendableCoins(in.Sender).IsAllGTE(coinInputs) {
+		return nil, errors.New("LockCoin: the sender does not have enough amount to lock")
+	}
+	sim, err := types.NewSimulator(rcp, c.seed)
+	if err != nil {
+		return nil, err
+	}
+	if err = sim.CheckInputs(items, c.spendableCoins(in.Sender)); err != nil {
+		return nil, err
+	}
+	exec := types.Execution{
+		NodeVersion: NodeVersion,
+		ID:          c.nextID(types.TypeExecution),
+		RecipeID:    rcp.ID,
+		CookbookID:  rcp.CookbookID,
+		CoinInputs:  coinInputs,
+		ItemInputs:  items,
+		BlockHeight: c.height,
+		Sender:      in.Sender,
+	}
+	if rcp.BlockInterval > 0 {
+		for _, item := range items {
+			item.OwnerRecipeID = rcp.ID
+			c.items[item.ID] = item
+		}
+		c.executions[exec.ID] = exec
+		output, err := json.Marshal(types.ExecuteRecipeScheduleOutput{ExecID: exec.ID})
+		if err != nil {
+			return nil, err
+		}
+		return &types.MsgExecuteRecipeResponse{Message: "scheduled the recipe", Status: StatusSuccess, Output: output}, nil
+	}
+	output, err := c.completeExecution(exec)
+	if err != nil {
+		delete(c.executions, exec.ID)
+		return nil, err
+	}
+	return &types.MsgExecuteRecipeResponse{Message: "successfully executed the recipe", Status: StatusSuccess, Output: output}, nil
+}
+
+// FiatItem is a function to create item on cookbook owned by sender
+func (c *Chain) FiatItem(ctx context.Context, in *types.MsgFiatItem) (*types.MsgFiatItemResponse, error) {
+	err := c.beginMsg("FiatItem", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	// node lets any account create items of an existing cookbook, e.g. players of recipe_flow fixture
+	if _, ok := c.cookbooks[in.CookbookID]; !ok {
+		return nil, fmt.Errorf("The cookbook with the id %s does not exist", in.CookbookID)
+	}
+	item := types.Item{
+		NodeVersion: NodeVersion,
+		ID:          c.nextID(types.TypeItem),
+		Doubles:     in.Doubles,
+		Longs:       in.Longs,
+		Strings:     in.Strings,
+		CookbookID:  in.CookbookID,
+		Sender:      in.Sender,
+		Tradable:    true,
+		LastUpdate:  c.height,
+		TransferFee: in.TransferFee,
+	}
+	c.items[item.ID] = item
+	return &types.MsgFiatItemResponse{ItemID: item.ID, Message: "successfully created an item", Status: StatusSuccess}, nil
+}
+
+// FulfillTrade is a function to exchange inputs of fulfiller with outputs of trade
+func (c *Chain) FulfillTrade(ctx context.Context, in *types.MsgFulfillTrade) (*types.MsgFulfillTradeResponse, error) {
+	err := c.beginMsg("FulfillTrade", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	trd, ok := c.trades[in.TradeID]
+	if !ok {
+		return nil, fmt.Errorf("The trade with the id %s does not exist", in.TradeID)
+	}
+	if trd.Completed || trd.Disabled {
+		return nil, errors.New("this trade is already completed or disabled")
+	}
+	if trd.Sender == in.Sender {
+		return nil, errors.New("trade creator can't fulfill the trade")
+	}
+	if !c.balances[trd.Sender].IsAllGTE(trd.CoinOutputs) {
+		return nil, errors.New("trade creator doesn't have enough coins for the trade")
+	}
+	items, err := c.ownedItems(in.Sender, in.ItemIDs)
+	if err != nil {
+		return nil, err
+	}
+	itemInputs := []types.ItemInput{}
+	for idx, tii := range trd.ItemInputs {
+		if idx < len(items) && items[idx].CookbookID != tii.CookbookID {
+			return nil, fmt.Errorf("item %d should be of cookbook %s", idx, tii.CookbookID)
+		}
+		itemInputs = append(itemInputs, tii.ItemInput)
+	}
+	// item inputs of trade are matched the same way as item inputs of recipe
+	sim, err := types.NewSimulator(types.Recipe{ItemInputs: itemInputs}, c.seed)
+	if err != nil {
+		return nil, err
+	}
+	if err = sim.CheckInputs(items, sdk.NewCoins()); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if err = item.NewTradeError(); err != nil {
+			return nil, fmt.Errorf("%s is not tradable: %s", item.ID, err.Error())
+		}
+	}
+	// pylon price of trade is paid with trade fee by fulfiller when it's in coin inputs and by trade creator otherwise,
+	// the other coins are exchanged as they are
+	coinInputs, coinOutputs := types.CoinInputList(trd.CoinInputs).ToCoins(), trd.CoinOutputs
+	payer, payee, price := in.Sender, trd.Sender, coins.PylonsOf(coinInputs)
+	if price > 0 {
+		coinInputs = coinInputs.Sub(pylons(price))
+	} else {
+		payer, payee, price = trd.Sender, in.Sender, coins.PylonsOf(coinOutputs)
+		coinOutputs = coinOutputs.Sub(pylons(price))
+	}
+	if err = c.pay(in.Sender, trd.Sender, coinInputs); err != nil {
+		return nil, err
+	}
+	// coin outputs of trade are unlocked once it's completed
+	trd.Completed = true
+	trd.FulFiller = in.Sender
+	c.trades[trd.ID] = trd
+	if err = c.pay(trd.Sender, in.Sender, coinOutputs); err != nil {
+		return nil, err
+	}
+	if err = c.payFee(payer, payee, coins.TradeFee(price)); err != nil {
+		return nil, err
+	}
+	// receiver of pylon price pays transfer fees of all traded items, each side pays for its items without price
+	itemOutputs := []types.Item{}
+	for _, item := range trd.ItemOutputs {
+		itemOutputs = append(itemOutputs, c.items[item.ID])
+	}
+	if price > 0 {
+		err = c.payTransferFees(payee, append(append([]types.Item{}, itemOutputs...), items...))
+	} else if err = c.payTransferFees(trd.Sender, itemOutputs); err == nil {
+		err = c.payTransferFees(in.Sender, items)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range itemOutputs {
+		item.OwnerTradeID = ""
+		item.Sender = in.Sender
+		c.items[item.ID] = item
+	}
+	for _, item := range items {
+		item.Sender = trd.Sender
+		c.items[item.ID] = item
+	}
+	return &types.MsgFulfillTradeResponse{Message: "successfully fulfilled the trade", Status: StatusSuccess}, nil
+}
+
+// GetPylons is a function to give requested pylons to requester
+func (c *Chain) GetPylons(ctx context.Context, in *types.MsgGetPylons) (*types.MsgGetPylonsResponse, error) {
+	err := c.beginMsg("GetPylons", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	c.balances[in.Requester] = c.balances[in.Requester].Add(in.Amount...)
+	return &types.MsgGetPylonsResponse{Message: "successfully got the pylons", Status: StatusSuccess}, nil
+}
+
+// GoogleIAPGetPylons is a function to give pylons of google iap product to requester
+// Receipt signature is verified by google_iap_pubkey of fee configuration, so receipts signed by a test key are
+// accepted once the key is trusted.
+func (c *Chain) GoogleIAPGetPylons(ctx context.Context, in *types.MsgGoogleIAPGetPylons) (*types.MsgGoogleIAPGetPylonsResponse, error) {
+	err := c.beginMsg("GoogleIAPGetPylons", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if c.iapOrders[in.PurchaseToken] {
+		return nil, errors.New("the iap order ID is already being used")
+	}
+	for _, product := range config.Config.GoogleIAP {
+		if product.ProductID == in.ProductID {
+			c.iapOrders[in.PurchaseToken] = true
+			c.balances[in.Requester] = c.balances[in.Requester].Add(pylons(product.Amount)...)
+			return &types.MsgGoogleIAPGetPylonsResponse{Message: "successfully got the pylons", Status: StatusSuccess}, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not a valid product id", in.ProductID)
+}
+
+// HandlerMsgUpdateCookbook is a function to update cookbook owned by sender
+func (c *Chain) HandlerMsgUpdateCookbook(ctx context.Context, in *types.MsgUpdateCookbook) (*types.MsgUpdateCookbookResponse, error) {
+	err := c.beginMsg("HandlerMsgUpdateCookbook", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	cb, err := c.ownedCookbook(in.Sender, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	cb.Description = in.Description
+	cb.Version = in.Version
+	cb.Developer = in.Developer
+	cb.SupportEmail = in.SupportEmail
+	c.cookbooks[cb.ID] = cb
+	return &types.MsgUpdateCookbookResponse{CookbookID: cb.ID, Message: "successfully updated the cookbook", Status: StatusSuccess}, nil
+}
+
+// HandlerMsgUpdateRecipe is a function to update recipe owned by sender
+func (c *Chain) HandlerMsgUpdateRecipe(ctx context.Context, in *types.MsgUpdateRecipe) (*types.MsgUpdateRecipeResponse, error) {
+	err := c.beginMsg("HandlerMsgUpdateRecipe", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	rcp, err := c.ownedRecipe(in.Sender, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	rcp.Name = in.Name
+	rcp.CookbookID = in.CookbookID
+	rcp.CoinInputs = in.CoinInputs
+	rcp.ItemInputs = in.ItemInputs
+	rcp.Entries = in.Entries
+	rcp.Outputs = in.Outputs
+	rcp.BlockInterval = in.BlockInterval
+	rcp.Description = in.Description
+	c.recipes[rcp.ID] = rcp
+	return &types.MsgUpdateRecipeResponse{RecipeID: rcp.ID, Message: "successfully updated the recipe", Status: StatusSuccess}, nil
+}
+
+// SendCoins is a function to send unlocked coins of sender to receiver
+func (c *Chain) SendCoins(ctx context.Context, in *types.MsgSendCoins) (*types.MsgSendCoinsResponse, error) {
+	err := c.beginMsg("SendCoins", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if !c.spendableCoins(in.Sender).IsAllGTE(in.Amount) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "Sender does not have enough coins")
+	}
+	if err = c.pay(in.Sender, in.Receiver, in.Amount); err != nil {
+		return nil, err
+	}
+	return &types.MsgSendCoinsResponse{}, nil
+}
+
+// SendItems is a function to send items of sender to receiver, transfer fees are paid to cookbook owners
+func (c *Chain) SendItems(ctx context.Context, in *types.MsgSendItems) (*types.MsgSendItemsResponse, error) {
+	err := c.beginMsg("SendItems", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	items, err := c.ownedItems(in.Sender, in.ItemIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if err = item.NewTradeError(); err != nil {
+			return nil, fmt.Errorf("%s is not tradable: %s", item.ID, err.Error())
+		}
+	}
+	if err = c.payTransferFees(in.Sender, items); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		item.Sender = in.Receiver
+		c.items[item.ID] = item
+	}
+	return &types.MsgSendItemsResponse{Message: "successfully sent the items", Status: StatusSuccess}, nil
+}
+
+// UpdateItemString is a function to update string attribute of item owned by sender charging update fee
+func (c *Chain) UpdateItemString(ctx context.Context, in *types.MsgUpdateItemString) (*types.MsgUpdateItemStringResponse, error) {
+	err := c.beginMsg("UpdateItemString", in)
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	items, err := c.ownedItems(in.Sender, []string{in.ItemID})
+	if err != nil {
+		return nil, err
+	}
+	item := items[0]
+	item.Strings = append([]types.StringKeyValue{}, item.Strings...)
+	if !item.SetString(in.Field, in.Value) {
+		return nil, fmt.Errorf("Provided field %s does not exist", in.Field)
+	}
+	if err = c.charge(in.Sender, pylons(config.Config.Fee.UpdateItemFieldString)); err != nil {
+		return nil, err
+	}
+	c.items[item.ID] = item
+	return &types.MsgUpdateItemStringResponse{Message: "successfully updated the item field", Status: StatusSuccess}, nil
+}
@@ -0,0 +1,387 @@
+package mockchain
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func sortedCookbooks(m map[string]types.Cookbook) []types.Cookbook {
+	list := []types.Cookbook{}
+	for _, cb := range m {
+		list = append(list, cb)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func sortedRecipes(m map[string]types.Recipe) []types.Recipe {
+	list := []types.Recipe{}
+	for _, rcp := range m {
+		list = append(list, rcp)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func sortedItems(m map[string]types.Item) []types.Item {
+	list := []types.Item{}
+	for _, item := range m {
+		list = append(list, item)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func sortedTrades(m map[string]types.Trade) []types.Trade {
+	list := []types.Trade{}
+	for _, trd := range m {
+		list = append(list, trd)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func sortedExecutions(m map[string]types.Execution) []types.Execution {
+	list := []types.Execution{}
+	for _, exec := range m {
+		list = append(list, exec)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// AddrFromPubKey is a function to get bech32 address of hex encoded secp256k1 public key
+func (c *Chain) AddrFromPubKey(ctx context.Context, in *types.AddrFromPubKeyRequest) (*types.AddrFromPubKeyResponse, error) {
+	err := c.begin("AddrFromPubKey")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	bz, err := hex.DecodeString(in.HexPubKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey := secp256k1.PubKey{Key: bz}
+	return &types.AddrFromPubKeyResponse{Bech32Addr: sdk.AccAddress(pubKey.Address()).String()}, nil
+}
+
+// CheckGoogleIAPOrder is a function to check if google iap order of purchase token is already used
+func (c *Chain) CheckGoogleIAPOrder(ctx context.Context, in *types.CheckGoogleIAPOrderRequest) (*types.CheckGoogleIAPOrderResponse, error) {
+	err := c.begin("CheckGoogleIAPOrder")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return &types.CheckGoogleIAPOrderResponse{PurchaseToken: in.PurchaseToken, Exist: c.iapOrders[in.PurchaseToken]}, nil
+}
+
+// GetCookbook is a function to get cookbook by ID
+func (c *Chain) GetCookbook(ctx context.Context, in *types.GetCookbookRequest) (*types.GetCookbookResponse, error) {
+	err := c.begin("GetCookbook")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	cb, ok := c.cookbooks[in.CookbookID]
+	if !ok {
+		return nil, fmt.Errorf("The cookbook with the id %s does not exist", in.CookbookID)
+	}
+	return &types.GetCookbookResponse{
+		NodeVersion:  cb.NodeVersion,
+		ID:           cb.ID,
+		Name:         cb.Name,
+		Description:  cb.Description,
+		Version:      cb.Version,
+		Developer:    cb.Developer,
+		Level:        cb.Level,
+		SupportEmail: cb.SupportEmail,
+		CostPerBlock: cb.CostPerBlock,
+		Sender:       cb.Sender,
+	}, nil
+}
+
+// GetExecution is a function to get execution by ID
+func (c *Chain) GetExecution(ctx context.Context, in *types.GetExecutionRequest) (*types.GetExecutionResponse, error) {
+	err := c.begin("GetExecution")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	exec, ok := c.executions[in.ExecutionID]
+	if !ok {
+		return nil, fmt.Errorf("The execution with the id %s does not exist", in.ExecutionID)
+	}
+	return &types.GetExecutionResponse{
+		NodeVersion: exec.NodeVersion,
+		ID:          exec.ID,
+		RecipeID:    exec.RecipeID,
+		CookbookID:  exec.CookbookID,
+		CoinsInput:  exec.CoinInputs,
+		ItemInputs:  exec.ItemInputs,
+		BlockHeight: exec.BlockHeight,
+		Sender:      exec.Sender,
+		Completed:   exec.Completed,
+	}, nil
+}
+
+// GetItem is a function to get item by ID
+func (c *Chain) GetItem(ctx context.Context, in *types.GetItemRequest) (*types.GetItemResponse, error) {
+	err := c.begin("GetItem")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	item, ok := c.items[in.ItemID]
+	if !ok {
+		return nil, fmt.Errorf("The item with the id %s does not exist", in.ItemID)
+	}
+	return &types.GetItemResponse{Item: item}, nil
+}
+
+// GetLockedCoinDetails is a function to get coins locked by pending executions and trades of address
+func (c *Chain) GetLockedCoinDetails(ctx context.Context, in *types.GetLockedCoinDetailsRequest) (*types.GetLockedCoinDetailsResponse, error) {
+	err := c.begin("GetLockedCoinDetails")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	resp := c.lockedCoins(in.Address)
+	return &resp, nil
+}
+
+// GetLockedCoins is a function to get total coins locked of address
+func (c *Chain) GetLockedCoins(ctx context.Context, in *types.GetLockedCoinsRequest) (*types.GetLockedCoinsResponse, error) {
+	err := c.begin("GetLockedCoins")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return &types.GetLockedCoinsResponse{
+		NodeVersion: NodeVersion,
+		Sender:      in.Address,
+		Amount:      c.lockedCoins(in.Address).Amount,
+	}, nil
+}
+
+// GetRecipe is a function to get recipe by ID
+func (c *Chain) GetRecipe(ctx context.Context, in *types.GetRecipeRequest) (*types.GetRecipeResponse, error) {
+	err := c.begin("GetRecipe")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	rcp, ok := c.recipes[in.RecipeID]
+	if !ok {
+		return nil, fmt.Errorf("The recipe with the id %s does not exist", in.RecipeID)
+	}
+	return &types.GetRecipeResponse{
+		NodeVersion:   rcp.NodeVersion,
+		ID:            rcp.ID,
+		CookbookID:    rcp.CookbookID,
+		Name:          rcp.Name,
+		CoinInputs:    rcp.CoinInputs,
+		ItemInputs:    rcp.ItemInputs,
+		Entries:       rcp.Entries,
+		Outputs:       rcp.Outputs,
+		Description:   rcp.Description,
+		BlockInterval: rcp.BlockInterval,
+		Sender:        rcp.Sender,
+		Disabled:      rcp.Disabled,
+	}, nil
+}
+
+// GetTrade is a function to get trade by ID
+func (c *Chain) GetTrade(ctx context.Context, in *types.GetTradeRequest) (*types.GetTradeResponse, error) {
+	err := c.begin("GetTrade")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	trd, ok := c.trades[in.TradeID]
+	if !ok {
+		return nil, fmt.Errorf("The trade with the id %s does not exist", in.TradeID)
+	}
+	return &types.GetTradeResponse{
+		NodeVersion: trd.NodeVersion,
+		ID:          trd.ID,
+		CoinInputs:  trd.CoinInputs,
+		ItemInputs:  trd.ItemInputs,
+		CoinOutputs: trd.CoinOutputs,
+		ItemOutputs: trd.ItemOutputs,
+		ExtraInfo:   trd.ExtraInfo,
+		Sender:      trd.Sender,
+		FulFiller:   trd.FulFiller,
+		Disabled:    trd.Disabled,
+		Completed:   trd.Completed,
+	}, nil
+}
+
+// ItemsByCookbook is a function to get items of cookbook
+func (c *Chain) ItemsByCookbook(ctx context.Context, in *types.ItemsByCookbookRequest) (*types.ItemsByCookbookResponse, error) {
+	err := c.begin("ItemsByCookbook")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.ItemsByCookbookResponse{}
+	for _, item := range sortedItems(c.items) {
+		if item.CookbookID == in.CookbookID {
+			resp.Items = append(resp.Items, item)
+		}
+	}
+	return resp, nil
+}
+
+// ItemsBySender is a function to get items owned by sender
+func (c *Chain) ItemsBySender(ctx context.Context, in *types.ItemsBySenderRequest) (*types.ItemsBySenderResponse, error) {
+	err := c.begin("ItemsBySender")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.ItemsBySenderResponse{}
+	for _, item := range sortedItems(c.items) {
+		if item.Sender == in.Sender {
+			resp.Items = append(resp.Items, item)
+		}
+	}
+	return resp, nil
+}
+
+// ListCookbook is a function to get cookbooks of address, all cookbooks when address is empty
+func (c *Chain) ListCookbook(ctx context.Context, in *types.ListCookbookRequest) (*types.ListCookbookResponse, error) {
+	err := c.begin("ListCookbook")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.ListCookbookResponse{}
+	for _, cb := range sortedCookbooks(c.cookbooks) {
+		if len(in.Address) == 0 || cb.Sender == in.Address {
+			resp.Cookbooks = append(resp.Cookbooks, cb)
+		}
+	}
+	return resp, nil
+}
+
+// ListExecutions is a function to get executions of sender
+func (c *Chain) ListExecutions(ctx context.Context, in *types.ListExecutionsRequest) (*types.ListExecutionsResponse, error) {
+	err := c.begin("ListExecutions")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.ListExecutionsResponse{}
+	for _, exec := range sortedExecutions(c.executions) {
+		if exec.Sender == in.Sender {
+			resp.Executions = append(resp.Executions, exec)
+		}
+	}
+	return resp, nil
+}
+
+// ListRecipe is a function to get recipes of address, all recipes when address is empty
+func (c *Chain) ListRecipe(ctx context.Context, in *types.ListRecipeRequest) (*types.ListRecipeResponse, error) {
+	err := c.begin("ListRecipe")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.ListRecipeResponse{}
+	for _, rcp := range sortedRecipes(c.recipes) {
+		if len(in.Address) == 0 || rcp.Sender == in.Address {
+			resp.Recipes = append(resp.Recipes, rcp)
+		}
+	}
+	return resp, nil
+}
+
+// ListRecipeByCookbook is a function to get recipes of cookbook
+func (c *Chain) ListRecipeByCookbook(ctx context.Context, in *types.ListRecipeByCookbookRequest) (*types.ListRecipeByCookbookResponse, error) {
+	err := c.begin("ListRecipeByCookbook")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.ListRecipeByCookbookResponse{}
+	for _, rcp := range sortedRecipes(c.recipes) {
+		if rcp.CookbookID == in.CookbookID {
+			resp.Recipes = append(resp.Recipes, rcp)
+		}
+	}
+	return resp, nil
+}
+
+// ListShortenRecipe is a function to get shorten recipes of address, all recipes when address is empty
+func (c *Chain) ListShortenRecipe(ctx context.Context, in *types.ListShortenRecipeRequest) (*types.ListShortenRecipeResponse, error) {
+	err := c.begin("ListShortenRecipe")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.ListShortenRecipeResponse{}
+	for _, rcp := range sortedRecipes(c.recipes) {
+		if len(in.Address) == 0 || rcp.Sender == in.Address {
+			resp.Recipes = append(resp.Recipes, shortenRecipe(rcp))
+		}
+	}
+	return resp, nil
+}
+
+// ListShortenRecipeByCookbook is a function to get shorten recipes of cookbook
+func (c *Chain) ListShortenRecipeByCookbook(ctx context.Context, in *types.ListShortenRecipeByCookbookRequest) (*types.ListShortenRecipeByCookbookResponse, error) {
+	err := c.begin("ListShortenRecipeByCookbook")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.ListShortenRecipeByCookbookResponse{}
+	for _, rcp := range sortedRecipes(c.recipes) {
+		if rcp.CookbookID == in.CookbookID {
+			resp.Recipes = append(resp.Recipes, shortenRecipe(rcp))
+		}
+	}
+	return resp, nil
+}
+
+// ListTrade is a function to get trades of address, all trades when address is empty
+func (c *Chain) ListTrade(ctx context.Context, in *types.ListTradeRequest) (*types.ListTradeResponse, error) {
+	err := c.begin("ListTrade")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.ListTradeResponse{}
+	for _, trd := range sortedTrades(c.trades) {
+		if len(in.Address) == 0 || trd.Sender == in.Address {
+			resp.Trades = append(resp.Trades, trd)
+		}
+	}
+	return resp, nil
+}
+
+// PylonsBalance is a function to get pylon balance of address
+func (c *Chain) PylonsBalance(ctx context.Context, in *types.PylonsBalanceRequest) (*types.PylonsBalanceResponse, error) {
+	err := c.begin("PylonsBalance")
+	defer c.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return &types.PylonsBalanceResponse{Balance: c.balances[in.Address].AmountOf(types.Pylon).Int64()}, nil
+}
+
+func shortenRecipe(rcp types.Recipe) types.ShortenRecipe {
+	return types.ShortenRecipe{
+		ID:          rcp.ID,
+		CookbookID:  rcp.CookbookID,
+		Name:        rcp.Name,
+		Description: rcp.Description,
+		Sender:      rcp.Sender,
+	}
+}
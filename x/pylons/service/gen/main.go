@@ -0,0 +1,175 @@
+// gen generates stable service interfaces, grpc implementations and mocks from generated grpc clients of pylons
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"text/template"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// method is a struct to describe a rpc method of grpc client
+type method struct {
+	Name     string
+	Request  string
+	Response string
+}
+
+// service is a struct to describe a service interface generated from a grpc client
+type service struct {
+	Interface   string
+	Description string
+	Client      string
+	NewClient   string
+	Impl        string
+	Mock        string
+	Methods     []method
+}
+
+const serviceTemplate = `// Code generated by x/pylons/service/gen. DO NOT EDIT.
+
+package service
+
+import (
+	"context"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	grpc1 "github.com/gogo/protobuf/grpc"
+)
+
+// APIVersion is the version of pylons grpc services the interfaces are generated from
+const APIVersion = "{{.Version}}"
+{{range .Services}}
+// {{.Interface}} is an interface of {{.Description}}
+type {{.Interface}} interface {
+{{- range .Methods}}
+	{{.Name}}(ctx context.Context, in *types.{{.Request}}) (*types.{{.Response}}, error)
+{{- end}}
+}
+
+type {{.Impl}} struct {
+	client types.{{.Client}}
+}
+
+// New{{.Interface}} is a function to create {{.Interface}} sending requests on grpc connection
+func New{{.Interface}}(conn grpc1.ClientConn) {{.Interface}} {
+	return {{.Impl}}{client: types.{{.NewClient}}(conn)}
+}
+{{$impl := .Impl}}
+{{- range .Methods}}
+// {{.Name}} is a function to send {{.Request}} and get its response
+func (s {{$impl}}) {{.Name}}(ctx context.Context, in *types.{{.Request}}) (*types.{{.Response}}, error) {
+	return s.client.{{.Name}}(ctx, in)
+}
+{{end}}
+{{- end}}`
+
+const mockTemplate = `// Code generated by x/pylons/service/gen. DO NOT EDIT.
+
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+{{range .Services}}
+// {{.Mock}} is a mock of {{.Interface}}, methods return result of their func fields and ErrNotMocked when it's nil
+type {{.Mock}} struct {
+	mux   sync.Mutex
+	calls []MockCall
+{{range .Methods}}
+	{{.Name}}Func func(ctx context.Context, in *types.{{.Request}}) (*types.{{.Response}}, error)
+{{- end}}
+}
+
+var _ {{.Interface}} = &{{.Mock}}{}
+
+// Calls is a function to get calls made on the mock in order
+func (m *{{.Mock}}) Calls() []MockCall {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return append([]MockCall{}, m.calls...)
+}
+{{$mock := .Mock}}
+{{- range .Methods}}
+// {{.Name}} is a function to record the call and return result of {{.Name}}Func
+func (m *{{$mock}}) {{.Name}}(ctx context.Context, in *types.{{.Request}}) (*types.{{.Response}}, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "{{.Name}}", Request: in})
+	m.mux.Unlock()
+	if m.{{.Name}}Func == nil {
+		return nil, ErrNotMocked
+	}
+	return m.{{.Name}}Func(ctx, in)
+}
+{{end}}
+{{- end}}`
+
+// methodsOf is a function to get rpc methods of grpc client interface in declaration order
+func methodsOf(client reflect.Type) ([]method, error) {
+	methods := []method{}
+	for idx := 0; idx < client.NumMethod(); idx++ {
+		m := client.Method(idx)
+		if m.Type.NumIn() != 3 || m.Type.NumOut() != 2 {
+			return nil, fmt.Errorf("unexpected signature of %s.%s", client.Name(), m.Name)
+		}
+		methods = append(methods, method{
+			Name:     m.Name,
+			Request:  m.Type.In(1).Elem().Name(),
+			Response: m.Type.Out(0).Elem().Name(),
+		})
+	}
+	return methods, nil
+}
+
+func render(file, text string, data interface{}) error {
+	tmpl, err := template.New(file).Parse(text)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	output, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("error formatting %s: %w", file, err)
+	}
+	return ioutil.WriteFile(file, output, 0644)
+}
+
+func main() {
+	version := flag.String("version", "v1", "api version of generated interfaces")
+	flag.Parse()
+
+	queryMethods, err := methodsOf(reflect.TypeOf((*types.QueryClient)(nil)).Elem())
+	if err == nil {
+		var msgMethods []method
+		msgMethods, err = methodsOf(reflect.TypeOf((*types.MsgClient)(nil)).Elem())
+		data := map[string]interface{}{
+			"Version": *version,
+			"Services": []service{
+				{"PylonsQueryService", "pylons query service", "QueryClient", "NewQueryClient", "queryService", "MockQueryService", queryMethods},
+				{"PylonsMsgService", "pylons msg service", "MsgClient", "NewMsgClient", "msgService", "MockMsgService", msgMethods},
+			},
+		}
+		if err == nil {
+			err = render("service.gen.go", serviceTemplate, data)
+		}
+		if err == nil {
+			err = render("mock.gen.go", mockTemplate, data)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,498 @@
+// Code generated by x/pylons/service/gen. DO NOT EDIT.
+
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// MockQueryService is a mock of PylonsQueryService, methods return result of their func fields and ErrNotMocked when it's nil
+type MockQueryService struct {
+	mux   sync.Mutex
+	calls []MockCall
+
+	AddrFromPubKeyFunc              func(ctx context.Context, in *types.AddrFromPubKeyRequest) (*types.AddrFromPubKeyResponse, error)
+	CheckGoogleIAPOrderFunc         func(ctx context.Context, in *types.CheckGoogleIAPOrderRequest) (*types.CheckGoogleIAPOrderResponse, error)
+	GetCookbookFunc                 func(ctx context.Context, in *types.GetCookbookRequest) (*types.GetCookbookResponse, error)
+	GetExecutionFunc                func(ctx context.Context, in *types.GetExecutionRequest) (*types.GetExecutionResponse, error)
+	GetItemFunc                     func(ctx context.Context, in *types.GetItemRequest) (*types.GetItemResponse, error)
+	GetLockedCoinDetailsFunc        func(ctx context.Context, in *types.GetLockedCoinDetailsRequest) (*types.GetLockedCoinDetailsResponse, error)
+	GetLockedCoinsFunc              func(ctx context.Context, in *types.GetLockedCoinsRequest) (*types.GetLockedCoinsResponse, error)
+	GetRecipeFunc                   func(ctx context.Context, in *types.GetRecipeRequest) (*types.GetRecipeResponse, error)
+	GetTradeFunc                    func(ctx context.Context, in *types.GetTradeRequest) (*types.GetTradeResponse, error)
+	ItemsByCookbookFunc             func(ctx context.Context, in *types.ItemsByCookbookRequest) (*types.ItemsByCookbookResponse, error)
+	ItemsBySenderFunc               func(ctx context.Context, in *types.ItemsBySenderRequest) (*types.ItemsBySenderResponse, error)
+	ListCookbookFunc                func(ctx context.Context, in *types.ListCookbookRequest) (*types.ListCookbookResponse, error)
+	ListExecutionsFunc              func(ctx context.Context, in *types.ListExecutionsRequest) (*types.ListExecutionsResponse, error)
+	ListRecipeFunc                  func(ctx context.Context, in *types.ListRecipeRequest) (*types.ListRecipeResponse, error)
+	ListRecipeByCookbookFunc        func(ctx context.Context, in *types.ListRecipeByCookbookRequest) (*types.ListRecipeByCookbookResponse, error)
+	ListShortenRecipeFunc           func(ctx context.Context, in *types.ListShortenRecipeRequest) (*types.ListShortenRecipeResponse, error)
+	ListShortenRecipeByCookbookFunc func(ctx context.Context, in *types.ListShortenRecipeByCookbookRequest) (*types.ListShortenRecipeByCookbookResponse, error)
+	ListTradeFunc                   func(ctx context.Context, in *types.ListTradeRequest) (*types.ListTradeResponse, error)
+	PylonsBalanceFunc               func(ctx context.Context, in *types.PylonsBalanceRequest) (*types.PylonsBalanceResponse, error)
+}
+
+var _ PylonsQueryService = &MockQueryService{}
+
+// Calls is a function to get calls made on the mock in order
+func (m *MockQueryService) Calls() []MockCall {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return append([]MockCall{}, m.calls...)
+}
+
+// AddrFromPubKey is a function to record the call and return result of AddrFromPubKeyFunc
+func (m *MockQueryService) AddrFromPubKey(ctx context.Context, in *types.AddrFromPubKeyRequest) (*types.AddrFromPubKeyResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "AddrFromPubKey", Request: in})
+	m.mux.Unlock()
+	if m.AddrFromPubKeyFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.AddrFromPubKeyFunc(ctx, in)
+}
+
+// CheckGoogleIAPOrder is a function to record the call and return result of CheckGoogleIAPOrderFunc
+func (m *MockQueryService) CheckGoogleIAPOrder(ctx context.Context, in *types.CheckGoogleIAPOrderRequest) (*types.CheckGoogleIAPOrderResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "CheckGoogleIAPOrder", Request: in})
+	m.mux.Unlock()
+	if m.CheckGoogleIAPOrderFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.CheckGoogleIAPOrderFunc(ctx, in)
+}
+
+// GetCookbook is a function to record the call and return result of GetCookbookFunc
+func (m *MockQueryService) GetCookbook(ctx context.Context, in *types.GetCookbookRequest) (*types.GetCookbookResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "GetCookbook", Request: in})
+	m.mux.Unlock()
+	if m.GetCookbookFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetCookbookFunc(ctx, in)
+}
+
+// GetExecution is a function to record the call and return result of GetExecutionFunc
+func (m *MockQueryService) GetExecution(ctx context.Context, in *types.GetExecutionRequest) (*types.GetExecutionResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "GetExecution", Request: in})
+	m.mux.Unlock()
+	if m.GetExecutionFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetExecutionFunc(ctx, in)
+}
+
+// GetItem is a function to record the call and return result of GetItemFunc
+func (m *MockQueryService) GetItem(ctx context.Context, in *types.GetItemRequest) (*types.GetItemResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "GetItem", Request: in})
+	m.mux.Unlock()
+	if m.GetItemFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetItemFunc(ctx, in)
+}
+
+// GetLockedCoinDetails is a function to record the call and return result of GetLockedCoinDetailsFunc
+func (m *MockQueryService) GetLockedCoinDetails(ctx context.Context, in *types.GetLockedCoinDetailsRequest) (*types.GetLockedCoinDetailsResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "GetLockedCoinDetails", Request: in})
+	m.mux.Unlock()
+	if m.GetLockedCoinDetailsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetLockedCoinDetailsFunc(ctx, in)
+}
+
+// GetLockedCoins is a function to record the call and return result of GetLockedCoinsFunc
+func (m *MockQueryService) GetLockedCoins(ctx context.Context, in *types.GetLockedCoinsRequest) (*types.GetLockedCoinsResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "GetLockedCoins", Request: in})
+	m.mux.Unlock()
+	if m.GetLockedCoinsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetLockedCoinsFunc(ctx, in)
+}
+
+// GetRecipe is a function to record the call and return result of GetRecipeFunc
+func (m *MockQueryService) GetRecipe(ctx context.Context, in *types.GetRecipeRequest) (*types.GetRecipeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "GetRecipe", Request: in})
+	m.mux.Unlock()
+	if m.GetRecipeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetRecipeFunc(ctx, in)
+}
+
+// GetTrade is a function to record the call and return result of GetTradeFunc
+func (m *MockQueryService) GetTrade(ctx context.Context, in *types.GetTradeRequest) (*types.GetTradeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "GetTrade", Request: in})
+	m.mux.Unlock()
+	if m.GetTradeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetTradeFunc(ctx, in)
+}
+
+// ItemsByCookbook is a function to record the call and return result of ItemsByCookbookFunc
+func (m *MockQueryService) ItemsByCookbook(ctx context.Context, in *types.ItemsByCookbookRequest) (*types.ItemsByCookbookResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "ItemsByCookbook", Request: in})
+	m.mux.Unlock()
+	if m.ItemsByCookbookFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ItemsByCookbookFunc(ctx, in)
+}
+
+// ItemsBySender is a function to record the call and return result of ItemsBySenderFunc
+func (m *MockQueryService) ItemsBySender(ctx context.Context, in *types.ItemsBySenderRequest) (*types.ItemsBySenderResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "ItemsBySender", Request: in})
+	m.mux.Unlock()
+	if m.ItemsBySenderFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ItemsBySenderFunc(ctx, in)
+}
+
+// ListCookbook is a function to record the call and return result of ListCookbookFunc
+func (m *MockQueryService) ListCookbook(ctx context.Context, in *types.ListCookbookRequest) (*types.ListCookbookResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "ListCookbook", Request: in})
+	m.mux.Unlock()
+	if m.ListCookbookFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListCookbookFunc(ctx, in)
+}
+
+// ListExecutions is a function to record the call and return result of ListExecutionsFunc
+func (m *MockQueryService) ListExecutions(ctx context.Context, in *types.ListExecutionsRequest) (*types.ListExecutionsResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "ListExecutions", Request: in})
+	m.mux.Unlock()
+	if m.ListExecutionsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListExecutionsFunc(ctx, in)
+}
+
+// ListRecipe is a function to record the call and return result of ListRecipeFunc
+func (m *MockQueryService) ListRecipe(ctx context.Context, in *types.ListRecipeRequest) (*types.ListRecipeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "ListRecipe", Request: in})
+	m.mux.Unlock()
+	if m.ListRecipeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListRecipeFunc(ctx, in)
+}
+
+// ListRecipeByCookbook is a function to record the call and return result of ListRecipeByCookbookFunc
+func (m *MockQueryService) ListRecipeByCookbook(ctx context.Context, in *types.ListRecipeByCookbookRequest) (*types.ListRecipeByCookbookResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "ListRecipeByCookbook", Request: in})
+	m.mux.Unlock()
+	if m.ListRecipeByCookbookFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListRecipeByCookbookFunc(ctx, in)
+}
+
+// ListShortenRecipe is a function to record the call and return result of ListShortenRecipeFunc
+func (m *MockQueryService) ListShortenRecipe(ctx context.Context, in *types.ListShortenRecipeRequest) (*types.ListShortenRecipeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "ListShortenRecipe", Request: in})
+	m.mux.Unlock()
+	if m.ListShortenRecipeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListShortenRecipeFunc(ctx, in)
+}
+
+// ListShortenRecipeByCookbook is a function to record the call and return result of ListShortenRecipeByCookbookFunc
+func (m *MockQueryService) ListShortenRecipeByCookbook(ctx context.Context, in *types.ListShortenRecipeByCookbookRequest) (*types.ListShortenRecipeByCookbookResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "ListShortenRecipeByCookbook", Request: in})
+	m.mux.Unlock()
+	if m.ListShortenRecipeByCookbookFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListShortenRecipeByCookbookFunc(ctx, in)
+}
+
+// ListTrade is a function to record the call and return result of ListTradeFunc
+func (m *MockQueryService) ListTrade(ctx context.Context, in *types.ListTradeRequest) (*types.ListTradeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "ListTrade", Request: in})
+	m.mux.Unlock()
+	if m.ListTradeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListTradeFunc(ctx, in)
+}
+
+// PylonsBalance is a function to record the call and return result of PylonsBalanceFunc
+func (m *MockQueryService) PylonsBalance(ctx context.Context, in *types.PylonsBalanceRequest) (*types.PylonsBalanceResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "PylonsBalance", Request: in})
+	m.mux.Unlock()
+	if m.PylonsBalanceFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.PylonsBalanceFunc(ctx, in)
+}
+
+// MockMsgService is a mock of PylonsMsgService, methods return result of their func fields and ErrNotMocked when it's nil
+type MockMsgService struct {
+	mux   sync.Mutex
+	calls []MockCall
+
+	CheckExecutionFunc           func(ctx context.Context, in *types.MsgCheckExecution) (*types.MsgCheckExecutionResponse, error)
+	CreateAccountFunc            func(ctx context.Context, in *types.MsgCreateAccount) (*types.MsgCreateExecutionResponse, error)
+	CreateCookbookFunc           func(ctx context.Context, in *types.MsgCreateCookbook) (*types.MsgCreateCookbookResponse, error)
+	CreateRecipeFunc             func(ctx context.Context, in *types.MsgCreateRecipe) (*types.MsgCreateRecipeResponse, error)
+	CreateTradeFunc              func(ctx context.Context, in *types.MsgCreateTrade) (*types.MsgCreateTradeResponse, error)
+	DisableRecipeFunc            func(ctx context.Context, in *types.MsgDisableRecipe) (*types.MsgDisableRecipeResponse, error)
+	DisableTradeFunc             func(ctx context.Context, in *types.MsgDisableTrade) (*types.MsgDisableTradeResponse, error)
+	EnableRecipeFunc             func(ctx context.Context, in *types.MsgEnableRecipe) (*types.MsgEnableRecipeResponse, error)
+	EnableTradeFunc              func(ctx context.Context, in *types.MsgEnableTrade) (*types.MsgEnableTradeResponse, error)
+	ExecuteRecipeFunc            func(ctx context.Context, in *types.MsgExecuteRecipe) (*types.MsgExecuteRecipeResponse, error)
+	FiatItemFunc                 func(ctx context.Context, in *types.MsgFiatItem) (*types.MsgFiatItemResponse, error)
+	FulfillTradeFunc             func(ctx context.Context, in *types.MsgFulfillTrade) (*types.MsgFulfillTradeResponse, error)
+	GetPylonsFunc                func(ctx context.Context, in *types.MsgGetPylons) (*types.MsgGetPylonsResponse, error)
+	GoogleIAPGetPylonsFunc       func(ctx context.Context, in *types.MsgGoogleIAPGetPylons) (*types.MsgGoogleIAPGetPylonsResponse, error)
+	HandlerMsgUpdateCookbookFunc func(ctx context.Context, in *types.MsgUpdateCookbook) (*types.MsgUpdateCookbookResponse, error)
+	HandlerMsgUpdateRecipeFunc   func(ctx context.Context, in *types.MsgUpdateRecipe) (*types.MsgUpdateRecipeResponse, error)
+	SendCoinsFunc                func(ctx context.Context, in *types.MsgSendCoins) (*types.MsgSendCoinsResponse, error)
+	SendItemsFunc                func(ctx context.Context, in *types.MsgSendItems) (*types.MsgSendItemsResponse, error)
+	UpdateItemStringFunc         func(ctx context.Context, in *types.MsgUpdateItemString) (*types.MsgUpdateItemStringResponse, error)
+}
+
+var _ PylonsMsgService = &MockMsgService{}
+
+// Calls is a function to get calls made on the mock in order
+func (m *MockMsgService) Calls() []MockCall {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return append([]MockCall{}, m.calls...)
+}
+
+// CheckExecution is a function to record the call and return result of CheckExecutionFunc
+func (m *MockMsgService) CheckExecution(ctx context.Context, in *types.MsgCheckExecution) (*types.MsgCheckExecutionResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "CheckExecution", Request: in})
+	m.mux.Unlock()
+	if m.CheckExecutionFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.CheckExecutionFunc(ctx, in)
+}
+
+// CreateAccount is a function to record the call and return result of CreateAccountFunc
+func (m *MockMsgService) CreateAccount(ctx context.Context, in *types.MsgCreateAccount) (*types.MsgCreateExecutionResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "CreateAccount", Request: in})
+	m.mux.Unlock()
+	if m.CreateAccountFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.CreateAccountFunc(ctx, in)
+}
+
+// CreateCookbook is a function to record the call and return result of CreateCookbookFunc
+func (m *MockMsgService) CreateCookbook(ctx context.Context, in *types.MsgCreateCookbook) (*types.MsgCreateCookbookResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "CreateCookbook", Request: in})
+	m.mux.Unlock()
+	if m.CreateCookbookFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.CreateCookbookFunc(ctx, in)
+}
+
+// CreateRecipe is a function to record the call and return result of CreateRecipeFunc
+func (m *MockMsgService) CreateRecipe(ctx context.Context, in *types.MsgCreateRecipe) (*types.MsgCreateRecipeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "CreateRecipe", Request: in})
+	m.mux.Unlock()
+	if m.CreateRecipeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.CreateRecipeFunc(ctx, in)
+}
+
+// CreateTrade is a function to record the call and return result of CreateTradeFunc
+func (m *MockMsgService) CreateTrade(ctx context.Context, in *types.MsgCreateTrade) (*types.MsgCreateTradeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "CreateTrade", Request: in})
+	m.mux.Unlock()
+	if m.CreateTradeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.CreateTradeFunc(ctx, in)
+}
+
+// DisableRecipe is a function to record the call and return result of DisableRecipeFunc
+func (m *MockMsgService) DisableRecipe(ctx context.Context, in *types.MsgDisableRecipe) (*types.MsgDisableRecipeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "DisableRecipe", Request: in})
+	m.mux.Unlock()
+	if m.DisableRecipeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.DisableRecipeFunc(ctx, in)
+}
+
+// DisableTrade is a function to record the call and return result of DisableTradeFunc
+func (m *MockMsgService) DisableTrade(ctx context.Context, in *types.MsgDisableTrade) (*types.MsgDisableTradeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "DisableTrade", Request: in})
+	m.mux.Unlock()
+	if m.DisableTradeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.DisableTradeFunc(ctx, in)
+}
+
+// EnableRecipe is a function to record the call and return result of EnableRecipeFunc
+func (m *MockMsgService) EnableRecipe(ctx context.Context, in *types.MsgEnableRecipe) (*types.MsgEnableRecipeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "EnableRecipe", Request: in})
+	m.mux.Unlock()
+	if m.EnableRecipeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.EnableRecipeFunc(ctx, in)
+}
+
+// EnableTrade is a function to record the call and return result of EnableTradeFunc
+func (m *MockMsgService) EnableTrade(ctx context.Context, in *types.MsgEnableTrade) (*types.MsgEnableTradeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "EnableTrade", Request: in})
+	m.mux.Unlock()
+	if m.EnableTradeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.EnableTradeFunc(ctx, in)
+}
+
+// ExecuteRecipe is a function to record the call and return result of ExecuteRecipeFunc
+func (m *MockMsgService) ExecuteRecipe(ctx context.Context, in *types.MsgExecuteRecipe) (*types.MsgExecuteRecipeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "ExecuteRecipe", Request: in})
+	m.mux.Unlock()
+	if m.ExecuteRecipeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ExecuteRecipeFunc(ctx, in)
+}
+
+// FiatItem is a function to record the call and return result of FiatItemFunc
+func (m *MockMsgService) FiatItem(ctx context.Context, in *types.MsgFiatItem) (*types.MsgFiatItemResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "FiatItem", Request: in})
+	m.mux.Unlock()
+	if m.FiatItemFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.FiatItemFunc(ctx, in)
+}
+
+// FulfillTrade is a function to record the call and return result of FulfillTradeFunc
+func (m *MockMsgService) FulfillTrade(ctx context.Context, in *types.MsgFulfillTrade) (*types.MsgFulfillTradeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "FulfillTrade", Request: in})
+	m.mux.Unlock()
+	if m.FulfillTradeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.FulfillTradeFunc(ctx, in)
+}
+
+// GetPylons is a function to record the call and return result of GetPylonsFunc
+func (m *MockMsgService) GetPylons(ctx context.Context, in *types.MsgGetPylons) (*types.MsgGetPylonsResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "GetPylons", Request: in})
+	m.mux.Unlock()
+	if m.GetPylonsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetPylonsFunc(ctx, in)
+}
+
+// GoogleIAPGetPylons is a function to record the call and return result of GoogleIAPGetPylonsFunc
+func (m *MockMsgService) GoogleIAPGetPylons(ctx context.Context, in *types.MsgGoogleIAPGetPylons) (*types.MsgGoogleIAPGetPylonsResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "GoogleIAPGetPylons", Request: in})
+	m.mux.Unlock()
+	if m.GoogleIAPGetPylonsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GoogleIAPGetPylonsFunc(ctx, in)
+}
+
+// HandlerMsgUpdateCookbook is a function to record the call and return result of HandlerMsgUpdateCookbookFunc
+func (m *MockMsgService) HandlerMsgUpdateCookbook(ctx context.Context, in *types.MsgUpdateCookbook) (*types.MsgUpdateCookbookResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "HandlerMsgUpdateCookbook", Request: in})
+	m.mux.Unlock()
+	if m.HandlerMsgUpdateCookbookFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.HandlerMsgUpdateCookbookFunc(ctx, in)
+}
+
+// HandlerMsgUpdateRecipe is a function to record the call and return result of HandlerMsgUpdateRecipeFunc
+func (m *MockMsgService) HandlerMsgUpdateRecipe(ctx context.Context, in *types.MsgUpdateRecipe) (*types.MsgUpdateRecipeResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "HandlerMsgUpdateRecipe", Request: in})
+	m.mux.Unlock()
+	if m.HandlerMsgUpdateRecipeFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.HandlerMsgUpdateRecipeFunc(ctx, in)
+}
+
+// SendCoins is a function to record the call and return result of SendCoinsFunc
+func (m *MockMsgService) SendCoins(ctx context.Context, in *types.MsgSendCoins) (*types.MsgSendCoinsResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "SendCoins", Request: in})
+	m.mux.Unlock()
+	if m.SendCoinsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.SendCoinsFunc(ctx, in)
+}
+
+// SendItems is a function to record the call and return result of SendItemsFunc
+func (m *MockMsgService) SendItems(ctx context.Context, in *types.MsgSendItems) (*types.MsgSendItemsResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "SendItems", Request: in})
+	m.mux.Unlock()
+	if m.SendItemsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.SendItemsFunc(ctx, in)
+}
+
+// UpdateItemString is a function to record the call and return result of UpdateItemStringFunc
+func (m *MockMsgService) UpdateItemString(ctx context.Context, in *types.MsgUpdateItemString) (*types.MsgUpdateItemStringResponse, error) {
+	m.mux.Lock()
+	m.calls = append(m.calls, MockCall{Method: "UpdateItemString", Request: in})
+	m.mux.Unlock()
+	if m.UpdateItemStringFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.UpdateItemStringFunc(ctx, in)
+}
@@ -0,0 +1,267 @@
+// Code generated by x/pylons/service/gen. DO NOT EDIT.
+
+package service
+
+import (
+	"context"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	grpc1 "github.com/gogo/protobuf/grpc"
+)
+
+// APIVersion is the version of pylons grpc services the interfaces are generated from
+const APIVersion = "v1"
+
+// PylonsQueryService is an interface of pylons query service
+type PylonsQueryService interface {
+	AddrFromPubKey(ctx context.Context, in *types.AddrFromPubKeyRequest) (*types.AddrFromPubKeyResponse, error)
+	CheckGoogleIAPOrder(ctx context.Context, in *types.CheckGoogleIAPOrderRequest) (*types.CheckGoogleIAPOrderResponse, error)
+	GetCookbook(ctx context.Context, in *types.GetCookbookRequest) (*types.GetCookbookResponse, error)
+	GetExecution(ctx context.Context, in *types.GetExecutionRequest) (*types.GetExecutionResponse, error)
+	GetItem(ctx context.Context, in *types.GetItemRequest) (*types.GetItemResponse, error)
+	GetLockedCoinDetails(ctx context.Context, in *types.GetLockedCoinDetailsRequest) (*types.GetLockedCoinDetailsResponse, error)
+	GetLockedCoins(ctx context.Context, in *types.GetLockedCoinsRequest) (*types.GetLockedCoinsResponse, error)
+	GetRecipe(ctx context.Context, in *types.GetRecipeRequest) (*types.GetRecipeResponse, error)
+	GetTrade(ctx context.Context, in *types.GetTradeRequest) (*types.GetTradeResponse, error)
+	ItemsByCookbook(ctx context.Context, in *types.ItemsByCookbookRequest) (*types.ItemsByCookbookResponse, error)
+	ItemsBySender(ctx context.Context, in *types.ItemsBySenderRequest) (*types.ItemsBySenderResponse, error)
+	ListCookbook(ctx context.Context, in *types.ListCookbookRequest) (*types.ListCookbookResponse, error)
+	ListExecutions(ctx context.Context, in *types.ListExecutionsRequest) (*types.ListExecutionsResponse, error)
+	ListRecipe(ctx context.Context, in *types.ListRecipeRequest) (*types.ListRecipeResponse, error)
+	ListRecipeByCookbook(ctx context.Context, in *types.ListRecipeByCookbookRequest) (*types.ListRecipeByCookbookResponse, error)
+	ListShortenRecipe(ctx context.Context, in *types.ListShortenRecipeRequest) (*types.ListShortenRecipeResponse, error)
+	ListShortenRecipeByCookbook(ctx context.Context, in *types.ListShortenRecipeByCookbookRequest) (*types.ListShortenRecipeByCookbookResponse, error)
+	ListTrade(ctx context.Context, in *types.ListTradeRequest) (*types.ListTradeResponse, error)
+	PylonsBalance(ctx context.Context, in *types.PylonsBalanceRequest) (*types.PylonsBalanceResponse, error)
+}
+
+type queryService struct {
+	client types.QueryClient
+}
+
+// NewPylonsQueryService is a function to create PylonsQueryService sending requests on grpc connection
+func NewPylonsQueryService(conn grpc1.ClientConn) PylonsQueryService {
+	return queryService{client: types.NewQueryClient(conn)}
+}
+
+// AddrFromPubKey is a function to send AddrFromPubKeyRequest and get its response
+func (s queryService) AddrFromPubKey(ctx context.Context, in *types.AddrFromPubKeyRequest) (*types.AddrFromPubKeyResponse, error) {
+	return s.client.AddrFromPubKey(ctx, in)
+}
+
+// CheckGoogleIAPOrder is a function to send CheckGoogleIAPOrderRequest and get its response
+func (s queryService) CheckGoogleIAPOrder(ctx context.Context, in *types.CheckGoogleIAPOrderRequest) (*types.CheckGoogleIAPOrderResponse, error) {
+	return s.client.CheckGoogleIAPOrder(ctx, in)
+}
+
+// GetCookbook is a function to send GetCookbookRequest and get its response
+func (s queryService) GetCookbook(ctx context.Context, in *types.GetCookbookRequest) (*types.GetCookbookResponse, error) {
+	return s.client.GetCookbook(ctx, in)
+}
+
+// GetExecution is a function to send GetExecutionRequest and get its response
+func (s queryService) GetExecution(ctx context.Context, in *types.GetExecutionRequest) (*types.GetExecutionResponse, error) {
+	return s.client.GetExecution(ctx, in)
+}
+
+// GetItem is a function to send GetItemRequest and get its response
+func (s queryService) GetItem(ctx context.Context, in *types.GetItemRequest) (*types.GetItemResponse, error) {
+	return s.client.GetItem(ctx, in)
+}
+
+// GetLockedCoinDetails is a function to send GetLockedCoinDetailsRequest and get its response
+func (s queryService) GetLockedCoinDetails(ctx context.Context, in *types.GetLockedCoinDetailsRequest) (*types.GetLockedCoinDetailsResponse, error) {
+	return s.client.GetLockedCoinDetails(ctx, in)
+}
+
+// GetLockedCoins is a function to send GetLockedCoinsRequest and get its response
+func (s queryService) GetLockedCoins(ctx context.Context, in *types.GetLockedCoinsRequest) (*types.GetLockedCoinsResponse, error) {
+	return s.client.GetLockedCoins(ctx, in)
+}
+
+// GetRecipe is a function to send GetRecipeRequest and get its response
+func (s queryService) GetRecipe(ctx context.Context, in *types.GetRecipeRequest) (*types.GetRecipeResponse, error) {
+	return s.client.GetRecipe(ctx, in)
+}
+
+// GetTrade is a function to send GetTradeRequest and get its response
+func (s queryService) GetTrade(ctx context.Context, in *types.GetTradeRequest) (*types.GetTradeResponse, error) {
+	return s.client.GetTrade(ctx, in)
+}
+
+// ItemsByCookbook is a function to send ItemsByCookbookRequest and get its response
+func (s queryService) ItemsByCookbook(ctx context.Context, in *types.ItemsByCookbookRequest) (*types.ItemsByCookbookResponse, error) {
+	return s.client.ItemsByCookbook(ctx, in)
+}
+
+// ItemsBySender is a function to send ItemsBySenderRequest and get its response
+func (s queryService) ItemsBySender(ctx context.Context, in *types.ItemsBySenderRequest) (*types.ItemsBySenderResponse, error) {
+	return s.client.ItemsBySender(ctx, in)
+}
+
+// ListCookbook is a function to send ListCookbookRequest and get its response
+func (s queryService) ListCookbook(ctx context.Context, in *types.ListCookbookRequest) (*types.ListCookbookResponse, error) {
+	return s.client.ListCookbook(ctx, in)
+}
+
+// ListExecutions is a function to send ListExecutionsRequest and get its response
+func (s queryService) ListExecutions(ctx context.Context, in *types.ListExecutionsRequest) (*types.ListExecutionsResponse, error) {
+	return s.client.ListExecutions(ctx, in)
+}
+
+// ListRecipe is a function to send ListRecipeRequest and get its response
+func (s queryService) ListRecipe(ctx context.Context, in *types.ListRecipeRequest) (*types.ListRecipeResponse, error) {
+	return s.client.ListRecipe(ctx, in)
+}
+
+// ListRecipeByCookbook is a function to send ListRecipeByCookbookRequest and get its response
+func (s queryService) ListRecipeByCookbook(ctx context.Context, in *types.ListRecipeByCookbookRequest) (*types.ListRecipeByCookbookResponse, error) {
+	return s.client.ListRecipeByCookbook(ctx, in)
+}
+
+// ListShortenRecipe is a function to send ListShortenRecipeRequest and get its response
+func (s queryService) ListShortenRecipe(ctx context.Context, in *types.ListShortenRecipeRequest) (*types.ListShortenRecipeResponse, error) {
+	return s.client.ListShortenRecipe(ctx, in)
+}
+
+// ListShortenRecipeByCookbook is a function to send ListShortenRecipeByCookbookRequest and get its response
+func (s queryService) ListShortenRecipeByCookbook(ctx context.Context, in *types.ListShortenRecipeByCookbookRequest) (*types.ListShortenRecipeByCookbookResponse, error) {
+	return s.client.ListShortenRecipeByCookbook(ctx, in)
+}
+
+// ListTrade is a function to send ListTradeRequest and get its response
+func (s queryService) ListTrade(ctx context.Context, in *types.ListTradeRequest) (*types.ListTradeResponse, error) {
+	return s.client.ListTrade(ctx, in)
+}
+
+// PylonsBalance is a function to send PylonsBalanceRequest and get its response
+func (s queryService) PylonsBalance(ctx context.Context, in *types.PylonsBalanceRequest) (*types.PylonsBalanceResponse, error) {
+	return s.client.PylonsBalance(ctx, in)
+}
+
+// PylonsMsgService is an interface of pylons msg service
+type PylonsMsgService interface {
+	CheckExecution(ctx context.Context, in *types.MsgCheckExecution) (*types.MsgCheckExecutionResponse, error)
+	CreateAccount(ctx context.Context, in *types.MsgCreateAccount) (*types.MsgCreateExecutionResponse, error)
+	CreateCookbook(ctx context.Context, in *types.MsgCreateCookbook) (*types.MsgCreateCookbookResponse, error)
+	CreateRecipe(ctx context.Context, in *types.MsgCreateRecipe) (*types.MsgCreateRecipeResponse, error)
+	CreateTrade(ctx context.Context, in *types.MsgCreateTrade) (*types.MsgCreateTradeResponse, error)
+	DisableRecipe(ctx context.Context, in *types.MsgDisableRecipe) (*types.MsgDisableRecipeResponse, error)
+	DisableTrade(ctx context.Context, in *types.MsgDisableTrade) (*types.MsgDisableTradeResponse, error)
+	EnableRecipe(ctx context.Context, in *types.MsgEnableRecipe) (*types.MsgEnableRecipeResponse, error)
+	EnableTrade(ctx context.Context, in *types.MsgEnableTrade) (*types.MsgEnableTradeResponse, error)
+	ExecuteRecipe(ctx context.Context, in *types.MsgExecuteRecipe) (*types.MsgExecuteRecipeResponse, error)
+	FiatItem(ctx context.Context, in *types.MsgFiatItem) (*types.MsgFiatItemResponse, error)
+	FulfillTrade(ctx context.Context, in *types.MsgFulfillTrade) (*types.MsgFulfillTradeResponse, error)
+	GetPylons(ctx context.Context, in *types.MsgGetPylons) (*types.MsgGetPylonsResponse, error)
+	GoogleIAPGetPylons(ctx context.Context, in *types.MsgGoogleIAPGetPylons) (*types.MsgGoogleIAPGetPylonsResponse, error)
+	HandlerMsgUpdateCookbook(ctx context.Context, in *types.MsgUpdateCookbook) (*types.MsgUpdateCookbookResponse, error)
+	HandlerMsgUpdateRecipe(ctx context.Context, in *types.MsgUpdateRecipe) (*types.MsgUpdateRecipeResponse, error)
+	SendCoins(ctx context.Context, in *types.MsgSendCoins) (*types.MsgSendCoinsResponse, error)
+	SendItems(ctx context.Context, in *types.MsgSendItems) (*types.MsgSendItemsResponse, error)
+	UpdateItemString(ctx context.Context, in *types.MsgUpdateItemString) (*types.MsgUpdateItemStringResponse, error)
+}
+
+type msgService struct {
+	client types.MsgClient
+}
+
+// NewPylonsMsgService is a function to create PylonsMsgService sending requests on grpc connection
+func NewPylonsMsgService(conn grpc1.ClientConn) PylonsMsgService {
+	return msgService{client: types.NewMsgClient(conn)}
+}
+
+// CheckExecution is a function to send MsgCheckExecution and get its response
+func (s msgService) CheckExecution(ctx context.Context, in *types.MsgCheckExecution) (*types.MsgCheckExecutionResponse, error) {
+	return s.client.CheckExecution(ctx, in)
+}
+
+// CreateAccount is a function to send MsgCreateAccount and get its response
+func (s msgService) CreateAccount(ctx context.Context, in *types.MsgCreateAccount) (*types.MsgCreateExecutionResponse, error) {
+	return s.client.CreateAccount(ctx, in)
+}
+
+// CreateCookbook is a function to send MsgCreateCookbook and get its response
+func (s msgService) CreateCookbook(ctx context.Context, in *types.MsgCreateCookbook) (*types.MsgCreateCookbookResponse, error) {
+	return s.client.CreateCookbook(ctx, in)
+}
+
+// CreateRecipe is a function to send MsgCreateRecipe and get its response
+func (s msgService) CreateRecipe(ctx context.Context, in *types.MsgCreateRecipe) (*types.MsgCreateRecipeResponse, error) {
+	return s.client.CreateRecipe(ctx, in)
+}
+
+// CreateTrade is a function to send MsgCreateTrade and get its response
+func (s msgService) CreateTrade(ctx context.Context, in *types.MsgCreateTrade) (*types.MsgCreateTradeResponse, error) {
+	return s.client.CreateTrade(ctx, in)
+}
+
+// DisableRecipe is a function to send MsgDisableRecipe and get its response
+func (s msgService) DisableRecipe(ctx context.Context, in *types.MsgDisableRecipe) (*types.MsgDisableRecipeResponse, error) {
+	return s.client.DisableRecipe(ctx, in)
+}
+
+// DisableTrade is a function to send MsgDisableTrade and get its response
+func (s msgService) DisableTrade(ctx context.Context, in *types.MsgDisableTrade) (*types.MsgDisableTradeResponse, error) {
+	return s.client.DisableTrade(ctx, in)
+}
+
+// EnableRecipe is a function to send MsgEnableRecipe and get its response
+func (s msgService) EnableRecipe(ctx context.Context, in *types.MsgEnableRecipe) (*types.MsgEnableRecipeResponse, error) {
+	return s.client.EnableRecipe(ctx, in)
+}
+
+// EnableTrade is a function to send MsgEnableTrade and get its response
+func (s msgService) EnableTrade(ctx context.Context, in *types.MsgEnableTrade) (*types.MsgEnableTradeResponse, error) {
+	return s.client.EnableTrade(ctx, in)
+}
+
+// ExecuteRecipe is a function to send MsgExecuteRecipe and get its response
+func (s msgService) ExecuteRecipe(ctx context.Context, in *types.MsgExecuteRecipe) (*types.MsgExecuteRecipeResponse, error) {
+	return s.client.ExecuteRecipe(ctx, in)
+}
+
+// FiatItem is a function to send MsgFiatItem and get its response
+func (s msgService) FiatItem(ctx context.Context, in *types.MsgFiatItem) (*types.MsgFiatItemResponse, error) {
+	return s.client.FiatItem(ctx, in)
+}
+
+// FulfillTrade is a function to send MsgFulfillTrade and get its response
+func (s msgService) FulfillTrade(ctx context.Context, in *types.MsgFulfillTrade) (*types.MsgFulfillTradeResponse, error) {
+	return s.client.FulfillTrade(ctx, in)
+}
+
+// GetPylons is a function to send MsgGetPylons and get its response
+func (s msgService) GetPylons(ctx context.Context, in *types.MsgGetPylons) (*types.MsgGetPylonsResponse, error) {
+	return s.client.GetPylons(ctx, in)
+}
+
+// GoogleIAPGetPylons is a function to send MsgGoogleIAPGetPylons and get its response
+func (s msgService) GoogleIAPGetPylons(ctx context.Context, in *types.MsgGoogleIAPGetPylons) (*types.MsgGoogleIAPGetPylonsResponse, error) {
+	return s.client.GoogleIAPGetPylons(ctx, in)
+}
+
+// HandlerMsgUpdateCookbook is a function to send MsgUpdateCookbook and get its response
+func (s msgService) HandlerMsgUpdateCookbook(ctx context.Context, in *types.MsgUpdateCookbook) (*types.MsgUpdateCookbookResponse, error) {
+	return s.client.HandlerMsgUpdateCookbook(ctx, in)
+}
+
+// HandlerMsgUpdateRecipe is a function to send MsgUpdateRecipe and get its response
+func (s msgService) HandlerMsgUpdateRecipe(ctx context.Context, in *types.MsgUpdateRecipe) (*types.MsgUpdateRecipeResponse, error) {
+	return s.client.HandlerMsgUpdateRecipe(ctx, in)
+}
+
+// SendCoins is a function to send MsgSendCoins and get its response
+func (s msgService) SendCoins(ctx context.Context, in *types.MsgSendCoins) (*types.MsgSendCoinsResponse, error) {
+	return s.client.SendCoins(ctx, in)
+}
+
+// SendItems is a function to send MsgSendItems and get its response
+func (s msgService) SendItems(ctx context.Context, in *types.MsgSendItems) (*types.MsgSendItemsResponse, error) {
+	return s.client.SendItems(ctx, in)
+}
+
+// UpdateItemString is a function to send MsgUpdateItemString and get its response
+func (s msgService) UpdateItemString(ctx context.Context, in *types.MsgUpdateItemString) (*types.MsgUpdateItemStringResponse, error) {
+	return s.client.UpdateItemString(ctx, in)
+}
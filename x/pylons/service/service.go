@@ -0,0 +1,17 @@
+// Package service provides stable interfaces of pylons grpc services and their mocks
+// so that applications can depend on the interfaces instead of running pylonsd or building rest calls
+// Msg services are sent as transactions by NewTxMsgService as nodes don't serve them over grpc.
+package service
+
+import "errors"
+
+//go:generate go run ./gen -version v1
+
+// ErrNotMocked is an error returned by mock methods whose func field is not set
+var ErrNotMocked = errors.New("method is not mocked")
+
+// MockCall is a struct to describe a call made on a mock service
+type MockCall struct {
+	Method  string
+	Request interface{}
+}
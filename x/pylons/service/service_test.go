@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+// fakeConn is a grpc connection answering GetCookbook requests without node
+type fakeConn struct {
+	methods []string
+}
+
+func (c *fakeConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	c.methods = append(c.methods, method)
+	req, ok := args.(*types.GetCookbookRequest)
+	if !ok {
+		return errors.New("unexpected request")
+	}
+	reply.(*types.GetCookbookResponse).ID = req.CookbookID
+	return nil
+}
+
+func (c *fakeConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, errors.New("streams are not supported")
+}
+
+func TestPylonsQueryService(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	conn := &fakeConn{}
+	svc := NewPylonsQueryService(conn)
+	resp, err := svc.GetCookbook(context.Background(), &types.GetCookbookRequest{CookbookID: "cookbook_001"})
+	t.MustNil(err, "error getting cookbook")
+	t.MustTrue(resp.ID == "cookbook_001", "response should be decoded from connection")
+	t.MustTrue(len(conn.methods) == 1 && conn.methods[0] == "/pylons.Query/GetCookbook", "request should be sent to grpc method of query service")
+}
+
+func TestMockServices(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	var query PylonsQueryService = &MockQueryService{
+		GetItemFunc: func(ctx context.Context, in *types.GetItemRequest) (*types.GetItemResponse, error) {
+			return &types.GetItemResponse{Item: types.Item{ID: in.ItemID}}, nil
+		},
+	}
+	item, err := query.GetItem(context.Background(), &types.GetItemRequest{ItemID: "item_001"})
+	t.MustTrue(err == nil && item.Item.ID == "item_001", "mocked method should return result of its func")
+	_, err = query.GetRecipe(context.Background(), &types.GetRecipeRequest{RecipeID: "recipe_001"})
+	t.MustTrue(errors.Is(err, ErrNotMocked), "method without func should return ErrNotMocked")
+
+	calls := query.(*MockQueryService).Calls()
+	t.MustTrue(len(calls) == 2 && calls[0].Method == "GetItem" && calls[1].Method == "GetRecipe", "calls should be recorded in order")
+
+	msg := &MockMsgService{}
+	_, err = msg.ExecuteRecipe(context.Background(), &types.MsgExecuteRecipe{RecipeID: "recipe_001"})
+	t.MustTrue(errors.Is(err, ErrNotMocked), "msg service should be mocked too")
+	t.MustTrue(msg.Calls()[0].Request.(*types.MsgExecuteRecipe).RecipeID == "recipe_001", "request should be recorded")
+}
+
+func TestTxMsgService(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	respBytes, err := proto.Marshal(&types.MsgExecuteRecipeResponse{Status: "Success", Message: "successfully executed the recipe"})
+	t.MustNil(err, "error encoding msg response")
+	dataBytes, err := proto.Marshal(&sdk.TxMsgData{Data: []*sdk.MsgData{{MsgType: "execute_recipe", Data: respBytes}}})
+	t.MustNil(err, "error encoding transaction data")
+
+	broadcasts := [][]sdk.Msg{}
+	res := &sdk.TxResponse{TxHash: "ABCD", Data: hex.EncodeToString(dataBytes)}
+	svc := NewTxMsgService(func(ctx context.Context, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+		broadcasts = append(broadcasts, msgs)
+		return res, nil
+	})
+	msg := types.NewMsgExecuteRecipe("recipe_001", "cosmos1sender", []string{})
+	resp, err := svc.ExecuteRecipe(context.Background(), &msg)
+	t.MustNil(err, "error executing recipe")
+	t.MustTrue(resp.Status == "Success", "msg response should be decoded from transaction data")
+	t.MustTrue(len(broadcasts) == 1 && broadcasts[0][0].(*types.MsgExecuteRecipe).RecipeID == "recipe_001", "msg should be broadcast in a transaction")
+
+	_, err = svc.ExecuteRecipe(context.Background(), &types.MsgExecuteRecipe{RecipeID: "recipe_001"})
+	t.MustTrue(errors.Is(err, sdkerrors.ErrInvalidAddress) && len(broadcasts) == 1, "invalid msg should not be broadcast")
+
+	res = &sdk.TxResponse{TxHash: "ABCD", Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrInsufficientFunds.ABCICode(), RawLog: "insufficient funds"}
+	_, err = svc.ExecuteRecipe(context.Background(), &msg)
+	t.MustTrue(errors.Is(err, sdkerrors.ErrInsufficientFunds), "failed transaction should return error of its code")
+
+	res = &sdk.TxResponse{TxHash: "ABCD"}
+	_, err = svc.ExecuteRecipe(context.Background(), &msg)
+	t.MustTrue(errors.Is(err, ErrNoMsgResponse), "transaction which is not committed should have no msg response")
+}
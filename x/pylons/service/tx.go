@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	grpc1 "github.com/gogo/protobuf/grpc"
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+// ErrNoMsgResponse is an error of transaction response having no msg response, as it's not committed in sync and async broadcast modes
+var ErrNoMsgResponse = errors.New("transaction response has no msg response")
+
+// Broadcaster is a function to sign msgs into a transaction and broadcast it, it returns response of the transaction
+type Broadcaster func(ctx context.Context, msgs ...sdk.Msg) (*sdk.TxResponse, error)
+
+// ClientBroadcaster is a function to get broadcaster signing transactions by From key of client context with factory
+// Account number and sequence are queried for each transaction unless factory sets them, so transactions are sent one by one.
+// Broadcast mode of client context should be block for msg services to get msg responses.
+// Client context queries are not canceled by ctx, it's only checked before the transaction is signed.
+func ClientBroadcaster(clientCtx client.Context, txf tx.Factory) Broadcaster {
+	var mux sync.Mutex
+	return func(ctx context.Context, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+		mux.Lock()
+		defer mux.Unlock()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		txf, err := tx.PrepareFactory(clientCtx, txf)
+		if err != nil {
+			return nil, err
+		}
+		txBuilder, err := tx.BuildUnsignedTx(txf, msgs...)
+		if err != nil {
+			return nil, err
+		}
+		if err = tx.Sign(txf, clientCtx.GetFromName(), txBuilder, true); err != nil {
+			return nil, err
+		}
+		txBytes, err := clientCtx.TxConfig.TxEncoder()(txBuilder.GetTx())
+		if err != nil {
+			return nil, err
+		}
+		return clientCtx.BroadcastTx(txBytes)
+	}
+}
+
+// txConn is a grpc connection sending requests of msg service as transactions
+type txConn struct {
+	broadcast Broadcaster
+}
+
+var _ grpc1.ClientConn = txConn{}
+
+// NewTxConn is a function to create grpc connection sending each msg service request as a transaction by broadcast
+// Nodes don't serve msg services over grpc, so msg service clients are backed by transactions instead.
+func NewTxConn(broadcast Broadcaster) grpc1.ClientConn {
+	return txConn{broadcast: broadcast}
+}
+
+// NewTxMsgService is a function to create PylonsMsgService sending msgs as transactions by broadcast
+func NewTxMsgService(broadcast Broadcaster) PylonsMsgService {
+	return NewPylonsMsgService(NewTxConn(broadcast))
+}
+
+// Invoke is a function to broadcast request msg and decode msg response of committed transaction into reply
+// Failed transactions return registered errors of their codespace and code, so errors.Is can check them.
+func (c txConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	msg, ok := args.(sdk.Msg)
+	if !ok {
+		return fmt.Errorf("request of %s is %T which is not a msg", method, args)
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	res, err := c.broadcast(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if res.Code != 0 {
+		return sdkerrors.Wrapf(sdkerrors.ABCIError(res.Codespace, res.Code, res.RawLog), "transaction %s", res.TxHash)
+	}
+	dataBytes, err := hex.DecodeString(res.Data)
+	if err != nil {
+		return err
+	}
+	txMsgData := sdk.TxMsgData{}
+	if err = proto.Unmarshal(dataBytes, &txMsgData); err != nil {
+		return err
+	}
+	if len(txMsgData.Data) == 0 {
+		return fmt.Errorf("%w: transaction %s", ErrNoMsgResponse, res.TxHash)
+	}
+	return proto.Unmarshal(txMsgData.Data[0].Data, reply.(proto.Message))
+}
+
+// NewStream is a function to satisfy grpc connection, msg services have no streaming method
+func (c txConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, fmt.Errorf("streaming %s is not supported by msg services", method)
+}
@@ -482,10 +482,12 @@ func ValidateEmail(email string) error {
 	return errors.New("invalid email address")
 }
 
+// semVerRegex matches SemVer, groups are major, minor, patch, pre-release and build
+var semVerRegex = regexp.MustCompile(`^([0-9]+)\.([0-9]+)\.([0-9]+)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+))?$`)
+
 // ValidateVersion validates the SemVer
 func ValidateVersion(s string) error {
-	regex := regexp.MustCompile(`^([0-9]+)\.([0-9]+)\.([0-9]+)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z-]+)?$`)
-	if regex.MatchString(s) {
+	if semVerRegex.MatchString(s) {
 		return nil
 	}
 
@@ -0,0 +1,164 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// LootTableRow is a struct to describe a weighted output of recipe with the probability it's selected
+type LootTableRow struct {
+	OutputIndex int
+	EntryIDs    []string
+	Weight      int64
+	Probability float64
+}
+
+// LootTable is a struct to describe weight table of recipe outputs as designed
+type LootTable struct {
+	RecipeID string
+	Rows     []LootTableRow
+	// Entries is the probability each entry is paid out by an execution, entries of several outputs sum them up
+	Entries map[string]float64
+}
+
+// NewLootTable is a function to get loot table of recipe by evaluating weights of outputs for input items once
+// It is exact when weights do not use rand functions, Simulator.Distribution is needed otherwise.
+func NewLootTable(rcp Recipe, items []Item) (LootTable, error) {
+	sim, err := NewSimulator(rcp, 0)
+	if err != nil {
+		return LootTable{}, err
+	}
+	weights, err := sim.outputWeights(items)
+	if err != nil {
+		return LootTable{}, err
+	}
+	total := int64(0)
+	for _, weight := range weights {
+		total += weight
+	}
+	table := LootTable{RecipeID: rcp.ID, Entries: make(map[string]float64)}
+	for idx, output := range rcp.Outputs {
+		row := LootTableRow{
+			OutputIndex: idx,
+			EntryIDs:    output.EntryIDs,
+			Weight:      weights[idx],
+			Probability: float64(weights[idx]) / float64(total),
+		}
+		table.Rows = append(table.Rows, row)
+		for _, entryID := range output.EntryIDs {
+			table.Entries[entryID] += row.Probability
+		}
+	}
+	return table, nil
+}
+
+// EntryIDs is a function to get IDs of entries paid out by any output in order
+func (lt LootTable) EntryIDs() []string {
+	ids := []string{}
+	for id := range lt.Entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ChiSquaredTest is a struct to describe goodness of fit of observed counts to expected probabilities
+type ChiSquaredTest struct {
+	Statistic        float64
+	DegreesOfFreedom int
+	// PValue is the probability of a statistic at least as large when observed counts follow the probabilities
+	PValue float64
+}
+
+// Passes is a function to check if observations are consistent with probabilities at significance level alpha
+func (test ChiSquaredTest) Passes(alpha float64) bool {
+	return test.PValue >= alpha
+}
+
+// ChiSquared is a function to run Pearson's chi-squared test of observed counts against probabilities
+// Outcomes of zero probability fail the test when they are observed and are left out otherwise.
+func ChiSquared(observed []int, probabilities []float64) (ChiSquaredTest, error) {
+	if len(observed) != len(probabilities) {
+		return ChiSquaredTest{}, fmt.Errorf("%d observed counts are given for %d probabilities", len(observed), len(probabilities))
+	}
+	total := 0
+	for _, count := range observed {
+		total += count
+	}
+	if total == 0 {
+		return ChiSquaredTest{}, errors.New("no observation is given")
+	}
+	test := ChiSquaredTest{}
+	outcomes := 0
+	for idx, probability := range probabilities {
+		expected := probability * float64(total)
+		if expected == 0 {
+			if observed[idx] > 0 {
+				return ChiSquaredTest{Statistic: math.Inf(1), DegreesOfFreedom: len(probabilities) - 1}, nil
+			}
+			continue
+		}
+		diff := float64(observed[idx]) - expected
+		test.Statistic += diff * diff / expected
+		outcomes++
+	}
+	test.DegreesOfFreedom = outcomes - 1
+	test.PValue = 1
+	if test.DegreesOfFreedom > 0 {
+		test.PValue = upperIncompleteGamma(float64(test.DegreesOfFreedom)/2, test.Statistic/2)
+	}
+	return test, nil
+}
+
+// upperIncompleteGamma is a function to get regularized upper incomplete gamma function Q(a, x)
+// Series converges fast for x < a+1 and continued fraction does otherwise.
+func upperIncompleteGamma(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	lgamma, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lgamma)
+	const (
+		maxIterations = 1000
+		epsilon       = 1e-14
+		tiny          = 1e-300
+	)
+	if x < a+1 {
+		sum := 1 / a
+		term := sum
+		for n := 1; n < maxIterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+		return math.Max(0, 1-sum*prefix)
+	}
+	// modified Lentz's method
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < maxIterations; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return prefix * h
+}
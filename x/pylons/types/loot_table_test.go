@@ -0,0 +1,60 @@
+package types
+
+import (
+	"math"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestLootTable(originT *originT.T) {
+	t := testing.NewT(originT)
+	rcp := Recipe{
+		ID: "recipe1",
+		Entries: EntriesList{
+			CoinOutputs: []CoinOutput{{ID: "gold", Coin: "gold", Count: "10"}},
+			ItemOutputs: []ItemOutput{{ID: "sword"}},
+		},
+		Outputs: []WeightedOutputs{
+			{EntryIDs: []string{"gold"}, Weight: "6"},
+			{EntryIDs: []string{"gold", "sword"}, Weight: "3"},
+			{EntryIDs: []string{}, Weight: "1"},
+		},
+	}
+	table, err := NewLootTable(rcp, nil)
+	t.MustNil(err, "error getting loot table")
+	t.MustTrue(len(table.Rows) == 3 && table.Rows[1].Weight == 3, "each output should be a row")
+	t.MustTrue(math.Abs(table.Rows[0].Probability-0.6) < 1e-9, "probability should be weight over total weight")
+	t.MustTrue(math.Abs(table.Entries["gold"]-0.9) < 1e-9, "probability of entry should sum up its outputs")
+	t.MustTrue(len(table.EntryIDs()) == 2 && table.EntryIDs()[0] == "gold", "entry ids should be sorted")
+
+	rcp.Outputs = []WeightedOutputs{{EntryIDs: []string{"gold"}, Weight: "0"}}
+	_, err = NewLootTable(rcp, nil)
+	t.MustTrue(err != nil, "loot table without positive weight should fail")
+}
+
+func TestChiSquared(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	test, err := ChiSquared([]int{60, 30, 10}, []float64{0.6, 0.3, 0.1})
+	t.MustNil(err, "error running chi-squared test")
+	t.MustTrue(test.Statistic == 0 && test.DegreesOfFreedom == 2 && test.PValue > 0.999, "exact observations should fit")
+
+	// critical value of chi-squared distribution with 2 degrees of freedom at 0.05 is 5.991
+	test, err = ChiSquared([]int{50, 30, 20}, []float64{0.6, 0.3, 0.1})
+	t.MustNil(err, "error running chi-squared test")
+	t.WithFields(testing.Fields{
+		"statistic": test.Statistic,
+		"p_value":   test.PValue,
+	}).MustTrue(math.Abs(test.Statistic-11.6667) < 1e-3 && test.PValue < 0.05 && !test.Passes(0.05), "skewed observations should not fit")
+	t.MustTrue(math.Abs(upperIncompleteGamma(1, 5.991/2)-0.05) < 1e-3, "p-value should match chi-squared table")
+	t.MustTrue(math.Abs(upperIncompleteGamma(5, 18.307/2)-0.05) < 1e-3, "p-value of 10 degrees of freedom should match chi-squared table")
+	t.MustTrue(math.Abs(upperIncompleteGamma(0.5, 3.841/2)-0.05) < 1e-3, "p-value of 1 degree of freedom should match chi-squared table")
+
+	test, err = ChiSquared([]int{99, 1}, []float64{1, 0})
+	t.MustNil(err, "error running chi-squared test")
+	t.MustTrue(math.IsInf(test.Statistic, 1) && !test.Passes(0.01), "observed outcome of zero probability should fail")
+
+	_, err = ChiSquared([]int{0, 0}, []float64{0.5, 0.5})
+	t.MustTrue(err != nil, "test without observation should fail")
+}
@@ -0,0 +1,362 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MsgFieldError is a struct to describe a field of msg which fails local validation
+type MsgFieldError struct {
+	// Field is the path of field e.g. Outputs[1].Weight, it's empty for errors of whole msg
+	Field   string
+	Message string
+}
+
+// Error is a function to get readable field error
+func (e MsgFieldError) Error() string {
+	if len(e.Field) == 0 {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// MsgValidationError is an error having all fields of msg which fail local validation
+// It wraps ValidateBasic error of the msg so that the error is classified the same as node rejection.
+type MsgValidationError struct {
+	MsgType string
+	Fields  []MsgFieldError
+	basic   error
+}
+
+// Error is a function to get all field errors of msg
+func (e *MsgValidationError) Error() string {
+	msgs := []string{}
+	for _, field := range e.Fields {
+		msgs = append(msgs, field.Error())
+	}
+	return fmt.Sprintf("invalid %s msg: %s", e.MsgType, strings.Join(msgs, "; "))
+}
+
+// Unwrap is a function to get ValidateBasic error of the msg
+func (e *MsgValidationError) Unwrap() error {
+	return e.basic
+}
+
+// ValidatableMsg is an interface of msgs which can be validated locally with field level errors
+type ValidatableMsg interface {
+	sdk.Msg
+	Validate() error
+}
+
+// ValidateMsg is a function to validate msg before broadcast, msgs which don't have Validate are checked by ValidateBasic
+func ValidateMsg(msg sdk.Msg) error {
+	if vmsg, ok := msg.(ValidatableMsg); ok {
+		return vmsg.Validate()
+	}
+	return msg.ValidateBasic()
+}
+
+// msgValidator is a struct to collect field errors of msg
+type msgValidator struct {
+	fields []MsgFieldError
+}
+
+func (v *msgValidator) addf(field, format string, args ...interface{}) {
+	v.fields = append(v.fields, MsgFieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *msgValidator) address(field, addr string) {
+	if len(addr) == 0 {
+		v.addf(field, "address should not be empty")
+		return
+	}
+	if _, err := sdk.AccAddressFromBech32(addr); err != nil {
+		v.addf(field, "invalid address %s: %s", addr, err.Error())
+	}
+}
+
+func (v *msgValidator) notEmpty(field, value string) {
+	if len(value) == 0 {
+		v.addf(field, "should not be empty")
+	}
+}
+
+func (v *msgValidator) minLength(field, value string, length int) {
+	if len(value) < length {
+		v.addf(field, "should have at least %d characters, got %d", length, len(value))
+	}
+}
+
+func (v *msgValidator) denom(field, denom string) {
+	if err := sdk.ValidateDenom(denom); err != nil {
+		v.addf(field, "invalid denom %q: %s", denom, err.Error())
+	}
+}
+
+func (v *msgValidator) coins(field string, coins sdk.Coins) {
+	for idx, coin := range coins {
+		v.denom(fmt.Sprintf("%s[%d].denom", field, idx), coin.Denom)
+		if !coin.Amount.IsPositive() {
+			v.addf(fmt.Sprintf("%s[%d].amount", field, idx), "should be positive, got %s", coin.Amount)
+		}
+	}
+}
+
+func (v *msgValidator) coinInputs(field string, inputs []CoinInput) {
+	for idx, input := range inputs {
+		v.denom(fmt.Sprintf("%s[%d].Coin", field, idx), input.Coin)
+		if input.Count <= 0 {
+			v.addf(fmt.Sprintf("%s[%d].Count", field, idx), "should be positive, got %d", input.Count)
+		}
+	}
+}
+
+func (v *msgValidator) itemIDs(field string, itemIDs []string) {
+	for idx, itemID := range itemIDs {
+		if len(itemID) == 0 {
+			v.addf(fmt.Sprintf("%s[%d]", field, idx), "item id should not be empty")
+		}
+	}
+}
+
+// program is a function to check program is not empty and integer literal is not negative
+func (v *msgValidator) program(field, program string) {
+	if err := ProgramValidateBasic(program); err != nil {
+		v.addf(field, err.Error())
+		return
+	}
+	if num, err := strconv.ParseInt(strings.TrimSpace(program), 10, 64); err == nil && num < 0 {
+		v.addf(field, "should not be negative, got %d", num)
+	}
+}
+
+func (v *msgValidator) recipe(coinInputs []CoinInput, outputs []WeightedOutputs, entries EntriesList, blockInterval int64) {
+	v.coinInputs("CoinInputs", coinInputs)
+	for idx, entry := range entries.CoinOutputs {
+		v.denom(fmt.Sprintf("Entries.CoinOutputs[%d].Coin", idx), entry.Coin)
+		v.program(fmt.Sprintf("Entries.CoinOutputs[%d].Count", idx), entry.Count)
+	}
+	for idx, entry := range entries.ItemOutputs {
+		v.weightRanges(fmt.Sprintf("Entries.ItemOutputs[%d]", idx), entry.Doubles, entry.Longs)
+	}
+	for idx, entry := range entries.ItemModifyOutputs {
+		v.weightRanges(fmt.Sprintf("Entries.ItemModifyOutputs[%d]", idx), entry.Doubles, entry.Longs)
+	}
+	for idx, output := range outputs {
+		v.program(fmt.Sprintf("Outputs[%d].Weight", idx), output.Weight)
+	}
+	if blockInterval < 0 {
+		v.addf("BlockInterval", "should not be negative, got %d", blockInterval)
+	}
+}
+
+func (v *msgValidator) weightRanges(field string, doubles []DoubleParam, longs []LongParam) {
+	for i, param := range doubles {
+		for j, wr := range param.WeightRanges {
+			if wr.Weight < 0 {
+				v.addf(fmt.Sprintf("%s.Doubles[%d].WeightRanges[%d].Weight", field, i, j), "should not be negative, got %d", wr.Weight)
+			}
+		}
+	}
+	for i, param := range longs {
+		for j, wr := range param.WeightRanges {
+			if wr.Weight < 0 {
+				v.addf(fmt.Sprintf("%s.Longs[%d].WeightRanges[%d].Weight", field, i, j), "should not be negative, got %d", wr.Weight)
+			}
+		}
+	}
+}
+
+// result is a function to get validation error of msg, ValidateBasic error is kept with field errors so that errors expected from node still match
+func (v *msgValidator) result(msg sdk.Msg) error {
+	basic := msg.ValidateBasic()
+	if basic != nil {
+		v.fields = append(v.fields, MsgFieldError{Message: basic.Error()})
+	}
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &MsgValidationError{MsgType: msg.Type(), Fields: v.fields, basic: basic}
+}
+
+// Validate is a function to validate MsgCreateAccount msg with field level errors
+func (msg MsgCreateAccount) Validate() error {
+	v := msgValidator{}
+	v.address("Requester", msg.Requester)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgCreateCookbook msg with field level errors
+func (msg MsgCreateCookbook) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.minLength("Name", msg.Name, 8)
+	v.minLength("Description", msg.Description, 20)
+	if msg.CostPerBlock < 0 {
+		v.addf("CostPerBlock", "should not be negative, got %d", msg.CostPerBlock)
+	}
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgUpdateCookbook msg with field level errors
+func (msg MsgUpdateCookbook) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.notEmpty("ID", msg.ID)
+	v.minLength("Description", msg.Description, 20)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgCreateRecipe msg with field level errors
+func (msg MsgCreateRecipe) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.notEmpty("CookbookID", msg.CookbookID)
+	v.minLength("Description", msg.Description, 20)
+	v.recipe(msg.CoinInputs, msg.Outputs, msg.Entries, msg.BlockInterval)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgUpdateRecipe msg with field level errors
+func (msg MsgUpdateRecipe) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.notEmpty("ID", msg.ID)
+	v.notEmpty("CookbookID", msg.CookbookID)
+	v.minLength("Description", msg.Description, 20)
+	v.recipe(msg.CoinInputs, msg.Outputs, msg.Entries, msg.BlockInterval)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgExecuteRecipe msg with field level errors
+func (msg MsgExecuteRecipe) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.notEmpty("RecipeID", msg.RecipeID)
+	v.itemIDs("ItemIDs", msg.ItemIDs)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgCheckExecution msg with field level errors
+func (msg MsgCheckExecution) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.notEmpty("ExecID", msg.ExecID)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgDisableRecipe msg with field level errors
+func (msg MsgDisableRecipe) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.notEmpty("RecipeID", msg.RecipeID)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgEnableRecipe msg with field level errors
+func (msg MsgEnableRecipe) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.notEmpty("RecipeID", msg.RecipeID)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgGetPylons msg with field level errors
+func (msg MsgGetPylons) Validate() error {
+	v := msgValidator{}
+	v.address("Requester", msg.Requester)
+	v.coins("Amount", msg.Amount)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgGoogleIAPGetPylons msg with field level errors
+func (msg MsgGoogleIAPGetPylons) Validate() error {
+	v := msgValidator{}
+	v.address("Requester", msg.Requester)
+	v.notEmpty("ProductID", msg.ProductID)
+	v.notEmpty("PurchaseToken", msg.PurchaseToken)
+	v.notEmpty("ReceiptDataBase64", msg.ReceiptDataBase64)
+	v.notEmpty("Signature", msg.Signature)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgFiatItem msg with field level errors
+func (msg MsgFiatItem) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.notEmpty("CookbookID", msg.CookbookID)
+	if msg.TransferFee < 0 {
+		v.addf("TransferFee", "should not be negative, got %d", msg.TransferFee)
+	}
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgCreateTrade msg with field level errors
+func (msg MsgCreateTrade) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.coinInputs("CoinInputs", msg.CoinInputs)
+	v.coins("CoinOutputs", msg.CoinOutputs)
+	for idx, input := range msg.ItemInputs {
+		v.notEmpty(fmt.Sprintf("ItemInputs[%d].CookbookID", idx), input.CookbookID)
+	}
+	for idx, item := range msg.ItemOutputs {
+		v.notEmpty(fmt.Sprintf("ItemOutputs[%d].ID", idx), item.ID)
+	}
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgFulfillTrade msg with field level errors
+func (msg MsgFulfillTrade) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.notEmpty("TradeID", msg.TradeID)
+	v.itemIDs("ItemIDs", msg.ItemIDs)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgDisableTrade msg with field level errors
+func (msg MsgDisableTrade) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.notEmpty("TradeID", msg.TradeID)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgEnableTrade msg with field level errors
+func (msg MsgEnableTrade) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.notEmpty("TradeID", msg.TradeID)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgSendCoins msg with field level errors
+func (msg MsgSendCoins) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.address("Receiver", msg.Receiver)
+	v.coins("Amount", msg.Amount)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgSendItems msg with field level errors
+func (msg MsgSendItems) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.address("Receiver", msg.Receiver)
+	v.itemIDs("ItemIDs", msg.ItemIDs)
+	return v.result(&msg)
+}
+
+// Validate is a function to validate MsgUpdateItemString msg with field level errors
+func (msg MsgUpdateItemString) Validate() error {
+	v := msgValidator{}
+	v.address("Sender", msg.Sender)
+	v.notEmpty("ItemID", msg.ItemID)
+	v.notEmpty("Field", msg.Field)
+	return v.result(&msg)
+}
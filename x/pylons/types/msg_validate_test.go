@@ -0,0 +1,39 @@
+package types
+
+import (
+	"errors"
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func TestValidateMsg(originT *originT.T) {
+	t := testing.NewT(originT)
+	sender := "cosmos105wr8t6y97rwv90xzhxd4juj4lsajtjaass6h7"
+
+	msg := NewMsgSendCoins(sdk.Coins{sdk.NewInt64Coin(Pylon, 10)}, sender, "cosmos1invalid")
+	err := ValidateMsg(&msg)
+	var validationErr *MsgValidationError
+	t.WithFields(testing.Fields{
+		"error": err,
+	}).MustTrue(errors.As(err, &validationErr) && len(validationErr.Fields) == 1 && validationErr.Fields[0].Field == "Receiver", "invalid receiver address should be reported by field")
+
+	msg = NewMsgSendCoins(sdk.Coins{sdk.Coin{Denom: "Gold!", Amount: sdk.NewInt(-1)}}, "", sender)
+	err = ValidateMsg(&msg)
+	t.MustContain(err.Error(), "Sender: address should not be empty", "empty sender should be reported")
+	t.MustContain(err.Error(), "Amount[0].denom: invalid denom", "bad denom should be reported")
+	t.MustContain(err.Error(), "Amount[0].amount: should be positive", "negative amount should be reported")
+	t.MustTrue(errors.Is(err, sdkerrors.ErrInvalidAddress), "validation error should wrap ValidateBasic error")
+
+	rcp := NewMsgCreateRecipe("recipe", "cookbook", "", "this has to meet character limits", CoinInputList{}, ItemInputList{}, EntriesList{},
+		WeightedOutputsList{{EntryIDs: []string{}, Weight: "-3"}}, 0, sender)
+	err = ValidateMsg(&rcp)
+	t.WithFields(testing.Fields{
+		"error": err,
+	}).MustContain(err.Error(), "Outputs[0].Weight: should not be negative", "negative weight should be reported")
+
+	cb := NewMsgCreateCookbook("cookbook name", "", "this has to meet character limits", "developer", "1.0.0", "example@example.com", 0, DefaultCostPerBlock, sender)
+	t.MustNil(ValidateMsg(&cb), "valid msg should pass")
+}
@@ -297,6 +297,16 @@ func (msg MsgCreateRecipe) ValidateBasic() error {
 		}
 	}
 
+	// type check of programs, attributes which are not declared by item inputs are checked on execution
+	programEnv, err := NewProgramEnv(msg.ItemInputs)
+	if err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	programEnv.AllowUndeclared = true
+	if err := programEnv.CheckRecipe(msg.Entries, msg.Outputs); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
 	if msg.Sender == "" {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Sender)
 	}
@@ -1,6 +1,23 @@
 package types
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter/functions"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
 
 // ProgramValidateBasic validate program
 func ProgramValidateBasic(program string) error {
@@ -9,3 +26,500 @@ func ProgramValidateBasic(program string) error {
 	}
 	return nil
 }
+
+// ProgramType describes the result type a recipe program should have
+type ProgramType int
+
+// describes the result types of recipe programs
+const (
+	ProgramInt    ProgramType = iota // weights, coin output counts and long params
+	ProgramDouble                    // double params
+	ProgramString                    // string params
+)
+
+func (pt ProgramType) exprType() *exprpb.Type {
+	switch pt {
+	case ProgramDouble:
+		return decls.Double
+	case ProgramString:
+		return decls.String
+	default:
+		return decls.Int
+	}
+}
+
+func (pt ProgramType) String() string {
+	switch pt {
+	case ProgramDouble:
+		return "double"
+	case ProgramString:
+		return "string"
+	default:
+		return "int"
+	}
+}
+
+// programIdentRegexp matches attribute keys which can be used as variables of programs
+var programIdentRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z_0-9]*$`)
+
+// ProgramEnv is a struct to parse, type-check and evaluate recipe programs offline before a recipe is broadcast
+// Attributes of item input N are declared as inputN.key and ID.key where ID is the item input ID,
+// attributes of the first item input are declared without prefix as well.
+// lastUpdate of items and lastBlockHeight are declared as int.
+type ProgramEnv struct {
+	env      *cel.Env
+	declared map[string]bool
+	programs map[string]cel.Program // compiled programs by type and source
+	mux      sync.Mutex
+	// AllowUndeclared checks references which are not declared by item inputs as dyn instead of failing,
+	// items can have attributes which are not declared by item inputs
+	AllowUndeclared bool
+	// Rand is the source of rand functions, a fixed seed makes evaluation reproducible
+	Rand *rand.Rand
+	// BlockHeight is lastBlockHeight of evaluation used by block_since
+	BlockHeight int64
+}
+
+// NewProgramEnv is a function to create program env of recipe from its declared item inputs
+func NewProgramEnv(itemInputs []ItemInput) (*ProgramEnv, error) {
+	varDecls := []*exprpb.Decl{decls.NewVar("lastBlockHeight", decls.Int)}
+	declared := map[string]bool{"lastBlockHeight": true}
+	for idx, itemInput := range itemInputs {
+		for _, prefix := range programVarPrefixes(idx, itemInput.ID) {
+			for name, typ := range itemInputVarTypes(itemInput) {
+				if !declared[prefix+name] {
+					declared[prefix+name] = true
+					varDecls = append(varDecls, decls.NewVar(prefix+name, typ))
+				}
+			}
+		}
+	}
+	env, err := cel.NewEnv(cel.Declarations(append(varDecls, programFuncDecls...)...))
+	if err != nil {
+		return nil, err
+	}
+	return &ProgramEnv{
+		env:      env,
+		declared: declared,
+		programs: make(map[string]cel.Program),
+		Rand:     rand.New(rand.NewSource(0)),
+	}, nil
+}
+
+// programVarPrefixes is a function to get prefixes of variables of item input
+func programVarPrefixes(idx int, itemInputID string) []string {
+	prefixes := []string{fmt.Sprintf("input%d.", idx)}
+	if programIdentRegexp.MatchString(itemInputID) {
+		prefixes = append(prefixes, itemInputID+".")
+	}
+	if idx == 0 {
+		prefixes = append(prefixes, "")
+	}
+	return prefixes
+}
+
+// itemInputVarTypes is a function to get types of attributes declared by item input and its conditions
+func itemInputVarTypes(itemInput ItemInput) map[string]*exprpb.Type {
+	varTypes := map[string]*exprpb.Type{"lastUpdate": decls.Int}
+	for _, params := range []struct {
+		Doubles []DoubleInputParam
+		Longs   []LongInputParam
+		Strings []StringInputParam
+	}{
+		{itemInput.Doubles, itemInput.Longs, itemInput.Strings},
+		{itemInput.Conditions.Doubles, itemInput.Conditions.Longs, itemInput.Conditions.Strings},
+	} {
+		for _, param := range params.Doubles {
+			varTypes[param.Key] = decls.Double
+		}
+		for _, param := range params.Longs {
+			varTypes[param.Key] = decls.Int
+		}
+		for _, param := range params.Strings {
+			varTypes[param.Key] = decls.String
+		}
+	}
+	for name := range varTypes {
+		if !programIdentRegexp.MatchString(name) {
+			delete(varTypes, name)
+		}
+	}
+	return varTypes
+}
+
+var programFuncDecls = []*exprpb.Decl{
+	decls.NewFunction("rand",
+		decls.NewOverload("rand", []*exprpb.Type{}, decls.Double),
+		decls.NewOverload("rand_int_max", []*exprpb.Type{decls.Int}, decls.Int)),
+	decls.NewFunction("rand_int",
+		decls.NewOverload("rand_int_int", []*exprpb.Type{decls.Int}, decls.Int)),
+	decls.NewFunction("log2",
+		decls.NewOverload("log2_double", []*exprpb.Type{decls.Double}, decls.Double)),
+	decls.NewFunction("min",
+		decls.NewOverload("min_double_double", []*exprpb.Type{decls.Double, decls.Double}, decls.Double)),
+	decls.NewFunction("max",
+		decls.NewOverload("max_double_double", []*exprpb.Type{decls.Double, decls.Double}, decls.Double)),
+	decls.NewFunction("min_int",
+		decls.NewOverload("min_int_int_int", []*exprpb.Type{decls.Int, decls.Int}, decls.Int)),
+	decls.NewFunction("max_int",
+		decls.NewOverload("max_int_int_int", []*exprpb.Type{decls.Int, decls.Int}, decls.Int)),
+	decls.NewFunction("block_since",
+		decls.NewOverload("block_since_int", []*exprpb.Type{decls.Int}, decls.Int)),
+}
+
+// functions is a function to get implementations of program functions
+func (pe *ProgramEnv) functions() []*functions.Overload {
+	randMax := func(value ref.Val) ref.Val {
+		max, ok := value.(celtypes.Int)
+		if !ok || max <= 0 {
+			return celtypes.NewErr("rand argument should be a positive int: %v", value)
+		}
+		return celtypes.Int(pe.Rand.Int63n(int64(max)))
+	}
+	return []*functions.Overload{
+		{Operator: "rand", Function: func(values ...ref.Val) ref.Val {
+			return celtypes.Double(pe.Rand.Float64())
+		}},
+		{Operator: "rand_int_max", Unary: randMax},
+		{Operator: "rand_int_int", Unary: randMax},
+		{Operator: "log2_double", Unary: func(value ref.Val) ref.Val {
+			return celtypes.Double(math.Log2(float64(value.(celtypes.Double))))
+		}},
+		{Operator: "min_double_double", Binary: func(lhs, rhs ref.Val) ref.Val {
+			return celtypes.Double(math.Min(float64(lhs.(celtypes.Double)), float64(rhs.(celtypes.Double))))
+		}},
+		{Operator: "max_double_double", Binary: func(lhs, rhs ref.Val) ref.Val {
+			return celtypes.Double(math.Max(float64(lhs.(celtypes.Double)), float64(rhs.(celtypes.Double))))
+		}},
+		{Operator: "min_int_int_int", Binary: func(lhs, rhs ref.Val) ref.Val {
+			if lhs.(celtypes.Int) < rhs.(celtypes.Int) {
+				return lhs
+			}
+			return rhs
+		}},
+		{Operator: "max_int_int_int", Binary: func(lhs, rhs ref.Val) ref.Val {
+			if lhs.(celtypes.Int) > rhs.(celtypes.Int) {
+				return lhs
+			}
+			return rhs
+		}},
+		{Operator: "block_since_int", Unary: func(value ref.Val) ref.Val {
+			return celtypes.Int(pe.BlockHeight) - value.(celtypes.Int)
+		}},
+	}
+}
+
+// compile is a function to parse and type-check program against expected result type
+// It returns the env the program is checked in, which has undeclared references when AllowUndeclared is set
+func (pe *ProgramEnv) compile(program string, programType ProgramType) (*cel.Env, *cel.Ast, error) {
+	if err := ProgramValidateBasic(program); err != nil {
+		return nil, nil, err
+	}
+	env := pe.env
+	parsed, iss := env.Parse(program)
+	if iss != nil && iss.Err() != nil {
+		return nil, nil, fmt.Errorf("program %q: %s", program, iss.Err().Error())
+	}
+	if pe.AllowUndeclared {
+		undeclared := []*exprpb.Decl{}
+		for _, name := range programReferences(parsed.Expr()) {
+			if !pe.declared[name] {
+				undeclared = append(undeclared, decls.NewVar(name, decls.Dyn))
+			}
+		}
+		if len(undeclared) > 0 {
+			var err error
+			if env, err = env.Extend(cel.Declarations(undeclared...)); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	ast, iss := env.Check(parsed)
+	if iss != nil && iss.Err() != nil {
+		return nil, nil, fmt.Errorf("program %q: %s", program, iss.Err().Error())
+	}
+	if !programType.accepts(ast.ResultType()) {
+		return nil, nil, fmt.Errorf("program %q should return %s but returns %s", program, programType, ast.ResultType())
+	}
+	return env, ast, nil
+}
+
+// accepts is a function to check if result type of program can be used for program type
+// int result is converted for double params and dyn result is checked when it's evaluated
+func (pt ProgramType) accepts(resultType *exprpb.Type) bool {
+	if proto.Equal(resultType, pt.exprType()) || proto.Equal(resultType, decls.Dyn) {
+		return true
+	}
+	return pt == ProgramDouble && proto.Equal(resultType, decls.Int)
+}
+
+// programReferences is a function to get qualified names of variables referred by parsed program e.g. input1.attack
+func programReferences(expr *exprpb.Expr) []string {
+	names := []string{}
+	var walk func(expr *exprpb.Expr)
+	walk = func(expr *exprpb.Expr) {
+		if expr == nil {
+			return
+		}
+		if name, ok := qualifiedName(expr); ok {
+			names = append(names, name)
+			return
+		}
+		switch kind := expr.ExprKind.(type) {
+		case *exprpb.Expr_SelectExpr:
+			walk(kind.SelectExpr.Operand)
+		case *exprpb.Expr_CallExpr:
+			walk(kind.CallExpr.Target)
+			for _, arg := range kind.CallExpr.Args {
+				walk(arg)
+			}
+		case *exprpb.Expr_ListExpr:
+			for _, elem := range kind.ListExpr.Elements {
+				walk(elem)
+			}
+		case *exprpb.Expr_StructExpr:
+			for _, entry := range kind.StructExpr.Entries {
+				walk(entry.GetMapKey())
+				walk(entry.Value)
+			}
+		}
+	}
+	walk(expr)
+	return names
+}
+
+// qualifiedName is a function to get name of identifier or field selection of identifier e.g. input1.attack
+func qualifiedName(expr *exprpb.Expr) (string, bool) {
+	switch kind := expr.ExprKind.(type) {
+	case *exprpb.Expr_IdentExpr:
+		return kind.IdentExpr.Name, true
+	case *exprpb.Expr_SelectExpr:
+		if kind.SelectExpr.TestOnly {
+			return "", false
+		}
+		operand, ok := qualifiedName(kind.SelectExpr.Operand)
+		return operand + "." + kind.SelectExpr.Field, ok
+	}
+	return "", false
+}
+
+// Check is a function to parse and type-check program against declared variables and expected result type
+func (pe *ProgramEnv) Check(program string, programType ProgramType) error {
+	_, _, err := pe.compile(program, programType)
+	return err
+}
+
+// FormatProgram is a function to pretty-print program in canonical form e.g. "HP*8/ 10" as "HP * 8 / 10"
+// Programs are only parsed, so programs referring to undeclared attributes can be formatted.
+func FormatProgram(program string) (string, error) {
+	if err := ProgramValidateBasic(program); err != nil {
+		return "", err
+	}
+	env, err := cel.NewEnv()
+	if err != nil {
+		return "", err
+	}
+	parsed, iss := env.Parse(program)
+	if iss != nil && iss.Err() != nil {
+		return "", fmt.Errorf("program %q: %s", program, iss.Err().Error())
+	}
+	// cel unparses 2.0 as 2 which is int, so programs having such doubles only get whitespaces normalized
+	if hasIntegralDouble(parsed.Expr()) {
+		return strings.Join(strings.Fields(program), " "), nil
+	}
+	return cel.AstToString(parsed)
+}
+
+// hasIntegralDouble is a function to check if parsed program has double literal of integral value e.g. 2.0
+func hasIntegralDouble(expr *exprpb.Expr) bool {
+	if expr == nil {
+		return false
+	}
+	switch kind := expr.ExprKind.(type) {
+	case *exprpb.Expr_ConstExpr:
+		value, ok := kind.ConstExpr.ConstantKind.(*exprpb.Constant_DoubleValue)
+		return ok && value.DoubleValue == math.Trunc(value.DoubleValue)
+	case *exprpb.Expr_SelectExpr:
+		return hasIntegralDouble(kind.SelectExpr.Operand)
+	case *exprpb.Expr_CallExpr:
+		for _, arg := range append([]*exprpb.Expr{kind.CallExpr.Target}, kind.CallExpr.Args...) {
+			if hasIntegralDouble(arg) {
+				return true
+			}
+		}
+	case *exprpb.Expr_ListExpr:
+		for _, elem := range kind.ListExpr.Elements {
+			if hasIntegralDouble(elem) {
+				return true
+			}
+		}
+	case *exprpb.Expr_StructExpr:
+		for _, entry := range kind.StructExpr.Entries {
+			if hasIntegralDouble(entry.GetMapKey()) || hasIntegralDouble(entry.Value) {
+				return true
+			}
+		}
+	case *exprpb.Expr_ComprehensionExpr:
+		c := kind.ComprehensionExpr
+		for _, sub := range []*exprpb.Expr{c.IterRange, c.AccuInit, c.LoopCondition, c.LoopStep, c.Result} {
+			if hasIntegralDouble(sub) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// program is a function to get compiled program from cache or by compiling it
+func (pe *ProgramEnv) program(program string, programType ProgramType) (cel.Program, error) {
+	pe.mux.Lock()
+	defer pe.mux.Unlock()
+	key := fmt.Sprintf("%s:%t:%s", programType, pe.AllowUndeclared, program)
+	if prg, ok := pe.programs[key]; ok {
+		return prg, nil
+	}
+	env, ast, err := pe.compile(program, programType)
+	if err != nil {
+		return nil, err
+	}
+	prg, err := env.Program(ast, cel.Functions(pe.functions()...))
+	if err != nil {
+		return nil, err
+	}
+	pe.programs[key] = prg
+	return prg, nil
+}
+
+// Eval is a function to evaluate program with variables e.g. from ItemProgramVariables
+// Result is int64, float64 or string by program type
+func (pe *ProgramEnv) Eval(program string, programType ProgramType, variables map[string]interface{}) (interface{}, error) {
+	prg, err := pe.program(program, programType)
+	if err != nil {
+		return nil, err
+	}
+	activation := map[string]interface{}{"lastBlockHeight": pe.BlockHeight}
+	for name, value := range variables {
+		activation[name] = value
+	}
+	val, _, err := prg.Eval(activation)
+	if err != nil {
+		return nil, fmt.Errorf("program %q: %s", program, err.Error())
+	}
+	switch result := val.Value().(type) {
+	case int64:
+		if programType == ProgramDouble {
+			return float64(result), nil
+		}
+		return result, nil
+	case float64:
+		if programType == ProgramDouble {
+			return result, nil
+		}
+	case string:
+		if programType == ProgramString {
+			return result, nil
+		}
+	}
+	return nil, fmt.Errorf("program %q should return %s but returns %v", program, programType, val.Type())
+}
+
+// ItemProgramVariables is a function to get program variables of items matched to item inputs in order
+func ItemProgramVariables(itemInputs []ItemInput, items []Item) (map[string]interface{}, error) {
+	variables := map[string]interface{}{}
+	for idx, item := range items {
+		itemInputID := ""
+		if idx < len(itemInputs) {
+			itemInputID = itemInputs[idx].ID
+		}
+		itemVars, err := itemProgramVariables(item)
+		if err != nil {
+			return nil, err
+		}
+		for _, prefix := range programVarPrefixes(idx, itemInputID) {
+			for name, value := range itemVars {
+				variables[prefix+name] = value
+			}
+		}
+	}
+	return variables, nil
+}
+
+// itemProgramVariables is a function to get attributes of item as program variables without prefix
+func itemProgramVariables(item Item) (map[string]interface{}, error) {
+	itemVars := map[string]interface{}{"lastUpdate": item.LastUpdate}
+	for _, dbl := range item.Doubles {
+		value, err := strconv.ParseFloat(dbl.Value.String(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("double %s of item %s: %s", dbl.Key, item.ID, err.Error())
+		}
+		itemVars[dbl.Key] = value
+	}
+	for _, long := range item.Longs {
+		itemVars[long.Key] = long.Value
+	}
+	for _, str := range item.Strings {
+		itemVars[str.Key] = str.Value
+	}
+	return itemVars, nil
+}
+
+// ValidateRecipePrograms is a function to type-check programs of recipe entries and outputs against its declared item inputs
+// Weights and coin output counts should return int, item params should return their types
+func ValidateRecipePrograms(itemInputs []ItemInput, entries EntriesList, outputs []WeightedOutputs) error {
+	pe, err := NewProgramEnv(itemInputs)
+	if err != nil {
+		return err
+	}
+	return pe.CheckRecipe(entries, outputs)
+}
+
+// CheckRecipe is a function to type-check programs of recipe entries and outputs
+func (pe *ProgramEnv) CheckRecipe(entries EntriesList, outputs []WeightedOutputs) error {
+	for _, output := range outputs {
+		if err := pe.Check(output.Weight, ProgramInt); err != nil {
+			return fmt.Errorf("Output Weight: %s", err.Error())
+		}
+	}
+	for _, coinOutput := range entries.CoinOutputs {
+		if err := pe.Check(coinOutput.Count, ProgramInt); err != nil {
+			return fmt.Errorf("CoinOutput %s Count: %s", coinOutput.ID, err.Error())
+		}
+	}
+	for _, itemOutput := range entries.ItemOutputs {
+		if err := pe.checkParamPrograms(itemOutput.Doubles, itemOutput.Longs, itemOutput.Strings); err != nil {
+			return fmt.Errorf("ItemOutput %s: %s", itemOutput.ID, err.Error())
+		}
+	}
+	for _, itemModifyOutput := range entries.ItemModifyOutputs {
+		if err := pe.checkParamPrograms(itemModifyOutput.Doubles, itemModifyOutput.Longs, itemModifyOutput.Strings); err != nil {
+			return fmt.Errorf("ItemModifyOutput %s: %s", itemModifyOutput.ID, err.Error())
+		}
+	}
+	return nil
+}
+
+// checkParamPrograms is a function to type-check programs of item params, params without program are skipped
+func (pe *ProgramEnv) checkParamPrograms(doubles []DoubleParam, longs []LongParam, strings []StringParam) error {
+	for _, param := range doubles {
+		if len(param.Program) > 0 {
+			if err := pe.Check(param.Program, ProgramDouble); err != nil {
+				return fmt.Errorf("double %s: %s", param.Key, err.Error())
+			}
+		}
+	}
+	for _, param := range longs {
+		if len(param.Program) > 0 {
+			if err := pe.Check(param.Program, ProgramInt); err != nil {
+				return fmt.Errorf("long %s: %s", param.Key, err.Error())
+			}
+		}
+	}
+	for _, param := range strings {
+		if len(param.Program) > 0 {
+			if err := pe.Check(param.Program, ProgramString); err != nil {
+				return fmt.Errorf("string %s: %s", param.Key, err.Error())
+			}
+		}
+	}
+	return nil
+}
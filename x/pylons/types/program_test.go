@@ -0,0 +1,96 @@
+package types
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestProgramEnv(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	itemInputs := []ItemInput{
+		{
+			ID:    "character",
+			Longs: []LongInputParam{{Key: "HP", MinValue: 1, MaxValue: 100}, {Key: "MaxHP", MinValue: 1, MaxValue: 100}},
+		},
+		{
+			ID:      "sword",
+			Doubles: []DoubleInputParam{{Key: "attack", MinValue: sdk.NewDec(1), MaxValue: sdk.NewDec(100)}},
+		},
+	}
+	pe, err := NewProgramEnv(itemInputs)
+	t.MustNil(err, "error creating program env")
+
+	t.MustNil(pe.Check("HP * 8 / 10", ProgramInt), "attributes of first item input should be declared without prefix")
+	t.MustNil(pe.Check("1 + int(input1.attack / 2.0)", ProgramInt), "attributes should be declared with input index")
+	t.MustNil(pe.Check("sword.attack * 2.0", ProgramDouble), "attributes should be declared with item input ID")
+	t.MustNil(pe.Check("max_int(min_int(HP + block_since(lastUpdate), MaxHP), 1)", ProgramInt), "functions should be declared")
+	t.MustTrue(pe.Check("XP + double(rand_int(3))", ProgramDouble) != nil, "undeclared attribute should fail type check")
+	t.MustTrue(pe.Check("attack * 2.0", ProgramDouble) != nil, "attributes of other item inputs should not be declared without prefix")
+	t.MustTrue(pe.Check("input1.attack * 2.0", ProgramInt) != nil, "result type should be checked")
+	t.MustTrue(pe.Check("HP +", ProgramInt) != nil, "syntax error should be detected")
+	t.MustTrue(pe.Check("", ProgramInt) != nil, "empty program should be rejected")
+
+	items := []Item{
+		{ID: "c1", Longs: []LongKeyValue{{Key: "HP", Value: 50}, {Key: "MaxHP", Value: 100}}, LastUpdate: 90},
+		{ID: "s1", Doubles: []DoubleKeyValue{{Key: "attack", Value: sdk.NewDec(5)}}},
+	}
+	variables, err := ItemProgramVariables(itemInputs, items)
+	t.MustNil(err, "error getting item program variables")
+	pe.BlockHeight = 100
+	result, err := pe.Eval("min_int(HP + block_since(lastUpdate), MaxHP) - 20 / int(input1.attack)", ProgramInt, variables)
+	t.MustNil(err, "error evaluating program")
+	t.MustTrue(result == int64(56), "program should be evaluated with item attributes and block height")
+	result, err = pe.Eval("sword.attack * 2.0", ProgramDouble, variables)
+	t.MustTrue(err == nil && result == 10.0, "double program should be evaluated")
+
+	for i := 0; i < 20; i++ {
+		result, err = pe.Eval("rand_int(3)", ProgramInt, variables)
+		t.MustTrue(err == nil && result.(int64) >= 0 && result.(int64) < 3, "rand_int should be in range")
+	}
+	_, err = pe.Eval("rand_int(0)", ProgramInt, variables)
+	t.MustTrue(err != nil, "rand_int with non positive argument should fail")
+	_, err = pe.Eval("HP", ProgramInt, map[string]interface{}{})
+	t.MustTrue(err != nil, "missing variable should fail evaluation")
+
+	entries := EntriesList{
+		CoinOutputs: []CoinOutput{{ID: "coin", Coin: "loudcoin", Count: "rand_int(2) + 1"}},
+		ItemModifyOutputs: []ItemModifyOutput{{
+			ID:           "upgraded",
+			ItemInputRef: "character",
+			Longs:        []LongParam{{Key: "HP", Program: "MaxHP"}},
+		}},
+	}
+	outputs := []WeightedOutputs{{EntryIDs: []string{"coin", "upgraded"}, Weight: "HP / 10"}}
+	t.MustNil(ValidateRecipePrograms(itemInputs, entries, outputs), "valid recipe programs should pass")
+	entries.ItemModifyOutputs[0].Longs[0].Program = "sword.attack"
+	t.MustTrue(ValidateRecipePrograms(itemInputs, entries, outputs) != nil, "long param returning double should fail")
+
+	pe.AllowUndeclared = true
+	t.MustNil(pe.Check("GiantKill + 1", ProgramInt), "undeclared attribute should be allowed as dyn")
+	t.MustNil(pe.Check("input0.XP * 2.0", ProgramDouble), "undeclared qualified attribute should be allowed as dyn")
+	t.MustNil(pe.Check("1", ProgramDouble), "int result should be allowed for double params")
+	t.MustTrue(pe.Check("HP * 2.0", ProgramInt) != nil, "declared attribute should be type checked")
+	t.MustTrue(pe.Check("unknown_func(HP)", ProgramInt) != nil, "unknown function should fail type check")
+	result, err = pe.Eval("GiantKill + 1", ProgramInt, map[string]interface{}{"GiantKill": int64(2)})
+	t.MustTrue(err == nil && result == int64(3), "undeclared attribute should be evaluated")
+	result, err = pe.Eval("1", ProgramDouble, variables)
+	t.MustTrue(err == nil && result == 1.0, "int result of double program should be converted")
+}
+
+func TestFormatProgram(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	formatted, err := FormatProgram("max_int(HP*8/ 10,input1.attack>2.5?1:0)")
+	t.MustNil(err, "error formatting program")
+	t.WithFields(testing.Fields{
+		"formatted": formatted,
+	}).MustTrue(formatted == "max_int(HP * 8 / 10, (input1.attack > 2.5) ? 1 : 0)", "program should be formatted in canonical form")
+	formatted, err = FormatProgram("input1.attack *  2.0")
+	t.MustNil(err, "error formatting program")
+	t.MustTrue(formatted == "input1.attack * 2.0", "integral double should be kept as double")
+	_, err = FormatProgram("HP +")
+	t.MustTrue(err != nil, "syntax error should be reported")
+}
@@ -0,0 +1,154 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SemVerPart is a type of version part bumped by Bump
+type SemVerPart string
+
+// describes version parts
+const (
+	SemVerMajor SemVerPart = "major"
+	SemVerMinor SemVerPart = "minor"
+	SemVerPatch SemVerPart = "patch"
+)
+
+// SemVer is a struct to describe parsed version of cookbook
+type SemVer struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	PreRelease string
+	Build      string
+}
+
+// ParseSemVer is a function to parse version validated by ValidateVersion
+func ParseSemVer(s string) (SemVer, error) {
+	match := semVerRegex.FindStringSubmatch(s)
+	if match == nil {
+		return SemVer{}, fmt.Errorf("invalid semVer %q", s)
+	}
+	parts := [3]uint64{}
+	for idx := range parts {
+		part, err := strconv.ParseUint(match[idx+1], 10, 64)
+		if err != nil {
+			return SemVer{}, fmt.Errorf("invalid semVer %q: %w", s, err)
+		}
+		parts[idx] = part
+	}
+	return SemVer{
+		Major:      parts[0],
+		Minor:      parts[1],
+		Patch:      parts[2],
+		PreRelease: match[4],
+		Build:      match[5],
+	}, nil
+}
+
+// String is a function to format version
+func (v SemVer) String() string {
+	version := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.PreRelease) > 0 {
+		version += "-" + v.PreRelease
+	}
+	if len(v.Build) > 0 {
+		version += "+" + v.Build
+	}
+	return version
+}
+
+// Bump is a function to get the next version of part, lower parts are reset and pre-release and build are dropped
+// Bumping patch of a pre-release gives its release e.g. 1.2.0-rc.1 to 1.2.0 as pre-release precedes the release.
+func (v SemVer) Bump(part SemVerPart) (SemVer, error) {
+	next := SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	switch part {
+	case SemVerMajor:
+		if len(v.PreRelease) == 0 || v.Minor != 0 || v.Patch != 0 {
+			next.Major++
+		}
+		next.Minor, next.Patch = 0, 0
+	case SemVerMinor:
+		if len(v.PreRelease) == 0 || v.Patch != 0 {
+			next.Minor++
+		}
+		next.Patch = 0
+	case SemVerPatch:
+		if len(v.PreRelease) == 0 {
+			next.Patch++
+		}
+	default:
+		return v, fmt.Errorf("unknown version part %q, it should be one of major, minor and patch", part)
+	}
+	return next, nil
+}
+
+// Compare is a function to compare precedence of versions, it returns -1, 0 or 1 when v is lower, equal or higher
+// Build is ignored and pre-release has lower precedence than its release as SemVer defines.
+func (v SemVer) Compare(o SemVer) int {
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	switch {
+	case v.PreRelease == o.PreRelease:
+		return 0
+	case len(v.PreRelease) == 0:
+		return 1
+	case len(o.PreRelease) == 0:
+		return -1
+	}
+	vIDs := strings.Split(v.PreRelease, ".")
+	oIDs := strings.Split(o.PreRelease, ".")
+	for idx := 0; idx < len(vIDs) && idx < len(oIDs); idx++ {
+		if c := comparePreReleaseID(vIDs[idx], oIDs[idx]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(vIDs)), uint64(len(oIDs)))
+}
+
+// CompareVersions is a function to compare precedence of version strings, see SemVer.Compare
+func CompareVersions(a, b string) (int, error) {
+	va, err := ParseSemVer(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParseSemVer(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePreReleaseID is a function to compare pre-release identifiers, numeric ones are lower than alphanumeric ones
+func comparePreReleaseID(a, b string) int {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		return compareUint(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
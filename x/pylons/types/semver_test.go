@@ -0,0 +1,58 @@
+package types
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+)
+
+func TestSemVer(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	version, err := ParseSemVer("1.2.3-rc.1+build5")
+	t.MustNil(err, "error parsing version")
+	t.MustTrue(version.Major == 1 && version.Minor == 2 && version.Patch == 3, "version numbers should be parsed")
+	t.MustTrue(version.PreRelease == "rc.1" && version.Build == "build5", "pre-release and build should be parsed")
+	t.MustTrue(version.String() == "1.2.3-rc.1+build5", "version should be formatted as parsed")
+	_, err = ParseSemVer("1.2")
+	t.MustTrue(err != nil, "version without patch should be invalid")
+
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.10.0", "2.0.0"}
+	for idx := 1; idx < len(ordered); idx++ {
+		c, err := CompareVersions(ordered[idx-1], ordered[idx])
+		t.MustNil(err, "error comparing versions")
+		t.WithFields(testing.Fields{
+			"lower":  ordered[idx-1],
+			"higher": ordered[idx],
+		}).MustTrue(c == -1, "versions should be compared by semVer precedence")
+	}
+	c, err := CompareVersions("1.0.0+build1", "1.0.0+build2")
+	t.MustNil(err, "error comparing versions")
+	t.MustTrue(c == 0, "build should be ignored by comparison")
+
+	bumps := []struct {
+		version  string
+		part     SemVerPart
+		expected string
+	}{
+		{"1.2.3", SemVerPatch, "1.2.4"},
+		{"1.2.3", SemVerMinor, "1.3.0"},
+		{"1.2.3+build", SemVerMajor, "2.0.0"},
+		{"1.3.0-rc.1", SemVerPatch, "1.3.0"},
+		{"1.3.0-rc.1", SemVerMinor, "1.3.0"},
+		{"2.0.0-rc.1", SemVerMajor, "2.0.0"},
+	}
+	for _, bump := range bumps {
+		version, err := ParseSemVer(bump.version)
+		t.MustNil(err, "error parsing version")
+		next, err := version.Bump(bump.part)
+		t.MustNil(err, "error bumping version")
+		t.WithFields(testing.Fields{
+			"version": bump.version,
+			"part":    bump.part,
+			"next":    next.String(),
+		}).MustTrue(next.String() == bump.expected && version.Compare(next) < 0, "bumped version should be the next higher version")
+	}
+	_, err = version.Bump(SemVerPart("build"))
+	t.MustTrue(err != nil, "unknown part should not be bumped")
+}
@@ -0,0 +1,419 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Simulator is a struct to compute outputs of recipe executions offline to balance recipes without broadcasting them
+// Weights, coin output counts and item params are evaluated by programs or weight ranges like execution on chain.
+// Rate of params is not applied and attributes are always set.
+type Simulator struct {
+	Recipe     Recipe
+	ProgramEnv *ProgramEnv
+	// BlockHeight is the height of simulated executions, used by block_since and lastUpdate of items
+	BlockHeight int64
+	rand        *rand.Rand
+}
+
+// SimulationResult is a struct to describe the result of a simulated execution
+type SimulationResult struct {
+	OutputIndex   int // index of weighted output of recipe
+	EntryIDs      []string
+	Coins         sdk.Coins
+	Items         []Item // new items of item outputs, ID is the entry ID
+	ModifiedItems []Item // input items modified by item modify outputs
+}
+
+// ValueStats is a struct to describe the range of a numeric value over simulated executions
+type ValueStats struct {
+	Count int
+	Min   float64
+	Max   float64
+	Mean  float64
+}
+
+// OutputDistribution is a struct to describe how often a weighted output is selected
+type OutputDistribution struct {
+	EntryIDs    []string
+	Count       int
+	Probability float64
+}
+
+// SimulationDistribution is a struct to describe the results of simulated executions
+type SimulationDistribution struct {
+	Runs    int
+	Outputs []OutputDistribution  // per weighted output of recipe
+	Coins   map[string]ValueStats // per coin denom of outputs
+	// Attributes are numeric attributes of output items per entry ID and key e.g. "sword.attack"
+	Attributes map[string]ValueStats
+}
+
+// NewSimulator is a function to create simulator of recipe, the same seed gives the same results
+func NewSimulator(rcp Recipe, seed int64) (*Simulator, error) {
+	programEnv, err := NewProgramEnv(rcp.ItemInputs)
+	if err != nil {
+		return nil, err
+	}
+	// items can have attributes which are not declared by item inputs
+	programEnv.AllowUndeclared = true
+	sim := &Simulator{
+		Recipe:     rcp,
+		ProgramEnv: programEnv,
+		rand:       rand.New(rand.NewSource(seed)),
+	}
+	programEnv.Rand = sim.rand
+	return sim, nil
+}
+
+// CheckInputs is a function to check if coins and items satisfy coin inputs and item inputs of recipe
+// Items should be in the order of item inputs
+func (sim *Simulator) CheckInputs(items []Item, coins sdk.Coins) error {
+	required := CoinInputList(sim.Recipe.CoinInputs).ToCoins()
+	if !coins.IsAllGTE(required) {
+		return fmt.Errorf("insufficient coins, %s is required but got %s", required, coins)
+	}
+	if len(items) != len(sim.Recipe.ItemInputs) {
+		return fmt.Errorf("%d items are required but got %d", len(sim.Recipe.ItemInputs), len(items))
+	}
+	for idx, itemInput := range sim.Recipe.ItemInputs {
+		if err := CheckItemInput(items[idx], itemInput); err != nil {
+			return fmt.Errorf("item %d does not match item input %s: %s", idx, itemInput.ID, err.Error())
+		}
+	}
+	return nil
+}
+
+// CheckItemInput is a function to check if item satisfies params and conditions of item input
+func CheckItemInput(item Item, itemInput ItemInput) error {
+	for _, params := range []struct {
+		Doubles []DoubleInputParam
+		Longs   []LongInputParam
+		Strings []StringInputParam
+	}{
+		{itemInput.Doubles, itemInput.Longs, itemInput.Strings},
+		{itemInput.Conditions.Doubles, itemInput.Conditions.Longs, itemInput.Conditions.Strings},
+	} {
+		for _, param := range params.Doubles {
+			value, ok := item.FindDouble(param.Key)
+			if !ok || value.LT(param.MinValue) || value.GT(param.MaxValue) {
+				return fmt.Errorf("double %s should be between %s and %s", param.Key, param.MinValue, param.MaxValue)
+			}
+		}
+		for _, param := range params.Longs {
+			value, ok := item.FindLong(param.Key)
+			if !ok || int64(value) < param.MinValue || int64(value) > param.MaxValue {
+				return fmt.Errorf("long %s should be between %d and %d", param.Key, param.MinValue, param.MaxValue)
+			}
+		}
+		for _, param := range params.Strings {
+			value, ok := item.FindString(param.Key)
+			if !ok || value != param.Value {
+				return fmt.Errorf("string %s should be %s", param.Key, param.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// OutputProbabilities is a function to get probability of each weighted output by evaluating weights once
+// It is exact when weights do not use rand functions, Distribution is needed otherwise
+func (sim *Simulator) OutputProbabilities(items []Item) ([]float64, error) {
+	weights, err := sim.outputWeights(items)
+	if err != nil {
+		return nil, err
+	}
+	total := int64(0)
+	for _, weight := range weights {
+		total += weight
+	}
+	probabilities := make([]float64, len(weights))
+	for idx, weight := range weights {
+		probabilities[idx] = float64(weight) / float64(total)
+	}
+	return probabilities, nil
+}
+
+// outputWeights is a function to evaluate weights of outputs, negative weights are treated as 0
+func (sim *Simulator) outputWeights(items []Item) ([]int64, error) {
+	variables, err := ItemProgramVariables(sim.Recipe.ItemInputs, items)
+	if err != nil {
+		return nil, err
+	}
+	sim.ProgramEnv.BlockHeight = sim.BlockHeight
+	weights := []int64{}
+	total := int64(0)
+	for idx, output := range sim.Recipe.Outputs {
+		weight, err := sim.ProgramEnv.Eval(output.Weight, ProgramInt, variables)
+		if err != nil {
+			return nil, fmt.Errorf("output %d weight: %s", idx, err.Error())
+		}
+		weights = append(weights, Max(weight.(int64), 0))
+		total += Max(weight.(int64), 0)
+	}
+	if total == 0 {
+		return nil, errors.New("no output has positive weight")
+	}
+	return weights, nil
+}
+
+// Execute is a function to simulate an execution of recipe with input items and coins
+func (sim *Simulator) Execute(items []Item, coins sdk.Coins) (SimulationResult, error) {
+	if err := sim.CheckInputs(items, coins); err != nil {
+		return SimulationResult{}, err
+	}
+	weights, err := sim.outputWeights(items)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	total := int64(0)
+	for _, weight := range weights {
+		total += weight
+	}
+	outputIndex := 0
+	for pick := sim.rand.Int63n(total); pick >= weights[outputIndex]; outputIndex++ {
+		pick -= weights[outputIndex]
+	}
+
+	variables, err := ItemProgramVariables(sim.Recipe.ItemInputs, items)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	result := SimulationResult{
+		OutputIndex: outputIndex,
+		EntryIDs:    sim.Recipe.Outputs[outputIndex].EntryIDs,
+		Coins:       sdk.NewCoins(),
+	}
+	for _, entryID := range result.EntryIDs {
+		entry, err := sim.Recipe.Entries.FindByID(entryID)
+		if err != nil {
+			return SimulationResult{}, err
+		}
+		switch entry := entry.(type) {
+		case *CoinOutput:
+			count, err := sim.ProgramEnv.Eval(entry.Count, ProgramInt, variables)
+			if err != nil {
+				return SimulationResult{}, fmt.Errorf("coin output %s: %s", entry.ID, err.Error())
+			}
+			result.Coins = result.Coins.Add(sdk.NewInt64Coin(entry.Coin, count.(int64)))
+		case *ItemOutput:
+			item := Item{
+				NodeVersion: "0.0.1",
+				ID:          entry.ID,
+				CookbookID:  sim.Recipe.CookbookID,
+				Tradable:    true,
+				LastUpdate:  sim.BlockHeight,
+				TransferFee: entry.TransferFee,
+			}
+			if err := sim.actualizeParams(&item, entry.Doubles, entry.Longs, entry.Strings, variables, false); err != nil {
+				return SimulationResult{}, fmt.Errorf("item output %s: %s", entry.ID, err.Error())
+			}
+			result.Items = append(result.Items, item)
+		case *ItemModifyOutput:
+			inputIndex := sim.Recipe.GetItemInputRefIndex(entry.ItemInputRef)
+			if inputIndex < 0 {
+				return SimulationResult{}, fmt.Errorf("item modify output %s refers unknown item input %s", entry.ID, entry.ItemInputRef)
+			}
+			item := copyItem(items[inputIndex])
+			// attributes of the modified item are available without prefix
+			itemVariables, err := itemProgramVariables(item)
+			if err != nil {
+				return SimulationResult{}, err
+			}
+			modifyVariables := make(map[string]interface{}, len(variables)+len(itemVariables))
+			for name, value := range variables {
+				modifyVariables[name] = value
+			}
+			for name, value := range itemVariables {
+				modifyVariables[name] = value
+			}
+			if err := sim.actualizeParams(&item, entry.Doubles, entry.Longs, entry.Strings, modifyVariables, true); err != nil {
+				return SimulationResult{}, fmt.Errorf("item modify output %s: %s", entry.ID, err.Error())
+			}
+			if entry.TransferFee > 0 {
+				item.TransferFee = entry.TransferFee
+			}
+			item.LastUpdate = sim.BlockHeight
+			result.ModifiedItems = append(result.ModifiedItems, item)
+		}
+	}
+	return result, nil
+}
+
+// actualizeParams is a function to set attributes of item by programs or weight ranges of params
+// Weight ranges of upgrade params e.g. of item modify outputs are added to existing attributes like node does.
+func (sim *Simulator) actualizeParams(item *Item, doubles []DoubleParam, longs []LongParam, strs []StringParam, variables map[string]interface{}, upgrade bool) error {
+	for _, param := range doubles {
+		var value sdk.Dec
+		if len(param.Program) > 0 {
+			result, err := sim.ProgramEnv.Eval(param.Program, ProgramDouble, variables)
+			if err != nil {
+				return fmt.Errorf("double %s: %s", param.Key, err.Error())
+			}
+			value, err = sdk.NewDecFromStr(strconv.FormatFloat(result.(float64), 'f', sdk.Precision, 64))
+			if err != nil {
+				return fmt.Errorf("double %s: %s", param.Key, err.Error())
+			}
+		} else {
+			weightRange, err := pickWeightRange(len(param.WeightRanges), func(idx int) int64 { return param.WeightRanges[idx].Weight }, sim.rand)
+			if err != nil {
+				return fmt.Errorf("double %s: %s", param.Key, err.Error())
+			}
+			lower, upper := param.WeightRanges[weightRange].Lower, param.WeightRanges[weightRange].Upper
+			value = lower.Add(upper.Sub(lower).Mul(sdk.MustNewDecFromStr(strconv.FormatFloat(sim.rand.Float64(), 'f', sdk.Precision, 64))))
+		}
+		if idx, ok := item.FindDoubleKey(param.Key); ok {
+			if upgrade && len(param.Program) == 0 {
+				value = item.Doubles[idx].Value.Add(value)
+			}
+			item.Doubles[idx].Value = value
+		} else {
+			item.Doubles = append(item.Doubles, DoubleKeyValue{Key: param.Key, Value: value})
+		}
+	}
+	for _, param := range longs {
+		var value int64
+		if len(param.Program) > 0 {
+			result, err := sim.ProgramEnv.Eval(param.Program, ProgramInt, variables)
+			if err != nil {
+				return fmt.Errorf("long %s: %s", param.Key, err.Error())
+			}
+			value = result.(int64)
+		} else {
+			weightRange, err := pickWeightRange(len(param.WeightRanges), func(idx int) int64 { return param.WeightRanges[idx].Weight }, sim.rand)
+			if err != nil {
+				return fmt.Errorf("long %s: %s", param.Key, err.Error())
+			}
+			lower, upper := param.WeightRanges[weightRange].Lower, param.WeightRanges[weightRange].Upper
+			value = lower
+			if upper > lower {
+				value += sim.rand.Int63n(upper - lower + 1)
+			}
+		}
+		if idx, ok := item.FindLongKey(param.Key); ok {
+			if upgrade && len(param.Program) == 0 {
+				value += item.Longs[idx].Value
+			}
+			item.Longs[idx].Value = value
+		} else {
+			item.Longs = append(item.Longs, LongKeyValue{Key: param.Key, Value: value})
+		}
+	}
+	for _, param := range strs {
+		value := param.Value
+		if len(param.Program) > 0 {
+			result, err := sim.ProgramEnv.Eval(param.Program, ProgramString, variables)
+			if err != nil {
+				return fmt.Errorf("string %s: %s", param.Key, err.Error())
+			}
+			value = result.(string)
+		}
+		if !item.SetString(param.Key, value) {
+			item.Strings = append(item.Strings, StringKeyValue{Key: param.Key, Value: value})
+		}
+	}
+	return nil
+}
+
+// pickWeightRange is a function to pick index of weight range by weights
+func pickWeightRange(count int, weightOf func(idx int) int64, r *rand.Rand) (int, error) {
+	total := int64(0)
+	for idx := 0; idx < count; idx++ {
+		total += Max(weightOf(idx), 0)
+	}
+	if total == 0 {
+		return 0, errors.New("param should have program or weight ranges with positive weight")
+	}
+	pick := r.Int63n(total)
+	for idx := 0; idx < count; idx++ {
+		if pick < Max(weightOf(idx), 0) {
+			return idx, nil
+		}
+		pick -= Max(weightOf(idx), 0)
+	}
+	return count - 1, nil
+}
+
+// Distribution is a function to simulate executions of recipe runs times and get distribution of outputs
+func (sim *Simulator) Distribution(items []Item, coins sdk.Coins, runs int) (SimulationDistribution, error) {
+	dist := SimulationDistribution{
+		Runs:       runs,
+		Coins:      make(map[string]ValueStats),
+		Attributes: make(map[string]ValueStats),
+	}
+	for _, output := range sim.Recipe.Outputs {
+		dist.Outputs = append(dist.Outputs, OutputDistribution{EntryIDs: output.EntryIDs})
+	}
+	for run := 0; run < runs; run++ {
+		result, err := sim.Execute(items, coins)
+		if err != nil {
+			return dist, err
+		}
+		dist.Outputs[result.OutputIndex].Count++
+		for _, coin := range result.Coins {
+			dist.Coins[coin.Denom] = dist.Coins[coin.Denom].add(float64(coin.Amount.Int64()))
+		}
+		outputItems := append(append([]Item{}, result.Items...), result.ModifiedItems...)
+		itemEntryIDs := sim.itemEntryIDs(result.EntryIDs)
+		for idx, item := range outputItems {
+			for _, dbl := range item.Doubles {
+				value, err := strconv.ParseFloat(dbl.Value.String(), 64)
+				if err != nil {
+					return dist, err
+				}
+				key := itemEntryIDs[idx] + "." + dbl.Key
+				dist.Attributes[key] = dist.Attributes[key].add(value)
+			}
+			for _, long := range item.Longs {
+				key := itemEntryIDs[idx] + "." + long.Key
+				dist.Attributes[key] = dist.Attributes[key].add(float64(long.Value))
+			}
+		}
+	}
+	for idx := range dist.Outputs {
+		dist.Outputs[idx].Probability = float64(dist.Outputs[idx].Count) / float64(runs)
+	}
+	return dist, nil
+}
+
+// itemEntryIDs is a function to get entry IDs of item outputs followed by item modify outputs in the order of Execute result
+func (sim *Simulator) itemEntryIDs(entryIDs []string) []string {
+	itemIDs, modifyIDs := []string{}, []string{}
+	for _, entryID := range entryIDs {
+		entry, err := sim.Recipe.Entries.FindByID(entryID)
+		if err != nil {
+			continue
+		}
+		switch entry.(type) {
+		case *ItemOutput:
+			itemIDs = append(itemIDs, entryID)
+		case *ItemModifyOutput:
+			modifyIDs = append(modifyIDs, entryID)
+		}
+	}
+	return append(itemIDs, modifyIDs...)
+}
+
+func (vs ValueStats) add(value float64) ValueStats {
+	if vs.Count == 0 {
+		vs.Min, vs.Max = value, value
+	}
+	vs.Min = math.Min(vs.Min, value)
+	vs.Max = math.Max(vs.Max, value)
+	vs.Mean = (vs.Mean*float64(vs.Count) + value) / float64(vs.Count+1)
+	vs.Count++
+	return vs
+}
+
+// copyItem is a function to copy item so that modifying attributes doesn't change the original one
+func copyItem(item Item) Item {
+	item.Doubles = append([]DoubleKeyValue{}, item.Doubles...)
+	item.Longs = append([]LongKeyValue{}, item.Longs...)
+	item.Strings = append([]StringKeyValue{}, item.Strings...)
+	return item
+}
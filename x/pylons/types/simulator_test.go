@@ -0,0 +1,77 @@
+package types
+
+import (
+	originT "testing"
+
+	testing "github.com/Pylons-tech/pylons_sdk/cmd/evtesting"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSimulator(originT *originT.T) {
+	t := testing.NewT(originT)
+
+	rcp := Recipe{
+		CookbookID: "cookbook",
+		CoinInputs: []CoinInput{{Coin: "pylon", Count: 10}},
+		ItemInputs: []ItemInput{{
+			ID:    "sword",
+			Longs: []LongInputParam{{Key: "level", MinValue: 1, MaxValue: 1}},
+		}},
+		Entries: EntriesList{
+			CoinOutputs: []CoinOutput{{ID: "gold", Coin: "gold", Count: "10 + rand_int(5)"}},
+			ItemOutputs: []ItemOutput{{
+				ID:      "shield",
+				Doubles: []DoubleParam{{Key: "defense", WeightRanges: []DoubleWeightRange{{Lower: sdk.NewDec(1), Upper: sdk.NewDec(3), Weight: 1}}}},
+				Longs:   []LongParam{{Key: "level", Program: "level"}},
+				Strings: []StringParam{{Key: "Name", Value: "Shield"}},
+			}},
+			ItemModifyOutputs: []ItemModifyOutput{{
+				ID:           "upgraded_sword",
+				ItemInputRef: "sword",
+				Doubles:      []DoubleParam{{Key: "attack", Program: "attack * 2.0"}},
+				Longs:        []LongParam{{Key: "level", Program: "level + 1"}},
+			}},
+		},
+		Outputs: []WeightedOutputs{
+			{EntryIDs: []string{"gold"}, Weight: "3"},
+			{EntryIDs: []string{"shield", "upgraded_sword"}, Weight: "1"},
+			{EntryIDs: []string{}, Weight: "level - 5"},
+		},
+	}
+	sword := Item{
+		ID:      "sword1",
+		Doubles: []DoubleKeyValue{{Key: "attack", Value: sdk.NewDec(5)}},
+		Longs:   []LongKeyValue{{Key: "level", Value: 1}},
+	}
+	coins := sdk.NewCoins(sdk.NewInt64Coin("pylon", 10))
+
+	sim, err := NewSimulator(rcp, 1)
+	t.MustNil(err, "error creating simulator")
+	t.MustTrue(sim.CheckInputs([]Item{sword}, sdk.NewCoins(sdk.NewInt64Coin("pylon", 9))) != nil, "insufficient coins should be rejected")
+	t.MustTrue(sim.CheckInputs([]Item{{ID: "other"}}, coins) != nil, "item which doesn't match item input should be rejected")
+
+	probabilities, err := sim.OutputProbabilities([]Item{sword})
+	t.MustNil(err, "error getting output probabilities")
+	t.MustTrue(probabilities[0] == 0.75 && probabilities[1] == 0.25 && probabilities[2] == 0, "probabilities should follow weights and negative weight should be 0")
+
+	dist, err := sim.Distribution([]Item{sword}, coins, 2000)
+	t.MustNil(err, "error simulating distribution")
+	t.WithFields(testing.Fields{
+		"distribution": dist,
+	}).MustTrue(dist.Outputs[0].Probability > 0.7 && dist.Outputs[0].Probability < 0.8 && dist.Outputs[2].Count == 0, "distribution should follow weights")
+	gold := dist.Coins["gold"]
+	t.MustTrue(gold.Min == 10 && gold.Max == 14, "coin output count should be in range of program")
+	defense := dist.Attributes["shield.defense"]
+	t.MustTrue(defense.Min >= 1 && defense.Max <= 3 && defense.Min < defense.Max, "double of weight range should be in the range")
+	t.MustTrue(dist.Attributes["upgraded_sword.attack"].Min == 10 && dist.Attributes["upgraded_sword.level"].Max == 2, "modified attributes should be evaluated by programs")
+	t.MustTrue(dist.Attributes["shield.level"].Max == 1, "item output program should refer item input attributes")
+
+	sim, _ = NewSimulator(rcp, 1)
+	first, err := sim.Execute([]Item{sword}, coins)
+	t.MustNil(err, "error simulating execution")
+	sim, _ = NewSimulator(rcp, 1)
+	second, _ := sim.Execute([]Item{sword}, coins)
+	t.MustTrue(first.OutputIndex == second.OutputIndex && first.Coins.IsEqual(second.Coins), "same seed should give the same result")
+	level, _ := sword.FindLong("level")
+	t.MustTrue(level == 1, "input item should not be changed by simulation")
+}